@@ -2,20 +2,51 @@ package routes
 
 import (
 	"context"
+	"errors"
 	"evently/internal/analytics"
+	"evently/internal/apikeys"
+	"evently/internal/archive"
 	"evently/internal/auth"
 	"evently/internal/bookings"
+	"evently/internal/branding"
 	"evently/internal/cancellation"
+	"evently/internal/capacityalerts"
+	"evently/internal/documents"
+	"evently/internal/domainevents"
+	"evently/internal/emailtemplates"
+	"evently/internal/eventchanges"
 	"evently/internal/events"
+	"evently/internal/favorites"
+	"evently/internal/impersonation"
+	"evently/internal/jobs"
+	"evently/internal/notificationcenter"
+	"evently/internal/notificationprefs"
 	"evently/internal/notifications"
+	"evently/internal/outbox"
+	"evently/internal/permissions"
+	"evently/internal/profiles"
+	"evently/internal/promotions"
+	"evently/internal/reminders"
+	"evently/internal/resale"
+	"evently/internal/reviews"
 	"evently/internal/seats"
+	"evently/internal/series"
 	"evently/internal/shared/config"
 	"evently/internal/shared/database"
 	"evently/internal/shared/middleware"
+	"evently/internal/shared/utils/constants"
+	"evently/internal/support"
 	"evently/internal/tags"
 	"evently/internal/venues"
 	"evently/internal/waitlist"
+	"evently/internal/webhooks"
+	"evently/pkg/alerting"
 	"evently/pkg/cache"
+	"evently/pkg/currency"
+	"evently/pkg/media"
+	"evently/pkg/metrics"
+	"evently/pkg/ratelimit"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
@@ -26,6 +57,7 @@ import (
 	"github.com/google/uuid"
 	files "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	"gorm.io/gorm"
 )
 
 type VenueServiceAdapter struct {
@@ -40,37 +72,313 @@ func (v *VenueServiceAdapter) GetSectionsByTemplateID(ctx context.Context, templ
 	return sections, nil
 }
 
+type BrandingServiceAdapter struct {
+	brandingService branding.Service
+}
+
+func (b *BrandingServiceAdapter) GetBrandingForEvent(ctx context.Context, eventID uuid.UUID) (*events.EventBranding, error) {
+	resolved, err := b.brandingService.GetBrandingForEvent(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &events.EventBranding{
+		Name:           resolved.Name,
+		LogoURL:        resolved.LogoURL,
+		PrimaryColor:   resolved.PrimaryColor,
+		SecondaryColor: resolved.SecondaryColor,
+		Source:         resolved.Source,
+	}, nil
+}
+
+func (b *BrandingServiceAdapter) GetEmailBranding(ctx context.Context, eventID *uuid.UUID) (string, string, string, error) {
+	resolved := b.brandingService.GetPlatformBranding()
+	if eventID != nil {
+		eventBranding, err := b.brandingService.GetBrandingForEvent(ctx, *eventID)
+		if err != nil {
+			return "", "", "", err
+		}
+		resolved = eventBranding
+	}
+	return resolved.Name, resolved.LogoURL, resolved.PrimaryColor, nil
+}
+
+// NotificationPreferenceAdapter lets the outbox publisher consult notification preferences
+type NotificationPreferenceAdapter struct {
+	preferenceService notificationprefs.Service
+	profileService    profiles.Service // Supplies the profile phone when no SMS number is set
+}
+
+func (n *NotificationPreferenceAdapter) ResolveDelivery(ctx context.Context, userID uuid.UUID, notificationType notifications.NotificationType) (*notifications.DeliveryPlan, error) {
+	delivery, err := n.preferenceService.ResolveDelivery(ctx, userID, string(notificationType), time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	smsPhone := delivery.SMSPhone
+	if smsPhone == "" && delivery.Channels.SMS && n.profileService != nil {
+		if smsPhone, err = n.profileService.GetPhone(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+	return &notifications.DeliveryPlan{
+		Email:      delivery.Channels.Email,
+		SMS:        delivery.Channels.SMS,
+		Push:       delivery.Channels.Push,
+		SMSPhone:   smsPhone,
+		PushToken:  delivery.PushToken,
+		DeferUntil: delivery.DeferUntil,
+	}, nil
+}
+
+// InAppNotificationAdapter stores outbox notifications in the in-app notification center
+type InAppNotificationAdapter struct {
+	notificationCenter notificationcenter.Service
+}
+
+func (n *InAppNotificationAdapter) RecordInApp(ctx context.Context, notification *notifications.EmailNotification, title, body string) error {
+	return n.notificationCenter.Record(ctx, &notificationcenter.Notification{
+		UserID:          notification.RecipientID,
+		SourceID:        notification.ID,
+		Type:            string(notification.Type),
+		Title:           title,
+		Body:            body,
+		EventID:         notification.EventID,
+		BookingID:       notification.BookingID,
+		WaitlistEntryID: notification.WaitlistEntryID,
+	})
+}
+
+type RatingServiceAdapter struct {
+	reviewService reviews.Service
+}
+
+func (r *RatingServiceAdapter) GetRatingForEvent(ctx context.Context, eventID uuid.UUID) (*events.EventRating, error) {
+	summary, err := r.reviewService.GetRatingSummary(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	return &events.EventRating{AverageRating: summary.AverageRating, ReviewCount: summary.ReviewCount}, nil
+}
+
+func (r *RatingServiceAdapter) GetRatingsForEvents(ctx context.Context, eventIDs []uuid.UUID) (map[uuid.UUID]events.EventRating, error) {
+	summaries, err := r.reviewService.GetRatingSummaries(ctx, eventIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[uuid.UUID]events.EventRating, len(summaries))
+	for eventID, summary := range summaries {
+		result[eventID] = events.EventRating{AverageRating: summary.AverageRating, ReviewCount: summary.ReviewCount}
+	}
+	return result, nil
+}
+
+type FavoriteServiceAdapter struct {
+	favoriteService favorites.Service
+}
+
+func (f *FavoriteServiceAdapter) GetFavoritedEventIDs(ctx context.Context, userID uuid.UUID, eventIDs []uuid.UUID) (map[uuid.UUID]bool, error) {
+	return f.favoriteService.GetFavoritedEventIDs(ctx, userID, eventIDs)
+}
+
+func (f *FavoriteServiceAdapter) NotifyPriceDrop(ctx context.Context, eventID uuid.UUID, oldPrice, newPrice float64) error {
+	return f.favoriteService.NotifyPriceDrop(ctx, eventID, oldPrice, newPrice)
+}
+
+type EventChangeAdapter struct {
+	changeService eventchanges.Service
+}
+
+func (a *EventChangeAdapter) EventChanged(ctx context.Context, change events.EventChange) error {
+	return a.changeService.RecordChange(ctx, eventchanges.Change{
+		EventID:        change.EventID,
+		ChangedBy:      change.ChangedBy,
+		VenueBefore:    change.VenueBefore,
+		VenueAfter:     change.VenueAfter,
+		DateTimeBefore: change.DateTimeBefore,
+		DateTimeAfter:  change.DateTimeAfter,
+	})
+}
+
+type SeriesEventAdapter struct {
+	eventService events.Service
+}
+
+func (a *SeriesEventAdapter) CloneOccurrence(ctx context.Context, sourceID, adminID uuid.UUID, dateTime time.Time) (uuid.UUID, error) {
+	event, err := a.eventService.CloneEventAsAdmin(sourceID, adminID, events.CloneEventRequest{DateTime: dateTime})
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return uuid.Parse(event.ID)
+}
+
+func (a *SeriesEventAdapter) UpdateOccurrence(ctx context.Context, eventID, adminID uuid.UUID, req series.UpdateOccurrenceRequest) error {
+	_, err := a.eventService.UpdateEventAsAdmin(eventID, adminID, events.UpdateEventRequest{
+		Name:        req.Name,
+		Description: req.Description,
+		Venue:       req.Venue,
+		DateTime:    req.DateTime,
+		BasePrice:   req.BasePrice,
+		Status:      req.Status,
+		ImageURL:    req.ImageURL,
+		Tags:        req.Tags,
+	})
+	return err
+}
+
+func (a *SeriesEventAdapter) DeleteOccurrence(ctx context.Context, eventID, adminID uuid.UUID) error {
+	return a.eventService.DeleteEventAsAdmin(eventID, adminID)
+}
+
+type EventTitleAdapter struct {
+	eventService events.Service
+}
+
+func (e *EventTitleAdapter) GetEventTitle(ctx context.Context, eventID uuid.UUID) (string, error) {
+	event, err := e.eventService.GetEventByID(ctx, eventID)
+	if err != nil {
+		return "", err
+	}
+	return event.Name, nil
+}
+
+// WaitlistEventLookupAdapter provides event details for waitlist notifications
+type WaitlistEventLookupAdapter struct {
+	eventService events.Service
+}
+
+func (w *WaitlistEventLookupAdapter) GetEventDetails(ctx context.Context, eventID uuid.UUID) (*waitlist.EventDetails, error) {
+	event, err := w.eventService.GetEventByID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	details := &waitlist.EventDetails{
+		Name:     event.Name,
+		DateTime: event.DateTime,
+		Venue:    event.Venue,
+	}
+
+	// Notifications still go out with the event details if availability can't be loaded
+	sections, err := w.eventService.GetSectionAvailability(eventID)
+	if err != nil {
+		log.Printf("⚠️ Failed to load section availability for event %s: %v", eventID, err)
+		return details, nil
+	}
+	for _, section := range sections {
+		details.Sections = append(details.Sections, waitlist.SectionAvailability{
+			ID:        section.ID,
+			Name:      section.Name,
+			Available: section.Available,
+		})
+	}
+	return details, nil
+}
+
+// ReportRendererAdapter renders analytics report previews with the notification email templates
+type ReportRendererAdapter struct{}
+
+func (a *ReportRendererAdapter) RenderReport(recipientName string, templateData map[string]interface{}) (string, string, string, error) {
+	return notifications.RenderEmail(notifications.NotificationTypeAnalyticsReport, recipientName, templateData)
+}
+
 type Router struct {
 	config                 *config.Config
 	db                     *database.DB
 	tagService             tags.Service             // For dependency injection
 	eventService           events.Service           // For dependency injection
 	venueService           venues.Service           // For dependency injection
+	promotionService       promotions.Service       // For dependency injection
+	brandingService        branding.Service         // For dependency injection
+	reviewService          reviews.Service          // For dependency injection
+	favoriteService        favorites.Service        // For dependency injection
 	bookingService         bookings.Service         // For dependency injection
+	seatService            seats.Service            // For dependency injection
 	cancellationService    cancellation.Service     // For dependency injection
 	cancellationController *cancellation.Controller // For controller recreation when service updates
 	analyticsService       analytics.Service        // For analytics
 	waitlistService        waitlist.Service         // For waitlist operations
 	cacheService           cache.Service            // For caching
+	currencies             currency.Provider        // Exchange rates for event currencies
+	jobService             jobs.Service             // For export jobs
 	notificationService    notifications.NotificationService
+	outboxRelay            *outbox.Relay           // Relays queued notifications, nil without a notification service
+	domainEvents           domainevents.Repository // Records domain events saved outside a transaction
+	domainEventRelay       *outbox.Relay           // Publishes domain events to Kafka, nil unless enabled
+	holdExpirySweeper      *seats.HoldExpirySweeper
+	holdMonitor            *seats.HoldMonitor
+	redisWatch             *seats.RedisWatch     // Switches seat availability to Postgres-only while Redis is down
+	poolBreaker            *database.PoolBreaker // Sheds booking requests while the Postgres pool is saturated, nil when disabled
+	localCache             *cache.LocalCache     // In-process tier in front of Redis, nil when disabled
+	recapJob               *analytics.RecapJob
+	reportJob              *analytics.ReportJob
+	rollupJob              *analytics.RollupJob
+	dunningJob             *bookings.DunningJob
+	sagaRecoveryJob        *bookings.SagaRecoveryJob
+	consistencyJob         *bookings.ConsistencyJob // Alerts on double booked seats, nil when disabled
+	waitlistEscalationJob  *waitlist.EscalationJob
+	waitlistReconcileJob   *waitlist.ReconcileJob
+	upcomingWindowJob      *events.UpcomingWindowJob
+	eventLifecycleJob      *events.LifecycleJob
+	sellOutWatchJob        *favorites.SellOutWatchJob
+	capacityMonitor        *capacityalerts.Monitor
+	apiKeyUsageJob         *apikeys.UsageJob
+	webhookDeliveryJob     *webhooks.DeliveryJob
+	webhookService         webhooks.Service           // Publishes event.updated and event.status_changed from the events service
+	preferenceService      notificationprefs.Service  // Consulted by the outbox publisher before dispatch
+	profileService         profiles.Service           // Profile phone used for SMS when no SMS number is set
+	notificationCenter     notificationcenter.Service // Stores in-app notifications from the outbox publisher
+	archivalJob            *archive.ArchivalJob
+	eventChangeJob         *eventchanges.SendJob
+	eventReminderJob       *reminders.SendJob
+	documentCleanupJob     *documents.CleanupJob
+	jobWorkerPool          *jobs.WorkerPool
+	rateLimiter            *ratelimit.RateLimiter // nil when rate limiting is disabled
 }
 
 func NewRouter(cfg *config.Config, db *database.DB, notificationService notifications.NotificationService) *Router {
 
+	// Venues and tags read the cache through the global client
+	cache.Use(db.GetRedis())
+
+	var localCache *cache.LocalCache
+	if cfg.LocalCache.Enabled {
+		localCache = cache.InitLocal(db.GetRedis(), cache.LocalConfig{
+			MaxEntries:    cfg.LocalCache.MaxEntries,
+			MaxEntryBytes: cfg.LocalCache.MaxEntryBytes,
+			TTL:           cfg.LocalCache.TTL,
+			Prefixes:      constants.LOCAL_CACHE_KEY_PREFIXES,
+		})
+	}
+
 	cacheService := cache.NewService(db.GetRedis())
+	currencies := currency.NewProvider(cfg.Currency.Base, cfg.Currency.Rates, cfg.Currency.RatesURL, cfg.Currency.RefreshInterval)
 
 	return &Router{
 		config:              cfg,
 		db:                  db,
 		cacheService:        cacheService,
+		localCache:          localCache,
+		currencies:          currencies,
 		notificationService: notificationService,
+		domainEvents:        domainevents.NewRepository(db.GetPostgreSQL()),
 	}
 }
 
+// SetRateLimiter enables the rate limit administration routes
+func (r *Router) SetRateLimiter(rateLimiter *ratelimit.RateLimiter) {
+	r.rateLimiter = rateLimiter
+}
+
 func (r *Router) SetupRoutes(engine *gin.Engine) {
 
 	r.setupHealthRoutes(engine)
 
+	if r.config.Metrics.Enabled {
+		engine.GET(r.config.Metrics.Path, metrics.Handler())
+	}
+
 	// Redirect root path to health check
 	engine.GET("/", func(c *gin.Context) {
 		c.Redirect(http.StatusMovedPermanently, "/health")
@@ -78,70 +386,417 @@ func (r *Router) SetupRoutes(engine *gin.Engine) {
 
 	r.setupSwaggerRoutes(engine)
 
+	// Uploaded media such as organizer logos
+	engine.Static("/uploads", r.config.Upload.Path)
+
 	api := engine.Group(r.config.GetAPIBasePath())
 	{
 
 		r.setupAuthRoutes(api)
 
+		r.setupAPIKeyRoutes(api)
+
+		r.setupPermissionRoutes(api)
+
+		r.setupImpersonationRoutes(api)
+
+		r.setupWebhookRoutes(api)
+
+		r.setupJobRoutes(api)
+
 		r.setupTagRoutes(api)
 
 		r.setupVenueRoutes(api)
 
 		r.setupSeatRoutes(api)
 
+		r.setupPromotionRoutes(api)
+
+		r.setupBrandingRoutes(api)
+
+		r.setupReviewRoutes(api)
+
+		r.setupFavoriteRoutes(api)
+
+		r.setupCapacityAlertRoutes(api)
+
 		r.setupEventRoutes(api)
 
+		r.setupSeriesRoutes(api)
+
 		r.setupCancellationRoutes(api)
 
 		r.setupWaitlistRoutes(api)
 
 		r.setupBookingRoutes(api)
 
+		r.setupResaleRoutes(api)
+
+		r.setupSupportRoutes(api)
+
+		r.setupDocumentRoutes(api)
+
+		r.setupEmailTemplateRoutes(api)
+
+		r.setupNotificationPreferenceRoutes(api)
+
+		r.setupProfileRoutes(api)
+
+		r.setupNotificationCenterRoutes(api)
+
 		r.setupAnalyticsRoutes(api)
+
+		r.setupArchiveRoutes(api)
+
+		r.setupRateLimitRoutes(api)
+
+		r.setupCacheRoutes(api)
+	}
+
+	r.setupOutboxRelay()
+	r.setupDomainEventRelay()
+}
+
+func (r *Router) setupOutboxRelay() {
+	if r.notificationService == nil {
+		log.Printf("⚠️ No notification service available - outbox messages will stay queued until it is configured")
+		return
+	}
+
+	authRepo := auth.NewRepository(r.db.GetPostgreSQL())
+	var eventResolver notifications.EventResolver
+	if r.eventService != nil {
+		eventResolver = &EventTitleAdapter{eventService: r.eventService}
+	}
+
+	publisher := notifications.NewOutboxPublisher(r.notificationService, auth.NewUserServiceAdapter(authRepo), eventResolver)
+	if r.brandingService != nil {
+		publisher.SetBrandingResolver(&BrandingServiceAdapter{brandingService: r.brandingService})
+	}
+	if r.preferenceService != nil {
+		publisher.SetPreferenceResolver(&NotificationPreferenceAdapter{
+			preferenceService: r.preferenceService,
+			profileService:    r.profileService,
+		})
+	}
+	if r.notificationCenter != nil {
+		publisher.SetInAppRecorder(&InAppNotificationAdapter{notificationCenter: r.notificationCenter})
+	}
+
+	relayConfig := outbox.DefaultRelayConfig()
+	relayConfig.PollInterval = r.config.Outbox.PollInterval
+	relayConfig.BatchSize = r.config.Outbox.BatchSize
+	relayConfig.MaxAttempts = r.config.Outbox.MaxAttempts
+	relayConfig.BaseBackoff = r.config.Outbox.BaseBackoff
+	relayConfig.MaxBackoff = r.config.Outbox.MaxBackoff
+
+	r.outboxRelay = outbox.NewRelay(outbox.NewRepository(r.db.GetPostgreSQL()), outbox.Notifications(publisher), relayConfig)
+}
+
+func (r *Router) setupDomainEventRelay() {
+	if !r.config.DomainEvents.Enabled {
+		return
+	}
+
+	kafkaConfig := domainevents.DefaultKafkaConfig()
+	kafkaConfig.Brokers = r.config.DomainEvents.Brokers
+	kafkaConfig.TopicPrefix = r.config.DomainEvents.TopicPrefix
+
+	publisher, err := domainevents.NewKafkaPublisher(kafkaConfig)
+	if err != nil {
+		log.Printf("⚠️ Domain events can't be published - they will stay queued until Kafka is reachable: %v", err)
+		return
+	}
+
+	// Domain events are outbox messages of their own topics, relayed to Kafka
+	relayConfig := outbox.DefaultRelayConfig()
+	relayConfig.Name = "DOMAIN EVENTS"
+	relayConfig.Topics = domainevents.Topics
+	relayConfig.PollInterval = r.config.DomainEvents.PollInterval
+	relayConfig.BatchSize = r.config.DomainEvents.BatchSize
+	relayConfig.MaxAttempts = r.config.DomainEvents.MaxAttempts
+	relayConfig.BaseBackoff = r.config.DomainEvents.BaseBackoff
+	relayConfig.MaxBackoff = r.config.DomainEvents.MaxBackoff
+	relayConfig.Retention = r.config.DomainEvents.Retention
+
+	r.domainEventRelay = outbox.NewRelay(outbox.NewRepository(r.db.GetPostgreSQL()), publisher, relayConfig)
+}
+
+// StartBackgroundJobs starts workers owned by the router
+func (r *Router) StartBackgroundJobs(ctx context.Context) {
+	if r.db.Replicas != nil {
+		r.db.Replicas.Start(ctx)
+	}
+	if r.outboxRelay != nil {
+		r.outboxRelay.Start(ctx)
+	}
+	if r.domainEventRelay != nil {
+		r.domainEventRelay.Start(ctx)
+	}
+	if r.holdExpirySweeper != nil {
+		r.holdExpirySweeper.Start(ctx)
+	}
+	if r.redisWatch != nil {
+		r.redisWatch.Start(ctx)
+	}
+	if r.poolBreaker != nil {
+		r.poolBreaker.Start(ctx)
+	}
+	if r.localCache != nil {
+		r.localCache.Start(ctx)
+	}
+	if r.holdMonitor != nil {
+		r.holdMonitor.Start(ctx)
+	}
+	if r.recapJob != nil {
+		r.recapJob.Start(ctx)
+	}
+	if r.reportJob != nil {
+		r.reportJob.Start(ctx)
+	}
+	if r.rollupJob != nil {
+		r.rollupJob.Start(ctx)
+	}
+	if r.dunningJob != nil {
+		r.dunningJob.Start(ctx)
+	}
+	if r.sagaRecoveryJob != nil {
+		r.sagaRecoveryJob.Start(ctx)
+	}
+	if r.consistencyJob != nil {
+		r.consistencyJob.Start(ctx)
+	}
+	if r.waitlistEscalationJob != nil {
+		r.waitlistEscalationJob.Start(ctx)
+	}
+	if r.waitlistReconcileJob != nil {
+		r.waitlistReconcileJob.Start(ctx)
+	}
+	if r.upcomingWindowJob != nil {
+		r.upcomingWindowJob.Start(ctx)
+	}
+	if r.eventLifecycleJob != nil {
+		r.eventLifecycleJob.Start(ctx)
+	}
+	if r.sellOutWatchJob != nil {
+		r.sellOutWatchJob.Start(ctx)
+	}
+	if r.capacityMonitor != nil {
+		r.capacityMonitor.Start(ctx)
+	}
+	if r.apiKeyUsageJob != nil {
+		r.apiKeyUsageJob.Start(ctx)
+	}
+	if r.webhookDeliveryJob != nil {
+		r.webhookDeliveryJob.Start(ctx)
+	}
+	if r.archivalJob != nil {
+		r.archivalJob.Start(ctx)
+	}
+	if r.eventChangeJob != nil {
+		r.eventChangeJob.Start(ctx)
+	}
+	if r.eventReminderJob != nil {
+		r.eventReminderJob.Start(ctx)
+	}
+	if r.documentCleanupJob != nil {
+		r.documentCleanupJob.Start(ctx)
+	}
+	if r.jobWorkerPool != nil {
+		r.jobWorkerPool.Start(ctx)
+	}
+}
+
+// StopBackgroundJobs stops workers started by StartBackgroundJobs
+func (r *Router) StopBackgroundJobs() {
+	if r.db.Replicas != nil {
+		r.db.Replicas.Stop()
+	}
+	if r.outboxRelay != nil {
+		r.outboxRelay.Stop()
+	}
+	if r.domainEventRelay != nil {
+		r.domainEventRelay.Stop()
+	}
+	if r.holdExpirySweeper != nil {
+		r.holdExpirySweeper.Stop()
+	}
+	if r.redisWatch != nil {
+		r.redisWatch.Stop()
+	}
+	if r.poolBreaker != nil {
+		r.poolBreaker.Stop()
+	}
+	if r.localCache != nil {
+		r.localCache.Stop()
+	}
+	if r.holdMonitor != nil {
+		r.holdMonitor.Stop()
+	}
+	if r.recapJob != nil {
+		r.recapJob.Stop()
+	}
+	if r.reportJob != nil {
+		r.reportJob.Stop()
+	}
+	if r.rollupJob != nil {
+		r.rollupJob.Stop()
+	}
+	if r.dunningJob != nil {
+		r.dunningJob.Stop()
+	}
+	if r.sagaRecoveryJob != nil {
+		r.sagaRecoveryJob.Stop()
+	}
+	if r.consistencyJob != nil {
+		r.consistencyJob.Stop()
+	}
+	if r.waitlistEscalationJob != nil {
+		r.waitlistEscalationJob.Stop()
+	}
+	if r.waitlistReconcileJob != nil {
+		r.waitlistReconcileJob.Stop()
+	}
+	if r.upcomingWindowJob != nil {
+		r.upcomingWindowJob.Stop()
+	}
+	if r.eventLifecycleJob != nil {
+		r.eventLifecycleJob.Stop()
+	}
+	if r.sellOutWatchJob != nil {
+		r.sellOutWatchJob.Stop()
+	}
+	if r.capacityMonitor != nil {
+		r.capacityMonitor.Stop()
+	}
+	if r.apiKeyUsageJob != nil {
+		r.apiKeyUsageJob.Stop()
+	}
+	if r.webhookDeliveryJob != nil {
+		r.webhookDeliveryJob.Stop()
+	}
+	if r.archivalJob != nil {
+		r.archivalJob.Stop()
+	}
+	if r.eventChangeJob != nil {
+		r.eventChangeJob.Stop()
+	}
+	if r.eventReminderJob != nil {
+		r.eventReminderJob.Stop()
+	}
+	if r.documentCleanupJob != nil {
+		r.documentCleanupJob.Stop()
+	}
+	if r.jobWorkerPool != nil {
+		r.jobWorkerPool.Stop()
 	}
 }
 
 func (r *Router) setupHealthRoutes(engine *gin.Engine) {
-	engine.GET("/health", func(c *gin.Context) {
-
-		if err := r.db.HealthCheckDB(c.Request.Context()); err != nil {
-			c.JSON(http.StatusServiceUnavailable, gin.H{
-				"status":    "unhealthy",
-				"error":     err.Error(),
-				"timestamp": time.Now(),
-				"docs":      "/docs",
-				"service":   "event-backend",
-			})
-			return
-		}
+	engine.GET("/health", r.health)
+	engine.GET("/ping", r.ping)
+	engine.GET("/status", r.status)
+}
 
-		c.JSON(http.StatusOK, gin.H{
-			"status":    "healthy",
+// health godoc
+//
+// @Summary      Health check endpoint
+// @Description  Returns the health status of the API service. A Redis outage does not fail the check:
+// @Description  status becomes 'degraded' while seat availability is served from Postgres alone and new
+// @Description  holds are refused, and returns to 'healthy' once Redis answers again.
+// @Tags         Health
+// @Produce      json
+// @Success      200 {object} object "Service is healthy or running degraded without Redis"
+// @Failure      503 {object} object "Service is unhealthy"
+// @Router       /health [get]
+func (r *Router) health(c *gin.Context) {
+	// With degraded mode available a Redis outage is reported, not failed on
+	healthCheck := r.db.HealthCheckDB
+	if r.redisWatch != nil {
+		healthCheck = r.db.HealthCheckPostgres
+	}
+
+	if err := healthCheck(c.Request.Context()); err != nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"status":    "unhealthy",
+			"error":     err.Error(),
 			"timestamp": time.Now(),
+			"docs":      "/docs",
 			"service":   "event-backend",
 		})
-	})
+		return
+	}
 
-	engine.GET("/ping", func(c *gin.Context) {
+	if r.redisWatch != nil {
+		redisStatus := r.redisWatch.Status()
+		status := "healthy"
+		if redisStatus.Degraded {
+			status = "degraded"
+		}
 		c.JSON(http.StatusOK, gin.H{
-			"message": "pong",
-			"version": r.config.APIVersion,
+			"status":    status,
+			"redis":     redisStatus,
+			"database":  r.databaseHealth(),
+			"timestamp": time.Now(),
+			"service":   "event-backend",
 		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":    "healthy",
+		"database":  r.databaseHealth(),
+		"timestamp": time.Now(),
+		"service":   "event-backend",
 	})
+}
 
-	engine.GET("/status", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{
-			"status":      "operational",
-			"api_version": r.config.APIVersion,
-			"timestamp":   time.Now(),
-		})
+// ping godoc
+//
+// @Summary      Ping endpoint
+// @Description  Simple ping endpoint to check if API is responding
+// @Tags         Health
+// @Produce      json
+// @Success      200 {object} object "Pong response"
+// @Router       /ping [get]
+func (r *Router) ping(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"message": "pong",
+		"version": r.config.APIVersion,
 	})
 }
 
+// status godoc
+//
+// @Summary      API status
+// @Description  Returns general status information about the API
+// @Tags         Health
+// @Produce      json
+// @Success      200 {object} object "API status information"
+// @Router       /status [get]
+func (r *Router) status(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"status":      "operational",
+		"api_version": r.config.APIVersion,
+		"timestamp":   time.Now(),
+	})
+}
+
+// databaseHealth reports the connection pool and, when configured, the read
+// replicas
+func (r *Router) databaseHealth() gin.H {
+	health := gin.H{"pool": r.db.PoolStatus(r.poolBreaker)}
+	if r.db.Replicas != nil {
+		health["replicas"] = r.db.Replicas.Status()
+	}
+	return health
+}
+
 func (r *Router) setupAuthRoutes(rg *gin.RouterGroup) {
 
 	authRepo := auth.NewRepository(r.db.GetPostgreSQL())
 	authService := auth.NewService(authRepo, r.config)
+	authService.SetCurrencyProvider(r.currencies)
 	authController := auth.NewController(authService)
 	authRouter := auth.NewRouter(authController)
 
@@ -164,6 +819,7 @@ func (r *Router) setupEventRoutes(rg *gin.RouterGroup) {
 	// Initialize event dependencies
 	eventRepo := events.NewRepository(r.db.GetPostgreSQL())
 	eventService := events.NewService(eventRepo)
+	eventService.SetCurrencyProvider(r.currencies)
 
 	// Inject cache service dependency
 	if eventService, ok := eventService.(interface{ SetCacheService(cache.Service) }); ok && r.cacheService != nil {
@@ -181,6 +837,70 @@ func (r *Router) setupEventRoutes(rg *gin.RouterGroup) {
 		eventService.SetVenueService(venueServiceAdapter)
 	}
 
+	// Inject promotion service dependency through an adapter
+	if r.promotionService != nil {
+		eventService.SetPromotionService(r.promotionService)
+	}
+
+	// Inject branding service dependency through an adapter
+	if r.brandingService != nil {
+		eventService.SetBrandingService(&BrandingServiceAdapter{brandingService: r.brandingService})
+	}
+
+	// Inject rating service dependency through an adapter
+	if r.reviewService != nil {
+		eventService.SetRatingService(&RatingServiceAdapter{reviewService: r.reviewService})
+	}
+
+	// Inject favorite service dependency through an adapter
+	if r.favoriteService != nil {
+		eventService.SetFavoriteService(&FavoriteServiceAdapter{favoriteService: r.favoriteService})
+	}
+
+	// Venue and schedule changes are batched, then sent to attendees and waitlist members
+	changeConfig := eventchanges.DefaultConfig()
+	changeConfig.BatchWindow = r.config.EventChanges.BatchWindow
+	changeConfig.CheckInterval = r.config.EventChanges.CheckInterval
+	changeConfig.ReconfirmURL = r.config.EventChanges.ReconfirmURL
+	changeConfig.WaitlistURL = r.config.EventChanges.WaitlistURL
+	changeService := eventchanges.NewService(eventchanges.NewRepository(r.db.GetPostgreSQL()), changeConfig)
+	eventService.SubscribeChanges(&EventChangeAdapter{changeService: changeService})
+	if r.webhookService != nil {
+		eventService.SetWebhookPublisher(r.webhookService)
+	}
+	eventService.SetDomainEventPublisher(r.domainEvents)
+	r.eventChangeJob = eventchanges.NewSendJob(changeService, changeConfig)
+
+	// Attendees are reminded ahead of the event, once per offset however often it is moved
+	if r.config.EventReminders.Enabled {
+		reminderConfig := reminders.DefaultConfig()
+		reminderConfig.Offsets = r.config.EventReminders.Offsets
+		reminderConfig.CheckInterval = r.config.EventReminders.CheckInterval
+		reminderConfig.BookingURL = r.config.EventReminders.BookingURL
+		reminderService := reminders.NewService(reminders.NewRepository(r.db.GetPostgreSQL()), reminderConfig)
+		r.eventReminderJob = reminders.NewSendJob(reminderService, reminderConfig)
+	}
+
+	// Upcoming events are served from one precomputed window, kept warm while the cache is available
+	upcomingConfig := events.DefaultUpcomingWindowConfig()
+	upcomingConfig.Size = r.config.UpcomingEvents.WindowSize
+	upcomingConfig.RefreshInterval = r.config.UpcomingEvents.RefreshInterval
+	eventService.SetUpcomingWindowConfig(upcomingConfig)
+	if r.cacheService != nil {
+		r.upcomingWindowJob = events.NewUpcomingWindowJob(eventService, upcomingConfig)
+	}
+
+	eventService.SetSitemapConfig(&events.SitemapConfig{EventURL: r.config.Sitemap.EventURL})
+
+	// Events are completed, started and sold out on a schedule
+	lifecycleConfig := events.DefaultLifecycleConfig()
+	lifecycleConfig.Interval = r.config.EventLifecycle.Interval
+	lifecycleConfig.BatchSize = r.config.EventLifecycle.BatchSize
+	eventService.SetLifecycleConfig(lifecycleConfig)
+	if r.config.EventLifecycle.Enabled {
+		r.eventLifecycleJob = events.NewLifecycleJob(eventService, lifecycleConfig)
+	}
+
 	// Store event service for dependency injection
 	r.eventService = eventService
 
@@ -189,6 +909,109 @@ func (r *Router) setupEventRoutes(rg *gin.RouterGroup) {
 	events.SetupEventRoutes(rg, eventController)
 }
 
+func (r *Router) setupSeriesRoutes(rg *gin.RouterGroup) {
+	seriesRepo := series.NewRepository(r.db.GetPostgreSQL())
+	seriesService := series.NewService(seriesRepo)
+
+	if r.cacheService != nil {
+		seriesService.SetCacheService(r.cacheService)
+	}
+
+	// Occurrences are cloned and edited through the event service so caches and validation stay in one place
+	if r.eventService != nil {
+		seriesService.SetEventService(&SeriesEventAdapter{eventService: r.eventService})
+	}
+
+	seriesController := series.NewController(seriesService)
+
+	series.SetupSeriesRoutes(rg, seriesController)
+}
+
+func (r *Router) setupPromotionRoutes(rg *gin.RouterGroup) {
+	promotionRepo := promotions.NewRepository(r.db.GetPostgreSQL())
+	promotionService := promotions.NewService(promotionRepo)
+
+	if r.cacheService != nil {
+		promotionService.SetCacheService(r.cacheService)
+	}
+
+	// Store promotion service for dependency injection
+	r.promotionService = promotionService
+
+	promotionController := promotions.NewController(promotionService)
+
+	promotions.SetupPromotionRoutes(rg, promotionController)
+}
+
+func (r *Router) setupBrandingRoutes(rg *gin.RouterGroup) {
+	store := media.NewLocalStore(r.config.Upload.Path, r.config.Upload.PublicURL, r.config.Upload.MaxSize)
+	platform := branding.PlatformBranding{
+		Name:           r.config.Branding.Name,
+		LogoURL:        r.config.Branding.LogoURL,
+		PrimaryColor:   r.config.Branding.PrimaryColor,
+		SecondaryColor: r.config.Branding.SecondaryColor,
+	}
+
+	brandingRepo := branding.NewRepository(r.db.GetPostgreSQL())
+	brandingService := branding.NewService(brandingRepo, store, platform)
+
+	if r.cacheService != nil {
+		brandingService.SetCacheService(r.cacheService)
+	}
+
+	// Store branding service for dependency injection
+	r.brandingService = brandingService
+
+	brandingController := branding.NewController(brandingService)
+
+	branding.SetupBrandingRoutes(rg, brandingController)
+}
+
+func (r *Router) setupReviewRoutes(rg *gin.RouterGroup) {
+	reviewRepo := reviews.NewRepository(r.db.GetPostgreSQL())
+	reviewService := reviews.NewService(reviewRepo)
+
+	if r.cacheService != nil {
+		reviewService.SetCacheService(r.cacheService)
+	}
+
+	// Store review service for dependency injection
+	r.reviewService = reviewService
+
+	reviewController := reviews.NewController(reviewService)
+
+	reviews.SetupReviewRoutes(rg, reviewController)
+}
+
+func (r *Router) setupFavoriteRoutes(rg *gin.RouterGroup) {
+	favoriteRepo := favorites.NewRepository(r.db.GetPostgreSQL())
+	favoriteService := favorites.NewService(favoriteRepo)
+
+	// Store favorite service for dependency injection
+	r.favoriteService = favoriteService
+
+	sellOutConfig := favorites.DefaultSellOutWatchConfig()
+	sellOutConfig.CheckInterval = r.config.Favorites.SellOutCheckInterval
+	sellOutConfig.RemainingRatio = r.config.Favorites.SellOutRemainingRatio
+	r.sellOutWatchJob = favorites.NewSellOutWatchJob(favoriteService, sellOutConfig)
+
+	favoriteController := favorites.NewController(favoriteService)
+
+	favorites.SetupFavoriteRoutes(rg, favoriteController)
+}
+
+func (r *Router) setupCapacityAlertRoutes(rg *gin.RouterGroup) {
+	capacityService := capacityalerts.NewService(capacityalerts.NewRepository(r.db.GetPostgreSQL()))
+
+	monitorConfig := capacityalerts.DefaultMonitorConfig()
+	monitorConfig.CheckInterval = r.config.CapacityAlerts.CheckInterval
+	r.capacityMonitor = capacityalerts.NewMonitor(capacityService, monitorConfig)
+
+	capacityController := capacityalerts.NewController(capacityService)
+
+	capacityalerts.SetupCapacityAlertRoutes(rg, capacityController)
+}
+
 func (r *Router) setupVenueRoutes(rg *gin.RouterGroup) {
 	// Initialize venue dependencies
 	venueRepo := venues.NewRepository(r.db.GetPostgreSQL())
@@ -216,11 +1039,60 @@ func (r *Router) setupSeatRoutes(rg *gin.RouterGroup) {
 		seatService.SetCacheService(r.cacheService)
 	}
 
+	// Serve Postgres-only availability and refuse new holds while Redis is down
+	r.redisWatch = seats.NewRedisWatch(r.db.GetRedis(), r.config.RedisWatch)
+	if seatService, ok := seatService.(interface{ SetRedisWatch(*seats.RedisWatch) }); ok {
+		seatService.SetRedisWatch(r.redisWatch)
+	}
+
+	// Hold anomaly monitoring needs Redis to inspect holds
+	if r.config.HoldMonitor.Enabled && r.db.GetRedis() != nil {
+		r.holdMonitor = seats.NewHoldMonitor(seatRepo, r.newAlerter(), r.config)
+	}
+
+	// Holds expire in Redis without a trace, so expiries are tracked and swept for SeatHoldExpired
+	if r.config.DomainEvents.Enabled && r.db.GetRedis() != nil {
+		r.holdExpirySweeper = seats.NewHoldExpirySweeper(seatRepo, r.domainEvents, r.config)
+	}
+
+	// Store seat service for dependency injection
+	r.seatService = seatService
+
 	seatController := seats.NewController(seatService)
+	seatController.SetRedactPII(r.config.Privacy.RedactDebugPII)
 
 	seats.SetupSeatRoutes(rg, seatController)
 }
 
+// newAlerter builds an alerter that delivers to every configured channel,
+// falling back to the application log when none is configured
+func (r *Router) newAlerter() alerting.Alerter {
+	cfg := r.config.Alerting
+
+	var channels []alerting.Alerter
+	if cfg.SlackWebhookURL != "" {
+		channels = append(channels, alerting.NewSlackAlerter(cfg.SlackWebhookURL))
+	}
+	if cfg.WebhookURL != "" {
+		channels = append(channels, alerting.NewWebhookAlerter(cfg.WebhookURL))
+	}
+	if len(cfg.EmailRecipients) > 0 && r.config.Email.SMTPHost != "" {
+		channels = append(channels, alerting.NewEmailAlerter(alerting.EmailConfig{
+			Host:       r.config.Email.SMTPHost,
+			Port:       r.config.Email.SMTPPort,
+			Username:   r.config.Email.SMTPUsername,
+			Password:   r.config.Email.SMTPPassword,
+			From:       r.config.Email.FromEmail,
+			Recipients: cfg.EmailRecipients,
+		}))
+	}
+	if len(channels) == 0 {
+		channels = append(channels, alerting.LogAlerter{})
+	}
+
+	return alerting.NewThrottledAlerter(alerting.NewMultiAlerter(channels...), cfg.Cooldown)
+}
+
 func (r *Router) setupBookingRoutes(rg *gin.RouterGroup) {
 	// Initialize booking dependencies
 	bookingRepo := bookings.NewRepository(r.db.GetPostgreSQL())
@@ -238,6 +1110,62 @@ func (r *Router) setupBookingRoutes(rg *gin.RouterGroup) {
 
 	// Create booking service
 	bookingService := bookings.NewService(bookingRepo, seatServiceAdapter, waitlistServiceAdapter)
+
+	// Failed payments are retried on a schedule before the booking is cancelled
+	dunningConfig := bookings.DefaultDunningConfig()
+	dunningConfig.CheckInterval = r.config.Dunning.CheckInterval
+	dunningConfig.RetrySchedule = r.config.Dunning.RetrySchedule
+	dunningConfig.MaxAttempts = r.config.Dunning.MaxAttempts
+	dunningConfig.PaymentWindow = r.config.Dunning.PaymentWindow
+	dunningConfig.ResumePaymentURL = r.config.Dunning.ResumePaymentURL
+	bookingService.SetDunningConfig(dunningConfig)
+	r.dunningJob = bookings.NewDunningJob(bookingService, dunningConfig)
+
+	// Confirmations interrupted by a crash are finished or rolled back
+	sagaConfig := bookings.DefaultSagaConfig()
+	sagaConfig.RecoveryInterval = r.config.Saga.RecoveryInterval
+	sagaConfig.StaleAfter = r.config.Saga.StaleAfter
+	sagaConfig.MaxRecoveryAttempts = r.config.Saga.MaxRecoveryAttempts
+	bookingService.SetSagaConfig(sagaConfig)
+	r.sagaRecoveryJob = bookings.NewSagaRecoveryJob(bookingService, sagaConfig)
+
+	// Overlapping bookings are reported or refused depending on the deployment
+	conflictConfig := bookings.DefaultConflictConfig()
+	conflictConfig.Mode = r.config.BookingConflict.Mode
+	conflictConfig.Window = r.config.BookingConflict.Window
+	bookingService.SetConflictConfig(conflictConfig)
+
+	// Seats in two live bookings are reported, strict mode also locks seats
+	// while a booking is finalized
+	consistencyConfig := bookings.DefaultConsistencyConfig()
+	consistencyConfig.CheckInterval = r.config.SeatConsistency.Interval
+	consistencyConfig.StrictSeatLocks = r.config.SeatConsistency.StrictLocks
+	bookingService.SetConsistencyConfig(consistencyConfig)
+	if r.config.SeatConsistency.Enabled {
+		r.consistencyJob = bookings.NewConsistencyJob(bookingService, r.newAlerter(), consistencyConfig)
+	}
+
+	// Bookings can be handed to other users, who accept from an email invitation
+	transferConfig := bookings.DefaultTransferConfig()
+	transferConfig.Expiry = r.config.Transfer.Expiry
+	transferConfig.AcceptURL = r.config.Transfer.AcceptURL
+	bookingService.SetTransferConfig(transferConfig)
+
+	// Service fees and taxes are itemized on every new booking
+	pricingConfig := bookings.DefaultPricingConfig()
+	pricingConfig.ServiceFeePercent = r.config.Pricing.ServiceFeePercent
+	pricingConfig.ServiceFeePerTicket = r.config.Pricing.ServiceFeePerTicket
+	pricingConfig.RefundServiceFee = r.config.Pricing.RefundServiceFee
+	for _, rule := range r.config.Pricing.TaxRules {
+		pricingConfig.TaxRules = append(pricingConfig.TaxRules, bookings.TaxRule{
+			Name:        rule.Name,
+			Rate:        rule.Rate,
+			IncludeFees: rule.IncludeFees,
+		})
+	}
+	bookingService.SetPricingConfig(pricingConfig)
+	bookingService.SetCurrencyProvider(r.currencies)
+
 	bookingController := bookings.NewController(bookingService)
 
 	// Store booking service for dependency injection
@@ -245,45 +1173,315 @@ func (r *Router) setupBookingRoutes(rg *gin.RouterGroup) {
 
 	// Update cancellation service with booking service dependency (if cancellation service exists)
 	if r.cancellationService != nil {
-		// Create booking service adapter for cancellation service
-		bookingServiceAdapter := &BookingServiceAdapter{bookingService: bookingService}
+		r.rebuildCancellationService()
+	}
 
-		// Get existing waitlist service adapter if available
-		var waitlistAdapter cancellation.WaitlistService
-		if r.waitlistService != nil {
-			waitlistAdapter = &WaitlistServiceAdapter{waitlistService: r.waitlistService}
+	// Booking endpoints fail fast while the connection pool is saturated
+	bookingGroup := rg
+	if r.config.PoolBreaker.Enabled {
+		if sqlDB, err := r.db.GetPostgreSQL().DB(); err == nil {
+			r.poolBreaker = database.NewPoolBreaker(sqlDB, r.config.PoolBreaker)
+			bookingGroup = rg.Group("", middleware.ShedWhenSaturated(r.poolBreaker))
 		}
+	}
+
+	bookings.SetupBookingRoutes(bookingGroup, bookingController)
+}
 
-		// Re-create cancellation service with booking dependency
-		cancellationRepo := cancellation.NewRepository(r.db.GetPostgreSQL())
-		r.cancellationService = cancellation.NewService(cancellationRepo, bookingServiceAdapter, waitlistAdapter)
+func (r *Router) setupResaleRoutes(rg *gin.RouterGroup) {
+	resaleConfig := resale.DefaultConfig()
+	resaleConfig.FeePercent = r.config.Resale.FeePercent
+	resaleConfig.Cutoff = r.config.Resale.Cutoff
+
+	// Purchases move seats with the booking service, so this runs after the booking routes
+	resaleService := resale.NewService(resale.NewRepository(r.db.GetPostgreSQL()), r.bookingService)
+	resaleService.SetConfig(resaleConfig)
+
+	resaleController := resale.NewController(resaleService)
+
+	resale.SetupResaleRoutes(rg, resaleController)
+}
+
+func (r *Router) setupSupportRoutes(rg *gin.RouterGroup) {
+	supportRepo := support.NewRepository(r.db.GetPostgreSQL())
+	supportService := support.NewService(supportRepo)
+
+	supportController := support.NewController(supportService)
+
+	support.SetupSupportRoutes(rg, supportController)
+}
 
-		// Recreate the controller with the updated service
-		r.cancellationController = cancellation.NewController(r.cancellationService)
+func (r *Router) setupDocumentRoutes(rg *gin.RouterGroup) {
+	// Archives hold personal data, so they are stored outside the public uploads
+	store := media.NewLocalStore(r.config.Documents.Path, "", 0)
+
+	documentConfig := documents.DefaultConfig()
+	documentConfig.LinkTTL = r.config.Documents.LinkTTL
+	documentConfig.MinInterval = r.config.Documents.MinInterval
+	documentConfig.CleanupInterval = r.config.Documents.CleanupInterval
+	documentConfig.LinkSecret = r.config.Documents.LinkSecret
+	documentConfig.DownloadURL = r.config.PublicURL + r.config.GetAPIBasePath() + "/documents/archives/{archive_id}/download"
+	documentConfig.BrandName = r.config.Branding.Name
+
+	documentService := documents.NewService(documents.NewRepository(r.db.GetPostgreSQL()), store, documentConfig)
+	r.documentCleanupJob = documents.NewCleanupJob(documentService, documentConfig)
+
+	documentController := documents.NewController(documentService)
+
+	documents.SetupDocumentRoutes(rg, documentController)
+}
+
+func (r *Router) setupAPIKeyRoutes(rg *gin.RouterGroup) {
+	keyConfig := apikeys.DefaultConfig()
+	keyConfig.CacheTTL = r.config.APIKeys.CacheTTL
+	keyConfig.RotationGrace = r.config.APIKeys.RotationGrace
+	keyConfig.FlushInterval = r.config.APIKeys.FlushInterval
+
+	keyService := apikeys.NewService(apikeys.NewRepository(r.db.GetPostgreSQL()), keyConfig)
+	r.apiKeyUsageJob = apikeys.NewUsageJob(keyService, keyConfig)
+
+	// Routes that accept X-API-Key check keys against this service
+	middleware.SetAPIKeyAuthenticator(keyService)
+
+	keyController := apikeys.NewController(keyService)
+
+	apikeys.SetupAPIKeyRoutes(rg, keyController)
+}
+
+func (r *Router) setupImpersonationRoutes(rg *gin.RouterGroup) {
+	impersonationConfig := impersonation.DefaultConfig()
+	impersonationConfig.TTL = r.config.Impersonation.TTL
+	impersonationConfig.MaxTTL = r.config.Impersonation.MaxTTL
+
+	impersonationService := impersonation.NewService(impersonation.NewRepository(r.db.GetPostgreSQL()), r.config.JWT.Secret)
+	impersonationService.SetConfig(impersonationConfig)
+
+	// JWT authentication checks impersonation tokens against their session and audits them
+	middleware.SetImpersonationAuditor(impersonationService)
+
+	impersonationController := impersonation.NewController(impersonationService)
+
+	impersonation.SetupImpersonationRoutes(rg, impersonationController)
+}
+
+func (r *Router) setupPermissionRoutes(rg *gin.RouterGroup) {
+	permissionConfig := permissions.DefaultConfig()
+	permissionConfig.CacheTTL = r.config.Permissions.CacheTTL
+
+	permissionService := permissions.NewService(permissions.NewRepository(r.db.GetPostgreSQL()), permissionConfig)
+
+	// Permission checks look up the custom roles of non-admin users here
+	middleware.SetPermissionResolver(permissionService)
+
+	permissionController := permissions.NewController(permissionService)
+
+	permissions.SetupPermissionRoutes(rg, permissionController)
+}
+
+func (r *Router) setupWebhookRoutes(rg *gin.RouterGroup) {
+	webhookConfig := webhooks.DefaultConfig()
+	webhookConfig.PollInterval = r.config.Webhooks.PollInterval
+	webhookConfig.BatchSize = r.config.Webhooks.BatchSize
+	webhookConfig.Concurrency = r.config.Webhooks.Concurrency
+	webhookConfig.Timeout = r.config.Webhooks.Timeout
+	webhookConfig.MaxAttempts = r.config.Webhooks.MaxAttempts
+	webhookConfig.BaseBackoff = r.config.Webhooks.BaseBackoff
+	webhookConfig.MaxBackoff = r.config.Webhooks.MaxBackoff
+	webhookConfig.AllowHTTP = r.config.Webhooks.AllowHTTP
+
+	webhookService := webhooks.NewService(webhooks.NewRepository(r.db.GetPostgreSQL()), webhookConfig)
+	r.webhookDeliveryJob = webhooks.NewDeliveryJob(webhookService, webhookConfig)
+	r.webhookService = webhookService
+
+	webhookController := webhooks.NewController(webhookService)
+
+	webhooks.SetupWebhookRoutes(rg, webhookController)
+}
+
+func (r *Router) setupEmailTemplateRoutes(rg *gin.RouterGroup) {
+	templateConfig := emailtemplates.DefaultConfig()
+	templateConfig.TestSendLimit = r.config.EmailTemplates.TestSendLimit
+	templateConfig.TestSendWindow = r.config.EmailTemplates.TestSendWindow
+	templateConfig.BrandName = r.config.Branding.Name
+	templateConfig.BrandLogoURL = r.config.Branding.LogoURL
+	templateConfig.BrandPrimaryColor = r.config.Branding.PrimaryColor
+
+	// Previews still work without email delivery, test sends then fail
+	var sender emailtemplates.NotificationSender
+	if r.notificationService != nil {
+		sender = r.notificationService
 	}
+	templateConfig.CacheTTL = r.config.EmailTemplates.CacheTTL
 
-	bookings.SetupBookingRoutes(rg, bookingController)
+	// MJML templates are compiled when saved, and rejected without API credentials
+	var mjml emailtemplates.MJMLCompiler
+	if compiler := emailtemplates.NewMJMLAPICompiler(r.config.EmailTemplates.MJMLAPIURL,
+		r.config.EmailTemplates.MJMLAppID, r.config.EmailTemplates.MJMLSecretKey); compiler != nil {
+		mjml = compiler
+	}
+
+	templateRepo := emailtemplates.NewRepository(r.db.GetPostgreSQL())
+	templateService := emailtemplates.NewService(templateRepo, sender, mjml, templateConfig)
+
+	// The email worker renders admin-edited templates in place of the built-in ones
+	if r.notificationService != nil {
+		r.notificationService.SetTemplateSource(templateService)
+	}
+
+	templateController := emailtemplates.NewController(templateService)
+
+	emailtemplates.SetupEmailTemplateRoutes(rg, templateController)
+}
+
+func (r *Router) setupNotificationPreferenceRoutes(rg *gin.RouterGroup) {
+	preferenceRepo := notificationprefs.NewRepository(r.db.GetPostgreSQL())
+	preferenceService := notificationprefs.NewService(preferenceRepo)
+
+	// Store preference service so the outbox publisher can consult it
+	r.preferenceService = preferenceService
+
+	preferenceController := notificationprefs.NewController(preferenceService)
+
+	notificationprefs.SetupNotificationPreferenceRoutes(rg, preferenceController)
+}
+
+func (r *Router) setupProfileRoutes(rg *gin.RouterGroup) {
+	profileConfig := profiles.DefaultConfig()
+	profileConfig.EmailChangeExpiry = r.config.Profile.EmailChangeExpiry
+	profileConfig.EmailVerifyURL = r.config.Profile.EmailVerifyURL
+
+	profileService := profiles.NewService(profiles.NewRepository(r.db.GetPostgreSQL()), r.preferenceService)
+	profileService.SetConfig(profileConfig)
+
+	// Store profile service so SMS can fall back to the profile phone
+	r.profileService = profileService
+
+	profileController := profiles.NewController(profileService)
+
+	profiles.SetupProfileRoutes(rg, profileController)
+}
+
+func (r *Router) setupNotificationCenterRoutes(rg *gin.RouterGroup) {
+	notificationRepo := notificationcenter.NewRepository(r.db.GetPostgreSQL())
+	notificationCenter := notificationcenter.NewService(notificationRepo)
+
+	// Store the notification center so the outbox publisher can add to it
+	r.notificationCenter = notificationCenter
+
+	notificationController := notificationcenter.NewController(notificationCenter)
+
+	notificationcenter.SetupNotificationCenterRoutes(rg, notificationController)
+}
+
+func (r *Router) setupJobRoutes(rg *gin.RouterGroup) {
+	// Results can hold personal data, so they are stored outside the public uploads
+	store := media.NewLocalStore(r.config.Jobs.Path, "", 0)
+
+	jobConfig := jobs.DefaultConfig()
+	jobConfig.Workers = r.config.Jobs.Workers
+	jobConfig.PollInterval = r.config.Jobs.PollInterval
+	jobConfig.Retention = r.config.Jobs.Retention
+	jobConfig.CleanupInterval = r.config.Jobs.CleanupInterval
+	jobConfig.ResultURL = r.config.GetAPIBasePath() + "/jobs/{job_id}/result"
+
+	// Handlers are registered by the features that enqueue jobs
+	r.jobService = jobs.NewService(jobs.NewRepository(r.db.GetPostgreSQL()), store, jobConfig)
+	r.jobWorkerPool = jobs.NewWorkerPool(r.jobService, jobConfig)
+
+	jobController := jobs.NewController(r.jobService)
+
+	jobs.SetupJobRoutes(rg, jobController)
+}
+
+func (r *Router) setupArchiveRoutes(rg *gin.RouterGroup) {
+	archiveRepo := archive.NewRepository(r.db.GetPostgreSQL())
+	archiveService := archive.NewService(archiveRepo)
+	archiveService.SetCacheService(r.cacheService)
+
+	if r.config.Archive.Enabled {
+		archivalConfig := archive.DefaultArchivalConfig()
+		archivalConfig.CheckInterval = r.config.Archive.CheckInterval
+		archivalConfig.RetentionMonths = r.config.Archive.RetentionMonths
+		r.archivalJob = archive.NewArchivalJob(archiveService, archivalConfig)
+	}
+
+	archiveController := archive.NewController(archiveService)
+
+	archive.SetupArchiveRoutes(rg, archiveController)
+}
+
+func (r *Router) setupRateLimitRoutes(rg *gin.RouterGroup) {
+	if r.rateLimiter == nil {
+		log.Printf("Rate limiting disabled - allowlist/denylist routes not registered")
+		return
+	}
+
+	rateLimitController := ratelimit.NewAdminController(r.rateLimiter)
+
+	ratelimit.SetupAdminRoutes(rg, rateLimitController)
+}
+
+func (r *Router) setupCacheRoutes(rg *gin.RouterGroup) {
+	redisClient := r.db.GetRedis()
+	if redisClient == nil {
+		log.Printf("Redis unavailable - cache administration routes not registered")
+		return
+	}
+
+	cacheController := cache.NewAdminController(redisClient)
+	if r.eventService != nil {
+		cacheController.RegisterWarmer("event_lists", r.eventService.WarmEventLists)
+		cacheController.RegisterWarmer("upcoming_events", func(ctx context.Context) (int, error) {
+			if err := r.eventService.RefreshUpcomingWindow(ctx); err != nil {
+				return 0, err
+			}
+			return 1, nil
+		})
+	}
+	if r.analyticsService != nil {
+		cacheController.RegisterWarmer("dashboard_analytics", func(ctx context.Context) (int, error) {
+			// A refresh reads the primary and replaces the cached dashboard
+			if _, err := r.analyticsService.GetDashboardAnalytics(ctx, true); err != nil {
+				return 0, err
+			}
+			return 1, nil
+		})
+	}
+
+	cache.SetupAdminRoutes(rg, cacheController)
 }
 
 func (r *Router) setupCancellationRoutes(rg *gin.RouterGroup) {
-	// Initialize cancellation dependencies
+	// Booking and waitlist services are injected later, as they are set up
+	r.rebuildCancellationService()
+
+	r.setupCancellationRoutesWithWrappers(rg)
+}
+
+// rebuildCancellationService creates the cancellation service and controller
+// with whichever of its dependencies have been set up so far
+func (r *Router) rebuildCancellationService() {
 	cancellationRepo := cancellation.NewRepository(r.db.GetPostgreSQL())
 
-	// Create booking service adapter for cancellation service
 	var bookingServiceAdapter cancellation.BookingService
 	if r.bookingService != nil {
 		bookingServiceAdapter = &BookingServiceAdapter{bookingService: r.bookingService}
 	}
 
-	// Initialize without waitlist service (will be injected later)
-	cancellationService := cancellation.NewService(cancellationRepo, bookingServiceAdapter, nil)
-	cancellationController := cancellation.NewController(cancellationService)
+	var waitlistAdapter cancellation.WaitlistService
+	if r.waitlistService != nil {
+		waitlistAdapter = &WaitlistServiceAdapter{waitlistService: r.waitlistService}
+	}
+
+	cancellationService := cancellation.NewService(cancellationRepo, bookingServiceAdapter, waitlistAdapter)
+	cancellationService.SetCacheService(r.cacheService)
+	if r.eventService != nil {
+		cancellationService.SetEventService(&EventCancellationAdapter{eventService: r.eventService})
+	}
 
-	// Store cancellation service and controller for dependency injection
+	// The routes call through r.cancellationController, so they pick up the new controller
 	r.cancellationService = cancellationService
-	r.cancellationController = cancellationController
-
-	r.setupCancellationRoutesWithWrappers(rg)
+	r.cancellationController = cancellation.NewController(cancellationService)
 }
 
 type BookingServiceAdapter struct {
@@ -296,17 +1494,26 @@ func (b *BookingServiceAdapter) GetBooking(ctx context.Context, bookingID uuid.U
 		return cancellation.BookingInfo{}, err
 	}
 
-	return cancellation.BookingInfo{
-		ID:         booking.ID,
-		UserID:     booking.UserID,
-		EventID:    booking.EventID,
-		TotalPrice: booking.TotalPrice,
-		TotalSeats: booking.TotalSeats,
-		Status:     booking.Status,
-		BookingRef: booking.BookingRef,
-		Version:    booking.Version,
-		CreatedAt:  booking.CreatedAt,
-	}, nil
+	info := cancellation.BookingInfo{
+		ID:           booking.ID,
+		UserID:       booking.UserID,
+		EventID:      booking.EventID,
+		TotalPrice:   booking.TotalPrice,
+		Currency:     booking.Currency,
+		ExchangeRate: booking.ExchangeRate,
+		TotalSeats:   booking.TotalSeats,
+		Status:       booking.Status,
+		BookingRef:   booking.BookingRef,
+		Version:      booking.Version,
+		CreatedAt:    booking.CreatedAt,
+	}
+	if booking.PriceBreakdown != nil {
+		info.NonRefundable = booking.PriceBreakdown.NonRefundable
+	}
+	for _, seat := range booking.FreedSeats() {
+		info.FreedSeats = append(info.FreedSeats, cancellation.FreedSeat(seat))
+	}
+	return info, nil
 }
 
 func (b *BookingServiceAdapter) CancelBookingInternal(ctx context.Context, bookingID uuid.UUID) error {
@@ -314,15 +1521,70 @@ func (b *BookingServiceAdapter) CancelBookingInternal(ctx context.Context, booki
 }
 
 func (b *BookingServiceAdapter) CancelBookingWithVersion(ctx context.Context, bookingID uuid.UUID, expectedVersion int) error {
-	return b.bookingService.CancelBookingWithVersion(ctx, bookingID, expectedVersion)
+	return cancellationError(b.bookingService.CancelBookingWithVersion(ctx, bookingID, expectedVersion))
+}
+
+func (b *BookingServiceAdapter) PrepareSeatCancellation(ctx context.Context, bookingID uuid.UUID, expectedVersion int, seatIDs []uuid.UUID) (*cancellation.CancelledSeats, error) {
+	cancelled, err := b.bookingService.PrepareSeatCancellation(ctx, bookingID, expectedVersion, seatIDs)
+	if err != nil {
+		return nil, cancellationError(err)
+	}
+
+	result := &cancellation.CancelledSeats{
+		Price:          cancelled.Price,
+		NonRefundable:  cancelled.NonRefundable,
+		RemainingSeats: cancelled.RemainingSeats,
+		Apply: func(tx *gorm.DB) error {
+			return cancellationError(bookings.ApplySeatCancellation(tx, cancelled))
+		},
+	}
+	for _, seat := range cancelled.FreedSeats {
+		result.FreedSeats = append(result.FreedSeats, cancellation.FreedSeat(seat))
+	}
+	return result, nil
+}
+
+// cancellationError turns a lost optimistic lock into the cancellation
+// package's own error
+func cancellationError(err error) error {
+	if errors.Is(err, bookings.ErrBookingModified) {
+		return cancellation.ErrBookingModified
+	}
+	return err
 }
 
 type WaitlistServiceAdapter struct {
 	waitlistService waitlist.Service
 }
 
-func (w *WaitlistServiceAdapter) ProcessCancellation(ctx context.Context, eventID uuid.UUID, freedTickets int) error {
-	return w.waitlistService.ProcessCancellation(ctx, eventID, freedTickets)
+func (w *WaitlistServiceAdapter) ProcessCancellation(ctx context.Context, eventID uuid.UUID, freedTickets int, freed []cancellation.FreedSeat) error {
+	seats := make([]waitlist.FreedSeat, len(freed))
+	for i, seat := range freed {
+		seats[i] = waitlist.FreedSeat{SeatID: seat.SeatID, SectionID: seat.SectionID, Price: seat.Price}
+	}
+	return w.waitlistService.ProcessCancellation(ctx, eventID, freedTickets, seats)
+}
+
+func (w *WaitlistServiceAdapter) CloseWaitlist(ctx context.Context, eventID uuid.UUID, reason string) (int, error) {
+	return w.waitlistService.CloseWaitlist(ctx, eventID, reason)
+}
+
+type EventCancellationAdapter struct {
+	eventService events.Service
+}
+
+func (a *EventCancellationAdapter) CancelEvent(ctx context.Context, eventID, adminID uuid.UUID) error {
+	_, err := a.eventService.CancelEventAsAdmin(eventID, adminID)
+	switch {
+	case err == nil:
+		return nil
+	case err.Error() == "event not found":
+		return cancellation.ErrEventNotFound
+	case errors.Is(err, events.ErrEventNotCancellable):
+		return fmt.Errorf("%w: %v", cancellation.ErrEventNotCancellable, err)
+	default:
+		return err
+	}
 }
 
 type SeatServiceAdapter struct {
@@ -378,6 +1640,7 @@ func (s *SeatServiceAdapter) GetSeatsByHoldID(ctx context.Context, holdID string
 			SeatNumber:  seatInfo.SeatNumber,
 			Row:         seatInfo.Row,
 			Price:       seatInfo.Price,
+			BasePrice:   seatInfo.BasePrice,
 			SectionName: seatInfo.SectionName,
 		})
 	}
@@ -392,14 +1655,82 @@ func (s *SeatServiceAdapter) GetHoldDetails(ctx context.Context, holdID string)
 	}
 
 	return &bookings.SeatHoldDetails{
-		HoldID:  details.HoldID,
-		UserID:  details.UserID,
-		EventID: details.EventID,
-		SeatIDs: details.SeatIDs,
-		TTL:     details.TTL,
+		HoldID:       details.HoldID,
+		UserID:       details.UserID,
+		EventID:      details.EventID,
+		SeatIDs:      details.SeatIDs,
+		TicketTypeID: details.TicketTypeID,
+		Quantity:     details.Quantity,
+		TTL:          details.TTL,
 	}, nil
 }
 
+func (s *SeatServiceAdapter) GetTicketsByHoldID(ctx context.Context, holdID string) (*bookings.TicketHoldInfo, error) {
+	tickets, err := s.seatService.GetTicketsByHoldID(ctx, holdID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &bookings.TicketHoldInfo{
+		TicketTypeID: tickets.TicketTypeID,
+		SectionID:    tickets.SectionID,
+		Name:         tickets.Name,
+		Quantity:     tickets.Quantity,
+		UnitPrice:    tickets.UnitPrice,
+		BasePrice:    tickets.BasePrice,
+	}, nil
+}
+
+func (s *SeatServiceAdapter) ConsumeHold(ctx context.Context, holdID string) error {
+	return s.seatService.ConsumeHold(ctx, holdID)
+}
+
+func (s *SeatServiceAdapter) ReturnTickets(ctx context.Context, ticketTypeID uuid.UUID, quantity int) error {
+	return s.seatService.ReturnTickets(ctx, ticketTypeID, quantity)
+}
+
+// WaitlistEscalationSenderAdapter routes waitlist escalations to the notification channel senders
+type WaitlistEscalationSenderAdapter struct {
+	sms  notifications.SMSSender
+	push notifications.PushSender
+}
+
+func (a *WaitlistEscalationSenderAdapter) SendSMS(ctx context.Context, phone, body string) error {
+	return a.sms.SendSMS(ctx, phone, body)
+}
+
+func (a *WaitlistEscalationSenderAdapter) SendPush(ctx context.Context, token, title, body string, data map[string]string) error {
+	return a.push.SendPush(ctx, token, title, body, data)
+}
+
+// WaitlistDeliveryTrackerAdapter records the delivery outcome of waitlist
+// notifications in waitlist_notifications
+type WaitlistDeliveryTrackerAdapter struct {
+	waitlistService waitlist.Service
+}
+
+func (a *WaitlistDeliveryTrackerAdapter) RecordDelivery(ctx context.Context, notification *notifications.EmailNotification, deliveryErr error) {
+	if notification.WaitlistEntryID == nil {
+		return
+	}
+
+	var notificationType waitlist.NotificationType
+	switch notification.Type {
+	case notifications.NotificationTypeWaitlistSpotAvailable:
+		notificationType = waitlist.NotificationTypeSpotAvailable
+	case notifications.NotificationTypeWaitlistPositionUpdate:
+		notificationType = waitlist.NotificationTypePositionUpdate
+	default:
+		return
+	}
+
+	err := a.waitlistService.RecordNotificationDelivery(ctx, *notification.WaitlistEntryID, notification.ID.String(),
+		notificationType, waitlist.NotificationChannel(notification.DeliveryChannel()), deliveryErr)
+	if err != nil {
+		log.Printf("⚠️ Failed to record waitlist notification delivery %s: %v", notification.ID, err)
+	}
+}
+
 type WaitlistServiceAdapterForBookings struct {
 	waitlistService waitlist.Service
 }
@@ -422,20 +1753,85 @@ func (w *WaitlistServiceAdapterForBookings) MarkAsConverted(ctx context.Context,
 	return w.waitlistService.MarkAsConverted(ctx, userID, eventID, bookingID)
 }
 
+func (w *WaitlistServiceAdapterForBookings) RevertConversion(ctx context.Context, userID, eventID, bookingID uuid.UUID) error {
+	return w.waitlistService.RevertConversion(ctx, userID, eventID, bookingID)
+}
+
+func (w *WaitlistServiceAdapterForBookings) ProcessCancellation(ctx context.Context, eventID uuid.UUID, freedTickets int, freed []bookings.FreedSeat) error {
+	seats := make([]waitlist.FreedSeat, len(freed))
+	for i, seat := range freed {
+		seats[i] = waitlist.FreedSeat{SeatID: seat.SeatID, SectionID: seat.SectionID, Price: seat.Price}
+	}
+	return w.waitlistService.ProcessCancellation(ctx, eventID, freedTickets, seats)
+}
+
+// WaitlistServiceAdapterForSeats offers seats released from admin blocks to the waitlist
+type WaitlistServiceAdapterForSeats struct {
+	waitlistService waitlist.Service
+}
+
+func (w *WaitlistServiceAdapterForSeats) ProcessCancellation(ctx context.Context, eventID uuid.UUID, freedTickets int, freed []seats.FreedSeat) error {
+	freedSeats := make([]waitlist.FreedSeat, len(freed))
+	for i, seat := range freed {
+		freedSeats[i] = waitlist.FreedSeat{SeatID: seat.SeatID, SectionID: seat.SectionID, Price: seat.Price}
+	}
+	return w.waitlistService.ProcessCancellation(ctx, eventID, freedTickets, freedSeats)
+}
+
 func (r *Router) setupAnalyticsRoutes(rg *gin.RouterGroup) {
 
 	analyticsRepo := analytics.NewRepository(r.db.GetPostgreSQL())
+	if !r.config.AnalyticsRollup.Enabled {
+		// Without the rollup job the rollup tables would go stale
+		analyticsRepo = analyticsRepo.Live()
+	}
 	analyticsService := analytics.NewService(analyticsRepo)
 
 	if analyticsService, ok := analyticsService.(interface{ SetCacheService(cache.Service) }); ok && r.cacheService != nil {
 		analyticsService.SetCacheService(r.cacheService)
 	}
 
+	analyticsService.SetBaseCurrency(r.currencies.Base())
+
+	shareConfig := analytics.DefaultShareLinkConfig()
+	shareConfig.DefaultTTL = r.config.AnalyticsShareLinks.DefaultTTL
+	shareConfig.MaxTTL = r.config.AnalyticsShareLinks.MaxTTL
+	shareConfig.Secret = r.config.AnalyticsShareLinks.Secret
+	shareConfig.URL = r.config.PublicURL + r.config.GetAPIBasePath() + "/analytics/shared/{token}"
+	analyticsService.SetShareLinkConfig(shareConfig)
+
 	analyticsController := analytics.NewController(analyticsService)
 
 	// Store analytics service for dependency injection
 	r.analyticsService = analyticsService
 
+	if r.config.Recap.Enabled {
+		recapConfig := analytics.DefaultRecapJobConfig()
+		recapConfig.SendMonth = time.Month(r.config.Recap.SendMonth)
+		recapConfig.SendDay = r.config.Recap.SendDay
+		recapConfig.BatchSize = r.config.Recap.BatchSize
+		recapConfig.BatchInterval = r.config.Recap.BatchInterval
+		r.recapJob = analytics.NewRecapJob(analyticsService, recapConfig)
+	}
+
+	// Report previews use the notification service's templates
+	analyticsService.SetReportRenderer(&ReportRendererAdapter{})
+
+	if r.config.AnalyticsReports.Enabled {
+		reportConfig := analytics.DefaultReportJobConfig()
+		reportConfig.SendHour = r.config.AnalyticsReports.SendHour
+		reportConfig.BatchSize = r.config.AnalyticsReports.BatchSize
+		r.reportJob = analytics.NewReportJob(analyticsService, reportConfig)
+	}
+
+	if r.config.AnalyticsRollup.Enabled {
+		rollupConfig := analytics.DefaultRollupJobConfig()
+		rollupConfig.Interval = r.config.AnalyticsRollup.Interval
+		rollupConfig.NightlyHour = r.config.AnalyticsRollup.NightlyHour
+		rollupConfig.BackfillDays = r.config.AnalyticsRollup.BackfillDays
+		r.rollupJob = analytics.NewRollupJob(analyticsService, rollupConfig)
+	}
+
 	analytics.SetupAnalyticsRoutes(rg, analyticsController)
 }
 
@@ -443,43 +1839,63 @@ func (r *Router) setupWaitlistRoutes(rg *gin.RouterGroup) {
 	// Initialize waitlist dependencies
 	waitlistRepo := waitlist.NewRepository(r.db.GetPostgreSQL(), r.db.GetRedis())
 
-	var notificationAdapter waitlist.NotificationService
-	if r.notificationService != nil {
-		// Use the notification service passed from main.go
-		notificationAdapter = notifications.NewWaitlistServiceAdapter(r.notificationService)
-		log.Printf("✅ Waitlist service initialized with email notification adapter")
-	} else {
-		log.Printf("⚠️ No notification service available for waitlist - notifications will be disabled")
+	// Create waitlist service - notifications are delivered through the outbox relay
+	waitlistConfig := waitlist.DefaultServiceConfig()
+	waitlistConfig.BookingURL = r.config.Waitlist.BookingURL
+	waitlistService := waitlist.NewService(waitlistRepo, waitlistConfig)
+	if r.eventService != nil {
+		waitlistService.SetEventLookup(&WaitlistEventLookupAdapter{eventService: r.eventService})
+	}
+	if r.jobService != nil {
+		waitlistService.SetJobService(r.jobService)
+		r.jobService.Register(waitlist.ExportJobType, waitlistService.ExportEntries)
 	}
+	waitlistController := waitlist.NewController(waitlistService)
 
-	// Create user service adapter
-	authRepo := auth.NewRepository(r.db.GetPostgreSQL())
-	userServiceAdapter := auth.NewUserServiceAdapter(authRepo)
+	// Unopened spot-available emails are followed up over SMS/push for users who opted in
+	escalationConfig := waitlist.DefaultEscalationConfig()
+	escalationConfig.Enabled = r.config.WaitlistEscalation.Enabled
+	escalationConfig.After = r.config.WaitlistEscalation.After
+	escalationConfig.Interval = r.config.WaitlistEscalation.Interval
+	escalationConfig.MaxAttempts = r.config.WaitlistEscalation.MaxAttempts
+	escalationConfig.OpenTrackingURL = r.config.PublicURL + r.config.GetAPIBasePath() + "/waitlist/notifications"
+	waitlistService.SetEscalationConfig(escalationConfig)
+	channelSenders, err := notifications.NewChannelSenders(notifications.NewChannelConfigFromEnv())
+	if err != nil {
+		log.Printf("⚠️ SMS/push providers unavailable, escalations will only be logged: %v", err)
+		channelSenders = notifications.ChannelSenders{SMS: notifications.LogSMSSender{}, Push: notifications.LogPushSender{}}
+	}
+	waitlistService.SetEscalationSender(&WaitlistEscalationSenderAdapter{
+		sms:  channelSenders.SMS,
+		push: channelSenders.Push,
+	})
+	if escalationConfig.Enabled {
+		r.waitlistEscalationJob = waitlist.NewEscalationJob(waitlistService, escalationConfig)
+	}
 
-	// Create waitlist service
-	waitlistService := waitlist.NewService(waitlistRepo, notificationAdapter, userServiceAdapter, nil)
-	waitlistController := waitlist.NewController(waitlistService)
+	// Redis queues are checked against Postgres entries and repaired if they drift
+	if r.config.WaitlistReconcile.Enabled {
+		reconcileConfig := waitlist.DefaultReconcileConfig()
+		reconcileConfig.Interval = r.config.WaitlistReconcile.Interval
+		r.waitlistReconcileJob = waitlist.NewReconcileJob(waitlistService, reconcileConfig)
+	}
+
+	// Delivery outcomes of waitlist notifications are tracked per channel
+	if r.notificationService != nil {
+		r.notificationService.SetDeliveryTracker(&WaitlistDeliveryTrackerAdapter{waitlistService: waitlistService})
+	}
 
 	// Store waitlist service for dependency injection
 	r.waitlistService = waitlistService
 
 	// Update cancellation service with waitlist service dependency (if cancellation service exists)
 	if r.cancellationService != nil {
-		// Create waitlist service adapter for cancellation service
-		waitlistAdapter := &WaitlistServiceAdapter{waitlistService: waitlistService}
-
-		// Re-create cancellation service with waitlist dependency
-		cancellationRepo := cancellation.NewRepository(r.db.GetPostgreSQL())
-		var bookingServiceAdapter cancellation.BookingService
-		if r.bookingService != nil {
-			bookingServiceAdapter = &BookingServiceAdapter{bookingService: r.bookingService}
-		}
-
-		// Update the cancellation service with waitlist integration
-		r.cancellationService = cancellation.NewService(cancellationRepo, bookingServiceAdapter, waitlistAdapter)
+		r.rebuildCancellationService()
+	}
 
-		// Recreate the controller with the updated service
-		r.cancellationController = cancellation.NewController(r.cancellationService)
+	// Seats released from admin blocks are offered to the waitlist
+	if seatService, ok := r.seatService.(interface{ SetWaitlistService(seats.WaitlistNotifier) }); ok {
+		seatService.SetWaitlistService(&WaitlistServiceAdapterForSeats{waitlistService: waitlistService})
 	}
 
 	// Setup waitlist routes
@@ -487,9 +1903,9 @@ func (r *Router) setupWaitlistRoutes(rg *gin.RouterGroup) {
 }
 
 func (r *Router) setupCancellationRoutesWithWrappers(rg *gin.RouterGroup) {
-	// Event cancellation policy routes (Admin only)
+	// Event cancellation policy routes (events:write)
 	events := rg.Group("/admin/events")
-	events.Use(middleware.JWTAuth(), middleware.RequireRoles("ADMIN"))
+	events.Use(middleware.JWTAuth(), middleware.RequirePermission(middleware.PermissionEventsWrite))
 	{
 		events.POST("/:eventId/cancellation-policy", func(c *gin.Context) {
 			r.cancellationController.CreateCancellationPolicy(c)
@@ -500,6 +1916,9 @@ func (r *Router) setupCancellationRoutesWithWrappers(rg *gin.RouterGroup) {
 		events.PUT("/:eventId/cancellation-policy", func(c *gin.Context) {
 			r.cancellationController.UpdateCancellationPolicy(c)
 		})
+		events.POST("/:eventId/cancel", func(c *gin.Context) {
+			r.cancellationController.CancelEvent(c)
+		})
 	}
 
 	// Booking cancellation routes (Users and Admins)
@@ -520,6 +1939,21 @@ func (r *Router) setupCancellationRoutesWithWrappers(rg *gin.RouterGroup) {
 		})
 	}
 
+	// Late cancellation approval (bookings:manage)
+	approvals := rg.Group("/admin/cancellations")
+	approvals.Use(middleware.JWTAuth(), middleware.RequirePermission(middleware.PermissionBookingsManage))
+	{
+		approvals.GET("/pending", func(c *gin.Context) {
+			r.cancellationController.GetPendingCancellations(c)
+		})
+		approvals.POST("/:id/approve", func(c *gin.Context) {
+			r.cancellationController.ApproveCancellation(c)
+		})
+		approvals.POST("/:id/reject", func(c *gin.Context) {
+			r.cancellationController.RejectCancellation(c)
+		})
+	}
+
 	// User-specific cancellation routes
 	users := rg.Group("/users")
 	users.Use(middleware.JWTAuth(), middleware.RequireRoles("USER", "ADMIN"))
@@ -0,0 +1,455 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cache test for a running backend. Each cached endpoint is first verified on
+// its own: every response must carry an X-Cache header, and a repeat request
+// must be a HIT. With --duration set, the verified endpoints are then put under
+// sustained load from --concurrency workers, and the hit ratio and the latency
+// of hits and misses are reported per endpoint.
+//
+// Every request is a read, so it is safe to point at a shared environment.
+// The report is written to --report and to stdout as JSON; the exit code is 0
+// when every check passed and 1 otherwise.
+
+type Options struct {
+	BaseURL     string
+	APIPath     string
+	EventID     string
+	Timeout     time.Duration
+	Concurrency int
+	Duration    time.Duration
+	MinHitRatio float64
+	ReportPath  string
+}
+
+type CheckResult struct {
+	Name       string `json:"name"`
+	Path       string `json:"path"`
+	Status     string `json:"status"`          // PASS, FAIL or SKIP
+	First      string `json:"first,omitempty"` // X-Cache of the first request
+	Requests   int    `json:"requests,omitempty"`
+	DurationMs int64  `json:"duration_ms"`
+	Detail     string `json:"detail,omitempty"`
+}
+
+type LatencyStats struct {
+	Count int     `json:"count"`
+	AvgMs float64 `json:"avg_ms"`
+	P50Ms float64 `json:"p50_ms"`
+	P95Ms float64 `json:"p95_ms"`
+	P99Ms float64 `json:"p99_ms"`
+	MaxMs float64 `json:"max_ms"`
+}
+
+type EndpointLoad struct {
+	Name     string       `json:"name"`
+	Path     string       `json:"path"`
+	Status   string       `json:"status"` // PASS or FAIL
+	Requests int          `json:"requests"`
+	Hits     int          `json:"hits"`
+	Misses   int          `json:"misses"`
+	NoHeader int          `json:"no_header"` // Responses without X-Cache
+	Errors   int          `json:"errors"`    // Transport errors and non-2xx responses
+	HitRatio float64      `json:"hit_ratio"`
+	Hit      LatencyStats `json:"hit_latency"`
+	Miss     LatencyStats `json:"miss_latency"`
+	Detail   string       `json:"detail,omitempty"`
+}
+
+type LoadReport struct {
+	Concurrency    int            `json:"concurrency"`
+	DurationMs     int64          `json:"duration_ms"`
+	Requests       int            `json:"requests"`
+	RequestsPerSec float64        `json:"requests_per_sec"`
+	Endpoints      []EndpointLoad `json:"endpoints"`
+}
+
+type Report struct {
+	Target     string        `json:"target"`
+	Passed     bool          `json:"passed"`
+	StartedAt  time.Time     `json:"started_at"`
+	DurationMs int64         `json:"duration_ms"`
+	Checks     []CheckResult `json:"checks"`
+	Load       *LoadReport   `json:"load,omitempty"`
+}
+
+const (
+	statusPass = "PASS"
+	statusFail = "FAIL"
+	statusSkip = "SKIP"
+
+	headerCache = "X-Cache"
+	cacheHit    = "HIT"
+	cacheMiss   = "MISS"
+
+	// The first request may store its result asynchronously, so a repeat is
+	// retried a few times before the endpoint is declared uncached
+	verifyAttempts = 5
+	verifyPause    = 100 * time.Millisecond
+)
+
+// endpoint is a cached, public GET route under the API base path
+type endpoint struct {
+	name string
+	path string
+}
+
+type runner struct {
+	opts   Options
+	client *http.Client
+	report *Report
+}
+
+func main() {
+	opts := Options{}
+	flag.StringVar(&opts.BaseURL, "url", getEnv("CACHE_TEST_BASE_URL", "http://localhost:8080"), "base URL of the backend")
+	flag.StringVar(&opts.APIPath, "api-path", getEnv("CACHE_TEST_API_PATH", "/api/v1"), "API base path")
+	flag.StringVar(&opts.EventID, "event", os.Getenv("CACHE_TEST_EVENT_ID"), "event ID for the detail endpoint (default: first event in the list)")
+	flag.DurationVar(&opts.Timeout, "timeout", 10*time.Second, "per-request timeout")
+	flag.IntVar(&opts.Concurrency, "concurrency", 10, "concurrent workers in the load phase")
+	flag.DurationVar(&opts.Duration, "duration", 0, "length of the load phase, e.g. 30s (0 skips it)")
+	flag.Float64Var(&opts.MinHitRatio, "min-hit-ratio", 0.9, "lowest hit ratio an endpoint may have under load")
+	flag.StringVar(&opts.ReportPath, "report", "cache_test_report.json", "file the JSON report is written to")
+	flag.Parse()
+
+	if opts.Concurrency < 1 {
+		fmt.Fprintln(os.Stderr, "--concurrency must be at least 1")
+		os.Exit(2)
+	}
+	opts.BaseURL = strings.TrimRight(opts.BaseURL, "/")
+
+	r := &runner{
+		opts: opts,
+		client: &http.Client{
+			Timeout: opts.Timeout,
+			// Keep a connection per worker instead of redialling under load
+			Transport: &http.Transport{
+				Proxy:               http.ProxyFromEnvironment,
+				MaxIdleConns:        opts.Concurrency,
+				MaxIdleConnsPerHost: opts.Concurrency,
+				IdleConnTimeout:     90 * time.Second,
+			},
+		},
+		report: &Report{Target: opts.BaseURL, StartedAt: time.Now().UTC()},
+	}
+	r.run()
+
+	var encoded bytes.Buffer
+	encoder := json.NewEncoder(&encoded)
+	encoder.SetEscapeHTML(false) // Keep query strings in paths readable
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(r.report); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to encode report: %v\n", err)
+		os.Exit(2)
+	}
+	if opts.ReportPath != "" {
+		if err := os.WriteFile(opts.ReportPath, encoded.Bytes(), 0o644); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to write report: %v\n", err)
+			os.Exit(2)
+		}
+	}
+	os.Stdout.Write(encoded.Bytes())
+
+	if !r.report.Passed {
+		os.Exit(1)
+	}
+}
+
+func (r *runner) run() {
+	started := time.Now()
+
+	endpoints := []endpoint{
+		{name: "event_list", path: "/events?page=1&limit=10"},
+		{name: "active_tags", path: "/tags/active"},
+	}
+
+	eventID, err := r.resolveEventID()
+	if err != nil {
+		r.skip("event_detail", "/events/{id}", err.Error())
+	} else {
+		endpoints = append(endpoints, endpoint{name: "event_detail", path: "/events/" + eventID})
+	}
+
+	var verified []endpoint
+	for _, e := range endpoints {
+		if r.verify(e) {
+			verified = append(verified, e)
+		}
+	}
+
+	if r.opts.Duration > 0 && len(verified) > 0 {
+		r.report.Load = r.load(verified)
+	}
+
+	r.report.Passed = true
+	for _, c := range r.report.Checks {
+		if c.Status == statusFail {
+			r.report.Passed = false
+		}
+	}
+	if r.report.Load != nil {
+		for _, e := range r.report.Load.Endpoints {
+			if e.Status == statusFail {
+				r.report.Passed = false
+			}
+		}
+	}
+	r.report.DurationMs = time.Since(started).Milliseconds()
+}
+
+func (r *runner) skip(name, path, reason string) {
+	r.report.Checks = append(r.report.Checks, CheckResult{Name: name, Path: path, Status: statusSkip, Detail: reason})
+}
+
+//  VERIFICATION
+
+// resolveEventID returns the event given with --event, or the first listed one
+func (r *runner) resolveEventID() (string, error) {
+	if r.opts.EventID != "" {
+		return r.opts.EventID, nil
+	}
+
+	var envelope struct {
+		Data struct {
+			Events []struct {
+				ID string `json:"id"`
+			} `json:"events"`
+		} `json:"data"`
+	}
+	status, _, err := r.get("/events?page=1&limit=1", &envelope)
+	if err != nil {
+		return "", fmt.Errorf("could not list events: %w", err)
+	}
+	if status != http.StatusOK {
+		return "", fmt.Errorf("could not list events: HTTP %d", status)
+	}
+	if len(envelope.Data.Events) == 0 {
+		return "", errors.New("no events to test, pass --event or CACHE_TEST_EVENT_ID")
+	}
+	return envelope.Data.Events[0].ID, nil
+}
+
+// verify checks that an endpoint reports its cache status and that a repeat
+// request is served from the cache
+func (r *runner) verify(e endpoint) bool {
+	started := time.Now()
+	result := CheckResult{Name: e.name, Path: e.path, Status: statusPass}
+	defer func() {
+		result.DurationMs = time.Since(started).Milliseconds()
+		r.report.Checks = append(r.report.Checks, result)
+	}()
+
+	fail := func(format string, args ...interface{}) bool {
+		result.Status = statusFail
+		result.Detail = fmt.Sprintf(format, args...)
+		return false
+	}
+
+	for attempt := 1; attempt <= verifyAttempts+1; attempt++ {
+		status, cacheStatus, err := r.get(e.path, nil)
+		result.Requests = attempt
+		if err != nil {
+			return fail("request %d: %v", attempt, err)
+		}
+		if status != http.StatusOK {
+			return fail("request %d: HTTP %d", attempt, status)
+		}
+		if cacheStatus != cacheHit && cacheStatus != cacheMiss {
+			return fail("request %d: missing or invalid %s header %q", attempt, headerCache, cacheStatus)
+		}
+
+		if attempt == 1 {
+			result.First = cacheStatus
+			continue
+		}
+		if cacheStatus == cacheHit {
+			result.Detail = fmt.Sprintf("first %s, HIT after %d repeat(s)", result.First, attempt-1)
+			return true
+		}
+		time.Sleep(verifyPause)
+	}
+	return fail("first %s, still MISS after %d repeats", result.First, verifyAttempts)
+}
+
+//  LOAD
+
+// sample is one request made in the load phase
+type sample struct {
+	cacheStatus string
+	latency     time.Duration
+	failed      bool
+}
+
+// load runs the endpoints round robin from every worker until the duration ends
+func (r *runner) load(endpoints []endpoint) *LoadReport {
+	deadline := time.Now().Add(r.opts.Duration)
+	started := time.Now()
+
+	// Each worker keeps its own samples so the hot loop takes no locks
+	perWorker := make([][][]sample, r.opts.Concurrency)
+	var wg sync.WaitGroup
+	for w := 0; w < r.opts.Concurrency; w++ {
+		perWorker[w] = make([][]sample, len(endpoints))
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			samples := perWorker[w]
+			for i := w; time.Now().Before(deadline); i++ {
+				idx := i % len(endpoints)
+				requestStarted := time.Now()
+				status, cacheStatus, err := r.get(endpoints[idx].path, nil)
+				samples[idx] = append(samples[idx], sample{
+					cacheStatus: cacheStatus,
+					latency:     time.Since(requestStarted),
+					failed:      err != nil || status < 200 || status >= 300,
+				})
+			}
+		}(w)
+	}
+	wg.Wait()
+	elapsed := time.Since(started)
+
+	report := &LoadReport{Concurrency: r.opts.Concurrency, DurationMs: elapsed.Milliseconds()}
+	for idx, e := range endpoints {
+		var samples []sample
+		for _, worker := range perWorker {
+			samples = append(samples, worker[idx]...)
+		}
+		result := r.summarize(e, samples)
+		report.Requests += result.Requests
+		report.Endpoints = append(report.Endpoints, result)
+	}
+	if elapsed > 0 {
+		report.RequestsPerSec = round(float64(report.Requests) / elapsed.Seconds())
+	}
+	return report
+}
+
+func (r *runner) summarize(e endpoint, samples []sample) EndpointLoad {
+	result := EndpointLoad{Name: e.name, Path: e.path, Status: statusPass, Requests: len(samples)}
+
+	var hits, misses []time.Duration
+	for _, s := range samples {
+		switch {
+		case s.failed:
+			result.Errors++
+		case s.cacheStatus == cacheHit:
+			result.Hits++
+			hits = append(hits, s.latency)
+		case s.cacheStatus == cacheMiss:
+			result.Misses++
+			misses = append(misses, s.latency)
+		default:
+			result.NoHeader++
+		}
+	}
+	if answered := result.Hits + result.Misses; answered > 0 {
+		result.HitRatio = round(float64(result.Hits) / float64(answered))
+	}
+	result.Hit = latencyStats(hits)
+	result.Miss = latencyStats(misses)
+
+	var problems []string
+	if result.Errors > 0 {
+		problems = append(problems, fmt.Sprintf("%d failed requests", result.Errors))
+	}
+	if result.NoHeader > 0 {
+		problems = append(problems, fmt.Sprintf("%d responses without %s", result.NoHeader, headerCache))
+	}
+	if result.HitRatio < r.opts.MinHitRatio {
+		problems = append(problems, fmt.Sprintf("hit ratio %.2f below %.2f", result.HitRatio, r.opts.MinHitRatio))
+	}
+	if len(problems) > 0 {
+		result.Status = statusFail
+		result.Detail = strings.Join(problems, "; ")
+	}
+	return result
+}
+
+func latencyStats(latencies []time.Duration) LatencyStats {
+	if len(latencies) == 0 {
+		return LatencyStats{}
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+
+	var total time.Duration
+	for _, l := range latencies {
+		total += l
+	}
+	percentile := func(p float64) float64 {
+		idx := int(math.Ceil(p*float64(len(latencies)))) - 1
+		if idx < 0 {
+			idx = 0
+		}
+		return ms(latencies[idx])
+	}
+
+	return LatencyStats{
+		Count: len(latencies),
+		AvgMs: ms(total / time.Duration(len(latencies))),
+		P50Ms: percentile(0.50),
+		P95Ms: percentile(0.95),
+		P99Ms: percentile(0.99),
+		MaxMs: ms(latencies[len(latencies)-1]),
+	}
+}
+
+func ms(d time.Duration) float64 {
+	return round(float64(d) / float64(time.Millisecond))
+}
+
+func round(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+//  HTTP HELPERS
+
+// get calls a GET endpoint under the API base path and returns the HTTP status
+// and X-Cache header, decoding the body into out when it is set
+func (r *runner) get(path string, out interface{}) (int, string, error) {
+	req, err := http.NewRequest(http.MethodGet, r.opts.BaseURL+r.opts.APIPath+path, nil)
+	if err != nil {
+		return 0, "", err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "evently-cachetest")
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+
+	cacheStatus := resp.Header.Get(headerCache)
+	if out == nil {
+		// Drain the body so the connection is reused
+		_, err = io.Copy(io.Discard, resp.Body)
+		return resp.StatusCode, cacheStatus, err
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil && !errors.Is(err, io.EOF) {
+		return resp.StatusCode, cacheStatus, fmt.Errorf("invalid JSON response: %w", err)
+	}
+	return resp.StatusCode, cacheStatus, nil
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
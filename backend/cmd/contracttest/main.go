@@ -0,0 +1,572 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"evently/api/routes"
+	"evently/internal/shared/config"
+	"evently/internal/shared/database"
+	"evently/internal/shared/middleware"
+	"evently/pkg/cache"
+	"evently/pkg/ratelimit"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+	"github.com/joho/godotenv"
+	"github.com/redis/go-redis/v9"
+	"gopkg.in/yaml.v3"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// API contract test. Builds the router in-process and calls every registered
+// API route anonymously, plus every GET route as a user and as an admin, then
+// checks each response against the conventions clients and SDKs rely on:
+//
+//   - envelope:   JSON bodies are a StandardApiResponse whose status and
+//     status_code agree with the HTTP status, errors carry an error_code and
+//     request_id echoes the X-Request-ID sent with the request
+//   - pagination: paged lists carry total_count, page, limit and total_pages
+//     next to a single item array, repeated in the envelope pagination
+//   - rate_limit: X-RateLimit-* headers are sent on every API response while
+//     rate limiting is enabled
+//   - request_id: every API response echoes the X-Request-ID it was sent
+//   - documented: every API route has an operation in the generated
+//     docs/swagger.yaml, so a handler without annotations fails the run
+//
+// Only GET routes are called with a token, and with random IDs, so a run never
+// changes existing data; point it at a disposable database all the same, since
+// some GETs (such as document archives) start work for the caller. Without a
+// reachable database the router is built on disconnected clients: handlers
+// then fail, which still exercises their error envelopes, and checks that need
+// data are skipped.
+//
+// Results are written to stdout as JSON; the exit code is 0 when every check
+// passed and 1 otherwise, so CI can fail the build when a handler drifts.
+
+type Options struct {
+	Offline bool
+	Route   string
+	Spec    string
+}
+
+type CheckResult struct {
+	Name       string `json:"name"`
+	Method     string `json:"method"`
+	Path       string `json:"path"`
+	Caller     string `json:"caller"` // ANONYMOUS, USER or ADMIN
+	Status     string `json:"status"` // PASS, FAIL or SKIP
+	HTTPStatus int    `json:"http_status,omitempty"`
+	Detail     string `json:"detail,omitempty"`
+}
+
+type Report struct {
+	Passed     bool           `json:"passed"`
+	Database   bool           `json:"database"` // False when run on disconnected clients
+	StartedAt  time.Time      `json:"started_at"`
+	DurationMs int64          `json:"duration_ms"`
+	Routes     int            `json:"routes"`
+	Summary    map[string]int `json:"summary"`
+	Checks     []CheckResult  `json:"checks"`
+}
+
+const (
+	statusPass = "PASS"
+	statusFail = "FAIL"
+	statusSkip = "SKIP"
+)
+
+const (
+	callerAnonymous = "ANONYMOUS"
+	callerUser      = "USER"
+	callerAdmin     = "ADMIN"
+)
+
+// apiResponse mirrors response.StandardApiResponse with presence tracking
+type apiResponse struct {
+	Status     *string         `json:"status"`
+	StatusCode *int            `json:"status_code"`
+	ErrorCode  *string         `json:"error_code"`
+	Message    *string         `json:"message"`
+	RequestID  *string         `json:"request_id"`
+	Data       json.RawMessage `json:"data"`
+	Pagination json.RawMessage `json:"pagination"`
+	Errors     json.RawMessage `json:"errors"`
+}
+
+// paginationFields are the fields of every paged list, e.g. events.PaginatedEvents
+var paginationFields = []string{"total_count", "page", "limit", "total_pages"}
+
+// rateLimitHeaders are set by ratelimit.Middleware on every limited response
+var rateLimitHeaders = []string{"X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset"}
+
+type runner struct {
+	opts    Options
+	cfg     *config.Config
+	engine  *gin.Engine
+	limited bool // Rate limiting is enabled and backed by Redis
+	report  *Report
+	tokens  map[string]string
+
+	documented map[string]bool // "METHOD /gin/:path" of every spec operation
+	specErr    error
+}
+
+func main() {
+	opts := Options{}
+	flag.BoolVar(&opts.Offline, "offline", false, "build the router on disconnected clients instead of the configured database")
+	flag.StringVar(&opts.Route, "route", "", "only check routes whose path contains this string")
+	flag.StringVar(&opts.Spec, "spec", "docs/swagger.yaml", "generated Swagger spec the routes must be documented in")
+	flag.Parse()
+
+	_ = godotenv.Load()
+	gin.SetMode(gin.TestMode)
+
+	// Handlers log and print freely; keep stdout for the report
+	stdout := os.Stdout
+	os.Stdout = os.Stderr
+	log.SetOutput(os.Stderr)
+
+	cfg := config.Load()
+	r := &runner{
+		opts:   opts,
+		cfg:    cfg,
+		report: &Report{StartedAt: time.Now().UTC(), Summary: map[string]int{}},
+	}
+
+	db := r.connect()
+	defer db.Close()
+	r.engine = r.buildEngine(db)
+	r.tokens = map[string]string{
+		callerUser:  r.mintToken("USER"),
+		callerAdmin: r.mintToken("ADMIN"),
+	}
+	r.documented, r.specErr = loadSpec(opts.Spec)
+
+	r.run()
+
+	encoder := json.NewEncoder(stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(r.report); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to write report: %v\n", err)
+		os.Exit(2)
+	}
+
+	if !r.report.Passed {
+		os.Exit(1)
+	}
+}
+
+// connect opens the configured database, or disconnected clients when it is
+// unreachable or -offline is set. Disconnected clients fail every query.
+func (r *runner) connect() *database.DB {
+	if !r.opts.Offline {
+		db, err := database.InitDB(r.cfg)
+		if err == nil {
+			r.report.Database = true
+			return db
+		}
+		fmt.Fprintf(os.Stderr, "database unavailable, running offline: %v\n", err)
+	}
+
+	pg, err := gorm.Open(postgres.Open(r.cfg.Database.DSN), &gorm.Config{
+		Logger:               logger.Default.LogMode(logger.Silent),
+		DisableAutomaticPing: true,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to create database client: %v\n", err)
+		os.Exit(2)
+	}
+
+	return &database.DB{
+		PostgreSQL: pg,
+		Redis: redis.NewClient(&redis.Options{
+			Addr:        r.cfg.Redis.Addr,
+			Password:    r.cfg.Redis.Password,
+			DB:          r.cfg.Redis.DB,
+			DialTimeout: 200 * time.Millisecond,
+			MaxRetries:  -1,
+		}),
+	}
+}
+
+// buildEngine wires the router the same way server/main.go does
+func (r *runner) buildEngine(db *database.DB) *gin.Engine {
+	engine := gin.New()
+	engine.Use(middleware.RequestID(), gin.Recovery(), cache.StatusMiddleware())
+
+	var rateLimiter *ratelimit.RateLimiter
+	if r.cfg.RateLimit.Enabled && r.report.Database {
+		rateLimiter = ratelimit.NewRateLimiter(db.GetRedis(), &ratelimit.Config{
+			Enabled:                 true,
+			WindowDuration:          r.cfg.RateLimit.WindowDuration,
+			DefaultRequests:         r.cfg.RateLimit.DefaultRequests,
+			PublicRequests:          r.cfg.RateLimit.PublicRequests,
+			AuthRequests:            r.cfg.RateLimit.AuthRequests,
+			BookingRequests:         r.cfg.RateLimit.BookingRequests,
+			AdminRequests:           r.cfg.RateLimit.AdminRequests,
+			AnalyticsRequests:       r.cfg.RateLimit.AnalyticsRequests,
+			BookingCriticalRequests: r.cfg.RateLimit.BookingCriticalRequests,
+			UserRequests:            r.cfg.RateLimit.UserRequests,
+			HealthRequests:          r.cfg.RateLimit.HealthRequests,
+
+			PerUserLimits:       r.cfg.RateLimit.PerUserLimits,
+			UserLimitMultiplier: r.cfg.RateLimit.UserLimitMultiplier,
+			SlidingLogTypes:     ratelimit.ParseLimitTypes(r.cfg.RateLimit.SlidingLogTypes),
+			Burst:               ratelimit.ParseBurst(r.cfg.RateLimit.Burst),
+		})
+		engine.Use(ratelimit.Middleware(rateLimiter, middleware.BearerUserID(r.cfg)))
+		r.limited = true
+	}
+
+	appRouter := routes.NewRouter(r.cfg, db, nil)
+	if rateLimiter != nil {
+		appRouter.SetRateLimiter(rateLimiter)
+	}
+	appRouter.SetupRoutes(engine)
+	return engine
+}
+
+// mintToken signs an access token for a random user, the same claims auth issues
+func (r *runner) mintToken(role string) string {
+	userID := uuid.New().String()
+	claims := jwt.MapClaims{
+		"user_id": userID,
+		"email":   fmt.Sprintf("contract-%s@example.com", strings.ToLower(role)),
+		"role":    role,
+		"type":    "access",
+		"mfa":     true,
+		"iss":     "evently",
+		"sub":     userID,
+		"iat":     time.Now().Unix(),
+		"exp":     time.Now().Add(time.Hour).Unix(),
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(r.cfg.JWT.Secret))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to sign %s token: %v\n", role, err)
+		os.Exit(2)
+	}
+	return token
+}
+
+func (r *runner) run() {
+	started := time.Now()
+
+	apiPath := r.cfg.GetAPIBasePath()
+	var apiRoutes []gin.RouteInfo
+	for _, route := range r.engine.Routes() {
+		if !strings.HasPrefix(route.Path, apiPath+"/") || !strings.Contains(route.Path, r.opts.Route) {
+			continue
+		}
+		apiRoutes = append(apiRoutes, route)
+	}
+	sort.Slice(apiRoutes, func(i, j int) bool {
+		if apiRoutes[i].Path != apiRoutes[j].Path {
+			return apiRoutes[i].Path < apiRoutes[j].Path
+		}
+		return apiRoutes[i].Method < apiRoutes[j].Method
+	})
+	r.report.Routes = len(apiRoutes)
+
+	for _, route := range apiRoutes {
+		r.record(r.checkDocumented(CheckResult{Name: "documented", Method: route.Method, Path: route.Path, Caller: callerAnonymous}))
+		r.checkRoute(route, callerAnonymous)
+		if route.Method == http.MethodGet {
+			r.checkRoute(route, callerUser)
+			r.checkRoute(route, callerAdmin)
+		}
+	}
+
+	r.report.Passed = r.report.Summary[statusFail] == 0
+	r.report.DurationMs = time.Since(started).Milliseconds()
+}
+
+func (r *runner) checkRoute(route gin.RouteInfo, caller string) {
+	recorder, requestID := r.call(route, caller)
+	result := func(name string) CheckResult {
+		return CheckResult{Name: name, Method: route.Method, Path: route.Path, Caller: caller, HTTPStatus: recorder.Code}
+	}
+
+	// Handlers streaming files or feeds answer successes in their own format,
+	// but their errors still go through the envelope
+	isJSON := strings.HasPrefix(recorder.Header().Get("Content-Type"), "application/json")
+	if !isJSON && recorder.Code < http.StatusBadRequest {
+		check := result("envelope")
+		check.Status = statusSkip
+		check.Detail = fmt.Sprintf("non-JSON response (%s)", recorder.Header().Get("Content-Type"))
+		r.record(check)
+	} else {
+		check := r.checkEnvelope(result("envelope"), recorder, isJSON, requestID)
+		r.record(check)
+		if check.Status == statusPass && route.Method == http.MethodGet && recorder.Code == http.StatusOK {
+			r.record(r.checkPagination(result("pagination"), recorder.Body.Bytes()))
+		}
+	}
+
+	if r.limited {
+		r.record(r.checkRateLimit(result("rate_limit"), recorder.Header()))
+	}
+	r.record(r.checkRequestID(result("request_id"), recorder.Header(), requestID))
+}
+
+// call sends one request with path parameters filled in with random IDs, and
+// returns the request ID it was sent with
+func (r *runner) call(route gin.RouteInfo, caller string) (*httptest.ResponseRecorder, string) {
+	var body io.Reader
+	if route.Method != http.MethodGet && route.Method != http.MethodDelete {
+		body = bytes.NewBufferString("{}")
+	}
+
+	req := httptest.NewRequest(route.Method, fillPath(route.Path), body)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token, ok := r.tokens[caller]; ok {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	requestID := "contract-" + uuid.New().String()
+	req.Header.Set("X-Request-ID", requestID)
+
+	recorder := httptest.NewRecorder()
+	r.engine.ServeHTTP(recorder, req)
+	return recorder, requestID
+}
+
+func (r *runner) checkEnvelope(check CheckResult, recorder *httptest.ResponseRecorder, isJSON bool, requestID string) CheckResult {
+	if !isJSON {
+		return fail(check, fmt.Sprintf("error response is %q, not a JSON envelope", recorder.Header().Get("Content-Type")))
+	}
+
+	var envelope apiResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &envelope); err != nil {
+		return fail(check, fmt.Sprintf("body is not a JSON object: %v", err))
+	}
+
+	switch {
+	case envelope.Status == nil || envelope.StatusCode == nil || envelope.Message == nil:
+		return fail(check, "missing status, status_code or message")
+	case *envelope.StatusCode != recorder.Code:
+		return fail(check, fmt.Sprintf("status_code %d does not match HTTP status %d", *envelope.StatusCode, recorder.Code))
+	case recorder.Code >= http.StatusBadRequest && *envelope.Status != "error":
+		return fail(check, fmt.Sprintf("status %q on an error response, want \"error\"", *envelope.Status))
+	case recorder.Code < http.StatusBadRequest && *envelope.Status != "success":
+		return fail(check, fmt.Sprintf("status %q on a successful response, want \"success\"", *envelope.Status))
+	case strings.TrimSpace(*envelope.Message) == "":
+		return fail(check, "message is empty")
+	case recorder.Code >= http.StatusBadRequest && (envelope.ErrorCode == nil || *envelope.ErrorCode == ""):
+		return fail(check, "error response has no error_code")
+	case recorder.Code < http.StatusBadRequest && envelope.ErrorCode != nil:
+		return fail(check, fmt.Sprintf("error_code %q on a successful response", *envelope.ErrorCode))
+	case envelope.RequestID == nil || *envelope.RequestID != requestID:
+		return fail(check, "request_id does not echo X-Request-ID")
+	}
+
+	check.Status = statusPass
+	return check
+}
+
+// checkPagination looks for paged lists in the data of a successful response.
+// A response with any pagination field must have all of them and one item array,
+// and the envelope pagination must agree with them. An envelope pagination on
+// its own, for data that is a bare list, must be complete.
+func (r *runner) checkPagination(check CheckResult, body []byte) CheckResult {
+	var envelope apiResponse
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return fail(check, fmt.Sprintf("body is not a JSON object: %v", err))
+	}
+
+	var meta map[string]float64
+	if len(envelope.Pagination) > 0 {
+		if err := json.Unmarshal(envelope.Pagination, &meta); err != nil {
+			return fail(check, fmt.Sprintf("pagination is not an object of numbers: %v", err))
+		}
+		if missing := missingFields(meta); len(missing) > 0 {
+			return fail(check, fmt.Sprintf("pagination is missing %s", strings.Join(missing, ", ")))
+		}
+	}
+
+	var data map[string]json.RawMessage
+	if len(envelope.Data) == 0 || json.Unmarshal(envelope.Data, &data) != nil {
+		if meta != nil {
+			check.Status = statusPass
+			return check
+		}
+		check.Status = statusSkip
+		check.Detail = "data is not an object"
+		return check
+	}
+
+	fields := map[string]float64{}
+	for _, field := range paginationFields {
+		raw, ok := data[field]
+		if !ok {
+			continue
+		}
+		var number float64
+		if err := json.Unmarshal(raw, &number); err != nil {
+			return fail(check, fmt.Sprintf("%s is not a number", field))
+		}
+		fields[field] = number
+	}
+	if len(fields) == 0 {
+		if meta != nil {
+			check.Status = statusPass
+			return check
+		}
+		check.Status = statusSkip
+		check.Detail = "not a paged list"
+		return check
+	}
+	if missing := missingFields(fields); len(missing) > 0 {
+		return fail(check, fmt.Sprintf("paged list is missing %s", strings.Join(missing, ", ")))
+	}
+	if meta == nil {
+		return fail(check, "paged list has no envelope pagination")
+	}
+	for _, field := range paginationFields {
+		if meta[field] != fields[field] {
+			return fail(check, fmt.Sprintf("pagination %s %v does not match data %v", field, meta[field], fields[field]))
+		}
+	}
+
+	arrays := 0
+	for field, raw := range data {
+		var items []json.RawMessage
+		if json.Unmarshal(raw, &items) == nil && !isPaginationField(field) {
+			arrays++
+		}
+	}
+	if arrays != 1 {
+		return fail(check, fmt.Sprintf("paged list has %d item arrays, want 1", arrays))
+	}
+
+	check.Status = statusPass
+	return check
+}
+
+func (r *runner) checkRateLimit(check CheckResult, headers http.Header) CheckResult {
+	var missing []string
+	for _, header := range rateLimitHeaders {
+		if headers.Get(header) == "" {
+			missing = append(missing, header)
+		}
+	}
+	if len(missing) > 0 {
+		return fail(check, fmt.Sprintf("missing %s", strings.Join(missing, ", ")))
+	}
+
+	check.Status = statusPass
+	return check
+}
+
+func (r *runner) checkRequestID(check CheckResult, headers http.Header, requestID string) CheckResult {
+	if got := headers.Get("X-Request-ID"); got != requestID {
+		return fail(check, fmt.Sprintf("X-Request-ID header is %q, want %q", got, requestID))
+	}
+
+	check.Status = statusPass
+	return check
+}
+
+func (r *runner) checkDocumented(check CheckResult) CheckResult {
+	if r.specErr != nil {
+		return fail(check, r.specErr.Error())
+	}
+	if !r.documented[check.Method+" "+check.Path] {
+		return fail(check, fmt.Sprintf("no operation in %s, annotate the handler and run make docs", r.opts.Spec))
+	}
+
+	check.Status = statusPass
+	return check
+}
+
+// loadSpec reads the operations of a Swagger spec, with their paths in gin form
+func loadSpec(path string) (map[string]bool, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the spec: %w", err)
+	}
+
+	var doc struct {
+		Paths map[string]map[string]interface{} `yaml:"paths"`
+	}
+	if err := yaml.Unmarshal(raw, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse the spec: %w", err)
+	}
+
+	documented := map[string]bool{}
+	for specPath, item := range doc.Paths {
+		for method := range item {
+			documented[strings.ToUpper(method)+" "+ginPath(specPath)] = true
+		}
+	}
+	return documented, nil
+}
+
+func (r *runner) record(check CheckResult) {
+	r.report.Summary[check.Status]++
+	r.report.Checks = append(r.report.Checks, check)
+}
+
+func fail(check CheckResult, detail string) CheckResult {
+	check.Status = statusFail
+	check.Detail = detail
+	return check
+}
+
+// fillPath replaces path parameters with a random UUID, and wildcards with a file name
+func fillPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		switch {
+		case strings.HasPrefix(segment, ":"):
+			segments[i] = uuid.New().String()
+		case strings.HasPrefix(segment, "*"):
+			segments[i] = "contract.txt"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// ginPath turns a spec path such as /api/v1/events/{eventId} into /api/v1/events/:eventId
+func ginPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			segments[i] = ":" + strings.Trim(segment, "{}")
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func missingFields(values map[string]float64) []string {
+	var missing []string
+	for _, field := range paginationFields {
+		if _, ok := values[field]; !ok {
+			missing = append(missing, field)
+		}
+	}
+	return missing
+}
+
+func isPaginationField(field string) bool {
+	for _, name := range paginationFields {
+		if field == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"evently/internal/shared/config"
+	"evently/internal/shared/migrate"
+	"evently/migrations"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+// Applies the versioned SQL migrations in migrations/ to the configured
+// database. The server checks the schema version at startup and refuses to run
+// against any other than the latest migration here.
+//
+//	migrate up          apply every pending migration
+//	migrate down [n]    revert the last n migrations (default 1)
+//	migrate status      list migrations and whether each is applied
+//	migrate force <v>   set the version without running anything, e.g. `force 1`
+//	                    to adopt a database created by GORM AutoMigrate
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: migrate [-timeout 5m] up | down [n] | status | force <version>")
+	flag.PrintDefaults()
+}
+
+func main() {
+	timeout := flag.Duration("timeout", 5*time.Minute, "time limit for the whole command")
+	flag.Usage = usage
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		usage()
+		os.Exit(2)
+	}
+
+	cfg := config.Load()
+	db, err := sql.Open("pgx", cfg.Database.DSN)
+	if err != nil {
+		fail(err)
+	}
+	defer db.Close()
+
+	migrator, err := migrate.New(db, migrations.FS)
+	if err != nil {
+		fail(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	switch command := flag.Arg(0); command {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			fail(err)
+		}
+		fmt.Printf("✅ Applied %d migrations, schema at version %d\n", applied, migrator.Expected())
+
+	case "down":
+		steps := 1
+		if flag.NArg() > 1 {
+			if steps, err = strconv.Atoi(flag.Arg(1)); err != nil || steps < 1 {
+				fail(fmt.Errorf("invalid step count %q", flag.Arg(1)))
+			}
+		}
+		reverted, err := migrator.Down(ctx, steps)
+		if err != nil {
+			fail(err)
+		}
+		fmt.Printf("✅ Reverted %d migrations\n", reverted)
+
+	case "status":
+		printStatus(ctx, migrator)
+
+	case "force":
+		if flag.NArg() < 2 {
+			usage()
+			os.Exit(2)
+		}
+		version, err := strconv.ParseUint(flag.Arg(1), 10, 64)
+		if err != nil {
+			fail(fmt.Errorf("invalid version %q", flag.Arg(1)))
+		}
+		if err := migrator.Force(ctx, uint(version)); err != nil {
+			fail(err)
+		}
+		fmt.Printf("✅ Schema version set to %d\n", version)
+
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n", command)
+		usage()
+		os.Exit(2)
+	}
+}
+
+func printStatus(ctx context.Context, migrator *migrate.Migrator) {
+	version, dirty, err := migrator.Version(ctx)
+	if err != nil {
+		fail(err)
+	}
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		fail(err)
+	}
+
+	fmt.Printf("Schema version: %d (expected %d)", version, migrator.Expected())
+	if dirty {
+		fmt.Print(" DIRTY")
+	}
+	fmt.Println()
+
+	for _, status := range statuses {
+		mark := "pending"
+		if status.Applied {
+			mark = "applied"
+		}
+		fmt.Printf("  %06d  %-8s %s\n", status.Version, mark, status.Name)
+	}
+
+	if err := migrator.Validate(ctx); err != nil {
+		fmt.Println()
+		fail(err)
+	}
+}
+
+func fail(err error) {
+	fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+	os.Exit(1)
+}
@@ -0,0 +1,708 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"evently/internal/bookings"
+	"evently/internal/events"
+	"evently/internal/seats"
+	"evently/internal/tags"
+	"evently/internal/users"
+	"evently/internal/venues"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
+)
+
+// LoadTestOptions sizes the randomized dataset generated on top of the fixed
+// seed data. The same seed always generates the same names, prices, dates and
+// popularity, so load test runs can be compared.
+type LoadTestOptions struct {
+	Users    int
+	Events   int
+	Bookings int
+	Seed     int64
+}
+
+// Enabled reports whether any load test data was asked for
+func (o LoadTestOptions) Enabled() bool {
+	return o.Users > 0 || o.Events > 0 || o.Bookings > 0
+}
+
+const (
+	loadTestBatchSize    = 1000
+	loadTestSeatsPerRow  = 50
+	loadTestMaxSeats     = 4                      // Largest party size a booking is generated with
+	loadTestMaxArenaSize = 200000                 // Seats, bookings past what fits are dropped
+	loadTestHistory      = 365 * 24 * time.Hour   // Events start up to a year back
+	loadTestHorizon      = 90 * 24 * time.Hour    // and up to three months ahead
+	loadTestCancelRate   = 0.07                   // Share of bookings that were later cancelled
+	loadTestPopularity   = 1.1                    // Zipf exponent of event popularity
+	loadTestBusiestShare = 8                      // The busiest event sells up to this many times the average
+	loadTestPassword     = "qwerty"               // Password of every generated user
+	loadTestEmailDomain  = "loadtest.evently.dev" // Generated users never collide with the fixed seed users
+)
+
+var (
+	loadTestFirstNames = []string{
+		"Aarav", "Aditi", "Ananya", "Arjun", "Diya", "Isha", "Kabir", "Kavya", "Meera", "Neha",
+		"Nikhil", "Priya", "Rahul", "Riya", "Rohan", "Saanvi", "Sameer", "Tara", "Vihaan", "Zoya",
+		"Alex", "Chris", "Emma", "Liam", "Maya", "Noah", "Olivia", "Sam", "Sofia", "Yusuf",
+	}
+	loadTestLastNames = []string{
+		"Agarwal", "Bhatt", "Chopra", "Desai", "Gupta", "Iyer", "Joshi", "Kapoor", "Khan", "Mehta",
+		"Nair", "Patel", "Rao", "Reddy", "Shah", "Sharma", "Singh", "Verma", "Brown", "Garcia",
+		"Lee", "Martin", "Nguyen", "Smith", "Taylor", "Wilson",
+	}
+	loadTestEventAdjectives = []string{
+		"Annual", "Midnight", "Summer", "Winter", "Grand", "Indie", "Global", "City", "Open-Air", "Unplugged",
+		"Future", "Classic", "Sunset", "Electric", "Heritage",
+	}
+	loadTestEventThemes = []string{
+		"Jazz", "Rock", "Comedy", "Startup", "AI", "Cloud", "Design", "Food", "Wine", "Cricket",
+		"Football", "Poetry", "Film", "Fashion", "Photography", "Theatre", "Dance", "Product", "Data", "Gaming",
+	}
+	loadTestEventKinds = []string{
+		"Festival", "Summit", "Night", "Conference", "Showcase", "Meetup", "Live", "Expo", "Workshop", "Gala",
+	}
+	loadTestVenues = []string{
+		"Convention Centre", "Arena", "Amphitheatre", "Exhibition Hall", "Stadium", "Opera House",
+		"Civic Centre", "Auditorium", "Expo Grounds", "Club House",
+	}
+	loadTestCities = []string{
+		"Mumbai", "Delhi", "Bengaluru", "Hyderabad", "Chennai", "Pune", "Kolkata", "Ahmedabad", "Jaipur", "Goa",
+	}
+	loadTestPaymentMethods = []string{"card", "card", "card", "upi", "upi", "netbanking", "wallet"}
+)
+
+// loadTestSection is one section of the generated arena
+type loadTestSection struct {
+	section    venues.VenueSection
+	multiplier float64
+	seatIDs    []uuid.UUID
+}
+
+// loadTestEvent is what booking generation needs to know about an event
+type loadTestEvent struct {
+	id        uuid.UUID
+	basePrice float64
+	createdAt time.Time
+	dateTime  time.Time
+}
+
+type loadTestSeeder struct {
+	db   *gorm.DB
+	opts LoadTestOptions
+	rng  *rand.Rand
+	now  time.Time
+}
+
+// SeedLoadTest generates users, events and historical bookings for load
+// testing. Events are held in one generated arena sized so the most popular
+// event's bookings fit, and booked seats never overlap within an event.
+func (s *Seeder) SeedLoadTest(opts LoadTestOptions, adminID uuid.UUID, tagIDs []uuid.UUID) error {
+	fmt.Printf("  🧪 Generating load test data (users=%d, events=%d, bookings=%d, seed=%d)...\n",
+		opts.Users, opts.Events, opts.Bookings, opts.Seed)
+
+	g := &loadTestSeeder{
+		// Per-row logging would drown the progress output
+		db:   s.pg.Session(&gorm.Session{Logger: logger.Default.LogMode(logger.Silent)}),
+		opts: opts,
+		rng:  rand.New(rand.NewSource(opts.Seed)),
+		now:  time.Now().UTC(),
+	}
+
+	userIDs, err := g.seedUsers()
+	if err != nil {
+		return fmt.Errorf("failed to generate users: %w", err)
+	}
+	if len(userIDs) == 0 {
+		// Book as previously generated users, or the seed users when there are none
+		if err := g.db.Model(&users.User{}).Where("email LIKE ?", "%@"+loadTestEmailDomain).Pluck("id", &userIDs).Error; err != nil {
+			return fmt.Errorf("failed to load users: %w", err)
+		}
+	}
+	if len(userIDs) == 0 {
+		if err := g.db.Model(&users.User{}).Where("role = ?", users.RoleUser).Pluck("id", &userIDs).Error; err != nil {
+			return fmt.Errorf("failed to load users: %w", err)
+		}
+	}
+
+	if opts.Events == 0 {
+		if opts.Bookings > 0 {
+			fmt.Println("    ⚠️ Bookings are only generated for load test events, pass --events too")
+		}
+		return nil
+	}
+	if adminID == uuid.Nil {
+		return errMissingAdmin
+	}
+
+	perEvent := g.allocateBookings()
+	busiest := 0
+	for _, count := range perEvent {
+		busiest = max(busiest, count)
+	}
+
+	sections, err := g.seedArena(busiest * loadTestMaxSeats)
+	if err != nil {
+		return fmt.Errorf("failed to generate arena: %w", err)
+	}
+
+	generated, err := g.seedEvents(adminID, tagIDs, sections[0].section.TemplateID, sections)
+	if err != nil {
+		return fmt.Errorf("failed to generate events: %w", err)
+	}
+
+	if opts.Bookings == 0 || len(userIDs) == 0 {
+		return nil
+	}
+	return g.seedBookings(generated, perEvent, userIDs, sections)
+}
+
+// seedUsers creates regular users with generated names. They all share one
+// password, hashed once, since hashing per user would dominate the run.
+func (g *loadTestSeeder) seedUsers() ([]uuid.UUID, error) {
+	if g.opts.Users == 0 {
+		return nil, nil
+	}
+	fmt.Printf("    👤 Generating %d users...\n", g.opts.Users)
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(loadTestPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	// Appended users are numbered after the ones already generated
+	var generatedBefore int64
+	if err := g.db.Unscoped().Model(&users.User{}).Where("email LIKE ?", "%@"+loadTestEmailDomain).Count(&generatedBefore).Error; err != nil {
+		return nil, err
+	}
+
+	userIDs := make([]uuid.UUID, 0, g.opts.Users)
+	batch := make([]users.User, 0, loadTestBatchSize)
+	for i := int(generatedBefore); i < int(generatedBefore)+g.opts.Users; i++ {
+		firstName := pick(g.rng, loadTestFirstNames)
+		lastName := pick(g.rng, loadTestLastNames)
+		joinedAt := g.now.Add(-time.Duration(g.rng.Int63n(int64(loadTestHistory + loadTestHistory/2))))
+
+		user := users.User{
+			ID:        uuid.New(),
+			FirstName: firstName,
+			LastName:  lastName,
+			Email:     fmt.Sprintf("%s.%s.%d@%s", strings.ToLower(firstName), strings.ToLower(lastName), i+1, loadTestEmailDomain),
+			Password:  string(hashedPassword),
+			Role:      users.RoleUser,
+			CreatedAt: joinedAt,
+			UpdatedAt: joinedAt,
+		}
+		userIDs = append(userIDs, user.ID)
+		batch = append(batch, user)
+
+		if len(batch) == loadTestBatchSize {
+			if err := g.db.Create(&batch).Error; err != nil {
+				return nil, err
+			}
+			batch = batch[:0]
+		}
+	}
+	if len(batch) > 0 {
+		if err := g.db.Create(&batch).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	fmt.Printf("    ✅ Generated %d users (password: %s)\n", len(userIDs), loadTestPassword)
+	return userIDs, nil
+}
+
+// allocateBookings splits the bookings across events by a Zipf-like
+// popularity: a few events sell out while most sell modestly. No event gets
+// more than loadTestBusiestShare times the average, the excess going to the
+// next most popular events.
+func (g *loadTestSeeder) allocateBookings() []int {
+	count := g.opts.Events
+	perEvent := make([]int, count)
+	if g.opts.Bookings == 0 {
+		return perEvent
+	}
+
+	ranks := g.rng.Perm(count)
+	weights := make([]float64, count)
+	total := 0.0
+	for i, rank := range ranks {
+		weights[i] = 1 / math.Pow(float64(rank+1), loadTestPopularity)
+		total += weights[i]
+	}
+
+	limit := int(math.Ceil(float64(g.opts.Bookings) / float64(count) * loadTestBusiestShare))
+	limit = min(limit, loadTestMaxArenaSize/loadTestMaxSeats)
+
+	assigned := 0
+	for i := range perEvent {
+		perEvent[i] = min(int(float64(g.opts.Bookings)*weights[i]/total), limit)
+		assigned += perEvent[i]
+	}
+
+	// Hand out what rounding and the cap left over, most popular first
+	order := make([]int, count)
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(a, b int) bool { return ranks[order[a]] < ranks[order[b]] })
+
+	for remaining := g.opts.Bookings - assigned; remaining > 0; {
+		progressed := false
+		for _, i := range order {
+			if remaining == 0 {
+				break
+			}
+			room := min(limit-perEvent[i], remaining)
+			if room <= 0 {
+				continue
+			}
+			perEvent[i] += room
+			remaining -= room
+			progressed = true
+		}
+		if !progressed {
+			fmt.Printf("    ⚠️ Only %d bookings fit in %d events, %d dropped\n", g.opts.Bookings-remaining, count, remaining)
+			break
+		}
+	}
+
+	return perEvent
+}
+
+// seedArena creates the venue template every generated event is held in, with
+// VIP, lower bowl and floor sections
+func (g *loadTestSeeder) seedArena(minSeats int) ([]loadTestSection, error) {
+	rows := max(20, (minSeats+loadTestSeatsPerRow-1)/loadTestSeatsPerRow)
+	fmt.Printf("    🏟️ Generating arena with %d seats...\n", rows*loadTestSeatsPerRow)
+
+	template := venues.VenueTemplate{
+		ID:                 uuid.New(),
+		Name:               fmt.Sprintf("Load Test Arena %d (%s)", g.opts.Seed, g.now.Format("2006-01-02 15:04:05")), // Unique across appended runs
+		Description:        "Generated arena for load testing",
+		DefaultRows:        rows,
+		DefaultSeatsPerRow: loadTestSeatsPerRow,
+		LayoutType:         "STADIUM",
+		CreatedAt:          g.now,
+		UpdatedAt:          g.now,
+	}
+	template.LineageID, template.Version = template.ID, 1
+	if err := g.db.Create(&template).Error; err != nil {
+		return nil, err
+	}
+
+	// VIP takes the first tenth of the rows, the lower bowl the next third
+	vipRows := max(1, rows/10)
+	lowerRows := max(1, rows*3/10)
+	layout := []struct {
+		name       string
+		rows       int
+		multiplier float64
+	}{
+		{"VIP", vipRows, 3.0},
+		{"Lower Bowl", lowerRows, 1.5},
+		{"Floor", rows - vipRows - lowerRows, 1.0},
+	}
+
+	sections := make([]loadTestSection, 0, len(layout))
+	firstRow := 0
+	for _, part := range layout {
+		section := venues.VenueSection{
+			ID:          uuid.New(),
+			TemplateID:  template.ID,
+			Name:        part.name,
+			Description: fmt.Sprintf("%s seating", part.name),
+			RowStart:    rowLabel(firstRow),
+			RowEnd:      rowLabel(firstRow + part.rows - 1),
+			SeatsPerRow: loadTestSeatsPerRow,
+			TotalSeats:  part.rows * loadTestSeatsPerRow,
+			CreatedAt:   g.now,
+			UpdatedAt:   g.now,
+		}
+		if err := g.db.Create(&section).Error; err != nil {
+			return nil, err
+		}
+
+		seatIDs, err := g.seedSeats(section.ID, firstRow, part.rows)
+		if err != nil {
+			return nil, err
+		}
+		sections = append(sections, loadTestSection{section: section, multiplier: part.multiplier, seatIDs: seatIDs})
+		firstRow += part.rows
+	}
+
+	return sections, nil
+}
+
+func (g *loadTestSeeder) seedSeats(sectionID uuid.UUID, firstRow, rows int) ([]uuid.UUID, error) {
+	seatIDs := make([]uuid.UUID, 0, rows*loadTestSeatsPerRow)
+	batch := make([]seats.Seat, 0, loadTestBatchSize)
+
+	for r := firstRow; r < firstRow+rows; r++ {
+		row := rowLabel(r)
+		for position := 1; position <= loadTestSeatsPerRow; position++ {
+			seat := seats.Seat{
+				ID:         uuid.New(),
+				SectionID:  sectionID,
+				SeatNumber: fmt.Sprintf("%s%d", row, position),
+				Row:        row,
+				Position:   position,
+				Status:     "AVAILABLE",
+				Aisle:      position == 1 || position == loadTestSeatsPerRow,
+				CreatedAt:  g.now,
+				UpdatedAt:  g.now,
+			}
+			seatIDs = append(seatIDs, seat.ID)
+			batch = append(batch, seat)
+
+			if len(batch) == loadTestBatchSize {
+				if err := g.db.Create(&batch).Error; err != nil {
+					return nil, err
+				}
+				batch = batch[:0]
+			}
+		}
+	}
+	if len(batch) > 0 {
+		if err := g.db.Create(&batch).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	return seatIDs, nil
+}
+
+// seedEvents creates events spread over the past year and the next three
+// months, with tags and arena pricing. Past events are completed.
+func (g *loadTestSeeder) seedEvents(adminID uuid.UUID, tagIDs []uuid.UUID, templateID uuid.UUID, sections []loadTestSection) ([]loadTestEvent, error) {
+	fmt.Printf("    🎪 Generating %d events...\n", g.opts.Events)
+
+	generated := make([]loadTestEvent, 0, g.opts.Events)
+	eventBatch := make([]events.Event, 0, loadTestBatchSize)
+	var tagBatch []tags.EventTag
+	var pricingBatch []venues.EventPricing
+
+	flush := func() error {
+		if len(eventBatch) > 0 {
+			if err := g.db.Omit(clause.Associations).Create(&eventBatch).Error; err != nil {
+				return err
+			}
+		}
+		if len(tagBatch) > 0 {
+			if err := g.db.CreateInBatches(&tagBatch, loadTestBatchSize).Error; err != nil {
+				return err
+			}
+		}
+		if len(pricingBatch) > 0 {
+			if err := g.db.CreateInBatches(&pricingBatch, loadTestBatchSize).Error; err != nil {
+				return err
+			}
+		}
+		eventBatch, tagBatch, pricingBatch = eventBatch[:0], tagBatch[:0], pricingBatch[:0]
+		return nil
+	}
+
+	for i := 0; i < g.opts.Events; i++ {
+		offset := time.Duration(g.rng.Int63n(int64(loadTestHistory+loadTestHorizon))) - loadTestHistory
+		dateTime := g.now.Add(offset).Truncate(time.Hour)
+		// Announced one to four months ahead
+		createdAt := dateTime.Add(-time.Duration(30+g.rng.Intn(90)) * 24 * time.Hour)
+		if createdAt.After(g.now) {
+			createdAt = g.now
+		}
+
+		status := events.EventStatusPublished
+		if dateTime.Before(g.now) {
+			status = events.EventStatusCompleted
+		}
+
+		event := events.Event{
+			ID: uuid.New(),
+			Name: fmt.Sprintf("%s %s %s %d", pick(g.rng, loadTestEventAdjectives), pick(g.rng, loadTestEventThemes),
+				pick(g.rng, loadTestEventKinds), dateTime.Year()),
+			Description:     "Generated event for load testing.",
+			Venue:           fmt.Sprintf("%s %s", pick(g.rng, loadTestCities), pick(g.rng, loadTestVenues)),
+			VenueTemplateID: templateID,
+			DateTime:        dateTime,
+			BasePrice:       float64(300 + 50*g.rng.Intn(95)), // 300 to 5000 INR
+			Status:          status,
+			CreatedBy:       adminID,
+			CreatedAt:       createdAt,
+			UpdatedAt:       createdAt,
+		}
+		eventBatch = append(eventBatch, event)
+		generated = append(generated, loadTestEvent{id: event.ID, basePrice: event.BasePrice, createdAt: createdAt, dateTime: dateTime})
+
+		if len(tagIDs) > 0 {
+			for _, tagIndex := range g.rng.Perm(len(tagIDs))[:min(len(tagIDs), 1+g.rng.Intn(3))] {
+				tagBatch = append(tagBatch, tags.EventTag{ID: uuid.New(), EventID: event.ID, TagID: tagIDs[tagIndex], CreatedAt: createdAt})
+			}
+		}
+		for _, section := range sections {
+			pricingBatch = append(pricingBatch, venues.EventPricing{
+				ID:              uuid.New(),
+				EventID:         event.ID,
+				SectionID:       section.section.ID,
+				PriceMultiplier: section.multiplier,
+				IsActive:        true,
+				CreatedAt:       createdAt,
+				UpdatedAt:       createdAt,
+			})
+		}
+
+		if len(eventBatch) == loadTestBatchSize {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("    ✅ Generated %d events\n", len(generated))
+	return generated, nil
+}
+
+// seedBookings creates each event's bookings, with their seats and payment,
+// made between the event's announcement and its start (or now), busier closer
+// to the date. Cancelled bookings keep their refunded payment but, like real
+// cancellations, no longer hold seats.
+func (g *loadTestSeeder) seedBookings(generated []loadTestEvent, perEvent []int, userIDs []uuid.UUID, sections []loadTestSection) error {
+	fmt.Printf("    🎟️ Generating %d bookings...\n", g.opts.Bookings)
+
+	// Flat seat list, so a seat's section is found by index
+	type arenaSeat struct {
+		id      uuid.UUID
+		section *loadTestSection
+	}
+	var arena []arenaSeat
+	for i := range sections {
+		for _, seatID := range sections[i].seatIDs {
+			arena = append(arena, arenaSeat{id: seatID, section: &sections[i]})
+		}
+	}
+
+	bookingBatch := make([]bookings.Booking, 0, loadTestBatchSize)
+	var seatBatch []bookings.SeatBooking
+	var paymentBatch []bookings.Payment
+
+	// References count on from the bookings already there, so appended runs don't collide
+	var existing int64
+	if err := g.db.Model(&bookings.Booking{}).Count(&existing).Error; err != nil {
+		return err
+	}
+
+	created := 0
+	flush := func() error {
+		if len(bookingBatch) == 0 {
+			return nil
+		}
+		if err := g.db.Omit(clause.Associations).Create(&bookingBatch).Error; err != nil {
+			return err
+		}
+		if err := g.db.Omit(clause.Associations).CreateInBatches(&seatBatch, loadTestBatchSize).Error; err != nil {
+			return err
+		}
+		if err := g.db.Omit(clause.Associations).Create(&paymentBatch).Error; err != nil {
+			return err
+		}
+
+		created += len(bookingBatch)
+		if created%50000 < len(bookingBatch) {
+			fmt.Printf("      … %d bookings\n", created)
+		}
+		bookingBatch, seatBatch, paymentBatch = bookingBatch[:0], seatBatch[:0], paymentBatch[:0]
+		return nil
+	}
+
+	for i, event := range generated {
+		if perEvent[i] == 0 {
+			continue
+		}
+
+		// Sales run from the announcement to the start, or until now
+		salesEnd := event.dateTime
+		if salesEnd.After(g.now) {
+			salesEnd = g.now
+		}
+		window := salesEnd.Sub(event.createdAt)
+		if window <= 0 {
+			continue
+		}
+
+		seatOrder := g.rng.Perm(len(arena))
+		nextSeat := 0
+
+		for b := 0; b < perEvent[i]; b++ {
+			party := partySize(g.rng)
+			if nextSeat+party > len(seatOrder) {
+				break
+			}
+
+			// Square root skews bookings towards the event date
+			bookedAt := event.createdAt.Add(time.Duration(math.Sqrt(g.rng.Float64()) * float64(window)))
+			booking := bookings.Booking{
+				ID:           uuid.New(),
+				UserID:       pickUser(g.rng, userIDs),
+				EventID:      event.id,
+				TotalSeats:   party,
+				Status:       "CONFIRMED",
+				BookingRef:   loadTestBookingRef(bookedAt, int(existing)+created+len(bookingBatch)),
+				Version:      1,
+				Currency:     "INR",
+				ExchangeRate: 1,
+				CreatedAt:    bookedAt,
+				UpdatedAt:    bookedAt,
+			}
+
+			cancelled := g.rng.Float64() < loadTestCancelRate
+			for s := 0; s < party; s++ {
+				seat := arena[seatOrder[nextSeat+s]]
+				price := event.basePrice * seat.section.multiplier
+				booking.TotalPrice += price
+
+				if !cancelled {
+					seatBatch = append(seatBatch, bookings.SeatBooking{
+						ID:              uuid.New(),
+						BookingID:       booking.ID,
+						EventID:         event.id,
+						SeatID:          seat.id,
+						SectionID:       seat.section.section.ID,
+						SeatPrice:       price,
+						SectionName:     seat.section.section.Name,
+						BasePrice:       event.basePrice,
+						PriceMultiplier: seat.section.multiplier,
+						TotalPrice:      price,
+						CreatedAt:       bookedAt,
+					})
+				}
+			}
+			if !cancelled {
+				nextSeat += party
+			}
+			booking.BaseTotalPrice = booking.TotalPrice
+
+			processedAt := bookedAt
+			payment := bookings.Payment{
+				ID:            uuid.New(),
+				BookingID:     booking.ID,
+				Amount:        booking.TotalPrice,
+				Currency:      "INR",
+				Status:        "COMPLETED",
+				PaymentMethod: pick(g.rng, loadTestPaymentMethods),
+				TransactionID: fmt.Sprintf("TXN_LT_%d_%s", g.opts.Seed, strings.ToUpper(strings.ReplaceAll(booking.ID.String(), "-", "")[:12])),
+				ProcessedAt:   &processedAt,
+				Attempts:      1,
+				CreatedAt:     bookedAt,
+				UpdatedAt:     bookedAt,
+			}
+
+			if cancelled {
+				cancelledAt := bookedAt.Add(time.Duration(g.rng.Float64() * float64(salesEnd.Sub(bookedAt))))
+				booking.Status = "CANCELLED"
+				booking.CancelledAt = &cancelledAt
+				booking.UpdatedAt = cancelledAt
+				payment.Status = "REFUNDED"
+				payment.UpdatedAt = cancelledAt
+			}
+
+			bookingBatch = append(bookingBatch, booking)
+			paymentBatch = append(paymentBatch, payment)
+
+			if len(bookingBatch) == loadTestBatchSize {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+
+	fmt.Printf("    ✅ Generated %d bookings\n", created)
+	return nil
+}
+
+// partySize returns a booking's seat count: mostly one or two
+func partySize(rng *rand.Rand) int {
+	switch roll := rng.Float64(); {
+	case roll < 0.40:
+		return 1
+	case roll < 0.75:
+		return 2
+	case roll < 0.90:
+		return 3
+	default:
+		return loadTestMaxSeats
+	}
+}
+
+// pickUser favours a minority of frequent bookers over the long tail
+func pickUser(rng *rand.Rand, userIDs []uuid.UUID) uuid.UUID {
+	u := rng.Float64()
+	return userIDs[int(u*u*float64(len(userIDs)))]
+}
+
+func pick(rng *rand.Rand, values []string) string {
+	return values[rng.Intn(len(values))]
+}
+
+// rowLabel names rows A to Z, then AA, AB and so on
+func rowLabel(index int) string {
+	label := ""
+	for index >= 0 {
+		label = string(rune('A'+index%26)) + label
+		index = index/26 - 1
+	}
+	return label
+}
+
+// loadTestBookingRef follows the EVT-<date>-<letters> format of real booking
+// references, with the letters counting up so they never collide
+func loadTestBookingRef(bookedAt time.Time, sequence int) string {
+	letters := make([]byte, 6)
+	for i := len(letters) - 1; i >= 0; i-- {
+		letters[i] = byte('A' + sequence%26)
+		sequence /= 26
+	}
+	return fmt.Sprintf("EVT-%s-%s", bookedAt.Format("20060102"), letters)
+}
+
+// countFlag is an integer flag that accepts k and M suffixes, e.g. --bookings=1M
+type countFlag int
+
+func (c *countFlag) String() string {
+	return strconv.Itoa(int(*c))
+}
+
+func (c *countFlag) Set(value string) error {
+	multiplier := 1
+	switch {
+	case strings.HasSuffix(value, "k"), strings.HasSuffix(value, "K"):
+		multiplier, value = 1000, value[:len(value)-1]
+	case strings.HasSuffix(value, "m"), strings.HasSuffix(value, "M"):
+		multiplier, value = 1000000, value[:len(value)-1]
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return fmt.Errorf("must be a non-negative count like 500, 10k or 1M")
+	}
+	*c = countFlag(n * multiplier)
+	return nil
+}
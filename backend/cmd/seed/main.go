@@ -2,8 +2,11 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"evently/internal/cancellation"
@@ -16,14 +19,54 @@ import (
 	"evently/internal/venues"
 
 	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
 	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
 )
 
+const (
+	ModeReset  = "reset"  // Truncate every table, then seed everything
+	ModeAppend = "append" // Keep existing data and add the seed records that are missing
+)
+
+// Seed data groups --only chooses from, in dependency order
+var seedGroups = []string{"users", "tags", "venues", "events", "policies"}
+
+// Options controls what the seeder creates and whether it writes at all
+type Options struct {
+	Mode     string
+	Only     map[string]bool // Groups to create in append mode, nil for all
+	DryRun   bool            // Run everything in a transaction that is rolled back
+	LoadTest LoadTestOptions
+}
+
 type Seeder struct {
-	db *database.DB
+	db   *database.DB
+	pg   *gorm.DB // Where every query goes, a transaction during dry runs
+	opts Options
 }
 
+// errDryRun rolls back the dry run transaction
+var errDryRun = errors.New("dry run")
+
+var errMissingAdmin = errors.New("admin user admin@gmail.com not found, add users to --only")
+
 func main() {
+	var opts Options
+	var only string
+	flag.StringVar(&opts.Mode, "mode", ModeReset, "reset wipes every table before seeding, append only adds missing seed data")
+	flag.StringVar(&only, "only", "", "comma-separated groups to create in append mode: "+strings.Join(seedGroups, ","))
+	flag.BoolVar(&opts.DryRun, "dry-run", false, "print what would be created, then roll every change back")
+	flag.Var((*countFlag)(&opts.LoadTest.Users), "users", "load test users to generate on top of the seed data, e.g. 10k")
+	flag.Var((*countFlag)(&opts.LoadTest.Events), "events", "load test events to generate, e.g. 500")
+	flag.Var((*countFlag)(&opts.LoadTest.Bookings), "bookings", "historical bookings to generate across the load test events, e.g. 1M")
+	flag.Int64Var(&opts.LoadTest.Seed, "seed", 42, "random seed, the same seed generates the same dataset")
+	flag.Parse()
+
+	if err := opts.parseOnly(only); err != nil {
+		log.Fatalf("Invalid flags: %v", err)
+	}
+
 	fmt.Println("🌱 Starting Evently Database Seeder...")
 
 	// Load configuration
@@ -36,23 +79,112 @@ func main() {
 	}
 	defer db.Close()
 
-	seeder := &Seeder{db: db}
+	seeder := &Seeder{db: db, pg: db.PostgreSQL, opts: opts}
+	if err := seeder.Run(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// parseOnly validates the mode and the --only groups
+func (o *Options) parseOnly(only string) error {
+	if o.Mode != ModeReset && o.Mode != ModeAppend {
+		return fmt.Errorf("--mode must be %s or %s", ModeReset, ModeAppend)
+	}
+	if only == "" {
+		return nil
+	}
+	if o.Mode != ModeAppend {
+		return fmt.Errorf("--only needs --mode=append, a reset always seeds everything")
+	}
+
+	o.Only = make(map[string]bool)
+	for _, group := range strings.Split(only, ",") {
+		group = strings.TrimSpace(group)
+		known := false
+		for _, candidate := range seedGroups {
+			known = known || candidate == group
+		}
+		if !known {
+			return fmt.Errorf("unknown --only group %q, expected some of %s", group, strings.Join(seedGroups, ","))
+		}
+		o.Only[group] = true
+	}
+	return nil
+}
+
+// creates reports whether a seed data group is created in this run
+func (s *Seeder) creates(group string) bool {
+	return s.opts.Only == nil || s.opts.Only[group]
+}
+
+func (s *Seeder) appending() bool {
+	return s.opts.Mode == ModeAppend
+}
+
+// Run seeds the database. A dry run does the same work in a transaction and
+// rolls it back, so it prints exactly what a real run would create. A reset
+// dry run truncates inside that transaction too, which holds table locks until
+// it finishes.
+func (s *Seeder) Run() error {
+	if !s.opts.DryRun {
+		return s.run()
+	}
+
+	fmt.Println("🔍 Dry run: every change is rolled back at the end")
+	err := s.pg.Transaction(func(tx *gorm.DB) error {
+		s.pg = tx
+		if err := s.run(); err != nil {
+			return err
+		}
+		return errDryRun
+	})
+	if !errors.Is(err, errDryRun) {
+		return err
+	}
+
+	fmt.Println("\n🔍 Dry run complete, nothing was written.")
+	return nil
+}
 
-	// Clean database
-	fmt.Println("\n🧹 Cleaning database...")
-	if err := seeder.CleanDatabase(); err != nil {
-		log.Fatalf("Failed to clean database: %v", err)
+func (s *Seeder) run() error {
+	if s.appending() {
+		fmt.Println("\n➕ Append mode: existing data is kept")
+	} else {
+		// Clean database
+		fmt.Println("\n🧹 Cleaning database...")
+		if err := s.CleanDatabase(); err != nil {
+			return fmt.Errorf("failed to clean database: %w", err)
+		}
+		fmt.Println("✅ Database cleaned successfully")
 	}
-	fmt.Println("✅ Database cleaned successfully")
 
 	// Seed data
 	fmt.Println("\n🌱 Seeding database...")
-	if err := seeder.SeedAll(); err != nil {
-		log.Fatalf("Failed to seed database: %v", err)
+	if err := s.SeedAll(); err != nil {
+		return fmt.Errorf("failed to seed database: %w", err)
 	}
 	fmt.Println("✅ Database seeded successfully")
 
 	fmt.Println("\n🎉 Seeding completed! Database is ready for testing.")
+	return nil
+}
+
+// existingID looks up a seed record by the column that identifies it (email,
+// name, event) in append mode, returning uuid.Nil when there is none.
+// Soft-deleted rows count, as they still hold unique values.
+func (s *Seeder) existingID(model interface{}, column string, value interface{}) (uuid.UUID, error) {
+	if !s.appending() {
+		return uuid.Nil, nil
+	}
+
+	var ids []uuid.UUID
+	if err := s.pg.Unscoped().Model(model).Where(column+" = ?", value).Limit(1).Pluck("id", &ids).Error; err != nil {
+		return uuid.Nil, err
+	}
+	if len(ids) == 0 {
+		return uuid.Nil, nil
+	}
+	return ids[0], nil
 }
 
 // CleanDatabase truncates all tables in the correct order (respecting foreign key constraints)
@@ -60,55 +192,98 @@ func (s *Seeder) CleanDatabase() error {
 	// Order matters due to foreign key constraints
 	// Delete in reverse dependency order
 	tables := []string{
+		"outbox_messages",
+		"notification_preferences",
+		"in_app_notifications",
+		"notification_template_versions",
+		"event_reminders",
+		"yearly_recap_subscriptions",
+		"analytics_report_subscriptions",
+		"analytics_share_links",
+		"daily_bookings_agg",
+		"event_revenue_agg",
+		"tag_popularity_agg",
+		"organizer_brandings",
+		"event_reviews",
+		"event_favorites",
+		"api_key_usage",
+		"api_keys",
+		"webhook_delivery_attempts",
+		"webhook_deliveries",
+		"webhook_events",
+		"webhook_endpoints",
+		"event_capacity_alerts",
+		"event_capacity_alert_settings",
+		"archived_bookings",
+		"archived_events",
+		"event_change_batches",
+		"document_archives",
+		"jobs",
+		"support_messages",
+		"support_tickets",
 		"waitlist_notifications",
 		"waitlist_analytics",
 		"waitlist_entries",
 		"cancellations",
 		"cancellation_policies",
+		"resale_sales",
+		"resale_listings",
+		"booking_ownership_changes",
+		"booking_transfers",
+		"booking_sagas",
+		"booking_charges",
 		"payments",
 		"seat_bookings",
+		"ticket_bookings",
 		"bookings",
+		"ticket_types",
+		"event_seat_blocks",
+		"venue_conflict_overrides",
 		"event_pricing",
 		"event_tags",
+		"event_series",
 		"seats",
+		"seat_booking_rules",
 		"venue_sections",
 		"venue_templates",
+		"physical_venues",
 		"events",
+		"user_preferred_tags",
 		"tags",
+		"email_changes",
+		"impersonation_actions",
+		"impersonation_sessions",
+		"user_roles",
+		"roles",
+		"user_recovery_codes",
+		"user_two_factors",
 		"users",
 	}
 
-	tx := s.db.PostgreSQL.Begin()
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
+	// Nested in the dry run transaction as a savepoint
+	return s.pg.Transaction(func(tx *gorm.DB) error {
+		// Disable foreign key constraints temporarily
+		if err := tx.Exec("SET CONSTRAINTS ALL DEFERRED").Error; err != nil {
+			return fmt.Errorf("failed to defer constraints: %w", err)
 		}
-	}()
-
-	// Disable foreign key constraints temporarily
-	if err := tx.Exec("SET CONSTRAINTS ALL DEFERRED").Error; err != nil {
-		tx.Rollback()
-		return fmt.Errorf("failed to defer constraints: %w", err)
-	}
 
-	for _, table := range tables {
-		fmt.Printf("  Truncating table: %s\n", table)
-		if err := tx.Exec(fmt.Sprintf("TRUNCATE TABLE %s RESTART IDENTITY CASCADE", table)).Error; err != nil {
-			tx.Rollback()
-			return fmt.Errorf("failed to truncate table %s: %w", table, err)
+		for _, table := range tables {
+			fmt.Printf("  Truncating table: %s\n", table)
+			if err := tx.Exec(fmt.Sprintf("TRUNCATE TABLE %s RESTART IDENTITY CASCADE", table)).Error; err != nil {
+				return fmt.Errorf("failed to truncate table %s: %w", table, err)
+			}
 		}
-	}
-
-	// Re-enable foreign key constraints
-	if err := tx.Exec("SET CONSTRAINTS ALL IMMEDIATE").Error; err != nil {
-		tx.Rollback()
-		return fmt.Errorf("failed to restore constraints: %w", err)
-	}
 
-	return tx.Commit().Error
+		// Re-enable foreign key constraints
+		if err := tx.Exec("SET CONSTRAINTS ALL IMMEDIATE").Error; err != nil {
+			return fmt.Errorf("failed to restore constraints: %w", err)
+		}
+		return nil
+	})
 }
 
-// SeedAll seeds all required data
+// SeedAll seeds the selected data, looking up the records it depends on when
+// they are left out by --only, then the load test dataset if one was asked for
 func (s *Seeder) SeedAll() error {
 	ctx := context.Background()
 
@@ -137,13 +312,32 @@ func (s *Seeder) SeedAll() error {
 	}
 
 	// Seed cancellation policies
-	if err := s.SeedCancellationPolicies(eventIDs); err != nil {
-		return fmt.Errorf("failed to seed cancellation policies: %w", err)
+	if s.creates("policies") {
+		if err := s.SeedCancellationPolicies(eventIDs); err != nil {
+			return fmt.Errorf("failed to seed cancellation policies: %w", err)
+		}
 	}
 
-	// Clear Redis cache to ensure fresh state
-	if err := s.db.Redis.FlushDB(ctx).Err(); err != nil {
-		log.Printf("Warning: Failed to clear Redis cache: %v", err)
+	if s.opts.LoadTest.Enabled() {
+		if err := s.SeedLoadTest(s.opts.LoadTest, userIDs["admin"], tagIDs); err != nil {
+			return fmt.Errorf("failed to seed load test data: %w", err)
+		}
+	}
+
+	// Clear Redis cache to ensure fresh state. Appending keeps whatever the
+	// environment has cached, and a dry run changes nothing.
+	if !s.appending() && !s.opts.DryRun {
+		var err error
+		if cluster, ok := s.db.Redis.(*redis.ClusterClient); ok {
+			err = cluster.ForEachMaster(ctx, func(ctx context.Context, node *redis.Client) error {
+				return node.FlushDB(ctx).Err()
+			})
+		} else {
+			err = s.db.Redis.FlushDB(ctx).Err()
+		}
+		if err != nil {
+			log.Printf("Warning: Failed to clear Redis cache: %v", err)
+		}
 	}
 
 	return nil
@@ -174,6 +368,19 @@ func (s *Seeder) SeedUsers() (map[string]uuid.UUID, error) {
 	}
 
 	for _, userData := range usersData {
+		existing, err := s.existingID(&users.User{}, "email", userData.email)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up user %s: %w", userData.email, err)
+		}
+		if existing != uuid.Nil {
+			userIDs[userData.key] = existing
+			fmt.Printf("    ⏭️ User exists: %s\n", userData.email)
+			continue
+		}
+		if !s.creates("users") {
+			continue
+		}
+
 		user := users.User{
 			ID:        uuid.New(),
 			FirstName: userData.firstName,
@@ -185,7 +392,7 @@ func (s *Seeder) SeedUsers() (map[string]uuid.UUID, error) {
 			UpdatedAt: time.Now(),
 		}
 
-		if err := s.db.PostgreSQL.Create(&user).Error; err != nil {
+		if err := s.pg.Create(&user).Error; err != nil {
 			return nil, fmt.Errorf("failed to create user %s: %w", userData.email, err)
 		}
 
@@ -216,6 +423,23 @@ func (s *Seeder) SeedTags(adminID uuid.UUID) ([]uuid.UUID, error) {
 	}
 
 	for _, tagData := range tagsData {
+		existing, err := s.existingID(&tags.Tag{}, "name", tagData.name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up tag %s: %w", tagData.name, err)
+		}
+		if existing != uuid.Nil {
+			tagIDs = append(tagIDs, existing)
+			fmt.Printf("    ⏭️ Tag exists: %s\n", tagData.name)
+			continue
+		}
+		if !s.creates("tags") {
+			tagIDs = append(tagIDs, uuid.Nil) // Events skip tags that don't exist
+			continue
+		}
+		if adminID == uuid.Nil {
+			return nil, errMissingAdmin
+		}
+
 		tag := tags.Tag{
 			ID:          uuid.New(),
 			Name:        tagData.name,
@@ -228,7 +452,7 @@ func (s *Seeder) SeedTags(adminID uuid.UUID) ([]uuid.UUID, error) {
 			UpdatedAt:   time.Now(),
 		}
 
-		if err := s.db.PostgreSQL.Create(&tag).Error; err != nil {
+		if err := s.pg.Create(&tag).Error; err != nil {
 			return nil, fmt.Errorf("failed to create tag %s: %w", tag.Name, err)
 		}
 
@@ -243,10 +467,8 @@ func (s *Seeder) SeedTags(adminID uuid.UUID) ([]uuid.UUID, error) {
 func (s *Seeder) SeedVenueTemplates() ([]uuid.UUID, error) {
 	fmt.Println("  🏟️ Seeding venue templates...")
 
-	var templateIDs []uuid.UUID
-
 	// Venue Template 1: Small Theater
-	template1 := venues.VenueTemplate{
+	theaterID, err := s.seedVenueTemplate(venues.VenueTemplate{
 		ID:                 uuid.New(),
 		Name:               "Small Theater",
 		Description:        "Intimate theater with premium and standard seating",
@@ -255,21 +477,13 @@ func (s *Seeder) SeedVenueTemplates() ([]uuid.UUID, error) {
 		LayoutType:         "THEATER",
 		CreatedAt:          time.Now(),
 		UpdatedAt:          time.Now(),
-	}
-
-	if err := s.db.PostgreSQL.Create(&template1).Error; err != nil {
-		return nil, fmt.Errorf("failed to create venue template 1: %w", err)
-	}
-	templateIDs = append(templateIDs, template1.ID)
-	fmt.Printf("    ✅ Created venue template: %s\n", template1.Name)
-
-	// Create sections for Small Theater
-	if err := s.createTheaterSections(template1.ID); err != nil {
-		return nil, fmt.Errorf("failed to create sections for Small Theater: %w", err)
+	}, s.createTheaterSections)
+	if err != nil {
+		return nil, err
 	}
 
 	// Venue Template 2: Conference Hall
-	template2 := venues.VenueTemplate{
+	conferenceHallID, err := s.seedVenueTemplate(venues.VenueTemplate{
 		ID:                 uuid.New(),
 		Name:               "Conference Hall",
 		Description:        "Professional conference hall with premium and general seating",
@@ -278,20 +492,41 @@ func (s *Seeder) SeedVenueTemplates() ([]uuid.UUID, error) {
 		LayoutType:         "CONFERENCE",
 		CreatedAt:          time.Now(),
 		UpdatedAt:          time.Now(),
+	}, s.createConferenceHallSections)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := s.db.PostgreSQL.Create(&template2).Error; err != nil {
-		return nil, fmt.Errorf("failed to create venue template 2: %w", err)
+	return []uuid.UUID{theaterID, conferenceHallID}, nil
+}
+
+// seedVenueTemplate creates a template and its sections, or in append mode
+// reuses the template with the same name. It returns uuid.Nil when the
+// template doesn't exist and venues are left out by --only.
+func (s *Seeder) seedVenueTemplate(template venues.VenueTemplate, createSections func(templateID uuid.UUID) error) (uuid.UUID, error) {
+	existing, err := s.existingID(&venues.VenueTemplate{}, "name", template.Name)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to look up venue template %s: %w", template.Name, err)
+	}
+	if existing != uuid.Nil {
+		fmt.Printf("    ⏭️ Venue template exists: %s\n", template.Name)
+		return existing, nil
+	}
+	if !s.creates("venues") {
+		return uuid.Nil, nil
 	}
-	templateIDs = append(templateIDs, template2.ID)
-	fmt.Printf("    ✅ Created venue template: %s\n", template2.Name)
 
-	// Create sections for Conference Hall
-	if err := s.createConferenceHallSections(template2.ID); err != nil {
-		return nil, fmt.Errorf("failed to create sections for Conference Hall: %w", err)
+	// Seeded templates start their own lineage at version 1
+	template.LineageID, template.Version = template.ID, 1
+	if err := s.pg.Create(&template).Error; err != nil {
+		return uuid.Nil, fmt.Errorf("failed to create venue template %s: %w", template.Name, err)
 	}
+	fmt.Printf("    ✅ Created venue template: %s\n", template.Name)
 
-	return templateIDs, nil
+	if err := createSections(template.ID); err != nil {
+		return uuid.Nil, fmt.Errorf("failed to create sections for %s: %w", template.Name, err)
+	}
+	return template.ID, nil
 }
 
 // createTheaterSections creates sections for the theater template
@@ -310,7 +545,7 @@ func (s *Seeder) createTheaterSections(templateID uuid.UUID) error {
 		UpdatedAt:   time.Now(),
 	}
 
-	if err := s.db.PostgreSQL.Create(&premiumSection).Error; err != nil {
+	if err := s.pg.Create(&premiumSection).Error; err != nil {
 		return fmt.Errorf("failed to create premium section: %w", err)
 	}
 	fmt.Printf("      ✅ Created section: %s (%d seats)\n", premiumSection.Name, premiumSection.TotalSeats)
@@ -334,7 +569,7 @@ func (s *Seeder) createTheaterSections(templateID uuid.UUID) error {
 		UpdatedAt:   time.Now(),
 	}
 
-	if err := s.db.PostgreSQL.Create(&standardSection).Error; err != nil {
+	if err := s.pg.Create(&standardSection).Error; err != nil {
 		return fmt.Errorf("failed to create standard section: %w", err)
 	}
 	fmt.Printf("      ✅ Created section: %s (%d seats)\n", standardSection.Name, standardSection.TotalSeats)
@@ -363,7 +598,7 @@ func (s *Seeder) createConferenceHallSections(templateID uuid.UUID) error {
 		UpdatedAt:   time.Now(),
 	}
 
-	if err := s.db.PostgreSQL.Create(&vipSection).Error; err != nil {
+	if err := s.pg.Create(&vipSection).Error; err != nil {
 		return fmt.Errorf("failed to create VIP section: %w", err)
 	}
 	fmt.Printf("      ✅ Created section: %s (%d seats)\n", vipSection.Name, vipSection.TotalSeats)
@@ -387,7 +622,7 @@ func (s *Seeder) createConferenceHallSections(templateID uuid.UUID) error {
 		UpdatedAt:   time.Now(),
 	}
 
-	if err := s.db.PostgreSQL.Create(&generalSection).Error; err != nil {
+	if err := s.pg.Create(&generalSection).Error; err != nil {
 		return fmt.Errorf("failed to create general section: %w", err)
 	}
 	fmt.Printf("      ✅ Created section: %s (%d seats)\n", generalSection.Name, generalSection.TotalSeats)
@@ -413,11 +648,12 @@ func (s *Seeder) createSeatsForSection(sectionID uuid.UUID, row string, seatCoun
 			Row:        row,
 			Position:   i,
 			Status:     "AVAILABLE",
+			Aisle:      i == 1 || i == seatCount,
 			CreatedAt:  time.Now(),
 			UpdatedAt:  time.Now(),
 		}
 
-		if err := s.db.PostgreSQL.Create(&seat).Error; err != nil {
+		if err := s.pg.Create(&seat).Error; err != nil {
 			return fmt.Errorf("failed to create seat %s: %w", seat.SeatNumber, err)
 		}
 	}
@@ -522,6 +758,25 @@ func (s *Seeder) SeedEvents(adminID uuid.UUID, venueTemplateIDs []uuid.UUID, tag
 	}
 
 	for _, eventData := range eventsData {
+		existing, err := s.existingID(&events.Event{}, "name", eventData.name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up event %s: %w", eventData.name, err)
+		}
+		if existing != uuid.Nil {
+			eventIDs = append(eventIDs, existing)
+			fmt.Printf("    ⏭️ Event exists: %s\n", eventData.name)
+			continue
+		}
+		if !s.creates("events") {
+			continue
+		}
+		if adminID == uuid.Nil {
+			return nil, errMissingAdmin
+		}
+		if eventData.venueTemplateID == uuid.Nil {
+			return nil, fmt.Errorf("venue template for event %s not found, add venues to --only", eventData.name)
+		}
+
 		event := events.Event{
 			ID:              uuid.New(),
 			Name:            eventData.name,
@@ -537,7 +792,7 @@ func (s *Seeder) SeedEvents(adminID uuid.UUID, venueTemplateIDs []uuid.UUID, tag
 			UpdatedAt:       time.Now(),
 		}
 
-		if err := s.db.PostgreSQL.Create(&event).Error; err != nil {
+		if err := s.pg.Create(&event).Error; err != nil {
 			return nil, fmt.Errorf("failed to create event %s: %w", event.Name, err)
 		}
 
@@ -546,7 +801,7 @@ func (s *Seeder) SeedEvents(adminID uuid.UUID, venueTemplateIDs []uuid.UUID, tag
 
 		// Associate tags with event
 		for _, tagIndex := range eventData.tagIndexes {
-			if tagIndex < len(tagIDs) {
+			if tagIndex < len(tagIDs) && tagIDs[tagIndex] != uuid.Nil {
 				eventTag := tags.EventTag{
 					ID:        uuid.New(),
 					EventID:   event.ID,
@@ -554,7 +809,7 @@ func (s *Seeder) SeedEvents(adminID uuid.UUID, venueTemplateIDs []uuid.UUID, tag
 					CreatedAt: time.Now(),
 				}
 
-				if err := s.db.PostgreSQL.Create(&eventTag).Error; err != nil {
+				if err := s.pg.Create(&eventTag).Error; err != nil {
 					return nil, fmt.Errorf("failed to associate tag with event %s: %w", event.Name, err)
 				}
 			}
@@ -573,7 +828,7 @@ func (s *Seeder) SeedEvents(adminID uuid.UUID, venueTemplateIDs []uuid.UUID, tag
 func (s *Seeder) createEventPricing(eventID, venueTemplateID uuid.UUID, pricingMap map[string]float64) error {
 	// Get all sections for this venue template
 	var sections []venues.VenueSection
-	if err := s.db.PostgreSQL.Where("template_id = ?", venueTemplateID).Find(&sections).Error; err != nil {
+	if err := s.pg.Where("template_id = ?", venueTemplateID).Find(&sections).Error; err != nil {
 		return fmt.Errorf("failed to fetch sections: %w", err)
 	}
 
@@ -593,7 +848,7 @@ func (s *Seeder) createEventPricing(eventID, venueTemplateID uuid.UUID, pricingM
 			UpdatedAt:       time.Now(),
 		}
 
-		if err := s.db.PostgreSQL.Create(&pricing).Error; err != nil {
+		if err := s.pg.Create(&pricing).Error; err != nil {
 			return fmt.Errorf("failed to create pricing for section %s: %w", section.Name, err)
 		}
 	}
@@ -621,9 +876,18 @@ func (s *Seeder) SeedCancellationPolicies(eventIDs []uuid.UUID) error {
 	}
 
 	for i, eventID := range eventIDs {
+		existing, err := s.existingID(&cancellation.CancellationPolicy{}, "event_id", eventID)
+		if err != nil {
+			return fmt.Errorf("failed to look up cancellation policy: %w", err)
+		}
+		if existing != uuid.Nil {
+			fmt.Printf("    ⏭️ Cancellation policy exists for event %s\n", eventID)
+			continue
+		}
+
 		// Get event details to calculate deadline
 		var event events.Event
-		if err := s.db.PostgreSQL.First(&event, "id = ?", eventID).Error; err != nil {
+		if err := s.pg.First(&event, "id = ?", eventID).Error; err != nil {
 			return fmt.Errorf("failed to fetch event: %w", err)
 		}
 
@@ -648,7 +912,7 @@ func (s *Seeder) SeedCancellationPolicies(eventIDs []uuid.UUID) error {
 			UpdatedAt:            time.Now(),
 		}
 
-		if err := s.db.PostgreSQL.Create(&policy).Error; err != nil {
+		if err := s.pg.Create(&policy).Error; err != nil {
 			return fmt.Errorf("failed to create cancellation policy for event: %w", err)
 		}
 
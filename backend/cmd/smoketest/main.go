@@ -0,0 +1,350 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// Smoke test for a deployed environment. Every step is read-only except the
+// seat hold, which is released again before the run ends, so it is safe to
+// point at production with a dedicated test user, event and sandbox section.
+//
+// Results are written to stdout as JSON; the exit code is 0 when every check
+// passed and 1 otherwise, so release gates can consume either.
+
+type Options struct {
+	BaseURL   string
+	APIPath   string
+	Email     string
+	Password  string
+	EventID   string
+	SectionID string
+	Timeout   time.Duration
+}
+
+type CheckResult struct {
+	Name       string `json:"name"`
+	Status     string `json:"status"` // PASS, FAIL or SKIP
+	HTTPStatus int    `json:"http_status,omitempty"`
+	DurationMs int64  `json:"duration_ms"`
+	Detail     string `json:"detail,omitempty"`
+}
+
+type Report struct {
+	Target     string        `json:"target"`
+	Passed     bool          `json:"passed"`
+	StartedAt  time.Time     `json:"started_at"`
+	DurationMs int64         `json:"duration_ms"`
+	Checks     []CheckResult `json:"checks"`
+}
+
+const (
+	statusPass = "PASS"
+	statusFail = "FAIL"
+	statusSkip = "SKIP"
+)
+
+// apiResponse mirrors response.StandardApiResponse
+type apiResponse struct {
+	Status     string          `json:"status"`
+	StatusCode int             `json:"status_code"`
+	Message    string          `json:"message"`
+	Data       json.RawMessage `json:"data"`
+	Errors     interface{}     `json:"errors"`
+}
+
+type runner struct {
+	opts   Options
+	client *http.Client
+	report *Report
+
+	accessToken string
+	userID      string
+}
+
+func main() {
+	opts := Options{}
+	flag.StringVar(&opts.BaseURL, "url", getEnv("SMOKE_BASE_URL", "http://localhost:8080"), "base URL of the deployed backend")
+	flag.StringVar(&opts.APIPath, "api-path", getEnv("SMOKE_API_PATH", "/api/v1"), "API base path")
+	flag.StringVar(&opts.Email, "email", os.Getenv("SMOKE_USER_EMAIL"), "email of the smoke test user")
+	flag.StringVar(&opts.Password, "password", os.Getenv("SMOKE_USER_PASSWORD"), "password of the smoke test user")
+	flag.StringVar(&opts.EventID, "event", os.Getenv("SMOKE_EVENT_ID"), "designated test event ID")
+	flag.StringVar(&opts.SectionID, "section", os.Getenv("SMOKE_SECTION_ID"), "sandbox section ID used for the hold/release check")
+	flag.DurationVar(&opts.Timeout, "timeout", 10*time.Second, "per-request timeout")
+	flag.Parse()
+
+	opts.BaseURL = strings.TrimRight(opts.BaseURL, "/")
+
+	r := &runner{
+		opts:   opts,
+		client: &http.Client{Timeout: opts.Timeout},
+		report: &Report{Target: opts.BaseURL, StartedAt: time.Now().UTC()},
+	}
+	r.run()
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(r.report); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to write report: %v\n", err)
+		os.Exit(2)
+	}
+
+	if !r.report.Passed {
+		os.Exit(1)
+	}
+}
+
+func (r *runner) run() {
+	started := time.Now()
+
+	r.check("health", r.checkHealth)
+
+	if r.opts.Email == "" || r.opts.Password == "" {
+		r.skip("auth_login", "SMOKE_USER_EMAIL and SMOKE_USER_PASSWORD not set")
+	} else {
+		r.check("auth_login", r.checkLogin)
+	}
+
+	r.check("event_list", r.checkEventList)
+
+	var seatID string
+	if r.opts.EventID == "" {
+		r.skip("test_event_availability", "SMOKE_EVENT_ID not set")
+	} else {
+		r.check("test_event_availability", r.checkTestEvent)
+	}
+
+	switch {
+	case r.opts.EventID == "" || r.opts.SectionID == "":
+		r.skip("section_availability", "SMOKE_EVENT_ID and SMOKE_SECTION_ID not set")
+		r.skip("seat_hold_release", "SMOKE_EVENT_ID and SMOKE_SECTION_ID not set")
+	default:
+		r.check("section_availability", func() (int, string, error) {
+			status, id, err := r.findAvailableSeat()
+			seatID = id
+			if err != nil {
+				return status, "", err
+			}
+			return status, "seat " + id, nil
+		})
+
+		switch {
+		case r.accessToken == "":
+			r.skip("seat_hold_release", "no access token, login did not succeed")
+		case seatID == "":
+			r.skip("seat_hold_release", "no available seat in sandbox section")
+		default:
+			r.check("seat_hold_release", func() (int, string, error) {
+				return r.checkHoldRelease(seatID)
+			})
+		}
+	}
+
+	r.report.Passed = true
+	for _, c := range r.report.Checks {
+		if c.Status == statusFail {
+			r.report.Passed = false
+			break
+		}
+	}
+	r.report.DurationMs = time.Since(started).Milliseconds()
+}
+
+func (r *runner) check(name string, fn func() (int, string, error)) {
+	started := time.Now()
+	httpStatus, detail, err := fn()
+
+	result := CheckResult{
+		Name:       name,
+		Status:     statusPass,
+		HTTPStatus: httpStatus,
+		DurationMs: time.Since(started).Milliseconds(),
+		Detail:     detail,
+	}
+	if err != nil {
+		result.Status = statusFail
+		result.Detail = err.Error()
+	}
+	r.report.Checks = append(r.report.Checks, result)
+}
+
+func (r *runner) skip(name, reason string) {
+	r.report.Checks = append(r.report.Checks, CheckResult{Name: name, Status: statusSkip, Detail: reason})
+}
+
+//  CHECKS
+
+func (r *runner) checkHealth() (int, string, error) {
+	var body struct {
+		Status string `json:"status"`
+		Error  string `json:"error"`
+	}
+	status, err := r.do(http.MethodGet, r.opts.BaseURL+"/health", nil, &body)
+	if err != nil {
+		return status, "", err
+	}
+	if status != http.StatusOK || body.Status != "healthy" {
+		return status, "", fmt.Errorf("unhealthy: %s", body.Error)
+	}
+	return status, "", nil
+}
+
+func (r *runner) checkLogin() (int, string, error) {
+	payload := map[string]string{"email": r.opts.Email, "password": r.opts.Password}
+
+	var auth struct {
+		AccessToken string `json:"access_token"`
+		User        struct {
+			ID string `json:"id"`
+		} `json:"user"`
+	}
+	status, err := r.api(http.MethodPost, "/auth/login", payload, &auth)
+	if err != nil {
+		return status, "", err
+	}
+	if auth.AccessToken == "" || auth.User.ID == "" {
+		return status, "", errors.New("login response missing access token or user")
+	}
+
+	r.accessToken = auth.AccessToken
+	r.userID = auth.User.ID
+	return status, "", nil
+}
+
+func (r *runner) checkEventList() (int, string, error) {
+	var list json.RawMessage
+	status, err := r.api(http.MethodGet, "/events?page=1&limit=1", nil, &list)
+	if err != nil {
+		return status, "", err
+	}
+	return status, "", nil
+}
+
+func (r *runner) checkTestEvent() (int, string, error) {
+	var event struct {
+		ID               string `json:"id"`
+		Status           string `json:"status"`
+		AvailableTickets int    `json:"available_tickets"`
+	}
+	status, err := r.api(http.MethodGet, "/events/"+r.opts.EventID, nil, &event)
+	if err != nil {
+		return status, "", err
+	}
+	if event.AvailableTickets <= 0 {
+		return status, "", fmt.Errorf("test event has no available tickets (status %s)", event.Status)
+	}
+	return status, fmt.Sprintf("%d tickets available", event.AvailableTickets), nil
+}
+
+func (r *runner) findAvailableSeat() (int, string, error) {
+	var seats []struct {
+		ID string `json:"id"`
+	}
+	path := fmt.Sprintf("/sections/%s/seats/available?event_id=%s", r.opts.SectionID, r.opts.EventID)
+	status, err := r.api(http.MethodGet, path, nil, &seats)
+	if err != nil {
+		return status, "", err
+	}
+	if len(seats) == 0 {
+		return status, "", errors.New("sandbox section has no available seats")
+	}
+	return status, seats[0].ID, nil
+}
+
+// checkHoldRelease holds a single seat and always releases it again
+func (r *runner) checkHoldRelease(seatID string) (int, string, error) {
+	payload := map[string]interface{}{
+		"event_id": r.opts.EventID,
+		"seat_ids": []string{seatID},
+		"user_id":  r.userID,
+	}
+
+	var hold struct {
+		HoldID string `json:"hold_id"`
+	}
+	status, err := r.api(http.MethodPost, "/seats/hold", payload, &hold)
+	if err != nil {
+		return status, "", fmt.Errorf("hold failed: %w", err)
+	}
+	if hold.HoldID == "" {
+		return status, "", errors.New("hold response missing hold_id")
+	}
+
+	status, err = r.api(http.MethodDelete, "/seats/hold/"+hold.HoldID, nil, nil)
+	if err != nil {
+		return status, "", fmt.Errorf("release of hold %s failed: %w", hold.HoldID, err)
+	}
+	return status, "hold " + hold.HoldID + " released", nil
+}
+
+//  HTTP HELPERS
+
+// api calls an endpoint under the API base path and decodes the data field of the standard envelope
+func (r *runner) api(method, path string, payload interface{}, data interface{}) (int, error) {
+	var envelope apiResponse
+	status, err := r.do(method, r.opts.BaseURL+r.opts.APIPath+path, payload, &envelope)
+	if err != nil {
+		return status, err
+	}
+	if status >= http.StatusBadRequest || envelope.Status != "success" {
+		return status, fmt.Errorf("%s %s: %s", method, path, envelope.Message)
+	}
+	if data != nil && len(envelope.Data) > 0 {
+		if err := json.Unmarshal(envelope.Data, data); err != nil {
+			return status, fmt.Errorf("failed to decode %s %s response: %w", method, path, err)
+		}
+	}
+	return status, nil
+}
+
+func (r *runner) do(method, url string, payload interface{}, out interface{}) (int, error) {
+	var body io.Reader
+	if payload != nil {
+		encoded, err := json.Marshal(payload)
+		if err != nil {
+			return 0, err
+		}
+		body = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "evently-smoketest")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if r.accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+r.accessToken)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil && !errors.Is(err, io.EOF) {
+			return resp.StatusCode, fmt.Errorf("invalid JSON response: %w", err)
+		}
+	}
+	return resp.StatusCode, nil
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
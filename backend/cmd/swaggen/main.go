@@ -0,0 +1,281 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"evently/internal/shared/config"
+	"evently/pkg/ratelimit"
+
+	"github.com/go-openapi/spec"
+	"github.com/swaggo/swag"
+	"gopkg.in/yaml.v3"
+)
+
+// Swagger generator. The spec served at /docs is built from the swag
+// annotations of server/main.go (general API info and security schemes) and
+// of the route handlers (one operation per handler, see any controller), so
+// it changes with the code instead of drifting from it.
+//
+// Each operation also gets the rate limit of its route: the tier that
+// ratelimit.Middleware picks for the path, with the default requests per
+// window, and the 429 answer once they are spent. Defaults are documented
+// whatever RATE_LIMIT_* says in the environment, so every build writes the
+// same spec.
+//
+// Run from backend/ with `make docs`, which also fails when the result differs
+// from the committed spec; `make build` and air run it before compiling. The
+// exit code is 0 when the spec was written and 1 when an annotation could not
+// be parsed.
+
+type Options struct {
+	Dir      string
+	MainFile string
+	Output   string
+	Exclude  string
+}
+
+// typeOverrides documents types swag cannot see into as what they marshal to,
+// blank ones are left out
+var typeOverrides = map[string]string{
+	"encoding/xml.Name":                     "",
+	"github.com/google/uuid.UUID":           "string",
+	"github.com/google/uuid.NullUUID":       "string",
+	"gorm.io/gorm.DeletedAt":                "string",
+	"gorm.io/datatypes.JSON":                "object",
+	"encoding/json.RawMessage":              "object",
+	"github.com/shopspring/decimal.Decimal": "number",
+	"time.Duration":                         "integer", // Stdlib types match by their name as written
+}
+
+// rateLimitHeaders are set by ratelimit.Middleware on every limited response
+var rateLimitHeaders = map[string]string{
+	"X-RateLimit-Limit":     "Requests allowed in the current window",
+	"X-RateLimit-Remaining": "Requests left in the current window",
+	"X-RateLimit-Reset":     "Unix time at which the window resets",
+}
+
+func main() {
+	opts := Options{}
+	flag.StringVar(&opts.Dir, "dir", ".", "root of the module to parse")
+	flag.StringVar(&opts.MainFile, "main", "server/main.go", "file holding the general API annotations, relative to -dir")
+	flag.StringVar(&opts.Output, "output", "docs/swagger.yaml", "spec to write")
+	flag.StringVar(&opts.Exclude, "exclude", "cmd,docs,deployments,migrations", "directories not to parse, relative to -dir")
+	flag.Parse()
+
+	doc, err := generate(opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to generate the spec: %v\n", err)
+		os.Exit(1)
+	}
+
+	out, err := toYAML(doc)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to encode the spec: %v\n", err)
+		os.Exit(1)
+	}
+	if err := os.MkdirAll(filepath.Dir(opts.Output), 0o755); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to create %s: %v\n", filepath.Dir(opts.Output), err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(opts.Output, out, 0o644); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to write %s: %v\n", opts.Output, err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Wrote %s: %d operations\n", opts.Output, countOperations(doc))
+}
+
+func generate(opts Options) (*spec.Swagger, error) {
+	var excludes []string
+	for _, dir := range strings.Split(opts.Exclude, ",") {
+		if dir = strings.TrimSpace(dir); dir != "" {
+			excludes = append(excludes, filepath.Join(opts.Dir, dir))
+		}
+	}
+
+	parser := swag.New(
+		swag.SetExcludedDirsAndFiles(strings.Join(excludes, ",")),
+		swag.SetOverrides(typeOverrides),
+		swag.SetStrict(true), // Two handlers claiming one route fail the build
+		swag.SetDebugger(log.New(io.Discard, "", 0)),
+	)
+	if err := parser.ParseAPIMultiSearchDir([]string{opts.Dir}, opts.MainFile, 100); err != nil {
+		return nil, err
+	}
+
+	doc := parser.GetSwagger()
+	if err := documentRateLimits(doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// documentRateLimits adds the limit of its tier and the 429 answer to every
+// operation, as ratelimit.Middleware applies them to every route
+func documentRateLimits(doc *spec.Swagger) error {
+	limiter := ratelimit.NewRateLimiter(nil, defaultRateLimits())
+	window := defaultRateLimits().WindowDuration
+
+	headers := map[string]spec.Header{}
+	for name, description := range rateLimitHeaders {
+		headers[name] = *spec.ResponseHeader().Typed("integer", "").WithDescription(description)
+	}
+
+	for path, item := range doc.Paths.Paths {
+		if err := separateOperations(&item); err != nil {
+			return err
+		}
+
+		limitType := ratelimit.LimitTypeForPath(ginPath(path))
+		for _, operation := range operations(&item) {
+			operation.AddExtension("x-rate-limit", map[string]interface{}{
+				"tier":      string(limitType),
+				"requests":  limiter.Limit(limitType),
+				"window":    window.String(),
+				"algorithm": string(limiter.AlgorithmFor(limitType)),
+			})
+
+			if operation.Responses == nil {
+				operation.Responses = &spec.Responses{}
+			}
+			if operation.Responses.StatusCodeResponses == nil {
+				operation.Responses.StatusCodeResponses = map[int]spec.Response{}
+			}
+			limited, ok := operation.Responses.StatusCodeResponses[429]
+			if !ok {
+				limited = *spec.NewResponse().
+					WithDescription("Rate limit of the route's tier exceeded").
+					WithSchema(spec.RefSchema("#/definitions/response.StandardApiResponse"))
+			}
+			for name, header := range headers {
+				limited.AddHeader(name, &header)
+			}
+			operation.Responses.StatusCodeResponses[429] = limited
+		}
+		doc.Paths.Paths[path] = item
+	}
+	return nil
+}
+
+// separateOperations gives the path its own copy of each operation. A handler
+// with several @Router lines shares one operation between its paths, and those
+// can sit in different tiers, such as GET /events/{id} and GET /admin/events/{id}.
+func separateOperations(item *spec.PathItem) error {
+	for _, operation := range []**spec.Operation{&item.Get, &item.Put, &item.Post, &item.Delete, &item.Options, &item.Head, &item.Patch} {
+		if *operation == nil {
+			continue
+		}
+
+		raw, err := json.Marshal(*operation)
+		if err != nil {
+			return err
+		}
+		copied := &spec.Operation{}
+		if err := json.Unmarshal(raw, copied); err != nil {
+			return err
+		}
+		*operation = copied
+	}
+	return nil
+}
+
+// defaultRateLimits are the limits of a deployment that sets no RATE_LIMIT_* variable
+func defaultRateLimits() *ratelimit.Config {
+	for _, variable := range os.Environ() {
+		if name, _, _ := strings.Cut(variable, "="); strings.HasPrefix(name, "RATE_LIMIT_") {
+			os.Unsetenv(name)
+		}
+	}
+
+	cfg := config.Load().RateLimit
+	return &ratelimit.Config{
+		Enabled:                 cfg.Enabled,
+		WindowDuration:          cfg.WindowDuration,
+		DefaultRequests:         cfg.DefaultRequests,
+		PublicRequests:          cfg.PublicRequests,
+		AuthRequests:            cfg.AuthRequests,
+		BookingRequests:         cfg.BookingRequests,
+		AdminRequests:           cfg.AdminRequests,
+		AnalyticsRequests:       cfg.AnalyticsRequests,
+		BookingCriticalRequests: cfg.BookingCriticalRequests,
+		UserRequests:            cfg.UserRequests,
+		HealthRequests:          cfg.HealthRequests,
+
+		PerUserLimits:       cfg.PerUserLimits,
+		UserLimitMultiplier: cfg.UserLimitMultiplier,
+		SlidingLogTypes:     ratelimit.ParseLimitTypes(cfg.SlidingLogTypes),
+		Burst:               ratelimit.ParseBurst(cfg.Burst),
+	}
+}
+
+// ginPath turns a spec path such as /api/v1/events/{eventId} into the route
+// gin matched, /api/v1/events/:eventId
+func ginPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			segments[i] = ":" + strings.Trim(segment, "{}")
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func operations(item *spec.PathItem) []*spec.Operation {
+	var ops []*spec.Operation
+	for _, op := range []*spec.Operation{item.Get, item.Put, item.Post, item.Delete, item.Options, item.Head, item.Patch} {
+		if op != nil {
+			ops = append(ops, op)
+		}
+	}
+	return ops
+}
+
+func countOperations(doc *spec.Swagger) int {
+	count := 0
+	for _, item := range doc.Paths.Paths {
+		count += len(operations(&item))
+	}
+	return count
+}
+
+// toYAML writes the spec as YAML in the key order of its JSON encoding, which
+// sorts paths and definitions, so regenerating an unchanged API changes nothing
+func toYAML(doc *spec.Swagger) ([]byte, error) {
+	raw, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	var node yaml.Node
+	if err := yaml.Unmarshal(raw, &node); err != nil {
+		return nil, err
+	}
+	plain(&node)
+
+	var out bytes.Buffer
+	encoder := yaml.NewEncoder(&out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// plain drops the flow and quoting styles the JSON was written in
+func plain(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		plain(child)
+	}
+}
@@ -1,11 +1,4 @@
--- Initialize database with required extensions and initial setup
+-- Initialize database with required extensions. Tables and indexes are
+-- created by the migrations in migrations/, see `make migrate-up`.
 CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
 CREATE EXTENSION IF NOT EXISTS "pg_trgm";
-
--- Create indexes for performance
-CREATE INDEX IF NOT EXISTS idx_events_date ON events(event_date);
-CREATE INDEX IF NOT EXISTS idx_bookings_user_id ON bookings(user_id);
-CREATE INDEX IF NOT EXISTS idx_bookings_event_id ON bookings(event_id);
-
--- Insert sample data for development
--- This will be replaced by proper migration scripts
@@ -1,8 +1,12 @@
 package analytics
 
 import (
+	"context"
+	"errors"
+	"log"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -24,6 +28,7 @@ type Controller interface {
 	GetTagPopularityAnalytics(c *gin.Context)
 	GetTagTrends(c *gin.Context)
 	GetTagComparisons(c *gin.Context)
+	GetTagHierarchyAnalytics(c *gin.Context)
 
 	// Booking Analytics (new)
 	GetBookingAnalytics(c *gin.Context)
@@ -36,6 +41,26 @@ type Controller interface {
 	// User-facing Analytics
 	GetUserBookingHistory(c *gin.Context)
 	GetPersonalAnalytics(c *gin.Context)
+
+	// Yearly recap
+	GetYearlyRecap(c *gin.Context)
+	GetRecapSubscription(c *gin.Context)
+	UpdateRecapSubscription(c *gin.Context)
+	SendYearlyRecaps(c *gin.Context)
+
+	// Scheduled reports
+	GetReportSubscriptions(c *gin.Context)
+	UpdateReportSubscription(c *gin.Context)
+	PreviewReport(c *gin.Context)
+
+	// Fees and taxes
+	GetRevenueBreakdown(c *gin.Context)
+
+	// Shared event analytics
+	CreateShareLink(c *gin.Context)
+	GetShareLinks(c *gin.Context)
+	RevokeShareLink(c *gin.Context)
+	GetSharedEventAnalytics(c *gin.Context)
 }
 
 // controller implements the Controller interface
@@ -50,8 +75,24 @@ func NewController(service Service) Controller {
 
 // Dashboard Analytics Implementation
 
+// GetDashboardAnalytics godoc
+//
+// @Summary      Get admin dashboard analytics
+// @Description  Get comprehensive dashboard analytics for administrators
+// @Description
+// @Description  Partner API keys need the `analytics:read` scope. Requires the `analytics:read` permission.
+// @Tags         Analytics
+// @Produce      json
+// @Security     Bearer
+// @Security     ApiKey
+// @Param        refresh query boolean false "Read the bookings, events and tags tables directly instead of the rollup tables, which are refreshed hourly" default(false)
+// @Success      200 {object} response.StandardApiResponse{data=DashboardAnalytics} "Dashboard analytics retrieved successfully"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/analytics/admin/dashboard [get]
 func (ctrl *controller) GetDashboardAnalytics(c *gin.Context) {
-	dashboard, err := ctrl.service.GetDashboardAnalytics()
+	dashboard, err := ctrl.service.GetDashboardAnalytics(c.Request.Context(), wantsRefresh(c))
 	if err != nil {
 		response.RespondJSON(c, "error", http.StatusInternalServerError, err.Error(), nil, nil)
 		return
@@ -62,6 +103,24 @@ func (ctrl *controller) GetDashboardAnalytics(c *gin.Context) {
 
 // Event Analytics Implementation
 
+// GetEventAnalytics godoc
+//
+// @Summary      Get specific event analytics (Admin)
+// @Description  Get detailed analytics for a specific event
+// @Description
+// @Description  Partner API keys need the `analytics:read` scope. Requires the `analytics:read` permission.
+// @Tags         Analytics
+// @Produce      json
+// @Security     Bearer
+// @Security     ApiKey
+// @Param        id path string true "ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=EventAnalytics} "Event analytics retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/analytics/admin/events/{id} [get]
 func (ctrl *controller) GetEventAnalytics(c *gin.Context) {
 	eventIDStr := c.Param("id")
 	eventID, err := uuid.Parse(eventIDStr)
@@ -70,7 +129,7 @@ func (ctrl *controller) GetEventAnalytics(c *gin.Context) {
 		return
 	}
 
-	analytics, err := ctrl.service.GetEventAnalytics(eventID)
+	analytics, err := ctrl.service.GetEventAnalytics(c.Request.Context(), eventID)
 	if err != nil {
 		statusCode := http.StatusInternalServerError
 		if err.Error() == "event not found" {
@@ -83,6 +142,21 @@ func (ctrl *controller) GetEventAnalytics(c *gin.Context) {
 	response.RespondJSON(c, "success", http.StatusOK, "Event analytics retrieved successfully", analytics, nil)
 }
 
+// GetGlobalEventAnalytics godoc
+//
+// @Summary      Get global event analytics (Admin)
+// @Description  Get global analytics for all events
+// @Description
+// @Description  Partner API keys need the `analytics:read` scope. Requires the `analytics:read` permission.
+// @Tags         Analytics
+// @Produce      json
+// @Security     Bearer
+// @Security     ApiKey
+// @Success      200 {object} response.StandardApiResponse{data=GlobalEventAnalytics} "Global event analytics retrieved successfully"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/analytics/admin/events [get]
 func (ctrl *controller) GetGlobalEventAnalytics(c *gin.Context) {
 	analytics, err := ctrl.service.GetGlobalEventAnalytics()
 	if err != nil {
@@ -95,8 +169,24 @@ func (ctrl *controller) GetGlobalEventAnalytics(c *gin.Context) {
 
 // Tag Analytics Implementation
 
+// GetTagAnalytics godoc
+//
+// @Summary      Get tag analytics (Admin)
+// @Description  Get analytics for tags usage and performance
+// @Description
+// @Description  Partner API keys need the `analytics:read` scope. Requires the `analytics:read` permission.
+// @Tags         Analytics
+// @Produce      json
+// @Security     Bearer
+// @Security     ApiKey
+// @Param        refresh query boolean false "Read the bookings, events and tags tables directly instead of the rollup tables, which are refreshed hourly" default(false)
+// @Success      200 {object} response.StandardApiResponse{data=TagAnalyticsResponse} "Tag analytics retrieved successfully"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/analytics/admin/tags [get]
 func (ctrl *controller) GetTagAnalytics(c *gin.Context) {
-	analytics, err := ctrl.service.GetTagAnalytics()
+	analytics, err := ctrl.service.GetTagAnalytics(wantsRefresh(c))
 	if err != nil {
 		response.RespondJSON(c, "error", http.StatusInternalServerError, err.Error(), nil, nil)
 		return
@@ -105,8 +195,24 @@ func (ctrl *controller) GetTagAnalytics(c *gin.Context) {
 	response.RespondJSON(c, "success", http.StatusOK, "Tag analytics retrieved successfully", analytics, nil)
 }
 
+// GetTagPopularityAnalytics godoc
+//
+// @Summary      Get tag popularity analytics (Admin)
+// @Description  Get popularity metrics for tags
+// @Description
+// @Description  Partner API keys need the `analytics:read` scope. Requires the `analytics:read` permission.
+// @Tags         Analytics
+// @Produce      json
+// @Security     Bearer
+// @Security     ApiKey
+// @Param        refresh query boolean false "Read the bookings, events and tags tables directly instead of the rollup tables, which are refreshed hourly" default(false)
+// @Success      200 {object} response.StandardApiResponse{data=[]TagAnalytics} "Tag popularity analytics retrieved successfully"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/analytics/admin/tags/popularity [get]
 func (ctrl *controller) GetTagPopularityAnalytics(c *gin.Context) {
-	analytics, err := ctrl.service.GetTagPopularityAnalytics()
+	analytics, err := ctrl.service.GetTagPopularityAnalytics(wantsRefresh(c))
 	if err != nil {
 		response.RespondJSON(c, "error", http.StatusInternalServerError, err.Error(), nil, nil)
 		return
@@ -115,6 +221,22 @@ func (ctrl *controller) GetTagPopularityAnalytics(c *gin.Context) {
 	response.RespondJSON(c, "success", http.StatusOK, "Tag popularity analytics retrieved successfully", analytics, nil)
 }
 
+// GetTagTrends godoc
+//
+// @Summary      Get tag trends (Admin)
+// @Description  Get trending analysis for tags
+// @Description
+// @Description  Partner API keys need the `analytics:read` scope. Requires the `analytics:read` permission.
+// @Tags         Analytics
+// @Produce      json
+// @Security     Bearer
+// @Security     ApiKey
+// @Param        months query integer false "Number of months for trend analysis" default(6)
+// @Success      200 {object} response.StandardApiResponse{data=[]TagTrend} "Tag trends retrieved successfully"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/analytics/admin/tags/trends [get]
 func (ctrl *controller) GetTagTrends(c *gin.Context) {
 	monthsStr := c.DefaultQuery("months", "6")
 	months, err := strconv.Atoi(monthsStr)
@@ -131,6 +253,21 @@ func (ctrl *controller) GetTagTrends(c *gin.Context) {
 	response.RespondJSON(c, "success", http.StatusOK, "Tag trends retrieved successfully", trends, nil)
 }
 
+// GetTagComparisons godoc
+//
+// @Summary      Get tag performance comparisons (Admin)
+// @Description  Compare performance metrics across different tags
+// @Description
+// @Description  Partner API keys need the `analytics:read` scope. Requires the `analytics:read` permission.
+// @Tags         Analytics
+// @Produce      json
+// @Security     Bearer
+// @Security     ApiKey
+// @Success      200 {object} response.StandardApiResponse{data=[]TagComparison} "Tag comparisons retrieved successfully"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/analytics/admin/tags/comparisons [get]
 func (ctrl *controller) GetTagComparisons(c *gin.Context) {
 	comparisons, err := ctrl.service.GetTagComparisons()
 	if err != nil {
@@ -141,10 +278,51 @@ func (ctrl *controller) GetTagComparisons(c *gin.Context) {
 	response.RespondJSON(c, "success", http.StatusOK, "Tag comparisons retrieved successfully", comparisons, nil)
 }
 
+// GetTagHierarchyAnalytics godoc
+//
+// @Summary      Get tag hierarchy analytics (Admin)
+// @Description  Every active tag with its child tags rolled up into it. event_count, bookings, revenue and utilization cover events tagged with the tag or any tag below it, each event counted once per tag; own_event_count covers the tag alone.
+// @Description
+// @Description  Partner API keys need the `analytics:read` scope. Requires the `analytics:read` permission.
+// @Tags         Analytics
+// @Produce      json
+// @Security     Bearer
+// @Security     ApiKey
+// @Success      200 {object} response.StandardApiResponse{data=[]TagHierarchyAnalytics} "Tag hierarchy analytics retrieved successfully"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/analytics/admin/tags/hierarchy [get]
+func (ctrl *controller) GetTagHierarchyAnalytics(c *gin.Context) {
+	analytics, err := ctrl.service.GetTagHierarchyAnalytics()
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusInternalServerError, err.Error(), nil, nil)
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Tag hierarchy analytics retrieved successfully", analytics, nil)
+}
+
 // Booking Analytics Implementation
 
+// GetBookingAnalytics godoc
+//
+// @Summary      Get booking analytics (Admin)
+// @Description  Get comprehensive booking analytics
+// @Description
+// @Description  Partner API keys need the `analytics:read` scope. Requires the `analytics:read` permission.
+// @Tags         Analytics
+// @Produce      json
+// @Security     Bearer
+// @Security     ApiKey
+// @Param        refresh query boolean false "Read the bookings, events and tags tables directly instead of the rollup tables, which are refreshed hourly" default(false)
+// @Success      200 {object} response.StandardApiResponse{data=BookingAnalytics} "Booking analytics retrieved successfully"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/analytics/admin/bookings [get]
 func (ctrl *controller) GetBookingAnalytics(c *gin.Context) {
-	analytics, err := ctrl.service.GetBookingAnalytics()
+	analytics, err := ctrl.service.GetBookingAnalytics(wantsRefresh(c))
 	if err != nil {
 		response.RespondJSON(c, "error", http.StatusInternalServerError, err.Error(), nil, nil)
 		return
@@ -153,8 +331,24 @@ func (ctrl *controller) GetBookingAnalytics(c *gin.Context) {
 	response.RespondJSON(c, "success", http.StatusOK, "Booking analytics retrieved successfully", analytics, nil)
 }
 
+// GetBookingDailyStats godoc
+//
+// @Summary      Get daily booking statistics (Admin)
+// @Description  Get daily booking statistics and trends
+// @Description
+// @Description  Partner API keys need the `analytics:read` scope. Requires the `analytics:read` permission.
+// @Tags         Analytics
+// @Produce      json
+// @Security     Bearer
+// @Security     ApiKey
+// @Param        refresh query boolean false "Read the bookings, events and tags tables directly instead of the rollup tables, which are refreshed hourly" default(false)
+// @Success      200 {object} response.StandardApiResponse{data=[]DailyBookingStats} "Daily booking statistics retrieved successfully"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/analytics/admin/bookings/daily [get]
 func (ctrl *controller) GetBookingDailyStats(c *gin.Context) {
-	stats, err := ctrl.service.GetBookingDailyStats()
+	stats, err := ctrl.service.GetBookingDailyStats(wantsRefresh(c))
 	if err != nil {
 		response.RespondJSON(c, "error", http.StatusInternalServerError, err.Error(), nil, nil)
 		return
@@ -163,6 +357,24 @@ func (ctrl *controller) GetBookingDailyStats(c *gin.Context) {
 	response.RespondJSON(c, "success", http.StatusOK, "Daily booking statistics retrieved successfully", stats, nil)
 }
 
+// GetCancellationAnalytics godoc
+//
+// @Summary      Get cancellation analytics (Admin)
+// @Description  Get analytics for booking cancellations. `cancellation_reasons` splits processed
+// @Description  cancellations by reason code with their refunds in the base currency;
+// @Description  `reasons_by_event` and `reasons_by_tag` list the 10 most cancelled events and tags
+// @Description  with their top 3 reasons.
+// @Description
+// @Description  Partner API keys need the `analytics:read` scope. Requires the `analytics:read` permission.
+// @Tags         Analytics
+// @Produce      json
+// @Security     Bearer
+// @Security     ApiKey
+// @Success      200 {object} response.StandardApiResponse{data=CancellationAnalytics} "Cancellation analytics retrieved successfully"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/analytics/admin/bookings/cancellations [get]
 func (ctrl *controller) GetCancellationAnalytics(c *gin.Context) {
 	analytics, err := ctrl.service.GetCancellationAnalytics()
 	if err != nil {
@@ -175,6 +387,21 @@ func (ctrl *controller) GetCancellationAnalytics(c *gin.Context) {
 
 // User Analytics Implementation
 
+// GetUserAnalytics godoc
+//
+// @Summary      Get user analytics (Admin)
+// @Description  Get user behavior analytics
+// @Description
+// @Description  Partner API keys need the `analytics:read` scope. Requires the `analytics:read` permission.
+// @Tags         Analytics
+// @Produce      json
+// @Security     Bearer
+// @Security     ApiKey
+// @Success      200 {object} response.StandardApiResponse{data=UserAnalytics} "User analytics retrieved successfully"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/analytics/admin/users [get]
 func (ctrl *controller) GetUserAnalytics(c *gin.Context) {
 	analytics, err := ctrl.service.GetUserAnalytics()
 	if err != nil {
@@ -187,6 +414,17 @@ func (ctrl *controller) GetUserAnalytics(c *gin.Context) {
 
 // User-facing Analytics Implementation
 
+// GetUserBookingHistory godoc
+//
+// @Summary      Get user booking history analytics
+// @Description  Get analytics for the current user's booking history
+// @Tags         Analytics
+// @Produce      json
+// @Security     Bearer
+// @Success      200 {object} response.StandardApiResponse{data=UserBookingHistory} "User booking history retrieved successfully"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/analytics/user/bookings/history [get]
 func (ctrl *controller) GetUserBookingHistory(c *gin.Context) {
 	// Get user ID from context (set by auth middleware)
 	userID, exists := c.Get("user_id")
@@ -210,6 +448,17 @@ func (ctrl *controller) GetUserBookingHistory(c *gin.Context) {
 	response.RespondJSON(c, "success", http.StatusOK, "User booking history retrieved successfully", history, nil)
 }
 
+// GetPersonalAnalytics godoc
+//
+// @Summary      Get personal analytics
+// @Description  Get personal analytics and insights for the current user. Recommendations include upcoming events matching the preferred tags and city on the user's profile.
+// @Tags         Analytics
+// @Produce      json
+// @Security     Bearer
+// @Success      200 {object} response.StandardApiResponse{data=PersonalAnalytics} "Personal analytics retrieved successfully"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/analytics/user/personal [get]
 func (ctrl *controller) GetPersonalAnalytics(c *gin.Context) {
 	// Get user ID from context (set by auth middleware)
 	userID, exists := c.Get("user_id")
@@ -233,6 +482,486 @@ func (ctrl *controller) GetPersonalAnalytics(c *gin.Context) {
 	response.RespondJSON(c, "success", http.StatusOK, "Personal analytics retrieved successfully", analytics, nil)
 }
 
+// Yearly Recap Implementation
+
+// GetYearlyRecap godoc
+//
+// @Summary      Get yearly recap
+// @Description  Get the current user's 'year in events' recap
+// @Tags         Analytics
+// @Produce      json
+// @Security     Bearer
+// @Param        year query integer false "Recap year, defaults to the previous year"
+// @Success      200 {object} response.StandardApiResponse{data=YearlyRecap} "Yearly recap retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse "Invalid year"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/analytics/user/recap [get]
+func (ctrl *controller) GetYearlyRecap(c *gin.Context) {
+	userUUID, ok := ctrl.validateUserAccess(c)
+	if !ok {
+		return
+	}
+
+	year := time.Now().Year() - 1
+	if yearStr := c.Query("year"); yearStr != "" {
+		parsed, err := strconv.Atoi(yearStr)
+		if err != nil {
+			response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid year", nil, err.Error())
+			return
+		}
+		year = parsed
+	}
+
+	recap, err := ctrl.service.GetYearlyRecap(*userUUID, year)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "invalid recap year" {
+			statusCode = http.StatusBadRequest
+		}
+		response.RespondJSON(c, "error", statusCode, err.Error(), nil, nil)
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Yearly recap retrieved successfully", recap, nil)
+}
+
+// GetRecapSubscription godoc
+//
+// @Summary      Get yearly recap subscription
+// @Description  Get whether the current user receives the yearly recap email
+// @Tags         Analytics
+// @Produce      json
+// @Security     Bearer
+// @Success      200 {object} response.StandardApiResponse{data=RecapSubscription} "Recap subscription retrieved successfully"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/analytics/user/recap/subscription [get]
+func (ctrl *controller) GetRecapSubscription(c *gin.Context) {
+	userUUID, ok := ctrl.validateUserAccess(c)
+	if !ok {
+		return
+	}
+
+	subscription, err := ctrl.service.GetRecapSubscription(*userUUID)
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusInternalServerError, err.Error(), nil, nil)
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Recap subscription retrieved successfully", subscription, nil)
+}
+
+// UpdateRecapSubscription godoc
+//
+// @Summary      Update yearly recap subscription
+// @Description  Opt in to or out of the yearly recap email
+// @Tags         Analytics
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        request body RecapSubscriptionRequest true "Request body"
+// @Success      200 {object} response.StandardApiResponse{data=RecapSubscription} "Recap subscription updated successfully"
+// @Failure      400 {object} response.StandardApiResponse "Invalid request body"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/analytics/user/recap/subscription [put]
+func (ctrl *controller) UpdateRecapSubscription(c *gin.Context) {
+	userUUID, ok := ctrl.validateUserAccess(c)
+	if !ok {
+		return
+	}
+
+	var req RecapSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid request body", nil, err.Error())
+		return
+	}
+
+	subscription, err := ctrl.service.SetRecapSubscription(*userUUID, *req.OptIn)
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusInternalServerError, err.Error(), nil, nil)
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Recap subscription updated successfully", subscription, nil)
+}
+
+// SendYearlyRecaps lets admins trigger a recap run outside the schedule.
+// The run continues in the background after the response is sent.
+//
+// @Summary      Send yearly recap emails (Admin)
+// @Description  Queue the yearly recap email for every opted-in user who hasn't received it yet. The run continues in the background.
+// @Description
+// @Description  Requires the `notifications:manage` permission. Partner API keys are refused.
+// @Tags         Analytics
+// @Produce      json
+// @Security     Bearer
+// @Param        year query integer false "Recap year, defaults to the previous year"
+// @Success      202 {object} response.StandardApiResponse{data=object} "Yearly recap run started"
+// @Failure      400 {object} response.StandardApiResponse "Invalid year"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Router       /api/v1/analytics/admin/recaps/send [post]
+func (ctrl *controller) SendYearlyRecaps(c *gin.Context) {
+	year := time.Now().Year() - 1
+	if yearStr := c.Query("year"); yearStr != "" {
+		parsed, err := strconv.Atoi(yearStr)
+		if err != nil || parsed < 2000 || parsed > time.Now().Year() {
+			response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid year", nil, nil)
+			return
+		}
+		year = parsed
+	}
+
+	config := DefaultRecapJobConfig()
+	go func() {
+		result, err := ctrl.service.SendYearlyRecaps(context.Background(), year, config.BatchSize, config.BatchInterval)
+		if err != nil {
+			log.Printf("Failed to send yearly recaps for %d: %v", year, err)
+			return
+		}
+		log.Printf("Queued %d yearly recap emails for %d", result.Queued, year)
+	}()
+
+	response.RespondJSON(c, "success", http.StatusAccepted, "Yearly recap run started", gin.H{"year": year}, nil)
+}
+
+// Scheduled Reports Implementation
+
+// GetReportSubscriptions godoc
+//
+// @Summary      Get report subscriptions (Admin)
+// @Description  The calling admin's weekly and monthly analytics report email subscriptions.
+// @Description
+// @Description  Partner API keys need the `analytics:read` scope. Requires the `analytics:read` permission.
+// @Tags         Analytics
+// @Produce      json
+// @Security     Bearer
+// @Security     ApiKey
+// @Success      200 {object} response.StandardApiResponse{data=[]ReportSubscriptionResponse} "Report subscriptions retrieved successfully"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/analytics/admin/reports/subscriptions [get]
+func (ctrl *controller) GetReportSubscriptions(c *gin.Context) {
+	adminUUID, ok := ctrl.validateUserAccess(c)
+	if !ok {
+		return
+	}
+
+	subscriptions, err := ctrl.service.GetReportSubscriptions(*adminUUID)
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusInternalServerError, err.Error(), nil, nil)
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Report subscriptions retrieved successfully", subscriptions, nil)
+}
+
+// UpdateReportSubscription godoc
+//
+// @Summary      Update a report subscription (Admin)
+// @Description  Opt in or out of the weekly report (previous Monday to Sunday, sent on Mondays) or the monthly
+// @Description  report (previous calendar month, sent on the 1st). Reports go out from ANALYTICS_REPORTS_SEND_HOUR UTC.
+// @Description  After opting in, the latest finished period is sent on the next scheduled check.
+// @Description
+// @Description  Partner API keys need the `analytics:read` scope. Requires the `analytics:read` permission.
+// @Tags         Analytics
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Security     ApiKey
+// @Param        frequency path string true "Frequency" Enums(weekly, monthly)
+// @Param        request body ReportSubscriptionRequest true "Request body"
+// @Success      200 {object} response.StandardApiResponse{data=ReportSubscriptionResponse} "Report subscription updated successfully"
+// @Failure      400 {object} response.StandardApiResponse "Invalid frequency, section or request body"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/analytics/admin/reports/subscriptions/{frequency} [put]
+func (ctrl *controller) UpdateReportSubscription(c *gin.Context) {
+	adminUUID, ok := ctrl.validateUserAccess(c)
+	if !ok {
+		return
+	}
+
+	frequency, err := ParseReportFrequency(c.Param("frequency"))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Frequency must be weekly or monthly", nil, err.Error())
+		return
+	}
+
+	var req ReportSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid request body", nil, err.Error())
+		return
+	}
+
+	subscription, err := ctrl.service.SetReportSubscription(*adminUUID, frequency, req)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, ErrInvalidReportSection) {
+			statusCode = http.StatusBadRequest
+		}
+		response.RespondJSON(c, "error", statusCode, err.Error(), nil, nil)
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Report subscription updated successfully", subscription, nil)
+}
+
+// PreviewReport renders the admin's report for the last full period. With
+// ?format=html the email body is returned as a page instead of JSON.
+//
+// @Summary      Preview the analytics report email (Admin)
+// @Description  Renders the calling admin's report for the last full period exactly as it would be emailed, without
+// @Description  sending it. Admins without a subscription see every section.
+// @Description
+// @Description  Partner API keys need the `analytics:read` scope. Requires the `analytics:read` permission.
+// @Tags         Analytics
+// @Produce      json,text/html
+// @Security     Bearer
+// @Security     ApiKey
+// @Param        frequency query string false "Frequency" Enums(weekly, monthly) default(weekly)
+// @Param        format query string false "Set to html to get the email body as a page instead of JSON" Enums(html)
+// @Success      200 {object} response.StandardApiResponse{data=ReportPreview} "Report preview rendered successfully"
+// @Failure      400 {object} response.StandardApiResponse "Invalid frequency"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Failure      503 {object} response.StandardApiResponse
+// @Router       /api/v1/analytics/admin/reports/preview [get]
+func (ctrl *controller) PreviewReport(c *gin.Context) {
+	adminUUID, ok := ctrl.validateUserAccess(c)
+	if !ok {
+		return
+	}
+
+	frequency, err := ParseReportFrequency(c.DefaultQuery("frequency", "weekly"))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Frequency must be weekly or monthly", nil, err.Error())
+		return
+	}
+
+	preview, err := ctrl.service.PreviewReport(*adminUUID, frequency)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, ErrReportPreviewDisabled) {
+			statusCode = http.StatusServiceUnavailable
+		}
+		response.RespondJSON(c, "error", statusCode, err.Error(), nil, nil)
+		return
+	}
+
+	if c.Query("format") == "html" {
+		c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(preview.HTML))
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Report preview rendered successfully", preview, nil)
+}
+
+// Revenue breakdown Implementation
+
+// GetRevenueBreakdown godoc
+//
+// @Summary      Get revenue breakdown with fees and taxes (Admin)
+// @Description  Splits the revenue of bookings confirmed in the period into base price, section pricing,
+// @Description  service fees and taxes, with the tax collected per tax rule. Bookings made before charges
+// @Description  were itemized are counted in `unitemized_revenue`. Amounts are in the base currency, each
+// @Description  booking converted at the exchange rate of its booking time.
+// @Description
+// @Description  Partner API keys need the `analytics:read` scope. Requires the `analytics:read` permission.
+// @Tags         Analytics
+// @Produce      json
+// @Security     Bearer
+// @Security     ApiKey
+// @Param        start_date query string false "First day, UTC. Defaults to 30 days before end_date." Format(date)
+// @Param        end_date query string false "Last day, inclusive, UTC. Defaults to today." Format(date)
+// @Success      200 {object} response.StandardApiResponse{data=RevenueBreakdown} "Revenue breakdown retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse "Invalid date range"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/analytics/admin/revenue/breakdown [get]
+func (ctrl *controller) GetRevenueBreakdown(c *gin.Context) {
+	start, end, err := ctrl.parseDateRange(c)
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, err.Error(), nil, nil)
+		return
+	}
+
+	breakdown, err := ctrl.service.GetRevenueBreakdown(start, end)
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusInternalServerError, err.Error(), nil, nil)
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Revenue breakdown retrieved successfully", breakdown, nil)
+}
+
+// Shared event analytics Implementation
+
+// CreateShareLink godoc
+//
+// @Summary      Create an analytics share link (Admin)
+// @Description  Creates an expiring, read-only link to the event's analytics for someone
+// @Description  without an account, such as a sponsor or venue manager. Revenue is only
+// @Description  included when listed in `sections`. The URL is signed and can't be
+// @Description  changed to open another event or section.
+// @Description
+// @Description  Requires the `analytics:read` permission. Partner API keys are refused, as the link itself grants access.
+// @Tags         Analytics
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "ID" Format(uuid)
+// @Param        request body ShareLinkRequest true "Request body"
+// @Success      201 {object} response.StandardApiResponse{data=ShareLinkResponse} "Share link created"
+// @Failure      400 {object} response.StandardApiResponse "Unknown section or expiry beyond the maximum"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Event not found"
+// @Failure      500 {object} response.StandardApiResponse
+// @Failure      503 {object} response.StandardApiResponse
+// @Router       /api/v1/analytics/admin/events/{id}/share-links [post]
+func (ctrl *controller) CreateShareLink(c *gin.Context) {
+	adminUUID, ok := ctrl.validateUserAccess(c)
+	if !ok {
+		return
+	}
+
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid event ID format", nil, err.Error())
+		return
+	}
+
+	var req ShareLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid request body", nil, err.Error())
+		return
+	}
+
+	link, err := ctrl.service.CreateShareLink(*adminUUID, eventID, req)
+	if err != nil {
+		ctrl.respondShareLinkError(c, err)
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusCreated, "Share link created successfully", link, nil)
+}
+
+// GetShareLinks godoc
+//
+// @Summary      List an event's share links (Admin)
+// @Description  Lists the event's share links with their status and view counts
+// @Description
+// @Description  Requires the `analytics:read` permission. Partner API keys are refused, as the link itself grants access.
+// @Tags         Analytics
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=[]ShareLinkResponse} "Share links retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/analytics/admin/events/{id}/share-links [get]
+func (ctrl *controller) GetShareLinks(c *gin.Context) {
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid event ID format", nil, err.Error())
+		return
+	}
+
+	links, err := ctrl.service.GetShareLinks(eventID)
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusInternalServerError, err.Error(), nil, nil)
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Share links retrieved successfully", links, nil)
+}
+
+// RevokeShareLink godoc
+//
+// @Summary      Revoke an analytics share link (Admin)
+// @Description  Revokes the link; its URL stops working immediately
+// @Description
+// @Description  Requires the `analytics:read` permission. Partner API keys are refused, as the link itself grants access.
+// @Tags         Analytics
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse "Share link revoked"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Share link not found"
+// @Failure      500 {object} response.StandardApiResponse
+// @Failure      503 {object} response.StandardApiResponse
+// @Router       /api/v1/analytics/admin/share-links/{id} [delete]
+func (ctrl *controller) RevokeShareLink(c *gin.Context) {
+	linkID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid share link ID format", nil, err.Error())
+		return
+	}
+
+	if err := ctrl.service.RevokeShareLink(linkID); err != nil {
+		ctrl.respondShareLinkError(c, err)
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Share link revoked successfully", nil, nil)
+}
+
+// GetSharedEventAnalytics serves the read-only snapshot behind a share link. The
+// token is the only credential, so the response is never cached or indexed.
+//
+// @Summary      View a shared event analytics snapshot
+// @Description  Public endpoint behind a share link. Returns only the sections the link
+// @Description  shares. Demographics are suppressed for events with fewer than 5 attendees.
+// @Tags         Analytics
+// @Produce      json
+// @Param        token path string true "Token"
+// @Success      200 {object} response.StandardApiResponse{data=SharedEventAnalytics} "Snapshot retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse "Link is invalid, expired or revoked"
+// @Failure      404 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Failure      503 {object} response.StandardApiResponse "Share links are not enabled"
+// @Router       /api/v1/analytics/shared/{token} [get]
+func (ctrl *controller) GetSharedEventAnalytics(c *gin.Context) {
+	c.Header("Cache-Control", "no-store")
+	c.Header("X-Robots-Tag", "noindex, nofollow")
+
+	snapshot, err := ctrl.service.GetSharedEventAnalytics(c.Param("token"))
+	if err != nil {
+		ctrl.respondShareLinkError(c, err)
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Shared event analytics retrieved successfully", snapshot, nil)
+}
+
+func (ctrl *controller) respondShareLinkError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ErrInvalidShareSection), errors.Is(err, ErrInvalidShareExpiry):
+		response.RespondJSON(c, "error", http.StatusBadRequest, err.Error(), nil, nil)
+	case errors.Is(err, ErrInvalidShareLink):
+		response.RespondJSON(c, "error", http.StatusForbidden, err.Error(), nil, nil)
+	case errors.Is(err, ErrShareLinkNotFound), errors.Is(err, ErrSharedEventNotFound):
+		response.RespondJSON(c, "error", http.StatusNotFound, err.Error(), nil, nil)
+	case errors.Is(err, ErrShareLinksDisabled):
+		response.RespondJSON(c, "error", http.StatusServiceUnavailable, err.Error(), nil, nil)
+	default:
+		response.RespondJSON(c, "error", http.StatusInternalServerError, err.Error(), nil, nil)
+	}
+}
+
 // Helper methods for validation and error handling
 
 func (ctrl *controller) validateAdminAccess(c *gin.Context) bool {
@@ -280,11 +1009,39 @@ func (ctrl *controller) parsePositiveInt(value string, defaultValue int, maxValu
 	return parsed
 }
 
-func (ctrl *controller) parseDateRange(c *gin.Context) (string, string, error) {
-	startDate := c.Query("start_date")
-	endDate := c.Query("end_date")
+// parseDateRange reads start_date and end_date as YYYY-MM-DD in UTC, both
+// inclusive, and returns an end exclusive range. It defaults to the last 30 days.
+func (ctrl *controller) parseDateRange(c *gin.Context) (time.Time, time.Time, error) {
+	now := time.Now().UTC()
+	end := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1)
+	start := end.AddDate(0, 0, -30)
+
+	if value := c.Query("end_date"); value != "" {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.New("end_date must be formatted as YYYY-MM-DD")
+		}
+		end = parsed.AddDate(0, 0, 1)
+		start = end.AddDate(0, 0, -30)
+	}
+
+	if value := c.Query("start_date"); value != "" {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.New("start_date must be formatted as YYYY-MM-DD")
+		}
+		start = parsed
+	}
+
+	if !start.Before(end) {
+		return time.Time{}, time.Time{}, errors.New("start_date must not be after end_date")
+	}
+	return start, end, nil
+}
 
-	// Add validation logic for date format if needed
-	// For now, return as-is
-	return startDate, endDate, nil
+// wantsRefresh reports whether the caller asked for live numbers with ?refresh=true
+// instead of the latest rollup
+func wantsRefresh(c *gin.Context) bool {
+	refresh, _ := strconv.ParseBool(c.Query("refresh"))
+	return refresh
 }
@@ -2,6 +2,8 @@ package analytics
 
 import (
 	"time"
+
+	"github.com/google/uuid"
 )
 
 // Dashboard & Overview Models
@@ -93,13 +95,15 @@ type GlobalEventAnalytics struct {
 }
 
 type EventPerformance struct {
-	EventID      string  `json:"event_id"`
-	EventName    string  `json:"event_name"`
-	BookingCount int     `json:"booking_count"`
-	Utilization  float64 `json:"utilization"`
-	Revenue      float64 `json:"revenue"`
-	Venue        string  `json:"venue"`
-	DateTime     string  `json:"date_time"`
+	EventID       string  `json:"event_id"`
+	EventName     string  `json:"event_name"`
+	BookingCount  int     `json:"booking_count"`
+	Utilization   float64 `json:"utilization"`
+	Revenue       float64 `json:"revenue"`
+	Venue         string  `json:"venue"`
+	DateTime      string  `json:"date_time"`
+	AverageRating float64 `json:"average_rating"` // Published attendee reviews only
+	ReviewCount   int     `json:"review_count"`
 }
 
 type DailyBooking struct {
@@ -174,6 +178,21 @@ type TagComparison struct {
 	BookingConversion float64 `json:"booking_conversion"`
 }
 
+// TagHierarchyAnalytics rolls each tag's child tags up into it: an event
+// tagged Jazz counts toward Jazz and toward Music above it, once per tag
+// however many of its tags the event carries
+type TagHierarchyAnalytics struct {
+	TagID           string  `json:"tag_id"`
+	TagName         string  `json:"tag_name"`
+	ParentID        *string `json:"parent_id,omitempty"`
+	DescendantCount int     `json:"descendant_count"` // Active tags anywhere below this one
+	OwnEventCount   int     `json:"own_event_count"`  // Events tagged with this tag itself
+	EventCount      int     `json:"event_count"`      // Events tagged with this tag or any tag below it
+	TotalBookings   int     `json:"total_bookings"`
+	TotalRevenue    float64 `json:"total_revenue"`
+	AvgUtilization  float64 `json:"avg_utilization"`
+}
+
 type TagPerformance struct {
 	TagID       string  `json:"tag_id"`
 	TagName     string  `json:"tag_name"`
@@ -306,11 +325,13 @@ type BookingInsight struct {
 }
 
 type CancellationAnalytics struct {
-	Overview            CancellationOverview  `json:"overview"`
-	CancellationReasons []CancellationReason  `json:"cancellation_reasons"`
-	TimingAnalysis      CancellationTiming    `json:"timing_analysis"`
-	FinancialImpact     CancellationFinancial `json:"financial_impact"`
-	Trends              []CancellationTrend   `json:"trends"`
+	Overview            CancellationOverview      `json:"overview"`
+	CancellationReasons []CancellationReason      `json:"cancellation_reasons"`
+	ReasonsByEvent      []CancellationReasonGroup `json:"reasons_by_event"` // Most cancelled events and their top reasons
+	ReasonsByTag        []CancellationReasonGroup `json:"reasons_by_tag"`   // Most cancelled tags and their top reasons
+	TimingAnalysis      CancellationTiming        `json:"timing_analysis"`
+	FinancialImpact     CancellationFinancial     `json:"financial_impact"`
+	Trends              []CancellationTrend       `json:"trends"`
 }
 
 type CancellationOverview struct {
@@ -324,10 +345,20 @@ type CancellationOverview struct {
 }
 
 type CancellationReason struct {
-	Reason      string  `json:"reason"`
+	Reason      string  `json:"reason"` // Reason code
 	Count       int     `json:"count"`
 	Percentage  float64 `json:"percentage"`
-	RefundTotal float64 `json:"refund_total"`
+	RefundTotal float64 `json:"refund_total"` // In the base currency
+}
+
+// CancellationReasonGroup is the reasons bookings of an event, or of events
+// with a tag, were cancelled for
+type CancellationReasonGroup struct {
+	ID            uuid.UUID            `json:"id"`
+	Name          string               `json:"name"`
+	Cancellations int                  `json:"cancellations"`
+	RefundTotal   float64              `json:"refund_total"`
+	TopReasons    []CancellationReason `json:"top_reasons"`
 }
 
 type CancellationTiming struct {
@@ -492,6 +523,7 @@ type UserBookingRecord struct {
 	BookingDate time.Time `json:"booking_date"`
 	EventDate   time.Time `json:"event_date"`
 	TotalAmount float64   `json:"total_amount"`
+	Currency    string    `json:"currency"` // Currency the booking was paid in
 	SeatCount   int       `json:"seat_count"`
 	Status      string    `json:"status"`
 }
@@ -534,10 +566,20 @@ type UserPersonalInsight struct {
 type PersonalAnalytics struct {
 	BookingPatterns  PersonalBookingPatterns  `json:"booking_patterns"`
 	SpendingInsights PersonalSpendingInsights `json:"spending_insights"`
+	Interests        PersonalInterests        `json:"interests"`
 	Recommendations  []PersonalRecommendation `json:"recommendations"`
 	Achievements     []Achievement            `json:"achievements"`
 }
 
+// PersonalInterests are the preferred tags and city on the user's profile,
+// with how many upcoming events on sale match them
+type PersonalInterests struct {
+	PreferredTags  []string `json:"preferred_tags"`
+	City           string   `json:"city,omitempty"`
+	MatchingEvents int      `json:"matching_events"` // Tagged with a preferred tag or a tag below one
+	NearbyEvents   int      `json:"nearby_events"`   // At venues in the user's city
+}
+
 type PersonalBookingPatterns struct {
 	BookingFrequency    string   `json:"booking_frequency"`
 	PreferredDay        string   `json:"preferred_day"`
@@ -570,3 +612,219 @@ type Achievement struct {
 	UnlockedAt  time.Time `json:"unlocked_at"`
 	Rarity      string    `json:"rarity"` // "common", "rare", "epic", "legendary"
 }
+
+// Yearly recap DTOs
+
+type YearlyRecap struct {
+	UserID         uuid.UUID     `json:"user_id"`
+	Year           int           `json:"year"`
+	TotalBookings  int           `json:"total_bookings"`
+	TotalTickets   int           `json:"total_tickets"`
+	TotalSpent     float64       `json:"total_spent"`
+	EventsAttended int           `json:"events_attended"`
+	BusiestMonth   string        `json:"busiest_month,omitempty"`
+	FirstBooking   *time.Time    `json:"first_booking,omitempty"`
+	FavoriteTags   []string      `json:"favorite_tags"`
+	FavoriteVenues []string      `json:"favorite_venues"`
+	Achievements   []Achievement `json:"achievements"`
+}
+
+type RecapSubscriptionRequest struct {
+	OptIn *bool `json:"opt_in" binding:"required"`
+}
+
+type RecapRunResult struct {
+	Year   int `json:"year"`
+	Queued int `json:"queued"`
+}
+
+// Scheduled analytics reports
+
+type ReportSubscriptionRequest struct {
+	Enabled  *bool           `json:"enabled" binding:"required"`
+	Sections []ReportSection `json:"sections"` // Defaults to every section
+}
+
+type ReportSubscriptionResponse struct {
+	ReportSubscription
+	Sections []ReportSection `json:"sections"`
+}
+
+// AnalyticsReport is the content of one scheduled report, before sections are picked
+type AnalyticsReport struct {
+	Frequency        ReportFrequency        `json:"frequency"`
+	PeriodStart      time.Time              `json:"period_start"`
+	PeriodEnd        time.Time              `json:"period_end"` // Exclusive
+	RevenueSummary   ReportRevenueSummary   `json:"revenue_summary"`
+	TopEvents        []ReportTopEvent       `json:"top_events"`
+	CancellationRate ReportCancellationRate `json:"cancellation_rate"`
+}
+
+// RevenueBreakdown splits confirmed booking revenue into ticket price, fees and taxes
+type RevenueBreakdown struct {
+	StartDate          time.Time      `json:"start_date"`
+	EndDate            time.Time      `json:"end_date"` // Exclusive
+	Currency           string         `json:"currency"` // Amounts are converted at each booking's exchange rate
+	ConfirmedBookings  int            `json:"confirmed_bookings"`
+	GrossRevenue       float64        `json:"gross_revenue"` // Everything customers paid
+	BaseRevenue        float64        `json:"base_revenue"`
+	SectionAdjustments float64        `json:"section_adjustments"`
+	ServiceFees        float64        `json:"service_fees"`
+	TaxCollected       float64        `json:"tax_collected"`
+	NetRevenue         float64        `json:"net_revenue"`        // Gross revenue less tax
+	UnitemizedRevenue  float64        `json:"unitemized_revenue"` // Bookings made before charges were itemized
+	Taxes              []TaxCollected `json:"taxes"`
+}
+
+// TaxCollected is the amount collected for one tax rule
+type TaxCollected struct {
+	Name   string  `json:"name"`
+	Rate   float64 `json:"rate"`
+	Amount float64 `json:"amount"`
+}
+
+type ReportRevenueSummary struct {
+	TotalRevenue      float64 `json:"total_revenue"`
+	PreviousRevenue   float64 `json:"previous_revenue"` // Same length period just before
+	ConfirmedBookings int     `json:"confirmed_bookings"`
+	TicketsSold       int     `json:"tickets_sold"`
+	AverageOrder      float64 `json:"average_order"`
+}
+
+type ReportTopEvent struct {
+	EventID   uuid.UUID `json:"event_id"`
+	EventName string    `json:"event_name"`
+	Bookings  int       `json:"bookings"`
+	Revenue   float64   `json:"revenue"`
+}
+
+type ReportCancellationRate struct {
+	TotalBookings     int     `json:"total_bookings"`
+	CancelledBookings int     `json:"cancelled_bookings"`
+	Rate              float64 `json:"rate"` // Percent of the period's bookings
+}
+
+// ReportPreview is a report email rendered as it would be sent now
+type ReportPreview struct {
+	Subject  string           `json:"subject"`
+	HTML     string           `json:"html"`
+	Text     string           `json:"text"`
+	Sections []ReportSection  `json:"sections"`
+	Report   *AnalyticsReport `json:"report"`
+}
+
+type ReportRunResult struct {
+	Frequency   ReportFrequency `json:"frequency"`
+	PeriodStart time.Time       `json:"period_start"`
+	Queued      int             `json:"queued"`
+}
+
+// Shared event analytics
+
+type ShareLinkRequest struct {
+	Label          string         `json:"label" binding:"required,max=100"`           // Who the link is for, e.g. the sponsor's name
+	Sections       []ShareSection `json:"sections"`                                   // Defaults to sales_curve, utilization and demographics
+	ExpiresInHours int            `json:"expires_in_hours" binding:"omitempty,min=1"` // Defaults to a week
+}
+
+// Share link states
+const (
+	ShareLinkStatusActive  = "ACTIVE"
+	ShareLinkStatusExpired = "EXPIRED"
+	ShareLinkStatusRevoked = "REVOKED"
+)
+
+type ShareLinkResponse struct {
+	ShareLink
+	Sections []ShareSection `json:"sections"`
+	Status   string         `json:"status"`
+	URL      string         `json:"url,omitempty"` // Only while the link is active
+}
+
+// SharedEventAnalytics is the read-only snapshot behind a share link. Sections
+// that were not shared are left out.
+type SharedEventAnalytics struct {
+	EventID      uuid.UUID           `json:"event_id"`
+	EventName    string              `json:"event_name"`
+	Venue        string              `json:"venue"`
+	DateTime     time.Time           `json:"date_time"`
+	SharedWith   string              `json:"shared_with"`
+	Sections     []ShareSection      `json:"sections"`
+	GeneratedAt  time.Time           `json:"generated_at"`
+	ExpiresAt    time.Time           `json:"expires_at"`
+	SalesCurve   []SalesCurvePoint   `json:"sales_curve,omitempty"`
+	Revenue      *SharedRevenue      `json:"revenue,omitempty"`
+	Utilization  *SharedUtilization  `json:"utilization,omitempty"`
+	Demographics *SharedDemographics `json:"demographics,omitempty"`
+}
+
+// SalesCurvePoint is one day of confirmed sales with the running ticket total
+type SalesCurvePoint struct {
+	Date              string `json:"date"`
+	Bookings          int    `json:"bookings"`
+	Tickets           int    `json:"tickets"`
+	CumulativeTickets int    `json:"cumulative_tickets"`
+}
+
+type SharedRevenue struct {
+	Currency     string         `json:"currency"`
+	Total        float64        `json:"total"`
+	AverageOrder float64        `json:"average_order"`
+	ByDay        []DailyBooking `json:"by_day"`
+}
+
+type SharedUtilization struct {
+	Capacity    int                  `json:"capacity"`
+	TicketsSold int                  `json:"tickets_sold"`
+	Utilization float64              `json:"utilization"` // Percent of capacity
+	Sections    []SectionUtilization `json:"sections"`
+}
+
+type SectionUtilization struct {
+	SectionName string  `json:"section_name"`
+	Capacity    int     `json:"capacity"`
+	TicketsSold int     `json:"tickets_sold"`
+	Utilization float64 `json:"utilization"`
+}
+
+// SharedDemographics describes the event's audience in aggregate. Breakdowns are
+// withheld while there are too few attendees to keep them anonymous.
+type SharedDemographics struct {
+	Attendees  int                 `json:"attendees"`
+	Suppressed bool                `json:"suppressed"`
+	Audience   []DemographicBucket `json:"audience,omitempty"`    // New and returning customers
+	AccountAge []DemographicBucket `json:"account_age,omitempty"` // How long attendees had an account when they booked
+	GroupSizes []DemographicBucket `json:"group_sizes,omitempty"` // Tickets per booking
+}
+
+type DemographicBucket struct {
+	Label   string  `json:"label"`
+	Count   int     `json:"count"`
+	Percent float64 `json:"percent"`
+}
+
+// EventSalesDay is one day of an event's confirmed sales
+type EventSalesDay struct {
+	Date     string
+	Bookings int
+	Tickets  int
+	Revenue  float64
+}
+
+// EventDemographicCounts are the raw attendee counts behind SharedDemographics
+type EventDemographicCounts struct {
+	Attendees          int
+	ReturningAttendees int
+	AccountUnderMonth  int
+	AccountUnderYear   int
+	AccountOverYear    int
+	GroupSizes         EventGroupSizeCounts `gorm:"-"`
+}
+
+// EventGroupSizeCounts counts confirmed bookings by tickets per booking
+type EventGroupSizeCounts struct {
+	SoloBookings       int
+	PairBookings       int
+	SmallGroupBookings int
+	LargeGroupBookings int
+}
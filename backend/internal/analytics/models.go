@@ -0,0 +1,188 @@
+package analytics
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RecapSubscription stores a user's opt-in for the yearly recap email
+type RecapSubscription struct {
+	UserID       uuid.UUID `json:"user_id" gorm:"type:uuid;primaryKey"`
+	OptedIn      bool      `json:"opted_in" gorm:"not null;default:false;index"`
+	LastSentYear int       `json:"last_sent_year" gorm:"not null;default:0"` // Last year a recap was queued for
+	CreatedAt    time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt    time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (RecapSubscription) TableName() string {
+	return "yearly_recap_subscriptions"
+}
+
+// ReportFrequency is how often a scheduled analytics report is emailed
+type ReportFrequency string
+
+const (
+	ReportFrequencyWeekly  ReportFrequency = "WEEKLY"  // Monday to Sunday, sent the following Monday
+	ReportFrequencyMonthly ReportFrequency = "MONTHLY" // Calendar month, sent on the 1st
+)
+
+// ReportSection is one block of the analytics report email
+type ReportSection string
+
+const (
+	ReportSectionRevenueSummary   ReportSection = "revenue_summary"
+	ReportSectionTopEvents        ReportSection = "top_events"
+	ReportSectionCancellationRate ReportSection = "cancellation_rate"
+)
+
+// AllReportSections is the default section list, in the order they are rendered
+var AllReportSections = []ReportSection{ReportSectionRevenueSummary, ReportSectionTopEvents, ReportSectionCancellationRate}
+
+// ReportSubscription is an admin's opt-in for one scheduled analytics report
+type ReportSubscription struct {
+	ID              uuid.UUID       `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	AdminID         uuid.UUID       `json:"admin_id" gorm:"type:uuid;not null;uniqueIndex:idx_report_subscriptions_admin_frequency"`
+	Frequency       ReportFrequency `json:"frequency" gorm:"type:varchar(10);not null;check:frequency IN ('WEEKLY', 'MONTHLY');uniqueIndex:idx_report_subscriptions_admin_frequency"`
+	Sections        string          `json:"-" gorm:"type:varchar(255);not null"` // Comma separated ReportSection values
+	Enabled         bool            `json:"enabled" gorm:"not null;default:false;index"`
+	LastPeriodStart *time.Time      `json:"last_period_start,omitempty"` // Start of the last period a report was queued for
+	CreatedAt       time.Time       `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt       time.Time       `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (ReportSubscription) TableName() string {
+	return "analytics_report_subscriptions"
+}
+
+// SectionList returns the subscribed sections in rendering order
+func (s *ReportSubscription) SectionList() []ReportSection {
+	chosen := make(map[ReportSection]bool)
+	for _, section := range strings.Split(s.Sections, ",") {
+		chosen[ReportSection(strings.TrimSpace(section))] = true
+	}
+
+	sections := make([]ReportSection, 0, len(AllReportSections))
+	for _, section := range AllReportSections {
+		if chosen[section] {
+			sections = append(sections, section)
+		}
+	}
+	return sections
+}
+
+// ShareSection is one block of a shared event analytics snapshot
+type ShareSection string
+
+const (
+	ShareSectionSalesCurve   ShareSection = "sales_curve"
+	ShareSectionRevenue      ShareSection = "revenue"
+	ShareSectionUtilization  ShareSection = "utilization"
+	ShareSectionDemographics ShareSection = "demographics"
+)
+
+// AllShareSections lists every section in the order they are shown
+var AllShareSections = []ShareSection{ShareSectionSalesCurve, ShareSectionRevenue, ShareSectionUtilization, ShareSectionDemographics}
+
+// DefaultShareSections leaves revenue out, so it is only shared on purpose
+var DefaultShareSections = []ShareSection{ShareSectionSalesCurve, ShareSectionUtilization, ShareSectionDemographics}
+
+// ShareLink lets a stakeholder without an account, such as a sponsor or venue
+// manager, view a read-only analytics snapshot of one event. The token is not
+// stored; it is signed from the link ID and expiry, so revoking the link or
+// letting it expire is what turns it off.
+type ShareLink struct {
+	ID           uuid.UUID  `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	EventID      uuid.UUID  `json:"event_id" gorm:"type:uuid;not null;index"`
+	Label        string     `json:"label" gorm:"type:varchar(100);not null"` // Who the link was made for
+	Sections     string     `json:"-" gorm:"type:varchar(255);not null"`     // Comma separated ShareSection values
+	ExpiresAt    time.Time  `json:"expires_at" gorm:"not null"`
+	RevokedAt    *time.Time `json:"revoked_at,omitempty"`
+	CreatedBy    uuid.UUID  `json:"created_by" gorm:"type:uuid;not null"`
+	ViewCount    int        `json:"view_count" gorm:"not null;default:0"`
+	LastViewedAt *time.Time `json:"last_viewed_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
+
+func (ShareLink) TableName() string {
+	return "analytics_share_links"
+}
+
+// SectionList returns the shared sections in display order
+func (l *ShareLink) SectionList() []ShareSection {
+	chosen := make(map[ShareSection]bool)
+	for _, section := range strings.Split(l.Sections, ",") {
+		chosen[ShareSection(strings.TrimSpace(section))] = true
+	}
+
+	sections := make([]ShareSection, 0, len(AllShareSections))
+	for _, section := range AllShareSections {
+		if chosen[section] {
+			sections = append(sections, section)
+		}
+	}
+	return sections
+}
+
+// Shares reports whether the link includes section
+func (l *ShareLink) Shares(section ShareSection) bool {
+	for _, shared := range l.SectionList() {
+		if shared == section {
+			return true
+		}
+	}
+	return false
+}
+
+// Rollup tables are rebuilt by the RollupJob so dashboards read precomputed
+// aggregates instead of scanning bookings on every request
+
+// DailyBookingsAgg holds one day of booking totals
+type DailyBookingsAgg struct {
+	Date              time.Time `gorm:"type:date;primaryKey"`
+	TotalBookings     int       `gorm:"not null;default:0"`
+	ConfirmedBookings int       `gorm:"not null;default:0"`
+	CancelledBookings int       `gorm:"not null;default:0"`
+	Revenue           float64   `gorm:"not null;default:0"`
+	AverageValue      float64   `gorm:"not null;default:0"`
+	RefreshedAt       time.Time `gorm:"not null"`
+}
+
+func (DailyBookingsAgg) TableName() string {
+	return "daily_bookings_agg"
+}
+
+// EventRevenueAgg holds confirmed bookings, revenue and ratings per event
+type EventRevenueAgg struct {
+	EventID       uuid.UUID `gorm:"type:uuid;primaryKey"`
+	EventName     string    `gorm:"not null"`
+	Venue         string
+	DateTime      time.Time
+	BookingCount  int       `gorm:"not null;default:0;index"`
+	Revenue       float64   `gorm:"not null;default:0"`
+	TicketsSold   int       `gorm:"not null;default:0"`
+	Capacity      int       `gorm:"not null;default:0"` // Seats of the event's venue template
+	AverageRating float64   `gorm:"not null;default:0"`
+	ReviewCount   int       `gorm:"not null;default:0"`
+	RefreshedAt   time.Time `gorm:"not null"`
+}
+
+func (EventRevenueAgg) TableName() string {
+	return "event_revenue_agg"
+}
+
+// TagPopularityAgg holds event and booking totals per tag
+type TagPopularityAgg struct {
+	TagID          uuid.UUID `gorm:"type:uuid;primaryKey"`
+	TagName        string    `gorm:"not null"`
+	EventCount     int       `gorm:"not null;default:0"`
+	TotalBookings  int       `gorm:"not null;default:0;index"`
+	TotalRevenue   float64   `gorm:"not null;default:0"`
+	AvgUtilization float64   `gorm:"not null;default:0"`
+	RefreshedAt    time.Time `gorm:"not null"`
+}
+
+func (TagPopularityAgg) TableName() string {
+	return "tag_popularity_agg"
+}
@@ -0,0 +1,99 @@
+package analytics
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// RecapJobConfig contains configuration for the yearly recap job
+type RecapJobConfig struct {
+	CheckInterval time.Duration
+	SendMonth     time.Month // Recaps for the previous year go out from SendDay of this month
+	SendDay       int
+	BatchSize     int
+	BatchInterval time.Duration
+}
+
+// DefaultRecapJobConfig returns default recap job configuration
+func DefaultRecapJobConfig() *RecapJobConfig {
+	return &RecapJobConfig{
+		CheckInterval: time.Hour,        // Check hourly whether recaps are due
+		SendMonth:     time.January,     // Send last year's recap in January
+		SendDay:       2,                // Starting on the 2nd, once the year's bookings have settled
+		BatchSize:     100,              // Queue 100 recaps per batch
+		BatchInterval: 30 * time.Second, // Pause between batches to rate limit email sends
+	}
+}
+
+// RecapJob sends the opt-in "year in events" recap email
+type RecapJob struct {
+	service Service
+	config  *RecapJobConfig
+	done    chan struct{}
+}
+
+// NewRecapJob creates a new recap job
+func NewRecapJob(service Service, config *RecapJobConfig) *RecapJob {
+	if config == nil {
+		config = DefaultRecapJobConfig()
+	}
+
+	return &RecapJob{
+		service: service,
+		config:  config,
+		done:    make(chan struct{}),
+	}
+}
+
+// Start starts the recap job
+func (j *RecapJob) Start(ctx context.Context) {
+	log.Printf("Started yearly recap job with %v interval", j.config.CheckInterval)
+	go j.run(ctx)
+}
+
+// Stop stops the recap job
+func (j *RecapJob) Stop() {
+	close(j.done)
+}
+
+func (j *RecapJob) run(ctx context.Context) {
+	ticker := time.NewTicker(j.config.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			j.sendIfDue(ctx)
+		case <-j.done:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// sendIfDue queues last year's recaps during the send month. Users whose recap
+// was already queued are skipped, so repeated runs only pick up stragglers.
+func (j *RecapJob) sendIfDue(ctx context.Context) {
+	now := time.Now()
+	if now.Month() != j.config.SendMonth || now.Day() < j.config.SendDay {
+		return
+	}
+
+	// A December send covers the current year, any other month the previous one
+	year := now.Year() - 1
+	if j.config.SendMonth == time.December {
+		year = now.Year()
+	}
+
+	result, err := j.service.SendYearlyRecaps(ctx, year, j.config.BatchSize, j.config.BatchInterval)
+	if err != nil {
+		log.Printf("Failed to send yearly recaps for %d: %v", year, err)
+		return
+	}
+
+	if result.Queued > 0 {
+		log.Printf("Queued %d yearly recap emails for %d", result.Queued, year)
+	}
+}
@@ -0,0 +1,92 @@
+package analytics
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// ReportJobConfig contains configuration for the scheduled report job
+type ReportJobConfig struct {
+	CheckInterval time.Duration
+	SendHour      int // UTC hour from which the previous period's reports go out
+	BatchSize     int
+}
+
+// DefaultReportJobConfig returns default report job configuration
+func DefaultReportJobConfig() *ReportJobConfig {
+	return &ReportJobConfig{
+		CheckInterval: time.Hour, // Check hourly whether reports are due
+		SendHour:      7,         // Send at 07:00 UTC, after the nightly rollup
+		BatchSize:     100,
+	}
+}
+
+// ReportJob emails weekly and monthly analytics reports to subscribed admins
+type ReportJob struct {
+	service Service
+	config  *ReportJobConfig
+	done    chan struct{}
+}
+
+// NewReportJob creates a new report job
+func NewReportJob(service Service, config *ReportJobConfig) *ReportJob {
+	if config == nil {
+		config = DefaultReportJobConfig()
+	}
+
+	return &ReportJob{
+		service: service,
+		config:  config,
+		done:    make(chan struct{}),
+	}
+}
+
+// Start starts the report job
+func (j *ReportJob) Start(ctx context.Context) {
+	log.Printf("Started analytics report job with %v interval", j.config.CheckInterval)
+	go j.run(ctx)
+}
+
+// Stop stops the report job
+func (j *ReportJob) Stop() {
+	close(j.done)
+}
+
+func (j *ReportJob) run(ctx context.Context) {
+	ticker := time.NewTicker(j.config.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			j.sendIfDue(ctx)
+		case <-j.done:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// sendIfDue queues reports once the send hour has passed. Subscriptions already
+// sent the last period are skipped, so a run missed on the due day is caught up
+// the next day, and new subscribers get the latest finished period.
+func (j *ReportJob) sendIfDue(ctx context.Context) {
+	now := time.Now().UTC()
+	if now.Hour() < j.config.SendHour {
+		return
+	}
+
+	for _, frequency := range []ReportFrequency{ReportFrequencyWeekly, ReportFrequencyMonthly} {
+		result, err := j.service.SendScheduledReports(ctx, frequency, now, j.config.BatchSize)
+		if err != nil {
+			log.Printf("Failed to send %s analytics reports: %v", frequency, err)
+			continue
+		}
+
+		if result.Queued > 0 {
+			log.Printf("Queued %d %s analytics reports for the period from %s", result.Queued, frequency, result.PeriodStart.Format("2006-01-02"))
+		}
+	}
+}
@@ -0,0 +1,273 @@
+package analytics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"evently/internal/outbox"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// reportTopEventsLimit is how many events the top events section lists
+const reportTopEventsLimit = 5
+
+var (
+	ErrInvalidReportFrequency = errors.New("invalid report frequency")
+	ErrInvalidReportSection   = errors.New("invalid report section")
+	ErrReportPreviewDisabled  = errors.New("report preview is not available")
+)
+
+// ReportRenderer renders report emails the way the notification service sends them
+type ReportRenderer interface {
+	RenderReport(recipientName string, templateData map[string]interface{}) (subject, htmlBody, textBody string, err error)
+}
+
+// SetReportRenderer enables the report preview endpoint
+func (s *service) SetReportRenderer(renderer ReportRenderer) {
+	s.reportRenderer = renderer
+}
+
+// ParseReportFrequency accepts "weekly" or "monthly" in any case
+func ParseReportFrequency(value string) (ReportFrequency, error) {
+	switch frequency := ReportFrequency(strings.ToUpper(strings.TrimSpace(value))); frequency {
+	case ReportFrequencyWeekly, ReportFrequencyMonthly:
+		return frequency, nil
+	default:
+		return "", ErrInvalidReportFrequency
+	}
+}
+
+// reportPeriod returns the last full period of the frequency before now, in UTC.
+// The end is exclusive.
+func reportPeriod(frequency ReportFrequency, now time.Time) (time.Time, time.Time) {
+	now = now.UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	if frequency == ReportFrequencyMonthly {
+		end := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return end.AddDate(0, -1, 0), end
+	}
+
+	daysSinceMonday := (int(today.Weekday()) + 6) % 7
+	end := today.AddDate(0, 0, -daysSinceMonday)
+	return end.AddDate(0, 0, -7), end
+}
+
+func (s *service) GetReportSubscriptions(adminID uuid.UUID) ([]ReportSubscriptionResponse, error) {
+	subscriptions, err := s.repo.GetReportSubscriptions(adminID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]ReportSubscriptionResponse, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		responses = append(responses, ReportSubscriptionResponse{
+			ReportSubscription: subscription,
+			Sections:           subscription.SectionList(),
+		})
+	}
+	return responses, nil
+}
+
+func (s *service) SetReportSubscription(adminID uuid.UUID, frequency ReportFrequency, req ReportSubscriptionRequest) (*ReportSubscriptionResponse, error) {
+	sections := req.Sections
+	if len(sections) == 0 {
+		sections = AllReportSections
+	}
+
+	names := make([]string, 0, len(sections))
+	for _, section := range sections {
+		if !isReportSection(section) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidReportSection, section)
+		}
+		names = append(names, string(section))
+	}
+
+	subscription, err := s.repo.UpsertReportSubscription(&ReportSubscription{
+		AdminID:   adminID,
+		Frequency: frequency,
+		Sections:  strings.Join(names, ","),
+		Enabled:   *req.Enabled,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReportSubscriptionResponse{ReportSubscription: *subscription, Sections: subscription.SectionList()}, nil
+}
+
+func isReportSection(section ReportSection) bool {
+	for _, known := range AllReportSections {
+		if section == known {
+			return true
+		}
+	}
+	return false
+}
+
+// BuildReport gathers every section of the report for one period
+func (s *service) BuildReport(frequency ReportFrequency, start, end time.Time) (*AnalyticsReport, error) {
+	revenue, err := s.repo.GetReportRevenue(start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	previousStart := start.AddDate(0, 0, -7)
+	if frequency == ReportFrequencyMonthly {
+		previousStart = start.AddDate(0, -1, 0)
+	}
+	previous, err := s.repo.GetReportRevenue(previousStart, start)
+	if err != nil {
+		return nil, err
+	}
+	revenue.PreviousRevenue = previous.TotalRevenue
+
+	topEvents, err := s.repo.GetReportTopEvents(start, end, reportTopEventsLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	cancellations, err := s.repo.GetReportCancellationRate(start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AnalyticsReport{
+		Frequency:        frequency,
+		PeriodStart:      start,
+		PeriodEnd:        end,
+		RevenueSummary:   *revenue,
+		TopEvents:        topEvents,
+		CancellationRate: *cancellations,
+	}, nil
+}
+
+// reportTemplateData keeps only the sections a subscription asked for
+func reportTemplateData(report *AnalyticsReport, sections []ReportSection) map[string]interface{} {
+	data := map[string]interface{}{
+		"frequency":    strings.ToLower(string(report.Frequency)),
+		"period_start": report.PeriodStart.Format("Jan 2, 2006"),
+		"period_end":   report.PeriodEnd.AddDate(0, 0, -1).Format("Jan 2, 2006"),
+	}
+
+	for _, section := range sections {
+		switch section {
+		case ReportSectionRevenueSummary:
+			data[string(section)] = report.RevenueSummary
+		case ReportSectionTopEvents:
+			data[string(section)] = report.TopEvents
+		case ReportSectionCancellationRate:
+			data[string(section)] = report.CancellationRate
+		}
+	}
+	return data
+}
+
+// PreviewReport renders the admin's report for the last full period without sending it.
+// Admins without a subscription see every section.
+func (s *service) PreviewReport(adminID uuid.UUID, frequency ReportFrequency) (*ReportPreview, error) {
+	if s.reportRenderer == nil {
+		return nil, ErrReportPreviewDisabled
+	}
+
+	sections := AllReportSections
+	subscription, err := s.repo.GetReportSubscription(adminID, frequency)
+	if err == nil {
+		sections = subscription.SectionList()
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to get report subscription: %w", err)
+	}
+
+	start, end := reportPeriod(frequency, time.Now())
+	report, err := s.BuildReport(frequency, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := s.repo.GetUserFirstName(adminID)
+	if err != nil || name == "" {
+		name = "Admin"
+	}
+
+	subject, htmlBody, textBody, err := s.reportRenderer.RenderReport(name, reportTemplateData(report, sections))
+	if err != nil {
+		return nil, fmt.Errorf("failed to render report: %w", err)
+	}
+
+	return &ReportPreview{
+		Subject:  subject,
+		HTML:     htmlBody,
+		Text:     textBody,
+		Sections: sections,
+		Report:   report,
+	}, nil
+}
+
+// SendScheduledReports queues the last full period's report for every due
+// subscription of the frequency. The report is built once and trimmed to each
+// admin's sections; admins already sent this period are skipped, so repeated
+// runs only pick up stragglers.
+func (s *service) SendScheduledReports(ctx context.Context, frequency ReportFrequency, now time.Time, batchSize int) (*ReportRunResult, error) {
+	start, end := reportPeriod(frequency, now)
+	result := &ReportRunResult{Frequency: frequency, PeriodStart: start}
+
+	var report *AnalyticsReport
+	failed := make(map[uuid.UUID]bool)
+
+	for {
+		subscriptions, err := s.repo.GetDueReportSubscriptions(frequency, start, batchSize+len(failed))
+		if err != nil {
+			return result, err
+		}
+
+		progressed := false
+		for _, subscription := range subscriptions {
+			if failed[subscription.ID] {
+				continue
+			}
+
+			if report == nil {
+				if report, err = s.BuildReport(frequency, start, end); err != nil {
+					return result, err
+				}
+			}
+
+			if err := s.queueReport(&subscription, report); err != nil {
+				failed[subscription.ID] = true
+				continue
+			}
+			result.Queued++
+			progressed = true
+		}
+
+		// Stop when only failing subscriptions are left
+		if !progressed {
+			return result, nil
+		}
+
+		if ctx.Err() != nil {
+			return result, ctx.Err()
+		}
+	}
+}
+
+func (s *service) queueReport(subscription *ReportSubscription, report *AnalyticsReport) error {
+	payload := &outbox.NotificationPayload{
+		Type:         "ANALYTICS_REPORT",
+		RecipientID:  subscription.AdminID,
+		TemplateData: reportTemplateData(report, subscription.SectionList()),
+	}
+
+	key := fmt.Sprintf("report:%s:%s", subscription.ID, report.PeriodStart.Format("2006-01-02"))
+	message, err := outbox.NewNotificationMessage(outbox.AggregateUser, subscription.AdminID, key, payload)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.MarkReportQueued(subscription.ID, report.PeriodStart, message)
+}
@@ -1,11 +1,17 @@
 package analytics
 
 import (
+	"errors"
 	"fmt"
+	"sort"
 	"time"
 
+	"evently/internal/outbox"
+	"evently/internal/shared/dbresolver"
+
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // Repository defines the analytics repository interface
@@ -27,6 +33,7 @@ type Repository interface {
 	GetTagTrends(months int) ([]TagTrend, error)
 	GetTagComparisons() ([]TagComparison, error)
 	GetTagOverview() (*TagOverview, error)
+	GetTagHierarchyAnalytics() ([]TagHierarchyAnalytics, error)
 
 	// Booking Analytics
 	GetBookingAnalytics() (*BookingAnalytics, error)
@@ -43,16 +50,56 @@ type Repository interface {
 	// User-facing Analytics
 	GetUserBookingHistory(userID uuid.UUID) (*UserBookingHistory, error)
 	GetPersonalAnalytics(userID uuid.UUID) (*PersonalAnalytics, error)
+
+	// Yearly recap
+	GetYearlyRecap(userID uuid.UUID, year int) (*YearlyRecap, error)
+	GetRecapSubscription(userID uuid.UUID) (*RecapSubscription, error)
+	UpsertRecapSubscription(userID uuid.UUID, optedIn bool) (*RecapSubscription, error)
+	GetRecapRecipients(year int, limit int) ([]uuid.UUID, error)
+	MarkRecapQueued(userID uuid.UUID, year int, message *outbox.Message) error
+
+	// Scheduled reports
+	GetReportSubscriptions(adminID uuid.UUID) ([]ReportSubscription, error)
+	GetReportSubscription(adminID uuid.UUID, frequency ReportFrequency) (*ReportSubscription, error)
+	UpsertReportSubscription(subscription *ReportSubscription) (*ReportSubscription, error)
+	GetDueReportSubscriptions(frequency ReportFrequency, periodStart time.Time, limit int) ([]ReportSubscription, error)
+	MarkReportQueued(subscriptionID uuid.UUID, periodStart time.Time, message *outbox.Message) error
+	GetReportRevenue(start, end time.Time) (*ReportRevenueSummary, error)
+	GetReportTopEvents(start, end time.Time, limit int) ([]ReportTopEvent, error)
+	GetReportCancellationRate(start, end time.Time) (*ReportCancellationRate, error)
+	GetUserFirstName(userID uuid.UUID) (string, error)
+
+	// Fees and taxes
+	GetRevenueBreakdown(start, end time.Time) (*RevenueBreakdown, error)
+
+	// Shared event analytics
+	CreateShareLink(link *ShareLink) error
+	GetShareLink(id uuid.UUID) (*ShareLink, error)
+	GetShareLinks(eventID uuid.UUID) ([]ShareLink, error)
+	RevokeShareLink(id uuid.UUID, revokedAt time.Time) error
+	RecordShareLinkView(id uuid.UUID, viewedAt time.Time) error
+	GetSharedEvent(eventID uuid.UUID) (*SharedEventAnalytics, error)
+	GetEventSalesByDay(eventID uuid.UUID) ([]EventSalesDay, error)
+	GetEventSectionUtilization(eventID uuid.UUID) ([]SectionUtilization, error)
+	GetEventDemographics(eventID uuid.UUID) (*EventDemographicCounts, error)
+
+	// Rollups
+	Live() Repository
+	RefreshDailyBookingsAgg(since time.Time) error
+	RefreshEventRevenueAgg() error
+	RefreshTagPopularityAgg() error
 }
 
 // repository implements the Repository interface
 type repository struct {
-	db *gorm.DB
+	db   *gorm.DB
+	read *gorm.DB // Reports, served by a read replica when one is configured
+	live bool     // Aggregate the source tables instead of reading the rollup tables
 }
 
 // NewRepository creates a new analytics repository instance
 func NewRepository(db *gorm.DB) Repository {
-	return &repository{db: db}
+	return &repository{db: db, read: dbresolver.ReadDB(db)}
 }
 
 // Dashboard Analytics Implementation
@@ -150,16 +197,16 @@ func (r *repository) GetOverviewMetrics() (*OverviewMetrics, error) {
 
 	// Get total events
 	var totalEvents int64
-	err := r.db.Table("events").Count(&totalEvents).Error
+	err := r.read.Table("events").Where("deleted_at IS NULL").Count(&totalEvents).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to count events: %w", err)
 	}
 	metrics.TotalEvents = int(totalEvents)
 
-	// Get active events (published and upcoming)
+	// Get active events (on sale and upcoming)
 	var activeEvents int64
-	err = r.db.Table("events").
-		Where("status = ? AND date_time > ?", "published", time.Now()).
+	err = r.read.Table("events").
+		Where("status IN ? AND date_time > ? AND deleted_at IS NULL", []string{"published", "sold_out"}, time.Now()).
 		Count(&activeEvents).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to count active events: %w", err)
@@ -168,7 +215,7 @@ func (r *repository) GetOverviewMetrics() (*OverviewMetrics, error) {
 
 	// Get total bookings
 	var totalBookings int64
-	err = r.db.Table("bookings").
+	err = r.read.Table("bookings").
 		Where("status = ?", "CONFIRMED").
 		Count(&totalBookings).Error
 	if err != nil {
@@ -177,9 +224,9 @@ func (r *repository) GetOverviewMetrics() (*OverviewMetrics, error) {
 	metrics.TotalBookings = int(totalBookings)
 
 	// Get total revenue
-	err = r.db.Table("bookings").
+	err = r.read.Table("bookings").
 		Where("status = ?", "CONFIRMED").
-		Select("COALESCE(SUM(total_price), 0)").
+		Select("COALESCE(SUM(base_total_price), 0)").
 		Scan(&metrics.TotalRevenue).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to calculate total revenue: %w", err)
@@ -187,10 +234,10 @@ func (r *repository) GetOverviewMetrics() (*OverviewMetrics, error) {
 
 	// Get total users (assuming a users table exists)
 	var totalUsers int64
-	err = r.db.Table("users").Count(&totalUsers).Error
+	err = r.read.Table("users").Where("deleted_at IS NULL").Count(&totalUsers).Error
 	if err != nil {
 		// If users table doesn't exist, count unique user IDs from bookings
-		err = r.db.Table("bookings").
+		err = r.read.Table("bookings").
 			Select("COUNT(DISTINCT user_id)").
 			Scan(&totalUsers).Error
 		if err != nil {
@@ -201,28 +248,30 @@ func (r *repository) GetOverviewMetrics() (*OverviewMetrics, error) {
 
 	// Calculate cancellation rate
 	var allBookings, cancelledBookings int64
-	r.db.Table("bookings").Count(&allBookings)
-	r.db.Table("bookings").Where("status = ?", "CANCELLED").Count(&cancelledBookings)
+	r.read.Table("bookings").Count(&allBookings)
+	r.read.Table("bookings").Where("status = ?", "CANCELLED").Count(&cancelledBookings)
 	if allBookings > 0 {
 		metrics.CancellationRate = float64(cancelledBookings) / float64(allBookings) * 100
 	}
 
-	// Note: Average utilization calculation requires venue capacity data
-	metrics.AvgUtilization = 0.0
+	metrics.AvgUtilization, err = r.getAverageUtilization()
+	if err != nil {
+		return nil, err
+	}
 
 	// Calculate revenue growth (comparing last 30 days to previous 30 days)
 	var currentRevenue, previousRevenue float64
 	currentStart := time.Now().AddDate(0, 0, -30)
 	previousStart := time.Now().AddDate(0, 0, -60)
 
-	r.db.Table("bookings").
+	r.read.Table("bookings").
 		Where("status = ? AND created_at >= ?", "CONFIRMED", currentStart).
-		Select("COALESCE(SUM(total_price), 0)").
+		Select("COALESCE(SUM(base_total_price), 0)").
 		Scan(&currentRevenue)
 
-	r.db.Table("bookings").
+	r.read.Table("bookings").
 		Where("status = ? AND created_at >= ? AND created_at < ?", "CONFIRMED", previousStart, currentStart).
-		Select("COALESCE(SUM(total_price), 0)").
+		Select("COALESCE(SUM(base_total_price), 0)").
 		Scan(&previousRevenue)
 
 	if previousRevenue > 0 {
@@ -243,7 +292,7 @@ func (r *repository) GetRecentActivity(limit int) ([]RecentActivityItem, error)
 		EventName string    `json:"event_name"`
 	}
 
-	err := r.db.Table("bookings b").
+	err := r.read.Table("bookings b").
 		Select("b.user_id, b.event_id, b.created_at, e.name as event_name").
 		Joins("JOIN events e ON e.id = b.event_id").
 		Where("b.status = ?", "CONFIRMED").
@@ -275,7 +324,7 @@ func (r *repository) GetRecentActivity(limit int) ([]RecentActivityItem, error)
 		EventName   string     `json:"event_name"`
 	}
 
-	err = r.db.Table("bookings b").
+	err = r.read.Table("bookings b").
 		Select("b.user_id, b.event_id, b.cancelled_at, e.name as event_name").
 		Joins("JOIN events e ON e.id = b.event_id").
 		Where("b.status = ? AND b.cancelled_at IS NOT NULL", "CANCELLED").
@@ -308,8 +357,9 @@ func (r *repository) GetRecentActivity(limit int) ([]RecentActivityItem, error)
 		CreatedAt time.Time `json:"created_at"`
 	}
 
-	err = r.db.Table("events").
+	err = r.read.Table("events").
 		Select("id, name, created_at").
+		Where("deleted_at IS NULL").
 		Order("created_at DESC").
 		Limit(limit / 4).
 		Scan(&recentEvents).Error
@@ -357,7 +407,7 @@ func (r *repository) GetEventAnalytics(eventID uuid.UUID) (*EventAnalytics, erro
 		Name string    `json:"name"`
 	}
 
-	err := r.db.Table("events").
+	err := r.read.Table("events").
 		Where("id = ?", eventID).
 		Select("id, name").
 		Scan(&event).Error
@@ -370,7 +420,7 @@ func (r *repository) GetEventAnalytics(eventID uuid.UUID) (*EventAnalytics, erro
 
 	// Get booking statistics
 	var bookingCount int64
-	err = r.db.Table("bookings").
+	err = r.read.Table("bookings").
 		Where("event_id = ? AND status = ?", eventID, "CONFIRMED").
 		Count(&bookingCount).Error
 	if err != nil {
@@ -378,9 +428,9 @@ func (r *repository) GetEventAnalytics(eventID uuid.UUID) (*EventAnalytics, erro
 	}
 	analytics.TotalBookings = int(bookingCount)
 
-	err = r.db.Table("bookings").
+	err = r.read.Table("bookings").
 		Where("event_id = ? AND status = ?", eventID, "CONFIRMED").
-		Select("COALESCE(SUM(total_price), 0)").
+		Select("COALESCE(SUM(base_total_price), 0)").
 		Scan(&analytics.TotalRevenue).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to calculate revenue: %w", err)
@@ -388,19 +438,19 @@ func (r *repository) GetEventAnalytics(eventID uuid.UUID) (*EventAnalytics, erro
 
 	// Calculate cancellation rate
 	var totalBookings, cancelledBookings int64
-	r.db.Table("bookings").Where("event_id = ?", eventID).Count(&totalBookings)
-	r.db.Table("bookings").Where("event_id = ? AND status = ?", eventID, "CANCELLED").Count(&cancelledBookings)
+	r.read.Table("bookings").Where("event_id = ?", eventID).Count(&totalBookings)
+	r.read.Table("bookings").Where("event_id = ? AND status = ?", eventID, "CANCELLED").Count(&cancelledBookings)
 	if totalBookings > 0 {
 		analytics.CancellationRate = float64(cancelledBookings) / float64(totalBookings) * 100
 	}
 
 	// Get daily booking breakdown
 	var dailyBookings []DailyBooking
-	err = r.db.Raw(`
+	err = r.read.Raw(`
 		SELECT 
 			DATE(created_at) as date,
 			COUNT(*) as bookings,
-			COALESCE(SUM(total_price), 0) as revenue
+			COALESCE(SUM(base_total_price), 0) as revenue
 		FROM bookings 
 		WHERE event_id = ? AND status = ?
 		GROUP BY DATE(created_at)
@@ -413,7 +463,14 @@ func (r *repository) GetEventAnalytics(eventID uuid.UUID) (*EventAnalytics, erro
 
 	analytics.BookingsByDay = dailyBookings
 
-	// Note: Capacity utilization requires venue template data which is not available
+	err = r.read.Raw(`
+		WITH `+eventUtilizationCTE+`
+		SELECT COALESCE(MAX(utilization), 0) FROM event_utilization WHERE event_id = ?
+	`, eventID).Scan(&analytics.CapacityUtilization).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate capacity utilization: %w", err)
+	}
+
 	// Top sections and hourly trends require detailed booking timing data
 	analytics.TopSections = []SectionStats{}
 	analytics.BookingTrends = []HourlyStats{}
@@ -426,14 +483,14 @@ func (r *repository) GetGlobalEventAnalytics() (*GlobalEventAnalytics, error) {
 
 	// Get totals
 	var totalEvents int64
-	err := r.db.Table("events").Count(&totalEvents).Error
+	err := r.read.Table("events").Where("deleted_at IS NULL").Count(&totalEvents).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to count events: %w", err)
 	}
 	analytics.TotalEvents = int(totalEvents)
 
 	var totalBookings int64
-	err = r.db.Table("bookings").
+	err = r.read.Table("bookings").
 		Where("status = ?", "CONFIRMED").
 		Count(&totalBookings).Error
 	if err != nil {
@@ -441,9 +498,9 @@ func (r *repository) GetGlobalEventAnalytics() (*GlobalEventAnalytics, error) {
 	}
 	analytics.TotalBookings = int(totalBookings)
 
-	err = r.db.Table("bookings").
+	err = r.read.Table("bookings").
 		Where("status = ?", "CONFIRMED").
-		Select("COALESCE(SUM(total_price), 0)").
+		Select("COALESCE(SUM(base_total_price), 0)").
 		Scan(&analytics.TotalRevenue).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to calculate revenue: %w", err)
@@ -455,8 +512,9 @@ func (r *repository) GetGlobalEventAnalytics() (*GlobalEventAnalytics, error) {
 		Count  int    `json:"count"`
 	}
 
-	err = r.db.Table("events").
+	err = r.read.Table("events").
 		Select("status, COUNT(*) as count").
+		Where("deleted_at IS NULL").
 		Group("status").
 		Scan(&statusCounts).Error
 	if err != nil {
@@ -470,17 +528,29 @@ func (r *repository) GetGlobalEventAnalytics() (*GlobalEventAnalytics, error) {
 
 	// Get most popular events
 	var popularEvents []EventPerformance
-	err = r.db.Raw(`
+	err = r.read.Raw(`
+		WITH ` + eventUtilizationCTE + `
 		SELECT 
 			e.id as event_id,
 			e.name as event_name,
 			e.venue,
 			e.date_time,
 			COUNT(b.id) as booking_count,
-			COALESCE(SUM(b.total_price), 0) as revenue
+			COALESCE(SUM(b.base_total_price), 0) as revenue,
+			COALESCE(eu.utilization, 0) as utilization,
+			COALESCE(rv.average_rating, 0) as average_rating,
+			COALESCE(rv.review_count, 0) as review_count
 		FROM events e
 		LEFT JOIN bookings b ON e.id = b.event_id AND b.status = 'CONFIRMED'
-		GROUP BY e.id, e.name, e.venue, e.date_time
+		LEFT JOIN event_utilization eu ON eu.event_id = e.id
+		LEFT JOIN (
+			SELECT event_id, ROUND(AVG(rating)::numeric, 1) as average_rating, COUNT(*) as review_count
+			FROM event_reviews
+			WHERE status = 'PUBLISHED'
+			GROUP BY event_id
+		) rv ON rv.event_id = e.id
+		WHERE e.deleted_at IS NULL
+		GROUP BY e.id, e.name, e.venue, e.date_time, eu.utilization, rv.average_rating, rv.review_count
 		ORDER BY booking_count DESC
 		LIMIT 10
 	`).Scan(&popularEvents).Error
@@ -493,11 +563,11 @@ func (r *repository) GetGlobalEventAnalytics() (*GlobalEventAnalytics, error) {
 
 	// Get booking trends
 	var bookingTrends []DailyBooking
-	err = r.db.Raw(`
+	err = r.read.Raw(`
 		SELECT 
 			DATE(created_at) as date,
 			COUNT(*) as bookings,
-			COALESCE(SUM(total_price), 0) as revenue
+			COALESCE(SUM(base_total_price), 0) as revenue
 		FROM bookings 
 		WHERE status = ? AND created_at >= ?
 		GROUP BY DATE(created_at)
@@ -512,10 +582,10 @@ func (r *repository) GetGlobalEventAnalytics() (*GlobalEventAnalytics, error) {
 
 	// Get revenue by month
 	var monthlyRevenue []MonthlyRevenue
-	err = r.db.Raw(`
+	err = r.read.Raw(`
 		SELECT 
 			TO_CHAR(DATE_TRUNC('month', created_at), 'YYYY-MM') as month,
-			COALESCE(SUM(total_price), 0) as revenue,
+			COALESCE(SUM(base_total_price), 0) as revenue,
 			COUNT(DISTINCT event_id) as events
 		FROM bookings 
 		WHERE status = ? AND created_at >= ?
@@ -529,26 +599,48 @@ func (r *repository) GetGlobalEventAnalytics() (*GlobalEventAnalytics, error) {
 
 	analytics.RevenueByMonth = monthlyRevenue
 
-	// Note: Average utilization calculation requires venue capacity data
-	analytics.AverageUtilization = 0.0
+	analytics.AverageUtilization, err = r.getAverageUtilization()
+	if err != nil {
+		return nil, err
+	}
 
 	return &analytics, nil
 }
 
 func (r *repository) GetEventPerformanceMetrics() ([]EventPerformance, error) {
+	if !r.live {
+		performances, err := r.getEventPerformanceFromRollup()
+		if err != nil || len(performances) > 0 {
+			return performances, err
+		}
+		// Not rolled up yet
+	}
+
 	var performances []EventPerformance
 
-	err := r.db.Raw(`
+	err := r.read.Raw(`
+		WITH ` + eventUtilizationCTE + `
 		SELECT 
 			e.id as event_id,
 			e.name as event_name,
 			e.venue,
 			e.date_time,
 			COUNT(b.id) as booking_count,
-			COALESCE(SUM(b.total_price), 0) as revenue
+			COALESCE(SUM(b.base_total_price), 0) as revenue,
+			COALESCE(eu.utilization, 0) as utilization,
+			COALESCE(rv.average_rating, 0) as average_rating,
+			COALESCE(rv.review_count, 0) as review_count
 		FROM events e
 		LEFT JOIN bookings b ON e.id = b.event_id AND b.status = 'CONFIRMED'
-		GROUP BY e.id, e.name, e.venue, e.date_time
+		LEFT JOIN event_utilization eu ON eu.event_id = e.id
+		LEFT JOIN (
+			SELECT event_id, ROUND(AVG(rating)::numeric, 1) as average_rating, COUNT(*) as review_count
+			FROM event_reviews
+			WHERE status = 'PUBLISHED'
+			GROUP BY event_id
+		) rv ON rv.event_id = e.id
+		WHERE e.deleted_at IS NULL
+		GROUP BY e.id, e.name, e.venue, e.date_time, eu.utilization, rv.average_rating, rv.review_count
 		ORDER BY booking_count DESC
 		LIMIT 20
 	`).Scan(&performances).Error
@@ -557,12 +649,6 @@ func (r *repository) GetEventPerformanceMetrics() ([]EventPerformance, error) {
 		return nil, fmt.Errorf("failed to get event performance metrics: %w", err)
 	}
 
-	// Note: Utilization calculation requires venue capacity data which is not available
-	// Setting to 0 to indicate data not available rather than fake placeholder
-	for i := range performances {
-		performances[i].Utilization = 0.0
-	}
-
 	return performances, nil
 }
 
@@ -575,8 +661,9 @@ func (r *repository) GetEventAnalyticsOverview() (*EventOverview, error) {
 		Count  int    `json:"count"`
 	}
 
-	err := r.db.Table("events").
+	err := r.read.Table("events").
 		Select("status, COUNT(*) as count").
+		Where("deleted_at IS NULL").
 		Group("status").
 		Scan(&statusCounts).Error
 	if err != nil {
@@ -600,8 +687,8 @@ func (r *repository) GetEventAnalyticsOverview() (*EventOverview, error) {
 
 	// Get upcoming events
 	var upcomingEvents int64
-	err = r.db.Table("events").
-		Where("status = ? AND date_time > ?", "published", time.Now()).
+	err = r.read.Table("events").
+		Where("status IN ? AND date_time > ? AND deleted_at IS NULL", []string{"published", "sold_out"}, time.Now()).
 		Count(&upcomingEvents).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to count upcoming events: %w", err)
@@ -609,9 +696,9 @@ func (r *repository) GetEventAnalyticsOverview() (*EventOverview, error) {
 	overview.UpcomingEvents = int(upcomingEvents)
 
 	// Get total revenue
-	err = r.db.Table("bookings").
+	err = r.read.Table("bookings").
 		Where("status = ?", "CONFIRMED").
-		Select("COALESCE(SUM(total_price), 0)").
+		Select("COALESCE(SUM(base_total_price), 0)").
 		Scan(&overview.TotalRevenue).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to calculate total revenue: %w", err)
@@ -626,10 +713,10 @@ func (r *repository) GetEventAnalyticsOverview() (*EventOverview, error) {
 
 	// Get revenue by month
 	var monthlyRevenue []MonthlyRevenue
-	err = r.db.Raw(`
+	err = r.read.Raw(`
 		SELECT 
 			TO_CHAR(DATE_TRUNC('month', created_at), 'YYYY-MM') as month,
-			COALESCE(SUM(total_price), 0) as revenue,
+			COALESCE(SUM(base_total_price), 0) as revenue,
 			COUNT(DISTINCT event_id) as events
 		FROM bookings 
 		WHERE status = ? AND created_at >= ?
@@ -643,14 +730,56 @@ func (r *repository) GetEventAnalyticsOverview() (*EventOverview, error) {
 
 	overview.RevenueByMonth = monthlyRevenue
 
-	// Note: Average utilization requires venue capacity data
-	overview.AverageUtilization = 0.0
+	overview.AverageUtilization, err = r.getAverageUtilization()
+	if err != nil {
+		return nil, err
+	}
 
 	return &overview, nil
 }
 
 // Helper functions
 
+// templateCapacitySQL totals the seats of each venue template's sections. An
+// event's capacity is the total of the template it is laid out on.
+const templateCapacitySQL = `
+			SELECT template_id, SUM(total_seats) AS capacity
+			FROM venue_sections
+			GROUP BY template_id
+		`
+
+// eventUtilizationCTE defines event_utilization: the percent of each event's
+// capacity taken by confirmed bookings. Events whose template has no seats are
+// left out rather than reported as empty.
+const eventUtilizationCTE = `event_utilization AS (
+			SELECT
+				e.id AS event_id,
+				e.status,
+				COALESCE(sold.tickets, 0)::float / cap.capacity * 100 AS utilization
+			FROM events e
+			JOIN (` + templateCapacitySQL + `) cap ON cap.template_id = e.venue_template_id AND cap.capacity > 0
+			LEFT JOIN (
+				SELECT event_id, SUM(total_seats) AS tickets
+				FROM bookings
+				WHERE status = 'CONFIRMED'
+				GROUP BY event_id
+			) sold ON sold.event_id = e.id
+			WHERE e.deleted_at IS NULL
+		)`
+
+// getAverageUtilization averages utilization over events that were not cancelled
+func (r *repository) getAverageUtilization() (float64, error) {
+	var average float64
+	err := r.read.Raw(`
+		WITH ` + eventUtilizationCTE + `
+		SELECT COALESCE(AVG(utilization), 0) FROM event_utilization WHERE status <> 'cancelled'
+	`).Scan(&average).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to calculate average utilization: %w", err)
+	}
+	return average, nil
+}
+
 func convertTagAnalyticsToPerformance(tagAnalytics []TagAnalytics) []TagPerformance {
 	var performances []TagPerformance
 	for _, tag := range tagAnalytics {
@@ -697,20 +826,28 @@ func (r *repository) GetTagAnalytics() (*TagAnalyticsResponse, error) {
 }
 
 func (r *repository) GetTagPopularityAnalytics() ([]TagAnalytics, error) {
+	if !r.live {
+		analytics, err := r.getTagPopularityFromRollup()
+		if err != nil || len(analytics) > 0 {
+			return analytics, err
+		}
+		// Not rolled up yet
+	}
+
 	var analytics []TagAnalytics
 
-	err := r.db.Raw(`
+	err := r.read.Raw(`
 		SELECT 
 			t.id as tag_id,
 			t.name as tag_name,
 			COUNT(DISTINCT et.event_id) as event_count,
 			COUNT(DISTINCT b.id) as total_bookings,
-			COALESCE(SUM(b.total_price), 0) as total_revenue,
+			COALESCE(SUM(b.base_total_price), 0) as total_revenue,
 			AVG(CASE WHEN b.status = 'CONFIRMED' THEN 1.0 ELSE 0.0 END) * 100 as avg_utilization
 		FROM tags t
 		LEFT JOIN event_tags et ON t.id = et.tag_id
 		LEFT JOIN bookings b ON et.event_id = b.event_id AND b.status = 'CONFIRMED'
-		WHERE t.is_active = true
+		WHERE t.is_active = true AND t.deleted_at IS NULL
 		GROUP BY t.id, t.name
 		ORDER BY total_bookings DESC, total_revenue DESC
 		LIMIT 20
@@ -720,7 +857,13 @@ func (r *repository) GetTagPopularityAnalytics() ([]TagAnalytics, error) {
 		return nil, fmt.Errorf("failed to get tag popularity analytics: %w", err)
 	}
 
-	// Calculate popularity score for each tag
+	scoreTagPopularity(analytics)
+
+	return analytics, nil
+}
+
+// scoreTagPopularity calculates the popularity score for each tag
+func scoreTagPopularity(analytics []TagAnalytics) {
 	for i := range analytics {
 		eventScore := float64(analytics[i].EventCount) * 0.3
 		bookingScore := float64(analytics[i].TotalBookings) * 0.4
@@ -728,25 +871,23 @@ func (r *repository) GetTagPopularityAnalytics() ([]TagAnalytics, error) {
 		utilizationScore := analytics[i].AvgUtilization * 0.1
 		analytics[i].PopularityScore = eventScore + bookingScore + revenueScore + utilizationScore
 	}
-
-	return analytics, nil
 }
 
 func (r *repository) GetTagTrends(months int) ([]TagTrend, error) {
 	var trends []TagTrend
 
-	err := r.db.Raw(`
+	err := r.read.Raw(`
 		SELECT 
 			t.id as tag_id,
 			t.name as tag_name,
 			TO_CHAR(DATE_TRUNC('month', e.created_at), 'YYYY-MM') as month,
 			COUNT(DISTINCT et.event_id) as event_count,
-			COALESCE(SUM(b.total_price), 0) as revenue
+			COALESCE(SUM(b.base_total_price), 0) as revenue
 		FROM tags t
 		LEFT JOIN event_tags et ON t.id = et.tag_id
 		LEFT JOIN events e ON et.event_id = e.id
 		LEFT JOIN bookings b ON e.id = b.event_id AND b.status = 'CONFIRMED'
-		WHERE t.is_active = true 
+		WHERE t.is_active = true AND t.deleted_at IS NULL
 			AND e.created_at >= ?
 		GROUP BY t.id, t.name, DATE_TRUNC('month', e.created_at)
 		ORDER BY t.name, month
@@ -762,19 +903,25 @@ func (r *repository) GetTagTrends(months int) ([]TagTrend, error) {
 func (r *repository) GetTagComparisons() ([]TagComparison, error) {
 	var comparisons []TagComparison
 
-	err := r.db.Raw(`
+	err := r.read.Raw(`
+		WITH ` + eventUtilizationCTE + `
 		SELECT 
 			t.id as tag_id,
 			t.name as tag_name,
 			COUNT(DISTINCT et.event_id) as event_count,
-			0.0 as avg_capacity_util, -- Requires venue capacity data
-			AVG(b.total_price / b.total_seats) as avg_ticket_price,
-			COALESCE(SUM(b.total_price), 0) as total_revenue,
+			COALESCE((
+				SELECT AVG(eu.utilization)
+				FROM event_utilization eu
+				JOIN event_tags tagged ON tagged.event_id = eu.event_id
+				WHERE tagged.tag_id = t.id
+			), 0) as avg_capacity_util,
+			AVG(b.base_total_price / b.total_seats) as avg_ticket_price,
+			COALESCE(SUM(b.base_total_price), 0) as total_revenue,
 			COUNT(DISTINCT b.id)::float / NULLIF(COUNT(DISTINCT et.event_id), 0) as booking_conversion
 		FROM tags t
 		LEFT JOIN event_tags et ON t.id = et.tag_id
 		LEFT JOIN bookings b ON et.event_id = b.event_id AND b.status = 'CONFIRMED'
-		WHERE t.is_active = true
+		WHERE t.is_active = true AND t.deleted_at IS NULL
 		GROUP BY t.id, t.name
 		HAVING COUNT(DISTINCT et.event_id) > 0
 		ORDER BY total_revenue DESC
@@ -788,18 +935,72 @@ func (r *repository) GetTagComparisons() ([]TagComparison, error) {
 	return comparisons, nil
 }
 
+// GetTagHierarchyAnalytics aggregates every active tag over its own events
+// and those of all active tags below it. Each event is counted once per tag.
+func (r *repository) GetTagHierarchyAnalytics() ([]TagHierarchyAnalytics, error) {
+	var analytics []TagHierarchyAnalytics
+
+	err := r.read.Raw(`
+		WITH RECURSIVE tag_subtree AS (
+			SELECT id AS root_id, id AS tag_id
+			FROM tags
+			WHERE is_active = true AND deleted_at IS NULL
+			UNION
+			SELECT ts.root_id, t.id
+			FROM tags t
+			JOIN tag_subtree ts ON t.parent_id = ts.tag_id
+			WHERE t.is_active = true AND t.deleted_at IS NULL
+		),
+		subtree_events AS (
+			SELECT DISTINCT ts.root_id, et.event_id
+			FROM tag_subtree ts
+			JOIN event_tags et ON et.tag_id = ts.tag_id
+		),
+		event_sales AS (
+			SELECT event_id, COUNT(*) AS bookings, SUM(base_total_price) AS revenue
+			FROM bookings
+			WHERE status = 'CONFIRMED'
+			GROUP BY event_id
+		),
+		` + eventUtilizationCTE + `
+		SELECT
+			t.id as tag_id,
+			t.name as tag_name,
+			t.parent_id,
+			(SELECT COUNT(*) - 1 FROM tag_subtree d WHERE d.root_id = t.id) as descendant_count,
+			(SELECT COUNT(*) FROM event_tags own WHERE own.tag_id = t.id) as own_event_count,
+			COUNT(se.event_id) as event_count,
+			COALESCE(SUM(es.bookings), 0) as total_bookings,
+			COALESCE(SUM(es.revenue), 0) as total_revenue,
+			COALESCE(AVG(eu.utilization), 0) as avg_utilization
+		FROM tags t
+		LEFT JOIN subtree_events se ON se.root_id = t.id
+		LEFT JOIN event_sales es ON es.event_id = se.event_id
+		LEFT JOIN event_utilization eu ON eu.event_id = se.event_id
+		WHERE t.is_active = true AND t.deleted_at IS NULL
+		GROUP BY t.id, t.name, t.parent_id
+		ORDER BY total_revenue DESC, event_count DESC, t.name
+	`).Scan(&analytics).Error
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tag hierarchy analytics: %w", err)
+	}
+
+	return analytics, nil
+}
+
 func (r *repository) GetTagOverview() (*TagOverview, error) {
 	var overview TagOverview
 
 	// Get total and active tags
 	var totalTags, activeTags int64
-	err := r.db.Table("tags").Count(&totalTags).Error
+	err := r.read.Table("tags").Where("deleted_at IS NULL").Count(&totalTags).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to count total tags: %w", err)
 	}
 	overview.TotalTags = int(totalTags)
 
-	err = r.db.Table("tags").Where("is_active = ?", true).Count(&activeTags).Error
+	err = r.read.Table("tags").Where("is_active = ? AND deleted_at IS NULL", true).Count(&activeTags).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to count active tags: %w", err)
 	}
@@ -807,11 +1008,11 @@ func (r *repository) GetTagOverview() (*TagOverview, error) {
 
 	// Get tags with events
 	var tagsWithEvents int64
-	err = r.db.Raw(`
+	err = r.read.Raw(`
 		SELECT COUNT(DISTINCT t.id)
 		FROM tags t
 		INNER JOIN event_tags et ON t.id = et.tag_id
-		WHERE t.is_active = true
+		WHERE t.is_active = true AND t.deleted_at IS NULL
 	`).Scan(&tagsWithEvents).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to count tags with events: %w", err)
@@ -820,12 +1021,13 @@ func (r *repository) GetTagOverview() (*TagOverview, error) {
 
 	// Get average tags per event
 	var avgTagsPerEvent float64
-	err = r.db.Raw(`
+	err = r.read.Raw(`
 		SELECT AVG(tag_count)
 		FROM (
 			SELECT COUNT(et.tag_id) as tag_count
 			FROM events e
 			LEFT JOIN event_tags et ON e.id = et.event_id
+			WHERE e.deleted_at IS NULL
 			GROUP BY e.id
 		) subq
 	`).Scan(&avgTagsPerEvent).Error
@@ -837,11 +1039,11 @@ func (r *repository) GetTagOverview() (*TagOverview, error) {
 
 	// Get most and least popular tags
 	var mostPopular, leastUsed string
-	err = r.db.Raw(`
+	err = r.read.Raw(`
 		SELECT t.name
 		FROM tags t
 		LEFT JOIN event_tags et ON t.id = et.tag_id
-		WHERE t.is_active = true
+		WHERE t.is_active = true AND t.deleted_at IS NULL
 		GROUP BY t.id, t.name
 		ORDER BY COUNT(et.event_id) DESC
 		LIMIT 1
@@ -850,11 +1052,11 @@ func (r *repository) GetTagOverview() (*TagOverview, error) {
 		overview.MostPopularTag = mostPopular
 	}
 
-	err = r.db.Raw(`
+	err = r.read.Raw(`
 		SELECT t.name
 		FROM tags t
 		LEFT JOIN event_tags et ON t.id = et.tag_id
-		WHERE t.is_active = true
+		WHERE t.is_active = true AND t.deleted_at IS NULL
 		GROUP BY t.id, t.name
 		ORDER BY COUNT(et.event_id) ASC
 		LIMIT 1
@@ -897,35 +1099,35 @@ func (r *repository) GetBookingOverview() (*BookingOverview, error) {
 
 	// Get booking counts by status
 	var totalBookings, confirmedBookings, cancelledBookings int64
-	err := r.db.Table("bookings").Count(&totalBookings).Error
+	err := r.read.Table("bookings").Count(&totalBookings).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to count total bookings: %w", err)
 	}
 	overview.TotalBookings = int(totalBookings)
 
-	err = r.db.Table("bookings").Where("status = ?", "CONFIRMED").Count(&confirmedBookings).Error
+	err = r.read.Table("bookings").Where("status = ?", "CONFIRMED").Count(&confirmedBookings).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to count confirmed bookings: %w", err)
 	}
 	overview.ConfirmedBookings = int(confirmedBookings)
 
-	err = r.db.Table("bookings").Where("status = ?", "CANCELLED").Count(&cancelledBookings).Error
+	err = r.read.Table("bookings").Where("status = ?", "CANCELLED").Count(&cancelledBookings).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to count cancelled bookings: %w", err)
 	}
 	overview.CancelledBookings = int(cancelledBookings)
 
 	// Get revenue and averages
-	err = r.db.Table("bookings").
+	err = r.read.Table("bookings").
 		Where("status = ?", "CONFIRMED").
-		Select("COALESCE(SUM(total_price), 0)").
+		Select("COALESCE(SUM(base_total_price), 0)").
 		Scan(&overview.TotalRevenue).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to calculate total revenue: %w", err)
 	}
 
 	var avgBookingSize, avgTicketPrice float64
-	err = r.db.Table("bookings").
+	err = r.read.Table("bookings").
 		Where("status = ?", "CONFIRMED").
 		Select("AVG(total_seats)").
 		Scan(&avgBookingSize).Error
@@ -933,9 +1135,9 @@ func (r *repository) GetBookingOverview() (*BookingOverview, error) {
 		overview.AverageBookingSize = avgBookingSize
 	}
 
-	err = r.db.Table("bookings").
+	err = r.read.Table("bookings").
 		Where("status = ? AND total_seats > 0", "CONFIRMED").
-		Select("AVG(total_price / total_seats)").
+		Select("AVG(base_total_price / total_seats)").
 		Scan(&avgTicketPrice).Error
 	if err == nil {
 		overview.AverageTicketPrice = avgTicketPrice
@@ -967,16 +1169,24 @@ func (r *repository) GetBookingOverview() (*BookingOverview, error) {
 }
 
 func (r *repository) GetDailyBookingStats(days int) ([]DailyBookingStats, error) {
+	if !r.live {
+		stats, err := r.getDailyBookingStatsFromRollup(days)
+		if err != nil || len(stats) > 0 {
+			return stats, err
+		}
+		// Not rolled up yet
+	}
+
 	var stats []DailyBookingStats
 
-	err := r.db.Raw(`
+	err := r.read.Raw(`
 		SELECT 
 			DATE(created_at) as date,
 			COUNT(*) as total_bookings,
 			SUM(CASE WHEN status = 'CONFIRMED' THEN 1 ELSE 0 END) as confirmed_bookings,
 			SUM(CASE WHEN status = 'CANCELLED' THEN 1 ELSE 0 END) as cancelled_bookings,
-			COALESCE(SUM(CASE WHEN status = 'CONFIRMED' THEN total_price ELSE 0 END), 0) as revenue,
-			AVG(CASE WHEN status = 'CONFIRMED' THEN total_price ELSE NULL END) as average_value
+			COALESCE(SUM(CASE WHEN status = 'CONFIRMED' THEN base_total_price ELSE 0 END), 0) as revenue,
+			AVG(CASE WHEN status = 'CONFIRMED' THEN base_total_price ELSE NULL END) as average_value
 		FROM bookings
 		WHERE created_at >= ?
 		GROUP BY DATE(created_at)
@@ -1002,22 +1212,22 @@ func (r *repository) GetBookingTrends() (*BookingTrendAnalysis, error) {
 	var currentUsers, previousUsers int64
 
 	// Current period
-	err := r.db.Table("bookings").
+	err := r.read.Table("bookings").
 		Where("status = ? AND created_at >= ?", "CONFIRMED", currentStart).
 		Count(&currentBookings).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to get current bookings: %w", err)
 	}
 
-	err = r.db.Table("bookings").
+	err = r.read.Table("bookings").
 		Where("status = ? AND created_at >= ?", "CONFIRMED", currentStart).
-		Select("COALESCE(SUM(total_price), 0)").
+		Select("COALESCE(SUM(base_total_price), 0)").
 		Scan(&currentRevenue).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to get current revenue: %w", err)
 	}
 
-	err = r.db.Table("bookings").
+	err = r.read.Table("bookings").
 		Where("status = ? AND created_at >= ?", "CONFIRMED", currentStart).
 		Select("COUNT(DISTINCT user_id)").
 		Scan(&currentUsers).Error
@@ -1026,22 +1236,22 @@ func (r *repository) GetBookingTrends() (*BookingTrendAnalysis, error) {
 	}
 
 	// Previous period
-	err = r.db.Table("bookings").
+	err = r.read.Table("bookings").
 		Where("status = ? AND created_at >= ? AND created_at < ?", "CONFIRMED", previousStart, currentStart).
 		Count(&previousBookings).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to get previous bookings: %w", err)
 	}
 
-	err = r.db.Table("bookings").
+	err = r.read.Table("bookings").
 		Where("status = ? AND created_at >= ? AND created_at < ?", "CONFIRMED", previousStart, currentStart).
-		Select("COALESCE(SUM(total_price), 0)").
+		Select("COALESCE(SUM(base_total_price), 0)").
 		Scan(&previousRevenue).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to get previous revenue: %w", err)
 	}
 
-	err = r.db.Table("bookings").
+	err = r.read.Table("bookings").
 		Where("status = ? AND created_at >= ? AND created_at < ?", "CONFIRMED", previousStart, currentStart).
 		Select("COUNT(DISTINCT user_id)").
 		Scan(&previousUsers).Error
@@ -1096,6 +1306,102 @@ func (r *repository) GetBookingTrends() (*BookingTrendAnalysis, error) {
 	return &trends, nil
 }
 
+const (
+	cancellationReasonGroups = 10 // Events or tags listed by cancellation reason
+	topCancellationReasons   = 3  // Reasons listed per event or tag
+)
+
+// getCancellationReasons returns how processed cancellations split by reason
+// code, most frequent first
+func (r *repository) getCancellationReasons() ([]CancellationReason, error) {
+	reasons := []CancellationReason{}
+	err := r.read.Raw(`
+		SELECT reason_code AS reason, COUNT(*) AS count, COALESCE(SUM(base_refund), 0) AS refund_total
+		FROM cancellations
+		WHERE status = 'PROCESSED'
+		GROUP BY reason_code
+		ORDER BY count DESC, reason_code
+	`).Scan(&reasons).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cancellation reasons: %w", err)
+	}
+
+	setReasonPercentages(reasons)
+	return reasons, nil
+}
+
+// getCancellationReasonsByGroup runs a query returning id, name, reason,
+// count and refund_total per group and reason, and keeps the groups with the
+// most cancellations along with their top reasons
+func (r *repository) getCancellationReasonsByGroup(query string) ([]CancellationReasonGroup, error) {
+	var rows []struct {
+		ID   uuid.UUID
+		Name string
+		CancellationReason
+	}
+	if err := r.read.Raw(query).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	var groups []CancellationReasonGroup
+	index := make(map[uuid.UUID]int)
+	for _, row := range rows {
+		i, ok := index[row.ID]
+		if !ok {
+			i = len(groups)
+			index[row.ID] = i
+			groups = append(groups, CancellationReasonGroup{ID: row.ID, Name: row.Name})
+		}
+		groups[i].Cancellations += row.Count
+		groups[i].RefundTotal += row.RefundTotal
+		groups[i].TopReasons = append(groups[i].TopReasons, row.CancellationReason)
+	}
+
+	sort.Slice(groups, func(a, b int) bool {
+		if groups[a].Cancellations != groups[b].Cancellations {
+			return groups[a].Cancellations > groups[b].Cancellations
+		}
+		return groups[a].Name < groups[b].Name
+	})
+	if len(groups) > cancellationReasonGroups {
+		groups = groups[:cancellationReasonGroups]
+	}
+
+	for i := range groups {
+		reasons := groups[i].TopReasons
+		sort.Slice(reasons, func(a, b int) bool {
+			if reasons[a].Count != reasons[b].Count {
+				return reasons[a].Count > reasons[b].Count
+			}
+			return reasons[a].Reason < reasons[b].Reason
+		})
+		setReasonPercentages(reasons)
+		if len(reasons) > topCancellationReasons {
+			reasons = reasons[:topCancellationReasons]
+		}
+		groups[i].TopReasons = reasons
+	}
+
+	if groups == nil {
+		groups = []CancellationReasonGroup{}
+	}
+	return groups, nil
+}
+
+// setReasonPercentages sets each reason's share of the cancellations listed
+func setReasonPercentages(reasons []CancellationReason) {
+	total := 0
+	for _, reason := range reasons {
+		total += reason.Count
+	}
+	if total == 0 {
+		return
+	}
+	for i := range reasons {
+		reasons[i].Percentage = float64(reasons[i].Count) / float64(total) * 100
+	}
+}
+
 func (r *repository) GetCancellationAnalytics() (*CancellationAnalytics, error) {
 	var analytics CancellationAnalytics
 
@@ -1103,7 +1409,7 @@ func (r *repository) GetCancellationAnalytics() (*CancellationAnalytics, error)
 	var totalCancellations int64
 	var totalRefundAmount float64
 
-	err := r.db.Table("bookings").
+	err := r.read.Table("bookings").
 		Where("status = ?", "CANCELLED").
 		Count(&totalCancellations).Error
 	if err != nil {
@@ -1111,16 +1417,16 @@ func (r *repository) GetCancellationAnalytics() (*CancellationAnalytics, error)
 	}
 
 	// Calculate refund amount (assuming full refunds for simplicity)
-	err = r.db.Table("bookings").
+	err = r.read.Table("bookings").
 		Where("status = ?", "CANCELLED").
-		Select("COALESCE(SUM(total_price), 0)").
+		Select("COALESCE(SUM(base_total_price), 0)").
 		Scan(&totalRefundAmount).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to calculate refund amount: %w", err)
 	}
 
 	var totalBookings int64
-	r.db.Table("bookings").Count(&totalBookings)
+	r.read.Table("bookings").Count(&totalBookings)
 
 	analytics.Overview = CancellationOverview{
 		TotalCancellations: int(totalCancellations),
@@ -1133,12 +1439,49 @@ func (r *repository) GetCancellationAnalytics() (*CancellationAnalytics, error)
 		analytics.Overview.CancellationRate = float64(totalCancellations) / float64(totalBookings) * 100
 	}
 
-	// Note: Cancellation reasons require a reason field in the cancellations table
-	analytics.CancellationReasons = []CancellationReason{}
+	reasons, err := r.getCancellationReasons()
+	if err != nil {
+		return nil, err
+	}
+	analytics.CancellationReasons = reasons
+
+	byEvent, err := r.getCancellationReasonsByGroup(`
+		SELECT b.event_id AS id, e.name AS name, c.reason_code AS reason,
+			COUNT(*) AS count, COALESCE(SUM(c.base_refund), 0) AS refund_total
+		FROM cancellations c
+		JOIN bookings b ON b.id = c.booking_id
+		JOIN events e ON e.id = b.event_id
+		WHERE c.status = 'PROCESSED'
+		GROUP BY b.event_id, e.name, c.reason_code`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cancellation reasons by event: %w", err)
+	}
+	analytics.ReasonsByEvent = byEvent
+
+	byTag, err := r.getCancellationReasonsByGroup(`
+		SELECT t.id AS id, t.name AS name, c.reason_code AS reason,
+			COUNT(*) AS count, COALESCE(SUM(c.base_refund), 0) AS refund_total
+		FROM cancellations c
+		JOIN bookings b ON b.id = c.booking_id
+		JOIN event_tags et ON et.event_id = b.event_id
+		JOIN tags t ON t.id = et.tag_id
+		WHERE c.status = 'PROCESSED' AND t.deleted_at IS NULL
+		GROUP BY t.id, t.name, c.reason_code`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cancellation reasons by tag: %w", err)
+	}
+	analytics.ReasonsByTag = byTag
+
+	if len(byEvent) > 0 {
+		analytics.Overview.MostCancelledEvent = byEvent[0].Name
+	}
+	if len(byTag) > 0 {
+		analytics.Overview.HighestCancelledTag = byTag[0].Name
+	}
 
 	// Get cancellation trends
 	var trendData []CancellationTrend
-	err = r.db.Raw(`
+	err = r.read.Raw(`
 		WITH daily_bookings AS (
     SELECT DATE(created_at) AS date, COUNT(*) AS total_bookings
     FROM bookings
@@ -1148,7 +1491,7 @@ SELECT
     DATE(b1.cancelled_at) AS date,
     COUNT(*) AS cancellations,
     COUNT(*)::float / db.total_bookings * 100 AS cancellation_rate,
-    COALESCE(SUM(b1.total_price), 0) AS refund_amount
+    COALESCE(SUM(b1.base_total_price), 0) AS refund_amount
 FROM bookings b1
 JOIN daily_bookings db ON db.date = DATE(b1.cancelled_at)
 WHERE b1.status = 'CANCELLED' 
@@ -1192,7 +1535,7 @@ func (r *repository) GetUserOverview() (*UserOverview, error) {
 	// Count unique users from bookings (assuming no separate users table)
 	var totalUsers, activeUsers, newUsers int64
 
-	err := r.db.Table("bookings").
+	err := r.read.Table("bookings").
 		Select("COUNT(DISTINCT user_id)").
 		Scan(&totalUsers).Error
 	if err != nil {
@@ -1201,7 +1544,7 @@ func (r *repository) GetUserOverview() (*UserOverview, error) {
 	overview.TotalUsers = int(totalUsers)
 
 	// Active users (booked in last 30 days)
-	err = r.db.Table("bookings").
+	err = r.read.Table("bookings").
 		Where("created_at >= ? AND status = ?", time.Now().AddDate(0, 0, -30), "CONFIRMED").
 		Select("COUNT(DISTINCT user_id)").
 		Scan(&activeUsers).Error
@@ -1211,7 +1554,7 @@ func (r *repository) GetUserOverview() (*UserOverview, error) {
 	overview.ActiveUsers = int(activeUsers)
 
 	// New users (first booking in last 30 days)
-	err = r.db.Raw(`
+	err = r.read.Raw(`
 		SELECT COUNT(DISTINCT user_id)
 		FROM bookings b1
 		WHERE b1.created_at >= ?
@@ -1233,7 +1576,7 @@ func (r *repository) GetUserOverview() (*UserOverview, error) {
 
 	// Calculate average bookings per user
 	var avgBookingsPerUser float64
-	err = r.db.Raw(`
+	err = r.read.Raw(`
 		SELECT AVG(booking_count)
 		FROM (
 			SELECT COUNT(*) as booking_count
@@ -1248,7 +1591,7 @@ func (r *repository) GetUserOverview() (*UserOverview, error) {
 
 	// Get user growth data (last 12 months)
 	var growthStats []UserGrowthStats
-	err = r.db.Raw(`
+	err = r.read.Raw(`
 		SELECT 
 			TO_CHAR(DATE_TRUNC('month', first_booking), 'YYYY-MM') as date,
 			COUNT(*) as new_users
@@ -1287,15 +1630,15 @@ func (r *repository) GetUserBehaviorAnalytics() (*UserBehavior, error) {
 
 	// Event preferences based on tag popularity
 	var preferences []PreferenceStats
-	err := r.db.Raw(`
+	err := r.read.Raw(`
 		SELECT 
 			t.name as value,
 			COUNT(DISTINCT b.user_id) as user_count,
-			COALESCE(SUM(b.total_price), 0) as revenue
+			COALESCE(SUM(b.base_total_price), 0) as revenue
 		FROM tags t
 		JOIN event_tags et ON t.id = et.tag_id
 		JOIN bookings b ON et.event_id = b.event_id
-		WHERE b.status = 'CONFIRMED' AND t.is_active = true
+		WHERE b.status = 'CONFIRMED' AND t.is_active = true AND t.deleted_at IS NULL
 		GROUP BY t.id, t.name
 		ORDER BY user_count DESC
 		LIMIT 10
@@ -1311,7 +1654,7 @@ func (r *repository) GetUserBehaviorAnalytics() (*UserBehavior, error) {
 
 	// Booking frequency analysis
 	var avgBookingsPerMonth float64
-	err = r.db.Raw(`
+	err = r.read.Raw(`
 		SELECT AVG(monthly_bookings)
 		FROM (
 			SELECT 
@@ -1333,9 +1676,9 @@ func (r *repository) GetUserBehaviorAnalytics() (*UserBehavior, error) {
 
 	// Price preferences
 	var avgTicketPrice float64
-	err = r.db.Table("bookings").
+	err = r.read.Table("bookings").
 		Where("status = ? AND total_seats > 0", "CONFIRMED").
-		Select("AVG(total_price / total_seats)").
+		Select("AVG(base_total_price / total_seats)").
 		Scan(&avgTicketPrice).Error
 
 	if err == nil {
@@ -1350,8 +1693,8 @@ func (r *repository) GetUserBehaviorAnalytics() (*UserBehavior, error) {
 	var cancellationRate float64
 	var totalUsers, usersWithCancellations int64
 
-	r.db.Table("bookings").Select("COUNT(DISTINCT user_id)").Scan(&totalUsers)
-	r.db.Table("bookings").Where("status = ?", "CANCELLED").Select("COUNT(DISTINCT user_id)").Scan(&usersWithCancellations)
+	r.read.Table("bookings").Select("COUNT(DISTINCT user_id)").Scan(&totalUsers)
+	r.read.Table("bookings").Where("status = ?", "CANCELLED").Select("COUNT(DISTINCT user_id)").Scan(&usersWithCancellations)
 
 	if totalUsers > 0 {
 		cancellationRate = float64(usersWithCancellations) / float64(totalUsers) * 100
@@ -1377,16 +1720,16 @@ func (r *repository) GetUserBookingHistory(userID uuid.UUID) (*UserBookingHistor
 	var totalSpent, avgBookingValue float64
 	var memberSince time.Time
 
-	err := r.db.Table("bookings").
+	err := r.read.Table("bookings").
 		Where("user_id = ? AND status = ?", userID, "CONFIRMED").
 		Count(&totalBookings).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to count user bookings: %w", err)
 	}
 
-	err = r.db.Table("bookings").
+	err = r.read.Table("bookings").
 		Where("user_id = ? AND status = ?", userID, "CONFIRMED").
-		Select("COALESCE(SUM(total_price), 0)").
+		Select("COALESCE(SUM(base_total_price), 0)").
 		Scan(&totalSpent).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to calculate total spent: %w", err)
@@ -1396,7 +1739,7 @@ func (r *repository) GetUserBookingHistory(userID uuid.UUID) (*UserBookingHistor
 		avgBookingValue = totalSpent / float64(totalBookings)
 	}
 
-	err = r.db.Table("bookings").
+	err = r.read.Table("bookings").
 		Where("user_id = ?", userID).
 		Select("MIN(created_at)").
 		Scan(&memberSince).Error
@@ -1406,7 +1749,7 @@ func (r *repository) GetUserBookingHistory(userID uuid.UUID) (*UserBookingHistor
 
 	// Get favorite venue and event type (simplified)
 	var favoriteVenue, favoriteEventType string
-	err = r.db.Raw(`
+	err = r.read.Raw(`
 		SELECT e.venue
 		FROM bookings b
 		JOIN events e ON b.event_id = e.id
@@ -1434,7 +1777,7 @@ func (r *repository) GetUserBookingHistory(userID uuid.UUID) (*UserBookingHistor
 
 	// Get booking history records
 	var bookingRecords []UserBookingRecord
-	err = r.db.Raw(`
+	err = r.read.Raw(`
 		SELECT 
 			b.id as booking_id,
 			e.name as event_name,
@@ -1442,6 +1785,7 @@ func (r *repository) GetUserBookingHistory(userID uuid.UUID) (*UserBookingHistor
 			b.created_at as booking_date,
 			e.date_time as event_date,
 			b.total_price as total_amount,
+			b.currency,
 			b.total_seats as seat_count,
 			b.status
 		FROM bookings b
@@ -1457,10 +1801,10 @@ func (r *repository) GetUserBookingHistory(userID uuid.UUID) (*UserBookingHistor
 
 	// Calculate spending analysis
 	var monthlySpending []MonthlySpending
-	err = r.db.Raw(`
+	err = r.read.Raw(`
 		SELECT 
 			TO_CHAR(DATE_TRUNC('month', created_at), 'YYYY-MM') as month,
-			COALESCE(SUM(total_price), 0) as amount,
+			COALESCE(SUM(base_total_price), 0) as amount,
 			COUNT(*) as bookings
 		FROM bookings
 		WHERE user_id = ? AND status = 'CONFIRMED'
@@ -1498,7 +1842,7 @@ func (r *repository) GetPersonalAnalytics(userID uuid.UUID) (*PersonalAnalytics,
 
 	// Get actual preferred day from booking patterns
 	var dayOfWeek int
-	err := r.db.Raw(`
+	err := r.read.Raw(`
 		SELECT EXTRACT(DOW FROM e.date_time) as day_of_week
 		FROM bookings b
 		JOIN events e ON b.event_id = e.id
@@ -1516,7 +1860,7 @@ func (r *repository) GetPersonalAnalytics(userID uuid.UUID) (*PersonalAnalytics,
 	}
 
 	// Calculate average advance booking time
-	_ = r.db.Raw(`
+	_ = r.read.Raw(`
 		SELECT AVG(EXTRACT(EPOCH FROM (e.date_time - b.created_at)) / 86400)
 		FROM bookings b
 		JOIN events e ON b.event_id = e.id
@@ -1533,10 +1877,10 @@ func (r *repository) GetPersonalAnalytics(userID uuid.UUID) (*PersonalAnalytics,
 
 	// Get spending insights
 	var monthlyAverage, yearOverYearGrowth float64
-	_ = r.db.Raw(`
+	_ = r.read.Raw(`
 		SELECT AVG(monthly_total)
 		FROM (
-			SELECT COALESCE(SUM(total_price), 0) as monthly_total
+			SELECT COALESCE(SUM(base_total_price), 0) as monthly_total
 			FROM bookings
 			WHERE user_id = ? AND status = 'CONFIRMED'
 			AND created_at >= ?
@@ -1552,9 +1896,731 @@ func (r *repository) GetPersonalAnalytics(userID uuid.UUID) (*PersonalAnalytics,
 		SavingsOpportunity: 0.0, // Requires savings analysis
 	}
 
+	// Interests from the user's profile
+	analytics.Interests = PersonalInterests{PreferredTags: []string{}}
+	_ = r.read.Raw(`
+		SELECT t.name
+		FROM user_preferred_tags upt
+		JOIN tags t ON t.id = upt.tag_id
+		WHERE upt.user_id = ? AND t.is_active = true AND t.deleted_at IS NULL
+		ORDER BY t.name
+	`, userID).Scan(&analytics.Interests.PreferredTags).Error
+
+	if len(analytics.Interests.PreferredTags) > 0 {
+		// Interest in Music also counts Jazz events
+		_ = r.read.Raw(`
+			WITH RECURSIVE tag_tree AS (
+				SELECT upt.tag_id AS id FROM user_preferred_tags upt WHERE upt.user_id = ?
+				UNION
+				SELECT t.id FROM tags t
+				JOIN tag_tree tt ON t.parent_id = tt.id
+				WHERE t.is_active = true AND t.deleted_at IS NULL
+			)
+			SELECT COUNT(DISTINCT e.id)
+			FROM events e
+			JOIN event_tags et ON et.event_id = e.id
+			WHERE et.tag_id IN (SELECT id FROM tag_tree)
+			AND e.status = 'published' AND e.unlisted = false AND e.date_time > ? AND e.deleted_at IS NULL
+		`, userID, time.Now()).Scan(&analytics.Interests.MatchingEvents).Error
+	}
+
+	_ = r.read.Raw(`SELECT COALESCE(city, '') FROM users WHERE id = ?`, userID).Scan(&analytics.Interests.City).Error
+	if analytics.Interests.City != "" {
+		_ = r.read.Raw(`
+			SELECT COUNT(*)
+			FROM events e
+			JOIN venue_templates vt ON vt.id = e.venue_template_id
+			JOIN physical_venues pv ON pv.id = vt.physical_venue_id AND pv.deleted_at IS NULL
+			WHERE LOWER(pv.city) = LOWER(?)
+			AND e.status = 'published' AND e.unlisted = false AND e.date_time > ? AND e.deleted_at IS NULL
+		`, analytics.Interests.City, time.Now()).Scan(&analytics.Interests.NearbyEvents).Error
+	}
+
 	// Recommendations and achievements will be populated by service layer
 	analytics.Recommendations = []PersonalRecommendation{}
 	analytics.Achievements = []Achievement{}
 
 	return &analytics, nil
 }
+
+// Yearly Recap Implementation
+
+func (r *repository) GetYearlyRecap(userID uuid.UUID, year int) (*YearlyRecap, error) {
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(1, 0, 0)
+
+	recap := &YearlyRecap{
+		UserID:         userID,
+		Year:           year,
+		FavoriteTags:   []string{},
+		FavoriteVenues: []string{},
+	}
+
+	var totals struct {
+		TotalBookings  int
+		TotalTickets   int
+		TotalSpent     float64
+		EventsAttended int
+		FirstBooking   *time.Time
+	}
+	err := r.read.Raw(`
+		SELECT
+			COUNT(*) as total_bookings,
+			COALESCE(SUM(total_seats), 0) as total_tickets,
+			COALESCE(SUM(base_total_price), 0) as total_spent,
+			COUNT(DISTINCT event_id) as events_attended,
+			MIN(created_at) as first_booking
+		FROM bookings
+		WHERE user_id = ? AND status = 'CONFIRMED' AND created_at >= ? AND created_at < ?
+	`, userID, start, end).Scan(&totals).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get yearly booking totals: %w", err)
+	}
+
+	recap.TotalBookings = totals.TotalBookings
+	recap.TotalTickets = totals.TotalTickets
+	recap.TotalSpent = totals.TotalSpent
+	recap.EventsAttended = totals.EventsAttended
+	recap.FirstBooking = totals.FirstBooking
+
+	if recap.TotalBookings == 0 {
+		return recap, nil
+	}
+
+	err = r.read.Raw(`
+		SELECT t.name
+		FROM bookings b
+		JOIN event_tags et ON et.event_id = b.event_id
+		JOIN tags t ON t.id = et.tag_id
+		WHERE b.user_id = ? AND b.status = 'CONFIRMED' AND b.created_at >= ? AND b.created_at < ?
+		GROUP BY t.name
+		ORDER BY COUNT(*) DESC, t.name ASC
+		LIMIT 3
+	`, userID, start, end).Scan(&recap.FavoriteTags).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get favorite tags: %w", err)
+	}
+
+	err = r.read.Raw(`
+		SELECT e.venue
+		FROM bookings b
+		JOIN events e ON e.id = b.event_id
+		WHERE b.user_id = ? AND b.status = 'CONFIRMED' AND b.created_at >= ? AND b.created_at < ?
+		GROUP BY e.venue
+		ORDER BY COUNT(*) DESC, e.venue ASC
+		LIMIT 3
+	`, userID, start, end).Scan(&recap.FavoriteVenues).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get favorite venues: %w", err)
+	}
+
+	err = r.read.Raw(`
+		SELECT TO_CHAR(DATE_TRUNC('month', created_at), 'FMMonth')
+		FROM bookings
+		WHERE user_id = ? AND status = 'CONFIRMED' AND created_at >= ? AND created_at < ?
+		GROUP BY DATE_TRUNC('month', created_at)
+		ORDER BY COUNT(*) DESC, DATE_TRUNC('month', created_at) ASC
+		LIMIT 1
+	`, userID, start, end).Scan(&recap.BusiestMonth).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get busiest month: %w", err)
+	}
+
+	return recap, nil
+}
+
+func (r *repository) GetRecapSubscription(userID uuid.UUID) (*RecapSubscription, error) {
+	var subscription RecapSubscription
+	err := r.db.Where("user_id = ?", userID).First(&subscription).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// Recaps are opt-in, no row means not subscribed
+			return &RecapSubscription{UserID: userID}, nil
+		}
+		return nil, fmt.Errorf("failed to get recap subscription: %w", err)
+	}
+	return &subscription, nil
+}
+
+func (r *repository) UpsertRecapSubscription(userID uuid.UUID, optedIn bool) (*RecapSubscription, error) {
+	subscription := &RecapSubscription{UserID: userID, OptedIn: optedIn}
+	err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{"opted_in": optedIn, "updated_at": time.Now()}),
+	}).Create(subscription).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to update recap subscription: %w", err)
+	}
+
+	return r.GetRecapSubscription(userID)
+}
+
+// GetRecapRecipients returns opted-in users with bookings in the year whose
+// recap hasn't been queued yet
+func (r *repository) GetRecapRecipients(year int, limit int) ([]uuid.UUID, error) {
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(1, 0, 0)
+
+	var userIDs []uuid.UUID
+	err := r.db.Raw(`
+		SELECT s.user_id
+		FROM yearly_recap_subscriptions s
+		WHERE s.opted_in = true AND s.last_sent_year < ?
+			AND EXISTS (
+				SELECT 1 FROM bookings b
+				WHERE b.user_id = s.user_id AND b.status = 'CONFIRMED'
+					AND b.created_at >= ? AND b.created_at < ?
+			)
+		ORDER BY s.user_id
+		LIMIT ?
+	`, year, start, end, limit).Scan(&userIDs).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recap recipients: %w", err)
+	}
+	return userIDs, nil
+}
+
+// MarkRecapQueued records the sent year and writes the recap email to the
+// outbox in the same transaction
+func (r *repository) MarkRecapQueued(userID uuid.UUID, year int, message *outbox.Message) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&RecapSubscription{}).
+			Where("user_id = ?", userID).
+			Updates(map[string]interface{}{"last_sent_year": year, "updated_at": time.Now()}).Error
+		if err != nil {
+			return fmt.Errorf("failed to update recap subscription: %w", err)
+		}
+
+		return outbox.Enqueue(tx, message)
+	})
+}
+
+// Scheduled Reports Implementation
+
+func (r *repository) GetReportSubscriptions(adminID uuid.UUID) ([]ReportSubscription, error) {
+	var subscriptions []ReportSubscription
+	err := r.db.Where("admin_id = ?", adminID).Order("frequency").Find(&subscriptions).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get report subscriptions: %w", err)
+	}
+	return subscriptions, nil
+}
+
+func (r *repository) GetReportSubscription(adminID uuid.UUID, frequency ReportFrequency) (*ReportSubscription, error) {
+	var subscription ReportSubscription
+	err := r.db.Where("admin_id = ? AND frequency = ?", adminID, frequency).First(&subscription).Error
+	if err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+func (r *repository) UpsertReportSubscription(subscription *ReportSubscription) (*ReportSubscription, error) {
+	err := r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "admin_id"}, {Name: "frequency"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"enabled":    subscription.Enabled,
+			"sections":   subscription.Sections,
+			"updated_at": time.Now(),
+		}),
+	}).Create(subscription).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to update report subscription: %w", err)
+	}
+
+	return r.GetReportSubscription(subscription.AdminID, subscription.Frequency)
+}
+
+// GetDueReportSubscriptions returns enabled subscriptions of admins that have not
+// been sent the report for the period starting at periodStart
+func (r *repository) GetDueReportSubscriptions(frequency ReportFrequency, periodStart time.Time, limit int) ([]ReportSubscription, error) {
+	var subscriptions []ReportSubscription
+	err := r.db.
+		Joins("JOIN users u ON u.id = analytics_report_subscriptions.admin_id AND u.role = 'ADMIN' AND u.deleted_at IS NULL").
+		Where("analytics_report_subscriptions.frequency = ? AND analytics_report_subscriptions.enabled = true", frequency).
+		Where("analytics_report_subscriptions.last_period_start IS NULL OR analytics_report_subscriptions.last_period_start < ?", periodStart).
+		Order("analytics_report_subscriptions.id").
+		Limit(limit).
+		Find(&subscriptions).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get due report subscriptions: %w", err)
+	}
+	return subscriptions, nil
+}
+
+// MarkReportQueued records the sent period and writes the report email to the
+// outbox in the same transaction
+func (r *repository) MarkReportQueued(subscriptionID uuid.UUID, periodStart time.Time, message *outbox.Message) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&ReportSubscription{}).
+			Where("id = ?", subscriptionID).
+			Updates(map[string]interface{}{"last_period_start": periodStart, "updated_at": time.Now()}).Error
+		if err != nil {
+			return fmt.Errorf("failed to update report subscription: %w", err)
+		}
+
+		return outbox.Enqueue(tx, message)
+	})
+}
+
+func (r *repository) GetReportRevenue(start, end time.Time) (*ReportRevenueSummary, error) {
+	var summary ReportRevenueSummary
+	err := r.read.Raw(`
+		SELECT
+			COALESCE(SUM(base_total_price), 0) as total_revenue,
+			COUNT(*) as confirmed_bookings,
+			COALESCE(SUM(total_seats), 0) as tickets_sold,
+			COALESCE(AVG(base_total_price), 0) as average_order
+		FROM bookings
+		WHERE status = 'CONFIRMED' AND created_at >= ? AND created_at < ?
+	`, start, end).Scan(&summary).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get report revenue: %w", err)
+	}
+	return &summary, nil
+}
+
+// GetRevenueBreakdown sums the charges of bookings confirmed in the period,
+// converted to the base currency at each booking's exchange rate
+func (r *repository) GetRevenueBreakdown(start, end time.Time) (*RevenueBreakdown, error) {
+	breakdown := RevenueBreakdown{StartDate: start, EndDate: end, Taxes: []TaxCollected{}}
+	err := r.read.Raw(`
+		SELECT
+			COUNT(*) as confirmed_bookings,
+			COALESCE(SUM(b.base_total_price), 0) as gross_revenue,
+			COALESCE(SUM(c.base * b.exchange_rate), 0) as base_revenue,
+			COALESCE(SUM(c.section * b.exchange_rate), 0) as section_adjustments,
+			COALESCE(SUM(c.service_fee * b.exchange_rate), 0) as service_fees,
+			COALESCE(SUM(c.tax * b.exchange_rate), 0) as tax_collected,
+			COALESCE(SUM(b.base_total_price) FILTER (WHERE c.booking_id IS NULL), 0) as unitemized_revenue
+		FROM bookings b
+		LEFT JOIN (
+			SELECT
+				booking_id,
+				SUM(amount) FILTER (WHERE type = 'BASE') as base,
+				SUM(amount) FILTER (WHERE type = 'SECTION') as section,
+				SUM(amount) FILTER (WHERE type = 'SERVICE_FEE') as service_fee,
+				SUM(amount) FILTER (WHERE type = 'TAX') as tax
+			FROM booking_charges
+			GROUP BY booking_id
+		) c ON c.booking_id = b.id
+		WHERE b.status = 'CONFIRMED' AND b.created_at >= ? AND b.created_at < ?
+	`, start, end).Scan(&breakdown).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get revenue breakdown: %w", err)
+	}
+
+	err = r.read.Raw(`
+		SELECT
+			c.name,
+			c.rate,
+			SUM(c.amount * b.exchange_rate) as amount
+		FROM booking_charges c
+		JOIN bookings b ON b.id = c.booking_id
+		WHERE c.type = 'TAX' AND b.status = 'CONFIRMED' AND b.created_at >= ? AND b.created_at < ?
+		GROUP BY c.name, c.rate
+		ORDER BY amount DESC, c.name ASC
+	`, start, end).Scan(&breakdown.Taxes).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get taxes collected: %w", err)
+	}
+
+	breakdown.NetRevenue = breakdown.GrossRevenue - breakdown.TaxCollected
+	return &breakdown, nil
+}
+
+func (r *repository) GetReportTopEvents(start, end time.Time, limit int) ([]ReportTopEvent, error) {
+	events := []ReportTopEvent{}
+	err := r.read.Raw(`
+		SELECT
+			e.id as event_id,
+			e.name as event_name,
+			COUNT(b.id) as bookings,
+			COALESCE(SUM(b.base_total_price), 0) as revenue
+		FROM bookings b
+		JOIN events e ON e.id = b.event_id
+		WHERE b.status = 'CONFIRMED' AND b.created_at >= ? AND b.created_at < ?
+		GROUP BY e.id, e.name
+		ORDER BY revenue DESC, bookings DESC, e.name ASC
+		LIMIT ?
+	`, start, end, limit).Scan(&events).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get report top events: %w", err)
+	}
+	return events, nil
+}
+
+// GetReportCancellationRate counts bookings made in the period and how many of them were cancelled
+func (r *repository) GetReportCancellationRate(start, end time.Time) (*ReportCancellationRate, error) {
+	var rate ReportCancellationRate
+	err := r.read.Raw(`
+		SELECT
+			COUNT(*) as total_bookings,
+			COUNT(*) FILTER (WHERE status = 'CANCELLED') as cancelled_bookings
+		FROM bookings
+		WHERE created_at >= ? AND created_at < ?
+	`, start, end).Scan(&rate).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get report cancellation rate: %w", err)
+	}
+
+	if rate.TotalBookings > 0 {
+		rate.Rate = float64(rate.CancelledBookings) / float64(rate.TotalBookings) * 100
+	}
+	return &rate, nil
+}
+
+func (r *repository) GetUserFirstName(userID uuid.UUID) (string, error) {
+	var firstName string
+	err := r.db.Table("users").Select("first_name").Where("id = ?", userID).Scan(&firstName).Error
+	return firstName, err
+}
+
+// Rollups Implementation
+
+// Live returns a repository that aggregates the source tables directly, for
+// callers that cannot wait for the next rollup
+func (r *repository) Live() Repository {
+	return &repository{db: r.db, read: r.read, live: true}
+}
+
+func (r *repository) getEventPerformanceFromRollup() ([]EventPerformance, error) {
+	var performances []EventPerformance
+
+	// Joined with events so events deleted since the last rollup drop out
+	err := r.read.Raw(`
+		SELECT
+			a.event_id,
+			a.event_name,
+			a.venue,
+			a.date_time,
+			a.booking_count,
+			a.revenue,
+			COALESCE(a.tickets_sold::float / NULLIF(a.capacity, 0) * 100, 0) as utilization,
+			a.average_rating,
+			a.review_count
+		FROM event_revenue_agg a
+		JOIN events e ON e.id = a.event_id AND e.deleted_at IS NULL
+		ORDER BY a.booking_count DESC
+		LIMIT 20
+	`).Scan(&performances).Error
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event performance rollup: %w", err)
+	}
+
+	return performances, nil
+}
+
+func (r *repository) getTagPopularityFromRollup() ([]TagAnalytics, error) {
+	var analytics []TagAnalytics
+
+	err := r.read.Raw(`
+		SELECT
+			a.tag_id,
+			a.tag_name,
+			a.event_count,
+			a.total_bookings,
+			a.total_revenue,
+			a.avg_utilization
+		FROM tag_popularity_agg a
+		JOIN tags t ON t.id = a.tag_id AND t.is_active = true AND t.deleted_at IS NULL
+		ORDER BY a.total_bookings DESC, a.total_revenue DESC
+		LIMIT 20
+	`).Scan(&analytics).Error
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tag popularity rollup: %w", err)
+	}
+
+	scoreTagPopularity(analytics)
+
+	return analytics, nil
+}
+
+func (r *repository) getDailyBookingStatsFromRollup(days int) ([]DailyBookingStats, error) {
+	var stats []DailyBookingStats
+
+	err := r.read.Raw(`
+		SELECT
+			date,
+			total_bookings,
+			confirmed_bookings,
+			cancelled_bookings,
+			revenue,
+			average_value
+		FROM daily_bookings_agg
+		WHERE date >= DATE(?)
+		ORDER BY date DESC
+	`, time.Now().AddDate(0, 0, -days)).Scan(&stats).Error
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get daily booking rollup: %w", err)
+	}
+
+	return stats, nil
+}
+
+// RefreshDailyBookingsAgg rebuilds the daily rows from since onwards
+func (r *repository) RefreshDailyBookingsAgg(since time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`DELETE FROM daily_bookings_agg WHERE date >= DATE(?)`, since).Error; err != nil {
+			return fmt.Errorf("failed to clear daily booking rollup: %w", err)
+		}
+
+		err := tx.Exec(`
+			INSERT INTO daily_bookings_agg
+				(date, total_bookings, confirmed_bookings, cancelled_bookings, revenue, average_value, refreshed_at)
+			SELECT
+				DATE(created_at),
+				COUNT(*),
+				SUM(CASE WHEN status = 'CONFIRMED' THEN 1 ELSE 0 END),
+				SUM(CASE WHEN status = 'CANCELLED' THEN 1 ELSE 0 END),
+				COALESCE(SUM(CASE WHEN status = 'CONFIRMED' THEN base_total_price ELSE 0 END), 0),
+				COALESCE(AVG(CASE WHEN status = 'CONFIRMED' THEN base_total_price ELSE NULL END), 0),
+				NOW()
+			FROM bookings
+			WHERE created_at >= DATE(?)
+			GROUP BY DATE(created_at)
+		`, since).Error
+		if err != nil {
+			return fmt.Errorf("failed to refresh daily booking rollup: %w", err)
+		}
+		return nil
+	})
+}
+
+// RefreshEventRevenueAgg replaces the per-event rollup
+func (r *repository) RefreshEventRevenueAgg() error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`DELETE FROM event_revenue_agg`).Error; err != nil {
+			return fmt.Errorf("failed to clear event revenue rollup: %w", err)
+		}
+
+		err := tx.Exec(`
+			INSERT INTO event_revenue_agg
+				(event_id, event_name, venue, date_time, booking_count, revenue, tickets_sold, capacity, average_rating, review_count, refreshed_at)
+			SELECT
+				e.id,
+				e.name,
+				e.venue,
+				e.date_time,
+				COUNT(b.id),
+				COALESCE(SUM(b.base_total_price), 0),
+				COALESCE(SUM(b.total_seats), 0),
+				COALESCE(cap.capacity, 0),
+				COALESCE(rv.average_rating, 0),
+				COALESCE(rv.review_count, 0),
+				NOW()
+			FROM events e
+			LEFT JOIN bookings b ON e.id = b.event_id AND b.status = 'CONFIRMED'
+			LEFT JOIN (` + templateCapacitySQL + `) cap ON cap.template_id = e.venue_template_id
+			LEFT JOIN (
+				SELECT event_id, ROUND(AVG(rating)::numeric, 1) as average_rating, COUNT(*) as review_count
+				FROM event_reviews
+				WHERE status = 'PUBLISHED'
+				GROUP BY event_id
+			) rv ON rv.event_id = e.id
+			WHERE e.deleted_at IS NULL
+			GROUP BY e.id, e.name, e.venue, e.date_time, cap.capacity, rv.average_rating, rv.review_count
+		`).Error
+		if err != nil {
+			return fmt.Errorf("failed to refresh event revenue rollup: %w", err)
+		}
+		return nil
+	})
+}
+
+// RefreshTagPopularityAgg replaces the per-tag rollup
+func (r *repository) RefreshTagPopularityAgg() error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`DELETE FROM tag_popularity_agg`).Error; err != nil {
+			return fmt.Errorf("failed to clear tag popularity rollup: %w", err)
+		}
+
+		err := tx.Exec(`
+			INSERT INTO tag_popularity_agg
+				(tag_id, tag_name, event_count, total_bookings, total_revenue, avg_utilization, refreshed_at)
+			SELECT
+				t.id,
+				t.name,
+				COUNT(DISTINCT et.event_id),
+				COUNT(DISTINCT b.id),
+				COALESCE(SUM(b.base_total_price), 0),
+				COALESCE(AVG(CASE WHEN b.status = 'CONFIRMED' THEN 1.0 ELSE 0.0 END) * 100, 0),
+				NOW()
+			FROM tags t
+			LEFT JOIN event_tags et ON t.id = et.tag_id
+			LEFT JOIN bookings b ON et.event_id = b.event_id AND b.status = 'CONFIRMED'
+			WHERE t.is_active = true AND t.deleted_at IS NULL
+			GROUP BY t.id, t.name
+		`).Error
+		if err != nil {
+			return fmt.Errorf("failed to refresh tag popularity rollup: %w", err)
+		}
+		return nil
+	})
+}
+
+//  SHARED EVENT ANALYTICS
+
+func (r *repository) CreateShareLink(link *ShareLink) error {
+	if err := r.db.Create(link).Error; err != nil {
+		return fmt.Errorf("failed to create share link: %w", err)
+	}
+	return nil
+}
+
+func (r *repository) GetShareLink(id uuid.UUID) (*ShareLink, error) {
+	var link ShareLink
+	if err := r.db.Where("id = ?", id).First(&link).Error; err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
+
+func (r *repository) GetShareLinks(eventID uuid.UUID) ([]ShareLink, error) {
+	var links []ShareLink
+	err := r.db.Where("event_id = ?", eventID).Order("created_at DESC").Find(&links).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get share links: %w", err)
+	}
+	return links, nil
+}
+
+// RevokeShareLink revokes a link once; revoking it again keeps the first time
+func (r *repository) RevokeShareLink(id uuid.UUID, revokedAt time.Time) error {
+	result := r.db.Model(&ShareLink{}).Where("id = ?", id).
+		Update("revoked_at", gorm.Expr("COALESCE(revoked_at, ?)", revokedAt))
+	if result.Error != nil {
+		return fmt.Errorf("failed to revoke share link: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *repository) RecordShareLinkView(id uuid.UUID, viewedAt time.Time) error {
+	return r.db.Model(&ShareLink{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"view_count":     gorm.Expr("view_count + 1"),
+		"last_viewed_at": viewedAt,
+	}).Error
+}
+
+// GetSharedEvent returns the event fields shown at the top of a snapshot
+func (r *repository) GetSharedEvent(eventID uuid.UUID) (*SharedEventAnalytics, error) {
+	var event struct {
+		ID       uuid.UUID
+		Name     string
+		Venue    string
+		DateTime time.Time
+	}
+	err := r.read.Table("events").
+		Select("id, name, venue, date_time").
+		Where("id = ? AND deleted_at IS NULL", eventID).
+		Take(&event).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &SharedEventAnalytics{
+		EventID:   event.ID,
+		EventName: event.Name,
+		Venue:     event.Venue,
+		DateTime:  event.DateTime,
+	}, nil
+}
+
+// GetEventSalesByDay returns confirmed bookings, tickets and revenue per day the
+// bookings were made, oldest first
+func (r *repository) GetEventSalesByDay(eventID uuid.UUID) ([]EventSalesDay, error) {
+	var days []EventSalesDay
+	err := r.read.Raw(`
+		SELECT
+			TO_CHAR(created_at, 'YYYY-MM-DD') AS date,
+			COUNT(*) AS bookings,
+			COALESCE(SUM(total_seats), 0) AS tickets,
+			COALESCE(SUM(base_total_price), 0) AS revenue
+		FROM bookings
+		WHERE event_id = ? AND status = 'CONFIRMED'
+		GROUP BY TO_CHAR(created_at, 'YYYY-MM-DD')
+		ORDER BY date
+	`, eventID).Scan(&days).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event sales: %w", err)
+	}
+	return days, nil
+}
+
+// GetEventSectionUtilization returns every section of the event's venue with the
+// seats sold in it by confirmed bookings
+func (r *repository) GetEventSectionUtilization(eventID uuid.UUID) ([]SectionUtilization, error) {
+	var sections []SectionUtilization
+	err := r.read.Raw(`
+		SELECT
+			vs.name AS section_name,
+			vs.total_seats AS capacity,
+			COALESCE(sold.tickets, 0) AS tickets_sold,
+			CASE WHEN vs.total_seats > 0 THEN COALESCE(sold.tickets, 0)::float / vs.total_seats * 100 ELSE 0 END AS utilization
+		FROM events e
+		JOIN venue_sections vs ON vs.template_id = e.venue_template_id
+		LEFT JOIN (
+			SELECT sb.section_id, COUNT(*) AS tickets
+			FROM seat_bookings sb
+			JOIN bookings b ON b.id = sb.booking_id AND b.status = 'CONFIRMED'
+			WHERE sb.event_id = ?
+			GROUP BY sb.section_id
+		) sold ON sold.section_id = vs.id
+		WHERE e.id = ?
+		ORDER BY vs.name
+	`, eventID, eventID).Scan(&sections).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get section utilization: %w", err)
+	}
+	return sections, nil
+}
+
+// GetEventDemographics counts the event's confirmed attendees by how they relate
+// to the platform. Returning attendees had a confirmed booking for another event
+// before their first booking for this one.
+func (r *repository) GetEventDemographics(eventID uuid.UUID) (*EventDemographicCounts, error) {
+	var counts EventDemographicCounts
+	err := r.read.Raw(`
+		WITH attendees AS (
+			SELECT user_id, MIN(created_at) AS booked_at
+			FROM bookings
+			WHERE event_id = ? AND status = 'CONFIRMED'
+			GROUP BY user_id
+		)
+		SELECT
+			COUNT(*) AS attendees,
+			COUNT(*) FILTER (WHERE EXISTS (
+				SELECT 1 FROM bookings p
+				WHERE p.user_id = a.user_id AND p.event_id <> ? AND p.status = 'CONFIRMED' AND p.created_at < a.booked_at
+			)) AS returning_attendees,
+			COUNT(*) FILTER (WHERE u.created_at > a.booked_at - INTERVAL '30 days') AS account_under_month,
+			COUNT(*) FILTER (WHERE u.created_at <= a.booked_at - INTERVAL '30 days' AND u.created_at > a.booked_at - INTERVAL '1 year') AS account_under_year,
+			COUNT(*) FILTER (WHERE u.created_at <= a.booked_at - INTERVAL '1 year') AS account_over_year
+		FROM attendees a
+		JOIN users u ON u.id = a.user_id
+	`, eventID, eventID).Scan(&counts).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to count attendees: %w", err)
+	}
+
+	err = r.read.Raw(`
+		SELECT
+			COUNT(*) FILTER (WHERE total_seats = 1) AS solo_bookings,
+			COUNT(*) FILTER (WHERE total_seats = 2) AS pair_bookings,
+			COUNT(*) FILTER (WHERE total_seats BETWEEN 3 AND 4) AS small_group_bookings,
+			COUNT(*) FILTER (WHERE total_seats >= 5) AS large_group_bookings
+		FROM bookings
+		WHERE event_id = ? AND status = 'CONFIRMED'
+	`, eventID).Scan(&counts.GroupSizes).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to count group sizes: %w", err)
+	}
+
+	return &counts, nil
+}
@@ -0,0 +1,96 @@
+package analytics
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// RollupJobConfig contains configuration for the analytics rollup job
+type RollupJobConfig struct {
+	Interval     time.Duration // How often the rollups are refreshed
+	NightlyHour  int           // Hour of day (server time) the daily rows are rebuilt in full
+	BackfillDays int           // Days of daily booking rows the nightly rebuild covers
+}
+
+// DefaultRollupJobConfig returns default rollup job configuration
+func DefaultRollupJobConfig() *RollupJobConfig {
+	return &RollupJobConfig{
+		Interval:     time.Hour, // Dashboards lag bookings by at most an hour
+		NightlyHour:  3,         // Rebuild in the quiet hours
+		BackfillDays: 400,       // Covers a year-on-year comparison
+	}
+}
+
+// RollupJob keeps the analytics rollup tables up to date. Each run refreshes the
+// event and tag rollups and the last two days of daily booking rows; once a night
+// the daily rows are rebuilt in full to pick up cancellations of older bookings.
+type RollupJob struct {
+	service     Service
+	config      *RollupJobConfig
+	done        chan struct{}
+	lastNightly time.Time
+}
+
+// NewRollupJob creates a new rollup job
+func NewRollupJob(service Service, config *RollupJobConfig) *RollupJob {
+	if config == nil {
+		config = DefaultRollupJobConfig()
+	}
+
+	return &RollupJob{
+		service: service,
+		config:  config,
+		done:    make(chan struct{}),
+	}
+}
+
+// Start starts the rollup job
+func (j *RollupJob) Start(ctx context.Context) {
+	log.Printf("Started analytics rollup job with %v interval", j.config.Interval)
+	go j.run(ctx)
+}
+
+// Stop stops the rollup job
+func (j *RollupJob) Stop() {
+	close(j.done)
+}
+
+func (j *RollupJob) run(ctx context.Context) {
+	// Fill the rollups on startup so dashboards do not wait for the first tick
+	j.refresh(ctx)
+
+	ticker := time.NewTicker(j.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			j.refresh(ctx)
+		case <-j.done:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (j *RollupJob) refresh(ctx context.Context) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	nightly := j.lastNightly.IsZero() || (now.Hour() == j.config.NightlyHour && j.lastNightly.Before(today))
+	since := today.AddDate(0, 0, -1)
+	if nightly {
+		since = today.AddDate(0, 0, -j.config.BackfillDays)
+	}
+
+	if err := j.service.RefreshRollups(ctx, since); err != nil {
+		log.Printf("Failed to refresh analytics rollups: %v", err)
+		return
+	}
+
+	if nightly {
+		j.lastNightly = now
+	}
+}
@@ -13,14 +13,23 @@ func SetupAnalyticsRoutes(rg *gin.RouterGroup, controller Controller) {
 	// Setup admin analytics routes (protected)
 	setupAdminAnalyticsRoutes(analytics, controller)
 
+	// Setup admin share link routes (protected, no partner keys)
+	setupShareLinkRoutes(analytics, controller)
+
+	// Setup admin recap routes (protected, no partner keys)
+	setupRecapRoutes(analytics, controller)
+
 	// Setup user analytics routes (protected)
 	setupUserAnalyticsRoutes(analytics, controller)
+
+	// Setup shared event analytics routes (share token only)
+	setupSharedAnalyticsRoutes(analytics, controller)
 }
 
 func setupAdminAnalyticsRoutes(rg *gin.RouterGroup, controller Controller) {
 	admin := rg.Group("/admin")
-	admin.Use(middleware.JWTAuth())
-	admin.Use(middleware.RequireAdmin())
+	admin.Use(middleware.JWTOrAPIKey(middleware.ScopeAnalyticsRead)) // Partner keys can read reports
+	admin.Use(middleware.RequirePermission(middleware.PermissionAnalyticsRead))
 
 	// Dashboard & Overview
 	admin.GET("/dashboard", controller.GetDashboardAnalytics)
@@ -39,6 +48,7 @@ func setupAdminAnalyticsRoutes(rg *gin.RouterGroup, controller Controller) {
 		tags.GET("/popularity", controller.GetTagPopularityAnalytics) // Tag popularity metrics
 		tags.GET("/trends", controller.GetTagTrends)                  // Tag trends (with ?months=6 param)
 		tags.GET("/comparisons", controller.GetTagComparisons)        // Tag performance comparisons
+		tags.GET("/hierarchy", controller.GetTagHierarchyAnalytics)   // Child tags rolled up into their parents
 	}
 
 	// Booking Analytics
@@ -49,11 +59,49 @@ func setupAdminAnalyticsRoutes(rg *gin.RouterGroup, controller Controller) {
 		bookings.GET("/cancellations", controller.GetCancellationAnalytics) // Cancellation rates & analysis
 	}
 
+	// Revenue split into ticket price, fees and taxes
+	admin.GET("/revenue/breakdown", controller.GetRevenueBreakdown) // With ?start_date=2025-01-01&end_date=2025-01-31 params
+
 	// User Analytics
 	users := admin.Group("/users")
 	{
 		users.GET("", controller.GetUserAnalytics) // User behavior analytics
 	}
+
+	// Scheduled report emails
+	reports := admin.Group("/reports")
+	{
+		reports.GET("/subscriptions", controller.GetReportSubscriptions)              // The admin's report subscriptions
+		reports.PUT("/subscriptions/:frequency", controller.UpdateReportSubscription) // Opt in/out of the weekly or monthly report
+		reports.GET("/preview", controller.PreviewReport)                             // Render the report (with ?frequency=weekly&format=html params)
+	}
+}
+
+// Share links grant read access on their own, so they are managed with a JWT
+// only: a partner key with the analytics:read scope must not list or mint them
+func setupShareLinkRoutes(rg *gin.RouterGroup, controller Controller) {
+	admin := rg.Group("/admin")
+	admin.Use(middleware.JWTAuth())
+	admin.Use(middleware.RequirePermission(middleware.PermissionAnalyticsRead))
+
+	// Read-only snapshots for sponsors and venue managers without accounts
+	events := admin.Group("/events")
+	{
+		events.POST("/:id/share-links", controller.CreateShareLink) // Create a share link (returns its URL)
+		events.GET("/:id/share-links", controller.GetShareLinks)    // The event's share links with view counts
+	}
+	admin.DELETE("/share-links/:id", controller.RevokeShareLink) // Revoke a share link
+}
+
+// A recap run emails every opted-in user, so starting one takes the permission
+// over outgoing notifications, not the read-only analytics:read
+func setupRecapRoutes(rg *gin.RouterGroup, controller Controller) {
+	admin := rg.Group("/admin")
+	admin.Use(middleware.JWTAuth())
+	admin.Use(middleware.RequirePermission(middleware.PermissionNotificationsManage))
+
+	// Yearly recap emails
+	admin.POST("/recaps/send", controller.SendYearlyRecaps) // Trigger recap run (with ?year=2025 param)
 }
 
 func setupUserAnalyticsRoutes(rg *gin.RouterGroup, controller Controller) {
@@ -67,4 +115,18 @@ func setupUserAnalyticsRoutes(rg *gin.RouterGroup, controller Controller) {
 	}
 
 	user.GET("/personal", controller.GetPersonalAnalytics) // Personal booking insights
+
+	// Yearly recap
+	recap := user.Group("/recap")
+	{
+		recap.GET("", controller.GetYearlyRecap)                       // Year in events (with ?year=2025 param)
+		recap.GET("/subscription", controller.GetRecapSubscription)    // Recap email opt-in status
+		recap.PUT("/subscription", controller.UpdateRecapSubscription) // Opt in/out of the recap email
+	}
+}
+
+func setupSharedAnalyticsRoutes(rg *gin.RouterGroup, controller Controller) {
+	shared := rg.Group("/shared")
+
+	shared.GET("/:token", controller.GetSharedEventAnalytics) // Event snapshot behind a share link
 }
@@ -3,8 +3,11 @@ package analytics
 import (
 	"context"
 	"fmt"
+	"log"
+	"strings"
 	"time"
 
+	"evently/internal/outbox"
 	"evently/internal/shared/utils/constants"
 	"evently/pkg/cache"
 
@@ -14,21 +17,23 @@ import (
 // Service defines the analytics service interface
 type Service interface {
 	// Dashboard Analytics
-	GetDashboardAnalytics() (*DashboardAnalytics, error)
+	// refresh=true reads the source tables instead of the rollups
+	GetDashboardAnalytics(ctx context.Context, refresh bool) (*DashboardAnalytics, error)
 
 	// Event Analytics (migrated from events package)
-	GetEventAnalytics(eventID uuid.UUID) (*EventAnalytics, error)
+	GetEventAnalytics(ctx context.Context, eventID uuid.UUID) (*EventAnalytics, error)
 	GetGlobalEventAnalytics() (*GlobalEventAnalytics, error)
 
 	// Tag Analytics (migrated from tags package)
-	GetTagAnalytics() (*TagAnalyticsResponse, error)
-	GetTagPopularityAnalytics() ([]TagAnalytics, error)
+	GetTagAnalytics(refresh bool) (*TagAnalyticsResponse, error)
+	GetTagPopularityAnalytics(refresh bool) ([]TagAnalytics, error)
 	GetTagTrends(months int) ([]TagTrend, error)
 	GetTagComparisons() ([]TagComparison, error)
+	GetTagHierarchyAnalytics() ([]TagHierarchyAnalytics, error)
 
 	// Booking Analytics (new)
-	GetBookingAnalytics() (*BookingAnalytics, error)
-	GetBookingDailyStats() ([]DailyBookingStats, error)
+	GetBookingAnalytics(refresh bool) (*BookingAnalytics, error)
+	GetBookingDailyStats(refresh bool) ([]DailyBookingStats, error)
 	GetCancellationAnalytics() (*CancellationAnalytics, error)
 
 	// User Analytics (new)
@@ -37,17 +42,49 @@ type Service interface {
 	// User-facing Analytics
 	GetUserBookingHistory(userID uuid.UUID) (*UserBookingHistory, error)
 	GetPersonalAnalytics(userID uuid.UUID) (*PersonalAnalytics, error)
+
+	// Yearly recap
+	GetYearlyRecap(userID uuid.UUID, year int) (*YearlyRecap, error)
+	GetRecapSubscription(userID uuid.UUID) (*RecapSubscription, error)
+	SetRecapSubscription(userID uuid.UUID, optedIn bool) (*RecapSubscription, error)
+	SendYearlyRecaps(ctx context.Context, year int, batchSize int, batchInterval time.Duration) (*RecapRunResult, error)
+
+	// Rollups
+	RefreshRollups(ctx context.Context, dailySince time.Time) error
+
+	// Scheduled reports
+	GetReportSubscriptions(adminID uuid.UUID) ([]ReportSubscriptionResponse, error)
+	SetReportSubscription(adminID uuid.UUID, frequency ReportFrequency, req ReportSubscriptionRequest) (*ReportSubscriptionResponse, error)
+	BuildReport(frequency ReportFrequency, start, end time.Time) (*AnalyticsReport, error)
+	PreviewReport(adminID uuid.UUID, frequency ReportFrequency) (*ReportPreview, error)
+	SendScheduledReports(ctx context.Context, frequency ReportFrequency, now time.Time, batchSize int) (*ReportRunResult, error)
+	SetReportRenderer(renderer ReportRenderer)
+
+	// Fees and taxes
+	GetRevenueBreakdown(start, end time.Time) (*RevenueBreakdown, error)
+	SetBaseCurrency(code string)
+
+	// Shared event analytics
+	SetShareLinkConfig(config *ShareLinkConfig)
+	CreateShareLink(adminID, eventID uuid.UUID, req ShareLinkRequest) (*ShareLinkResponse, error)
+	GetShareLinks(eventID uuid.UUID) ([]ShareLinkResponse, error)
+	RevokeShareLink(linkID uuid.UUID) error
+	GetSharedEventAnalytics(token string) (*SharedEventAnalytics, error)
 }
 
 // service implements the Service interface
 type service struct {
-	repo         Repository
-	cacheService cache.Service
+	repo           Repository
+	cacheService   cache.Service
+	reportRenderer ReportRenderer
+	baseCurrency   string // Revenue is reported in this currency
+	shareConfig    *ShareLinkConfig
+	shareKey       []byte // Signs share tokens
 }
 
 // NewService creates a new analytics service instance
 func NewService(repo Repository) Service {
-	return &service{repo: repo}
+	return &service{repo: repo, baseCurrency: "INR"}
 }
 
 // SetCacheService injects the cache service dependency
@@ -55,14 +92,21 @@ func (s *service) SetCacheService(cacheService cache.Service) {
 	s.cacheService = cacheService
 }
 
+// reader picks the rollup tables, or the source tables when a caller asks for fresh numbers
+func (s *service) reader(refresh bool) Repository {
+	if refresh {
+		return s.repo.Live()
+	}
+	return s.repo
+}
+
 // Dashboard Analytics Implementation
 
-func (s *service) GetDashboardAnalytics() (*DashboardAnalytics, error) {
-	ctx := context.Background()
+func (s *service) GetDashboardAnalytics(ctx context.Context, refresh bool) (*DashboardAnalytics, error) {
 	cacheKey := constants.CACHE_KEY_ANALYTICS_DASHBOARD
 
-	// Try to get from cache first
-	if s.cacheService != nil {
+	// Try to get from cache first; a refresh replaces the cached copy
+	if s.cacheService != nil && !refresh {
 		var cachedDashboard DashboardAnalytics
 		if err := s.cacheService.Get(ctx, cacheKey, &cachedDashboard); err == nil {
 			return &cachedDashboard, nil
@@ -70,7 +114,7 @@ func (s *service) GetDashboardAnalytics() (*DashboardAnalytics, error) {
 	}
 
 	// Cache miss - get from repository
-	dashboard, err := s.repo.GetDashboardAnalytics()
+	dashboard, err := s.reader(refresh).GetDashboardAnalytics()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get dashboard analytics: %w", err)
 	}
@@ -88,8 +132,7 @@ func (s *service) GetDashboardAnalytics() (*DashboardAnalytics, error) {
 
 // Event Analytics Implementation
 
-func (s *service) GetEventAnalytics(eventID uuid.UUID) (*EventAnalytics, error) {
-	ctx := context.Background()
+func (s *service) GetEventAnalytics(ctx context.Context, eventID uuid.UUID) (*EventAnalytics, error) {
 	cacheKey := constants.BuildAnalyticsEventKey(eventID.String())
 
 	// Try to get from cache first
@@ -134,8 +177,8 @@ func (s *service) GetGlobalEventAnalytics() (*GlobalEventAnalytics, error) {
 
 // Tag Analytics Implementation
 
-func (s *service) GetTagAnalytics() (*TagAnalyticsResponse, error) {
-	analytics, err := s.repo.GetTagAnalytics()
+func (s *service) GetTagAnalytics(refresh bool) (*TagAnalyticsResponse, error) {
+	analytics, err := s.reader(refresh).GetTagAnalytics()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get tag analytics: %w", err)
 	}
@@ -146,8 +189,8 @@ func (s *service) GetTagAnalytics() (*TagAnalyticsResponse, error) {
 	return analytics, nil
 }
 
-func (s *service) GetTagPopularityAnalytics() ([]TagAnalytics, error) {
-	analytics, err := s.repo.GetTagPopularityAnalytics()
+func (s *service) GetTagPopularityAnalytics(refresh bool) ([]TagAnalytics, error) {
+	analytics, err := s.reader(refresh).GetTagPopularityAnalytics()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get tag popularity analytics: %w", err)
 	}
@@ -178,6 +221,15 @@ func (s *service) GetTagTrends(months int) ([]TagTrend, error) {
 	return trends, nil
 }
 
+func (s *service) GetTagHierarchyAnalytics() ([]TagHierarchyAnalytics, error) {
+	analytics, err := s.repo.GetTagHierarchyAnalytics()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tag hierarchy analytics: %w", err)
+	}
+
+	return analytics, nil
+}
+
 func (s *service) GetTagComparisons() ([]TagComparison, error) {
 	comparisons, err := s.repo.GetTagComparisons()
 	if err != nil {
@@ -192,8 +244,8 @@ func (s *service) GetTagComparisons() ([]TagComparison, error) {
 
 // Booking Analytics Implementation
 
-func (s *service) GetBookingAnalytics() (*BookingAnalytics, error) {
-	analytics, err := s.repo.GetBookingAnalytics()
+func (s *service) GetBookingAnalytics(refresh bool) (*BookingAnalytics, error) {
+	analytics, err := s.reader(refresh).GetBookingAnalytics()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get booking analytics: %w", err)
 	}
@@ -205,8 +257,8 @@ func (s *service) GetBookingAnalytics() (*BookingAnalytics, error) {
 	return analytics, nil
 }
 
-func (s *service) GetBookingDailyStats() ([]DailyBookingStats, error) {
-	stats, err := s.repo.GetDailyBookingStats(30) // Default to 30 days
+func (s *service) GetBookingDailyStats(refresh bool) ([]DailyBookingStats, error) {
+	stats, err := s.reader(refresh).GetDailyBookingStats(30) // Default to 30 days
 	if err != nil {
 		return nil, fmt.Errorf("failed to get daily booking stats: %w", err)
 	}
@@ -233,6 +285,21 @@ func (s *service) GetCancellationAnalytics() (*CancellationAnalytics, error) {
 	return analytics, nil
 }
 
+// GetRevenueBreakdown returns confirmed revenue split into ticket price, fees and taxes
+func (s *service) GetRevenueBreakdown(start, end time.Time) (*RevenueBreakdown, error) {
+	breakdown, err := s.repo.GetRevenueBreakdown(start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get revenue breakdown: %w", err)
+	}
+	breakdown.Currency = s.baseCurrency
+	return breakdown, nil
+}
+
+// SetBaseCurrency sets the currency booking revenue was converted to
+func (s *service) SetBaseCurrency(code string) {
+	s.baseCurrency = code
+}
+
 // User Analytics Implementation
 
 func (s *service) GetUserAnalytics() (*UserAnalytics, error) {
@@ -409,6 +476,26 @@ func (s *service) generatePersonalRecommendations(userID uuid.UUID, analytics *P
 		})
 	}
 
+	if interests := analytics.Interests; interests.MatchingEvents > 0 {
+		recommendations = append(recommendations, PersonalRecommendation{
+			Type:        "event",
+			Title:       "Picked For Your Interests",
+			Description: fmt.Sprintf("%d upcoming events match the interests on your profile", interests.MatchingEvents),
+			Reason:      fmt.Sprintf("You're interested in %s", strings.Join(interests.PreferredTags, ", ")),
+			Confidence:  0.9,
+		})
+	}
+
+	if interests := analytics.Interests; interests.NearbyEvents > 0 {
+		recommendations = append(recommendations, PersonalRecommendation{
+			Type:        "venue",
+			Title:       "Happening Near You",
+			Description: fmt.Sprintf("%d upcoming events are at venues in %s", interests.NearbyEvents, interests.City),
+			Reason:      fmt.Sprintf("Your profile says you're in %s", interests.City),
+			Confidence:  0.75,
+		})
+	}
+
 	if analytics.SpendingInsights.MonthlyAverage > 0 {
 		recommendations = append(recommendations, PersonalRecommendation{
 			Type:        "event",
@@ -454,3 +541,175 @@ func (s *service) calculateAchievements(userID uuid.UUID, analytics *PersonalAna
 
 	return achievements
 }
+
+// Yearly Recap Implementation
+
+func (s *service) GetYearlyRecap(userID uuid.UUID, year int) (*YearlyRecap, error) {
+	if year < 2000 || year > time.Now().Year() {
+		return nil, fmt.Errorf("invalid recap year")
+	}
+
+	recap, err := s.repo.GetYearlyRecap(userID, year)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get yearly recap: %w", err)
+	}
+
+	recap.Achievements = s.calculateRecapAchievements(recap)
+
+	// Include the personal achievements already shown in the app
+	if personal, err := s.GetPersonalAnalytics(userID); err == nil {
+		recap.Achievements = append(recap.Achievements, personal.Achievements...)
+	}
+
+	return recap, nil
+}
+
+func (s *service) GetRecapSubscription(userID uuid.UUID) (*RecapSubscription, error) {
+	return s.repo.GetRecapSubscription(userID)
+}
+
+func (s *service) SetRecapSubscription(userID uuid.UUID, optedIn bool) (*RecapSubscription, error) {
+	return s.repo.UpsertRecapSubscription(userID, optedIn)
+}
+
+// SendYearlyRecaps queues recap emails for every opted-in user in batches,
+// pausing between batches to keep the email send rate bounded
+func (s *service) SendYearlyRecaps(ctx context.Context, year int, batchSize int, batchInterval time.Duration) (*RecapRunResult, error) {
+	result := &RecapRunResult{Year: year}
+	failed := make(map[uuid.UUID]bool)
+
+	for {
+		recipients, err := s.repo.GetRecapRecipients(year, batchSize+len(failed))
+		if err != nil {
+			return result, err
+		}
+
+		progressed := false
+		for _, userID := range recipients {
+			if failed[userID] {
+				continue
+			}
+
+			if err := s.queueYearlyRecap(userID, year); err != nil {
+				failed[userID] = true
+				continue
+			}
+			result.Queued++
+			progressed = true
+		}
+
+		// Stop when only failing users are left
+		if !progressed {
+			return result, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return result, ctx.Err()
+		case <-time.After(batchInterval):
+		}
+	}
+}
+
+func (s *service) queueYearlyRecap(userID uuid.UUID, year int) error {
+	recap, err := s.GetYearlyRecap(userID, year)
+	if err != nil {
+		return err
+	}
+
+	achievements := make([]string, 0, len(recap.Achievements))
+	for _, achievement := range recap.Achievements {
+		achievements = append(achievements, fmt.Sprintf("%s %s - %s", achievement.Icon, achievement.Title, achievement.Description))
+	}
+
+	payload := &outbox.NotificationPayload{
+		Type:        "YEARLY_RECAP",
+		RecipientID: userID,
+		TemplateData: map[string]interface{}{
+			"year":            recap.Year,
+			"total_bookings":  recap.TotalBookings,
+			"total_tickets":   recap.TotalTickets,
+			"total_spent":     recap.TotalSpent,
+			"events_attended": recap.EventsAttended,
+			"busiest_month":   recap.BusiestMonth,
+			"favorite_tags":   recap.FavoriteTags,
+			"favorite_venues": recap.FavoriteVenues,
+			"achievements":    achievements,
+		},
+	}
+
+	message, err := outbox.NewNotificationMessage(outbox.AggregateUser, userID, fmt.Sprintf("recap:%d:%s", year, userID), payload)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.MarkRecapQueued(userID, year, message)
+}
+
+func (s *service) calculateRecapAchievements(recap *YearlyRecap) []Achievement {
+	var achievements []Achievement
+	unlockedAt := time.Date(recap.Year, time.December, 31, 0, 0, 0, 0, time.UTC)
+
+	if recap.EventsAttended >= 10 {
+		achievements = append(achievements, Achievement{
+			ID:          "regular",
+			Title:       "Regular",
+			Description: fmt.Sprintf("Attended %d events in %d", recap.EventsAttended, recap.Year),
+			Icon:        "🎟️",
+			UnlockedAt:  unlockedAt,
+			Rarity:      "rare",
+		})
+	}
+
+	if len(recap.FavoriteTags) >= 3 {
+		achievements = append(achievements, Achievement{
+			ID:          "explorer",
+			Title:       "Explorer",
+			Description: "Enjoyed events across many categories",
+			Icon:        "🧭",
+			UnlockedAt:  unlockedAt,
+			Rarity:      "common",
+		})
+	}
+
+	if recap.TotalTickets >= 20 {
+		achievements = append(achievements, Achievement{
+			ID:          "crowd_bringer",
+			Title:       "Crowd Bringer",
+			Description: fmt.Sprintf("Booked %d tickets this year", recap.TotalTickets),
+			Icon:        "👥",
+			UnlockedAt:  unlockedAt,
+			Rarity:      "epic",
+		})
+	}
+
+	return achievements
+}
+
+// Rollups Implementation
+
+// RefreshRollups rebuilds the event and tag rollups and the daily booking rows
+// from dailySince onwards
+func (s *service) RefreshRollups(ctx context.Context, dailySince time.Time) error {
+	start := time.Now()
+
+	if err := s.repo.RefreshDailyBookingsAgg(dailySince); err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := s.repo.RefreshEventRevenueAgg(); err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := s.repo.RefreshTagPopularityAgg(); err != nil {
+		return err
+	}
+
+	log.Printf("📊 Refreshed analytics rollups (daily bookings since %s) in %v",
+		dailySince.Format("2006-01-02"), time.Since(start).Round(time.Millisecond))
+	return nil
+}
@@ -0,0 +1,347 @@
+package analytics
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// minDemographicAttendees is the smallest audience whose breakdowns are shared;
+// below it a bucket could point at a single person
+const minDemographicAttendees = 5
+
+var (
+	ErrShareLinksDisabled  = errors.New("share links are not enabled")
+	ErrShareLinkNotFound   = errors.New("share link not found")
+	ErrInvalidShareSection = errors.New("invalid share section")
+	ErrInvalidShareExpiry  = errors.New("share link expiry is too long")
+	ErrInvalidShareLink    = errors.New("share link is invalid, expired or revoked")
+	ErrSharedEventNotFound = errors.New("event not found")
+)
+
+// ShareLinkConfig enables share links
+type ShareLinkConfig struct {
+	Secret     string        // Signs share tokens
+	DefaultTTL time.Duration // Used when a request does not set an expiry
+	MaxTTL     time.Duration
+	URL        string // Snapshot URL shown to admins, {token} is replaced
+}
+
+// DefaultShareLinkConfig returns default share link configuration
+func DefaultShareLinkConfig() *ShareLinkConfig {
+	return &ShareLinkConfig{
+		DefaultTTL: 7 * 24 * time.Hour,
+		MaxTTL:     90 * 24 * time.Hour,
+	}
+}
+
+// SetShareLinkConfig enables share links. Until it is called, links can't be
+// created or opened.
+func (s *service) SetShareLinkConfig(config *ShareLinkConfig) {
+	s.shareConfig = config
+	key := sha256.Sum256([]byte("analytics-share-link:" + config.Secret))
+	s.shareKey = key[:]
+}
+
+func (s *service) CreateShareLink(adminID, eventID uuid.UUID, req ShareLinkRequest) (*ShareLinkResponse, error) {
+	if s.shareConfig == nil {
+		return nil, ErrShareLinksDisabled
+	}
+
+	sections := req.Sections
+	if len(sections) == 0 {
+		sections = DefaultShareSections
+	}
+	names := make([]string, 0, len(sections))
+	for _, section := range sections {
+		if !validShareSection(section) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidShareSection, section)
+		}
+		names = append(names, string(section))
+	}
+
+	ttl := s.shareConfig.DefaultTTL
+	if req.ExpiresInHours > 0 {
+		ttl = time.Duration(req.ExpiresInHours) * time.Hour
+	}
+	if ttl > s.shareConfig.MaxTTL {
+		return nil, fmt.Errorf("%w: the maximum is %d hours", ErrInvalidShareExpiry, int(s.shareConfig.MaxTTL.Hours()))
+	}
+
+	if _, err := s.repo.GetSharedEvent(eventID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSharedEventNotFound
+		}
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	link := &ShareLink{
+		ID:        uuid.New(),
+		EventID:   eventID,
+		Label:     strings.TrimSpace(req.Label),
+		Sections:  strings.Join(names, ","),
+		ExpiresAt: time.Now().Add(ttl).Truncate(time.Second),
+		CreatedBy: adminID,
+	}
+	if err := s.repo.CreateShareLink(link); err != nil {
+		return nil, err
+	}
+
+	return s.toShareLinkResponse(link, time.Now()), nil
+}
+
+func (s *service) GetShareLinks(eventID uuid.UUID) ([]ShareLinkResponse, error) {
+	links, err := s.repo.GetShareLinks(eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	responses := make([]ShareLinkResponse, 0, len(links))
+	for i := range links {
+		responses = append(responses, *s.toShareLinkResponse(&links[i], now))
+	}
+	return responses, nil
+}
+
+func (s *service) RevokeShareLink(linkID uuid.UUID) error {
+	if err := s.repo.RevokeShareLink(linkID, time.Now()); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrShareLinkNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// GetSharedEventAnalytics opens a share token and builds the event's snapshot
+// with only the sections the link shares
+func (s *service) GetSharedEventAnalytics(token string) (*SharedEventAnalytics, error) {
+	if s.shareConfig == nil {
+		return nil, ErrShareLinksDisabled
+	}
+
+	linkID, err := s.verifyShareToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	link, err := s.repo.GetShareLink(linkID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidShareLink
+		}
+		return nil, fmt.Errorf("failed to get share link: %w", err)
+	}
+	now := time.Now()
+	if shareLinkStatus(link, now) != ShareLinkStatusActive {
+		return nil, ErrInvalidShareLink
+	}
+
+	snapshot, err := s.repo.GetSharedEvent(link.EventID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidShareLink
+		}
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+	snapshot.SharedWith = link.Label
+	snapshot.Sections = link.SectionList()
+	snapshot.GeneratedAt = now
+	snapshot.ExpiresAt = link.ExpiresAt
+
+	var days []EventSalesDay
+	if link.Shares(ShareSectionSalesCurve) || link.Shares(ShareSectionRevenue) || link.Shares(ShareSectionUtilization) {
+		if days, err = s.repo.GetEventSalesByDay(link.EventID); err != nil {
+			return nil, err
+		}
+	}
+	if link.Shares(ShareSectionSalesCurve) {
+		snapshot.SalesCurve = buildSalesCurve(days)
+	}
+	if link.Shares(ShareSectionRevenue) {
+		snapshot.Revenue = s.buildSharedRevenue(days)
+	}
+
+	if link.Shares(ShareSectionUtilization) {
+		sections, err := s.repo.GetEventSectionUtilization(link.EventID)
+		if err != nil {
+			return nil, err
+		}
+		snapshot.Utilization = buildSharedUtilization(sections, days)
+	}
+
+	if link.Shares(ShareSectionDemographics) {
+		counts, err := s.repo.GetEventDemographics(link.EventID)
+		if err != nil {
+			return nil, err
+		}
+		snapshot.Demographics = buildSharedDemographics(counts)
+	}
+
+	if err := s.repo.RecordShareLinkView(link.ID, now); err != nil {
+		log.Printf("Warning: failed to record view of share link %s: %v", link.ID, err)
+	}
+
+	return snapshot, nil
+}
+
+//  TOKENS
+
+// shareToken is "<link id>.<expiry unix>.<signature>". The expiry is signed in so
+// an expired token is turned away before the database is read.
+func (s *service) shareToken(link *ShareLink) string {
+	expires := link.ExpiresAt.Unix()
+	return fmt.Sprintf("%s.%d.%s", link.ID, expires, s.signShareToken(link.ID, expires))
+}
+
+func (s *service) verifyShareToken(token string) (uuid.UUID, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return uuid.Nil, ErrInvalidShareLink
+	}
+	linkID, err := uuid.Parse(parts[0])
+	if err != nil {
+		return uuid.Nil, ErrInvalidShareLink
+	}
+	expires, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || !hmac.Equal([]byte(parts[2]), []byte(s.signShareToken(linkID, expires))) {
+		return uuid.Nil, ErrInvalidShareLink
+	}
+	if time.Now().Unix() > expires {
+		return uuid.Nil, ErrInvalidShareLink
+	}
+	return linkID, nil
+}
+
+func (s *service) signShareToken(linkID uuid.UUID, expires int64) string {
+	mac := hmac.New(sha256.New, s.shareKey)
+	fmt.Fprintf(mac, "%s:%d", linkID, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+//  HELPERS
+
+func (s *service) toShareLinkResponse(link *ShareLink, now time.Time) *ShareLinkResponse {
+	resp := &ShareLinkResponse{
+		ShareLink: *link,
+		Sections:  link.SectionList(),
+		Status:    shareLinkStatus(link, now),
+	}
+	if resp.Status == ShareLinkStatusActive && s.shareConfig != nil {
+		resp.URL = strings.ReplaceAll(s.shareConfig.URL, "{token}", s.shareToken(link))
+	}
+	return resp
+}
+
+func shareLinkStatus(link *ShareLink, now time.Time) string {
+	switch {
+	case link.RevokedAt != nil:
+		return ShareLinkStatusRevoked
+	case !now.Before(link.ExpiresAt):
+		return ShareLinkStatusExpired
+	default:
+		return ShareLinkStatusActive
+	}
+}
+
+func validShareSection(section ShareSection) bool {
+	for _, known := range AllShareSections {
+		if section == known {
+			return true
+		}
+	}
+	return false
+}
+
+func buildSalesCurve(days []EventSalesDay) []SalesCurvePoint {
+	curve := make([]SalesCurvePoint, 0, len(days))
+	cumulative := 0
+	for _, day := range days {
+		cumulative += day.Tickets
+		curve = append(curve, SalesCurvePoint{
+			Date:              day.Date,
+			Bookings:          day.Bookings,
+			Tickets:           day.Tickets,
+			CumulativeTickets: cumulative,
+		})
+	}
+	return curve
+}
+
+func (s *service) buildSharedRevenue(days []EventSalesDay) *SharedRevenue {
+	revenue := &SharedRevenue{Currency: s.baseCurrency, ByDay: make([]DailyBooking, 0, len(days))}
+	bookings := 0
+	for _, day := range days {
+		revenue.Total += day.Revenue
+		bookings += day.Bookings
+		revenue.ByDay = append(revenue.ByDay, DailyBooking{Date: day.Date, Bookings: day.Bookings, Revenue: roundShare(day.Revenue)})
+	}
+	if bookings > 0 {
+		revenue.AverageOrder = roundShare(revenue.Total / float64(bookings))
+	}
+	revenue.Total = roundShare(revenue.Total)
+	return revenue
+}
+
+func buildSharedUtilization(sections []SectionUtilization, days []EventSalesDay) *SharedUtilization {
+	utilization := &SharedUtilization{Sections: make([]SectionUtilization, 0, len(sections))}
+	for _, section := range sections {
+		utilization.Capacity += section.Capacity
+		section.Utilization = roundShare(section.Utilization)
+		utilization.Sections = append(utilization.Sections, section)
+	}
+	// Counted from bookings so general admission tickets are included
+	for _, day := range days {
+		utilization.TicketsSold += day.Tickets
+	}
+	if utilization.Capacity > 0 {
+		utilization.Utilization = roundShare(float64(utilization.TicketsSold) / float64(utilization.Capacity) * 100)
+	}
+	return utilization
+}
+
+func buildSharedDemographics(counts *EventDemographicCounts) *SharedDemographics {
+	demographics := &SharedDemographics{Attendees: counts.Attendees}
+	if counts.Attendees < minDemographicAttendees {
+		demographics.Suppressed = true
+		return demographics
+	}
+
+	demographics.Audience = demographicBuckets(counts.Attendees, []string{"new", "returning"},
+		[]int{counts.Attendees - counts.ReturningAttendees, counts.ReturningAttendees})
+	demographics.AccountAge = demographicBuckets(counts.Attendees, []string{"under_1_month", "1_to_12_months", "over_1_year"},
+		[]int{counts.AccountUnderMonth, counts.AccountUnderYear, counts.AccountOverYear})
+
+	groups := counts.GroupSizes
+	bookings := groups.SoloBookings + groups.PairBookings + groups.SmallGroupBookings + groups.LargeGroupBookings
+	demographics.GroupSizes = demographicBuckets(bookings, []string{"1", "2", "3-4", "5+"},
+		[]int{groups.SoloBookings, groups.PairBookings, groups.SmallGroupBookings, groups.LargeGroupBookings})
+
+	return demographics
+}
+
+func demographicBuckets(total int, labels []string, counts []int) []DemographicBucket {
+	buckets := make([]DemographicBucket, len(labels))
+	for i, label := range labels {
+		buckets[i] = DemographicBucket{Label: label, Count: counts[i]}
+		if total > 0 {
+			buckets[i].Percent = roundShare(float64(counts[i]) / float64(total) * 100)
+		}
+	}
+	return buckets
+}
+
+func roundShare(value float64) float64 {
+	return math.Round(value*100) / 100
+}
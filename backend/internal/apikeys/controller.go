@@ -0,0 +1,224 @@
+package apikeys
+
+import (
+	"errors"
+	"net/http"
+
+	"evently/internal/shared/utils/response"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type Controller struct {
+	service Service
+}
+
+func NewController(service Service) *Controller {
+	return &Controller{service: service}
+}
+
+// CreateKey mints a scoped key. The key is only returned in this response.
+//
+// @Summary      Create API key (Admin)
+// @Description  Mints a scoped key for a partner integration. The key is only returned in this response.
+// @Description
+// @Description  Requires the `system:manage` permission.
+// @Tags         Admin API Keys
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        request body CreateKeyRequest true "Request body"
+// @Success      201 {object} response.StandardApiResponse{data=IssuedKeyResponse} "API key created successfully"
+// @Failure      400 {object} response.StandardApiResponse "Invalid scopes, owner or expiry"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse
+// @Failure      409 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/api-keys [post]
+func (ctrl *Controller) CreateKey(c *gin.Context) {
+	adminID, ok := ctrl.adminID(c)
+	if !ok {
+		return
+	}
+
+	var req CreateKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid request body", nil, err.Error())
+		return
+	}
+
+	issued, err := ctrl.service.CreateKey(c.Request.Context(), adminID, req)
+	if err != nil {
+		ctrl.respondError(c, err, "Failed to create API key")
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusCreated, "API key created successfully. Store the key now; it will not be shown again", issued, nil)
+}
+
+// ListKeys godoc
+//
+// @Summary      List API keys (Admin)
+// @Description  Requires the `system:manage` permission.
+// @Tags         Admin API Keys
+// @Produce      json
+// @Security     Bearer
+// @Success      200 {object} response.StandardApiResponse{data=[]APIKey} "API keys retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse
+// @Failure      409 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/api-keys [get]
+func (ctrl *Controller) ListKeys(c *gin.Context) {
+	keys, err := ctrl.service.ListKeys(c.Request.Context())
+	if err != nil {
+		ctrl.respondError(c, err, "Failed to retrieve API keys")
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "API keys retrieved successfully", keys, nil)
+}
+
+// GetKey returns a key with its recent daily usage
+//
+// @Summary      Get API key with usage (Admin)
+// @Description  Returns the key with its daily request counts for the last 30 days. Usage is written once a minute.
+// @Description
+// @Description  Requires the `system:manage` permission.
+// @Tags         Admin API Keys
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=KeyDetailResponse} "API key retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "API key not found"
+// @Failure      409 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/api-keys/{id} [get]
+func (ctrl *Controller) GetKey(c *gin.Context) {
+	id, ok := ctrl.keyID(c)
+	if !ok {
+		return
+	}
+
+	key, err := ctrl.service.GetKey(c.Request.Context(), id)
+	if err != nil {
+		ctrl.respondError(c, err, "Failed to retrieve API key")
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "API key retrieved successfully", key, nil)
+}
+
+// RotateKey issues a new secret; the old one works until the grace period ends
+//
+// @Summary      Rotate API key (Admin)
+// @Description  Issues a new secret. The previous secret keeps working for the rotation grace period (24 hours by default).
+// @Description
+// @Description  Requires the `system:manage` permission.
+// @Tags         Admin API Keys
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=IssuedKeyResponse} "API key rotated successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "API key not found"
+// @Failure      409 {object} response.StandardApiResponse "API key has been revoked or has expired"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/api-keys/{id}/rotate [post]
+func (ctrl *Controller) RotateKey(c *gin.Context) {
+	id, ok := ctrl.keyID(c)
+	if !ok {
+		return
+	}
+
+	issued, err := ctrl.service.RotateKey(c.Request.Context(), id)
+	if err != nil {
+		ctrl.respondError(c, err, "Failed to rotate API key")
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "API key rotated successfully. Store the key now; it will not be shown again", issued, nil)
+}
+
+// RevokeKey godoc
+//
+// @Summary      Revoke API key (Admin)
+// @Description  Disables the key and any previous secret still in its rotation grace period.
+// @Description
+// @Description  Requires the `system:manage` permission.
+// @Tags         Admin API Keys
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=APIKey} "API key revoked successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "API key not found"
+// @Failure      409 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/api-keys/{id} [delete]
+func (ctrl *Controller) RevokeKey(c *gin.Context) {
+	adminID, ok := ctrl.adminID(c)
+	if !ok {
+		return
+	}
+	id, ok := ctrl.keyID(c)
+	if !ok {
+		return
+	}
+
+	key, err := ctrl.service.RevokeKey(c.Request.Context(), adminID, id)
+	if err != nil {
+		ctrl.respondError(c, err, "Failed to revoke API key")
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "API key revoked successfully", key, nil)
+}
+
+func (ctrl *Controller) adminID(c *gin.Context) (uuid.UUID, bool) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.RespondJSON(c, "error", http.StatusUnauthorized, "User not authenticated", nil, nil)
+		return uuid.Nil, false
+	}
+
+	adminID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusInternalServerError, "Invalid user ID format", nil, nil)
+		return uuid.Nil, false
+	}
+	return adminID, true
+}
+
+func (ctrl *Controller) keyID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid API key ID", nil, err.Error())
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func (ctrl *Controller) respondError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, ErrKeyNotFound):
+		response.RespondJSON(c, "error", http.StatusNotFound, err.Error(), nil, nil)
+	case errors.Is(err, ErrKeyRevoked):
+		response.RespondJSON(c, "error", http.StatusConflict, err.Error(), nil, nil)
+	case errors.Is(err, ErrInvalidScope), errors.Is(err, ErrOwnerNotFound), errors.Is(err, ErrInvalidExpiry):
+		response.RespondJSON(c, "error", http.StatusBadRequest, err.Error(), nil, nil)
+	default:
+		response.RespondJSON(c, "error", http.StatusInternalServerError, message, nil, err.Error())
+	}
+}
@@ -0,0 +1,63 @@
+package apikeys
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// APIKey lets a partner integration call the API as its owner, limited to the
+// key's scopes. Only a hash of the secret is stored; the key itself is shown
+// once when it is created or rotated.
+type APIKey struct {
+	ID         uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	Name       string     `gorm:"not null" json:"name"`
+	Prefix     string     `gorm:"uniqueIndex;not null" json:"prefix"` // Identifies the key in listings and logs
+	KeyHash    string     `gorm:"uniqueIndex;not null" json:"-"`
+	Scopes     []string   `gorm:"type:jsonb;serializer:json;not null" json:"scopes"`
+	OwnerID    uuid.UUID  `gorm:"type:uuid;not null;index" json:"owner_id"` // Requests act as this user
+	CreatedBy  uuid.UUID  `gorm:"type:uuid;not null" json:"created_by"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `gorm:"index" json:"revoked_at,omitempty"`
+	RevokedBy  *uuid.UUID `gorm:"type:uuid" json:"revoked_by,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+
+	// After a rotation the previous secret keeps working until PreviousExpiresAt
+	// so the partner can deploy the new one without downtime
+	PreviousKeyHash   *string    `gorm:"index" json:"-"`
+	PreviousExpiresAt *time.Time `json:"previous_expires_at,omitempty"`
+	RotatedAt         *time.Time `json:"rotated_at,omitempty"`
+}
+
+func (APIKey) TableName() string {
+	return "api_keys"
+}
+
+// Active reports whether the key can authenticate requests
+func (k *APIKey) Active(now time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || k.ExpiresAt.After(now))
+}
+
+// HasScope reports whether the key was granted scope
+func (k *APIKey) HasScope(scope string) bool {
+	for _, s := range k.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// Usage is the number of authenticated requests a key made on one day (UTC)
+type Usage struct {
+	KeyID      uuid.UUID `gorm:"type:uuid;primaryKey" json:"-"`
+	Date       time.Time `gorm:"type:date;primaryKey" json:"date"`
+	Requests   int64     `gorm:"not null;default:0" json:"requests"`
+	LastUsedAt time.Time `gorm:"not null" json:"last_used_at"`
+}
+
+func (Usage) TableName() string {
+	return "api_key_usage"
+}
@@ -0,0 +1,118 @@
+package apikeys
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	CreateKey(ctx context.Context, key *APIKey) error
+	GetKey(ctx context.Context, id uuid.UUID) (*APIKey, error)
+	ListKeys(ctx context.Context) ([]APIKey, error)
+	UpdateKey(ctx context.Context, key *APIKey) error
+	FindKeyByHash(ctx context.Context, hash string, now time.Time) (*APIKey, error)
+	GetUserRole(ctx context.Context, userID uuid.UUID) (string, error)
+
+	// Usage metering
+	AddUsage(ctx context.Context, usage []Usage) error
+	GetUsage(ctx context.Context, keyID uuid.UUID, since time.Time) ([]Usage, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+//  KEYS
+
+func (r *repository) CreateKey(ctx context.Context, key *APIKey) error {
+	return r.db.WithContext(ctx).Create(key).Error
+}
+
+func (r *repository) GetKey(ctx context.Context, id uuid.UUID) (*APIKey, error) {
+	var key APIKey
+	if err := r.db.WithContext(ctx).Where("id = ?", id).Take(&key).Error; err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *repository) ListKeys(ctx context.Context) ([]APIKey, error) {
+	var keys []APIKey
+	err := r.db.WithContext(ctx).Order("created_at DESC").Find(&keys).Error
+	return keys, err
+}
+
+func (r *repository) UpdateKey(ctx context.Context, key *APIKey) error {
+	return r.db.WithContext(ctx).Save(key).Error
+}
+
+// FindKeyByHash matches the current secret, or the previous one while its rotation grace period lasts
+func (r *repository) FindKeyByHash(ctx context.Context, hash string, now time.Time) (*APIKey, error) {
+	var key APIKey
+	err := r.db.WithContext(ctx).
+		Where("key_hash = ? OR (previous_key_hash = ? AND previous_expires_at > ?)", hash, hash, now).
+		Take(&key).Error
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *repository) GetUserRole(ctx context.Context, userID uuid.UUID) (string, error) {
+	var role string
+	err := r.db.WithContext(ctx).
+		Table("users").
+		Select("role").
+		Where("id = ? AND deleted_at IS NULL", userID).
+		Take(&role).Error
+	return role, err
+}
+
+//  USAGE
+
+// AddUsage adds request counts to each key's daily totals
+func (r *repository) AddUsage(ctx context.Context, usage []Usage) error {
+	if len(usage) == 0 {
+		return nil
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "key_id"}, {Name: "date"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"requests":     gorm.Expr("api_key_usage.requests + excluded.requests"),
+				"last_used_at": gorm.Expr("GREATEST(api_key_usage.last_used_at, excluded.last_used_at)"),
+			}),
+		}).Create(&usage).Error
+		if err != nil {
+			return err
+		}
+
+		for _, u := range usage {
+			err := tx.Model(&APIKey{}).
+				Where("id = ? AND (last_used_at IS NULL OR last_used_at < ?)", u.KeyID, u.LastUsedAt).
+				UpdateColumn("last_used_at", u.LastUsedAt).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *repository) GetUsage(ctx context.Context, keyID uuid.UUID, since time.Time) ([]Usage, error) {
+	var usage []Usage
+	err := r.db.WithContext(ctx).
+		Where("key_id = ? AND date >= ?", keyID, since).
+		Order("date DESC").
+		Find(&usage).Error
+	return usage, err
+}
@@ -0,0 +1,14 @@
+package apikeys
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CreateKeyRequest struct {
+	Name      string     `json:"name" binding:"required,max=100"`
+	Scopes    []string   `json:"scopes" binding:"required,min=1"` // events:read, bookings:write, analytics:read
+	OwnerID   *uuid.UUID `json:"owner_id"`                        // Partner account the key acts as; defaults to the issuing admin
+	ExpiresAt *time.Time `json:"expires_at"`
+}
@@ -0,0 +1,16 @@
+package apikeys
+
+// IssuedKeyResponse carries a newly created or rotated key. The key is not
+// stored and cannot be retrieved again.
+type IssuedKeyResponse struct {
+	APIKey *APIKey `json:"api_key"`
+	Key    string  `json:"key"`
+}
+
+type KeyDetailResponse struct {
+	*APIKey
+	Active        bool    `json:"active"`
+	UsageDays     int     `json:"usage_days"`
+	TotalRequests int64   `json:"total_requests"`
+	Usage         []Usage `json:"usage"` // Newest day first, written every flush interval
+}
@@ -0,0 +1,19 @@
+package apikeys
+
+import (
+	"evently/internal/shared/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupAPIKeyRoutes(rg *gin.RouterGroup, controller *Controller) {
+	admin := rg.Group("/admin/api-keys")
+	admin.Use(middleware.JWTAuth(), middleware.RequirePermission(middleware.PermissionSystemManage))
+	{
+		admin.POST("", controller.CreateKey)            // POST /api/v1/admin/api-keys
+		admin.GET("", controller.ListKeys)              // GET /api/v1/admin/api-keys
+		admin.GET("/:id", controller.GetKey)            // GET /api/v1/admin/api-keys/:id - Key with daily usage
+		admin.POST("/:id/rotate", controller.RotateKey) // POST /api/v1/admin/api-keys/:id/rotate
+		admin.DELETE("/:id", controller.RevokeKey)      // DELETE /api/v1/admin/api-keys/:id - Revoke
+	}
+}
@@ -0,0 +1,425 @@
+package apikeys
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"evently/internal/shared/middleware"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// keyPrefix starts every key so leaked keys are easy to recognise in code and logs
+const keyPrefix = "evk_"
+
+var (
+	ErrKeyNotFound   = errors.New("API key not found")
+	ErrKeyRevoked    = errors.New("API key has been revoked or has expired")
+	ErrInvalidScope  = fmt.Errorf("scopes must be one or more of: %s", strings.Join(middleware.APIKeyScopes, ", "))
+	ErrOwnerNotFound = errors.New("owner user not found")
+	ErrInvalidExpiry = errors.New("expires_at must be in the future")
+)
+
+// Config contains API key settings
+type Config struct {
+	CacheTTL      time.Duration // How long a verified key is trusted before it is looked up again
+	RotationGrace time.Duration // How long the previous secret keeps working after a rotation
+	FlushInterval time.Duration // How often metered usage is written to the database
+	UsageDays     int           // Days of usage returned with a key
+}
+
+// DefaultConfig returns default API key configuration
+func DefaultConfig() *Config {
+	return &Config{
+		CacheTTL:      30 * time.Second,
+		RotationGrace: 24 * time.Hour,
+		FlushInterval: time.Minute,
+		UsageDays:     30,
+	}
+}
+
+type Service interface {
+	CreateKey(ctx context.Context, adminID uuid.UUID, req CreateKeyRequest) (*IssuedKeyResponse, error)
+	ListKeys(ctx context.Context) ([]APIKey, error)
+	GetKey(ctx context.Context, id uuid.UUID) (*KeyDetailResponse, error)
+	RotateKey(ctx context.Context, id uuid.UUID) (*IssuedKeyResponse, error)
+	RevokeKey(ctx context.Context, adminID, id uuid.UUID) (*APIKey, error)
+
+	// Authenticate implements middleware.APIKeyAuthenticator
+	Authenticate(ctx context.Context, rawKey, scope string) (*middleware.APIKeyPrincipal, error)
+
+	// Usage metering
+	FlushUsage(ctx context.Context) (int, error)
+}
+
+// cachedKey is a verified key and its owner's role
+type cachedKey struct {
+	key     *APIKey
+	role    string
+	expires time.Time
+}
+
+type usageKey struct {
+	keyID uuid.UUID
+	day   int64
+}
+
+type service struct {
+	repo   Repository
+	config *Config
+
+	cacheMu sync.Mutex
+	cache   map[string]cachedKey // By key hash
+
+	usageMu sync.Mutex
+	usage   map[usageKey]*Usage // Metered requests not yet written
+}
+
+func NewService(repo Repository, config *Config) Service {
+	if config == nil {
+		config = DefaultConfig()
+	}
+	return &service{
+		repo:   repo,
+		config: config,
+		cache:  make(map[string]cachedKey),
+		usage:  make(map[usageKey]*Usage),
+	}
+}
+
+func (s *service) CreateKey(ctx context.Context, adminID uuid.UUID, req CreateKeyRequest) (*IssuedKeyResponse, error) {
+	scopes, err := normalizeScopes(req.Scopes)
+	if err != nil {
+		return nil, err
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return nil, ErrInvalidExpiry
+	}
+
+	// Keys act as the partner's own account, or the issuing admin's when none is given
+	ownerID := adminID
+	if req.OwnerID != nil {
+		ownerID = *req.OwnerID
+	}
+	if _, err := s.repo.GetUserRole(ctx, ownerID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOwnerNotFound
+		}
+		return nil, fmt.Errorf("failed to get owner: %w", err)
+	}
+
+	raw, prefix, hash, err := generateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	key := &APIKey{
+		ID:        uuid.New(),
+		Name:      strings.TrimSpace(req.Name),
+		Prefix:    prefix,
+		KeyHash:   hash,
+		Scopes:    scopes,
+		OwnerID:   ownerID,
+		CreatedBy: adminID,
+		ExpiresAt: req.ExpiresAt,
+	}
+	if err := s.repo.CreateKey(ctx, key); err != nil {
+		return nil, fmt.Errorf("failed to create API key: %w", err)
+	}
+
+	return &IssuedKeyResponse{APIKey: key, Key: raw}, nil
+}
+
+func (s *service) ListKeys(ctx context.Context) ([]APIKey, error) {
+	keys, err := s.repo.ListKeys(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API keys: %w", err)
+	}
+	return keys, nil
+}
+
+func (s *service) GetKey(ctx context.Context, id uuid.UUID) (*KeyDetailResponse, error) {
+	key, err := s.getKey(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	since := time.Now().UTC().AddDate(0, 0, -s.config.UsageDays)
+	usage, err := s.repo.GetUsage(ctx, id, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get API key usage: %w", err)
+	}
+
+	var total int64
+	for _, u := range usage {
+		total += u.Requests
+	}
+
+	return &KeyDetailResponse{
+		APIKey:        key,
+		Active:        key.Active(time.Now()),
+		UsageDays:     s.config.UsageDays,
+		TotalRequests: total,
+		Usage:         usage,
+	}, nil
+}
+
+// RotateKey issues a new secret for a key. The previous secret keeps working for
+// the configured grace period.
+func (s *service) RotateKey(ctx context.Context, id uuid.UUID) (*IssuedKeyResponse, error) {
+	key, err := s.getKey(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if !key.Active(now) {
+		return nil, ErrKeyRevoked
+	}
+
+	raw, prefix, hash, err := generateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	previousHash := key.KeyHash
+	key.PreviousKeyHash = nil
+	key.PreviousExpiresAt = nil
+	if s.config.RotationGrace > 0 {
+		graceEnds := now.Add(s.config.RotationGrace)
+		key.PreviousKeyHash = &previousHash
+		key.PreviousExpiresAt = &graceEnds
+	}
+	key.Prefix = prefix
+	key.KeyHash = hash
+	key.RotatedAt = &now
+
+	if err := s.repo.UpdateKey(ctx, key); err != nil {
+		return nil, fmt.Errorf("failed to rotate API key: %w", err)
+	}
+	s.forget(key.ID)
+
+	return &IssuedKeyResponse{APIKey: key, Key: raw}, nil
+}
+
+// RevokeKey disables a key and any previous secret immediately. Revoking an
+// already revoked key is a no-op.
+func (s *service) RevokeKey(ctx context.Context, adminID, id uuid.UUID) (*APIKey, error) {
+	key, err := s.getKey(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if key.RevokedAt != nil {
+		return key, nil
+	}
+
+	now := time.Now()
+	key.RevokedAt = &now
+	key.RevokedBy = &adminID
+	key.PreviousKeyHash = nil
+	key.PreviousExpiresAt = nil
+
+	if err := s.repo.UpdateKey(ctx, key); err != nil {
+		return nil, fmt.Errorf("failed to revoke API key: %w", err)
+	}
+	s.forget(key.ID)
+
+	return key, nil
+}
+
+// Authenticate checks a raw key against the scope of the route it was sent to
+// and meters the request. Verified keys are cached, so on other replicas a
+// revocation takes effect within the cache TTL.
+func (s *service) Authenticate(ctx context.Context, rawKey, scope string) (*middleware.APIKeyPrincipal, error) {
+	if !strings.HasPrefix(rawKey, keyPrefix) {
+		return nil, middleware.ErrInvalidAPIKey
+	}
+
+	now := time.Now()
+	hash := hashKey(rawKey)
+	entry, err := s.lookup(ctx, hash, now)
+	if err != nil {
+		return nil, err
+	}
+
+	key := entry.key
+	if !key.Active(now) {
+		return nil, middleware.ErrInvalidAPIKey
+	}
+	if hash != key.KeyHash && (key.PreviousExpiresAt == nil || !key.PreviousExpiresAt.After(now)) {
+		return nil, middleware.ErrInvalidAPIKey
+	}
+	if !key.HasScope(scope) {
+		return nil, middleware.ErrAPIKeyScope
+	}
+
+	s.meter(key.ID, now)
+
+	return &middleware.APIKeyPrincipal{
+		KeyID:  key.ID.String(),
+		UserID: key.OwnerID.String(),
+		Role:   entry.role,
+	}, nil
+}
+
+// FlushUsage writes metered requests to the daily usage totals. Returns the
+// number of rows written. Counts are kept for the next flush if writing fails.
+func (s *service) FlushUsage(ctx context.Context) (int, error) {
+	s.usageMu.Lock()
+	pending := s.usage
+	s.usage = make(map[usageKey]*Usage)
+	s.usageMu.Unlock()
+
+	if len(pending) == 0 {
+		return 0, nil
+	}
+
+	rows := make([]Usage, 0, len(pending))
+	for _, u := range pending {
+		rows = append(rows, *u)
+	}
+
+	if err := s.repo.AddUsage(ctx, rows); err != nil {
+		s.usageMu.Lock()
+		for k, u := range pending {
+			if current, ok := s.usage[k]; ok {
+				current.Requests += u.Requests
+				if u.LastUsedAt.After(current.LastUsedAt) {
+					current.LastUsedAt = u.LastUsedAt
+				}
+			} else {
+				s.usage[k] = u
+			}
+		}
+		s.usageMu.Unlock()
+		return 0, fmt.Errorf("failed to write API key usage: %w", err)
+	}
+
+	return len(rows), nil
+}
+
+func (s *service) meter(keyID uuid.UUID, now time.Time) {
+	day := now.UTC().Truncate(24 * time.Hour)
+	k := usageKey{keyID: keyID, day: day.Unix()}
+
+	s.usageMu.Lock()
+	defer s.usageMu.Unlock()
+
+	u, ok := s.usage[k]
+	if !ok {
+		u = &Usage{KeyID: keyID, Date: day}
+		s.usage[k] = u
+	}
+	u.Requests++
+	u.LastUsedAt = now
+}
+
+func (s *service) lookup(ctx context.Context, hash string, now time.Time) (cachedKey, error) {
+	s.cacheMu.Lock()
+	entry, ok := s.cache[hash]
+	s.cacheMu.Unlock()
+	if ok && entry.expires.After(now) {
+		return entry, nil
+	}
+
+	key, err := s.repo.FindKeyByHash(ctx, hash, now)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return cachedKey{}, middleware.ErrInvalidAPIKey
+		}
+		return cachedKey{}, fmt.Errorf("failed to look up API key: %w", err)
+	}
+
+	role, err := s.repo.GetUserRole(ctx, key.OwnerID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Printf("API key %s belongs to a deleted user %s", key.Prefix, key.OwnerID)
+			return cachedKey{}, middleware.ErrInvalidAPIKey
+		}
+		return cachedKey{}, fmt.Errorf("failed to look up API key owner: %w", err)
+	}
+
+	entry = cachedKey{key: key, role: role, expires: now.Add(s.config.CacheTTL)}
+	s.cacheMu.Lock()
+	s.cache[hash] = entry
+	s.cacheMu.Unlock()
+	return entry, nil
+}
+
+// forget drops every cached secret of a key after it changes
+func (s *service) forget(keyID uuid.UUID) {
+	s.cacheMu.Lock()
+	defer s.cacheMu.Unlock()
+	for hash, entry := range s.cache {
+		if entry.key.ID == keyID {
+			delete(s.cache, hash)
+		}
+	}
+}
+
+func (s *service) getKey(ctx context.Context, id uuid.UUID) (*APIKey, error) {
+	key, err := s.repo.GetKey(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrKeyNotFound
+		}
+		return nil, fmt.Errorf("failed to get API key: %w", err)
+	}
+	return key, nil
+}
+
+// generateKey returns a new key as evk_<prefix>_<secret>, its prefix and its hash
+func generateKey() (raw, prefix, hash string, err error) {
+	id := make([]byte, 4)
+	secret := make([]byte, 24)
+	if _, err := rand.Read(id); err != nil {
+		return "", "", "", fmt.Errorf("failed to generate API key: %w", err)
+	}
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", "", fmt.Errorf("failed to generate API key: %w", err)
+	}
+
+	prefix = keyPrefix + hex.EncodeToString(id)
+	raw = prefix + "_" + base64.RawURLEncoding.EncodeToString(secret)
+	return raw, prefix, hashKey(raw), nil
+}
+
+func hashKey(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
+
+// normalizeScopes validates and de-duplicates scopes
+func normalizeScopes(scopes []string) ([]string, error) {
+	valid := make(map[string]bool, len(middleware.APIKeyScopes))
+	for _, scope := range middleware.APIKeyScopes {
+		valid[scope] = true
+	}
+
+	seen := make(map[string]bool, len(scopes))
+	normalized := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		scope = strings.ToLower(strings.TrimSpace(scope))
+		if !valid[scope] {
+			return nil, ErrInvalidScope
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			normalized = append(normalized, scope)
+		}
+	}
+	if len(normalized) == 0 {
+		return nil, ErrInvalidScope
+	}
+	return normalized, nil
+}
@@ -0,0 +1,69 @@
+package apikeys
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// UsageJob periodically writes metered API key requests to the database
+type UsageJob struct {
+	service Service
+	config  *Config
+	done    chan struct{}
+	stopped chan struct{}
+}
+
+// NewUsageJob creates a new API key usage job
+func NewUsageJob(service Service, config *Config) *UsageJob {
+	if config == nil {
+		config = DefaultConfig()
+	}
+
+	return &UsageJob{
+		service: service,
+		config:  config,
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+}
+
+// Start starts the API key usage job
+func (j *UsageJob) Start(ctx context.Context) {
+	log.Printf("Started API key usage job with %v interval", j.config.FlushInterval)
+	go j.run(ctx)
+}
+
+// Stop stops the job after writing the usage metered since the last flush
+func (j *UsageJob) Stop() {
+	close(j.done)
+	<-j.stopped
+}
+
+func (j *UsageJob) run(ctx context.Context) {
+	defer close(j.stopped)
+
+	ticker := time.NewTicker(j.config.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			j.flush(ctx)
+		case <-j.done:
+			// The run context may already be cancelled during shutdown
+			flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			j.flush(flushCtx)
+			cancel()
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (j *UsageJob) flush(ctx context.Context) {
+	if _, err := j.service.FlushUsage(ctx); err != nil {
+		log.Printf("Failed to flush API key usage: %v", err)
+	}
+}
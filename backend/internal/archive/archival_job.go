@@ -0,0 +1,82 @@
+package archive
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// ArchivalConfig contains configuration for the event archival job
+type ArchivalConfig struct {
+	CheckInterval   time.Duration
+	RetentionMonths int // Events are archived this many months after they took place
+	BatchSize       int
+}
+
+// DefaultArchivalConfig returns default archival configuration
+func DefaultArchivalConfig() *ArchivalConfig {
+	return &ArchivalConfig{
+		CheckInterval:   24 * time.Hour, // Run once a day
+		RetentionMonths: 12,             // Keep a year of events in the live tables
+		BatchSize:       50,             // Archive up to 50 events per run
+	}
+}
+
+// ArchivalJob moves completed events and their bookings to the archive tables
+type ArchivalJob struct {
+	service Service
+	config  *ArchivalConfig
+	done    chan struct{}
+}
+
+// NewArchivalJob creates a new archival job
+func NewArchivalJob(service Service, config *ArchivalConfig) *ArchivalJob {
+	if config == nil {
+		config = DefaultArchivalConfig()
+	}
+
+	return &ArchivalJob{
+		service: service,
+		config:  config,
+		done:    make(chan struct{}),
+	}
+}
+
+// Start starts the archival job
+func (j *ArchivalJob) Start(ctx context.Context) {
+	log.Printf("Started event archival job with %v interval, retention %d months", j.config.CheckInterval, j.config.RetentionMonths)
+	go j.run(ctx)
+}
+
+// Stop stops the archival job
+func (j *ArchivalJob) Stop() {
+	close(j.done)
+}
+
+func (j *ArchivalJob) run(ctx context.Context) {
+	ticker := time.NewTicker(j.config.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			j.archive(ctx)
+		case <-j.done:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (j *ArchivalJob) archive(ctx context.Context) {
+	result, err := j.service.ArchiveCompletedEvents(ctx, j.config.RetentionMonths, j.config.BatchSize)
+	if err != nil {
+		log.Printf("Failed to archive completed events: %v", err)
+		return
+	}
+
+	if result.Archived > 0 {
+		log.Printf("Archived %d completed events", result.Archived)
+	}
+}
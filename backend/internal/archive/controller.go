@@ -0,0 +1,343 @@
+package archive
+
+import (
+	"net/http"
+	"strings"
+
+	"evently/internal/shared/utils/response"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type Controller struct {
+	service Service
+}
+
+func NewController(service Service) *Controller {
+	return &Controller{service: service}
+}
+
+//  ARCHIVED EVENTS
+
+// ListArchivedEvents godoc
+//
+// @Summary      List archived events (Admin)
+// @Description  Requires the `archive:manage` permission.
+// @Tags         Admin Archive
+// @Produce      json
+// @Security     Bearer
+// @Param        page query integer false "Page" default(1) minimum(1)
+// @Param        limit query integer false "Limit" default(20) minimum(1) maximum(100)
+// @Success      200 {object} response.StandardApiResponse{data=PaginatedArchivedEvents} "Archived events retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/archive/events [get]
+func (ctrl *Controller) ListArchivedEvents(c *gin.Context) {
+	var query ArchiveListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid query parameters", nil, err.Error())
+		return
+	}
+
+	events, err := ctrl.service.ListArchivedEvents(c.Request.Context(), query)
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusInternalServerError, "Failed to retrieve archived events", nil, err.Error())
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Archived events retrieved successfully", events, nil)
+}
+
+// GetArchivedEvent godoc
+//
+// @Summary      Get archived event (Admin)
+// @Description  Archived event summary with its archived bookings
+// @Description
+// @Description  Requires the `archive:manage` permission.
+// @Tags         Admin Archive
+// @Produce      json
+// @Security     Bearer
+// @Param        eventId path string true "Event ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=ArchivedEventDetail} "Archived event retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Archived event not found"
+// @Router       /api/v1/admin/archive/events/{eventId} [get]
+func (ctrl *Controller) GetArchivedEvent(c *gin.Context) {
+	eventID, ok := ctrl.uuidParam(c, "eventId", "Invalid event ID")
+	if !ok {
+		return
+	}
+
+	event, err := ctrl.service.GetArchivedEvent(c.Request.Context(), eventID)
+	if err != nil {
+		response.RespondJSON(c, "error", errorStatus(err), err.Error(), nil, nil)
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Archived event retrieved successfully", event, nil)
+}
+
+// RestoreArchivedEvent godoc
+//
+// @Summary      Restore archived event (Admin)
+// @Description  Move an archived event and its bookings back to the live tables
+// @Description
+// @Description  Requires the `archive:manage` permission.
+// @Tags         Admin Archive
+// @Produce      json
+// @Security     Bearer
+// @Param        eventId path string true "Event ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse "Archived event restored successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Archived event not found"
+// @Router       /api/v1/admin/archive/events/{eventId}/restore [post]
+func (ctrl *Controller) RestoreArchivedEvent(c *gin.Context) {
+	eventID, ok := ctrl.uuidParam(c, "eventId", "Invalid event ID")
+	if !ok {
+		return
+	}
+
+	if err := ctrl.service.RestoreArchivedEvent(c.Request.Context(), eventID); err != nil {
+		response.RespondJSON(c, "error", errorStatus(err), err.Error(), nil, nil)
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Archived event restored successfully", nil, nil)
+}
+
+// PurgeArchivedEvent godoc
+//
+// @Summary      Purge archived event (Admin)
+// @Description  Permanently delete an archived event and its archived bookings
+// @Description
+// @Description  Requires the `archive:manage` permission.
+// @Tags         Admin Archive
+// @Produce      json
+// @Security     Bearer
+// @Param        eventId path string true "Event ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse "Archived event purged successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Archived event not found"
+// @Router       /api/v1/admin/archive/events/{eventId} [delete]
+func (ctrl *Controller) PurgeArchivedEvent(c *gin.Context) {
+	eventID, ok := ctrl.uuidParam(c, "eventId", "Invalid event ID")
+	if !ok {
+		return
+	}
+
+	if err := ctrl.service.PurgeArchivedEvent(c.Request.Context(), eventID); err != nil {
+		response.RespondJSON(c, "error", errorStatus(err), err.Error(), nil, nil)
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Archived event purged successfully", nil, nil)
+}
+
+// RunArchival godoc
+//
+// @Summary      Archive completed events now (Admin)
+// @Description  Move events that took place more than the given number of months ago, with their bookings, to the archive tables
+// @Description
+// @Description  Requires the `archive:manage` permission.
+// @Tags         Admin Archive
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        request body RunArchivalRequest true "Request body"
+// @Success      200 {object} response.StandardApiResponse{data=ArchivalRunResult} "Archival completed"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/archive/run [post]
+func (ctrl *Controller) RunArchival(c *gin.Context) {
+	var req RunArchivalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid request body", nil, err.Error())
+		return
+	}
+
+	result, err := ctrl.service.ArchiveCompletedEvents(c.Request.Context(), req.OlderThanMonths, req.Limit)
+	if err != nil {
+		response.RespondJSON(c, "error", errorStatus(err), err.Error(), nil, nil)
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Archival completed", result, nil)
+}
+
+//  SOFT-DELETED RECORDS
+
+// DeleteUser godoc
+//
+// @Summary      Delete user (Admin)
+// @Description  Soft delete a user account. The email stays reserved until the account is purged.
+// @Description
+// @Description  Requires the `users:manage` permission.
+// @Tags         Admin Archive
+// @Produce      json
+// @Security     Bearer
+// @Param        userId path string true "User ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse "User deleted successfully"
+// @Failure      400 {object} response.StandardApiResponse "Admins cannot delete their own account"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "User not found"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/users/{userId} [delete]
+func (ctrl *Controller) DeleteUser(c *gin.Context) {
+	adminID, ok := ctrl.currentUserID(c)
+	if !ok {
+		return
+	}
+	userID, ok := ctrl.uuidParam(c, "userId", "Invalid user ID")
+	if !ok {
+		return
+	}
+
+	if err := ctrl.service.DeleteUser(c.Request.Context(), userID, adminID); err != nil {
+		response.RespondJSON(c, "error", errorStatus(err), err.Error(), nil, nil)
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "User deleted successfully", nil, nil)
+}
+
+// ListDeleted godoc
+//
+// @Summary      List soft-deleted records (Admin)
+// @Description  Requires the `archive:manage` permission.
+// @Tags         Admin Archive
+// @Produce      json
+// @Security     Bearer
+// @Param        kind path string true "Kind" Enums(events, venue-templates, tags, users)
+// @Param        page query integer false "Page" default(1) minimum(1)
+// @Param        limit query integer false "Limit" default(20) minimum(1) maximum(100)
+// @Success      200 {object} response.StandardApiResponse{data=PaginatedDeletedRecords} "Deleted records retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse "Invalid record kind"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/trash/{kind} [get]
+func (ctrl *Controller) ListDeleted(c *gin.Context) {
+	var query DeletedListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid query parameters", nil, err.Error())
+		return
+	}
+
+	records, err := ctrl.service.ListDeleted(c.Request.Context(), RecordKind(c.Param("kind")), query)
+	if err != nil {
+		response.RespondJSON(c, "error", errorStatus(err), err.Error(), nil, nil)
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Deleted records retrieved successfully", records, nil)
+}
+
+// RestoreDeleted godoc
+//
+// @Summary      Restore soft-deleted record (Admin)
+// @Description  Requires the `archive:manage` permission.
+// @Tags         Admin Archive
+// @Produce      json
+// @Security     Bearer
+// @Param        kind path string true "Kind" Enums(events, venue-templates, tags, users)
+// @Param        id path string true "ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse "Record restored successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Deleted record not found"
+// @Router       /api/v1/admin/trash/{kind}/{id}/restore [post]
+func (ctrl *Controller) RestoreDeleted(c *gin.Context) {
+	id, ok := ctrl.uuidParam(c, "id", "Invalid record ID")
+	if !ok {
+		return
+	}
+
+	if err := ctrl.service.RestoreDeleted(c.Request.Context(), RecordKind(c.Param("kind")), id); err != nil {
+		response.RespondJSON(c, "error", errorStatus(err), err.Error(), nil, nil)
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Record restored successfully", nil, nil)
+}
+
+// PurgeDeleted godoc
+//
+// @Summary      Purge soft-deleted record (Admin)
+// @Description  Permanently delete a soft-deleted record. Events and users with bookings, and templates still used by events, cannot be purged.
+// @Description
+// @Description  Requires the `archive:manage` permission.
+// @Tags         Admin Archive
+// @Produce      json
+// @Security     Bearer
+// @Param        kind path string true "Kind" Enums(events, venue-templates, tags, users)
+// @Param        id path string true "ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse "Record purged successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Deleted record not found"
+// @Failure      409 {object} response.StandardApiResponse "Record is still referenced and cannot be purged"
+// @Router       /api/v1/admin/trash/{kind}/{id} [delete]
+func (ctrl *Controller) PurgeDeleted(c *gin.Context) {
+	id, ok := ctrl.uuidParam(c, "id", "Invalid record ID")
+	if !ok {
+		return
+	}
+
+	if err := ctrl.service.PurgeDeleted(c.Request.Context(), RecordKind(c.Param("kind")), id); err != nil {
+		response.RespondJSON(c, "error", errorStatus(err), err.Error(), nil, nil)
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Record purged successfully", nil, nil)
+}
+
+//  HELPERS
+
+func (ctrl *Controller) uuidParam(c *gin.Context, name, message string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param(name))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, message, nil, err.Error())
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func (ctrl *Controller) currentUserID(c *gin.Context) (uuid.UUID, bool) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.RespondJSON(c, "error", http.StatusUnauthorized, "Admin not authenticated", nil, nil)
+		return uuid.Nil, false
+	}
+
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusInternalServerError, "Invalid admin ID format", nil, nil)
+		return uuid.Nil, false
+	}
+	return userUUID, true
+}
+
+func errorStatus(err error) int {
+	msg := err.Error()
+	switch {
+	case strings.HasSuffix(msg, "not found"):
+		return http.StatusNotFound
+	case strings.Contains(msg, "cannot be purged"):
+		return http.StatusConflict
+	case strings.HasPrefix(msg, "invalid"), strings.HasPrefix(msg, "retention"), strings.HasPrefix(msg, "admins cannot"):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,84 @@
+package archive
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ArchivedEvent is a completed event moved out of the live tables. The original
+// rows are kept as jsonb snapshots so the event can be restored as it was.
+type ArchivedEvent struct {
+	ID           uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"` // Original event ID
+	Name         string    `gorm:"not null" json:"name"`
+	Venue        string    `json:"venue"`
+	DateTime     time.Time `gorm:"index" json:"date_time"`
+	Status       string    `gorm:"type:varchar(20)" json:"status"`
+	BookingCount int       `gorm:"not null;default:0" json:"booking_count"` // Confirmed bookings at archival time
+	Revenue      float64   `gorm:"not null;default:0" json:"revenue"`
+
+	// Snapshots of the live rows
+	Event              json.RawMessage `gorm:"type:jsonb;not null" json:"-"`
+	EventTags          json.RawMessage `gorm:"type:jsonb" json:"-"`
+	EventPricing       json.RawMessage `gorm:"type:jsonb" json:"-"`
+	CancellationPolicy json.RawMessage `gorm:"type:jsonb" json:"-"`
+
+	ArchivedAt time.Time `gorm:"not null;index" json:"archived_at"`
+}
+
+// ArchivedBooking is a booking of an archived event together with its seats,
+// payments, saga and cancellations
+type ArchivedBooking struct {
+	ID         uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"` // Original booking ID
+	EventID    uuid.UUID `gorm:"type:uuid;not null;index" json:"event_id"`
+	UserID     uuid.UUID `gorm:"type:uuid;not null;index" json:"user_id"`
+	BookingRef string    `gorm:"not null" json:"booking_ref"`
+	Status     string    `gorm:"type:varchar(20)" json:"status"`
+	TotalPrice float64   `json:"total_price"`
+
+	// Snapshots of the live rows
+	Booking      json.RawMessage `gorm:"type:jsonb;not null" json:"-"`
+	SeatBookings json.RawMessage `gorm:"type:jsonb" json:"-"`
+	Payments     json.RawMessage `gorm:"type:jsonb" json:"-"`
+	Sagas        json.RawMessage `gorm:"type:jsonb" json:"-"`
+	Cancellation json.RawMessage `gorm:"type:jsonb" json:"-"` // Array of cancellations, a single object in older archives
+
+	ArchivedAt time.Time `gorm:"not null" json:"archived_at"`
+}
+
+func (ArchivedEvent) TableName() string {
+	return "archived_events"
+}
+
+func (ArchivedBooking) TableName() string {
+	return "archived_bookings"
+}
+
+// RecordKind identifies an entity type that supports soft delete
+type RecordKind string
+
+const (
+	RecordKindEvents         RecordKind = "events"
+	RecordKindVenueTemplates RecordKind = "venue-templates"
+	RecordKindTags           RecordKind = "tags"
+	RecordKindUsers          RecordKind = "users"
+)
+
+// recordTable maps a record kind to its table and the column used as a label
+type recordTable struct {
+	Table       string
+	LabelColumn string
+}
+
+var recordTables = map[RecordKind]recordTable{
+	RecordKindEvents:         {Table: "events", LabelColumn: "name"},
+	RecordKindVenueTemplates: {Table: "venue_templates", LabelColumn: "name"},
+	RecordKindTags:           {Table: "tags", LabelColumn: "name"},
+	RecordKindUsers:          {Table: "users", LabelColumn: "email"},
+}
+
+func (k RecordKind) IsValid() bool {
+	_, ok := recordTables[k]
+	return ok
+}
@@ -0,0 +1,337 @@
+package archive
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	// Archival
+	FindArchivableEvents(ctx context.Context, cutoff time.Time, limit int) ([]uuid.UUID, error)
+	ArchiveEvent(ctx context.Context, eventID uuid.UUID) error
+	ListArchivedEvents(ctx context.Context, page, limit int) ([]ArchivedEvent, int64, error)
+	GetArchivedEvent(ctx context.Context, eventID uuid.UUID) (*ArchivedEvent, error)
+	GetArchivedBookings(ctx context.Context, eventID uuid.UUID) ([]ArchivedBooking, error)
+	RestoreArchivedEvent(ctx context.Context, eventID uuid.UUID) error
+	PurgeArchivedEvent(ctx context.Context, eventID uuid.UUID) error
+
+	// Soft-deleted records
+	SoftDeleteUser(ctx context.Context, userID uuid.UUID) error
+	ListDeleted(ctx context.Context, kind RecordKind, page, limit int) ([]DeletedRecord, int64, error)
+	RestoreDeleted(ctx context.Context, kind RecordKind, id uuid.UUID) error
+	PurgeDeleted(ctx context.Context, kind RecordKind, id uuid.UUID) error
+	CountBookingsForRecord(ctx context.Context, kind RecordKind, id uuid.UUID) (int64, error)
+	CountEventsUsingTemplate(ctx context.Context, templateID uuid.UUID) (int64, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+//  ARCHIVAL
+
+// FindArchivableEvents returns events that took place before the cutoff and were not cancelled or deleted
+func (r *repository) FindArchivableEvents(ctx context.Context, cutoff time.Time, limit int) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := r.db.WithContext(ctx).
+		Table("events").
+		Where("date_time < ? AND status IN ? AND deleted_at IS NULL", cutoff, []string{"completed", "published", "sold_out", "ongoing"}).
+		Order("date_time ASC").
+		Limit(limit).
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
+// ArchiveEvent snapshots the event and its bookings into the archive tables and
+// removes the live rows in a single transaction
+func (r *repository) ArchiveEvent(ctx context.Context, eventID uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Exec(`
+			INSERT INTO archived_events (id, name, venue, date_time, status, booking_count, revenue,
+				event, event_tags, event_pricing, cancellation_policy, archived_at)
+			SELECT
+				e.id, e.name, e.venue, e.date_time, e.status,
+				(SELECT COUNT(*) FROM bookings b WHERE b.event_id = e.id AND b.status = 'CONFIRMED'),
+				(SELECT COALESCE(SUM(b.base_total_price), 0) FROM bookings b WHERE b.event_id = e.id AND b.status = 'CONFIRMED'),
+				to_jsonb(e),
+				COALESCE((SELECT jsonb_agg(to_jsonb(et)) FROM event_tags et WHERE et.event_id = e.id), '[]'::jsonb),
+				COALESCE((SELECT jsonb_agg(to_jsonb(ep)) FROM event_pricing ep WHERE ep.event_id = e.id), '[]'::jsonb),
+				(SELECT to_jsonb(cp) FROM cancellation_policies cp WHERE cp.event_id = e.id),
+				NOW()
+			FROM events e
+			WHERE e.id = ? AND e.deleted_at IS NULL`, eventID)
+		if result.Error != nil {
+			return fmt.Errorf("failed to archive event: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		if err := tx.Exec(`
+			INSERT INTO archived_bookings (id, event_id, user_id, booking_ref, status, total_price,
+				booking, seat_bookings, payments, sagas, cancellation, archived_at)
+			SELECT
+				b.id, b.event_id, b.user_id, b.booking_ref, b.status, b.total_price,
+				to_jsonb(b),
+				COALESCE((SELECT jsonb_agg(to_jsonb(sb)) FROM seat_bookings sb WHERE sb.booking_id = b.id), '[]'::jsonb),
+				COALESCE((SELECT jsonb_agg(to_jsonb(p)) FROM payments p WHERE p.booking_id = b.id), '[]'::jsonb),
+				COALESCE((SELECT jsonb_agg(to_jsonb(s)) FROM booking_sagas s WHERE s.booking_id = b.id), '[]'::jsonb),
+				(SELECT jsonb_agg(to_jsonb(c)) FROM cancellations c WHERE c.booking_id = b.id),
+				NOW()
+			FROM bookings b
+			WHERE b.event_id = ?`, eventID).Error; err != nil {
+			return fmt.Errorf("failed to archive bookings: %w", err)
+		}
+
+		// Children before parents
+		statements := []string{
+			"DELETE FROM cancellations WHERE booking_id IN (SELECT id FROM bookings WHERE event_id = ?)",
+			"DELETE FROM booking_sagas WHERE booking_id IN (SELECT id FROM bookings WHERE event_id = ?)",
+			"DELETE FROM payments WHERE booking_id IN (SELECT id FROM bookings WHERE event_id = ?)",
+			"DELETE FROM seat_bookings WHERE booking_id IN (SELECT id FROM bookings WHERE event_id = ?)",
+			"DELETE FROM bookings WHERE event_id = ?",
+			"DELETE FROM event_pricing WHERE event_id = ?",
+			"DELETE FROM event_tags WHERE event_id = ?",
+			"DELETE FROM cancellation_policies WHERE event_id = ?",
+			"DELETE FROM events WHERE id = ?",
+		}
+		for _, statement := range statements {
+			if err := tx.Exec(statement, eventID).Error; err != nil {
+				return fmt.Errorf("failed to remove live rows: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *repository) ListArchivedEvents(ctx context.Context, page, limit int) ([]ArchivedEvent, int64, error) {
+	var total int64
+	if err := r.db.WithContext(ctx).Model(&ArchivedEvent{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var events []ArchivedEvent
+	err := r.db.WithContext(ctx).
+		Omit("event", "event_tags", "event_pricing", "cancellation_policy").
+		Order("archived_at DESC").
+		Limit(limit).
+		Offset((page - 1) * limit).
+		Find(&events).Error
+	return events, total, err
+}
+
+func (r *repository) GetArchivedEvent(ctx context.Context, eventID uuid.UUID) (*ArchivedEvent, error) {
+	var event ArchivedEvent
+	if err := r.db.WithContext(ctx).Where("id = ?", eventID).First(&event).Error; err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+func (r *repository) GetArchivedBookings(ctx context.Context, eventID uuid.UUID) ([]ArchivedBooking, error) {
+	var bookings []ArchivedBooking
+	err := r.db.WithContext(ctx).
+		Omit("booking", "seat_bookings", "payments", "sagas", "cancellation").
+		Where("event_id = ?", eventID).
+		Order("booking_ref ASC").
+		Find(&bookings).Error
+	return bookings, err
+}
+
+// RestoreArchivedEvent rebuilds the live rows from the snapshots and drops the archive entries
+func (r *repository) RestoreArchivedEvent(ctx context.Context, eventID uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Parents before children
+		statements := []string{
+			`INSERT INTO events
+				SELECT rec.* FROM archived_events ae, jsonb_populate_record(NULL::events, ae.event) rec
+				WHERE ae.id = ?`,
+			`INSERT INTO event_tags
+				SELECT rec.* FROM archived_events ae, jsonb_populate_recordset(NULL::event_tags, ae.event_tags) rec
+				WHERE ae.id = ?`,
+			`INSERT INTO event_pricing
+				SELECT rec.* FROM archived_events ae, jsonb_populate_recordset(NULL::event_pricing, ae.event_pricing) rec
+				WHERE ae.id = ?`,
+			// Archives can predate approval of late cancellations
+			`INSERT INTO cancellation_policies
+				SELECT rec.* FROM archived_events ae, jsonb_populate_record(
+					jsonb_populate_record(NULL::cancellation_policies, '{"require_approval": false}'), ae.cancellation_policy) rec
+				WHERE ae.id = ? AND ae.cancellation_policy IS NOT NULL`,
+			`INSERT INTO bookings
+				SELECT rec.* FROM archived_bookings ab, jsonb_populate_record(NULL::bookings, ab.booking) rec
+				WHERE ab.event_id = ?`,
+			// Archives can predate the pricing snapshot columns, which then take their defaults
+			`INSERT INTO seat_bookings
+				SELECT rec.* FROM archived_bookings ab, jsonb_populate_recordset(
+					jsonb_populate_record(NULL::seat_bookings, '{"section_name": "", "base_price": 0, "price_multiplier": 1, "service_fee": 0, "tax": 0, "total_price": 0, "non_refundable": 0}'),
+					ab.seat_bookings) rec
+				WHERE ab.event_id = ?`,
+			`INSERT INTO payments
+				SELECT rec.* FROM archived_bookings ab, jsonb_populate_recordset(NULL::payments, ab.payments) rec
+				WHERE ab.event_id = ?`,
+			`INSERT INTO booking_sagas
+				SELECT rec.* FROM archived_bookings ab, jsonb_populate_recordset(NULL::booking_sagas, ab.sagas) rec
+				WHERE ab.event_id = ?`,
+			// Archives made before partial cancellations hold a single cancellation,
+			// and predate reason codes
+			`INSERT INTO cancellations
+				SELECT rec.* FROM archived_bookings ab, jsonb_populate_recordset(
+					jsonb_populate_record(NULL::cancellations, '{"partial": false, "reason_code": "OTHER"}'),
+					CASE jsonb_typeof(ab.cancellation) WHEN 'array' THEN ab.cancellation ELSE jsonb_build_array(ab.cancellation) END) rec
+				WHERE ab.event_id = ? AND ab.cancellation IS NOT NULL`,
+		}
+		for _, statement := range statements {
+			if err := tx.Exec(statement, eventID).Error; err != nil {
+				return fmt.Errorf("failed to restore archived rows: %w", err)
+			}
+		}
+
+		return r.deleteArchive(tx, eventID)
+	})
+}
+
+// PurgeArchivedEvent permanently deletes an archived event and its bookings
+func (r *repository) PurgeArchivedEvent(ctx context.Context, eventID uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return r.deleteArchive(tx, eventID)
+	})
+}
+
+func (r *repository) deleteArchive(tx *gorm.DB, eventID uuid.UUID) error {
+	if err := tx.Where("event_id = ?", eventID).Delete(&ArchivedBooking{}).Error; err != nil {
+		return fmt.Errorf("failed to delete archived bookings: %w", err)
+	}
+	result := tx.Where("id = ?", eventID).Delete(&ArchivedEvent{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete archived event: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+//  SOFT-DELETED RECORDS
+
+func (r *repository) SoftDeleteUser(ctx context.Context, userID uuid.UUID) error {
+	result := r.db.WithContext(ctx).Exec(
+		"UPDATE users SET deleted_at = NOW() WHERE id = ? AND deleted_at IS NULL", userID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *repository) ListDeleted(ctx context.Context, kind RecordKind, page, limit int) ([]DeletedRecord, int64, error) {
+	table := recordTables[kind]
+
+	var total int64
+	if err := r.db.WithContext(ctx).Table(table.Table).Where("deleted_at IS NOT NULL").Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var records []DeletedRecord
+	err := r.db.WithContext(ctx).
+		Table(table.Table).
+		Select(fmt.Sprintf("id, %s AS label, deleted_at", table.LabelColumn)).
+		Where("deleted_at IS NOT NULL").
+		Order("deleted_at DESC").
+		Limit(limit).
+		Offset((page - 1) * limit).
+		Scan(&records).Error
+	return records, total, err
+}
+
+func (r *repository) RestoreDeleted(ctx context.Context, kind RecordKind, id uuid.UUID) error {
+	table := recordTables[kind]
+	result := r.db.WithContext(ctx).Exec(
+		fmt.Sprintf("UPDATE %s SET deleted_at = NULL WHERE id = ? AND deleted_at IS NOT NULL", table.Table), id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// PurgeDeleted permanently removes a soft-deleted record and its dependent rows
+func (r *repository) PurgeDeleted(ctx context.Context, kind RecordKind, id uuid.UUID) error {
+	var dependents []string
+	switch kind {
+	case RecordKindEvents:
+		dependents = []string{
+			"DELETE FROM event_pricing WHERE event_id = ?",
+			"DELETE FROM event_tags WHERE event_id = ?",
+			"DELETE FROM cancellation_policies WHERE event_id = ?",
+		}
+	case RecordKindTags:
+		dependents = []string{
+			"DELETE FROM event_tags WHERE tag_id = ?",
+			"UPDATE tags SET parent_id = NULL WHERE parent_id = ?", // Deleted child tags left under it
+		}
+	case RecordKindVenueTemplates:
+		// Seats cascade with their sections
+		dependents = []string{"DELETE FROM venue_sections WHERE template_id = ?"}
+	}
+
+	table := recordTables[kind]
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Table(table.Table).Where("id = ? AND deleted_at IS NOT NULL", id).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		for _, statement := range dependents {
+			if err := tx.Exec(statement, id).Error; err != nil {
+				return fmt.Errorf("failed to delete dependent rows: %w", err)
+			}
+		}
+
+		return tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE id = ?", table.Table), id).Error
+	})
+}
+
+// CountBookingsForRecord counts live bookings that would be orphaned by purging an event or user
+func (r *repository) CountBookingsForRecord(ctx context.Context, kind RecordKind, id uuid.UUID) (int64, error) {
+	column := ""
+	switch kind {
+	case RecordKindEvents:
+		column = "event_id"
+	case RecordKindUsers:
+		column = "user_id"
+	default:
+		return 0, nil
+	}
+
+	var count int64
+	err := r.db.WithContext(ctx).Table("bookings").Where(column+" = ?", id).Count(&count).Error
+	return count, err
+}
+
+// CountEventsUsingTemplate includes soft-deleted and archived events, which still point at the template
+func (r *repository) CountEventsUsingTemplate(ctx context.Context, templateID uuid.UUID) (int64, error) {
+	var live, archived int64
+	if err := r.db.WithContext(ctx).Table("events").Where("venue_template_id = ?", templateID).Count(&live).Error; err != nil {
+		return 0, err
+	}
+	if err := r.db.WithContext(ctx).Model(&ArchivedEvent{}).Where("event->>'venue_template_id' = ?", templateID.String()).Count(&archived).Error; err != nil {
+		return 0, err
+	}
+	return live + archived, nil
+}
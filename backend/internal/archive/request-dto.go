@@ -0,0 +1,17 @@
+package archive
+
+type ArchiveListQuery struct {
+	Page  int `form:"page" binding:"omitempty,min=1"`
+	Limit int `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+type DeletedListQuery struct {
+	Page  int `form:"page" binding:"omitempty,min=1"`
+	Limit int `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+// RunArchivalRequest archives events that took place more than OlderThanMonths ago
+type RunArchivalRequest struct {
+	OlderThanMonths int `json:"older_than_months" binding:"required,min=1,max=120"`
+	Limit           int `json:"limit" binding:"omitempty,min=1,max=500"`
+}
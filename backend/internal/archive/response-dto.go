@@ -0,0 +1,49 @@
+package archive
+
+import (
+	"time"
+
+	"evently/internal/shared/utils/response"
+
+	"github.com/google/uuid"
+)
+
+type PaginatedArchivedEvents struct {
+	Events     []ArchivedEvent `json:"events"`
+	TotalCount int64           `json:"total_count"`
+	Page       int             `json:"page"`
+	Limit      int             `json:"limit"`
+}
+
+func (p PaginatedArchivedEvents) PaginationMeta() response.Pagination {
+	return response.NewPagination(p.Page, p.Limit, p.TotalCount)
+}
+
+type ArchivedEventDetail struct {
+	ArchivedEvent
+	Bookings []ArchivedBooking `json:"bookings"`
+}
+
+// DeletedRecord is a soft-deleted row awaiting restore or purge
+type DeletedRecord struct {
+	ID        uuid.UUID `json:"id"`
+	Label     string    `json:"label"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+type PaginatedDeletedRecords struct {
+	Kind       RecordKind      `json:"kind"`
+	Records    []DeletedRecord `json:"records"`
+	TotalCount int64           `json:"total_count"`
+	Page       int             `json:"page"`
+	Limit      int             `json:"limit"`
+}
+
+func (p PaginatedDeletedRecords) PaginationMeta() response.Pagination {
+	return response.NewPagination(p.Page, p.Limit, p.TotalCount)
+}
+
+type ArchivalRunResult struct {
+	Archived int         `json:"archived"`
+	EventIDs []uuid.UUID `json:"event_ids"`
+}
@@ -0,0 +1,36 @@
+package archive
+
+import (
+	"evently/internal/shared/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupArchiveRoutes(rg *gin.RouterGroup, controller *Controller) {
+	// Archived events - archive:manage
+	archive := rg.Group("/admin/archive")
+	archive.Use(middleware.JWTAuth(), middleware.RequirePermission(middleware.PermissionArchiveManage))
+	{
+		archive.POST("/run", controller.RunArchival)                              // POST /api/v1/admin/archive/run - Archive completed events now
+		archive.GET("/events", controller.ListArchivedEvents)                     // GET /api/v1/admin/archive/events
+		archive.GET("/events/:eventId", controller.GetArchivedEvent)              // GET /api/v1/admin/archive/events/:eventId
+		archive.POST("/events/:eventId/restore", controller.RestoreArchivedEvent) // POST /api/v1/admin/archive/events/:eventId/restore
+		archive.DELETE("/events/:eventId", controller.PurgeArchivedEvent)         // DELETE /api/v1/admin/archive/events/:eventId - Purge permanently
+	}
+
+	// Soft-deleted records (events, venue-templates, tags, users) - archive:manage
+	trash := rg.Group("/admin/trash")
+	trash.Use(middleware.JWTAuth(), middleware.RequirePermission(middleware.PermissionArchiveManage))
+	{
+		trash.GET("/:kind", controller.ListDeleted)                 // GET /api/v1/admin/trash/:kind
+		trash.POST("/:kind/:id/restore", controller.RestoreDeleted) // POST /api/v1/admin/trash/:kind/:id/restore
+		trash.DELETE("/:kind/:id", controller.PurgeDeleted)         // DELETE /api/v1/admin/trash/:kind/:id - Purge permanently
+	}
+
+	// Users have no other delete endpoint - users:manage
+	users := rg.Group("/admin/users")
+	users.Use(middleware.JWTAuth(), middleware.RequirePermission(middleware.PermissionUsersManage))
+	{
+		users.DELETE("/:userId", controller.DeleteUser) // DELETE /api/v1/admin/users/:userId - Soft delete
+	}
+}
@@ -0,0 +1,259 @@
+package archive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"evently/internal/shared/utils/constants"
+	"evently/pkg/cache"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Service interface {
+	SetCacheService(cacheService cache.Service)
+
+	// Archival of completed events
+	ArchiveCompletedEvents(ctx context.Context, olderThanMonths, limit int) (*ArchivalRunResult, error)
+	ListArchivedEvents(ctx context.Context, query ArchiveListQuery) (*PaginatedArchivedEvents, error)
+	GetArchivedEvent(ctx context.Context, eventID uuid.UUID) (*ArchivedEventDetail, error)
+	RestoreArchivedEvent(ctx context.Context, eventID uuid.UUID) error
+	PurgeArchivedEvent(ctx context.Context, eventID uuid.UUID) error
+
+	// Soft-deleted records
+	DeleteUser(ctx context.Context, userID, adminID uuid.UUID) error
+	ListDeleted(ctx context.Context, kind RecordKind, query DeletedListQuery) (*PaginatedDeletedRecords, error)
+	RestoreDeleted(ctx context.Context, kind RecordKind, id uuid.UUID) error
+	PurgeDeleted(ctx context.Context, kind RecordKind, id uuid.UUID) error
+}
+
+type service struct {
+	repo         Repository
+	cacheService cache.Service
+}
+
+func NewService(repo Repository) Service {
+	return &service{repo: repo}
+}
+
+// SetCacheService injects the cache service dependency
+func (s *service) SetCacheService(cacheService cache.Service) {
+	s.cacheService = cacheService
+}
+
+// invalidateCache drops cached listings that may include the archived or restored record
+func (s *service) invalidateCache(ctx context.Context, kind RecordKind) {
+	if s.cacheService == nil {
+		return
+	}
+
+	patterns := []string{constants.PATTERN_INVALIDATE_EVENT_ALL}
+	switch kind {
+	case RecordKindTags:
+		patterns = append(patterns, constants.PATTERN_INVALIDATE_TAGS_ALL)
+	case RecordKindVenueTemplates:
+		patterns = append(patterns, constants.PATTERN_INVALIDATE_VENUES_ALL)
+	}
+
+	for _, pattern := range patterns {
+		if err := s.cacheService.DeletePattern(ctx, pattern); err != nil {
+			log.Printf("Warning: failed to invalidate cache pattern %s: %v", pattern, err)
+		}
+	}
+}
+
+//  ARCHIVAL
+
+// ArchiveCompletedEvents moves events that took place more than olderThanMonths ago to the archive tables
+func (s *service) ArchiveCompletedEvents(ctx context.Context, olderThanMonths, limit int) (*ArchivalRunResult, error) {
+	if olderThanMonths <= 0 {
+		return nil, fmt.Errorf("retention must be at least one month")
+	}
+	if limit <= 0 {
+		limit = 50
+	}
+
+	cutoff := time.Now().AddDate(0, -olderThanMonths, 0)
+	eventIDs, err := s.repo.FindArchivableEvents(ctx, cutoff, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find archivable events: %w", err)
+	}
+
+	result := &ArchivalRunResult{EventIDs: []uuid.UUID{}}
+	for _, eventID := range eventIDs {
+		// Each event is archived in its own transaction so one failure does not block the rest
+		if err := s.repo.ArchiveEvent(ctx, eventID); err != nil {
+			log.Printf("Failed to archive event %s: %v", eventID, err)
+			continue
+		}
+		result.EventIDs = append(result.EventIDs, eventID)
+	}
+	result.Archived = len(result.EventIDs)
+
+	if result.Archived > 0 {
+		s.invalidateCache(ctx, RecordKindEvents)
+	}
+	return result, nil
+}
+
+func (s *service) ListArchivedEvents(ctx context.Context, query ArchiveListQuery) (*PaginatedArchivedEvents, error) {
+	page, limit := normalizePage(query.Page, query.Limit)
+
+	events, total, err := s.repo.ListArchivedEvents(ctx, page, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list archived events: %w", err)
+	}
+
+	return &PaginatedArchivedEvents{
+		Events:     events,
+		TotalCount: total,
+		Page:       page,
+		Limit:      limit,
+	}, nil
+}
+
+func (s *service) GetArchivedEvent(ctx context.Context, eventID uuid.UUID) (*ArchivedEventDetail, error) {
+	event, err := s.repo.GetArchivedEvent(ctx, eventID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("archived event not found")
+		}
+		return nil, fmt.Errorf("failed to get archived event: %w", err)
+	}
+
+	bookings, err := s.repo.GetArchivedBookings(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get archived bookings: %w", err)
+	}
+
+	return &ArchivedEventDetail{ArchivedEvent: *event, Bookings: bookings}, nil
+}
+
+func (s *service) RestoreArchivedEvent(ctx context.Context, eventID uuid.UUID) error {
+	if _, err := s.repo.GetArchivedEvent(ctx, eventID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("archived event not found")
+		}
+		return fmt.Errorf("failed to get archived event: %w", err)
+	}
+
+	if err := s.repo.RestoreArchivedEvent(ctx, eventID); err != nil {
+		return fmt.Errorf("failed to restore archived event: %w", err)
+	}
+
+	s.invalidateCache(ctx, RecordKindEvents)
+	return nil
+}
+
+func (s *service) PurgeArchivedEvent(ctx context.Context, eventID uuid.UUID) error {
+	if err := s.repo.PurgeArchivedEvent(ctx, eventID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("archived event not found")
+		}
+		return fmt.Errorf("failed to purge archived event: %w", err)
+	}
+	return nil
+}
+
+//  SOFT-DELETED RECORDS
+
+// DeleteUser soft-deletes a user account; the email stays reserved until the account is purged
+func (s *service) DeleteUser(ctx context.Context, userID, adminID uuid.UUID) error {
+	if userID == adminID {
+		return fmt.Errorf("admins cannot delete their own account")
+	}
+
+	if err := s.repo.SoftDeleteUser(ctx, userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("user not found")
+		}
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+	return nil
+}
+
+func (s *service) ListDeleted(ctx context.Context, kind RecordKind, query DeletedListQuery) (*PaginatedDeletedRecords, error) {
+	if !kind.IsValid() {
+		return nil, fmt.Errorf("invalid record kind: %s", kind)
+	}
+	page, limit := normalizePage(query.Page, query.Limit)
+
+	records, total, err := s.repo.ListDeleted(ctx, kind, page, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deleted records: %w", err)
+	}
+
+	return &PaginatedDeletedRecords{
+		Kind:       kind,
+		Records:    records,
+		TotalCount: total,
+		Page:       page,
+		Limit:      limit,
+	}, nil
+}
+
+func (s *service) RestoreDeleted(ctx context.Context, kind RecordKind, id uuid.UUID) error {
+	if !kind.IsValid() {
+		return fmt.Errorf("invalid record kind: %s", kind)
+	}
+
+	if err := s.repo.RestoreDeleted(ctx, kind, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("deleted record not found")
+		}
+		return fmt.Errorf("failed to restore record: %w", err)
+	}
+
+	s.invalidateCache(ctx, kind)
+	return nil
+}
+
+// PurgeDeleted permanently removes a soft-deleted record. Events and users with
+// bookings, and templates still referenced by events, are refused: bookings are
+// kept until the event is archived.
+func (s *service) PurgeDeleted(ctx context.Context, kind RecordKind, id uuid.UUID) error {
+	if !kind.IsValid() {
+		return fmt.Errorf("invalid record kind: %s", kind)
+	}
+
+	switch kind {
+	case RecordKindEvents, RecordKindUsers:
+		bookings, err := s.repo.CountBookingsForRecord(ctx, kind, id)
+		if err != nil {
+			return fmt.Errorf("failed to check bookings: %w", err)
+		}
+		if bookings > 0 {
+			return fmt.Errorf("record has %d bookings and cannot be purged", bookings)
+		}
+	case RecordKindVenueTemplates:
+		events, err := s.repo.CountEventsUsingTemplate(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to check template usage: %w", err)
+		}
+		if events > 0 {
+			return fmt.Errorf("template is used by %d events and cannot be purged", events)
+		}
+	}
+
+	if err := s.repo.PurgeDeleted(ctx, kind, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("deleted record not found")
+		}
+		return fmt.Errorf("failed to purge record: %w", err)
+	}
+	return nil
+}
+
+func normalizePage(page, limit int) (int, int) {
+	if page <= 0 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = 20
+	}
+	return page, limit
+}
@@ -20,6 +20,19 @@ func NewController(service Service) *Controller {
 	}
 }
 
+// Register godoc
+//
+// @Summary      Register a new user
+// @Description  Create a new user account
+// @Tags         Authentication
+// @Accept       json
+// @Produce      json
+// @Param        request body RegisterRequest true "Request body"
+// @Success      201 {object} response.StandardApiResponse{data=AuthResponse} "User registered successfully"
+// @Failure      400 {object} response.StandardApiResponse "Invalid request data"
+// @Failure      409 {object} response.StandardApiResponse "User already exists - USER_ALREADY_EXISTS"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/auth/register [post]
 func (c *Controller) Register(ctx *gin.Context) {
 	var req RegisterRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
@@ -36,7 +49,7 @@ func (c *Controller) Register(ctx *gin.Context) {
 	if err != nil {
 		switch err {
 		case ErrUserAlreadyExists:
-			response.RespondJSON(ctx, "error", http.StatusConflict, "User with this email already exists", nil, nil)
+			response.RespondError(ctx, http.StatusConflict, response.CodeUserAlreadyExists, "User with this email already exists", nil)
 		default:
 			response.RespondJSON(ctx, "error", http.StatusInternalServerError, "Failed to register user", nil, nil)
 		}
@@ -46,6 +59,20 @@ func (c *Controller) Register(ctx *gin.Context) {
 	response.RespondJSON(ctx, "success", http.StatusCreated, "User registered successfully", resp, nil)
 }
 
+// Login godoc
+//
+// @Summary      User login
+// @Description  Authenticate user and get access token. Users with two-factor authentication enabled
+// @Description  get `two_factor_required` and a short-lived `challenge_token` instead of tokens.
+// @Tags         Authentication
+// @Accept       json
+// @Produce      json
+// @Param        request body LoginRequest true "Request body"
+// @Success      200 {object} response.StandardApiResponse{data=AuthResponse} "Login successful"
+// @Failure      400 {object} response.StandardApiResponse "Invalid credentials"
+// @Failure      401 {object} response.StandardApiResponse "Authentication failed - INVALID_CREDENTIALS"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/auth/login [post]
 func (c *Controller) Login(ctx *gin.Context) {
 	var req LoginRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
@@ -62,16 +89,34 @@ func (c *Controller) Login(ctx *gin.Context) {
 	if err != nil {
 		switch err {
 		case ErrInvalidCredentials:
-			response.RespondJSON(ctx, "error", http.StatusUnauthorized, "Invalid email or password", nil, nil)
+			response.RespondError(ctx, http.StatusUnauthorized, response.CodeInvalidCredentials, "Invalid email or password", nil)
 		default:
 			response.RespondJSON(ctx, "error", http.StatusInternalServerError, "Failed to login", nil, nil)
 		}
 		return
 	}
 
+	if resp.TwoFactorRequired {
+		response.RespondJSON(ctx, "success", http.StatusOK, "Two-factor authentication required", resp, nil)
+		return
+	}
+
 	response.RespondJSON(ctx, "success", http.StatusOK, "Login successful", resp, nil)
 }
 
+// RefreshToken godoc
+//
+// @Summary      Refresh access token
+// @Description  Get a new access token using refresh token
+// @Tags         Authentication
+// @Accept       json
+// @Produce      json
+// @Param        request body RefreshTokenRequest true "Request body"
+// @Success      200 {object} response.StandardApiResponse{data=TokenPair} "Token refreshed successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse "Invalid refresh token - INVALID_TOKEN, TOKEN_EXPIRED"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/auth/refresh [post]
 func (c *Controller) RefreshToken(ctx *gin.Context) {
 	var req RefreshTokenRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
@@ -87,8 +132,10 @@ func (c *Controller) RefreshToken(ctx *gin.Context) {
 	tokenPair, err := c.service.RefreshToken(ctx.Request.Context(), req.RefreshToken)
 	if err != nil {
 		switch err {
-		case ErrInvalidToken, ErrTokenExpired:
-			response.RespondJSON(ctx, "error", http.StatusUnauthorized, "Invalid or expired refresh token", nil, nil)
+		case ErrInvalidToken:
+			response.RespondError(ctx, http.StatusUnauthorized, response.CodeInvalidToken, "Invalid or expired refresh token", nil)
+		case ErrTokenExpired:
+			response.RespondError(ctx, http.StatusUnauthorized, response.CodeTokenExpired, "Invalid or expired refresh token", nil)
 		case ErrUserNotFound:
 			response.RespondJSON(ctx, "error", http.StatusUnauthorized, "User not found", nil, nil)
 		default:
@@ -100,6 +147,16 @@ func (c *Controller) RefreshToken(ctx *gin.Context) {
 	response.RespondJSON(ctx, "success", http.StatusOK, "Token refreshed successfully", tokenPair, nil)
 }
 
+// Logout godoc
+//
+// @Summary      User logout
+// @Description  Invalidate user session
+// @Tags         Authentication
+// @Accept       json
+// @Produce      json
+// @Param        request body LogoutRequest true "Request body"
+// @Success      200 {object} response.StandardApiResponse "Logged out successfully"
+// @Router       /api/v1/auth/logout [post]
 func (c *Controller) Logout(ctx *gin.Context) {
 	var req LogoutRequest
 	ctx.ShouldBindJSON(&req) // Optional body
@@ -107,6 +164,24 @@ func (c *Controller) Logout(ctx *gin.Context) {
 	response.RespondJSON(ctx, "success", http.StatusOK, "Logged out successfully", nil, nil)
 }
 
+// ChangePassword godoc
+//
+// @Summary      Change user password
+// @Description  Change the authenticated user's password
+// @Description
+// @Description  Refused while impersonating a user.
+// @Tags         Authentication
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        request body ChangePasswordRequest true "Request body"
+// @Success      200 {object} response.StandardApiResponse "Password changed successfully"
+// @Failure      400 {object} response.StandardApiResponse "Invalid request data"
+// @Failure      401 {object} response.StandardApiResponse "Unauthorized or incorrect current password - INVALID_CREDENTIALS"
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/auth/change-password [put]
 func (c *Controller) ChangePassword(ctx *gin.Context) {
 	userID, exists := ctx.Get("user_id")
 	if !exists {
@@ -129,7 +204,7 @@ func (c *Controller) ChangePassword(ctx *gin.Context) {
 	if err != nil {
 		switch err {
 		case ErrInvalidCredentials:
-			response.RespondJSON(ctx, "error", http.StatusUnauthorized, "Current password is incorrect", nil, nil)
+			response.RespondError(ctx, http.StatusUnauthorized, response.CodeInvalidCredentials, "Current password is incorrect", nil)
 		case ErrUserNotFound:
 			response.RespondJSON(ctx, "error", http.StatusNotFound, "User not found", nil, nil)
 		default:
@@ -141,6 +216,16 @@ func (c *Controller) ChangePassword(ctx *gin.Context) {
 	response.RespondJSON(ctx, "success", http.StatusOK, "Password changed successfully", nil, nil)
 }
 
+// GetMe godoc
+//
+// @Summary      Get current user profile
+// @Description  Get the authenticated user's profile information
+// @Tags         Authentication
+// @Produce      json
+// @Security     Bearer
+// @Success      200 {object} response.StandardApiResponse{data=object} "User profile retrieved successfully"
+// @Failure      401 {object} response.StandardApiResponse "Unauthorized"
+// @Router       /api/v1/auth/me [get]
 func (c *Controller) GetMe(ctx *gin.Context) {
 	userID, exists := ctx.Get("user_id")
 	if !exists {
@@ -152,10 +237,299 @@ func (c *Controller) GetMe(ctx *gin.Context) {
 	role, _ := ctx.Get("user_role")
 
 	userData := map[string]interface{}{
-		"id":    userID,
-		"email": email,
-		"role":  role,
+		"id":                  userID,
+		"email":               email,
+		"role":                role,
+		"two_factor_verified": ctx.GetBool("mfa_verified"),
 	}
 
 	response.RespondJSON(ctx, "success", http.StatusOK, "User data retrieved successfully", userData, nil)
 }
+
+// UpdateDisplayCurrency godoc
+//
+// @Summary      Set display currency
+// @Description  Set the currency booking totals are also shown in, as `display_price` next to the
+// @Description  booking's own currency. Conversions use the current exchange rate.
+// @Tags         Authentication
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        request body DisplayCurrencyRequest true "Request body"
+// @Success      200 {object} response.StandardApiResponse{data=DisplayCurrencyResponse} "Display currency updated successfully"
+// @Failure      400 {object} response.StandardApiResponse "Invalid or unsupported currency"
+// @Failure      401 {object} response.StandardApiResponse "Unauthorized"
+// @Failure      404 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/auth/me/display-currency [put]
+func (c *Controller) UpdateDisplayCurrency(ctx *gin.Context) {
+	userID, exists := ctx.Get("user_id")
+	if !exists {
+		response.RespondJSON(ctx, "error", http.StatusUnauthorized, "User not authenticated", nil, nil)
+		return
+	}
+
+	var req DisplayCurrencyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid request body", nil, err.Error())
+		return
+	}
+
+	if err := c.validator.Struct(&req); err != nil {
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Validation failed", nil, err.Error())
+		return
+	}
+
+	preference, err := c.service.UpdateDisplayCurrency(ctx.Request.Context(), userID.(string), &req)
+	if err != nil {
+		switch err {
+		case ErrUnsupportedCurrency:
+			response.RespondJSON(ctx, "error", http.StatusBadRequest, err.Error(), nil, nil)
+		case ErrUserNotFound:
+			response.RespondJSON(ctx, "error", http.StatusNotFound, "User not found", nil, nil)
+		default:
+			response.RespondJSON(ctx, "error", http.StatusInternalServerError, "Failed to update display currency", nil, nil)
+		}
+		return
+	}
+
+	response.RespondJSON(ctx, "success", http.StatusOK, "Display currency updated successfully", preference, nil)
+}
+
+//  TWO-FACTOR AUTHENTICATION
+
+// EnrollTwoFactor godoc
+//
+// @Summary      Start two-factor enrollment
+// @Description  Generate a TOTP secret for the authenticated user. Two-factor authentication is not
+// @Description  enforced until the enrollment is confirmed at /auth/2fa/enable.
+// @Description
+// @Description  Refused while impersonating a user.
+// @Tags         Authentication
+// @Produce      json
+// @Security     Bearer
+// @Success      200 {object} response.StandardApiResponse{data=TwoFactorEnrollment} "Enrollment started"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse
+// @Failure      409 {object} response.StandardApiResponse "Two-factor authentication is already enabled - TWO_FACTOR_STATE_INVALID"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/auth/2fa/enroll [post]
+func (c *Controller) EnrollTwoFactor(ctx *gin.Context) {
+	userID, exists := ctx.Get("user_id")
+	if !exists {
+		response.RespondJSON(ctx, "error", http.StatusUnauthorized, "User not authenticated", nil, nil)
+		return
+	}
+
+	enrollment, err := c.service.EnrollTwoFactor(ctx.Request.Context(), userID.(string))
+	if err != nil {
+		switch err {
+		case ErrTwoFactorAlreadyEnabled:
+			response.RespondError(ctx, http.StatusConflict, response.CodeTwoFactorStateInvalid, err.Error(), nil)
+		case ErrUserNotFound:
+			response.RespondJSON(ctx, "error", http.StatusNotFound, "User not found", nil, nil)
+		default:
+			response.RespondJSON(ctx, "error", http.StatusInternalServerError, "Failed to start two-factor enrollment", nil, nil)
+		}
+		return
+	}
+
+	response.RespondJSON(ctx, "success", http.StatusOK, "Scan the QR code and confirm with a code to enable two-factor authentication", enrollment, nil)
+}
+
+// EnableTwoFactor godoc
+//
+// @Summary      Confirm two-factor enrollment
+// @Description  Confirm the enrollment with a code from the authenticator and receive recovery codes
+// @Description
+// @Description  Refused while impersonating a user.
+// @Tags         Authentication
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        request body EnableTwoFactorRequest true "Request body"
+// @Success      200 {object} response.StandardApiResponse{data=RecoveryCodesResponse} "Two-factor authentication enabled"
+// @Failure      400 {object} response.StandardApiResponse "TWO_FACTOR_STATE_INVALID"
+// @Failure      401 {object} response.StandardApiResponse "Invalid code - INVALID_TWO_FACTOR_CODE, INVALID_CREDENTIALS"
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse
+// @Failure      409 {object} response.StandardApiResponse "TWO_FACTOR_STATE_INVALID"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/auth/2fa/enable [post]
+func (c *Controller) EnableTwoFactor(ctx *gin.Context) {
+	userID, exists := ctx.Get("user_id")
+	if !exists {
+		response.RespondJSON(ctx, "error", http.StatusUnauthorized, "User not authenticated", nil, nil)
+		return
+	}
+
+	var req EnableTwoFactorRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid request body", nil, err.Error())
+		return
+	}
+
+	if err := c.validator.Struct(&req); err != nil {
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Validation failed", nil, err.Error())
+		return
+	}
+
+	codes, err := c.service.EnableTwoFactor(ctx.Request.Context(), userID.(string), &req)
+	if err != nil {
+		c.respondTwoFactorError(ctx, err, "Failed to enable two-factor authentication")
+		return
+	}
+
+	response.RespondJSON(ctx, "success", http.StatusOK, "Two-factor authentication enabled, store the recovery codes safely", codes, nil)
+}
+
+// DisableTwoFactor godoc
+//
+// @Summary      Disable two-factor authentication
+// @Description  Refused while impersonating a user.
+// @Tags         Authentication
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        request body DisableTwoFactorRequest true "Request body"
+// @Success      200 {object} response.StandardApiResponse "Two-factor authentication disabled"
+// @Failure      400 {object} response.StandardApiResponse "TWO_FACTOR_STATE_INVALID"
+// @Failure      401 {object} response.StandardApiResponse "Incorrect password or code - INVALID_TWO_FACTOR_CODE, INVALID_CREDENTIALS"
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse
+// @Failure      409 {object} response.StandardApiResponse "TWO_FACTOR_STATE_INVALID"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/auth/2fa/disable [post]
+func (c *Controller) DisableTwoFactor(ctx *gin.Context) {
+	userID, exists := ctx.Get("user_id")
+	if !exists {
+		response.RespondJSON(ctx, "error", http.StatusUnauthorized, "User not authenticated", nil, nil)
+		return
+	}
+
+	var req DisableTwoFactorRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid request body", nil, err.Error())
+		return
+	}
+
+	if err := c.validator.Struct(&req); err != nil {
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Validation failed", nil, err.Error())
+		return
+	}
+
+	if err := c.service.DisableTwoFactor(ctx.Request.Context(), userID.(string), &req); err != nil {
+		c.respondTwoFactorError(ctx, err, "Failed to disable two-factor authentication")
+		return
+	}
+
+	response.RespondJSON(ctx, "success", http.StatusOK, "Two-factor authentication disabled", nil, nil)
+}
+
+// RegenerateRecoveryCodes godoc
+//
+// @Summary      Regenerate recovery codes
+// @Description  Replace all recovery codes; previous codes stop working
+// @Description
+// @Description  Refused while impersonating a user.
+// @Tags         Authentication
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        request body TwoFactorCodeRequest true "Request body"
+// @Success      200 {object} response.StandardApiResponse{data=RecoveryCodesResponse} "Recovery codes regenerated"
+// @Failure      400 {object} response.StandardApiResponse "TWO_FACTOR_STATE_INVALID"
+// @Failure      401 {object} response.StandardApiResponse "Invalid code - INVALID_TWO_FACTOR_CODE, INVALID_CREDENTIALS"
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse
+// @Failure      409 {object} response.StandardApiResponse "TWO_FACTOR_STATE_INVALID"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/auth/2fa/recovery-codes [post]
+func (c *Controller) RegenerateRecoveryCodes(ctx *gin.Context) {
+	userID, exists := ctx.Get("user_id")
+	if !exists {
+		response.RespondJSON(ctx, "error", http.StatusUnauthorized, "User not authenticated", nil, nil)
+		return
+	}
+
+	var req TwoFactorCodeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid request body", nil, err.Error())
+		return
+	}
+
+	if err := c.validator.Struct(&req); err != nil {
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Validation failed", nil, err.Error())
+		return
+	}
+
+	codes, err := c.service.RegenerateRecoveryCodes(ctx.Request.Context(), userID.(string), &req)
+	if err != nil {
+		c.respondTwoFactorError(ctx, err, "Failed to regenerate recovery codes")
+		return
+	}
+
+	response.RespondJSON(ctx, "success", http.StatusOK, "Recovery codes regenerated, previous codes no longer work", codes, nil)
+}
+
+// VerifyTwoFactor godoc
+//
+// @Summary      Complete two-factor login
+// @Description  Exchange the login challenge and a current authenticator code (or an unused recovery
+// @Description  code) for tokens. Sessions created this way satisfy the admin two-factor requirement.
+// @Tags         Authentication
+// @Accept       json
+// @Produce      json
+// @Param        request body VerifyTwoFactorRequest true "Request body"
+// @Success      200 {object} response.StandardApiResponse{data=AuthResponse} "Login successful"
+// @Failure      400 {object} response.StandardApiResponse "TWO_FACTOR_STATE_INVALID"
+// @Failure      401 {object} response.StandardApiResponse "Invalid code or expired challenge - INVALID_TOKEN, TOKEN_EXPIRED, INVALID_TWO_FACTOR_CODE, INVALID_CREDENTIALS"
+// @Failure      404 {object} response.StandardApiResponse
+// @Failure      409 {object} response.StandardApiResponse "TWO_FACTOR_STATE_INVALID"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/auth/2fa/verify [post]
+func (c *Controller) VerifyTwoFactor(ctx *gin.Context) {
+	var req VerifyTwoFactorRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid request body", nil, err.Error())
+		return
+	}
+
+	if err := c.validator.Struct(&req); err != nil {
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Validation failed", nil, err.Error())
+		return
+	}
+
+	resp, err := c.service.VerifyTwoFactorLogin(ctx.Request.Context(), &req)
+	if err != nil {
+		switch err {
+		case ErrInvalidToken:
+			response.RespondError(ctx, http.StatusUnauthorized, response.CodeInvalidToken, "Invalid or expired challenge, sign in again", nil)
+		case ErrTokenExpired:
+			response.RespondError(ctx, http.StatusUnauthorized, response.CodeTokenExpired, "Invalid or expired challenge, sign in again", nil)
+		default:
+			c.respondTwoFactorError(ctx, err, "Failed to verify two-factor code")
+		}
+		return
+	}
+
+	response.RespondJSON(ctx, "success", http.StatusOK, "Login successful", resp, nil)
+}
+
+func (c *Controller) respondTwoFactorError(ctx *gin.Context, err error, fallback string) {
+	switch err {
+	case ErrInvalidTwoFactorCode:
+		response.RespondError(ctx, http.StatusUnauthorized, response.CodeInvalidTwoFactorCode, "Invalid two-factor code", nil)
+	case ErrInvalidCredentials:
+		response.RespondError(ctx, http.StatusUnauthorized, response.CodeInvalidCredentials, "Password is incorrect", nil)
+	case ErrTwoFactorNotEnrolled, ErrTwoFactorNotPending:
+		response.RespondError(ctx, http.StatusBadRequest, response.CodeTwoFactorStateInvalid, err.Error(), nil)
+	case ErrTwoFactorAlreadyEnabled:
+		response.RespondError(ctx, http.StatusConflict, response.CodeTwoFactorStateInvalid, err.Error(), nil)
+	case ErrUserNotFound:
+		response.RespondJSON(ctx, "error", http.StatusNotFound, "User not found", nil, nil)
+	default:
+		response.RespondJSON(ctx, "error", http.StatusInternalServerError, fallback, nil, nil)
+	}
+}
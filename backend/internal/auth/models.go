@@ -1,7 +1,10 @@
 package auth
 
 import (
+	"time"
+
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
 )
 
 // JWTClaims represents JWT token claims
@@ -9,7 +12,13 @@ type JWTClaims struct {
 	UserID string `json:"user_id"`
 	Email  string `json:"email"`
 	Role   string `json:"role"`
-	Type   string `json:"type"` // "access" or "refresh"
+	Type   string `json:"type"`          // "access", "refresh" or "2fa_challenge"
+	MFA    bool   `json:"mfa,omitempty"` // Session passed two-factor verification
+
+	// Set on tokens a support admin uses to act as the user, see internal/impersonation
+	ImpersonatorID  string `json:"impersonator_id,omitempty"`
+	ImpersonationID string `json:"impersonation_id,omitempty"`
+
 	jwt.RegisteredClaims
 }
 
@@ -19,3 +28,32 @@ type TokenPair struct {
 	RefreshToken string `json:"refresh_token"`
 	ExpiresIn    int64  `json:"expires_in"`
 }
+
+// TwoFactor is a user's TOTP enrollment. It is created pending by enrollment
+// and only enforced at login once a code has been confirmed.
+type TwoFactor struct {
+	UserID       uuid.UUID  `gorm:"type:uuid;primaryKey" json:"user_id"`
+	Secret       string     `gorm:"not null" json:"-"`
+	Enabled      bool       `gorm:"not null;default:false" json:"enabled"`
+	EnabledAt    *time.Time `json:"enabled_at,omitempty"`
+	LastUsedStep int64      `gorm:"not null;default:0" json:"-"` // Time step of the last accepted code, rejects replays
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+func (TwoFactor) TableName() string {
+	return "user_two_factors"
+}
+
+// RecoveryCode is a single-use code for signing in without the authenticator
+type RecoveryCode struct {
+	ID        uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	UserID    uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	CodeHash  string     `gorm:"not null" json:"-"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func (RecoveryCode) TableName() string {
+	return "user_recovery_codes"
+}
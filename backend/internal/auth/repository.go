@@ -4,10 +4,13 @@ package auth
 import (
 	"context"
 	"errors"
+	"time"
 
 	"evently/internal/users"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type Repository interface {
@@ -15,7 +18,18 @@ type Repository interface {
 	GetUserByEmail(ctx context.Context, email string) (*users.User, error)
 	GetUserByID(ctx context.Context, id string) (*users.User, error)
 	UpdateUserPassword(ctx context.Context, userID string, hashedPassword string) error
+	UpdateDisplayCurrency(ctx context.Context, userID string, currency string) error
 	EmailExists(ctx context.Context, email string) (bool, error)
+
+	// Two-factor authentication
+	GetTwoFactor(ctx context.Context, userID string) (*TwoFactor, error)
+	SavePendingTwoFactor(ctx context.Context, twoFactor *TwoFactor) error
+	EnableTwoFactor(ctx context.Context, userID string, step int64, codes []RecoveryCode) error
+	DisableTwoFactor(ctx context.Context, userID string) error
+	UseTOTPStep(ctx context.Context, userID string, step int64) (bool, error)
+	GetUnusedRecoveryCodes(ctx context.Context, userID string) ([]RecoveryCode, error)
+	UseRecoveryCode(ctx context.Context, codeID uuid.UUID) (bool, error)
+	ReplaceRecoveryCodes(ctx context.Context, userID string, codes []RecoveryCode) error
 }
 
 type repository struct {
@@ -59,6 +73,22 @@ func (r *repository) GetUserByID(ctx context.Context, id string) (*users.User, e
 	return &user, nil
 }
 
+func (r *repository) UpdateDisplayCurrency(ctx context.Context, userID string, currency string) error {
+	result := r.db.WithContext(ctx).Model(&users.User{}).
+		Where("id = ?", userID).
+		Update("display_currency", currency)
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return ErrUserNotFound
+	}
+
+	return nil
+}
+
 func (r *repository) UpdateUserPassword(ctx context.Context, userID string, hashedPassword string) error {
 	result := r.db.WithContext(ctx).Model(&users.User{}).
 		Where("id = ?", userID).
@@ -77,9 +107,105 @@ func (r *repository) UpdateUserPassword(ctx context.Context, userID string, hash
 
 func (r *repository) EmailExists(ctx context.Context, email string) (bool, error) {
 	var count int64
-	err := r.db.WithContext(ctx).Model(&users.User{}).Where("email = ?", email).Count(&count).Error
+	// Soft-deleted accounts keep their email reserved until purged
+	err := r.db.WithContext(ctx).Unscoped().Model(&users.User{}).Where("email = ?", email).Count(&count).Error
 	if err != nil {
 		return false, err
 	}
 	return count > 0, nil
 }
+
+//  TWO-FACTOR AUTHENTICATION
+
+func (r *repository) GetTwoFactor(ctx context.Context, userID string) (*TwoFactor, error) {
+	var twoFactor TwoFactor
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&twoFactor).Error
+	if err != nil {
+		return nil, err
+	}
+	return &twoFactor, nil
+}
+
+// SavePendingTwoFactor stores a new secret, replacing an enrollment that was never confirmed
+func (r *repository) SavePendingTwoFactor(ctx context.Context, twoFactor *TwoFactor) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"secret", "enabled", "enabled_at", "last_used_step", "updated_at"}),
+			Where:     clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "user_two_factors.enabled = false"}}},
+		}).
+		Create(twoFactor).Error
+}
+
+// EnableTwoFactor confirms the enrollment and stores its first recovery codes
+func (r *repository) EnableTwoFactor(ctx context.Context, userID string, step int64, codes []RecoveryCode) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&TwoFactor{}).
+			Where("user_id = ? AND enabled = false", userID).
+			Updates(map[string]interface{}{
+				"enabled":        true,
+				"enabled_at":     time.Now(),
+				"last_used_step": step,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrTwoFactorNotPending
+		}
+
+		if err := tx.Where("user_id = ?", userID).Delete(&RecoveryCode{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&codes).Error
+	})
+}
+
+func (r *repository) DisableTwoFactor(ctx context.Context, userID string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&RecoveryCode{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("user_id = ?", userID).Delete(&TwoFactor{}).Error
+	})
+}
+
+// UseTOTPStep records an accepted code's time step. It fails when the step, or a
+// later one, was already used, so a code cannot be replayed within its window.
+func (r *repository) UseTOTPStep(ctx context.Context, userID string, step int64) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&TwoFactor{}).
+		Where("user_id = ? AND last_used_step < ?", userID, step).
+		Update("last_used_step", step)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *repository) GetUnusedRecoveryCodes(ctx context.Context, userID string) ([]RecoveryCode, error) {
+	var codes []RecoveryCode
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Find(&codes).Error
+	return codes, err
+}
+
+// UseRecoveryCode marks a code used, reporting false if it was used concurrently
+func (r *repository) UseRecoveryCode(ctx context.Context, codeID uuid.UUID) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&RecoveryCode{}).
+		Where("id = ? AND used_at IS NULL", codeID).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *repository) ReplaceRecoveryCodes(ctx context.Context, userID string, codes []RecoveryCode) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&RecoveryCode{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&codes).Error
+	})
+}
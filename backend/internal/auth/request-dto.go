@@ -26,6 +26,33 @@ type ChangePasswordRequest struct {
 	NewPassword     string `json:"new_password" validate:"required,min=6"`
 }
 
+// sets the display currency, an empty currency clears it
+type DisplayCurrencyRequest struct {
+	Currency string `json:"currency" validate:"omitempty,len=3,alpha"`
+}
+
+// confirms a two-factor enrollment with a code from the authenticator
+type EnableTwoFactorRequest struct {
+	Code string `json:"code" validate:"required,len=6,numeric"`
+}
+
+// a current authenticator code or an unused recovery code
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" validate:"required"`
+}
+
+// turns two-factor authentication off
+type DisableTwoFactorRequest struct {
+	Password string `json:"password" validate:"required"`
+	Code     string `json:"code" validate:"required"`
+}
+
+// completes a login that requires two-factor authentication
+type VerifyTwoFactorRequest struct {
+	ChallengeToken string `json:"challenge_token" validate:"required"`
+	Code           string `json:"code" validate:"required"`
+}
+
 // represents logout request
 type LogoutRequest struct {
 	RefreshToken string `json:"refresh_token,omitempty"`
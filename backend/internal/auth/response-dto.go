@@ -2,21 +2,43 @@ package auth
 
 import "time"
 
-// represents the authentication response
+// represents the authentication response. When two-factor authentication is
+// required only the challenge token is set, to be exchanged at /auth/2fa/verify.
 type AuthResponse struct {
-	User         UserResponse `json:"user"`
-	AccessToken  string       `json:"access_token"`
-	RefreshToken string       `json:"refresh_token"`
-	ExpiresIn    int64        `json:"expires_in"`
+	User              UserResponse `json:"user"`
+	AccessToken       string       `json:"access_token,omitempty"`
+	RefreshToken      string       `json:"refresh_token,omitempty"`
+	ExpiresIn         int64        `json:"expires_in,omitempty"`
+	TwoFactorRequired bool         `json:"two_factor_required,omitempty"`
+	ChallengeToken    string       `json:"challenge_token,omitempty"`
+}
+
+// represents the user's display currency preference
+type DisplayCurrencyResponse struct {
+	DisplayCurrency string `json:"display_currency"` // Empty when prices are shown in event currencies only
+}
+
+// represents a started two-factor enrollment
+type TwoFactorEnrollment struct {
+	Secret     string `json:"secret"`      // base32, for manual entry
+	OTPAuthURL string `json:"otpauth_url"` // encode as a QR code for authenticator apps
+	Issuer     string `json:"issuer"`
+	Account    string `json:"account"`
+}
+
+// represents freshly generated recovery codes, shown only once
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
 }
 
 // represents user data in responses (without sensitive info)
 type UserResponse struct {
-	ID        string    `json:"id"`
-	FirstName string    `json:"first_name"`
-	LastName  string    `json:"last_name"`
-	Email     string    `json:"email"`
-	Role      string    `json:"role"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID              string    `json:"id"`
+	FirstName       string    `json:"first_name"`
+	LastName        string    `json:"last_name"`
+	Email           string    `json:"email"`
+	Role            string    `json:"role"`
+	DisplayCurrency string    `json:"display_currency,omitempty"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
 }
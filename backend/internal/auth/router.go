@@ -27,13 +27,27 @@ func (authRouter *Router) SetupRoutes(rg *gin.RouterGroup) {
 		auth.POST("/login", authRouter.controller.Login)
 		auth.POST("/refresh", authRouter.controller.RefreshToken)
 		auth.POST("/logout", authRouter.controller.Logout)
+		auth.POST("/2fa/verify", authRouter.controller.VerifyTwoFactor) // Second login step for users with 2FA
 
 		// Protected routes
 		protected := auth.Group("")
 		protected.Use(middleware.JWTAuthWithConfig(authRouter.config))
 		{
-			protected.PUT("/change-password", authRouter.controller.ChangePassword)
 			protected.GET("/me", authRouter.controller.GetMe)
+			protected.PUT("/me/display-currency", authRouter.controller.UpdateDisplayCurrency) // Currency booking totals are also shown in
+		}
+
+		// Account security, out of reach of support admins impersonating the user
+		security := auth.Group("")
+		security.Use(middleware.JWTAuthWithConfig(authRouter.config), middleware.DenyImpersonation())
+		{
+			security.PUT("/change-password", authRouter.controller.ChangePassword)
+
+			// Two-factor enrollment and management
+			security.POST("/2fa/enroll", authRouter.controller.EnrollTwoFactor)
+			security.POST("/2fa/enable", authRouter.controller.EnableTwoFactor)
+			security.POST("/2fa/disable", authRouter.controller.DisableTwoFactor)
+			security.POST("/2fa/recovery-codes", authRouter.controller.RegenerateRecoveryCodes)
 		}
 	}
 }
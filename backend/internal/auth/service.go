@@ -12,6 +12,7 @@ import (
 
 	"evently/internal/shared/config"
 	"evently/internal/users"
+	"evently/pkg/currency"
 	// Remove any import of "evently/internal/auth" from other packages that also import this file
 )
 
@@ -21,6 +22,13 @@ var (
 	ErrUserAlreadyExists  = errors.New("user already exists")
 	ErrInvalidToken       = errors.New("invalid token")
 	ErrTokenExpired       = errors.New("token expired")
+
+	ErrTwoFactorAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	ErrTwoFactorNotEnrolled    = errors.New("two-factor authentication is not enrolled")
+	ErrTwoFactorNotPending     = errors.New("no pending two-factor enrollment")
+	ErrInvalidTwoFactorCode    = errors.New("invalid two-factor code")
+
+	ErrUnsupportedCurrency = errors.New("unsupported currency")
 )
 
 type Service interface {
@@ -29,11 +37,23 @@ type Service interface {
 	RefreshToken(ctx context.Context, refreshToken string) (*TokenPair, error)
 	ChangePassword(ctx context.Context, userID string, req *ChangePasswordRequest) error
 	ValidateToken(tokenString string) (*JWTClaims, error)
+
+	// Display currency preference
+	SetCurrencyProvider(provider currency.Provider)
+	UpdateDisplayCurrency(ctx context.Context, userID string, req *DisplayCurrencyRequest) (*DisplayCurrencyResponse, error)
+
+	// Two-factor authentication
+	EnrollTwoFactor(ctx context.Context, userID string) (*TwoFactorEnrollment, error)
+	EnableTwoFactor(ctx context.Context, userID string, req *EnableTwoFactorRequest) (*RecoveryCodesResponse, error)
+	DisableTwoFactor(ctx context.Context, userID string, req *DisableTwoFactorRequest) error
+	RegenerateRecoveryCodes(ctx context.Context, userID string, req *TwoFactorCodeRequest) (*RecoveryCodesResponse, error)
+	VerifyTwoFactorLogin(ctx context.Context, req *VerifyTwoFactorRequest) (*AuthResponse, error)
 }
 
 type service struct {
-	repo   Repository
-	config *config.Config
+	repo       Repository
+	config     *config.Config
+	currencies currency.Provider
 }
 
 func NewService(repo Repository, cfg *config.Config) Service {
@@ -84,20 +104,21 @@ func (s *service) Register(ctx context.Context, req *RegisterRequest) (*AuthResp
 	}
 
 	// Generate tokens
-	tokenPair, err := s.generateTokenPair(user.ID.String(), user.Email, string(user.Role))
+	tokenPair, err := s.generateTokenPair(user.ID.String(), user.Email, string(user.Role), false)
 	if err != nil {
 		return nil, err
 	}
 
 	return &AuthResponse{
 		User: UserResponse{
-			ID:        user.ID.String(),
-			FirstName: user.FirstName,
-			LastName:  user.LastName,
-			Email:     user.Email,
-			Role:      string(user.Role),
-			CreatedAt: user.CreatedAt,
-			UpdatedAt: user.UpdatedAt,
+			ID:              user.ID.String(),
+			FirstName:       user.FirstName,
+			LastName:        user.LastName,
+			Email:           user.Email,
+			Role:            string(user.Role),
+			DisplayCurrency: user.DisplayCurrency,
+			CreatedAt:       user.CreatedAt,
+			UpdatedAt:       user.UpdatedAt,
 		},
 		AccessToken:  tokenPair.AccessToken,
 		RefreshToken: tokenPair.RefreshToken,
@@ -119,21 +140,35 @@ func (s *service) Login(ctx context.Context, req *LoginRequest) (*AuthResponse,
 		return nil, ErrInvalidCredentials
 	}
 
-	// Generate tokens
-	tokenPair, err := s.generateTokenPair(user.ID.String(), user.Email, string(user.Role))
+	// Users with two-factor enabled get a short-lived challenge instead of tokens
+	enabled, err := s.twoFactorEnabled(ctx, user.ID.String())
+	if err != nil {
+		return nil, err
+	}
+	if enabled {
+		return s.twoFactorChallenge(user)
+	}
+
+	return s.authResponse(user, false)
+}
+
+// authResponse issues a token pair for a signed-in user
+func (s *service) authResponse(user *users.User, mfa bool) (*AuthResponse, error) {
+	tokenPair, err := s.generateTokenPair(user.ID.String(), user.Email, string(user.Role), mfa)
 	if err != nil {
 		return nil, err
 	}
 
 	return &AuthResponse{
 		User: UserResponse{
-			ID:        user.ID.String(),
-			FirstName: user.FirstName,
-			LastName:  user.LastName,
-			Email:     user.Email,
-			Role:      string(user.Role),
-			CreatedAt: user.CreatedAt,
-			UpdatedAt: user.UpdatedAt,
+			ID:              user.ID.String(),
+			FirstName:       user.FirstName,
+			LastName:        user.LastName,
+			Email:           user.Email,
+			Role:            string(user.Role),
+			DisplayCurrency: user.DisplayCurrency,
+			CreatedAt:       user.CreatedAt,
+			UpdatedAt:       user.UpdatedAt,
 		},
 		AccessToken:  tokenPair.AccessToken,
 		RefreshToken: tokenPair.RefreshToken,
@@ -157,8 +192,8 @@ func (s *service) RefreshToken(ctx context.Context, refreshToken string) (*Token
 		return nil, ErrUserNotFound
 	}
 
-	// Generate new token pair
-	tokenPair, err := s.generateTokenPair(user.ID.String(), user.Email, string(user.Role))
+	// Generate new token pair, keeping the session's two-factor verification
+	tokenPair, err := s.generateTokenPair(user.ID.String(), user.Email, string(user.Role), claims.MFA)
 	if err != nil {
 		return nil, err
 	}
@@ -187,11 +222,29 @@ func (s *service) ChangePassword(ctx context.Context, userID string, req *Change
 	return s.repo.UpdateUserPassword(ctx, userID, string(hashedPassword))
 }
 
+// SetCurrencyProvider sets the currencies users can pick as their display currency
+func (s *service) SetCurrencyProvider(provider currency.Provider) {
+	s.currencies = provider
+}
+
+// UpdateDisplayCurrency sets the currency prices are shown in, an empty currency clears it
+func (s *service) UpdateDisplayCurrency(ctx context.Context, userID string, req *DisplayCurrencyRequest) (*DisplayCurrencyResponse, error) {
+	code := currency.Normalize(req.Currency)
+	if code != "" && s.currencies != nil && !s.currencies.Supported(code) {
+		return nil, ErrUnsupportedCurrency
+	}
+
+	if err := s.repo.UpdateDisplayCurrency(ctx, userID, code); err != nil {
+		return nil, err
+	}
+	return &DisplayCurrencyResponse{DisplayCurrency: code}, nil
+}
+
 func (s *service) ValidateToken(tokenString string) (*JWTClaims, error) {
 	return s.validateToken(tokenString)
 }
 
-func (s *service) generateTokenPair(userID, email, role string) (*TokenPair, error) {
+func (s *service) generateTokenPair(userID, email, role string, mfa bool) (*TokenPair, error) {
 	now := time.Now()
 
 	// Access token (15 minutes)
@@ -200,6 +253,7 @@ func (s *service) generateTokenPair(userID, email, role string) (*TokenPair, err
 		Email:  email,
 		Role:   role,
 		Type:   "access",
+		MFA:    mfa,
 		RegisteredClaims: jwt.RegisteredClaims{
 			IssuedAt:  jwt.NewNumericDate(now),
 			ExpiresAt: jwt.NewNumericDate(now.Add(s.config.JWT.JWTExpiresIn)),
@@ -220,6 +274,7 @@ func (s *service) generateTokenPair(userID, email, role string) (*TokenPair, err
 		Email:  email,
 		Role:   role,
 		Type:   "refresh",
+		MFA:    mfa,
 		RegisteredClaims: jwt.RegisteredClaims{
 			IssuedAt:  jwt.NewNumericDate(now),
 			ExpiresAt: jwt.NewNumericDate(now.Add(s.config.JWT.RefreshExpiresIn)),
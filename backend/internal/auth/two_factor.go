@@ -0,0 +1,283 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+
+	"evently/internal/users"
+)
+
+const (
+	tokenTypeChallenge = "2fa_challenge"
+	recoveryCodeCount  = 10
+)
+
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+func (s *service) EnrollTwoFactor(ctx context.Context, userID string) (*TwoFactorEnrollment, error) {
+	user, err := s.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	enabled, err := s.twoFactorEnabled(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if enabled {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+
+	secret, err := generateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.SavePendingTwoFactor(ctx, &TwoFactor{UserID: user.ID, Secret: secret}); err != nil {
+		return nil, err
+	}
+
+	issuer := s.config.TwoFactor.Issuer
+	return &TwoFactorEnrollment{
+		Secret:     secret,
+		OTPAuthURL: totpURI(issuer, user.Email, secret),
+		Issuer:     issuer,
+		Account:    user.Email,
+	}, nil
+}
+
+func (s *service) EnableTwoFactor(ctx context.Context, userID string, req *EnableTwoFactorRequest) (*RecoveryCodesResponse, error) {
+	twoFactor, err := s.repo.GetTwoFactor(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTwoFactorNotEnrolled
+		}
+		return nil, err
+	}
+	if twoFactor.Enabled {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+
+	step, ok := verifyTOTP(twoFactor.Secret, req.Code, time.Now())
+	if !ok {
+		return nil, ErrInvalidTwoFactorCode
+	}
+
+	plain, codes, err := generateRecoveryCodes(twoFactor)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.EnableTwoFactor(ctx, userID, step, codes); err != nil {
+		return nil, err
+	}
+
+	return &RecoveryCodesResponse{RecoveryCodes: plain}, nil
+}
+
+func (s *service) DisableTwoFactor(ctx context.Context, userID string, req *DisableTwoFactorRequest) error {
+	user, err := s.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		return ErrUserNotFound
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)); err != nil {
+		return ErrInvalidCredentials
+	}
+
+	twoFactor, err := s.enabledTwoFactor(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := s.verifySecondFactor(ctx, twoFactor, req.Code); err != nil {
+		return err
+	}
+
+	return s.repo.DisableTwoFactor(ctx, userID)
+}
+
+func (s *service) RegenerateRecoveryCodes(ctx context.Context, userID string, req *TwoFactorCodeRequest) (*RecoveryCodesResponse, error) {
+	twoFactor, err := s.enabledTwoFactor(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.verifySecondFactor(ctx, twoFactor, req.Code); err != nil {
+		return nil, err
+	}
+
+	plain, codes, err := generateRecoveryCodes(twoFactor)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.ReplaceRecoveryCodes(ctx, userID, codes); err != nil {
+		return nil, err
+	}
+
+	return &RecoveryCodesResponse{RecoveryCodes: plain}, nil
+}
+
+// VerifyTwoFactorLogin exchanges a login challenge and a code for a verified session
+func (s *service) VerifyTwoFactorLogin(ctx context.Context, req *VerifyTwoFactorRequest) (*AuthResponse, error) {
+	claims, err := s.validateToken(req.ChallengeToken)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Type != tokenTypeChallenge {
+		return nil, ErrInvalidToken
+	}
+
+	user, err := s.repo.GetUserByID(ctx, claims.UserID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	twoFactor, err := s.enabledTwoFactor(ctx, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.verifySecondFactor(ctx, twoFactor, req.Code); err != nil {
+		return nil, err
+	}
+
+	return s.authResponse(user, true)
+}
+
+// twoFactorChallenge is the login response for users with two-factor enabled
+func (s *service) twoFactorChallenge(user *users.User) (*AuthResponse, error) {
+	now := time.Now()
+	claims := JWTClaims{
+		UserID: user.ID.String(),
+		Email:  user.Email,
+		Role:   string(user.Role),
+		Type:   tokenTypeChallenge,
+		RegisteredClaims: jwt.RegisteredClaims{
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(s.config.TwoFactor.ChallengeTTL)),
+			Issuer:    "evently",
+			Subject:   user.ID.String(),
+		},
+	}
+
+	challenge, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(s.config.JWT.Secret))
+	if err != nil {
+		return nil, err
+	}
+
+	return &AuthResponse{
+		User: UserResponse{
+			ID:    user.ID.String(),
+			Email: user.Email,
+		},
+		TwoFactorRequired: true,
+		ChallengeToken:    challenge,
+	}, nil
+}
+
+func (s *service) twoFactorEnabled(ctx context.Context, userID string) (bool, error) {
+	twoFactor, err := s.repo.GetTwoFactor(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return twoFactor.Enabled, nil
+}
+
+func (s *service) enabledTwoFactor(ctx context.Context, userID string) (*TwoFactor, error) {
+	twoFactor, err := s.repo.GetTwoFactor(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTwoFactorNotEnrolled
+		}
+		return nil, err
+	}
+	if !twoFactor.Enabled {
+		return nil, ErrTwoFactorNotEnrolled
+	}
+	return twoFactor, nil
+}
+
+// verifySecondFactor accepts a current authenticator code or an unused recovery code.
+// Each is single use: an authenticator code cannot be replayed within its window.
+func (s *service) verifySecondFactor(ctx context.Context, twoFactor *TwoFactor, code string) error {
+	userID := twoFactor.UserID.String()
+
+	if step, ok := verifyTOTP(twoFactor.Secret, code, time.Now()); ok {
+		used, err := s.repo.UseTOTPStep(ctx, userID, step)
+		if err != nil {
+			return err
+		}
+		if !used {
+			return ErrInvalidTwoFactorCode
+		}
+		return nil
+	}
+
+	normalized := normalizeRecoveryCode(code)
+	if normalized == "" {
+		return ErrInvalidTwoFactorCode
+	}
+
+	codes, err := s.repo.GetUnusedRecoveryCodes(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, recovery := range codes {
+		if bcrypt.CompareHashAndPassword([]byte(recovery.CodeHash), []byte(normalized)) != nil {
+			continue
+		}
+		used, err := s.repo.UseRecoveryCode(ctx, recovery.ID)
+		if err != nil {
+			return err
+		}
+		if !used {
+			return ErrInvalidTwoFactorCode
+		}
+		return nil
+	}
+
+	return ErrInvalidTwoFactorCode
+}
+
+// generateRecoveryCodes returns codes formatted for display and their hashed records
+func generateRecoveryCodes(twoFactor *TwoFactor) ([]string, []RecoveryCode, error) {
+	plain := make([]string, 0, recoveryCodeCount)
+	codes := make([]RecoveryCode, 0, recoveryCodeCount)
+
+	for i := 0; i < recoveryCodeCount; i++ {
+		raw := make([]byte, 5)
+		if _, err := rand.Read(raw); err != nil {
+			return nil, nil, err
+		}
+		code := strings.ToLower(recoveryCodeEncoding.EncodeToString(raw)) // 8 characters
+
+		hash, err := bcrypt.GenerateFromPassword([]byte(code), bcrypt.DefaultCost)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		plain = append(plain, code[:4]+"-"+code[4:])
+		codes = append(codes, RecoveryCode{UserID: twoFactor.UserID, CodeHash: string(hash)})
+	}
+
+	return plain, codes, nil
+}
+
+// normalizeRecoveryCode strips formatting so codes can be typed with or without the dash
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+	if len(code) != 8 {
+		return ""
+	}
+	return code
+}
@@ -0,0 +1,98 @@
+package bookings
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Conflict check modes
+const (
+	ConflictModeOff   = "off"   // No conflict check
+	ConflictModeWarn  = "warn"  // Book anyway and return the conflicts alongside the booking
+	ConflictModeBlock = "block" // Refuse the booking while a conflict exists
+)
+
+// ConflictConfig contains configuration for detecting bookings that overlap in time
+type ConflictConfig struct {
+	Mode string
+	// Events only record a start time, so two events overlap when they start within this window of each other
+	Window time.Duration
+}
+
+// DefaultConflictConfig returns default conflict check configuration
+func DefaultConflictConfig() *ConflictConfig {
+	return &ConflictConfig{
+		Mode:   ConflictModeWarn,
+		Window: 3 * time.Hour, // Roughly the length of a concert or match
+	}
+}
+
+// Enabled reports whether conflicts should be looked up at all
+func (c *ConflictConfig) Enabled() bool {
+	return c != nil && (c.Mode == ConflictModeWarn || c.Mode == ConflictModeBlock)
+}
+
+// BookingConflict is an existing booking for another event that overlaps the one being booked
+type BookingConflict struct {
+	BookingID     string    `json:"booking_id"`
+	BookingRef    string    `json:"booking_ref"`
+	Status        string    `json:"status"`
+	TotalSeats    int       `json:"total_seats"`
+	EventID       string    `json:"event_id"`
+	EventName     string    `json:"event_name"`
+	Venue         string    `json:"venue"`
+	EventDateTime time.Time `json:"event_date_time"`
+}
+
+// ConflictError is returned in block mode when the user already has plans at that time
+type ConflictError struct {
+	Conflicts []BookingConflict
+}
+
+func (e *ConflictError) Error() string {
+	names := make([]string, len(e.Conflicts))
+	for i, conflict := range e.Conflicts {
+		names[i] = fmt.Sprintf("%s at %s", conflict.EventName, conflict.EventDateTime.UTC().Format("2006-01-02 15:04 MST"))
+	}
+	return fmt.Sprintf("booking conflicts with your existing booking(s) for %s", strings.Join(names, ", "))
+}
+
+func (s *service) SetConflictConfig(config *ConflictConfig) {
+	s.conflictConfig = config
+}
+
+// GetBookingConflicts lists the user's active bookings for other events overlapping the given event
+func (s *service) GetBookingConflicts(ctx context.Context, userID, eventID uuid.UUID) ([]BookingConflict, error) {
+	if !s.conflictConfig.Enabled() {
+		return []BookingConflict{}, nil
+	}
+
+	conflicts, err := s.repo.FindOverlappingBookings(ctx, userID, eventID, s.conflictConfig.Window)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check booking conflicts: %w", err)
+	}
+	return conflicts, nil
+}
+
+// checkConflicts enforces the configured mode, returning the conflicts to report in warn mode
+func (s *service) checkConflicts(ctx context.Context, userID, eventID uuid.UUID) ([]BookingConflict, error) {
+	conflicts, err := s.GetBookingConflicts(ctx, userID, eventID)
+	if err != nil {
+		// The check is advisory in warn mode, so a lookup failure shouldn't lose the booking
+		if s.conflictConfig.Mode == ConflictModeBlock {
+			return nil, err
+		}
+		log.Printf("⚠️ Booking conflict check failed for user %s, event %s: %v", userID, eventID, err)
+		return nil, nil
+	}
+
+	if len(conflicts) > 0 && s.conflictConfig.Mode == ConflictModeBlock {
+		return nil, &ConflictError{Conflicts: conflicts}
+	}
+	return conflicts, nil
+}
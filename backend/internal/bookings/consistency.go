@@ -0,0 +1,189 @@
+package bookings
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"evently/pkg/alerting"
+
+	"github.com/google/uuid"
+)
+
+// A seat is double booked when it is in two bookings for the same event that
+// aren't cancelled. Redis holds and the conflict check in CreateAtomic should
+// make that impossible, and the unique (event_id, seat_id) index on
+// seat_bookings backs them up; seat bookings of cancelled bookings are deleted,
+// so the full index covers what a partial one over live bookings would. The
+// consistency check looks for what slipped through anyway, such as a database
+// restored without the index.
+
+// ConsistencyConfig contains configuration for the double booking check
+type ConsistencyConfig struct {
+	CheckInterval time.Duration
+	SampleSize    int // Double booked seats listed in an alert
+	// Serialize finalizations of the same seats with Postgres advisory locks,
+	// so a conflict is found by the check rather than the unique index
+	StrictSeatLocks bool
+}
+
+// DefaultConsistencyConfig returns default consistency check configuration
+func DefaultConsistencyConfig() *ConsistencyConfig {
+	return &ConsistencyConfig{
+		CheckInterval:   5 * time.Minute,
+		SampleSize:      10,
+		StrictSeatLocks: false,
+	}
+}
+
+// DoubleBooking is a seat held by more than one live booking of an event
+type DoubleBooking struct {
+	EventID    uuid.UUID   `json:"event_id"`
+	SeatID     uuid.UUID   `json:"seat_id"`
+	BookingIDs []uuid.UUID `json:"booking_ids"`
+}
+
+// SeatConsistencyReport is the outcome of one double booking check
+type SeatConsistencyReport struct {
+	CheckedAt      time.Time       `json:"checked_at"`
+	DoubleBookings []DoubleBooking `json:"double_bookings"`
+	UniqueIndex    bool            `json:"unique_index"` // seat_bookings (event_id, seat_id) is enforced
+}
+
+func (s *service) SetConsistencyConfig(config *ConsistencyConfig) {
+	s.consistencyConfig = config
+	s.repo.SetStrictSeatLocks(config.StrictSeatLocks)
+}
+
+// CheckSeatConsistency looks for seats booked twice for the same event
+func (s *service) CheckSeatConsistency(ctx context.Context) (*SeatConsistencyReport, error) {
+	doubles, err := s.repo.FindDoubleBookings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	indexed, err := s.repo.HasSeatBookingUniqueIndex(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SeatConsistencyReport{
+		CheckedAt:      time.Now(),
+		DoubleBookings: doubles,
+		UniqueIndex:    indexed,
+	}, nil
+}
+
+// ConsistencyJob periodically checks for double booked seats and alerts on them
+type ConsistencyJob struct {
+	service Service
+	alerter alerting.Alerter
+	config  *ConsistencyConfig
+	done    chan struct{}
+}
+
+// NewConsistencyJob creates a new consistency check job
+func NewConsistencyJob(service Service, alerter alerting.Alerter, config *ConsistencyConfig) *ConsistencyJob {
+	if config == nil {
+		config = DefaultConsistencyConfig()
+	}
+
+	return &ConsistencyJob{
+		service: service,
+		alerter: alerter,
+		config:  config,
+		done:    make(chan struct{}),
+	}
+}
+
+// Start starts the consistency check job
+func (j *ConsistencyJob) Start(ctx context.Context) {
+	log.Printf("🧮 CONSISTENCY: Starting double booking check with %v interval", j.config.CheckInterval)
+	go j.run(ctx)
+}
+
+// Stop stops the consistency check job
+func (j *ConsistencyJob) Stop() {
+	log.Println("🧮 CONSISTENCY: Stopping double booking check...")
+	close(j.done)
+}
+
+func (j *ConsistencyJob) run(ctx context.Context) {
+	ticker := time.NewTicker(j.config.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			j.Check(ctx)
+		case <-j.done:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Check runs the double booking check once and alerts on what it finds
+func (j *ConsistencyJob) Check(ctx context.Context) {
+	report, err := j.service.CheckSeatConsistency(ctx)
+	if err != nil {
+		log.Printf("❌ CONSISTENCY: %v", err)
+		return
+	}
+
+	if !report.UniqueIndex {
+		j.send(ctx, alerting.Alert{
+			Key:      "bookings.seat_index_missing",
+			Severity: alerting.SeverityCritical,
+			Title:    "Seat booking unique index missing",
+			Message:  "seat_bookings has no unique (event_id, seat_id) index, nothing in Postgres stops a seat from being booked twice",
+			Fields:   map[string]string{"index": "idx_unique_seat_event"},
+		})
+	}
+
+	if len(report.DoubleBookings) == 0 {
+		return
+	}
+
+	events := make(map[uuid.UUID]bool)
+	for _, double := range report.DoubleBookings {
+		events[double.EventID] = true
+	}
+	sample := report.DoubleBookings
+	if len(sample) > j.config.SampleSize {
+		sample = sample[:j.config.SampleSize]
+	}
+	lines := make([]string, len(sample))
+	for i, double := range sample {
+		ids := make([]string, len(double.BookingIDs))
+		for k, id := range double.BookingIDs {
+			ids[k] = id.String()
+		}
+		lines[i] = fmt.Sprintf("event %s seat %s: %s", double.EventID, double.SeatID, strings.Join(ids, ", "))
+	}
+
+	log.Printf("🚨 CONSISTENCY: %d seats are double booked across %d events", len(report.DoubleBookings), len(events))
+	j.send(ctx, alerting.Alert{
+		Key:      "bookings.double_booked",
+		Severity: alerting.SeverityCritical,
+		Title:    "Double booked seats detected",
+		Message:  fmt.Sprintf("%d seats are in more than one live booking, refund or move the later bookings", len(report.DoubleBookings)),
+		Fields: map[string]string{
+			"seats":  fmt.Sprintf("%d", len(report.DoubleBookings)),
+			"events": fmt.Sprintf("%d", len(events)),
+			"sample": strings.Join(lines, "; "),
+		},
+	})
+}
+
+func (j *ConsistencyJob) send(ctx context.Context, alert alerting.Alert) {
+	if j.alerter == nil {
+		return
+	}
+	alert.Timestamp = time.Now()
+	if err := j.alerter.Send(ctx, alert); err != nil {
+		log.Printf("❌ CONSISTENCY: Failed to send alert %s: %v", alert.Key, err)
+	}
+}
@@ -1,8 +1,12 @@
 package bookings
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
+
+	"evently/internal/shared/utils/response"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -16,86 +20,121 @@ func NewController(service Service) *Controller {
 	return &Controller{service: service}
 }
 
+// ConfirmBooking godoc
+//
+// @Summary      Confirm booking
+// @Description  Confirm a booking from held seats
+// @Description
+// @Description  Partner API keys need the `bookings:write` scope.
+// @Tags         Bookings
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Security     ApiKey
+// @Param        request body BookingConfirmationRequest true "Request body"
+// @Success      201 {object} response.StandardApiResponse{data=BookingConfirmationResponse} "Booking confirmed successfully"
+// @Success      202 {object} response.StandardApiResponse{data=BookingConfirmationResponse} "Payment failed. The booking moves to PENDING_PAYMENT with its seats reserved while the payment is retried on a schedule; the user is emailed a resume-payment link. The booking is cancelled and the waitlist notified once the retries are exhausted."
+// @Failure      400 {object} response.StandardApiResponse "Invalid hold ID or hold expired"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      409 {object} response.StandardApiResponse "The user already has a booking for an overlapping event and the deployment runs the conflict check in block mode (BOOKING_CONFLICT_MODE=block). In warn mode the booking goes through and the same details are returned in `data.conflicts`. - BOOKING_CONFLICT"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/bookings/confirm [post]
 func (c *Controller) ConfirmBooking(ctx *gin.Context) {
 	// Get user ID from JWT
 	userIDInterface, exists := ctx.Get("user_id")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		response.RespondJSON(ctx, "error", http.StatusUnauthorized, "User not authenticated", nil, nil)
 		return
 	}
 
 	userIDStr, ok := userIDInterface.(string)
 	if !ok {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID format"})
+		response.RespondJSON(ctx, "error", http.StatusInternalServerError, "Invalid user ID format", nil, nil)
 		return
 	}
 
 	userID, err := uuid.Parse(userIDStr)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid user ID", nil, nil)
 		return
 	}
 
 	var req BookingConfirmationRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request body",
-			"details": err.Error(),
-		})
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid request body", nil, err.Error())
 		return
 	}
 
 	// Confirm booking
-	response, err := c.service.ConfirmBooking(ctx.Request.Context(), userID, req)
+	confirmation, err := c.service.ConfirmBooking(ctx.Request.Context(), userID, req)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Failed to confirm booking",
-			"details": err.Error(),
-		})
+		var conflictErr *ConflictError
+		if errors.As(err, &conflictErr) {
+			response.RespondErrorWithData(ctx, http.StatusConflict, response.CodeBookingConflict, "Failed to confirm booking",
+				gin.H{"conflicts": conflictErr.Conflicts}, err.Error())
+			return
+		}
+		response.RespondError(ctx, http.StatusBadRequest, bookingErrorCode(err, http.StatusBadRequest), "Failed to confirm booking", err.Error())
 		return
 	}
 
-	ctx.JSON(http.StatusCreated, gin.H{
-		"message": "Booking confirmed successfully",
-		"data":    response,
-	})
+	// A declined payment leaves the booking pending with retries scheduled
+	if confirmation.Status != "CONFIRMED" {
+		response.RespondJSON(ctx, "success", http.StatusAccepted, "Payment failed, booking is awaiting payment", confirmation, nil)
+		return
+	}
+
+	response.RespondJSON(ctx, "success", http.StatusCreated, "Booking confirmed successfully", confirmation, nil)
 }
 
+// GetBooking godoc
+//
+// @Summary      Get booking by ID
+// @Description  Get details of a specific booking
+// @Tags         Bookings
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=Booking} "Booking retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Booking not found - BOOKING_NOT_FOUND"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/bookings/{id} [get]
 func (c *Controller) GetBooking(ctx *gin.Context) {
 
 	bookingIDStr := ctx.Param("id")
 	bookingID, err := uuid.Parse(bookingIDStr)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid booking ID"})
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid booking ID", nil, nil)
 		return
 	}
 
 	// user ID from JWT
 	userIDInterface, exists := ctx.Get("user_id")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		response.RespondJSON(ctx, "error", http.StatusUnauthorized, "User not authenticated", nil, nil)
 		return
 	}
 
 	userIDStr, ok := userIDInterface.(string)
 	if !ok {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID format"})
+		response.RespondJSON(ctx, "error", http.StatusInternalServerError, "Invalid user ID format", nil, nil)
 		return
 	}
 
 	userID, err := uuid.Parse(userIDStr)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid user ID", nil, nil)
 		return
 	}
 
 	// Get booking
 	booking, err := c.service.GetBooking(ctx.Request.Context(), bookingID)
 	if err != nil {
-		ctx.JSON(http.StatusNotFound, gin.H{
-			"error":   "Booking not found",
-			"details": err.Error(),
-		})
+		response.RespondError(ctx, http.StatusNotFound, response.CodeBookingNotFound, "Booking not found", err.Error())
 		return
 	}
 
@@ -104,33 +143,46 @@ func (c *Controller) GetBooking(ctx *gin.Context) {
 	role, _ := roleInterface.(string)
 
 	if role != "ADMIN" && booking.UserID != userID {
-		ctx.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+		response.RespondJSON(ctx, "error", http.StatusForbidden, "Access denied", nil, nil)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{
-		"message": "Booking retrieved successfully",
-		"data":    booking,
-	})
+	response.RespondJSON(ctx, "success", http.StatusOK, "Booking retrieved successfully", booking, nil)
 }
 
+// GetUserBookings godoc
+//
+// @Summary      Get user bookings
+// @Description  Get all bookings for the authenticated user
+// @Tags         Bookings
+// @Produce      json
+// @Security     Bearer
+// @Param        limit query integer false "Limit" default(10) minimum(1) maximum(100)
+// @Param        offset query integer false "Offset" default(0) minimum(0)
+// @Param        status query string false "Status" Enums(CONFIRMED, CONFIRMING, PENDING, PENDING_PAYMENT, CANCELLED, REFUNDED)
+// @Success      200 {object} response.StandardApiResponse{data=object} "User bookings retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/users/bookings [get]
 func (c *Controller) GetUserBookings(ctx *gin.Context) {
 
 	userIDInterface, exists := ctx.Get("user_id")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		response.RespondJSON(ctx, "error", http.StatusUnauthorized, "User not authenticated", nil, nil)
 		return
 	}
 
 	userIDStr, ok := userIDInterface.(string)
 	if !ok {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID format"})
+		response.RespondJSON(ctx, "error", http.StatusInternalServerError, "Invalid user ID format", nil, nil)
 		return
 	}
 
 	userID, err := uuid.Parse(userIDStr)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid user ID", nil, nil)
 		return
 	}
 
@@ -151,63 +203,256 @@ func (c *Controller) GetUserBookings(ctx *gin.Context) {
 	// Get user bookings
 	bookings, err := c.service.GetUserBookings(ctx.Request.Context(), userID, limit, offset)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to get user bookings",
-			"details": err.Error(),
-		})
+		response.RespondJSON(ctx, "error", http.StatusInternalServerError, "Failed to get user bookings", nil, err.Error())
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{
-		"message": "Bookings retrieved successfully",
-		"data": gin.H{
-			"bookings": bookings,
-			"count":    len(bookings),
-			"limit":    limit,
-			"offset":   offset,
-		},
-	})
+	response.RespondJSON(ctx, "success", http.StatusOK, "Bookings retrieved successfully", gin.H{
+		"bookings": bookings,
+		"count":    len(bookings),
+		"limit":    limit,
+		"offset":   offset,
+	}, nil)
 }
 
+// CancelBooking godoc
+//
+// @Summary      Cancel booking
+// @Description  Cancel a confirmed booking
+// @Tags         Bookings
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse "Booking cancelled successfully"
+// @Failure      400 {object} response.StandardApiResponse "Booking cannot be cancelled"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/bookings/{id}/cancel [post]
 func (c *Controller) CancelBooking(ctx *gin.Context) {
 	// Parse booking ID from URL
 	bookingIDStr := ctx.Param("id")
 	bookingID, err := uuid.Parse(bookingIDStr)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid booking ID"})
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid booking ID", nil, nil)
 		return
 	}
 
 	// Get user ID from JWT context
 	userIDInterface, exists := ctx.Get("user_id")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		response.RespondJSON(ctx, "error", http.StatusUnauthorized, "User not authenticated", nil, nil)
 		return
 	}
 
 	userIDStr, ok := userIDInterface.(string)
 	if !ok {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID format"})
+		response.RespondJSON(ctx, "error", http.StatusInternalServerError, "Invalid user ID format", nil, nil)
 		return
 	}
 
 	userID, err := uuid.Parse(userIDStr)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid user ID", nil, nil)
 		return
 	}
 
 	// Cancel booking
 	err = c.service.CancelBooking(ctx.Request.Context(), bookingID, userID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Failed to cancel booking",
-			"details": err.Error(),
-		})
+		response.RespondError(ctx, http.StatusBadRequest, bookingErrorCode(err, http.StatusBadRequest), "Failed to cancel booking", err.Error())
+		return
+	}
+
+	response.RespondJSON(ctx, "success", http.StatusOK, "Booking cancelled successfully", nil, nil)
+}
+
+// GetBookingConflicts lets clients warn about overlapping plans before the user holds seats
+//
+// @Summary      Check booking conflicts
+// @Description  List the user's bookings for other events that overlap the given event, so clients can
+// @Description  warn before seats are held. Always empty when the conflict check is turned off.
+// @Tags         Bookings
+// @Produce      json
+// @Security     Bearer
+// @Param        event_id query string true "Event ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=object} "Conflicts retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse "Missing or invalid event_id"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/users/bookings/conflicts [get]
+func (c *Controller) GetBookingConflicts(ctx *gin.Context) {
+	userIDInterface, exists := ctx.Get("user_id")
+	if !exists {
+		response.RespondJSON(ctx, "error", http.StatusUnauthorized, "User not authenticated", nil, nil)
+		return
+	}
+
+	userID, err := uuid.Parse(userIDInterface.(string))
+	if err != nil {
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid user ID", nil, nil)
+		return
+	}
+
+	eventID, err := uuid.Parse(ctx.Query("event_id"))
+	if err != nil {
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "A valid event_id query parameter is required", nil, nil)
+		return
+	}
+
+	conflicts, err := c.service.GetBookingConflicts(ctx.Request.Context(), userID, eventID)
+	if err != nil {
+		response.RespondJSON(ctx, "error", http.StatusInternalServerError, "Failed to check booking conflicts", nil, err.Error())
+		return
+	}
+
+	response.RespondJSON(ctx, "success", http.StatusOK, "Booking conflicts checked successfully", gin.H{
+		"has_conflicts": len(conflicts) > 0,
+		"conflicts":     conflicts,
+	}, nil)
+}
+
+// CheckInBooking marks a booking as admitted at the venue door (admin only)
+//
+// @Summary      Check in booking (Admin)
+// @Description  Mark a confirmed booking as admitted at the venue door. Checked-in attendees can review the event afterwards.
+// @Description
+// @Description  Requires the `bookings:manage` permission.
+// @Tags         Admin Bookings
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=Booking} "Booking checked in successfully"
+// @Failure      400 {object} response.StandardApiResponse "Booking is not confirmed"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Booking not found"
+// @Failure      409 {object} response.StandardApiResponse "Booking is already checked in"
+// @Router       /api/v1/admin/bookings/{id}/check-in [post]
+func (c *Controller) CheckInBooking(ctx *gin.Context) {
+	bookingID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid booking ID", nil, nil)
+		return
+	}
+
+	booking, err := c.service.CheckInBooking(ctx.Request.Context(), bookingID)
+	if err != nil {
+		statusCode := http.StatusBadRequest
+		switch {
+		case strings.Contains(err.Error(), "booking not found"):
+			statusCode = http.StatusNotFound
+		case strings.Contains(err.Error(), "already checked in"),
+			strings.Contains(err.Error(), "modified by another process"):
+			statusCode = http.StatusConflict
+		}
+		response.RespondError(ctx, statusCode, bookingErrorCode(err, statusCode), "Failed to check in booking", err.Error())
+		return
+	}
+
+	response.RespondJSON(ctx, "success", http.StatusOK, "Booking checked in successfully", booking, nil)
+}
+
+// ResumePayment godoc
+//
+// @Summary      Resume payment
+// @Description  Retry the failed payment of a PENDING_PAYMENT booking right away
+// @Tags         Bookings
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "ID" Format(uuid)
+// @Param        request body ResumePaymentRequest false "Request body"
+// @Success      200 {object} response.StandardApiResponse{data=PaymentInfo} "Payment completed, booking confirmed"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      402 {object} response.StandardApiResponse "Payment failed again - PAYMENT_DECLINED"
+// @Failure      403 {object} response.StandardApiResponse "Booking belongs to another user"
+// @Failure      404 {object} response.StandardApiResponse "Booking not found"
+// @Failure      409 {object} response.StandardApiResponse "Booking is not awaiting payment"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/bookings/{id}/resume-payment [post]
+func (c *Controller) ResumePayment(ctx *gin.Context) {
+	// Parse booking ID from URL
+	bookingIDStr := ctx.Param("id")
+	bookingID, err := uuid.Parse(bookingIDStr)
+	if err != nil {
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid booking ID", nil, nil)
+		return
+	}
+
+	// Get user ID from JWT context
+	userIDInterface, exists := ctx.Get("user_id")
+	if !exists {
+		response.RespondJSON(ctx, "error", http.StatusUnauthorized, "User not authenticated", nil, nil)
+		return
+	}
+
+	userIDStr, ok := userIDInterface.(string)
+	if !ok {
+		response.RespondJSON(ctx, "error", http.StatusInternalServerError, "Invalid user ID format", nil, nil)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{
-		"message": "Booking cancelled successfully",
-	})
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid user ID", nil, nil)
+		return
+	}
+
+	// Body is optional, an empty payment method keeps the original one
+	var req ResumePaymentRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid request body", nil, err.Error())
+			return
+		}
+	}
+
+	payment, err := c.service.ResumePayment(ctx.Request.Context(), bookingID, userID, req)
+	if err != nil {
+		statusCode := http.StatusBadRequest
+		switch {
+		case strings.Contains(err.Error(), "booking not found"):
+			statusCode = http.StatusNotFound
+		case strings.HasPrefix(err.Error(), "unauthorized"):
+			statusCode = http.StatusForbidden
+		case err.Error() == "booking is not awaiting payment":
+			statusCode = http.StatusConflict
+		}
+		response.RespondError(ctx, statusCode, bookingErrorCode(err, statusCode), "Failed to resume payment", err.Error())
+		return
+	}
+
+	if payment.Status != "COMPLETED" {
+		response.RespondErrorWithData(ctx, http.StatusPaymentRequired, response.CodePaymentDeclined, "Payment failed", payment, nil)
+		return
+	}
+
+	response.RespondJSON(ctx, "success", http.StatusOK, "Payment completed, booking confirmed", payment, nil)
+}
+
+// bookingErrorCode picks the error code of a failed booking operation, falling
+// back to the generic code of its status
+func bookingErrorCode(err error, status int) string {
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "booking not found"):
+		return response.CodeBookingNotFound
+	case strings.Contains(msg, "hold is invalid or expired"), strings.Contains(msg, "hold not found or expired"):
+		return response.CodeHoldNotFound
+	case strings.Contains(msg, "already checked in"):
+		return response.CodeAlreadyCheckedIn
+	case strings.Contains(msg, "modified by another process"):
+		return response.CodeBookingModified
+	case msg == "booking is not awaiting payment":
+		return response.CodeNotAwaitingPayment
+	case strings.Contains(msg, "already cancelled"):
+		return response.CodeBookingAlreadyCancelled
+	case strings.HasPrefix(msg, "unauthorized"):
+		return response.CodeForbidden
+	default:
+		return response.StatusErrorCode(status)
+	}
 }
@@ -0,0 +1,81 @@
+package bookings
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"evently/pkg/currency"
+
+	"github.com/google/uuid"
+)
+
+// DisplayPrice is a booking total converted to the user's display currency
+type DisplayPrice struct {
+	Currency string  `json:"currency"`
+	Amount   float64 `json:"amount"`
+	Rate     float64 `json:"rate"` // Units of Currency per unit of the booking currency
+}
+
+// SetCurrencyProvider sets the exchange rates bookings are converted with
+func (s *service) SetCurrencyProvider(provider currency.Provider) {
+	s.currencies = provider
+}
+
+// bookingCurrency returns the event's currency and the rate converting it to the
+// base currency. The rate is stored on the booking so revenue never moves with
+// later rate changes.
+func (s *service) bookingCurrency(ctx context.Context, eventID uuid.UUID) (string, float64, error) {
+	code, err := s.repo.GetEventCurrency(ctx, eventID)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to get event currency: %w", err)
+	}
+	if s.currencies == nil {
+		return code, 1, nil
+	}
+
+	rate, err := s.currencies.Rate(ctx, code, s.currencies.Base())
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to get exchange rate: %w", err)
+	}
+	return code, rate, nil
+}
+
+// displayCurrency returns the user's display currency, empty when they have none
+func (s *service) displayCurrency(ctx context.Context, userID uuid.UUID) string {
+	if s.currencies == nil {
+		return ""
+	}
+	code, err := s.repo.GetUserDisplayCurrency(ctx, userID)
+	if err != nil {
+		log.Printf("Warning: failed to get display currency for user %s: %v", userID, err)
+		return ""
+	}
+	return code
+}
+
+// displayPrice converts an amount to the display currency. Nothing is returned
+// when the amount already is in that currency or the rate is unknown.
+func (s *service) displayPrice(ctx context.Context, amount float64, from, to string) *DisplayPrice {
+	if to == "" || to == from || s.currencies == nil {
+		return nil
+	}
+
+	rate, err := s.currencies.Rate(ctx, from, to)
+	if err != nil {
+		log.Printf("Warning: failed to convert %s to display currency %s: %v", from, to, err)
+		return nil
+	}
+	return &DisplayPrice{Currency: to, Amount: currency.Convert(amount, rate), Rate: rate}
+}
+
+// setDisplayPrices fills in the display price of a user's bookings
+func (s *service) setDisplayPrices(ctx context.Context, userID uuid.UUID, bookings []Booking) {
+	display := s.displayCurrency(ctx, userID)
+	if display == "" {
+		return
+	}
+	for i := range bookings {
+		bookings[i].DisplayPrice = s.displayPrice(ctx, bookings[i].TotalPrice, bookings[i].Currency, display)
+	}
+}
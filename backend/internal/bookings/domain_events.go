@@ -0,0 +1,33 @@
+package bookings
+
+import (
+	"fmt"
+
+	"evently/internal/domainevents"
+	"evently/internal/outbox"
+
+	"gorm.io/gorm"
+)
+
+// enqueueBookingDomainEvent records BookingConfirmed or BookingCancelled in the
+// caller's transaction. The data matches the partner webhook payload.
+func enqueueBookingDomainEvent(tx *gorm.DB, booking *Booking, eventType string) error {
+	data := BookingWebhookData{
+		BookingID:   booking.ID,
+		BookingRef:  booking.BookingRef,
+		EventID:     booking.EventID,
+		UserID:      booking.UserID,
+		Status:      booking.Status,
+		TotalSeats:  booking.TotalSeats,
+		TotalPrice:  booking.TotalPrice,
+		Currency:    booking.Currency,
+		CreatedAt:   booking.CreatedAt,
+		CancelledAt: booking.CancelledAt,
+	}
+
+	event, err := domainevents.NewEvent(eventType, booking.ID, fmt.Sprintf("booking:%s:%s", booking.ID, eventType), data)
+	if err != nil {
+		return err
+	}
+	return outbox.Enqueue(tx, event)
+}
@@ -3,13 +3,16 @@ package bookings
 type Status string
 
 const (
-	StatusConfirmed Status = "CONFIRMED"
-	StatusCancelled Status = "CANCELLED"
+	StatusConfirmed      Status = "CONFIRMED"
+	StatusConfirming     Status = "CONFIRMING"      // Payment authorized, being captured
+	StatusPending        Status = "PENDING"         // Awaiting payment, seats stay reserved
+	StatusPendingPayment Status = "PENDING_PAYMENT" // Payment declined, retried until payment_due_at
+	StatusCancelled      Status = "CANCELLED"
 )
 
 func (s Status) IsValid() bool {
 	switch s {
-	case StatusConfirmed, StatusCancelled:
+	case StatusConfirmed, StatusConfirming, StatusPending, StatusPendingPayment, StatusCancelled:
 		return true
 	}
 	return false
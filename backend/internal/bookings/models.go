@@ -13,16 +13,31 @@ type Booking struct {
 	EventID     uuid.UUID  `gorm:"type:uuid;index;not null" json:"event_id"`
 	TotalSeats  int        `gorm:"not null" json:"total_seats"`
 	TotalPrice  float64    `gorm:"not null" json:"total_price"`
-	Status      string     `gorm:"type:varchar(20);check:status IN ('CONFIRMED', 'CANCELLED');default:'CONFIRMED';index" json:"status"`
+	Status      string     `gorm:"type:varchar(20);check:status IN ('CONFIRMED', 'CONFIRMING', 'PENDING', 'PENDING_PAYMENT', 'CANCELLED');default:'CONFIRMED';index" json:"status"`
 	BookingRef  string     `gorm:"unique;not null" json:"booking_ref"`
 	Version     int        `gorm:"not null;default:1" json:"version"`
 	CreatedAt   time.Time  `json:"created_at"`
 	UpdatedAt   time.Time  `json:"updated_at"`
 	CancelledAt *time.Time `json:"cancelled_at,omitempty"`
+	CheckedInAt *time.Time `json:"checked_in_at,omitempty"` // Set when the ticket is scanned at the door
+	// Deadline of a PENDING_PAYMENT booking's payment, it is cancelled and its seats freed after this
+	PaymentDueAt *time.Time `gorm:"index:idx_bookings_payment_due_at,where:status = 'PENDING_PAYMENT'" json:"payment_due_at,omitempty"`
+
+	// Prices are in the event's currency, converted to the base currency at booking time
+	Currency       string  `gorm:"type:varchar(3);not null;default:'INR'" json:"currency"`
+	ExchangeRate   float64 `gorm:"not null;default:1" json:"exchange_rate"` // Base currency units per unit of Currency
+	BaseTotalPrice float64 `gorm:"not null;default:0" json:"base_total_price"`
 
 	// Relationships
-	SeatBookings []SeatBooking `json:"seat_bookings,omitempty" gorm:"foreignKey:BookingID;constraint:OnDelete:CASCADE;"`
-	Payments     []Payment     `json:"payments,omitempty" gorm:"foreignKey:BookingID;constraint:OnDelete:RESTRICT;"`
+	SeatBookings   []SeatBooking   `json:"seat_bookings,omitempty" gorm:"foreignKey:BookingID;constraint:OnDelete:CASCADE;"`
+	TicketBookings []TicketBooking `json:"ticket_bookings,omitempty" gorm:"foreignKey:BookingID;constraint:OnDelete:CASCADE;"`
+	Payments       []Payment       `json:"payments,omitempty" gorm:"foreignKey:BookingID;constraint:OnDelete:RESTRICT;"`
+	Charges        []BookingCharge `json:"charges,omitempty" gorm:"foreignKey:BookingID;constraint:OnDelete:CASCADE;"`
+
+	// Summary of the charges, filled in when the booking is read
+	PriceBreakdown *PriceBreakdown `json:"price_breakdown,omitempty" gorm:"-"`
+	// Total in the user's display currency, at the current rate
+	DisplayPrice *DisplayPrice `json:"display_price,omitempty" gorm:"-"`
 }
 
 // SeatBooking schema
@@ -35,22 +50,61 @@ type SeatBooking struct {
 	SeatPrice float64   `gorm:"not null" json:"seat_price"`
 	CreatedAt time.Time `json:"created_at"`
 
+	// Pricing snapshot taken at booking time, so refunds and invoices never re-derive
+	// prices from the event's current pricing. Fee and tax are this seat's share of
+	// the booking's charges. Zero on seats booked before snapshots were recorded.
+	SectionName     string  `gorm:"type:varchar(100);not null;default:''" json:"section_name"`
+	BasePrice       float64 `gorm:"not null;default:0" json:"base_price"`       // Event base price
+	PriceMultiplier float64 `gorm:"not null;default:1" json:"price_multiplier"` // Section multiplier, SeatPrice = BasePrice × multiplier
+	ServiceFee      float64 `gorm:"not null;default:0" json:"service_fee"`
+	Tax             float64 `gorm:"not null;default:0" json:"tax"`
+	TotalPrice      float64 `gorm:"not null;default:0" json:"total_price"`    // Seat price plus fee and tax
+	NonRefundable   float64 `gorm:"not null;default:0" json:"non_refundable"` // Kept on cancellation whatever the policy
+
 	// Relationships
 	Booking *Booking `json:"booking,omitempty" gorm:"foreignKey:BookingID;constraint:OnDelete:CASCADE;"`
 	Seat    *Seat    `json:"seat,omitempty" gorm:"foreignKey:SeatID;constraint:OnDelete:RESTRICT;"`
 }
 
+// TicketBooking schema, general admission tickets booked by quantity. Unlike
+// seat bookings the rows are kept on cancellation, sold counts skip cancelled bookings.
+type TicketBooking struct {
+	ID           uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	BookingID    uuid.UUID  `gorm:"type:uuid;index;not null" json:"booking_id"`
+	EventID      uuid.UUID  `gorm:"type:uuid;index;not null" json:"event_id"`
+	TicketTypeID uuid.UUID  `gorm:"type:uuid;index;not null" json:"ticket_type_id"`
+	SectionID    *uuid.UUID `gorm:"type:uuid" json:"section_id,omitempty"`
+	Quantity     int        `gorm:"not null" json:"quantity"`
+	UnitPrice    float64    `gorm:"not null" json:"unit_price"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// BookingCharge schema, one line of the price the customer paid. The charges
+// of a booking add up to its total price.
+type BookingCharge struct {
+	ID         uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	BookingID  uuid.UUID `gorm:"type:uuid;index;not null" json:"booking_id"`
+	Type       string    `gorm:"type:varchar(20);check:type IN ('BASE', 'SECTION', 'SERVICE_FEE', 'TAX');not null" json:"type"`
+	Name       string    `gorm:"type:varchar(100);not null" json:"name"`
+	Rate       float64   `gorm:"not null;default:0" json:"rate,omitempty"` // Percent, for fees and taxes
+	Amount     float64   `gorm:"not null" json:"amount"`
+	Refundable bool      `gorm:"not null;default:true" json:"refundable"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
 // Payment schema
 type Payment struct {
 	ID            uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
 	BookingID     uuid.UUID  `gorm:"type:uuid;index;not null" json:"booking_id"`
 	Amount        float64    `gorm:"not null" json:"amount"`
 	Currency      string     `gorm:"type:varchar(3);default:'INR'" json:"currency"`
-	Status        string     `gorm:"type:varchar(20);check:status IN ('PENDING', 'COMPLETED', 'FAILED', 'REFUNDED');default:'PENDING'" json:"status"`
+	Status        string     `gorm:"type:varchar(20);check:status IN ('PENDING', 'AUTHORIZED', 'COMPLETED', 'FAILED', 'REFUNDED', 'VOIDED');default:'PENDING'" json:"status"`
 	PaymentMethod string     `gorm:"type:varchar(50)" json:"payment_method"`
 	TransactionID string     `gorm:"unique" json:"transaction_id"`
 	ProcessedAt   *time.Time `json:"processed_at,omitempty"`
 	FailureReason string     `json:"failure_reason,omitempty"`
+	Attempts      int        `gorm:"not null;default:0" json:"attempts"`
+	NextRetryAt   *time.Time `gorm:"index" json:"next_retry_at,omitempty"` // Set while a failed payment is in dunning
 	CreatedAt     time.Time  `json:"created_at"`
 	UpdatedAt     time.Time  `json:"updated_at"`
 
@@ -58,6 +112,63 @@ type Payment struct {
 	Booking *Booking `json:"booking,omitempty" gorm:"foreignKey:BookingID;constraint:OnDelete:CASCADE;"`
 }
 
+// BookingSaga persists the progress of a booking confirmation so a crashed
+// confirmation can be finished or compensated by the recovery job
+type BookingSaga struct {
+	ID               uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	BookingID        uuid.UUID  `gorm:"type:uuid;uniqueIndex;not null" json:"booking_id"`
+	UserID           uuid.UUID  `gorm:"type:uuid;not null" json:"user_id"`
+	EventID          uuid.UUID  `gorm:"type:uuid;not null" json:"event_id"`
+	HoldID           string     `gorm:"type:varchar(100);not null" json:"hold_id"`
+	TransactionID    string     `gorm:"type:varchar(100)" json:"transaction_id"`         // Lets recovery void an authorization whose booking was never created
+	ConvertsWaitlist bool       `gorm:"not null;default:false" json:"converts_waitlist"` // User booked from a waitlist notification
+	Status           string     `gorm:"type:varchar(20);not null;index:idx_booking_sagas_status_updated" json:"status"`
+	CurrentStep      string     `gorm:"type:varchar(30)" json:"current_step"` // Step being run or compensated
+	CompletedSteps   int        `gorm:"not null;default:0" json:"completed_steps"`
+	LastError        string     `gorm:"type:text" json:"last_error,omitempty"`
+	RecoveryAttempts int        `gorm:"not null;default:0" json:"recovery_attempts"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `gorm:"index:idx_booking_sagas_status_updated" json:"updated_at"`
+	FinishedAt       *time.Time `json:"finished_at,omitempty"`
+}
+
+// BookingTransfer is an offer to hand a booking, or some of its seats, to
+// another registered user. Only one offer per booking can be pending.
+type BookingTransfer struct {
+	ID              uuid.UUID   `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	BookingID       uuid.UUID   `gorm:"type:uuid;not null;index;uniqueIndex:idx_booking_transfers_one_pending,where:status = 'PENDING'" json:"booking_id"`
+	EventID         uuid.UUID   `gorm:"type:uuid;not null" json:"event_id"`
+	FromUserID      uuid.UUID   `gorm:"type:uuid;not null;index" json:"from_user_id"`
+	ToUserID        uuid.UUID   `gorm:"type:uuid;not null;index" json:"to_user_id"`
+	RecipientEmail  string      `gorm:"type:varchar(255);not null" json:"recipient_email"`
+	SeatIDs         []uuid.UUID `gorm:"type:jsonb;serializer:json;not null" json:"seat_ids"` // Empty transfers the whole booking
+	Message         string      `gorm:"type:varchar(500)" json:"message,omitempty"`
+	Status          string      `gorm:"type:varchar(20);check:status IN ('PENDING', 'ACCEPTED', 'DECLINED', 'CANCELLED', 'EXPIRED');not null;default:'PENDING';index:idx_booking_transfers_status_expires" json:"status"`
+	ExpiresAt       time.Time   `gorm:"not null;index:idx_booking_transfers_status_expires" json:"expires_at"`
+	RespondedAt     *time.Time  `json:"responded_at,omitempty"`
+	ResultBookingID *uuid.UUID  `gorm:"type:uuid" json:"result_booking_id,omitempty"` // Booking the recipient holds once accepted
+	CreatedAt       time.Time   `json:"created_at"`
+	UpdatedAt       time.Time   `json:"updated_at"`
+}
+
+// BookingOwnershipChange is the audit trail of bookings changing hands. When
+// seats are split off into a new booking, BookingID is the new booking and
+// SourceBookingID the one they came from; otherwise both are the same.
+type BookingOwnershipChange struct {
+	ID              uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	BookingID       uuid.UUID  `gorm:"type:uuid;not null;index" json:"booking_id"`
+	SourceBookingID uuid.UUID  `gorm:"type:uuid;not null;index" json:"source_booking_id"`
+	TransferID      *uuid.UUID `gorm:"type:uuid" json:"transfer_id,omitempty"`
+	FromUserID      uuid.UUID  `gorm:"type:uuid;not null" json:"from_user_id"`
+	ToUserID        uuid.UUID  `gorm:"type:uuid;not null" json:"to_user_id"`
+	Reason          string     `gorm:"type:varchar(20);not null" json:"reason"`
+	SeatCount       int        `gorm:"not null" json:"seat_count"`
+	PreviousRef     string     `gorm:"type:varchar(50);not null" json:"previous_ref"` // Reference on the tickets the sender held, no longer valid
+	NewRef          string     `gorm:"type:varchar(50);not null" json:"new_ref"`
+	RemainingRef    string     `gorm:"type:varchar(50)" json:"remaining_ref,omitempty"` // Reference the sender's remaining seats were reissued under on a split
+	ChangedAt       time.Time  `gorm:"not null" json:"changed_at"`
+}
+
 // Forward declarations
 type Seat struct {
 	ID         uuid.UUID `json:"id"`
@@ -76,14 +187,46 @@ func (SeatBooking) TableName() string {
 	return "seat_bookings"
 }
 
+func (TicketBooking) TableName() string {
+	return "ticket_bookings"
+}
+
+func (BookingCharge) TableName() string {
+	return "booking_charges"
+}
+
 func (Payment) TableName() string {
 	return "payments"
 }
 
+func (BookingSaga) TableName() string {
+	return "booking_sagas"
+}
+
+func (BookingTransfer) TableName() string {
+	return "booking_transfers"
+}
+
+func (BookingOwnershipChange) TableName() string {
+	return "booking_ownership_changes"
+}
+
 func (b *Booking) IsConfirmed() bool {
 	return b.Status == "CONFIRMED"
 }
 
+func (b *Booking) IsPending() bool {
+	return b.Status == "PENDING"
+}
+
+func (b *Booking) IsPendingPayment() bool {
+	return b.Status == "PENDING_PAYMENT"
+}
+
+func (b *Booking) IsConfirming() bool {
+	return b.Status == "CONFIRMING"
+}
+
 func (b *Booking) IsCancelled() bool {
 	return b.Status == "CANCELLED"
 }
@@ -95,11 +238,31 @@ func (b *Booking) Cancel() {
 	b.UpdatedAt = now
 }
 
+// FreedSeats lists the seats and tickets the booking returns to sale when
+// cancelled. Needs SeatBookings and TicketBookings loaded.
+func (b *Booking) FreedSeats() []FreedSeat {
+	var freed []FreedSeat
+	for _, sb := range b.SeatBookings {
+		seatID, sectionID := sb.SeatID, sb.SectionID
+		freed = append(freed, FreedSeat{SeatID: &seatID, SectionID: &sectionID, Price: sb.SeatPrice})
+	}
+	for _, tb := range b.TicketBookings {
+		for i := 0; i < tb.Quantity; i++ {
+			freed = append(freed, FreedSeat{SectionID: tb.SectionID, Price: tb.UnitPrice})
+		}
+	}
+	return freed
+}
+
 // Helper methods for payment management
 func (p *Payment) IsPending() bool {
 	return p.Status == "PENDING"
 }
 
+func (p *Payment) IsAuthorized() bool {
+	return p.Status == "AUTHORIZED"
+}
+
 func (p *Payment) IsCompleted() bool {
 	return p.Status == "COMPLETED"
 }
@@ -112,6 +275,17 @@ func (p *Payment) IsRefunded() bool {
 	return p.Status == "REFUNDED"
 }
 
+func (p *Payment) IsVoided() bool {
+	return p.Status == "VOIDED"
+}
+
+// MarkAuthorized records funds reserved by the gateway, captured later
+func (p *Payment) MarkAuthorized(transactionID string) {
+	p.Status = "AUTHORIZED"
+	p.TransactionID = transactionID
+	p.UpdatedAt = time.Now()
+}
+
 func (p *Payment) MarkCompleted(transactionID string) {
 	p.Status = "COMPLETED"
 	p.TransactionID = transactionID
@@ -128,6 +302,18 @@ func (p *Payment) MarkFailed(reason string) {
 	p.UpdatedAt = now
 }
 
+func (p *Payment) MarkRefunded() {
+	p.Status = "REFUNDED"
+	p.NextRetryAt = nil
+	p.UpdatedAt = time.Now()
+}
+
+// MarkVoided records an authorization released without being captured
+func (p *Payment) MarkVoided() {
+	p.Status = "VOIDED"
+	p.UpdatedAt = time.Now()
+}
+
 func (p *Payment) ToPaymentInfo() PaymentInfo {
 	return PaymentInfo{
 		ID:            p.ID.String(),
@@ -137,5 +323,8 @@ func (p *Payment) ToPaymentInfo() PaymentInfo {
 		PaymentMethod: p.PaymentMethod,
 		TransactionID: p.TransactionID,
 		ProcessedAt:   p.ProcessedAt,
+		FailureReason: p.FailureReason,
+		Attempts:      p.Attempts,
+		NextRetryAt:   p.NextRetryAt,
 	}
 }
@@ -0,0 +1,250 @@
+package bookings
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// OwnershipChangeResale is the reason recorded for ownership moved by a resale purchase
+const OwnershipChangeResale = "RESALE"
+
+// OwnershipMove is everything that moves a booking, or seats split off it, to
+// another user. Split is nil when the whole booking changes hands; otherwise
+// it is the new owner's booking and Source holds the previous owner's reduced
+// booking. Moves are built by PrepareOwnershipMove and written by
+// ApplyOwnershipMove, inside the caller's transaction.
+type OwnershipMove struct {
+	Source *Booking
+	Split  *Booking
+	Change *BookingOwnershipChange
+}
+
+// ResultBookingID is the booking the new owner holds once the move is applied
+func (m *OwnershipMove) ResultBookingID() uuid.UUID {
+	if m.Split != nil {
+		return m.Split.ID
+	}
+	return m.Source.ID
+}
+
+// TransferableSeats describes seats of a booking that its owner may hand over
+type TransferableSeats struct {
+	Booking    *Booking
+	SeatIDs    []uuid.UUID // Nil when the whole booking is handed over
+	SeatCount  int
+	FaceValue  float64 // What the owner paid for the seats, in the booking currency
+	EventStart time.Time
+}
+
+// CheckTransferableSeats verifies the owner can hand over the booking, or the
+// picked seats of it, and works out what they paid for them
+func (s *service) CheckTransferableSeats(ctx context.Context, bookingID, ownerID uuid.UUID, seatIDs []string) (*TransferableSeats, error) {
+	booking, err := s.repo.GetByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	event, err := s.checkTransferable(ctx, booking, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	picked, err := transferSeats(booking, seatIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &TransferableSeats{
+		Booking:    booking,
+		SeatIDs:    picked,
+		SeatCount:  booking.TotalSeats,
+		FaceValue:  booking.TotalPrice,
+		EventStart: event.DateTime,
+	}
+	if len(picked) > 0 {
+		moved, share, movedSeatPrice := seatShare(booking, picked)
+		if len(moved) != len(picked) {
+			return nil, fmt.Errorf("%w: seats are no longer part of this booking", ErrBookingNotTransferable)
+		}
+		result.SeatCount = len(moved)
+		result.FaceValue = splitPrice(booking.Charges, share, movedSeatPrice)
+	}
+	return result, nil
+}
+
+// PrepareOwnershipMove builds the move of a booking, or of the given seats of
+// it, from one user to another. The booking is checked again, and whatever
+// changes hands is reissued under a new reference so tickets the previous
+// owner kept no longer get anyone in.
+func (s *service) PrepareOwnershipMove(ctx context.Context, bookingID, fromUserID, toUserID uuid.UUID, seatIDs []uuid.UUID, reason string) (*OwnershipMove, error) {
+	booking, err := s.repo.GetByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.checkTransferable(ctx, booking, fromUserID); err != nil {
+		return nil, err
+	}
+
+	newRef, err := s.generateBookingReference()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate booking reference: %w", err)
+	}
+
+	move := &OwnershipMove{Source: booking}
+	change := &BookingOwnershipChange{
+		ID:              uuid.New(),
+		BookingID:       booking.ID,
+		SourceBookingID: booking.ID,
+		FromUserID:      fromUserID,
+		ToUserID:        toUserID,
+		Reason:          reason,
+		SeatCount:       booking.TotalSeats,
+		PreviousRef:     booking.BookingRef,
+		NewRef:          newRef,
+		ChangedAt:       time.Now(),
+	}
+
+	if len(seatIDs) > 0 {
+		split, err := splitBooking(booking, seatIDs, toUserID, newRef)
+		if err != nil {
+			return nil, err
+		}
+
+		// The previous owner's tickets list the seats that left, so they are reissued too
+		remainingRef, err := s.generateBookingReference()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate booking reference: %w", err)
+		}
+		booking.BookingRef = remainingRef
+
+		move.Split = split
+		change.BookingID = split.ID
+		change.SeatCount = split.TotalSeats
+		change.RemainingRef = remainingRef
+	}
+	move.Change = change
+
+	return move, nil
+}
+
+// ApplyOwnershipMove writes a prepared move in tx. The source booking must
+// still be the version the move was prepared from and still belong to the
+// previous owner, confirmed and not checked in.
+func ApplyOwnershipMove(tx *gorm.DB, move *OwnershipMove) error {
+	source, split, change := move.Source, move.Split, move.Change
+	now := time.Now()
+
+	updates := map[string]interface{}{
+		"booking_ref": source.BookingRef,
+		"updated_at":  now,
+		"version":     gorm.Expr("version + 1"),
+	}
+	if split == nil {
+		updates["user_id"] = change.ToUserID
+		updates["booking_ref"] = change.NewRef
+	} else {
+		updates["total_seats"] = source.TotalSeats
+		updates["total_price"] = source.TotalPrice
+		updates["base_total_price"] = source.BaseTotalPrice
+	}
+
+	result := tx.Model(&Booking{}).
+		Where("id = ? AND version = ? AND user_id = ? AND status = 'CONFIRMED' AND checked_in_at IS NULL",
+			source.ID, source.Version, change.FromUserID).
+		Updates(updates)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update booking: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrBookingModified
+	}
+
+	if split != nil {
+		seatIDs := make([]uuid.UUID, len(split.SeatBookings))
+		for i, sb := range split.SeatBookings {
+			seatIDs[i] = sb.SeatID
+		}
+		charges := split.Charges
+		split.SeatBookings = nil
+		split.Charges = nil
+
+		if err := tx.Create(split).Error; err != nil {
+			return fmt.Errorf("failed to create booking: %w", err)
+		}
+
+		result = tx.Model(&SeatBooking{}).
+			Where("booking_id = ? AND seat_id IN ?", source.ID, seatIDs).
+			Update("booking_id", split.ID)
+		if result.Error != nil {
+			return fmt.Errorf("failed to move seat bookings: %w", result.Error)
+		}
+		if int(result.RowsAffected) != len(seatIDs) {
+			return fmt.Errorf("seats are no longer part of this booking")
+		}
+
+		for _, charge := range source.Charges {
+			if err := tx.Model(&BookingCharge{}).Where("id = ?", charge.ID).Update("amount", charge.Amount).Error; err != nil {
+				return fmt.Errorf("failed to update booking charges: %w", err)
+			}
+		}
+		if len(charges) > 0 {
+			for i := range charges {
+				charges[i].BookingID = split.ID
+			}
+			if err := tx.Create(&charges).Error; err != nil {
+				return fmt.Errorf("failed to create booking charges: %w", err)
+			}
+		}
+	}
+
+	if err := tx.Create(change).Error; err != nil {
+		return fmt.Errorf("failed to record ownership change: %w", err)
+	}
+	return nil
+}
+
+// seatShare picks the given seats out of a booking and returns their share of
+// its value, by the price each seat sold at
+func seatShare(booking *Booking, seatIDs []uuid.UUID) ([]SeatBooking, float64, float64) {
+	picked := make(map[uuid.UUID]bool, len(seatIDs))
+	for _, seatID := range seatIDs {
+		picked[seatID] = true
+	}
+
+	var moved []SeatBooking
+	var movedValue, totalValue, movedSeatPrice float64
+	for _, sb := range booking.SeatBookings {
+		value := sb.TotalPrice
+		if value == 0 {
+			value = sb.SeatPrice // Booked before pricing snapshots
+		}
+		totalValue += value
+		if picked[sb.SeatID] {
+			moved = append(moved, sb)
+			movedValue += value
+			movedSeatPrice += sb.SeatPrice
+		}
+	}
+
+	share := 0.0
+	if totalValue > 0 {
+		share = movedValue / totalValue
+	}
+	return moved, share, movedSeatPrice
+}
+
+// splitPrice is the price of a share of a booking: its share of each charge,
+// or of the seat prices for bookings made before charges were itemized
+func splitPrice(charges []BookingCharge, share, movedSeatPrice float64) float64 {
+	if len(charges) == 0 {
+		return roundAmount(movedSeatPrice)
+	}
+	var total float64
+	for _, charge := range charges {
+		total += roundAmount(charge.Amount * share)
+	}
+	return roundAmount(total)
+}
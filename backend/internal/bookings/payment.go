@@ -0,0 +1,353 @@
+package bookings
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"evently/internal/outbox"
+	"evently/pkg/metrics"
+
+	"github.com/google/uuid"
+)
+
+// PaymentGateway moves a booking's payment and returns the gateway's
+// transaction reference. Confirmation authorizes while the hold is valid and
+// captures once the booking exists; dunning retries charge in one step. Void
+// releases an authorization or reverses a capture, and must succeed for
+// transactions the gateway never saw, since saga compensation calls it
+// whenever the payment outcome is unknown.
+type PaymentGateway interface {
+	Authorize(ctx context.Context, payment *Payment) (string, error)
+	Capture(ctx context.Context, payment *Payment) error
+	Charge(ctx context.Context, payment *Payment) (string, error)
+	Void(ctx context.Context, payment *Payment) error
+}
+
+// MockPaymentGateway accepts every payment
+type MockPaymentGateway struct{}
+
+func (MockPaymentGateway) Authorize(ctx context.Context, payment *Payment) (string, error) {
+	return payment.TransactionID, nil
+}
+
+func (MockPaymentGateway) Capture(ctx context.Context, payment *Payment) error {
+	return nil
+}
+
+func (MockPaymentGateway) Charge(ctx context.Context, payment *Payment) (string, error) {
+	return payment.TransactionID, nil
+}
+
+func (MockPaymentGateway) Void(ctx context.Context, payment *Payment) error {
+	return nil
+}
+
+// DunningConfig contains configuration for failed payment retries
+type DunningConfig struct {
+	CheckInterval    time.Duration
+	RetrySchedule    []time.Duration // Delay before each retry, the last entry repeats
+	MaxAttempts      int             // Total charge attempts including the first
+	PaymentWindow    time.Duration   // Time from the first decline until an unpaid booking is cancelled
+	BatchSize        int
+	Lease            time.Duration
+	ResumePaymentURL string // Link sent to users, {booking_id} is replaced
+}
+
+// DefaultDunningConfig returns default dunning configuration
+func DefaultDunningConfig() *DunningConfig {
+	return &DunningConfig{
+		CheckInterval:    time.Minute,                                  // Look for due retries every minute
+		RetrySchedule:    []time.Duration{10 * time.Minute, time.Hour}, // Retry after 10 minutes, then after an hour
+		MaxAttempts:      3,                                            // Cancel after the first charge and two retries fail
+		PaymentWindow:    2 * time.Hour,                                // Cancel bookings still unpaid after two hours
+		BatchSize:        50,                                           // Retry up to 50 payments per run
+		Lease:            5 * time.Minute,                              // Claimed retries are hidden from other instances for 5 minutes
+		ResumePaymentURL: "http://localhost:3000/bookings/{booking_id}/pay",
+	}
+}
+
+// retryDelay returns the delay before the retry that follows the given attempt
+func (c *DunningConfig) retryDelay(attempt int) time.Duration {
+	if len(c.RetrySchedule) == 0 {
+		return time.Hour
+	}
+	if attempt > len(c.RetrySchedule) {
+		attempt = len(c.RetrySchedule)
+	}
+	if attempt < 1 {
+		attempt = 1
+	}
+	return c.RetrySchedule[attempt-1]
+}
+
+// paymentDueAt returns the payment deadline of a booking that becomes
+// PENDING_PAYMENT now
+func (c *DunningConfig) paymentDueAt() *time.Time {
+	if c.PaymentWindow <= 0 {
+		return nil
+	}
+	due := time.Now().Add(c.PaymentWindow)
+	return &due
+}
+
+func (c *DunningConfig) resumeURL(bookingID uuid.UUID) string {
+	return strings.ReplaceAll(c.ResumePaymentURL, "{booking_id}", bookingID.String())
+}
+
+// chargePayment attempts a charge and moves the booking along the dunning flow:
+// success confirms it, failure schedules a retry, and the final failure cancels
+// it and hands the seats to the waitlist.
+func (s *service) chargePayment(ctx context.Context, booking *Booking, payment *Payment) error {
+	payment.Attempts++
+
+	transactionID, chargeErr := s.paymentGateway.Charge(ctx, payment)
+	if chargeErr != nil {
+		return s.declinePayment(ctx, booking, payment, chargeErr)
+	}
+
+	payment.MarkCompleted(transactionID)
+	return s.settlePayment(ctx, booking, payment)
+}
+
+// settlePayment confirms the booking of a completed payment
+func (s *service) settlePayment(ctx context.Context, booking *Booking, payment *Payment) error {
+	payment.FailureReason = ""
+	payment.NextRetryAt = nil
+
+	booking.Status = "CONFIRMED"
+	confirmation, err := s.buildConfirmationMessage(booking)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.SettlePayment(ctx, booking.ID, payment, confirmation); err != nil {
+		return err
+	}
+	metrics.OnSaleActivity.Inc(booking.EventID.String(), metrics.OnSaleBookingConfirmed)
+	return nil
+}
+
+// declinePayment records a declined attempt: it moves the booking to
+// PENDING_PAYMENT and schedules a retry, or cancels the booking and hands the
+// seats to the waitlist once attempts run out
+func (s *service) declinePayment(ctx context.Context, booking *Booking, payment *Payment, declineErr error) error {
+	metrics.OnSaleActivity.Inc(booking.EventID.String(), metrics.OnSalePaymentFailed)
+
+	payment.MarkFailed(declineErr.Error())
+
+	if payment.Attempts >= s.dunningConfig.MaxAttempts {
+		payment.NextRetryAt = nil
+		message, err := s.buildPaymentNotification(booking, payment, "BOOKING_PAYMENT_EXPIRED", "payment_expired")
+		if err != nil {
+			return err
+		}
+		if err := s.repo.CancelUnpaid(ctx, booking.ID, payment, message); err != nil {
+			return err
+		}
+		booking.Status = "CANCELLED"
+
+		log.Printf("❌ DUNNING: Cancelled booking %s after %d failed payment attempts", booking.ID, payment.Attempts)
+		s.returnTickets(ctx, booking)
+		s.notifyWaitlist(booking)
+		return nil
+	}
+
+	nextRetry := time.Now().Add(s.dunningConfig.retryDelay(payment.Attempts))
+	payment.NextRetryAt = &nextRetry
+
+	// The first decline starts the payment window, retries keep the deadline
+	if booking.PaymentDueAt == nil {
+		booking.PaymentDueAt = s.dunningConfig.paymentDueAt()
+	}
+	booking.Status = "PENDING_PAYMENT"
+
+	message, err := s.buildPaymentNotification(booking, payment, "PAYMENT_FAILED",
+		fmt.Sprintf("payment_failed:%d", payment.Attempts))
+	if err != nil {
+		return err
+	}
+	if err := s.repo.RecordPaymentFailure(ctx, booking, payment, message); err != nil {
+		return err
+	}
+
+	log.Printf("⚠️ DUNNING: Payment for booking %s failed (attempt %d/%d), retrying at %s: %v",
+		booking.ID, payment.Attempts, s.dunningConfig.MaxAttempts, nextRetry.Format(time.RFC3339), declineErr)
+	return nil
+}
+
+// RetryDuePayments retries failed payments whose retry time has passed
+func (s *service) RetryDuePayments(ctx context.Context) (int, error) {
+	payments, err := s.repo.ClaimDuePaymentRetries(ctx, s.dunningConfig.BatchSize, s.dunningConfig.Lease)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range payments {
+		payment := &payments[i]
+		booking, err := s.repo.GetByID(ctx, payment.BookingID)
+		if err != nil {
+			log.Printf("❌ DUNNING: Failed to load booking %s: %v", payment.BookingID, err)
+			continue
+		}
+		if err := s.chargePayment(ctx, booking, payment); err != nil {
+			log.Printf("❌ DUNNING: Retry for booking %s failed: %v", booking.ID, err)
+		}
+	}
+
+	return len(payments), nil
+}
+
+// ExpireUnpaidBookings cancels PENDING_PAYMENT bookings whose payment deadline
+// has passed, frees their seats for the waitlist and tells their users. A
+// retry claimed just before the deadline holds its lease, so deadlines are
+// only enforced once the lease is over.
+func (s *service) ExpireUnpaidBookings(ctx context.Context) (int, error) {
+	ids, err := s.repo.ClaimExpiredPendingBookings(ctx, time.Now().Add(-s.dunningConfig.Lease), s.dunningConfig.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for _, id := range ids {
+		booking, err := s.repo.GetByID(ctx, id)
+		if err != nil {
+			log.Printf("❌ DUNNING: Failed to load booking %s: %v", id, err)
+			continue
+		}
+		if err := s.expireUnpaid(ctx, booking); err != nil {
+			log.Printf("❌ DUNNING: Failed to expire booking %s: %v", id, err)
+			continue
+		}
+		expired++
+	}
+
+	return expired, nil
+}
+
+func (s *service) expireUnpaid(ctx context.Context, booking *Booking) error {
+	if len(booking.Payments) == 0 {
+		return fmt.Errorf("no payment record found for booking")
+	}
+	payment := &booking.Payments[0]
+	payment.NextRetryAt = nil
+
+	message, err := s.buildPaymentNotification(booking, payment, "BOOKING_PAYMENT_EXPIRED", "payment_expired")
+	if err != nil {
+		return err
+	}
+	if err := s.repo.CancelUnpaid(ctx, booking.ID, payment, message); err != nil {
+		return err
+	}
+	booking.Status = "CANCELLED"
+
+	log.Printf("⌛ DUNNING: Cancelled booking %s, payment was due at %s", booking.ID, booking.PaymentDueAt.Format(time.RFC3339))
+	s.returnTickets(ctx, booking)
+	s.notifyWaitlist(booking)
+	return nil
+}
+
+// notifyWaitlist offers the seats of a cancelled booking to the waitlist
+func (s *service) notifyWaitlist(booking *Booking) {
+	if s.waitlistService == nil {
+		return
+	}
+
+	go func() {
+		if err := s.waitlistService.ProcessCancellation(context.Background(), booking.EventID, booking.TotalSeats, booking.FreedSeats()); err != nil {
+			log.Printf("❌ DUNNING: Failed to notify waitlist for event %s: %v", booking.EventID, err)
+		}
+	}()
+}
+
+// buildPaymentNotification creates a dunning outbox message for a booking
+func (s *service) buildPaymentNotification(booking *Booking, payment *Payment, notificationType string, dedupSuffix string) (*outbox.Message, error) {
+	bookingID := booking.ID
+	eventID := booking.EventID
+
+	templateData := map[string]interface{}{
+		"booking_number": booking.BookingRef,
+		"total_amount":   payment.Amount,
+		"attempt":        payment.Attempts,
+		"max_attempts":   s.dunningConfig.MaxAttempts,
+		"failure_reason": payment.FailureReason,
+		"resume_url":     s.dunningConfig.resumeURL(booking.ID),
+	}
+	if payment.NextRetryAt != nil {
+		templateData["next_retry_at"] = payment.NextRetryAt.Format("Jan 2, 2006 3:04 PM MST")
+	}
+	if booking.PaymentDueAt != nil {
+		templateData["payment_due_at"] = booking.PaymentDueAt.Format("Jan 2, 2006 3:04 PM MST")
+	}
+
+	payload := &outbox.NotificationPayload{
+		Type:         notificationType,
+		RecipientID:  booking.UserID,
+		EventID:      &eventID,
+		BookingID:    &bookingID,
+		TemplateData: templateData,
+	}
+
+	message, err := outbox.NewNotificationMessage(outbox.AggregateBooking, booking.ID,
+		fmt.Sprintf("booking:%s:%s", booking.ID, dedupSuffix), payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build payment notification: %w", err)
+	}
+
+	return message, nil
+}
+
+// DunningJob periodically retries failed payments and cancels bookings left
+// unpaid past their deadline
+type DunningJob struct {
+	service Service
+	config  *DunningConfig
+	done    chan struct{}
+}
+
+// NewDunningJob creates a new dunning job
+func NewDunningJob(service Service, config *DunningConfig) *DunningJob {
+	if config == nil {
+		config = DefaultDunningConfig()
+	}
+
+	return &DunningJob{
+		service: service,
+		config:  config,
+		done:    make(chan struct{}),
+	}
+}
+
+// Start starts the dunning job
+func (j *DunningJob) Start(ctx context.Context) {
+	log.Printf("💳 DUNNING: Starting payment retry job with %v interval, %v payment window", j.config.CheckInterval, j.config.PaymentWindow)
+	go j.run(ctx)
+}
+
+// Stop stops the dunning job
+func (j *DunningJob) Stop() {
+	log.Println("💳 DUNNING: Stopping payment retry job...")
+	close(j.done)
+}
+
+func (j *DunningJob) run(ctx context.Context) {
+	ticker := time.NewTicker(j.config.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := j.service.RetryDuePayments(ctx); err != nil {
+				log.Printf("❌ DUNNING: %v", err)
+			}
+			if _, err := j.service.ExpireUnpaidBookings(ctx); err != nil {
+				log.Printf("❌ DUNNING: %v", err)
+			}
+		case <-j.done:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package bookings
+
+import (
+	"math"
+)
+
+// Charge types of a booking's price breakdown
+const (
+	ChargeTypeBase       = "BASE"        // Event base price for every ticket
+	ChargeTypeSection    = "SECTION"     // Difference the section or ticket type multiplier adds
+	ChargeTypeServiceFee = "SERVICE_FEE" // Platform fee
+	ChargeTypeTax        = "TAX"         // One charge per tax rule and taxed amount
+)
+
+// TaxRule is a tax charged on top of the ticket price
+type TaxRule struct {
+	Name string
+	Rate float64 // Percent
+	// The tax is also charged on the service fee
+	IncludeFees bool
+}
+
+// PricingConfig contains the fees and taxes added to ticket prices
+type PricingConfig struct {
+	ServiceFeePercent   float64 // Percent of the ticket price
+	ServiceFeePerTicket float64 // Flat amount per ticket
+	TaxRules            []TaxRule
+	// Whether a cancellation refunds the service fee and the tax charged on it
+	RefundServiceFee bool
+}
+
+// DefaultPricingConfig returns default pricing configuration, tickets sold at face value
+func DefaultPricingConfig() *PricingConfig {
+	return &PricingConfig{
+		RefundServiceFee: false,
+	}
+}
+
+// PriceBreakdown summarizes a booking's charges
+type PriceBreakdown struct {
+	BasePrice         float64   `json:"base_price"`
+	SectionAdjustment float64   `json:"section_adjustment"`
+	Subtotal          float64   `json:"subtotal"` // Ticket price before fees and taxes
+	ServiceFee        float64   `json:"service_fee"`
+	Tax               float64   `json:"tax"`
+	Total             float64   `json:"total"`
+	NonRefundable     float64   `json:"non_refundable"` // Kept on cancellation whatever the policy
+	Taxes             []TaxLine `json:"taxes"`
+}
+
+// TaxLine is the amount charged for one tax rule
+type TaxLine struct {
+	Name   string  `json:"name"`
+	Rate   float64 `json:"rate"`
+	Amount float64 `json:"amount"`
+}
+
+// SetPricingConfig sets the fees and taxes added to new bookings
+func (s *service) SetPricingConfig(config *PricingConfig) {
+	s.pricingConfig = config
+}
+
+// priceCharges splits a booking's price into charges. basePrice is the event
+// base price for all tickets and subtotal the ticket price after multipliers.
+func (s *service) priceCharges(basePrice, subtotal float64, tickets int) []BookingCharge {
+	config := s.pricingConfig
+	if config == nil {
+		config = DefaultPricingConfig()
+	}
+
+	basePrice = roundAmount(basePrice)
+	subtotal = roundAmount(subtotal)
+
+	charges := []BookingCharge{{Type: ChargeTypeBase, Name: "Base price", Amount: basePrice, Refundable: true}}
+	if adjustment := roundAmount(subtotal - basePrice); adjustment != 0 {
+		charges = append(charges, BookingCharge{Type: ChargeTypeSection, Name: "Section pricing", Amount: adjustment, Refundable: true})
+	}
+
+	fee := roundAmount(subtotal*config.ServiceFeePercent/100 + config.ServiceFeePerTicket*float64(tickets))
+	if fee > 0 {
+		charges = append(charges, BookingCharge{
+			Type:       ChargeTypeServiceFee,
+			Name:       "Service fee",
+			Rate:       config.ServiceFeePercent,
+			Amount:     fee,
+			Refundable: config.RefundServiceFee,
+		})
+	}
+
+	for _, rule := range config.TaxRules {
+		if tax := roundAmount(subtotal * rule.Rate / 100); tax > 0 {
+			charges = append(charges, BookingCharge{Type: ChargeTypeTax, Name: rule.Name, Rate: rule.Rate, Amount: tax, Refundable: true})
+		}
+		// Tax on the fee follows the fee on refunds
+		if rule.IncludeFees && fee > 0 {
+			if tax := roundAmount(fee * rule.Rate / 100); tax > 0 {
+				charges = append(charges, BookingCharge{Type: ChargeTypeTax, Name: rule.Name, Rate: rule.Rate, Amount: tax, Refundable: config.RefundServiceFee})
+			}
+		}
+	}
+
+	return charges
+}
+
+// snapshotSeatPrices records each seat's share of the booking's service fee and
+// taxes. Shares follow the same rules as priceCharges, so a seat's fee and tax
+// are what it would cost booked on its own, and they add up to the charges.
+func (s *service) snapshotSeatPrices(seatBookings []SeatBooking, charges []BookingCharge) {
+	breakdown := NewPriceBreakdown(charges)
+	if len(seatBookings) == 0 || breakdown == nil {
+		return
+	}
+
+	config := s.pricingConfig
+	if config == nil {
+		config = DefaultPricingConfig()
+	}
+
+	var taxRate, feeTaxRate float64
+	for _, rule := range config.TaxRules {
+		taxRate += rule.Rate
+		if rule.IncludeFees {
+			feeTaxRate += rule.Rate
+		}
+	}
+
+	feeWeights := make([]float64, len(seatBookings))
+	taxWeights := make([]float64, len(seatBookings))
+	for i, seatBooking := range seatBookings {
+		feeWeights[i] = seatBooking.SeatPrice*config.ServiceFeePercent/100 + config.ServiceFeePerTicket
+		taxWeights[i] = seatBooking.SeatPrice*taxRate/100 + feeWeights[i]*feeTaxRate/100
+	}
+
+	fees := allocate(breakdown.ServiceFee, feeWeights)
+	taxes := allocate(breakdown.Tax, taxWeights)
+	// Only the fee and the tax on it are ever non-refundable
+	nonRefundable := allocate(breakdown.NonRefundable, feeWeights)
+
+	for i := range seatBookings {
+		seatBookings[i].ServiceFee = fees[i]
+		seatBookings[i].Tax = taxes[i]
+		seatBookings[i].NonRefundable = nonRefundable[i]
+		seatBookings[i].TotalPrice = roundAmount(seatBookings[i].SeatPrice + fees[i] + taxes[i])
+	}
+}
+
+// allocate splits an amount in proportion to weights, equally when they are all
+// zero. Shares are rounded and the last one takes the rounding difference.
+func allocate(amount float64, weights []float64) []float64 {
+	shares := make([]float64, len(weights))
+	if len(weights) == 0 {
+		return shares
+	}
+
+	var total float64
+	for _, weight := range weights {
+		total += weight
+	}
+
+	var allocated float64
+	last := len(weights) - 1
+	for i := 0; i < last; i++ {
+		share := amount / float64(len(weights))
+		if total > 0 {
+			share = amount * weights[i] / total
+		}
+		shares[i] = roundAmount(share)
+		allocated += shares[i]
+	}
+	shares[last] = roundAmount(amount - allocated)
+	return shares
+}
+
+// NewPriceBreakdown sums charges into a breakdown. Bookings made before
+// charges were recorded have none and get no breakdown.
+func NewPriceBreakdown(charges []BookingCharge) *PriceBreakdown {
+	if len(charges) == 0 {
+		return nil
+	}
+
+	breakdown := &PriceBreakdown{Taxes: []TaxLine{}}
+	taxIndex := make(map[string]int)
+
+	for _, charge := range charges {
+		switch charge.Type {
+		case ChargeTypeBase:
+			breakdown.BasePrice += charge.Amount
+		case ChargeTypeSection:
+			breakdown.SectionAdjustment += charge.Amount
+		case ChargeTypeServiceFee:
+			breakdown.ServiceFee += charge.Amount
+		case ChargeTypeTax:
+			breakdown.Tax += charge.Amount
+			if i, ok := taxIndex[charge.Name]; ok {
+				breakdown.Taxes[i].Amount = roundAmount(breakdown.Taxes[i].Amount + charge.Amount)
+			} else {
+				taxIndex[charge.Name] = len(breakdown.Taxes)
+				breakdown.Taxes = append(breakdown.Taxes, TaxLine{Name: charge.Name, Rate: charge.Rate, Amount: charge.Amount})
+			}
+		}
+
+		breakdown.Total += charge.Amount
+		if !charge.Refundable {
+			breakdown.NonRefundable += charge.Amount
+		}
+	}
+
+	breakdown.BasePrice = roundAmount(breakdown.BasePrice)
+	breakdown.SectionAdjustment = roundAmount(breakdown.SectionAdjustment)
+	breakdown.Subtotal = roundAmount(breakdown.BasePrice + breakdown.SectionAdjustment)
+	breakdown.ServiceFee = roundAmount(breakdown.ServiceFee)
+	breakdown.Tax = roundAmount(breakdown.Tax)
+	breakdown.Total = roundAmount(breakdown.Total)
+	breakdown.NonRefundable = roundAmount(breakdown.NonRefundable)
+	return breakdown
+}
+
+// sumCharges returns the amount the customer pays for the charges
+func sumCharges(charges []BookingCharge) float64 {
+	var total float64
+	for _, charge := range charges {
+		total += charge.Amount
+	}
+	return roundAmount(total)
+}
+
+// roundAmount rounds to the smallest currency unit
+func roundAmount(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
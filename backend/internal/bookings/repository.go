@@ -3,15 +3,22 @@ package bookings
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
+	"evently/internal/domainevents"
+	"evently/internal/outbox"
+	"evently/internal/webhooks"
+
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type Repository interface {
 	Create(ctx context.Context, booking *Booking) error
-	CreateAtomic(ctx context.Context, booking *Booking) error
+	CreateAtomic(ctx context.Context, booking *Booking, messages ...*outbox.Message) error
 	CheckSeatBookingConflicts(ctx context.Context, seatIDs []uuid.UUID, eventID uuid.UUID) ([]uuid.UUID, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*Booking, error)
 	GetByHoldID(ctx context.Context, holdID string) (*Booking, error)
@@ -20,20 +27,55 @@ type Repository interface {
 	UpdateWithVersion(ctx context.Context, booking *Booking) error
 	Cancel(ctx context.Context, id uuid.UUID) error
 	CancelWithVersion(ctx context.Context, id uuid.UUID, expectedVersion int) error
+	CheckIn(ctx context.Context, id uuid.UUID, at time.Time) (bool, error)
+	FindOverlappingBookings(ctx context.Context, userID, eventID uuid.UUID, window time.Duration) ([]BookingConflict, error)
+	GetEventCurrency(ctx context.Context, eventID uuid.UUID) (string, error)
+	GetUserDisplayCurrency(ctx context.Context, userID uuid.UUID) (string, error)
 
 	// Payment operations
 	CreatePayment(ctx context.Context, payment *Payment) error
 	UpdatePayment(ctx context.Context, payment *Payment) error
 	GetPaymentByID(ctx context.Context, paymentID uuid.UUID) (*Payment, error)
 
+	// Payment settlement and dunning. Each writes its notifications in the same transaction.
+	SettlePayment(ctx context.Context, bookingID uuid.UUID, payment *Payment, messages ...*outbox.Message) error
+	RecordPaymentFailure(ctx context.Context, booking *Booking, payment *Payment, messages ...*outbox.Message) error
+	CancelUnpaid(ctx context.Context, bookingID uuid.UUID, payment *Payment, messages ...*outbox.Message) error
+	ClaimDuePaymentRetries(ctx context.Context, limit int, lease time.Duration) ([]Payment, error)
+	ClaimExpiredPendingBookings(ctx context.Context, dueBefore time.Time, limit int) ([]uuid.UUID, error)
+
+	// Confirmation saga state
+	CreateSaga(ctx context.Context, saga *BookingSaga) error
+	UpdateSaga(ctx context.Context, saga *BookingSaga) error
+	ClaimStaleSagas(ctx context.Context, staleBefore time.Time, limit int) ([]BookingSaga, error)
+	ClaimStuckConfirmations(ctx context.Context, staleBefore time.Time, limit int) ([]uuid.UUID, error)
+
 	// Seat booking operations
 	CreateSeatBookings(ctx context.Context, seatBookings []SeatBooking) error
 	GetSeatBookingsByBookingID(ctx context.Context, bookingID uuid.UUID) ([]SeatBooking, error)
 	DeleteSeatBookingsByBookingID(ctx context.Context, bookingID uuid.UUID) error
+
+	// Transfers between users
+	CreateTransfer(ctx context.Context, transfer *BookingTransfer, messages ...*outbox.Message) error
+	GetTransfer(ctx context.Context, id uuid.UUID) (*BookingTransfer, error)
+	GetTransfersByUserID(ctx context.Context, userID uuid.UUID) ([]BookingTransfer, error)
+	CloseTransfer(ctx context.Context, id uuid.UUID, status string, at time.Time) (bool, error)
+	ExpireTransfers(ctx context.Context, now time.Time) (int64, error)
+	CompleteTransfer(ctx context.Context, completion *TransferCompletion, messages ...*outbox.Message) error
+	GetOwnershipChanges(ctx context.Context, bookingID uuid.UUID) ([]BookingOwnershipChange, error)
+	GetTransferUser(ctx context.Context, id uuid.UUID) (*TransferUser, error)
+	GetTransferUserByEmail(ctx context.Context, email string) (*TransferUser, error)
+	GetTransferEvent(ctx context.Context, eventID uuid.UUID) (*TransferEvent, error)
+
+	// Seat booking consistency
+	SetStrictSeatLocks(enabled bool)
+	FindDoubleBookings(ctx context.Context) ([]DoubleBooking, error)
+	HasSeatBookingUniqueIndex(ctx context.Context) (bool, error)
 }
 
 type repository struct {
-	db *gorm.DB
+	db          *gorm.DB
+	strictLocks bool // Take advisory locks on seats before finalizing a booking
 }
 
 func NewRepository(db *gorm.DB) Repository {
@@ -45,12 +87,15 @@ func (r *repository) Create(ctx context.Context, booking *Booking) error {
 	return r.CreateAtomic(ctx, booking)
 }
 
-// CreateAtomic creates a booking with atomic conflict checking
-func (r *repository) CreateAtomic(ctx context.Context, booking *Booking) error {
+// CreateAtomic creates a booking with atomic conflict checking. Outbox messages
+// are written in the same transaction so confirmations are never lost.
+func (r *repository) CreateAtomic(ctx context.Context, booking *Booking, messages ...*outbox.Message) error {
 	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		// Store associations temporarily
 		seatBookings := booking.SeatBookings
+		ticketBookings := booking.TicketBookings
 		payments := booking.Payments
+		charges := booking.Charges
 
 		// Extract seat IDs and event ID for conflict checking
 		if len(seatBookings) > 0 {
@@ -59,6 +104,12 @@ func (r *repository) CreateAtomic(ctx context.Context, booking *Booking) error {
 				seatIDs[i] = sb.SeatID
 			}
 
+			if r.strictLocks {
+				if err := lockSeats(tx, booking.EventID, seatIDs); err != nil {
+					return err
+				}
+			}
+
 			// Check for existing bookings with SELECT FOR UPDATE to prevent race conditions
 			// Only consider non-cancelled bookings as conflicts
 			var existingCount int64
@@ -75,9 +126,36 @@ func (r *repository) CreateAtomic(ctx context.Context, booking *Booking) error {
 			}
 		}
 
+		// General admission holds come out of a Redis counter, the locked
+		// ticket type row makes the capacity hold even if that drifted
+		for _, tb := range ticketBookings {
+			var ticketType struct {
+				Capacity int
+			}
+			if err := tx.Raw("SELECT capacity FROM ticket_types WHERE id = ? FOR UPDATE", tb.TicketTypeID).
+				Scan(&ticketType).Error; err != nil {
+				return fmt.Errorf("failed to lock ticket type: %w", err)
+			}
+
+			var sold int64
+			if err := tx.Table("ticket_bookings tb").
+				Joins("JOIN bookings b ON b.id = tb.booking_id").
+				Where("tb.ticket_type_id = ? AND b.status != 'CANCELLED'", tb.TicketTypeID).
+				Select("COALESCE(SUM(tb.quantity), 0)").
+				Scan(&sold).Error; err != nil {
+				return fmt.Errorf("failed to count tickets sold: %w", err)
+			}
+
+			if int(sold)+tb.Quantity > ticketType.Capacity {
+				return fmt.Errorf("not enough tickets left for this ticket type")
+			}
+		}
+
 		// Clear associations to avoid GORM auto-creating them
 		booking.SeatBookings = nil
+		booking.TicketBookings = nil
 		booking.Payments = nil
+		booking.Charges = nil
 
 		// Set initial version if not set
 		if booking.Version == 0 {
@@ -101,6 +179,18 @@ func (r *repository) CreateAtomic(ctx context.Context, booking *Booking) error {
 			booking.SeatBookings = seatBookings
 		}
 
+		// Create ticket bookings the same way
+		if len(ticketBookings) > 0 {
+			for i := range ticketBookings {
+				ticketBookings[i].BookingID = booking.ID
+				ticketBookings[i].EventID = booking.EventID
+			}
+			if err := tx.Create(&ticketBookings).Error; err != nil {
+				return fmt.Errorf("failed to create ticket bookings: %w", err)
+			}
+			booking.TicketBookings = ticketBookings
+		}
+
 		// Create payment records with the generated BookingID
 		if len(payments) > 0 {
 			for i := range payments {
@@ -112,7 +202,18 @@ func (r *repository) CreateAtomic(ctx context.Context, booking *Booking) error {
 			booking.Payments = payments
 		}
 
-		return nil
+		// Record the price breakdown the total was built from
+		if len(charges) > 0 {
+			for i := range charges {
+				charges[i].BookingID = booking.ID
+			}
+			if err := tx.Create(&charges).Error; err != nil {
+				return fmt.Errorf("failed to create booking charges: %w", err)
+			}
+			booking.Charges = charges
+		}
+
+		return outbox.Enqueue(tx, messages...)
 	})
 }
 
@@ -136,7 +237,9 @@ func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*Booking, error
 	var booking Booking
 	err := r.db.WithContext(ctx).
 		Preload("SeatBookings").
+		Preload("TicketBookings").
 		Preload("Payments").
+		Preload("Charges").
 		First(&booking, "id = ?", id).Error
 
 	if err != nil {
@@ -149,11 +252,89 @@ func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*Booking, error
 	return &booking, nil
 }
 
+// CheckIn marks a confirmed booking as checked in. It reports false when the
+// booking isn't confirmed or was already checked in.
+func (r *repository) CheckIn(ctx context.Context, id uuid.UUID, at time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&Booking{}).
+		Where("id = ? AND status = ? AND checked_in_at IS NULL", id, "CONFIRMED").
+		Updates(map[string]interface{}{
+			"checked_in_at": at,
+			"updated_at":    at,
+		})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to check in booking: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// FindOverlappingBookings returns the user's confirmed or pending bookings for other
+// events starting within the window of the given event's start time
+// GetEventCurrency returns the currency the event's tickets are priced in
+func (r *repository) GetEventCurrency(ctx context.Context, eventID uuid.UUID) (string, error) {
+	var currencies []string
+	err := r.db.WithContext(ctx).
+		Table("events").
+		Where("id = ? AND deleted_at IS NULL", eventID).
+		Pluck("currency", &currencies).Error
+	if err != nil {
+		return "", err
+	}
+	if len(currencies) == 0 {
+		return "", gorm.ErrRecordNotFound
+	}
+	return currencies[0], nil
+}
+
+// GetUserDisplayCurrency returns the currency the user wants prices shown in, empty when unset
+func (r *repository) GetUserDisplayCurrency(ctx context.Context, userID uuid.UUID) (string, error) {
+	var currencies []string
+	err := r.db.WithContext(ctx).
+		Table("users").
+		Where("id = ?", userID).
+		Pluck("COALESCE(display_currency, '')", &currencies).Error
+	if err != nil || len(currencies) == 0 {
+		return "", err
+	}
+	return currencies[0], nil
+}
+
+func (r *repository) FindOverlappingBookings(ctx context.Context, userID, eventID uuid.UUID, window time.Duration) ([]BookingConflict, error) {
+	var conflicts []BookingConflict
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT
+			b.id AS booking_id,
+			b.booking_ref,
+			b.status,
+			b.total_seats,
+			e.id AS event_id,
+			e.name AS event_name,
+			e.venue,
+			e.date_time AS event_date_time
+		FROM bookings b
+		JOIN events e ON e.id = b.event_id
+		JOIN events target ON target.id = ?
+		WHERE b.user_id = ?
+			AND b.event_id <> target.id
+			AND b.status IN ('CONFIRMED', 'CONFIRMING', 'PENDING', 'PENDING_PAYMENT')
+			AND e.status <> 'cancelled'
+			AND e.date_time > target.date_time - make_interval(secs => ?)
+			AND e.date_time < target.date_time + make_interval(secs => ?)
+		ORDER BY e.date_time ASC
+	`, eventID, userID, window.Seconds(), window.Seconds()).Scan(&conflicts).Error
+	if err != nil {
+		return nil, err
+	}
+	return conflicts, nil
+}
+
 func (r *repository) GetByHoldID(ctx context.Context, holdID string) (*Booking, error) {
 	var booking Booking
 	err := r.db.WithContext(ctx).
 		Preload("SeatBookings").
+		Preload("TicketBookings").
 		Preload("Payments").
+		Preload("Charges").
 		First(&booking, "booking_ref = ?", holdID).Error
 
 	if err != nil {
@@ -170,7 +351,9 @@ func (r *repository) GetByUserID(ctx context.Context, userID uuid.UUID, limit, o
 	var bookings []Booking
 	query := r.db.WithContext(ctx).
 		Preload("SeatBookings").
+		Preload("TicketBookings").
 		Preload("Payments").
+		Preload("Charges").
 		Where("user_id = ?", userID).
 		Order("created_at DESC")
 
@@ -211,8 +394,8 @@ func (r *repository) UpdateWithVersion(ctx context.Context, booking *Booking) er
 
 	// Check if version matches (optimistic lock check)
 	if currentBooking.Version != booking.Version {
-		return fmt.Errorf("booking was modified by another process (version mismatch: expected %d, got %d)",
-			booking.Version, currentBooking.Version)
+		return fmt.Errorf("%w (version mismatch: expected %d, got %d)",
+			ErrBookingModified, booking.Version, currentBooking.Version)
 	}
 
 	// Update with version increment using atomic transaction
@@ -236,7 +419,7 @@ func (r *repository) UpdateWithVersion(ctx context.Context, booking *Booking) er
 		}
 
 		if result.RowsAffected == 0 {
-			return fmt.Errorf("booking was modified by another process during update")
+			return fmt.Errorf("%w during update", ErrBookingModified)
 		}
 
 		booking.Version++ // Update in-memory version
@@ -257,6 +440,7 @@ func (r *repository) Cancel(ctx context.Context, id uuid.UUID) error {
 		}
 
 		// Cancel the booking
+		wasConfirmed := booking.IsConfirmed()
 		booking.Cancel()
 		if err := tx.Save(&booking).Error; err != nil {
 			return fmt.Errorf("failed to cancel booking: %w", err)
@@ -267,6 +451,12 @@ func (r *repository) Cancel(ctx context.Context, id uuid.UUID) error {
 			return fmt.Errorf("failed to delete seat bookings: %w", err)
 		}
 
+		if wasConfirmed {
+			if err := enqueueBookingWebhook(tx, &booking, webhooks.EventBookingCancelled); err != nil {
+				return err
+			}
+			return enqueueBookingDomainEvent(tx, &booking, domainevents.BookingCancelled)
+		}
 		return nil
 	})
 }
@@ -285,11 +475,12 @@ func (r *repository) CancelWithVersion(ctx context.Context, id uuid.UUID, expect
 
 		// Optimistic lock check
 		if booking.Version != expectedVersion {
-			return fmt.Errorf("booking was modified by another process (version mismatch: expected %d, got %d)",
-				expectedVersion, booking.Version)
+			return fmt.Errorf("%w (version mismatch: expected %d, got %d)",
+				ErrBookingModified, expectedVersion, booking.Version)
 		}
 
 		// Update status with version increment
+		wasConfirmed := booking.IsConfirmed()
 		now := time.Now()
 		result := tx.Model(&booking).
 			Where("id = ? AND version = ?", id, expectedVersion).
@@ -305,7 +496,7 @@ func (r *repository) CancelWithVersion(ctx context.Context, id uuid.UUID, expect
 		}
 
 		if result.RowsAffected == 0 {
-			return fmt.Errorf("booking was modified by another process during cancellation")
+			return fmt.Errorf("%w during cancellation", ErrBookingModified)
 		}
 
 		// Delete associated seat bookings to free up seats for future bookings
@@ -313,6 +504,14 @@ func (r *repository) CancelWithVersion(ctx context.Context, id uuid.UUID, expect
 			return fmt.Errorf("failed to delete seat bookings: %w", err)
 		}
 
+		if wasConfirmed {
+			booking.Status = "CANCELLED"
+			booking.CancelledAt = &now
+			if err := enqueueBookingWebhook(tx, &booking, webhooks.EventBookingCancelled); err != nil {
+				return err
+			}
+			return enqueueBookingDomainEvent(tx, &booking, domainevents.BookingCancelled)
+		}
 		return nil
 	})
 }
@@ -346,6 +545,181 @@ func (r *repository) GetPaymentByID(ctx context.Context, paymentID uuid.UUID) (*
 	return &payment, nil
 }
 
+// SettlePayment records a successful charge or capture and confirms the
+// booking awaiting payment or being confirmed
+func (r *repository) SettlePayment(ctx context.Context, bookingID uuid.UUID, payment *Payment, messages ...*outbox.Message) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		payment.UpdatedAt = time.Now()
+		if err := tx.Save(payment).Error; err != nil {
+			return fmt.Errorf("failed to update payment: %w", err)
+		}
+
+		result := tx.Model(&Booking{}).
+			Where("id = ? AND status IN ('PENDING', 'PENDING_PAYMENT', 'CONFIRMING')", bookingID).
+			Updates(map[string]interface{}{
+				"status":     "CONFIRMED",
+				"updated_at": time.Now(),
+				"version":    gorm.Expr("version + 1"),
+			})
+		if result.Error != nil {
+			return fmt.Errorf("failed to confirm booking: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("booking is no longer awaiting payment")
+		}
+
+		var booking Booking
+		if err := tx.Take(&booking, "id = ?", bookingID).Error; err != nil {
+			return fmt.Errorf("failed to get booking: %w", err)
+		}
+		if err := enqueueBookingWebhook(tx, &booking, webhooks.EventBookingConfirmed); err != nil {
+			return err
+		}
+		if err := enqueueBookingDomainEvent(tx, &booking, domainevents.BookingConfirmed); err != nil {
+			return err
+		}
+
+		return outbox.Enqueue(tx, messages...)
+	})
+}
+
+// RecordPaymentFailure stores a failed charge and its retry schedule, and
+// moves the booking to PENDING_PAYMENT with its payment deadline
+func (r *repository) RecordPaymentFailure(ctx context.Context, booking *Booking, payment *Payment, messages ...*outbox.Message) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		payment.UpdatedAt = time.Now()
+		if err := tx.Save(payment).Error; err != nil {
+			return fmt.Errorf("failed to update payment: %w", err)
+		}
+
+		result := tx.Model(&Booking{}).
+			Where("id = ? AND status IN ('PENDING', 'PENDING_PAYMENT')", booking.ID).
+			Updates(map[string]interface{}{
+				"status":         "PENDING_PAYMENT",
+				"payment_due_at": booking.PaymentDueAt,
+				"updated_at":     time.Now(),
+			})
+		if result.Error != nil {
+			return fmt.Errorf("failed to update booking: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("booking is no longer awaiting payment")
+		}
+
+		return outbox.Enqueue(tx, messages...)
+	})
+}
+
+// CancelUnpaid cancels a booking whose payment retries are exhausted
+// and frees its seats
+func (r *repository) CancelUnpaid(ctx context.Context, bookingID uuid.UUID, payment *Payment, messages ...*outbox.Message) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		payment.UpdatedAt = time.Now()
+		if err := tx.Save(payment).Error; err != nil {
+			return fmt.Errorf("failed to update payment: %w", err)
+		}
+
+		now := time.Now()
+		result := tx.Model(&Booking{}).
+			Where("id = ? AND status IN ('PENDING', 'PENDING_PAYMENT')", bookingID).
+			Updates(map[string]interface{}{
+				"status":       "CANCELLED",
+				"cancelled_at": &now,
+				"updated_at":   now,
+				"version":      gorm.Expr("version + 1"),
+			})
+		if result.Error != nil {
+			return fmt.Errorf("failed to cancel booking: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("booking is no longer awaiting payment")
+		}
+
+		// Delete associated seat bookings to free up seats for future bookings
+		if err := tx.Where("booking_id = ?", bookingID).Delete(&SeatBooking{}).Error; err != nil {
+			return fmt.Errorf("failed to delete seat bookings: %w", err)
+		}
+
+		return outbox.Enqueue(tx, messages...)
+	})
+}
+
+// ClaimDuePaymentRetries returns failed payments of PENDING_PAYMENT bookings
+// that are due for a retry. Claimed payments are leased so other instances
+// skip them.
+func (r *repository) ClaimDuePaymentRetries(ctx context.Context, limit int, lease time.Duration) ([]Payment, error) {
+	var payments []Payment
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Table: clause.Table{Name: "payments"}, Options: "SKIP LOCKED"}).
+			Joins("JOIN bookings ON bookings.id = payments.booking_id").
+			Where("payments.status = 'FAILED' AND payments.next_retry_at <= ? AND bookings.status = 'PENDING_PAYMENT'", now).
+			Where("bookings.payment_due_at IS NULL OR bookings.payment_due_at > ?", now).
+			Order("payments.next_retry_at ASC").
+			Limit(limit).
+			Find(&payments).Error
+		if err != nil {
+			return err
+		}
+
+		if len(payments) == 0 {
+			return nil
+		}
+
+		ids := make([]uuid.UUID, len(payments))
+		for i, payment := range payments {
+			ids[i] = payment.ID
+		}
+
+		return tx.Model(&Payment{}).
+			Where("id IN ?", ids).
+			Update("next_retry_at", now.Add(lease)).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim payment retries: %w", err)
+	}
+
+	return payments, nil
+}
+
+// ClaimExpiredPendingBookings returns PENDING_PAYMENT bookings whose payment
+// was due before dueBefore. Claimed bookings are touched, and bookings touched
+// after dueBefore are skipped, so other instances leave them alone meanwhile.
+func (r *repository) ClaimExpiredPendingBookings(ctx context.Context, dueBefore time.Time, limit int) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var bookings []Booking
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Select("id").
+			Where("status = 'PENDING_PAYMENT' AND payment_due_at <= ? AND updated_at < ?", dueBefore, dueBefore).
+			Order("payment_due_at ASC").
+			Limit(limit).
+			Find(&bookings).Error
+		if err != nil {
+			return err
+		}
+
+		if len(bookings) == 0 {
+			return nil
+		}
+
+		for _, booking := range bookings {
+			ids = append(ids, booking.ID)
+		}
+
+		return tx.Model(&Booking{}).
+			Where("id IN ?", ids).
+			Update("updated_at", time.Now()).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim expired pending bookings: %w", err)
+	}
+
+	return ids, nil
+}
+
 func (r *repository) CreateSeatBookings(ctx context.Context, seatBookings []SeatBooking) error {
 	if len(seatBookings) == 0 {
 		return nil
@@ -382,3 +756,326 @@ func (r *repository) DeleteSeatBookingsByBookingID(ctx context.Context, bookingI
 
 	return nil
 }
+
+func (r *repository) CreateSaga(ctx context.Context, saga *BookingSaga) error {
+	if err := r.db.WithContext(ctx).Create(saga).Error; err != nil {
+		return fmt.Errorf("failed to create booking saga: %w", err)
+	}
+	return nil
+}
+
+func (r *repository) UpdateSaga(ctx context.Context, saga *BookingSaga) error {
+	saga.UpdatedAt = time.Now()
+	if err := r.db.WithContext(ctx).Save(saga).Error; err != nil {
+		return fmt.Errorf("failed to update booking saga: %w", err)
+	}
+	return nil
+}
+
+// ClaimStaleSagas locks unfinished sagas that stopped making progress and bumps
+// their updated_at so other instances skip them while they are recovered
+func (r *repository) ClaimStaleSagas(ctx context.Context, staleBefore time.Time, limit int) ([]BookingSaga, error) {
+	var sagas []BookingSaga
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status IN ? AND updated_at < ?", []string{SagaStatusRunning, SagaStatusCompensating}, staleBefore).
+			Order("updated_at ASC").
+			Limit(limit).
+			Find(&sagas).Error
+		if err != nil {
+			return err
+		}
+
+		if len(sagas) == 0 {
+			return nil
+		}
+
+		ids := make([]uuid.UUID, len(sagas))
+		now := time.Now()
+		for i := range sagas {
+			ids[i] = sagas[i].ID
+			sagas[i].RecoveryAttempts++
+			sagas[i].UpdatedAt = now
+		}
+
+		return tx.Model(&BookingSaga{}).
+			Where("id IN ?", ids).
+			Updates(map[string]interface{}{
+				"updated_at":        now,
+				"recovery_attempts": gorm.Expr("recovery_attempts + 1"),
+			}).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim stale sagas: %w", err)
+	}
+
+	return sagas, nil
+}
+
+// ClaimStuckConfirmations returns bookings left CONFIRMING with no running or
+// compensating saga to finish them, e.g. after saga recovery gave up. Claimed
+// bookings get a fresh updated_at so other instances skip them meanwhile.
+func (r *repository) ClaimStuckConfirmations(ctx context.Context, staleBefore time.Time, limit int) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var bookings []Booking
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Select("id").
+			Where("status = 'CONFIRMING' AND updated_at < ?", staleBefore).
+			Where("NOT EXISTS (SELECT 1 FROM booking_sagas bs WHERE bs.booking_id = bookings.id AND bs.status IN ?)",
+				[]string{SagaStatusRunning, SagaStatusCompensating}).
+			Order("updated_at ASC").
+			Limit(limit).
+			Find(&bookings).Error
+		if err != nil {
+			return err
+		}
+
+		if len(bookings) == 0 {
+			return nil
+		}
+
+		for _, booking := range bookings {
+			ids = append(ids, booking.ID)
+		}
+
+		return tx.Model(&Booking{}).
+			Where("id IN ?", ids).
+			Update("updated_at", time.Now()).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim stuck confirmations: %w", err)
+	}
+
+	return ids, nil
+}
+
+//  TRANSFERS
+
+func (r *repository) CreateTransfer(ctx context.Context, transfer *BookingTransfer, messages ...*outbox.Message) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(transfer).Error; err != nil {
+			return err
+		}
+		return outbox.Enqueue(tx, messages...)
+	})
+}
+
+func (r *repository) GetTransfer(ctx context.Context, id uuid.UUID) (*BookingTransfer, error) {
+	var transfer BookingTransfer
+	err := r.db.WithContext(ctx).First(&transfer, "id = ?", id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrTransferNotFound
+		}
+		return nil, fmt.Errorf("failed to get transfer: %w", err)
+	}
+	return &transfer, nil
+}
+
+func (r *repository) GetTransfersByUserID(ctx context.Context, userID uuid.UUID) ([]BookingTransfer, error) {
+	var transfers []BookingTransfer
+	err := r.db.WithContext(ctx).
+		Where("from_user_id = ? OR to_user_id = ?", userID, userID).
+		Order("created_at DESC").
+		Find(&transfers).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transfers: %w", err)
+	}
+	return transfers, nil
+}
+
+// CloseTransfer declines or withdraws a pending transfer. It reports false when
+// the transfer was no longer pending.
+func (r *repository) CloseTransfer(ctx context.Context, id uuid.UUID, status string, at time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&BookingTransfer{}).
+		Where("id = ? AND status = ?", id, TransferStatusPending).
+		Updates(map[string]interface{}{
+			"status":       status,
+			"responded_at": at,
+			"updated_at":   at,
+		})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to update transfer: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// ExpireTransfers closes pending transfers whose offer has lapsed
+func (r *repository) ExpireTransfers(ctx context.Context, now time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Model(&BookingTransfer{}).
+		Where("status = ? AND expires_at <= ?", TransferStatusPending, now).
+		Updates(map[string]interface{}{
+			"status":     TransferStatusExpired,
+			"updated_at": now,
+		})
+	return result.RowsAffected, result.Error
+}
+
+// CompleteTransfer accepts a transfer and applies its ownership move in one
+// transaction
+func (r *repository) CompleteTransfer(ctx context.Context, completion *TransferCompletion, messages ...*outbox.Message) error {
+	transfer := completion.Transfer
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&BookingTransfer{}).
+			Where("id = ? AND status = ? AND expires_at > ?", transfer.ID, TransferStatusPending, now).
+			Updates(map[string]interface{}{
+				"status":            transfer.Status,
+				"responded_at":      transfer.RespondedAt,
+				"result_booking_id": transfer.ResultBookingID,
+				"updated_at":        now,
+			})
+		if result.Error != nil {
+			return fmt.Errorf("failed to accept transfer: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return ErrTransferClosed
+		}
+
+		if err := ApplyOwnershipMove(tx, completion.Move); err != nil {
+			return err
+		}
+		return outbox.Enqueue(tx, messages...)
+	})
+}
+
+// GetOwnershipChanges returns the ownership changes of a booking and of the
+// bookings it was split from or into, oldest first
+func (r *repository) GetOwnershipChanges(ctx context.Context, bookingID uuid.UUID) ([]BookingOwnershipChange, error) {
+	var changes []BookingOwnershipChange
+	err := r.db.WithContext(ctx).
+		Where("booking_id = ? OR source_booking_id = ?", bookingID, bookingID).
+		Order("changed_at ASC").
+		Find(&changes).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ownership changes: %w", err)
+	}
+	return changes, nil
+}
+
+func (r *repository) GetTransferUser(ctx context.Context, id uuid.UUID) (*TransferUser, error) {
+	var user TransferUser
+	err := r.db.WithContext(ctx).
+		Table("users").
+		Select("id, first_name, last_name, email").
+		Where("id = ? AND deleted_at IS NULL", id).
+		Take(&user).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return &user, nil
+}
+
+func (r *repository) GetTransferUserByEmail(ctx context.Context, email string) (*TransferUser, error) {
+	var user TransferUser
+	err := r.db.WithContext(ctx).
+		Table("users").
+		Select("id, first_name, last_name, email").
+		Where("LOWER(email) = ? AND deleted_at IS NULL", email).
+		Take(&user).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrRecipientNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return &user, nil
+}
+
+func (r *repository) GetTransferEvent(ctx context.Context, eventID uuid.UUID) (*TransferEvent, error) {
+	var event TransferEvent
+	err := r.db.WithContext(ctx).
+		Table("events").
+		Select("name, date_time, status").
+		Where("id = ? AND deleted_at IS NULL", eventID).
+		Take(&event).Error
+	if err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+func (r *repository) SetStrictSeatLocks(enabled bool) {
+	r.strictLocks = enabled
+}
+
+// lockSeats takes a transaction scoped advisory lock on every seat, so two
+// bookings of the same seat can't both pass the conflict check before either
+// commits. Locks are taken in seat order to avoid deadlocks between bookings
+// sharing several seats.
+func lockSeats(tx *gorm.DB, eventID uuid.UUID, seatIDs []uuid.UUID) error {
+	keys := make([]string, len(seatIDs))
+	for i, seatID := range seatIDs {
+		keys[i] = "seat_booking:" + eventID.String() + ":" + seatID.String()
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtextextended(?, 0))", key).Error; err != nil {
+			return fmt.Errorf("failed to lock seat: %w", err)
+		}
+	}
+	return nil
+}
+
+// FindDoubleBookings returns the seats that are in more than one live booking
+// of the same event
+func (r *repository) FindDoubleBookings(ctx context.Context) ([]DoubleBooking, error) {
+	var rows []struct {
+		EventID    uuid.UUID
+		SeatID     uuid.UUID
+		BookingIDs string
+	}
+	err := r.db.WithContext(ctx).
+		Table("seat_bookings sb").
+		Select("sb.event_id, sb.seat_id, string_agg(sb.booking_id::text, ',' ORDER BY b.created_at) AS booking_ids").
+		Joins("JOIN bookings b ON b.id = sb.booking_id").
+		Where("b.status != 'CANCELLED'").
+		Group("sb.event_id, sb.seat_id").
+		Having("COUNT(DISTINCT sb.booking_id) > 1").
+		Order("sb.event_id, sb.seat_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find double bookings: %w", err)
+	}
+
+	doubles := make([]DoubleBooking, 0, len(rows))
+	for _, row := range rows {
+		double := DoubleBooking{EventID: row.EventID, SeatID: row.SeatID}
+		for _, raw := range strings.Split(row.BookingIDs, ",") {
+			id, err := uuid.Parse(raw)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse booking id %q: %w", raw, err)
+			}
+			double.BookingIDs = append(double.BookingIDs, id)
+		}
+		doubles = append(doubles, double)
+	}
+	return doubles, nil
+}
+
+// HasSeatBookingUniqueIndex tells whether a valid unique index on seat_bookings
+// (event_id, seat_id) exists, partial or not
+func (r *repository) HasSeatBookingUniqueIndex(ctx context.Context) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT COUNT(*)
+		FROM pg_index i
+		JOIN pg_class t ON t.oid = i.indrelid
+		WHERE t.relname = 'seat_bookings'
+		  AND i.indisunique AND i.indisvalid
+		  AND (SELECT array_agg(a.attname::text ORDER BY a.attname)
+		       FROM unnest(i.indkey) k
+		       JOIN pg_attribute a ON a.attrelid = t.oid AND a.attnum = k) = ARRAY['event_id', 'seat_id']`).
+		Scan(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to check seat booking index: %w", err)
+	}
+	return count > 0, nil
+}
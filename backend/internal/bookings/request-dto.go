@@ -5,3 +5,16 @@ type BookingConfirmationRequest struct {
 	EventID       string `json:"event_id" binding:"required,uuid"`
 	PaymentMethod string `json:"payment_method" binding:"required"`
 }
+
+// Optional body for resuming a failed payment, defaults to the original method
+type ResumePaymentRequest struct {
+	PaymentMethod string `json:"payment_method"`
+}
+
+// Offer a booking to another registered user. Without seat IDs the whole
+// booking is transferred.
+type CreateTransferRequest struct {
+	RecipientEmail string   `json:"recipient_email" binding:"required,email"`
+	SeatIDs        []string `json:"seat_ids"`
+	Message        string   `json:"message" binding:"max=500"`
+}
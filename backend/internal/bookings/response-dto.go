@@ -3,15 +3,28 @@ package bookings
 import "time"
 
 type BookingConfirmationResponse struct {
-	BookingID  string           `json:"booking_id"`
-	BookingRef string           `json:"booking_ref"`
-	Status     string           `json:"status"`
-	TotalPrice float64          `json:"total_price"`
-	TotalSeats int              `json:"total_seats"`
-	Version    int              `json:"version"`
-	Seats      []BookedSeatInfo `json:"seats"`
-	Payment    PaymentInfo      `json:"payment"`
-	CreatedAt  time.Time        `json:"created_at"`
+	BookingID      string            `json:"booking_id"`
+	BookingRef     string            `json:"booking_ref"`
+	Status         string            `json:"status"`
+	TotalPrice     float64           `json:"total_price"`
+	Currency       string            `json:"currency"`
+	DisplayPrice   *DisplayPrice     `json:"display_price,omitempty"` // Total in the user's display currency
+	TotalSeats     int               `json:"total_seats"`
+	Version        int               `json:"version"`
+	PriceBreakdown *PriceBreakdown   `json:"price_breakdown,omitempty"` // How the total splits into ticket price, fees and taxes
+	Seats          []BookedSeatInfo  `json:"seats"`
+	Tickets        *BookedTicketInfo `json:"tickets,omitempty"` // General admission bookings
+	Payment        PaymentInfo       `json:"payment"`
+	Conflicts      []BookingConflict `json:"conflicts,omitempty"` // Overlapping bookings, reported in warn mode
+	CreatedAt      time.Time         `json:"created_at"`
+}
+
+type BookedTicketInfo struct {
+	TicketTypeID string  `json:"ticket_type_id"`
+	SectionID    string  `json:"section_id,omitempty"`
+	Name         string  `json:"name"`
+	Quantity     int     `json:"quantity"`
+	UnitPrice    float64 `json:"unit_price"`
 }
 
 type BookedSeatInfo struct {
@@ -21,6 +34,13 @@ type BookedSeatInfo struct {
 	Row         string  `json:"row"`
 	SectionName string  `json:"section_name"`
 	Price       float64 `json:"price"`
+
+	// Pricing snapshot stored with the seat booking
+	BasePrice       float64 `json:"base_price"`
+	PriceMultiplier float64 `json:"price_multiplier"`
+	ServiceFee      float64 `json:"service_fee"`
+	Tax             float64 `json:"tax"`
+	TotalPrice      float64 `json:"total_price"`
 }
 
 type PaymentInfo struct {
@@ -31,4 +51,7 @@ type PaymentInfo struct {
 	PaymentMethod string     `json:"payment_method"`
 	TransactionID string     `json:"transaction_id"`
 	ProcessedAt   *time.Time `json:"processed_at,omitempty"`
+	FailureReason string     `json:"failure_reason,omitempty"`
+	Attempts      int        `json:"attempts"`
+	NextRetryAt   *time.Time `json:"next_retry_at,omitempty"`
 }
@@ -7,20 +7,47 @@ import (
 )
 
 func SetupBookingRoutes(rg *gin.RouterGroup, controller *Controller) {
+	// Booking creation - partner keys with bookings:write can confirm holds
+	partnerBookings := rg.Group("/bookings")
+	partnerBookings.Use(middleware.JWTOrAPIKey(middleware.ScopeBookingsWrite), middleware.RequireRoles("USER", "ADMIN"))
+	{
+		partnerBookings.POST("/confirm", controller.ConfirmBooking) // POST /api/v1/bookings/confirm
+	}
+
 	// Booking routes
 	bookings := rg.Group("/bookings")
 	bookings.Use(middleware.JWTAuth(), middleware.RequireRoles("USER", "ADMIN"))
 	{
 		// Core booking operations
-		bookings.POST("/confirm", controller.ConfirmBooking)   // POST /api/v1/bookings/confirm
-		bookings.GET("/:id", controller.GetBooking)            // GET /api/v1/bookings/:id
-		bookings.POST("/:id/cancel", controller.CancelBooking) // POST /api/v1/bookings/:id/cancel
+		bookings.GET("/:id", controller.GetBooking)                    // GET /api/v1/bookings/:id
+		bookings.POST("/:id/cancel", controller.CancelBooking)         // POST /api/v1/bookings/:id/cancel
+		bookings.POST("/:id/resume-payment", controller.ResumePayment) // POST /api/v1/bookings/:id/resume-payment
+		bookings.POST("/:id/transfers", controller.CreateTransfer)     // POST /api/v1/bookings/:id/transfers
+	}
+
+	// Transfers between users
+	transfers := rg.Group("/transfers")
+	transfers.Use(middleware.JWTAuth(), middleware.RequireRoles("USER", "ADMIN"))
+	{
+		transfers.POST("/:id/accept", controller.AcceptTransfer)   // POST /api/v1/transfers/:id/accept
+		transfers.POST("/:id/decline", controller.DeclineTransfer) // POST /api/v1/transfers/:id/decline
+		transfers.POST("/:id/cancel", controller.CancelTransfer)   // POST /api/v1/transfers/:id/cancel
 	}
 
 	// User-specific booking routes
 	users := rg.Group("/users")
 	users.Use(middleware.JWTAuth(), middleware.RequireRoles("USER", "ADMIN"))
 	{
-		users.GET("/bookings", controller.GetUserBookings) // GET /api/v1/users/bookings
+		users.GET("/bookings", controller.GetUserBookings)               // GET /api/v1/users/bookings
+		users.GET("/bookings/conflicts", controller.GetBookingConflicts) // GET /api/v1/users/bookings/conflicts?event_id=
+		users.GET("/transfers", controller.GetUserTransfers)             // GET /api/v1/users/transfers
+	}
+
+	// Admin booking routes
+	adminBookings := rg.Group("/admin/bookings")
+	adminBookings.Use(middleware.JWTAuth(), middleware.RequirePermission(middleware.PermissionBookingsManage))
+	{
+		adminBookings.POST("/:id/check-in", controller.CheckInBooking)              // POST /api/v1/admin/bookings/:id/check-in
+		adminBookings.GET("/:id/ownership-history", controller.GetOwnershipHistory) // GET /api/v1/admin/bookings/:id/ownership-history
 	}
 }
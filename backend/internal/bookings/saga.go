@@ -0,0 +1,527 @@
+package bookings
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Saga statuses
+const (
+	SagaStatusRunning      = "RUNNING"
+	SagaStatusCompleted    = "COMPLETED"
+	SagaStatusCompensating = "COMPENSATING"
+	SagaStatusCompensated  = "COMPENSATED"
+	SagaStatusFailed       = "FAILED" // Recovery gave up, needs manual attention
+)
+
+// Booking confirmation saga steps, in execution order
+const (
+	SagaStepHoldSeats        = "hold_seats"
+	SagaStepAuthorizePayment = "authorize_payment"
+	SagaStepCreateBooking    = "create_booking"
+	SagaStepConvertWaitlist  = "convert_waitlist"
+	SagaStepCapturePayment   = "capture_payment"
+	SagaStepFinalizeBooking  = "finalize_booking"
+	SagaStepReleaseHold      = "release_hold"
+)
+
+// SagaConfig contains configuration for recovering interrupted booking confirmations
+type SagaConfig struct {
+	RecoveryInterval    time.Duration
+	StaleAfter          time.Duration // A running saga without progress for this long is treated as crashed
+	MaxRecoveryAttempts int
+	BatchSize           int
+}
+
+// DefaultSagaConfig returns default saga recovery configuration
+func DefaultSagaConfig() *SagaConfig {
+	return &SagaConfig{
+		RecoveryInterval:    time.Minute,     // Look for interrupted confirmations every minute
+		StaleAfter:          5 * time.Minute, // Well above the payment gateway timeout
+		MaxRecoveryAttempts: 5,               // Then mark the saga FAILED for manual follow-up
+		BatchSize:           20,              // Recover up to 20 sagas per run
+	}
+}
+
+// sagaStep is a forward action of the confirmation saga and the action that undoes it.
+// Compensations must be idempotent since recovery may run them more than once.
+type sagaStep struct {
+	name       string
+	run        func(ctx context.Context, sc *sagaContext) error
+	compensate func(ctx context.Context, sc *sagaContext) error
+}
+
+// sagaContext is the state shared by the steps of one saga run
+type sagaContext struct {
+	saga     *BookingSaga
+	booking  *Booking // In-memory booking, loaded from the database during recovery
+	declined error    // Authorization decline, handed to dunning once the booking exists
+}
+
+// confirmationSteps lists the booking confirmation saga. Seats are held before the
+// saga starts, so hold_seats only carries the compensation that gives them back.
+//
+// Payment is two-phase: funds are authorized while the hold is still valid, the
+// booking is created as CONFIRMING, and only then is the payment captured and the
+// booking confirmed. A failure before finalize_booking voids the authorization
+// (or refunds the capture), cancels the booking and releases the hold.
+func (s *service) confirmationSteps() []sagaStep {
+	return []sagaStep{
+		{name: SagaStepHoldSeats, compensate: s.releaseHoldStep},
+		{name: SagaStepAuthorizePayment, run: s.authorizePaymentStep, compensate: s.voidPaymentStep},
+		{name: SagaStepCreateBooking, run: s.createBookingStep, compensate: s.cancelBookingStep},
+		{name: SagaStepConvertWaitlist, run: s.convertWaitlistStep, compensate: s.revertWaitlistStep},
+		{name: SagaStepCapturePayment, run: s.capturePaymentStep},
+		{name: SagaStepFinalizeBooking, run: s.finalizeBookingStep},
+		{name: SagaStepReleaseHold, run: s.consumeHoldStep},
+	}
+}
+
+func (s *service) sagaStepIndex(name string) int {
+	for i, step := range s.confirmationSteps() {
+		if step.name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// runSaga runs the remaining steps and compensates everything done so far if one fails
+func (s *service) runSaga(ctx context.Context, sc *sagaContext) error {
+	steps := s.confirmationSteps()
+
+	for i := sc.saga.CompletedSteps; i < len(steps); i++ {
+		step := steps[i]
+
+		// Record the step before running it so a crash leaves a trail for recovery
+		sc.saga.CurrentStep = step.name
+		if err := s.repo.UpdateSaga(ctx, sc.saga); err != nil {
+			return s.abortSaga(ctx, sc, err)
+		}
+
+		if step.run != nil {
+			if err := step.run(ctx, sc); err != nil {
+				return s.abortSaga(ctx, sc, fmt.Errorf("%s failed: %w", step.name, err))
+			}
+		}
+		sc.saga.CompletedSteps = i + 1
+	}
+
+	now := time.Now()
+	sc.saga.Status = SagaStatusCompleted
+	sc.saga.CurrentStep = ""
+	sc.saga.FinishedAt = &now
+	if err := s.repo.UpdateSaga(ctx, sc.saga); err != nil {
+		// Every step went through; recovery will only re-release the hold and close the saga
+		log.Printf("⚠️ SAGA: Failed to mark saga %s completed: %v", sc.saga.ID, err)
+	}
+
+	return nil
+}
+
+// abortSaga compensates a failed saga and returns the original failure
+func (s *service) abortSaga(ctx context.Context, sc *sagaContext, cause error) error {
+	log.Printf("❌ SAGA: Booking %s failed at %s, compensating: %v", sc.saga.BookingID, sc.saga.CurrentStep, cause)
+
+	sc.saga.Status = SagaStatusCompensating
+	sc.saga.LastError = cause.Error()
+
+	// Compensation must finish even if the client has gone away
+	if err := s.compensateSaga(context.WithoutCancel(ctx), sc); err != nil {
+		log.Printf("❌ SAGA: Compensation of booking %s incomplete, left for recovery: %v", sc.saga.BookingID, err)
+	}
+
+	return cause
+}
+
+// compensateSaga undoes completed steps in reverse order. The step that was in
+// flight is compensated too since it may have partially applied.
+func (s *service) compensateSaga(ctx context.Context, sc *sagaContext) error {
+	steps := s.confirmationSteps()
+
+	last := sc.saga.CompletedSteps
+	if last >= len(steps) {
+		last = len(steps) - 1
+	}
+
+	for i := last; i >= 0; i-- {
+		step := steps[i]
+		sc.saga.CurrentStep = step.name
+		if err := s.repo.UpdateSaga(ctx, sc.saga); err != nil {
+			log.Printf("⚠️ SAGA: Failed to record compensation progress for saga %s: %v", sc.saga.ID, err)
+		}
+
+		if step.compensate != nil {
+			if err := step.compensate(ctx, sc); err != nil {
+				sc.saga.LastError = fmt.Sprintf("compensating %s: %v", step.name, err)
+				if updateErr := s.repo.UpdateSaga(ctx, sc.saga); updateErr != nil {
+					log.Printf("⚠️ SAGA: Failed to record compensation error for saga %s: %v", sc.saga.ID, updateErr)
+				}
+				return fmt.Errorf("compensating %s: %w", step.name, err)
+			}
+		}
+		sc.saga.CompletedSteps = i
+	}
+
+	now := time.Now()
+	sc.saga.Status = SagaStatusCompensated
+	sc.saga.CurrentStep = ""
+	sc.saga.FinishedAt = &now
+	if err := s.repo.UpdateSaga(ctx, sc.saga); err != nil {
+		return err
+	}
+
+	log.Printf("↩️ SAGA: Booking %s rolled back", sc.saga.BookingID)
+	return nil
+}
+
+//  STEPS
+
+func (s *service) createBookingStep(ctx context.Context, sc *sagaContext) error {
+	return s.repo.CreateAtomic(ctx, sc.booking)
+}
+
+func (s *service) cancelBookingStep(ctx context.Context, sc *sagaContext) error {
+	// Read from the database, the in-memory booking exists even if the insert failed
+	booking, err := s.repo.GetByID(ctx, sc.saga.BookingID)
+	if err != nil {
+		if err.Error() == "booking not found" {
+			return nil
+		}
+		return err
+	}
+	if booking.IsCancelled() {
+		return nil
+	}
+
+	if err := s.repo.Cancel(ctx, booking.ID); err != nil {
+		return err
+	}
+	if sc.booking != nil {
+		sc.booking.Status = "CANCELLED"
+	}
+	return nil
+}
+
+func (s *service) convertWaitlistStep(ctx context.Context, sc *sagaContext) error {
+	if !sc.saga.ConvertsWaitlist || s.waitlistService == nil {
+		return nil
+	}
+	return s.waitlistService.MarkAsConverted(ctx, sc.saga.UserID, sc.saga.EventID, sc.saga.BookingID)
+}
+
+func (s *service) revertWaitlistStep(ctx context.Context, sc *sagaContext) error {
+	if !sc.saga.ConvertsWaitlist || s.waitlistService == nil {
+		return nil
+	}
+	return s.waitlistService.RevertConversion(ctx, sc.saga.UserID, sc.saga.EventID, sc.saga.BookingID)
+}
+
+// authorizePaymentStep reserves the funds while the hold still keeps the seats.
+// A decline is not a saga failure: the booking is created awaiting payment and
+// dunning takes over, as it does for a declined one-step charge.
+func (s *service) authorizePaymentStep(ctx context.Context, sc *sagaContext) error {
+	if len(sc.booking.Payments) == 0 {
+		return fmt.Errorf("no payment record found for booking")
+	}
+	payment := &sc.booking.Payments[0]
+	payment.Attempts++
+
+	transactionID, err := s.paymentGateway.Authorize(ctx, payment)
+	if err != nil {
+		sc.declined = err
+		sc.booking.Status = "PENDING_PAYMENT"
+		sc.booking.PaymentDueAt = s.dunningConfig.paymentDueAt()
+		return nil
+	}
+
+	payment.MarkAuthorized(transactionID)
+	sc.booking.Status = "CONFIRMING"
+	sc.saga.TransactionID = transactionID
+	return nil
+}
+
+// capturePaymentStep takes the authorized funds. The capture is recorded right
+// away so recovery finalizes the booking instead of voiding a captured payment.
+func (s *service) capturePaymentStep(ctx context.Context, sc *sagaContext) error {
+	if len(sc.booking.Payments) == 0 {
+		return fmt.Errorf("no payment record found for booking")
+	}
+	payment := &sc.booking.Payments[0]
+
+	if sc.declined != nil {
+		return s.declinePayment(ctx, sc.booking, payment, sc.declined)
+	}
+	if !payment.IsAuthorized() {
+		return nil
+	}
+
+	if err := s.paymentGateway.Capture(ctx, payment); err != nil {
+		return fmt.Errorf("failed to capture payment: %w", err)
+	}
+
+	payment.MarkCompleted(payment.TransactionID)
+	return s.repo.UpdatePayment(ctx, payment)
+}
+
+// finalizeBookingStep confirms a booking whose payment was captured. Declined
+// bookings stay pending for dunning.
+func (s *service) finalizeBookingStep(ctx context.Context, sc *sagaContext) error {
+	booking, err := s.loadSagaBooking(ctx, sc)
+	if err != nil {
+		return err
+	}
+	if booking == nil || !booking.IsConfirming() || len(booking.Payments) == 0 {
+		return nil
+	}
+
+	payment := &booking.Payments[0]
+	if !payment.IsCompleted() {
+		return fmt.Errorf("payment for booking %s was not captured", booking.ID)
+	}
+	return s.settlePayment(ctx, booking, payment)
+}
+
+// voidPaymentStep releases the authorization or reverses the capture. When the
+// outcome was never recorded the gateway is asked to void anyway, which is a
+// no-op for transactions it never saw.
+func (s *service) voidPaymentStep(ctx context.Context, sc *sagaContext) error {
+	booking, err := s.loadSagaBooking(ctx, sc)
+	if err != nil {
+		return err
+	}
+	if booking == nil || len(booking.Payments) == 0 {
+		// The booking was never created, the saga still knows the transaction
+		if sc.saga.TransactionID == "" {
+			return nil
+		}
+		payment := &Payment{BookingID: sc.saga.BookingID, TransactionID: sc.saga.TransactionID}
+		if err := s.paymentGateway.Void(ctx, payment); err != nil {
+			return fmt.Errorf("failed to void payment: %w", err)
+		}
+		return nil
+	}
+
+	payment := &booking.Payments[0]
+	if payment.IsFailed() || payment.IsRefunded() || payment.IsVoided() {
+		return nil
+	}
+
+	if err := s.paymentGateway.Void(ctx, payment); err != nil {
+		return fmt.Errorf("failed to void payment: %w", err)
+	}
+
+	// The payment row is only missing when the booking insert failed
+	if !payment.IsPending() && payment.ID != uuid.Nil {
+		if payment.IsCompleted() {
+			payment.MarkRefunded()
+		} else {
+			payment.MarkVoided()
+		}
+		if err := s.repo.UpdatePayment(ctx, payment); err != nil {
+			return err
+		}
+	}
+
+	log.Printf("↩️ SAGA: Voided payment %s for booking %s", payment.TransactionID, booking.ID)
+	return nil
+}
+
+// releaseHoldStep frees the Redis hold. Holds expire on their own, so a failure
+// here is logged rather than failing the saga.
+func (s *service) releaseHoldStep(ctx context.Context, sc *sagaContext) error {
+	if err := s.seatService.ReleaseHold(ctx, sc.saga.HoldID); err != nil {
+		log.Printf("⚠️ SAGA: Failed to release hold %s: %v", sc.saga.HoldID, err)
+	}
+	return nil
+}
+
+// consumeHoldStep frees the Redis hold of a booked confirmation. Unlike a
+// plain release it keeps general admission tickets taken, they are sold now.
+func (s *service) consumeHoldStep(ctx context.Context, sc *sagaContext) error {
+	if err := s.seatService.ConsumeHold(ctx, sc.saga.HoldID); err != nil {
+		log.Printf("⚠️ SAGA: Failed to release hold %s: %v", sc.saga.HoldID, err)
+	}
+	return nil
+}
+
+// loadSagaBooking returns the saga's booking, or nil if it was never created
+func (s *service) loadSagaBooking(ctx context.Context, sc *sagaContext) (*Booking, error) {
+	if sc.booking != nil {
+		return sc.booking, nil
+	}
+
+	booking, err := s.repo.GetByID(ctx, sc.saga.BookingID)
+	if err != nil {
+		if err.Error() == "booking not found" {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	sc.booking = booking
+	return booking, nil
+}
+
+//  RECOVERY
+
+// RecoverStaleSagas finishes or compensates confirmations interrupted by a crash
+func (s *service) RecoverStaleSagas(ctx context.Context) (int, error) {
+	sagas, err := s.repo.ClaimStaleSagas(ctx, time.Now().Add(-s.sagaConfig.StaleAfter), s.sagaConfig.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range sagas {
+		if err := s.recoverSaga(ctx, &sagaContext{saga: &sagas[i]}); err != nil {
+			log.Printf("❌ SAGA: Recovery of saga %s failed: %v", sagas[i].ID, err)
+		}
+	}
+
+	return len(sagas), nil
+}
+
+func (s *service) recoverSaga(ctx context.Context, sc *sagaContext) error {
+	saga := sc.saga
+
+	if saga.RecoveryAttempts > s.sagaConfig.MaxRecoveryAttempts {
+		now := time.Now()
+		saga.Status = SagaStatusFailed
+		saga.FinishedAt = &now
+		log.Printf("🚨 SAGA: Giving up on saga %s for booking %s after %d recovery attempts: %s",
+			saga.ID, saga.BookingID, saga.RecoveryAttempts-1, saga.LastError)
+		return s.repo.UpdateSaga(ctx, saga)
+	}
+
+	booking, err := s.loadSagaBooking(ctx, sc)
+	if err != nil {
+		return err
+	}
+
+	if saga.Status == SagaStatusRunning {
+		// Once the capture or decline is recorded the booking stands: dunning
+		// owns declined payments, so only the steps after the capture are left
+		// to run. An authorization that was never captured is voided instead.
+		if booking != nil && len(booking.Payments) > 0 && (booking.Payments[0].IsCompleted() || booking.Payments[0].IsFailed()) {
+			if next := s.sagaStepIndex(SagaStepCapturePayment) + 1; saga.CompletedSteps < next {
+				saga.CompletedSteps = next
+			}
+			log.Printf("🔁 SAGA: Resuming booking %s after %s", saga.BookingID, SagaStepCapturePayment)
+			return s.runSaga(ctx, sc)
+		}
+
+		saga.Status = SagaStatusCompensating
+		saga.LastError = fmt.Sprintf("confirmation interrupted during %s", saga.CurrentStep)
+	}
+
+	log.Printf("🔁 SAGA: Compensating interrupted booking %s", saga.BookingID)
+	return s.compensateSaga(ctx, sc)
+}
+
+//  RECONCILIATION
+
+// ReconcileStuckBookings resolves bookings left CONFIRMING that no saga is
+// working on: a captured payment is confirmed, anything short of a capture is
+// voided and the booking cancelled. The hold is long gone by now, so its seats
+// were already given back.
+func (s *service) ReconcileStuckBookings(ctx context.Context) (int, error) {
+	ids, err := s.repo.ClaimStuckConfirmations(ctx, time.Now().Add(-s.sagaConfig.StaleAfter), s.sagaConfig.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, id := range ids {
+		if err := s.reconcileBooking(ctx, id); err != nil {
+			log.Printf("❌ SAGA: Reconciling booking %s failed: %v", id, err)
+		}
+	}
+
+	return len(ids), nil
+}
+
+func (s *service) reconcileBooking(ctx context.Context, bookingID uuid.UUID) error {
+	booking, err := s.repo.GetByID(ctx, bookingID)
+	if err != nil {
+		return err
+	}
+	if !booking.IsConfirming() {
+		return nil
+	}
+
+	if len(booking.Payments) > 0 && booking.Payments[0].IsCompleted() {
+		if err := s.settlePayment(ctx, booking, &booking.Payments[0]); err != nil {
+			return err
+		}
+		log.Printf("🩹 SAGA: Confirmed stuck booking %s, its payment was captured", booking.ID)
+		return nil
+	}
+
+	sc := &sagaContext{saga: &BookingSaga{BookingID: booking.ID}, booking: booking}
+	if err := s.voidPaymentStep(ctx, sc); err != nil {
+		return err
+	}
+	if err := s.repo.Cancel(ctx, booking.ID); err != nil {
+		return err
+	}
+
+	log.Printf("🩹 SAGA: Cancelled stuck booking %s and voided its authorization", booking.ID)
+	return nil
+}
+
+// SagaRecoveryJob periodically recovers interrupted booking confirmations and
+// reconciles bookings stuck in CONFIRMING
+type SagaRecoveryJob struct {
+	service Service
+	config  *SagaConfig
+	done    chan struct{}
+}
+
+// NewSagaRecoveryJob creates a new saga recovery job
+func NewSagaRecoveryJob(service Service, config *SagaConfig) *SagaRecoveryJob {
+	if config == nil {
+		config = DefaultSagaConfig()
+	}
+
+	return &SagaRecoveryJob{
+		service: service,
+		config:  config,
+		done:    make(chan struct{}),
+	}
+}
+
+// Start starts the saga recovery job
+func (j *SagaRecoveryJob) Start(ctx context.Context) {
+	log.Printf("🔁 SAGA: Starting recovery job with %v interval", j.config.RecoveryInterval)
+	go j.run(ctx)
+}
+
+// Stop stops the saga recovery job
+func (j *SagaRecoveryJob) Stop() {
+	log.Println("🔁 SAGA: Stopping recovery job...")
+	close(j.done)
+}
+
+func (j *SagaRecoveryJob) run(ctx context.Context) {
+	ticker := time.NewTicker(j.config.RecoveryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := j.service.RecoverStaleSagas(ctx); err != nil {
+				log.Printf("❌ SAGA: %v", err)
+			}
+			if _, err := j.service.ReconcileStuckBookings(ctx); err != nil {
+				log.Printf("❌ SAGA: %v", err)
+			}
+		case <-j.done:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package bookings
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"evently/pkg/currency"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SeatCancellation is what cancelling some seats of a booking takes off it. The
+// booking keeps its reference and its other seats, with totals and charges
+// reduced by the cancelled seats' share. Cancellations are built by
+// PrepareSeatCancellation and written by ApplySeatCancellation, inside the
+// caller's transaction.
+type SeatCancellation struct {
+	BookingID      uuid.UUID
+	SeatIDs        []uuid.UUID
+	Price          float64 // What the customer paid for the seats, fees and taxes included
+	NonRefundable  float64 // Part of Price kept whatever the cancellation policy
+	RemainingSeats int
+	FreedSeats     []FreedSeat
+
+	booking *Booking // With reduced totals and charges
+}
+
+// PrepareSeatCancellation works out the cancellation of some of a confirmed
+// booking's seats without writing it. The booking must still be at
+// expectedVersion and keep at least one seat; cancelling every seat is
+// cancelling the booking.
+func (s *service) PrepareSeatCancellation(ctx context.Context, bookingID uuid.UUID, expectedVersion int, seatIDs []uuid.UUID) (*SeatCancellation, error) {
+	booking, err := s.repo.GetByID(ctx, bookingID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get booking: %w", err)
+	}
+	if !booking.IsConfirmed() {
+		return nil, fmt.Errorf("only confirmed bookings can have seats cancelled")
+	}
+	if booking.CheckedInAt != nil {
+		return nil, fmt.Errorf("booking is already checked in")
+	}
+	if len(booking.TicketBookings) > 0 {
+		return nil, fmt.Errorf("bookings with general admission tickets can only be cancelled whole")
+	}
+	if booking.Version != expectedVersion {
+		return nil, fmt.Errorf("%w (version mismatch: expected %d, got %d)",
+			ErrBookingModified, expectedVersion, booking.Version)
+	}
+
+	cancelled, share, cancelledSeatPrice := seatShare(booking, seatIDs)
+	if len(cancelled) != len(seatIDs) {
+		return nil, fmt.Errorf("seats are no longer part of this booking")
+	}
+	if len(cancelled) >= len(booking.SeatBookings) {
+		return nil, fmt.Errorf("cancelling every seat cancels the booking")
+	}
+
+	result := &SeatCancellation{
+		BookingID: booking.ID,
+		SeatIDs:   seatIDs,
+		Price:     splitPrice(booking.Charges, share, cancelledSeatPrice),
+		booking:   booking,
+	}
+	for _, sb := range cancelled {
+		result.NonRefundable += sb.NonRefundable
+		seatID, sectionID := sb.SeatID, sb.SectionID
+		result.FreedSeats = append(result.FreedSeats, FreedSeat{SeatID: &seatID, SectionID: &sectionID, Price: sb.SeatPrice})
+	}
+	result.NonRefundable = roundAmount(result.NonRefundable)
+
+	for i := range booking.Charges {
+		charge := &booking.Charges[i]
+		charge.Amount = roundAmount(charge.Amount - roundAmount(charge.Amount*share))
+	}
+	booking.TotalSeats -= len(cancelled)
+	booking.TotalPrice = roundAmount(booking.TotalPrice - result.Price)
+	booking.BaseTotalPrice = currency.Convert(booking.TotalPrice, booking.ExchangeRate)
+	result.RemainingSeats = booking.TotalSeats
+
+	return result, nil
+}
+
+// ApplySeatCancellation writes a prepared seat cancellation in tx. The booking
+// must still be the version the cancellation was prepared from, confirmed and
+// not checked in.
+func ApplySeatCancellation(tx *gorm.DB, cancellation *SeatCancellation) error {
+	booking := cancellation.booking
+	result := tx.Model(&Booking{}).
+		Where("id = ? AND version = ? AND status = 'CONFIRMED' AND checked_in_at IS NULL", booking.ID, booking.Version).
+		Updates(map[string]interface{}{
+			"total_seats":      booking.TotalSeats,
+			"total_price":      booking.TotalPrice,
+			"base_total_price": booking.BaseTotalPrice,
+			"updated_at":       time.Now(),
+			"version":          gorm.Expr("version + 1"),
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to update booking: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrBookingModified
+	}
+
+	// Deleting the seat bookings frees the seats for future bookings
+	result = tx.Where("booking_id = ? AND seat_id IN ?", booking.ID, cancellation.SeatIDs).Delete(&SeatBooking{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete seat bookings: %w", result.Error)
+	}
+	if int(result.RowsAffected) != len(cancellation.SeatIDs) {
+		return fmt.Errorf("seats are no longer part of this booking")
+	}
+
+	for _, charge := range booking.Charges {
+		if err := tx.Model(&BookingCharge{}).Where("id = ?", charge.ID).Update("amount", charge.Amount).Error; err != nil {
+			return fmt.Errorf("failed to update booking charges: %w", err)
+		}
+	}
+	return nil
+}
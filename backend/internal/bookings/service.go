@@ -3,14 +3,25 @@ package bookings
 import (
 	"context"
 	"crypto/rand"
+	"errors"
 	"fmt"
+	"log"
+	"math"
 	"math/big"
 	"strings"
 	"time"
 
+	"evently/internal/outbox"
+	"evently/pkg/currency"
+	"evently/pkg/metrics"
+
 	"github.com/google/uuid"
 )
 
+// ErrBookingModified is returned when a booking changed after it was read, so
+// an update checked against its version was refused
+var ErrBookingModified = errors.New("booking was modified by another process")
+
 // BookingData represents booking data for external services
 type BookingData struct {
 	ID         uuid.UUID `json:"id"`
@@ -29,11 +40,25 @@ type SeatService interface {
 	ReleaseHold(ctx context.Context, holdID string) error
 	GetSeatsByHoldID(ctx context.Context, holdID string) ([]SeatInfo, error)
 	GetHoldDetails(ctx context.Context, holdID string) (*SeatHoldDetails, error)
+
+	// General admission
+	GetTicketsByHoldID(ctx context.Context, holdID string) (*TicketHoldInfo, error)
+	ConsumeHold(ctx context.Context, holdID string) error
+	ReturnTickets(ctx context.Context, ticketTypeID uuid.UUID, quantity int) error
 }
 
 type WaitlistService interface {
 	GetWaitlistStatusForBooking(ctx context.Context, userID, eventID uuid.UUID) (*WaitlistStatusForBooking, error)
 	MarkAsConverted(ctx context.Context, userID, eventID, bookingID uuid.UUID) error
+	RevertConversion(ctx context.Context, userID, eventID, bookingID uuid.UUID) error
+	ProcessCancellation(ctx context.Context, eventID uuid.UUID, freedTickets int, freed []FreedSeat) error
+}
+
+// FreedSeat is a seat or general admission ticket a cancelled booking returns to sale
+type FreedSeat struct {
+	SeatID    *uuid.UUID // Nil for general admission tickets
+	SectionID *uuid.UUID
+	Price     float64
 }
 
 type WaitlistStatusForBooking struct {
@@ -42,11 +67,23 @@ type WaitlistStatusForBooking struct {
 }
 
 type SeatHoldDetails struct {
-	HoldID  string   `json:"hold_id"`
-	UserID  string   `json:"user_id"`
-	EventID string   `json:"event_id"`
-	SeatIDs []string `json:"seat_ids"`
-	TTL     int      `json:"ttl_seconds"`
+	HoldID       string   `json:"hold_id"`
+	UserID       string   `json:"user_id"`
+	EventID      string   `json:"event_id"`
+	SeatIDs      []string `json:"seat_ids"`
+	TicketTypeID string   `json:"ticket_type_id,omitempty"` // Set on general admission holds
+	Quantity     int      `json:"quantity,omitempty"`
+	TTL          int      `json:"ttl_seconds"`
+}
+
+// TicketHoldInfo represents the general admission tickets of a hold
+type TicketHoldInfo struct {
+	TicketTypeID uuid.UUID  `json:"ticket_type_id"`
+	SectionID    *uuid.UUID `json:"section_id,omitempty"`
+	Name         string     `json:"name"`
+	Quantity     int        `json:"quantity"`
+	UnitPrice    float64    `json:"unit_price"`
+	BasePrice    float64    `json:"base_price"` // Event base price per ticket, before the multiplier
 }
 
 type Service interface {
@@ -57,16 +94,61 @@ type Service interface {
 	CancelBooking(ctx context.Context, bookingID uuid.UUID, userID uuid.UUID) error
 	CancelBookingInternal(ctx context.Context, bookingID uuid.UUID) error
 	CancelBookingWithVersion(ctx context.Context, bookingID uuid.UUID, expectedVersion int) error
+	PrepareSeatCancellation(ctx context.Context, bookingID uuid.UUID, expectedVersion int, seatIDs []uuid.UUID) (*SeatCancellation, error)
+	CheckInBooking(ctx context.Context, bookingID uuid.UUID) (*Booking, error)
+
+	// Fees and taxes
+	SetPricingConfig(config *PricingConfig)
+	SetCurrencyProvider(provider currency.Provider)
+
+	// Overlapping bookings
+	SetConflictConfig(config *ConflictConfig)
+	GetBookingConflicts(ctx context.Context, userID, eventID uuid.UUID) ([]BookingConflict, error)
 
 	// Payment operations
+	SetPaymentGateway(gateway PaymentGateway)
+	SetDunningConfig(config *DunningConfig)
 	ProcessPayment(ctx context.Context, bookingID uuid.UUID, amount float64, method string) (*PaymentInfo, error)
+	ResumePayment(ctx context.Context, bookingID uuid.UUID, userID uuid.UUID, req ResumePaymentRequest) (*PaymentInfo, error)
+	RetryDuePayments(ctx context.Context) (int, error)
+	ExpireUnpaidBookings(ctx context.Context) (int, error)
+
+	// Confirmation saga recovery
+	SetSagaConfig(config *SagaConfig)
+	RecoverStaleSagas(ctx context.Context) (int, error)
+	ReconcileStuckBookings(ctx context.Context) (int, error)
+
+	// Transfers to other users
+	SetTransferConfig(config *TransferConfig)
+	CreateTransfer(ctx context.Context, bookingID, userID uuid.UUID, req CreateTransferRequest) (*BookingTransfer, error)
+	GetUserTransfers(ctx context.Context, userID uuid.UUID) ([]BookingTransfer, error)
+	AcceptTransfer(ctx context.Context, transferID, userID uuid.UUID) (*Booking, error)
+	DeclineTransfer(ctx context.Context, transferID, userID uuid.UUID) (*BookingTransfer, error)
+	CancelTransfer(ctx context.Context, transferID, userID uuid.UUID) (*BookingTransfer, error)
+	GetOwnershipHistory(ctx context.Context, bookingID uuid.UUID) ([]BookingOwnershipChange, error)
+
+	// Ownership moves driven by other modules, such as resale
+	CheckTransferableSeats(ctx context.Context, bookingID, ownerID uuid.UUID, seatIDs []string) (*TransferableSeats, error)
+	PrepareOwnershipMove(ctx context.Context, bookingID, fromUserID, toUserID uuid.UUID, seatIDs []uuid.UUID, reason string) (*OwnershipMove, error)
+
+	// Double booking detection
+	SetConsistencyConfig(config *ConsistencyConfig)
+	CheckSeatConsistency(ctx context.Context) (*SeatConsistencyReport, error)
 }
 
 // service implements the Service interface
 type service struct {
-	repo            Repository
-	seatService     SeatService
-	waitlistService WaitlistService
+	repo              Repository
+	seatService       SeatService
+	waitlistService   WaitlistService
+	paymentGateway    PaymentGateway
+	dunningConfig     *DunningConfig
+	sagaConfig        *SagaConfig
+	conflictConfig    *ConflictConfig
+	pricingConfig     *PricingConfig
+	transferConfig    *TransferConfig
+	consistencyConfig *ConsistencyConfig
+	currencies        currency.Provider
 }
 
 // HoldValidationResult represents the result of hold validation
@@ -85,17 +167,36 @@ type SeatInfo struct {
 	SeatNumber  string    `json:"seat_number"`
 	Row         string    `json:"row"`
 	Price       float64   `json:"price"`
+	BasePrice   float64   `json:"base_price"` // Event base price, before the section multiplier
 	SectionName string    `json:"section_name"`
 }
 
 func NewService(repo Repository, seatService SeatService, waitlistService WaitlistService) Service {
 	return &service{
-		repo:            repo,
-		seatService:     seatService,
-		waitlistService: waitlistService,
+		repo:              repo,
+		seatService:       seatService,
+		waitlistService:   waitlistService,
+		paymentGateway:    MockPaymentGateway{},
+		dunningConfig:     DefaultDunningConfig(),
+		sagaConfig:        DefaultSagaConfig(),
+		conflictConfig:    DefaultConflictConfig(),
+		transferConfig:    DefaultTransferConfig(),
+		consistencyConfig: DefaultConsistencyConfig(),
 	}
 }
 
+func (s *service) SetPaymentGateway(gateway PaymentGateway) {
+	s.paymentGateway = gateway
+}
+
+func (s *service) SetDunningConfig(config *DunningConfig) {
+	s.dunningConfig = config
+}
+
+func (s *service) SetSagaConfig(config *SagaConfig) {
+	s.sagaConfig = config
+}
+
 func (s *service) ConfirmBooking(ctx context.Context, userID uuid.UUID, req BookingConfirmationRequest) (*BookingConfirmationResponse, error) {
 	// Step 1: Validate the hold
 	holdValidation, err := s.seatService.ValidateHold(ctx, req.HoldID, userID.String())
@@ -125,6 +226,13 @@ func (s *service) ConfirmBooking(ctx context.Context, userID uuid.UUID, req Book
 		return nil, fmt.Errorf("invalid event ID format: %w", err)
 	}
 
+	// Step 1.8: Check for bookings at the same time, blocking or reporting them per deployment
+	conflicts, err := s.checkConflicts(ctx, userID, eventIDForWaitlist)
+	if err != nil {
+		return nil, err
+	}
+
+	convertsWaitlist := false
 	if s.waitlistService != nil {
 		waitlistStatus, err := s.waitlistService.GetWaitlistStatusForBooking(ctx, userID, eventIDForWaitlist)
 		if err == nil && waitlistStatus != nil {
@@ -134,6 +242,7 @@ func (s *service) ConfirmBooking(ctx context.Context, userID uuid.UUID, req Book
 				if waitlistStatus.IsExpired {
 					return nil, fmt.Errorf("waitlist booking window has expired - you have been moved back to the queue")
 				}
+				convertsWaitlist = true
 			} else if waitlistStatus.Status == "ACTIVE" {
 				// User is on waitlist but not notified yet
 				return nil, fmt.Errorf("you are still on the waitlist and have not been notified yet")
@@ -146,40 +255,97 @@ func (s *service) ConfirmBooking(ctx context.Context, userID uuid.UUID, req Book
 		// If no waitlist entry found, user can book normally (not from waitlist)
 	}
 
-	// Step 2: Get seat information for pricing
-	seats, err := s.seatService.GetSeatsByHoldID(ctx, req.HoldID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get seats for hold: %w", err)
-	}
-
-	if len(seats) == 0 {
-		return nil, fmt.Errorf("no seats found for hold")
-	}
-
-	// Step 3: Calculate total amount
-	var totalAmount float64
+	// Step 2-3: Price the hold, seat by seat or as a general admission quantity
+	var ticketAmount, baseAmount float64
+	var totalSeats int
 	var seatBookings []SeatBooking
+	var ticketBookings []TicketBooking
 	var bookedSeats []BookedSeatInfo
+	var bookedTickets *BookedTicketInfo
 
-	for _, seat := range seats {
-		totalAmount += seat.Price
+	if holdDetails.TicketTypeID != "" {
+		tickets, err := s.seatService.GetTicketsByHoldID(ctx, req.HoldID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tickets for hold: %w", err)
+		}
+		if tickets.Quantity <= 0 {
+			return nil, fmt.Errorf("no tickets found for hold")
+		}
+
+		totalSeats = tickets.Quantity
+		ticketAmount = tickets.UnitPrice * float64(tickets.Quantity)
+		baseAmount = tickets.BasePrice * float64(tickets.Quantity)
+		ticketBookings = append(ticketBookings, TicketBooking{
+			TicketTypeID: tickets.TicketTypeID,
+			SectionID:    tickets.SectionID,
+			Quantity:     tickets.Quantity,
+			UnitPrice:    tickets.UnitPrice,
+		})
+
+		bookedTickets = &BookedTicketInfo{
+			TicketTypeID: tickets.TicketTypeID.String(),
+			Name:         tickets.Name,
+			Quantity:     tickets.Quantity,
+			UnitPrice:    tickets.UnitPrice,
+		}
+		if tickets.SectionID != nil {
+			bookedTickets.SectionID = tickets.SectionID.String()
+		}
+	} else {
+		seats, err := s.seatService.GetSeatsByHoldID(ctx, req.HoldID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get seats for hold: %w", err)
+		}
 
-		seatBooking := SeatBooking{
-			SeatID:    seat.ID,
-			SectionID: seat.SectionID,
-			SeatPrice: seat.Price,
+		if len(seats) == 0 {
+			return nil, fmt.Errorf("no seats found for hold")
 		}
-		seatBookings = append(seatBookings, seatBooking)
-
-		bookedSeat := BookedSeatInfo{
-			SeatID:      seat.ID.String(),
-			SectionID:   seat.SectionID.String(),
-			SeatNumber:  seat.SeatNumber,
-			Row:         seat.Row,
-			SectionName: seat.SectionName,
-			Price:       seat.Price,
+
+		totalSeats = len(seats)
+		for _, seat := range seats {
+			ticketAmount += seat.Price
+			baseAmount += seat.BasePrice
+
+			// The hold prices a seat as base price × section multiplier
+			multiplier := 1.0
+			if seat.BasePrice > 0 {
+				multiplier = math.Round(seat.Price/seat.BasePrice*10000) / 10000
+			}
+
+			seatBooking := SeatBooking{
+				SeatID:          seat.ID,
+				SectionID:       seat.SectionID,
+				SeatPrice:       seat.Price,
+				SectionName:     seat.SectionName,
+				BasePrice:       seat.BasePrice,
+				PriceMultiplier: multiplier,
+			}
+			seatBookings = append(seatBookings, seatBooking)
+
+			bookedSeat := BookedSeatInfo{
+				SeatID:      seat.ID.String(),
+				SectionID:   seat.SectionID.String(),
+				SeatNumber:  seat.SeatNumber,
+				Row:         seat.Row,
+				SectionName: seat.SectionName,
+				Price:       seat.Price,
+			}
+			bookedSeats = append(bookedSeats, bookedSeat)
 		}
-		bookedSeats = append(bookedSeats, bookedSeat)
+	}
+
+	// Step 3.5: Add fees and taxes, the total is what the charges add up to
+	charges := s.priceCharges(baseAmount, ticketAmount, totalSeats)
+	totalAmount := sumCharges(charges)
+
+	// Each seat keeps its share, so a seat can be refunded or invoiced on its own
+	s.snapshotSeatPrices(seatBookings, charges)
+	for i := range bookedSeats {
+		bookedSeats[i].BasePrice = seatBookings[i].BasePrice
+		bookedSeats[i].PriceMultiplier = seatBookings[i].PriceMultiplier
+		bookedSeats[i].ServiceFee = seatBookings[i].ServiceFee
+		bookedSeats[i].Tax = seatBookings[i].Tax
+		bookedSeats[i].TotalPrice = seatBookings[i].TotalPrice
 	}
 
 	// Step 4: Generate booking reference
@@ -194,14 +360,26 @@ func (s *service) ConfirmBooking(ctx context.Context, userID uuid.UUID, req Book
 		return nil, fmt.Errorf("invalid event ID: %w", err)
 	}
 
+	// Charged in the event's currency, revenue is reported in the base currency
+	bookingCurrency, exchangeRate, err := s.bookingCurrency(ctx, eventUUID)
+	if err != nil {
+		return nil, err
+	}
+
 	booking := &Booking{
-		UserID:       userID,
-		EventID:      eventUUID,
-		TotalSeats:   len(seats),
-		TotalPrice:   totalAmount,
-		Status:       "CONFIRMED",
-		BookingRef:   bookingRef,
-		SeatBookings: seatBookings,
+		ID:             uuid.New(),
+		UserID:         userID,
+		EventID:        eventUUID,
+		TotalSeats:     totalSeats,
+		TotalPrice:     totalAmount,
+		Currency:       bookingCurrency,
+		ExchangeRate:   exchangeRate,
+		BaseTotalPrice: currency.Convert(totalAmount, exchangeRate),
+		Status:         "PENDING", // CONFIRMING once the payment is authorized
+		BookingRef:     bookingRef,
+		SeatBookings:   seatBookings,
+		TicketBookings: ticketBookings,
+		Charges:        charges,
 	}
 
 	// Step 6: Generate transaction ID for payment
@@ -210,77 +388,86 @@ func (s *service) ConfirmBooking(ctx context.Context, userID uuid.UUID, req Book
 	// Step 7: Create payment record with transaction ID
 	payment := &Payment{
 		Amount:        totalAmount,
-		Currency:      "INR",
+		Currency:      bookingCurrency,
 		Status:        "PENDING",
 		PaymentMethod: req.PaymentMethod,
 		TransactionID: transactionID,
 	}
 	booking.Payments = []Payment{*payment}
 
-	// Step 8: Extract seat IDs for final conflict check
-	seatIDs := make([]uuid.UUID, len(seats))
-	for i, seat := range seats {
-		seatIDs[i] = seat.ID
-	}
-
-	// Check for conflicts one more time before creating booking
-	conflictingSeats, err := s.repo.CheckSeatBookingConflicts(ctx, seatIDs, eventUUID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to check seat conflicts: %w", err)
-	}
-	if len(conflictingSeats) > 0 {
-		return nil, fmt.Errorf("seats are no longer available (conflicting seats: %v)", conflictingSeats)
-	}
+	// Step 8: Extract seat IDs for final conflict check. General admission
+	// capacity is checked when the booking is created.
+	if len(seatBookings) > 0 {
+		seatIDs := make([]uuid.UUID, len(seatBookings))
+		for i, seatBooking := range seatBookings {
+			seatIDs[i] = seatBooking.SeatID
+		}
 
-	// Process in atomic transaction (create booking, seat bookings, and payment)
-	if err := s.repo.CreateAtomic(ctx, booking); err != nil {
-		return nil, fmt.Errorf("failed to create booking atomically: %w", err)
+		// Check for conflicts one more time before creating booking
+		conflictingSeats, err := s.repo.CheckSeatBookingConflicts(ctx, seatIDs, eventUUID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check seat conflicts: %w", err)
+		}
+		if len(conflictingSeats) > 0 {
+			return nil, fmt.Errorf("seats are no longer available (conflicting seats: %v)", conflictingSeats)
+		}
 	}
 
-	// Step 9: Process mock payment
-	paymentInfo, err := s.ProcessPayment(ctx, booking.ID, totalAmount, req.PaymentMethod)
-	if err != nil {
-		return nil, fmt.Errorf("payment processing failed: %w", err)
+	// Steps 9-11 run as a saga: authorize the payment, create the booking,
+	// convert the waitlist entry, capture the payment, confirm the booking and
+	// release the hold. A failure part way through is compensated in reverse
+	// order, and the persisted saga lets the recovery job finish or roll back a
+	// confirmation interrupted by a crash.
+	saga := &BookingSaga{
+		BookingID:        booking.ID,
+		UserID:           userID,
+		EventID:          eventUUID,
+		HoldID:           req.HoldID,
+		TransactionID:    transactionID,
+		ConvertsWaitlist: convertsWaitlist,
+		Status:           SagaStatusRunning,
+		CompletedSteps:   s.sagaStepIndex(SagaStepHoldSeats) + 1, // Seats are already held
 	}
-
-	// Step 10: Mark waitlist as converted (if booking was from waitlist)
-	if s.waitlistService != nil {
-		fmt.Printf("🔄 BOOKING: Attempting to mark waitlist as converted for user %s, event %s, booking %s\n", userID, eventIDForWaitlist, booking.ID)
-		if err := s.waitlistService.MarkAsConverted(ctx, userID, eventIDForWaitlist, booking.ID); err != nil {
-			// Log warning but don't fail the booking since payment is processed
-			fmt.Printf("❌ BOOKING: Failed to mark waitlist as converted for user %s, booking %s: %v\n",
-				userID, booking.ID, err)
-		} else {
-			fmt.Printf("✅ BOOKING: Successfully marked waitlist as converted for user %s, booking %s\n", userID, booking.ID)
-		}
-	} else {
-		fmt.Printf("⚠️ BOOKING: Waitlist service is nil, cannot mark as converted\n")
+	if err := s.repo.CreateSaga(ctx, saga); err != nil {
+		return nil, fmt.Errorf("failed to start booking confirmation: %w", err)
 	}
 
-	// Step 11: Release Redis hold
-	if err := s.seatService.ReleaseHold(ctx, req.HoldID); err != nil {
-		// Log error but don't fail the booking since payment is processed
-		fmt.Printf("Warning: Failed to release hold %s: %v\n", req.HoldID, err)
+	if err := s.runSaga(ctx, &sagaContext{saga: saga, booking: booking}); err != nil {
+		metrics.BookingsTotal.Inc("failed")
+		return nil, fmt.Errorf("booking confirmation failed: %w", err)
 	}
+	metrics.BookingsTotal.Inc(strings.ToLower(booking.Status))
+	paymentInfo := booking.Payments[0].ToPaymentInfo()
 
 	// Step 12: Return response
 	response := &BookingConfirmationResponse{
-		BookingID:  booking.ID.String(),
-		BookingRef: booking.BookingRef,
-		Status:     booking.Status,
-		TotalPrice: booking.TotalPrice,
-		TotalSeats: booking.TotalSeats,
-		Version:    booking.Version,
-		Seats:      bookedSeats,
-		Payment:    *paymentInfo,
-		CreatedAt:  booking.CreatedAt,
+		BookingID:      booking.ID.String(),
+		BookingRef:     booking.BookingRef,
+		Status:         booking.Status,
+		TotalPrice:     booking.TotalPrice,
+		Currency:       booking.Currency,
+		DisplayPrice:   s.displayPrice(ctx, booking.TotalPrice, booking.Currency, s.displayCurrency(ctx, userID)),
+		TotalSeats:     booking.TotalSeats,
+		Version:        booking.Version,
+		PriceBreakdown: NewPriceBreakdown(charges),
+		Seats:          bookedSeats,
+		Tickets:        bookedTickets,
+		Payment:        paymentInfo,
+		Conflicts:      conflicts,
+		CreatedAt:      booking.CreatedAt,
 	}
 
 	return response, nil
 }
 
 func (s *service) GetBooking(ctx context.Context, bookingID uuid.UUID) (*Booking, error) {
-	return s.repo.GetByID(ctx, bookingID)
+	booking, err := s.repo.GetByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	booking.PriceBreakdown = NewPriceBreakdown(booking.Charges)
+	booking.DisplayPrice = s.displayPrice(ctx, booking.TotalPrice, booking.Currency, s.displayCurrency(ctx, booking.UserID))
+	return booking, nil
 }
 
 func (s *service) GetBookingData(ctx context.Context, bookingID uuid.UUID) (*BookingData, error) {
@@ -303,7 +490,15 @@ func (s *service) GetBookingData(ctx context.Context, bookingID uuid.UUID) (*Boo
 }
 
 func (s *service) GetUserBookings(ctx context.Context, userID uuid.UUID, limit, offset int) ([]Booking, error) {
-	return s.repo.GetByUserID(ctx, userID, limit, offset)
+	bookings, err := s.repo.GetByUserID(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	for i := range bookings {
+		bookings[i].PriceBreakdown = NewPriceBreakdown(bookings[i].Charges)
+	}
+	s.setDisplayPrices(ctx, userID, bookings)
+	return bookings, nil
 }
 
 func (s *service) CancelBooking(ctx context.Context, bookingID uuid.UUID, userID uuid.UUID) error {
@@ -328,6 +523,7 @@ func (s *service) CancelBooking(ctx context.Context, bookingID uuid.UUID, userID
 		return fmt.Errorf("failed to cancel booking: %w", err)
 	}
 
+	s.returnTickets(ctx, booking)
 	return nil
 }
 
@@ -349,6 +545,7 @@ func (s *service) CancelBookingInternal(ctx context.Context, bookingID uuid.UUID
 		return fmt.Errorf("failed to cancel booking: %w", err)
 	}
 
+	s.returnTickets(ctx, booking)
 	return nil
 }
 
@@ -367,8 +564,8 @@ func (s *service) CancelBookingWithVersion(ctx context.Context, bookingID uuid.U
 
 	// Validate version matches current state
 	if booking.Version != expectedVersion {
-		return fmt.Errorf("booking was modified by another process (version mismatch: expected %d, got %d)",
-			expectedVersion, booking.Version)
+		return fmt.Errorf("%w (version mismatch: expected %d, got %d)",
+			ErrBookingModified, expectedVersion, booking.Version)
 	}
 
 	// Cancel the booking with version check
@@ -376,12 +573,50 @@ func (s *service) CancelBookingWithVersion(ctx context.Context, bookingID uuid.U
 		return fmt.Errorf("failed to cancel booking with version: %w", err)
 	}
 
+	s.returnTickets(ctx, booking)
 	return nil
 }
 
-// processes a mock payment
+// returnTickets puts a cancelled booking's general admission tickets back on
+// sale. Seats need nothing, their seat bookings are deleted with the booking.
+func (s *service) returnTickets(ctx context.Context, booking *Booking) {
+	for _, ticketBooking := range booking.TicketBookings {
+		if err := s.seatService.ReturnTickets(ctx, ticketBooking.TicketTypeID, ticketBooking.Quantity); err != nil {
+			log.Printf("⚠️ Failed to return %d tickets of type %s for booking %s: %v",
+				ticketBooking.Quantity, ticketBooking.TicketTypeID, booking.ID, err)
+		}
+	}
+}
+
+// CheckInBooking records that a confirmed booking's holder was admitted to the event
+func (s *service) CheckInBooking(ctx context.Context, bookingID uuid.UUID) (*Booking, error) {
+	booking, err := s.repo.GetByID(ctx, bookingID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get booking: %w", err)
+	}
+
+	if booking.CheckedInAt != nil {
+		return nil, fmt.Errorf("booking is already checked in")
+	}
+	if !booking.IsConfirmed() {
+		return nil, fmt.Errorf("only confirmed bookings can be checked in")
+	}
+
+	now := time.Now()
+	checkedIn, err := s.repo.CheckIn(ctx, bookingID, now)
+	if err != nil {
+		return nil, err
+	}
+	if !checkedIn {
+		return nil, ErrBookingModified
+	}
+
+	booking.CheckedInAt = &now
+	return booking, nil
+}
+
+// ProcessPayment charges the booking's payment through the payment gateway
 func (s *service) ProcessPayment(ctx context.Context, bookingID uuid.UUID, amount float64, method string) (*PaymentInfo, error) {
-	// Get the existing payment record from the booking
 	booking, err := s.repo.GetByID(ctx, bookingID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get booking: %w", err)
@@ -391,26 +626,69 @@ func (s *service) ProcessPayment(ctx context.Context, bookingID uuid.UUID, amoun
 		return nil, fmt.Errorf("no payment record found for booking")
 	}
 
-	// Update the existing payment to completed status
 	payment := &booking.Payments[0]
-	now := time.Now()
-	payment.Status = "COMPLETED"
-	payment.ProcessedAt = &now
-	payment.UpdatedAt = now
-
-	if err := s.repo.UpdatePayment(ctx, payment); err != nil {
-		return nil, fmt.Errorf("failed to update payment record: %w", err)
-	}
-
-	return &PaymentInfo{
-		ID:            payment.ID.String(),
-		Amount:        payment.Amount,
-		Currency:      payment.Currency,
-		Status:        payment.Status,
-		PaymentMethod: payment.PaymentMethod,
-		TransactionID: payment.TransactionID,
-		ProcessedAt:   payment.ProcessedAt,
-	}, nil
+	if payment.IsCompleted() {
+		info := payment.ToPaymentInfo()
+		return &info, nil
+	}
+	if payment.IsAuthorized() {
+		// Capturing is up to the confirmation saga or the reconciler, a charge would take the money twice
+		return nil, fmt.Errorf("payment is authorized and awaiting capture")
+	}
+	if method != "" {
+		payment.PaymentMethod = method
+	}
+
+	if err := s.chargePayment(ctx, booking, payment); err != nil {
+		return nil, err
+	}
+
+	info := payment.ToPaymentInfo()
+	return &info, nil
+}
+
+// ResumePayment lets a user retry a failed payment right away instead of
+// waiting for the next scheduled retry
+func (s *service) ResumePayment(ctx context.Context, bookingID uuid.UUID, userID uuid.UUID, req ResumePaymentRequest) (*PaymentInfo, error) {
+	booking, err := s.repo.GetByID(ctx, bookingID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get booking: %w", err)
+	}
+
+	if booking.UserID != userID {
+		return nil, fmt.Errorf("unauthorized: booking does not belong to user")
+	}
+
+	if !booking.IsPendingPayment() || len(booking.Payments) == 0 || !booking.Payments[0].IsFailed() {
+		return nil, fmt.Errorf("booking is not awaiting payment")
+	}
+
+	return s.ProcessPayment(ctx, bookingID, booking.TotalPrice, req.PaymentMethod)
+}
+
+// buildConfirmationMessage creates the BOOKING_CONFIRMED outbox message for a booking
+func (s *service) buildConfirmationMessage(booking *Booking) (*outbox.Message, error) {
+	bookingID := booking.ID
+	eventID := booking.EventID
+	payload := &outbox.NotificationPayload{
+		Type:        "BOOKING_CONFIRMED",
+		RecipientID: booking.UserID,
+		EventID:     &eventID,
+		BookingID:   &bookingID,
+		TemplateData: map[string]interface{}{
+			"booking_number": booking.BookingRef,
+			"quantity":       booking.TotalSeats,
+			"total_amount":   booking.TotalPrice,
+		},
+	}
+
+	message, err := outbox.NewNotificationMessage(outbox.AggregateBooking, booking.ID,
+		fmt.Sprintf("booking:%s:confirmed", booking.ID), payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build confirmation notification: %w", err)
+	}
+
+	return message, nil
 }
 
 func (s *service) generateBookingReference() (string, error) {
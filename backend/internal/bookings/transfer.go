@@ -0,0 +1,444 @@
+package bookings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"evently/internal/outbox"
+	"evently/pkg/currency"
+
+	"github.com/google/uuid"
+)
+
+// Transfer statuses
+const (
+	TransferStatusPending   = "PENDING"   // Waiting for the recipient
+	TransferStatusAccepted  = "ACCEPTED"  // Ownership moved to the recipient
+	TransferStatusDeclined  = "DECLINED"  // Turned down by the recipient
+	TransferStatusCancelled = "CANCELLED" // Withdrawn by the sender
+	TransferStatusExpired   = "EXPIRED"   // Not accepted in time
+)
+
+// OwnershipChangeTransfer is the reason recorded for ownership moved by an accepted transfer
+const OwnershipChangeTransfer = "TRANSFER"
+
+var (
+	ErrTransferNotFound       = errors.New("transfer not found")
+	ErrTransferForbidden      = errors.New("transfer does not belong to user")
+	ErrTransferClosed         = errors.New("transfer is no longer pending")
+	ErrRecipientNotFound      = errors.New("no registered user with that email")
+	ErrBookingNotTransferable = errors.New("booking cannot be transferred")
+)
+
+// TransferConfig contains configuration for booking transfers
+type TransferConfig struct {
+	Expiry    time.Duration // How long the recipient has to accept, never past the event start
+	AcceptURL string        // Page where the recipient accepts, {transfer_id} is replaced
+}
+
+// DefaultTransferConfig returns default transfer configuration
+func DefaultTransferConfig() *TransferConfig {
+	return &TransferConfig{
+		Expiry:    48 * time.Hour,
+		AcceptURL: "http://localhost:3000/transfers/{transfer_id}",
+	}
+}
+
+// TransferUser is the sender or recipient of a transfer
+type TransferUser struct {
+	ID        uuid.UUID
+	FirstName string
+	LastName  string
+	Email     string
+}
+
+func (u *TransferUser) Name() string {
+	return strings.TrimSpace(u.FirstName + " " + u.LastName)
+}
+
+// TransferEvent is what a transfer needs to know about the booking's event
+type TransferEvent struct {
+	Name     string
+	DateTime time.Time
+	Status   string
+}
+
+// TransferCompletion is everything an accepted transfer writes
+type TransferCompletion struct {
+	Transfer *BookingTransfer
+	Move     *OwnershipMove
+}
+
+func (s *service) SetTransferConfig(config *TransferConfig) {
+	s.transferConfig = config
+}
+
+// CreateTransfer offers a confirmed booking, or some of its seats, to another
+// registered user. The recipient is invited by email and has until the offer
+// expires to accept.
+func (s *service) CreateTransfer(ctx context.Context, bookingID, userID uuid.UUID, req CreateTransferRequest) (*BookingTransfer, error) {
+	s.expireTransfers(ctx)
+
+	booking, err := s.repo.GetByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	event, err := s.checkTransferable(ctx, booking, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	seatIDs, err := transferSeats(booking, req.SeatIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	recipient, err := s.repo.GetTransferUserByEmail(ctx, strings.ToLower(strings.TrimSpace(req.RecipientEmail)))
+	if err != nil {
+		return nil, err
+	}
+	if recipient.ID == userID {
+		return nil, fmt.Errorf("%w: you can't transfer a booking to yourself", ErrBookingNotTransferable)
+	}
+	sender, err := s.repo.GetTransferUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The offer lapses when the event starts if that comes first
+	expiresAt := time.Now().Add(s.getTransferConfig().Expiry)
+	if event.DateTime.Before(expiresAt) {
+		expiresAt = event.DateTime
+	}
+
+	transfer := &BookingTransfer{
+		ID:             uuid.New(),
+		BookingID:      booking.ID,
+		EventID:        booking.EventID,
+		FromUserID:     userID,
+		ToUserID:       recipient.ID,
+		RecipientEmail: recipient.Email,
+		SeatIDs:        seatIDs,
+		Message:        strings.TrimSpace(req.Message),
+		Status:         TransferStatusPending,
+		ExpiresAt:      expiresAt,
+	}
+
+	seatCount := len(seatIDs)
+	if seatCount == 0 {
+		seatCount = booking.TotalSeats
+	}
+	message, err := s.buildTransferOfferedMessage(transfer, sender, event, seatCount)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CreateTransfer(ctx, transfer, message); err != nil {
+		if strings.Contains(err.Error(), "duplicate key") {
+			return nil, fmt.Errorf("%w: this booking already has a pending transfer", ErrBookingNotTransferable)
+		}
+		return nil, fmt.Errorf("failed to create transfer: %w", err)
+	}
+
+	log.Printf("🎟️ TRANSFER: Booking %s offered to user %s (%d seats), expires %s",
+		booking.ID, recipient.ID, seatCount, expiresAt.Format(time.RFC3339))
+	return transfer, nil
+}
+
+// GetUserTransfers lists the transfers a user has sent or received, newest first
+func (s *service) GetUserTransfers(ctx context.Context, userID uuid.UUID) ([]BookingTransfer, error) {
+	s.expireTransfers(ctx)
+	return s.repo.GetTransfersByUserID(ctx, userID)
+}
+
+// AcceptTransfer moves the offered booking or seats to the recipient. The
+// booking is reissued under a new reference, so tickets the sender still holds
+// no longer get anyone in. Returns the booking the recipient now holds.
+func (s *service) AcceptTransfer(ctx context.Context, transferID, userID uuid.UUID) (*Booking, error) {
+	transfer, err := s.getPendingTransfer(ctx, transferID)
+	if err != nil {
+		return nil, err
+	}
+	if transfer.ToUserID != userID {
+		return nil, ErrTransferForbidden
+	}
+
+	move, err := s.PrepareOwnershipMove(ctx, transfer.BookingID, transfer.FromUserID, transfer.ToUserID,
+		transfer.SeatIDs, OwnershipChangeTransfer)
+	if err != nil {
+		return nil, err
+	}
+	change := move.Change
+	change.TransferID = &transfer.ID
+
+	now := time.Now()
+	resultID := move.ResultBookingID()
+	transfer.Status = TransferStatusAccepted
+	transfer.RespondedAt = &now
+	transfer.ResultBookingID = &resultID
+
+	message, err := s.buildTransferAcceptedMessage(ctx, transfer, change)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CompleteTransfer(ctx, &TransferCompletion{Transfer: transfer, Move: move}, message); err != nil {
+		return nil, err
+	}
+
+	log.Printf("🎟️ TRANSFER: %d seats of booking %s moved from user %s to user %s as booking %s",
+		change.SeatCount, transfer.BookingID, transfer.FromUserID, transfer.ToUserID, resultID)
+	return s.GetBooking(ctx, resultID)
+}
+
+// DeclineTransfer lets the recipient turn an offer down
+func (s *service) DeclineTransfer(ctx context.Context, transferID, userID uuid.UUID) (*BookingTransfer, error) {
+	transfer, err := s.getPendingTransfer(ctx, transferID)
+	if err != nil {
+		return nil, err
+	}
+	if transfer.ToUserID != userID {
+		return nil, ErrTransferForbidden
+	}
+	return s.closeTransfer(ctx, transfer, TransferStatusDeclined)
+}
+
+// CancelTransfer lets the sender withdraw an offer that hasn't been accepted
+func (s *service) CancelTransfer(ctx context.Context, transferID, userID uuid.UUID) (*BookingTransfer, error) {
+	transfer, err := s.getPendingTransfer(ctx, transferID)
+	if err != nil {
+		return nil, err
+	}
+	if transfer.FromUserID != userID {
+		return nil, ErrTransferForbidden
+	}
+	return s.closeTransfer(ctx, transfer, TransferStatusCancelled)
+}
+
+// GetOwnershipHistory returns the ownership changes of a booking, including
+// seats split off it and the booking it was split from, oldest first
+func (s *service) GetOwnershipHistory(ctx context.Context, bookingID uuid.UUID) ([]BookingOwnershipChange, error) {
+	if _, err := s.repo.GetByID(ctx, bookingID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetOwnershipChanges(ctx, bookingID)
+}
+
+func (s *service) getTransferConfig() *TransferConfig {
+	if s.transferConfig == nil {
+		return DefaultTransferConfig()
+	}
+	return s.transferConfig
+}
+
+// expireTransfers closes offers past their expiry so the booking can be offered again
+func (s *service) expireTransfers(ctx context.Context) {
+	expired, err := s.repo.ExpireTransfers(ctx, time.Now())
+	if err != nil {
+		log.Printf("⚠️ Failed to expire booking transfers: %v", err)
+		return
+	}
+	if expired > 0 {
+		log.Printf("🎟️ TRANSFER: Expired %d unanswered transfers", expired)
+	}
+}
+
+func (s *service) getPendingTransfer(ctx context.Context, transferID uuid.UUID) (*BookingTransfer, error) {
+	s.expireTransfers(ctx)
+
+	transfer, err := s.repo.GetTransfer(ctx, transferID)
+	if err != nil {
+		return nil, err
+	}
+	if transfer.Status != TransferStatusPending {
+		return nil, fmt.Errorf("%w: it was %s", ErrTransferClosed, strings.ToLower(transfer.Status))
+	}
+	return transfer, nil
+}
+
+func (s *service) closeTransfer(ctx context.Context, transfer *BookingTransfer, status string) (*BookingTransfer, error) {
+	now := time.Now()
+	closed, err := s.repo.CloseTransfer(ctx, transfer.ID, status, now)
+	if err != nil {
+		return nil, err
+	}
+	if !closed {
+		return nil, ErrTransferClosed
+	}
+
+	transfer.Status = status
+	transfer.RespondedAt = &now
+	transfer.UpdatedAt = now
+	return transfer, nil
+}
+
+// checkTransferable verifies the booking belongs to the user and can still
+// change hands: confirmed, not checked in, and for an event yet to start
+func (s *service) checkTransferable(ctx context.Context, booking *Booking, ownerID uuid.UUID) (*TransferEvent, error) {
+	if booking.UserID != ownerID {
+		return nil, fmt.Errorf("unauthorized: booking does not belong to user")
+	}
+	if !booking.IsConfirmed() {
+		return nil, fmt.Errorf("%w: only confirmed bookings can be transferred", ErrBookingNotTransferable)
+	}
+	if booking.CheckedInAt != nil {
+		return nil, fmt.Errorf("%w: booking is already checked in", ErrBookingNotTransferable)
+	}
+
+	event, err := s.repo.GetTransferEvent(ctx, booking.EventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+	if strings.EqualFold(event.Status, "cancelled") {
+		return nil, fmt.Errorf("%w: event has been cancelled", ErrBookingNotTransferable)
+	}
+	if !event.DateTime.After(time.Now()) {
+		return nil, fmt.Errorf("%w: event has already started", ErrBookingNotTransferable)
+	}
+	return event, nil
+}
+
+// transferSeats validates the seats picked for a transfer. It returns nil when
+// the whole booking is transferred, which includes picking every seat.
+func transferSeats(booking *Booking, requested []string) ([]uuid.UUID, error) {
+	if len(requested) == 0 {
+		return nil, nil
+	}
+	if len(booking.SeatBookings) == 0 {
+		return nil, fmt.Errorf("%w: general admission bookings can only be transferred whole", ErrBookingNotTransferable)
+	}
+
+	booked := make(map[uuid.UUID]bool, len(booking.SeatBookings))
+	for _, sb := range booking.SeatBookings {
+		booked[sb.SeatID] = true
+	}
+
+	seen := make(map[uuid.UUID]bool, len(requested))
+	seatIDs := make([]uuid.UUID, 0, len(requested))
+	for _, raw := range requested {
+		seatID, err := uuid.Parse(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid seat ID %q", raw)
+		}
+		if !booked[seatID] {
+			return nil, fmt.Errorf("%w: seat %s is not part of this booking", ErrBookingNotTransferable, seatID)
+		}
+		if seen[seatID] {
+			continue
+		}
+		seen[seatID] = true
+		seatIDs = append(seatIDs, seatID)
+	}
+
+	if len(seatIDs) == len(booking.SeatBookings) {
+		return nil, nil
+	}
+	return seatIDs, nil
+}
+
+// splitBooking builds the recipient's booking for seats split off source and
+// takes them out of source's totals. Charges are divided in proportion to the
+// seats' prices; payments stay with the source booking.
+func splitBooking(source *Booking, seatIDs []uuid.UUID, recipientID uuid.UUID, ref string) (*Booking, error) {
+	seatBookings, share, movedSeatPrice := seatShare(source, seatIDs)
+	if len(seatBookings) != len(seatIDs) {
+		return nil, fmt.Errorf("%w: seats are no longer part of this booking", ErrBookingNotTransferable)
+	}
+
+	now := time.Now()
+	split := &Booking{
+		ID:           uuid.New(),
+		UserID:       recipientID,
+		EventID:      source.EventID,
+		TotalSeats:   len(seatBookings),
+		Status:       string(StatusConfirmed),
+		BookingRef:   ref,
+		Version:      1,
+		Currency:     source.Currency,
+		ExchangeRate: source.ExchangeRate,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+		SeatBookings: seatBookings,
+	}
+	split.TotalPrice = splitPrice(source.Charges, share, movedSeatPrice)
+	split.BaseTotalPrice = currency.Convert(split.TotalPrice, split.ExchangeRate)
+
+	for i := range source.Charges {
+		charge := &source.Charges[i]
+		amount := roundAmount(charge.Amount * share)
+		split.Charges = append(split.Charges, BookingCharge{
+			Type:       charge.Type,
+			Name:       charge.Name,
+			Rate:       charge.Rate,
+			Amount:     amount,
+			Refundable: charge.Refundable,
+		})
+		charge.Amount = roundAmount(charge.Amount - amount)
+	}
+
+	source.TotalSeats -= split.TotalSeats
+	source.TotalPrice = roundAmount(source.TotalPrice - split.TotalPrice)
+	source.BaseTotalPrice = currency.Convert(source.TotalPrice, source.ExchangeRate)
+	return split, nil
+}
+
+// buildTransferOfferedMessage creates the invitation sent to the recipient
+func (s *service) buildTransferOfferedMessage(transfer *BookingTransfer, sender *TransferUser, event *TransferEvent, seatCount int) (*outbox.Message, error) {
+	eventID := transfer.EventID
+	bookingID := transfer.BookingID
+	payload := &outbox.NotificationPayload{
+		Type:        "TICKET_TRANSFER_OFFERED",
+		RecipientID: transfer.ToUserID,
+		EventID:     &eventID,
+		BookingID:   &bookingID,
+		TemplateData: map[string]interface{}{
+			"event_title": event.Name,
+			"sender_name": sender.Name(),
+			"seat_count":  seatCount,
+			"message":     transfer.Message,
+			"expires_at":  transfer.ExpiresAt.UTC().Format("Mon, Jan 2, 2006 at 15:04 MST"),
+			"accept_url":  strings.ReplaceAll(s.getTransferConfig().AcceptURL, "{transfer_id}", transfer.ID.String()),
+		},
+	}
+
+	message, err := outbox.NewNotificationMessage(outbox.AggregateBookingTransfer, transfer.ID,
+		fmt.Sprintf("transfer:%s:offered", transfer.ID), payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build transfer notification: %w", err)
+	}
+	return message, nil
+}
+
+// buildTransferAcceptedMessage lets the sender know their tickets changed hands
+func (s *service) buildTransferAcceptedMessage(ctx context.Context, transfer *BookingTransfer, change *BookingOwnershipChange) (*outbox.Message, error) {
+	recipientName := transfer.RecipientEmail
+	if recipient, err := s.repo.GetTransferUser(ctx, transfer.ToUserID); err == nil && recipient.Name() != "" {
+		recipientName = recipient.Name()
+	}
+
+	eventID := transfer.EventID
+	bookingID := transfer.BookingID
+	payload := &outbox.NotificationPayload{
+		Type:        "TICKET_TRANSFER_ACCEPTED",
+		RecipientID: transfer.FromUserID,
+		EventID:     &eventID,
+		BookingID:   &bookingID,
+		TemplateData: map[string]interface{}{
+			"recipient_name": recipientName,
+			"seat_count":     change.SeatCount,
+			"booking_number": change.PreviousRef,
+			"remaining_ref":  change.RemainingRef, // Empty when the whole booking was transferred
+		},
+	}
+
+	message, err := outbox.NewNotificationMessage(outbox.AggregateBookingTransfer, transfer.ID,
+		fmt.Sprintf("transfer:%s:accepted", transfer.ID), payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build transfer notification: %w", err)
+	}
+	return message, nil
+}
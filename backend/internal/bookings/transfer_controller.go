@@ -0,0 +1,282 @@
+package bookings
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"evently/internal/shared/utils/response"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// CreateTransfer offers a booking, or some of its seats, to another user
+//
+// @Summary      Transfer booking
+// @Description  Offer a confirmed booking, or some of its seats, to another registered user. The recipient
+// @Description  is invited by email and has until the offer expires (or the event starts) to accept.
+// @Tags         Bookings
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "ID" Format(uuid)
+// @Param        request body CreateTransferRequest true "Request body"
+// @Success      201 {object} response.StandardApiResponse{data=BookingTransfer} "Transfer offered"
+// @Failure      400 {object} response.StandardApiResponse "Invalid request, unknown seats or a transfer to yourself"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse "Booking belongs to another user"
+// @Failure      404 {object} response.StandardApiResponse "Booking or recipient not found"
+// @Failure      409 {object} response.StandardApiResponse "Booking is not transferable or already has a pending transfer"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/bookings/{id}/transfers [post]
+func (c *Controller) CreateTransfer(ctx *gin.Context) {
+	bookingID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid booking ID", nil, nil)
+		return
+	}
+
+	userID, ok := transferUserID(ctx)
+	if !ok {
+		return
+	}
+
+	var req CreateTransferRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid request body", nil, err.Error())
+		return
+	}
+
+	transfer, err := c.service.CreateTransfer(ctx.Request.Context(), bookingID, userID, req)
+	if err != nil {
+		respondTransferError(ctx, err, "Failed to create transfer")
+		return
+	}
+
+	response.RespondJSON(ctx, "success", http.StatusCreated, "Transfer offered, the recipient has been invited by email", transfer, nil)
+}
+
+// GetUserTransfers lists the transfers the user has sent or received
+//
+// @Summary      Get user transfers
+// @Description  List the transfers the authenticated user has sent or received
+// @Tags         Bookings
+// @Produce      json
+// @Security     Bearer
+// @Success      200 {object} response.StandardApiResponse{data=object} "Transfers retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/users/transfers [get]
+func (c *Controller) GetUserTransfers(ctx *gin.Context) {
+	userID, ok := transferUserID(ctx)
+	if !ok {
+		return
+	}
+
+	transfers, err := c.service.GetUserTransfers(ctx.Request.Context(), userID)
+	if err != nil {
+		response.RespondJSON(ctx, "error", http.StatusInternalServerError, "Failed to get transfers", nil, err.Error())
+		return
+	}
+
+	response.RespondJSON(ctx, "success", http.StatusOK, "Transfers retrieved successfully", gin.H{
+		"transfers": transfers,
+		"count":     len(transfers),
+	}, nil)
+}
+
+// AcceptTransfer takes ownership of the offered booking or seats
+//
+// @Summary      Accept transfer
+// @Description  Take ownership of the offered booking or seats. The tickets are reissued under a new
+// @Description  booking reference; the sender's old reference stops working at the door.
+// @Tags         Bookings
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=Booking} "Transfer accepted"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse "Transfer was offered to another user"
+// @Failure      404 {object} response.StandardApiResponse "Transfer not found"
+// @Failure      409 {object} response.StandardApiResponse "Transfer is no longer pending or the booking changed"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/transfers/{id}/accept [post]
+func (c *Controller) AcceptTransfer(ctx *gin.Context) {
+	transferID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid transfer ID", nil, nil)
+		return
+	}
+
+	userID, ok := transferUserID(ctx)
+	if !ok {
+		return
+	}
+
+	booking, err := c.service.AcceptTransfer(ctx.Request.Context(), transferID, userID)
+	if err != nil {
+		respondTransferError(ctx, err, "Failed to accept transfer")
+		return
+	}
+
+	response.RespondJSON(ctx, "success", http.StatusOK, "Transfer accepted, the tickets are now yours", booking, nil)
+}
+
+// DeclineTransfer turns down an offered transfer
+//
+// @Summary      Decline transfer
+// @Description  Turn down a transfer offered to you
+// @Tags         Bookings
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=BookingTransfer} "Transfer declined"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse "Transfer was offered to another user"
+// @Failure      404 {object} response.StandardApiResponse "Transfer not found"
+// @Failure      409 {object} response.StandardApiResponse "Transfer is no longer pending"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/transfers/{id}/decline [post]
+func (c *Controller) DeclineTransfer(ctx *gin.Context) {
+	c.closeTransfer(ctx, c.service.DeclineTransfer, "decline", "Transfer declined")
+}
+
+// CancelTransfer withdraws a transfer the user offered
+//
+// @Summary      Cancel transfer
+// @Description  Withdraw a transfer you offered
+// @Tags         Bookings
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=BookingTransfer} "Transfer cancelled"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse "Transfer was offered by another user"
+// @Failure      404 {object} response.StandardApiResponse "Transfer not found"
+// @Failure      409 {object} response.StandardApiResponse "Transfer is no longer pending"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/transfers/{id}/cancel [post]
+func (c *Controller) CancelTransfer(ctx *gin.Context) {
+	c.closeTransfer(ctx, c.service.CancelTransfer, "cancel", "Transfer cancelled")
+}
+
+func (c *Controller) closeTransfer(ctx *gin.Context, closeFn func(ctx context.Context, transferID, userID uuid.UUID) (*BookingTransfer, error), action, message string) {
+	transferID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid transfer ID", nil, nil)
+		return
+	}
+
+	userID, ok := transferUserID(ctx)
+	if !ok {
+		return
+	}
+
+	transfer, err := closeFn(ctx.Request.Context(), transferID, userID)
+	if err != nil {
+		respondTransferError(ctx, err, "Failed to "+action+" transfer")
+		return
+	}
+
+	response.RespondJSON(ctx, "success", http.StatusOK, message, transfer, nil)
+}
+
+// GetOwnershipHistory shows support who has held a booking (admin only)
+//
+// @Summary      Get booking ownership history (Admin)
+// @Description  Trace who has held a booking, including seats split off into or out of it by transfers
+// @Description
+// @Description  Requires the `bookings:manage` permission.
+// @Tags         Admin Bookings
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=object} "Ownership history retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Booking not found"
+// @Router       /api/v1/admin/bookings/{id}/ownership-history [get]
+func (c *Controller) GetOwnershipHistory(ctx *gin.Context) {
+	bookingID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid booking ID", nil, nil)
+		return
+	}
+
+	changes, err := c.service.GetOwnershipHistory(ctx.Request.Context(), bookingID)
+	if err != nil {
+		respondTransferError(ctx, err, "Failed to get ownership history")
+		return
+	}
+
+	response.RespondJSON(ctx, "success", http.StatusOK, "Ownership history retrieved successfully", gin.H{
+		"changes": changes,
+		"count":   len(changes),
+	}, nil)
+}
+
+// transferUserID reads the authenticated user, responding with an error when it is missing
+func transferUserID(ctx *gin.Context) (uuid.UUID, bool) {
+	userIDInterface, exists := ctx.Get("user_id")
+	if !exists {
+		response.RespondJSON(ctx, "error", http.StatusUnauthorized, "User not authenticated", nil, nil)
+		return uuid.Nil, false
+	}
+
+	userIDStr, ok := userIDInterface.(string)
+	if !ok {
+		response.RespondJSON(ctx, "error", http.StatusInternalServerError, "Invalid user ID format", nil, nil)
+		return uuid.Nil, false
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid user ID", nil, nil)
+		return uuid.Nil, false
+	}
+	return userID, true
+}
+
+// respondTransferError answers a failed transfer operation with its status and error code
+func respondTransferError(ctx *gin.Context, err error, message string) {
+	status := transferErrorStatus(err)
+	response.RespondError(ctx, status, transferErrorCode(err, status), message, err.Error())
+}
+
+func transferErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrTransferNotFound), errors.Is(err, ErrRecipientNotFound),
+		strings.Contains(err.Error(), "booking not found"):
+		return http.StatusNotFound
+	case errors.Is(err, ErrTransferForbidden), strings.HasPrefix(err.Error(), "unauthorized"):
+		return http.StatusForbidden
+	case errors.Is(err, ErrTransferClosed), errors.Is(err, ErrBookingNotTransferable),
+		strings.Contains(err.Error(), "modified by another process"):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+func transferErrorCode(err error, status int) string {
+	switch {
+	case errors.Is(err, ErrTransferNotFound):
+		return response.CodeTransferNotFound
+	case errors.Is(err, ErrRecipientNotFound):
+		return response.CodeRecipientNotFound
+	case errors.Is(err, ErrTransferClosed):
+		return response.CodeTransferClosed
+	case errors.Is(err, ErrBookingNotTransferable):
+		return response.CodeBookingNotTransferable
+	default:
+		return bookingErrorCode(err, status)
+	}
+}
@@ -0,0 +1,51 @@
+package bookings
+
+import (
+	"fmt"
+	"time"
+
+	"evently/internal/webhooks"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Partner webhooks follow confirmed bookings: booking.confirmed when a payment
+// confirms a booking, and booking.cancelled when a confirmed booking is
+// cancelled. Pending bookings that never confirm send neither.
+
+// BookingWebhookData is the data of booking webhook events
+type BookingWebhookData struct {
+	BookingID   uuid.UUID  `json:"booking_id"`
+	BookingRef  string     `json:"booking_ref"`
+	EventID     uuid.UUID  `json:"event_id"`
+	UserID      uuid.UUID  `json:"user_id"`
+	Status      string     `json:"status"`
+	TotalSeats  int        `json:"total_seats"`
+	TotalPrice  float64    `json:"total_price"`
+	Currency    string     `json:"currency"`
+	CreatedAt   time.Time  `json:"created_at"`
+	CancelledAt *time.Time `json:"cancelled_at,omitempty"`
+}
+
+// enqueueBookingWebhook records a booking webhook event in the caller's transaction
+func enqueueBookingWebhook(tx *gorm.DB, booking *Booking, eventType string) error {
+	data := BookingWebhookData{
+		BookingID:   booking.ID,
+		BookingRef:  booking.BookingRef,
+		EventID:     booking.EventID,
+		UserID:      booking.UserID,
+		Status:      booking.Status,
+		TotalSeats:  booking.TotalSeats,
+		TotalPrice:  booking.TotalPrice,
+		Currency:    booking.Currency,
+		CreatedAt:   booking.CreatedAt,
+		CancelledAt: booking.CancelledAt,
+	}
+
+	event, err := webhooks.NewEvent(eventType, fmt.Sprintf("booking:%s:%s", booking.ID, eventType), data)
+	if err != nil {
+		return err
+	}
+	return webhooks.Enqueue(tx, event)
+}
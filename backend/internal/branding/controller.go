@@ -0,0 +1,189 @@
+package branding
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"evently/internal/shared/utils/response"
+	"evently/pkg/media"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type Controller struct {
+	service Service
+}
+
+func NewController(service Service) *Controller {
+	return &Controller{service: service}
+}
+
+// GetBranding godoc
+//
+// @Summary      Get organizer branding (Admin)
+// @Description  Returns the branding used on the caller's events, with platform defaults for unset fields
+// @Description
+// @Description  Requires the `notifications:manage` permission.
+// @Tags         Admin Branding
+// @Produce      json
+// @Security     Bearer
+// @Success      200 {object} response.StandardApiResponse{data=BrandingResponse} "Branding retrieved successfully"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/branding [get]
+func (ctrl *Controller) GetBranding(c *gin.Context) {
+	userID, ok := ctrl.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	branding, err := ctrl.service.GetBranding(c.Request.Context(), userID)
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusInternalServerError, "Failed to get branding", nil, err.Error())
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Branding retrieved successfully", branding, nil)
+}
+
+// UpdateBranding godoc
+//
+// @Summary      Update organizer branding (Admin)
+// @Description  Set the display name and brand colors used on the caller's event tickets, invoices and emails
+// @Description
+// @Description  Requires the `notifications:manage` permission.
+// @Tags         Admin Branding
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        request body UpdateBrandingRequest true "Request body"
+// @Success      200 {object} response.StandardApiResponse{data=BrandingResponse} "Branding updated successfully"
+// @Failure      400 {object} response.StandardApiResponse "Invalid color"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/branding [put]
+func (ctrl *Controller) UpdateBranding(c *gin.Context) {
+	userID, ok := ctrl.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateBrandingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid request body", nil, err.Error())
+		return
+	}
+
+	branding, err := ctrl.service.UpdateBranding(c.Request.Context(), userID, req)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if strings.HasPrefix(err.Error(), "invalid color") {
+			statusCode = http.StatusBadRequest
+		}
+		response.RespondJSON(c, "error", statusCode, err.Error(), nil, nil)
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Branding updated successfully", branding, nil)
+}
+
+// UploadLogo godoc
+//
+// @Summary      Upload organizer logo (Admin)
+// @Description  Upload a PNG, JPEG or WebP logo, replacing any existing one
+// @Description
+// @Description  Requires the `notifications:manage` permission.
+// @Tags         Admin Branding
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     Bearer
+// @Param        logo formData file true "Logo"
+// @Success      200 {object} response.StandardApiResponse{data=BrandingResponse} "Logo uploaded successfully"
+// @Failure      400 {object} response.StandardApiResponse "Missing file or unsupported format"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      413 {object} response.StandardApiResponse "Logo exceeds the maximum upload size"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/branding/logo [post]
+func (ctrl *Controller) UploadLogo(c *gin.Context) {
+	userID, ok := ctrl.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	fileHeader, err := c.FormFile("logo")
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "A logo file is required", nil, err.Error())
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Failed to read logo file", nil, err.Error())
+		return
+	}
+	defer file.Close()
+
+	branding, err := ctrl.service.UploadLogo(c.Request.Context(), userID, file)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, media.ErrTooLarge):
+			statusCode = http.StatusRequestEntityTooLarge
+		case strings.HasPrefix(err.Error(), "unsupported logo format"), err.Error() == "logo file is empty":
+			statusCode = http.StatusBadRequest
+		}
+		response.RespondJSON(c, "error", statusCode, err.Error(), nil, nil)
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Logo uploaded successfully", branding, nil)
+}
+
+// DeleteLogo godoc
+//
+// @Summary      Remove organizer logo (Admin)
+// @Description  Remove the logo so the platform logo is used again
+// @Description
+// @Description  Requires the `notifications:manage` permission.
+// @Tags         Admin Branding
+// @Produce      json
+// @Security     Bearer
+// @Success      200 {object} response.StandardApiResponse{data=BrandingResponse} "Logo removed successfully"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/branding/logo [delete]
+func (ctrl *Controller) DeleteLogo(c *gin.Context) {
+	userID, ok := ctrl.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	branding, err := ctrl.service.DeleteLogo(c.Request.Context(), userID)
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusInternalServerError, "Failed to delete logo", nil, err.Error())
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Logo removed successfully", branding, nil)
+}
+
+func (ctrl *Controller) currentUserID(c *gin.Context) (uuid.UUID, bool) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.RespondJSON(c, "error", http.StatusUnauthorized, "User not authenticated", nil, nil)
+		return uuid.Nil, false
+	}
+
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusInternalServerError, "Invalid user ID format", nil, nil)
+		return uuid.Nil, false
+	}
+	return userUUID, true
+}
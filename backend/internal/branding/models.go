@@ -0,0 +1,37 @@
+package branding
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Branding sources
+const (
+	SourceOrganizer = "ORGANIZER"
+	SourcePlatform  = "PLATFORM"
+)
+
+// OrganizerBranding holds the logo and colors an organizer uses on their events' tickets, invoices and emails
+type OrganizerBranding struct {
+	UserID         uuid.UUID `gorm:"type:uuid;primaryKey" json:"user_id"`
+	DisplayName    string    `gorm:"type:varchar(100)" json:"display_name"`
+	LogoKey        string    `gorm:"type:varchar(255)" json:"-"`
+	LogoURL        string    `gorm:"type:varchar(500)" json:"logo_url"`
+	PrimaryColor   string    `gorm:"type:varchar(7)" json:"primary_color"`
+	SecondaryColor string    `gorm:"type:varchar(7)" json:"secondary_color"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+func (OrganizerBranding) TableName() string {
+	return "organizer_brandings"
+}
+
+// PlatformBranding is the fallback used for any field an organizer has not set
+type PlatformBranding struct {
+	Name           string
+	LogoURL        string
+	PrimaryColor   string
+	SecondaryColor string
+}
@@ -0,0 +1,56 @@
+package branding
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	GetByUserID(ctx context.Context, userID uuid.UUID) (*OrganizerBranding, error)
+	GetByEventID(ctx context.Context, eventID uuid.UUID) (*OrganizerBranding, error)
+	Upsert(ctx context.Context, branding *OrganizerBranding) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) GetByUserID(ctx context.Context, userID uuid.UUID) (*OrganizerBranding, error) {
+	var branding OrganizerBranding
+	err := r.db.WithContext(ctx).First(&branding, "user_id = ?", userID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &branding, nil
+}
+
+// GetByEventID returns the branding of the organizer who created the event
+func (r *repository) GetByEventID(ctx context.Context, eventID uuid.UUID) (*OrganizerBranding, error) {
+	var branding OrganizerBranding
+	err := r.db.WithContext(ctx).
+		Table("organizer_brandings ob").
+		Select("ob.*").
+		Joins("JOIN events e ON e.created_by = ob.user_id").
+		Where("e.id = ?", eventID).
+		Take(&branding).Error
+	if err != nil {
+		return nil, err
+	}
+	return &branding, nil
+}
+
+func (r *repository) Upsert(ctx context.Context, branding *OrganizerBranding) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"display_name", "logo_key", "logo_url", "primary_color", "secondary_color", "updated_at"}),
+		}).
+		Create(branding).Error
+}
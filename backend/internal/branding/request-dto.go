@@ -0,0 +1,8 @@
+package branding
+
+// UpdateBrandingRequest sets organizer brand details, an empty string clears a field back to the platform default
+type UpdateBrandingRequest struct {
+	DisplayName    *string `json:"display_name" binding:"omitempty,max=100"`
+	PrimaryColor   *string `json:"primary_color"`
+	SecondaryColor *string `json:"secondary_color"`
+}
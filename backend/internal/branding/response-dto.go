@@ -0,0 +1,13 @@
+package branding
+
+import "time"
+
+// BrandingResponse is the effective branding after platform fallbacks are applied
+type BrandingResponse struct {
+	Name           string     `json:"name"`
+	LogoURL        string     `json:"logo_url"`
+	PrimaryColor   string     `json:"primary_color"`
+	SecondaryColor string     `json:"secondary_color"`
+	Source         string     `json:"source"` // ORGANIZER when any field is customised, PLATFORM otherwise
+	UpdatedAt      *time.Time `json:"updated_at,omitempty"`
+}
@@ -0,0 +1,19 @@
+package branding
+
+import (
+	"evently/internal/shared/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupBrandingRoutes(rg *gin.RouterGroup, controller *Controller) {
+	// Organizer branding - events are organised by admins, so branding belongs to the admin account
+	adminBranding := rg.Group("/admin/branding")
+	adminBranding.Use(middleware.JWTAuth(), middleware.RequirePermission(middleware.PermissionNotificationsManage))
+	{
+		adminBranding.GET("", controller.GetBranding)        // GET /api/v1/admin/branding
+		adminBranding.PUT("", controller.UpdateBranding)     // PUT /api/v1/admin/branding
+		adminBranding.POST("/logo", controller.UploadLogo)   // POST /api/v1/admin/branding/logo
+		adminBranding.DELETE("/logo", controller.DeleteLogo) // DELETE /api/v1/admin/branding/logo
+	}
+}
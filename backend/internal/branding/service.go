@@ -0,0 +1,279 @@
+package branding
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"evently/internal/shared/utils/constants"
+	"evently/pkg/cache"
+	"evently/pkg/media"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var hexColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// Allowed logo formats keyed by sniffed content type. SVG is intentionally
+// excluded since it can carry scripts and is rendered inline in emails.
+var logoExtensions = map[string]string{
+	"image/png":  ".png",
+	"image/jpeg": ".jpg",
+	"image/webp": ".webp",
+}
+
+type Service interface {
+	SetCacheService(cacheService cache.Service)
+
+	GetBranding(ctx context.Context, userID uuid.UUID) (*BrandingResponse, error)
+	UpdateBranding(ctx context.Context, userID uuid.UUID, req UpdateBrandingRequest) (*BrandingResponse, error)
+	UploadLogo(ctx context.Context, userID uuid.UUID, r io.Reader) (*BrandingResponse, error)
+	DeleteLogo(ctx context.Context, userID uuid.UUID) (*BrandingResponse, error)
+
+	// GetBrandingForEvent resolves the event organizer's branding, falling back to the platform
+	GetBrandingForEvent(ctx context.Context, eventID uuid.UUID) (*BrandingResponse, error)
+	GetPlatformBranding() *BrandingResponse
+}
+
+type service struct {
+	repo         Repository
+	store        media.Store
+	platform     PlatformBranding
+	cacheService cache.Service
+}
+
+func NewService(repo Repository, store media.Store, platform PlatformBranding) Service {
+	return &service{
+		repo:     repo,
+		store:    store,
+		platform: platform,
+	}
+}
+
+// SetCacheService injects the cache service dependency
+func (s *service) SetCacheService(cacheService cache.Service) {
+	s.cacheService = cacheService
+}
+
+func (s *service) GetBranding(ctx context.Context, userID uuid.UUID) (*BrandingResponse, error) {
+	branding, err := s.repo.GetByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return s.GetPlatformBranding(), nil
+		}
+		return nil, fmt.Errorf("failed to get branding: %w", err)
+	}
+	return s.resolve(branding), nil
+}
+
+func (s *service) UpdateBranding(ctx context.Context, userID uuid.UUID, req UpdateBrandingRequest) (*BrandingResponse, error) {
+	for _, color := range []*string{req.PrimaryColor, req.SecondaryColor} {
+		if color != nil && *color != "" && !hexColorPattern.MatchString(*color) {
+			return nil, fmt.Errorf("invalid color %q: must be a hex color like #1A2B3C", *color)
+		}
+	}
+
+	branding, err := s.getOrNew(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.DisplayName != nil {
+		branding.DisplayName = strings.TrimSpace(*req.DisplayName)
+	}
+	if req.PrimaryColor != nil {
+		branding.PrimaryColor = strings.ToUpper(*req.PrimaryColor)
+	}
+	if req.SecondaryColor != nil {
+		branding.SecondaryColor = strings.ToUpper(*req.SecondaryColor)
+	}
+
+	if err := s.save(ctx, branding); err != nil {
+		return nil, err
+	}
+	return s.resolve(branding), nil
+}
+
+func (s *service) UploadLogo(ctx context.Context, userID uuid.UUID, r io.Reader) (*BrandingResponse, error) {
+	if s.store == nil {
+		return nil, errors.New("media storage is not configured")
+	}
+
+	// Sniff the content type rather than trusting the client supplied header
+	head := make([]byte, 512)
+	n, err := io.ReadFull(r, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("logo file is empty")
+		}
+		return nil, fmt.Errorf("failed to read logo: %w", err)
+	}
+	head = head[:n]
+
+	ext, ok := logoExtensions[http.DetectContentType(head)]
+	if !ok {
+		return nil, errors.New("unsupported logo format: must be PNG, JPEG or WebP")
+	}
+
+	branding, err := s.getOrNew(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// A fresh key per upload lets clients and email providers cache logos indefinitely
+	key := fmt.Sprintf("branding/%s/logo-%d%s", userID, time.Now().UnixNano(), ext)
+	url, err := s.store.Save(ctx, key, io.MultiReader(bytes.NewReader(head), r))
+	if err != nil {
+		if errors.Is(err, media.ErrTooLarge) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to store logo: %w", err)
+	}
+
+	previousKey := branding.LogoKey
+	branding.LogoKey = key
+	branding.LogoURL = url
+
+	if err := s.save(ctx, branding); err != nil {
+		if delErr := s.store.Delete(ctx, key); delErr != nil {
+			log.Printf("Warning: failed to clean up logo %s: %v", key, delErr)
+		}
+		return nil, err
+	}
+
+	s.deleteLogoFile(ctx, previousKey)
+	return s.resolve(branding), nil
+}
+
+func (s *service) DeleteLogo(ctx context.Context, userID uuid.UUID) (*BrandingResponse, error) {
+	branding, err := s.repo.GetByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return s.GetPlatformBranding(), nil
+		}
+		return nil, fmt.Errorf("failed to get branding: %w", err)
+	}
+
+	previousKey := branding.LogoKey
+	branding.LogoKey = ""
+	branding.LogoURL = ""
+
+	if err := s.save(ctx, branding); err != nil {
+		return nil, err
+	}
+
+	s.deleteLogoFile(ctx, previousKey)
+	return s.resolve(branding), nil
+}
+
+func (s *service) GetBrandingForEvent(ctx context.Context, eventID uuid.UUID) (*BrandingResponse, error) {
+	cacheKey := constants.BuildEventBrandingKey(eventID.String())
+	if s.cacheService != nil {
+		var cached BrandingResponse
+		if err := s.cacheService.Get(ctx, cacheKey, &cached); err == nil {
+			return &cached, nil
+		}
+	}
+
+	var response *BrandingResponse
+	branding, err := s.repo.GetByEventID(ctx, eventID)
+	switch {
+	case err == nil:
+		response = s.resolve(branding)
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		response = s.GetPlatformBranding()
+	default:
+		return nil, fmt.Errorf("failed to get event branding: %w", err)
+	}
+
+	if s.cacheService != nil {
+		if err := s.cacheService.Set(ctx, cacheKey, response, constants.TTL_BRANDING_EVENT); err != nil {
+			log.Printf("Warning: failed to cache branding for event %s: %v", eventID, err)
+		}
+	}
+
+	return response, nil
+}
+
+func (s *service) GetPlatformBranding() *BrandingResponse {
+	return &BrandingResponse{
+		Name:           s.platform.Name,
+		LogoURL:        s.platform.LogoURL,
+		PrimaryColor:   s.platform.PrimaryColor,
+		SecondaryColor: s.platform.SecondaryColor,
+		Source:         SourcePlatform,
+	}
+}
+
+// resolve fills any unset organizer field from the platform branding
+func (s *service) resolve(branding *OrganizerBranding) *BrandingResponse {
+	response := s.GetPlatformBranding()
+
+	customised := false
+	if branding.DisplayName != "" {
+		response.Name = branding.DisplayName
+		customised = true
+	}
+	if branding.LogoURL != "" {
+		response.LogoURL = branding.LogoURL
+		customised = true
+	}
+	if branding.PrimaryColor != "" {
+		response.PrimaryColor = branding.PrimaryColor
+		customised = true
+	}
+	if branding.SecondaryColor != "" {
+		response.SecondaryColor = branding.SecondaryColor
+		customised = true
+	}
+
+	if customised {
+		response.Source = SourceOrganizer
+		updatedAt := branding.UpdatedAt
+		response.UpdatedAt = &updatedAt
+	}
+	return response
+}
+
+func (s *service) getOrNew(ctx context.Context, userID uuid.UUID) (*OrganizerBranding, error) {
+	branding, err := s.repo.GetByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &OrganizerBranding{UserID: userID}, nil
+		}
+		return nil, fmt.Errorf("failed to get branding: %w", err)
+	}
+	return branding, nil
+}
+
+func (s *service) save(ctx context.Context, branding *OrganizerBranding) error {
+	branding.UpdatedAt = time.Now()
+	if err := s.repo.Upsert(ctx, branding); err != nil {
+		return fmt.Errorf("failed to save branding: %w", err)
+	}
+
+	// An organizer can own many events, so drop every cached event branding
+	if s.cacheService != nil {
+		if err := s.cacheService.DeletePattern(ctx, constants.PATTERN_INVALIDATE_BRANDING_EVENT); err != nil {
+			log.Printf("Warning: failed to invalidate branding cache: %v", err)
+		}
+	}
+	return nil
+}
+
+func (s *service) deleteLogoFile(ctx context.Context, key string) {
+	if key == "" || s.store == nil {
+		return
+	}
+	if err := s.store.Delete(ctx, key); err != nil {
+		log.Printf("Warning: failed to delete old logo %s: %v", key, err)
+	}
+}
@@ -0,0 +1,251 @@
+package cancellation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"evently/internal/outbox"
+	"evently/pkg/currency"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Notifications sent to the user as a late cancellation is reviewed
+const (
+	NotificationTypeCancellationPending  = "CANCELLATION_PENDING_APPROVAL"
+	NotificationTypeCancellationApproved = "CANCELLATION_APPROVED"
+	NotificationTypeCancellationRejected = "CANCELLATION_REJECTED"
+)
+
+var (
+	ErrCancellationNotFound   = errors.New("cancellation not found")
+	ErrCancellationNotPending = errors.New("cancellation is not awaiting approval")
+)
+
+type CancellationReviewRequest struct {
+	Note string `json:"note" binding:"max=500"` // Shown to the user, required to reject
+}
+
+// requestApproval records a late cancellation for an admin to review. The
+// booking is left as it is; the refund quoted for a whole booking is worked
+// out again when the cancellation is approved.
+func (s *service) requestApproval(ctx context.Context, booking BookingInfo, policy *CancellationPolicy, seatIDs []uuid.UUID, req CancellationRequest) (*Cancellation, error) {
+	cancellation := &Cancellation{
+		ID:          uuid.New(),
+		BookingID:   booking.ID,
+		Partial:     len(seatIDs) > 0,
+		SeatIDs:     seatIDs,
+		RequestedAt: time.Now(),
+		Currency:    booking.Currency,
+		ReasonCode:  req.ReasonCode,
+		Reason:      req.Reason,
+		Status:      StatusPendingApproval,
+	}
+	if !cancellation.Partial {
+		if err := s.refundBooking(ctx, booking, policy, cancellation); err != nil {
+			return nil, err
+		}
+	}
+
+	message, err := s.buildReviewMessage(booking, policy, cancellation, NotificationTypeCancellationPending)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.CreateCancellationWithNotification(ctx, cancellation, message); err != nil {
+		return nil, err
+	}
+
+	log.Printf("⏳ Late cancellation %s of booking %s is awaiting approval", cancellation.ID, booking.ID)
+	return cancellation, nil
+}
+
+func (s *service) GetPendingCancellations(ctx context.Context) ([]Cancellation, error) {
+	return s.repo.GetPendingCancellations(ctx)
+}
+
+// ApproveCancellation processes a pending cancellation as if it had been made
+// before the deadline: the booking, or the seats picked, are cancelled and
+// refunded under the event's policy as it stands now.
+func (s *service) ApproveCancellation(ctx context.Context, cancellationID, adminID uuid.UUID, req CancellationReviewRequest) (*Cancellation, error) {
+	cancellation, booking, err := s.pendingCancellation(ctx, cancellationID)
+	if err != nil {
+		return nil, err
+	}
+	if booking.Status == "CANCELLED" {
+		return nil, fmt.Errorf("booking is already cancelled, reject the cancellation instead")
+	}
+	policy, err := s.repo.GetCancellationPolicyByEventID(ctx, booking.EventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cancellation policy: %w", err)
+	}
+
+	// The booking may have lost seats since the request
+	if cancellation.Partial {
+		requested := make([]string, len(cancellation.SeatIDs))
+		for i, seatID := range cancellation.SeatIDs {
+			requested[i] = seatID.String()
+		}
+		seatIDs, err := cancelledSeatIDs(booking, requested)
+		if err != nil {
+			return nil, fmt.Errorf("booking changed since the cancellation was requested: %w", err)
+		}
+		cancellation.Partial = len(seatIDs) > 0
+		cancellation.SeatIDs = seatIDs
+	}
+
+	// Seats leave with the approval, the whole booking is cancelled before it
+	var cancelled *CancelledSeats
+	var cancelSeats func(tx *gorm.DB) error
+	if cancellation.Partial {
+		cancelled, err = s.refundSeats(ctx, booking, cancellation)
+		if err != nil {
+			return nil, err
+		}
+		cancelSeats = cancelled.Apply
+	} else {
+		if err := s.refundBooking(ctx, booking, policy, cancellation); err != nil {
+			return nil, err
+		}
+		if err := s.bookingService.CancelBookingWithVersion(ctx, booking.ID, booking.Version); err != nil {
+			if errors.Is(err, ErrBookingModified) {
+				return nil, ErrBookingModified
+			}
+			return nil, fmt.Errorf("failed to cancel booking: %w", err)
+		}
+	}
+
+	now := time.Now()
+	cancellation.Status = StatusProcessed
+	cancellation.ProcessedAt = &now
+	cancellation.ReviewedBy = &adminID
+	cancellation.ReviewedAt = &now
+	cancellation.ReviewNote = strings.TrimSpace(req.Note)
+
+	message, err := s.buildReviewMessage(booking, policy, cancellation, NotificationTypeCancellationApproved)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.ReviewCancellation(ctx, cancellation, message, cancelSeats); err != nil {
+		switch {
+		case errors.Is(err, ErrBookingModified):
+			return nil, ErrBookingModified
+		case cancellation.Partial:
+			return nil, fmt.Errorf("failed to record the approval: %w", err)
+		}
+		return nil, fmt.Errorf("booking cancelled but failed to record the approval: %w", err)
+	}
+
+	log.Printf("✅ Cancellation %s of booking %s approved by admin %s, refund %.2f %s",
+		cancellation.ID, booking.ID, adminID, cancellation.RefundAmount, cancellation.Currency)
+
+	if cancellation.Partial {
+		s.releaseSeats(booking.EventID, cancelled.FreedSeats)
+	} else {
+		s.releaseBooking(booking)
+	}
+	return cancellation, nil
+}
+
+// RejectCancellation turns a pending cancellation down, the booking stays as it is
+func (s *service) RejectCancellation(ctx context.Context, cancellationID, adminID uuid.UUID, req CancellationReviewRequest) (*Cancellation, error) {
+	note := strings.TrimSpace(req.Note)
+	if note == "" {
+		return nil, fmt.Errorf("invalid request: a note is required to reject a cancellation")
+	}
+
+	cancellation, booking, err := s.pendingCancellation(ctx, cancellationID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	cancellation.Status = StatusRejected
+	cancellation.ReviewedBy = &adminID
+	cancellation.ReviewedAt = &now
+	cancellation.ReviewNote = note
+
+	message, err := s.buildReviewMessage(booking, nil, cancellation, NotificationTypeCancellationRejected)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.ReviewCancellation(ctx, cancellation, message, nil); err != nil {
+		return nil, err
+	}
+
+	log.Printf("🚫 Cancellation %s of booking %s rejected by admin %s", cancellation.ID, booking.ID, adminID)
+	return cancellation, nil
+}
+
+// pendingCancellation returns a cancellation awaiting approval and its booking
+func (s *service) pendingCancellation(ctx context.Context, cancellationID uuid.UUID) (*Cancellation, BookingInfo, error) {
+	cancellation, err := s.repo.GetCancellationByID(ctx, cancellationID)
+	if err != nil {
+		return nil, BookingInfo{}, err
+	}
+	if cancellation.Status != StatusPendingApproval {
+		return nil, BookingInfo{}, ErrCancellationNotPending
+	}
+
+	booking, err := s.bookingService.GetBooking(ctx, cancellation.BookingID)
+	if err != nil {
+		return nil, BookingInfo{}, fmt.Errorf("failed to get booking: %w", err)
+	}
+	return cancellation, booking, nil
+}
+
+// refundBooking sets what cancelling the whole booking refunds
+func (s *service) refundBooking(ctx context.Context, booking BookingInfo, policy *CancellationPolicy, cancellation *Cancellation) error {
+	share, err := s.bookingShare(ctx, booking, booking.TotalSeats)
+	if err != nil {
+		return err
+	}
+	retained := retainedFees(booking)
+	cancellationFee, refundAmount, err := policyFee(policy, booking.TotalPrice-retained, share)
+	if err != nil {
+		return err
+	}
+
+	cancellation.CancellationFee = cancellationFee
+	cancellation.RefundAmount = refundAmount
+	cancellation.RetainedFees = retained
+	cancellation.BaseRefund = currency.Convert(refundAmount, booking.ExchangeRate)
+	return nil
+}
+
+// buildReviewMessage builds the user's notification of a step of the review.
+// policy may be nil for a rejection, which refunds nothing.
+func (s *service) buildReviewMessage(booking BookingInfo, policy *CancellationPolicy, cancellation *Cancellation, notificationType string) (*outbox.Message, error) {
+	seatCount := booking.TotalSeats
+	if cancellation.Partial {
+		seatCount = len(cancellation.SeatIDs)
+	}
+	processingDays := defaultRefundProcessingDays
+	if policy != nil {
+		processingDays = policy.RefundProcessingDays
+	}
+
+	eventID, bookingID := booking.EventID, booking.ID
+	payload := &outbox.NotificationPayload{
+		Type:        notificationType,
+		RecipientID: booking.UserID,
+		EventID:     &eventID,
+		BookingID:   &bookingID,
+		TemplateData: map[string]interface{}{
+			"booking_ref":            booking.BookingRef,
+			"partial":                cancellation.Partial,
+			"seat_count":             seatCount,
+			"refund_amount":          fmt.Sprintf("%.2f", cancellation.RefundAmount),
+			"currency":               cancellation.Currency,
+			"refund_processing_days": processingDays,
+			"review_note":            cancellation.ReviewNote,
+		},
+	}
+
+	dedupKey := fmt.Sprintf("cancellation:%s:%s", cancellation.ID, strings.ToLower(notificationType))
+	return outbox.NewNotificationMessage(outbox.AggregateCancellation, cancellation.ID, dedupKey, payload)
+}
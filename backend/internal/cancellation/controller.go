@@ -1,7 +1,12 @@
 package cancellation
 
 import (
+	"context"
+	"errors"
 	"net/http"
+	"strings"
+
+	"evently/internal/shared/utils/response"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -18,216 +23,483 @@ func NewController(service Service) *Controller {
 }
 
 // CreateCancellationPolicy handles POST /api/v1/events/:eventId/cancellation-policy
+//
+// @Summary      Create cancellation policy (Admin)
+// @Description  Create a cancellation policy for an event
+// @Description
+// @Description  Requires the `events:write` permission.
+// @Tags         Admin Cancellation
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        eventId path string true "Event ID" Format(uuid)
+// @Param        request body CancellationPolicyRequest true "Request body"
+// @Success      201 {object} response.StandardApiResponse{data=CancellationPolicy} "Cancellation policy created successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/events/{eventId}/cancellation-policy [post]
 func (c *Controller) CreateCancellationPolicy(ctx *gin.Context) {
 	// Parse event ID from URL
 	eventIDStr := ctx.Param("eventId")
 	eventID, err := uuid.Parse(eventIDStr)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid event ID"})
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid event ID", nil, nil)
 		return
 	}
 
 	// Parse request body
 	var req CancellationPolicyRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request body",
-			"details": err.Error(),
-		})
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid request body", nil, err.Error())
 		return
 	}
 
 	// Create cancellation policy
 	policy, err := c.service.CreateCancellationPolicy(ctx.Request.Context(), eventID, req)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Failed to create cancellation policy",
-			"details": err.Error(),
-		})
+		response.RespondError(ctx, http.StatusBadRequest, cancellationErrorCode(err, http.StatusBadRequest), "Failed to create cancellation policy", err.Error())
 		return
 	}
 
-	ctx.JSON(http.StatusCreated, gin.H{
-		"message": "Cancellation policy created successfully",
-		"data":    policy,
-	})
+	response.RespondJSON(ctx, "success", http.StatusCreated, "Cancellation policy created successfully", policy, nil)
 }
 
 // GetCancellationPolicy handles GET /api/v1/events/:eventId/cancellation-policy
+//
+// @Summary      Get cancellation policy (Admin)
+// @Description  Get the cancellation policy for an event
+// @Description
+// @Description  Requires the `events:write` permission.
+// @Tags         Admin Cancellation
+// @Produce      json
+// @Security     Bearer
+// @Param        eventId path string true "Event ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=CancellationPolicy} "Cancellation policy retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "NO_CANCELLATION_POLICY"
+// @Router       /api/v1/admin/events/{eventId}/cancellation-policy [get]
 func (c *Controller) GetCancellationPolicy(ctx *gin.Context) {
 	// Parse event ID from URL
 	eventIDStr := ctx.Param("eventId")
 	eventID, err := uuid.Parse(eventIDStr)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid event ID"})
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid event ID", nil, nil)
 		return
 	}
 
 	// Get cancellation policy
 	policy, err := c.service.GetCancellationPolicy(ctx.Request.Context(), eventID)
 	if err != nil {
-		ctx.JSON(http.StatusNotFound, gin.H{
-			"error":   "Cancellation policy not found",
-			"details": err.Error(),
-		})
+		response.RespondError(ctx, http.StatusNotFound, response.CodeNoCancellationPolicy, "Cancellation policy not found", err.Error())
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{
-		"message": "Cancellation policy retrieved successfully",
-		"data":    policy,
-	})
+	response.RespondJSON(ctx, "success", http.StatusOK, "Cancellation policy retrieved successfully", policy, nil)
 }
 
 // UpdateCancellationPolicy handles PUT /api/v1/events/:eventId/cancellation-policy
+//
+// @Summary      Update cancellation policy (Admin)
+// @Description  Update the cancellation policy for an event
+// @Description
+// @Description  Requires the `events:write` permission.
+// @Tags         Admin Cancellation
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        eventId path string true "Event ID" Format(uuid)
+// @Param        request body CancellationPolicyRequest true "Request body"
+// @Success      200 {object} response.StandardApiResponse{data=CancellationPolicy} "Cancellation policy updated successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/events/{eventId}/cancellation-policy [put]
 func (c *Controller) UpdateCancellationPolicy(ctx *gin.Context) {
 	// Parse event ID from URL
 	eventIDStr := ctx.Param("eventId")
 	eventID, err := uuid.Parse(eventIDStr)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid event ID"})
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid event ID", nil, nil)
 		return
 	}
 
 	// Parse request body
 	var req CancellationPolicyRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request body",
-			"details": err.Error(),
-		})
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid request body", nil, err.Error())
 		return
 	}
 
 	// Update cancellation policy
 	policy, err := c.service.UpdateCancellationPolicy(ctx.Request.Context(), eventID, req)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Failed to update cancellation policy",
-			"details": err.Error(),
-		})
+		response.RespondError(ctx, http.StatusBadRequest, cancellationErrorCode(err, http.StatusBadRequest), "Failed to update cancellation policy", err.Error())
+		return
+	}
+
+	response.RespondJSON(ctx, "success", http.StatusOK, "Cancellation policy updated successfully", policy, nil)
+}
+
+// CancelEvent handles POST /api/v1/admin/events/:eventId/cancel
+//
+// @Summary      Cancel event (Admin)
+// @Description  Cancel an event and everything attached to it. Every confirmed booking is cancelled with a full refund, the waitlist is closed, and attendees and waitlisted users receive an EVENT_CANCELLED notification. The operation is idempotent; retrying after a partial failure resumes the remaining bookings without duplicate notifications.
+// @Description
+// @Description  Requires the `events:write` permission.
+// @Tags         Admin Cancellation
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        eventId path string true "Event ID" Format(uuid)
+// @Param        request body EventCancellationRequest true "Request body"
+// @Success      200 {object} response.StandardApiResponse{data=EventCancellationResult} "Event cancelled"
+// @Failure      400 {object} response.StandardApiResponse "Invalid request body"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Event not found - EVENT_NOT_FOUND"
+// @Failure      409 {object} response.StandardApiResponse "The event has already taken place or cannot be cancelled - EVENT_NOT_CANCELLABLE"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/events/{eventId}/cancel [post]
+func (c *Controller) CancelEvent(ctx *gin.Context) {
+	eventID, err := uuid.Parse(ctx.Param("eventId"))
+	if err != nil {
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid event ID", nil, err.Error())
+		return
+	}
+
+	userID, exists := ctx.Get("user_id")
+	if !exists {
+		response.RespondJSON(ctx, "error", http.StatusUnauthorized, "User not authenticated", nil, nil)
+		return
+	}
+	adminID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		response.RespondJSON(ctx, "error", http.StatusInternalServerError, "Invalid user ID format", nil, nil)
+		return
+	}
+
+	var req EventCancellationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid request body", nil, err.Error())
+		return
+	}
+
+	result, err := c.service.CancelEvent(ctx.Request.Context(), eventID, adminID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrEventNotFound):
+			response.RespondError(ctx, http.StatusNotFound, response.CodeEventNotFound, err.Error(), nil)
+		case errors.Is(err, ErrEventNotCancellable):
+			response.RespondError(ctx, http.StatusConflict, response.CodeEventNotCancellable, err.Error(), nil)
+		default:
+			response.RespondJSON(ctx, "error", http.StatusInternalServerError, "Failed to cancel event", nil, err.Error())
+		}
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{
-		"message": "Cancellation policy updated successfully",
-		"data":    policy,
-	})
+	message := "Event cancelled, attendees refunded and notified"
+	if len(result.Failed) > 0 {
+		message = "Event cancelled, some bookings could not be cancelled; cancel the event again to retry them"
+	}
+	response.RespondJSON(ctx, "success", http.StatusOK, message, result, nil)
 }
 
+// RequestCancellation godoc
+//
+// @Summary      Request booking cancellation
+// @Description  Request cancellation for a booking, or for some of its seats with `seat_ids`. Cancelling
+// @Description  seats keeps the rest of the booking under the same reference: its totals and fees are
+// @Description  reduced by the seats' share, their price is refunded under the cancellation policy (a
+// @Description  fixed fee is charged in proportion to the seats), and the freed seats go to the waitlist.
+// @Description  Picking every seat cancels the whole booking.
+// @Description
+// @Description  After the cancellation deadline, events whose policy requires approval take the request
+// @Description  as `PENDING_APPROVAL` (202): the booking is left as it is until an admin approves the
+// @Description  cancellation, which processes it, or rejects it. The user is notified at each step.
+// @Tags         Cancellation
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "ID" Format(uuid)
+// @Param        request body CancellationRequest false "Request body"
+// @Success      201 {object} response.StandardApiResponse{data=Cancellation}
+// @Success      202 {object} response.StandardApiResponse{data=Cancellation} "Late cancellation awaiting an admin's approval, refund amounts of a whole booking are a quote"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/bookings/{id}/request-cancel [post]
 func (c *Controller) RequestCancellation(ctx *gin.Context) {
 	bookingIDStr := ctx.Param("id")
 	bookingID, err := uuid.Parse(bookingIDStr)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid booking ID"})
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid booking ID", nil, nil)
 		return
 	}
 
 	// Get user ID from JWT
 	userIDInterface, exists := ctx.Get("user_id")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		response.RespondJSON(ctx, "error", http.StatusUnauthorized, "User not authenticated", nil, nil)
 		return
 	}
 
 	userIDStr, ok := userIDInterface.(string)
 	if !ok {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID format"})
+		response.RespondJSON(ctx, "error", http.StatusInternalServerError, "Invalid user ID format", nil, nil)
 		return
 	}
 
 	userID, err := uuid.Parse(userIDStr)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid user ID", nil, nil)
 		return
 	}
 
 	var req CancellationRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request body",
-			"details": err.Error(),
-		})
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid request body", nil, err.Error())
 		return
 	}
 
 	cancellation, err := c.service.RequestCancellation(ctx.Request.Context(), bookingID, userID, req)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Failed to request cancellation",
-			"details": err.Error(),
-		})
+		response.RespondError(ctx, http.StatusBadRequest, cancellationErrorCode(err, http.StatusBadRequest), "Failed to request cancellation", err.Error())
 		return
 	}
 
-	ctx.JSON(http.StatusCreated, gin.H{
-		"message": "Cancellation processed successfully. Refund will be credited within the specified processing days.",
-		"data":    cancellation,
-	})
+	if cancellation.Status == StatusPendingApproval {
+		response.RespondJSON(ctx, "success", http.StatusAccepted, "Cancellation requested after the deadline. It will be processed once an admin approves it.", cancellation, nil)
+		return
+	}
+
+	response.RespondJSON(ctx, "success", http.StatusCreated, "Cancellation processed successfully. Refund will be credited within the specified processing days.", cancellation, nil)
 }
 
 // GetCancellation handles GET /api/v1/cancellations/:id
+//
+// @Summary      Get cancellation details
+// @Description  Get details of a specific cancellation request
+// @Tags         Cancellation
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=Cancellation} "Cancellation details retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "CANCELLATION_NOT_FOUND"
+// @Router       /api/v1/cancellations/{id} [get]
 func (c *Controller) GetCancellation(ctx *gin.Context) {
 	// Parse cancellation ID from URL
 	cancellationIDStr := ctx.Param("id")
 	cancellationID, err := uuid.Parse(cancellationIDStr)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid cancellation ID"})
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid cancellation ID", nil, nil)
 		return
 	}
 
 	// Get cancellation
 	cancellation, err := c.service.GetCancellation(ctx.Request.Context(), cancellationID)
 	if err != nil {
-		ctx.JSON(http.StatusNotFound, gin.H{
-			"error":   "Cancellation not found",
-			"details": err.Error(),
-		})
+		response.RespondError(ctx, http.StatusNotFound, response.CodeCancellationNotFound, "Cancellation not found", err.Error())
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{
-		"message": "Cancellation retrieved successfully",
-		"data":    cancellation,
-	})
+	response.RespondJSON(ctx, "success", http.StatusOK, "Cancellation retrieved successfully", cancellation, nil)
 }
 
 // GetUserCancellations handles GET /api/v1/users/cancellations
+//
+// @Summary      Get user cancellations
+// @Description  Get all cancellation requests for the authenticated user
+// @Tags         Cancellation
+// @Produce      json
+// @Security     Bearer
+// @Success      200 {object} response.StandardApiResponse{data=object} "User cancellations retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/users/cancellations [get]
 func (c *Controller) GetUserCancellations(ctx *gin.Context) {
 	// Get user ID from JWT context
 	userIDInterface, exists := ctx.Get("user_id")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		response.RespondJSON(ctx, "error", http.StatusUnauthorized, "User not authenticated", nil, nil)
 		return
 	}
 
 	userIDStr, ok := userIDInterface.(string)
 	if !ok {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID format"})
+		response.RespondJSON(ctx, "error", http.StatusInternalServerError, "Invalid user ID format", nil, nil)
 		return
 	}
 
 	userID, err := uuid.Parse(userIDStr)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid user ID", nil, nil)
 		return
 	}
 
 	// Get user cancellations
 	cancellations, err := c.service.GetUserCancellations(ctx.Request.Context(), userID)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to get user cancellations",
-			"details": err.Error(),
-		})
+		response.RespondJSON(ctx, "error", http.StatusInternalServerError, "Failed to get user cancellations", nil, err.Error())
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{
-		"message": "User cancellations retrieved successfully",
-		"data": gin.H{
-			"cancellations": cancellations,
-			"count":         len(cancellations),
-		},
-	})
+	response.RespondJSON(ctx, "success", http.StatusOK, "User cancellations retrieved successfully", gin.H{
+		"cancellations": cancellations,
+		"count":         len(cancellations),
+	}, nil)
+}
+
+// GetPendingCancellations handles GET /api/v1/admin/cancellations/pending
+//
+// @Summary      List cancellations awaiting approval (Admin)
+// @Description  Late cancellations waiting for an admin to approve or reject them, oldest first. Requires `bookings:manage`.
+// @Description
+// @Description  Requires the `bookings:manage` permission.
+// @Tags         Admin Cancellation
+// @Produce      json
+// @Security     Bearer
+// @Success      200 {object} response.StandardApiResponse{data=object} "Pending cancellations retrieved successfully"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/cancellations/pending [get]
+func (c *Controller) GetPendingCancellations(ctx *gin.Context) {
+	cancellations, err := c.service.GetPendingCancellations(ctx.Request.Context())
+	if err != nil {
+		response.RespondJSON(ctx, "error", http.StatusInternalServerError, "Failed to get pending cancellations", nil, err.Error())
+		return
+	}
+
+	response.RespondJSON(ctx, "success", http.StatusOK, "Pending cancellations retrieved successfully", gin.H{
+		"cancellations": cancellations,
+		"count":         len(cancellations),
+	}, nil)
+}
+
+// ApproveCancellation handles POST /api/v1/admin/cancellations/:id/approve
+//
+// @Summary      Approve a late cancellation (Admin)
+// @Description  Processes a pending cancellation: the booking, or the seats picked, are cancelled and
+// @Description  refunded under the event's current policy, and the user is notified. Fails when the
+// @Description  booking changed since the request in a way that no longer matches it. Requires `bookings:manage`.
+// @Description
+// @Description  Requires the `bookings:manage` permission.
+// @Tags         Admin Cancellation
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "ID" Format(uuid)
+// @Param        request body CancellationReviewRequest false "Request body"
+// @Success      200 {object} response.StandardApiResponse{data=Cancellation} "Cancellation approved"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Cancellation not found - CANCELLATION_NOT_FOUND"
+// @Failure      409 {object} response.StandardApiResponse "Cancellation is not awaiting approval - CANCELLATION_NOT_PENDING"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/cancellations/{id}/approve [post]
+func (c *Controller) ApproveCancellation(ctx *gin.Context) {
+	c.review(ctx, c.service.ApproveCancellation, "Cancellation approved, the user has been notified")
+}
+
+// RejectCancellation handles POST /api/v1/admin/cancellations/:id/reject
+//
+// @Summary      Reject a late cancellation (Admin)
+// @Description  Turns a pending cancellation down, the booking stays as it is and the user is notified with the note. Requires `bookings:manage`.
+// @Description
+// @Description  Requires the `bookings:manage` permission.
+// @Tags         Admin Cancellation
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "ID" Format(uuid)
+// @Param        request body CancellationReviewRequest true "Request body"
+// @Success      200 {object} response.StandardApiResponse{data=Cancellation} "Cancellation rejected"
+// @Failure      400 {object} response.StandardApiResponse "A note is required to reject"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Cancellation not found - CANCELLATION_NOT_FOUND"
+// @Failure      409 {object} response.StandardApiResponse "Cancellation is not awaiting approval - CANCELLATION_NOT_PENDING"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/cancellations/{id}/reject [post]
+func (c *Controller) RejectCancellation(ctx *gin.Context) {
+	c.review(ctx, c.service.RejectCancellation, "Cancellation rejected, the user has been notified")
+}
+
+type reviewFunc func(ctx context.Context, cancellationID, adminID uuid.UUID, req CancellationReviewRequest) (*Cancellation, error)
+
+func (c *Controller) review(ctx *gin.Context, review reviewFunc, message string) {
+	cancellationID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid cancellation ID", nil, err.Error())
+		return
+	}
+
+	userID, exists := ctx.Get("user_id")
+	if !exists {
+		response.RespondJSON(ctx, "error", http.StatusUnauthorized, "User not authenticated", nil, nil)
+		return
+	}
+	adminID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		response.RespondJSON(ctx, "error", http.StatusInternalServerError, "Invalid user ID format", nil, nil)
+		return
+	}
+
+	var req CancellationReviewRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid request body", nil, err.Error())
+			return
+		}
+	}
+
+	cancellation, err := review(ctx.Request.Context(), cancellationID, adminID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrCancellationNotFound):
+			response.RespondError(ctx, http.StatusNotFound, response.CodeCancellationNotFound, err.Error(), nil)
+		case errors.Is(err, ErrCancellationNotPending):
+			response.RespondError(ctx, http.StatusConflict, response.CodeCancellationNotPending, err.Error(), nil)
+		default:
+			response.RespondError(ctx, http.StatusBadRequest, cancellationErrorCode(err, http.StatusBadRequest), "Failed to review cancellation", err.Error())
+		}
+		return
+	}
+
+	response.RespondJSON(ctx, "success", http.StatusOK, message, cancellation, nil)
+}
+
+// cancellationErrorCode picks the error code of a failed cancellation,
+// falling back to the generic code of its status
+func cancellationErrorCode(err error, status int) string {
+	switch {
+	case errors.Is(err, ErrDeadlinePassed):
+		return response.CodeCancellationDeadline
+	case errors.Is(err, ErrCancellationNotAllowed):
+		return response.CodeCancellationNotAllowed
+	case errors.Is(err, ErrNoPolicy):
+		return response.CodeNoCancellationPolicy
+	case errors.Is(err, ErrPolicyExists):
+		return response.CodeCancellationPolicyExists
+	case errors.Is(err, ErrBookingAlreadyCancelled):
+		return response.CodeBookingAlreadyCancelled
+	case errors.Is(err, ErrCancellationExists), errors.Is(err, ErrCancellationPending):
+		return response.CodeCancellationExists
+	case errors.Is(err, ErrBookingModified):
+		return response.CodeBookingModified
+	case strings.HasPrefix(err.Error(), "unauthorized"):
+		return response.CodeForbidden
+	default:
+		return response.StatusErrorCode(status)
+	}
 }
@@ -0,0 +1,170 @@
+package cancellation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"evently/internal/outbox"
+	"evently/pkg/currency"
+
+	"github.com/google/uuid"
+)
+
+const NotificationTypeEventCancelled = "EVENT_CANCELLED"
+
+// defaultRefundProcessingDays is quoted to attendees of events without a policy
+const defaultRefundProcessingDays = 5
+
+var (
+	ErrEventNotFound       = errors.New("event not found")
+	ErrEventNotCancellable = errors.New("event cannot be cancelled")
+)
+
+// EventService marks an event cancelled. Cancelling an event that already is
+// cancelled must succeed, so an interrupted cancellation can be run again.
+type EventService interface {
+	CancelEvent(ctx context.Context, eventID, adminID uuid.UUID) error
+}
+
+type EventCancellationRequest struct {
+	Reason string `json:"reason" binding:"required,min=1,max=500"` // Shown to attendees and waitlist members
+}
+
+// EventCancellationResult summarises an event cancellation
+type EventCancellationResult struct {
+	EventID           uuid.UUID          `json:"event_id"`
+	BookingsCancelled int                `json:"bookings_cancelled"`
+	Refunded          map[string]float64 `json:"refunded"`        // Total refunded per currency
+	WaitlistClosed    int                `json:"waitlist_closed"` // Waitlist entries cancelled
+	Failed            []FailedBooking    `json:"failed"`          // Bookings to retry by cancelling the event again
+}
+
+type FailedBooking struct {
+	BookingID uuid.UUID `json:"booking_id"`
+	Error     string    `json:"error"`
+}
+
+// SetEventService injects the event service used to cancel whole events
+func (s *service) SetEventService(eventService EventService) {
+	s.eventService = eventService
+}
+
+// CancelEvent cancels an event and everything booked on it: the event stops
+// selling, each confirmed booking is cancelled with a full refund, fees
+// included, and attendees and waitlist members are notified. Freed seats are
+// not offered to the waitlist. Bookings that fail are reported and picked up
+// when the event is cancelled again; bookings already done are skipped.
+func (s *service) CancelEvent(ctx context.Context, eventID, adminID uuid.UUID, req EventCancellationRequest) (*EventCancellationResult, error) {
+	if s.eventService == nil || s.bookingService == nil {
+		return nil, fmt.Errorf("event cancellation is not available")
+	}
+	if err := s.eventService.CancelEvent(ctx, eventID, adminID); err != nil {
+		return nil, err
+	}
+
+	bookingIDs, err := s.repo.GetConfirmedBookingIDs(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	processingDays := defaultRefundProcessingDays
+	if policy, err := s.repo.GetCancellationPolicyByEventID(ctx, eventID); err == nil {
+		processingDays = policy.RefundProcessingDays
+	}
+
+	reason := strings.TrimSpace(req.Reason)
+	result := &EventCancellationResult{
+		EventID:  eventID,
+		Refunded: make(map[string]float64),
+		Failed:   []FailedBooking{},
+	}
+	for _, bookingID := range bookingIDs {
+		cancellation, err := s.cancelForEvent(ctx, bookingID, reason, processingDays)
+		if err != nil {
+			log.Printf("❌ Failed to cancel booking %s of cancelled event %s: %v", bookingID, eventID, err)
+			result.Failed = append(result.Failed, FailedBooking{BookingID: bookingID, Error: err.Error()})
+			continue
+		}
+		result.BookingsCancelled++
+		result.Refunded[cancellation.Currency] += cancellation.RefundAmount
+	}
+
+	if s.waitlistService != nil {
+		closed, err := s.waitlistService.CloseWaitlist(ctx, eventID, reason)
+		if err != nil {
+			log.Printf("❌ Failed to close waitlist of cancelled event %s: %v", eventID, err)
+		}
+		result.WaitlistClosed = closed
+	}
+
+	log.Printf("🚫 Event %s cancelled by admin %s: %d bookings refunded, %d failed, %d waitlist entries closed",
+		eventID, adminID, result.BookingsCancelled, len(result.Failed), result.WaitlistClosed)
+	return result, nil
+}
+
+// cancelForEvent refunds one booking in full and queues the attendee's
+// notification with the cancellation record, then cancels the booking. A
+// record left by an earlier attempt is reused.
+func (s *service) cancelForEvent(ctx context.Context, bookingID uuid.UUID, reason string, processingDays int) (*Cancellation, error) {
+	booking, err := s.bookingService.GetBooking(ctx, bookingID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get booking: %w", err)
+	}
+
+	cancellation, err := s.repo.GetCancellationByBookingID(ctx, bookingID)
+	if err != nil {
+		now := time.Now()
+		cancellation = &Cancellation{
+			BookingID:   bookingID,
+			RequestedAt: now,
+			ProcessedAt: &now,
+			// Nothing is kept when the organizer cancels
+			RefundAmount: booking.TotalPrice,
+			Currency:     booking.Currency,
+			BaseRefund:   currency.Convert(booking.TotalPrice, booking.ExchangeRate),
+			ReasonCode:   ReasonEventCancelled,
+			Reason:       "Event cancelled: " + reason,
+			Status:       "PROCESSED",
+		}
+
+		message, err := s.buildEventCancelledMessage(booking, cancellation, reason, processingDays)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.repo.CreateCancellationWithNotification(ctx, cancellation, message); err != nil {
+			return nil, err
+		}
+	}
+
+	if booking.Status != "CANCELLED" {
+		if err := s.bookingService.CancelBookingInternal(ctx, bookingID); err != nil {
+			return nil, fmt.Errorf("refund recorded but failed to cancel booking: %w", err)
+		}
+	}
+	return cancellation, nil
+}
+
+func (s *service) buildEventCancelledMessage(booking BookingInfo, cancellation *Cancellation, reason string, processingDays int) (*outbox.Message, error) {
+	eventID, bookingID := booking.EventID, booking.ID
+	payload := &outbox.NotificationPayload{
+		Type:        NotificationTypeEventCancelled,
+		RecipientID: booking.UserID,
+		EventID:     &eventID,
+		BookingID:   &bookingID,
+		TemplateData: map[string]interface{}{
+			"recipient_kind":         "attendee",
+			"reason":                 reason,
+			"booking_ref":            booking.BookingRef,
+			"refund_amount":          fmt.Sprintf("%.2f", cancellation.RefundAmount),
+			"currency":               cancellation.Currency,
+			"refund_processing_days": processingDays,
+		},
+	}
+
+	dedupKey := fmt.Sprintf("event-cancelled:%s:booking:%s", booking.EventID, booking.ID)
+	return outbox.NewNotificationMessage(outbox.AggregateCancellation, booking.ID, dedupKey, payload)
+}
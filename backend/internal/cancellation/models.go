@@ -6,6 +6,20 @@ import (
 	"github.com/google/uuid"
 )
 
+// Reason codes of cancellations. EVENT_CANCELLED is only recorded for
+// bookings refunded because the organizer cancelled the event.
+const (
+	ReasonSchedulingConflict = "SCHEDULING_CONFLICT"
+	ReasonChangeOfPlans      = "CHANGE_OF_PLANS"
+	ReasonIllness            = "ILLNESS"
+	ReasonTravel             = "TRAVEL"
+	ReasonPrice              = "PRICE"
+	ReasonEventChanged       = "EVENT_CHANGED"
+	ReasonDuplicateBooking   = "DUPLICATE_BOOKING"
+	ReasonOther              = "OTHER"
+	ReasonEventCancelled     = "EVENT_CANCELLED"
+)
+
 type CancellationPolicy struct {
 	ID                   uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
 	EventID              uuid.UUID `gorm:"type:uuid;unique;not null" json:"event_id"`
@@ -14,21 +28,44 @@ type CancellationPolicy struct {
 	FeeType              string    `gorm:"type:varchar(20);check:fee_type IN ('NONE', 'FIXED', 'PERCENTAGE');default:'NONE'" json:"fee_type"`
 	FeeAmount            float64   `gorm:"default:0" json:"fee_amount"`
 	RefundProcessingDays int       `gorm:"default:5" json:"refund_processing_days"`
+	RequireApproval      bool      `gorm:"not null;default:false" json:"require_approval"` // Late cancellations wait for an admin instead of being refused
 	CreatedAt            time.Time `json:"created_at"`
 	UpdatedAt            time.Time `json:"updated_at"`
 }
 
+// Cancellation statuses. A late cancellation under a policy requiring approval
+// stays PENDING_APPROVAL until an admin approves it, which processes it, or
+// rejects it, which leaves the booking as it was.
+const (
+	StatusProcessed       = "PROCESSED"
+	StatusFailed          = "FAILED"
+	StatusPendingApproval = "PENDING_APPROVAL"
+	StatusRejected        = "REJECTED"
+)
+
+// Cancellation records the refund of a booking, or of some of its seats. A
+// booking has any number of partial cancellations but only one whole, not
+// counting rejected ones.
 type Cancellation struct {
-	ID              uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
-	BookingID       uuid.UUID  `gorm:"type:uuid;unique;not null" json:"booking_id"`
-	RequestedAt     time.Time  `json:"requested_at"`
-	ProcessedAt     *time.Time `json:"processed_at,omitempty"`
-	CancellationFee float64    `gorm:"default:0" json:"cancellation_fee"`
-	RefundAmount    float64    `gorm:"default:0" json:"refund_amount"`
-	Reason          string     `json:"reason"`
-	Status          string     `gorm:"type:varchar(20);check:status IN ('PROCESSED', 'FAILED');default:'PROCESSED'" json:"status"`
-	CreatedAt       time.Time  `json:"created_at"`
-	UpdatedAt       time.Time  `json:"updated_at"`
+	ID              uuid.UUID   `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	BookingID       uuid.UUID   `gorm:"type:uuid;not null;index;uniqueIndex:idx_cancellations_booking_whole,where:partial = false AND status <> 'REJECTED'" json:"booking_id"`
+	Partial         bool        `gorm:"not null;default:false" json:"partial"`
+	SeatIDs         []uuid.UUID `gorm:"type:jsonb;serializer:json" json:"seat_ids,omitempty"` // Seats cancelled by a partial cancellation
+	RequestedAt     time.Time   `json:"requested_at"`
+	ProcessedAt     *time.Time  `json:"processed_at,omitempty"`
+	CancellationFee float64     `gorm:"default:0" json:"cancellation_fee"`
+	RefundAmount    float64     `gorm:"default:0" json:"refund_amount"`
+	RetainedFees    float64     `gorm:"default:0" json:"retained_fees"`                         // Non-refundable service fees kept besides the cancellation fee
+	Currency        string      `gorm:"type:varchar(3);not null;default:'INR'" json:"currency"` // Currency the booking was paid in
+	BaseRefund      float64     `gorm:"default:0" json:"base_refund"`                           // Refund in the base currency, at the booking's exchange rate
+	ReasonCode      string      `gorm:"type:varchar(30);not null;default:'OTHER';index" json:"reason_code"`
+	Reason          string      `json:"reason"` // Free text, alongside the reason code
+	Status          string      `gorm:"type:varchar(20);check:status IN ('PROCESSED', 'FAILED', 'PENDING_APPROVAL', 'REJECTED');default:'PROCESSED'" json:"status"`
+	ReviewedBy      *uuid.UUID  `gorm:"type:uuid" json:"reviewed_by,omitempty"` // Admin who approved or rejected the cancellation
+	ReviewedAt      *time.Time  `json:"reviewed_at,omitempty"`
+	ReviewNote      string      `json:"review_note,omitempty"` // Shown to the user
+	CreatedAt       time.Time   `json:"created_at"`
+	UpdatedAt       time.Time   `json:"updated_at"`
 }
 
 func (CancellationPolicy) TableName() string {
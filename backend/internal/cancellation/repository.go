@@ -2,8 +2,11 @@ package cancellation
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	"evently/internal/outbox"
+
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
@@ -16,10 +19,21 @@ type Repository interface {
 
 	// Cancellation operations
 	CreateCancellation(ctx context.Context, cancellation *Cancellation) error
+	CreateSeatCancellation(ctx context.Context, cancellation *Cancellation, cancelSeats func(tx *gorm.DB) error) error
 	GetCancellationByID(ctx context.Context, id uuid.UUID) (*Cancellation, error)
 	GetCancellationsByUserID(ctx context.Context, userID uuid.UUID) ([]Cancellation, error)
 	GetCancellationByBookingID(ctx context.Context, bookingID uuid.UUID) (*Cancellation, error)
+	CountCancelledSeats(ctx context.Context, bookingID uuid.UUID) (int, error)
 	UpdateCancellation(ctx context.Context, cancellation *Cancellation) error
+
+	// Approval operations
+	HasPendingCancellation(ctx context.Context, bookingID uuid.UUID) (bool, error)
+	GetPendingCancellations(ctx context.Context) ([]Cancellation, error)
+	ReviewCancellation(ctx context.Context, cancellation *Cancellation, message *outbox.Message, cancelSeats func(tx *gorm.DB) error) error
+
+	// Event cancellation operations
+	GetConfirmedBookingIDs(ctx context.Context, eventID uuid.UUID) ([]uuid.UUID, error)
+	CreateCancellationWithNotification(ctx context.Context, cancellation *Cancellation, message *outbox.Message) error
 }
 
 type repository struct {
@@ -66,12 +80,27 @@ func (r *repository) CreateCancellation(ctx context.Context, cancellation *Cance
 	return nil
 }
 
+// CreateSeatCancellation takes the cancelled seats off the booking and records
+// the cancellation in one transaction, so seats never leave without a refund
+func (r *repository) CreateSeatCancellation(ctx context.Context, cancellation *Cancellation, cancelSeats func(tx *gorm.DB) error) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := cancelSeats(tx); err != nil {
+			return err
+		}
+		return tx.Create(cancellation).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to cancel seats: %w", err)
+	}
+	return nil
+}
+
 func (r *repository) GetCancellationByID(ctx context.Context, id uuid.UUID) (*Cancellation, error) {
 	var cancellation Cancellation
 	err := r.db.WithContext(ctx).First(&cancellation, "id = ?", id).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("cancellation not found")
+			return nil, ErrCancellationNotFound
 		}
 		return nil, fmt.Errorf("failed to get cancellation: %w", err)
 	}
@@ -94,9 +123,11 @@ func (r *repository) GetCancellationsByUserID(ctx context.Context, userID uuid.U
 	return cancellations, nil
 }
 
+// GetCancellationByBookingID returns the cancellation of the whole booking,
+// partial and rejected cancellations aren't considered
 func (r *repository) GetCancellationByBookingID(ctx context.Context, bookingID uuid.UUID) (*Cancellation, error) {
 	var cancellation Cancellation
-	err := r.db.WithContext(ctx).First(&cancellation, "booking_id = ?", bookingID).Error
+	err := r.db.WithContext(ctx).First(&cancellation, "booking_id = ? AND partial = false AND status <> ?", bookingID, StatusRejected).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, fmt.Errorf("cancellation not found for booking: %s", bookingID)
@@ -106,6 +137,21 @@ func (r *repository) GetCancellationByBookingID(ctx context.Context, bookingID u
 	return &cancellation, nil
 }
 
+// CountCancelledSeats returns how many seats processed partial cancellations
+// took off a booking
+func (r *repository) CountCancelledSeats(ctx context.Context, bookingID uuid.UUID) (int, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&Cancellation{}).
+		Where("booking_id = ? AND partial AND status = ?", bookingID, StatusProcessed).
+		Select("COALESCE(SUM(jsonb_array_length(seat_ids)), 0)").
+		Scan(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to count cancelled seats: %w", err)
+	}
+	return int(count), nil
+}
+
 func (r *repository) UpdateCancellation(ctx context.Context, cancellation *Cancellation) error {
 	err := r.db.WithContext(ctx).Save(cancellation).Error
 	if err != nil {
@@ -113,3 +159,89 @@ func (r *repository) UpdateCancellation(ctx context.Context, cancellation *Cance
 	}
 	return nil
 }
+
+func (r *repository) HasPendingCancellation(ctx context.Context, bookingID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&Cancellation{}).
+		Where("booking_id = ? AND status = ?", bookingID, StatusPendingApproval).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to check pending cancellations: %w", err)
+	}
+	return count > 0, nil
+}
+
+// GetPendingCancellations returns cancellations awaiting approval, oldest first
+func (r *repository) GetPendingCancellations(ctx context.Context) ([]Cancellation, error) {
+	var cancellations []Cancellation
+	err := r.db.WithContext(ctx).
+		Where("status = ?", StatusPendingApproval).
+		Order("requested_at ASC").
+		Find(&cancellations).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending cancellations: %w", err)
+	}
+	return cancellations, nil
+}
+
+// ReviewCancellation saves the outcome of a pending cancellation and queues the
+// user's notification in one transaction, along with cancelSeats when an
+// approval takes seats off the booking. It fails with ErrCancellationNotPending
+// when another admin reviewed the cancellation first.
+func (r *repository) ReviewCancellation(ctx context.Context, cancellation *Cancellation, message *outbox.Message, cancelSeats func(tx *gorm.DB) error) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(cancellation).
+			Where("status = ?", StatusPendingApproval).
+			Select("partial", "seat_ids", "processed_at", "cancellation_fee", "refund_amount", "retained_fees",
+				"base_refund", "status", "reviewed_by", "reviewed_at", "review_note", "updated_at").
+			Updates(cancellation)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrCancellationNotPending
+		}
+		if cancelSeats != nil {
+			if err := cancelSeats(tx); err != nil {
+				return err
+			}
+		}
+		return outbox.Enqueue(tx, message)
+	})
+	if err != nil {
+		if errors.Is(err, ErrCancellationNotPending) {
+			return err
+		}
+		return fmt.Errorf("failed to review cancellation: %w", err)
+	}
+	return nil
+}
+
+func (r *repository) GetConfirmedBookingIDs(ctx context.Context, eventID uuid.UUID) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := r.db.WithContext(ctx).
+		Table("bookings").
+		Where("event_id = ? AND status = ?", eventID, "CONFIRMED").
+		Order("created_at ASC").
+		Pluck("id", &ids).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get confirmed bookings: %w", err)
+	}
+	return ids, nil
+}
+
+// CreateCancellationWithNotification saves a cancellation and queues the
+// attendee's notification in one transaction
+func (r *repository) CreateCancellationWithNotification(ctx context.Context, cancellation *Cancellation, message *outbox.Message) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(cancellation).Error; err != nil {
+			return err
+		}
+		return outbox.Enqueue(tx, message)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create cancellation: %w", err)
+	}
+	return nil
+}
@@ -7,13 +7,14 @@ import (
 )
 
 func SetupCancellationRoutes(rg *gin.RouterGroup, controller *Controller) {
-	// Event cancellation policy routes (Admin only)
+	// Event cancellation policy routes (events:write)
 	events := rg.Group("/admin/events")
-	events.Use(middleware.JWTAuth(), middleware.RequireRoles("ADMIN"))
+	events.Use(middleware.JWTAuth(), middleware.RequirePermission(middleware.PermissionEventsWrite))
 	{
 		events.POST("/:eventId/cancellation-policy", controller.CreateCancellationPolicy) // POST /api/v1/events/:eventId/cancellation-policy
 		events.GET("/:eventId/cancellation-policy", controller.GetCancellationPolicy)     // GET /api/v1/events/:eventId/cancellation-policy
 		events.PUT("/:eventId/cancellation-policy", controller.UpdateCancellationPolicy)  // PUT /api/v1/events/:eventId/cancellation-policy
+		events.POST("/:eventId/cancel", controller.CancelEvent)                           // POST /api/v1/admin/events/:eventId/cancel
 	}
 
 	// Booking cancellation routes (Users and Admins)
@@ -30,6 +31,15 @@ func SetupCancellationRoutes(rg *gin.RouterGroup, controller *Controller) {
 		cancellations.GET("/:id", controller.GetCancellation) // GET /api/v1/cancellations/:id
 	}
 
+	// Late cancellation approval (bookings:manage)
+	approvals := rg.Group("/admin/cancellations")
+	approvals.Use(middleware.JWTAuth(), middleware.RequirePermission(middleware.PermissionBookingsManage))
+	{
+		approvals.GET("/pending", controller.GetPendingCancellations)  // GET /api/v1/admin/cancellations/pending
+		approvals.POST("/:id/approve", controller.ApproveCancellation) // POST /api/v1/admin/cancellations/:id/approve
+		approvals.POST("/:id/reject", controller.RejectCancellation)   // POST /api/v1/admin/cancellations/:id/reject
+	}
+
 	// User-specific cancellation routes
 	users := rg.Group("/users")
 	users.Use(middleware.JWTAuth(), middleware.RequireRoles("USER", "ADMIN"))
@@ -0,0 +1,134 @@
+package cancellation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"evently/pkg/currency"
+
+	"github.com/google/uuid"
+)
+
+// cancelledSeatIDs validates the seats picked for cancellation. It returns nil
+// when the whole booking is cancelled, which includes picking every seat.
+func cancelledSeatIDs(booking BookingInfo, requested []string) ([]uuid.UUID, error) {
+	if len(requested) == 0 {
+		return nil, nil
+	}
+
+	booked := make(map[uuid.UUID]bool, len(booking.FreedSeats))
+	for _, seat := range booking.FreedSeats {
+		if seat.SeatID == nil {
+			return nil, fmt.Errorf("bookings with general admission tickets can only be cancelled whole")
+		}
+		booked[*seat.SeatID] = true
+	}
+
+	seen := make(map[uuid.UUID]bool, len(requested))
+	seatIDs := make([]uuid.UUID, 0, len(requested))
+	for _, raw := range requested {
+		seatID, err := uuid.Parse(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid seat ID %q", raw)
+		}
+		if !booked[seatID] {
+			return nil, fmt.Errorf("seat %s is not part of this booking", seatID)
+		}
+		if seen[seatID] {
+			continue
+		}
+		seen[seatID] = true
+		seatIDs = append(seatIDs, seatID)
+	}
+
+	if len(seatIDs) == len(booked) {
+		return nil, nil
+	}
+	return seatIDs, nil
+}
+
+// cancelSeats cancels some seats of a booking. The refund of their price is
+// set under the event's cancellation policy, and the seats leave the booking
+// in the transaction that records it.
+func (s *service) cancelSeats(ctx context.Context, booking BookingInfo, seatIDs []uuid.UUID, req CancellationRequest) (*Cancellation, error) {
+	now := time.Now()
+	cancellation := &Cancellation{
+		BookingID:   booking.ID,
+		Partial:     true,
+		SeatIDs:     seatIDs,
+		RequestedAt: now,
+		ProcessedAt: &now,
+		Currency:    booking.Currency,
+		ReasonCode:  req.ReasonCode,
+		Reason:      req.Reason,
+		Status:      StatusProcessed,
+	}
+	cancelled, err := s.refundSeats(ctx, booking, cancellation)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.CreateSeatCancellation(ctx, cancellation, cancelled.Apply); err != nil {
+		if errors.Is(err, ErrBookingModified) {
+			return nil, ErrBookingModified
+		}
+		return nil, err
+	}
+
+	log.Printf("✂️ Cancelled %d seats of booking %s, %d left, refund %.2f %s",
+		len(seatIDs), booking.ID, cancelled.RemainingSeats, cancellation.RefundAmount, booking.Currency)
+	s.releaseSeats(booking.EventID, cancelled.FreedSeats)
+	return cancellation, nil
+}
+
+// refundSeats prepares taking the cancellation's seats off the booking and sets
+// what is refunded for them. The seats are only taken off once the returned
+// cancellation is applied.
+func (s *service) refundSeats(ctx context.Context, booking BookingInfo, cancellation *Cancellation) (*CancelledSeats, error) {
+	policy, err := s.repo.GetCancellationPolicyByEventID(ctx, booking.EventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cancellation policy: %w", err)
+	}
+	seatIDs := cancellation.SeatIDs
+	share, err := s.bookingShare(ctx, booking, len(seatIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	cancelled, err := s.bookingService.PrepareSeatCancellation(ctx, booking.ID, booking.Version, seatIDs)
+	if err != nil {
+		if errors.Is(err, ErrBookingModified) {
+			return nil, ErrBookingModified
+		}
+		return nil, fmt.Errorf("failed to cancel seats: %w", err)
+	}
+
+	retained := cancelled.NonRefundable
+	if retained > cancelled.Price {
+		retained = cancelled.Price
+	}
+	cancellationFee, refundAmount, err := policyFee(policy, cancelled.Price-retained, share)
+	if err != nil {
+		return nil, err
+	}
+
+	cancellation.CancellationFee = cancellationFee
+	cancellation.RefundAmount = refundAmount
+	cancellation.RetainedFees = retained
+	cancellation.BaseRefund = currency.Convert(refundAmount, booking.ExchangeRate)
+	return cancelled, nil
+}
+
+// releaseSeats offers seats freed by a partial cancellation to the waitlist
+func (s *service) releaseSeats(eventID uuid.UUID, freed []FreedSeat) {
+	if s.waitlistService == nil {
+		return
+	}
+	go func() {
+		if err := s.waitlistService.ProcessCancellation(context.Background(), eventID, len(freed), freed); err != nil {
+			log.Printf("❌ Failed to notify waitlist of %d seats freed on event %s: %v", len(freed), eventID, err)
+		}
+	}()
+}
@@ -2,14 +2,35 @@ package cancellation
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
 	"strings"
 	"time"
 
+	"evently/internal/shared/utils/constants"
+	"evently/pkg/cache"
+	"evently/pkg/currency"
+
 	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrPolicyExists            = errors.New("cancellation policy already exists for this event")
+	ErrNoPolicy                = errors.New("no cancellation policy found for this event")
+	ErrCancellationNotAllowed  = errors.New("cancellation is not allowed for this event")
+	ErrDeadlinePassed          = errors.New("cancellation deadline has passed")
+	ErrBookingAlreadyCancelled = errors.New("booking is already cancelled")
+	ErrCancellationExists      = errors.New("cancellation request already exists for this booking")
+	ErrCancellationPending     = errors.New("a cancellation of this booking is already awaiting approval")
+	ErrBookingModified         = errors.New("booking was recently modified, please refresh and try again")
 )
 
 type Service interface {
+	SetCacheService(cacheService cache.Service)
+	SetEventService(eventService EventService)
+
 	// Cancellation Policy management
 	CreateCancellationPolicy(ctx context.Context, eventID uuid.UUID, req CancellationPolicyRequest) (*CancellationPolicy, error)
 	GetCancellationPolicy(ctx context.Context, eventID uuid.UUID) (*CancellationPolicy, error)
@@ -20,6 +41,14 @@ type Service interface {
 	GetCancellation(ctx context.Context, cancellationID uuid.UUID) (*Cancellation, error)
 	GetUserCancellations(ctx context.Context, userID uuid.UUID) ([]Cancellation, error)
 
+	// Approval of late cancellations
+	GetPendingCancellations(ctx context.Context) ([]Cancellation, error)
+	ApproveCancellation(ctx context.Context, cancellationID, adminID uuid.UUID, req CancellationReviewRequest) (*Cancellation, error)
+	RejectCancellation(ctx context.Context, cancellationID, adminID uuid.UUID, req CancellationReviewRequest) (*Cancellation, error)
+
+	// Event cancellation, refunding every attendee
+	CancelEvent(ctx context.Context, eventID, adminID uuid.UUID, req EventCancellationRequest) (*EventCancellationResult, error)
+
 	// Business logic helpers
 	CalculateCancellationFee(ctx context.Context, bookingID uuid.UUID) (float64, float64, error) // fee, refund
 	ValidateCancellationEligibility(ctx context.Context, bookingID uuid.UUID) error
@@ -29,22 +58,46 @@ type BookingService interface {
 	GetBooking(ctx context.Context, bookingID uuid.UUID) (BookingInfo, error)
 	CancelBookingInternal(ctx context.Context, bookingID uuid.UUID) error
 	CancelBookingWithVersion(ctx context.Context, bookingID uuid.UUID, expectedVersion int) error
+	PrepareSeatCancellation(ctx context.Context, bookingID uuid.UUID, expectedVersion int, seatIDs []uuid.UUID) (*CancelledSeats, error)
 }
 
 type WaitlistService interface {
-	ProcessCancellation(ctx context.Context, eventID uuid.UUID, freedTickets int) error
+	ProcessCancellation(ctx context.Context, eventID uuid.UUID, freedTickets int, freed []FreedSeat) error
+	CloseWaitlist(ctx context.Context, eventID uuid.UUID, reason string) (int, error)
 }
 
 type BookingInfo struct {
-	ID         uuid.UUID `json:"id"`
-	UserID     uuid.UUID `json:"user_id"`
-	EventID    uuid.UUID `json:"event_id"`
-	TotalPrice float64   `json:"total_price"`
-	TotalSeats int       `json:"total_seats"`
-	Status     string    `json:"status"`
-	BookingRef string    `json:"booking_ref"`
-	Version    int       `json:"version"`
-	CreatedAt  time.Time `json:"created_at"`
+	ID            uuid.UUID `json:"id"`
+	UserID        uuid.UUID `json:"user_id"`
+	EventID       uuid.UUID `json:"event_id"`
+	TotalPrice    float64   `json:"total_price"`
+	Currency      string    `json:"currency"`
+	ExchangeRate  float64   `json:"exchange_rate"`  // Base currency units per unit of Currency, fixed at booking time
+	NonRefundable float64   `json:"non_refundable"` // Service fees and their tax, kept whatever the cancellation policy
+	TotalSeats    int       `json:"total_seats"`
+	Status        string    `json:"status"`
+	BookingRef    string    `json:"booking_ref"`
+	Version       int       `json:"version"`
+	CreatedAt     time.Time `json:"created_at"`
+
+	FreedSeats []FreedSeat `json:"-"` // Seats and tickets returned to sale on cancellation
+}
+
+// CancelledSeats is what cancelling some seats takes off a booking. Nothing is
+// written until Apply runs, in the transaction recording the cancellation.
+type CancelledSeats struct {
+	Price          float64 // Paid for the seats, fees and taxes included
+	NonRefundable  float64 // Part of Price kept whatever the policy
+	RemainingSeats int
+	FreedSeats     []FreedSeat
+	Apply          func(tx *gorm.DB) error // Fails with ErrBookingModified when the booking changed since
+}
+
+// FreedSeat is a seat or general admission ticket a cancelled booking returns to sale
+type FreedSeat struct {
+	SeatID    *uuid.UUID // Nil for general admission tickets
+	SectionID *uuid.UUID
+	Price     float64
 }
 
 type CancellationPolicyRequest struct {
@@ -53,16 +106,33 @@ type CancellationPolicyRequest struct {
 	FeeType              string    `json:"fee_type" binding:"required,oneof=NONE FIXED PERCENTAGE"`
 	FeeAmount            float64   `json:"fee_amount"`
 	RefundProcessingDays int       `json:"refund_processing_days" binding:"min=1,max=30"`
+	RequireApproval      bool      `json:"require_approval"` // Take cancellations after the deadline for an admin to approve
 }
 
 type CancellationRequest struct {
-	Reason string `json:"reason" binding:"required,min=1,max=500"`
+	ReasonCode string   `json:"reason_code" binding:"omitempty,oneof=SCHEDULING_CONFLICT CHANGE_OF_PLANS ILLNESS TRAVEL PRICE EVENT_CHANGED DUPLICATE_BOOKING OTHER"`
+	Reason     string   `json:"reason" binding:"max=500"` // Free text, required with OTHER or without a code
+	SeatIDs    []string `json:"seat_ids,omitempty"`       // Cancel only these seats, the rest of the booking stays
+}
+
+// normalize defaults the reason code and checks a reason was given
+func (req *CancellationRequest) normalize() error {
+	req.Reason = strings.TrimSpace(req.Reason)
+	if req.ReasonCode == "" {
+		req.ReasonCode = ReasonOther
+	}
+	if req.ReasonCode == ReasonOther && req.Reason == "" {
+		return fmt.Errorf("invalid request: reason is required when the reason code is OTHER")
+	}
+	return nil
 }
 
 type service struct {
 	repo            Repository
 	bookingService  BookingService
 	waitlistService WaitlistService
+	eventService    EventService
+	cacheService    cache.Service
 }
 
 func NewService(repo Repository, bookingService BookingService, waitlistService WaitlistService) Service {
@@ -73,11 +143,29 @@ func NewService(repo Repository, bookingService BookingService, waitlistService
 	}
 }
 
+// SetCacheService injects the cache service dependency
+func (s *service) SetCacheService(cacheService cache.Service) {
+	s.cacheService = cacheService
+}
+
+// invalidatePolicyCache drops cached responses showing the event's cancellation
+// terms, so clients don't keep seeing the old policy until the TTL runs out
+func (s *service) invalidatePolicyCache(ctx context.Context, eventID uuid.UUID) {
+	if s.cacheService == nil {
+		return
+	}
+	for _, pattern := range constants.BuildEventPriceCachePatterns(eventID.String()) {
+		if err := s.cacheService.DeletePattern(ctx, pattern); err != nil {
+			log.Printf("Warning: failed to invalidate cache pattern %s: %v", pattern, err)
+		}
+	}
+}
+
 func (s *service) CreateCancellationPolicy(ctx context.Context, eventID uuid.UUID, req CancellationPolicyRequest) (*CancellationPolicy, error) {
 	// Check if policy already exists
 	_, err := s.repo.GetCancellationPolicyByEventID(ctx, eventID)
 	if err == nil {
-		return nil, fmt.Errorf("cancellation policy already exists for this event")
+		return nil, ErrPolicyExists
 	}
 
 	// Validate request
@@ -93,12 +181,15 @@ func (s *service) CreateCancellationPolicy(ctx context.Context, eventID uuid.UUI
 		FeeType:              req.FeeType,
 		FeeAmount:            req.FeeAmount,
 		RefundProcessingDays: req.RefundProcessingDays,
+		RequireApproval:      req.RequireApproval,
 	}
 
 	if err := s.repo.CreateCancellationPolicy(ctx, policy); err != nil {
 		return nil, fmt.Errorf("failed to create cancellation policy: %w", err)
 	}
 
+	s.invalidatePolicyCache(ctx, eventID)
+
 	return policy, nil
 }
 
@@ -124,12 +215,15 @@ func (s *service) UpdateCancellationPolicy(ctx context.Context, eventID uuid.UUI
 	policy.FeeType = req.FeeType
 	policy.FeeAmount = req.FeeAmount
 	policy.RefundProcessingDays = req.RefundProcessingDays
+	policy.RequireApproval = req.RequireApproval
 	policy.UpdatedAt = time.Now()
 
 	if err := s.repo.UpdateCancellationPolicy(ctx, policy); err != nil {
 		return nil, fmt.Errorf("failed to update cancellation policy: %w", err)
 	}
 
+	s.invalidatePolicyCache(ctx, eventID)
+
 	return policy, nil
 }
 
@@ -145,15 +239,40 @@ func (s *service) RequestCancellation(ctx context.Context, bookingID uuid.UUID,
 		return nil, fmt.Errorf("unauthorized: booking does not belong to user")
 	}
 
+	if err := req.normalize(); err != nil {
+		return nil, err
+	}
+
 	// Validate cancellation eligibility
-	if err := s.ValidateCancellationEligibility(ctx, bookingID); err != nil {
+	policy, needsApproval, err := s.checkEligibility(ctx, booking)
+	if err != nil {
 		return nil, fmt.Errorf("cancellation not allowed: %w", err)
 	}
 
-	// Check if cancellation already exists
-	_, err = s.repo.GetCancellationByBookingID(ctx, bookingID)
-	if err == nil {
-		return nil, fmt.Errorf("cancellation request already exists for this booking")
+	pending, err := s.repo.HasPendingCancellation(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if pending {
+		return nil, ErrCancellationPending
+	}
+
+	seatIDs, err := cancelledSeatIDs(booking, req.SeatIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(seatIDs) == 0 {
+		// Check if cancellation already exists
+		if _, err := s.repo.GetCancellationByBookingID(ctx, bookingID); err == nil {
+			return nil, ErrCancellationExists
+		}
+	}
+
+	if needsApproval {
+		return s.requestApproval(ctx, booking, policy, seatIDs, req)
+	}
+	if len(seatIDs) > 0 {
+		return s.cancelSeats(ctx, booking, seatIDs, req)
 	}
 
 	// Calculate cancellation fee and refund amount
@@ -170,8 +289,12 @@ func (s *service) RequestCancellation(ctx context.Context, bookingID uuid.UUID,
 		ProcessedAt:     &now, // Process immediately
 		CancellationFee: cancellationFee,
 		RefundAmount:    refundAmount,
+		RetainedFees:    retainedFees(booking),
+		Currency:        booking.Currency,
+		BaseRefund:      currency.Convert(refundAmount, booking.ExchangeRate),
+		ReasonCode:      req.ReasonCode,
 		Reason:          req.Reason,
-		Status:          "PROCESSED", // Auto-approve and process instantly
+		Status:          StatusProcessed, // Auto-approve and process instantly
 	}
 
 	if err := s.repo.CreateCancellation(ctx, cancellation); err != nil {
@@ -181,30 +304,35 @@ func (s *service) RequestCancellation(ctx context.Context, bookingID uuid.UUID,
 	// Update booking status to CANCELLED with version check
 	if err := s.bookingService.CancelBookingWithVersion(ctx, bookingID, booking.Version); err != nil {
 		// If version mismatch, provide a user-friendly message
-		if strings.Contains(err.Error(), "version mismatch") || strings.Contains(err.Error(), "modified by another process") {
-			return nil, fmt.Errorf("booking was recently modified, please refresh and try again")
+		if errors.Is(err, ErrBookingModified) {
+			return nil, ErrBookingModified
 		}
 		return cancellation, fmt.Errorf("cancellation created but failed to update booking status: %w", err)
 	}
 
-	// Notify waitlist users about freed seats (run in background to avoid blocking)
+	s.releaseBooking(booking)
+
+	return cancellation, nil
+}
+
+// releaseBooking notifies waitlist users about the seats of a cancelled
+// booking (run in background to avoid blocking)
+func (s *service) releaseBooking(booking BookingInfo) {
 	go func() {
 		if s.waitlistService != nil {
 			// Log the notification attempt
 			fmt.Printf("🔔 NOTIFICATION DISPATCH: Starting waitlist notification for booking %s (event: %s, seats: %d)\n",
-				bookingID, booking.EventID, booking.TotalSeats)
+				booking.ID, booking.EventID, booking.TotalSeats)
 
-			if err := s.waitlistService.ProcessCancellation(context.Background(), booking.EventID, booking.TotalSeats); err != nil {
+			if err := s.waitlistService.ProcessCancellation(context.Background(), booking.EventID, booking.TotalSeats, booking.FreedSeats); err != nil {
 				fmt.Printf("❌ NOTIFICATION FAILED: Event %s - Error: %v\n", booking.EventID, err)
 			} else {
 				fmt.Printf("✅ NOTIFICATION SUCCESS: Event %s - %d seats freed and waitlist notified\n", booking.EventID, booking.TotalSeats)
 			}
 		} else {
-			fmt.Printf("⚠️  NOTIFICATION SKIPPED: Waitlist service not available for booking %s\n", bookingID)
+			fmt.Printf("⚠️  NOTIFICATION SKIPPED: Waitlist service not available for booking %s\n", booking.ID)
 		}
 	}()
-
-	return cancellation, nil
 }
 
 func (s *service) GetCancellation(ctx context.Context, cancellationID uuid.UUID) (*Cancellation, error) {
@@ -228,31 +356,65 @@ func (s *service) CalculateCancellationFee(ctx context.Context, bookingID uuid.U
 		return 0, 0, fmt.Errorf("failed to get cancellation policy: %w", err)
 	}
 
+	share, err := s.bookingShare(ctx, booking, booking.TotalSeats)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// The policy fee applies to what is refundable at all
+	return policyFee(policy, booking.TotalPrice-retainedFees(booking), share)
+}
+
+// bookingShare returns the part of the booking as it was first booked that
+// seats make up, counting seats already cancelled off it
+func (s *service) bookingShare(ctx context.Context, booking BookingInfo, seats int) (float64, error) {
+	cancelled, err := s.repo.CountCancelledSeats(ctx, booking.ID)
+	if err != nil {
+		return 0, err
+	}
+	booked := booking.TotalSeats + cancelled
+	if booked == 0 {
+		return 1, nil
+	}
+	return float64(seats) / float64(booked), nil
+}
+
+// policyFee returns the cancellation fee and refund for a refundable amount.
+// share is the part of the booking cancelled; a fixed fee is charged in
+// proportion, so cancelling seats one by one costs what cancelling them at once does.
+func policyFee(policy *CancellationPolicy, refundable, share float64) (float64, float64, error) {
 	var cancellationFee float64
-	totalPrice := booking.TotalPrice
 
 	// Calculate fee based on policy
 	switch policy.FeeType {
 	case "NONE":
 		cancellationFee = 0
 	case "FIXED":
-		cancellationFee = policy.FeeAmount
+		cancellationFee = policy.FeeAmount * share
 	case "PERCENTAGE":
-		cancellationFee = totalPrice * (policy.FeeAmount / 100)
+		cancellationFee = refundable * (policy.FeeAmount / 100)
 	default:
 		return 0, 0, fmt.Errorf("invalid fee type: %s", policy.FeeType)
 	}
 
 	// Ensure fee doesn't exceed total price
-	if cancellationFee > totalPrice {
-		cancellationFee = totalPrice
+	if cancellationFee > refundable {
+		cancellationFee = refundable
 	}
 
-	refundAmount := totalPrice - cancellationFee
+	refundAmount := refundable - cancellationFee
 
 	return cancellationFee, refundAmount, nil
 }
 
+// retainedFees returns the non-refundable part of a booking, never more than its price
+func retainedFees(booking BookingInfo) float64 {
+	if booking.NonRefundable > booking.TotalPrice {
+		return booking.TotalPrice
+	}
+	return booking.NonRefundable
+}
+
 func (s *service) ValidateCancellationEligibility(ctx context.Context, bookingID uuid.UUID) error {
 	// Get booking information
 	booking, err := s.bookingService.GetBooking(ctx, bookingID)
@@ -260,28 +422,38 @@ func (s *service) ValidateCancellationEligibility(ctx context.Context, bookingID
 		return fmt.Errorf("failed to get booking: %w", err)
 	}
 
+	_, _, err = s.checkEligibility(ctx, booking)
+	return err
+}
+
+// checkEligibility returns the policy a booking is cancelled under and whether
+// the cancellation is late and has to wait for an admin's approval
+func (s *service) checkEligibility(ctx context.Context, booking BookingInfo) (*CancellationPolicy, bool, error) {
 	// Check if booking is already cancelled
 	if booking.Status == "CANCELLED" {
-		return fmt.Errorf("booking is already cancelled")
+		return nil, false, ErrBookingAlreadyCancelled
 	}
 
 	// Get cancellation policy
 	policy, err := s.repo.GetCancellationPolicyByEventID(ctx, booking.EventID)
 	if err != nil {
-		return fmt.Errorf("no cancellation policy found for this event")
+		return nil, false, ErrNoPolicy
 	}
 
 	// Check if cancellation is allowed
 	if !policy.AllowCancellation {
-		return fmt.Errorf("cancellation is not allowed for this event")
+		return nil, false, ErrCancellationNotAllowed
 	}
 
 	// Check if within cancellation deadline
 	if time.Now().After(policy.CancellationDeadline) {
-		return fmt.Errorf("cancellation deadline has passed")
+		if policy.RequireApproval {
+			return policy, true, nil
+		}
+		return nil, false, ErrDeadlinePassed
 	}
 
-	return nil
+	return policy, false, nil
 }
 
 func (s *service) validatePolicyRequest(req CancellationPolicyRequest) error {
@@ -0,0 +1,120 @@
+package capacityalerts
+
+import (
+	"errors"
+	"net/http"
+
+	"evently/internal/shared/utils/response"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type Controller struct {
+	service Service
+}
+
+func NewController(service Service) *Controller {
+	return &Controller{service: service}
+}
+
+// GetSettings returns an event's capacity alert thresholds and the ones already crossed
+//
+// @Summary      Get capacity alert settings (Admin)
+// @Description  The thresholds at which the event's organizer is emailed as seats are booked, the current utilization
+// @Description  and the thresholds already crossed. Events without their own settings alert at 80%, 95% and sold out.
+// @Description
+// @Description  Requires the `events:write` permission.
+// @Tags         Admin Events
+// @Produce      json
+// @Security     Bearer
+// @Param        eventId path string true "Event ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=SettingsResponse} "Capacity alert settings retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Event not found"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/events/{eventId}/capacity-alerts [get]
+func (ctrl *Controller) GetSettings(c *gin.Context) {
+	eventID, err := uuid.Parse(c.Param("eventId"))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid event ID", nil, err.Error())
+		return
+	}
+
+	settings, err := ctrl.service.GetSettings(c.Request.Context(), eventID)
+	if err != nil {
+		ctrl.respondError(c, err, "Failed to get capacity alert settings")
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Capacity alert settings retrieved successfully", settings, nil)
+}
+
+// UpdateSettings changes an event's capacity alert thresholds
+//
+// @Summary      Update capacity alert settings (Admin)
+// @Description  Only the admin who created the event can change its alerts. Each threshold alerts once; when a check finds
+// @Description  several newly crossed, only the highest is emailed. Checked every CAPACITY_ALERTS_CHECK_INTERVAL.
+// @Description
+// @Description  Requires the `events:write` permission.
+// @Tags         Admin Events
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        eventId path string true "Event ID" Format(uuid)
+// @Param        request body UpdateSettingsRequest true "Request body"
+// @Success      200 {object} response.StandardApiResponse{data=SettingsResponse} "Capacity alert settings updated successfully"
+// @Failure      400 {object} response.StandardApiResponse "Invalid thresholds"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse "Not the event's organizer"
+// @Failure      404 {object} response.StandardApiResponse "Event not found"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/events/{eventId}/capacity-alerts [put]
+func (ctrl *Controller) UpdateSettings(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.RespondJSON(c, "error", http.StatusUnauthorized, "User not authenticated", nil, nil)
+		return
+	}
+
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusInternalServerError, "Invalid user ID format", nil, nil)
+		return
+	}
+
+	eventID, err := uuid.Parse(c.Param("eventId"))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid event ID", nil, err.Error())
+		return
+	}
+
+	var req UpdateSettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid request body", nil, err.Error())
+		return
+	}
+
+	settings, err := ctrl.service.UpdateSettings(c.Request.Context(), userUUID, eventID, req)
+	if err != nil {
+		ctrl.respondError(c, err, "Failed to update capacity alert settings")
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Capacity alert settings updated successfully", settings, nil)
+}
+
+func (ctrl *Controller) respondError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, ErrEventNotFound):
+		response.RespondJSON(c, "error", http.StatusNotFound, err.Error(), nil, nil)
+	case errors.Is(err, ErrNotOrganizer):
+		response.RespondJSON(c, "error", http.StatusForbidden, err.Error(), nil, nil)
+	case errors.Is(err, ErrInvalidThresholds):
+		response.RespondJSON(c, "error", http.StatusBadRequest, err.Error(), nil, nil)
+	default:
+		response.RespondJSON(c, "error", http.StatusInternalServerError, message, nil, err.Error())
+	}
+}
@@ -0,0 +1,66 @@
+package capacityalerts
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SoldOutThreshold is the threshold for an event with no seats left
+const SoldOutThreshold = 100
+
+// DefaultThresholds are used for events without their own settings
+var DefaultThresholds = []int{80, 95, SoldOutThreshold}
+
+// AlertSettings are an event's capacity alert thresholds. Events without a row
+// are alerted at DefaultThresholds.
+type AlertSettings struct {
+	EventID    uuid.UUID  `gorm:"type:uuid;primaryKey" json:"event_id"`
+	Enabled    bool       `gorm:"not null;default:true" json:"enabled"`
+	Thresholds []int      `gorm:"type:jsonb;serializer:json;not null" json:"thresholds"` // Percent of capacity booked, ascending
+	UpdatedBy  *uuid.UUID `gorm:"type:uuid" json:"updated_by,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+func (AlertSettings) TableName() string {
+	return "event_capacity_alert_settings"
+}
+
+// Alert records a threshold an event crossed. Each threshold alerts once per event.
+type Alert struct {
+	ID            uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	EventID       uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_capacity_alert_threshold" json:"event_id"`
+	Threshold     int       `gorm:"not null;uniqueIndex:idx_capacity_alert_threshold" json:"threshold"`
+	BookedCount   int       `gorm:"not null" json:"booked_count"`
+	TotalCapacity int       `gorm:"not null" json:"total_capacity"`
+	Notified      bool      `gorm:"not null;default:false" json:"notified"` // False when a higher threshold crossed in the same check was emailed instead
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+func (Alert) TableName() string {
+	return "event_capacity_alerts"
+}
+
+// EventCapacity is an upcoming event's booked seats against its venue capacity
+type EventCapacity struct {
+	EventID       uuid.UUID
+	EventName     string
+	OrganizerID   uuid.UUID
+	TotalCapacity int
+	BookedCount   int
+}
+
+// Utilization returns the percent of capacity booked
+func (e EventCapacity) Utilization() float64 {
+	if e.TotalCapacity <= 0 {
+		return 0
+	}
+	return float64(e.BookedCount) / float64(e.TotalCapacity) * 100
+}
+
+// Reached reports whether the event has reached a threshold. Compared in whole
+// seats so the sold out threshold needs every seat booked, not a rounded 100%.
+func (e EventCapacity) Reached(threshold int) bool {
+	return e.TotalCapacity > 0 && e.BookedCount*100 >= e.TotalCapacity*threshold
+}
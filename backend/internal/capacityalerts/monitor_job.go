@@ -0,0 +1,80 @@
+package capacityalerts
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// MonitorConfig contains configuration for the capacity monitor
+type MonitorConfig struct {
+	CheckInterval time.Duration
+	BatchSize     int
+}
+
+// DefaultMonitorConfig returns default capacity monitor configuration
+func DefaultMonitorConfig() *MonitorConfig {
+	return &MonitorConfig{
+		CheckInterval: time.Minute, // Check every minute, organizers want to hear about a sell-out quickly
+		BatchSize:     100,         // Process up to 100 events per run
+	}
+}
+
+// Monitor notifies organizers when their events cross capacity thresholds
+type Monitor struct {
+	service Service
+	config  *MonitorConfig
+	done    chan struct{}
+}
+
+// NewMonitor creates a new capacity monitor
+func NewMonitor(service Service, config *MonitorConfig) *Monitor {
+	if config == nil {
+		config = DefaultMonitorConfig()
+	}
+
+	return &Monitor{
+		service: service,
+		config:  config,
+		done:    make(chan struct{}),
+	}
+}
+
+// Start starts the capacity monitor
+func (m *Monitor) Start(ctx context.Context) {
+	log.Printf("Started capacity alert monitor with %v interval", m.config.CheckInterval)
+	go m.run(ctx)
+}
+
+// Stop stops the capacity monitor
+func (m *Monitor) Stop() {
+	close(m.done)
+}
+
+func (m *Monitor) run(ctx context.Context) {
+	ticker := time.NewTicker(m.config.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			m.check(ctx)
+		case <-m.done:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (m *Monitor) check(ctx context.Context) {
+	queued, err := m.service.CheckThresholds(ctx, m.config.BatchSize)
+	if err != nil {
+		log.Printf("Failed to check events for capacity alerts: %v", err)
+		return
+	}
+
+	if queued > 0 {
+		log.Printf("Queued %d capacity alerts for organizers", queued)
+	}
+}
@@ -0,0 +1,149 @@
+package capacityalerts
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"evently/internal/outbox"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	GetSettings(ctx context.Context, eventID uuid.UUID) (*AlertSettings, error)
+	SaveSettings(ctx context.Context, settings *AlertSettings) error
+	GetAlerts(ctx context.Context, eventID uuid.UUID) ([]Alert, error)
+	RecordAlerts(ctx context.Context, alerts []Alert, messages []*outbox.Message) error
+
+	// Capacity
+	GetEventCapacity(ctx context.Context, eventID uuid.UUID) (*EventCapacity, error)
+	GetEventsCrossingThresholds(ctx context.Context, limit int) ([]EventCapacity, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+//  SETTINGS
+
+func (r *repository) GetSettings(ctx context.Context, eventID uuid.UUID) (*AlertSettings, error) {
+	var settings AlertSettings
+	err := r.db.WithContext(ctx).Where("event_id = ?", eventID).Take(&settings).Error
+	if err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+func (r *repository) SaveSettings(ctx context.Context, settings *AlertSettings) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "event_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"enabled", "thresholds", "updated_by", "updated_at"}),
+	}).Create(settings).Error
+}
+
+//  ALERTS
+
+func (r *repository) GetAlerts(ctx context.Context, eventID uuid.UUID) ([]Alert, error) {
+	var alerts []Alert
+	err := r.db.WithContext(ctx).
+		Where("event_id = ?", eventID).
+		Order("threshold ASC").
+		Find(&alerts).Error
+	return alerts, err
+}
+
+// RecordAlerts stores crossed thresholds and writes the organizer emails to the
+// outbox in the same transaction. Thresholds already recorded are left alone.
+func (r *repository) RecordAlerts(ctx context.Context, alerts []Alert, messages []*outbox.Message) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for i := range alerts {
+			err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "event_id"}, {Name: "threshold"}},
+				DoNothing: true,
+			}).Create(&alerts[i]).Error
+			if err != nil {
+				return fmt.Errorf("failed to record capacity alert: %w", err)
+			}
+		}
+
+		return outbox.Enqueue(tx, messages...)
+	})
+}
+
+//  CAPACITY
+
+// capacitySelect joins an event to its venue capacity and confirmed seat bookings
+const capacitySelect = `
+	SELECT
+		e.id AS event_id,
+		e.name AS event_name,
+		e.created_by AS organizer_id,
+		COALESCE(cap.total_capacity, 0) AS total_capacity,
+		COALESCE(bk.booked_count, 0) AS booked_count
+	FROM events e
+	LEFT JOIN (
+		SELECT template_id, SUM(total_seats) AS total_capacity
+		FROM venue_sections
+		GROUP BY template_id
+	) cap ON cap.template_id = e.venue_template_id
+	LEFT JOIN (
+		SELECT sb.event_id, COUNT(*) AS booked_count
+		FROM seat_bookings sb
+		JOIN bookings b ON b.id = sb.booking_id AND b.status = 'CONFIRMED'
+		GROUP BY sb.event_id
+	) bk ON bk.event_id = e.id`
+
+func (r *repository) GetEventCapacity(ctx context.Context, eventID uuid.UUID) (*EventCapacity, error) {
+	var events []EventCapacity
+	err := r.db.WithContext(ctx).Raw(capacitySelect+`
+		WHERE e.id = ? AND e.deleted_at IS NULL
+	`, eventID).Scan(&events).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event capacity: %w", err)
+	}
+	if len(events) == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &events[0], nil
+}
+
+// GetEventsCrossingThresholds returns upcoming published events that have reached
+// one of their alert thresholds without it being recorded yet
+func (r *repository) GetEventsCrossingThresholds(ctx context.Context, limit int) ([]EventCapacity, error) {
+	defaults, err := json.Marshal(DefaultThresholds)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []EventCapacity
+	err = r.db.WithContext(ctx).Raw(capacitySelect+`
+		LEFT JOIN event_capacity_alert_settings s ON s.event_id = e.id
+		WHERE e.status IN ('published', 'sold_out')
+			AND e.deleted_at IS NULL
+			AND e.date_time > NOW()
+			AND cap.total_capacity > 0
+			AND COALESCE(s.enabled, TRUE)
+			AND EXISTS (
+				SELECT 1 FROM jsonb_array_elements_text(COALESCE(s.thresholds, ?::jsonb)) t(threshold)
+				WHERE COALESCE(bk.booked_count, 0) * 100 >= cap.total_capacity * t.threshold::int
+					AND NOT EXISTS (
+						SELECT 1 FROM event_capacity_alerts a
+						WHERE a.event_id = e.id AND a.threshold = t.threshold::int
+					)
+			)
+		ORDER BY e.date_time ASC
+		LIMIT ?
+	`, string(defaults), limit).Scan(&events).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get events crossing capacity thresholds: %w", err)
+	}
+	return events, nil
+}
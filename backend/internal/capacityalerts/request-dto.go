@@ -0,0 +1,7 @@
+package capacityalerts
+
+// UpdateSettingsRequest changes an event's capacity alerts. Omitted fields keep their current value.
+type UpdateSettingsRequest struct {
+	Enabled    *bool `json:"enabled"`
+	Thresholds []int `json:"thresholds" binding:"omitempty,min=1,max=10,dive,min=1,max=100"` // Percent of capacity booked, 100 is sold out
+}
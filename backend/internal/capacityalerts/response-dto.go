@@ -0,0 +1,17 @@
+package capacityalerts
+
+import "github.com/google/uuid"
+
+// SettingsResponse is an event's capacity alert settings with its current capacity
+// and the thresholds it has already crossed
+type SettingsResponse struct {
+	EventID       uuid.UUID  `json:"event_id"`
+	Enabled       bool       `json:"enabled"`
+	Thresholds    []int      `json:"thresholds"`
+	IsDefault     bool       `json:"is_default"` // True until the organizer saves their own settings
+	TotalCapacity int        `json:"total_capacity"`
+	BookedCount   int        `json:"booked_count"`
+	Utilization   float64    `json:"utilization"` // Percent of capacity booked
+	Alerts        []Alert    `json:"alerts"`
+	UpdatedBy     *uuid.UUID `json:"updated_by,omitempty"`
+}
@@ -0,0 +1,16 @@
+package capacityalerts
+
+import (
+	"evently/internal/shared/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupCapacityAlertRoutes(rg *gin.RouterGroup, controller *Controller) {
+	admin := rg.Group("/admin/events")
+	admin.Use(middleware.JWTAuth(), middleware.RequirePermission(middleware.PermissionEventsWrite))
+	{
+		admin.GET("/:eventId/capacity-alerts", controller.GetSettings)    // GET /api/v1/admin/events/:eventId/capacity-alerts
+		admin.PUT("/:eventId/capacity-alerts", controller.UpdateSettings) // PUT /api/v1/admin/events/:eventId/capacity-alerts
+	}
+}
@@ -0,0 +1,233 @@
+package capacityalerts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"time"
+
+	"evently/internal/outbox"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// NotificationTypeCapacityThreshold is the organizer email sent when an event crosses a threshold
+const NotificationTypeCapacityThreshold = "EVENT_CAPACITY_THRESHOLD"
+
+var (
+	ErrEventNotFound     = errors.New("event not found")
+	ErrNotOrganizer      = errors.New("unauthorized: you can only change alerts for events you created")
+	ErrInvalidThresholds = errors.New("thresholds must be between 1 and 100")
+)
+
+type Service interface {
+	GetSettings(ctx context.Context, eventID uuid.UUID) (*SettingsResponse, error)
+	UpdateSettings(ctx context.Context, userID, eventID uuid.UUID, req UpdateSettingsRequest) (*SettingsResponse, error)
+
+	// Monitor
+	CheckThresholds(ctx context.Context, limit int) (int, error)
+}
+
+type service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) Service {
+	return &service{repo: repo}
+}
+
+func (s *service) GetSettings(ctx context.Context, eventID uuid.UUID) (*SettingsResponse, error) {
+	capacity, err := s.repo.GetEventCapacity(ctx, eventID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrEventNotFound
+		}
+		return nil, err
+	}
+
+	settings, err := s.settings(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	return s.buildResponse(ctx, capacity, settings)
+}
+
+// UpdateSettings changes an event's thresholds. Like event updates, only the
+// organizer who created the event can change them.
+func (s *service) UpdateSettings(ctx context.Context, userID, eventID uuid.UUID, req UpdateSettingsRequest) (*SettingsResponse, error) {
+	capacity, err := s.repo.GetEventCapacity(ctx, eventID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrEventNotFound
+		}
+		return nil, err
+	}
+	if capacity.OrganizerID != userID {
+		return nil, ErrNotOrganizer
+	}
+
+	settings, err := s.settings(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if req.Enabled != nil {
+		settings.Enabled = *req.Enabled
+	}
+	if req.Thresholds != nil {
+		thresholds, err := normalizeThresholds(req.Thresholds)
+		if err != nil {
+			return nil, err
+		}
+		settings.Thresholds = thresholds
+	}
+	settings.UpdatedBy = &userID
+	settings.UpdatedAt = time.Now()
+
+	if err := s.repo.SaveSettings(ctx, settings); err != nil {
+		return nil, fmt.Errorf("failed to save capacity alert settings: %w", err)
+	}
+	return s.buildResponse(ctx, capacity, settings)
+}
+
+// CheckThresholds records thresholds upcoming events have newly crossed and emails
+// their organizers. When several are crossed at once, such as a large group
+// booking going from 70% to sold out, only the highest is emailed. Returns the
+// number of emails queued.
+func (s *service) CheckThresholds(ctx context.Context, limit int) (int, error) {
+	events, err := s.repo.GetEventsCrossingThresholds(ctx, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	queued := 0
+	for _, event := range events {
+		settings, err := s.settings(ctx, event.EventID)
+		if err != nil {
+			log.Printf("Failed to get capacity alert settings for event %s: %v", event.EventID, err)
+			continue
+		}
+		recorded, err := s.repo.GetAlerts(ctx, event.EventID)
+		if err != nil {
+			log.Printf("Failed to get capacity alerts for event %s: %v", event.EventID, err)
+			continue
+		}
+
+		alerted := make(map[int]bool, len(recorded))
+		for _, alert := range recorded {
+			alerted[alert.Threshold] = true
+		}
+
+		var alerts []Alert
+		for _, threshold := range settings.Thresholds {
+			if alerted[threshold] || !event.Reached(threshold) {
+				continue
+			}
+			alerts = append(alerts, Alert{
+				ID:            uuid.New(),
+				EventID:       event.EventID,
+				Threshold:     threshold,
+				BookedCount:   event.BookedCount,
+				TotalCapacity: event.TotalCapacity,
+			})
+		}
+		if len(alerts) == 0 {
+			continue
+		}
+
+		// Thresholds are ascending, so the last one crossed is the one worth an email
+		highest := &alerts[len(alerts)-1]
+		highest.Notified = true
+
+		message, err := s.buildMessage(event, highest.Threshold)
+		if err != nil {
+			return queued, err
+		}
+
+		if err := s.repo.RecordAlerts(ctx, alerts, []*outbox.Message{message}); err != nil {
+			log.Printf("Failed to record capacity alerts for event %s: %v", event.EventID, err)
+			continue
+		}
+		log.Printf("📈 Event %s reached %d%% of capacity (%d/%d), organizer notified",
+			event.EventID, highest.Threshold, event.BookedCount, event.TotalCapacity)
+		queued++
+	}
+
+	return queued, nil
+}
+
+func (s *service) buildMessage(event EventCapacity, threshold int) (*outbox.Message, error) {
+	eventID := event.EventID
+	payload := &outbox.NotificationPayload{
+		Type:        NotificationTypeCapacityThreshold,
+		RecipientID: event.OrganizerID,
+		EventID:     &eventID,
+		TemplateData: map[string]interface{}{
+			"event_title":     event.EventName,
+			"threshold":       threshold,
+			"sold_out":        threshold >= SoldOutThreshold,
+			"booked_count":    event.BookedCount,
+			"total_capacity":  event.TotalCapacity,
+			"remaining_seats": event.TotalCapacity - event.BookedCount,
+			"utilization":     math.Round(event.Utilization()*10) / 10,
+		},
+	}
+
+	return outbox.NewNotificationMessage(outbox.AggregateEventCapacity, event.EventID,
+		fmt.Sprintf("capacity-alert:%s:%d", event.EventID, threshold), payload)
+}
+
+// settings returns the event's settings, or the defaults when it has none
+func (s *service) settings(ctx context.Context, eventID uuid.UUID) (*AlertSettings, error) {
+	settings, err := s.repo.GetSettings(ctx, eventID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &AlertSettings{
+			EventID:    eventID,
+			Enabled:    true,
+			Thresholds: append([]int(nil), DefaultThresholds...),
+		}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get capacity alert settings: %w", err)
+	}
+	return settings, nil
+}
+
+func (s *service) buildResponse(ctx context.Context, capacity *EventCapacity, settings *AlertSettings) (*SettingsResponse, error) {
+	alerts, err := s.repo.GetAlerts(ctx, capacity.EventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get capacity alerts: %w", err)
+	}
+
+	return &SettingsResponse{
+		EventID:       capacity.EventID,
+		Enabled:       settings.Enabled,
+		Thresholds:    settings.Thresholds,
+		IsDefault:     settings.CreatedAt.IsZero(),
+		TotalCapacity: capacity.TotalCapacity,
+		BookedCount:   capacity.BookedCount,
+		Utilization:   math.Round(capacity.Utilization()*10) / 10,
+		Alerts:        alerts,
+		UpdatedBy:     settings.UpdatedBy,
+	}, nil
+}
+
+// normalizeThresholds sorts and de-duplicates thresholds
+func normalizeThresholds(thresholds []int) ([]int, error) {
+	seen := make(map[int]bool, len(thresholds))
+	normalized := make([]int, 0, len(thresholds))
+	for _, threshold := range thresholds {
+		if threshold < 1 || threshold > SoldOutThreshold {
+			return nil, ErrInvalidThresholds
+		}
+		if !seen[threshold] {
+			seen[threshold] = true
+			normalized = append(normalized, threshold)
+		}
+	}
+	sort.Ints(normalized)
+	return normalized, nil
+}
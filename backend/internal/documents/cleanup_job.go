@@ -0,0 +1,67 @@
+package documents
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// CleanupJob deletes archives whose download links have lapsed and fails builds
+// that never finished
+type CleanupJob struct {
+	service Service
+	config  *Config
+	done    chan struct{}
+}
+
+// NewCleanupJob creates a new document archive cleanup job
+func NewCleanupJob(service Service, config *Config) *CleanupJob {
+	if config == nil {
+		config = DefaultConfig()
+	}
+
+	return &CleanupJob{
+		service: service,
+		config:  config,
+		done:    make(chan struct{}),
+	}
+}
+
+// Start starts the cleanup job
+func (j *CleanupJob) Start(ctx context.Context) {
+	log.Printf("Started document archive cleanup job with %v interval", j.config.CleanupInterval)
+	go j.run(ctx)
+}
+
+// Stop stops the cleanup job
+func (j *CleanupJob) Stop() {
+	close(j.done)
+}
+
+func (j *CleanupJob) run(ctx context.Context) {
+	ticker := time.NewTicker(j.config.CleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			j.cleanup(ctx)
+		case <-j.done:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (j *CleanupJob) cleanup(ctx context.Context) {
+	removed, err := j.service.CleanupArchives(ctx)
+	if err != nil {
+		log.Printf("Failed to clean up document archives: %v", err)
+		return
+	}
+
+	if removed > 0 {
+		log.Printf("Deleted %d expired document archives", removed)
+	}
+}
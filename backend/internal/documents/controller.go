@@ -0,0 +1,115 @@
+package documents
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+
+	"evently/internal/shared/utils/response"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type Controller struct {
+	service Service
+}
+
+func NewController(service Service) *Controller {
+	return &Controller{service: service}
+}
+
+// RequestArchive returns the user's document archive, starting a build if needed.
+// 202 while it is being built, 200 with a download link once ready.
+//
+// @Summary      Request an archive of my tickets and invoices
+// @Description  Bundles every ticket and invoice of the signed-in user into a ZIP in the background and emails a signed
+// @Description  download link when it is ready. Calling again returns the archive in progress, or the ready archive with
+// @Description  its link. A new archive can be requested once per DOCUMENTS_MIN_INTERVAL; failed builds can be retried straight away.
+// @Tags         Documents
+// @Produce      json
+// @Security     Bearer
+// @Success      200 {object} response.StandardApiResponse{data=ArchiveResponse} "Archive is ready"
+// @Success      202 {object} response.StandardApiResponse{data=ArchiveResponse} "Archive is being prepared"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      429 {object} response.StandardApiResponse "An archive was requested too recently, see the Retry-After header"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/users/me/documents/archive [get]
+func (ctrl *Controller) RequestArchive(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.RespondJSON(c, "error", http.StatusUnauthorized, "User not authenticated", nil, nil)
+		return
+	}
+
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusInternalServerError, "Invalid user ID format", nil, nil)
+		return
+	}
+
+	archive, err := ctrl.service.RequestArchive(c.Request.Context(), userUUID)
+	if err != nil {
+		var throttled *ThrottledError
+		if errors.As(err, &throttled) {
+			retryAfter := int(math.Ceil(throttled.RetryAfter.Seconds()))
+			c.Header("Retry-After", fmt.Sprintf("%d", retryAfter))
+			response.RespondJSON(c, "error", http.StatusTooManyRequests, err.Error(), nil,
+				map[string]interface{}{"retry_after_seconds": retryAfter})
+			return
+		}
+		response.RespondJSON(c, "error", http.StatusInternalServerError, "Failed to request document archive", nil, err.Error())
+		return
+	}
+
+	if archive.Status == ArchiveStatusReady {
+		response.RespondJSON(c, "success", http.StatusOK, "Document archive is ready", archive, nil)
+		return
+	}
+	response.RespondJSON(c, "success", http.StatusAccepted, "Document archive is being prepared, you will be emailed when it is ready", archive, nil)
+}
+
+// DownloadArchive streams an archive for a signed link. The link is the
+// credential, so it works from an email without logging in.
+//
+// @Summary      Download a document archive
+// @Description  Signed link from the archive ready email. The signature is the credential, no login is needed.
+// @Tags         Documents
+// @Produce      json,application/zip
+// @Param        id path string true "ID" Format(uuid)
+// @Param        expires query integer true "Unix time the link stops working"
+// @Param        signature query string true "Signature"
+// @Success      200 {file} file "ZIP with tickets/, invoices/ and manifest.json"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse "Invalid or expired link"
+// @Failure      410 {object} response.StandardApiResponse "Archive is no longer available"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/documents/archives/{id}/download [get]
+func (ctrl *Controller) DownloadArchive(c *gin.Context) {
+	archiveID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid archive ID", nil, err.Error())
+		return
+	}
+
+	archive, file, err := ctrl.service.OpenArchive(c.Request.Context(), archiveID, c.Query("expires"), c.Query("signature"))
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrInvalidLink):
+			response.RespondJSON(c, "error", http.StatusForbidden, err.Error(), nil, nil)
+		case errors.Is(err, ErrNotReady):
+			response.RespondJSON(c, "error", http.StatusGone, err.Error(), nil, nil)
+		default:
+			response.RespondJSON(c, "error", http.StatusInternalServerError, "Failed to download document archive", nil, err.Error())
+		}
+		return
+	}
+	defer file.Close()
+
+	filename := fmt.Sprintf("documents-%s.zip", archive.CreatedAt.Format("2006-01-02"))
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Header("Cache-Control", "private, no-store")
+	c.DataFromReader(http.StatusOK, archive.SizeBytes, "application/zip", file, nil)
+}
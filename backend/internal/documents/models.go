@@ -0,0 +1,92 @@
+package documents
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ArchiveStatus string
+
+const (
+	ArchiveStatusProcessing ArchiveStatus = "PROCESSING" // Being built in the background
+	ArchiveStatusReady      ArchiveStatus = "READY"      // Stored and downloadable until it expires
+	ArchiveStatusFailed     ArchiveStatus = "FAILED"     // Build failed, the user may request again
+	ArchiveStatusExpired    ArchiveStatus = "EXPIRED"    // Link lapsed and the file was deleted
+)
+
+// DocumentArchive is a ZIP of all of a user's tickets and invoices. Only one
+// archive per user can be processing at a time.
+type DocumentArchive struct {
+	ID           uuid.UUID     `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	UserID       uuid.UUID     `gorm:"type:uuid;not null;index;uniqueIndex:idx_document_archives_one_processing,where:status = 'PROCESSING'" json:"user_id"`
+	Status       ArchiveStatus `gorm:"type:varchar(20);not null;check:status IN ('PROCESSING', 'READY', 'FAILED', 'EXPIRED');index" json:"status"`
+	StorageKey   string        `gorm:"size:255" json:"-"`
+	SizeBytes    int64         `gorm:"not null;default:0" json:"size_bytes"`
+	BookingCount int           `gorm:"not null;default:0" json:"booking_count"`
+	TicketCount  int           `gorm:"not null;default:0" json:"ticket_count"`
+	InvoiceCount int           `gorm:"not null;default:0" json:"invoice_count"`
+	Error        string        `gorm:"type:text" json:"error,omitempty"`
+	ExpiresAt    *time.Time    `gorm:"index" json:"expires_at,omitempty"`
+	CreatedAt    time.Time     `json:"created_at"`
+	CompletedAt  *time.Time    `json:"completed_at,omitempty"`
+}
+
+func (DocumentArchive) TableName() string {
+	return "document_archives"
+}
+
+// BookingDocument is a booking with everything its ticket and invoice show
+type BookingDocument struct {
+	ID          uuid.UUID
+	BookingRef  string
+	Status      string
+	TotalSeats  int
+	TotalPrice  float64
+	Currency    string
+	CreatedAt   time.Time
+	CancelledAt *time.Time
+	EventID     uuid.UUID
+	EventName   string
+	Venue       string
+	EventDate   time.Time
+
+	Seats    []SeatLine    `gorm:"-"`
+	Tickets  []TicketLine  `gorm:"-"`
+	Payments []PaymentLine `gorm:"-"`
+}
+
+type SeatLine struct {
+	BookingID   uuid.UUID
+	SectionName string
+	Row         string
+	SeatNumber  string
+	Price       float64
+}
+
+// TicketLine is a general admission ticket type bought by quantity
+type TicketLine struct {
+	BookingID uuid.UUID
+	Name      string
+	Quantity  int
+	UnitPrice float64
+}
+
+type PaymentLine struct {
+	BookingID     uuid.UUID
+	Amount        float64
+	Currency      string
+	Status        string
+	PaymentMethod string
+	TransactionID string
+	ProcessedAt   *time.Time
+	CreatedAt     time.Time
+}
+
+// DocumentOwner is who the documents are issued to
+type DocumentOwner struct {
+	ID        uuid.UUID
+	FirstName string
+	LastName  string
+	Email     string
+}
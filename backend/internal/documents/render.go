@@ -0,0 +1,190 @@
+package documents
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"time"
+)
+
+// Tickets and invoices are standalone HTML files so they open and print in any
+// browser without the app
+
+const ticketHTML = `<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Ticket {{.Booking.BookingRef}}</title></head>
+<body style="font-family: sans-serif; max-width: 640px; margin: 24px auto;">
+	<h1>{{.Brand}} ticket</h1>
+	<h2>{{.Booking.EventName}}</h2>
+	<p>{{.Booking.Venue}}<br>{{.Booking.EventDate.Format "Monday, 2 January 2006 at 15:04 MST"}}</p>
+	<p>Booking reference: <strong>{{.Booking.BookingRef}}</strong><br>Issued to: {{.OwnerName}}</p>
+	{{if .Booking.Seats}}
+	<table border="1" cellpadding="6" cellspacing="0">
+		<tr><th>Section</th><th>Row</th><th>Seat</th></tr>
+		{{range .Booking.Seats}}<tr><td>{{.SectionName}}</td><td>{{.Row}}</td><td>{{.SeatNumber}}</td></tr>
+		{{end}}
+	</table>
+	{{end}}
+	{{if .Booking.Tickets}}
+	<table border="1" cellpadding="6" cellspacing="0">
+		<tr><th>Ticket</th><th>Quantity</th></tr>
+		{{range .Booking.Tickets}}<tr><td>{{.Name}}</td><td>{{.Quantity}}</td></tr>
+		{{end}}
+	</table>
+	{{end}}
+	<p>Show the booking reference at the entrance.</p>
+</body>
+</html>
+`
+
+const invoiceHTML = `<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Invoice {{.Booking.BookingRef}}</title></head>
+<body style="font-family: sans-serif; max-width: 640px; margin: 24px auto;">
+	<h1>{{.Brand}} invoice</h1>
+	<p>Invoice for booking <strong>{{.Booking.BookingRef}}</strong><br>
+	Date: {{.Booking.CreatedAt.Format "2 January 2006"}}<br>
+	Billed to: {{.OwnerName}} ({{.OwnerEmail}})</p>
+	<p>{{.Booking.EventName}}, {{.Booking.Venue}}, {{.Booking.EventDate.Format "2 January 2006 15:04 MST"}}</p>
+	{{if eq .Booking.Status "CANCELLED"}}<p><strong>This booking was cancelled{{if .Booking.CancelledAt}} on {{.Booking.CancelledAt.Format "2 January 2006"}}{{end}}.</strong></p>{{end}}
+	<table border="1" cellpadding="6" cellspacing="0">
+		<tr><th>Item</th><th>Quantity</th><th>Unit price</th><th>Amount</th></tr>
+		{{range .Booking.Seats}}<tr><td>{{.SectionName}}, row {{.Row}}, seat {{.SeatNumber}}</td><td>1</td><td>{{printf "%.2f" .Price}}</td><td>{{printf "%.2f" .Price}}</td></tr>
+		{{end}}
+		{{range .Booking.Tickets}}<tr><td>{{.Name}}</td><td>{{.Quantity}}</td><td>{{printf "%.2f" .UnitPrice}}</td><td>{{printf "%.2f" (lineTotal .Quantity .UnitPrice)}}</td></tr>
+		{{end}}
+		<tr><td colspan="3"><strong>Total</strong></td><td><strong>{{printf "%.2f" .Booking.TotalPrice}} {{.Booking.Currency}}</strong></td></tr>
+	</table>
+	{{if .Booking.Payments}}
+	<h3>Payments</h3>
+	<table border="1" cellpadding="6" cellspacing="0">
+		<tr><th>Date</th><th>Method</th><th>Transaction</th><th>Status</th><th>Amount</th></tr>
+		{{range .Booking.Payments}}<tr><td>{{.CreatedAt.Format "2 Jan 2006"}}</td><td>{{.PaymentMethod}}</td><td>{{.TransactionID}}</td><td>{{.Status}}</td><td>{{printf "%.2f" .Amount}} {{.Currency}}</td></tr>
+		{{end}}
+	</table>
+	{{end}}
+</body>
+</html>
+`
+
+var (
+	documentFuncs = template.FuncMap{
+		"lineTotal": func(quantity int, unitPrice float64) float64 { return float64(quantity) * unitPrice },
+	}
+	ticketTemplate  = template.Must(template.New("ticket").Funcs(documentFuncs).Parse(ticketHTML))
+	invoiceTemplate = template.Must(template.New("invoice").Funcs(documentFuncs).Parse(invoiceHTML))
+)
+
+type documentData struct {
+	Brand      string
+	OwnerName  string
+	OwnerEmail string
+	Booking    *BookingDocument
+}
+
+// archiveManifest is written to the ZIP root so the contents can be read by a machine
+type archiveManifest struct {
+	GeneratedAt time.Time       `json:"generated_at"`
+	UserID      string          `json:"user_id"`
+	Bookings    []manifestEntry `json:"bookings"`
+}
+
+type manifestEntry struct {
+	BookingRef string  `json:"booking_ref"`
+	Event      string  `json:"event"`
+	EventDate  string  `json:"event_date"`
+	Status     string  `json:"status"`
+	Total      float64 `json:"total"`
+	Currency   string  `json:"currency"`
+	Ticket     string  `json:"ticket,omitempty"`
+	Invoice    string  `json:"invoice"`
+}
+
+// archiveCounts is what went into an archive
+type archiveCounts struct {
+	Bookings int
+	Tickets  int
+	Invoices int
+}
+
+// writeArchive writes the ZIP of the owner's bookings. Every booking gets an
+// invoice; only confirmed bookings get a ticket since cancelled ones no longer admit.
+func writeArchive(w io.Writer, brand string, owner *DocumentOwner, bookings []BookingDocument, now time.Time) (archiveCounts, error) {
+	var counts archiveCounts
+	zw := zip.NewWriter(w)
+
+	data := documentData{
+		Brand:      brand,
+		OwnerName:  owner.FirstName + " " + owner.LastName,
+		OwnerEmail: owner.Email,
+	}
+	manifest := archiveManifest{
+		GeneratedAt: now,
+		UserID:      owner.ID.String(),
+		Bookings:    make([]manifestEntry, 0, len(bookings)),
+	}
+
+	for i := range bookings {
+		booking := &bookings[i]
+		data.Booking = booking
+		entry := manifestEntry{
+			BookingRef: booking.BookingRef,
+			Event:      booking.EventName,
+			EventDate:  booking.EventDate.Format(time.RFC3339),
+			Status:     booking.Status,
+			Total:      booking.TotalPrice,
+			Currency:   booking.Currency,
+		}
+
+		if booking.Status == "CONFIRMED" {
+			entry.Ticket = fmt.Sprintf("tickets/%s.html", booking.BookingRef)
+			if err := writeTemplate(zw, entry.Ticket, booking.CreatedAt, ticketTemplate, data); err != nil {
+				return counts, err
+			}
+			counts.Tickets++
+		}
+
+		entry.Invoice = fmt.Sprintf("invoices/%s.html", booking.BookingRef)
+		if err := writeTemplate(zw, entry.Invoice, booking.CreatedAt, invoiceTemplate, data); err != nil {
+			return counts, err
+		}
+		counts.Invoices++
+		counts.Bookings++
+
+		manifest.Bookings = append(manifest.Bookings, entry)
+	}
+
+	file, err := zw.CreateHeader(&zip.FileHeader{Name: "manifest.json", Method: zip.Deflate, Modified: now})
+	if err != nil {
+		return counts, fmt.Errorf("failed to add manifest: %w", err)
+	}
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(manifest); err != nil {
+		return counts, fmt.Errorf("failed to write manifest: %w", err)
+	}
+
+	if err := zw.Close(); err != nil {
+		return counts, fmt.Errorf("failed to finish archive: %w", err)
+	}
+	return counts, nil
+}
+
+func writeTemplate(zw *zip.Writer, name string, modified time.Time, tmpl *template.Template, data documentData) error {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return fmt.Errorf("failed to render %s: %w", name, err)
+	}
+
+	file, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: modified})
+	if err != nil {
+		return fmt.Errorf("failed to add %s: %w", name, err)
+	}
+	if _, err := buf.WriteTo(file); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,219 @@
+package documents
+
+import (
+	"context"
+	"time"
+
+	"evently/internal/outbox"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	// Archives
+	Create(ctx context.Context, archive *DocumentArchive) error
+	GetByID(ctx context.Context, id uuid.UUID) (*DocumentArchive, error)
+	GetLatest(ctx context.Context, userID uuid.UUID) (*DocumentArchive, error)
+	Complete(ctx context.Context, archive *DocumentArchive, notifications []*outbox.Message) error
+	Fail(ctx context.Context, id uuid.UUID, reason string) error
+	FailStale(ctx context.Context, startedBefore time.Time) (int64, error)
+	GetExpired(ctx context.Context, now time.Time, limit int) ([]DocumentArchive, error)
+	MarkExpired(ctx context.Context, id uuid.UUID) error
+
+	// Document contents
+	GetOwner(ctx context.Context, userID uuid.UUID) (*DocumentOwner, error)
+	GetBookingDocuments(ctx context.Context, userID uuid.UUID) ([]BookingDocument, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+//  ARCHIVES
+
+func (r *repository) Create(ctx context.Context, archive *DocumentArchive) error {
+	return r.db.WithContext(ctx).Create(archive).Error
+}
+
+func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*DocumentArchive, error) {
+	var archive DocumentArchive
+	if err := r.db.WithContext(ctx).First(&archive, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &archive, nil
+}
+
+func (r *repository) GetLatest(ctx context.Context, userID uuid.UUID) (*DocumentArchive, error) {
+	var archive DocumentArchive
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Take(&archive).Error
+	if err != nil {
+		return nil, err
+	}
+	return &archive, nil
+}
+
+// Complete stores the built archive's details and queues the ready email in the
+// same transaction, unless the archive stopped processing in the meantime
+func (r *repository) Complete(ctx context.Context, archive *DocumentArchive, notifications []*outbox.Message) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&DocumentArchive{}).
+			Where("id = ? AND status = ?", archive.ID, ArchiveStatusProcessing).
+			Updates(map[string]interface{}{
+				"status":        ArchiveStatusReady,
+				"storage_key":   archive.StorageKey,
+				"size_bytes":    archive.SizeBytes,
+				"booking_count": archive.BookingCount,
+				"ticket_count":  archive.TicketCount,
+				"invoice_count": archive.InvoiceCount,
+				"expires_at":    archive.ExpiresAt,
+				"completed_at":  archive.CompletedAt,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return outbox.Enqueue(tx, notifications...)
+	})
+}
+
+func (r *repository) Fail(ctx context.Context, id uuid.UUID, reason string) error {
+	return r.db.WithContext(ctx).
+		Model(&DocumentArchive{}).
+		Where("id = ? AND status = ?", id, ArchiveStatusProcessing).
+		Updates(map[string]interface{}{
+			"status":       ArchiveStatusFailed,
+			"error":        reason,
+			"completed_at": time.Now(),
+		}).Error
+}
+
+// FailStale fails archives whose build never finished, e.g. after a restart
+func (r *repository) FailStale(ctx context.Context, startedBefore time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Model(&DocumentArchive{}).
+		Where("status = ? AND created_at < ?", ArchiveStatusProcessing, startedBefore).
+		Updates(map[string]interface{}{
+			"status":       ArchiveStatusFailed,
+			"error":        "archive build did not finish",
+			"completed_at": time.Now(),
+		})
+	return result.RowsAffected, result.Error
+}
+
+func (r *repository) GetExpired(ctx context.Context, now time.Time, limit int) ([]DocumentArchive, error) {
+	var archives []DocumentArchive
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND expires_at < ?", ArchiveStatusReady, now).
+		Order("expires_at ASC").
+		Limit(limit).
+		Find(&archives).Error
+	return archives, err
+}
+
+func (r *repository) MarkExpired(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).
+		Model(&DocumentArchive{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":      ArchiveStatusExpired,
+			"storage_key": "",
+		}).Error
+}
+
+//  DOCUMENT CONTENTS
+
+func (r *repository) GetOwner(ctx context.Context, userID uuid.UUID) (*DocumentOwner, error) {
+	var owner DocumentOwner
+	err := r.db.WithContext(ctx).
+		Table("users").
+		Select("id, first_name, last_name, email").
+		Where("id = ? AND deleted_at IS NULL", userID).
+		Take(&owner).Error
+	if err != nil {
+		return nil, err
+	}
+	return &owner, nil
+}
+
+// GetBookingDocuments returns the user's confirmed and cancelled bookings with
+// their seats, general admission tickets and payments, newest first
+func (r *repository) GetBookingDocuments(ctx context.Context, userID uuid.UUID) ([]BookingDocument, error) {
+	db := r.db.WithContext(ctx)
+
+	var bookings []BookingDocument
+	err := db.Table("bookings b").
+		Select(`b.id, b.booking_ref, b.status, b.total_seats, b.total_price, b.currency, b.created_at, b.cancelled_at,
+			e.id AS event_id, e.name AS event_name, e.venue, e.date_time AS event_date`).
+		Joins("JOIN events e ON e.id = b.event_id").
+		Where("b.user_id = ? AND b.status IN ?", userID, []string{"CONFIRMED", "CANCELLED"}).
+		Order("b.created_at DESC").
+		Scan(&bookings).Error
+	if err != nil || len(bookings) == 0 {
+		return bookings, err
+	}
+
+	bookingIDs := make([]uuid.UUID, 0, len(bookings))
+	for _, booking := range bookings {
+		bookingIDs = append(bookingIDs, booking.ID)
+	}
+
+	var seats []SeatLine
+	err = db.Table("seat_bookings sb").
+		Select("sb.booking_id, vs.name AS section_name, s.row, s.seat_number, sb.seat_price AS price").
+		Joins("JOIN seats s ON s.id = sb.seat_id").
+		Joins("JOIN venue_sections vs ON vs.id = sb.section_id").
+		Where("sb.booking_id IN ?", bookingIDs).
+		Order("vs.name, s.row, s.position").
+		Scan(&seats).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var tickets []TicketLine
+	err = db.Table("ticket_bookings tb").
+		Select("tb.booking_id, tt.name, tb.quantity, tb.unit_price").
+		Joins("JOIN ticket_types tt ON tt.id = tb.ticket_type_id").
+		Where("tb.booking_id IN ?", bookingIDs).
+		Order("tt.name").
+		Scan(&tickets).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var payments []PaymentLine
+	err = db.Table("payments").
+		Select("booking_id, amount, currency, status, payment_method, transaction_id, processed_at, created_at").
+		Where("booking_id IN ?", bookingIDs).
+		Order("created_at").
+		Scan(&payments).Error
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[uuid.UUID]*BookingDocument, len(bookings))
+	for i := range bookings {
+		byID[bookings[i].ID] = &bookings[i]
+	}
+	for _, seat := range seats {
+		byID[seat.BookingID].Seats = append(byID[seat.BookingID].Seats, seat)
+	}
+	for _, ticket := range tickets {
+		byID[ticket.BookingID].Tickets = append(byID[ticket.BookingID].Tickets, ticket)
+	}
+	for _, payment := range payments {
+		byID[payment.BookingID].Payments = append(byID[payment.BookingID].Payments, payment)
+	}
+
+	return bookings, nil
+}
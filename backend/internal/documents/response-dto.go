@@ -0,0 +1,7 @@
+package documents
+
+// ArchiveResponse is an archive's status, with a signed download link once it is ready
+type ArchiveResponse struct {
+	DocumentArchive
+	DownloadURL string `json:"download_url,omitempty"`
+}
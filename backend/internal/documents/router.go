@@ -0,0 +1,21 @@
+package documents
+
+import (
+	"evently/internal/shared/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupDocumentRoutes(rg *gin.RouterGroup, controller *Controller) {
+	users := rg.Group("/users/me/documents")
+	users.Use(middleware.JWTAuth(), middleware.RequireRoles("USER", "ADMIN"))
+	{
+		users.GET("/archive", controller.RequestArchive) // GET /api/v1/users/me/documents/archive
+	}
+
+	// Signed links from the ready email, no login needed
+	archives := rg.Group("/documents/archives")
+	{
+		archives.GET("/:id/download", controller.DownloadArchive) // GET /api/v1/documents/archives/:id/download
+	}
+}
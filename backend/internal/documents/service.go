@@ -0,0 +1,313 @@
+package documents
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"evently/internal/outbox"
+	"evently/pkg/media"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const NotificationTypeArchiveReady = "DOCUMENT_ARCHIVE_READY"
+
+// Config contains configuration for user document archives
+type Config struct {
+	LinkTTL         time.Duration // How long an archive can be downloaded once built
+	MinInterval     time.Duration // Minimum gap between a user's archive requests
+	BuildTimeout    time.Duration // Archives still processing after this are failed
+	CleanupInterval time.Duration
+	CleanupBatch    int
+	DownloadURL     string // Public download endpoint, {archive_id} is replaced
+	LinkSecret      string // Signs download links
+	BrandName       string // Shown on tickets and invoices
+}
+
+// DefaultConfig returns default document archive configuration
+func DefaultConfig() *Config {
+	return &Config{
+		LinkTTL:         24 * time.Hour, // Links work for a day
+		MinInterval:     time.Hour,      // One new archive per user per hour
+		BuildTimeout:    30 * time.Minute,
+		CleanupInterval: time.Hour,
+		CleanupBatch:    100,
+		DownloadURL:     "http://localhost:8080/api/v1/documents/archives/{archive_id}/download",
+		BrandName:       "Evently",
+	}
+}
+
+// ThrottledError is returned when a user asks for a new archive too soon
+type ThrottledError struct {
+	RetryAfter time.Duration
+}
+
+func (e *ThrottledError) Error() string {
+	return fmt.Sprintf("an archive was requested recently, try again in %s", e.RetryAfter.Round(time.Minute))
+}
+
+var (
+	ErrInvalidLink = errors.New("invalid or expired download link")
+	ErrNotReady    = errors.New("archive is not ready for download")
+)
+
+type Service interface {
+	// RequestArchive returns the user's current archive, starting a new build when allowed
+	RequestArchive(ctx context.Context, userID uuid.UUID) (*ArchiveResponse, error)
+	// OpenArchive checks a signed download link and opens the archive it points to
+	OpenArchive(ctx context.Context, archiveID uuid.UUID, expires, signature string) (*DocumentArchive, io.ReadCloser, error)
+	// CleanupArchives fails stuck builds and deletes archives whose links have lapsed
+	CleanupArchives(ctx context.Context) (int, error)
+}
+
+type service struct {
+	repo    Repository
+	store   media.Store
+	config  *Config
+	linkKey []byte
+}
+
+func NewService(repo Repository, store media.Store, config *Config) Service {
+	if config == nil {
+		config = DefaultConfig()
+	}
+	linkKey := sha256.Sum256([]byte("document-archive-link:" + config.LinkSecret))
+	return &service{repo: repo, store: store, config: config, linkKey: linkKey[:]}
+}
+
+//  REQUESTS
+
+func (s *service) RequestArchive(ctx context.Context, userID uuid.UUID) (*ArchiveResponse, error) {
+	latest, err := s.repo.GetLatest(ctx, userID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to get archive: %w", err)
+	}
+
+	now := time.Now()
+	if latest != nil && latest.Status == ArchiveStatusProcessing {
+		if now.Sub(latest.CreatedAt) < s.config.BuildTimeout {
+			return s.toResponse(latest), nil
+		}
+		if err := s.repo.Fail(ctx, latest.ID, "archive build did not finish"); err != nil {
+			return nil, fmt.Errorf("failed to update archive: %w", err)
+		}
+		latest.Status = ArchiveStatusFailed
+	}
+
+	// Failed builds can be retried straight away
+	if latest != nil && latest.Status != ArchiveStatusFailed {
+		if wait := latest.CreatedAt.Add(s.config.MinInterval).Sub(now); wait > 0 {
+			if latest.Status == ArchiveStatusReady && latest.ExpiresAt != nil && latest.ExpiresAt.After(now) {
+				return s.toResponse(latest), nil
+			}
+			return nil, &ThrottledError{RetryAfter: wait}
+		}
+	}
+
+	archive := &DocumentArchive{
+		UserID: userID,
+		Status: ArchiveStatusProcessing,
+	}
+	if err := s.repo.Create(ctx, archive); err != nil {
+		// Another request started a build first
+		if strings.Contains(err.Error(), "duplicate key") {
+			current, getErr := s.repo.GetLatest(ctx, userID)
+			if getErr != nil {
+				return nil, fmt.Errorf("failed to get archive: %w", getErr)
+			}
+			return s.toResponse(current), nil
+		}
+		return nil, fmt.Errorf("failed to create archive: %w", err)
+	}
+
+	go s.buildArchive(archive.ID, userID)
+
+	log.Printf("📦 Document archive %s requested by user %s", archive.ID, userID)
+	return s.toResponse(archive), nil
+}
+
+// buildArchive renders and stores the archive, then queues the ready email. It
+// runs after the request has returned, so it has its own context.
+func (s *service) buildArchive(archiveID, userID uuid.UUID) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.config.BuildTimeout)
+	defer cancel()
+
+	if err := s.build(ctx, archiveID, userID); err != nil {
+		log.Printf("Failed to build document archive %s for user %s: %v", archiveID, userID, err)
+		// The build context may be what ran out
+		if failErr := s.repo.Fail(context.Background(), archiveID, err.Error()); failErr != nil {
+			log.Printf("Warning: failed to mark document archive %s failed: %v", archiveID, failErr)
+		}
+	}
+}
+
+func (s *service) build(ctx context.Context, archiveID, userID uuid.UUID) error {
+	owner, err := s.repo.GetOwner(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	bookings, err := s.repo.GetBookingDocuments(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get bookings: %w", err)
+	}
+
+	now := time.Now()
+	var buf bytes.Buffer
+	counts, err := writeArchive(&buf, s.config.BrandName, owner, bookings, now)
+	if err != nil {
+		return err
+	}
+
+	archive := &DocumentArchive{
+		ID:           archiveID,
+		StorageKey:   fmt.Sprintf("archives/%s/%s.zip", userID, archiveID),
+		SizeBytes:    int64(buf.Len()),
+		BookingCount: counts.Bookings,
+		TicketCount:  counts.Tickets,
+		InvoiceCount: counts.Invoices,
+	}
+	if _, err := s.store.Save(ctx, archive.StorageKey, &buf); err != nil {
+		return fmt.Errorf("failed to store archive: %w", err)
+	}
+
+	completedAt := time.Now()
+	expiresAt := completedAt.Add(s.config.LinkTTL)
+	archive.CompletedAt = &completedAt
+	archive.ExpiresAt = &expiresAt
+
+	message, err := s.buildReadyNotification(archive, userID)
+	if err != nil {
+		s.deleteFile(ctx, archive)
+		return err
+	}
+
+	if err := s.repo.Complete(ctx, archive, []*outbox.Message{message}); err != nil {
+		s.deleteFile(ctx, archive)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("archive stopped processing before it was built")
+		}
+		return fmt.Errorf("failed to save archive: %w", err)
+	}
+
+	log.Printf("📦 Document archive %s ready for user %s (%d bookings, %d bytes)",
+		archiveID, userID, counts.Bookings, archive.SizeBytes)
+	return nil
+}
+
+func (s *service) buildReadyNotification(archive *DocumentArchive, userID uuid.UUID) (*outbox.Message, error) {
+	payload := &outbox.NotificationPayload{
+		Type:        NotificationTypeArchiveReady,
+		RecipientID: userID,
+		TemplateData: map[string]interface{}{
+			"download_url":  s.downloadURL(archive),
+			"expires_at":    archive.ExpiresAt.UTC().Format("Mon, Jan 2, 2006 3:04 PM MST"),
+			"booking_count": archive.BookingCount,
+			"ticket_count":  archive.TicketCount,
+			"invoice_count": archive.InvoiceCount,
+		},
+	}
+
+	return outbox.NewNotificationMessage(outbox.AggregateUser, userID,
+		fmt.Sprintf("document_archive:%s:ready", archive.ID), payload)
+}
+
+//  DOWNLOADS
+
+func (s *service) OpenArchive(ctx context.Context, archiveID uuid.UUID, expires, signature string) (*DocumentArchive, io.ReadCloser, error) {
+	expiresUnix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || !hmac.Equal([]byte(signature), []byte(s.sign(archiveID, expiresUnix))) {
+		return nil, nil, ErrInvalidLink
+	}
+	if time.Now().Unix() > expiresUnix {
+		return nil, nil, ErrInvalidLink
+	}
+
+	archive, err := s.repo.GetByID(ctx, archiveID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrInvalidLink
+		}
+		return nil, nil, fmt.Errorf("failed to get archive: %w", err)
+	}
+	if archive.Status != ArchiveStatusReady || archive.StorageKey == "" {
+		return nil, nil, ErrNotReady
+	}
+
+	file, err := s.store.Open(ctx, archive.StorageKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open archive: %w", err)
+	}
+	return archive, file, nil
+}
+
+// downloadURL is the signed link for a ready archive, valid until the archive expires
+func (s *service) downloadURL(archive *DocumentArchive) string {
+	expires := archive.ExpiresAt.Unix()
+	link := strings.ReplaceAll(s.config.DownloadURL, "{archive_id}", archive.ID.String())
+	return fmt.Sprintf("%s?expires=%d&signature=%s", link, expires, s.sign(archive.ID, expires))
+}
+
+func (s *service) sign(archiveID uuid.UUID, expires int64) string {
+	mac := hmac.New(sha256.New, s.linkKey)
+	fmt.Fprintf(mac, "%s:%d", archiveID, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+//  CLEANUP
+
+func (s *service) CleanupArchives(ctx context.Context) (int, error) {
+	failed, err := s.repo.FailStale(ctx, time.Now().Add(-s.config.BuildTimeout))
+	if err != nil {
+		return 0, fmt.Errorf("failed to fail stale archives: %w", err)
+	}
+	if failed > 0 {
+		log.Printf("Failed %d document archives that never finished building", failed)
+	}
+
+	expired, err := s.repo.GetExpired(ctx, time.Now(), s.config.CleanupBatch)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get expired archives: %w", err)
+	}
+
+	removed := 0
+	for i := range expired {
+		if err := s.store.Delete(ctx, expired[i].StorageKey); err != nil {
+			log.Printf("Failed to delete document archive %s: %v", expired[i].ID, err)
+			continue
+		}
+		if err := s.repo.MarkExpired(ctx, expired[i].ID); err != nil {
+			log.Printf("Failed to mark document archive %s expired: %v", expired[i].ID, err)
+			continue
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+//  HELPERS
+
+func (s *service) deleteFile(ctx context.Context, archive *DocumentArchive) {
+	if err := s.store.Delete(ctx, archive.StorageKey); err != nil {
+		log.Printf("Warning: failed to delete document archive file %s: %v", archive.StorageKey, err)
+	}
+}
+
+func (s *service) toResponse(archive *DocumentArchive) *ArchiveResponse {
+	resp := &ArchiveResponse{DocumentArchive: *archive}
+	if archive.Status == ArchiveStatusReady && archive.ExpiresAt != nil {
+		resp.DownloadURL = s.downloadURL(archive)
+	}
+	return resp
+}
@@ -0,0 +1,109 @@
+package domainevents
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"evently/internal/outbox"
+
+	"github.com/google/uuid"
+)
+
+// Domain event types published for downstream consumers such as analytics and CRM
+const (
+	BookingConfirmed   = "BookingConfirmed"
+	BookingCancelled   = "BookingCancelled"
+	SeatHoldExpired    = "SeatHoldExpired"
+	EventPublished     = "EventPublished"
+	EventStatusChanged = "EventStatusChanged"
+	WaitlistJoined     = "WaitlistJoined"
+)
+
+// Topics events are published to, before the configured prefix. Events of one
+// aggregate share a topic and are keyed by aggregate ID, so consumers see them
+// in order.
+const (
+	TopicBookings = "bookings"
+	TopicSeats    = "seats"
+	TopicEvents   = "events"
+	TopicWaitlist = "waitlist"
+)
+
+// Topics lists the outbox topics domain events are recorded under, the ones
+// the domain event relay claims
+var Topics = []string{TopicBookings, TopicSeats, TopicEvents, TopicWaitlist}
+
+// Schema describes the data of one event type. Version is bumped on breaking
+// changes so consumers and a schema registry can tell payload shapes apart.
+type Schema struct {
+	Topic         string
+	AggregateType string
+	Version       int
+}
+
+// Schemas lists every event type that can be published
+var Schemas = map[string]Schema{
+	BookingConfirmed:   {Topic: TopicBookings, AggregateType: outbox.AggregateBooking, Version: 1},
+	BookingCancelled:   {Topic: TopicBookings, AggregateType: outbox.AggregateBooking, Version: 1},
+	SeatHoldExpired:    {Topic: TopicSeats, AggregateType: outbox.AggregateSeatHold, Version: 1},
+	EventPublished:     {Topic: TopicEvents, AggregateType: outbox.AggregateEvent, Version: 1},
+	EventStatusChanged: {Topic: TopicEvents, AggregateType: outbox.AggregateEvent, Version: 1},
+	WaitlistJoined:     {Topic: TopicWaitlist, AggregateType: outbox.AggregateWaitlistEntry, Version: 1},
+}
+
+// Source identifies this service in every envelope
+const Source = "evently-backend"
+
+// Envelope is the message value. It follows the CloudEvents 1.0 JSON format so
+// consumers and schema registries can route on type and dataschema without
+// reading the data.
+type Envelope struct {
+	SpecVersion     string      `json:"specversion"`
+	ID              uuid.UUID   `json:"id"`
+	Source          string      `json:"source"`
+	Type            string      `json:"type"`
+	Subject         string      `json:"subject"` // Aggregate ID, also the message key
+	Time            time.Time   `json:"time"`
+	DataContentType string      `json:"datacontenttype"`
+	DataSchema      string      `json:"dataschema"`
+	Data            interface{} `json:"data"`
+}
+
+// DataSchema names the schema of an event type's data, e.g.
+// "urn:evently:events:BookingConfirmed:v1"
+func DataSchema(eventType string, version int) string {
+	return fmt.Sprintf("urn:evently:events:%s:v%d", eventType, version)
+}
+
+// NewEvent builds a pending outbox message for the event, to be written with
+// outbox.Enqueue in the transaction of the change it describes. Its payload is
+// the envelope exactly as it is sent to Kafka. The dedup key must be stable for
+// the logical event so retried writes collapse into one message.
+func NewEvent(eventType string, aggregateID uuid.UUID, dedupKey string, data interface{}) (*outbox.Message, error) {
+	schema, ok := Schemas[eventType]
+	if !ok {
+		return nil, fmt.Errorf("unknown domain event type %q", eventType)
+	}
+
+	id := uuid.New()
+	payload, err := json.Marshal(Envelope{
+		SpecVersion:     "1.0",
+		ID:              id,
+		Source:          Source,
+		Type:            eventType,
+		Subject:         aggregateID.String(),
+		Time:            time.Now().UTC(),
+		DataContentType: "application/json",
+		DataSchema:      DataSchema(eventType, schema.Version),
+		Data:            data,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal domain event: %w", err)
+	}
+
+	// Prefixed so domain event keys can't collide with notification keys
+	msg := outbox.NewMessage(schema.Topic, schema.AggregateType, aggregateID, "domain:"+dedupKey, string(payload))
+	msg.ID = id
+	return msg, nil
+}
@@ -0,0 +1,106 @@
+package domainevents
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"evently/internal/outbox"
+	"evently/pkg/metrics"
+
+	"github.com/IBM/sarama"
+)
+
+// KafkaConfig contains configuration for the Kafka publisher
+type KafkaConfig struct {
+	Brokers     []string
+	TopicPrefix string // Prepended to every topic, e.g. "evently." gives "evently.bookings"
+	RetryMax    int
+	Timeout     time.Duration
+}
+
+// DefaultKafkaConfig returns default Kafka publisher configuration
+func DefaultKafkaConfig() *KafkaConfig {
+	return &KafkaConfig{
+		Brokers:     []string{"localhost:9092"},
+		TopicPrefix: "evently.",
+		RetryMax:    3,
+		Timeout:     10 * time.Second,
+	}
+}
+
+// KafkaPublisher publishes events with an idempotent sync producer. Messages are
+// keyed by aggregate ID, so every event of a booking lands on one partition.
+type KafkaPublisher struct {
+	producer sarama.SyncProducer
+	config   *KafkaConfig
+}
+
+// NewKafkaPublisher creates a Kafka publisher
+func NewKafkaPublisher(config *KafkaConfig) (*KafkaPublisher, error) {
+	if config == nil {
+		config = DefaultKafkaConfig()
+	}
+
+	saramaConfig := sarama.NewConfig()
+	saramaConfig.Producer.Return.Successes = true
+	saramaConfig.Producer.Return.Errors = true
+	saramaConfig.Producer.RequiredAcks = sarama.WaitForAll
+	saramaConfig.Producer.Retry.Max = config.RetryMax
+	saramaConfig.Producer.Timeout = config.Timeout
+	saramaConfig.Producer.Idempotent = true
+	saramaConfig.Producer.Compression = sarama.CompressionSnappy
+	saramaConfig.Producer.Partitioner = sarama.NewHashPartitioner
+	saramaConfig.Net.MaxOpenRequests = 1
+
+	producer, err := sarama.NewSyncProducer(config.Brokers, saramaConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kafka producer: %w", err)
+	}
+
+	log.Printf("📤 Kafka domain event publisher created successfully")
+	return &KafkaPublisher{producer: producer, config: config}, nil
+}
+
+// Publish sends one recorded event. It implements outbox.Publisher, so the
+// outbox relay delivers domain events with it.
+func (p *KafkaPublisher) Publish(ctx context.Context, msg *outbox.Message) error {
+	var envelope Envelope
+	if err := json.Unmarshal([]byte(msg.Payload), &envelope); err != nil {
+		return fmt.Errorf("%w: invalid domain event envelope: %v", outbox.ErrUndeliverable, err)
+	}
+
+	topic := p.config.TopicPrefix + msg.Topic
+	message := &sarama.ProducerMessage{
+		Topic: topic,
+		Key:   sarama.StringEncoder(msg.AggregateID.String()),
+		Value: sarama.StringEncoder(msg.Payload),
+		// CloudEvents Kafka binding headers, so consumers can filter without
+		// parsing the value
+		Headers: []sarama.RecordHeader{
+			{Key: []byte("content-type"), Value: []byte("application/cloudevents+json")},
+			{Key: []byte("ce_specversion"), Value: []byte(envelope.SpecVersion)},
+			{Key: []byte("ce_id"), Value: []byte(envelope.ID.String())},
+			{Key: []byte("ce_type"), Value: []byte(envelope.Type)},
+			{Key: []byte("ce_source"), Value: []byte(envelope.Source)},
+			{Key: []byte("ce_dataschema"), Value: []byte(envelope.DataSchema)},
+		},
+		Timestamp: msg.CreatedAt,
+	}
+
+	if _, _, err := p.producer.SendMessage(message); err != nil {
+		metrics.KafkaPublishFailuresTotal.Inc(topic)
+		return fmt.Errorf("failed to send domain event to Kafka: %w", err)
+	}
+	return nil
+}
+
+func (p *KafkaPublisher) Close() error {
+	if err := p.producer.Close(); err != nil {
+		return fmt.Errorf("failed to close Kafka producer: %w", err)
+	}
+	log.Printf("📤 Kafka domain event publisher closed")
+	return nil
+}
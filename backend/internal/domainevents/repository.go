@@ -0,0 +1,32 @@
+package domainevents
+
+import (
+	"context"
+
+	"evently/internal/outbox"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	// Records an event outside of a caller's transaction, for changes that are
+	// saved before their event can be built
+	Publish(ctx context.Context, eventType string, aggregateID uuid.UUID, dedupKey string, data interface{}) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) Publish(ctx context.Context, eventType string, aggregateID uuid.UUID, dedupKey string, data interface{}) error {
+	event, err := NewEvent(eventType, aggregateID, dedupKey, data)
+	if err != nil {
+		return err
+	}
+	return outbox.Enqueue(r.db.WithContext(ctx), event)
+}
@@ -0,0 +1,376 @@
+package emailtemplates
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+
+	"evently/internal/shared/utils/response"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type Controller struct {
+	service Service
+}
+
+func NewController(service Service) *Controller {
+	return &Controller{service: service}
+}
+
+// ListTemplates returns every notification template with its sample data
+//
+// @Summary      List notification email templates
+// @Description  Every email template with the sample data it is previewed with when no data is provided
+// @Description
+// @Description  Requires the `notifications:manage` permission.
+// @Tags         Admin Notification Templates
+// @Produce      json
+// @Security     Bearer
+// @Success      200 {object} response.StandardApiResponse{data=[]TemplateInfo} "Notification templates retrieved successfully"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/notification-templates [get]
+func (ctrl *Controller) ListTemplates(c *gin.Context) {
+	templates, err := ctrl.service.ListTemplates(c.Request.Context())
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusInternalServerError, "Failed to retrieve notification templates", nil, err.Error())
+		return
+	}
+	response.RespondJSON(c, "success", http.StatusOK, "Notification templates retrieved successfully", templates, nil)
+}
+
+// Preview renders a template without sending it. The body is optional.
+//
+// @Summary      Render a notification template
+// @Description  Renders the subject, HTML and plain text of a template without sending anything. Provided data is merged
+// @Description  over the template's sample data, and platform branding is applied unless the data sets brand_* keys.
+// @Description
+// @Description  Requires the `notifications:manage` permission.
+// @Tags         Admin Notification Templates
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "Notification type, case-insensitive"
+// @Param        request body PreviewRequest false "Request body"
+// @Success      200 {object} response.StandardApiResponse{data=PreviewResponse} "Notification template rendered successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Template not found"
+// @Failure      409 {object} response.StandardApiResponse
+// @Failure      429 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Failure      503 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/notification-templates/{id}/preview [post]
+func (ctrl *Controller) Preview(c *gin.Context) {
+	var req PreviewRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid request body", nil, err.Error())
+			return
+		}
+	}
+
+	preview, err := ctrl.service.Preview(c.Request.Context(), c.Param("id"), req)
+	if err != nil {
+		ctrl.respondError(c, err, "Failed to render notification template")
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Notification template rendered successfully", preview, nil)
+}
+
+// TestSend renders a template and emails it to the given address
+//
+// @Summary      Send a test email of a notification template
+// @Description  Renders a template like the preview endpoint and emails it to the given address with a '[TEST] ' subject
+// @Description  prefix. Each admin can send EMAIL_TEST_SEND_LIMIT test emails per EMAIL_TEST_SEND_WINDOW.
+// @Description
+// @Description  Requires the `notifications:manage` permission.
+// @Tags         Admin Notification Templates
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "Notification type, case-insensitive"
+// @Param        request body TestSendRequest true "Request body"
+// @Success      202 {object} response.StandardApiResponse{data=TestSendResponse} "Test email queued for delivery"
+// @Failure      400 {object} response.StandardApiResponse "Invalid request body"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Template not found"
+// @Failure      409 {object} response.StandardApiResponse
+// @Failure      429 {object} response.StandardApiResponse "Too many test emails sent, see the Retry-After header"
+// @Failure      500 {object} response.StandardApiResponse
+// @Failure      503 {object} response.StandardApiResponse "Email sending is not configured"
+// @Router       /api/v1/admin/notification-templates/{id}/test-send [post]
+func (ctrl *Controller) TestSend(c *gin.Context) {
+	adminID, ok := ctrl.currentAdminID(c)
+	if !ok {
+		return
+	}
+
+	var req TestSendRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid request body", nil, err.Error())
+		return
+	}
+
+	result, err := ctrl.service.TestSend(c.Request.Context(), adminID, c.Param("id"), req)
+	if err != nil {
+		ctrl.respondError(c, err, "Failed to send test email")
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusAccepted, "Test email queued for delivery", result, nil)
+}
+
+// ListVersions returns every saved version of a template for one locale, newest first
+//
+// @Summary      List versions of a notification template
+// @Description  Every saved version of a template for one locale, newest first
+// @Description
+// @Description  Requires the `notifications:manage` permission.
+// @Tags         Admin Notification Templates
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "Notification type, case-insensitive"
+// @Param        locale query string false "Locale" default(en)
+// @Success      200 {object} response.StandardApiResponse{data=[]TemplateVersion} "Template versions retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse "Invalid locale"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Template not found"
+// @Failure      409 {object} response.StandardApiResponse
+// @Failure      429 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Failure      503 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/notification-templates/{id}/versions [get]
+func (ctrl *Controller) ListVersions(c *gin.Context) {
+	var query LocaleQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid query parameters", nil, err.Error())
+		return
+	}
+
+	versions, err := ctrl.service.ListVersions(c.Request.Context(), c.Param("id"), query.Locale)
+	if err != nil {
+		ctrl.respondError(c, err, "Failed to retrieve template versions")
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Template versions retrieved successfully", versions, nil)
+}
+
+// GetVersion godoc
+//
+// @Summary      Get a notification template version
+// @Description  Requires the `notifications:manage` permission.
+// @Tags         Admin Notification Templates
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "Notification type, case-insensitive"
+// @Param        version path integer true "Version" minimum(1)
+// @Param        locale query string false "Locale" default(en)
+// @Success      200 {object} response.StandardApiResponse{data=TemplateVersion} "Template version retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Template or version not found"
+// @Failure      409 {object} response.StandardApiResponse
+// @Failure      429 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Failure      503 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/notification-templates/{id}/versions/{version} [get]
+func (ctrl *Controller) GetVersion(c *gin.Context) {
+	version, ok := ctrl.versionParam(c)
+	if !ok {
+		return
+	}
+
+	var query LocaleQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid query parameters", nil, err.Error())
+		return
+	}
+
+	templateVersion, err := ctrl.service.GetVersion(c.Request.Context(), c.Param("id"), query.Locale, version)
+	if err != nil {
+		ctrl.respondError(c, err, "Failed to retrieve template version")
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Template version retrieved successfully", templateVersion, nil)
+}
+
+// CreateVersion saves edited template copy as a new version, live unless activate is false
+//
+// @Summary      Save a new version of a notification template
+// @Description  Saves an edited subject, HTML and text body for one locale as the next version. The template is parsed and
+// @Description  rendered against its sample data before saving. MJML is compiled to HTML through the MJML API when saved,
+// @Description  so MJML_APP_ID and MJML_SECRET_KEY must be set. The new version goes live unless activate is false.
+// @Description
+// @Description  Requires the `notifications:manage` permission.
+// @Tags         Admin Notification Templates
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "Notification type, case-insensitive"
+// @Param        request body CreateVersionRequest true "Request body"
+// @Success      201 {object} response.StandardApiResponse{data=TemplateVersion} "Template version saved successfully"
+// @Failure      400 {object} response.StandardApiResponse "Invalid request body, locale or template"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Template not found"
+// @Failure      409 {object} response.StandardApiResponse "Another version was saved at the same time, retry"
+// @Failure      429 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Failure      503 {object} response.StandardApiResponse "MJML rendering is not configured"
+// @Router       /api/v1/admin/notification-templates/{id}/versions [post]
+func (ctrl *Controller) CreateVersion(c *gin.Context) {
+	adminID, ok := ctrl.currentAdminID(c)
+	if !ok {
+		return
+	}
+
+	var req CreateVersionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid request body", nil, err.Error())
+		return
+	}
+
+	version, err := ctrl.service.CreateVersion(c.Request.Context(), adminID, c.Param("id"), req)
+	if err != nil {
+		ctrl.respondError(c, err, "Failed to save template version")
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusCreated, "Template version saved successfully", version, nil)
+}
+
+// ActivateVersion makes a saved version live, rolling back to it if it is older
+//
+// @Summary      Activate a notification template version
+// @Description  Makes the version live for its locale, replacing the active one. Activating an earlier version is how a
+// @Description  change is rolled back. Other API instances pick up the change within EMAIL_TEMPLATE_CACHE_TTL.
+// @Description
+// @Description  Requires the `notifications:manage` permission.
+// @Tags         Admin Notification Templates
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "Notification type, case-insensitive"
+// @Param        version path integer true "Version" minimum(1)
+// @Param        locale query string false "Locale" default(en)
+// @Success      200 {object} response.StandardApiResponse{data=TemplateVersion} "Template version activated successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Template or version not found"
+// @Failure      409 {object} response.StandardApiResponse "Another version was activated at the same time, retry"
+// @Failure      429 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Failure      503 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/notification-templates/{id}/versions/{version}/activate [post]
+func (ctrl *Controller) ActivateVersion(c *gin.Context) {
+	adminID, ok := ctrl.currentAdminID(c)
+	if !ok {
+		return
+	}
+	version, ok := ctrl.versionParam(c)
+	if !ok {
+		return
+	}
+
+	var query LocaleQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid query parameters", nil, err.Error())
+		return
+	}
+
+	templateVersion, err := ctrl.service.ActivateVersion(c.Request.Context(), adminID, c.Param("id"), query.Locale, version)
+	if err != nil {
+		ctrl.respondError(c, err, "Failed to activate template version")
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Template version activated successfully", templateVersion, nil)
+}
+
+// ResetLocale switches a locale back to the built-in template, keeping its versions
+//
+// @Summary      Reset a locale to the built-in template
+// @Description  Deactivates the custom template for the locale. Its versions are kept and can be activated again.
+// @Description
+// @Description  Requires the `notifications:manage` permission.
+// @Tags         Admin Notification Templates
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "Notification type, case-insensitive"
+// @Param        locale path string true "Locale"
+// @Success      200 {object} response.StandardApiResponse "Template reset to the built-in version"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Template not found or no custom template is active for the locale"
+// @Failure      409 {object} response.StandardApiResponse
+// @Failure      429 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Failure      503 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/notification-templates/{id}/locales/{locale} [delete]
+func (ctrl *Controller) ResetLocale(c *gin.Context) {
+	if err := ctrl.service.ResetLocale(c.Request.Context(), c.Param("id"), c.Param("locale")); err != nil {
+		ctrl.respondError(c, err, "Failed to reset template")
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Template reset to the built-in version", nil, nil)
+}
+
+func (ctrl *Controller) versionParam(c *gin.Context) (int, bool) {
+	version, err := strconv.Atoi(c.Param("version"))
+	if err != nil || version < 1 {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid template version", nil, nil)
+		return 0, false
+	}
+	return version, true
+}
+
+func (ctrl *Controller) currentAdminID(c *gin.Context) (uuid.UUID, bool) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.RespondJSON(c, "error", http.StatusUnauthorized, "User not authenticated", nil, nil)
+		return uuid.Nil, false
+	}
+
+	adminID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusInternalServerError, "Invalid user ID format", nil, nil)
+		return uuid.Nil, false
+	}
+	return adminID, true
+}
+
+func (ctrl *Controller) respondError(c *gin.Context, err error, message string) {
+	var throttled *ThrottledError
+	switch {
+	case errors.As(err, &throttled):
+		retryAfter := int(math.Ceil(throttled.RetryAfter.Seconds()))
+		c.Header("Retry-After", fmt.Sprintf("%d", retryAfter))
+		response.RespondJSON(c, "error", http.StatusTooManyRequests, err.Error(), nil,
+			map[string]interface{}{"retry_after_seconds": retryAfter})
+	case errors.Is(err, ErrTemplateNotFound), errors.Is(err, ErrVersionNotFound):
+		response.RespondJSON(c, "error", http.StatusNotFound, err.Error(), nil, nil)
+	case errors.Is(err, ErrInvalidLocale), errors.Is(err, ErrInvalidTemplate):
+		response.RespondJSON(c, "error", http.StatusBadRequest, err.Error(), nil, nil)
+	case errors.Is(err, ErrVersionConflict):
+		response.RespondJSON(c, "error", http.StatusConflict, err.Error(), nil, nil)
+	case errors.Is(err, ErrSendingNotConfigured), errors.Is(err, ErrMJMLNotConfigured):
+		response.RespondJSON(c, "error", http.StatusServiceUnavailable, err.Error(), nil, nil)
+	default:
+		response.RespondJSON(c, "error", http.StatusInternalServerError, message, nil, err.Error())
+	}
+}
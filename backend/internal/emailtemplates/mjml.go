@@ -0,0 +1,94 @@
+package emailtemplates
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// MJMLCompiler turns MJML markup into email-safe HTML
+type MJMLCompiler interface {
+	Compile(ctx context.Context, mjml string) (string, error)
+}
+
+// MJMLAPICompiler compiles MJML with the hosted MJML API. Go template actions
+// pass through compilation untouched, so the result is still a template.
+type MJMLAPICompiler struct {
+	url       string
+	appID     string
+	secretKey string
+	client    *http.Client
+}
+
+// NewMJMLAPICompiler returns nil when no credentials are configured
+func NewMJMLAPICompiler(url, appID, secretKey string) *MJMLAPICompiler {
+	if appID == "" || secretKey == "" {
+		return nil
+	}
+	return &MJMLAPICompiler{
+		url:       url,
+		appID:     appID,
+		secretKey: secretKey,
+		client:    &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+func (m *MJMLAPICompiler) Compile(ctx context.Context, mjml string) (string, error) {
+	payload, err := json.Marshal(map[string]string{"mjml": mjml})
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, m.url, bytes.NewReader(payload))
+	if err != nil {
+		return "", err
+	}
+	req.SetBasicAuth(m.appID, m.secretKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("mjml request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		HTML    string `json:"html"`
+		Message string `json:"message"`
+		Errors  []struct {
+			Line    int    `json:"line"`
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 4*1024*1024)).Decode(&result); err != nil {
+		return "", fmt.Errorf("mjml returned status %d with an unreadable body", resp.StatusCode)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("mjml returned status %d: %s", resp.StatusCode, result.Message)
+	}
+
+	// Validation errors come back alongside best-effort HTML; reject them so
+	// a broken layout is never saved
+	if len(result.Errors) > 0 {
+		messages := make([]string, 0, len(result.Errors))
+		for _, e := range result.Errors {
+			messages = append(messages, fmt.Sprintf("line %d: %s", e.Line, e.Message))
+		}
+		return "", &MJMLError{Messages: messages}
+	}
+	return result.HTML, nil
+}
+
+// MJMLError is returned when the MJML markup itself is invalid
+type MJMLError struct {
+	Messages []string
+}
+
+func (e *MJMLError) Error() string {
+	return "invalid MJML: " + strings.Join(e.Messages, "; ")
+}
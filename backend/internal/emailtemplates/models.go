@@ -0,0 +1,49 @@
+package emailtemplates
+
+import (
+	"time"
+
+	"evently/internal/notifications"
+
+	"github.com/google/uuid"
+)
+
+// Template source formats
+const (
+	FormatGoTemplate = "GO_TEMPLATE" // HTML body is a Go html template
+	FormatMJML       = "MJML"        // HTML body is MJML, compiled to HTML when saved
+)
+
+// TemplateVersion is one saved revision of an email template for a notification
+// type and locale. Edits create a new version; at most one version per type and
+// locale is active, and rolling back activates an earlier one.
+type TemplateVersion struct {
+	ID          uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	Type        string     `gorm:"type:varchar(50);not null;uniqueIndex:idx_notification_template_version" json:"type"`
+	Locale      string     `gorm:"type:varchar(10);not null;uniqueIndex:idx_notification_template_version" json:"locale"`
+	Version     int        `gorm:"not null;uniqueIndex:idx_notification_template_version" json:"version"`
+	Format      string     `gorm:"type:varchar(20);not null;check:format IN ('GO_TEMPLATE', 'MJML')" json:"format"`
+	Subject     string     `gorm:"type:text;not null" json:"subject"`
+	Source      string     `gorm:"type:text;not null" json:"source"`    // HTML body as written, Go template or MJML
+	HTMLBody    string     `gorm:"type:text;not null" json:"html_body"` // Go html template the email is rendered from
+	TextBody    string     `gorm:"type:text;not null" json:"text_body"`
+	Comment     string     `gorm:"type:varchar(500)" json:"comment,omitempty"` // What changed
+	IsActive    bool       `gorm:"not null;default:false" json:"is_active"`
+	CreatedBy   uuid.UUID  `gorm:"type:uuid;not null" json:"created_by"`
+	ActivatedAt *time.Time `json:"activated_at,omitempty"`
+	ActivatedBy *uuid.UUID `gorm:"type:uuid" json:"activated_by,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+func (TemplateVersion) TableName() string {
+	return "notification_template_versions"
+}
+
+// EmailTemplate returns the version in the form the email worker renders
+func (v *TemplateVersion) EmailTemplate() *notifications.EmailTemplate {
+	return &notifications.EmailTemplate{
+		Subject: v.Subject,
+		HTML:    v.HTMLBody,
+		Text:    v.TextBody,
+	}
+}
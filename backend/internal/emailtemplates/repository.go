@@ -0,0 +1,163 @@
+package emailtemplates
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	ListActive(ctx context.Context) ([]TemplateVersion, error)
+	ListVersions(ctx context.Context, templateType, locale string) ([]TemplateVersion, error)
+	GetVersion(ctx context.Context, templateType, locale string, version int) (*TemplateVersion, error)
+	GetActive(ctx context.Context, templateType, locale string) (*TemplateVersion, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*TemplateVersion, error)
+	CreateVersion(ctx context.Context, version *TemplateVersion) error
+	Activate(ctx context.Context, version *TemplateVersion, adminID uuid.UUID) error
+	Deactivate(ctx context.Context, templateType, locale string) (int64, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+// ListActive returns the live version of every customised type and locale
+func (r *repository) ListActive(ctx context.Context) ([]TemplateVersion, error) {
+	var versions []TemplateVersion
+	err := r.db.WithContext(ctx).
+		Where("is_active").
+		Order("type ASC, locale ASC").
+		Find(&versions).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list active templates: %w", err)
+	}
+	return versions, nil
+}
+
+func (r *repository) ListVersions(ctx context.Context, templateType, locale string) ([]TemplateVersion, error) {
+	var versions []TemplateVersion
+	err := r.db.WithContext(ctx).
+		Where("type = ? AND locale = ?", templateType, locale).
+		Order("version DESC").
+		Find(&versions).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list template versions: %w", err)
+	}
+	return versions, nil
+}
+
+func (r *repository) GetVersion(ctx context.Context, templateType, locale string, version int) (*TemplateVersion, error) {
+	var templateVersion TemplateVersion
+	err := r.db.WithContext(ctx).
+		Take(&templateVersion, "type = ? AND locale = ? AND version = ?", templateType, locale, version).Error
+	if err != nil {
+		return nil, err
+	}
+	return &templateVersion, nil
+}
+
+func (r *repository) GetActive(ctx context.Context, templateType, locale string) (*TemplateVersion, error) {
+	var templateVersion TemplateVersion
+	err := r.db.WithContext(ctx).
+		Take(&templateVersion, "type = ? AND locale = ? AND is_active", templateType, locale).Error
+	if err != nil {
+		return nil, err
+	}
+	return &templateVersion, nil
+}
+
+func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*TemplateVersion, error) {
+	var templateVersion TemplateVersion
+	err := r.db.WithContext(ctx).Take(&templateVersion, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &templateVersion, nil
+}
+
+// CreateVersion saves the next version number for the type and locale,
+// activating it in the same transaction when IsActive is set. Two concurrent
+// saves collide on the version's unique index.
+func (r *repository) CreateVersion(ctx context.Context, version *TemplateVersion) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var latest int
+		err := tx.Model(&TemplateVersion{}).
+			Where("type = ? AND locale = ?", version.Type, version.Locale).
+			Select("COALESCE(MAX(version), 0)").
+			Scan(&latest).Error
+		if err != nil {
+			return fmt.Errorf("failed to get latest template version: %w", err)
+		}
+		version.Version = latest + 1
+		version.CreatedAt = time.Now()
+
+		if version.IsActive {
+			if err := deactivate(tx, version.Type, version.Locale); err != nil {
+				return err
+			}
+			version.ActivatedAt = &version.CreatedAt
+			version.ActivatedBy = &version.CreatedBy
+		}
+
+		if err := tx.Create(version).Error; err != nil {
+			return fmt.Errorf("failed to save template version: %w", err)
+		}
+		return nil
+	})
+}
+
+// Activate makes the version the live one for its type and locale
+func (r *repository) Activate(ctx context.Context, version *TemplateVersion, adminID uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := deactivate(tx, version.Type, version.Locale); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		err := tx.Model(&TemplateVersion{}).
+			Where("id = ?", version.ID).
+			Updates(map[string]interface{}{
+				"is_active":    true,
+				"activated_at": now,
+				"activated_by": adminID,
+			}).Error
+		if err != nil {
+			return fmt.Errorf("failed to activate template version: %w", err)
+		}
+
+		version.IsActive = true
+		version.ActivatedAt = &now
+		version.ActivatedBy = &adminID
+		return nil
+	})
+}
+
+// Deactivate turns off the custom template for a type and locale, which goes
+// back to the built-in template. Versions are kept for a later rollback.
+func (r *repository) Deactivate(ctx context.Context, templateType, locale string) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Model(&TemplateVersion{}).
+		Where("type = ? AND locale = ? AND is_active", templateType, locale).
+		Update("is_active", false)
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to deactivate template: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+func deactivate(tx *gorm.DB, templateType, locale string) error {
+	err := tx.Model(&TemplateVersion{}).
+		Where("type = ? AND locale = ? AND is_active", templateType, locale).
+		Update("is_active", false).Error
+	if err != nil {
+		return fmt.Errorf("failed to deactivate current template version: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package emailtemplates
+
+// PreviewRequest renders a template. Data is merged over the template's sample data.
+type PreviewRequest struct {
+	RecipientName string                 `json:"recipient_name" binding:"max=100"`
+	Data          map[string]interface{} `json:"data"`
+	Locale        string                 `json:"locale" binding:"max=10"`           // Defaults to en
+	Version       *int                   `json:"version" binding:"omitempty,min=1"` // Defaults to the active version, or the built-in template
+}
+
+// TestSendRequest renders a template and emails it to the given address
+type TestSendRequest struct {
+	Email         string                 `json:"email" binding:"required,email"`
+	RecipientName string                 `json:"recipient_name" binding:"max=100"`
+	Data          map[string]interface{} `json:"data"`
+	Locale        string                 `json:"locale" binding:"max=10"`
+	Version       *int                   `json:"version" binding:"omitempty,min=1"`
+}
+
+// CreateVersionRequest saves a new version of a template for one locale
+type CreateVersionRequest struct {
+	Locale   string `json:"locale" binding:"max=10"`                           // e.g. en, pt-BR; defaults to en
+	Format   string `json:"format" binding:"omitempty,oneof=GO_TEMPLATE MJML"` // Defaults to GO_TEMPLATE
+	Subject  string `json:"subject" binding:"required,max=500"`
+	HTML     string `json:"html" binding:"required"` // Go html template or MJML markup
+	Text     string `json:"text" binding:"required"`
+	Comment  string `json:"comment" binding:"max=500"`
+	Activate *bool  `json:"activate"` // Defaults to true; false saves a draft to preview and test-send first
+}
+
+type LocaleQuery struct {
+	Locale string `form:"locale" binding:"max=10"` // Defaults to en
+}
@@ -0,0 +1,38 @@
+package emailtemplates
+
+import "time"
+
+// TemplateInfo is a notification template and the data it is previewed with by default
+type TemplateInfo struct {
+	ID         string                 `json:"id"` // The notification type, e.g. BOOKING_CONFIRMED
+	SampleData map[string]interface{} `json:"sample_data"`
+	Locales    []LocaleInfo           `json:"locales"` // Locales with a custom template, the rest use the built-in one
+}
+
+// LocaleInfo is the live custom template for one locale
+type LocaleInfo struct {
+	Locale        string     `json:"locale"`
+	ActiveVersion int        `json:"active_version"`
+	Format        string     `json:"format"`
+	ActivatedAt   *time.Time `json:"activated_at,omitempty"`
+}
+
+// PreviewResponse is a template rendered exactly as it would be sent
+type PreviewResponse struct {
+	TemplateID string                 `json:"template_id"`
+	Locale     string                 `json:"locale"`
+	Version    *int                   `json:"version"` // Null for the built-in template
+	Subject    string                 `json:"subject"`
+	HTML       string                 `json:"html"`
+	Text       string                 `json:"text"`
+	Data       map[string]interface{} `json:"data"` // Sample data with the provided data merged in
+}
+
+type TestSendResponse struct {
+	TemplateID     string `json:"template_id"`
+	Locale         string `json:"locale"`
+	Version        *int   `json:"version"`
+	NotificationID string `json:"notification_id"`
+	Email          string `json:"email"`
+	Subject        string `json:"subject"`
+}
@@ -0,0 +1,24 @@
+package emailtemplates
+
+import (
+	"evently/internal/shared/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupEmailTemplateRoutes(rg *gin.RouterGroup, controller *Controller) {
+	admin := rg.Group("/admin/notification-templates")
+	admin.Use(middleware.JWTAuth(), middleware.RequirePermission(middleware.PermissionNotificationsManage))
+	{
+		admin.GET("", controller.ListTemplates)           // GET /api/v1/admin/notification-templates
+		admin.POST("/:id/preview", controller.Preview)    // POST /api/v1/admin/notification-templates/:id/preview
+		admin.POST("/:id/test-send", controller.TestSend) // POST /api/v1/admin/notification-templates/:id/test-send
+
+		// Versions are per locale, selected with ?locale= (default en)
+		admin.GET("/:id/versions", controller.ListVersions)                       // GET /api/v1/admin/notification-templates/:id/versions
+		admin.POST("/:id/versions", controller.CreateVersion)                     // POST /api/v1/admin/notification-templates/:id/versions
+		admin.GET("/:id/versions/:version", controller.GetVersion)                // GET /api/v1/admin/notification-templates/:id/versions/:version
+		admin.POST("/:id/versions/:version/activate", controller.ActivateVersion) // POST /api/v1/admin/notification-templates/:id/versions/:version/activate
+		admin.DELETE("/:id/locales/:locale", controller.ResetLocale)              // DELETE /api/v1/admin/notification-templates/:id/locales/:locale
+	}
+}
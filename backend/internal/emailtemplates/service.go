@@ -0,0 +1,285 @@
+package emailtemplates
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"evently/internal/notifications"
+
+	"github.com/google/uuid"
+)
+
+// Config contains configuration for template previews and test sends
+type Config struct {
+	TestSendLimit  int           // Test emails an admin can send per window
+	TestSendWindow time.Duration // Sliding window of the test send limit
+	SubjectPrefix  string        // Marks test emails in the recipient's inbox
+
+	// Platform branding previews are rendered with unless the data sets its own
+	BrandName         string
+	BrandLogoURL      string
+	BrandPrimaryColor string
+
+	CacheTTL time.Duration // How long the email worker reuses a looked-up template
+}
+
+// DefaultConfig returns default template preview configuration
+func DefaultConfig() *Config {
+	return &Config{
+		TestSendLimit:  10, // Ten test emails per admin per hour
+		TestSendWindow: time.Hour,
+		SubjectPrefix:  "[TEST] ",
+		BrandName:      "Evently",
+		CacheTTL:       time.Minute,
+	}
+}
+
+// ThrottledError is returned when an admin sends too many test emails
+type ThrottledError struct {
+	RetryAfter time.Duration
+}
+
+func (e *ThrottledError) Error() string {
+	return fmt.Sprintf("too many test emails sent, try again in %s", e.RetryAfter.Round(time.Second))
+}
+
+var (
+	ErrTemplateNotFound     = errors.New("notification template not found")
+	ErrVersionNotFound      = errors.New("template version not found")
+	ErrSendingNotConfigured = errors.New("email sending is not configured")
+	ErrMJMLNotConfigured    = errors.New("MJML compilation is not configured")
+	ErrInvalidLocale        = errors.New("invalid locale: use a language code such as en or pt-BR")
+	ErrInvalidTemplate      = errors.New("invalid template")
+	ErrVersionConflict      = errors.New("another version was saved at the same time, try again")
+)
+
+// NotificationSender delivers a rendered notification, implemented by the notification service
+type NotificationSender interface {
+	SendNotification(ctx context.Context, notification *notifications.EmailNotification) error
+}
+
+type Service interface {
+	ListTemplates(ctx context.Context) ([]TemplateInfo, error)
+	Preview(ctx context.Context, templateID string, req PreviewRequest) (*PreviewResponse, error)
+	TestSend(ctx context.Context, adminID uuid.UUID, templateID string, req TestSendRequest) (*TestSendResponse, error)
+
+	// Versioned templates, edited without a deploy
+	ListVersions(ctx context.Context, templateID, locale string) ([]TemplateVersion, error)
+	GetVersion(ctx context.Context, templateID, locale string, version int) (*TemplateVersion, error)
+	CreateVersion(ctx context.Context, adminID uuid.UUID, templateID string, req CreateVersionRequest) (*TemplateVersion, error)
+	ActivateVersion(ctx context.Context, adminID uuid.UUID, templateID, locale string, version int) (*TemplateVersion, error)
+	ResetLocale(ctx context.Context, templateID, locale string) error
+
+	// Looked up by the email worker
+	notifications.TemplateSource
+}
+
+type service struct {
+	repo   Repository
+	sender NotificationSender
+	mjml   MJMLCompiler
+	config *Config
+
+	mu        sync.Mutex
+	testSends map[uuid.UUID][]time.Time // Recent test sends per admin
+
+	cacheMu sync.RWMutex
+	cache   map[string]cachedTemplate // Active templates by type and requested locale
+}
+
+// NewService creates the template service. sender may be nil, test sends then
+// fail; mjml may be nil, MJML templates are then rejected.
+func NewService(repo Repository, sender NotificationSender, mjml MJMLCompiler, config *Config) Service {
+	if config == nil {
+		config = DefaultConfig()
+	}
+	return &service{
+		repo:      repo,
+		sender:    sender,
+		mjml:      mjml,
+		config:    config,
+		testSends: make(map[uuid.UUID][]time.Time),
+		cache:     make(map[string]cachedTemplate),
+	}
+}
+
+func (s *service) ListTemplates(ctx context.Context) ([]TemplateInfo, error) {
+	active, err := s.repo.ListActive(ctx)
+	if err != nil {
+		return nil, err
+	}
+	locales := make(map[string][]LocaleInfo)
+	for _, version := range active {
+		locales[version.Type] = append(locales[version.Type], LocaleInfo{
+			Locale:        version.Locale,
+			ActiveVersion: version.Version,
+			Format:        version.Format,
+			ActivatedAt:   version.ActivatedAt,
+		})
+	}
+
+	types := notifications.TemplateTypes()
+	templates := make([]TemplateInfo, 0, len(types))
+	for _, notificationType := range types {
+		info := TemplateInfo{
+			ID:         string(notificationType),
+			SampleData: notifications.SampleTemplateData(notificationType, nil),
+			Locales:    locales[string(notificationType)],
+		}
+		if info.Locales == nil {
+			info.Locales = []LocaleInfo{}
+		}
+		templates = append(templates, info)
+	}
+	return templates, nil
+}
+
+func (s *service) Preview(ctx context.Context, templateID string, req PreviewRequest) (*PreviewResponse, error) {
+	notificationType, data, err := s.templateData(templateID, req.Data)
+	if err != nil {
+		return nil, err
+	}
+	locale, stored, err := s.selectVersion(ctx, notificationType, req.Locale, req.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	subject, htmlBody, textBody, err := render(notificationType, stored, recipientName(req.RecipientName), data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render template: %w", err)
+	}
+
+	return &PreviewResponse{
+		TemplateID: string(notificationType),
+		Locale:     locale,
+		Version:    versionNumber(stored),
+		Subject:    subject,
+		HTML:       htmlBody,
+		Text:       textBody,
+		Data:       data,
+	}, nil
+}
+
+func (s *service) TestSend(ctx context.Context, adminID uuid.UUID, templateID string, req TestSendRequest) (*TestSendResponse, error) {
+	if s.sender == nil {
+		return nil, ErrSendingNotConfigured
+	}
+
+	notificationType, data, err := s.templateData(templateID, req.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	locale, stored, err := s.selectVersion(ctx, notificationType, req.Locale, req.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	// Render first so a broken template fails here rather than in the email worker
+	subject, _, _, err := render(notificationType, stored, recipientName(req.RecipientName), data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render template: %w", err)
+	}
+
+	if err := s.reserveTestSend(adminID, time.Now()); err != nil {
+		return nil, err
+	}
+
+	notification := notifications.NewNotificationBuilder().
+		WithType(notificationType).
+		WithRecipient(uuid.Nil, req.Email, recipientName(req.RecipientName)).
+		WithTemplateData(data).
+		WithSubject(s.config.SubjectPrefix + subject).
+		Build()
+	notification.Locale = locale
+	if stored != nil {
+		// Pin the version so the worker sends this one even if it is not active
+		notification.TemplateVersionID = &stored.ID
+	}
+
+	if err := s.sender.SendNotification(ctx, notification); err != nil {
+		return nil, fmt.Errorf("failed to send test email: %w", err)
+	}
+	log.Printf("Test email %s sent to %s by admin %s", notificationType, req.Email, adminID)
+
+	return &TestSendResponse{
+		TemplateID:     string(notificationType),
+		Locale:         locale,
+		Version:        versionNumber(stored),
+		NotificationID: notification.ID.String(),
+		Email:          req.Email,
+		Subject:        notification.Subject,
+	}, nil
+}
+
+// templateData resolves a template ID and merges the provided data over the
+// sample data and platform branding
+func (s *service) templateData(templateID string, provided map[string]interface{}) (notifications.NotificationType, map[string]interface{}, error) {
+	notificationType, ok := notifications.ParseTemplateType(templateID)
+	if !ok {
+		return "", nil, ErrTemplateNotFound
+	}
+
+	data := notifications.SampleTemplateData(notificationType, provided)
+	for key, value := range map[string]string{
+		notifications.TemplateKeyBrandName:         s.config.BrandName,
+		notifications.TemplateKeyBrandLogoURL:      s.config.BrandLogoURL,
+		notifications.TemplateKeyBrandPrimaryColor: s.config.BrandPrimaryColor,
+	} {
+		if _, set := data[key]; !set && value != "" {
+			data[key] = value
+		}
+	}
+	return notificationType, data, nil
+}
+
+// reserveTestSend counts a test send against the admin's limit, or reports how
+// long until the oldest send in the window drops out of it
+func (s *service) reserveTestSend(adminID uuid.UUID, now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cutoff := now.Add(-s.config.TestSendWindow)
+	recent := s.testSends[adminID][:0]
+	for _, sentAt := range s.testSends[adminID] {
+		if sentAt.After(cutoff) {
+			recent = append(recent, sentAt)
+		}
+	}
+
+	if s.config.TestSendLimit > 0 && len(recent) >= s.config.TestSendLimit {
+		s.testSends[adminID] = recent
+		return &ThrottledError{RetryAfter: recent[0].Sub(cutoff)}
+	}
+
+	s.testSends[adminID] = append(recent, now)
+	return nil
+}
+
+// render renders a stored version, or the built-in template when stored is nil
+func render(notificationType notifications.NotificationType, stored *TemplateVersion, name string, data map[string]interface{}) (subject, htmlBody, textBody string, err error) {
+	if stored != nil {
+		return notifications.RenderStoredEmail(stored.EmailTemplate(), name, data)
+	}
+	return notifications.RenderEmail(notificationType, name, data)
+}
+
+func versionNumber(stored *TemplateVersion) *int {
+	if stored == nil {
+		return nil
+	}
+	version := stored.Version
+	return &version
+}
+
+func recipientName(name string) string {
+	if name = strings.TrimSpace(name); name != "" {
+		return name
+	}
+	return "Alex Sample"
+}
@@ -0,0 +1,286 @@
+package emailtemplates
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+
+	"evently/internal/notifications"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var localePattern = regexp.MustCompile(`^[a-z]{2,3}(-[A-Z]{2})?$`)
+
+// cachedTemplate is an active template lookup, including "none"
+type cachedTemplate struct {
+	template *notifications.EmailTemplate
+	loadedAt time.Time
+}
+
+func (s *service) ListVersions(ctx context.Context, templateID, locale string) ([]TemplateVersion, error) {
+	notificationType, locale, err := parseTemplateLocale(templateID, locale)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.ListVersions(ctx, string(notificationType), locale)
+}
+
+func (s *service) GetVersion(ctx context.Context, templateID, locale string, version int) (*TemplateVersion, error) {
+	notificationType, locale, err := parseTemplateLocale(templateID, locale)
+	if err != nil {
+		return nil, err
+	}
+	return s.getVersion(ctx, notificationType, locale, version)
+}
+
+// CreateVersion validates and saves a new version. MJML is compiled here so the
+// email worker never depends on the MJML API.
+func (s *service) CreateVersion(ctx context.Context, adminID uuid.UUID, templateID string, req CreateVersionRequest) (*TemplateVersion, error) {
+	notificationType, locale, err := parseTemplateLocale(templateID, req.Locale)
+	if err != nil {
+		return nil, err
+	}
+
+	format := req.Format
+	if format == "" {
+		format = FormatGoTemplate
+	}
+	htmlBody := req.HTML
+	if format == FormatMJML {
+		if s.mjml == nil {
+			return nil, ErrMJMLNotConfigured
+		}
+		compiled, err := s.mjml.Compile(ctx, req.HTML)
+		if err != nil {
+			var mjmlErr *MJMLError
+			if errors.As(err, &mjmlErr) {
+				return nil, fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+			}
+			return nil, fmt.Errorf("failed to compile MJML: %w", err)
+		}
+		htmlBody = compiled
+	}
+
+	version := &TemplateVersion{
+		Type:      string(notificationType),
+		Locale:    locale,
+		Format:    format,
+		Subject:   req.Subject,
+		Source:    req.HTML,
+		HTMLBody:  htmlBody,
+		TextBody:  req.Text,
+		Comment:   strings.TrimSpace(req.Comment),
+		IsActive:  req.Activate == nil || *req.Activate,
+		CreatedBy: adminID,
+	}
+
+	// A template that fails against its sample data would fail for real recipients too
+	if err := version.EmailTemplate().Parse(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+	}
+	_, data, _ := s.templateData(templateID, nil)
+	if _, _, _, err := notifications.RenderStoredEmail(version.EmailTemplate(), recipientName(""), data); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+	}
+
+	if err := s.repo.CreateVersion(ctx, version); err != nil {
+		if strings.Contains(err.Error(), "duplicate key") {
+			return nil, ErrVersionConflict
+		}
+		return nil, err
+	}
+	if version.IsActive {
+		s.invalidateCache()
+	}
+
+	log.Printf("📝 Template %s (%s) version %d saved by admin %s (active: %t)",
+		version.Type, version.Locale, version.Version, adminID, version.IsActive)
+	return version, nil
+}
+
+// ActivateVersion makes a version live, which is also how a change is rolled back
+func (s *service) ActivateVersion(ctx context.Context, adminID uuid.UUID, templateID, locale string, version int) (*TemplateVersion, error) {
+	notificationType, locale, err := parseTemplateLocale(templateID, locale)
+	if err != nil {
+		return nil, err
+	}
+	templateVersion, err := s.getVersion(ctx, notificationType, locale, version)
+	if err != nil {
+		return nil, err
+	}
+	if templateVersion.IsActive {
+		return templateVersion, nil
+	}
+
+	if err := s.repo.Activate(ctx, templateVersion, adminID); err != nil {
+		if strings.Contains(err.Error(), "duplicate key") {
+			return nil, ErrVersionConflict
+		}
+		return nil, err
+	}
+	s.invalidateCache()
+
+	log.Printf("📝 Template %s (%s) version %d activated by admin %s", templateVersion.Type, locale, version, adminID)
+	return templateVersion, nil
+}
+
+// ResetLocale goes back to the built-in template for a locale
+func (s *service) ResetLocale(ctx context.Context, templateID, locale string) error {
+	notificationType, locale, err := parseTemplateLocale(templateID, locale)
+	if err != nil {
+		return err
+	}
+	deactivated, err := s.repo.Deactivate(ctx, string(notificationType), locale)
+	if err != nil {
+		return err
+	}
+	if deactivated == 0 {
+		return ErrVersionNotFound
+	}
+	s.invalidateCache()
+	return nil
+}
+
+// ActiveTemplate returns the live template for the email worker, trying the
+// locale, then its language, then the default locale. Lookups, including
+// misses, are cached for CacheTTL.
+func (s *service) ActiveTemplate(ctx context.Context, notificationType notifications.NotificationType, locale string) (*notifications.EmailTemplate, error) {
+	key := string(notificationType) + "|" + locale
+	s.cacheMu.RLock()
+	cached, ok := s.cache[key]
+	s.cacheMu.RUnlock()
+	if ok && time.Since(cached.loadedAt) < s.config.CacheTTL {
+		return cached.template, nil
+	}
+
+	normalized, err := normalizeLocale(locale)
+	if err != nil {
+		normalized = notifications.DefaultLocale
+	}
+	version, err := s.activeVersion(ctx, notificationType, normalized)
+	if err != nil {
+		return nil, err
+	}
+
+	var template *notifications.EmailTemplate
+	if version != nil {
+		template = version.EmailTemplate()
+	}
+	s.cacheMu.Lock()
+	s.cache[key] = cachedTemplate{template: template, loadedAt: time.Now()}
+	s.cacheMu.Unlock()
+	return template, nil
+}
+
+// TemplateVersion returns a pinned version for test sends
+func (s *service) TemplateVersion(ctx context.Context, versionID uuid.UUID) (*notifications.EmailTemplate, error) {
+	version, err := s.repo.GetByID(ctx, versionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get template version: %w", err)
+	}
+	return version.EmailTemplate(), nil
+}
+
+// selectVersion picks the version a preview or test send renders: the requested
+// one, else the active one for the locale, else nil for the built-in template
+func (s *service) selectVersion(ctx context.Context, notificationType notifications.NotificationType, locale string, version *int) (string, *TemplateVersion, error) {
+	locale, err := normalizeLocale(locale)
+	if err != nil {
+		return "", nil, err
+	}
+	if version != nil {
+		stored, err := s.getVersion(ctx, notificationType, locale, *version)
+		return locale, stored, err
+	}
+
+	stored, err := s.activeVersion(ctx, notificationType, locale)
+	if err != nil {
+		return "", nil, err
+	}
+	if stored != nil {
+		locale = stored.Locale
+	}
+	return locale, stored, nil
+}
+
+func (s *service) activeVersion(ctx context.Context, notificationType notifications.NotificationType, locale string) (*TemplateVersion, error) {
+	for _, candidate := range localeFallbacks(locale) {
+		version, err := s.repo.GetActive(ctx, string(notificationType), candidate)
+		if err == nil {
+			return version, nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("failed to get active template: %w", err)
+		}
+	}
+	return nil, nil
+}
+
+func (s *service) getVersion(ctx context.Context, notificationType notifications.NotificationType, locale string, version int) (*TemplateVersion, error) {
+	templateVersion, err := s.repo.GetVersion(ctx, string(notificationType), locale, version)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrVersionNotFound
+		}
+		return nil, fmt.Errorf("failed to get template version: %w", err)
+	}
+	return templateVersion, nil
+}
+
+// invalidateCache drops cached lookups on this instance; other instances pick
+// up the change within CacheTTL
+func (s *service) invalidateCache() {
+	s.cacheMu.Lock()
+	s.cache = make(map[string]cachedTemplate)
+	s.cacheMu.Unlock()
+}
+
+func parseTemplateLocale(templateID, locale string) (notifications.NotificationType, string, error) {
+	notificationType, ok := notifications.ParseTemplateType(templateID)
+	if !ok {
+		return "", "", ErrTemplateNotFound
+	}
+	locale, err := normalizeLocale(locale)
+	if err != nil {
+		return "", "", err
+	}
+	return notificationType, locale, nil
+}
+
+// normalizeLocale accepts en, pt-br or pt_BR style codes and returns en or pt-BR
+func normalizeLocale(locale string) (string, error) {
+	locale = strings.TrimSpace(strings.ReplaceAll(locale, "_", "-"))
+	if locale == "" {
+		return notifications.DefaultLocale, nil
+	}
+	parts := strings.SplitN(locale, "-", 2)
+	locale = strings.ToLower(parts[0])
+	if len(parts) == 2 {
+		locale += "-" + strings.ToUpper(parts[1])
+	}
+	if !localePattern.MatchString(locale) {
+		return "", fmt.Errorf("%w: %s", ErrInvalidLocale, locale)
+	}
+	return locale, nil
+}
+
+// localeFallbacks lists the locales tried for a recipient, e.g. pt-BR, pt, en
+func localeFallbacks(locale string) []string {
+	fallbacks := []string{locale}
+	if language, _, found := strings.Cut(locale, "-"); found {
+		fallbacks = append(fallbacks, language)
+	}
+	if fallbacks[len(fallbacks)-1] != notifications.DefaultLocale && locale != notifications.DefaultLocale {
+		fallbacks = append(fallbacks, notifications.DefaultLocale)
+	}
+	return fallbacks
+}
@@ -0,0 +1,71 @@
+package eventchanges
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type BatchStatus string
+
+const (
+	BatchStatusPending   BatchStatus = "PENDING"
+	BatchStatusSent      BatchStatus = "SENT"
+	BatchStatusDiscarded BatchStatus = "DISCARDED" // Edits cancelled each other out, or the event is no longer live
+)
+
+// ChangeBatch collects the venue and schedule edits made to an event within the
+// batching window, so several quick edits produce one notification per recipient.
+// Before values are taken from the first edit, After values from the latest.
+type ChangeBatch struct {
+	ID             uuid.UUID   `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	EventID        uuid.UUID   `gorm:"type:uuid;not null;index" json:"event_id"`
+	VenueBefore    string      `json:"venue_before"`
+	VenueAfter     string      `json:"venue_after"`
+	DateTimeBefore time.Time   `json:"date_time_before"`
+	DateTimeAfter  time.Time   `json:"date_time_after"`
+	ChangedBy      uuid.UUID   `gorm:"type:uuid;not null" json:"changed_by"`
+	EditCount      int         `gorm:"not null;default:1" json:"edit_count"`
+	Status         BatchStatus `gorm:"type:varchar(20);not null;default:'PENDING';index:idx_event_change_due" json:"status"`
+	SendAfter      time.Time   `gorm:"not null;index:idx_event_change_due" json:"send_after"` // Pushed back by every new edit
+	RecipientCount int         `gorm:"not null;default:0" json:"recipient_count"`
+	SentAt         *time.Time  `json:"sent_at,omitempty"`
+	CreatedAt      time.Time   `json:"created_at"`
+	UpdatedAt      time.Time   `json:"updated_at"`
+}
+
+func (ChangeBatch) TableName() string {
+	return "event_change_batches"
+}
+
+func (b *ChangeBatch) VenueChanged() bool {
+	return b.VenueBefore != b.VenueAfter
+}
+
+func (b *ChangeBatch) DateTimeChanged() bool {
+	return !b.DateTimeBefore.Equal(b.DateTimeAfter)
+}
+
+// Change is a venue or schedule edit reported by the events service
+type Change struct {
+	EventID        uuid.UUID
+	ChangedBy      uuid.UUID
+	VenueBefore    string
+	VenueAfter     string
+	DateTimeBefore time.Time
+	DateTimeAfter  time.Time
+}
+
+// Recipient is a confirmed attendee or active waitlist member of a changed event
+type Recipient struct {
+	UserID          uuid.UUID
+	BookingID       *uuid.UUID
+	WaitlistEntryID *uuid.UUID
+}
+
+// eventState is the live state of an event when its batch is sent
+type eventState struct {
+	Status    string
+	DateTime  time.Time
+	DeletedAt *time.Time
+}
@@ -0,0 +1,179 @@
+package eventchanges
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"evently/internal/outbox"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	RecordChange(ctx context.Context, change Change, sendAfter time.Time) (*ChangeBatch, error)
+	GetDueBatches(ctx context.Context, now time.Time, limit int) ([]ChangeBatch, error)
+	GetEventState(ctx context.Context, eventID uuid.UUID) (*eventState, error)
+	GetRecipients(ctx context.Context, eventID uuid.UUID) ([]Recipient, error)
+	MarkBatchSent(ctx context.Context, batchID uuid.UUID, messages []*outbox.Message) error
+	MarkBatchDiscarded(ctx context.Context, batchID uuid.UUID) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+// RecordChange folds an edit into the event's pending batch, or opens a new one.
+// The pending row is locked so concurrent edits of the same event are merged in order.
+func (r *repository) RecordChange(ctx context.Context, change Change, sendAfter time.Time) (*ChangeBatch, error) {
+	var batch ChangeBatch
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("event_id = ? AND status = ?", change.EventID, BatchStatusPending).
+			First(&batch).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			batch = ChangeBatch{
+				ID:             uuid.New(),
+				EventID:        change.EventID,
+				VenueBefore:    change.VenueBefore,
+				VenueAfter:     change.VenueAfter,
+				DateTimeBefore: change.DateTimeBefore,
+				DateTimeAfter:  change.DateTimeAfter,
+				ChangedBy:      change.ChangedBy,
+				EditCount:      1,
+				Status:         BatchStatusPending,
+				SendAfter:      sendAfter,
+			}
+			return tx.Create(&batch).Error
+		}
+		if err != nil {
+			return err
+		}
+
+		batch.VenueAfter = change.VenueAfter
+		batch.DateTimeAfter = change.DateTimeAfter
+		batch.ChangedBy = change.ChangedBy
+		batch.EditCount++
+		batch.SendAfter = sendAfter
+
+		return tx.Model(&batch).Updates(map[string]interface{}{
+			"venue_after":     batch.VenueAfter,
+			"date_time_after": batch.DateTimeAfter,
+			"changed_by":      batch.ChangedBy,
+			"edit_count":      batch.EditCount,
+			"send_after":      batch.SendAfter,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &batch, nil
+}
+
+func (r *repository) GetDueBatches(ctx context.Context, now time.Time, limit int) ([]ChangeBatch, error) {
+	var batches []ChangeBatch
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND send_after <= ?", BatchStatusPending, now).
+		Order("send_after ASC").
+		Limit(limit).
+		Find(&batches).Error
+	return batches, err
+}
+
+func (r *repository) GetEventState(ctx context.Context, eventID uuid.UUID) (*eventState, error) {
+	var state eventState
+	result := r.db.WithContext(ctx).
+		Table("events").
+		Select("status, date_time, deleted_at").
+		Where("id = ?", eventID).
+		Scan(&state)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &state, nil
+}
+
+// GetRecipients returns confirmed attendees, then active or notified waitlist
+// members. Users with both a booking and a waitlist entry appear once, as attendees.
+func (r *repository) GetRecipients(ctx context.Context, eventID uuid.UUID) ([]Recipient, error) {
+	var bookings []struct {
+		ID     uuid.UUID
+		UserID uuid.UUID
+	}
+	if err := r.db.WithContext(ctx).
+		Table("bookings").
+		Select("id, user_id").
+		Where("event_id = ? AND status = ?", eventID, "CONFIRMED").
+		Order("created_at ASC").
+		Scan(&bookings).Error; err != nil {
+		return nil, err
+	}
+
+	var entries []struct {
+		ID     uuid.UUID
+		UserID uuid.UUID
+	}
+	if err := r.db.WithContext(ctx).
+		Table("waitlist_entries").
+		Select("id, user_id").
+		Where("event_id = ? AND status IN ?", eventID, []string{"ACTIVE", "NOTIFIED"}).
+		Order("position ASC").
+		Scan(&entries).Error; err != nil {
+		return nil, err
+	}
+
+	recipients := make([]Recipient, 0, len(bookings)+len(entries))
+	attending := make(map[uuid.UUID]bool, len(bookings))
+	for _, booking := range bookings {
+		bookingID := booking.ID
+		recipients = append(recipients, Recipient{UserID: booking.UserID, BookingID: &bookingID})
+		attending[booking.UserID] = true
+	}
+	for _, entry := range entries {
+		if attending[entry.UserID] {
+			continue
+		}
+		entryID := entry.ID
+		recipients = append(recipients, Recipient{UserID: entry.UserID, WaitlistEntryID: &entryID})
+	}
+	return recipients, nil
+}
+
+// MarkBatchSent queues the notifications and closes the batch in one transaction
+func (r *repository) MarkBatchSent(ctx context.Context, batchID uuid.UUID, messages []*outbox.Message) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&ChangeBatch{}).
+			Where("id = ? AND status = ?", batchID, BatchStatusPending).
+			Updates(map[string]interface{}{
+				"status":          BatchStatusSent,
+				"recipient_count": len(messages),
+				"sent_at":         now,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			// Already sent by another replica
+			return nil
+		}
+		return outbox.Enqueue(tx, messages...)
+	})
+}
+
+func (r *repository) MarkBatchDiscarded(ctx context.Context, batchID uuid.UUID) error {
+	return r.db.WithContext(ctx).
+		Model(&ChangeBatch{}).
+		Where("id = ? AND status = ?", batchID, BatchStatusPending).
+		Update("status", BatchStatusDiscarded).Error
+}
@@ -0,0 +1,66 @@
+package eventchanges
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// SendJob sends notifications for change batches whose batching window has closed
+type SendJob struct {
+	service Service
+	config  *Config
+	done    chan struct{}
+}
+
+// NewSendJob creates a new change notification job
+func NewSendJob(service Service, config *Config) *SendJob {
+	if config == nil {
+		config = DefaultConfig()
+	}
+
+	return &SendJob{
+		service: service,
+		config:  config,
+		done:    make(chan struct{}),
+	}
+}
+
+// Start starts the change notification job
+func (j *SendJob) Start(ctx context.Context) {
+	log.Printf("Started event change notification job with %v interval, %v batching window", j.config.CheckInterval, j.config.BatchWindow)
+	go j.run(ctx)
+}
+
+// Stop stops the change notification job
+func (j *SendJob) Stop() {
+	close(j.done)
+}
+
+func (j *SendJob) run(ctx context.Context) {
+	ticker := time.NewTicker(j.config.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			j.send(ctx)
+		case <-j.done:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (j *SendJob) send(ctx context.Context) {
+	queued, err := j.service.SendDueBatches(ctx)
+	if err != nil {
+		log.Printf("Failed to send event change notifications: %v", err)
+		return
+	}
+
+	if queued > 0 {
+		log.Printf("Queued %d event change notifications", queued)
+	}
+}
@@ -0,0 +1,164 @@
+package eventchanges
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"evently/internal/outbox"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const NotificationTypeEventChanged = "EVENT_SCHEDULE_CHANGED"
+
+// Config contains configuration for event change notifications
+type Config struct {
+	BatchWindow   time.Duration // Quiet period after the last edit before notifications go out
+	CheckInterval time.Duration
+	BatchSize     int
+	ReconfirmURL  string // Link for attendees, {booking_id} is replaced
+	WaitlistURL   string // Link for waitlist members, {entry_id} is replaced
+}
+
+// DefaultConfig returns default event change notification configuration
+func DefaultConfig() *Config {
+	return &Config{
+		BatchWindow:   5 * time.Minute,  // Edits within 5 minutes are sent as one notification
+		CheckInterval: 30 * time.Second, // Look for due batches every 30 seconds
+		BatchSize:     20,               // Send up to 20 batches per run
+		ReconfirmURL:  "http://localhost:3000/bookings/{booking_id}/reconfirm",
+		WaitlistURL:   "http://localhost:3000/waitlist/{entry_id}",
+	}
+}
+
+func (c *Config) reconfirmURL(bookingID uuid.UUID) string {
+	return strings.ReplaceAll(c.ReconfirmURL, "{booking_id}", bookingID.String())
+}
+
+func (c *Config) waitlistURL(entryID uuid.UUID) string {
+	return strings.ReplaceAll(c.WaitlistURL, "{entry_id}", entryID.String())
+}
+
+type Service interface {
+	// RecordChange adds a venue or schedule edit to the event's pending batch
+	RecordChange(ctx context.Context, change Change) error
+	// SendDueBatches queues notifications for batches whose window has closed
+	SendDueBatches(ctx context.Context) (int, error)
+}
+
+type service struct {
+	repo   Repository
+	config *Config
+}
+
+func NewService(repo Repository, config *Config) Service {
+	if config == nil {
+		config = DefaultConfig()
+	}
+	return &service{repo: repo, config: config}
+}
+
+func (s *service) RecordChange(ctx context.Context, change Change) error {
+	batch, err := s.repo.RecordChange(ctx, change, time.Now().Add(s.config.BatchWindow))
+	if err != nil {
+		return fmt.Errorf("failed to record event change: %w", err)
+	}
+
+	log.Printf("📝 Event %s change recorded (edit %d), notifications due at %s",
+		change.EventID, batch.EditCount, batch.SendAfter.Format(time.RFC3339))
+	return nil
+}
+
+func (s *service) SendDueBatches(ctx context.Context) (int, error) {
+	batches, err := s.repo.GetDueBatches(ctx, time.Now(), s.config.BatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get due change batches: %w", err)
+	}
+
+	sent := 0
+	for i := range batches {
+		queued, err := s.sendBatch(ctx, &batches[i])
+		if err != nil {
+			log.Printf("Failed to send change batch %s for event %s: %v", batches[i].ID, batches[i].EventID, err)
+			continue
+		}
+		sent += queued
+	}
+	return sent, nil
+}
+
+// sendBatch queues one notification per recipient with the net change of the batch
+func (s *service) sendBatch(ctx context.Context, batch *ChangeBatch) (int, error) {
+	// Edits that were reverted within the window leave nothing to announce
+	if !batch.VenueChanged() && !batch.DateTimeChanged() {
+		return 0, s.repo.MarkBatchDiscarded(ctx, batch.ID)
+	}
+
+	state, err := s.repo.GetEventState(ctx, batch.EventID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, fmt.Errorf("failed to get event: %w", err)
+	}
+	if state == nil || state.DeletedAt != nil || (state.Status != "published" && state.Status != "sold_out") {
+		return 0, s.repo.MarkBatchDiscarded(ctx, batch.ID)
+	}
+
+	recipients, err := s.repo.GetRecipients(ctx, batch.EventID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get recipients: %w", err)
+	}
+
+	messages := make([]*outbox.Message, 0, len(recipients))
+	for _, recipient := range recipients {
+		message, err := s.buildMessage(batch, recipient)
+		if err != nil {
+			return 0, err
+		}
+		messages = append(messages, message)
+	}
+
+	if err := s.repo.MarkBatchSent(ctx, batch.ID, messages); err != nil {
+		return 0, fmt.Errorf("failed to queue notifications: %w", err)
+	}
+
+	log.Printf("📣 Queued %d change notifications for event %s (%d edits)", len(messages), batch.EventID, batch.EditCount)
+	return len(messages), nil
+}
+
+func (s *service) buildMessage(batch *ChangeBatch, recipient Recipient) (*outbox.Message, error) {
+	eventID := batch.EventID
+	templateData := map[string]interface{}{
+		"venue_changed":     batch.VenueChanged(),
+		"date_time_changed": batch.DateTimeChanged(),
+		"venue_before":      batch.VenueBefore,
+		"venue_after":       batch.VenueAfter,
+		"date_time_before":  batch.DateTimeBefore.UTC().Format("Mon, Jan 2, 2006 3:04 PM MST"),
+		"date_time_after":   batch.DateTimeAfter.UTC().Format("Mon, Jan 2, 2006 3:04 PM MST"),
+	}
+
+	payload := &outbox.NotificationPayload{
+		Type:        NotificationTypeEventChanged,
+		RecipientID: recipient.UserID,
+		EventID:     &eventID,
+	}
+
+	var dedupKey string
+	if recipient.BookingID != nil {
+		payload.BookingID = recipient.BookingID
+		templateData["recipient_kind"] = "attendee"
+		templateData["action_url"] = s.config.reconfirmURL(*recipient.BookingID)
+		dedupKey = fmt.Sprintf("event-change:%s:booking:%s", batch.ID, *recipient.BookingID)
+	} else {
+		payload.WaitlistEntryID = recipient.WaitlistEntryID
+		templateData["recipient_kind"] = "waitlist"
+		templateData["action_url"] = s.config.waitlistURL(*recipient.WaitlistEntryID)
+		dedupKey = fmt.Sprintf("event-change:%s:waitlist:%s", batch.ID, *recipient.WaitlistEntryID)
+	}
+	payload.TemplateData = templateData
+
+	return outbox.NewNotificationMessage(outbox.AggregateEventChange, batch.ID, dedupKey, payload)
+}
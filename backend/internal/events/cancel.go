@@ -0,0 +1,77 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ErrCancelWithBookings is returned when an update would cancel an event that
+// people have booked, since that skips their refunds and notifications
+var ErrCancelWithBookings = errors.New("event has bookings, cancel it with POST /admin/events/{eventId}/cancel so attendees are refunded and notified")
+
+// ErrEventNotCancellable is returned for events that are completed or have started
+var ErrEventNotCancellable = errors.New("event cannot be cancelled")
+
+// CancelEventAsAdmin marks an event that hasn't started cancelled. Only the event changes
+// here; refunds and notifications are handled by the cancellation service,
+// which calls this first. An event that is already cancelled is returned as is
+// so an interrupted cancellation can be run again.
+func (s *service) CancelEventAsAdmin(id uuid.UUID, adminID uuid.UUID) (*EventResponse, error) {
+	event, err := s.repo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("event not found")
+		}
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	if event.Status != EventStatusCancelled {
+		if !event.Status.CanBeUpdated() {
+			return nil, fmt.Errorf("%w: status is %s", ErrEventNotCancellable, event.Status)
+		}
+		if event.DateTime.Before(time.Now()) {
+			return nil, fmt.Errorf("%w: it has already started", ErrEventNotCancellable)
+		}
+
+		previous := event.Status
+		updates := map[string]interface{}{
+			"status":     EventStatusCancelled,
+			"updated_at": time.Now(),
+			"updated_by": adminID,
+		}
+		event, err = s.repo.Update(id, updates)
+		if err != nil {
+			return nil, fmt.Errorf("failed to cancel event: %w", err)
+		}
+
+		if err := s.invalidateEventCache(context.Background(), &id); err != nil {
+			log.Warn("Failed to invalidate event cache after cancellation", "error", err)
+		}
+		s.publishUpdated(event, updates, false)
+		s.publishStatusChanged(previous, event, &adminID, TransitionTriggerManual)
+	}
+
+	response := event.ToResponse()
+	return &response, nil
+}
+
+// checkCancelViaUpdate stops an update from cancelling an event with bookings
+func (s *service) checkCancelViaUpdate(current *Event, req UpdateEventRequest) error {
+	if req.Status == nil || EventStatus(*req.Status) != EventStatusCancelled || current.Status == EventStatusCancelled {
+		return nil
+	}
+
+	bookings, err := s.repo.CountConfirmedBookings(current.ID)
+	if err != nil {
+		return fmt.Errorf("failed to check event bookings: %w", err)
+	}
+	if bookings > 0 {
+		return ErrCancelWithBookings
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package events
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventChange carries the attendee-facing fields touched by one event update.
+// Before and After are equal for fields that did not change.
+type EventChange struct {
+	EventID        uuid.UUID
+	ChangedBy      uuid.UUID
+	VenueBefore    string
+	VenueAfter     string
+	DateTimeBefore time.Time
+	DateTimeAfter  time.Time
+	ChangedAt      time.Time
+}
+
+func (c EventChange) VenueChanged() bool {
+	return c.VenueBefore != c.VenueAfter
+}
+
+func (c EventChange) DateTimeChanged() bool {
+	return !c.DateTimeBefore.Equal(c.DateTimeAfter)
+}
+
+// ChangeListener is told when an event's venue, date or time changes, so other
+// domains can react without the events package importing them
+type ChangeListener interface {
+	EventChanged(ctx context.Context, change EventChange) error
+}
+
+// SubscribeChanges registers a listener for event changes
+func (s *service) SubscribeChanges(listener ChangeListener) {
+	s.changeListeners = append(s.changeListeners, listener)
+}
+
+// publishChange tells every listener about venue and schedule changes made by an update
+func (s *service) publishChange(before, after *Event, changedBy uuid.UUID) {
+	if len(s.changeListeners) == 0 || before == nil || after == nil {
+		return
+	}
+
+	change := EventChange{
+		EventID:        after.ID,
+		ChangedBy:      changedBy,
+		VenueBefore:    before.Venue,
+		VenueAfter:     after.Venue,
+		DateTimeBefore: before.DateTime,
+		DateTimeAfter:  after.DateTime,
+		ChangedAt:      time.Now(),
+	}
+	if !change.VenueChanged() && !change.DateTimeChanged() {
+		return
+	}
+
+	for _, listener := range s.changeListeners {
+		if err := listener.EventChanged(context.Background(), change); err != nil {
+			log.Warn("Event change listener failed", "event_id", after.ID, "error", err)
+		}
+	}
+}
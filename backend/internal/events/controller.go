@@ -1,6 +1,8 @@
 package events
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -13,12 +15,21 @@ import (
 type Controller interface {
 	CreateEvent(c *gin.Context)
 	GetEvent(c *gin.Context)
+	GetAdminEvent(c *gin.Context)
 	UpdateEvent(c *gin.Context)
 	DeleteEvent(c *gin.Context)
 	GetAllEvents(c *gin.Context)
 	GetEventAnalytics(c *gin.Context)
 	GetAllEventAnalytics(c *gin.Context)
 	GetUpcomingEvents(c *gin.Context)
+	GetSitemap(c *gin.Context)
+	CloneEvent(c *gin.Context)
+	GetEventReadiness(c *gin.Context)
+	GetPricingSuggestions(c *gin.Context)
+	GetVenueConflicts(c *gin.Context)
+	GetOnSaleLive(c *gin.Context)
+	GetDraftEvents(c *gin.Context)
+	PreviewEvent(c *gin.Context)
 }
 
 type controller struct {
@@ -29,6 +40,24 @@ func NewController(service Service) Controller {
 	return &controller{service: service}
 }
 
+// CreateEvent godoc
+//
+// @Summary      Create event (Admin)
+// @Description  Create a new event (Admin only)
+// @Description
+// @Description  Requires the `events:write` permission.
+// @Tags         Admin Events
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        request body CreateEventRequest true "Request body"
+// @Success      201 {object} response.StandardApiResponse{data=EventResponse} "Event created successfully"
+// @Failure      400 {object} response.StandardApiResponse "Invalid request data"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse "Admin access required"
+// @Failure      409 {object} response.StandardApiResponse "The event overlaps other events at the same physical venue - VENUE_CONFLICT"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/events [post]
 func (ctrl *controller) CreateEvent(c *gin.Context) {
 	var req CreateEventRequest
 
@@ -52,6 +81,9 @@ func (ctrl *controller) CreateEvent(c *gin.Context) {
 
 	event, err := ctrl.service.CreateEvent(adminUUID, req)
 	if err != nil {
+		if respondVenueConflict(c, err) {
+			return
+		}
 		response.RespondJSON(c, "error", http.StatusBadRequest, err.Error(), nil, nil)
 		return
 	}
@@ -59,7 +91,48 @@ func (ctrl *controller) CreateEvent(c *gin.Context) {
 	response.RespondJSON(c, "success", http.StatusCreated, "Event created successfully", event, nil)
 }
 
+// GetEvent godoc
+//
+// @Summary      Get event by ID
+// @Description  Retrieve a specific event by its ID. Events marked noindex are served with an
+// @Description  `X-Robots-Tag: noindex` header.
+// @Description
+// @Description  Accepts a partner API key with the `events:read` scope.
+// @Tags         Events
+// @Produce      json
+// @Param        eventId path string true "Event ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=EventResponse} "Event retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Event not found"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/events/{eventId} [get]
 func (ctrl *controller) GetEvent(c *gin.Context) {
+	ctrl.getEvent(c, ctrl.service.GetPublicEventByID)
+}
+
+// GetAdminEvent godoc
+//
+// @Summary      Get event by ID (Admin)
+// @Description  Retrieve a specific event by its ID, without the cross-promotion slots of the public
+// @Description  detail so admin views are not counted as promotion impressions.
+// @Description
+// @Description  Requires the `events:write` permission.
+// @Tags         Admin Events
+// @Produce      json
+// @Security     Bearer
+// @Param        eventId path string true "Event ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=EventResponse} "Event retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Event not found"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/events/{eventId} [get]
+func (ctrl *controller) GetAdminEvent(c *gin.Context) {
+	ctrl.getEvent(c, ctrl.service.GetEventByID)
+}
+
+func (ctrl *controller) getEvent(c *gin.Context, lookup func(ctx context.Context, id uuid.UUID) (*EventResponse, error)) {
 	eventIDStr := c.Param("eventId")
 	eventID, err := uuid.Parse(eventIDStr)
 	if err != nil {
@@ -67,7 +140,7 @@ func (ctrl *controller) GetEvent(c *gin.Context) {
 		return
 	}
 
-	event, err := ctrl.service.GetEventByID(eventID)
+	event, err := lookup(c.Request.Context(), eventID)
 	if err != nil {
 		statusCode := http.StatusInternalServerError
 		if err.Error() == "event not found" {
@@ -77,9 +150,34 @@ func (ctrl *controller) GetEvent(c *gin.Context) {
 		return
 	}
 
+	// Indexing hint for crawlers that read the API directly
+	if event.NoIndex {
+		c.Header("X-Robots-Tag", "noindex")
+	}
+
 	response.RespondJSON(c, "success", http.StatusOK, "Event retrieved successfully", event, nil)
 }
 
+// UpdateEvent godoc
+//
+// @Summary      Update event (Admin)
+// @Description  Update an existing event (Admin only). Venue, date and time changes are emailed to confirmed attendees and active waitlist members once edits stop for the batching window (EVENT_CHANGE_BATCH_WINDOW), one email per recipient covering all edits. A status change must be a valid lifecycle transition, otherwise 409 is returned, and sold_out and ongoing can't be set by hand. Status changes are sent as event.status_changed webhooks and EventStatusChanged domain events.
+// @Description
+// @Description  Requires the `events:write` permission.
+// @Tags         Admin Events
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        eventId path string true "Event ID" Format(uuid)
+// @Param        request body UpdateEventRequest true "Request body"
+// @Success      200 {object} response.StandardApiResponse{data=EventResponse} "Event updated successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Event not found"
+// @Failure      409 {object} response.StandardApiResponse "The event overlaps other events at the same physical venue, the update sets the status to cancelled while the event has confirmed bookings (use POST /admin/events/{eventId}/cancel instead), or the status change is not a valid lifecycle transition - VENUE_CONFLICT"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/events/{eventId} [put]
 func (ctrl *controller) UpdateEvent(c *gin.Context) {
 	eventIDStr := c.Param("eventId")
 	eventID, err := uuid.Parse(eventIDStr)
@@ -110,17 +208,42 @@ func (ctrl *controller) UpdateEvent(c *gin.Context) {
 	// Admin can update any event
 	event, err := ctrl.service.UpdateEventAsAdmin(eventID, adminUUID, req)
 	if err != nil {
-		statusCode := http.StatusBadRequest
-		if err.Error() == "event not found" {
-			statusCode = http.StatusNotFound
+		if respondVenueConflict(c, err) {
+			return
 		}
-		response.RespondJSON(c, "error", statusCode, err.Error(), nil, nil)
+		statusCode, errorCode := http.StatusBadRequest, response.CodeBadRequest
+		switch {
+		case err.Error() == "event not found":
+			statusCode, errorCode = http.StatusNotFound, response.CodeEventNotFound
+		case errors.Is(err, ErrCancelWithBookings):
+			statusCode, errorCode = http.StatusConflict, response.CodeEventHasBookings
+		case errors.Is(err, ErrInvalidTransition):
+			statusCode, errorCode = http.StatusConflict, response.CodeInvalidStatusTransition
+		}
+		response.RespondError(c, statusCode, errorCode, err.Error(), nil)
 		return
 	}
 
 	response.RespondJSON(c, "success", http.StatusOK, "Event updated successfully", event, nil)
 }
 
+// DeleteEvent godoc
+//
+// @Summary      Delete event (Admin)
+// @Description  Delete an event (Admin only)
+// @Description
+// @Description  Requires the `events:write` permission.
+// @Tags         Admin Events
+// @Produce      json
+// @Security     Bearer
+// @Param        eventId path string true "Event ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse "Event deleted successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Event not found"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/events/{eventId} [delete]
 func (ctrl *controller) DeleteEvent(c *gin.Context) {
 	eventIDStr := c.Param("eventId")
 	eventID, err := uuid.Parse(eventIDStr)
@@ -156,6 +279,180 @@ func (ctrl *controller) DeleteEvent(c *gin.Context) {
 	response.RespondJSON(c, "success", http.StatusOK, "Event deleted successfully", nil, nil)
 }
 
+// CloneEvent godoc
+//
+// @Summary      Clone event (Admin)
+// @Description  Duplicate an event with its section pricing, tags and cancellation policy.
+// @Description  Fields left out are copied from the source event. When a different venue template
+// @Description  is given, pricing is carried over to sections with the same name. The cancellation
+// @Description  deadline keeps the same offset from the event date.
+// @Description
+// @Description  Requires the `events:write` permission.
+// @Tags         Admin Events
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        eventId path string true "Event ID" Format(uuid)
+// @Param        request body CloneEventRequest true "Request body"
+// @Success      201 {object} response.StandardApiResponse{data=EventResponse} "Event cloned successfully"
+// @Failure      400 {object} response.StandardApiResponse "Invalid request"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Event not found"
+// @Failure      409 {object} response.StandardApiResponse "The event overlaps other events at the same physical venue - VENUE_CONFLICT"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/events/{eventId}/clone [post]
+func (ctrl *controller) CloneEvent(c *gin.Context) {
+	eventIDStr := c.Param("eventId")
+	eventID, err := uuid.Parse(eventIDStr)
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid event ID", nil, err.Error())
+		return
+	}
+
+	var req CloneEventRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid request body", nil, err.Error())
+		return
+	}
+
+	// Get admin ID from context
+	adminID, exists := c.Get("user_id")
+	if !exists {
+		response.RespondJSON(c, "error", http.StatusUnauthorized, "Admin not authenticated", nil, nil)
+		return
+	}
+
+	adminUUID, err := uuid.Parse(adminID.(string))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusInternalServerError, "Invalid admin ID format", nil, nil)
+		return
+	}
+
+	event, err := ctrl.service.CloneEventAsAdmin(eventID, adminUUID, req)
+	if err != nil {
+		if respondVenueConflict(c, err) {
+			return
+		}
+		statusCode := http.StatusBadRequest
+		if err.Error() == "event not found" {
+			statusCode = http.StatusNotFound
+		}
+		response.RespondJSON(c, "error", statusCode, err.Error(), nil, nil)
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusCreated, "Event cloned successfully", event, nil)
+}
+
+// GetVenueConflicts lists events overlapping this one at its venue and the overrides recorded for it
+//
+// @Summary      Get event venue conflicts (Admin)
+// @Description  Events that currently overlap this one at the same physical venue (or the same template when
+// @Description  it has no venue), and the overrides admins recorded when scheduling over a conflict.
+// @Description
+// @Description  Requires the `events:write` permission.
+// @Tags         Admin Events
+// @Produce      json
+// @Security     Bearer
+// @Param        eventId path string true "Event ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=VenueConflictReport} "Venue conflicts retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Event not found"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/events/{eventId}/venue-conflicts [get]
+func (ctrl *controller) GetVenueConflicts(c *gin.Context) {
+	eventID, err := uuid.Parse(c.Param("eventId"))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid event ID", nil, err.Error())
+		return
+	}
+
+	report, err := ctrl.service.GetVenueConflicts(eventID)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "event not found" {
+			statusCode = http.StatusNotFound
+		}
+		response.RespondJSON(c, "error", statusCode, err.Error(), nil, nil)
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Venue conflicts retrieved successfully", report, nil)
+}
+
+// GetOnSaleLive returns the last minute of hold, booking, payment and waitlist activity
+// for an event with the current Redis and database latency
+//
+// @Summary      Get live on-sale activity (Admin)
+// @Description  Rolling one-minute counts of seat holds, hold conflicts, confirmed bookings, payment failures
+// @Description  and waitlist admissions for the event, with the current Redis and database round trip.
+// @Description  Counts come from in-memory counters of the instance serving the request, not from SQL,
+// @Description  so behind a load balancer each instance reports its own share of traffic.
+// @Description
+// @Description  Requires the `events:write` permission.
+// @Tags         Admin Events
+// @Produce      json
+// @Security     Bearer
+// @Param        eventId path string true "Event ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=OnSaleLive} "On-sale live metrics retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Event not found"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/events/{eventId}/onsale-live [get]
+func (ctrl *controller) GetOnSaleLive(c *gin.Context) {
+	eventID, err := uuid.Parse(c.Param("eventId"))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid event ID", nil, err.Error())
+		return
+	}
+
+	live, err := ctrl.service.GetOnSaleLive(c.Request.Context(), eventID)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "event not found" {
+			statusCode = http.StatusNotFound
+		}
+		response.RespondJSON(c, "error", statusCode, err.Error(), nil, nil)
+		return
+	}
+
+	// Polled every few seconds, never worth caching
+	c.Header("Cache-Control", "no-store")
+	response.RespondJSON(c, "success", http.StatusOK, "On-sale live metrics retrieved successfully", live, nil)
+}
+
+// respondVenueConflict answers 409 with the overlapping events when scheduling hit a venue conflict
+func respondVenueConflict(c *gin.Context, err error) bool {
+	var conflictErr *VenueConflictError
+	if !errors.As(err, &conflictErr) {
+		return false
+	}
+	response.RespondErrorWithData(c, http.StatusConflict, response.CodeVenueConflict, err.Error(), gin.H{"conflicts": conflictErr.Conflicts}, nil)
+	return true
+}
+
+// GetAllEvents godoc
+//
+// @Summary      Get all events
+// @Description  Retrieve all published events with optional pagination and filtering. A bearer token is optional; when sent, each event carries an is_favorited flag.
+// @Description
+// @Description  Accepts a partner API key with the `events:read` scope.
+// @Tags         Events
+// @Produce      json
+// @Param        limit query integer false "Number of events to return" default(10) minimum(1) maximum(100)
+// @Param        offset query integer false "Number of events to skip" default(0) minimum(0)
+// @Param        status query string false "Filter by event status" Enums(published, sold_out, ongoing, completed, cancelled)
+// @Param        tags query string false "Comma-separated tag names. Each tag also matches events tagged with any tag below it, so Music finds Jazz events."
+// @Success      200 {object} response.StandardApiResponse{data=PaginatedEvents} "Events retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/events [get]
+// @Router       /api/v1/admin/events [get]
 func (ctrl *controller) GetAllEvents(c *gin.Context) {
 	var query EventListQuery
 
@@ -164,7 +461,16 @@ func (ctrl *controller) GetAllEvents(c *gin.Context) {
 		return
 	}
 
-	events, err := ctrl.service.GetAllEvents(query)
+	// Signed-in callers get is_favorited flags on each event
+	if userID, exists := c.Get("user_id"); exists {
+		if idStr, ok := userID.(string); ok {
+			if viewerID, err := uuid.Parse(idStr); err == nil {
+				query.ViewerID = &viewerID
+			}
+		}
+	}
+
+	events, err := ctrl.service.GetAllEvents(c.Request.Context(), query)
 	if err != nil {
 		response.RespondJSON(c, "error", http.StatusInternalServerError, err.Error(), nil, nil)
 		return
@@ -173,6 +479,23 @@ func (ctrl *controller) GetAllEvents(c *gin.Context) {
 	response.RespondJSON(c, "success", http.StatusOK, "Events retrieved successfully", events, nil)
 }
 
+// GetEventAnalytics godoc
+//
+// @Summary      Get event analytics (Admin)
+// @Description  Get detailed analytics for a specific event (Admin only)
+// @Description
+// @Description  Requires the `events:write` permission.
+// @Tags         Admin Events
+// @Produce      json
+// @Security     Bearer
+// @Param        eventId path string true "Event ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=EventAnalytics} "Event analytics retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/events/{eventId}/analytics [get]
 func (ctrl *controller) GetEventAnalytics(c *gin.Context) {
 	eventIDStr := c.Param("eventId")
 	eventID, err := uuid.Parse(eventIDStr)
@@ -194,6 +517,126 @@ func (ctrl *controller) GetEventAnalytics(c *gin.Context) {
 	response.RespondJSON(c, "success", http.StatusOK, "Event analytics retrieved successfully", analytics, nil)
 }
 
+// GetSitemap serves the XML sitemap of upcoming public events
+//
+// @Summary      Event sitemap
+// @Description  sitemaps.org XML sitemap of upcoming published events. Unlisted and noindex events
+// @Description  are never included. Page URLs come from SITEMAP_EVENT_URL.
+// @Description
+// @Description  Accepts a partner API key with the `events:read` scope.
+// @Tags         Events
+// @Produce      xml,json
+// @Success      200 {object} Sitemap "Sitemap generated successfully"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/events/sitemap.xml [get]
+func (ctrl *controller) GetSitemap(c *gin.Context) {
+	sitemap, err := ctrl.service.GetSitemap()
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusInternalServerError, "Failed to generate sitemap", nil, err.Error())
+		return
+	}
+
+	c.XML(http.StatusOK, sitemap)
+}
+
+// GetEventReadiness returns the publish checklist for an event
+//
+// @Summary      Get event publish readiness (Admin)
+// @Description  Machine-readable checklist of what blocks an event from being published and what
+// @Description  organizers should review. Blockers: date in the past, venue template with no seats,
+// @Description  sections without pricing. Warnings: date within 48 hours, no cancellation policy,
+// @Description  no image. `ready` is true when there are no blockers.
+// @Description
+// @Description  Requires the `events:write` permission.
+// @Tags         Admin Events
+// @Produce      json
+// @Security     Bearer
+// @Param        eventId path string true "Event ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=EventReadiness} "Event readiness retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Event not found"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/events/{eventId}/readiness [get]
+func (ctrl *controller) GetEventReadiness(c *gin.Context) {
+	eventID, err := uuid.Parse(c.Param("eventId"))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid event ID", nil, err.Error())
+		return
+	}
+
+	readiness, err := ctrl.service.GetEventReadiness(eventID)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "event not found" {
+			statusCode = http.StatusNotFound
+		}
+		response.RespondJSON(c, "error", statusCode, err.Error(), nil, nil)
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Event readiness retrieved successfully", readiness, nil)
+}
+
+// GetPricingSuggestions suggests section multipliers for a new event from past sales
+//
+// @Summary      Suggest section price multipliers (Admin)
+// @Description  Suggests a price multiplier for each section of a venue template, for a new event.
+// @Description  Looks at up to 20 past, non-cancelled events on the same template, those sharing the
+// @Description  most tags first. Each section starts from its weighted historical multiplier, then is
+// @Description  raised when it sold above 85% of seats and lowered when it sold below, by at most 20%.
+// @Description  Events sharing more tags weigh more. Sections without priced history get 1.0 with
+// @Description  confidence NONE. The past events used are returned as evidence.
+// @Description
+// @Description  Requires the `events:write` permission.
+// @Tags         Admin Events
+// @Produce      json
+// @Security     Bearer
+// @Param        venue_template_id query string true "Venue template ID" Format(uuid)
+// @Param        tags query string false "Comma-separated tag names of the new event"
+// @Success      200 {object} response.StandardApiResponse{data=PricingSuggestions} "Pricing suggestions retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse "Invalid query parameters"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Venue template not found or has no sections"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/events/pricing-suggestions [get]
+func (ctrl *controller) GetPricingSuggestions(c *gin.Context) {
+	var query PricingSuggestionQuery
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid query parameters", nil, err.Error())
+		return
+	}
+
+	suggestions, err := ctrl.service.GetPricingSuggestions(query)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "venue template not found or has no sections" {
+			statusCode = http.StatusNotFound
+		}
+		response.RespondJSON(c, "error", statusCode, err.Error(), nil, nil)
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Pricing suggestions retrieved successfully", suggestions, nil)
+}
+
+// GetAllEventAnalytics godoc
+//
+// @Summary      Get all events analytics (Admin)
+// @Description  Get analytics for all events (Admin only)
+// @Description
+// @Description  Requires the `events:write` permission.
+// @Tags         Admin Events
+// @Produce      json
+// @Security     Bearer
+// @Success      200 {object} response.StandardApiResponse{data=GlobalAnalytics} "Analytics retrieved successfully"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/events/analytics [get]
 func (ctrl *controller) GetAllEventAnalytics(c *gin.Context) {
 	analytics, err := ctrl.service.GetAllEventAnalyticsAsAdmin()
 	if err != nil {
@@ -204,6 +647,20 @@ func (ctrl *controller) GetAllEventAnalytics(c *gin.Context) {
 	response.RespondJSON(c, "success", http.StatusOK, "All event analytics retrieved successfully", analytics, nil)
 }
 
+// GetUpcomingEvents godoc
+//
+// @Summary      Get upcoming events
+// @Description  Retrieve the soonest upcoming published events. All limits are served from one
+// @Description  precomputed window of upcoming events that is refreshed on a timer and whenever
+// @Description  an event changes, so capacity figures may lag by a few minutes.
+// @Description
+// @Description  Accepts a partner API key with the `events:read` scope.
+// @Tags         Events
+// @Produce      json
+// @Param        limit query integer false "Limit" default(10) minimum(1) maximum(100)
+// @Success      200 {object} response.StandardApiResponse{data=[]EventResponse} "Upcoming events retrieved successfully"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/events/upcoming [get]
 func (ctrl *controller) GetUpcomingEvents(c *gin.Context) {
 	limitStr := c.DefaultQuery("limit", "10")
 	limit, err := strconv.Atoi(limitStr)
@@ -219,3 +676,66 @@ func (ctrl *controller) GetUpcomingEvents(c *gin.Context) {
 
 	response.RespondJSON(c, "success", http.StatusOK, "Upcoming events retrieved successfully", events, nil)
 }
+
+// GetDraftEvents lists the drafts public users can't see yet
+//
+// @Summary      List draft events (Admin)
+// @Description  Drafts are hidden from public listings and detail pages. Scheduled drafts come first, by publish_at.
+// @Description
+// @Description  Requires the `events:write` permission.
+// @Tags         Admin Events
+// @Produce      json
+// @Security     Bearer
+// @Success      200 {object} response.StandardApiResponse{data=[]EventResponse} "Draft events retrieved successfully"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/events/drafts [get]
+func (ctrl *controller) GetDraftEvents(c *gin.Context) {
+	drafts, err := ctrl.service.GetDraftEvents()
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusInternalServerError, err.Error(), nil, nil)
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Draft events retrieved successfully", drafts, nil)
+}
+
+// PreviewEvent shows an event as its public page will, drafts included
+//
+// @Summary      Preview event (Admin)
+// @Description  Event detail as the public page will show it, for any status including draft. Previews bypass the cache and carry an X-Robots-Tag noindex header.
+// @Description
+// @Description  Requires the `events:write` permission.
+// @Tags         Admin Events
+// @Produce      json
+// @Security     Bearer
+// @Param        eventId path string true "Event ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=EventResponse} "Event preview retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Event not found"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/events/{eventId}/preview [get]
+func (ctrl *controller) PreviewEvent(c *gin.Context) {
+	eventID, err := uuid.Parse(c.Param("eventId"))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid event ID", nil, err.Error())
+		return
+	}
+
+	event, err := ctrl.service.PreviewEvent(c.Request.Context(), eventID)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "event not found" {
+			statusCode = http.StatusNotFound
+		}
+		response.RespondJSON(c, "error", statusCode, err.Error(), nil, nil)
+		return
+	}
+
+	// Previews are never meant for crawlers
+	c.Header("X-Robots-Tag", "noindex")
+	response.RespondJSON(c, "success", http.StatusOK, "Event preview retrieved successfully", event, nil)
+}
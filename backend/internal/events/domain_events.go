@@ -0,0 +1,60 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"evently/internal/domainevents"
+
+	"github.com/google/uuid"
+)
+
+// DomainEventPublisher records domain events for downstream consumers
+type DomainEventPublisher interface {
+	Publish(ctx context.Context, eventType string, aggregateID uuid.UUID, dedupKey string, data interface{}) error
+}
+
+// EventPublishedData is the data of EventPublished domain events
+type EventPublishedData struct {
+	EventID         uuid.UUID `json:"event_id"`
+	Name            string    `json:"name"`
+	Venue           string    `json:"venue"`
+	VenueTemplateID uuid.UUID `json:"venue_template_id"`
+	DateTime        time.Time `json:"date_time"`
+	DurationMinutes int       `json:"duration_minutes"`
+	BasePrice       float64   `json:"base_price"`
+	Currency        string    `json:"currency"`
+	Unlisted        bool      `json:"unlisted"`
+	CreatedBy       uuid.UUID `json:"created_by"`
+}
+
+func (s *service) SetDomainEventPublisher(publisher DomainEventPublisher) {
+	s.domainEvents = publisher
+}
+
+// publishEventPublished records EventPublished for a newly created or cloned
+// event. A failure is only logged because the event has already been saved.
+func (s *service) publishEventPublished(event *Event) {
+	if s.domainEvents == nil || event == nil || event.Status != EventStatusPublished {
+		return
+	}
+
+	data := EventPublishedData{
+		EventID:         event.ID,
+		Name:            event.Name,
+		Venue:           event.Venue,
+		VenueTemplateID: event.VenueTemplateID,
+		DateTime:        event.DateTime,
+		DurationMinutes: event.DurationMinutes,
+		BasePrice:       event.BasePrice,
+		Currency:        event.Currency,
+		Unlisted:        event.Unlisted,
+		CreatedBy:       event.CreatedBy,
+	}
+	dedupKey := fmt.Sprintf("event:%s:published:%d", event.ID, event.UpdatedAt.UnixNano())
+
+	if err := s.domainEvents.Publish(context.Background(), domainevents.EventPublished, event.ID, dedupKey, data); err != nil {
+		log.Warn("Failed to record EventPublished", "event_id", event.ID, "error", err)
+	}
+}
@@ -0,0 +1,127 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// initialStatus returns the status a new event is created with. A publish
+// time makes the event a draft until then.
+func initialStatus(draft bool, publishAt *time.Time, dateTime time.Time) (EventStatus, *time.Time, error) {
+	if publishAt == nil {
+		if draft {
+			return EventStatusDraft, nil, nil
+		}
+		return EventStatusPublished, nil, nil
+	}
+	if err := validatePublishAt(*publishAt, dateTime); err != nil {
+		return "", nil, err
+	}
+	return EventStatusDraft, publishAt, nil
+}
+
+func validatePublishAt(publishAt, dateTime time.Time) error {
+	if !publishAt.After(time.Now()) {
+		return errors.New("publish time must be in the future")
+	}
+	if !publishAt.Before(dateTime) {
+		return errors.New("publish time must be before the event date")
+	}
+	return nil
+}
+
+// applyPublishSchedule adds publish time changes to an update. Only drafts
+// are scheduled; publishing a draft by hand drops its schedule.
+func applyPublishSchedule(current *Event, req UpdateEventRequest, updates map[string]interface{}) error {
+	if _, ok := updates["status"]; ok && current.Status == EventStatusDraft {
+		if req.PublishAt != nil {
+			return errors.New("cannot schedule publishing while changing a draft's status")
+		}
+		updates["publish_at"] = nil
+		return nil
+	}
+
+	publishAt := current.PublishAt
+	if req.PublishAt != nil {
+		if current.Status != EventStatusDraft {
+			return errors.New("only draft events can be scheduled for publishing")
+		}
+		publishAt = req.PublishAt
+		updates["publish_at"] = *req.PublishAt
+	}
+
+	// A new event date has to leave room for a publish time already set
+	if publishAt != nil && current.Status == EventStatusDraft && (req.PublishAt != nil || req.DateTime != nil) {
+		dateTime := current.DateTime
+		if req.DateTime != nil {
+			dateTime = *req.DateTime
+		}
+		if err := validatePublishAt(*publishAt, dateTime); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetDraftEvents lists drafts for admins, those due to publish first
+func (s *service) GetDraftEvents() ([]EventResponse, error) {
+	drafts, err := s.repo.GetDrafts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get draft events: %w", err)
+	}
+
+	responses := make([]EventResponse, len(drafts))
+	for i, draft := range drafts {
+		responses[i] = draft.ToResponse()
+		if err := s.populateEventTags(&responses[i]); err != nil {
+			log.Warn("Failed to populate tags", "event_id", draft.ID, "error", err)
+		}
+	}
+	return responses, nil
+}
+
+// PreviewEvent returns an event as its public page will show it, whatever its
+// status. It skips the detail cache so drafts are never cached for the public.
+func (s *service) PreviewEvent(ctx context.Context, id uuid.UUID) (*EventResponse, error) {
+	event, err := s.repo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("event not found")
+		}
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	response := event.ToResponse()
+	if err := s.populateEventCapacity(&response); err != nil {
+		return nil, fmt.Errorf("failed to populate capacity data: %w", err)
+	}
+	if err := s.populateEventTags(&response); err != nil {
+		return nil, fmt.Errorf("failed to populate tags: %w", err)
+	}
+	if err := s.populateVenueSections(&response); err != nil {
+		return nil, fmt.Errorf("failed to populate venue sections: %w", err)
+	}
+	s.populateBranding(ctx, &response)
+
+	return &response, nil
+}
+
+// publishScheduled publishes a draft whose publish time has come and warms
+// its detail page, so the first visitors don't all miss the cache
+func (s *service) publishScheduled(ctx context.Context, draft *Event) bool {
+	event := s.scheduledTransition(ctx, draft, EventStatusPublished)
+	if event == nil {
+		return false
+	}
+
+	s.publishEventPublished(event)
+	if _, err := s.loadEventDetail(ctx, event.ID); err != nil {
+		log.WarnContext(ctx, "Failed to warm cache of published event", "event_id", event.ID, "error", err)
+	}
+	return true
+}
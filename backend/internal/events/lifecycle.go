@@ -0,0 +1,256 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"evently/internal/domainevents"
+	"evently/internal/webhooks"
+
+	"github.com/google/uuid"
+)
+
+// What moved an event to a new status
+const (
+	TransitionTriggerManual    = "manual"
+	TransitionTriggerScheduler = "scheduler"
+)
+
+// ErrStatusChanged is returned when an event's status changed between reading
+// it and moving it on, so the transition was not applied
+var ErrStatusChanged = errors.New("event status changed concurrently")
+
+// LifecycleConfig contains configuration for the scheduled status transitions
+type LifecycleConfig struct {
+	Interval  time.Duration // How often events are checked
+	BatchSize int           // Events moved per transition per run
+}
+
+// DefaultLifecycleConfig returns default lifecycle configuration
+func DefaultLifecycleConfig() *LifecycleConfig {
+	return &LifecycleConfig{
+		Interval:  time.Minute,
+		BatchSize: 200,
+	}
+}
+
+// EventAvailability is what is left to sell of an event on sale
+type EventAvailability struct {
+	EventID   uuid.UUID
+	Status    EventStatus
+	Capacity  int
+	Remaining int
+}
+
+// LifecycleRun counts the events one scheduler run moved to each status
+type LifecycleRun struct {
+	Published int `json:"published"` // Drafts whose publish time came
+	Completed int `json:"completed"`
+	Ongoing   int `json:"ongoing"`
+	SoldOut   int `json:"sold_out"`
+	Reopened  int `json:"reopened"` // Sold out events back on sale after seats were freed
+}
+
+// EventStatusChangedData is the data of event.status_changed webhooks and
+// EventStatusChanged domain events
+type EventStatusChangedData struct {
+	EventID   uuid.UUID   `json:"event_id"`
+	Name      string      `json:"name"`
+	DateTime  time.Time   `json:"date_time"`
+	From      EventStatus `json:"from"`
+	To        EventStatus `json:"to"`
+	Trigger   string      `json:"trigger"`
+	ChangedBy *uuid.UUID  `json:"changed_by,omitempty"` // Empty for scheduled transitions
+	ChangedAt time.Time   `json:"changed_at"`
+}
+
+func (s *service) SetLifecycleConfig(config *LifecycleConfig) {
+	s.lifecycleConfig = config
+}
+
+// AdvanceLifecycle applies the transitions driven by time and capacity: drafts
+// are published at their publish time, events whose end has passed are
+// completed, events that have started become ongoing, and events on sale
+// switch between published and sold out as their last seats go or come back.
+// Ended events are completed first so an event that ran its whole length
+// between two runs skips ongoing.
+func (s *service) AdvanceLifecycle(ctx context.Context) (*LifecycleRun, error) {
+	config := s.lifecycleConfig
+	if config == nil {
+		config = DefaultLifecycleConfig()
+	}
+	now := time.Now()
+	run := &LifecycleRun{}
+
+	due, err := s.repo.GetDueDrafts(now, config.BatchSize)
+	if err != nil {
+		return run, fmt.Errorf("failed to get drafts due to publish: %w", err)
+	}
+	for i := range due {
+		if s.publishScheduled(ctx, &due[i]) {
+			run.Published++
+		}
+	}
+	if run.Published > 0 {
+		if _, err := s.WarmEventLists(ctx); err != nil {
+			log.WarnContext(ctx, "Failed to warm event lists after publishing", "error", err)
+		}
+	}
+
+	ended, err := s.repo.GetEndedEvents(now, config.BatchSize)
+	if err != nil {
+		return run, fmt.Errorf("failed to get ended events: %w", err)
+	}
+	for i := range ended {
+		if s.scheduledTransition(ctx, &ended[i], EventStatusCompleted) != nil {
+			run.Completed++
+		}
+	}
+
+	started, err := s.repo.GetStartedEvents(now, config.BatchSize)
+	if err != nil {
+		return run, fmt.Errorf("failed to get started events: %w", err)
+	}
+	for i := range started {
+		if s.scheduledTransition(ctx, &started[i], EventStatusOngoing) != nil {
+			run.Ongoing++
+		}
+	}
+
+	changes, err := s.repo.GetAvailabilityChanges(now, config.BatchSize)
+	if err != nil {
+		return run, fmt.Errorf("failed to get event availability: %w", err)
+	}
+	for _, change := range changes {
+		event := &Event{ID: change.EventID, Status: change.Status}
+		if change.Status == EventStatusPublished {
+			if s.scheduledTransition(ctx, event, EventStatusSoldOut) != nil {
+				run.SoldOut++
+			}
+		} else if s.scheduledTransition(ctx, event, EventStatusPublished) != nil {
+			run.Reopened++
+		}
+	}
+
+	return run, nil
+}
+
+// scheduledTransition moves an event on for the scheduler and returns it, or
+// nil if it didn't move. Failures are logged so one event can't hold up the
+// rest of the run.
+func (s *service) scheduledTransition(ctx context.Context, event *Event, to EventStatus) *Event {
+	if !event.Status.CanTransitionTo(to) {
+		log.WarnContext(ctx, "Scheduler skipped event, status cannot move", "event_id", event.ID, "from", event.Status, "to", to)
+		return nil
+	}
+
+	updated, err := s.repo.TransitionStatus(event.ID, event.Status, to, nil)
+	if err != nil {
+		if !errors.Is(err, ErrStatusChanged) {
+			log.WarnContext(ctx, "Failed to move event status", "event_id", event.ID, "from", event.Status, "to", to, "error", err)
+		}
+		return nil
+	}
+
+	if err := s.invalidateEventCache(ctx, &event.ID); err != nil {
+		log.WarnContext(ctx, "Failed to invalidate event cache after status change", "error", err)
+	}
+	s.publishStatusChanged(event.Status, updated, nil, TransitionTriggerScheduler)
+	return updated
+}
+
+// publishStatusChanged sends event.status_changed to subscribed partners and
+// records EventStatusChanged when an event's status moved. A failure is only
+// logged because the change itself has already been saved.
+func (s *service) publishStatusChanged(from EventStatus, event *Event, changedBy *uuid.UUID, trigger string) {
+	if event == nil || event.Status == from {
+		return
+	}
+
+	data := EventStatusChangedData{
+		EventID:   event.ID,
+		Name:      event.Name,
+		DateTime:  event.DateTime,
+		From:      from,
+		To:        event.Status,
+		Trigger:   trigger,
+		ChangedBy: changedBy,
+		ChangedAt: event.UpdatedAt,
+	}
+	dedupKey := fmt.Sprintf("event:%s:status:%s:%d", event.ID, event.Status, event.UpdatedAt.UnixNano())
+
+	if s.webhookPublisher != nil {
+		if err := s.webhookPublisher.Publish(context.Background(), webhooks.EventEventStatusChanged, dedupKey, data); err != nil {
+			log.Warn("Failed to publish event.status_changed webhook", "event_id", event.ID, "error", err)
+		}
+	}
+	if s.domainEvents != nil {
+		if err := s.domainEvents.Publish(context.Background(), domainevents.EventStatusChanged, event.ID, dedupKey, data); err != nil {
+			log.Warn("Failed to record EventStatusChanged", "event_id", event.ID, "error", err)
+		}
+	}
+}
+
+// LifecycleJob periodically applies the scheduled status transitions
+type LifecycleJob struct {
+	service Service
+	config  *LifecycleConfig
+	done    chan struct{}
+}
+
+// NewLifecycleJob creates a new event lifecycle job
+func NewLifecycleJob(service Service, config *LifecycleConfig) *LifecycleJob {
+	if config == nil {
+		config = DefaultLifecycleConfig()
+	}
+
+	return &LifecycleJob{
+		service: service,
+		config:  config,
+		done:    make(chan struct{}),
+	}
+}
+
+// Start begins checking events on the configured interval
+func (j *LifecycleJob) Start(ctx context.Context) {
+	log.Info("Starting event lifecycle job", "interval", j.config.Interval)
+	go j.run(ctx)
+}
+
+// Stop stops the lifecycle job
+func (j *LifecycleJob) Stop() {
+	log.Info("Stopping event lifecycle job")
+	close(j.done)
+}
+
+func (j *LifecycleJob) run(ctx context.Context) {
+	j.advance(ctx)
+
+	ticker := time.NewTicker(j.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			j.advance(ctx)
+		case <-j.done:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (j *LifecycleJob) advance(ctx context.Context) {
+	run, err := j.service.AdvanceLifecycle(ctx)
+	if err != nil {
+		log.Error("Event lifecycle run failed", "error", err)
+	}
+	if run != nil && run.Published+run.Completed+run.Ongoing+run.SoldOut+run.Reopened > 0 {
+		log.Info("Event lifecycle run",
+			"published", run.Published, "completed", run.Completed, "ongoing", run.Ongoing,
+			"sold_out", run.SoldOut, "reopened", run.Reopened)
+	}
+}
@@ -0,0 +1,211 @@
+package events
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"evently/internal/shared/utils/constants"
+	"evently/pkg/metrics"
+)
+
+// Event list cache keyspace tuning. Unfiltered pages are always cached; filtered
+// ones are only cached once their filter set has proven popular, and only so
+// many filter sets are kept, least recently used going first.
+const (
+	listCacheMaxFilterSets = 500  // Filtered combinations cached at once
+	listCacheAdmitAfter    = 2    // Requests before a filter set is cached
+	listCacheMaxCandidates = 5000 // Filter sets counted while waiting to be admitted
+
+	listWarmPages = 5  // Unfiltered pages rebuilt per status by WarmEventLists
+	listWarmLimit = 10 // Page size clients get by default
+)
+
+// listWarmStatuses are the unfiltered lists WarmEventLists rebuilds: the
+// default list and the published-only one
+var listWarmStatuses = []string{"", "published"}
+
+// normalizeListQuery rewrites the filters of a list query into one canonical
+// form, so equivalent queries hit the same rows and the same cache entry
+func normalizeListQuery(query *EventListQuery) {
+	query.Search = strings.Join(strings.Fields(strings.ToLower(query.Search)), " ")
+	query.Venue = strings.Join(strings.Fields(strings.ToLower(query.Venue)), " ")
+	query.DateFrom = normalizeListDate(query.DateFrom)
+	query.DateTo = normalizeListDate(query.DateTo)
+
+	// Tag names match exactly, so only order and duplicates are dropped
+	seen := make(map[string]bool)
+	var tagNames []string
+	for _, tag := range strings.Split(query.Tags, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" && !seen[tag] {
+			seen[tag] = true
+			tagNames = append(tagNames, tag)
+		}
+	}
+	sort.Strings(tagNames)
+	query.Tags = strings.Join(tagNames, ",")
+}
+
+// normalizeListDate drops dates the repository would ignore anyway
+func normalizeListDate(date string) string {
+	parsed, err := time.Parse("2006-01-02", strings.TrimSpace(date))
+	if err != nil {
+		return ""
+	}
+	return parsed.Format("2006-01-02")
+}
+
+// listFilterNames are the filters set on a normalized query, in a fixed order.
+// They label the per-filter cache metrics, so values never leak into labels.
+func listFilterNames(query EventListQuery) []string {
+	var names []string
+	if query.Search != "" {
+		names = append(names, "search")
+	}
+	if query.Venue != "" {
+		names = append(names, "venue")
+	}
+	if query.Tags != "" {
+		names = append(names, "tags")
+	}
+	if query.DateFrom != "" {
+		names = append(names, "date_from")
+	}
+	if query.DateTo != "" {
+		names = append(names, "date_to")
+	}
+	return names
+}
+
+// listFilterHash identifies a normalized query's filter set. Page, limit and
+// status stay readable in the key.
+func listFilterHash(query EventListQuery) string {
+	canonical := strings.Join([]string{
+		"date_from=" + query.DateFrom,
+		"date_to=" + query.DateTo,
+		"search=" + query.Search,
+		"tags=" + query.Tags,
+		"venue=" + query.Venue,
+	}, "\n")
+	sum := sha256.Sum256([]byte(canonical))
+	return hex.EncodeToString(sum[:8])
+}
+
+// recordListCacheLookup counts a list lookup once per filter it used
+func recordListCacheLookup(filterNames []string, result string) {
+	if len(filterNames) == 0 {
+		metrics.EventListCacheTotal.Inc("none", result)
+		return
+	}
+	for _, name := range filterNames {
+		metrics.EventListCacheTotal.Inc(name, result)
+	}
+}
+
+// listCacheKeyspace decides which filter sets get cache entries. A filter set
+// is admitted after listCacheAdmitAfter requests and kept in LRU order; when
+// more than listCacheMaxFilterSets are admitted the least recently used is
+// evicted and its entries should be deleted.
+type listCacheKeyspace struct {
+	mu         sync.Mutex
+	admitted   *list.List               // Filter set hashes, most recently used first
+	elements   map[string]*list.Element // Hash to its place in admitted
+	candidates map[string]int           // Request counts of filter sets not yet admitted
+
+	maxAdmitted   int
+	admitAfter    int
+	maxCandidates int
+}
+
+func newListCacheKeyspace(maxAdmitted, admitAfter, maxCandidates int) *listCacheKeyspace {
+	return &listCacheKeyspace{
+		admitted:      list.New(),
+		elements:      make(map[string]*list.Element),
+		candidates:    make(map[string]int),
+		maxAdmitted:   maxAdmitted,
+		admitAfter:    admitAfter,
+		maxCandidates: maxCandidates,
+	}
+}
+
+// touch records a request for a filter set and reports whether it may be
+// cached, along with the filter set evicted to make room, if any
+func (k *listCacheKeyspace) touch(hash string) (cacheable bool, evicted string) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if element, ok := k.elements[hash]; ok {
+		k.admitted.MoveToFront(element)
+		return true, ""
+	}
+
+	k.candidates[hash]++
+	if k.candidates[hash] < k.admitAfter {
+		// Rare filter sets are forgotten together once too many pile up
+		if len(k.candidates) > k.maxCandidates {
+			k.candidates = make(map[string]int)
+		}
+		return false, ""
+	}
+
+	delete(k.candidates, hash)
+	k.elements[hash] = k.admitted.PushFront(hash)
+
+	if k.admitted.Len() > k.maxAdmitted {
+		oldest := k.admitted.Back()
+		k.admitted.Remove(oldest)
+		evicted = oldest.Value.(string)
+		delete(k.elements, evicted)
+	}
+	return true, evicted
+}
+
+// dropFilteredListsAsync deletes every cached page of an evicted filter set
+func (s *service) dropFilteredListsAsync(filterHash string) {
+	if s.cacheService == nil {
+		return
+	}
+	go func() {
+		pattern := constants.BuildFilteredEventListPrefix(filterHash) + ":*"
+		if err := s.cacheService.DeletePattern(context.Background(), pattern); err != nil {
+			log.Warn("Failed to drop evicted event list filter set", "filter_hash", filterHash, "error", err)
+		}
+	}()
+}
+
+// WarmEventLists rebuilds the first pages of the unfiltered event lists from
+// the database, replacing whatever is cached, and returns how many pages it
+// cached
+func (s *service) WarmEventLists(ctx context.Context) (int, error) {
+	if s.cacheService == nil {
+		return 0, nil
+	}
+
+	warmed := 0
+	for _, status := range listWarmStatuses {
+		for page := 1; page <= listWarmPages; page++ {
+			query := EventListQuery{Page: page, Limit: listWarmLimit, Status: status}
+			result, err := s.loadEventList(query)
+			if err != nil {
+				return warmed, err
+			}
+
+			key := constants.BuildEventListKey(page, listWarmLimit, status)
+			if err := s.setCache(ctx, key, result, constants.TTL_EVENT_LIST); err != nil {
+				return warmed, err
+			}
+			warmed++
+
+			if page >= result.TotalPages {
+				break
+			}
+		}
+	}
+
+	return warmed, nil
+}
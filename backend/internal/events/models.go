@@ -1,10 +1,13 @@
 package events
 
 import (
+	"evently/internal/promotions"
+	"evently/internal/shared/utils/response"
 	"evently/internal/tags"
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type TagResponse = tags.TagResponse
@@ -37,10 +40,21 @@ type Event struct {
 	Venue           string      `json:"venue" gorm:"not null;size:255"`
 	VenueTemplateID uuid.UUID   `json:"venue_template_id" gorm:"type:uuid;not null"`
 	DateTime        time.Time   `json:"date_time" gorm:"not null"`
+	DurationMinutes int         `json:"duration_minutes" gorm:"not null;default:0"` // 0 uses the venue's default duration
 	BasePrice       float64     `json:"base_price" gorm:"not null;check:base_price >= 0"`
+	Currency        string      `json:"currency" gorm:"type:varchar(3);not null;default:'INR'"` // Prices are charged in this currency
 	Status          EventStatus `json:"status" gorm:"type:varchar(20);default:'published'"`
+	PublishAt       *time.Time  `json:"publish_at,omitempty" gorm:"index"` // Drafts are published by the lifecycle scheduler at this time
 	ImageURL        string      `json:"image_url" gorm:"size:500"`
 
+	// Crawler visibility; private and partner-only events stay out of search engines
+	Unlisted bool `json:"unlisted" gorm:"not null;default:false"` // Left out of the sitemap and public feeds
+	NoIndex  bool `json:"noindex" gorm:"not null;default:false"`  // Detail responses ask crawlers not to index
+
+	// Recurring series membership; detached occurrences were edited on their own
+	SeriesID       *uuid.UUID `json:"series_id,omitempty" gorm:"type:uuid;index"`
+	SeriesDetached bool       `json:"series_detached" gorm:"default:false"`
+
 	// Many-to-many relationship with tags
 	Tags []tags.Tag `json:"-" gorm:"many2many:event_tags;constraint:OnDelete:CASCADE;"`
 
@@ -48,25 +62,53 @@ type Event struct {
 	UpdatedBy *uuid.UUID `json:"updated_by" gorm:"type:uuid"`
 	CreatedAt time.Time  `json:"created_at" gorm:"autoCreateTime"`
 	UpdatedAt time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+
+	// Soft delete; archived events are moved out of this table entirely
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
 }
 
 type EventResponse struct {
-	ID               string         `json:"id"`
-	Name             string         `json:"name"`
-	Description      string         `json:"description"`
-	Venue            string         `json:"venue"`
-	VenueTemplateID  string         `json:"venue_template_id"`
-	VenueSections    []VenueSection `json:"venue_sections,omitempty"` // Added venue sections
-	DateTime         time.Time      `json:"date_time"`
-	TotalCapacity    int            `json:"total_capacity"`    // Calculated from venue sections
-	BookedCount      int            `json:"booked_count"`      // Calculated from seat bookings
-	AvailableTickets int            `json:"available_tickets"` // Calculated
-	BasePrice        float64        `json:"base_price"`
-	Status           EventStatus    `json:"status"`
-	ImageURL         string         `json:"image_url"`
-	Tags             []TagInfo      `json:"tags"`
-	CreatedAt        time.Time      `json:"created_at"`
-	UpdatedAt        time.Time      `json:"updated_at"`
+	ID               string                     `json:"id"`
+	Name             string                     `json:"name"`
+	Description      string                     `json:"description"`
+	Venue            string                     `json:"venue"`
+	VenueTemplateID  string                     `json:"venue_template_id"`
+	VenueSections    []VenueSection             `json:"venue_sections,omitempty"` // Added venue sections
+	DateTime         time.Time                  `json:"date_time"`
+	DurationMinutes  int                        `json:"duration_minutes,omitempty"`
+	TotalCapacity    int                        `json:"total_capacity"`    // Calculated from venue sections
+	BookedCount      int                        `json:"booked_count"`      // Calculated from seat bookings
+	AvailableTickets int                        `json:"available_tickets"` // Calculated
+	BasePrice        float64                    `json:"base_price"`
+	Currency         string                     `json:"currency"`
+	Status           EventStatus                `json:"status"`
+	PublishAt        *time.Time                 `json:"publish_at,omitempty"` // Scheduled publish time of a draft
+	ImageURL         string                     `json:"image_url"`
+	Unlisted         bool                       `json:"unlisted"`
+	NoIndex          bool                       `json:"noindex"` // Also sent as an X-Robots-Tag header on the detail endpoint
+	Tags             []TagInfo                  `json:"tags"`
+	SeriesID         *string                    `json:"series_id,omitempty"`    // Set for occurrences of a recurring series
+	Promotions       []promotions.PromotedEvent `json:"promotions,omitempty"`   // "You may also like" slots, on the public detail only
+	Branding         *EventBranding             `json:"branding,omitempty"`     // Organizer theming for clients
+	Rating           *EventRating               `json:"rating,omitempty"`       // Attendee review aggregate
+	IsFavorited      *bool                      `json:"is_favorited,omitempty"` // Set for authenticated list requests
+	CreatedAt        time.Time                  `json:"created_at"`
+	UpdatedAt        time.Time                  `json:"updated_at"`
+}
+
+// EventBranding is the organizer's logo and colors, already resolved against platform defaults
+type EventBranding struct {
+	Name           string `json:"name"`
+	LogoURL        string `json:"logo_url"`
+	PrimaryColor   string `json:"primary_color"`
+	SecondaryColor string `json:"secondary_color"`
+	Source         string `json:"source"` // ORGANIZER or PLATFORM
+}
+
+// EventRating is the average of an event's published attendee reviews
+type EventRating struct {
+	AverageRating float64 `json:"average_rating"`
+	ReviewCount   int64   `json:"review_count"`
 }
 
 type CreateEventRequest struct {
@@ -76,9 +118,22 @@ type CreateEventRequest struct {
 	VenueTemplateID string                      `json:"venue_template_id" binding:"required,uuid"`
 	DateTime        time.Time                   `json:"date_time" binding:"required"`
 	BasePrice       float64                     `json:"base_price" binding:"required,min=0"`
+	Currency        string                      `json:"currency" binding:"omitempty,len=3"` // Defaults to the base currency
 	ImageURL        string                      `json:"image_url" binding:"omitempty,url"`
+	Unlisted        bool                        `json:"unlisted"`
+	NoIndex         bool                        `json:"noindex"`
 	Tags            []string                    `json:"tags"`
 	SectionPricing  []CreateEventSectionPricing `json:"section_pricing" binding:"required,min=1"`
+	DurationMinutes int                         `json:"duration_minutes" binding:"omitempty,min=15,max=1440"`
+
+	// Drafts are only visible to admins until published, by hand or at PublishAt,
+	// which also creates the event as a draft
+	Draft     bool       `json:"draft"`
+	PublishAt *time.Time `json:"publish_at"`
+
+	// Schedule over other events at the same venue; the reason is kept for audit
+	OverrideVenueConflict bool   `json:"override_venue_conflict"`
+	OverrideReason        string `json:"override_reason" binding:"max=500"`
 }
 
 // CreateEventSectionPricing represents pricing for a section in an event
@@ -94,9 +149,37 @@ type UpdateEventRequest struct {
 	VenueTemplateID *string    `json:"venue_template_id" binding:"omitempty,uuid"`
 	DateTime        *time.Time `json:"date_time"`
 	BasePrice       *float64   `json:"base_price" binding:"omitempty,min=0"`
+	Currency        *string    `json:"currency" binding:"omitempty,len=3"`
 	Status          *string    `json:"status" binding:"omitempty,oneof=published cancelled completed"`
 	ImageURL        *string    `json:"image_url" binding:"omitempty,url"`
+	Unlisted        *bool      `json:"unlisted"`
+	NoIndex         *bool      `json:"noindex"`
 	Tags            []string   `json:"tags"`
+	DurationMinutes *int       `json:"duration_minutes" binding:"omitempty,min=15,max=1440"`
+	PublishAt       *time.Time `json:"publish_at"` // Drafts only
+
+	// Schedule over other events at the same venue; the reason is kept for audit
+	OverrideVenueConflict bool   `json:"override_venue_conflict"`
+	OverrideReason        string `json:"override_reason" binding:"max=500"`
+}
+
+// CloneEventRequest duplicates an event. Fields left empty are copied from the
+// source event; section pricing entries override the copied multipliers.
+type CloneEventRequest struct {
+	Name            *string                     `json:"name" binding:"omitempty,min=3,max=255"`
+	Venue           *string                     `json:"venue" binding:"omitempty,min=3,max=255"`
+	VenueTemplateID *string                     `json:"venue_template_id" binding:"omitempty,uuid"`
+	DateTime        time.Time                   `json:"date_time" binding:"required"`
+	BasePrice       *float64                    `json:"base_price" binding:"omitempty,min=0"`
+	Currency        *string                     `json:"currency" binding:"omitempty,len=3"`
+	ImageURL        *string                     `json:"image_url" binding:"omitempty,url"`
+	Tags            []string                    `json:"tags"`
+	SectionPricing  []CreateEventSectionPricing `json:"section_pricing" binding:"omitempty,dive"`
+	DurationMinutes *int                        `json:"duration_minutes" binding:"omitempty,min=15,max=1440"`
+
+	// Schedule over other events at the same venue; the reason is kept for audit
+	OverrideVenueConflict bool   `json:"override_venue_conflict"`
+	OverrideReason        string `json:"override_reason" binding:"max=500"`
 }
 
 type EventListQuery struct {
@@ -106,8 +189,10 @@ type EventListQuery struct {
 	Venue    string `form:"venue"`
 	DateFrom string `form:"date_from"`
 	DateTo   string `form:"date_to"`
-	Status   string `form:"status" binding:"omitempty,oneof=published cancelled completed"`
-	Tags     string `form:"tags"`
+	Status   string `form:"status" binding:"omitempty,oneof=published sold_out ongoing cancelled completed"`
+	Tags     string `form:"tags"` // Comma-separated tag names, each also matching its child tags
+
+	ViewerID *uuid.UUID `form:"-"` // Authenticated caller, used to flag favorited events
 }
 
 type EventAnalytics struct {
@@ -134,6 +219,10 @@ type PaginatedEvents struct {
 	TotalPages int             `json:"total_pages"`
 }
 
+func (p PaginatedEvents) PaginationMeta() response.Pagination {
+	return response.NewPagination(p.Page, p.Limit, p.TotalCount)
+}
+
 type GlobalAnalytics struct {
 	TotalEvents        int               `json:"total_events"`
 	TotalBookings      int               `json:"total_bookings"`
@@ -162,7 +251,7 @@ type MonthlyRevenue struct {
 // Helper method to convert Event to EventResponse
 // Note: Tags, capacity and booking counts will be populated by the service layer
 func (e *Event) ToResponse() EventResponse {
-	return EventResponse{
+	response := EventResponse{
 		ID:               e.ID.String(),
 		Name:             e.Name,
 		Description:      e.Description,
@@ -170,16 +259,26 @@ func (e *Event) ToResponse() EventResponse {
 		VenueTemplateID:  e.VenueTemplateID.String(),
 		VenueSections:    []VenueSection{}, // Will be populated by service layer
 		DateTime:         e.DateTime,
+		DurationMinutes:  e.DurationMinutes,
 		TotalCapacity:    0, // Will be calculated by service layer
 		BookedCount:      0, // Will be calculated by service layer
 		AvailableTickets: 0, // Will be calculated by service layer
 		BasePrice:        e.BasePrice,
+		Currency:         e.Currency,
 		Status:           e.Status,
+		PublishAt:        e.PublishAt,
 		ImageURL:         e.ImageURL,
+		Unlisted:         e.Unlisted,
+		NoIndex:          e.NoIndex,
 		Tags:             []TagInfo{}, // Will be populated by service layer
 		CreatedAt:        e.CreatedAt,
 		UpdatedAt:        e.UpdatedAt,
 	}
+	if e.SeriesID != nil {
+		seriesID := e.SeriesID.String()
+		response.SeriesID = &seriesID
+	}
+	return response
 }
 
 // TableName specifies the table name for GORM
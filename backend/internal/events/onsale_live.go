@@ -0,0 +1,109 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"evently/pkg/metrics"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// OnSaleRate is how often something happened for the event over the last window
+type OnSaleRate struct {
+	Count     uint64  `json:"count"`      // Occurrences within the window
+	PerSecond float64 `json:"per_second"` // Average over the window
+}
+
+// DependencyLatency is the round trip of one cheap request to a backing store
+type DependencyLatency struct {
+	Status    string  `json:"status"` // "ok" or "unavailable"
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// OnSaleLive is the ops view of an event during an on-sale. Rates come from
+// in-memory counters of the instance that answered, not from SQL, so the view
+// stays cheap to poll while the database is busiest.
+type OnSaleLive struct {
+	EventID           string            `json:"event_id"`
+	EventName         string            `json:"event_name"`
+	Instance          string            `json:"instance"`
+	WindowSeconds     int               `json:"window_seconds"`
+	HoldsCreated      OnSaleRate        `json:"holds_created"`
+	HoldConflicts     OnSaleRate        `json:"hold_conflicts"`
+	ConflictRatio     float64           `json:"conflict_ratio"` // Share of hold attempts lost to contention
+	BookingsConfirmed OnSaleRate        `json:"bookings_confirmed"`
+	PaymentFailures   OnSaleRate        `json:"payment_failures"`
+	QueueAdmissions   OnSaleRate        `json:"queue_admissions"` // Waitlist users offered a booking window
+	Redis             DependencyLatency `json:"redis"`
+	Database          DependencyLatency `json:"database"`
+	GeneratedAt       time.Time         `json:"generated_at"`
+}
+
+func (s *service) GetOnSaleLive(ctx context.Context, eventID uuid.UUID) (*OnSaleLive, error) {
+	// The event lookup is a primary key read, so it doubles as the database probe
+	start := time.Now()
+	event, err := s.repo.GetByID(eventID)
+	databaseLatency := time.Since(start)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("event not found")
+		}
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	id := eventID.String()
+	window := metrics.OnSaleActivity.Window()
+	rate := func(activity string) OnSaleRate {
+		count := metrics.OnSaleActivity.Count(id, activity)
+		return OnSaleRate{Count: count, PerSecond: float64(count) / window.Seconds()}
+	}
+
+	live := &OnSaleLive{
+		EventID:           id,
+		EventName:         event.Name,
+		WindowSeconds:     int(window.Seconds()),
+		HoldsCreated:      rate(metrics.OnSaleHoldCreated),
+		HoldConflicts:     rate(metrics.OnSaleHoldConflict),
+		BookingsConfirmed: rate(metrics.OnSaleBookingConfirmed),
+		PaymentFailures:   rate(metrics.OnSalePaymentFailed),
+		QueueAdmissions:   rate(metrics.OnSaleQueueAdmission),
+		Database:          DependencyLatency{Status: "ok", LatencyMs: toMilliseconds(databaseLatency)},
+		Redis:             s.probeRedis(ctx),
+		GeneratedAt:       time.Now(),
+	}
+	live.Instance, _ = os.Hostname()
+
+	if attempts := live.HoldsCreated.Count + live.HoldConflicts.Count; attempts > 0 {
+		live.ConflictRatio = float64(live.HoldConflicts.Count) / float64(attempts)
+	}
+
+	return live, nil
+}
+
+func (s *service) probeRedis(ctx context.Context) DependencyLatency {
+	if s.cacheService == nil {
+		return DependencyLatency{Status: "unavailable", Error: "cache service not configured"}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+
+	start := time.Now()
+	err := s.cacheService.Ping(ctx)
+	latency := DependencyLatency{Status: "ok", LatencyMs: toMilliseconds(time.Since(start))}
+	if err != nil {
+		latency.Status = "unavailable"
+		latency.Error = err.Error()
+	}
+	return latency
+}
+
+func toMilliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
@@ -0,0 +1,287 @@
+package events
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Pricing assist tuning
+const (
+	pricingHistoryEvents   = 20   // Most similar past events looked at per request
+	pricingTargetSellRate  = 0.85 // Sell-through the suggestions steer towards
+	pricingAdjustmentScale = 0.5  // How strongly sell-through moves the multiplier
+	pricingMaxAdjustment   = 0.2  // Largest step away from the historical multiplier
+	pricingFullConfidence  = 5    // Past events needed for full confidence
+	pricingMinMultiplier   = 0.1  // Same bounds as section pricing requests
+	pricingMaxMultiplier   = 10.0
+)
+
+type PricingConfidenceLevel string
+
+const (
+	PricingConfidenceHigh   PricingConfidenceLevel = "HIGH"
+	PricingConfidenceMedium PricingConfidenceLevel = "MEDIUM"
+	PricingConfidenceLow    PricingConfidenceLevel = "LOW"
+	PricingConfidenceNone   PricingConfidenceLevel = "NONE" // No history, the suggestion is the default multiplier
+)
+
+type PricingSuggestionQuery struct {
+	VenueTemplateID string `form:"venue_template_id" binding:"required,uuid"`
+	Tags            string `form:"tags"` // Comma-separated tag names of the new event
+}
+
+// PricingEvidence is one past event's result for a section
+type PricingEvidence struct {
+	EventID         string    `json:"event_id"`
+	EventName       string    `json:"event_name"`
+	DateTime        time.Time `json:"date_time"`
+	PriceMultiplier float64   `json:"price_multiplier"`
+	Capacity        int       `json:"capacity"`
+	SeatsSold       int       `json:"seats_sold"`
+	SellThrough     float64   `json:"sell_through"`
+	Revenue         float64   `json:"revenue"`
+	RevenuePerSeat  float64   `json:"revenue_per_seat"`
+	SharedTags      int       `json:"shared_tags"` // Tags in common with the new event
+}
+
+// SectionPricingSuggestion is the suggested multiplier for one section and how it was reached
+type SectionPricingSuggestion struct {
+	SectionID           string                 `json:"section_id"`
+	SectionName         string                 `json:"section_name"`
+	SuggestedMultiplier float64                `json:"suggested_multiplier"`
+	HistoricalAverage   float64                `json:"historical_multiplier"`
+	SellThrough         float64                `json:"sell_through"`
+	RevenuePerSeat      float64                `json:"revenue_per_seat"`
+	Confidence          float64                `json:"confidence"` // 0 to 1
+	ConfidenceLevel     PricingConfidenceLevel `json:"confidence_level"`
+	Reason              string                 `json:"reason"`
+	Evidence            []PricingEvidence      `json:"evidence"`
+}
+
+type PricingSuggestions struct {
+	VenueTemplateID  string                     `json:"venue_template_id"`
+	Tags             []string                   `json:"tags"`
+	EventsConsidered int                        `json:"events_considered"`
+	Sections         []SectionPricingSuggestion `json:"sections"`
+	GeneratedAt      time.Time                  `json:"generated_at"`
+}
+
+// PricingHistoryEvent is a past event on the same venue template
+type PricingHistoryEvent struct {
+	ID         uuid.UUID
+	Name       string
+	DateTime   time.Time
+	SharedTags int
+}
+
+// PricingHistorySection is how one section of a past event was priced and sold
+type PricingHistorySection struct {
+	EventID         uuid.UUID
+	SectionID       uuid.UUID
+	Capacity        int
+	PriceMultiplier float64 // Zero when the section was not priced
+	SeatsSold       int
+	Revenue         float64
+}
+
+// GetPricingSuggestions suggests section multipliers for a new event from past
+// events on the same venue template, favouring events that share its tags
+func (s *service) GetPricingSuggestions(query PricingSuggestionQuery) (*PricingSuggestions, error) {
+	templateID, err := uuid.Parse(query.VenueTemplateID)
+	if err != nil {
+		return nil, errors.New("invalid venue template ID")
+	}
+	tagNames := splitTagNames(query.Tags)
+
+	// The template's sections, without an event so none count as priced
+	sections, err := s.repo.GetReadinessSections(uuid.Nil, templateID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get venue sections: %w", err)
+	}
+	if len(sections) == 0 {
+		return nil, errors.New("venue template not found or has no sections")
+	}
+
+	now := time.Now()
+	history, err := s.repo.GetPricingHistoryEvents(templateID, tagNames, now, pricingHistoryEvents)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pricing history: %w", err)
+	}
+
+	eventIDs := make([]uuid.UUID, 0, len(history))
+	for _, event := range history {
+		eventIDs = append(eventIDs, event.ID)
+	}
+
+	var results []PricingHistorySection
+	if len(eventIDs) > 0 {
+		results, err = s.repo.GetPricingHistorySections(eventIDs, templateID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get section sales: %w", err)
+		}
+	}
+
+	bySection := make(map[uuid.UUID][]PricingHistorySection)
+	for _, result := range results {
+		bySection[result.SectionID] = append(bySection[result.SectionID], result)
+	}
+
+	events := make(map[uuid.UUID]PricingHistoryEvent, len(history))
+	for _, event := range history {
+		events[event.ID] = event
+	}
+
+	suggestions := &PricingSuggestions{
+		VenueTemplateID:  templateID.String(),
+		Tags:             tagNames,
+		EventsConsidered: len(history),
+		Sections:         make([]SectionPricingSuggestion, 0, len(sections)),
+		GeneratedAt:      now,
+	}
+	for _, section := range sections {
+		suggestions.Sections = append(suggestions.Sections,
+			suggestSectionMultiplier(section, bySection[section.ID], events, len(tagNames) > 0))
+	}
+
+	return suggestions, nil
+}
+
+// suggestSectionMultiplier starts from the weighted historical multiplier and
+// nudges it up when the section sold out and down when it sold poorly. Events
+// sharing more tags with the new event weigh more.
+func suggestSectionMultiplier(section ReadinessSection, results []PricingHistorySection, events map[uuid.UUID]PricingHistoryEvent, tagged bool) SectionPricingSuggestion {
+	suggestion := SectionPricingSuggestion{
+		SectionID:           section.ID.String(),
+		SectionName:         section.Name,
+		SuggestedMultiplier: 1.0,
+		ConfidenceLevel:     PricingConfidenceNone,
+		Reason:              "No priced past events on this venue template, using the default multiplier",
+		Evidence:            []PricingEvidence{},
+	}
+
+	var totalWeight, multiplierSum, sellThroughSum, revenuePerSeatSum float64
+	var sellThroughs []float64
+	matchedTags := false
+
+	for _, result := range results {
+		if result.PriceMultiplier <= 0 || result.Capacity <= 0 {
+			continue // Unpriced or empty sections say nothing about price
+		}
+		event := events[result.EventID]
+
+		sellThrough := float64(result.SeatsSold) / float64(result.Capacity)
+		revenuePerSeat := result.Revenue / float64(result.Capacity)
+		weight := 1.0 + float64(event.SharedTags)
+		if event.SharedTags > 0 {
+			matchedTags = true
+		}
+
+		totalWeight += weight
+		multiplierSum += weight * result.PriceMultiplier
+		sellThroughSum += weight * sellThrough
+		revenuePerSeatSum += weight * revenuePerSeat
+		sellThroughs = append(sellThroughs, sellThrough)
+
+		suggestion.Evidence = append(suggestion.Evidence, PricingEvidence{
+			EventID:         event.ID.String(),
+			EventName:       event.Name,
+			DateTime:        event.DateTime,
+			PriceMultiplier: result.PriceMultiplier,
+			Capacity:        result.Capacity,
+			SeatsSold:       result.SeatsSold,
+			SellThrough:     roundTo(sellThrough, 4),
+			Revenue:         roundTo(result.Revenue, 2),
+			RevenuePerSeat:  roundTo(revenuePerSeat, 2),
+			SharedTags:      event.SharedTags,
+		})
+	}
+
+	if totalWeight == 0 {
+		return suggestion
+	}
+
+	average := multiplierSum / totalWeight
+	sellThrough := sellThroughSum / totalWeight
+
+	adjustment := pricingAdjustmentScale * (sellThrough - pricingTargetSellRate)
+	adjustment = math.Max(-pricingMaxAdjustment, math.Min(pricingMaxAdjustment, adjustment))
+	suggested := math.Max(pricingMinMultiplier, math.Min(pricingMaxMultiplier, average*(1+adjustment)))
+
+	// More events and steadier sales mean more confidence; history from
+	// unrelated events counts for less when the new event has tags
+	samples := math.Min(float64(len(sellThroughs))/pricingFullConfidence, 1)
+	consistency := 1 - math.Min(stdDev(sellThroughs), 0.5)
+	confidence := samples * consistency
+	if tagged && !matchedTags {
+		confidence *= 0.7
+	}
+
+	suggestion.SuggestedMultiplier = roundTo(suggested, 2)
+	suggestion.HistoricalAverage = roundTo(average, 2)
+	suggestion.SellThrough = roundTo(sellThrough, 4)
+	suggestion.RevenuePerSeat = roundTo(revenuePerSeatSum/totalWeight, 2)
+	suggestion.Confidence = roundTo(confidence, 2)
+	suggestion.ConfidenceLevel = pricingConfidenceLevel(confidence)
+
+	switch {
+	case adjustment > 0.005:
+		suggestion.Reason = fmt.Sprintf("Sold %.0f%% of seats across %d past event(s), above the %.0f%% target, so the price can go up",
+			sellThrough*100, len(sellThroughs), pricingTargetSellRate*100)
+	case adjustment < -0.005:
+		suggestion.Reason = fmt.Sprintf("Sold %.0f%% of seats across %d past event(s), below the %.0f%% target, so the price should come down",
+			sellThrough*100, len(sellThroughs), pricingTargetSellRate*100)
+	default:
+		suggestion.Reason = fmt.Sprintf("Sold %.0f%% of seats across %d past event(s), on target, so the historical multiplier holds",
+			sellThrough*100, len(sellThroughs))
+	}
+
+	return suggestion
+}
+
+func pricingConfidenceLevel(confidence float64) PricingConfidenceLevel {
+	switch {
+	case confidence >= 0.7:
+		return PricingConfidenceHigh
+	case confidence >= 0.4:
+		return PricingConfidenceMedium
+	default:
+		return PricingConfidenceLow
+	}
+}
+
+func splitTagNames(tags string) []string {
+	names := []string{}
+	for _, tag := range strings.Split(tags, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			names = append(names, tag)
+		}
+	}
+	return names
+}
+
+func stdDev(values []float64) float64 {
+	if len(values) < 2 {
+		return 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(len(values))
+
+	var variance float64
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+	return math.Sqrt(variance / float64(len(values)))
+}
+
+func roundTo(value float64, places int) float64 {
+	scale := math.Pow(10, float64(places))
+	return math.Round(value*scale) / scale
+}
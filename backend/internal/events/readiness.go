@@ -0,0 +1,167 @@
+package events
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ReadinessSeverity string
+
+const (
+	ReadinessBlocker ReadinessSeverity = "BLOCKER" // The event must not go live until this is fixed
+	ReadinessWarning ReadinessSeverity = "WARNING" // The event can go live, but organizers should review it
+)
+
+// Readiness check codes, stable so clients can map them to guidance
+const (
+	ReadinessDateInPast           = "DATE_IN_PAST"
+	ReadinessDateSoon             = "DATE_WITHIN_48H"
+	ReadinessVenueHasNoSeats      = "VENUE_HAS_NO_SEATS"
+	ReadinessSectionPricing       = "SECTION_PRICING_MISSING"
+	ReadinessNoCancellationPolicy = "CANCELLATION_POLICY_MISSING"
+	ReadinessImageMissing         = "IMAGE_MISSING"
+)
+
+// publishSoonWindow is how close to the event date publishing is flagged
+const publishSoonWindow = 48 * time.Hour
+
+// ReadinessCheck is one item of the publish checklist
+type ReadinessCheck struct {
+	Code       string            `json:"code"`
+	Severity   ReadinessSeverity `json:"severity"`
+	Message    string            `json:"message"`
+	SectionIDs []string          `json:"section_ids,omitempty"` // Sections the check refers to
+}
+
+// EventReadiness lists what stops an event from being published
+type EventReadiness struct {
+	EventID   string           `json:"event_id"`
+	Ready     bool             `json:"ready"` // No blockers
+	Blockers  []ReadinessCheck `json:"blockers"`
+	Warnings  []ReadinessCheck `json:"warnings"`
+	CheckedAt time.Time        `json:"checked_at"`
+}
+
+// ReadinessSection is a venue section of the event with its seat count and pricing state
+type ReadinessSection struct {
+	ID        uuid.UUID
+	Name      string
+	SeatCount int
+	Priced    bool
+}
+
+// readinessFacts is everything the publish gate looks at
+type readinessFacts struct {
+	Event                 *Event
+	Sections              []ReadinessSection
+	HasCancellationPolicy bool
+}
+
+// checkPublishReadiness is the publish gate: it returns the blockers and
+// warnings for an event in a stable order
+func checkPublishReadiness(facts readinessFacts, now time.Time) (blockers, warnings []ReadinessCheck) {
+	event := facts.Event
+
+	if !event.DateTime.After(now) {
+		blockers = append(blockers, ReadinessCheck{
+			Code:     ReadinessDateInPast,
+			Severity: ReadinessBlocker,
+			Message:  "Event date is in the past",
+		})
+	} else if event.DateTime.Sub(now) < publishSoonWindow {
+		warnings = append(warnings, ReadinessCheck{
+			Code:     ReadinessDateSoon,
+			Severity: ReadinessWarning,
+			Message:  fmt.Sprintf("Event starts within %d hours, attendees will have little time to book", int(publishSoonWindow.Hours())),
+		})
+	}
+
+	totalSeats := 0
+	var unpriced []string
+	var unpricedNames []string
+	for _, section := range facts.Sections {
+		totalSeats += section.SeatCount
+		if !section.Priced {
+			unpriced = append(unpriced, section.ID.String())
+			unpricedNames = append(unpricedNames, section.Name)
+		}
+	}
+
+	if totalSeats == 0 {
+		blockers = append(blockers, ReadinessCheck{
+			Code:     ReadinessVenueHasNoSeats,
+			Severity: ReadinessBlocker,
+			Message:  "Venue template has no seats",
+		})
+	}
+
+	if len(unpriced) > 0 {
+		blockers = append(blockers, ReadinessCheck{
+			Code:       ReadinessSectionPricing,
+			Severity:   ReadinessBlocker,
+			Message:    fmt.Sprintf("No pricing for %d section(s): %v", len(unpriced), unpricedNames),
+			SectionIDs: unpriced,
+		})
+	}
+
+	if !facts.HasCancellationPolicy {
+		warnings = append(warnings, ReadinessCheck{
+			Code:     ReadinessNoCancellationPolicy,
+			Severity: ReadinessWarning,
+			Message:  "No cancellation policy, attendees will get the platform default",
+		})
+	}
+
+	if event.ImageURL == "" {
+		warnings = append(warnings, ReadinessCheck{
+			Code:     ReadinessImageMissing,
+			Severity: ReadinessWarning,
+			Message:  "Event has no image",
+		})
+	}
+
+	return blockers, warnings
+}
+
+func (s *service) GetEventReadiness(eventID uuid.UUID) (*EventReadiness, error) {
+	event, err := s.repo.GetByID(eventID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("event not found")
+		}
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	sections, err := s.repo.GetReadinessSections(eventID, event.VenueTemplateID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get venue sections: %w", err)
+	}
+
+	hasPolicy, err := s.repo.HasCancellationPolicy(eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cancellation policy: %w", err)
+	}
+
+	now := time.Now()
+	blockers, warnings := checkPublishReadiness(readinessFacts{
+		Event:                 event,
+		Sections:              sections,
+		HasCancellationPolicy: hasPolicy,
+	}, now)
+
+	readiness := &EventReadiness{
+		EventID:   eventID.String(),
+		Ready:     len(blockers) == 0,
+		Blockers:  []ReadinessCheck{},
+		Warnings:  []ReadinessCheck{},
+		CheckedAt: now,
+	}
+	readiness.Blockers = append(readiness.Blockers, blockers...)
+	readiness.Warnings = append(readiness.Warnings, warnings...)
+
+	return readiness, nil
+}
@@ -1,11 +1,12 @@
 package events
 
 import (
-	"evently/internal/tags"
 	"fmt"
 	"strings"
 	"time"
 
+	"evently/internal/shared/dbresolver"
+
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
@@ -18,18 +19,37 @@ type Repository interface {
 	GetAll(query EventListQuery) ([]Event, int64, error)
 	GetByStatus(status EventStatus) ([]Event, error)
 	GetEventCapacityAndBookings(eventID uuid.UUID) (int, int, error)
+	CountConfirmedBookings(eventID uuid.UUID) (int64, error)
+	GetSectionAvailability(eventID, templateID uuid.UUID) ([]SectionAvailability, error)
 	GetEventAnalytics(eventID uuid.UUID) (*EventAnalytics, error)
 	GetGlobalAnalytics() (*GlobalAnalytics, error)
 	GetUpcomingEvents(limit int) ([]Event, error)
 	CheckSeatAvailability(eventID uuid.UUID, requestedSeats int) (bool, error)
+	Clone(source *Event, clone *Event, pricingOverrides map[uuid.UUID]float64) error
+	GetReadinessSections(eventID, templateID uuid.UUID) ([]ReadinessSection, error)
+	HasCancellationPolicy(eventID uuid.UUID) (bool, error)
+	GetSitemapEvents(now time.Time, limit int) ([]Event, error)
+	GetPricingHistoryEvents(templateID uuid.UUID, tagNames []string, before time.Time, limit int) ([]PricingHistoryEvent, error)
+	GetPricingHistorySections(eventIDs []uuid.UUID, templateID uuid.UUID) ([]PricingHistorySection, error)
+	GetVenueSchedule(templateID uuid.UUID) (*VenueSchedule, error)
+	FindVenueConflicts(schedule *VenueSchedule, templateID uuid.UUID, start, end time.Time, excludeID uuid.UUID) ([]VenueConflictEvent, error)
+	CreateVenueConflictOverrides(overrides []VenueConflictOverride) error
+	GetVenueConflictOverrides(eventID uuid.UUID) ([]VenueConflictOverride, error)
+	TransitionStatus(id uuid.UUID, from, to EventStatus, updatedBy *uuid.UUID) (*Event, error)
+	GetEndedEvents(now time.Time, limit int) ([]Event, error)
+	GetStartedEvents(now time.Time, limit int) ([]Event, error)
+	GetAvailabilityChanges(now time.Time, limit int) ([]EventAvailability, error)
+	GetDrafts() ([]Event, error)
+	GetDueDrafts(now time.Time, limit int) ([]Event, error)
 }
 
 type repository struct {
-	db *gorm.DB
+	db   *gorm.DB
+	read *gorm.DB // Listings and analytics, served by a read replica when one is configured
 }
 
 func NewRepository(db *gorm.DB) Repository {
-	return &repository{db: db}
+	return &repository{db: db, read: dbresolver.ReadDB(db)}
 }
 
 func (r *repository) Create(event *Event) error {
@@ -66,28 +86,84 @@ func (r *repository) Update(id uuid.UUID, updates map[string]interface{}) (*Even
 	return &event, nil
 }
 
-func (r *repository) Delete(id uuid.UUID) error {
+// Clone creates the clone event and copies the source's section pricing, tags and
+// cancellation policy in one transaction. When the clone uses a different venue
+// template, pricing is carried over to sections with the same name. The policy
+// deadline keeps the same offset from the event date.
+func (r *repository) Clone(source *Event, clone *Event, pricingOverrides map[uuid.UUID]float64) error {
 	return r.db.Transaction(func(tx *gorm.DB) error {
-		// First, delete all event-tag associations
-		if err := tx.Where("event_id = ?", id).Delete(&tags.EventTag{}).Error; err != nil {
-			return fmt.Errorf("failed to delete event-tag associations: %w", err)
+		if err := tx.Create(clone).Error; err != nil {
+			return fmt.Errorf("failed to create event: %w", err)
 		}
 
-		// Then delete the event itself
-		if err := tx.Where("id = ?", id).Delete(&Event{}).Error; err != nil {
-			return fmt.Errorf("failed to delete event: %w", err)
+		var err error
+		if clone.VenueTemplateID == source.VenueTemplateID {
+			err = tx.Exec(`
+				INSERT INTO event_pricing (id, event_id, section_id, price_multiplier, is_active, created_at, updated_at)
+				SELECT uuid_generate_v4(), ?, section_id, price_multiplier, is_active, NOW(), NOW()
+				FROM event_pricing
+				WHERE event_id = ?`, clone.ID, source.ID).Error
+		} else {
+			err = tx.Exec(`
+				INSERT INTO event_pricing (id, event_id, section_id, price_multiplier, is_active, created_at, updated_at)
+				SELECT uuid_generate_v4(), ?, ns.id, ep.price_multiplier, ep.is_active, NOW(), NOW()
+				FROM event_pricing ep
+				JOIN venue_sections os ON os.id = ep.section_id
+				JOIN venue_sections ns ON ns.template_id = ? AND ns.name = os.name
+				WHERE ep.event_id = ?`, clone.ID, clone.VenueTemplateID, source.ID).Error
+		}
+		if err != nil {
+			return fmt.Errorf("failed to copy event pricing: %w", err)
+		}
+
+		for sectionID, multiplier := range pricingOverrides {
+			if err := tx.Exec("DELETE FROM event_pricing WHERE event_id = ? AND section_id = ?", clone.ID, sectionID).Error; err != nil {
+				return fmt.Errorf("failed to override pricing for section %s: %w", sectionID, err)
+			}
+			err := tx.Exec(`
+				INSERT INTO event_pricing (id, event_id, section_id, price_multiplier, is_active, created_at, updated_at)
+				VALUES (uuid_generate_v4(), ?, ?, ?, true, NOW(), NOW())`, clone.ID, sectionID, multiplier).Error
+			if err != nil {
+				return fmt.Errorf("failed to override pricing for section %s: %w", sectionID, err)
+			}
+		}
+
+		if err := tx.Exec(`
+			INSERT INTO event_tags (id, event_id, tag_id, created_at)
+			SELECT uuid_generate_v4(), ?, tag_id, NOW()
+			FROM event_tags
+			WHERE event_id = ?`, clone.ID, source.ID).Error; err != nil {
+			return fmt.Errorf("failed to copy event tags: %w", err)
+		}
+
+		shiftSeconds := int64(clone.DateTime.Sub(source.DateTime).Seconds())
+		if err := tx.Exec(`
+			INSERT INTO cancellation_policies (id, event_id, allow_cancellation, cancellation_deadline, fee_type, fee_amount, refund_processing_days, created_at, updated_at)
+			SELECT uuid_generate_v4(), ?, allow_cancellation, cancellation_deadline + (? * INTERVAL '1 second'), fee_type, fee_amount, refund_processing_days, NOW(), NOW()
+			FROM cancellation_policies
+			WHERE event_id = ?`, clone.ID, shiftSeconds, source.ID).Error; err != nil {
+			return fmt.Errorf("failed to copy cancellation policy: %w", err)
 		}
 
 		return nil
 	})
 }
 
+// Delete soft-deletes the event. Tag associations are kept so an admin restore
+// brings the event back intact; purging removes them.
+func (r *repository) Delete(id uuid.UUID) error {
+	if err := r.db.Where("id = ?", id).Delete(&Event{}).Error; err != nil {
+		return fmt.Errorf("failed to delete event: %w", err)
+	}
+	return nil
+}
+
 func (r *repository) GetAll(query EventListQuery) ([]Event, int64, error) {
 	var events []Event
 	var totalCount int64
 
 	// Build the query
-	db := r.db.Model(&Event{})
+	db := r.read.Model(&Event{})
 
 	// Apply filters
 	if query.Search != "" {
@@ -100,8 +176,11 @@ func (r *repository) GetAll(query EventListQuery) ([]Event, int64, error) {
 		db = db.Where("LOWER(venue) LIKE ?", "%"+strings.ToLower(query.Venue)+"%")
 	}
 
+	// Drafts are only seen by admins, through previews
 	if query.Status != "" {
 		db = db.Where("status = ?", query.Status)
+	} else {
+		db = db.Where("status <> ?", EventStatusDraft)
 	}
 
 	if query.Tags != "" {
@@ -114,11 +193,20 @@ func (r *repository) GetAll(query EventListQuery) ([]Event, int64, error) {
 			}
 		}
 		if len(cleanTags) > 0 {
-			// Join with event_tags and tags table to filter by tag names
-			subquery := r.db.Table("event_tags").
-				Joins("JOIN tags ON event_tags.tag_id = tags.id").
-				Where("tags.name IN ? AND tags.is_active = ?", cleanTags, true).
-				Select("event_tags.event_id")
+			// Match events tagged with the named tags or any tag below them,
+			// so filtering by Music also finds Jazz events
+			subquery := r.db.Raw(`
+				WITH RECURSIVE tag_tree AS (
+					SELECT id FROM tags
+					WHERE name IN ? AND is_active = true AND deleted_at IS NULL
+					UNION
+					SELECT t.id FROM tags t
+					JOIN tag_tree tt ON t.parent_id = tt.id
+					WHERE t.is_active = true AND t.deleted_at IS NULL
+				)
+				SELECT event_tags.event_id FROM event_tags
+				WHERE event_tags.tag_id IN (SELECT id FROM tag_tree)
+			`, cleanTags)
 
 			db = db.Where("id IN (?)", subquery)
 		}
@@ -202,6 +290,29 @@ func (r *repository) GetEventCapacityAndBookings(eventID uuid.UUID) (int, int, e
 	return int(totalCapacity), int(bookedCount), nil
 }
 
+// CountConfirmedBookings counts confirmed bookings of any kind, seated or general admission
+func (r *repository) CountConfirmedBookings(eventID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.Table("bookings").
+		Where("event_id = ? AND status = ?", eventID, "CONFIRMED").
+		Count(&count).Error
+	return count, err
+}
+
+// GetSectionAvailability counts the unbooked, unblocked seats left in each section of the template
+func (r *repository) GetSectionAvailability(eventID, templateID uuid.UUID) ([]SectionAvailability, error) {
+	var sections []SectionAvailability
+	err := r.db.Table("venue_sections vs").
+		Select(`vs.id, vs.name,
+			(SELECT COUNT(*) FROM seats
+				LEFT JOIN seat_bookings sb ON sb.seat_id = seats.id AND sb.event_id = ?
+				WHERE seats.section_id = vs.id AND seats.status = 'AVAILABLE' AND sb.id IS NULL) AS available`, eventID).
+		Where("vs.template_id = ?", templateID).
+		Order("vs.name ASC").
+		Scan(&sections).Error
+	return sections, err
+}
+
 func (r *repository) CheckSeatAvailability(eventID uuid.UUID, requestedSeats int) (bool, error) {
 	// First get the event's venue template ID
 	var event Event
@@ -228,7 +339,7 @@ func (r *repository) GetEventAnalytics(eventID uuid.UUID) (*EventAnalytics, erro
 
 	// Get basic event info
 	var event Event
-	if err := r.db.Where("id = ?", eventID).First(&event).Error; err != nil {
+	if err := r.read.Where("id = ?", eventID).First(&event).Error; err != nil {
 		return nil, err
 	}
 
@@ -245,7 +356,7 @@ func (r *repository) GetEventAnalytics(eventID uuid.UUID) (*EventAnalytics, erro
 
 	// Calculate revenue from actual seat bookings
 	var totalRevenue float64
-	err = r.db.Table("seat_bookings").
+	err = r.read.Table("seat_bookings").
 		Joins("JOIN bookings ON seat_bookings.booking_id = bookings.id").
 		Select("COALESCE(SUM(seat_bookings.seat_price), 0) as total_revenue").
 		Where("seat_bookings.event_id = ? AND bookings.status = 'CONFIRMED'", eventID).
@@ -272,7 +383,8 @@ func (r *repository) GetUpcomingEvents(limit int) ([]Event, error) {
 	var events []Event
 	now := time.Now()
 
-	err := r.db.Where("date_time > ? AND status = ?", now, EventStatusPublished).
+	// Unlisted events are kept out of public feeds
+	err := r.read.Where("date_time > ? AND status IN ? AND unlisted = ?", now, onSaleStatuses, false).
 		Order("date_time ASC").
 		Limit(limit).
 		Find(&events).Error
@@ -285,7 +397,7 @@ func (r *repository) GetGlobalAnalytics() (*GlobalAnalytics, error) {
 
 	// Get total events count
 	var totalEvents int64
-	if err := r.db.Model(&Event{}).Count(&totalEvents).Error; err != nil {
+	if err := r.read.Model(&Event{}).Count(&totalEvents).Error; err != nil {
 		return nil, fmt.Errorf("failed to count total events: %w", err)
 	}
 	analytics.TotalEvents = int(totalEvents)
@@ -297,7 +409,7 @@ func (r *repository) GetGlobalAnalytics() (*GlobalAnalytics, error) {
 	}
 
 	var result aggregateResult
-	if err := r.db.Table("seat_bookings").
+	if err := r.read.Table("seat_bookings").
 		Joins("JOIN bookings ON seat_bookings.booking_id = bookings.id").
 		Select("COUNT(seat_bookings.id) as total_bookings, COALESCE(SUM(seat_bookings.seat_price), 0) as total_revenue").
 		Where("bookings.status = 'CONFIRMED'").
@@ -362,7 +474,7 @@ func (r *repository) GetGlobalAnalytics() (*GlobalAnalytics, error) {
 	}
 
 	var popularEventsData []popularEventData
-	if err := r.db.Table("events e").
+	if err := r.read.Table("events e").
 		Select(`
 			e.id as event_id,
 			e.name as event_name,
@@ -372,7 +484,7 @@ func (r *repository) GetGlobalAnalytics() (*GlobalAnalytics, error) {
 		`).
 		Joins(`
 			LEFT JOIN (
-				SELECT vs.event_id, COUNT(sb.id) as booking_count, SUM(sb.seat_price) as revenue
+				SELECT vs.event_id, COUNT(sb.id) as booking_count, SUM(sb.seat_price * b.exchange_rate) as revenue
 				FROM seat_bookings sb
 				JOIN bookings b ON sb.booking_id = b.id
 				JOIN venue_sections vs ON sb.section_id = vs.id
@@ -418,7 +530,7 @@ func (r *repository) GetGlobalAnalytics() (*GlobalAnalytics, error) {
 	}
 
 	var statusCounts []statusCount
-	if err := r.db.Model(&Event{}).
+	if err := r.read.Model(&Event{}).
 		Select("status, COUNT(*) as count").
 		Group("status").
 		Scan(&statusCounts).Error; err != nil {
@@ -437,10 +549,10 @@ func (r *repository) GetGlobalAnalytics() (*GlobalAnalytics, error) {
 	}
 
 	var monthlyRevenues []monthlyRevenueResult
-	if err := r.db.Table("bookings b").
+	if err := r.read.Table("bookings b").
 		Select(`
 			TO_CHAR(b.created_at, 'YYYY-MM') as month,
-			COALESCE(SUM(sb.seat_price), 0) as revenue,
+			COALESCE(SUM(sb.seat_price * b.exchange_rate), 0) as revenue,
 			COUNT(DISTINCT vs.event_id) as events
 		`).
 		Joins("JOIN seat_bookings sb ON b.id = sb.booking_id").
@@ -467,3 +579,269 @@ func (r *repository) GetGlobalAnalytics() (*GlobalAnalytics, error) {
 
 	return &analytics, nil
 }
+
+// GetReadinessSections returns the sections of the event's venue template with
+// their generated seat count and whether the event has active pricing for them
+func (r *repository) GetReadinessSections(eventID, templateID uuid.UUID) ([]ReadinessSection, error) {
+	var sections []ReadinessSection
+	err := r.db.Table("venue_sections vs").
+		Select(`vs.id, vs.name,
+			(SELECT COUNT(*) FROM seats WHERE seats.section_id = vs.id) AS seat_count,
+			EXISTS (SELECT 1 FROM event_pricing ep WHERE ep.event_id = ? AND ep.section_id = vs.id AND ep.is_active = true) AS priced`, eventID).
+		Where("vs.template_id = ?", templateID).
+		Order("vs.name ASC").
+		Scan(&sections).Error
+	return sections, err
+}
+
+func (r *repository) HasCancellationPolicy(eventID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.Table("cancellation_policies").
+		Where("event_id = ?", eventID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// GetSitemapEvents returns upcoming events on sale that crawlers may list and index
+func (r *repository) GetSitemapEvents(now time.Time, limit int) ([]Event, error) {
+	var events []Event
+	err := r.read.Select("id, updated_at").
+		Where("date_time > ? AND status IN ? AND unlisted = ? AND no_index = ?", now, onSaleStatuses, false, false).
+		Order("date_time ASC").
+		Limit(limit).
+		Find(&events).Error
+	return events, err
+}
+
+// GetPricingHistoryEvents returns past, non-cancelled events on a venue template,
+// those sharing the most tags first, then the most recent
+func (r *repository) GetPricingHistoryEvents(templateID uuid.UUID, tagNames []string, before time.Time, limit int) ([]PricingHistoryEvent, error) {
+	sharedTags := "0"
+	args := []interface{}{}
+	if len(tagNames) > 0 {
+		sharedTags = `(SELECT COUNT(*) FROM event_tags
+			JOIN tags ON event_tags.tag_id = tags.id
+			WHERE event_tags.event_id = events.id AND tags.name IN ? AND tags.deleted_at IS NULL)`
+		args = append(args, tagNames)
+	}
+
+	var events []PricingHistoryEvent
+	err := r.db.Model(&Event{}).
+		Select("events.id, events.name, events.date_time, "+sharedTags+" AS shared_tags", args...).
+		Where("events.venue_template_id = ? AND events.date_time < ? AND events.status <> ?", templateID, before, EventStatusCancelled).
+		Order("shared_tags DESC, events.date_time DESC").
+		Limit(limit).
+		Scan(&events).Error
+	return events, err
+}
+
+// GetPricingHistorySections returns each section's multiplier, capacity and confirmed sales for past events
+func (r *repository) GetPricingHistorySections(eventIDs []uuid.UUID, templateID uuid.UUID) ([]PricingHistorySection, error) {
+	var sections []PricingHistorySection
+	err := r.db.Table("events").
+		Select(`events.id AS event_id, venue_sections.id AS section_id,
+			(SELECT COUNT(*) FROM seats WHERE seats.section_id = venue_sections.id) AS capacity,
+			COALESCE(event_pricing.price_multiplier, 0) AS price_multiplier,
+			COALESCE(sold.seats_sold, 0) AS seats_sold,
+			COALESCE(sold.revenue, 0) AS revenue`).
+		Joins("JOIN venue_sections ON venue_sections.template_id = ?", templateID).
+		Joins("LEFT JOIN event_pricing ON event_pricing.event_id = events.id AND event_pricing.section_id = venue_sections.id AND event_pricing.is_active = true").
+		Joins(`LEFT JOIN (
+			SELECT seat_bookings.event_id, seat_bookings.section_id, COUNT(*) AS seats_sold, SUM(seat_bookings.seat_price) AS revenue
+			FROM seat_bookings
+			JOIN bookings ON seat_bookings.booking_id = bookings.id
+			WHERE seat_bookings.event_id IN ? AND bookings.status = 'CONFIRMED'
+			GROUP BY seat_bookings.event_id, seat_bookings.section_id
+		) sold ON sold.event_id = events.id AND sold.section_id = venue_sections.id`, eventIDs).
+		Where("events.id IN ?", eventIDs).
+		Scan(&sections).Error
+	return sections, err
+}
+
+// GetVenueSchedule returns the physical venue a template is used in and how its events are spaced
+func (r *repository) GetVenueSchedule(templateID uuid.UUID) (*VenueSchedule, error) {
+	var schedule VenueSchedule
+	err := r.db.Table("venue_templates").
+		Select(`physical_venues.id AS physical_venue_id,
+			COALESCE(physical_venues.default_duration_minutes, 0) AS default_duration_minutes,
+			COALESCE(physical_venues.changeover_minutes, 0) AS changeover_minutes`).
+		Joins("LEFT JOIN physical_venues ON physical_venues.id = venue_templates.physical_venue_id AND physical_venues.deleted_at IS NULL").
+		Where("venue_templates.id = ?", templateID).
+		Take(&schedule).Error
+	if err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+// FindVenueConflicts returns live events at the same venue whose time, changeover
+// included, overlaps [start, end). Without a physical venue only the template itself is checked.
+func (r *repository) FindVenueConflicts(schedule *VenueSchedule, templateID uuid.UUID, start, end time.Time, excludeID uuid.UUID) ([]VenueConflictEvent, error) {
+	defaultDuration := schedule.DefaultDurationMinutes
+	if defaultDuration == 0 {
+		defaultDuration = defaultEventDurationMinutes
+	}
+
+	query := r.db.Model(&Event{}).
+		Select("id, name, venue_template_id, date_time, duration_minutes").
+		Where("id <> ? AND status <> ?", excludeID, EventStatusCancelled)
+
+	if schedule.PhysicalVenueID != nil {
+		query = query.Where("venue_template_id IN (SELECT id FROM venue_templates WHERE physical_venue_id = ?)", *schedule.PhysicalVenueID)
+	} else {
+		query = query.Where("venue_template_id = ?", templateID)
+	}
+
+	var events []VenueConflictEvent
+	err := query.
+		Where("date_time < ?", end).
+		Where("date_time + (COALESCE(NULLIF(duration_minutes, 0), ?) + ?) * INTERVAL '1 minute' > ?", defaultDuration, schedule.ChangeoverMinutes, start).
+		Order("date_time ASC").
+		Scan(&events).Error
+	return events, err
+}
+
+func (r *repository) CreateVenueConflictOverrides(overrides []VenueConflictOverride) error {
+	return r.db.Create(&overrides).Error
+}
+
+func (r *repository) GetVenueConflictOverrides(eventID uuid.UUID) ([]VenueConflictOverride, error) {
+	var overrides []VenueConflictOverride
+	err := r.db.Where("event_id = ?", eventID).
+		Order("created_at DESC").
+		Find(&overrides).Error
+	return overrides, err
+}
+
+// TransitionStatus moves an event from one status to another. It returns
+// ErrStatusChanged when the event no longer has the from status, so a
+// transition decided on a stale read is never applied.
+func (r *repository) TransitionStatus(id uuid.UUID, from, to EventStatus, updatedBy *uuid.UUID) (*Event, error) {
+	updates := map[string]interface{}{
+		"status":     to,
+		"updated_at": time.Now(),
+	}
+	if updatedBy != nil {
+		updates["updated_by"] = *updatedBy
+	}
+
+	result := r.db.Model(&Event{}).
+		Where("id = ? AND status = ?", id, from).
+		Updates(updates)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrStatusChanged
+	}
+
+	var event Event
+	if err := r.db.Where("id = ?", id).First(&event).Error; err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// eventEndSQL is when an event ends: its own duration, else its physical
+// venue's default, else the default duration
+const eventEndSQL = `events.date_time + COALESCE(NULLIF(events.duration_minutes, 0), NULLIF(physical_venues.default_duration_minutes, 0), ?) * INTERVAL '1 minute'`
+
+// GetEndedEvents returns events that are still live but whose end has passed
+func (r *repository) GetEndedEvents(now time.Time, limit int) ([]Event, error) {
+	var events []Event
+	err := r.db.Model(&Event{}).
+		Select("events.*").
+		Joins("LEFT JOIN venue_templates ON venue_templates.id = events.venue_template_id").
+		Joins("LEFT JOIN physical_venues ON physical_venues.id = venue_templates.physical_venue_id AND physical_venues.deleted_at IS NULL").
+		Where("events.status IN ?", []EventStatus{EventStatusPublished, EventStatusSoldOut, EventStatusOngoing}).
+		Where(eventEndSQL+" <= ?", defaultEventDurationMinutes, now).
+		Order("events.date_time ASC").
+		Limit(limit).
+		Find(&events).Error
+	return events, err
+}
+
+// GetStartedEvents returns events on sale whose start has passed
+func (r *repository) GetStartedEvents(now time.Time, limit int) ([]Event, error) {
+	var events []Event
+	err := r.db.Where("status IN ? AND date_time <= ?", onSaleStatuses, now).
+		Order("date_time ASC").
+		Limit(limit).
+		Find(&events).Error
+	return events, err
+}
+
+// GetAvailabilityChanges returns upcoming events whose status no longer matches
+// what is left to sell: published events with nothing left and sold out
+// events that have seats or tickets again. Seats held back by a block and
+// seats or tickets in a pending booking count as taken.
+func (r *repository) GetAvailabilityChanges(now time.Time, limit int) ([]EventAvailability, error) {
+	var events []EventAvailability
+	err := r.db.Raw(`
+		SELECT * FROM (
+			SELECT
+				e.id AS event_id,
+				e.status,
+				COALESCE(st.total, 0) + COALESCE(tt.total, 0) AS capacity,
+				COALESCE(st.remaining, 0) + COALESCE(tt.remaining, 0) AS remaining
+			FROM events e
+			LEFT JOIN LATERAL (
+				SELECT
+					COUNT(*) AS total,
+					COUNT(*) FILTER (WHERE s.status = 'AVAILABLE'
+						AND NOT EXISTS (
+							SELECT 1 FROM seat_bookings sb
+							JOIN bookings b ON b.id = sb.booking_id AND b.status <> 'CANCELLED'
+							WHERE sb.event_id = e.id AND sb.seat_id = s.id
+						)
+						AND NOT EXISTS (
+							SELECT 1 FROM event_seat_blocks bl
+							WHERE bl.event_id = e.id AND bl.seat_id = s.id AND bl.released_at IS NULL
+						)
+					) AS remaining
+				FROM seats s
+				JOIN venue_sections vs ON vs.id = s.section_id
+				WHERE vs.template_id = e.venue_template_id
+			) st ON TRUE
+			LEFT JOIN LATERAL (
+				SELECT
+					SUM(t.capacity) AS total,
+					SUM(GREATEST(t.capacity - COALESCE(sold.quantity, 0), 0)) AS remaining
+				FROM ticket_types t
+				LEFT JOIN (
+					SELECT tb.ticket_type_id, SUM(tb.quantity) AS quantity
+					FROM ticket_bookings tb
+					JOIN bookings b ON b.id = tb.booking_id AND b.status <> 'CANCELLED'
+					WHERE tb.event_id = e.id
+					GROUP BY tb.ticket_type_id
+				) sold ON sold.ticket_type_id = t.id
+				WHERE t.event_id = e.id
+			) tt ON TRUE
+			WHERE e.status IN ? AND e.deleted_at IS NULL AND e.date_time > ?
+		) availability
+		WHERE (status = ? AND capacity > 0 AND remaining = 0)
+			OR (status = ? AND remaining > 0)
+		LIMIT ?
+	`, onSaleStatuses, now, EventStatusPublished, EventStatusSoldOut, limit).Scan(&events).Error
+	return events, err
+}
+
+// GetDrafts returns every draft, those scheduled to publish first
+func (r *repository) GetDrafts() ([]Event, error) {
+	var events []Event
+	err := r.db.Where("status = ?", EventStatusDraft).
+		Order("publish_at ASC NULLS LAST, date_time ASC").
+		Find(&events).Error
+	return events, err
+}
+
+// GetDueDrafts returns drafts whose publish time has come. Drafts whose event
+// date has also passed are left for an admin to deal with.
+func (r *repository) GetDueDrafts(now time.Time, limit int) ([]Event, error) {
+	var events []Event
+	err := r.db.Where("status = ? AND publish_at <= ? AND date_time > ?", EventStatusDraft, now, now).
+		Order("publish_at ASC").
+		Limit(limit).
+		Find(&events).Error
+	return events, err
+}
@@ -9,27 +9,46 @@ import (
 func SetupEventRoutes(router *gin.RouterGroup, controller Controller) {
 	// Public routes - anyone can view events (for browsing)
 	publicEvents := router.Group("/events")
+	publicEvents.Use(middleware.OptionalAPIKey(middleware.ScopeEventsRead)) // Partners identify themselves for usage metering
 	{
-		publicEvents.GET("", controller.GetAllEvents)               // GET /api/v1/events - Browse all events
-		publicEvents.GET("/:eventId", controller.GetEvent)          // GET /api/v1/events/:eventId - Get event details
-		publicEvents.GET("/upcoming", controller.GetUpcomingEvents) // GET /api/v1/events/upcoming - Browse upcoming events
+		publicEvents.GET("", middleware.OptionalJWTAuth(), controller.GetAllEvents) // GET /api/v1/events - Browse all events (flags favorites when signed in)
+		publicEvents.GET("/:eventId", controller.GetEvent)                          // GET /api/v1/events/:eventId - Get event details
+		publicEvents.GET("/upcoming", controller.GetUpcomingEvents)                 // GET /api/v1/events/upcoming - Browse upcoming events
+		publicEvents.GET("/sitemap.xml", controller.GetSitemap)                     // GET /api/v1/events/sitemap.xml - Sitemap of public events for crawlers
 	}
 
 	// Admin routes - only admins can create, update, delete and manage events
 	adminEvents := router.Group("/admin/events")
-	adminEvents.Use(middleware.JWTAuth(), middleware.RequireAdmin()) // Only admin users
+	adminEvents.Use(middleware.JWTAuth(), middleware.RequirePermission(middleware.PermissionEventsWrite))
 	{
 		// Event management - Admin only
-		adminEvents.POST("", controller.CreateEvent)            // POST /api/v1/admin/events - Create event
-		adminEvents.PUT("/:eventId", controller.UpdateEvent)    // PUT /api/v1/admin/events/:eventId - Update event
-		adminEvents.DELETE("/:eventId", controller.DeleteEvent) // DELETE /api/v1/admin/events/:eventId - Delete event
+		adminEvents.POST("", controller.CreateEvent)               // POST /api/v1/admin/events - Create event
+		adminEvents.PUT("/:eventId", controller.UpdateEvent)       // PUT /api/v1/admin/events/:eventId - Update event
+		adminEvents.DELETE("/:eventId", controller.DeleteEvent)    // DELETE /api/v1/admin/events/:eventId - Delete event
+		adminEvents.POST("/:eventId/clone", controller.CloneEvent) // POST /api/v1/admin/events/:eventId/clone - Clone event
+
+		// Publish checklist - Admin only
+		adminEvents.GET("/:eventId/readiness", controller.GetEventReadiness) // GET /api/v1/admin/events/:eventId/readiness - What's blocking publish
+
+		// Drafts and previews - Admin only, drafts are hidden from public endpoints
+		adminEvents.GET("/drafts", controller.GetDraftEvents)         // GET /api/v1/admin/events/drafts - Drafts, scheduled ones first
+		adminEvents.GET("/:eventId/preview", controller.PreviewEvent) // GET /api/v1/admin/events/:eventId/preview - Event detail, drafts included
+
+		// Venue double-booking guard - Admin only
+		adminEvents.GET("/:eventId/venue-conflicts", controller.GetVenueConflicts) // GET /api/v1/admin/events/:eventId/venue-conflicts - Overlapping events and overrides
+
+		// On-sale live ops - Admin only
+		adminEvents.GET("/:eventId/onsale-live", controller.GetOnSaleLive) // GET /api/v1/admin/events/:eventId/onsale-live - Last minute of holds, bookings and latency
+
+		// Pricing assist - Admin only
+		adminEvents.GET("/pricing-suggestions", controller.GetPricingSuggestions) // GET /api/v1/admin/events/pricing-suggestions - Section multipliers from past sales
 
 		// Event analytics - Admin only
 		adminEvents.GET("/analytics", controller.GetAllEventAnalytics)       // GET /api/v1/admin/events/analytics - Overall analytics
 		adminEvents.GET("/:eventId/analytics", controller.GetEventAnalytics) // GET /api/v1/admin/events/:eventId/analytics - Specific event analytics
 
 		// Admin can also browse events (same endpoints as users)
-		adminEvents.GET("", controller.GetAllEvents)      // GET /api/v1/admin/events - Admin browse events
-		adminEvents.GET("/:eventId", controller.GetEvent) // GET /api/v1/admin/events/:eventId - Admin get event details
+		adminEvents.GET("", controller.GetAllEvents)           // GET /api/v1/admin/events - Admin browse events
+		adminEvents.GET("/:eventId", controller.GetAdminEvent) // GET /api/v1/admin/events/:eventId - Admin get event details, no promotion impressions
 	}
 }
@@ -0,0 +1,33 @@
+package events
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SectionAvailability is the number of seats still open in a section of an event's venue
+type SectionAvailability struct {
+	ID        uuid.UUID
+	Name      string
+	Available int
+}
+
+// GetSectionAvailability returns the seats left in each section of the event's venue
+func (s *service) GetSectionAvailability(eventID uuid.UUID) ([]SectionAvailability, error) {
+	event, err := s.repo.GetByID(eventID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("event not found")
+		}
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	sections, err := s.repo.GetSectionAvailability(eventID, event.VenueTemplateID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get section availability: %w", err)
+	}
+	return sections, nil
+}
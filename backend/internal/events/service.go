@@ -4,26 +4,45 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
 	"math"
 	"reflect"
 	"strings"
 	"time"
 
+	"evently/internal/promotions"
 	"evently/internal/shared/utils/constants"
 	"evently/pkg/cache"
+	"evently/pkg/currency"
+	"evently/pkg/logger"
+	"evently/pkg/metrics"
 
 	"github.com/google/uuid"
+	"golang.org/x/sync/singleflight"
 	"gorm.io/gorm"
 )
 
+// log is the logger of the events module, see LOG_MODULE_LEVELS
+var log = logger.Module("events")
+
 type Service interface {
 	// Service dependency injection
 	SetTagService(tagService TagService)
 	SetVenueService(venueService VenueService)
+	SetPromotionService(promotionService PromotionService)
+	SetBrandingService(brandingService BrandingService)
+	SetRatingService(ratingService RatingService)
+	SetFavoriteService(favoriteService FavoriteService)
 	SetCacheService(cacheService cache.Service)
+	SetCurrencyProvider(provider currency.Provider)
+	SubscribeChanges(listener ChangeListener)
+	SetWebhookPublisher(publisher WebhookPublisher)
+	SetDomainEventPublisher(publisher DomainEventPublisher)
+	SetUpcomingWindowConfig(config *UpcomingWindowConfig)
+	SetSitemapConfig(config *SitemapConfig)
+	SetLifecycleConfig(config *LifecycleConfig)
 	CreateEvent(userID uuid.UUID, req CreateEventRequest) (*EventResponse, error)
-	GetEventByID(id uuid.UUID) (*EventResponse, error)
+	GetEventByID(ctx context.Context, id uuid.UUID) (*EventResponse, error)
+	GetPublicEventByID(ctx context.Context, id uuid.UUID) (*EventResponse, error)
 	// Original methods for backward compatibility
 	UpdateEvent(id uuid.UUID, userID uuid.UUID, req UpdateEventRequest) (*EventResponse, error)
 	DeleteEvent(id uuid.UUID, userID uuid.UUID) error
@@ -31,21 +50,48 @@ type Service interface {
 	// New admin methods
 	UpdateEventAsAdmin(id uuid.UUID, adminID uuid.UUID, req UpdateEventRequest) (*EventResponse, error)
 	DeleteEventAsAdmin(id uuid.UUID, adminID uuid.UUID) error
+	CancelEventAsAdmin(id uuid.UUID, adminID uuid.UUID) (*EventResponse, error)
 	GetEventAnalyticsAsAdmin(eventID uuid.UUID) (*EventAnalytics, error)
 	GetAllEventAnalyticsAsAdmin() (*GlobalAnalytics, error)
+	CloneEventAsAdmin(sourceID uuid.UUID, adminID uuid.UUID, req CloneEventRequest) (*EventResponse, error)
+	GetEventReadiness(eventID uuid.UUID) (*EventReadiness, error)
+	GetPricingSuggestions(query PricingSuggestionQuery) (*PricingSuggestions, error)
+	GetVenueConflicts(eventID uuid.UUID) (*VenueConflictReport, error)
+	GetOnSaleLive(ctx context.Context, eventID uuid.UUID) (*OnSaleLive, error)
+	GetDraftEvents() ([]EventResponse, error)
+	PreviewEvent(ctx context.Context, id uuid.UUID) (*EventResponse, error)
 	// Common methods
-	GetAllEvents(query EventListQuery) (*PaginatedEvents, error)
+	GetAllEvents(ctx context.Context, query EventListQuery) (*PaginatedEvents, error)
 	GetUpcomingEvents(limit int) ([]EventResponse, error)
+	GetSitemap() (*Sitemap, error)
+	RefreshUpcomingWindow(ctx context.Context) error
+	AdvanceLifecycle(ctx context.Context) (*LifecycleRun, error)
+	WarmEventLists(ctx context.Context) (int, error)
 	CheckEventAvailability(eventID uuid.UUID, seatCount int) (bool, error)
 	IsEventInFuture(eventID uuid.UUID) (bool, error)
 	GetEventCapacityData(eventID uuid.UUID) (totalCapacity, bookedCount, availableSeats int, err error)
+	GetSectionAvailability(eventID uuid.UUID) ([]SectionAvailability, error)
 }
 
 type service struct {
-	repo         Repository
-	tagService   TagService
-	venueService VenueService
-	cacheService cache.Service
+	repo             Repository
+	tagService       TagService
+	venueService     VenueService
+	promotionService PromotionService
+	brandingService  BrandingService
+	ratingService    RatingService
+	favoriteService  FavoriteService
+	cacheService     cache.Service
+	currencies       currency.Provider
+	changeListeners  []ChangeListener
+	webhookPublisher WebhookPublisher
+	domainEvents     DomainEventPublisher
+
+	upcomingWindowConfig *UpcomingWindowConfig
+	upcomingFlight       singleflight.Group
+	sitemapConfig        *SitemapConfig
+	lifecycleConfig      *LifecycleConfig
+	listKeyspace         *listCacheKeyspace
 }
 
 // TagService interface to avoid circular dependencies
@@ -61,9 +107,32 @@ type VenueService interface {
 	GetSectionsByTemplateID(ctx context.Context, templateID string) (interface{}, error)
 }
 
+// PromotionService interface to fetch cross-promotion slots, satisfied by promotions.Service
+type PromotionService interface {
+	GetPromotionsForEvent(ctx context.Context, eventID uuid.UUID) ([]promotions.PromotedEvent, error)
+}
+
+// BrandingService interface to resolve organizer branding without importing the branding package
+type BrandingService interface {
+	GetBrandingForEvent(ctx context.Context, eventID uuid.UUID) (*EventBranding, error)
+}
+
+// RatingService interface to fetch attendee review aggregates without importing the reviews package
+type RatingService interface {
+	GetRatingForEvent(ctx context.Context, eventID uuid.UUID) (*EventRating, error)
+	GetRatingsForEvents(ctx context.Context, eventIDs []uuid.UUID) (map[uuid.UUID]EventRating, error)
+}
+
+// FavoriteService interface to flag and notify favorited events without importing the favorites package
+type FavoriteService interface {
+	GetFavoritedEventIDs(ctx context.Context, userID uuid.UUID, eventIDs []uuid.UUID) (map[uuid.UUID]bool, error)
+	NotifyPriceDrop(ctx context.Context, eventID uuid.UUID, oldPrice, newPrice float64) error
+}
+
 func NewService(repo Repository) Service {
 	return &service{
-		repo: repo,
+		repo:         repo,
+		listKeyspace: newListCacheKeyspace(listCacheMaxFilterSets, listCacheAdmitAfter, listCacheMaxCandidates),
 	}
 }
 
@@ -75,11 +144,50 @@ func (s *service) SetVenueService(venueService VenueService) {
 	s.venueService = venueService
 }
 
+func (s *service) SetPromotionService(promotionService PromotionService) {
+	s.promotionService = promotionService
+}
+
+func (s *service) SetBrandingService(brandingService BrandingService) {
+	s.brandingService = brandingService
+}
+
+func (s *service) SetRatingService(ratingService RatingService) {
+	s.ratingService = ratingService
+}
+
+func (s *service) SetFavoriteService(favoriteService FavoriteService) {
+	s.favoriteService = favoriteService
+}
+
 // SetCacheService injects the cache service dependency
 func (s *service) SetCacheService(cacheService cache.Service) {
 	s.cacheService = cacheService
 }
 
+// SetCurrencyProvider sets the currencies events can be priced in
+func (s *service) SetCurrencyProvider(provider currency.Provider) {
+	s.currencies = provider
+}
+
+// eventCurrency validates a requested currency code, empty meaning the base currency
+func (s *service) eventCurrency(code string) (string, error) {
+	code = currency.Normalize(code)
+	if s.currencies == nil {
+		if code == "" {
+			return "INR", nil
+		}
+		return code, nil
+	}
+	if code == "" {
+		return s.currencies.Base(), nil
+	}
+	if !s.currencies.Supported(code) {
+		return "", fmt.Errorf("unsupported currency: %s", code)
+	}
+	return code, nil
+}
+
 // Cache helper methods
 func (s *service) setCache(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
 	if s.cacheService == nil {
@@ -132,9 +240,148 @@ func (s *service) invalidateEventCache(ctx context.Context, eventID *uuid.UUID)
 		}
 	}
 
+	// The upcoming window was just dropped with the rest of the event keys; rebuild it
+	// right away instead of letting the first readers race to do it
+	s.refreshUpcomingWindowAsync()
+
 	return nil
 }
 
+// Helper function to populate cross-promotion slots in event response
+func (s *service) populatePromotions(ctx context.Context, response *EventResponse) {
+	if s.promotionService == nil {
+		return
+	}
+
+	eventID, err := uuid.Parse(response.ID)
+	if err != nil {
+		return
+	}
+
+	promoted, err := s.promotionService.GetPromotionsForEvent(ctx, eventID)
+	if err != nil {
+		// Promotions are optional; never fail the event detail because of them
+		log.WarnContext(ctx, "Failed to load promotions", "event_id", eventID, "error", err)
+		return
+	}
+
+	response.Promotions = promoted
+}
+
+// Helper function to populate organizer branding in event response
+func (s *service) populateBranding(ctx context.Context, response *EventResponse) {
+	if s.brandingService == nil {
+		return
+	}
+
+	eventID, err := uuid.Parse(response.ID)
+	if err != nil {
+		return
+	}
+
+	branding, err := s.brandingService.GetBrandingForEvent(ctx, eventID)
+	if err != nil {
+		// Clients fall back to platform theming when branding is missing
+		log.WarnContext(ctx, "Failed to load branding", "event_id", eventID, "error", err)
+		return
+	}
+
+	response.Branding = branding
+}
+
+// Helper function to populate the attendee rating in event response
+func (s *service) populateRating(ctx context.Context, response *EventResponse) {
+	if s.ratingService == nil {
+		return
+	}
+
+	eventID, err := uuid.Parse(response.ID)
+	if err != nil {
+		return
+	}
+
+	rating, err := s.ratingService.GetRatingForEvent(ctx, eventID)
+	if err != nil {
+		log.WarnContext(ctx, "Failed to load rating", "event_id", eventID, "error", err)
+		return
+	}
+
+	if rating.ReviewCount > 0 {
+		response.Rating = rating
+	}
+}
+
+// Helper function to populate attendee ratings for a page of events in one lookup
+func (s *service) populateRatings(ctx context.Context, responses []EventResponse) {
+	if s.ratingService == nil || len(responses) == 0 {
+		return
+	}
+
+	eventIDs := make([]uuid.UUID, 0, len(responses))
+	for _, response := range responses {
+		if eventID, err := uuid.Parse(response.ID); err == nil {
+			eventIDs = append(eventIDs, eventID)
+		}
+	}
+
+	ratings, err := s.ratingService.GetRatingsForEvents(ctx, eventIDs)
+	if err != nil {
+		log.WarnContext(ctx, "Failed to load event ratings", "error", err)
+		return
+	}
+
+	for i := range responses {
+		eventID, err := uuid.Parse(responses[i].ID)
+		if err != nil {
+			continue
+		}
+		if rating, ok := ratings[eventID]; ok {
+			responses[i].Rating = &rating
+		}
+	}
+}
+
+// Helper function to flag the viewer's favorited events. Runs after caching since
+// the flag is per user while the cached list is shared.
+func (s *service) populateFavorites(ctx context.Context, viewerID *uuid.UUID, responses []EventResponse) {
+	if s.favoriteService == nil || viewerID == nil || len(responses) == 0 {
+		return
+	}
+
+	eventIDs := make([]uuid.UUID, 0, len(responses))
+	for _, response := range responses {
+		if eventID, err := uuid.Parse(response.ID); err == nil {
+			eventIDs = append(eventIDs, eventID)
+		}
+	}
+
+	favorited, err := s.favoriteService.GetFavoritedEventIDs(ctx, *viewerID, eventIDs)
+	if err != nil {
+		log.WarnContext(ctx, "Failed to load favorites", "user_id", viewerID, "error", err)
+		return
+	}
+
+	for i := range responses {
+		eventID, err := uuid.Parse(responses[i].ID)
+		if err != nil {
+			continue
+		}
+		isFavorited := favorited[eventID]
+		responses[i].IsFavorited = &isFavorited
+	}
+}
+
+// Helper function to notify users who favorited an event when its base price drops
+func (s *service) notifyPriceDrop(eventID uuid.UUID, oldPrice float64, newPrice *float64) {
+	if s.favoriteService == nil || newPrice == nil || *newPrice >= oldPrice {
+		return
+	}
+
+	if err := s.favoriteService.NotifyPriceDrop(context.Background(), eventID, oldPrice, *newPrice); err != nil {
+		log.Warn("Failed to queue price drop notifications", "event_id", eventID, "error", err)
+	}
+}
+
 // Helper function to populate tags in event response
 func (s *service) populateEventTags(response *EventResponse) error {
 	if s.tagService == nil {
@@ -336,14 +583,14 @@ func (s *service) validateTagsExist(tagNames []string) error {
 		}
 	}
 	// log
-	log.Printf("Validating tags: %v", cleanNames)
+	log.Debug("Validating tags", "tags", cleanNames)
 	if len(cleanNames) == 0 {
 		return nil // No tags to validate
 	}
 
 	// Get existing tags by names
 	existingTags, err := s.tagService.GetTagsByNames(cleanNames)
-	log.Printf("Existing tags found: %v", existingTags)
+	log.Debug("Existing tags found", "tags", existingTags)
 	if err != nil {
 		return fmt.Errorf("failed to fetch tags: %w", err)
 	}
@@ -465,15 +712,37 @@ func (s *service) CreateEvent(userID uuid.UUID, req CreateEventRequest) (*EventR
 		}
 	}
 
+	eventCurrency, err := s.eventCurrency(req.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	status, publishAt, err := initialStatus(req.Draft, req.PublishAt, req.DateTime)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reject slots that overlap other events at the same venue
+	overrides, err := s.checkVenueAvailability(venueTemplateID, req.DateTime, req.DurationMinutes, uuid.Nil,
+		venueOverride{Override: req.OverrideVenueConflict, Reason: req.OverrideReason})
+	if err != nil {
+		return nil, err
+	}
+
 	event := &Event{
 		Name:            req.Name,
 		Description:     req.Description,
 		Venue:           req.Venue,
 		VenueTemplateID: venueTemplateID,
 		DateTime:        req.DateTime,
+		DurationMinutes: req.DurationMinutes,
 		BasePrice:       req.BasePrice,
-		Status:          EventStatusPublished,
+		Currency:        eventCurrency,
+		Status:          status,
+		PublishAt:       publishAt,
 		ImageURL:        req.ImageURL,
+		Unlisted:        req.Unlisted,
+		NoIndex:         req.NoIndex,
 		CreatedBy:       userID,
 	}
 
@@ -488,6 +757,8 @@ func (s *service) CreateEvent(userID uuid.UUID, req CreateEventRequest) (*EventR
 		return nil, fmt.Errorf("failed to create event pricing: %w", err)
 	}
 
+	s.recordVenueOverrides(event.ID, userID, overrides)
+
 	response := event.ToResponse()
 
 	// Handle tags if provided (we already validated they exist)
@@ -513,23 +784,56 @@ func (s *service) CreateEvent(userID uuid.UUID, req CreateEventRequest) (*EventR
 	ctx := context.Background()
 	if err := s.invalidateEventCache(ctx, nil); err != nil {
 		// Log error but don't fail the request
-		fmt.Printf("Warning: failed to invalidate event cache after creation: %v\n", err)
+		log.WarnContext(ctx, "Failed to invalidate event cache after creation", "error", err)
 	}
 
+	s.publishEventPublished(event)
+
 	return &response, nil
 }
 
-func (s *service) GetEventByID(id uuid.UUID) (*EventResponse, error) {
-	ctx := context.Background()
+func (s *service) GetEventByID(ctx context.Context, id uuid.UUID) (*EventResponse, error) {
 	cacheKey := constants.BuildEventDetailKey(id.String())
 
 	// Try to get from cache first
 	var cachedEvent EventResponse
 	if err := s.getCache(ctx, cacheKey, &cachedEvent); err == nil {
+		s.populateBranding(ctx, &cachedEvent)
+		s.populateRating(ctx, &cachedEvent)
 		return &cachedEvent, nil
 	}
 
 	// Cache miss - get from database
+	response, err := s.loadEventDetail(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Branding has its own cache so organizer changes show up without flushing event details
+	s.populateBranding(ctx, response)
+
+	// Ratings change with every review, so they're kept out of the cached event detail
+	s.populateRating(ctx, response)
+
+	return response, nil
+}
+
+// GetPublicEventByID is the event detail page: the event with its cross-promotion
+// slots. Every call counts as an impression of those slots, so it is only used
+// for the public detail, never for admin views or other modules.
+func (s *service) GetPublicEventByID(ctx context.Context, id uuid.UUID) (*EventResponse, error) {
+	response, err := s.GetEventByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.populatePromotions(ctx, response)
+	return response, nil
+}
+
+// loadEventDetail reads an event's detail from the database and caches it.
+// Drafts are not found, they are only seen through the admin preview.
+func (s *service) loadEventDetail(ctx context.Context, id uuid.UUID) (*EventResponse, error) {
 	event, err := s.repo.GetByID(id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -537,6 +841,9 @@ func (s *service) GetEventByID(id uuid.UUID) (*EventResponse, error) {
 		}
 		return nil, fmt.Errorf("failed to get event: %w", err)
 	}
+	if event.Status == EventStatusDraft {
+		return nil, errors.New("event not found")
+	}
 
 	response := event.ToResponse()
 
@@ -556,9 +863,9 @@ func (s *service) GetEventByID(id uuid.UUID) (*EventResponse, error) {
 	}
 
 	// Cache the result
-	if err := s.setCache(ctx, cacheKey, response, constants.TTL_EVENT_DETAIL); err != nil {
+	if err := s.setCache(ctx, constants.BuildEventDetailKey(id.String()), response, constants.TTL_EVENT_DETAIL); err != nil {
 		// Log error but don't fail the request
-		fmt.Printf("Warning: failed to cache event detail: %v\n", err)
+		log.WarnContext(ctx, "Failed to cache event detail", "error", err)
 	}
 
 	return &response, nil
@@ -605,16 +912,42 @@ func (s *service) UpdateEvent(id uuid.UUID, userID uuid.UUID, req UpdateEventReq
 	if req.BasePrice != nil {
 		updates["base_price"] = *req.BasePrice
 	}
+	if req.Currency != nil {
+		eventCurrency, err := s.eventCurrency(*req.Currency)
+		if err != nil {
+			return nil, err
+		}
+		updates["currency"] = eventCurrency
+	}
 	if req.Status != nil {
 		status := EventStatus(*req.Status)
-		if !status.IsValid() {
-			return nil, errors.New("invalid event status")
+		if err := currentEvent.Status.ValidateManualTransition(status); err != nil {
+			return nil, err
+		}
+		if status != currentEvent.Status {
+			updates["status"] = status
 		}
-		updates["status"] = status
 	}
 	if req.ImageURL != nil {
 		updates["image_url"] = *req.ImageURL
 	}
+	if req.Unlisted != nil {
+		updates["unlisted"] = *req.Unlisted
+	}
+	if req.NoIndex != nil {
+		updates["no_index"] = *req.NoIndex
+	}
+	if req.DurationMinutes != nil {
+		updates["duration_minutes"] = *req.DurationMinutes
+	}
+	if err := applyPublishSchedule(currentEvent, req, updates); err != nil {
+		return nil, err
+	}
+
+	overrides, err := s.checkReschedule(currentEvent, req)
+	if err != nil {
+		return nil, err
+	}
 
 	// Update timestamp
 	updates["updated_at"] = time.Now()
@@ -624,6 +957,8 @@ func (s *service) UpdateEvent(id uuid.UUID, userID uuid.UUID, req UpdateEventReq
 		return nil, fmt.Errorf("failed to update event: %w", err)
 	}
 
+	s.recordVenueOverrides(id, userID, overrides)
+
 	// Handle tags if provided - validate first
 	if req.Tags != nil && s.tagService != nil {
 		if len(req.Tags) > 0 {
@@ -652,7 +987,15 @@ func (s *service) UpdateEvent(id uuid.UUID, userID uuid.UUID, req UpdateEventReq
 	ctx := context.Background()
 	if err := s.invalidateEventCache(ctx, &id); err != nil {
 		// Log error but don't fail the request
-		fmt.Printf("Warning: failed to invalidate event cache after update: %v\n", err)
+		log.WarnContext(ctx, "Failed to invalidate event cache after update", "error", err)
+	}
+
+	s.notifyPriceDrop(id, currentEvent.BasePrice, req.BasePrice)
+	s.publishChange(currentEvent, updatedEvent, userID)
+	s.publishUpdated(updatedEvent, updates, req.Tags != nil)
+	s.publishStatusChanged(currentEvent.Status, updatedEvent, &userID, TransitionTriggerManual)
+	if currentEvent.Status == EventStatusDraft {
+		s.publishEventPublished(updatedEvent)
 	}
 
 	return &response, nil
@@ -691,10 +1034,15 @@ func (s *service) DeleteEvent(id uuid.UUID, userID uuid.UUID) error {
 		return fmt.Errorf("failed to delete event: %w", err)
 	}
 
+	// Invalidate event cache after deletion
+	if err := s.invalidateEventCache(context.Background(), &id); err != nil {
+		log.Warn("Failed to invalidate event cache after deletion", "error", err)
+	}
+
 	return nil
 }
 
-func (s *service) GetAllEvents(query EventListQuery) (*PaginatedEvents, error) {
+func (s *service) GetAllEvents(ctx context.Context, query EventListQuery) (*PaginatedEvents, error) {
 	// Set defaults
 	if query.Page <= 0 {
 		query.Page = 1
@@ -703,19 +1051,59 @@ func (s *service) GetAllEvents(query EventListQuery) (*PaginatedEvents, error) {
 		query.Limit = 10
 	}
 
-	ctx := context.Background()
+	normalizeListQuery(&query)
+	filterNames := listFilterNames(query)
+
+	// Filtered lists share a bounded keyspace; filter sets that are not cached
+	// go straight to the database
 	cacheKey := constants.BuildEventListKey(query.Page, query.Limit, query.Status)
+	cacheable := true
+	if len(filterNames) > 0 {
+		filterHash := listFilterHash(query)
+		var evicted string
+		cacheable, evicted = s.listKeyspace.touch(filterHash)
+		if evicted != "" {
+			s.dropFilteredListsAsync(evicted)
+		}
+		cacheKey = constants.BuildFilteredEventListKey(filterHash, query.Page, query.Limit, query.Status)
+	}
 
-	// Try to get from cache first
-	var cachedResult PaginatedEvents
-	if err := s.getCache(ctx, cacheKey, &cachedResult); err == nil {
-		log.Printf("Cache HIT for event list: %s", cacheKey)
-		return &cachedResult, nil
+	if cacheable {
+		// Try to get from cache first
+		var cachedResult PaginatedEvents
+		if err := s.getCache(ctx, cacheKey, &cachedResult); err == nil {
+			recordListCacheLookup(filterNames, metrics.ResultHit)
+			s.populateRatings(ctx, cachedResult.Events)
+			s.populateFavorites(ctx, query.ViewerID, cachedResult.Events)
+			return &cachedResult, nil
+		}
+		recordListCacheLookup(filterNames, metrics.ResultMiss)
 	} else {
-		log.Printf("Cache MISS for event list: %s (error: %v)", cacheKey, err)
+		recordListCacheLookup(filterNames, metrics.ResultBypass)
 	}
 
 	// Cache miss - get from database
+	result, err := s.loadEventList(query)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cache the result, unless its filter set is not cached yet
+	if cacheable {
+		if err := s.setCache(ctx, cacheKey, result, constants.TTL_EVENT_LIST); err != nil {
+			// Log error but don't fail the request
+			log.WarnContext(ctx, "Failed to cache event list", "error", err)
+		}
+	}
+
+	s.populateRatings(ctx, result.Events)
+	s.populateFavorites(ctx, query.ViewerID, result.Events)
+
+	return result, nil
+}
+
+// loadEventList reads one page of the event list from the database
+func (s *service) loadEventList(query EventListQuery) (*PaginatedEvents, error) {
 	events, totalCount, err := s.repo.GetAll(query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get events: %w", err)
@@ -750,14 +1138,6 @@ func (s *service) GetAllEvents(query EventListQuery) (*PaginatedEvents, error) {
 		TotalPages: totalPages,
 	}
 
-	// Cache the result
-	if err := s.setCache(ctx, cacheKey, result, constants.TTL_EVENT_LIST); err != nil {
-		// Log error but don't fail the request
-		log.Printf("Warning: failed to cache event list: %v", err)
-	} else {
-		log.Printf("Cached event list: %s", cacheKey)
-	}
-
 	return result, nil
 }
 
@@ -792,49 +1172,13 @@ func (s *service) GetUpcomingEvents(limit int) ([]EventResponse, error) {
 		limit = 100
 	}
 
-	ctx := context.Background()
-	cacheKey := constants.CACHE_KEY_EVENTS_UPCOMING + ":limit:" + fmt.Sprintf("%d", limit)
-
-	// Try to get from cache first
-	var cachedResult []EventResponse
-	if err := s.getCache(ctx, cacheKey, &cachedResult); err == nil {
-		log.Printf("Cache HIT for upcoming events: %s", cacheKey)
-		return cachedResult, nil
-	} else {
-		log.Printf("Cache MISS for upcoming events: %s (error: %v)", cacheKey, err)
-	}
-
-	// Cache miss - get from database
-	events, err := s.repo.GetUpcomingEvents(limit)
+	// Every limit is served from one precomputed window rather than a cache entry per limit
+	window, err := s.getUpcomingWindow(context.Background())
 	if err != nil {
-		return nil, fmt.Errorf("failed to get upcoming events: %w", err)
+		return nil, err
 	}
 
-	responses := make([]EventResponse, len(events))
-	for i, event := range events {
-		response := event.ToResponse()
-
-		// Populate capacity data for each event
-		if err := s.populateEventCapacity(&response); err != nil {
-			// Log error but don't fail the entire request
-		}
-
-		// Populate tags for each event
-		if err := s.populateEventTags(&response); err != nil {
-			// Log error but don't fail the entire request
-		}
-		responses[i] = response
-	}
-
-	// Cache the result
-	if err := s.setCache(ctx, cacheKey, responses, constants.TTL_EVENT_UPCOMING); err != nil {
-		// Log error but don't fail the request
-		log.Printf("Warning: failed to cache upcoming events: %v", err)
-	} else {
-		log.Printf("Cached upcoming events: %s", cacheKey)
-	}
-
-	return responses, nil
+	return window.slice(limit, time.Now()), nil
 }
 
 func (s *service) CheckEventAvailability(eventID uuid.UUID, seatCount int) (bool, error) {
@@ -931,16 +1275,47 @@ func (s *service) UpdateEventAsAdmin(id uuid.UUID, adminID uuid.UUID, req Update
 	if req.BasePrice != nil {
 		updates["base_price"] = *req.BasePrice
 	}
+	if req.Currency != nil {
+		eventCurrency, err := s.eventCurrency(*req.Currency)
+		if err != nil {
+			return nil, err
+		}
+		updates["currency"] = eventCurrency
+	}
 	if req.Status != nil {
 		status := EventStatus(*req.Status)
-		if !status.IsValid() {
-			return nil, errors.New("invalid event status")
+		if err := currentEvent.Status.ValidateManualTransition(status); err != nil {
+			return nil, err
+		}
+		if status != currentEvent.Status {
+			updates["status"] = status
 		}
-		updates["status"] = status
 	}
 	if req.ImageURL != nil {
 		updates["image_url"] = *req.ImageURL
 	}
+	if req.Unlisted != nil {
+		updates["unlisted"] = *req.Unlisted
+	}
+	if req.NoIndex != nil {
+		updates["no_index"] = *req.NoIndex
+	}
+	if req.DurationMinutes != nil {
+		updates["duration_minutes"] = *req.DurationMinutes
+	}
+	if err := applyPublishSchedule(currentEvent, req, updates); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkCancelViaUpdate(currentEvent, req); err != nil {
+		return nil, err
+	}
+
+	overrides, err := s.checkReschedule(currentEvent, req)
+	if err != nil {
+		return nil, err
+	}
+
 	// Update timestamp
 	updates["updated_at"] = time.Now()
 	// Track who updated it
@@ -951,6 +1326,8 @@ func (s *service) UpdateEventAsAdmin(id uuid.UUID, adminID uuid.UUID, req Update
 		return nil, fmt.Errorf("failed to update event: %w", err)
 	}
 
+	s.recordVenueOverrides(id, adminID, overrides)
+
 	// Handle tags if provided - validate first
 	if req.Tags != nil && s.tagService != nil {
 		if len(req.Tags) > 0 {
@@ -975,6 +1352,19 @@ func (s *service) UpdateEventAsAdmin(id uuid.UUID, adminID uuid.UUID, req Update
 		return nil, fmt.Errorf("failed to populate tags: %w", err)
 	}
 
+	// Invalidate event cache after update
+	if err := s.invalidateEventCache(context.Background(), &id); err != nil {
+		log.Warn("Failed to invalidate event cache after update", "error", err)
+	}
+
+	s.notifyPriceDrop(id, currentEvent.BasePrice, req.BasePrice)
+	s.publishChange(currentEvent, updatedEvent, adminID)
+	s.publishUpdated(updatedEvent, updates, req.Tags != nil)
+	s.publishStatusChanged(currentEvent.Status, updatedEvent, &adminID, TransitionTriggerManual)
+	if currentEvent.Status == EventStatusDraft {
+		s.publishEventPublished(updatedEvent)
+	}
+
 	return &response, nil
 }
 
@@ -1002,6 +1392,11 @@ func (s *service) DeleteEventAsAdmin(id uuid.UUID, adminID uuid.UUID) error {
 		return fmt.Errorf("failed to delete event: %w", err)
 	}
 
+	// Invalidate event cache after deletion
+	if err := s.invalidateEventCache(context.Background(), &id); err != nil {
+		log.Warn("Failed to invalidate event cache after deletion", "error", err)
+	}
+
 	return nil
 }
 
@@ -1033,6 +1428,131 @@ func (s *service) GetAllEventAnalyticsAsAdmin() (*GlobalAnalytics, error) {
 	return analytics, nil
 }
 
+// CloneEventAsAdmin duplicates an event with its section pricing, tags and
+// cancellation policy, applying the overrides in the request
+func (s *service) CloneEventAsAdmin(sourceID uuid.UUID, adminID uuid.UUID, req CloneEventRequest) (*EventResponse, error) {
+	source, err := s.repo.GetByID(sourceID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("event not found")
+		}
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	if req.DateTime.Before(time.Now()) {
+		return nil, errors.New("event date must be in the future")
+	}
+
+	clone := &Event{
+		Name:            source.Name,
+		Description:     source.Description,
+		Venue:           source.Venue,
+		VenueTemplateID: source.VenueTemplateID,
+		DateTime:        req.DateTime,
+		BasePrice:       source.BasePrice,
+		Currency:        source.Currency,
+		Status:          EventStatusPublished,
+		DurationMinutes: source.DurationMinutes,
+		ImageURL:        source.ImageURL,
+		Unlisted:        source.Unlisted,
+		NoIndex:         source.NoIndex,
+		CreatedBy:       adminID,
+	}
+
+	if req.Name != nil {
+		clone.Name = *req.Name
+	}
+	if req.Venue != nil {
+		clone.Venue = *req.Venue
+	}
+	if req.VenueTemplateID != nil {
+		venueTemplateID, err := uuid.Parse(*req.VenueTemplateID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid venue template ID: %w", err)
+		}
+		clone.VenueTemplateID = venueTemplateID
+	}
+	if req.BasePrice != nil {
+		clone.BasePrice = *req.BasePrice
+	}
+	if req.Currency != nil {
+		cloneCurrency, err := s.eventCurrency(*req.Currency)
+		if err != nil {
+			return nil, err
+		}
+		clone.Currency = cloneCurrency
+	}
+	if req.ImageURL != nil {
+		clone.ImageURL = *req.ImageURL
+	}
+	if req.DurationMinutes != nil {
+		clone.DurationMinutes = *req.DurationMinutes
+	}
+
+	overrides, err := s.checkVenueAvailability(clone.VenueTemplateID, clone.DateTime, clone.DurationMinutes, uuid.Nil,
+		venueOverride{Override: req.OverrideVenueConflict, Reason: req.OverrideReason})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(req.Tags) > 0 && s.tagService != nil {
+		if err := s.validateTagsExist(req.Tags); err != nil {
+			return nil, fmt.Errorf("tag validation failed: %w", err)
+		}
+	}
+
+	// Pricing overrides must belong to the clone's venue template
+	pricingOverrides := make(map[uuid.UUID]float64, len(req.SectionPricing))
+	if len(req.SectionPricing) > 0 {
+		if s.venueService != nil {
+			if err := s.validateSectionsExist(clone.VenueTemplateID, req.SectionPricing); err != nil {
+				return nil, fmt.Errorf("section validation failed: %w", err)
+			}
+		}
+		for _, pricing := range req.SectionPricing {
+			sectionID, err := uuid.Parse(pricing.SectionID)
+			if err != nil {
+				return nil, fmt.Errorf("invalid section ID %s: %w", pricing.SectionID, err)
+			}
+			pricingOverrides[sectionID] = pricing.PriceMultiplier
+		}
+	}
+
+	if err := s.repo.Clone(source, clone, pricingOverrides); err != nil {
+		return nil, fmt.Errorf("failed to clone event: %w", err)
+	}
+
+	s.recordVenueOverrides(clone.ID, adminID, overrides)
+
+	// Replace copied tags when new ones are given (we already validated they exist)
+	if len(req.Tags) > 0 && s.tagService != nil {
+		if err := s.tagService.ReplaceEventTags(clone.ID, req.Tags); err != nil {
+			s.repo.Delete(clone.ID) // Best effort cleanup
+			return nil, fmt.Errorf("failed to assign tags: %w", err)
+		}
+	}
+
+	response := clone.ToResponse()
+
+	if err := s.populateEventCapacity(&response); err != nil {
+		return nil, fmt.Errorf("failed to populate capacity data: %w", err)
+	}
+
+	if err := s.populateEventTags(&response); err != nil {
+		return nil, fmt.Errorf("failed to populate tags: %w", err)
+	}
+
+	// Invalidate event cache after creation
+	ctx := context.Background()
+	if err := s.invalidateEventCache(ctx, nil); err != nil {
+		log.WarnContext(ctx, "Failed to invalidate event cache after clone", "error", err)
+	}
+
+	s.publishEventPublished(clone)
+
+	return &response, nil
+}
+
 // createEventPricing creates event pricing entries for the given event and sections
 func (s *service) createEventPricing(eventID uuid.UUID, sectionPricing []CreateEventSectionPricing) error {
 	// Create a temporary struct to match the event_pricing table
@@ -0,0 +1,70 @@
+package events
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// maxSitemapURLs is the sitemap protocol's limit for a single file
+const maxSitemapURLs = 50000
+
+// SitemapConfig contains configuration for the public event sitemap
+type SitemapConfig struct {
+	EventURL string // Public page of an event, {event_id} is replaced
+}
+
+// DefaultSitemapConfig returns default sitemap configuration
+func DefaultSitemapConfig() *SitemapConfig {
+	return &SitemapConfig{
+		EventURL: "http://localhost:3000/events/{event_id}",
+	}
+}
+
+func (c *SitemapConfig) eventURL(eventID uuid.UUID) string {
+	return strings.ReplaceAll(c.EventURL, "{event_id}", eventID.String())
+}
+
+// Sitemap is a sitemaps.org urlset of upcoming public events
+type Sitemap struct {
+	XMLName xml.Name     `xml:"urlset" swaggerignore:"true"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	URLs    []SitemapURL `xml:"url"`
+}
+
+type SitemapURL struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod"`
+}
+
+func (s *service) SetSitemapConfig(config *SitemapConfig) {
+	s.sitemapConfig = config
+}
+
+// GetSitemap lists upcoming published events that are neither unlisted nor marked noindex
+func (s *service) GetSitemap() (*Sitemap, error) {
+	config := s.sitemapConfig
+	if config == nil {
+		config = DefaultSitemapConfig()
+	}
+
+	events, err := s.repo.GetSitemapEvents(time.Now(), maxSitemapURLs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sitemap events: %w", err)
+	}
+
+	sitemap := &Sitemap{
+		Xmlns: "http://www.sitemaps.org/schemas/sitemap/0.9",
+		URLs:  make([]SitemapURL, 0, len(events)),
+	}
+	for _, event := range events {
+		sitemap.URLs = append(sitemap.URLs, SitemapURL{
+			Loc:     config.eventURL(event.ID),
+			LastMod: event.UpdatedAt.UTC().Format(time.RFC3339),
+		})
+	}
+	return sitemap, nil
+}
@@ -1,17 +1,41 @@
 package events
 
+import (
+	"errors"
+	"fmt"
+)
+
 type EventStatus string
 
 const (
+	EventStatusDraft     EventStatus = "draft"
 	EventStatusPublished EventStatus = "published"
-	EventStatusCancelled EventStatus = "cancelled"
+	EventStatusSoldOut   EventStatus = "sold_out"
+	EventStatusOngoing   EventStatus = "ongoing"
 	EventStatusCompleted EventStatus = "completed"
+	EventStatusCancelled EventStatus = "cancelled"
 )
 
+// onSaleStatuses are the statuses of events that are listed and can be booked
+var onSaleStatuses = []EventStatus{EventStatusPublished, EventStatusSoldOut}
+
+// ErrInvalidTransition is returned when an event cannot move to the status asked for
+var ErrInvalidTransition = errors.New("invalid event status transition")
+
+// transitions lists the statuses each status can move to. Completed and
+// cancelled events are final.
+var transitions = map[EventStatus][]EventStatus{
+	EventStatusDraft:     {EventStatusPublished, EventStatusCancelled},
+	EventStatusPublished: {EventStatusSoldOut, EventStatusOngoing, EventStatusCompleted, EventStatusCancelled},
+	EventStatusSoldOut:   {EventStatusPublished, EventStatusOngoing, EventStatusCompleted, EventStatusCancelled},
+	EventStatusOngoing:   {EventStatusCompleted},
+}
+
 // IsValid checks if the event status is valid
 func (es EventStatus) IsValid() bool {
 	switch es {
-	case EventStatusPublished, EventStatusCancelled, EventStatusCompleted:
+	case EventStatusDraft, EventStatusPublished, EventStatusSoldOut, EventStatusOngoing,
+		EventStatusCompleted, EventStatusCancelled:
 		return true
 	}
 	return false
@@ -22,9 +46,48 @@ func (es EventStatus) String() string {
 	return string(es)
 }
 
+// CanTransitionTo checks if an event with this status can move to next
+func (es EventStatus) CanTransitionTo(next EventStatus) bool {
+	for _, allowed := range transitions[es] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAutomatic reports whether the status is only set by the lifecycle
+// scheduler, from the event's capacity and schedule
+func (es EventStatus) IsAutomatic() bool {
+	return es == EventStatusSoldOut || es == EventStatusOngoing
+}
+
+// IsFinal reports whether the event can no longer change status
+func (es EventStatus) IsFinal() bool {
+	return len(transitions[es]) == 0
+}
+
+// ValidateManualTransition checks a status change asked for through the API.
+// Setting the current status again is allowed and changes nothing.
+func (es EventStatus) ValidateManualTransition(next EventStatus) error {
+	if !next.IsValid() {
+		return errors.New("invalid event status")
+	}
+	if next == es {
+		return nil
+	}
+	if next.IsAutomatic() {
+		return fmt.Errorf("%w: %s is set automatically", ErrInvalidTransition, next)
+	}
+	if !es.CanTransitionTo(next) {
+		return fmt.Errorf("%w: %s to %s", ErrInvalidTransition, es, next)
+	}
+	return nil
+}
+
 // CanBeUpdated checks if an event with this status can be updated
 func (es EventStatus) CanBeUpdated() bool {
-	return es == EventStatusPublished
+	return es == EventStatusDraft || es == EventStatusPublished || es == EventStatusSoldOut
 }
 
 // CanBeDeleted checks if an event with this status can be deleted
@@ -32,7 +95,8 @@ func (es EventStatus) CanBeDeleted() bool {
 	return false
 }
 
-// CanBeBooked checks if an event with this status allows new bookings
+// CanBeBooked checks if an event with this status allows new bookings. Sold
+// out events still take bookings for seats that are freed up again.
 func (es EventStatus) CanBeBooked() bool {
-	return es == EventStatusPublished
+	return es == EventStatusPublished || es == EventStatusSoldOut
 }
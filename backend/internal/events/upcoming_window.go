@@ -0,0 +1,184 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"evently/internal/shared/utils/constants"
+)
+
+// upcomingWindowFlight is the singleflight key for rebuilding the upcoming-events window
+const upcomingWindowFlight = "upcoming-window"
+
+// UpcomingWindowConfig contains configuration for the precomputed upcoming-events window
+type UpcomingWindowConfig struct {
+	Size            int           // Number of upcoming events kept in the window
+	RefreshInterval time.Duration // Must stay below the window TTL so readers never see a miss
+}
+
+// DefaultUpcomingWindowConfig returns default upcoming window configuration
+func DefaultUpcomingWindowConfig() *UpcomingWindowConfig {
+	return &UpcomingWindowConfig{
+		Size:            200,
+		RefreshInterval: 5 * time.Minute, // A third of the window TTL
+	}
+}
+
+// UpcomingWindow is a single ordered list of upcoming events that every limit is sliced from
+type UpcomingWindow struct {
+	Events      []EventResponse `json:"events"`
+	GeneratedAt time.Time       `json:"generated_at"`
+}
+
+// slice returns up to limit events that are still in the future. The window can be a
+// few minutes old, so events that started since it was built are skipped.
+func (w *UpcomingWindow) slice(limit int, now time.Time) []EventResponse {
+	result := make([]EventResponse, 0, limit)
+	for _, event := range w.Events {
+		if len(result) == limit {
+			break
+		}
+		if event.DateTime.After(now) {
+			result = append(result, event)
+		}
+	}
+	return result
+}
+
+func (s *service) SetUpcomingWindowConfig(config *UpcomingWindowConfig) {
+	s.upcomingWindowConfig = config
+}
+
+// getUpcomingWindow serves the window from cache, rebuilding it once per instance on a miss
+// so a burst of requests after expiry or invalidation doesn't stampede the database
+func (s *service) getUpcomingWindow(ctx context.Context) (*UpcomingWindow, error) {
+	var cached UpcomingWindow
+	if err := s.getCache(ctx, constants.CACHE_KEY_EVENTS_UPCOMING_WINDOW, &cached); err == nil {
+		return &cached, nil
+	}
+
+	result, err, shared := s.upcomingFlight.Do(upcomingWindowFlight, func() (interface{}, error) {
+		return s.buildUpcomingWindow(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if shared {
+		log.DebugContext(ctx, "Upcoming events window rebuild shared across concurrent requests")
+	}
+	return result.(*UpcomingWindow), nil
+}
+
+// RefreshUpcomingWindow rebuilds and caches the window, used by the refresh job and after event changes
+func (s *service) RefreshUpcomingWindow(ctx context.Context) error {
+	_, err, _ := s.upcomingFlight.Do(upcomingWindowFlight, func() (interface{}, error) {
+		return s.buildUpcomingWindow(ctx)
+	})
+	return err
+}
+
+// refreshUpcomingWindowAsync repopulates the window in the background after an event change
+func (s *service) refreshUpcomingWindowAsync() {
+	if s.cacheService == nil {
+		return
+	}
+	go func() {
+		if err := s.RefreshUpcomingWindow(context.Background()); err != nil {
+			log.Warn("Failed to refresh upcoming events window", "error", err)
+		}
+	}()
+}
+
+func (s *service) buildUpcomingWindow(ctx context.Context) (*UpcomingWindow, error) {
+	size := DefaultUpcomingWindowConfig().Size
+	if s.upcomingWindowConfig != nil && s.upcomingWindowConfig.Size > 0 {
+		size = s.upcomingWindowConfig.Size
+	}
+
+	events, err := s.repo.GetUpcomingEvents(size)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get upcoming events: %w", err)
+	}
+
+	window := &UpcomingWindow{
+		Events:      make([]EventResponse, len(events)),
+		GeneratedAt: time.Now(),
+	}
+	for i, event := range events {
+		response := event.ToResponse()
+
+		// Capacity and tags are best effort, a missing value shouldn't drop the event
+		if err := s.populateEventCapacity(&response); err != nil {
+			log.WarnContext(ctx, "Failed to populate capacity", "event_id", response.ID, "error", err)
+		}
+		if err := s.populateEventTags(&response); err != nil {
+			log.WarnContext(ctx, "Failed to populate tags", "event_id", response.ID, "error", err)
+		}
+		window.Events[i] = response
+	}
+
+	if err := s.setCache(ctx, constants.CACHE_KEY_EVENTS_UPCOMING_WINDOW, window, constants.TTL_EVENT_UPCOMING); err != nil {
+		log.WarnContext(ctx, "Failed to cache upcoming events window", "error", err)
+	} else {
+		log.DebugContext(ctx, "Cached upcoming events window", "events", len(window.Events))
+	}
+
+	return window, nil
+}
+
+// UpcomingWindowJob periodically rebuilds the upcoming-events window so it never expires under load
+type UpcomingWindowJob struct {
+	service Service
+	config  *UpcomingWindowConfig
+	done    chan struct{}
+}
+
+// NewUpcomingWindowJob creates a new upcoming window refresh job
+func NewUpcomingWindowJob(service Service, config *UpcomingWindowConfig) *UpcomingWindowJob {
+	if config == nil {
+		config = DefaultUpcomingWindowConfig()
+	}
+
+	return &UpcomingWindowJob{
+		service: service,
+		config:  config,
+		done:    make(chan struct{}),
+	}
+}
+
+// Start warms the window and keeps refreshing it on the configured interval
+func (j *UpcomingWindowJob) Start(ctx context.Context) {
+	log.Info("Starting upcoming events window job", "interval", j.config.RefreshInterval, "size", j.config.Size)
+	go j.run(ctx)
+}
+
+// Stop stops the refresh job
+func (j *UpcomingWindowJob) Stop() {
+	log.Info("Stopping upcoming events window job")
+	close(j.done)
+}
+
+func (j *UpcomingWindowJob) run(ctx context.Context) {
+	j.refresh(ctx)
+
+	ticker := time.NewTicker(j.config.RefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			j.refresh(ctx)
+		case <-j.done:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (j *UpcomingWindowJob) refresh(ctx context.Context) {
+	if err := j.service.RefreshUpcomingWindow(ctx); err != nil {
+		log.Error("Upcoming events window refresh failed", "error", err)
+	}
+}
@@ -0,0 +1,217 @@
+package events
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// defaultEventDurationMinutes applies to events without their own duration on
+// templates that are not linked to a physical venue
+const defaultEventDurationMinutes = 180
+
+// VenueSchedule is how events are spaced at the venue a template is used in.
+// Templates without a physical venue only conflict with themselves.
+type VenueSchedule struct {
+	PhysicalVenueID        *uuid.UUID
+	DefaultDurationMinutes int
+	ChangeoverMinutes      int
+}
+
+func (v *VenueSchedule) duration(eventMinutes int) time.Duration {
+	minutes := eventMinutes
+	if minutes == 0 {
+		minutes = v.DefaultDurationMinutes
+	}
+	if minutes == 0 {
+		minutes = defaultEventDurationMinutes
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+// occupied is how long an event keeps the venue busy, changeover included
+func (v *VenueSchedule) occupied(eventMinutes int) time.Duration {
+	return v.duration(eventMinutes) + time.Duration(v.ChangeoverMinutes)*time.Minute
+}
+
+// VenueConflict is another event using the same venue at an overlapping time
+type VenueConflict struct {
+	EventID         string    `json:"event_id"`
+	EventName       string    `json:"event_name"`
+	VenueTemplateID string    `json:"venue_template_id"`
+	StartsAt        time.Time `json:"starts_at"`
+	EndsAt          time.Time `json:"ends_at"` // Including the venue's changeover time
+}
+
+// VenueConflictEvent is an overlapping event as stored
+type VenueConflictEvent struct {
+	ID              uuid.UUID
+	Name            string
+	VenueTemplateID uuid.UUID
+	DateTime        time.Time
+	DurationMinutes int
+}
+
+// VenueConflictError is returned when an event would overlap others at its venue
+type VenueConflictError struct {
+	Conflicts []VenueConflict
+}
+
+func (e *VenueConflictError) Error() string {
+	return fmt.Sprintf("event overlaps %d other event(s) at the same venue, set override_venue_conflict with a reason to schedule it anyway", len(e.Conflicts))
+}
+
+// VenueConflictOverride is the audit record of an admin scheduling an event over a conflict
+type VenueConflictOverride struct {
+	ID                 uuid.UUID  `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	EventID            uuid.UUID  `json:"event_id" gorm:"type:uuid;not null;index"`
+	ConflictingEventID uuid.UUID  `json:"conflicting_event_id" gorm:"type:uuid;not null"`
+	PhysicalVenueID    *uuid.UUID `json:"physical_venue_id,omitempty" gorm:"type:uuid"`
+	AdminID            uuid.UUID  `json:"admin_id" gorm:"type:uuid;not null"`
+	Reason             string     `json:"reason" gorm:"type:text;not null"`
+	CreatedAt          time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
+
+func (VenueConflictOverride) TableName() string {
+	return "venue_conflict_overrides"
+}
+
+// VenueConflictReport lists an event's current overlaps and the overrides recorded for it
+type VenueConflictReport struct {
+	EventID   string                  `json:"event_id"`
+	Conflicts []VenueConflict         `json:"conflicts"`
+	Overrides []VenueConflictOverride `json:"overrides"`
+}
+
+// venueOverride is the override an admin sent with a create, update or clone
+type venueOverride struct {
+	Override bool
+	Reason   string
+}
+
+// findVenueConflicts returns the events that overlap the given slot at the template's venue
+func (s *service) findVenueConflicts(templateID uuid.UUID, start time.Time, durationMinutes int, excludeID uuid.UUID) ([]VenueConflict, *VenueSchedule, error) {
+	schedule, err := s.repo.GetVenueSchedule(templateID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get venue schedule: %w", err)
+	}
+
+	end := start.Add(schedule.occupied(durationMinutes))
+	events, err := s.repo.FindVenueConflicts(schedule, templateID, start, end, excludeID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to check venue conflicts: %w", err)
+	}
+
+	conflicts := make([]VenueConflict, 0, len(events))
+	for _, event := range events {
+		conflicts = append(conflicts, VenueConflict{
+			EventID:         event.ID.String(),
+			EventName:       event.Name,
+			VenueTemplateID: event.VenueTemplateID.String(),
+			StartsAt:        event.DateTime,
+			EndsAt:          event.DateTime.Add(schedule.occupied(event.DurationMinutes)),
+		})
+	}
+	return conflicts, schedule, nil
+}
+
+// checkVenueAvailability rejects a slot that overlaps other events at the venue,
+// unless the admin overrides it with a reason. The returned overrides are
+// recorded once the event is saved.
+func (s *service) checkVenueAvailability(templateID uuid.UUID, start time.Time, durationMinutes int, excludeID uuid.UUID, override venueOverride) ([]VenueConflictOverride, error) {
+	conflicts, schedule, err := s.findVenueConflicts(templateID, start, durationMinutes, excludeID)
+	if err != nil {
+		return nil, err
+	}
+	if len(conflicts) == 0 {
+		return nil, nil
+	}
+
+	if !override.Override {
+		return nil, &VenueConflictError{Conflicts: conflicts}
+	}
+	if override.Reason == "" {
+		return nil, errors.New("override_reason is required to override a venue conflict")
+	}
+
+	overrides := make([]VenueConflictOverride, 0, len(conflicts))
+	for _, conflict := range conflicts {
+		overrides = append(overrides, VenueConflictOverride{
+			ConflictingEventID: uuid.MustParse(conflict.EventID),
+			PhysicalVenueID:    schedule.PhysicalVenueID,
+			Reason:             override.Reason,
+		})
+	}
+	return overrides, nil
+}
+
+// checkReschedule re-checks the venue when an update moves or lengthens an event
+func (s *service) checkReschedule(current *Event, req UpdateEventRequest) ([]VenueConflictOverride, error) {
+	if req.DateTime == nil && req.DurationMinutes == nil {
+		return nil, nil
+	}
+	if req.Status != nil && EventStatus(*req.Status) == EventStatusCancelled {
+		return nil, nil // A cancelled event frees the venue
+	}
+
+	start, duration := current.DateTime, current.DurationMinutes
+	if req.DateTime != nil {
+		start = *req.DateTime
+	}
+	if req.DurationMinutes != nil {
+		duration = *req.DurationMinutes
+	}
+
+	return s.checkVenueAvailability(current.VenueTemplateID, start, duration, current.ID,
+		venueOverride{Override: req.OverrideVenueConflict, Reason: req.OverrideReason})
+}
+
+// recordVenueOverrides writes the audit trail for an event saved over conflicts
+func (s *service) recordVenueOverrides(eventID, adminID uuid.UUID, overrides []VenueConflictOverride) {
+	if len(overrides) == 0 {
+		return
+	}
+
+	for i := range overrides {
+		overrides[i].EventID = eventID
+		overrides[i].AdminID = adminID
+		log.Info("Venue conflict overridden", "event_id", eventID,
+			"conflicting_event_id", overrides[i].ConflictingEventID, "admin_id", adminID, "reason", overrides[i].Reason)
+	}
+
+	if err := s.repo.CreateVenueConflictOverrides(overrides); err != nil {
+		log.Warn("Failed to record venue conflict overrides", "event_id", eventID, "error", err)
+	}
+}
+
+// GetVenueConflicts reports the events currently overlapping an event and past overrides
+func (s *service) GetVenueConflicts(eventID uuid.UUID) (*VenueConflictReport, error) {
+	event, err := s.repo.GetByID(eventID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("event not found")
+		}
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	conflicts, _, err := s.findVenueConflicts(event.VenueTemplateID, event.DateTime, event.DurationMinutes, event.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	overrides, err := s.repo.GetVenueConflictOverrides(eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get venue conflict overrides: %w", err)
+	}
+
+	report := &VenueConflictReport{
+		EventID:   eventID.String(),
+		Conflicts: conflicts,
+		Overrides: []VenueConflictOverride{},
+	}
+	report.Overrides = append(report.Overrides, overrides...)
+	return report, nil
+}
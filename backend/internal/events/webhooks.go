@@ -0,0 +1,70 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"evently/internal/webhooks"
+
+	"github.com/google/uuid"
+)
+
+// WebhookPublisher queues partner webhook events
+type WebhookPublisher interface {
+	Publish(ctx context.Context, eventType, dedupKey string, data interface{}) error
+}
+
+// EventWebhookData is the data of an event.updated webhook
+type EventWebhookData struct {
+	EventID       uuid.UUID   `json:"event_id"`
+	Name          string      `json:"name"`
+	Venue         string      `json:"venue"`
+	DateTime      time.Time   `json:"date_time"`
+	BasePrice     float64     `json:"base_price"`
+	Currency      string      `json:"currency"`
+	Status        EventStatus `json:"status"`
+	ChangedFields []string    `json:"changed_fields"`
+	UpdatedAt     time.Time   `json:"updated_at"`
+}
+
+func (s *service) SetWebhookPublisher(publisher WebhookPublisher) {
+	s.webhookPublisher = publisher
+}
+
+// publishUpdated sends event.updated to subscribed partners. A failure is only
+// logged because the update itself has already been saved.
+func (s *service) publishUpdated(event *Event, updates map[string]interface{}, tagsChanged bool) {
+	if s.webhookPublisher == nil || event == nil {
+		return
+	}
+
+	changed := make([]string, 0, len(updates)+1)
+	for field := range updates {
+		if field != "updated_at" {
+			changed = append(changed, field)
+		}
+	}
+	if tagsChanged {
+		changed = append(changed, "tags")
+	}
+	sort.Strings(changed)
+
+	data := EventWebhookData{
+		EventID:       event.ID,
+		Name:          event.Name,
+		Venue:         event.Venue,
+		DateTime:      event.DateTime,
+		BasePrice:     event.BasePrice,
+		Currency:      event.Currency,
+		Status:        event.Status,
+		ChangedFields: changed,
+		UpdatedAt:     event.UpdatedAt,
+	}
+	dedupKey := fmt.Sprintf("event:%s:updated:%d", event.ID, event.UpdatedAt.UnixNano())
+
+	if err := s.webhookPublisher.Publish(context.Background(), webhooks.EventEventUpdated, dedupKey, data); err != nil {
+		log.Warn("Failed to publish event.updated webhook", "event_id", event.ID, "error", err)
+	}
+}
@@ -0,0 +1,149 @@
+package favorites
+
+import (
+	"net/http"
+
+	"evently/internal/shared/utils/response"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type Controller struct {
+	service Service
+}
+
+func NewController(service Service) *Controller {
+	return &Controller{service: service}
+}
+
+// AddFavorite godoc
+//
+// @Summary      Favorite an event
+// @Description  Save an event to the user's favorites. Favoriting twice is a no-op. Users are emailed when a favorited event is about to sell out or its price drops.
+// @Tags         Favorites
+// @Produce      json
+// @Security     Bearer
+// @Param        eventId path string true "Event ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=object} "Event added to favorites"
+// @Failure      400 {object} response.StandardApiResponse "Event was cancelled"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Event not found"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/events/{eventId}/favorite [post]
+func (ctrl *Controller) AddFavorite(c *gin.Context) {
+	eventID, err := uuid.Parse(c.Param("eventId"))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid event ID", nil, err.Error())
+		return
+	}
+
+	userID, ok := ctrl.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := ctrl.service.AddFavorite(c.Request.Context(), userID, eventID); err != nil {
+		statusCode := http.StatusInternalServerError
+		switch err.Error() {
+		case "event not found":
+			statusCode = http.StatusNotFound
+		case "cancelled events cannot be favorited":
+			statusCode = http.StatusBadRequest
+		}
+		response.RespondJSON(c, "error", statusCode, err.Error(), nil, nil)
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Event added to favorites", gin.H{"event_id": eventID, "is_favorited": true}, nil)
+}
+
+// RemoveFavorite godoc
+//
+// @Summary      Unfavorite an event
+// @Tags         Favorites
+// @Produce      json
+// @Security     Bearer
+// @Param        eventId path string true "Event ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=object} "Event removed from favorites"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Event is not in the user's favorites"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/events/{eventId}/favorite [delete]
+func (ctrl *Controller) RemoveFavorite(c *gin.Context) {
+	eventID, err := uuid.Parse(c.Param("eventId"))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid event ID", nil, err.Error())
+		return
+	}
+
+	userID, ok := ctrl.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := ctrl.service.RemoveFavorite(c.Request.Context(), userID, eventID); err != nil {
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "favorite not found" {
+			statusCode = http.StatusNotFound
+		}
+		response.RespondJSON(c, "error", statusCode, err.Error(), nil, nil)
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Event removed from favorites", gin.H{"event_id": eventID, "is_favorited": false}, nil)
+}
+
+// ListFavorites godoc
+//
+// @Summary      List favorite events
+// @Description  The signed-in user's favorited events, most recently saved first
+// @Tags         Favorites
+// @Produce      json
+// @Security     Bearer
+// @Param        page query integer false "Page" default(1) minimum(1)
+// @Param        limit query integer false "Limit" default(20) minimum(1) maximum(100)
+// @Success      200 {object} response.StandardApiResponse{data=PaginatedFavorites} "Favorites retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/users/me/favorites [get]
+func (ctrl *Controller) ListFavorites(c *gin.Context) {
+	userID, ok := ctrl.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var query FavoriteListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid query parameters", nil, err.Error())
+		return
+	}
+
+	favorites, err := ctrl.service.ListFavorites(c.Request.Context(), userID, query)
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusInternalServerError, "Failed to retrieve favorites", nil, err.Error())
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Favorites retrieved successfully", favorites, nil)
+}
+
+func (ctrl *Controller) currentUserID(c *gin.Context) (uuid.UUID, bool) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.RespondJSON(c, "error", http.StatusUnauthorized, "User not authenticated", nil, nil)
+		return uuid.Nil, false
+	}
+
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusInternalServerError, "Invalid user ID format", nil, nil)
+		return uuid.Nil, false
+	}
+	return userUUID, true
+}
@@ -0,0 +1,20 @@
+package favorites
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventFavorite is an event a user saved to their wishlist
+type EventFavorite struct {
+	ID                uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	UserID            uuid.UUID  `gorm:"type:uuid;not null;index;uniqueIndex:idx_event_favorite_user" json:"user_id"`
+	EventID           uuid.UUID  `gorm:"type:uuid;not null;index;uniqueIndex:idx_event_favorite_user" json:"event_id"`
+	SellOutNotifiedAt *time.Time `json:"sell_out_notified_at,omitempty"` // Set once the selling-out email is queued
+	CreatedAt         time.Time  `json:"created_at"`
+}
+
+func (EventFavorite) TableName() string {
+	return "event_favorites"
+}
@@ -0,0 +1,214 @@
+package favorites
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"evently/internal/outbox"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	Add(ctx context.Context, favorite *EventFavorite) error
+	Remove(ctx context.Context, userID, eventID uuid.UUID) (bool, error)
+	ListByUser(ctx context.Context, userID uuid.UUID, limit, offset int) ([]FavoriteEvent, int64, error)
+	GetFavoritedEventIDs(ctx context.Context, userID uuid.UUID, eventIDs []uuid.UUID) ([]uuid.UUID, error)
+
+	// Event lookups
+	GetEventSummary(ctx context.Context, eventID uuid.UUID) (*EventSummary, error)
+	GetSellingOutEvents(ctx context.Context, remainingRatio float64, limit int) ([]SellingOutEvent, error)
+
+	// Notifications
+	GetFavoriteUserIDs(ctx context.Context, eventID uuid.UUID) ([]uuid.UUID, error)
+	GetUnnotifiedFavorites(ctx context.Context, eventID uuid.UUID) ([]EventFavorite, error)
+	MarkSellOutQueued(ctx context.Context, favoriteIDs []uuid.UUID, messages []*outbox.Message) error
+	EnqueueNotifications(ctx context.Context, messages []*outbox.Message) error
+}
+
+// EventSummary is the slice of event data needed to favorite an event
+type EventSummary struct {
+	ID        uuid.UUID
+	Name      string
+	DateTime  time.Time
+	BasePrice float64
+	Status    string
+}
+
+// SellingOutEvent is an upcoming favorited event close to selling out
+type SellingOutEvent struct {
+	EventID       uuid.UUID
+	EventName     string
+	TotalCapacity int
+	BookedCount   int
+}
+
+// RemainingSeats is the number of seats not yet booked
+func (e *SellingOutEvent) RemainingSeats() int {
+	return e.TotalCapacity - e.BookedCount
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+//  FAVORITES
+
+// Add saves a favorite; favoriting an event twice is a no-op
+func (r *repository) Add(ctx context.Context, favorite *EventFavorite) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "event_id"}},
+			DoNothing: true,
+		}).
+		Create(favorite).Error
+}
+
+func (r *repository) Remove(ctx context.Context, userID, eventID uuid.UUID) (bool, error) {
+	result := r.db.WithContext(ctx).Delete(&EventFavorite{}, "user_id = ? AND event_id = ?", userID, eventID)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *repository) ListByUser(ctx context.Context, userID uuid.UUID, limit, offset int) ([]FavoriteEvent, int64, error) {
+	var total int64
+	err := r.db.WithContext(ctx).
+		Model(&EventFavorite{}).
+		Where("user_id = ?", userID).
+		Count(&total).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count favorites: %w", err)
+	}
+
+	var favorites []FavoriteEvent
+	err = r.db.WithContext(ctx).
+		Table("event_favorites f").
+		Select("e.id AS event_id, e.name, e.venue, e.date_time, e.base_price, e.image_url, e.status, f.created_at AS favorited_at").
+		Joins("JOIN events e ON e.id = f.event_id AND e.deleted_at IS NULL").
+		Where("f.user_id = ?", userID).
+		Order("f.created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Scan(&favorites).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list favorites: %w", err)
+	}
+
+	return favorites, total, nil
+}
+
+func (r *repository) GetFavoritedEventIDs(ctx context.Context, userID uuid.UUID, eventIDs []uuid.UUID) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	if len(eventIDs) == 0 {
+		return ids, nil
+	}
+
+	err := r.db.WithContext(ctx).
+		Model(&EventFavorite{}).
+		Where("user_id = ? AND event_id IN ?", userID, eventIDs).
+		Pluck("event_id", &ids).Error
+	return ids, err
+}
+
+//  EVENT LOOKUPS
+
+func (r *repository) GetEventSummary(ctx context.Context, eventID uuid.UUID) (*EventSummary, error) {
+	var event EventSummary
+	err := r.db.WithContext(ctx).
+		Table("events").
+		Select("id, name, date_time, base_price, status").
+		Where("id = ? AND deleted_at IS NULL", eventID).
+		Take(&event).Error
+	if err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// GetSellingOutEvents returns upcoming published events with favorites still to notify
+// whose remaining seats have dropped to the given share of capacity
+func (r *repository) GetSellingOutEvents(ctx context.Context, remainingRatio float64, limit int) ([]SellingOutEvent, error) {
+	var events []SellingOutEvent
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT
+			e.id AS event_id,
+			e.name AS event_name,
+			cap.total_capacity,
+			COALESCE(bk.booked_count, 0) AS booked_count
+		FROM events e
+		JOIN (
+			SELECT template_id, SUM(total_seats) AS total_capacity
+			FROM venue_sections
+			GROUP BY template_id
+		) cap ON cap.template_id = e.venue_template_id
+		LEFT JOIN (
+			SELECT sb.event_id, COUNT(*) AS booked_count
+			FROM seat_bookings sb
+			JOIN bookings b ON b.id = sb.booking_id AND b.status = 'CONFIRMED'
+			GROUP BY sb.event_id
+		) bk ON bk.event_id = e.id
+		WHERE e.status = 'published'
+			AND e.deleted_at IS NULL
+			AND e.date_time > NOW()
+			AND cap.total_capacity > 0
+			AND cap.total_capacity - COALESCE(bk.booked_count, 0) > 0
+			AND cap.total_capacity - COALESCE(bk.booked_count, 0) <= cap.total_capacity * ?
+			AND EXISTS (
+				SELECT 1 FROM event_favorites f
+				WHERE f.event_id = e.id AND f.sell_out_notified_at IS NULL
+			)
+		ORDER BY e.date_time ASC
+		LIMIT ?
+	`, remainingRatio, limit).Scan(&events).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get selling out events: %w", err)
+	}
+	return events, nil
+}
+
+//  NOTIFICATIONS
+
+func (r *repository) GetFavoriteUserIDs(ctx context.Context, eventID uuid.UUID) ([]uuid.UUID, error) {
+	var userIDs []uuid.UUID
+	err := r.db.WithContext(ctx).
+		Model(&EventFavorite{}).
+		Where("event_id = ?", eventID).
+		Pluck("user_id", &userIDs).Error
+	return userIDs, err
+}
+
+func (r *repository) GetUnnotifiedFavorites(ctx context.Context, eventID uuid.UUID) ([]EventFavorite, error) {
+	var favorites []EventFavorite
+	err := r.db.WithContext(ctx).
+		Where("event_id = ? AND sell_out_notified_at IS NULL", eventID).
+		Find(&favorites).Error
+	return favorites, err
+}
+
+// MarkSellOutQueued flags the favorites as notified and writes their emails to the
+// outbox in the same transaction
+func (r *repository) MarkSellOutQueued(ctx context.Context, favoriteIDs []uuid.UUID, messages []*outbox.Message) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&EventFavorite{}).
+			Where("id IN ?", favoriteIDs).
+			Update("sell_out_notified_at", time.Now()).Error
+		if err != nil {
+			return fmt.Errorf("failed to mark favorites notified: %w", err)
+		}
+
+		return outbox.Enqueue(tx, messages...)
+	})
+}
+
+func (r *repository) EnqueueNotifications(ctx context.Context, messages []*outbox.Message) error {
+	return outbox.Enqueue(r.db.WithContext(ctx), messages...)
+}
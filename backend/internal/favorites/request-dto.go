@@ -0,0 +1,6 @@
+package favorites
+
+type FavoriteListQuery struct {
+	Page  int `form:"page,default=1" binding:"min=1"`
+	Limit int `form:"limit,default=20" binding:"min=1,max=100"`
+}
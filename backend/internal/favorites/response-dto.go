@@ -0,0 +1,30 @@
+package favorites
+
+import (
+	"time"
+
+	"evently/internal/shared/utils/response"
+)
+
+// FavoriteEvent is a favorited event with the details needed to render a wishlist
+type FavoriteEvent struct {
+	EventID     string    `json:"event_id"`
+	Name        string    `json:"name"`
+	Venue       string    `json:"venue"`
+	DateTime    time.Time `json:"date_time"`
+	BasePrice   float64   `json:"base_price"`
+	ImageURL    string    `json:"image_url"`
+	Status      string    `json:"status"`
+	FavoritedAt time.Time `json:"favorited_at"`
+}
+
+type PaginatedFavorites struct {
+	Favorites  []FavoriteEvent `json:"favorites"`
+	TotalCount int64           `json:"total_count"`
+	Page       int             `json:"page"`
+	Limit      int             `json:"limit"`
+}
+
+func (p PaginatedFavorites) PaginationMeta() response.Pagination {
+	return response.NewPagination(p.Page, p.Limit, p.TotalCount)
+}
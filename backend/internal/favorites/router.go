@@ -0,0 +1,22 @@
+package favorites
+
+import (
+	"evently/internal/shared/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupFavoriteRoutes(rg *gin.RouterGroup, controller *Controller) {
+	events := rg.Group("/events")
+	events.Use(middleware.JWTAuth(), middleware.RequireRoles("USER", "ADMIN"))
+	{
+		events.POST("/:eventId/favorite", controller.AddFavorite)      // POST /api/v1/events/:eventId/favorite
+		events.DELETE("/:eventId/favorite", controller.RemoveFavorite) // DELETE /api/v1/events/:eventId/favorite
+	}
+
+	users := rg.Group("/users/me")
+	users.Use(middleware.JWTAuth(), middleware.RequireRoles("USER", "ADMIN"))
+	{
+		users.GET("/favorites", controller.ListFavorites) // GET /api/v1/users/me/favorites
+	}
+}
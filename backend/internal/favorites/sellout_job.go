@@ -0,0 +1,82 @@
+package favorites
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// SellOutWatchConfig contains configuration for the selling-out watch job
+type SellOutWatchConfig struct {
+	CheckInterval  time.Duration
+	RemainingRatio float64 // Notify once remaining seats drop to this share of capacity
+	BatchSize      int
+}
+
+// DefaultSellOutWatchConfig returns default selling-out watch configuration
+func DefaultSellOutWatchConfig() *SellOutWatchConfig {
+	return &SellOutWatchConfig{
+		CheckInterval:  5 * time.Minute, // Check every 5 minutes
+		RemainingRatio: 0.1,             // Notify when 10% of seats or fewer remain
+		BatchSize:      50,              // Process up to 50 events per run
+	}
+}
+
+// SellOutWatchJob notifies users when a favorited event is about to sell out
+type SellOutWatchJob struct {
+	service Service
+	config  *SellOutWatchConfig
+	done    chan struct{}
+}
+
+// NewSellOutWatchJob creates a new selling-out watch job
+func NewSellOutWatchJob(service Service, config *SellOutWatchConfig) *SellOutWatchJob {
+	if config == nil {
+		config = DefaultSellOutWatchConfig()
+	}
+
+	return &SellOutWatchJob{
+		service: service,
+		config:  config,
+		done:    make(chan struct{}),
+	}
+}
+
+// Start starts the selling-out watch job
+func (j *SellOutWatchJob) Start(ctx context.Context) {
+	log.Printf("Started favorites sell-out watch job with %v interval", j.config.CheckInterval)
+	go j.run(ctx)
+}
+
+// Stop stops the selling-out watch job
+func (j *SellOutWatchJob) Stop() {
+	close(j.done)
+}
+
+func (j *SellOutWatchJob) run(ctx context.Context) {
+	ticker := time.NewTicker(j.config.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			j.check(ctx)
+		case <-j.done:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (j *SellOutWatchJob) check(ctx context.Context) {
+	queued, err := j.service.NotifySellingOut(ctx, j.config.RemainingRatio, j.config.BatchSize)
+	if err != nil {
+		log.Printf("Failed to check favorited events for sell-out: %v", err)
+		return
+	}
+
+	if queued > 0 {
+		log.Printf("Queued %d selling out notifications for favorited events", queued)
+	}
+}
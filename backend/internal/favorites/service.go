@@ -0,0 +1,216 @@
+package favorites
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"evently/internal/outbox"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	NotificationTypeSellingOut = "FAVORITE_SELLING_OUT"
+	NotificationTypePriceDrop  = "FAVORITE_PRICE_DROP"
+)
+
+type Service interface {
+	// Wishlist
+	AddFavorite(ctx context.Context, userID, eventID uuid.UUID) error
+	RemoveFavorite(ctx context.Context, userID, eventID uuid.UUID) error
+	ListFavorites(ctx context.Context, userID uuid.UUID, query FavoriteListQuery) (*PaginatedFavorites, error)
+	GetFavoritedEventIDs(ctx context.Context, userID uuid.UUID, eventIDs []uuid.UUID) (map[uuid.UUID]bool, error)
+
+	// Notifications
+	NotifyPriceDrop(ctx context.Context, eventID uuid.UUID, oldPrice, newPrice float64) error
+	NotifySellingOut(ctx context.Context, remainingRatio float64, limit int) (int, error)
+}
+
+type service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) Service {
+	return &service{repo: repo}
+}
+
+//  WISHLIST
+
+func (s *service) AddFavorite(ctx context.Context, userID, eventID uuid.UUID) error {
+	event, err := s.repo.GetEventSummary(ctx, eventID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("event not found")
+		}
+		return fmt.Errorf("failed to get event: %w", err)
+	}
+
+	if event.Status == "cancelled" {
+		return errors.New("cancelled events cannot be favorited")
+	}
+
+	favorite := &EventFavorite{
+		UserID:  userID,
+		EventID: eventID,
+	}
+	if err := s.repo.Add(ctx, favorite); err != nil {
+		return fmt.Errorf("failed to add favorite: %w", err)
+	}
+	return nil
+}
+
+func (s *service) RemoveFavorite(ctx context.Context, userID, eventID uuid.UUID) error {
+	removed, err := s.repo.Remove(ctx, userID, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to remove favorite: %w", err)
+	}
+	if !removed {
+		return errors.New("favorite not found")
+	}
+	return nil
+}
+
+func (s *service) ListFavorites(ctx context.Context, userID uuid.UUID, query FavoriteListQuery) (*PaginatedFavorites, error) {
+	offset := (query.Page - 1) * query.Limit
+
+	favorites, total, err := s.repo.ListByUser(ctx, userID, query.Limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	if favorites == nil {
+		favorites = []FavoriteEvent{}
+	}
+
+	return &PaginatedFavorites{
+		Favorites:  favorites,
+		TotalCount: total,
+		Page:       query.Page,
+		Limit:      query.Limit,
+	}, nil
+}
+
+// GetFavoritedEventIDs returns which of the given events the user has favorited
+func (s *service) GetFavoritedEventIDs(ctx context.Context, userID uuid.UUID, eventIDs []uuid.UUID) (map[uuid.UUID]bool, error) {
+	ids, err := s.repo.GetFavoritedEventIDs(ctx, userID, eventIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get favorited events: %w", err)
+	}
+
+	favorited := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		favorited[id] = true
+	}
+	return favorited, nil
+}
+
+//  NOTIFICATIONS
+
+// NotifyPriceDrop queues a price drop email for every user who favorited an
+// upcoming event. The dedup key includes the new price so each distinct drop
+// is sent once, even if the update is retried.
+func (s *service) NotifyPriceDrop(ctx context.Context, eventID uuid.UUID, oldPrice, newPrice float64) error {
+	if newPrice >= oldPrice {
+		return nil
+	}
+
+	event, err := s.repo.GetEventSummary(ctx, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to get event: %w", err)
+	}
+	if event.Status != "published" || !event.DateTime.After(time.Now()) {
+		return nil
+	}
+
+	userIDs, err := s.repo.GetFavoriteUserIDs(ctx, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to get favorites: %w", err)
+	}
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	priceCents := int64(math.Round(newPrice * 100))
+	messages := make([]*outbox.Message, 0, len(userIDs))
+	for _, userID := range userIDs {
+		payload := &outbox.NotificationPayload{
+			Type:        NotificationTypePriceDrop,
+			RecipientID: userID,
+			EventID:     &eventID,
+			TemplateData: map[string]interface{}{
+				"old_price": oldPrice,
+				"new_price": newPrice,
+			},
+		}
+
+		dedupKey := fmt.Sprintf("favorite:price-drop:%s:%s:%d", eventID, userID, priceCents)
+		message, err := outbox.NewNotificationMessage(outbox.AggregateEventFavorite, eventID, dedupKey, payload)
+		if err != nil {
+			return err
+		}
+		messages = append(messages, message)
+	}
+
+	if err := s.repo.EnqueueNotifications(ctx, messages); err != nil {
+		return err
+	}
+
+	log.Printf("💸 Queued %d price drop notifications for event %s", len(messages), eventID)
+	return nil
+}
+
+// NotifySellingOut queues a one-off email to users whose favorited events have
+// dropped to the given share of remaining seats. Returns the number queued.
+func (s *service) NotifySellingOut(ctx context.Context, remainingRatio float64, limit int) (int, error) {
+	events, err := s.repo.GetSellingOutEvents(ctx, remainingRatio, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	queued := 0
+	for _, event := range events {
+		favorites, err := s.repo.GetUnnotifiedFavorites(ctx, event.EventID)
+		if err != nil {
+			log.Printf("Failed to get favorites for event %s: %v", event.EventID, err)
+			continue
+		}
+		if len(favorites) == 0 {
+			continue
+		}
+
+		favoriteIDs := make([]uuid.UUID, 0, len(favorites))
+		messages := make([]*outbox.Message, 0, len(favorites))
+		for _, favorite := range favorites {
+			eventID := event.EventID
+			payload := &outbox.NotificationPayload{
+				Type:        NotificationTypeSellingOut,
+				RecipientID: favorite.UserID,
+				EventID:     &eventID,
+				TemplateData: map[string]interface{}{
+					"remaining_seats": event.RemainingSeats(),
+					"total_capacity":  event.TotalCapacity,
+				},
+			}
+
+			message, err := outbox.NewNotificationMessage(outbox.AggregateEventFavorite, favorite.ID,
+				fmt.Sprintf("favorite:selling-out:%s", favorite.ID), payload)
+			if err != nil {
+				return queued, err
+			}
+			favoriteIDs = append(favoriteIDs, favorite.ID)
+			messages = append(messages, message)
+		}
+
+		if err := s.repo.MarkSellOutQueued(ctx, favoriteIDs, messages); err != nil {
+			log.Printf("Failed to queue selling out notifications for event %s: %v", event.EventID, err)
+			continue
+		}
+		queued += len(messages)
+	}
+
+	return queued, nil
+}
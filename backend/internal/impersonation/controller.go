@@ -0,0 +1,206 @@
+package impersonation
+
+import (
+	"errors"
+	"net/http"
+
+	"evently/internal/shared/utils/response"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type Controller struct {
+	service Service
+}
+
+func NewController(service Service) *Controller {
+	return &Controller{service: service}
+}
+
+// StartSession issues a time-limited token for acting as a user
+//
+// @Summary      Start impersonating a user (Admin)
+// @Description  Issues a short-lived access token that acts as the user. There is no refresh token. Every request made with it is recorded against the session, responses carry an impersonation banner, and password, 2FA and email changes are refused.
+// @Description
+// @Description  Requires the `users:impersonate` permission.
+// @Tags         Admin Impersonation
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        userId path string true "User ID" Format(uuid)
+// @Param        request body StartRequest true "Request body"
+// @Success      201 {object} response.StandardApiResponse{data=StartResponse} "Impersonation started"
+// @Failure      400 {object} response.StandardApiResponse "Invalid request or duration longer than allowed"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse "Admins and the caller themselves cannot be impersonated"
+// @Failure      404 {object} response.StandardApiResponse "User not found"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/users/{userId}/impersonate [post]
+func (ctrl *Controller) StartSession(c *gin.Context) {
+	adminID, ok := ctrl.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	userID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid user ID", nil, nil)
+		return
+	}
+
+	var req StartRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid request body", nil, err.Error())
+		return
+	}
+
+	session, err := ctrl.service.StartSession(c.Request.Context(), adminID, userID, req, c.ClientIP())
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrUserNotFound):
+			response.RespondJSON(c, "error", http.StatusNotFound, err.Error(), nil, nil)
+		case errors.Is(err, ErrCannotImpersonate), errors.Is(err, ErrCannotImpersonateSelf):
+			response.RespondJSON(c, "error", http.StatusForbidden, err.Error(), nil, nil)
+		case errors.Is(err, ErrDurationTooLong):
+			response.RespondJSON(c, "error", http.StatusBadRequest, err.Error(), nil, nil)
+		default:
+			response.RespondJSON(c, "error", http.StatusInternalServerError, "Failed to start impersonation", nil, err.Error())
+		}
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusCreated, "Impersonation started, every request made with this token is recorded", session, nil)
+}
+
+// ListSessions lists impersonation sessions, newest first
+//
+// @Summary      List impersonation sessions (Admin)
+// @Description  Requires the `users:impersonate` permission.
+// @Tags         Admin Impersonation
+// @Produce      json
+// @Security     Bearer
+// @Param        admin_id query string false "Admin ID" Format(uuid)
+// @Param        user_id query string false "User ID" Format(uuid)
+// @Param        active query boolean false "Only sessions whose token is still accepted"
+// @Param        page query integer false "Page" default(1)
+// @Param        limit query integer false "Limit" default(20) maximum(100)
+// @Success      200 {object} response.StandardApiResponse{data=PaginatedSessions} "Impersonation sessions retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/impersonations [get]
+func (ctrl *Controller) ListSessions(c *gin.Context) {
+	var query SessionListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid query parameters", nil, err.Error())
+		return
+	}
+
+	sessions, err := ctrl.service.ListSessions(c.Request.Context(), query)
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusInternalServerError, "Failed to list impersonation sessions", nil, err.Error())
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Impersonation sessions retrieved successfully", sessions, nil)
+}
+
+// GetSession returns a session with every request made in it
+//
+// @Summary      Get impersonation session (Admin)
+// @Description  The session with every request made in it, oldest first
+// @Description
+// @Description  Requires the `users:impersonate` permission.
+// @Tags         Admin Impersonation
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=SessionDetail} "Impersonation session retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Impersonation session not found"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/impersonations/{id} [get]
+func (ctrl *Controller) GetSession(c *gin.Context) {
+	sessionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid session ID", nil, nil)
+		return
+	}
+
+	session, err := ctrl.service.GetSession(c.Request.Context(), sessionID)
+	if err != nil {
+		if errors.Is(err, ErrSessionNotFound) {
+			response.RespondJSON(c, "error", http.StatusNotFound, err.Error(), nil, nil)
+			return
+		}
+		response.RespondJSON(c, "error", http.StatusInternalServerError, "Failed to get impersonation session", nil, err.Error())
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Impersonation session retrieved successfully", session, nil)
+}
+
+// EndSession revokes a session's token before it expires
+//
+// @Summary      End impersonation session (Admin)
+// @Description  Revokes the session's token immediately
+// @Description
+// @Description  Requires the `users:impersonate` permission.
+// @Tags         Admin Impersonation
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=Session} "Impersonation session ended successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Impersonation session not found"
+// @Failure      409 {object} response.StandardApiResponse "Session has already ended"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/impersonations/{id}/end [post]
+func (ctrl *Controller) EndSession(c *gin.Context) {
+	adminID, ok := ctrl.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	sessionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid session ID", nil, nil)
+		return
+	}
+
+	session, err := ctrl.service.EndSession(c.Request.Context(), sessionID, adminID)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrSessionNotFound):
+			response.RespondJSON(c, "error", http.StatusNotFound, err.Error(), nil, nil)
+		case errors.Is(err, ErrSessionEnded):
+			response.RespondJSON(c, "error", http.StatusConflict, err.Error(), nil, nil)
+		default:
+			response.RespondJSON(c, "error", http.StatusInternalServerError, "Failed to end impersonation session", nil, err.Error())
+		}
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Impersonation session ended successfully", session, nil)
+}
+
+func (ctrl *Controller) currentUserID(c *gin.Context) (uuid.UUID, bool) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.RespondJSON(c, "error", http.StatusUnauthorized, "User not authenticated", nil, nil)
+		return uuid.Nil, false
+	}
+
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusInternalServerError, "Invalid user ID format", nil, nil)
+		return uuid.Nil, false
+	}
+	return userUUID, true
+}
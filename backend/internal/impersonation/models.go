@@ -0,0 +1,58 @@
+package impersonation
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Session is a support admin acting as a user. It is open until its token
+// expires or an admin ends it, and is kept afterwards as an audit record.
+type Session struct {
+	ID        uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	AdminID   uuid.UUID  `gorm:"type:uuid;not null;index" json:"admin_id"`
+	UserID    uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	Reason    string     `gorm:"type:varchar(500);not null" json:"reason"`
+	ExpiresAt time.Time  `gorm:"not null" json:"expires_at"`
+	EndedAt   *time.Time `json:"ended_at,omitempty"`
+	EndedBy   *uuid.UUID `gorm:"type:uuid" json:"ended_by,omitempty"`
+	RequestIP string     `gorm:"type:varchar(45)" json:"request_ip,omitempty"`
+	CreatedAt time.Time  `gorm:"index" json:"created_at"`
+}
+
+func (Session) TableName() string {
+	return "impersonation_sessions"
+}
+
+// Active reports whether the session's token is still accepted
+func (s *Session) Active(now time.Time) bool {
+	return s.EndedAt == nil && now.Before(s.ExpiresAt)
+}
+
+// Action is a request made during an impersonation session
+type Action struct {
+	ID         uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	SessionID  uuid.UUID `gorm:"type:uuid;not null;index" json:"session_id"`
+	AdminID    uuid.UUID `gorm:"type:uuid;not null" json:"admin_id"`
+	UserID     uuid.UUID `gorm:"type:uuid;not null" json:"user_id"`
+	Method     string    `gorm:"type:varchar(10);not null" json:"method"`
+	Path       string    `gorm:"type:varchar(500);not null" json:"path"`
+	Route      string    `gorm:"type:varchar(255)" json:"route,omitempty"` // Route pattern, empty when no route matched
+	StatusCode int       `gorm:"not null" json:"status_code"`
+	DurationMs int64     `gorm:"not null;default:0" json:"duration_ms"`
+	RequestIP  string    `gorm:"type:varchar(45)" json:"request_ip,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func (Action) TableName() string {
+	return "impersonation_actions"
+}
+
+// ImpersonationUser is the account being impersonated
+type ImpersonationUser struct {
+	ID        uuid.UUID
+	FirstName string
+	LastName  string
+	Email     string
+	Role      string
+}
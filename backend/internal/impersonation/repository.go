@@ -0,0 +1,108 @@
+package impersonation
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	GetUser(ctx context.Context, userID uuid.UUID) (*ImpersonationUser, error)
+	CreateSession(ctx context.Context, session *Session) error
+	GetSession(ctx context.Context, id uuid.UUID) (*Session, error)
+	ListSessions(ctx context.Context, query SessionListQuery) ([]Session, int64, error)
+	EndSession(ctx context.Context, id, endedBy uuid.UUID, at time.Time) (bool, error)
+	CreateAction(ctx context.Context, action *Action) error
+	GetActions(ctx context.Context, sessionID uuid.UUID) ([]Action, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) GetUser(ctx context.Context, userID uuid.UUID) (*ImpersonationUser, error) {
+	var user ImpersonationUser
+	err := r.db.WithContext(ctx).
+		Table("users").
+		Select("id, first_name, last_name, email, role").
+		Where("id = ? AND deleted_at IS NULL", userID).
+		Take(&user).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *repository) CreateSession(ctx context.Context, session *Session) error {
+	return r.db.WithContext(ctx).Create(session).Error
+}
+
+func (r *repository) GetSession(ctx context.Context, id uuid.UUID) (*Session, error) {
+	var session Session
+	err := r.db.WithContext(ctx).First(&session, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSessionNotFound
+		}
+		return nil, err
+	}
+	return &session, nil
+}
+
+// ListSessions returns sessions newest first
+func (r *repository) ListSessions(ctx context.Context, query SessionListQuery) ([]Session, int64, error) {
+	db := r.db.WithContext(ctx).Model(&Session{})
+	if query.AdminID != "" {
+		db = db.Where("admin_id = ?", query.AdminID)
+	}
+	if query.UserID != "" {
+		db = db.Where("user_id = ?", query.UserID)
+	}
+	if query.ActiveOnly {
+		db = db.Where("ended_at IS NULL AND expires_at > ?", time.Now())
+	}
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var sessions []Session
+	err := db.Order("created_at DESC").
+		Offset((query.Page - 1) * query.Limit).
+		Limit(query.Limit).
+		Find(&sessions).Error
+	return sessions, total, err
+}
+
+// EndSession closes an open session. It reports false when the session had
+// already ended.
+func (r *repository) EndSession(ctx context.Context, id, endedBy uuid.UUID, at time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&Session{}).
+		Where("id = ? AND ended_at IS NULL", id).
+		Updates(map[string]interface{}{"ended_at": at, "ended_by": endedBy})
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *repository) CreateAction(ctx context.Context, action *Action) error {
+	return r.db.WithContext(ctx).Create(action).Error
+}
+
+func (r *repository) GetActions(ctx context.Context, sessionID uuid.UUID) ([]Action, error) {
+	var actions []Action
+	err := r.db.WithContext(ctx).
+		Where("session_id = ?", sessionID).
+		Order("created_at ASC").
+		Find(&actions).Error
+	return actions, err
+}
@@ -0,0 +1,16 @@
+package impersonation
+
+// StartRequest starts acting as a user
+type StartRequest struct {
+	Reason          string `json:"reason" binding:"required,min=10,max=500"` // Why support needs to see the account, e.g. a ticket number
+	DurationMinutes int    `json:"duration_minutes" binding:"omitempty,min=1"`
+}
+
+// SessionListQuery filters impersonation sessions
+type SessionListQuery struct {
+	AdminID    string `form:"admin_id" binding:"omitempty,uuid"`
+	UserID     string `form:"user_id" binding:"omitempty,uuid"`
+	ActiveOnly bool   `form:"active"`
+	Page       int    `form:"page,default=1" binding:"min=1"`
+	Limit      int    `form:"limit,default=20" binding:"min=1,max=100"`
+}
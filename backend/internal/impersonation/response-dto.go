@@ -0,0 +1,43 @@
+package impersonation
+
+import (
+	"time"
+
+	"evently/internal/shared/utils/response"
+)
+
+// StartResponse holds the token the admin uses to act as the user. There is
+// no refresh token, a new session is started once it expires.
+type StartResponse struct {
+	AccessToken string    `json:"access_token"`
+	ExpiresIn   int64     `json:"expires_in"` // Seconds
+	ExpiresAt   time.Time `json:"expires_at"`
+	Session     *Session  `json:"session"`
+	User        UserInfo  `json:"user"`
+}
+
+type UserInfo struct {
+	ID        string `json:"id"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Email     string `json:"email"`
+	Role      string `json:"role"`
+}
+
+// SessionDetail is a session with every request made in it, oldest first
+type SessionDetail struct {
+	Session
+	Active  bool     `json:"active"`
+	Actions []Action `json:"actions"`
+}
+
+type PaginatedSessions struct {
+	Sessions   []Session `json:"sessions"`
+	TotalCount int64     `json:"total_count"`
+	Page       int       `json:"page"`
+	Limit      int       `json:"limit"`
+}
+
+func (p PaginatedSessions) PaginationMeta() response.Pagination {
+	return response.NewPagination(p.Page, p.Limit, p.TotalCount)
+}
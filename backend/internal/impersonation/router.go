@@ -0,0 +1,18 @@
+package impersonation
+
+import (
+	"evently/internal/shared/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupImpersonationRoutes(rg *gin.RouterGroup, controller *Controller) {
+	admin := rg.Group("/admin")
+	admin.Use(middleware.JWTAuth(), middleware.RequirePermission(middleware.PermissionUsersImpersonate))
+	{
+		admin.POST("/users/:userId/impersonate", controller.StartSession) // POST /api/v1/admin/users/:userId/impersonate - Time-limited token for acting as the user
+		admin.GET("/impersonations", controller.ListSessions)             // GET /api/v1/admin/impersonations
+		admin.GET("/impersonations/:id", controller.GetSession)           // GET /api/v1/admin/impersonations/:id - With every request made in the session
+		admin.POST("/impersonations/:id/end", controller.EndSession)      // POST /api/v1/admin/impersonations/:id/end - Revoke the token early
+	}
+}
@@ -0,0 +1,260 @@
+package impersonation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"evently/internal/auth"
+	"evently/internal/shared/middleware"
+	"evently/internal/users"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrUserNotFound          = errors.New("user not found")
+	ErrSessionNotFound       = errors.New("impersonation session not found")
+	ErrSessionEnded          = errors.New("impersonation session has already ended")
+	ErrCannotImpersonate     = errors.New("admins cannot be impersonated")
+	ErrCannotImpersonateSelf = errors.New("you cannot impersonate yourself")
+	ErrDurationTooLong       = errors.New("duration_minutes is longer than impersonation tokens are allowed to last")
+)
+
+// Config contains configuration for impersonation
+type Config struct {
+	TTL    time.Duration // Token lifetime when the admin doesn't ask for one
+	MaxTTL time.Duration // Longest lifetime an admin can ask for
+}
+
+// DefaultConfig returns default impersonation configuration
+func DefaultConfig() *Config {
+	return &Config{
+		TTL:    15 * time.Minute,
+		MaxTTL: time.Hour,
+	}
+}
+
+type Service interface {
+	StartSession(ctx context.Context, adminID, userID uuid.UUID, req StartRequest, requestIP string) (*StartResponse, error)
+	EndSession(ctx context.Context, sessionID, adminID uuid.UUID) (*Session, error)
+	ListSessions(ctx context.Context, query SessionListQuery) (*PaginatedSessions, error)
+	GetSession(ctx context.Context, sessionID uuid.UUID) (*SessionDetail, error)
+
+	// middleware.ImpersonationAuditor
+	SessionActive(ctx context.Context, sessionID string) (bool, error)
+	RecordAction(ctx context.Context, action middleware.ImpersonatedAction)
+
+	SetConfig(config *Config)
+}
+
+type service struct {
+	repo      Repository
+	jwtSecret string
+	config    *Config
+}
+
+func NewService(repo Repository, jwtSecret string) Service {
+	return &service{
+		repo:      repo,
+		jwtSecret: jwtSecret,
+		config:    DefaultConfig(),
+	}
+}
+
+func (s *service) SetConfig(config *Config) {
+	s.config = config
+}
+
+// StartSession issues an access token for the user that is flagged with the
+// admin and session, so every request made with it is audited. Admin accounts
+// can't be impersonated, and the token can't be refreshed.
+func (s *service) StartSession(ctx context.Context, adminID, userID uuid.UUID, req StartRequest, requestIP string) (*StartResponse, error) {
+	if adminID == userID {
+		return nil, ErrCannotImpersonateSelf
+	}
+
+	ttl := s.config.TTL
+	if req.DurationMinutes > 0 {
+		ttl = time.Duration(req.DurationMinutes) * time.Minute
+		if ttl > s.config.MaxTTL {
+			return nil, fmt.Errorf("%w (at most %d minutes)", ErrDurationTooLong, int(s.config.MaxTTL.Minutes()))
+		}
+	}
+
+	user, err := s.repo.GetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role == string(users.RoleAdmin) {
+		return nil, ErrCannotImpersonate
+	}
+
+	now := time.Now()
+	session := &Session{
+		ID:        uuid.New(),
+		AdminID:   adminID,
+		UserID:    user.ID,
+		Reason:    strings.TrimSpace(req.Reason),
+		ExpiresAt: now.Add(ttl),
+		RequestIP: requestIP,
+		CreatedAt: now,
+	}
+
+	token, err := s.signToken(user, session, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign impersonation token: %w", err)
+	}
+
+	if err := s.repo.CreateSession(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to create impersonation session: %w", err)
+	}
+
+	log.Printf("🕵️ IMPERSONATION: Admin %s started session %s as user %s until %s (reason: %s)",
+		adminID, session.ID, user.ID, session.ExpiresAt.Format(time.RFC3339), session.Reason)
+
+	return &StartResponse{
+		AccessToken: token,
+		ExpiresIn:   int64(ttl.Seconds()),
+		ExpiresAt:   session.ExpiresAt,
+		Session:     session,
+		User: UserInfo{
+			ID:        user.ID.String(),
+			FirstName: user.FirstName,
+			LastName:  user.LastName,
+			Email:     user.Email,
+			Role:      user.Role,
+		},
+	}, nil
+}
+
+// EndSession stops a session's token from being accepted before it expires
+func (s *service) EndSession(ctx context.Context, sessionID, adminID uuid.UUID) (*Session, error) {
+	session, err := s.repo.GetSession(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if !session.Active(now) {
+		return nil, ErrSessionEnded
+	}
+
+	ended, err := s.repo.EndSession(ctx, sessionID, adminID, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to end impersonation session: %w", err)
+	}
+	if !ended {
+		return nil, ErrSessionEnded
+	}
+
+	session.EndedAt = &now
+	session.EndedBy = &adminID
+	log.Printf("🕵️ IMPERSONATION: Session %s ended by admin %s", sessionID, adminID)
+	return session, nil
+}
+
+func (s *service) ListSessions(ctx context.Context, query SessionListQuery) (*PaginatedSessions, error) {
+	sessions, total, err := s.repo.ListSessions(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list impersonation sessions: %w", err)
+	}
+	return &PaginatedSessions{
+		Sessions:   sessions,
+		TotalCount: total,
+		Page:       query.Page,
+		Limit:      query.Limit,
+	}, nil
+}
+
+func (s *service) GetSession(ctx context.Context, sessionID uuid.UUID) (*SessionDetail, error) {
+	session, err := s.repo.GetSession(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	actions, err := s.repo.GetActions(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get impersonated actions: %w", err)
+	}
+
+	return &SessionDetail{
+		Session: *session,
+		Active:  session.Active(time.Now()),
+		Actions: actions,
+	}, nil
+}
+
+func (s *service) SessionActive(ctx context.Context, sessionID string) (bool, error) {
+	id, err := uuid.Parse(sessionID)
+	if err != nil {
+		return false, nil
+	}
+
+	session, err := s.repo.GetSession(ctx, id)
+	if err != nil {
+		if errors.Is(err, ErrSessionNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return session.Active(time.Now()), nil
+}
+
+// RecordAction adds a request to the session's audit trail. A failed write is
+// logged rather than failing a request that has already been served.
+func (s *service) RecordAction(ctx context.Context, action middleware.ImpersonatedAction) {
+	sessionID, err := uuid.Parse(action.SessionID)
+	if err != nil {
+		return
+	}
+	adminID, _ := uuid.Parse(action.ImpersonatorID)
+	userID, _ := uuid.Parse(action.UserID)
+
+	path := action.Path
+	if len(path) > 500 {
+		path = path[:500]
+	}
+
+	record := &Action{
+		ID:         uuid.New(),
+		SessionID:  sessionID,
+		AdminID:    adminID,
+		UserID:     userID,
+		Method:     action.Method,
+		Path:       path,
+		Route:      action.Route,
+		StatusCode: action.StatusCode,
+		DurationMs: action.Duration.Milliseconds(),
+		RequestIP:  action.RequestIP,
+		CreatedAt:  time.Now(),
+	}
+	if err := s.repo.CreateAction(ctx, record); err != nil {
+		log.Printf("Failed to record impersonated %s %s in session %s: %v", action.Method, path, sessionID, err)
+	}
+}
+
+// signToken issues an access token for the user flagged with the impersonating
+// admin and the session, which JWT authentication checks on every request
+func (s *service) signToken(user *ImpersonationUser, session *Session, now time.Time) (string, error) {
+	claims := auth.JWTClaims{
+		UserID:          user.ID.String(),
+		Email:           user.Email,
+		Role:            user.Role,
+		Type:            "access",
+		ImpersonatorID:  session.AdminID.String(),
+		ImpersonationID: session.ID.String(),
+		RegisteredClaims: jwt.RegisteredClaims{
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(session.ExpiresAt),
+			Issuer:    "evently",
+			Subject:   user.ID.String(),
+		},
+	}
+
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(s.jwtSecret))
+}
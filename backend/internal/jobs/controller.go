@@ -0,0 +1,195 @@
+package jobs
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"evently/internal/shared/utils/response"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type Controller struct {
+	service Service
+}
+
+func NewController(service Service) *Controller {
+	return &Controller{service: service}
+}
+
+// ListJobs returns the current user's most recent jobs
+//
+// @Summary      List my jobs
+// @Description  The signed-in user's most recent jobs, newest first. Finished jobs are deleted after JOBS_RETENTION.
+// @Tags         Jobs
+// @Produce      json
+// @Security     Bearer
+// @Success      200 {object} response.StandardApiResponse{data=[]JobResponse} "Jobs retrieved successfully"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/jobs [get]
+func (ctrl *Controller) ListJobs(c *gin.Context) {
+	userUUID, _, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	jobs, err := ctrl.service.ListJobs(c.Request.Context(), userUUID)
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusInternalServerError, "Failed to get jobs", nil, err.Error())
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Jobs retrieved successfully", jobs, nil)
+}
+
+// GetJob is polled by clients for a job's status and progress
+//
+// @Summary      Get a job
+// @Description  Poll for a job's status and progress. Only the owner and admins can see a job.
+// @Tags         Jobs
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=JobResponse} "Job retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Job not found"
+// @Failure      409 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/jobs/{id} [get]
+func (ctrl *Controller) GetJob(c *gin.Context) {
+	userUUID, isAdmin, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	jobID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid job ID", nil, err.Error())
+		return
+	}
+
+	job, err := ctrl.service.GetJob(c.Request.Context(), jobID, userUUID, isAdmin)
+	if err != nil {
+		respondError(c, err, "Failed to get job")
+		return
+	}
+
+	// Running jobs change quickly, so clients should not reuse a cached poll
+	c.Header("Cache-Control", "no-store")
+	response.RespondJSON(c, "success", http.StatusOK, "Job retrieved successfully", job, nil)
+}
+
+// CancelJob cancels a queued job or asks a running one to stop
+//
+// @Summary      Cancel a job
+// @Description  Queued jobs are cancelled straight away; running jobs are asked to stop and become CANCELLED shortly after.
+// @Tags         Jobs
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=JobResponse} "Job cancelled"
+// @Success      202 {object} response.StandardApiResponse{data=JobResponse} "Cancellation requested, the running job stops shortly"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Job not found"
+// @Failure      409 {object} response.StandardApiResponse "Job has already finished"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/jobs/{id}/cancel [post]
+func (ctrl *Controller) CancelJob(c *gin.Context) {
+	userUUID, isAdmin, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	jobID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid job ID", nil, err.Error())
+		return
+	}
+
+	job, err := ctrl.service.CancelJob(c.Request.Context(), jobID, userUUID, isAdmin)
+	if err != nil {
+		respondError(c, err, "Failed to cancel job")
+		return
+	}
+
+	if job.Status == JobStatusCancelled {
+		response.RespondJSON(c, "success", http.StatusOK, "Job cancelled", job, nil)
+		return
+	}
+	response.RespondJSON(c, "success", http.StatusAccepted, "Cancellation requested, the job stops shortly", job, nil)
+}
+
+// DownloadResult streams the file a finished job produced
+//
+// @Summary      Download a job result
+// @Tags         Jobs
+// @Produce      json,application/octet-stream
+// @Security     Bearer
+// @Param        id path string true "ID" Format(uuid)
+// @Success      200 {file} file "The file the job produced, with the job's result_content_type"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Job not found"
+// @Failure      409 {object} response.StandardApiResponse "Job has no result to download"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/jobs/{id}/result [get]
+func (ctrl *Controller) DownloadResult(c *gin.Context) {
+	userUUID, isAdmin, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	jobID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid job ID", nil, err.Error())
+		return
+	}
+
+	job, file, err := ctrl.service.OpenResult(c.Request.Context(), jobID, userUUID, isAdmin)
+	if err != nil {
+		respondError(c, err, "Failed to download job result")
+		return
+	}
+	defer file.Close()
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, job.ResultName))
+	c.Header("Cache-Control", "private, no-store")
+	c.DataFromReader(http.StatusOK, job.ResultSize, job.ResultType, file, nil)
+}
+
+func currentUser(c *gin.Context) (uuid.UUID, bool, bool) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.RespondJSON(c, "error", http.StatusUnauthorized, "User not authenticated", nil, nil)
+		return uuid.Nil, false, false
+	}
+
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusInternalServerError, "Invalid user ID format", nil, nil)
+		return uuid.Nil, false, false
+	}
+
+	role, _ := c.Get("user_role")
+	return userUUID, role == "ADMIN", true
+}
+
+func respondError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, ErrJobNotFound):
+		response.RespondJSON(c, "error", http.StatusNotFound, err.Error(), nil, nil)
+	case errors.Is(err, ErrJobFinished), errors.Is(err, ErrNoResult):
+		response.RespondJSON(c, "error", http.StatusConflict, err.Error(), nil, nil)
+	default:
+		response.RespondJSON(c, "error", http.StatusInternalServerError, message, nil, err.Error())
+	}
+}
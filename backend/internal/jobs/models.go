@@ -0,0 +1,88 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type JobStatus string
+
+const (
+	JobStatusQueued    JobStatus = "QUEUED"    // Waiting for a worker
+	JobStatusRunning   JobStatus = "RUNNING"   // Claimed by a worker
+	JobStatusSucceeded JobStatus = "SUCCEEDED" // Finished, the result can be downloaded if it has one
+	JobStatusFailed    JobStatus = "FAILED"
+	JobStatusCancelled JobStatus = "CANCELLED"
+)
+
+// Finished reports whether the job has reached a final status
+func (s JobStatus) Finished() bool {
+	return s == JobStatusSucceeded || s == JobStatusFailed || s == JobStatusCancelled
+}
+
+// Job is a long-running export or task run by the worker pool. Only its owner
+// and admins can see it.
+type Job struct {
+	ID              uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	Type            string     `gorm:"type:varchar(50);not null;index" json:"type"`
+	OwnerID         uuid.UUID  `gorm:"type:uuid;not null;index" json:"owner_id"`
+	Status          JobStatus  `gorm:"type:varchar(20);not null;check:status IN ('QUEUED', 'RUNNING', 'SUCCEEDED', 'FAILED', 'CANCELLED');index:idx_jobs_status_created" json:"status"`
+	Progress        int        `gorm:"not null;default:0;check:progress BETWEEN 0 AND 100" json:"progress"` // Percent complete
+	ProgressMessage string     `gorm:"size:255" json:"progress_message,omitempty"`
+	Params          string     `gorm:"type:jsonb;not null;default:'{}'" json:"-"`
+	CancelRequested bool       `gorm:"not null;default:false" json:"cancel_requested"`
+	Error           string     `gorm:"type:text" json:"error,omitempty"`
+	ResultKey       string     `gorm:"size:255" json:"-"`
+	ResultName      string     `gorm:"size:255" json:"result_name,omitempty"`
+	ResultType      string     `gorm:"size:100" json:"result_content_type,omitempty"`
+	ResultSize      int64      `gorm:"not null;default:0" json:"result_size,omitempty"`
+	CreatedAt       time.Time  `gorm:"index:idx_jobs_status_created" json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+	StartedAt       *time.Time `json:"started_at,omitempty"`
+	HeartbeatAt     *time.Time `json:"-"` // Refreshed by the worker while running
+	FinishedAt      *time.Time `gorm:"index" json:"finished_at,omitempty"`
+}
+
+func (Job) TableName() string {
+	return "jobs"
+}
+
+// HasResult reports whether the job left a file to download
+func (j *Job) HasResult() bool {
+	return j.Status == JobStatusSucceeded && j.ResultKey != ""
+}
+
+// DecodeParams unmarshals the parameters the job was enqueued with
+func (j *Job) DecodeParams(dest interface{}) error {
+	return json.Unmarshal([]byte(j.Params), dest)
+}
+
+// Result is the file a job produces. Body is read once and stored as the job's attachment.
+type Result struct {
+	Name        string
+	ContentType string
+	Body        io.Reader
+}
+
+// Progress lets a running handler report how far along it is
+type Progress interface {
+	// Set records the percent complete (0-100) with a short status line
+	Set(percent int, message string)
+}
+
+// Handler runs one type of job. It should return promptly with ctx.Err() once
+// ctx is cancelled, which is how cancellation reaches it.
+type Handler func(ctx context.Context, job *Job, progress Progress) (*Result, error)
+
+var (
+	ErrUnknownType  = errors.New("unknown job type")
+	ErrJobNotFound  = errors.New("job not found")
+	ErrJobFinished  = errors.New("job has already finished")
+	ErrNoResult     = errors.New("job has no result to download")
+	errJobCancelled = errors.New("job was cancelled")
+)
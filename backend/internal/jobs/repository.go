@@ -0,0 +1,221 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	Create(ctx context.Context, job *Job) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Job, error)
+	ListByOwner(ctx context.Context, ownerID uuid.UUID, limit int) ([]Job, error)
+
+	// Worker operations
+	Claim(ctx context.Context, types []string) (*Job, error)
+	Heartbeat(ctx context.Context, id uuid.UUID) (cancelRequested bool, err error)
+	UpdateProgress(ctx context.Context, id uuid.UUID, percent int, message string) error
+	Finish(ctx context.Context, id uuid.UUID, status JobStatus, updates map[string]interface{}) error
+	Requeue(ctx context.Context, id uuid.UUID) error
+
+	// Cancellation
+	CancelQueued(ctx context.Context, id uuid.UUID) (bool, error)
+	RequestCancel(ctx context.Context, id uuid.UUID) (bool, error)
+
+	// Cleanup
+	FailStale(ctx context.Context, heartbeatBefore time.Time) (int64, error)
+	GetFinishedBefore(ctx context.Context, before time.Time, limit int) ([]Job, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+//  JOBS
+
+func (r *repository) Create(ctx context.Context, job *Job) error {
+	return r.db.WithContext(ctx).Create(job).Error
+}
+
+func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*Job, error) {
+	var job Job
+	if err := r.db.WithContext(ctx).First(&job, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *repository) ListByOwner(ctx context.Context, ownerID uuid.UUID, limit int) ([]Job, error) {
+	var jobs []Job
+	err := r.db.WithContext(ctx).
+		Where("owner_id = ?", ownerID).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&jobs).Error
+	return jobs, err
+}
+
+//  WORKER OPERATIONS
+
+// Claim takes the oldest queued job of the given types. Rows locked by another
+// worker are skipped, so several instances can share the queue.
+func (r *repository) Claim(ctx context.Context, types []string) (*Job, error) {
+	var job Job
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND type IN ?", JobStatusQueued, types).
+			Order("created_at ASC").
+			Take(&job).Error
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		job.Status = JobStatusRunning
+		job.StartedAt = &now
+		job.HeartbeatAt = &now
+		return tx.Model(&Job{}).
+			Where("id = ?", job.ID).
+			Updates(map[string]interface{}{
+				"status":       JobStatusRunning,
+				"started_at":   now,
+				"heartbeat_at": now,
+				"updated_at":   now,
+			}).Error
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to claim job: %w", err)
+	}
+	return &job, nil
+}
+
+// Heartbeat marks a running job alive and reports whether its owner asked to cancel it
+func (r *repository) Heartbeat(ctx context.Context, id uuid.UUID) (bool, error) {
+	var job Job
+	err := r.db.WithContext(ctx).
+		Model(&job).
+		Clauses(clause.Returning{Columns: []clause.Column{{Name: "cancel_requested"}}}).
+		Where("id = ? AND status = ?", id, JobStatusRunning).
+		Update("heartbeat_at", time.Now()).Error
+	if err != nil {
+		return false, err
+	}
+	return job.CancelRequested, nil
+}
+
+func (r *repository) UpdateProgress(ctx context.Context, id uuid.UUID, percent int, message string) error {
+	return r.db.WithContext(ctx).
+		Model(&Job{}).
+		Where("id = ? AND status = ?", id, JobStatusRunning).
+		Updates(map[string]interface{}{
+			"progress":         percent,
+			"progress_message": message,
+			"heartbeat_at":     time.Now(),
+		}).Error
+}
+
+// Finish moves a running job to its final status with any result fields
+func (r *repository) Finish(ctx context.Context, id uuid.UUID, status JobStatus, updates map[string]interface{}) error {
+	now := time.Now()
+	if updates == nil {
+		updates = make(map[string]interface{})
+	}
+	updates["status"] = status
+	updates["finished_at"] = now
+	updates["updated_at"] = now
+
+	return r.db.WithContext(ctx).
+		Model(&Job{}).
+		Where("id = ? AND status = ?", id, JobStatusRunning).
+		Updates(updates).Error
+}
+
+// Requeue hands a running job back to the queue, used when its worker shuts down
+func (r *repository) Requeue(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).
+		Model(&Job{}).
+		Where("id = ? AND status = ?", id, JobStatusRunning).
+		Updates(map[string]interface{}{
+			"status":           JobStatusQueued,
+			"progress":         0,
+			"progress_message": "",
+			"started_at":       nil,
+			"heartbeat_at":     nil,
+			"updated_at":       time.Now(),
+		}).Error
+}
+
+//  CANCELLATION
+
+// CancelQueued cancels a job no worker has picked up yet
+func (r *repository) CancelQueued(ctx context.Context, id uuid.UUID) (bool, error) {
+	now := time.Now()
+	result := r.db.WithContext(ctx).
+		Model(&Job{}).
+		Where("id = ? AND status = ?", id, JobStatusQueued).
+		Updates(map[string]interface{}{
+			"status":           JobStatusCancelled,
+			"cancel_requested": true,
+			"finished_at":      now,
+			"updated_at":       now,
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+// RequestCancel flags a running job; its worker stops it on the next heartbeat
+func (r *repository) RequestCancel(ctx context.Context, id uuid.UUID) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&Job{}).
+		Where("id = ? AND status = ?", id, JobStatusRunning).
+		Updates(map[string]interface{}{
+			"cancel_requested": true,
+			"updated_at":       time.Now(),
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+//  CLEANUP
+
+// FailStale fails running jobs whose worker stopped sending heartbeats, e.g. after a crash
+func (r *repository) FailStale(ctx context.Context, heartbeatBefore time.Time) (int64, error) {
+	now := time.Now()
+	result := r.db.WithContext(ctx).
+		Model(&Job{}).
+		Where("status = ? AND heartbeat_at < ?", JobStatusRunning, heartbeatBefore).
+		Updates(map[string]interface{}{
+			"status":      JobStatusFailed,
+			"error":       "worker stopped responding",
+			"finished_at": now,
+			"updated_at":  now,
+		})
+	return result.RowsAffected, result.Error
+}
+
+func (r *repository) GetFinishedBefore(ctx context.Context, before time.Time, limit int) ([]Job, error) {
+	var jobs []Job
+	err := r.db.WithContext(ctx).
+		Where("status IN ? AND finished_at < ?",
+			[]JobStatus{JobStatusSucceeded, JobStatusFailed, JobStatusCancelled}, before).
+		Order("finished_at ASC").
+		Limit(limit).
+		Find(&jobs).Error
+	return jobs, err
+}
+
+func (r *repository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&Job{}, "id = ?", id).Error
+}
@@ -0,0 +1,7 @@
+package jobs
+
+// JobResponse is a job with the link to download its result, once there is one
+type JobResponse struct {
+	Job
+	ResultURL string `json:"result_url,omitempty"`
+}
@@ -0,0 +1,18 @@
+package jobs
+
+import (
+	"evently/internal/shared/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupJobRoutes(rg *gin.RouterGroup, controller *Controller) {
+	jobs := rg.Group("/jobs")
+	jobs.Use(middleware.JWTAuth(), middleware.RequireRoles("USER", "ADMIN"))
+	{
+		jobs.GET("", controller.ListJobs)                  // GET /api/v1/jobs
+		jobs.GET("/:id", controller.GetJob)                // GET /api/v1/jobs/:id
+		jobs.POST("/:id/cancel", controller.CancelJob)     // POST /api/v1/jobs/:id/cancel
+		jobs.GET("/:id/result", controller.DownloadResult) // GET /api/v1/jobs/:id/result
+	}
+}
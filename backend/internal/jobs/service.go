@@ -0,0 +1,410 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"evently/pkg/media"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Config contains configuration for the job worker pool
+type Config struct {
+	Workers           int
+	PollInterval      time.Duration
+	HeartbeatInterval time.Duration // How often running jobs check in and look for cancellation
+	StaleAfter        time.Duration // Running jobs without a heartbeat this long are failed
+	Retention         time.Duration // Finished jobs and their results are deleted after this
+	CleanupInterval   time.Duration
+	CleanupBatch      int
+	ListLimit         int
+	ResultURL         string // Result download endpoint, {job_id} is replaced
+}
+
+// DefaultConfig returns default job configuration
+func DefaultConfig() *Config {
+	return &Config{
+		Workers:           2,               // Two jobs run at once per instance
+		PollInterval:      2 * time.Second, // Look for queued jobs every 2 seconds
+		HeartbeatInterval: 5 * time.Second, // Cancellation takes effect within 5 seconds
+		StaleAfter:        2 * time.Minute, // Workers that miss heartbeats for 2 minutes are presumed dead
+		Retention:         7 * 24 * time.Hour,
+		CleanupInterval:   time.Hour,
+		CleanupBatch:      100,
+		ListLimit:         50,
+		ResultURL:         "/api/v1/jobs/{job_id}/result",
+	}
+}
+
+type Service interface {
+	// Register makes a job type runnable; call it before the worker pool starts
+	Register(jobType string, handler Handler)
+	// Enqueue queues a job of a registered type for the given owner
+	Enqueue(ctx context.Context, jobType string, ownerID uuid.UUID, params interface{}) (*JobResponse, error)
+
+	// Owners see their own jobs, admins see every job
+	GetJob(ctx context.Context, id, userID uuid.UUID, isAdmin bool) (*JobResponse, error)
+	ListJobs(ctx context.Context, ownerID uuid.UUID) ([]JobResponse, error)
+	CancelJob(ctx context.Context, id, userID uuid.UUID, isAdmin bool) (*JobResponse, error)
+	OpenResult(ctx context.Context, id, userID uuid.UUID, isAdmin bool) (*Job, io.ReadCloser, error)
+
+	// Worker pool
+	RunNext(ctx context.Context) (bool, error)
+	CleanupJobs(ctx context.Context) (int, error)
+}
+
+type service struct {
+	repo   Repository
+	store  media.Store
+	config *Config
+
+	mu       sync.RWMutex
+	handlers map[string]Handler
+}
+
+func NewService(repo Repository, store media.Store, config *Config) Service {
+	if config == nil {
+		config = DefaultConfig()
+	}
+	return &service{
+		repo:     repo,
+		store:    store,
+		config:   config,
+		handlers: make(map[string]Handler),
+	}
+}
+
+//  JOBS
+
+func (s *service) Register(jobType string, handler Handler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[jobType] = handler
+}
+
+func (s *service) Enqueue(ctx context.Context, jobType string, ownerID uuid.UUID, params interface{}) (*JobResponse, error) {
+	if s.handler(jobType) == nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownType, jobType)
+	}
+
+	data := []byte("{}")
+	if params != nil {
+		var err error
+		if data, err = json.Marshal(params); err != nil {
+			return nil, fmt.Errorf("failed to encode job params: %w", err)
+		}
+	}
+
+	job := &Job{
+		Type:    jobType,
+		OwnerID: ownerID,
+		Status:  JobStatusQueued,
+		Params:  string(data),
+	}
+	if err := s.repo.Create(ctx, job); err != nil {
+		return nil, fmt.Errorf("failed to create job: %w", err)
+	}
+
+	log.Printf("🧰 Job %s (%s) queued for %s", job.ID, jobType, ownerID)
+	return s.toResponse(job), nil
+}
+
+func (s *service) GetJob(ctx context.Context, id, userID uuid.UUID, isAdmin bool) (*JobResponse, error) {
+	job, err := s.getVisibleJob(ctx, id, userID, isAdmin)
+	if err != nil {
+		return nil, err
+	}
+	return s.toResponse(job), nil
+}
+
+func (s *service) ListJobs(ctx context.Context, ownerID uuid.UUID) ([]JobResponse, error) {
+	jobs, err := s.repo.ListByOwner(ctx, ownerID, s.config.ListLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs: %w", err)
+	}
+
+	responses := make([]JobResponse, len(jobs))
+	for i := range jobs {
+		responses[i] = *s.toResponse(&jobs[i])
+	}
+	return responses, nil
+}
+
+// CancelJob stops a queued job straight away; a running job is flagged and
+// stops at its worker's next heartbeat
+func (s *service) CancelJob(ctx context.Context, id, userID uuid.UUID, isAdmin bool) (*JobResponse, error) {
+	job, err := s.getVisibleJob(ctx, id, userID, isAdmin)
+	if err != nil {
+		return nil, err
+	}
+	if job.Status.Finished() {
+		return nil, ErrJobFinished
+	}
+
+	cancelled, err := s.repo.CancelQueued(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel job: %w", err)
+	}
+	if !cancelled {
+		// Picked up by a worker in the meantime
+		if _, err := s.repo.RequestCancel(ctx, id); err != nil {
+			return nil, fmt.Errorf("failed to cancel job: %w", err)
+		}
+	}
+
+	log.Printf("🧰 Job %s cancellation requested by %s", id, userID)
+	return s.GetJob(ctx, id, userID, isAdmin)
+}
+
+func (s *service) OpenResult(ctx context.Context, id, userID uuid.UUID, isAdmin bool) (*Job, io.ReadCloser, error) {
+	job, err := s.getVisibleJob(ctx, id, userID, isAdmin)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !job.HasResult() {
+		return nil, nil, ErrNoResult
+	}
+
+	file, err := s.store.Open(ctx, job.ResultKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open job result: %w", err)
+	}
+	return job, file, nil
+}
+
+// getVisibleJob loads a job the user is allowed to see
+func (s *service) getVisibleJob(ctx context.Context, id, userID uuid.UUID, isAdmin bool) (*Job, error) {
+	job, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrJobNotFound
+		}
+		return nil, fmt.Errorf("failed to get job: %w", err)
+	}
+
+	// Other users' jobs are reported missing rather than forbidden
+	if !isAdmin && job.OwnerID != userID {
+		return nil, ErrJobNotFound
+	}
+	return job, nil
+}
+
+//  WORKER POOL
+
+// RunNext claims and runs one queued job, reporting whether there was one
+func (s *service) RunNext(ctx context.Context) (bool, error) {
+	types := s.registeredTypes()
+	if len(types) == 0 {
+		return false, nil
+	}
+
+	job, err := s.repo.Claim(ctx, types)
+	if err != nil {
+		return false, err
+	}
+	if job == nil {
+		return false, nil
+	}
+
+	s.run(ctx, job)
+	return true, nil
+}
+
+func (s *service) run(ctx context.Context, job *Job) {
+	handler := s.handler(job.Type)
+	log.Printf("🧰 Job %s (%s) started", job.ID, job.Type)
+
+	jobCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	stopHeartbeat := s.startHeartbeat(jobCtx, job.ID, cancel)
+	result, err := s.invoke(jobCtx, handler, job)
+	stopHeartbeat()
+
+	// Final writes must land even though the job context is done
+	finishCtx := context.Background()
+
+	switch {
+	case errors.Is(context.Cause(jobCtx), errJobCancelled):
+		s.finish(finishCtx, job, JobStatusCancelled, nil)
+		log.Printf("🧰 Job %s (%s) cancelled", job.ID, job.Type)
+
+	case ctx.Err() != nil:
+		// The pool is shutting down; another worker starts the job over
+		if err := s.repo.Requeue(finishCtx, job.ID); err != nil {
+			log.Printf("Warning: failed to requeue job %s: %v", job.ID, err)
+		}
+		log.Printf("🧰 Job %s (%s) interrupted by shutdown, requeued", job.ID, job.Type)
+
+	case err != nil:
+		s.finish(finishCtx, job, JobStatusFailed, map[string]interface{}{"error": err.Error()})
+		log.Printf("❌ Job %s (%s) failed: %v", job.ID, job.Type, err)
+
+	default:
+		updates := map[string]interface{}{"progress": 100}
+		if result != nil && result.Body != nil {
+			key := fmt.Sprintf("%s/%s", job.ID, result.Name)
+			countingBody := &countingReader{r: result.Body}
+			if _, err := s.store.Save(finishCtx, key, countingBody); err != nil {
+				s.finish(finishCtx, job, JobStatusFailed, map[string]interface{}{"error": fmt.Sprintf("failed to store result: %v", err)})
+				log.Printf("❌ Job %s (%s) failed to store its result: %v", job.ID, job.Type, err)
+				return
+			}
+			updates["result_key"] = key
+			updates["result_name"] = result.Name
+			updates["result_type"] = result.ContentType
+			updates["result_size"] = countingBody.n
+		}
+		s.finish(finishCtx, job, JobStatusSucceeded, updates)
+		log.Printf("🧰 Job %s (%s) succeeded", job.ID, job.Type)
+	}
+}
+
+// invoke runs the handler, turning a panic into a failed job instead of a dead worker
+func (s *service) invoke(ctx context.Context, handler Handler, job *Job) (result *Result, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("job panicked: %v", recovered)
+		}
+	}()
+	return handler(ctx, job, &progressReporter{repo: s.repo, jobID: job.ID})
+}
+
+// startHeartbeat keeps the job alive and cancels it once its owner asks to
+func (s *service) startHeartbeat(ctx context.Context, jobID uuid.UUID, cancel context.CancelCauseFunc) func() {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(s.config.HeartbeatInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				cancelRequested, err := s.repo.Heartbeat(ctx, jobID)
+				if err != nil {
+					log.Printf("Warning: job %s heartbeat failed: %v", jobID, err)
+					continue
+				}
+				if cancelRequested {
+					cancel(errJobCancelled)
+					return
+				}
+			case <-done:
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return func() { close(done) }
+}
+
+func (s *service) finish(ctx context.Context, job *Job, status JobStatus, updates map[string]interface{}) {
+	if err := s.repo.Finish(ctx, job.ID, status, updates); err != nil {
+		log.Printf("Warning: failed to record job %s as %s: %v", job.ID, status, err)
+	}
+}
+
+//  CLEANUP
+
+// CleanupJobs fails jobs whose worker died and deletes finished jobs past
+// retention along with their results
+func (s *service) CleanupJobs(ctx context.Context) (int, error) {
+	failed, err := s.repo.FailStale(ctx, time.Now().Add(-s.config.StaleAfter))
+	if err != nil {
+		return 0, fmt.Errorf("failed to fail stale jobs: %w", err)
+	}
+	if failed > 0 {
+		log.Printf("Failed %d jobs whose worker stopped responding", failed)
+	}
+
+	finished, err := s.repo.GetFinishedBefore(ctx, time.Now().Add(-s.config.Retention), s.config.CleanupBatch)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get finished jobs: %w", err)
+	}
+
+	removed := 0
+	for i := range finished {
+		if finished[i].ResultKey != "" {
+			if err := s.store.Delete(ctx, finished[i].ResultKey); err != nil {
+				log.Printf("Failed to delete result of job %s: %v", finished[i].ID, err)
+				continue
+			}
+		}
+		if err := s.repo.Delete(ctx, finished[i].ID); err != nil {
+			log.Printf("Failed to delete job %s: %v", finished[i].ID, err)
+			continue
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+//  HELPERS
+
+func (s *service) toResponse(job *Job) *JobResponse {
+	resp := &JobResponse{Job: *job}
+	if job.HasResult() {
+		resp.ResultURL = strings.ReplaceAll(s.config.ResultURL, "{job_id}", job.ID.String())
+	}
+	return resp
+}
+
+func (s *service) handler(jobType string) Handler {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.handlers[jobType]
+}
+
+// registeredTypes limits claims to jobs this instance can run
+func (s *service) registeredTypes() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	types := make([]string, 0, len(s.handlers))
+	for jobType := range s.handlers {
+		types = append(types, jobType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// progressReporter writes handler progress to the job row
+type progressReporter struct {
+	repo  Repository
+	jobID uuid.UUID
+	last  int
+}
+
+func (p *progressReporter) Set(percent int, message string) {
+	percent = max(0, min(99, percent)) // 100 is reserved for finished jobs
+	if percent < p.last {
+		percent = p.last
+	}
+	p.last = percent
+
+	if err := p.repo.UpdateProgress(context.Background(), p.jobID, percent, message); err != nil {
+		log.Printf("Warning: failed to update progress of job %s: %v", p.jobID, err)
+	}
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
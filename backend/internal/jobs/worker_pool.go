@@ -0,0 +1,118 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// WorkerPool runs queued jobs on a fixed number of workers and periodically
+// removes finished jobs past retention
+type WorkerPool struct {
+	service Service
+	config  *Config
+	done    chan struct{}
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+// NewWorkerPool creates a new job worker pool
+func NewWorkerPool(service Service, config *Config) *WorkerPool {
+	if config == nil {
+		config = DefaultConfig()
+	}
+
+	return &WorkerPool{
+		service: service,
+		config:  config,
+		done:    make(chan struct{}),
+	}
+}
+
+// Start starts the workers and the cleanup loop
+func (p *WorkerPool) Start(ctx context.Context) {
+	ctx, p.cancel = context.WithCancel(ctx)
+	log.Printf("Started job worker pool with %d workers, polling every %v", p.config.Workers, p.config.PollInterval)
+
+	for i := 0; i < p.config.Workers; i++ {
+		p.wg.Add(1)
+		go p.work(ctx, i+1)
+	}
+	go p.cleanupLoop(ctx)
+}
+
+// Stop interrupts running jobs, which go back to the queue, and waits for the workers to exit
+func (p *WorkerPool) Stop() {
+	close(p.done)
+	if p.cancel != nil {
+		p.cancel()
+	}
+	p.wg.Wait()
+}
+
+func (p *WorkerPool) work(ctx context.Context, worker int) {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(p.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			p.drain(ctx, worker)
+		case <-p.done:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// drain runs jobs back to back until the queue is empty or the pool stops
+func (p *WorkerPool) drain(ctx context.Context, worker int) {
+	for {
+		select {
+		case <-p.done:
+			return
+		default:
+		}
+
+		ran, err := p.service.RunNext(ctx)
+		if err != nil {
+			log.Printf("Job worker %d failed to run job: %v", worker, err)
+			return
+		}
+		if !ran {
+			return
+		}
+	}
+}
+
+func (p *WorkerPool) cleanupLoop(ctx context.Context) {
+	ticker := time.NewTicker(p.config.CleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			p.cleanup(ctx)
+		case <-p.done:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (p *WorkerPool) cleanup(ctx context.Context) {
+	removed, err := p.service.CleanupJobs(ctx)
+	if err != nil {
+		log.Printf("Failed to clean up jobs: %v", err)
+		return
+	}
+
+	if removed > 0 {
+		log.Printf("Deleted %d finished jobs", removed)
+	}
+}
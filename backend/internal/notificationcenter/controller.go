@@ -0,0 +1,163 @@
+package notificationcenter
+
+import (
+	"errors"
+	"net/http"
+
+	"evently/internal/shared/utils/response"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type Controller struct {
+	service Service
+}
+
+func NewController(service Service) *Controller {
+	return &Controller{service: service}
+}
+
+// ListNotifications returns the current user's in-app notifications, newest first
+//
+// @Summary      List in-app notifications
+// @Description  The user's notification center, newest first, with the unread count for the badge. Booking confirmations, waitlist alerts and event changes are added alongside their emails unless in-app is turned off in the notification preferences.
+// @Tags         Notification Center
+// @Produce      json
+// @Security     Bearer
+// @Param        unread query boolean false "Only unread notifications"
+// @Param        page query integer false "Page" default(1) minimum(1)
+// @Param        limit query integer false "Limit" default(20) minimum(1) maximum(100)
+// @Success      200 {object} response.StandardApiResponse{data=PaginatedNotifications} "Notifications retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse "Invalid query parameters"
+// @Failure      401 {object} response.StandardApiResponse "User not authenticated"
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/users/me/notifications [get]
+func (ctrl *Controller) ListNotifications(c *gin.Context) {
+	userID, ok := ctrl.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var query ListNotificationsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid query parameters", nil, err.Error())
+		return
+	}
+
+	notifications, err := ctrl.service.ListNotifications(c.Request.Context(), userID, query)
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusInternalServerError, "Failed to retrieve notifications", nil, err.Error())
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Notifications retrieved successfully", notifications, nil)
+}
+
+// GetUnreadCount returns the badge count for the notification center
+//
+// @Summary      Get unread notification count
+// @Tags         Notification Center
+// @Produce      json
+// @Security     Bearer
+// @Success      200 {object} response.StandardApiResponse{data=UnreadCountResponse} "Unread count retrieved successfully"
+// @Failure      401 {object} response.StandardApiResponse "User not authenticated"
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/users/me/notifications/unread-count [get]
+func (ctrl *Controller) GetUnreadCount(c *gin.Context) {
+	userID, ok := ctrl.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	count, err := ctrl.service.GetUnreadCount(c.Request.Context(), userID)
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusInternalServerError, "Failed to get unread count", nil, err.Error())
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Unread count retrieved successfully", count, nil)
+}
+
+// MarkAsRead godoc
+//
+// @Summary      Mark a notification as read
+// @Description  Marking a notification that is already read keeps its original read time.
+// @Tags         Notification Center
+// @Produce      json
+// @Security     Bearer
+// @Param        notificationId path string true "Notification ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=Notification} "Notification marked as read"
+// @Failure      400 {object} response.StandardApiResponse "Invalid notification ID"
+// @Failure      401 {object} response.StandardApiResponse "User not authenticated"
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Notification not found"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/users/me/notifications/{notificationId}/read [post]
+func (ctrl *Controller) MarkAsRead(c *gin.Context) {
+	userID, ok := ctrl.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	notificationID, err := uuid.Parse(c.Param("notificationId"))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid notification ID", nil, err.Error())
+		return
+	}
+
+	notification, err := ctrl.service.MarkAsRead(c.Request.Context(), userID, notificationID)
+	if err != nil {
+		if errors.Is(err, ErrNotificationNotFound) {
+			response.RespondJSON(c, "error", http.StatusNotFound, err.Error(), nil, nil)
+			return
+		}
+		response.RespondJSON(c, "error", http.StatusInternalServerError, "Failed to mark notification as read", nil, err.Error())
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Notification marked as read", notification, nil)
+}
+
+// MarkAllAsRead godoc
+//
+// @Summary      Mark all notifications as read
+// @Tags         Notification Center
+// @Produce      json
+// @Security     Bearer
+// @Success      200 {object} response.StandardApiResponse{data=MarkAllReadResponse} "Notifications marked as read"
+// @Failure      401 {object} response.StandardApiResponse "User not authenticated"
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/users/me/notifications/read-all [post]
+func (ctrl *Controller) MarkAllAsRead(c *gin.Context) {
+	userID, ok := ctrl.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	result, err := ctrl.service.MarkAllAsRead(c.Request.Context(), userID)
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusInternalServerError, "Failed to mark notifications as read", nil, err.Error())
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Notifications marked as read", result, nil)
+}
+
+func (ctrl *Controller) currentUserID(c *gin.Context) (uuid.UUID, bool) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.RespondJSON(c, "error", http.StatusUnauthorized, "User not authenticated", nil, nil)
+		return uuid.Nil, false
+	}
+
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusInternalServerError, "Invalid user ID format", nil, nil)
+		return uuid.Nil, false
+	}
+	return userUUID, true
+}
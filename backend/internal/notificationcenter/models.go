@@ -0,0 +1,28 @@
+package notificationcenter
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Notification is an entry in a user's in-app notification center. It is
+// created alongside the email for the same outbox message, whose derived ID is
+// kept in SourceID so a redelivered message does not show up twice.
+type Notification struct {
+	ID              uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	UserID          uuid.UUID  `gorm:"type:uuid;not null;index:idx_in_app_notifications_user_created" json:"-"`
+	SourceID        uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_in_app_notifications_source_id" json:"-"`
+	Type            string     `gorm:"type:varchar(50);not null" json:"type"`
+	Title           string     `gorm:"type:varchar(200);not null" json:"title"`
+	Body            string     `gorm:"type:text;not null" json:"body"`
+	EventID         *uuid.UUID `gorm:"type:uuid" json:"event_id,omitempty"`
+	BookingID       *uuid.UUID `gorm:"type:uuid" json:"booking_id,omitempty"`
+	WaitlistEntryID *uuid.UUID `gorm:"type:uuid" json:"waitlist_entry_id,omitempty"`
+	ReadAt          *time.Time `json:"read_at,omitempty"`
+	CreatedAt       time.Time  `gorm:"index:idx_in_app_notifications_user_created" json:"created_at"`
+}
+
+func (Notification) TableName() string {
+	return "in_app_notifications"
+}
@@ -0,0 +1,107 @@
+package notificationcenter
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	Create(ctx context.Context, notification *Notification) error
+	List(ctx context.Context, userID uuid.UUID, unreadOnly bool, limit, offset int) ([]Notification, int64, error)
+	GetByID(ctx context.Context, id, userID uuid.UUID) (*Notification, error)
+	CountUnread(ctx context.Context, userID uuid.UUID) (int64, error)
+	MarkRead(ctx context.Context, id, userID uuid.UUID, readAt time.Time) error
+	MarkAllRead(ctx context.Context, userID uuid.UUID, readAt time.Time) (int64, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+// Create stores a notification, ignoring one already created from the same source
+func (r *repository) Create(ctx context.Context, notification *Notification) error {
+	err := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "source_id"}}, DoNothing: true}).
+		Create(notification).Error
+	if err != nil {
+		return fmt.Errorf("failed to create in-app notification: %w", err)
+	}
+	return nil
+}
+
+func (r *repository) List(ctx context.Context, userID uuid.UUID, unreadOnly bool, limit, offset int) ([]Notification, int64, error) {
+	query := r.db.WithContext(ctx).Model(&Notification{}).Where("user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count notifications: %w", err)
+	}
+
+	var notifications []Notification
+	err := query.
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&notifications).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list notifications: %w", err)
+	}
+
+	return notifications, total, nil
+}
+
+func (r *repository) GetByID(ctx context.Context, id, userID uuid.UUID) (*Notification, error) {
+	var notification Notification
+	err := r.db.WithContext(ctx).Take(&notification, "id = ? AND user_id = ?", id, userID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &notification, nil
+}
+
+func (r *repository) CountUnread(ctx context.Context, userID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+	return count, nil
+}
+
+// MarkRead sets read_at on an unread notification; already read ones keep their first read time
+func (r *repository) MarkRead(ctx context.Context, id, userID uuid.UUID, readAt time.Time) error {
+	err := r.db.WithContext(ctx).
+		Model(&Notification{}).
+		Where("id = ? AND user_id = ? AND read_at IS NULL", id, userID).
+		Update("read_at", readAt).Error
+	if err != nil {
+		return fmt.Errorf("failed to mark notification read: %w", err)
+	}
+	return nil
+}
+
+func (r *repository) MarkAllRead(ctx context.Context, userID uuid.UUID, readAt time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Model(&Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Update("read_at", readAt)
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to mark notifications read: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,7 @@
+package notificationcenter
+
+type ListNotificationsQuery struct {
+	Unread bool `form:"unread"` // Only unread notifications
+	Page   int  `form:"page,default=1" binding:"min=1"`
+	Limit  int  `form:"limit,default=20" binding:"min=1,max=100"`
+}
@@ -0,0 +1,24 @@
+package notificationcenter
+
+import "evently/internal/shared/utils/response"
+
+type PaginatedNotifications struct {
+	Notifications []Notification `json:"notifications"`
+	UnreadCount   int64          `json:"unread_count"`
+	TotalCount    int64          `json:"total_count"`
+	Page          int            `json:"page"`
+	Limit         int            `json:"limit"`
+}
+
+func (p PaginatedNotifications) PaginationMeta() response.Pagination {
+	return response.NewPagination(p.Page, p.Limit, p.TotalCount)
+}
+
+type UnreadCountResponse struct {
+	UnreadCount int64 `json:"unread_count"`
+}
+
+type MarkAllReadResponse struct {
+	Marked      int64 `json:"marked"`
+	UnreadCount int64 `json:"unread_count"`
+}
@@ -0,0 +1,18 @@
+package notificationcenter
+
+import (
+	"evently/internal/shared/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupNotificationCenterRoutes(rg *gin.RouterGroup, controller *Controller) {
+	users := rg.Group("/users/me/notifications")
+	users.Use(middleware.JWTAuth(), middleware.RequireRoles("USER", "ADMIN"))
+	{
+		users.GET("", controller.ListNotifications)                // GET /api/v1/users/me/notifications
+		users.GET("/unread-count", controller.GetUnreadCount)      // GET /api/v1/users/me/notifications/unread-count
+		users.POST("/read-all", controller.MarkAllAsRead)          // POST /api/v1/users/me/notifications/read-all
+		users.POST("/:notificationId/read", controller.MarkAsRead) // POST /api/v1/users/me/notifications/:notificationId/read
+	}
+}
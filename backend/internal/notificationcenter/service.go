@@ -0,0 +1,98 @@
+package notificationcenter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var ErrNotificationNotFound = errors.New("notification not found")
+
+type Service interface {
+	ListNotifications(ctx context.Context, userID uuid.UUID, query ListNotificationsQuery) (*PaginatedNotifications, error)
+	GetUnreadCount(ctx context.Context, userID uuid.UUID) (*UnreadCountResponse, error)
+	MarkAsRead(ctx context.Context, userID, notificationID uuid.UUID) (*Notification, error)
+	MarkAllAsRead(ctx context.Context, userID uuid.UUID) (*MarkAllReadResponse, error)
+
+	// Record stores a notification delivered through the outbox. Recording the
+	// same source twice is a no-op.
+	Record(ctx context.Context, notification *Notification) error
+}
+
+type service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) Service {
+	return &service{repo: repo}
+}
+
+func (s *service) ListNotifications(ctx context.Context, userID uuid.UUID, query ListNotificationsQuery) (*PaginatedNotifications, error) {
+	notifications, total, err := s.repo.List(ctx, userID, query.Unread, query.Limit, (query.Page-1)*query.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	unread := total
+	if !query.Unread {
+		if unread, err = s.repo.CountUnread(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	return &PaginatedNotifications{
+		Notifications: notifications,
+		UnreadCount:   unread,
+		TotalCount:    total,
+		Page:          query.Page,
+		Limit:         query.Limit,
+	}, nil
+}
+
+func (s *service) GetUnreadCount(ctx context.Context, userID uuid.UUID) (*UnreadCountResponse, error) {
+	count, err := s.repo.CountUnread(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &UnreadCountResponse{UnreadCount: count}, nil
+}
+
+func (s *service) MarkAsRead(ctx context.Context, userID, notificationID uuid.UUID) (*Notification, error) {
+	if err := s.repo.MarkRead(ctx, notificationID, userID, time.Now()); err != nil {
+		return nil, err
+	}
+
+	notification, err := s.repo.GetByID(ctx, notificationID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotificationNotFound
+		}
+		return nil, fmt.Errorf("failed to get notification: %w", err)
+	}
+	return notification, nil
+}
+
+func (s *service) MarkAllAsRead(ctx context.Context, userID uuid.UUID) (*MarkAllReadResponse, error) {
+	marked, err := s.repo.MarkAllRead(ctx, userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	// Notifications created while marking stay unread
+	unread, err := s.repo.CountUnread(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &MarkAllReadResponse{Marked: marked, UnreadCount: unread}, nil
+}
+
+func (s *service) Record(ctx context.Context, notification *Notification) error {
+	if notification.CreatedAt.IsZero() {
+		notification.CreatedAt = time.Now()
+	}
+	return s.repo.Create(ctx, notification)
+}
@@ -0,0 +1,104 @@
+package notificationprefs
+
+import (
+	"errors"
+	"net/http"
+
+	"evently/internal/shared/utils/response"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type Controller struct {
+	service Service
+}
+
+func NewController(service Service) *Controller {
+	return &Controller{service: service}
+}
+
+// GetPreferences returns the current user's channels per notification type and quiet hours
+//
+// @Summary      Get notification preferences
+// @Description  Channels each notification type is delivered on and the user's quiet hours. Email and in-app are on by default, SMS and push are opt-in.
+// @Tags         Notification Preferences
+// @Produce      json
+// @Security     Bearer
+// @Success      200 {object} response.StandardApiResponse{data=PreferencesResponse} "Notification preferences retrieved successfully"
+// @Failure      401 {object} response.StandardApiResponse "User not authenticated"
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/users/me/notification-preferences [get]
+func (ctrl *Controller) GetPreferences(c *gin.Context) {
+	userID, ok := ctrl.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	preferences, err := ctrl.service.GetPreferences(c.Request.Context(), userID)
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusInternalServerError, "Failed to get notification preferences", nil, err.Error())
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Notification preferences retrieved successfully", preferences, nil)
+}
+
+// UpdatePreferences changes the current user's channels and quiet hours
+//
+// @Summary      Update notification preferences
+// @Description  Turns channels on or off per notification type and sets quiet hours. Omitted types, channels and fields keep their current value. During quiet hours notifications that are not time sensitive are held until the window ends.
+// @Tags         Notification Preferences
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        request body UpdatePreferencesRequest true "Request body"
+// @Success      200 {object} response.StandardApiResponse{data=PreferencesResponse} "Notification preferences updated successfully"
+// @Failure      400 {object} response.StandardApiResponse "Unknown notification type, malformed time, unknown timezone or invalid contact"
+// @Failure      401 {object} response.StandardApiResponse "User not authenticated"
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/users/me/notification-preferences [put]
+func (ctrl *Controller) UpdatePreferences(c *gin.Context) {
+	userID, ok := ctrl.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req UpdatePreferencesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid request body", nil, err.Error())
+		return
+	}
+
+	preferences, err := ctrl.service.UpdatePreferences(c.Request.Context(), userID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrUnknownType), errors.Is(err, ErrInvalidClock),
+			errors.Is(err, ErrInvalidTimezone), errors.Is(err, ErrEmptyQuietHours),
+			errors.Is(err, ErrInvalidPhone), errors.Is(err, ErrInvalidToken):
+			response.RespondJSON(c, "error", http.StatusBadRequest, err.Error(), nil, nil)
+		default:
+			response.RespondJSON(c, "error", http.StatusInternalServerError, "Failed to update notification preferences", nil, err.Error())
+		}
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Notification preferences updated successfully", preferences, nil)
+}
+
+func (ctrl *Controller) currentUserID(c *gin.Context) (uuid.UUID, bool) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.RespondJSON(c, "error", http.StatusUnauthorized, "User not authenticated", nil, nil)
+		return uuid.Nil, false
+	}
+
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusInternalServerError, "Invalid user ID format", nil, nil)
+		return uuid.Nil, false
+	}
+	return userUUID, true
+}
@@ -0,0 +1,118 @@
+package notificationprefs
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Quiet hours used until a user sets their own
+const (
+	DefaultQuietHoursStart = "22:00"
+	DefaultQuietHoursEnd   = "08:00"
+	DefaultTimezone        = "UTC"
+)
+
+// ChannelSettings turns each delivery channel on or off for one notification type
+type ChannelSettings struct {
+	Email bool `json:"email"`
+	SMS   bool `json:"sms"`
+	Push  bool `json:"push"`
+	InApp bool `json:"in_app"`
+}
+
+// DefaultChannels apply to every notification type a user has not configured.
+// SMS and push are opt-in since they reach a phone.
+var DefaultChannels = ChannelSettings{Email: true, InApp: true}
+
+// Preferences are a user's notification settings. Users without a row get
+// DefaultChannels for every type and no quiet hours.
+type Preferences struct {
+	UserID            uuid.UUID                  `gorm:"type:uuid;primaryKey" json:"user_id"`
+	Channels          map[string]ChannelSettings `gorm:"type:jsonb;serializer:json;not null" json:"channels"` // Keyed by notification type
+	QuietHoursEnabled bool                       `gorm:"not null;default:false" json:"quiet_hours_enabled"`
+	QuietHoursStart   string                     `gorm:"type:varchar(5);not null;default:'22:00'" json:"quiet_hours_start"` // HH:MM in Timezone
+	QuietHoursEnd     string                     `gorm:"type:varchar(5);not null;default:'08:00'" json:"quiet_hours_end"`
+	Timezone          string                     `gorm:"type:varchar(64);not null;default:'UTC'" json:"timezone"` // IANA name
+	SMSPhone          *string                    `gorm:"type:varchar(20)" json:"sms_phone,omitempty"`             // E.164, required for SMS
+	PushToken         *string                    `gorm:"type:varchar(512)" json:"-"`                              // Device token, required for push
+	CreatedAt         time.Time                  `json:"created_at"`
+	UpdatedAt         time.Time                  `json:"updated_at"`
+}
+
+func (Preferences) TableName() string {
+	return "notification_preferences"
+}
+
+// NewPreferences returns the defaults for a user who has not saved any preferences
+func NewPreferences(userID uuid.UUID) *Preferences {
+	return &Preferences{
+		UserID:          userID,
+		Channels:        map[string]ChannelSettings{},
+		QuietHoursStart: DefaultQuietHoursStart,
+		QuietHoursEnd:   DefaultQuietHoursEnd,
+		Timezone:        DefaultTimezone,
+	}
+}
+
+// ChannelsFor returns the channels a notification type is delivered on
+func (p *Preferences) ChannelsFor(notificationType string) ChannelSettings {
+	if settings, ok := p.Channels[notificationType]; ok {
+		return settings
+	}
+	return DefaultChannels
+}
+
+// QuietUntil returns when the quiet hours in effect at now end, or the zero
+// time when now is outside quiet hours. The window may span midnight.
+func (p *Preferences) QuietUntil(now time.Time) time.Time {
+	if !p.QuietHoursEnabled {
+		return time.Time{}
+	}
+	start, err := parseClock(p.QuietHoursStart)
+	if err != nil {
+		return time.Time{}
+	}
+	end, err := parseClock(p.QuietHoursEnd)
+	if err != nil || start == end {
+		return time.Time{}
+	}
+	loc, err := time.LoadLocation(p.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	local := now.In(loc)
+	minute := local.Hour()*60 + local.Minute()
+	quiet := minute >= start && minute < end
+	if start > end {
+		quiet = minute >= start || minute < end
+	}
+	if !quiet {
+		return time.Time{}
+	}
+
+	until := time.Date(local.Year(), local.Month(), local.Day(), end/60, end%60, 0, 0, loc)
+	if !until.After(local) {
+		until = time.Date(local.Year(), local.Month(), local.Day()+1, end/60, end%60, 0, 0, loc)
+	}
+	return until
+}
+
+// Delivery is how a user's preferences route one notification
+type Delivery struct {
+	Channels   ChannelSettings
+	SMSPhone   string
+	PushToken  string
+	DeferUntil *time.Time // Set when the notification should wait for quiet hours to end
+}
+
+// parseClock parses HH:MM into minutes after midnight
+func parseClock(value string) (int, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q: must be HH:MM", value)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
@@ -0,0 +1,40 @@
+package notificationprefs
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	GetByUserID(ctx context.Context, userID uuid.UUID) (*Preferences, error)
+	Upsert(ctx context.Context, preferences *Preferences) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) GetByUserID(ctx context.Context, userID uuid.UUID) (*Preferences, error) {
+	var preferences Preferences
+	err := r.db.WithContext(ctx).Take(&preferences, "user_id = ?", userID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &preferences, nil
+}
+
+func (r *repository) Upsert(ctx context.Context, preferences *Preferences) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"channels", "quiet_hours_enabled", "quiet_hours_start", "quiet_hours_end", "timezone", "sms_phone", "push_token", "updated_at"}),
+		}).
+		Create(preferences).Error
+}
@@ -0,0 +1,24 @@
+package notificationprefs
+
+// UpdatePreferencesRequest changes a user's notification preferences. Omitted
+// notification types, channels and quiet hour fields keep their current value.
+type UpdatePreferencesRequest struct {
+	Channels   map[string]UpdateChannelsRequest `json:"channels"` // Keyed by notification type, e.g. BOOKING_CONFIRMED
+	QuietHours *UpdateQuietHoursRequest         `json:"quiet_hours"`
+	SMSPhone   *string                          `json:"sms_phone"`  // E.164, e.g. +919876543210; empty string removes it
+	PushToken  *string                          `json:"push_token"` // Device token from the mobile app; empty string removes it
+}
+
+type UpdateChannelsRequest struct {
+	Email *bool `json:"email"`
+	SMS   *bool `json:"sms"`
+	Push  *bool `json:"push"`
+	InApp *bool `json:"in_app"`
+}
+
+type UpdateQuietHoursRequest struct {
+	Enabled  *bool   `json:"enabled"`
+	Start    *string `json:"start"`    // HH:MM, e.g. 22:00
+	End      *string `json:"end"`      // HH:MM, e.g. 08:00
+	Timezone *string `json:"timezone"` // IANA name, e.g. Asia/Kolkata
+}
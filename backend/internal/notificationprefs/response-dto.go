@@ -0,0 +1,32 @@
+package notificationprefs
+
+import "time"
+
+// PreferencesResponse is a user's effective preferences, defaults included
+type PreferencesResponse struct {
+	Types      []TypePreferences `json:"types"` // Every notification type
+	QuietHours QuietHours        `json:"quiet_hours"`
+	Contacts   Contacts          `json:"contacts"`
+	IsDefault  bool              `json:"is_default"` // True until the user saves their own preferences
+	UpdatedAt  *time.Time        `json:"updated_at,omitempty"`
+}
+
+type TypePreferences struct {
+	Type              string          `json:"type"`
+	TimeSensitive     bool            `json:"time_sensitive"` // Sent during quiet hours instead of waiting
+	Channels          ChannelSettings `json:"channels"`
+	AvailableChannels []string        `json:"available_channels"` // Channels the type can be delivered on
+}
+
+// Contacts are where SMS and push notifications are sent
+type Contacts struct {
+	SMSPhone       string `json:"sms_phone,omitempty"`
+	PushRegistered bool   `json:"push_registered"` // The token itself is never returned
+}
+
+type QuietHours struct {
+	Enabled  bool   `json:"enabled"`
+	Start    string `json:"start"`
+	End      string `json:"end"`
+	Timezone string `json:"timezone"`
+}
@@ -0,0 +1,16 @@
+package notificationprefs
+
+import (
+	"evently/internal/shared/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupNotificationPreferenceRoutes(rg *gin.RouterGroup, controller *Controller) {
+	users := rg.Group("/users/me/notification-preferences")
+	users.Use(middleware.JWTAuth(), middleware.RequireRoles("USER", "ADMIN"))
+	{
+		users.GET("", controller.GetPreferences)    // GET /api/v1/users/me/notification-preferences
+		users.PUT("", controller.UpdatePreferences) // PUT /api/v1/users/me/notification-preferences
+	}
+}
@@ -0,0 +1,234 @@
+package notificationprefs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"evently/internal/notifications"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrUnknownType     = errors.New("unknown notification type")
+	ErrInvalidClock    = errors.New("quiet hours must be given as HH:MM")
+	ErrInvalidTimezone = errors.New("unknown timezone: must be an IANA name like Europe/London")
+	ErrEmptyQuietHours = errors.New("quiet hours must start and end at different times")
+	ErrInvalidPhone    = errors.New("sms_phone must be in E.164 format, e.g. +919876543210")
+	ErrInvalidToken    = errors.New("push_token must be at most 512 characters")
+)
+
+var e164Pattern = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+
+const maxPushTokenLength = 512
+
+type Service interface {
+	GetPreferences(ctx context.Context, userID uuid.UUID) (*PreferencesResponse, error)
+	UpdatePreferences(ctx context.Context, userID uuid.UUID, req UpdatePreferencesRequest) (*PreferencesResponse, error)
+
+	// ResolveDelivery decides which channels a notification goes out on and,
+	// unless it is time sensitive, whether it waits for quiet hours to end
+	ResolveDelivery(ctx context.Context, userID uuid.UUID, notificationType string, now time.Time) (*Delivery, error)
+}
+
+type service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) Service {
+	return &service{repo: repo}
+}
+
+func (s *service) GetPreferences(ctx context.Context, userID uuid.UUID) (*PreferencesResponse, error) {
+	preferences, saved, err := s.preferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return buildResponse(preferences, !saved), nil
+}
+
+func (s *service) UpdatePreferences(ctx context.Context, userID uuid.UUID, req UpdatePreferencesRequest) (*PreferencesResponse, error) {
+	for notificationType := range req.Channels {
+		if !isKnownType(notificationType) {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownType, notificationType)
+		}
+	}
+
+	preferences, _, err := s.preferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for notificationType, update := range req.Channels {
+		channels := preferences.ChannelsFor(notificationType)
+		if update.Email != nil {
+			channels.Email = *update.Email
+		}
+		if update.SMS != nil {
+			channels.SMS = *update.SMS
+		}
+		if update.Push != nil {
+			channels.Push = *update.Push
+		}
+		if update.InApp != nil {
+			channels.InApp = *update.InApp
+		}
+		preferences.Channels[notificationType] = channels
+	}
+
+	if quiet := req.QuietHours; quiet != nil {
+		if quiet.Enabled != nil {
+			preferences.QuietHoursEnabled = *quiet.Enabled
+		}
+		if quiet.Start != nil {
+			preferences.QuietHoursStart = *quiet.Start
+		}
+		if quiet.End != nil {
+			preferences.QuietHoursEnd = *quiet.End
+		}
+		if quiet.Timezone != nil {
+			preferences.Timezone = *quiet.Timezone
+		}
+		if err := validateQuietHours(preferences); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.SMSPhone != nil {
+		phone := strings.TrimSpace(*req.SMSPhone)
+		if phone != "" && !e164Pattern.MatchString(phone) {
+			return nil, ErrInvalidPhone
+		}
+		preferences.SMSPhone = optional(phone)
+	}
+	if req.PushToken != nil {
+		token := strings.TrimSpace(*req.PushToken)
+		if len(token) > maxPushTokenLength {
+			return nil, ErrInvalidToken
+		}
+		preferences.PushToken = optional(token)
+	}
+
+	preferences.UpdatedAt = time.Now()
+	if err := s.repo.Upsert(ctx, preferences); err != nil {
+		return nil, fmt.Errorf("failed to save notification preferences: %w", err)
+	}
+	return buildResponse(preferences, false), nil
+}
+
+func (s *service) ResolveDelivery(ctx context.Context, userID uuid.UUID, notificationType string, now time.Time) (*Delivery, error) {
+	preferences, _, err := s.preferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery := &Delivery{Channels: preferences.ChannelsFor(notificationType)}
+	if preferences.SMSPhone != nil {
+		delivery.SMSPhone = *preferences.SMSPhone
+	}
+	if preferences.PushToken != nil {
+		delivery.PushToken = *preferences.PushToken
+	}
+	if !isTimeSensitive(notificationType) {
+		if until := preferences.QuietUntil(now); !until.IsZero() {
+			delivery.DeferUntil = &until
+		}
+	}
+	return delivery, nil
+}
+
+// preferences returns the user's saved preferences, or the defaults and false
+// when they have none
+func (s *service) preferences(ctx context.Context, userID uuid.UUID) (*Preferences, bool, error) {
+	preferences, err := s.repo.GetByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return NewPreferences(userID), false, nil
+		}
+		return nil, false, fmt.Errorf("failed to get notification preferences: %w", err)
+	}
+	if preferences.Channels == nil {
+		preferences.Channels = map[string]ChannelSettings{}
+	}
+	return preferences, true, nil
+}
+
+func validateQuietHours(p *Preferences) error {
+	start, err := parseClock(p.QuietHoursStart)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidClock, p.QuietHoursStart)
+	}
+	end, err := parseClock(p.QuietHoursEnd)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidClock, p.QuietHoursEnd)
+	}
+	if start == end {
+		return ErrEmptyQuietHours
+	}
+	if _, err := time.LoadLocation(p.Timezone); err != nil || p.Timezone == "" || p.Timezone == "Local" {
+		return fmt.Errorf("%w: %s", ErrInvalidTimezone, p.Timezone)
+	}
+	return nil
+}
+
+func buildResponse(p *Preferences, isDefault bool) *PreferencesResponse {
+	response := &PreferencesResponse{
+		Types: make([]TypePreferences, 0, len(notifications.NotificationTypes)),
+		QuietHours: QuietHours{
+			Enabled:  p.QuietHoursEnabled,
+			Start:    p.QuietHoursStart,
+			End:      p.QuietHoursEnd,
+			Timezone: p.Timezone,
+		},
+		Contacts:  Contacts{PushRegistered: p.PushToken != nil},
+		IsDefault: isDefault,
+	}
+	if p.SMSPhone != nil {
+		response.Contacts.SMSPhone = *p.SMSPhone
+	}
+	for _, notificationType := range notifications.NotificationTypes {
+		available := []string{}
+		for _, channel := range notifications.SupportedChannels(notificationType) {
+			available = append(available, strings.ToLower(string(channel)))
+		}
+		response.Types = append(response.Types, TypePreferences{
+			Type:              string(notificationType),
+			TimeSensitive:     isTimeSensitive(string(notificationType)),
+			Channels:          p.ChannelsFor(string(notificationType)),
+			AvailableChannels: available,
+		})
+	}
+	if !isDefault {
+		updatedAt := p.UpdatedAt
+		response.UpdatedAt = &updatedAt
+	}
+	return response
+}
+
+// optional returns nil for an empty value so clearing a contact stores NULL
+func optional(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+func isKnownType(notificationType string) bool {
+	for _, known := range notifications.NotificationTypes {
+		if string(known) == notificationType {
+			return true
+		}
+	}
+	return false
+}
+
+// isTimeSensitive reports whether a notification type skips quiet hours, such
+// as a waitlist offer that expires before morning
+func isTimeSensitive(notificationType string) bool {
+	return notifications.GetDefaultPriority(notifications.NotificationType(notificationType)).IsTimeSensitive()
+}
@@ -0,0 +1,54 @@
+package notifications
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+// Fallbacks used when a notification carries no branding, e.g. queued before branding existed
+const (
+	defaultBrandName         = "Evently"
+	defaultBrandPrimaryColor = "#4F46E5"
+)
+
+var brandColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// applyBranding wraps a rendered HTML body in the organizer's (or platform's) header
+// and swaps the default sign-off for the brand name in both bodies
+func applyBranding(data map[string]interface{}, htmlBody, textBody string) (string, string) {
+	name := brandValue(data, TemplateKeyBrandName)
+	if name == "" {
+		name = defaultBrandName
+	}
+	color := brandValue(data, TemplateKeyBrandPrimaryColor)
+	if !brandColorPattern.MatchString(color) {
+		color = defaultBrandPrimaryColor
+	}
+	logoURL := brandValue(data, TemplateKeyBrandLogoURL)
+
+	if name != defaultBrandName {
+		signOff := "Best regards,<br>" + html.EscapeString(name)
+		htmlBody = strings.ReplaceAll(htmlBody, "Best regards,<br>Evently Team", signOff)
+		textBody = strings.ReplaceAll(textBody, "Best regards,\nEvently Team", "Best regards,\n"+name)
+	}
+
+	header := fmt.Sprintf(`<h1 style="margin:0;color:#ffffff;font-size:20px;">%s</h1>`, html.EscapeString(name))
+	if logoURL != "" {
+		header = fmt.Sprintf(`<img src="%s" alt="%s" style="max-height:48px;">`,
+			html.EscapeString(logoURL), html.EscapeString(name))
+	}
+
+	wrapped := fmt.Sprintf(`<div style="font-family:Arial,sans-serif;max-width:600px;margin:0 auto;">
+	<div style="background:%s;padding:16px 24px;">%s</div>
+	<div style="padding:24px;border:1px solid #e5e7eb;border-top:none;">%s</div>
+</div>`, color, header, htmlBody)
+
+	return wrapped, textBody
+}
+
+func brandValue(data map[string]interface{}, key string) string {
+	value, _ := data[key].(string)
+	return strings.TrimSpace(value)
+}
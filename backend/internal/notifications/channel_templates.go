@@ -0,0 +1,161 @@
+package notifications
+
+import "fmt"
+
+// smsLimit keeps texts to two SMS segments
+const smsLimit = 300
+
+// SupportedChannels returns the channels a notification type can be delivered
+// on. Email covers every type; SMS is kept to time-critical messages since each
+// one costs money, long-form types such as reports stay email-only, and the
+// in-app notification center shows bookings, cancellations, transfers, resales, waitlist alerts, event changes and reminders.
+func SupportedChannels(notificationType NotificationType) []NotificationChannel {
+	channels := []NotificationChannel{NotificationChannelEmail}
+	if _, ok := RenderSMS(notificationType, map[string]interface{}{}); ok {
+		channels = append(channels, NotificationChannelSMS)
+	}
+	if _, _, ok := RenderPush(notificationType, map[string]interface{}{}); ok {
+		channels = append(channels, NotificationChannelPush)
+	}
+	if _, _, ok := RenderInApp(notificationType, map[string]interface{}{}); ok {
+		channels = append(channels, NotificationChannelInApp)
+	}
+	return channels
+}
+
+// RenderSMS builds the text message for a notification type, or reports false
+// when the type is not sent by SMS
+func RenderSMS(notificationType NotificationType, data map[string]interface{}) (string, bool) {
+	event := templateValue(data, "event_title", "your event")
+
+	var body string
+	switch notificationType {
+	case NotificationTypeWaitlistSpotAvailable:
+		body = fmt.Sprintf("A spot opened up for %s. Book before %s or it goes to the next person.",
+			event, templateValue(data, "expires_at", "your booking window closes"))
+		if url := templateValue(data, "booking_url", ""); url != "" {
+			body += " " + url
+		}
+	case NotificationTypeBookingConfirmed:
+		body = fmt.Sprintf("Your booking %s for %s is confirmed.", templateValue(data, "booking_number", ""), event)
+	case NotificationTypePaymentFailed:
+		body = fmt.Sprintf("Payment for booking %s (%s) failed. Update your payment details to keep your seats.",
+			templateValue(data, "booking_number", ""), event)
+	case NotificationTypeBookingPaymentExpired:
+		body = fmt.Sprintf("Booking %s for %s was cancelled after repeated payment failures.",
+			templateValue(data, "booking_number", ""), event)
+	case NotificationTypeEventScheduleChanged:
+		body = fmt.Sprintf("%s has changed. Check your email for the new details.", event)
+	case NotificationTypeEventCancelled:
+		body = fmt.Sprintf("%s has been cancelled. Check your email for details of your refund.", event)
+		if data["recipient_kind"] == "waitlist" {
+			body = fmt.Sprintf("%s has been cancelled and its waitlist closed.", event)
+		}
+	case NotificationTypeEventReminder:
+		body = fmt.Sprintf("Reminder: %s starts %s at %s.", event, templateValue(data, "starts_in", "soon"), templateValue(data, "venue", "the venue"))
+	default:
+		return "", false
+	}
+
+	body = "Evently: " + body
+	if len(body) > smsLimit {
+		body = body[:smsLimit-3] + "..."
+	}
+	return body, true
+}
+
+// RenderPush builds the push notification title and body for a notification
+// type, or reports false when the type is not sent as a push
+func RenderPush(notificationType NotificationType, data map[string]interface{}) (title, body string, ok bool) {
+	event := templateValue(data, "event_title", "An event")
+
+	switch notificationType {
+	case NotificationTypeWaitlistSpotAvailable:
+		if venue := templateValue(data, "venue", ""); venue != "" {
+			event += " at " + venue
+		}
+		return "A spot is waiting for you", fmt.Sprintf("Book %s before %s.", event, templateValue(data, "expires_at", "your window closes")), true
+	case NotificationTypeBookingConfirmed:
+		return "Booking confirmed", fmt.Sprintf("You're going to %s.", event), true
+	case NotificationTypeWaitlistPositionUpdate:
+		if venue := templateValue(data, "venue", ""); venue != "" {
+			event += " at " + venue
+		}
+		return "Waitlist update", fmt.Sprintf("You're now #%s in line for %s.", templateValue(data, "position", "?"), event), true
+	case NotificationTypePaymentFailed:
+		return "Payment failed", fmt.Sprintf("Update your payment details to keep your seats for %s.", event), true
+	case NotificationTypeBookingPaymentExpired:
+		return "Booking cancelled", fmt.Sprintf("Your booking for %s was cancelled after repeated payment failures.", event), true
+	case NotificationTypeFavoriteSellingOut:
+		return "Almost sold out", fmt.Sprintf("Only %s seats left for %s.", templateValue(data, "remaining_seats", "a few"), event), true
+	case NotificationTypeFavoritePriceDrop:
+		return "Price drop", fmt.Sprintf("%s is now %s.", event, templateValue(data, "new_price", "cheaper")), true
+	case NotificationTypeSupportTicketReply:
+		return "New support reply", fmt.Sprintf("Ticket %s has a new reply.", templateValue(data, "ticket_number", "")), true
+	case NotificationTypeEventScheduleChanged:
+		return "Event changed", fmt.Sprintf("%s has changed. Tap for the new details.", event), true
+	case NotificationTypeEventCancelled:
+		if data["recipient_kind"] == "waitlist" {
+			return "Event cancelled", fmt.Sprintf("%s has been cancelled and its waitlist closed.", event), true
+		}
+		return "Event cancelled", fmt.Sprintf("%s has been cancelled. Your booking will be refunded in full.", event), true
+	case NotificationTypeEventReminder:
+		return "Event reminder", fmt.Sprintf("%s starts %s at %s.", event, templateValue(data, "starts_in", "soon"), templateValue(data, "venue", "the venue")), true
+	case NotificationTypeDocumentArchiveReady:
+		return "Download ready", "Your tickets and invoices are ready to download.", true
+	case NotificationTypeEventCapacityThreshold:
+		return "Your event is filling up", fmt.Sprintf("%s has reached %s%% of capacity.", event, templateValue(data, "threshold", "?")), true
+	case NotificationTypeTicketTransferOffered:
+		return "Tickets for you", fmt.Sprintf("%s sent you %s tickets for %s. Accept before %s.",
+			templateValue(data, "sender_name", "Someone"), templateValue(data, "seat_count", ""), event, templateValue(data, "expires_at", "the offer expires")), true
+	case NotificationTypeTicketTransferAccepted:
+		return "Transfer accepted", fmt.Sprintf("%s accepted your tickets for %s.", templateValue(data, "recipient_name", "The recipient"), event), true
+	case NotificationTypeResaleSold:
+		if payout := templateValue(data, "payout", ""); payout != "" {
+			return "Tickets sold", fmt.Sprintf("Your tickets for %s sold. %s %s will be paid out to you.",
+				event, templateValue(data, "currency", ""), payout), true
+		}
+		return "Tickets sold", fmt.Sprintf("Your tickets for %s sold.", event), true
+	case NotificationTypeResalePurchased:
+		return "Tickets purchased", fmt.Sprintf("Your tickets for %s are under booking %s.", event, templateValue(data, "booking_number", "")), true
+	case NotificationTypeCancellationPending:
+		return "Cancellation requested", fmt.Sprintf("Your cancellation of booking %s for %s is waiting for approval.", templateValue(data, "booking_ref", ""), event), true
+	case NotificationTypeCancellationApproved:
+		return "Cancellation approved", fmt.Sprintf("Your cancellation for %s was approved. %s %s will be refunded.",
+			event, templateValue(data, "currency", ""), templateValue(data, "refund_amount", "")), true
+	case NotificationTypeCancellationRejected:
+		return "Cancellation declined", fmt.Sprintf("Your cancellation for %s was declined, your booking stays as it is.", event), true
+	default:
+		return "", "", false
+	}
+}
+
+// RenderInApp builds the notification center entry for a notification type, or
+// reports false when the type is not shown in the app. Entries read like the
+// push notification for the same type.
+func RenderInApp(notificationType NotificationType, data map[string]interface{}) (title, body string, ok bool) {
+	switch notificationType {
+	case NotificationTypeBookingConfirmed, NotificationTypeWaitlistSpotAvailable,
+		NotificationTypeWaitlistPositionUpdate, NotificationTypeEventScheduleChanged,
+		NotificationTypeEventReminder, NotificationTypeEventCancelled,
+		NotificationTypeTicketTransferOffered, NotificationTypeTicketTransferAccepted,
+		NotificationTypeResaleSold, NotificationTypeResalePurchased,
+		NotificationTypeCancellationPending, NotificationTypeCancellationApproved, NotificationTypeCancellationRejected:
+		return RenderPush(notificationType, data)
+	default:
+		return "", "", false
+	}
+}
+
+// templateValue returns a template data value as text, or fallback when it is missing
+func templateValue(data map[string]interface{}, key, fallback string) string {
+	value, ok := data[key]
+	if !ok || value == nil {
+		return fallback
+	}
+	text := fmt.Sprint(value)
+	if text == "" {
+		return fallback
+	}
+	return text
+}
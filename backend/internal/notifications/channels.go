@@ -0,0 +1,128 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+)
+
+// SMS and push providers
+const (
+	ProviderLog    = "log"
+	ProviderTwilio = "twilio"
+	ProviderMSG91  = "msg91"
+	ProviderFCM    = "fcm"
+)
+
+// SMSSender delivers text messages to a phone number in E.164 format
+type SMSSender interface {
+	SendSMS(ctx context.Context, phone, body string) error
+}
+
+// PushSender delivers push notifications to a device token
+type PushSender interface {
+	SendPush(ctx context.Context, token, title, body string, data map[string]string) error
+}
+
+// ChannelConfig selects and configures the SMS and push providers
+type ChannelConfig struct {
+	SMSProvider      string // log, twilio or msg91
+	TwilioAccountSID string
+	TwilioAuthToken  string
+	TwilioFromNumber string
+	MSG91AuthKey     string
+	MSG91TemplateID  string // DLT-approved flow template with a single ##body## variable
+
+	PushProvider       string // log or fcm
+	FCMProjectID       string
+	FCMCredentialsFile string // Service account JSON key
+}
+
+func NewChannelConfigFromEnv() *ChannelConfig {
+	return &ChannelConfig{
+		SMSProvider:        strings.ToLower(getEnvString("SMS_PROVIDER", ProviderLog)),
+		TwilioAccountSID:   getEnvString("TWILIO_ACCOUNT_SID", ""),
+		TwilioAuthToken:    getEnvString("TWILIO_AUTH_TOKEN", ""),
+		TwilioFromNumber:   getEnvString("TWILIO_FROM_NUMBER", ""),
+		MSG91AuthKey:       getEnvString("MSG91_AUTH_KEY", ""),
+		MSG91TemplateID:    getEnvString("MSG91_TEMPLATE_ID", ""),
+		PushProvider:       strings.ToLower(getEnvString("PUSH_PROVIDER", ProviderLog)),
+		FCMProjectID:       getEnvString("FCM_PROJECT_ID", ""),
+		FCMCredentialsFile: getEnvString("FCM_CREDENTIALS_FILE", ""),
+	}
+}
+
+// ChannelSenders deliver notifications on the channels other than email
+type ChannelSenders struct {
+	SMS  SMSSender
+	Push PushSender
+}
+
+// NewChannelSenders creates the configured providers. Providers left at "log"
+// only log what they would have sent.
+func NewChannelSenders(config *ChannelConfig) (ChannelSenders, error) {
+	if config == nil {
+		config = NewChannelConfigFromEnv()
+	}
+
+	var senders ChannelSenders
+	switch config.SMSProvider {
+	case "", ProviderLog:
+		senders.SMS = LogSMSSender{}
+	case ProviderTwilio:
+		sender, err := NewTwilioSMSSender(config.TwilioAccountSID, config.TwilioAuthToken, config.TwilioFromNumber)
+		if err != nil {
+			return ChannelSenders{}, err
+		}
+		senders.SMS = sender
+	case ProviderMSG91:
+		sender, err := NewMSG91SMSSender(config.MSG91AuthKey, config.MSG91TemplateID)
+		if err != nil {
+			return ChannelSenders{}, err
+		}
+		senders.SMS = sender
+	default:
+		return ChannelSenders{}, fmt.Errorf("unknown SMS provider %q: must be log, twilio or msg91", config.SMSProvider)
+	}
+
+	switch config.PushProvider {
+	case "", ProviderLog:
+		senders.Push = LogPushSender{}
+	case ProviderFCM:
+		sender, err := NewFCMPushSender(config.FCMProjectID, config.FCMCredentialsFile)
+		if err != nil {
+			return ChannelSenders{}, err
+		}
+		senders.Push = sender
+	default:
+		return ChannelSenders{}, fmt.Errorf("unknown push provider %q: must be log or fcm", config.PushProvider)
+	}
+
+	return senders, nil
+}
+
+// LogSMSSender logs text messages instead of sending them. It stands in until
+// an SMS provider is configured, the same way MockPaymentGateway does for payments.
+type LogSMSSender struct{}
+
+func (LogSMSSender) SendSMS(ctx context.Context, phone, body string) error {
+	log.Printf("📱 [SMS] To %s: %s", maskContact(phone), body)
+	return nil
+}
+
+// LogPushSender logs push notifications instead of sending them
+type LogPushSender struct{}
+
+func (LogPushSender) SendPush(ctx context.Context, token, title, body string, data map[string]string) error {
+	log.Printf("🔔 [PUSH] To %s: %s - %s", maskContact(token), title, body)
+	return nil
+}
+
+// maskContact keeps only the last four characters so logs don't leak contact details
+func maskContact(value string) string {
+	if len(value) <= 4 {
+		return "****"
+	}
+	return "****" + value[len(value)-4:]
+}
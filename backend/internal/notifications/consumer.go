@@ -6,8 +6,11 @@ import (
 	"fmt"
 	"log"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"evently/pkg/logger"
+
 	"github.com/IBM/sarama"
 )
 
@@ -15,6 +18,13 @@ type NotificationConsumer interface {
 	StartConsumers(ctx context.Context, numWorkers int) error
 	Stop() error
 	HealthCheck(ctx context.Context) error
+	SetDeliveryTracker(tracker DeliveryTracker)
+}
+
+// DeliveryTracker records the outcome of each delivery attempt, after retries,
+// so features can show whether their notifications went out
+type DeliveryTracker interface {
+	RecordDelivery(ctx context.Context, notification *EmailNotification, deliveryErr error)
 }
 
 type ConsumerConfig struct {
@@ -29,6 +39,7 @@ type ConsumerConfig struct {
 	OffsetOldest         bool
 	MaxRetries           int
 	RetryBackoffDuration time.Duration
+	DedupWindow          time.Duration
 }
 
 func DefaultConsumerConfig() *ConsumerConfig {
@@ -44,6 +55,7 @@ func DefaultConsumerConfig() *ConsumerConfig {
 		OffsetOldest:         false,
 		MaxRetries:           3,
 		RetryBackoffDuration: time.Second,
+		DedupWindow:          24 * time.Hour,
 	}
 }
 
@@ -51,12 +63,15 @@ type KafkaNotificationConsumer struct {
 	consumerGroup sarama.ConsumerGroup
 	config        *ConsumerConfig
 	emailService  EmailService
+	channels      ChannelSenders
+	tracker       atomic.Pointer[DeliveryTracker]
+	dedup         *deliveryDedup
 	topics        []string
 	ctx           context.Context
 	cancel        context.CancelFunc
 }
 
-func NewKafkaNotificationConsumer(config *ConsumerConfig, emailService EmailService) (NotificationConsumer, error) {
+func NewKafkaNotificationConsumer(config *ConsumerConfig, emailService EmailService, channels ChannelSenders) (NotificationConsumer, error) {
 	saramaConfig := sarama.NewConfig()
 
 	saramaConfig.Consumer.Group.Session.Timeout = time.Duration(config.SessionTimeoutMs) * time.Millisecond
@@ -87,12 +102,25 @@ func NewKafkaNotificationConsumer(config *ConsumerConfig, emailService EmailServ
 		consumerGroup: consumerGroup,
 		config:        config,
 		emailService:  emailService,
+		channels:      channels,
+		dedup:         newDeliveryDedup(config.DedupWindow),
 		topics:        config.Topics,
 		ctx:           ctx,
 		cancel:        cancel,
 	}, nil
 }
 
+// SetDeliveryTracker reports delivery outcomes to tracker from then on
+func (knc *KafkaNotificationConsumer) SetDeliveryTracker(tracker DeliveryTracker) {
+	knc.tracker.Store(&tracker)
+}
+
+func (knc *KafkaNotificationConsumer) recordDelivery(ctx context.Context, notification *EmailNotification, deliveryErr error) {
+	if tracker := knc.tracker.Load(); tracker != nil && *tracker != nil {
+		(*tracker).RecordDelivery(ctx, notification, deliveryErr)
+	}
+}
+
 func (knc *KafkaNotificationConsumer) StartConsumers(ctx context.Context, numWorkers int) error {
 	log.Printf("📥 Starting %d notification consumer workers for topics: %v", numWorkers, knc.topics)
 
@@ -213,6 +241,12 @@ func (h *ConsumerGroupHandler) processMessage(ctx context.Context, message *sara
 		return fmt.Errorf("failed to unmarshal notification: %w", err)
 	}
 
+	// Skip redelivered notifications that were already sent
+	if h.consumer.dedup.Seen(notification.ID) {
+		log.Printf("📥 Worker %d: Notification %s already delivered, skipping duplicate", h.workerID, notification.ID)
+		return nil
+	}
+
 	// Check if notification is expired
 	if notification.IsExpired() {
 		log.Printf("📥 Worker %d: Notification %s expired, skipping", h.workerID, notification.ID)
@@ -221,25 +255,69 @@ func (h *ConsumerGroupHandler) processMessage(ctx context.Context, message *sara
 
 	// Update status to sending
 	notification.Status = NotificationStatusSending
+	requestNote := ""
+	if notification.RequestID != "" {
+		ctx = logger.ContextWithRequestID(ctx, notification.RequestID)
+		requestNote = fmt.Sprintf(" (request %s)", notification.RequestID)
+	}
 
-	// Process email notification with retry logic
+	// Deliver on the notification's channel with retry logic
 	err := h.executeWithRetry(ctx, &notification)
 	if err != nil {
 		notification.MarkFailed(err)
+		h.consumer.recordDelivery(ctx, &notification, err)
 		return err
 	}
 
 	notification.MarkSent()
-	log.Printf("📧 Worker %d: Email notification sent successfully to %s", h.workerID, notification.RecipientEmail)
+	h.consumer.dedup.MarkDelivered(notification.ID)
+	h.consumer.recordDelivery(ctx, &notification, nil)
+	log.Printf("📧 Worker %d: %s notification sent successfully to user %s%s",
+		h.workerID, notification.DeliveryChannel(), notification.RecipientID, requestNote)
 	return nil
 }
 
+// deliver sends a notification on its channel
+func (h *ConsumerGroupHandler) deliver(ctx context.Context, notification *EmailNotification) error {
+	switch notification.DeliveryChannel() {
+	case NotificationChannelSMS:
+		if h.consumer.channels.SMS == nil {
+			return fmt.Errorf("no SMS provider configured")
+		}
+		body, ok := RenderSMS(notification.Type, notification.TemplateData)
+		if !ok {
+			return fmt.Errorf("%s notifications have no SMS template", notification.Type)
+		}
+		return h.consumer.channels.SMS.SendSMS(ctx, notification.RecipientPhone, body)
+
+	case NotificationChannelPush:
+		if h.consumer.channels.Push == nil {
+			return fmt.Errorf("no push provider configured")
+		}
+		title, body, ok := RenderPush(notification.Type, notification.TemplateData)
+		if !ok {
+			return fmt.Errorf("%s notifications have no push template", notification.Type)
+		}
+		data := map[string]string{"type": string(notification.Type)}
+		if notification.EventID != nil {
+			data["event_id"] = notification.EventID.String()
+		}
+		if notification.BookingID != nil {
+			data["booking_id"] = notification.BookingID.String()
+		}
+		return h.consumer.channels.Push.SendPush(ctx, notification.RecipientPushToken, title, body, data)
+
+	default:
+		return h.emailService.SendNotification(ctx, notification)
+	}
+}
+
 func (h *ConsumerGroupHandler) executeWithRetry(ctx context.Context, notification *EmailNotification) error {
 	maxRetries := h.consumer.config.MaxRetries
 	backoff := h.consumer.config.RetryBackoffDuration
 
 	for attempt := 0; attempt <= maxRetries; attempt++ {
-		err := h.emailService.SendNotification(ctx, notification)
+		err := h.deliver(ctx, notification)
 		if err == nil {
 			if attempt > 0 {
 				log.Printf("📥 Worker %d: Successfully processed notification after %d retries", h.workerID, attempt)
@@ -0,0 +1,54 @@
+package notifications
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// deliveryDedup remembers recently delivered notification IDs so that
+// redelivered messages (at-least-once relay, consumer rebalances) are not
+// emailed twice.
+type deliveryDedup struct {
+	mu        sync.Mutex
+	ttl       time.Duration
+	delivered map[uuid.UUID]time.Time
+	lastSweep time.Time
+}
+
+func newDeliveryDedup(ttl time.Duration) *deliveryDedup {
+	return &deliveryDedup{
+		ttl:       ttl,
+		delivered: make(map[uuid.UUID]time.Time),
+		lastSweep: time.Now(),
+	}
+}
+
+// Seen reports whether the notification was delivered within the TTL window
+func (d *deliveryDedup) Seen(id uuid.UUID) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	deliveredAt, ok := d.delivered[id]
+	return ok && time.Since(deliveredAt) < d.ttl
+}
+
+// MarkDelivered records a successful delivery and evicts expired entries
+func (d *deliveryDedup) MarkDelivered(id uuid.UUID) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := time.Now()
+	d.delivered[id] = now
+
+	if now.Sub(d.lastSweep) < d.ttl {
+		return
+	}
+	for key, deliveredAt := range d.delivered {
+		if now.Sub(deliveredAt) >= d.ttl {
+			delete(d.delivered, key)
+		}
+	}
+	d.lastSweep = now
+}
@@ -4,16 +4,20 @@ import (
 	"context"
 	"crypto/tls"
 	"fmt"
+	"html"
 	"log"
 	"net/smtp"
 	"os"
 	"strconv"
+	"strings"
+	"sync/atomic"
 	"time"
 )
 
 type EmailService interface {
 	SendNotification(ctx context.Context, notification *EmailNotification) error
 	SendHTML(ctx context.Context, to, subject, htmlBody, textBody string) error
+	SetTemplateSource(source TemplateSource)
 }
 
 type SMTPConfig struct {
@@ -51,7 +55,8 @@ func NewSMTPConfigFromEnv() *SMTPConfig {
 }
 
 type SMTPEmailService struct {
-	config *SMTPConfig
+	config    *SMTPConfig
+	templates atomic.Pointer[TemplateSource]
 }
 
 func NewSMTPEmailService(config *SMTPConfig) *SMTPEmailService {
@@ -94,12 +99,47 @@ func (s *SMTPEmailService) SendNotification(ctx context.Context, notification *E
 		notification.RecipientName,
 	)
 
-	htmlBody, textBody, err := s.generateContent(notification)
+	subject, htmlBody, textBody, err := s.render(ctx, notification)
 	if err != nil {
 		return fmt.Errorf("failed to generate email content: %w", err)
 	}
+	htmlBody, textBody = applyBranding(notification.TemplateData, htmlBody, textBody)
 
-	return s.SendHTML(ctx, notification.RecipientEmail, notification.Subject, htmlBody, textBody)
+	return s.SendHTML(ctx, notification.RecipientEmail, subject, htmlBody, textBody)
+}
+
+// SetTemplateSource makes emails use admin-managed templates where one exists
+func (s *SMTPEmailService) SetTemplateSource(source TemplateSource) {
+	s.templates.Store(&source)
+}
+
+// render uses the admin-managed template for the notification when there is
+// one, falling back to the built-in template if it is missing or fails
+func (s *SMTPEmailService) render(ctx context.Context, notification *EmailNotification) (subject, htmlBody, textBody string, err error) {
+	if source := s.templates.Load(); source != nil && *source != nil {
+		var stored *EmailTemplate
+		if notification.TemplateVersionID != nil {
+			stored, err = (*source).TemplateVersion(ctx, *notification.TemplateVersionID)
+		} else {
+			stored, err = (*source).ActiveTemplate(ctx, notification.Type, notification.Locale)
+		}
+		if err == nil && stored != nil {
+			subject, htmlBody, textBody, err = stored.Render(notification.RecipientName, notification.TemplateData)
+			if err == nil {
+				// Test sends render their own marked subject
+				if notification.TemplateVersionID != nil {
+					subject = notification.Subject
+				}
+				return subject, htmlBody, textBody, nil
+			}
+		}
+		if err != nil {
+			log.Printf("⚠️ [SMTP] Using the built-in %s template: %v", notification.Type, err)
+		}
+	}
+
+	htmlBody, textBody, err = s.generateContent(notification)
+	return notification.Subject, htmlBody, textBody, err
 }
 
 func (s *SMTPEmailService) SendHTML(ctx context.Context, to, subject, htmlBody, textBody string) error {
@@ -209,35 +249,41 @@ func (s *SMTPEmailService) generateContent(notification *EmailNotification) (str
 
 	switch notification.Type {
 	case NotificationTypeWaitlistSpotAvailable:
-		// expiresAtStr, ok := data["expires_at"].(string)
-		// if !ok {
-		// 	return "", "", fmt.Errorf("expires_at is not a string")
-		// }
-		// formattedDate, err := time.Parse("2006-01-02T15:04:05.999999-07:00", expiresAtStr)
-		// if err != nil {
-		// 	return "", "", fmt.Errorf("failed to parse expires_at: %w", err)
-		// }
+		htmlDetails, textDetails := waitlistEventDetails(data)
+
+		htmlLink, textLink := "", ""
+		if url := templateValue(data, "booking_url", ""); url != "" {
+			htmlLink = fmt.Sprintf(`<p><a href="%s">Book your tickets now</a></p>`, html.EscapeString(url))
+			textLink = fmt.Sprintf("Book your tickets now: %s\n", url)
+		}
 
 		htmlBody := fmt.Sprintf(`
 			<h2>🎉 Great News! A spot is available</h2>
 			<p>Hi %s,</p>
 			<p>A spot has become available for <strong>%s</strong>.</p>
+			%s
 			<p>You have until <strong>%v</strong> to secure your booking.</p>
 			<p>Your position in the waitlist queue was #%v.</p>
-			<p>Best regards,<br>Evently Team</p>
+			%s
+			<p>Best regards,<br>Evently Team</p>%s
 		`,
 			notification.RecipientName,
 			data["event_title"],
+			htmlDetails,
 			data["expires_at"],
 			data["position"],
+			htmlLink,
+			openTrackingPixel(data),
 		)
 
 		textBody := fmt.Sprintf(
-			"Hi %s,\n\nA spot has become available for %s.\nYou have until %v to secure your booking.\nYour position in the waitlist queue was #%v.\n\nBest regards,\nEvently Team",
+			"Hi %s,\n\nA spot has become available for %s.\n%sYou have until %v to secure your booking.\nYour position in the waitlist queue was #%v.\n%s\nBest regards,\nEvently Team",
 			notification.RecipientName,
 			data["event_title"],
+			textDetails,
 			data["expires_at"],
 			data["position"],
+			textLink,
 		)
 
 		return htmlBody, textBody, nil
@@ -271,23 +317,598 @@ func (s *SMTPEmailService) generateContent(notification *EmailNotification) (str
 		return htmlBody, textBody, nil
 
 	case NotificationTypeWaitlistPositionUpdate:
+		htmlDetails, textDetails := waitlistEventDetails(data)
+
 		htmlBody := fmt.Sprintf(`
 			<h2>📊 Waitlist Position Update</h2>
 			<p>Hi %s,</p>
 			<p>Your position for <strong>%s</strong> has been updated.</p>
 			<p>Current position: <strong>#%v</strong></p>
+			%s
 			<p>Best regards,<br>Evently Team</p>
 		`,
 			notification.RecipientName,
 			data["event_title"],
 			data["position"],
+			htmlDetails,
 		)
 
 		textBody := fmt.Sprintf(
-			"Hi %s,\n\nYour position for %s has been updated.\nCurrent position: #%v\n\nBest regards,\nEvently Team",
+			"Hi %s,\n\nYour position for %s has been updated.\nCurrent position: #%v\n%s\nBest regards,\nEvently Team",
 			notification.RecipientName,
 			data["event_title"],
 			data["position"],
+			textDetails,
+		)
+
+		return htmlBody, textBody, nil
+
+	case NotificationTypeYearlyRecap:
+		return renderYearlyRecap(notification)
+
+	case NotificationTypeAnalyticsReport:
+		return renderAnalyticsReport(notification)
+
+	case NotificationTypePaymentFailed:
+		retryLine := ""
+		if nextRetry, ok := data["next_retry_at"]; ok {
+			retryLine = fmt.Sprintf("We'll try again automatically at %v.", nextRetry)
+		}
+
+		htmlBody := fmt.Sprintf(`
+			<h2>⚠️ Payment Failed</h2>
+			<p>Hi %s,</p>
+			<p>We couldn't process the payment for your booking for <strong>%s</strong>.</p>
+			<p>Booking Number: <strong>%s</strong></p>
+			<p>Amount Due: $%.2f</p>
+			<p>Your seats are reserved while we retry (attempt %v of %v). %s</p>
+			<p><a href="%s">Complete your payment now</a></p>
+			<p>Best regards,<br>Evently Team</p>
+		`,
+			notification.RecipientName,
+			data["event_title"],
+			data["booking_number"],
+			data["total_amount"],
+			data["attempt"],
+			data["max_attempts"],
+			retryLine,
+			data["resume_url"],
+		)
+
+		textBody := fmt.Sprintf(
+			"Hi %s,\n\nWe couldn't process the payment for your booking for %s.\nBooking Number: %s\nAmount Due: $%.2f\nYour seats are reserved while we retry (attempt %v of %v). %s\n\nComplete your payment now: %s\n\nBest regards,\nEvently Team",
+			notification.RecipientName,
+			data["event_title"],
+			data["booking_number"],
+			data["total_amount"],
+			data["attempt"],
+			data["max_attempts"],
+			retryLine,
+			data["resume_url"],
+		)
+
+		return htmlBody, textBody, nil
+
+	case NotificationTypeBookingPaymentExpired:
+		htmlBody := fmt.Sprintf(`
+			<h2>❌ Booking Cancelled</h2>
+			<p>Hi %s,</p>
+			<p>We couldn't collect payment for your booking for <strong>%s</strong> after %v attempts, so it has been cancelled and the seats released.</p>
+			<p>Booking Number: <strong>%s</strong></p>
+			<p>You're welcome to book again if seats are still available.</p>
+			<p>Best regards,<br>Evently Team</p>
+		`,
+			notification.RecipientName,
+			data["event_title"],
+			data["attempt"],
+			data["booking_number"],
+		)
+
+		textBody := fmt.Sprintf(
+			"Hi %s,\n\nWe couldn't collect payment for your booking for %s after %v attempts, so it has been cancelled and the seats released.\nBooking Number: %s\n\nYou're welcome to book again if seats are still available.\n\nBest regards,\nEvently Team",
+			notification.RecipientName,
+			data["event_title"],
+			data["attempt"],
+			data["booking_number"],
+		)
+
+		return htmlBody, textBody, nil
+
+	case NotificationTypeFavoriteSellingOut:
+		htmlBody := fmt.Sprintf(`
+			<h2>🔥 Almost Sold Out</h2>
+			<p>Hi %s,</p>
+			<p><strong>%s</strong>, an event on your favorites list, is almost sold out.</p>
+			<p>Only <strong>%v</strong> of %v seats are left.</p>
+			<p>Book soon if you'd like to go!</p>
+			<p>Best regards,<br>Evently Team</p>
+		`,
+			notification.RecipientName,
+			data["event_title"],
+			data["remaining_seats"],
+			data["total_capacity"],
+		)
+
+		textBody := fmt.Sprintf(
+			"Hi %s,\n\n%s, an event on your favorites list, is almost sold out.\nOnly %v of %v seats are left.\n\nBook soon if you'd like to go!\n\nBest regards,\nEvently Team",
+			notification.RecipientName,
+			data["event_title"],
+			data["remaining_seats"],
+			data["total_capacity"],
+		)
+
+		return htmlBody, textBody, nil
+
+	case NotificationTypeFavoritePriceDrop:
+		htmlBody := fmt.Sprintf(`
+			<h2>💸 Price Drop</h2>
+			<p>Hi %s,</p>
+			<p>Good news! Tickets for <strong>%s</strong>, an event on your favorites list, just got cheaper.</p>
+			<p>Was: <s>$%.2f</s><br>Now: <strong>$%.2f</strong></p>
+			<p>Best regards,<br>Evently Team</p>
+		`,
+			notification.RecipientName,
+			data["event_title"],
+			data["old_price"],
+			data["new_price"],
+		)
+
+		textBody := fmt.Sprintf(
+			"Hi %s,\n\nGood news! Tickets for %s, an event on your favorites list, just got cheaper.\nWas: $%.2f\nNow: $%.2f\n\nBest regards,\nEvently Team",
+			notification.RecipientName,
+			data["event_title"],
+			data["old_price"],
+			data["new_price"],
+		)
+
+		return htmlBody, textBody, nil
+
+	case NotificationTypeSupportTicketReply:
+		statusLine := "Reply to this ticket in the app if you need anything else."
+		if resolved, ok := data["resolved"].(bool); ok && resolved {
+			statusLine = "We've marked this ticket as resolved. Replying in the app will reopen it."
+		}
+
+		htmlBody := fmt.Sprintf(`
+			<h2>💬 Support Reply</h2>
+			<p>Hi %s,</p>
+			<p>Our support team replied to your ticket <strong>%s</strong> (%s):</p>
+			<blockquote>%s</blockquote>
+			<p>%s</p>
+			<p>Best regards,<br>Evently Team</p>
+		`,
+			notification.RecipientName,
+			data["ticket_number"],
+			html.EscapeString(fmt.Sprint(data["subject"])),
+			html.EscapeString(fmt.Sprint(data["reply"])),
+			statusLine,
+		)
+
+		textBody := fmt.Sprintf(
+			"Hi %s,\n\nOur support team replied to your ticket %s (%s):\n\n%s\n\n%s\n\nBest regards,\nEvently Team",
+			notification.RecipientName,
+			data["ticket_number"],
+			data["subject"],
+			data["reply"],
+			statusLine,
+		)
+
+		return htmlBody, textBody, nil
+
+	case NotificationTypeEventScheduleChanged:
+		var htmlChanges, textChanges string
+		if changed, _ := data["date_time_changed"].(bool); changed {
+			htmlChanges += fmt.Sprintf("<p>Date &amp; time: <s>%s</s><br>Now: <strong>%s</strong></p>", data["date_time_before"], data["date_time_after"])
+			textChanges += fmt.Sprintf("Date & time: %s -> %s\n", data["date_time_before"], data["date_time_after"])
+		}
+		if changed, _ := data["venue_changed"].(bool); changed {
+			htmlChanges += fmt.Sprintf("<p>Venue: <s>%s</s><br>Now: <strong>%s</strong></p>",
+				html.EscapeString(fmt.Sprint(data["venue_before"])), html.EscapeString(fmt.Sprint(data["venue_after"])))
+			textChanges += fmt.Sprintf("Venue: %s -> %s\n", data["venue_before"], data["venue_after"])
+		}
+
+		intro := "an event you have tickets for"
+		action := "Please confirm you can still attend, or cancel your booking"
+		if data["recipient_kind"] == "waitlist" {
+			intro = "an event you're on the waitlist for"
+			action = "Check that the new details still work for you, or leave the waitlist"
+		}
+
+		htmlBody := fmt.Sprintf(`
+			<h2>📅 Event Updated</h2>
+			<p>Hi %s,</p>
+			<p>The organizer has changed <strong>%s</strong>, %s.</p>
+			%s
+			<p><a href="%s">%s</a></p>
+			<p>Best regards,<br>Evently Team</p>
+		`,
+			notification.RecipientName,
+			data["event_title"],
+			intro,
+			htmlChanges,
+			data["action_url"],
+			action,
+		)
+
+		textBody := fmt.Sprintf(
+			"Hi %s,\n\nThe organizer has changed %s, %s.\n\n%s\n%s: %s\n\nBest regards,\nEvently Team",
+			notification.RecipientName,
+			data["event_title"],
+			intro,
+			textChanges,
+			action,
+			data["action_url"],
+		)
+
+		return htmlBody, textBody, nil
+
+	case NotificationTypeEventReminder:
+		htmlBody := fmt.Sprintf(`
+			<h2>⏰ Your Event Is Coming Up</h2>
+			<p>Hi %s,</p>
+			<p>This is a reminder that <strong>%s</strong> starts %s.</p>
+			<p><strong>When:</strong> %s<br><strong>Where:</strong> %s<br><strong>Booking:</strong> %s</p>
+			<p><a href="%s">View your booking and tickets</a></p>
+			<p>See you there!<br>Evently Team</p>
+		`,
+			notification.RecipientName,
+			data["event_title"],
+			data["starts_in"],
+			data["event_date_time"],
+			html.EscapeString(fmt.Sprint(data["venue"])),
+			data["booking_ref"],
+			data["action_url"],
+		)
+
+		textBody := fmt.Sprintf(
+			"Hi %s,\n\nThis is a reminder that %s starts %s.\n\nWhen: %s\nWhere: %s\nBooking: %s\n\nView your booking and tickets: %s\n\nSee you there!\nEvently Team",
+			notification.RecipientName,
+			data["event_title"],
+			data["starts_in"],
+			data["event_date_time"],
+			data["venue"],
+			data["booking_ref"],
+			data["action_url"],
+		)
+
+		return htmlBody, textBody, nil
+
+	case NotificationTypeEventCancelled:
+		htmlNext := "<p>You have been removed from the waitlist. We hope to see you at another event soon.</p>"
+		textNext := "You have been removed from the waitlist. We hope to see you at another event soon."
+		if data["recipient_kind"] != "waitlist" {
+			htmlNext = fmt.Sprintf("<p>Your booking <strong>%s</strong> has been cancelled and refunded in full: <strong>%v %v</strong>. "+
+				"The refund reaches your original payment method within %v business days.</p>",
+				data["booking_ref"], data["currency"], data["refund_amount"], data["refund_processing_days"])
+			textNext = fmt.Sprintf("Your booking %s has been cancelled and refunded in full: %v %v. "+
+				"The refund reaches your original payment method within %v business days.",
+				data["booking_ref"], data["currency"], data["refund_amount"], data["refund_processing_days"])
+		}
+
+		htmlBody := fmt.Sprintf(`
+			<h2>🚫 Event Cancelled</h2>
+			<p>Hi %s,</p>
+			<p>We're sorry to let you know that <strong>%s</strong> has been cancelled by the organizer.</p>
+			<p><em>%s</em></p>
+			%s
+			<p>Best regards,<br>Evently Team</p>
+		`,
+			notification.RecipientName,
+			data["event_title"],
+			html.EscapeString(fmt.Sprint(data["reason"])),
+			htmlNext,
+		)
+
+		textBody := fmt.Sprintf(
+			"Hi %s,\n\nWe're sorry to let you know that %s has been cancelled by the organizer.\n\n%s\n\n%s\n\nBest regards,\nEvently Team",
+			notification.RecipientName,
+			data["event_title"],
+			data["reason"],
+			textNext,
+		)
+
+		return htmlBody, textBody, nil
+
+	case NotificationTypeDocumentArchiveReady:
+		htmlBody := fmt.Sprintf(`
+			<h2>📦 Your Documents Are Ready</h2>
+			<p>Hi %s,</p>
+			<p>The archive you asked for is ready. It has %v ticket(s) and %v invoice(s) from %v booking(s).</p>
+			<p><a href="%s">Download your archive</a></p>
+			<p>The link works until %s. After that you can request a new archive from your account.</p>
+			<p>Best regards,<br>Evently Team</p>
+		`,
+			notification.RecipientName,
+			data["ticket_count"],
+			data["invoice_count"],
+			data["booking_count"],
+			html.EscapeString(fmt.Sprint(data["download_url"])),
+			data["expires_at"],
+		)
+
+		textBody := fmt.Sprintf(
+			"Hi %s,\n\nThe archive you asked for is ready. It has %v ticket(s) and %v invoice(s) from %v booking(s).\n\nDownload it here: %s\n\nThe link works until %s. After that you can request a new archive from your account.\n\nBest regards,\nEvently Team",
+			notification.RecipientName,
+			data["ticket_count"],
+			data["invoice_count"],
+			data["booking_count"],
+			data["download_url"],
+			data["expires_at"],
+		)
+
+		return htmlBody, textBody, nil
+
+	case NotificationTypeTicketTransferOffered:
+		htmlNote, textNote := "", ""
+		if note := templateValue(data, "message", ""); note != "" {
+			htmlNote = fmt.Sprintf("<blockquote>%s</blockquote>", html.EscapeString(note))
+			textNote = fmt.Sprintf("\"%s\"\n\n", note)
+		}
+
+		htmlBody := fmt.Sprintf(`
+			<h2>🎟️ You've Been Sent Tickets</h2>
+			<p>Hi %s,</p>
+			<p>%s wants to give you %v ticket(s) for <strong>%s</strong>.</p>
+			%s
+			<p><a href="%s">Accept the tickets</a></p>
+			<p>The offer is open until %s. Once you accept, the tickets are issued in your name under a new booking reference.</p>
+			<p>Best regards,<br>Evently Team</p>
+		`,
+			notification.RecipientName,
+			html.EscapeString(fmt.Sprint(data["sender_name"])),
+			data["seat_count"],
+			data["event_title"],
+			htmlNote,
+			html.EscapeString(fmt.Sprint(data["accept_url"])),
+			data["expires_at"],
+		)
+
+		textBody := fmt.Sprintf(
+			"Hi %s,\n\n%s wants to give you %v ticket(s) for %s.\n\n%sAccept the tickets: %s\n\nThe offer is open until %s. Once you accept, the tickets are issued in your name under a new booking reference.\n\nBest regards,\nEvently Team",
+			notification.RecipientName,
+			data["sender_name"],
+			data["seat_count"],
+			data["event_title"],
+			textNote,
+			data["accept_url"],
+			data["expires_at"],
+		)
+
+		return htmlBody, textBody, nil
+
+	case NotificationTypeTicketTransferAccepted:
+		htmlNext := fmt.Sprintf("<p>Tickets issued under booking <strong>%s</strong> are no longer valid.</p>", data["booking_number"])
+		textNext := fmt.Sprintf("Tickets issued under booking %s are no longer valid.", data["booking_number"])
+		if remaining := templateValue(data, "remaining_ref", ""); remaining != "" {
+			htmlNext = fmt.Sprintf("<p>Your remaining seats were reissued under booking <strong>%s</strong>. Tickets with the old reference %s are no longer valid, please use the new ones.</p>",
+				remaining, data["booking_number"])
+			textNext = fmt.Sprintf("Your remaining seats were reissued under booking %s. Tickets with the old reference %s are no longer valid, please use the new ones.",
+				remaining, data["booking_number"])
+		}
+
+		htmlBody := fmt.Sprintf(`
+			<h2>✅ Transfer Accepted</h2>
+			<p>Hi %s,</p>
+			<p>%s accepted the %v ticket(s) you sent for <strong>%s</strong>.</p>
+			%s
+			<p>Best regards,<br>Evently Team</p>
+		`,
+			notification.RecipientName,
+			html.EscapeString(fmt.Sprint(data["recipient_name"])),
+			data["seat_count"],
+			data["event_title"],
+			htmlNext,
+		)
+
+		textBody := fmt.Sprintf(
+			"Hi %s,\n\n%s accepted the %v ticket(s) you sent for %s.\n%s\n\nBest regards,\nEvently Team",
+			notification.RecipientName,
+			data["recipient_name"],
+			data["seat_count"],
+			data["event_title"],
+			textNext,
+		)
+
+		return htmlBody, textBody, nil
+
+	case NotificationTypeResaleSold:
+		htmlNext := fmt.Sprintf("<p>Tickets issued under booking <strong>%s</strong> are no longer valid.</p>", data["booking_number"])
+		textNext := fmt.Sprintf("Tickets issued under booking %s are no longer valid.", data["booking_number"])
+		if remaining := templateValue(data, "remaining_ref", ""); remaining != "" {
+			htmlNext = fmt.Sprintf("<p>Your remaining seats were reissued under booking <strong>%s</strong>. Tickets with the old reference %s are no longer valid, please use the new ones.</p>",
+				remaining, data["booking_number"])
+			textNext = fmt.Sprintf("Your remaining seats were reissued under booking %s. Tickets with the old reference %s are no longer valid, please use the new ones.",
+				remaining, data["booking_number"])
+		}
+
+		htmlBody := fmt.Sprintf(`
+			<h2>💸 Your Tickets Sold</h2>
+			<p>Hi %s,</p>
+			<p>Your %v ticket(s) for <strong>%s</strong> sold on the resale marketplace for %v %v.</p>
+			<p>After the %v %v resale fee, <strong>%v %v</strong> will be paid out to you.</p>
+			%s
+			<p>Best regards,<br>Evently Team</p>
+		`,
+			notification.RecipientName,
+			data["seat_count"],
+			data["event_title"],
+			data["currency"], data["price"],
+			data["currency"], data["fee"],
+			data["currency"], data["payout"],
+			htmlNext,
+		)
+
+		textBody := fmt.Sprintf(
+			"Hi %s,\n\nYour %v ticket(s) for %s sold on the resale marketplace for %v %v.\n\nAfter the %v %v resale fee, %v %v will be paid out to you.\n\n%s\n\nBest regards,\nEvently Team",
+			notification.RecipientName,
+			data["seat_count"],
+			data["event_title"],
+			data["currency"], data["price"],
+			data["currency"], data["fee"],
+			data["currency"], data["payout"],
+			textNext,
+		)
+
+		return htmlBody, textBody, nil
+
+	case NotificationTypeResalePurchased:
+		htmlBody := fmt.Sprintf(`
+			<h2>🎟️ Your Resale Tickets</h2>
+			<p>Hi %s,</p>
+			<p>You bought %v ticket(s) for <strong>%s</strong> for %v %v.</p>
+			<p>Your tickets were issued in your name under booking <strong>%s</strong>. Show this reference at the entrance.</p>
+			<p>Best regards,<br>Evently Team</p>
+		`,
+			notification.RecipientName,
+			data["seat_count"],
+			data["event_title"],
+			data["currency"], data["price"],
+			data["booking_number"],
+		)
+
+		textBody := fmt.Sprintf(
+			"Hi %s,\n\nYou bought %v ticket(s) for %s for %v %v.\n\nYour tickets were issued in your name under booking %s. Show this reference at the entrance.\n\nBest regards,\nEvently Team",
+			notification.RecipientName,
+			data["seat_count"],
+			data["event_title"],
+			data["currency"], data["price"],
+			data["booking_number"],
+		)
+
+		return htmlBody, textBody, nil
+
+	case NotificationTypeCancellationPending, NotificationTypeCancellationApproved, NotificationTypeCancellationRejected:
+		what := fmt.Sprintf("booking <strong>%s</strong>", data["booking_ref"])
+		textWhat := fmt.Sprintf("booking %s", data["booking_ref"])
+		if partial, ok := data["partial"].(bool); ok && partial {
+			what = fmt.Sprintf("%v seat(s) of booking <strong>%s</strong>", data["seat_count"], data["booking_ref"])
+			textWhat = fmt.Sprintf("%v seat(s) of booking %s", data["seat_count"], data["booking_ref"])
+		}
+
+		heading, htmlOutcome, textOutcome := "⏳ Cancellation Awaiting Approval",
+			"<p>The cancellation deadline for this event has passed, so your request has been passed to our team for approval. We'll let you know their decision; until then your booking stays valid.</p>",
+			"The cancellation deadline for this event has passed, so your request has been passed to our team for approval. We'll let you know their decision; until then your booking stays valid."
+		switch notification.Type {
+		case NotificationTypeCancellationApproved:
+			heading = "✅ Cancellation Approved"
+			htmlOutcome = fmt.Sprintf("<p>Your cancellation has been approved. <strong>%v %v</strong> will reach your original payment method within %v business days.</p>",
+				data["currency"], data["refund_amount"], data["refund_processing_days"])
+			textOutcome = fmt.Sprintf("Your cancellation has been approved. %v %v will reach your original payment method within %v business days.",
+				data["currency"], data["refund_amount"], data["refund_processing_days"])
+		case NotificationTypeCancellationRejected:
+			heading = "❌ Cancellation Declined"
+			htmlOutcome = "<p>Your cancellation could not be approved. Your booking stays valid and nothing has been refunded.</p>"
+			textOutcome = "Your cancellation could not be approved. Your booking stays valid and nothing has been refunded."
+		}
+		htmlNote, textNote := "", ""
+		if note := templateValue(data, "review_note", ""); note != "" {
+			htmlNote = fmt.Sprintf("<blockquote>%s</blockquote>", html.EscapeString(note))
+			textNote = fmt.Sprintf("\"%s\"\n\n", note)
+		}
+
+		htmlBody := fmt.Sprintf(`
+			<h2>%s</h2>
+			<p>Hi %s,</p>
+			<p>This is about your cancellation of %s for <strong>%s</strong>.</p>
+			%s
+			%s
+			<p>Best regards,<br>Evently Team</p>
+		`,
+			heading,
+			notification.RecipientName,
+			what,
+			data["event_title"],
+			htmlOutcome,
+			htmlNote,
+		)
+
+		textBody := fmt.Sprintf(
+			"Hi %s,\n\nThis is about your cancellation of %s for %s.\n\n%s\n\n%sBest regards,\nEvently Team",
+			notification.RecipientName,
+			textWhat,
+			data["event_title"],
+			textOutcome,
+			textNote,
+		)
+
+		return htmlBody, textBody, nil
+
+	case NotificationTypeEmailChangeVerification:
+		htmlBody := fmt.Sprintf(`
+			<h2>✉️ Confirm Your New Email</h2>
+			<p>Hi %s,</p>
+			<p>You asked to change the email address of your Evently account to <strong>%s</strong>.</p>
+			<p><a href="%s">Confirm this address</a></p>
+			<p>The link works until %s. Until then you keep signing in with your current address. If you didn't ask for this, ignore this email.</p>
+			<p>Best regards,<br>Evently Team</p>
+		`,
+			notification.RecipientName,
+			html.EscapeString(fmt.Sprint(data["new_email"])),
+			html.EscapeString(fmt.Sprint(data["verify_url"])),
+			data["expires_at"],
+		)
+
+		textBody := fmt.Sprintf(
+			"Hi %s,\n\nYou asked to change the email address of your Evently account to %s.\n\nConfirm it here: %s\n\nThe link works until %s. Until then you keep signing in with your current address. If you didn't ask for this, ignore this email.\n\nBest regards,\nEvently Team",
+			notification.RecipientName,
+			data["new_email"],
+			data["verify_url"],
+			data["expires_at"],
+		)
+
+		return htmlBody, textBody, nil
+
+	case NotificationTypeEmailChanged:
+		htmlBody := fmt.Sprintf(`
+			<h2>🔐 Your Email Was Changed</h2>
+			<p>Hi %s,</p>
+			<p>The email address of your Evently account was changed to <strong>%s</strong> on %s. This address will no longer receive emails about your account.</p>
+			<p>If you didn't make this change, contact our support team straight away.</p>
+			<p>Best regards,<br>Evently Team</p>
+		`,
+			notification.RecipientName,
+			html.EscapeString(fmt.Sprint(data["new_email"])),
+			data["changed_at"],
+		)
+
+		textBody := fmt.Sprintf(
+			"Hi %s,\n\nThe email address of your Evently account was changed to %s on %s. This address will no longer receive emails about your account.\n\nIf you didn't make this change, contact our support team straight away.\n\nBest regards,\nEvently Team",
+			notification.RecipientName,
+			data["new_email"],
+			data["changed_at"],
+		)
+
+		return htmlBody, textBody, nil
+
+	case NotificationTypeEventCapacityThreshold:
+		headline := fmt.Sprintf("%v has reached %v%% of capacity.", data["event_title"], data["threshold"])
+		if soldOut, ok := data["sold_out"].(bool); ok && soldOut {
+			headline = fmt.Sprintf("%v is sold out.", data["event_title"])
+		}
+
+		htmlBody := fmt.Sprintf(`
+			<h2>📈 Capacity Update</h2>
+			<p>Hi %s,</p>
+			<p><strong>%s</strong></p>
+			<p>%v of %v seats are booked (%v%%), %v left.</p>
+			<p>Best regards,<br>Evently Team</p>
+		`,
+			notification.RecipientName,
+			html.EscapeString(headline),
+			data["booked_count"],
+			data["total_capacity"],
+			data["utilization"],
+			data["remaining_seats"],
+		)
+
+		textBody := fmt.Sprintf(
+			"Hi %s,\n\n%s\n%v of %v seats are booked (%v%%), %v left.\n\nBest regards,\nEvently Team",
+			notification.RecipientName,
+			headline,
+			data["booked_count"],
+			data["total_capacity"],
+			data["utilization"],
+			data["remaining_seats"],
 		)
 
 		return htmlBody, textBody, nil
@@ -312,3 +933,63 @@ func (s *SMTPEmailService) generateContent(notification *EmailNotification) (str
 		return htmlBody, textBody, nil
 	}
 }
+
+// waitlistEventDetails renders the date, venue and open sections of the event a
+// waitlist notification is about. Each part is left out when it wasn't provided.
+func waitlistEventDetails(data map[string]interface{}) (string, string) {
+	var htmlLines, textLines []string
+	if when := templateValue(data, "event_date_time", ""); when != "" {
+		htmlLines = append(htmlLines, "<strong>When:</strong> "+html.EscapeString(when))
+		textLines = append(textLines, "When: "+when)
+	}
+	if venue := templateValue(data, "venue", ""); venue != "" {
+		htmlLines = append(htmlLines, "<strong>Where:</strong> "+html.EscapeString(venue))
+		textLines = append(textLines, "Where: "+venue)
+	}
+
+	var htmlSections, textSections []string
+	for _, section := range templateList(data, "sections") {
+		line := fmt.Sprintf("%s: %s seats left", templateValue(section, "name", "Section"), templateValue(section, "available", "?"))
+		htmlSections = append(htmlSections, "<li>"+html.EscapeString(line)+"</li>")
+		textSections = append(textSections, "- "+line)
+	}
+
+	var htmlDetails, textDetails string
+	if len(htmlLines) > 0 {
+		htmlDetails = "<p>" + strings.Join(htmlLines, "<br>") + "</p>"
+		textDetails = strings.Join(textLines, "\n") + "\n"
+	}
+	if len(htmlSections) > 0 {
+		htmlDetails += "<p>Seats available now:</p><ul>" + strings.Join(htmlSections, "") + "</ul>"
+		textDetails += "Seats available now:\n" + strings.Join(textSections, "\n") + "\n"
+	}
+	return htmlDetails, textDetails
+}
+
+// templateList returns a template data value holding a list of objects. Lists
+// read back from JSON hold []interface{} rather than the original type.
+func templateList(data map[string]interface{}, key string) []map[string]interface{} {
+	switch value := data[key].(type) {
+	case []map[string]interface{}:
+		return value
+	case []interface{}:
+		items := make([]map[string]interface{}, 0, len(value))
+		for _, item := range value {
+			if object, ok := item.(map[string]interface{}); ok {
+				items = append(items, object)
+			}
+		}
+		return items
+	default:
+		return nil
+	}
+}
+
+// openTrackingPixel renders the open-tracking image for notifications that carry one
+func openTrackingPixel(data map[string]interface{}) string {
+	url, ok := data["open_tracking_url"].(string)
+	if !ok || url == "" {
+		return ""
+	}
+	return fmt.Sprintf(`<img src="%s" width="1" height="1" alt="" style="display:none">`, html.EscapeString(url))
+}
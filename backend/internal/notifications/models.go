@@ -14,13 +14,76 @@ const (
 	NotificationTypeWaitlistSpotAvailable  NotificationType = "WAITLIST_SPOT_AVAILABLE"
 	NotificationTypeBookingConfirmed       NotificationType = "BOOKING_CONFIRMED"
 	NotificationTypeWaitlistPositionUpdate NotificationType = "WAITLIST_POSITION_UPDATE"
+	NotificationTypeYearlyRecap            NotificationType = "YEARLY_RECAP"
+	NotificationTypePaymentFailed          NotificationType = "PAYMENT_FAILED"
+	NotificationTypeBookingPaymentExpired  NotificationType = "BOOKING_PAYMENT_EXPIRED"
+	NotificationTypeFavoriteSellingOut     NotificationType = "FAVORITE_SELLING_OUT"
+	NotificationTypeFavoritePriceDrop      NotificationType = "FAVORITE_PRICE_DROP"
+	NotificationTypeSupportTicketReply     NotificationType = "SUPPORT_TICKET_REPLY"
+	NotificationTypeEventScheduleChanged   NotificationType = "EVENT_SCHEDULE_CHANGED"
+	NotificationTypeEventReminder          NotificationType = "EVENT_REMINDER"
+	NotificationTypeEventCancelled         NotificationType = "EVENT_CANCELLED"
+	NotificationTypeDocumentArchiveReady   NotificationType = "DOCUMENT_ARCHIVE_READY"
+	NotificationTypeAnalyticsReport        NotificationType = "ANALYTICS_REPORT"
+	NotificationTypeEventCapacityThreshold NotificationType = "EVENT_CAPACITY_THRESHOLD"
+	NotificationTypeTicketTransferOffered  NotificationType = "TICKET_TRANSFER_OFFERED"
+	NotificationTypeTicketTransferAccepted NotificationType = "TICKET_TRANSFER_ACCEPTED"
+	NotificationTypeResaleSold             NotificationType = "RESALE_SOLD"
+	NotificationTypeResalePurchased        NotificationType = "RESALE_PURCHASED"
+	NotificationTypeCancellationPending    NotificationType = "CANCELLATION_PENDING_APPROVAL"
+	NotificationTypeCancellationApproved   NotificationType = "CANCELLATION_APPROVED"
+	NotificationTypeCancellationRejected   NotificationType = "CANCELLATION_REJECTED"
+
+	// Account security emails, always sent by email whatever the recipient's
+	// preferences, so they are not listed in NotificationTypes
+	NotificationTypeEmailChangeVerification NotificationType = "EMAIL_CHANGE_VERIFICATION"
+	NotificationTypeEmailChanged            NotificationType = "EMAIL_CHANGED"
 )
 
-// Only email channel since that's all that's implemented
+// NotificationTypes lists every notification type users can set preferences for
+var NotificationTypes = []NotificationType{
+	NotificationTypeWaitlistSpotAvailable,
+	NotificationTypeBookingConfirmed,
+	NotificationTypeWaitlistPositionUpdate,
+	NotificationTypeYearlyRecap,
+	NotificationTypePaymentFailed,
+	NotificationTypeBookingPaymentExpired,
+	NotificationTypeFavoriteSellingOut,
+	NotificationTypeFavoritePriceDrop,
+	NotificationTypeSupportTicketReply,
+	NotificationTypeEventScheduleChanged,
+	NotificationTypeEventReminder,
+	NotificationTypeEventCancelled,
+	NotificationTypeDocumentArchiveReady,
+	NotificationTypeAnalyticsReport,
+	NotificationTypeEventCapacityThreshold,
+	NotificationTypeTicketTransferOffered,
+	NotificationTypeTicketTransferAccepted,
+	NotificationTypeResaleSold,
+	NotificationTypeResalePurchased,
+	NotificationTypeCancellationPending,
+	NotificationTypeCancellationApproved,
+	NotificationTypeCancellationRejected,
+}
+
+// Template data keys carrying the branding an email is rendered with
+const (
+	TemplateKeyBrandName         = "brand_name"
+	TemplateKeyBrandLogoURL      = "brand_logo_url"
+	TemplateKeyBrandPrimaryColor = "brand_primary_color"
+)
+
+// TemplateKeyLocale picks the locale variant of an admin-managed email template
+const TemplateKeyLocale = "locale"
+
+// NotificationChannel is how a notification reaches the recipient
 type NotificationChannel string
 
 const (
 	NotificationChannelEmail NotificationChannel = "EMAIL"
+	NotificationChannelSMS   NotificationChannel = "SMS"
+	NotificationChannelPush  NotificationChannel = "PUSH"
+	NotificationChannelInApp NotificationChannel = "IN_APP"
 )
 
 type NotificationPriority string
@@ -32,6 +95,12 @@ const (
 	NotificationPriorityCritical NotificationPriority = "CRITICAL"
 )
 
+// IsTimeSensitive reports whether notifications of this priority go out during
+// the recipient's quiet hours instead of waiting for them to end
+func (p NotificationPriority) IsTimeSensitive() bool {
+	return p == NotificationPriorityHigh || p == NotificationPriorityCritical
+}
+
 type NotificationStatus string
 
 const (
@@ -51,20 +120,33 @@ type EmailNotification struct {
 	Type     NotificationType     `json:"type"`
 	Priority NotificationPriority `json:"priority"`
 
-	// Recipient info - removed phone since only email is supported
-	RecipientID    uuid.UUID `json:"recipient_id"`
-	RecipientEmail string    `json:"recipient_email"`
-	RecipientName  string    `json:"recipient_name"`
+	// Channel the notification is delivered on, empty is email
+	Channel NotificationChannel `json:"channel,omitempty"`
+
+	// Recipient info - phone and push token are only set for those channels
+	RecipientID        uuid.UUID `json:"recipient_id"`
+	RecipientEmail     string    `json:"recipient_email"`
+	RecipientName      string    `json:"recipient_name"`
+	RecipientPhone     string    `json:"recipient_phone,omitempty"`
+	RecipientPushToken string    `json:"recipient_push_token,omitempty"`
 
 	// Content
 	Subject      string                 `json:"subject"`
 	TemplateData map[string]interface{} `json:"template_data"`
 
+	// Admin-managed template selection - empty locale is DefaultLocale, and a
+	// template version pins one stored version, which test sends use
+	Locale            string     `json:"locale,omitempty"`
+	TemplateVersionID *uuid.UUID `json:"template_version_id,omitempty"`
+
 	// Context - kept only the ones actually used
 	EventID         *uuid.UUID `json:"event_id,omitempty"`
 	BookingID       *uuid.UUID `json:"booking_id,omitempty"`
 	WaitlistEntryID *uuid.UUID `json:"waitlist_entry_id,omitempty"`
 
+	// X-Request-ID of the API request that triggered the notification
+	RequestID string `json:"request_id,omitempty"`
+
 	// Timing
 	ExpiresAt *time.Time `json:"expires_at,omitempty"`
 
@@ -162,12 +244,75 @@ func GetDefaultPriority(notType NotificationType) NotificationPriority {
 		return NotificationPriorityMedium
 	case NotificationTypeWaitlistPositionUpdate:
 		return NotificationPriorityLow
+	case NotificationTypeYearlyRecap:
+		return NotificationPriorityLow
+	case NotificationTypePaymentFailed:
+		return NotificationPriorityHigh
+	case NotificationTypeBookingPaymentExpired:
+		return NotificationPriorityHigh
+	case NotificationTypeFavoriteSellingOut:
+		return NotificationPriorityMedium
+	case NotificationTypeFavoritePriceDrop:
+		return NotificationPriorityLow
+	case NotificationTypeSupportTicketReply:
+		return NotificationPriorityMedium
+	case NotificationTypeEventScheduleChanged:
+		return NotificationPriorityHigh
+	case NotificationTypeEventReminder:
+		return NotificationPriorityMedium
+	case NotificationTypeEventCancelled:
+		return NotificationPriorityHigh
+	case NotificationTypeDocumentArchiveReady:
+		return NotificationPriorityMedium
+	case NotificationTypeAnalyticsReport:
+		return NotificationPriorityLow
+	case NotificationTypeEventCapacityThreshold:
+		return NotificationPriorityHigh
+	case NotificationTypeTicketTransferOffered:
+		return NotificationPriorityHigh
+	case NotificationTypeTicketTransferAccepted:
+		return NotificationPriorityMedium
+	case NotificationTypeResaleSold:
+		return NotificationPriorityMedium
+	case NotificationTypeResalePurchased:
+		return NotificationPriorityHigh
+	case NotificationTypeCancellationPending:
+		return NotificationPriorityMedium
+	case NotificationTypeCancellationApproved:
+		return NotificationPriorityHigh
+	case NotificationTypeCancellationRejected:
+		return NotificationPriorityHigh
+	case NotificationTypeEmailChangeVerification:
+		return NotificationPriorityCritical
+	case NotificationTypeEmailChanged:
+		return NotificationPriorityCritical
 	default:
 		return NotificationPriorityMedium
 	}
 }
 
 // Utility methods
+
+// DeliveryChannel returns the channel the notification goes out on
+func (en *EmailNotification) DeliveryChannel() NotificationChannel {
+	if en.Channel == "" {
+		return NotificationChannelEmail
+	}
+	return en.Channel
+}
+
+// ForChannel copies the notification for delivery on another channel. The copy
+// gets an ID derived from the original's, so redeliveries of either are
+// deduplicated on their own.
+func (en *EmailNotification) ForChannel(channel NotificationChannel, phone, pushToken string) *EmailNotification {
+	copied := *en
+	copied.ID = uuid.NewSHA1(en.ID, []byte(channel))
+	copied.Channel = channel
+	copied.RecipientPhone = phone
+	copied.RecipientPushToken = pushToken
+	return &copied
+}
+
 func (en *EmailNotification) GetPartitionKey() string {
 	return en.RecipientID.String()
 }
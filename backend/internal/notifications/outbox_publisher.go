@@ -0,0 +1,232 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"evently/internal/outbox"
+
+	"github.com/google/uuid"
+)
+
+// RecipientResolver looks up recipient details for payloads that only carry a user ID
+type RecipientResolver interface {
+	GetUserByID(ctx context.Context, userID uuid.UUID) (email, firstName, lastName string, err error)
+}
+
+// EventResolver looks up the event title for payloads that don't include one
+type EventResolver interface {
+	GetEventTitle(ctx context.Context, eventID uuid.UUID) (string, error)
+}
+
+// BrandingResolver looks up the branding emails about an event are rendered with.
+// A nil event ID, or an organizer without branding, resolves to the platform branding.
+type BrandingResolver interface {
+	GetEmailBranding(ctx context.Context, eventID *uuid.UUID) (name, logoURL, primaryColor string, err error)
+}
+
+// DeliveryPlan is how a recipient's notification preferences route one notification
+type DeliveryPlan struct {
+	Email      bool
+	SMS        bool
+	Push       bool
+	InApp      bool
+	SMSPhone   string     // E.164 number the recipient registered for SMS
+	PushToken  string     // Device token the recipient registered for push
+	DeferUntil *time.Time // Set while the recipient's quiet hours are in effect
+}
+
+// defaultPlan is used when no preference resolver is configured
+var defaultPlan = &DeliveryPlan{Email: true, InApp: true}
+
+// PreferenceResolver looks up how a recipient wants a notification type delivered
+type PreferenceResolver interface {
+	ResolveDelivery(ctx context.Context, userID uuid.UUID, notificationType NotificationType) (*DeliveryPlan, error)
+}
+
+// InAppRecorder stores notifications shown in the in-app notification center.
+// Recording a notification ID twice must be a no-op.
+type InAppRecorder interface {
+	RecordInApp(ctx context.Context, notification *EmailNotification, title, body string) error
+}
+
+// OutboxPublisher relays outbox messages into the notification pipeline.
+// The notification ID is the outbox message ID, so redeliveries of the same
+// message are recognised and dropped by the consumer.
+type OutboxPublisher struct {
+	notificationService NotificationService
+	recipients          RecipientResolver
+	events              EventResolver
+	branding            BrandingResolver
+	preferences         PreferenceResolver
+	inApp               InAppRecorder
+}
+
+func NewOutboxPublisher(notificationService NotificationService, recipients RecipientResolver, events EventResolver) *OutboxPublisher {
+	return &OutboxPublisher{
+		notificationService: notificationService,
+		recipients:          recipients,
+		events:              events,
+	}
+}
+
+// SetBrandingResolver enables organizer branding in outgoing emails
+func (p *OutboxPublisher) SetBrandingResolver(branding BrandingResolver) {
+	p.branding = branding
+}
+
+// SetPreferenceResolver makes the publisher honour recipients' channel toggles
+// and quiet hours
+func (p *OutboxPublisher) SetPreferenceResolver(preferences PreferenceResolver) {
+	p.preferences = preferences
+}
+
+// SetInAppRecorder adds notifications to recipients' in-app notification center
+func (p *OutboxPublisher) SetInAppRecorder(inApp InAppRecorder) {
+	p.inApp = inApp
+}
+
+func (p *OutboxPublisher) PublishNotification(ctx context.Context, messageID uuid.UUID, payload *outbox.NotificationPayload) error {
+	if p.notificationService == nil {
+		return fmt.Errorf("notification service not available")
+	}
+
+	plan := defaultPlan
+	if p.preferences != nil && payload.RecipientID != uuid.Nil {
+		resolved, err := p.preferences.ResolveDelivery(ctx, payload.RecipientID, NotificationType(payload.Type))
+		if err != nil {
+			return fmt.Errorf("failed to resolve notification preferences: %w", err)
+		}
+		if resolved.DeferUntil != nil {
+			return &outbox.DeferredError{Until: *resolved.DeferUntil, Reason: "recipient quiet hours"}
+		}
+		plan = resolved
+	}
+
+	if payload.RecipientID == uuid.Nil && plan.InApp {
+		// Guests have no notification center
+		guestPlan := *plan
+		guestPlan.InApp = false
+		plan = &guestPlan
+	}
+
+	notificationType := NotificationType(payload.Type)
+	channels := p.channelsFor(notificationType, plan)
+	if len(channels) == 0 {
+		log.Printf("🔕 Skipping %s for user %s: every channel is turned off in their notification preferences", payload.Type, payload.RecipientID)
+		return nil
+	}
+
+	email, name := payload.RecipientEmail, payload.RecipientName
+	if email == "" && p.recipients != nil {
+		userEmail, firstName, lastName, err := p.recipients.GetUserByID(ctx, payload.RecipientID)
+		if err != nil {
+			return fmt.Errorf("failed to resolve recipient: %w", err)
+		}
+		email = userEmail
+		name = firstName
+		if lastName != "" {
+			name = firstName + " " + lastName
+		}
+	}
+	if email == "" && plan.Email {
+		return fmt.Errorf("recipient %s has no email address", payload.RecipientID)
+	}
+	if name == "" {
+		name = "User"
+	}
+
+	templateData := payload.TemplateData
+	if templateData == nil {
+		templateData = map[string]interface{}{}
+	}
+	if _, ok := templateData["event_title"]; !ok && payload.EventID != nil && p.events != nil {
+		if title, err := p.events.GetEventTitle(ctx, *payload.EventID); err == nil {
+			templateData["event_title"] = title
+		}
+	}
+	if _, ok := templateData[TemplateKeyBrandName]; !ok && p.branding != nil {
+		if brandName, logoURL, primaryColor, err := p.branding.GetEmailBranding(ctx, payload.EventID); err == nil {
+			templateData[TemplateKeyBrandName] = brandName
+			templateData[TemplateKeyBrandLogoURL] = logoURL
+			templateData[TemplateKeyBrandPrimaryColor] = primaryColor
+		}
+	}
+
+	builder := NewNotificationBuilder().
+		WithType(notificationType).
+		WithRecipient(payload.RecipientID, email, name).
+		WithTemplateData(templateData).
+		WithSubject(GenerateSubject(notificationType, templateData))
+	if payload.EventID != nil {
+		builder = builder.WithEventContext(*payload.EventID)
+	}
+	if payload.BookingID != nil {
+		builder = builder.WithBookingContext(*payload.BookingID)
+	}
+	if payload.WaitlistEntryID != nil {
+		builder = builder.WithWaitlistContext(*payload.WaitlistEntryID)
+	}
+
+	notification := builder.Build()
+	notification.ID = messageID
+	if locale, ok := templateData[TemplateKeyLocale].(string); ok {
+		notification.Locale = locale
+	}
+
+	// The email keeps the outbox message ID; the other channels get IDs derived
+	// from it, so a retried message is deduplicated per channel
+	outgoing := make([]*EmailNotification, 0, len(channels))
+	for _, channel := range channels {
+		switch channel {
+		case NotificationChannelSMS:
+			outgoing = append(outgoing, notification.ForChannel(channel, plan.SMSPhone, ""))
+		case NotificationChannelPush:
+			outgoing = append(outgoing, notification.ForChannel(channel, "", plan.PushToken))
+		case NotificationChannelInApp:
+			// Stored directly rather than sent through Kafka, there is nothing to deliver
+			title, body, _ := RenderInApp(notificationType, templateData)
+			if err := p.inApp.RecordInApp(ctx, notification.ForChannel(channel, "", ""), title, body); err != nil {
+				return fmt.Errorf("failed to record in-app notification: %w", err)
+			}
+		default:
+			outgoing = append(outgoing, notification)
+		}
+	}
+	switch len(outgoing) {
+	case 0:
+		return nil
+	case 1:
+		return p.notificationService.SendNotification(ctx, outgoing[0])
+	}
+	return p.notificationService.SendBatchNotifications(ctx, outgoing)
+}
+
+// channelsFor lists the channels a notification goes out on: those the plan
+// turns on, the type has a template for, and the recipient has a contact for
+func (p *OutboxPublisher) channelsFor(notificationType NotificationType, plan *DeliveryPlan) []NotificationChannel {
+	var channels []NotificationChannel
+	for _, channel := range SupportedChannels(notificationType) {
+		switch channel {
+		case NotificationChannelEmail:
+			if plan.Email {
+				channels = append(channels, channel)
+			}
+		case NotificationChannelSMS:
+			if plan.SMS && plan.SMSPhone != "" {
+				channels = append(channels, channel)
+			}
+		case NotificationChannelPush:
+			if plan.Push && plan.PushToken != "" {
+				channels = append(channels, channel)
+			}
+		case NotificationChannelInApp:
+			if plan.InApp && p.inApp != nil {
+				channels = append(channels, channel)
+			}
+		}
+	}
+	return channels
+}
@@ -2,10 +2,14 @@ package notifications
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"time"
 
+	"evently/pkg/logger"
+	"evently/pkg/metrics"
+
 	"github.com/IBM/sarama"
 	"github.com/google/uuid"
 )
@@ -79,6 +83,7 @@ func NewKafkaNotificationProducer(config *KafkaProducerConfig) (NotificationProd
 func (knp *KafkaNotificationProducer) PublishNotification(ctx context.Context, notification *EmailNotification) error {
 	notification.Status = NotificationStatusQueued
 	notification.UpdatedAt = time.Now()
+	stampRequestID(ctx, notification)
 
 	messageBytes, err := notification.ToJSON()
 	if err != nil {
@@ -95,6 +100,7 @@ func (knp *KafkaNotificationProducer) PublishNotification(ctx context.Context, n
 
 	partition, offset, err := knp.producer.SendMessage(message)
 	if err != nil {
+		metrics.KafkaPublishFailuresTotal.Inc(knp.config.NotificationTopic)
 		notification.Status = NotificationStatusFailed
 		errorStr := err.Error()
 		notification.LastError = &errorStr
@@ -117,6 +123,7 @@ func (knp *KafkaNotificationProducer) PublishBatchNotifications(ctx context.Cont
 	for _, notification := range notifications {
 		notification.Status = NotificationStatusQueued
 		notification.UpdatedAt = time.Now()
+		stampRequestID(ctx, notification)
 
 		messageBytes, err := notification.ToJSON()
 		if err != nil {
@@ -137,6 +144,14 @@ func (knp *KafkaNotificationProducer) PublishBatchNotifications(ctx context.Cont
 
 	err := knp.producer.SendMessages(messages)
 	if err != nil {
+		// Sync producers report exactly which messages failed; anything else failed the whole batch
+		failed := len(messages)
+		var producerErrs sarama.ProducerErrors
+		if errors.As(err, &producerErrs) {
+			failed = len(producerErrs)
+		}
+		metrics.KafkaPublishFailuresTotal.Add(float64(failed), knp.config.NotificationTopic)
+
 		for _, notification := range notifications {
 			notification.Status = NotificationStatusFailed
 			errorStr := err.Error()
@@ -156,7 +171,7 @@ func (knp *KafkaNotificationProducer) createHeaders(notification *EmailNotificat
 		{Key: []byte("priority"), Value: []byte(notification.Priority)},
 		{Key: []byte("recipient_id"), Value: []byte(notification.RecipientID.String())},
 		{Key: []byte("recipient_email"), Value: []byte(notification.RecipientEmail)},
-		{Key: []byte("channel"), Value: []byte(NotificationChannelEmail)},
+		{Key: []byte("channel"), Value: []byte(notification.DeliveryChannel())},
 		{Key: []byte("version"), Value: []byte("2.1")},
 		{Key: []byte("producer"), Value: []byte("evently-notifications-simplified")},
 		{Key: []byte("created_at"), Value: []byte(notification.CreatedAt.Format(time.RFC3339))},
@@ -191,9 +206,24 @@ func (knp *KafkaNotificationProducer) createHeaders(notification *EmailNotificat
 		})
 	}
 
+	if notification.RequestID != "" {
+		headers = append(headers, sarama.RecordHeader{
+			Key:   []byte("request_id"),
+			Value: []byte(notification.RequestID),
+		})
+	}
+
 	return headers
 }
 
+// stampRequestID records the request the notification is published for, unless
+// it already carries one
+func stampRequestID(ctx context.Context, notification *EmailNotification) {
+	if notification.RequestID == "" {
+		notification.RequestID = logger.RequestIDFromContext(ctx)
+	}
+}
+
 func (knp *KafkaNotificationProducer) Close() error {
 	if knp.producer != nil {
 		err := knp.producer.Close()
@@ -269,7 +299,7 @@ func (np *NotificationPublisher) PublishWaitlistNotification(ctx context.Context
 		WithEventContext(eventID).
 		WithWaitlistContext(waitlistEntryID).
 		WithTemplateData(templateData).
-		WithSubject(np.generateSubject(notificationType, templateData)).
+		WithSubject(GenerateSubject(notificationType, templateData)).
 		Build()
 
 	return np.producer.PublishNotification(ctx, notification)
@@ -285,13 +315,14 @@ func (np *NotificationPublisher) PublishBookingNotification(ctx context.Context,
 		WithBookingContext(bookingID).
 		WithEventContext(eventID).
 		WithTemplateData(templateData).
-		WithSubject(np.generateSubject(notificationType, templateData)).
+		WithSubject(GenerateSubject(notificationType, templateData)).
 		Build()
 
 	return np.producer.PublishNotification(ctx, notification)
 }
 
-func (np *NotificationPublisher) generateSubject(notificationType NotificationType, data map[string]interface{}) string {
+// GenerateSubject builds the email subject line for a notification type
+func GenerateSubject(notificationType NotificationType, data map[string]interface{}) string {
 	switch notificationType {
 	case NotificationTypeWaitlistSpotAvailable:
 		if eventTitle, ok := data["event_title"]; ok {
@@ -311,6 +342,117 @@ func (np *NotificationPublisher) generateSubject(notificationType NotificationTy
 		}
 		return "📊 Your waitlist position has been updated"
 
+	case NotificationTypeYearlyRecap:
+		if year, ok := data["year"]; ok {
+			return fmt.Sprintf("🎉 Your %v in events", year)
+		}
+		return "🎉 Your year in events"
+
+	case NotificationTypePaymentFailed:
+		if eventTitle, ok := data["event_title"]; ok {
+			return fmt.Sprintf("⚠️ Payment failed for %s", eventTitle)
+		}
+		return "⚠️ Your payment didn't go through"
+
+	case NotificationTypeBookingPaymentExpired:
+		if eventTitle, ok := data["event_title"]; ok {
+			return fmt.Sprintf("❌ Booking cancelled for %s", eventTitle)
+		}
+		return "❌ Your booking was cancelled"
+
+	case NotificationTypeFavoriteSellingOut:
+		if eventTitle, ok := data["event_title"]; ok {
+			return fmt.Sprintf("🔥 %s is almost sold out", eventTitle)
+		}
+		return "🔥 An event you saved is almost sold out"
+
+	case NotificationTypeFavoritePriceDrop:
+		if eventTitle, ok := data["event_title"]; ok {
+			return fmt.Sprintf("💸 Price drop for %s", eventTitle)
+		}
+		return "💸 An event you saved just got cheaper"
+
+	case NotificationTypeSupportTicketReply:
+		if number, ok := data["ticket_number"]; ok {
+			return fmt.Sprintf("💬 New reply on support ticket %s", number)
+		}
+		return "💬 New reply on your support ticket"
+
+	case NotificationTypeEventScheduleChanged:
+		if eventTitle, ok := data["event_title"]; ok {
+			return fmt.Sprintf("📅 %s has changed", eventTitle)
+		}
+		return "📅 An event you're going to has changed"
+
+	case NotificationTypeEventReminder:
+		if eventTitle, ok := data["event_title"]; ok {
+			return fmt.Sprintf("⏰ Reminder: %s starts %s", eventTitle, templateValue(data, "starts_in", "soon"))
+		}
+		return "⏰ Reminder: your event is coming up"
+
+	case NotificationTypeEventCancelled:
+		if eventTitle, ok := data["event_title"]; ok {
+			return fmt.Sprintf("🚫 %s has been cancelled", eventTitle)
+		}
+		return "🚫 An event you're going to has been cancelled"
+
+	case NotificationTypeDocumentArchiveReady:
+		return "📦 Your tickets and invoices are ready to download"
+
+	case NotificationTypeTicketTransferOffered:
+		if eventTitle, ok := data["event_title"]; ok {
+			return fmt.Sprintf("🎟️ %s sent you tickets for %s", templateValue(data, "sender_name", "Someone"), eventTitle)
+		}
+		return "🎟️ Someone sent you tickets"
+
+	case NotificationTypeTicketTransferAccepted:
+		if eventTitle, ok := data["event_title"]; ok {
+			return fmt.Sprintf("✅ Your tickets for %s were transferred", eventTitle)
+		}
+		return "✅ Your ticket transfer was accepted"
+
+	case NotificationTypeResaleSold:
+		if eventTitle, ok := data["event_title"]; ok {
+			return fmt.Sprintf("💸 Your tickets for %s sold", eventTitle)
+		}
+		return "💸 Your resale tickets sold"
+
+	case NotificationTypeResalePurchased:
+		if eventTitle, ok := data["event_title"]; ok {
+			return fmt.Sprintf("🎟️ Your resale tickets for %s", eventTitle)
+		}
+		return "🎟️ Your resale tickets are ready"
+
+	case NotificationTypeCancellationPending:
+		return fmt.Sprintf("⏳ Your cancellation of booking %s is awaiting approval", templateValue(data, "booking_ref", ""))
+
+	case NotificationTypeCancellationApproved:
+		return fmt.Sprintf("✅ Your cancellation of booking %s was approved", templateValue(data, "booking_ref", ""))
+
+	case NotificationTypeCancellationRejected:
+		return fmt.Sprintf("❌ Your cancellation of booking %s was declined", templateValue(data, "booking_ref", ""))
+
+	case NotificationTypeEmailChangeVerification:
+		return "✉️ Confirm your new email address"
+
+	case NotificationTypeEmailChanged:
+		return "🔐 Your account email was changed"
+
+	case NotificationTypeEventCapacityThreshold:
+		if soldOut, ok := data["sold_out"].(bool); ok && soldOut {
+			return fmt.Sprintf("🎟️ %v is sold out", data["event_title"])
+		}
+		if eventTitle, ok := data["event_title"]; ok {
+			return fmt.Sprintf("📈 %s has reached %v%% of capacity", eventTitle, data["threshold"])
+		}
+		return "📈 Your event is filling up"
+
+	case NotificationTypeAnalyticsReport:
+		if frequency, ok := data["frequency"]; ok {
+			return fmt.Sprintf("📈 Your %v Evently report: %v to %v", frequency, data["period_start"], data["period_end"])
+		}
+		return "📈 Your Evently report"
+
 	default:
 		return "📧 Notification from Evently"
 	}
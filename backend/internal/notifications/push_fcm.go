@@ -0,0 +1,179 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	fcmSendURL = "https://fcm.googleapis.com/v1/projects/%s/messages:send"
+	fcmScope   = "https://www.googleapis.com/auth/firebase.messaging"
+)
+
+// FCMPushSender sends push notifications through the Firebase Cloud Messaging
+// HTTP v1 API, authenticating as a service account
+type FCMPushSender struct {
+	projectID   string
+	clientEmail string
+	tokenURI    string
+	key         *rsa.PrivateKey
+	client      *http.Client
+
+	mu          sync.Mutex
+	accessToken string
+	expiresAt   time.Time
+}
+
+func NewFCMPushSender(projectID, credentialsFile string) (*FCMPushSender, error) {
+	if credentialsFile == "" {
+		return nil, fmt.Errorf("fcm requires FCM_CREDENTIALS_FILE")
+	}
+	raw, err := os.ReadFile(credentialsFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read FCM credentials: %w", err)
+	}
+
+	var credentials struct {
+		ProjectID   string `json:"project_id"`
+		ClientEmail string `json:"client_email"`
+		PrivateKey  string `json:"private_key"`
+		TokenURI    string `json:"token_uri"`
+	}
+	if err := json.Unmarshal(raw, &credentials); err != nil {
+		return nil, fmt.Errorf("failed to parse FCM credentials: %w", err)
+	}
+	key, err := jwt.ParseRSAPrivateKeyFromPEM([]byte(credentials.PrivateKey))
+	if err != nil {
+		return nil, fmt.Errorf("invalid FCM service account key: %w", err)
+	}
+
+	if projectID == "" {
+		projectID = credentials.ProjectID
+	}
+	if projectID == "" || credentials.ClientEmail == "" {
+		return nil, fmt.Errorf("fcm requires a project ID and a service account client email")
+	}
+	tokenURI := credentials.TokenURI
+	if tokenURI == "" {
+		tokenURI = "https://oauth2.googleapis.com/token"
+	}
+
+	return &FCMPushSender{
+		projectID:   projectID,
+		clientEmail: credentials.ClientEmail,
+		tokenURI:    tokenURI,
+		key:         key,
+		client:      &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+func (f *FCMPushSender) SendPush(ctx context.Context, token, title, body string, data map[string]string) error {
+	accessToken, err := f.token(ctx)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"message": map[string]interface{}{
+			"token":        token,
+			"notification": map[string]string{"title": title, "body": body},
+			"data":         data,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf(fcmSendURL, f.projectID), bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("fcm request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		var failure struct {
+			Error struct {
+				Status  string `json:"status"`
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		if err := json.NewDecoder(io.LimitReader(resp.Body, 64*1024)).Decode(&failure); err == nil && failure.Error.Message != "" {
+			return fmt.Errorf("fcm returned status %d: %s (%s)", resp.StatusCode, failure.Error.Message, failure.Error.Status)
+		}
+		return fmt.Errorf("fcm returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// token returns a cached OAuth access token, exchanging a freshly signed
+// service account assertion when it is about to expire
+func (f *FCMPushSender) token(ctx context.Context) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.accessToken != "" && time.Now().Before(f.expiresAt.Add(-time.Minute)) {
+		return f.accessToken, nil
+	}
+
+	now := time.Now()
+	assertion, err := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":   f.clientEmail,
+		"scope": fcmScope,
+		"aud":   f.tokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	}).SignedString(f.key)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign FCM assertion: %w", err)
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "urn:ietf:params:oauth:grant-type:jwt-bearer")
+	form.Set("assertion", assertion)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, f.tokenURI, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("fcm token request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("fcm token request returned status %d", resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil || result.AccessToken == "" {
+		return "", fmt.Errorf("fcm token response had no access token")
+	}
+
+	f.accessToken = result.AccessToken
+	f.expiresAt = now.Add(time.Duration(result.ExpiresIn) * time.Second)
+	return f.accessToken, nil
+}
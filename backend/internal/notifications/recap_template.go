@@ -0,0 +1,79 @@
+package notifications
+
+import (
+	"bytes"
+	"fmt"
+	htmltemplate "html/template"
+	texttemplate "text/template"
+)
+
+// The recap email has lists and optional sections, so it is rendered from
+// templates rather than formatted inline like the other notification types.
+// Template data arrives through the outbox as decoded JSON.
+
+const yearlyRecapHTML = `
+			<h2>🎉 Your {{.Data.year}} in events</h2>
+			<p>Hi {{.Name}},</p>
+			{{if .Data.total_bookings}}
+			<p>Here's a look back at your year with Evently.</p>
+			<ul>
+				<li>Bookings: <strong>{{.Data.total_bookings}}</strong></li>
+				<li>Tickets: <strong>{{.Data.total_tickets}}</strong></li>
+				<li>Events attended: <strong>{{.Data.events_attended}}</strong></li>
+				<li>Total spent: <strong>${{printf "%.2f" .Data.total_spent}}</strong></li>
+				{{if .Data.busiest_month}}<li>Busiest month: <strong>{{.Data.busiest_month}}</strong></li>{{end}}
+			</ul>
+			{{if .Data.favorite_tags}}<p>Your favorite categories: {{range $i, $t := .Data.favorite_tags}}{{if $i}}, {{end}}{{$t}}{{end}}</p>{{end}}
+			{{if .Data.favorite_venues}}<p>Your favorite venues: {{range $i, $v := .Data.favorite_venues}}{{if $i}}, {{end}}{{$v}}{{end}}</p>{{end}}
+			{{if .Data.achievements}}
+			<h3>🏆 Achievements</h3>
+			<ul>{{range .Data.achievements}}<li>{{.}}</li>{{end}}</ul>
+			{{end}}
+			{{else}}
+			<p>You didn't book any events this year. We hope to see you at one soon!</p>
+			{{end}}
+			<p>Best regards,<br>Evently Team</p>
+		`
+
+const yearlyRecapText = `Hi {{.Name}},
+
+{{if .Data.total_bookings}}Here's a look back at your {{.Data.year}} with Evently.
+
+Bookings: {{.Data.total_bookings}}
+Tickets: {{.Data.total_tickets}}
+Events attended: {{.Data.events_attended}}
+Total spent: ${{printf "%.2f" .Data.total_spent}}
+{{if .Data.busiest_month}}Busiest month: {{.Data.busiest_month}}
+{{end}}{{if .Data.favorite_tags}}Favorite categories: {{range $i, $t := .Data.favorite_tags}}{{if $i}}, {{end}}{{$t}}{{end}}
+{{end}}{{if .Data.favorite_venues}}Favorite venues: {{range $i, $v := .Data.favorite_venues}}{{if $i}}, {{end}}{{$v}}{{end}}
+{{end}}{{if .Data.achievements}}
+Achievements:
+{{range .Data.achievements}}- {{.}}
+{{end}}{{end}}{{else}}You didn't book any events in {{.Data.year}}. We hope to see you at one soon!
+{{end}}
+Best regards,
+Evently Team`
+
+var (
+	yearlyRecapHTMLTemplate = htmltemplate.Must(htmltemplate.New("yearly_recap_html").Parse(yearlyRecapHTML))
+	yearlyRecapTextTemplate = texttemplate.Must(texttemplate.New("yearly_recap_text").Parse(yearlyRecapText))
+)
+
+func renderYearlyRecap(notification *EmailNotification) (string, string, error) {
+	data := map[string]interface{}{
+		"Name": notification.RecipientName,
+		"Data": notification.TemplateData,
+	}
+
+	var htmlBody bytes.Buffer
+	if err := yearlyRecapHTMLTemplate.Execute(&htmlBody, data); err != nil {
+		return "", "", fmt.Errorf("failed to render recap html: %w", err)
+	}
+
+	var textBody bytes.Buffer
+	if err := yearlyRecapTextTemplate.Execute(&textBody, data); err != nil {
+		return "", "", fmt.Errorf("failed to render recap text: %w", err)
+	}
+
+	return htmlBody.String(), textBody.String(), nil
+}
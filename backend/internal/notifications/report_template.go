@@ -0,0 +1,111 @@
+package notifications
+
+import (
+	"bytes"
+	"fmt"
+	htmltemplate "html/template"
+	texttemplate "text/template"
+
+	"github.com/google/uuid"
+)
+
+// The analytics report lists sections an admin picked, so like the recap it is
+// rendered from templates. Sections missing from the data are left out.
+
+const analyticsReportHTML = `
+			<h2>📈 Your {{.Data.frequency}} Evently report</h2>
+			<p>Hi {{.Name}},</p>
+			<p>Here's how things went from {{.Data.period_start}} to {{.Data.period_end}}.</p>
+			{{with .Data.revenue_summary}}
+			<h3>💰 Revenue summary</h3>
+			<ul>
+				<li>Revenue: <strong>${{printf "%.2f" .total_revenue}}</strong>{{if .previous_revenue}} (previous period ${{printf "%.2f" .previous_revenue}}){{end}}</li>
+				<li>Confirmed bookings: <strong>{{.confirmed_bookings}}</strong></li>
+				<li>Tickets sold: <strong>{{.tickets_sold}}</strong></li>
+				<li>Average order: <strong>${{printf "%.2f" .average_order}}</strong></li>
+			</ul>
+			{{end}}
+			{{with .Data.top_events}}
+			<h3>🏆 Top events</h3>
+			<table style="border-collapse:collapse;width:100%;">
+				<tr><th align="left">Event</th><th align="right">Bookings</th><th align="right">Revenue</th></tr>
+				{{range .}}<tr><td>{{.event_name}}</td><td align="right">{{.bookings}}</td><td align="right">${{printf "%.2f" .revenue}}</td></tr>{{end}}
+			</table>
+			{{end}}
+			{{with .Data.cancellation_rate}}
+			<h3>↩️ Cancellations</h3>
+			<p><strong>{{.cancelled_bookings}}</strong> of {{.total_bookings}} bookings were cancelled ({{printf "%.1f" .rate}}%).</p>
+			{{end}}
+			<p>You can change or stop these reports from the admin dashboard.</p>
+			<p>Best regards,<br>Evently Team</p>
+		`
+
+const analyticsReportText = `Hi {{.Name}},
+
+Here's how things went from {{.Data.period_start}} to {{.Data.period_end}}.
+{{with .Data.revenue_summary}}
+Revenue summary
+Revenue: ${{printf "%.2f" .total_revenue}}{{if .previous_revenue}} (previous period ${{printf "%.2f" .previous_revenue}}){{end}}
+Confirmed bookings: {{.confirmed_bookings}}
+Tickets sold: {{.tickets_sold}}
+Average order: ${{printf "%.2f" .average_order}}
+{{end}}{{with .Data.top_events}}
+Top events
+{{range .}}- {{.event_name}}: {{.bookings}} bookings, ${{printf "%.2f" .revenue}}
+{{end}}{{end}}{{with .Data.cancellation_rate}}
+Cancellations
+{{.cancelled_bookings}} of {{.total_bookings}} bookings were cancelled ({{printf "%.1f" .rate}}%).
+{{end}}
+You can change or stop these reports from the admin dashboard.
+
+Best regards,
+Evently Team`
+
+var (
+	analyticsReportHTMLTemplate = htmltemplate.Must(htmltemplate.New("analytics_report_html").Parse(analyticsReportHTML))
+	analyticsReportTextTemplate = texttemplate.Must(texttemplate.New("analytics_report_text").Parse(analyticsReportText))
+)
+
+func renderAnalyticsReport(notification *EmailNotification) (string, string, error) {
+	data := map[string]interface{}{
+		"Name": notification.RecipientName,
+		"Data": notification.TemplateData,
+	}
+
+	var htmlBody bytes.Buffer
+	if err := analyticsReportHTMLTemplate.Execute(&htmlBody, data); err != nil {
+		return "", "", fmt.Errorf("failed to render analytics report html: %w", err)
+	}
+
+	var textBody bytes.Buffer
+	if err := analyticsReportTextTemplate.Execute(&textBody, data); err != nil {
+		return "", "", fmt.Errorf("failed to render analytics report text: %w", err)
+	}
+
+	return htmlBody.String(), textBody.String(), nil
+}
+
+// RenderEmail renders a notification exactly as it would be sent, for previews.
+// The template data takes a JSON round trip first so it has the same types it
+// would have after passing through the outbox.
+func RenderEmail(notificationType NotificationType, recipientName string, templateData map[string]interface{}) (subject, htmlBody, textBody string, err error) {
+	decoded, err := roundTripTemplateData(templateData)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	notification := NewNotificationBuilder().
+		WithType(notificationType).
+		WithRecipient(uuid.Nil, "", recipientName).
+		WithTemplateData(decoded).
+		WithSubject(GenerateSubject(notificationType, decoded)).
+		Build()
+
+	htmlBody, textBody, err = (&SMTPEmailService{}).generateContent(notification)
+	if err != nil {
+		return "", "", "", err
+	}
+	htmlBody, textBody = applyBranding(decoded, htmlBody, textBody)
+
+	return notification.Subject, htmlBody, textBody, nil
+}
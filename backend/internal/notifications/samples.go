@@ -0,0 +1,261 @@
+package notifications
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Sample template data for every notification type, used to preview templates
+// without a real booking or event behind them. Provided data is merged over it.
+var sampleTemplateData = map[NotificationType]map[string]interface{}{
+	NotificationTypeWaitlistSpotAvailable: {
+		"event_title":     "Summer Music Festival",
+		"event_date_time": "Sat, Jul 12, 2025 at 19:00 UTC",
+		"venue":           "City Arena",
+		"sections": []map[string]interface{}{
+			{"name": "Floor", "available": 2},
+			{"name": "Balcony", "available": 6},
+		},
+		"expires_at":  "2025-07-01 18:30 UTC",
+		"position":    3,
+		"booking_url": "https://evently.example.com/events/123/book?waitlist=456",
+	},
+	NotificationTypeBookingConfirmed: {
+		"event_title":    "Summer Music Festival",
+		"booking_number": "BK-2025-001234",
+		"quantity":       2,
+		"total_amount":   150.0,
+	},
+	NotificationTypeWaitlistPositionUpdate: {
+		"event_title":     "Summer Music Festival",
+		"event_date_time": "Sat, Jul 12, 2025 at 19:00 UTC",
+		"venue":           "City Arena",
+		"position":        5,
+	},
+	NotificationTypeYearlyRecap: {
+		"year":            2025,
+		"total_bookings":  6,
+		"total_tickets":   11,
+		"events_attended": 5,
+		"total_spent":     820.5,
+		"busiest_month":   "August",
+		"favorite_tags":   []string{"music", "comedy"},
+		"favorite_venues": []string{"City Arena"},
+		"achievements":    []string{"Front row regular"},
+	},
+	NotificationTypePaymentFailed: {
+		"event_title":    "Summer Music Festival",
+		"booking_number": "BK-2025-001234",
+		"total_amount":   150.0,
+		"attempt":        1,
+		"max_attempts":   3,
+		"next_retry_at":  "2025-07-01 18:30 UTC",
+		"resume_url":     "https://evently.example.com/bookings/resume",
+	},
+	NotificationTypeBookingPaymentExpired: {
+		"event_title":    "Summer Music Festival",
+		"booking_number": "BK-2025-001234",
+		"attempt":        3,
+	},
+	NotificationTypeFavoriteSellingOut: {
+		"event_title":     "Summer Music Festival",
+		"remaining_seats": 12,
+		"total_capacity":  500,
+	},
+	NotificationTypeFavoritePriceDrop: {
+		"event_title": "Summer Music Festival",
+		"old_price":   75.0,
+		"new_price":   60.0,
+	},
+	NotificationTypeSupportTicketReply: {
+		"ticket_number": "SUP-2025-000042",
+		"subject":       "Seat change request",
+		"reply":         "We've moved you to row B as requested.",
+		"resolved":      false,
+	},
+	NotificationTypeEventScheduleChanged: {
+		"event_title":       "Summer Music Festival",
+		"date_time_changed": true,
+		"date_time_before":  "2025-07-12 19:00 UTC",
+		"date_time_after":   "2025-07-13 19:00 UTC",
+		"venue_changed":     false,
+		"recipient_kind":    "booking",
+		"action_url":        "https://evently.example.com/bookings",
+	},
+	NotificationTypeEventReminder: {
+		"event_title":     "Summer Music Festival",
+		"event_date_time": "Sat, Jul 12, 2025 7:00 PM UTC",
+		"venue":           "Central Park Amphitheater",
+		"booking_ref":     "EVT-2025-000123",
+		"starts_in":       "in 24 hours",
+		"action_url":      "https://evently.example.com/bookings",
+	},
+	NotificationTypeEventCancelled: {
+		"event_title":            "Summer Music Festival",
+		"reason":                 "The headliner had to withdraw.",
+		"recipient_kind":         "attendee",
+		"booking_ref":            "EVT-2025-000123",
+		"refund_amount":          "2,500.00",
+		"currency":               "INR",
+		"refund_processing_days": 5,
+	},
+	NotificationTypeTicketTransferOffered: {
+		"event_title": "Summer Music Festival",
+		"sender_name": "Priya Shah",
+		"seat_count":  2,
+		"message":     "Have fun, I can't make it!",
+		"expires_at":  "Thu, Jul 3, 2025 at 18:30 UTC",
+		"accept_url":  "https://evently.example.com/transfers/sample",
+	},
+	NotificationTypeTicketTransferAccepted: {
+		"event_title":    "Summer Music Festival",
+		"recipient_name": "Alex Kim",
+		"seat_count":     2,
+		"booking_number": "EVT-20250601-ABCDEF",
+		"remaining_ref":  "EVT-20250702-GHIJKL",
+	},
+	NotificationTypeResaleSold: {
+		"event_title":    "Summer Music Festival",
+		"seat_count":     2,
+		"price":          "4,500.00",
+		"fee":            "450.00",
+		"payout":         "4,050.00",
+		"currency":       "INR",
+		"booking_number": "EVT-20250601-ABCDEF",
+		"remaining_ref":  "",
+	},
+	NotificationTypeResalePurchased: {
+		"event_title":    "Summer Music Festival",
+		"seat_count":     2,
+		"price":          "4,500.00",
+		"currency":       "INR",
+		"booking_number": "EVT-20250702-MNOPQR",
+	},
+	NotificationTypeCancellationPending: {
+		"event_title":            "Summer Music Festival",
+		"booking_ref":            "EVT-20250601-ABCDEF",
+		"partial":                false,
+		"seat_count":             2,
+		"refund_amount":          "3,600.00",
+		"currency":               "INR",
+		"refund_processing_days": 5,
+		"review_note":            "",
+	},
+	NotificationTypeCancellationApproved: {
+		"event_title":            "Summer Music Festival",
+		"booking_ref":            "EVT-20250601-ABCDEF",
+		"partial":                false,
+		"seat_count":             2,
+		"refund_amount":          "3,600.00",
+		"currency":               "INR",
+		"refund_processing_days": 5,
+		"review_note":            "Approved given the medical certificate you sent.",
+	},
+	NotificationTypeCancellationRejected: {
+		"event_title":            "Summer Music Festival",
+		"booking_ref":            "EVT-20250601-ABCDEF",
+		"partial":                true,
+		"seat_count":             1,
+		"refund_amount":          "0.00",
+		"currency":               "INR",
+		"refund_processing_days": 5,
+		"review_note":            "The event is tomorrow and the seats can no longer be resold.",
+	},
+	NotificationTypeEmailChangeVerification: {
+		"new_email":  "priya.new@example.com",
+		"verify_url": "https://evently.example.com/account/verify-email?token=3f9a1c",
+		"expires_at": "Tue, Jul 1, 2025 at 18:30 UTC",
+	},
+	NotificationTypeEmailChanged: {
+		"new_email":  "priya.new@example.com",
+		"changed_at": "Mon, Jun 30, 2025 at 18:30 UTC",
+	},
+	NotificationTypeDocumentArchiveReady: {
+		"ticket_count":  4,
+		"invoice_count": 5,
+		"booking_count": 5,
+		"download_url":  "https://evently.example.com/documents/archives/sample/download",
+		"expires_at":    "2025-07-08 12:00 UTC",
+	},
+	NotificationTypeEventCapacityThreshold: {
+		"event_title":     "Summer Music Festival",
+		"threshold":       95,
+		"sold_out":        false,
+		"booked_count":    476,
+		"total_capacity":  500,
+		"remaining_seats": 24,
+		"utilization":     95.2,
+	},
+	NotificationTypeAnalyticsReport: {
+		"frequency":    "weekly",
+		"period_start": "2025-06-23",
+		"period_end":   "2025-06-30",
+		"revenue_summary": map[string]interface{}{
+			"total_revenue":      12840.0,
+			"previous_revenue":   11200.0,
+			"confirmed_bookings": 96,
+			"tickets_sold":       184,
+			"average_order":      133.75,
+		},
+		"top_events": []map[string]interface{}{
+			{"event_name": "Summer Music Festival", "bookings": 41, "revenue": 6150.0},
+		},
+		"cancellation_rate": map[string]interface{}{
+			"total_bookings":     104,
+			"cancelled_bookings": 8,
+			"rate":               7.7,
+		},
+	},
+}
+
+// TemplateTypes lists the notification types that have a template, in a stable order
+func TemplateTypes() []NotificationType {
+	return []NotificationType{
+		NotificationTypeBookingConfirmed,
+		NotificationTypePaymentFailed,
+		NotificationTypeBookingPaymentExpired,
+		NotificationTypeWaitlistSpotAvailable,
+		NotificationTypeWaitlistPositionUpdate,
+		NotificationTypeFavoriteSellingOut,
+		NotificationTypeFavoritePriceDrop,
+		NotificationTypeEventScheduleChanged,
+		NotificationTypeEventReminder,
+		NotificationTypeEventCancelled,
+		NotificationTypeEventCapacityThreshold,
+		NotificationTypeTicketTransferOffered,
+		NotificationTypeTicketTransferAccepted,
+		NotificationTypeResaleSold,
+		NotificationTypeResalePurchased,
+		NotificationTypeCancellationPending,
+		NotificationTypeCancellationApproved,
+		NotificationTypeCancellationRejected,
+		NotificationTypeSupportTicketReply,
+		NotificationTypeEmailChangeVerification,
+		NotificationTypeEmailChanged,
+		NotificationTypeDocumentArchiveReady,
+		NotificationTypeYearlyRecap,
+		NotificationTypeAnalyticsReport,
+	}
+}
+
+// ParseTemplateType matches a template ID such as "booking_confirmed" to its notification type
+func ParseTemplateType(id string) (NotificationType, bool) {
+	notificationType := NotificationType(strings.ToUpper(strings.TrimSpace(id)))
+	_, ok := sampleTemplateData[notificationType]
+	return notificationType, ok
+}
+
+// SampleTemplateData returns a copy of the sample data for a notification type
+// with the given data merged over it
+func SampleTemplateData(notificationType NotificationType, overrides map[string]interface{}) map[string]interface{} {
+	data := make(map[string]interface{})
+
+	// A JSON round trip copies nested values so callers can't change the samples
+	if raw, err := json.Marshal(sampleTemplateData[notificationType]); err == nil {
+		_ = json.Unmarshal(raw, &data)
+	}
+	for key, value := range overrides {
+		data[key] = value
+	}
+	return data
+}
@@ -26,6 +26,8 @@ type NotificationService interface {
 	Start(ctx context.Context) error
 	Stop() error
 	HealthCheck(ctx context.Context) error
+	SetDeliveryTracker(tracker DeliveryTracker)
+	SetTemplateSource(source TemplateSource)
 }
 
 type ServiceConfig struct {
@@ -40,6 +42,7 @@ type ServiceConfig struct {
 	SMTPPassword       string
 	SMTPFromEmail      string
 	SMTPFromName       string
+	Channels           *ChannelConfig
 }
 
 func NewServiceConfigFromEnv() *ServiceConfig {
@@ -55,6 +58,7 @@ func NewServiceConfigFromEnv() *ServiceConfig {
 		SMTPPassword:       getEnvString("SMTP_PASSWORD", ""),
 		SMTPFromEmail:      getEnvString("FROM_EMAIL", ""),
 		SMTPFromName:       getEnvString("SMTP_FROM_NAME", "Evently"),
+		Channels:           NewChannelConfigFromEnv(),
 	}
 }
 
@@ -97,6 +101,16 @@ func NewEmailNotificationService(config *ServiceConfig) (NotificationService, er
 		return nil, fmt.Errorf("failed to create SMTP email service")
 	}
 
+	// Create SMS and push senders
+	channelConfig := config.Channels
+	if channelConfig == nil {
+		channelConfig = NewChannelConfigFromEnv()
+	}
+	channels, err := NewChannelSenders(channelConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create channel senders: %w", err)
+	}
+
 	// Create producer
 	producerConfig := DefaultKafkaProducerConfig()
 	producerConfig.Brokers = config.KafkaBrokers
@@ -113,7 +127,7 @@ func NewEmailNotificationService(config *ServiceConfig) (NotificationService, er
 	consumerConfig.Topics = []string{config.NotificationTopic}
 	consumerConfig.GroupID = config.ConsumerGroupID
 
-	consumer, err := NewKafkaNotificationConsumer(consumerConfig, emailService)
+	consumer, err := NewKafkaNotificationConsumer(consumerConfig, emailService, channels)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create notification consumer: %w", err)
 	}
@@ -122,7 +136,8 @@ func NewEmailNotificationService(config *ServiceConfig) (NotificationService, er
 
 	ctx, cancel := context.WithCancel(context.Background())
 
-	log.Printf("📧 Email notification service initialized (Host: %s, Port: %d)", config.SMTPHost, config.SMTPPort)
+	log.Printf("📧 Email notification service initialized (Host: %s, Port: %d, SMS: %s, Push: %s)",
+		config.SMTPHost, config.SMTPPort, channelConfig.SMSProvider, channelConfig.PushProvider)
 
 	return &EmailNotificationService{
 		config:       config,
@@ -205,6 +220,14 @@ func (ens *EmailNotificationService) SendBookingNotification(ctx context.Context
 	return ens.publisher.PublishBookingNotification(ctx, userID, email, name, bookingID, eventID, notificationType, templateData)
 }
 
+func (ens *EmailNotificationService) SetDeliveryTracker(tracker DeliveryTracker) {
+	ens.consumer.SetDeliveryTracker(tracker)
+}
+
+func (ens *EmailNotificationService) SetTemplateSource(source TemplateSource) {
+	ens.emailService.SetTemplateSource(source)
+}
+
 func (ens *EmailNotificationService) HealthCheck(ctx context.Context) error {
 	ens.mu.RLock()
 	isRunning := ens.isRunning
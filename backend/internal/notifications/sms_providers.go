@@ -0,0 +1,128 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	twilioAPIURL = "https://api.twilio.com/2010-04-01/Accounts/%s/Messages.json"
+	msg91FlowURL = "https://control.msg91.com/api/v5/flow/"
+)
+
+// TwilioSMSSender sends text messages through the Twilio Messages API
+type TwilioSMSSender struct {
+	accountSID string
+	authToken  string
+	from       string
+	client     *http.Client
+}
+
+func NewTwilioSMSSender(accountSID, authToken, from string) (*TwilioSMSSender, error) {
+	if accountSID == "" || authToken == "" || from == "" {
+		return nil, fmt.Errorf("twilio requires TWILIO_ACCOUNT_SID, TWILIO_AUTH_TOKEN and TWILIO_FROM_NUMBER")
+	}
+	return &TwilioSMSSender{
+		accountSID: accountSID,
+		authToken:  authToken,
+		from:       from,
+		client:     &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+func (t *TwilioSMSSender) SendSMS(ctx context.Context, phone, body string) error {
+	form := url.Values{}
+	form.Set("From", t.from)
+	form.Set("To", phone)
+	form.Set("Body", body)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf(twilioAPIURL, t.accountSID), strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(t.accountSID, t.authToken)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("twilio request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		var failure struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		}
+		if err := json.NewDecoder(io.LimitReader(resp.Body, 64*1024)).Decode(&failure); err == nil && failure.Message != "" {
+			return fmt.Errorf("twilio returned status %d: %s (code %d)", resp.StatusCode, failure.Message, failure.Code)
+		}
+		return fmt.Errorf("twilio returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// MSG91SMSSender sends text messages through an MSG91 flow. Indian carriers
+// only deliver DLT-registered templates, so the flow template carries the text
+// in a single ##body## variable.
+type MSG91SMSSender struct {
+	authKey    string
+	templateID string
+	client     *http.Client
+}
+
+func NewMSG91SMSSender(authKey, templateID string) (*MSG91SMSSender, error) {
+	if authKey == "" || templateID == "" {
+		return nil, fmt.Errorf("msg91 requires MSG91_AUTH_KEY and MSG91_TEMPLATE_ID")
+	}
+	return &MSG91SMSSender{
+		authKey:    authKey,
+		templateID: templateID,
+		client:     &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+func (m *MSG91SMSSender) SendSMS(ctx context.Context, phone, body string) error {
+	payload, err := json.Marshal(map[string]interface{}{
+		"template_id": m.templateID,
+		"short_url":   "0",
+		"recipients": []map[string]string{
+			// MSG91 takes the number with its country code but without the plus
+			{"mobiles": strings.TrimPrefix(phone, "+"), "body": body},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, msg91FlowURL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("authkey", m.authKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("msg91 request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// MSG91 reports some failures with a 200 and an error type in the body
+	var result struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	}
+	decodeErr := json.NewDecoder(io.LimitReader(resp.Body, 64*1024)).Decode(&result)
+	if resp.StatusCode >= 300 || (decodeErr == nil && result.Type == "error") {
+		return fmt.Errorf("msg91 returned status %d: %s", resp.StatusCode, result.Message)
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/google/uuid"
+)
+
+// DefaultLocale is used when a notification does not name one and as the last
+// fallback when a template has no variant for the recipient's locale
+const DefaultLocale = "en"
+
+// EmailTemplate is an admin-managed email template. The subject and text body
+// are text templates and the HTML body an html template, all executed with
+// .Name (the recipient's name) and .Data (the notification's template data),
+// like the built-in recap and report templates.
+type EmailTemplate struct {
+	Subject string
+	HTML    string
+	Text    string
+}
+
+// TemplateSource looks up admin-managed templates. Both methods return nil
+// without an error when there is no such template, and the built-in one is used.
+type TemplateSource interface {
+	// ActiveTemplate returns the live template for a notification type and locale
+	ActiveTemplate(ctx context.Context, notificationType NotificationType, locale string) (*EmailTemplate, error)
+
+	// TemplateVersion returns one stored version, active or not, for test sends
+	TemplateVersion(ctx context.Context, versionID uuid.UUID) (*EmailTemplate, error)
+}
+
+// Parse checks that all three parts of the template compile
+func (t *EmailTemplate) Parse() error {
+	_, _, _, err := t.parse()
+	return err
+}
+
+// Render executes the template for a recipient
+func (t *EmailTemplate) Render(recipientName string, templateData map[string]interface{}) (subject, htmlBody, textBody string, err error) {
+	subjectTemplate, htmlTemplate, textTemplate, err := t.parse()
+	if err != nil {
+		return "", "", "", err
+	}
+
+	data := map[string]interface{}{
+		"Name": recipientName,
+		"Data": templateData,
+	}
+
+	var subjectOut, htmlOut, textOut bytes.Buffer
+	if err := subjectTemplate.Execute(&subjectOut, data); err != nil {
+		return "", "", "", fmt.Errorf("failed to render subject: %w", err)
+	}
+	if err := htmlTemplate.Execute(&htmlOut, data); err != nil {
+		return "", "", "", fmt.Errorf("failed to render html body: %w", err)
+	}
+	if err := textTemplate.Execute(&textOut, data); err != nil {
+		return "", "", "", fmt.Errorf("failed to render text body: %w", err)
+	}
+
+	// Subjects are a single header line
+	subject = strings.Join(strings.Fields(subjectOut.String()), " ")
+	return subject, htmlOut.String(), textOut.String(), nil
+}
+
+func (t *EmailTemplate) parse() (*texttemplate.Template, *htmltemplate.Template, *texttemplate.Template, error) {
+	subjectTemplate, err := texttemplate.New("subject").Parse(t.Subject)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid subject template: %w", err)
+	}
+	htmlTemplate, err := htmltemplate.New("html").Parse(t.HTML)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid html template: %w", err)
+	}
+	textTemplate, err := texttemplate.New("text").Parse(t.Text)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid text template: %w", err)
+	}
+	return subjectTemplate, htmlTemplate, textTemplate, nil
+}
+
+// RenderStoredEmail renders an admin-managed template exactly as it would be
+// sent, for previews and test sends. Like RenderEmail, the template data takes a
+// JSON round trip first.
+func RenderStoredEmail(t *EmailTemplate, recipientName string, templateData map[string]interface{}) (subject, htmlBody, textBody string, err error) {
+	decoded, err := roundTripTemplateData(templateData)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	subject, htmlBody, textBody, err = t.Render(recipientName, decoded)
+	if err != nil {
+		return "", "", "", err
+	}
+	htmlBody, textBody = applyBranding(decoded, htmlBody, textBody)
+	return subject, htmlBody, textBody, nil
+}
+
+// roundTripTemplateData gives template data the types it has after passing through Kafka
+func roundTripTemplateData(templateData map[string]interface{}) (map[string]interface{}, error) {
+	raw, err := json.Marshal(templateData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode template data: %w", err)
+	}
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return nil, fmt.Errorf("failed to decode template data: %w", err)
+	}
+	return decoded, nil
+}
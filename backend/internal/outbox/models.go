@@ -0,0 +1,115 @@
+package outbox
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type MessageStatus string
+
+const (
+	MessageStatusPending   MessageStatus = "PENDING"
+	MessageStatusPublished MessageStatus = "PUBLISHED"
+	MessageStatusFailed    MessageStatus = "FAILED"
+)
+
+// Topics of outbox messages. Each relay claims the messages of its own topics:
+// notifications go to the notification service, the others are domain events
+// published to Kafka, see the domainevents package.
+const (
+	TopicNotifications = "notifications"
+)
+
+// Aggregate types that write to the outbox
+const (
+	AggregateBooking         = "BOOKING"
+	AggregateWaitlistEntry   = "WAITLIST_ENTRY"
+	AggregateUser            = "USER"
+	AggregateEventFavorite   = "EVENT_FAVORITE"
+	AggregateSupportTicket   = "SUPPORT_TICKET"
+	AggregateEventChange     = "EVENT_CHANGE"
+	AggregateEventCapacity   = "EVENT_CAPACITY"
+	AggregateEventReminder   = "EVENT_REMINDER"
+	AggregateCancellation    = "CANCELLATION"
+	AggregateBookingTransfer = "BOOKING_TRANSFER"
+	AggregateResaleListing   = "RESALE_LISTING"
+	AggregateEvent           = "EVENT"
+	AggregateSeatHold        = "SEAT_HOLD"
+)
+
+// Message is a notification or domain event waiting to be relayed. It is
+// written in the same transaction as the state change that triggered it, so a
+// committed booking or waitlist update always has its messages recorded.
+type Message struct {
+	ID            uuid.UUID     `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	Topic         string        `gorm:"type:varchar(50);not null;default:'notifications';index:idx_outbox_pending,priority:1" json:"topic"`
+	AggregateType string        `gorm:"type:varchar(50);not null;index:idx_outbox_aggregate" json:"aggregate_type"`
+	AggregateID   uuid.UUID     `gorm:"type:uuid;not null;index:idx_outbox_aggregate" json:"aggregate_id"`
+	DedupKey      string        `gorm:"type:varchar(255);not null;uniqueIndex" json:"dedup_key"`
+	Payload       string        `gorm:"type:jsonb;not null" json:"payload"`
+	RequestID     string        `gorm:"type:varchar(128)" json:"request_id,omitempty"` // API request the message was written for
+	Status        MessageStatus `gorm:"type:varchar(20);check:status IN ('PENDING', 'PUBLISHED', 'FAILED');default:'PENDING';index:idx_outbox_pending" json:"status"`
+	Attempts      int           `gorm:"not null;default:0" json:"attempts"`
+	NextAttemptAt time.Time     `gorm:"not null;index:idx_outbox_pending" json:"next_attempt_at"`
+	LastError     string        `gorm:"type:text" json:"last_error,omitempty"`
+	PublishedAt   *time.Time    `json:"published_at,omitempty"`
+	CreatedAt     time.Time     `json:"created_at"`
+	UpdatedAt     time.Time     `json:"updated_at"`
+}
+
+func (Message) TableName() string {
+	return "outbox_messages"
+}
+
+// NotificationPayload is the serialized notification carried by an outbox message.
+// Recipient email/name may be left empty; the relay resolves them at publish time.
+type NotificationPayload struct {
+	Type            string                 `json:"type"`
+	RecipientID     uuid.UUID              `json:"recipient_id"`
+	RecipientEmail  string                 `json:"recipient_email,omitempty"`
+	RecipientName   string                 `json:"recipient_name,omitempty"`
+	EventID         *uuid.UUID             `json:"event_id,omitempty"`
+	BookingID       *uuid.UUID             `json:"booking_id,omitempty"`
+	WaitlistEntryID *uuid.UUID             `json:"waitlist_entry_id,omitempty"`
+	TemplateData    map[string]interface{} `json:"template_data,omitempty"`
+}
+
+// NewMessage builds a pending outbox message carrying a JSON payload. The dedup
+// key must be stable for the logical message so retried writes collapse into
+// one row.
+func NewMessage(topic, aggregateType string, aggregateID uuid.UUID, dedupKey, payload string) *Message {
+	return &Message{
+		ID:            uuid.New(),
+		Topic:         topic,
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		DedupKey:      dedupKey,
+		Payload:       payload,
+		Status:        MessageStatusPending,
+		NextAttemptAt: time.Now(),
+	}
+}
+
+// NewNotificationMessage builds a pending notification message. The dedup key
+// must be stable for the logical notification so retried writes collapse into
+// one row.
+func NewNotificationMessage(aggregateType string, aggregateID uuid.UUID, dedupKey string, payload *NotificationPayload) (*Message, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal outbox payload: %w", err)
+	}
+
+	return NewMessage(TopicNotifications, aggregateType, aggregateID, dedupKey, string(data)), nil
+}
+
+// DecodePayload unmarshals the notification payload
+func (m *Message) DecodePayload() (*NotificationPayload, error) {
+	var payload NotificationPayload
+	if err := json.Unmarshal([]byte(m.Payload), &payload); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal outbox payload: %w", err)
+	}
+	return &payload, nil
+}
@@ -0,0 +1,238 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"evently/pkg/backoff"
+	"evently/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+// Publisher delivers one outbox message. The message ID is passed through so
+// downstream consumers can drop duplicate deliveries. A publisher that is an
+// io.Closer is closed when its relay stops.
+type Publisher interface {
+	Publish(ctx context.Context, msg *Message) error
+}
+
+// NotificationPublisher delivers a notification to the notification service
+type NotificationPublisher interface {
+	PublishNotification(ctx context.Context, messageID uuid.UUID, payload *NotificationPayload) error
+}
+
+// ErrUndeliverable is wrapped by a Publisher for a message that can never be
+// delivered, such as one with a malformed payload. It fails without retries.
+var ErrUndeliverable = errors.New("undeliverable message")
+
+// DeferredError is returned by a Publisher to hold a message back until a
+// later time, for instance the end of the recipient's quiet hours. Deferring
+// does not count as a failed attempt.
+type DeferredError struct {
+	Until  time.Time
+	Reason string
+}
+
+func (e *DeferredError) Error() string {
+	return fmt.Sprintf("deferred until %s: %s", e.Until.Format(time.RFC3339), e.Reason)
+}
+
+// RelayConfig contains configuration for the outbox relay
+type RelayConfig struct {
+	Name          string   // Log prefix
+	Topics        []string // Topics of the messages this relay publishes
+	PollInterval  time.Duration
+	BatchSize     int
+	MaxAttempts   int
+	BaseBackoff   time.Duration
+	MaxBackoff    time.Duration
+	Lease         time.Duration
+	Retention     time.Duration // Published messages are pruned after this long, never when zero
+	PruneInterval time.Duration
+}
+
+// DefaultRelayConfig returns default relay configuration, for notifications
+func DefaultRelayConfig() *RelayConfig {
+	return &RelayConfig{
+		Name:          "OUTBOX",
+		Topics:        []string{TopicNotifications},
+		PollInterval:  2 * time.Second,  // Poll for pending messages every 2 seconds
+		BatchSize:     50,               // Relay up to 50 messages per poll
+		MaxAttempts:   10,               // Give up after 10 failed publishes
+		BaseBackoff:   5 * time.Second,  // First retry after 5 seconds
+		MaxBackoff:    10 * time.Minute, // Cap retry delay at 10 minutes
+		Lease:         time.Minute,      // Claimed messages are hidden from other relays for a minute
+		PruneInterval: time.Hour,
+	}
+}
+
+// Relay moves pending outbox messages of its topics to a publisher with
+// at-least-once delivery: a message is only marked published after the
+// publisher acknowledges it, so a crash in between results in a redelivery
+// that consumers deduplicate by message ID.
+type Relay struct {
+	repo       Repository
+	publisher  Publisher
+	config     *RelayConfig
+	lastPruned time.Time
+	done       chan struct{}
+}
+
+// NewRelay creates a new outbox relay
+func NewRelay(repo Repository, publisher Publisher, config *RelayConfig) *Relay {
+	if config == nil {
+		config = DefaultRelayConfig()
+	}
+
+	return &Relay{
+		repo:      repo,
+		publisher: publisher,
+		config:    config,
+		done:      make(chan struct{}),
+	}
+}
+
+// Start starts the relay loop
+func (r *Relay) Start(ctx context.Context) {
+	log.Printf("📤 %s: Starting relay with %v poll interval", r.config.Name, r.config.PollInterval)
+	go r.run(ctx)
+}
+
+// Stop stops the relay loop and closes the publisher
+func (r *Relay) Stop() {
+	log.Printf("📤 %s: Stopping relay...", r.config.Name)
+	close(r.done)
+	if closer, ok := r.publisher.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			log.Printf("❌ %s: %v", r.config.Name, err)
+		}
+	}
+}
+
+func (r *Relay) run(ctx context.Context) {
+	ticker := time.NewTicker(r.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			r.relayBatch(ctx)
+			r.prune(ctx)
+		case <-r.done:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (r *Relay) relayBatch(ctx context.Context) {
+	messages, err := r.repo.ClaimBatch(ctx, r.config.Topics, r.config.BatchSize, r.config.Lease)
+	if err != nil {
+		log.Printf("❌ %s: Failed to claim messages: %v", r.config.Name, err)
+		return
+	}
+
+	for i := range messages {
+		r.relayMessage(ctx, &messages[i])
+	}
+}
+
+func (r *Relay) relayMessage(ctx context.Context, msg *Message) {
+	publishCtx := ctx
+	if msg.RequestID != "" {
+		publishCtx = logger.ContextWithRequestID(ctx, msg.RequestID)
+	}
+
+	if err := r.publisher.Publish(publishCtx, msg); err != nil {
+		var deferred *DeferredError
+		switch {
+		case errors.As(err, &deferred):
+			r.deferMessage(ctx, msg, deferred)
+		case errors.Is(err, ErrUndeliverable):
+			// Will never succeed, fail it immediately
+			log.Printf("❌ %s: %s message %s can't be delivered: %v", r.config.Name, msg.AggregateType, msg.ID, err)
+			if markErr := r.repo.MarkFailed(ctx, msg.ID, err.Error()); markErr != nil {
+				log.Printf("❌ %s: %v", r.config.Name, markErr)
+			}
+		default:
+			r.handlePublishError(ctx, msg, err)
+		}
+		return
+	}
+
+	if err := r.repo.MarkPublished(ctx, msg.ID); err != nil {
+		// The lease expires and the message is redelivered; consumers dedupe it
+		log.Printf("⚠️ %s: Message %s published but not marked: %v", r.config.Name, msg.ID, err)
+	}
+}
+
+func (r *Relay) handlePublishError(ctx context.Context, msg *Message, publishErr error) {
+	if msg.Attempts >= r.config.MaxAttempts {
+		log.Printf("❌ %s: Giving up on %s message %s after %d attempts: %v", r.config.Name, msg.AggregateType, msg.ID, msg.Attempts, publishErr)
+		if err := r.repo.MarkFailed(ctx, msg.ID, publishErr.Error()); err != nil {
+			log.Printf("❌ %s: %v", r.config.Name, err)
+		}
+		return
+	}
+
+	delay := backoff.Exponential(r.config.BaseBackoff, r.config.MaxBackoff, msg.Attempts)
+	log.Printf("⚠️ %s: Publish failed for %s message %s (attempt %d), retrying in %v: %v",
+		r.config.Name, msg.AggregateType, msg.ID, msg.Attempts, delay, publishErr)
+
+	if err := r.repo.MarkRetry(ctx, msg.ID, time.Now().Add(delay), publishErr.Error()); err != nil {
+		log.Printf("❌ %s: %v", r.config.Name, err)
+	}
+}
+
+func (r *Relay) deferMessage(ctx context.Context, msg *Message, deferred *DeferredError) {
+	log.Printf("⏸️ %s: Holding message %s until %s: %s", r.config.Name, msg.ID, deferred.Until.Format(time.RFC3339), deferred.Reason)
+	if err := r.repo.Defer(ctx, msg.ID, deferred.Until, deferred.Reason); err != nil {
+		log.Printf("❌ %s: %v", r.config.Name, err)
+	}
+}
+
+// prune deletes old published messages at most once per prune interval
+func (r *Relay) prune(ctx context.Context) {
+	if r.config.Retention <= 0 || time.Since(r.lastPruned) < r.config.PruneInterval {
+		return
+	}
+	r.lastPruned = time.Now()
+
+	deleted, err := r.repo.DeletePublishedBefore(ctx, r.config.Topics, time.Now().Add(-r.config.Retention))
+	if err != nil {
+		log.Printf("❌ %s: %v", r.config.Name, err)
+		return
+	}
+	if deleted > 0 {
+		log.Printf("🧹 %s: Pruned %d published messages", r.config.Name, deleted)
+	}
+}
+
+// Notifications adapts a NotificationPublisher to relay notification messages
+func Notifications(publisher NotificationPublisher) Publisher {
+	return &notificationPublisher{publisher: publisher}
+}
+
+type notificationPublisher struct {
+	publisher NotificationPublisher
+}
+
+func (p *notificationPublisher) Publish(ctx context.Context, msg *Message) error {
+	payload, err := msg.DecodePayload()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrUndeliverable, err)
+	}
+
+	if err := p.publisher.PublishNotification(ctx, msg.ID, payload); err != nil {
+		return err
+	}
+
+	log.Printf("✅ OUTBOX: Relayed %s message %s (attempt %d)", payload.Type, msg.ID, msg.Attempts)
+	return nil
+}
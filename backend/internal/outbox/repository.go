@@ -0,0 +1,178 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"evently/pkg/logger"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	// Claims due messages of the given topics for publishing. Claimed rows are
+	// leased so that other relay instances skip them until the lease expires.
+	ClaimBatch(ctx context.Context, topics []string, limit int, lease time.Duration) ([]Message, error)
+	MarkPublished(ctx context.Context, id uuid.UUID) error
+	MarkRetry(ctx context.Context, id uuid.UUID, nextAttemptAt time.Time, lastError string) error
+	MarkFailed(ctx context.Context, id uuid.UUID, lastError string) error
+	// Defer holds a message until a later time and gives back the attempt its claim used
+	Defer(ctx context.Context, id uuid.UUID, until time.Time, reason string) error
+	DeletePublishedBefore(ctx context.Context, topics []string, cutoff time.Time) (int64, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+// Enqueue writes messages using the caller's transaction. Messages whose dedup
+// key already exists are ignored, which makes enqueueing idempotent. Messages
+// are stamped with the request ID of the transaction's context, so the
+// notification can be traced back to the request that caused it.
+func Enqueue(tx *gorm.DB, messages ...*Message) error {
+	requestID := ""
+	if tx.Statement != nil {
+		requestID = logger.RequestIDFromContext(tx.Statement.Context)
+	}
+
+	for _, msg := range messages {
+		if msg == nil {
+			continue
+		}
+		if msg.RequestID == "" {
+			msg.RequestID = requestID
+		}
+		err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "dedup_key"}},
+			DoNothing: true,
+		}).Create(msg).Error
+		if err != nil {
+			return fmt.Errorf("failed to enqueue outbox message: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *repository) ClaimBatch(ctx context.Context, topics []string, limit int, lease time.Duration) ([]Message, error) {
+	var messages []Message
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("topic IN ? AND status = ? AND next_attempt_at <= ?", topics, MessageStatusPending, now).
+			Order("next_attempt_at ASC").
+			Limit(limit).
+			Find(&messages).Error
+		if err != nil {
+			return err
+		}
+
+		if len(messages) == 0 {
+			return nil
+		}
+
+		ids := make([]uuid.UUID, len(messages))
+		for i, msg := range messages {
+			ids[i] = msg.ID
+		}
+
+		return tx.Model(&Message{}).
+			Where("id IN ?", ids).
+			Updates(map[string]interface{}{
+				"next_attempt_at": now.Add(lease),
+				"attempts":        gorm.Expr("attempts + 1"),
+				"updated_at":      now,
+			}).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim outbox messages: %w", err)
+	}
+
+	for i := range messages {
+		messages[i].Attempts++
+	}
+
+	return messages, nil
+}
+
+func (r *repository) MarkPublished(ctx context.Context, id uuid.UUID) error {
+	now := time.Now()
+	err := r.db.WithContext(ctx).
+		Model(&Message{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":       MessageStatusPublished,
+			"published_at": now,
+			"last_error":   "",
+			"updated_at":   now,
+		}).Error
+	if err != nil {
+		return fmt.Errorf("failed to mark outbox message published: %w", err)
+	}
+	return nil
+}
+
+func (r *repository) MarkRetry(ctx context.Context, id uuid.UUID, nextAttemptAt time.Time, lastError string) error {
+	err := r.db.WithContext(ctx).
+		Model(&Message{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"next_attempt_at": nextAttemptAt,
+			"last_error":      lastError,
+			"updated_at":      time.Now(),
+		}).Error
+	if err != nil {
+		return fmt.Errorf("failed to reschedule outbox message: %w", err)
+	}
+	return nil
+}
+
+func (r *repository) MarkFailed(ctx context.Context, id uuid.UUID, lastError string) error {
+	err := r.db.WithContext(ctx).
+		Model(&Message{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":     MessageStatusFailed,
+			"last_error": lastError,
+			"updated_at": time.Now(),
+		}).Error
+	if err != nil {
+		return fmt.Errorf("failed to mark outbox message failed: %w", err)
+	}
+	return nil
+}
+
+func (r *repository) Defer(ctx context.Context, id uuid.UUID, until time.Time, reason string) error {
+	err := r.db.WithContext(ctx).
+		Model(&Message{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"next_attempt_at": until,
+			"attempts":        gorm.Expr("GREATEST(attempts - 1, 0)"),
+			"last_error":      reason,
+			"updated_at":      time.Now(),
+		}).Error
+	if err != nil {
+		return fmt.Errorf("failed to defer outbox message: %w", err)
+	}
+	return nil
+}
+
+// DeletePublishedBefore prunes messages of the given topics that were published
+// before cutoff. Failed messages are kept so they can be inspected and requeued.
+func (r *repository) DeletePublishedBefore(ctx context.Context, topics []string, cutoff time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("topic IN ? AND status = ? AND published_at < ?", topics, MessageStatusPublished, cutoff).
+		Delete(&Message{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to prune outbox messages: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,388 @@
+package permissions
+
+import (
+	"errors"
+	"net/http"
+
+	"evently/internal/shared/utils/response"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type Controller struct {
+	service Service
+}
+
+func NewController(service Service) *Controller {
+	return &Controller{service: service}
+}
+
+// ListPermissions godoc
+//
+// @Summary      List permissions (Admin)
+// @Description  Every permission a custom role can grant
+// @Description
+// @Description  Requires the `permissions:manage` permission.
+// @Tags         Admin Roles
+// @Produce      json
+// @Security     Bearer
+// @Success      200 {object} response.StandardApiResponse{data=[]middleware.PermissionInfo} "Permissions retrieved successfully"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/permissions [get]
+func (ctrl *Controller) ListPermissions(c *gin.Context) {
+	response.RespondJSON(c, "success", http.StatusOK, "Permissions retrieved successfully", ctrl.service.ListPermissions(), nil)
+}
+
+//  ROLES
+
+// CreateRole godoc
+//
+// @Summary      Create role (Admin)
+// @Description  Requires the `permissions:manage` permission.
+// @Tags         Admin Roles
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        request body CreateRoleRequest true "Request body"
+// @Success      201 {object} response.StandardApiResponse{data=Role} "Role created successfully"
+// @Failure      400 {object} response.StandardApiResponse "Invalid name or permissions"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse
+// @Failure      409 {object} response.StandardApiResponse "A role with this name already exists"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/roles [post]
+func (ctrl *Controller) CreateRole(c *gin.Context) {
+	adminID, ok := ctrl.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req CreateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid request body", nil, err.Error())
+		return
+	}
+
+	role, err := ctrl.service.CreateRole(c.Request.Context(), adminID, req)
+	if err != nil {
+		ctrl.respondError(c, err, "Failed to create role")
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusCreated, "Role created successfully", role, nil)
+}
+
+// ListRoles godoc
+//
+// @Summary      List roles (Admin)
+// @Description  Requires the `permissions:manage` permission.
+// @Tags         Admin Roles
+// @Produce      json
+// @Security     Bearer
+// @Success      200 {object} response.StandardApiResponse{data=[]RoleResponse} "Roles retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse
+// @Failure      409 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/roles [get]
+func (ctrl *Controller) ListRoles(c *gin.Context) {
+	roles, err := ctrl.service.ListRoles(c.Request.Context())
+	if err != nil {
+		ctrl.respondError(c, err, "Failed to retrieve roles")
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Roles retrieved successfully", roles, nil)
+}
+
+// GetRole godoc
+//
+// @Summary      Get role (Admin)
+// @Description  Requires the `permissions:manage` permission.
+// @Tags         Admin Roles
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=RoleResponse} "Role retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Role not found"
+// @Failure      409 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/roles/{id} [get]
+func (ctrl *Controller) GetRole(c *gin.Context) {
+	id, ok := ctrl.uuidParam(c, "id", "Invalid role ID")
+	if !ok {
+		return
+	}
+
+	role, err := ctrl.service.GetRole(c.Request.Context(), id)
+	if err != nil {
+		ctrl.respondError(c, err, "Failed to retrieve role")
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Role retrieved successfully", role, nil)
+}
+
+// UpdateRole godoc
+//
+// @Summary      Update role (Admin)
+// @Description  Changes the fields that are sent for everyone holding the role. Permissions replace the current ones.
+// @Description
+// @Description  Requires the `permissions:manage` permission.
+// @Tags         Admin Roles
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "ID" Format(uuid)
+// @Param        request body UpdateRoleRequest true "Request body"
+// @Success      200 {object} response.StandardApiResponse{data=Role} "Role updated successfully"
+// @Failure      400 {object} response.StandardApiResponse "Invalid name or permissions"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Role not found"
+// @Failure      409 {object} response.StandardApiResponse "A role with this name already exists"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/roles/{id} [put]
+func (ctrl *Controller) UpdateRole(c *gin.Context) {
+	id, ok := ctrl.uuidParam(c, "id", "Invalid role ID")
+	if !ok {
+		return
+	}
+
+	var req UpdateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid request body", nil, err.Error())
+		return
+	}
+
+	role, err := ctrl.service.UpdateRole(c.Request.Context(), id, req)
+	if err != nil {
+		ctrl.respondError(c, err, "Failed to update role")
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Role updated successfully", role, nil)
+}
+
+// DeleteRole godoc
+//
+// @Summary      Delete role (Admin)
+// @Description  Deletes the role and takes it away from everyone holding it
+// @Description
+// @Description  Requires the `permissions:manage` permission.
+// @Tags         Admin Roles
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse "Role deleted successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Role not found"
+// @Failure      409 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/roles/{id} [delete]
+func (ctrl *Controller) DeleteRole(c *gin.Context) {
+	id, ok := ctrl.uuidParam(c, "id", "Invalid role ID")
+	if !ok {
+		return
+	}
+
+	if err := ctrl.service.DeleteRole(c.Request.Context(), id); err != nil {
+		ctrl.respondError(c, err, "Failed to delete role")
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Role deleted successfully", nil, nil)
+}
+
+//  ASSIGNMENTS
+
+// GetUserPermissions godoc
+//
+// @Summary      Get user roles (Admin)
+// @Description  The user's custom roles and the permissions they add up to
+// @Description
+// @Description  Requires the `permissions:manage` permission.
+// @Tags         Admin Roles
+// @Produce      json
+// @Security     Bearer
+// @Param        userId path string true "User ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=UserPermissionsResponse} "User permissions retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "User not found"
+// @Failure      409 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/users/{userId}/roles [get]
+func (ctrl *Controller) GetUserPermissions(c *gin.Context) {
+	userID, ok := ctrl.uuidParam(c, "userId", "Invalid user ID")
+	if !ok {
+		return
+	}
+
+	permissions, err := ctrl.service.GetUserPermissions(c.Request.Context(), userID)
+	if err != nil {
+		ctrl.respondError(c, err, "Failed to retrieve user permissions")
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "User permissions retrieved successfully", permissions, nil)
+}
+
+// AssignRole godoc
+//
+// @Summary      Assign role (Admin)
+// @Description  Assigning a role the user already holds is a no-op
+// @Description
+// @Description  Requires the `permissions:manage` permission.
+// @Tags         Admin Roles
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        userId path string true "User ID" Format(uuid)
+// @Param        request body AssignRoleRequest true "Request body"
+// @Success      200 {object} response.StandardApiResponse{data=UserPermissionsResponse} "Role assigned successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "User or role not found"
+// @Failure      409 {object} response.StandardApiResponse "Admins already hold every permission"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/users/{userId}/roles [post]
+func (ctrl *Controller) AssignRole(c *gin.Context) {
+	adminID, ok := ctrl.currentUserID(c)
+	if !ok {
+		return
+	}
+	userID, ok := ctrl.uuidParam(c, "userId", "Invalid user ID")
+	if !ok {
+		return
+	}
+
+	var req AssignRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid request body", nil, err.Error())
+		return
+	}
+
+	permissions, err := ctrl.service.AssignRole(c.Request.Context(), adminID, userID, req)
+	if err != nil {
+		ctrl.respondError(c, err, "Failed to assign role")
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Role assigned successfully", permissions, nil)
+}
+
+// RevokeRole godoc
+//
+// @Summary      Revoke role (Admin)
+// @Description  Requires the `permissions:manage` permission.
+// @Tags         Admin Roles
+// @Produce      json
+// @Security     Bearer
+// @Param        userId path string true "User ID" Format(uuid)
+// @Param        roleId path string true "Role ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=UserPermissionsResponse} "Role revoked successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "User does not hold this role"
+// @Failure      409 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/users/{userId}/roles/{roleId} [delete]
+func (ctrl *Controller) RevokeRole(c *gin.Context) {
+	userID, ok := ctrl.uuidParam(c, "userId", "Invalid user ID")
+	if !ok {
+		return
+	}
+	roleID, ok := ctrl.uuidParam(c, "roleId", "Invalid role ID")
+	if !ok {
+		return
+	}
+
+	permissions, err := ctrl.service.RevokeRole(c.Request.Context(), userID, roleID)
+	if err != nil {
+		ctrl.respondError(c, err, "Failed to revoke role")
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Role revoked successfully", permissions, nil)
+}
+
+// GetMyPermissions lets clients show only the admin tools the caller can use
+//
+// @Summary      Get my permissions
+// @Description  The caller's custom roles and effective permissions, so clients can show only the admin tools they can use
+// @Tags         Admin Roles
+// @Produce      json
+// @Security     Bearer
+// @Success      200 {object} response.StandardApiResponse{data=UserPermissionsResponse} "Permissions retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse
+// @Failure      409 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/users/me/permissions [get]
+func (ctrl *Controller) GetMyPermissions(c *gin.Context) {
+	userID, ok := ctrl.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	permissions, err := ctrl.service.GetUserPermissions(c.Request.Context(), userID)
+	if err != nil {
+		ctrl.respondError(c, err, "Failed to retrieve permissions")
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Permissions retrieved successfully", permissions, nil)
+}
+
+func (ctrl *Controller) currentUserID(c *gin.Context) (uuid.UUID, bool) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.RespondJSON(c, "error", http.StatusUnauthorized, "User not authenticated", nil, nil)
+		return uuid.Nil, false
+	}
+
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusInternalServerError, "Invalid user ID format", nil, nil)
+		return uuid.Nil, false
+	}
+	return userUUID, true
+}
+
+func (ctrl *Controller) uuidParam(c *gin.Context, name, message string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param(name))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, message, nil, err.Error())
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func (ctrl *Controller) respondError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, ErrRoleNotFound), errors.Is(err, ErrUserNotFound), errors.Is(err, ErrRoleNotAssigned):
+		response.RespondJSON(c, "error", http.StatusNotFound, err.Error(), nil, nil)
+	case errors.Is(err, ErrRoleExists), errors.Is(err, ErrAdminHoldsAll):
+		response.RespondJSON(c, "error", http.StatusConflict, err.Error(), nil, nil)
+	case errors.Is(err, ErrInvalidRoleName), errors.Is(err, ErrReservedRoleName), errors.Is(err, ErrInvalidPermission):
+		response.RespondJSON(c, "error", http.StatusBadRequest, err.Error(), nil, nil)
+	default:
+		response.RespondJSON(c, "error", http.StatusInternalServerError, message, nil, err.Error())
+	}
+}
@@ -0,0 +1,36 @@
+package permissions
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Role is a custom role granting a set of permissions. Users keep their USER
+// or ADMIN account role and gain the permissions of each custom role assigned
+// to them.
+type Role struct {
+	ID          uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	Name        string    `gorm:"type:varchar(50);uniqueIndex;not null" json:"name"`
+	Description string    `gorm:"type:text" json:"description"`
+	Permissions []string  `gorm:"type:jsonb;serializer:json;not null" json:"permissions"`
+	CreatedBy   uuid.UUID `gorm:"type:uuid;not null" json:"created_by"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// UserRole assigns a custom role to a user
+type UserRole struct {
+	UserID     uuid.UUID `gorm:"type:uuid;primaryKey" json:"user_id"`
+	RoleID     uuid.UUID `gorm:"type:uuid;primaryKey;index" json:"role_id"`
+	AssignedBy uuid.UUID `gorm:"type:uuid;not null" json:"assigned_by"`
+	CreatedAt  time.Time `json:"assigned_at"`
+}
+
+func (Role) TableName() string {
+	return "roles"
+}
+
+func (UserRole) TableName() string {
+	return "user_roles"
+}
@@ -0,0 +1,134 @@
+package permissions
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	// Roles
+	CreateRole(ctx context.Context, role *Role) error
+	GetRole(ctx context.Context, id uuid.UUID) (*Role, error)
+	ListRoles(ctx context.Context) ([]RoleResponse, error)
+	UpdateRole(ctx context.Context, role *Role) error
+	DeleteRole(ctx context.Context, id uuid.UUID) error
+	CountRoleUsers(ctx context.Context, roleID uuid.UUID) (int64, error)
+
+	// Assignments
+	AssignRole(ctx context.Context, assignment *UserRole) error
+	RevokeRole(ctx context.Context, userID, roleID uuid.UUID) (bool, error)
+	GetUserRoles(ctx context.Context, userID uuid.UUID) ([]Role, error)
+	GetAccountRole(ctx context.Context, userID uuid.UUID) (string, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+//  ROLES
+
+func (r *repository) CreateRole(ctx context.Context, role *Role) error {
+	return r.db.WithContext(ctx).Create(role).Error
+}
+
+func (r *repository) GetRole(ctx context.Context, id uuid.UUID) (*Role, error) {
+	var role Role
+	if err := r.db.WithContext(ctx).Where("id = ?", id).Take(&role).Error; err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
+
+func (r *repository) ListRoles(ctx context.Context) ([]RoleResponse, error) {
+	var roles []Role
+	if err := r.db.WithContext(ctx).Order("name ASC").Find(&roles).Error; err != nil {
+		return nil, err
+	}
+
+	var counts []struct {
+		RoleID    uuid.UUID
+		UserCount int64
+	}
+	err := r.db.WithContext(ctx).
+		Model(&UserRole{}).
+		Select("role_id, COUNT(*) AS user_count").
+		Group("role_id").
+		Scan(&counts).Error
+	if err != nil {
+		return nil, err
+	}
+
+	userCounts := make(map[uuid.UUID]int64, len(counts))
+	for _, c := range counts {
+		userCounts[c.RoleID] = c.UserCount
+	}
+
+	result := make([]RoleResponse, len(roles))
+	for i, role := range roles {
+		result[i] = RoleResponse{Role: role, UserCount: userCounts[role.ID]}
+	}
+	return result, nil
+}
+
+func (r *repository) UpdateRole(ctx context.Context, role *Role) error {
+	return r.db.WithContext(ctx).Save(role).Error
+}
+
+// DeleteRole removes a role and takes it away from everyone holding it
+func (r *repository) DeleteRole(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("role_id = ?", id).Delete(&UserRole{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&Role{}).Error
+	})
+}
+
+func (r *repository) CountRoleUsers(ctx context.Context, roleID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&UserRole{}).Where("role_id = ?", roleID).Count(&count).Error
+	return count, err
+}
+
+//  ASSIGNMENTS
+
+// AssignRole is a no-op when the user already holds the role
+func (r *repository) AssignRole(ctx context.Context, assignment *UserRole) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(assignment).Error
+}
+
+// RevokeRole reports false when the user did not hold the role
+func (r *repository) RevokeRole(ctx context.Context, userID, roleID uuid.UUID) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Where("user_id = ? AND role_id = ?", userID, roleID).
+		Delete(&UserRole{})
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *repository) GetUserRoles(ctx context.Context, userID uuid.UUID) ([]Role, error) {
+	var roles []Role
+	err := r.db.WithContext(ctx).
+		Joins("JOIN user_roles ur ON ur.role_id = roles.id").
+		Where("ur.user_id = ?", userID).
+		Order("roles.name ASC").
+		Find(&roles).Error
+	return roles, err
+}
+
+// GetAccountRole returns the USER or ADMIN role of an account that has not been deleted
+func (r *repository) GetAccountRole(ctx context.Context, userID uuid.UUID) (string, error) {
+	var role string
+	err := r.db.WithContext(ctx).
+		Table("users").
+		Select("role").
+		Where("id = ? AND deleted_at IS NULL", userID).
+		Take(&role).Error
+	return role, err
+}
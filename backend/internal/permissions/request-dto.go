@@ -0,0 +1,20 @@
+package permissions
+
+import "github.com/google/uuid"
+
+type CreateRoleRequest struct {
+	Name        string   `json:"name" binding:"required,max=50"`
+	Description string   `json:"description" binding:"max=500"`
+	Permissions []string `json:"permissions" binding:"required,min=1"` // See GET /admin/permissions
+}
+
+// UpdateRoleRequest changes the fields that are sent; permissions replace the current ones
+type UpdateRoleRequest struct {
+	Name        *string  `json:"name" binding:"omitempty,max=50"`
+	Description *string  `json:"description" binding:"omitempty,max=500"`
+	Permissions []string `json:"permissions" binding:"omitempty,min=1"`
+}
+
+type AssignRoleRequest struct {
+	RoleID uuid.UUID `json:"role_id" binding:"required"`
+}
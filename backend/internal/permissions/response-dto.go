@@ -0,0 +1,18 @@
+package permissions
+
+import "github.com/google/uuid"
+
+// RoleResponse is a custom role with the number of users holding it
+type RoleResponse struct {
+	Role
+	UserCount int64 `json:"user_count"`
+}
+
+// UserPermissionsResponse is what a user may do: their account role, the
+// custom roles assigned to them and the permissions these add up to
+type UserPermissionsResponse struct {
+	UserID      uuid.UUID `json:"user_id"`
+	AccountRole string    `json:"account_role"` // USER or ADMIN; admins hold every permission
+	Roles       []Role    `json:"roles"`
+	Permissions []string  `json:"permissions"`
+}
@@ -0,0 +1,32 @@
+package permissions
+
+import (
+	"evently/internal/shared/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupPermissionRoutes(rg *gin.RouterGroup, controller *Controller) {
+	// Permission catalog and custom roles
+	admin := rg.Group("/admin")
+	admin.Use(middleware.JWTAuth(), middleware.RequirePermission(middleware.PermissionPermissionsManage))
+	{
+		admin.GET("/permissions", controller.ListPermissions) // GET /api/v1/admin/permissions - Every permission a role can grant
+		admin.POST("/roles", controller.CreateRole)           // POST /api/v1/admin/roles
+		admin.GET("/roles", controller.ListRoles)             // GET /api/v1/admin/roles
+		admin.GET("/roles/:id", controller.GetRole)           // GET /api/v1/admin/roles/:id
+		admin.PUT("/roles/:id", controller.UpdateRole)        // PUT /api/v1/admin/roles/:id
+		admin.DELETE("/roles/:id", controller.DeleteRole)     // DELETE /api/v1/admin/roles/:id - Also unassigns it
+
+		// Role assignments
+		admin.GET("/users/:userId/roles", controller.GetUserPermissions)    // GET /api/v1/admin/users/:userId/roles - Roles and effective permissions
+		admin.POST("/users/:userId/roles", controller.AssignRole)           // POST /api/v1/admin/users/:userId/roles
+		admin.DELETE("/users/:userId/roles/:roleId", controller.RevokeRole) // DELETE /api/v1/admin/users/:userId/roles/:roleId
+	}
+
+	users := rg.Group("/users/me")
+	users.Use(middleware.JWTAuth(), middleware.RequireRoles("USER", "ADMIN"))
+	{
+		users.GET("/permissions", controller.GetMyPermissions) // GET /api/v1/users/me/permissions
+	}
+}
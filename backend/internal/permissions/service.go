@@ -0,0 +1,391 @@
+package permissions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"evently/internal/shared/middleware"
+	"evently/internal/users"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrRoleNotFound      = errors.New("role not found")
+	ErrRoleExists        = errors.New("a role with this name already exists")
+	ErrInvalidRoleName   = errors.New("role name must not be empty")
+	ErrReservedRoleName  = errors.New("USER and ADMIN are account roles and cannot be used as custom role names")
+	ErrInvalidPermission = fmt.Errorf("permissions must be one or more of: %s", strings.Join(middleware.PermissionNames(), ", "))
+	ErrUserNotFound      = errors.New("user not found")
+	ErrRoleNotAssigned   = errors.New("user does not hold this role")
+	ErrAdminHoldsAll     = errors.New("admins already hold every permission")
+)
+
+// Config contains permission settings
+type Config struct {
+	CacheTTL time.Duration // How long a user's permissions are trusted before they are looked up again
+}
+
+// DefaultConfig returns default permission configuration
+func DefaultConfig() *Config {
+	return &Config{
+		CacheTTL: 30 * time.Second,
+	}
+}
+
+type Service interface {
+	ListPermissions() []middleware.PermissionInfo
+
+	// Roles
+	CreateRole(ctx context.Context, adminID uuid.UUID, req CreateRoleRequest) (*Role, error)
+	ListRoles(ctx context.Context) ([]RoleResponse, error)
+	GetRole(ctx context.Context, id uuid.UUID) (*RoleResponse, error)
+	UpdateRole(ctx context.Context, id uuid.UUID, req UpdateRoleRequest) (*Role, error)
+	DeleteRole(ctx context.Context, id uuid.UUID) error
+
+	// Assignments
+	GetUserPermissions(ctx context.Context, userID uuid.UUID) (*UserPermissionsResponse, error)
+	AssignRole(ctx context.Context, adminID, userID uuid.UUID, req AssignRoleRequest) (*UserPermissionsResponse, error)
+	RevokeRole(ctx context.Context, userID, roleID uuid.UUID) (*UserPermissionsResponse, error)
+
+	// UserPermissions implements middleware.PermissionResolver
+	UserPermissions(ctx context.Context, userID string) ([]string, error)
+}
+
+// cachedPermissions are the permissions a user's custom roles grant
+type cachedPermissions struct {
+	permissions []string
+	expires     time.Time
+}
+
+type service struct {
+	repo   Repository
+	config *Config
+
+	cacheMu    sync.Mutex
+	cache      map[string]cachedPermissions // By user ID
+	generation uint64                       // Bumped on every invalidation
+	lastPruned time.Time
+}
+
+func NewService(repo Repository, config *Config) Service {
+	if config == nil {
+		config = DefaultConfig()
+	}
+	return &service{
+		repo:   repo,
+		config: config,
+		cache:  make(map[string]cachedPermissions),
+	}
+}
+
+func (s *service) ListPermissions() []middleware.PermissionInfo {
+	return middleware.Permissions
+}
+
+//  ROLES
+
+func (s *service) CreateRole(ctx context.Context, adminID uuid.UUID, req CreateRoleRequest) (*Role, error) {
+	name, err := normalizeName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	permissions, err := normalizePermissions(req.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	role := &Role{
+		ID:          uuid.New(),
+		Name:        name,
+		Description: strings.TrimSpace(req.Description),
+		Permissions: permissions,
+		CreatedBy:   adminID,
+	}
+	if err := s.repo.CreateRole(ctx, role); err != nil {
+		if strings.Contains(err.Error(), "duplicate key") {
+			return nil, ErrRoleExists
+		}
+		return nil, fmt.Errorf("failed to create role: %w", err)
+	}
+	return role, nil
+}
+
+func (s *service) ListRoles(ctx context.Context) ([]RoleResponse, error) {
+	roles, err := s.repo.ListRoles(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list roles: %w", err)
+	}
+	return roles, nil
+}
+
+func (s *service) GetRole(ctx context.Context, id uuid.UUID) (*RoleResponse, error) {
+	role, err := s.getRole(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.repo.CountRoleUsers(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count role users: %w", err)
+	}
+	return &RoleResponse{Role: *role, UserCount: count}, nil
+}
+
+// UpdateRole changes a role for everyone holding it. Other instances pick up
+// the change within the cache TTL.
+func (s *service) UpdateRole(ctx context.Context, id uuid.UUID, req UpdateRoleRequest) (*Role, error) {
+	role, err := s.getRole(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		name, err := normalizeName(*req.Name)
+		if err != nil {
+			return nil, err
+		}
+		role.Name = name
+	}
+	if req.Description != nil {
+		role.Description = strings.TrimSpace(*req.Description)
+	}
+	if req.Permissions != nil {
+		permissions, err := normalizePermissions(req.Permissions)
+		if err != nil {
+			return nil, err
+		}
+		role.Permissions = permissions
+	}
+
+	if err := s.repo.UpdateRole(ctx, role); err != nil {
+		if strings.Contains(err.Error(), "duplicate key") {
+			return nil, ErrRoleExists
+		}
+		return nil, fmt.Errorf("failed to update role: %w", err)
+	}
+	s.forgetAll()
+
+	return role, nil
+}
+
+// DeleteRole removes a role from everyone holding it
+func (s *service) DeleteRole(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.getRole(ctx, id); err != nil {
+		return err
+	}
+	if err := s.repo.DeleteRole(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete role: %w", err)
+	}
+	s.forgetAll()
+	return nil
+}
+
+//  ASSIGNMENTS
+
+func (s *service) GetUserPermissions(ctx context.Context, userID uuid.UUID) (*UserPermissionsResponse, error) {
+	accountRole, err := s.getAccountRole(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	roles, err := s.repo.GetUserRoles(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user roles: %w", err)
+	}
+
+	permissions := grantedBy(roles)
+	if accountRole == string(users.RoleAdmin) {
+		permissions = middleware.PermissionNames()
+	}
+
+	return &UserPermissionsResponse{
+		UserID:      userID,
+		AccountRole: accountRole,
+		Roles:       roles,
+		Permissions: permissions,
+	}, nil
+}
+
+// AssignRole gives a user a custom role. Assigning a role the user already
+// holds is a no-op.
+func (s *service) AssignRole(ctx context.Context, adminID, userID uuid.UUID, req AssignRoleRequest) (*UserPermissionsResponse, error) {
+	accountRole, err := s.getAccountRole(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if accountRole == string(users.RoleAdmin) {
+		return nil, ErrAdminHoldsAll
+	}
+	if _, err := s.getRole(ctx, req.RoleID); err != nil {
+		return nil, err
+	}
+
+	assignment := &UserRole{
+		UserID:     userID,
+		RoleID:     req.RoleID,
+		AssignedBy: adminID,
+	}
+	if err := s.repo.AssignRole(ctx, assignment); err != nil {
+		return nil, fmt.Errorf("failed to assign role: %w", err)
+	}
+	s.forget(userID.String())
+
+	return s.GetUserPermissions(ctx, userID)
+}
+
+func (s *service) RevokeRole(ctx context.Context, userID, roleID uuid.UUID) (*UserPermissionsResponse, error) {
+	revoked, err := s.repo.RevokeRole(ctx, userID, roleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to revoke role: %w", err)
+	}
+	if !revoked {
+		return nil, ErrRoleNotAssigned
+	}
+	s.forget(userID.String())
+
+	return s.GetUserPermissions(ctx, userID)
+}
+
+// UserPermissions returns the permissions a user's custom roles grant. They
+// are cached, so on other replicas a change takes effect within the cache TTL.
+func (s *service) UserPermissions(ctx context.Context, userID string) ([]string, error) {
+	now := time.Now()
+	s.cacheMu.Lock()
+	entry, ok := s.cache[userID]
+	generation := s.generation
+	s.cacheMu.Unlock()
+	if ok && entry.expires.After(now) {
+		return entry.permissions, nil
+	}
+
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, nil
+	}
+	roles, err := s.repo.GetUserRoles(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user roles: %w", err)
+	}
+
+	entry = cachedPermissions{permissions: grantedBy(roles), expires: now.Add(s.config.CacheTTL)}
+	s.cacheMu.Lock()
+	defer s.cacheMu.Unlock()
+	// Roles read before an invalidation may be stale, so they aren't cached
+	if s.generation == generation {
+		s.pruneExpired(now)
+		s.cache[userID] = entry
+	}
+	return entry.permissions, nil
+}
+
+// pruneExpired drops expired entries, at most once per cache TTL, so users
+// who stopped calling gated routes don't stay in the cache. The caller holds
+// cacheMu.
+func (s *service) pruneExpired(now time.Time) {
+	if now.Sub(s.lastPruned) < s.config.CacheTTL {
+		return
+	}
+	s.lastPruned = now
+	for userID, entry := range s.cache {
+		if !entry.expires.After(now) {
+			delete(s.cache, userID)
+		}
+	}
+}
+
+// forget drops a user's cached permissions after their roles change
+func (s *service) forget(userID string) {
+	s.cacheMu.Lock()
+	defer s.cacheMu.Unlock()
+	s.generation++
+	delete(s.cache, userID)
+}
+
+// forgetAll drops every cached permission after a role changes
+func (s *service) forgetAll() {
+	s.cacheMu.Lock()
+	defer s.cacheMu.Unlock()
+	s.generation++
+	s.cache = make(map[string]cachedPermissions)
+}
+
+func (s *service) getRole(ctx context.Context, id uuid.UUID) (*Role, error) {
+	role, err := s.repo.GetRole(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRoleNotFound
+		}
+		return nil, fmt.Errorf("failed to get role: %w", err)
+	}
+	return role, nil
+}
+
+func (s *service) getAccountRole(ctx context.Context, userID uuid.UUID) (string, error) {
+	role, err := s.repo.GetAccountRole(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", ErrUserNotFound
+		}
+		return "", fmt.Errorf("failed to get user: %w", err)
+	}
+	return role, nil
+}
+
+// grantedBy merges the permissions of roles, sorted
+func grantedBy(roles []Role) []string {
+	seen := make(map[string]bool)
+	permissions := []string{}
+	for _, role := range roles {
+		for _, p := range role.Permissions {
+			if !seen[p] {
+				seen[p] = true
+				permissions = append(permissions, p)
+			}
+		}
+	}
+	sort.Strings(permissions)
+	return permissions
+}
+
+func normalizeName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", ErrInvalidRoleName
+	}
+	if users.IsValidRole(strings.ToUpper(name)) {
+		return "", ErrReservedRoleName
+	}
+	return name, nil
+}
+
+func normalizePermissions(permissions []string) ([]string, error) {
+	valid := make(map[string]bool, len(middleware.Permissions))
+	for _, p := range middleware.Permissions {
+		valid[p.Name] = true
+	}
+
+	seen := make(map[string]bool, len(permissions))
+	normalized := make([]string, 0, len(permissions))
+	for _, p := range permissions {
+		p = strings.ToLower(strings.TrimSpace(p))
+		if !valid[p] {
+			return nil, ErrInvalidPermission
+		}
+		if !seen[p] {
+			seen[p] = true
+			normalized = append(normalized, p)
+		}
+	}
+	if len(normalized) == 0 {
+		return nil, ErrInvalidPermission
+	}
+	sort.Strings(normalized)
+	return normalized, nil
+}
@@ -0,0 +1,267 @@
+package profiles
+
+import (
+	"errors"
+	"net/http"
+
+	"evently/internal/notificationprefs"
+	"evently/internal/shared/utils/response"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type Controller struct {
+	service Service
+}
+
+func NewController(service Service) *Controller {
+	return &Controller{service: service}
+}
+
+// GetProfile returns the current user's profile, preferred tags and notification preferences
+//
+// @Summary      Get profile
+// @Description  The current user's details, preferred tags, notification preferences and any email change waiting to be confirmed.
+// @Tags         Profile
+// @Produce      json
+// @Security     Bearer
+// @Success      200 {object} response.StandardApiResponse{data=ProfileResponse} "Profile retrieved successfully"
+// @Failure      401 {object} response.StandardApiResponse "User not authenticated"
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/users/me [get]
+func (ctrl *Controller) GetProfile(c *gin.Context) {
+	userID, ok := ctrl.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	profile, err := ctrl.service.GetProfile(c.Request.Context(), userID)
+	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			response.RespondJSON(c, "error", http.StatusNotFound, err.Error(), nil, nil)
+			return
+		}
+		response.RespondJSON(c, "error", http.StatusInternalServerError, "Failed to get profile", nil, err.Error())
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Profile retrieved successfully", profile, nil)
+}
+
+// UpdateProfile changes the current user's details, interests and notification preferences
+//
+// @Summary      Update profile
+// @Description  Changes the current user's details, interests and notification preferences. Omitted fields keep their current value and an empty string clears an optional one. Every field is validated before anything is saved. The email address is changed through /users/me/email instead.
+// @Tags         Profile
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        request body UpdateProfileRequest true "Request body"
+// @Success      200 {object} response.StandardApiResponse{data=ProfileResponse} "Profile updated successfully"
+// @Failure      400 {object} response.StandardApiResponse "Invalid name, phone, avatar URL or city, unknown or too many tags, or invalid notification preferences"
+// @Failure      401 {object} response.StandardApiResponse "User not authenticated"
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/users/me [put]
+func (ctrl *Controller) UpdateProfile(c *gin.Context) {
+	userID, ok := ctrl.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid request body", nil, err.Error())
+		return
+	}
+
+	profile, err := ctrl.service.UpdateProfile(c.Request.Context(), userID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrUserNotFound):
+			response.RespondJSON(c, "error", http.StatusNotFound, err.Error(), nil, nil)
+		case errors.Is(err, ErrInvalidName), errors.Is(err, ErrInvalidPhone),
+			errors.Is(err, ErrInvalidAvatarURL), errors.Is(err, ErrInvalidCity),
+			errors.Is(err, ErrTooManyTags), errors.Is(err, ErrUnknownTag),
+			errors.Is(err, notificationprefs.ErrUnknownType), errors.Is(err, notificationprefs.ErrInvalidClock),
+			errors.Is(err, notificationprefs.ErrInvalidTimezone), errors.Is(err, notificationprefs.ErrEmptyQuietHours),
+			errors.Is(err, notificationprefs.ErrInvalidPhone), errors.Is(err, notificationprefs.ErrInvalidToken):
+			response.RespondJSON(c, "error", http.StatusBadRequest, err.Error(), nil, nil)
+		default:
+			response.RespondJSON(c, "error", http.StatusInternalServerError, "Failed to update profile", nil, err.Error())
+		}
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Profile updated successfully", profile, nil)
+}
+
+// RequestEmailChange emails a confirmation link to the new address
+//
+// @Summary      Request an email change
+// @Description  Emails a confirmation link to the new address, valid for PROFILE_EMAIL_CHANGE_EXPIRY (24h by default). The account keeps signing in with its current address until the link is followed. A new request replaces one still pending.
+// @Description
+// @Description  Refused while impersonating a user.
+// @Tags         Profile
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        request body ChangeEmailRequest true "Request body"
+// @Success      202 {object} response.StandardApiResponse{data=PendingEmailChange} "Confirmation link sent to the new email address"
+// @Failure      400 {object} response.StandardApiResponse "Invalid request body or the address is already the account's"
+// @Failure      401 {object} response.StandardApiResponse "User not authenticated or password is incorrect"
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse
+// @Failure      409 {object} response.StandardApiResponse "Email is already registered"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/users/me/email [post]
+func (ctrl *Controller) RequestEmailChange(c *gin.Context) {
+	userID, ok := ctrl.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req ChangeEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid request body", nil, err.Error())
+		return
+	}
+
+	pending, err := ctrl.service.RequestEmailChange(c.Request.Context(), userID, req, c.ClientIP())
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrUserNotFound):
+			response.RespondJSON(c, "error", http.StatusNotFound, err.Error(), nil, nil)
+		case errors.Is(err, ErrInvalidPassword):
+			response.RespondJSON(c, "error", http.StatusUnauthorized, err.Error(), nil, nil)
+		case errors.Is(err, ErrEmailTaken):
+			response.RespondJSON(c, "error", http.StatusConflict, err.Error(), nil, nil)
+		case errors.Is(err, ErrSameEmail):
+			response.RespondJSON(c, "error", http.StatusBadRequest, err.Error(), nil, nil)
+		default:
+			response.RespondJSON(c, "error", http.StatusInternalServerError, "Failed to request email change", nil, err.Error())
+		}
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusAccepted, "Confirmation link sent to the new email address", pending, nil)
+}
+
+// CancelEmailChange withdraws the current user's pending email change
+//
+// @Summary      Cancel an email change
+// @Description  Withdraws the pending email change, so its link stops working.
+// @Description
+// @Description  Refused while impersonating a user.
+// @Tags         Profile
+// @Produce      json
+// @Security     Bearer
+// @Success      200 {object} response.StandardApiResponse "Email change cancelled successfully"
+// @Failure      401 {object} response.StandardApiResponse "User not authenticated"
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "No pending email change"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/users/me/email [delete]
+func (ctrl *Controller) CancelEmailChange(c *gin.Context) {
+	userID, ok := ctrl.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := ctrl.service.CancelEmailChange(c.Request.Context(), userID); err != nil {
+		if errors.Is(err, ErrNoPendingEmailChange) {
+			response.RespondJSON(c, "error", http.StatusNotFound, err.Error(), nil, nil)
+			return
+		}
+		response.RespondJSON(c, "error", http.StatusInternalServerError, "Failed to cancel email change", nil, err.Error())
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Email change cancelled successfully", nil, nil)
+}
+
+// GetEmailChanges lists the current user's email changes, newest first
+//
+// @Summary      List email changes
+// @Description  Every email change the current user asked for, newest first, as an audit trail.
+// @Tags         Profile
+// @Produce      json
+// @Security     Bearer
+// @Success      200 {object} response.StandardApiResponse{data=[]EmailChange} "Email changes retrieved successfully"
+// @Failure      401 {object} response.StandardApiResponse "User not authenticated"
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/users/me/email-changes [get]
+func (ctrl *Controller) GetEmailChanges(c *gin.Context) {
+	userID, ok := ctrl.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	changes, err := ctrl.service.GetEmailChanges(c.Request.Context(), userID)
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusInternalServerError, "Failed to get email changes", nil, err.Error())
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Email changes retrieved successfully", changes, nil)
+}
+
+// VerifyEmailChange confirms a new address from the emailed link, no login needed
+//
+// @Summary      Confirm an email change
+// @Description  Moves the account to the new address with the token from the emailed link. No login is needed. The previous address is told about the change.
+// @Tags         Profile
+// @Accept       json
+// @Produce      json
+// @Param        request body VerifyEmailRequest true "Request body"
+// @Success      200 {object} response.StandardApiResponse{data=EmailChange} "Email address changed successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Link is invalid or has already been used"
+// @Failure      409 {object} response.StandardApiResponse "Email was registered by another account in the meantime"
+// @Failure      410 {object} response.StandardApiResponse "Link has expired"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/users/email/verify [post]
+func (ctrl *Controller) VerifyEmailChange(c *gin.Context) {
+	var req VerifyEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid request body", nil, err.Error())
+		return
+	}
+
+	change, err := ctrl.service.VerifyEmailChange(c.Request.Context(), req.Token)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrInvalidEmailToken), errors.Is(err, ErrUserNotFound):
+			response.RespondJSON(c, "error", http.StatusNotFound, ErrInvalidEmailToken.Error(), nil, nil)
+		case errors.Is(err, ErrEmailChangeExpired):
+			response.RespondJSON(c, "error", http.StatusGone, err.Error(), nil, nil)
+		case errors.Is(err, ErrEmailTaken):
+			response.RespondJSON(c, "error", http.StatusConflict, err.Error(), nil, nil)
+		default:
+			response.RespondJSON(c, "error", http.StatusInternalServerError, "Failed to verify email change", nil, err.Error())
+		}
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Email address changed successfully, sign in with the new address", change, nil)
+}
+
+func (ctrl *Controller) currentUserID(c *gin.Context) (uuid.UUID, bool) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.RespondJSON(c, "error", http.StatusUnauthorized, "User not authenticated", nil, nil)
+		return uuid.Nil, false
+	}
+
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusInternalServerError, "Invalid user ID format", nil, nil)
+		return uuid.Nil, false
+	}
+	return userUUID, true
+}
@@ -0,0 +1,227 @@
+package profiles
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"evently/internal/outbox"
+	"evently/internal/users"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Notification types sent for email changes
+const (
+	notificationEmailChangeVerification = "EMAIL_CHANGE_VERIFICATION"
+	notificationEmailChanged            = "EMAIL_CHANGED"
+)
+
+var (
+	ErrInvalidPassword      = errors.New("password is incorrect")
+	ErrEmailTaken           = errors.New("email is already registered")
+	ErrSameEmail            = errors.New("new email is the same as the current one")
+	ErrNoPendingEmailChange = errors.New("no pending email change")
+	ErrInvalidEmailToken    = errors.New("email change link is invalid or has already been used")
+	ErrEmailChangeExpired   = errors.New("email change link has expired, request the change again")
+)
+
+// Config contains configuration for profile updates
+type Config struct {
+	EmailChangeExpiry time.Duration // How long the link sent to the new address works
+	EmailVerifyURL    string        // Page the link opens, {token} is replaced
+}
+
+// DefaultConfig returns default profile configuration
+func DefaultConfig() *Config {
+	return &Config{
+		EmailChangeExpiry: 24 * time.Hour,
+		EmailVerifyURL:    "http://localhost:3000/account/verify-email?token={token}",
+	}
+}
+
+// RequestEmailChange starts moving the account to another address. The user
+// confirms with their password, and the account keeps signing in with the
+// current address until the link emailed to the new one is followed.
+func (s *service) RequestEmailChange(ctx context.Context, userID uuid.UUID, req ChangeEmailRequest, requestIP string) (*PendingEmailChange, error) {
+	user, err := s.repo.GetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)); err != nil {
+		return nil, ErrInvalidPassword
+	}
+
+	newEmail := strings.TrimSpace(req.NewEmail)
+	if strings.EqualFold(newEmail, user.Email) {
+		return nil, ErrSameEmail
+	}
+	exists, err := s.repo.EmailExists(ctx, newEmail)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check email: %w", err)
+	}
+	if exists {
+		return nil, ErrEmailTaken
+	}
+
+	token, tokenHash, err := generateEmailToken()
+	if err != nil {
+		return nil, err
+	}
+
+	change := &EmailChange{
+		ID:        uuid.New(),
+		UserID:    user.ID,
+		OldEmail:  user.Email,
+		NewEmail:  newEmail,
+		TokenHash: tokenHash,
+		Status:    EmailChangeStatusPending,
+		ExpiresAt: time.Now().Add(s.config.EmailChangeExpiry),
+		RequestIP: requestIP,
+	}
+
+	message, err := s.buildVerificationMessage(user, change, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CreateEmailChange(ctx, change, message); err != nil {
+		return nil, fmt.Errorf("failed to create email change: %w", err)
+	}
+
+	log.Printf("✉️ EMAIL CHANGE: User %s asked to move to a new address, expires %s",
+		user.ID, change.ExpiresAt.Format(time.RFC3339))
+	return &PendingEmailChange{NewEmail: change.NewEmail, ExpiresAt: change.ExpiresAt}, nil
+}
+
+// CancelEmailChange withdraws the user's pending email change
+func (s *service) CancelEmailChange(ctx context.Context, userID uuid.UUID) error {
+	cancelled, err := s.repo.CancelEmailChanges(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to cancel email change: %w", err)
+	}
+	if cancelled == 0 {
+		return ErrNoPendingEmailChange
+	}
+	return nil
+}
+
+// VerifyEmailChange confirms the new address with the token from the emailed
+// link and moves the account to it. The previous address is told about the
+// change in case it wasn't made by its owner.
+func (s *service) VerifyEmailChange(ctx context.Context, token string) (*EmailChange, error) {
+	change, err := s.repo.GetEmailChangeByTokenHash(ctx, hashEmailToken(strings.TrimSpace(token)))
+	if err != nil {
+		return nil, err
+	}
+	if change.Status != EmailChangeStatusPending {
+		return nil, ErrInvalidEmailToken
+	}
+	if !time.Now().Before(change.ExpiresAt) {
+		if err := s.repo.ExpireEmailChange(ctx, change.ID); err != nil {
+			log.Printf("Failed to expire email change %s: %v", change.ID, err)
+		}
+		return nil, ErrEmailChangeExpired
+	}
+
+	// Another account may have registered the address since the request
+	exists, err := s.repo.EmailExists(ctx, change.NewEmail)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check email: %w", err)
+	}
+	if exists {
+		return nil, ErrEmailTaken
+	}
+
+	user, err := s.repo.GetUser(ctx, change.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := s.buildEmailChangedMessage(user, change)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CompleteEmailChange(ctx, change, message); err != nil {
+		if errors.Is(err, ErrInvalidEmailToken) || errors.Is(err, ErrEmailTaken) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to change email: %w", err)
+	}
+
+	log.Printf("✉️ EMAIL CHANGE: User %s moved to their new address", change.UserID)
+	return change, nil
+}
+
+func (s *service) GetEmailChanges(ctx context.Context, userID uuid.UUID) ([]EmailChange, error) {
+	return s.repo.GetEmailChanges(ctx, userID)
+}
+
+// buildVerificationMessage creates the confirmation link sent to the new address
+func (s *service) buildVerificationMessage(user *users.User, change *EmailChange, token string) (*outbox.Message, error) {
+	payload := &outbox.NotificationPayload{
+		Type:           notificationEmailChangeVerification,
+		RecipientID:    user.ID,
+		RecipientEmail: change.NewEmail,
+		RecipientName:  strings.TrimSpace(user.FirstName + " " + user.LastName),
+		TemplateData: map[string]interface{}{
+			"new_email":  change.NewEmail,
+			"verify_url": strings.ReplaceAll(s.config.EmailVerifyURL, "{token}", token),
+			"expires_at": change.ExpiresAt.UTC().Format("Mon, Jan 2, 2006 at 15:04 MST"),
+		},
+	}
+
+	message, err := outbox.NewNotificationMessage(outbox.AggregateUser, user.ID,
+		fmt.Sprintf("email-change:%s:verification", change.ID), payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build email change notification: %w", err)
+	}
+	return message, nil
+}
+
+// buildEmailChangedMessage creates the notice sent to the previous address
+func (s *service) buildEmailChangedMessage(user *users.User, change *EmailChange) (*outbox.Message, error) {
+	payload := &outbox.NotificationPayload{
+		Type:           notificationEmailChanged,
+		RecipientID:    user.ID,
+		RecipientEmail: change.OldEmail,
+		RecipientName:  strings.TrimSpace(user.FirstName + " " + user.LastName),
+		TemplateData: map[string]interface{}{
+			"new_email":  change.NewEmail,
+			"changed_at": time.Now().UTC().Format("Mon, Jan 2, 2006 at 15:04 MST"),
+		},
+	}
+
+	message, err := outbox.NewNotificationMessage(outbox.AggregateUser, user.ID,
+		fmt.Sprintf("email-change:%s:changed", change.ID), payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build email changed notification: %w", err)
+	}
+	return message, nil
+}
+
+// generateEmailToken returns a random token for the emailed link and the hash
+// stored in its place
+func generateEmailToken() (token, hash string, err error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", "", fmt.Errorf("failed to generate email change token: %w", err)
+	}
+	token = base64.RawURLEncoding.EncodeToString(raw)
+	return token, hashEmailToken(token), nil
+}
+
+func hashEmailToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,47 @@
+package profiles
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Email change statuses
+const (
+	EmailChangeStatusPending   = "PENDING"   // Waiting for the new address to be confirmed
+	EmailChangeStatusVerified  = "VERIFIED"  // Confirmed, the account uses the new address
+	EmailChangeStatusCancelled = "CANCELLED" // Withdrawn by the user or replaced by a newer request
+	EmailChangeStatusExpired   = "EXPIRED"   // Not confirmed in time
+)
+
+// EmailChange records a request to move an account to another email address.
+// The account keeps its current address until the link sent to the new one is
+// followed, and every request is kept as an audit trail.
+type EmailChange struct {
+	ID         uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	UserID     uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	OldEmail   string     `gorm:"type:varchar(255);not null" json:"old_email"`
+	NewEmail   string     `gorm:"type:varchar(255);not null" json:"new_email"`
+	TokenHash  string     `gorm:"type:varchar(64);not null;uniqueIndex" json:"-"` // SHA-256 of the emailed token
+	Status     string     `gorm:"type:varchar(20);not null;default:'PENDING';check:status IN ('PENDING', 'VERIFIED', 'CANCELLED', 'EXPIRED')" json:"status"`
+	ExpiresAt  time.Time  `gorm:"not null" json:"expires_at"`
+	VerifiedAt *time.Time `json:"verified_at,omitempty"`
+	RequestIP  string     `gorm:"type:varchar(45)" json:"request_ip,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+func (EmailChange) TableName() string {
+	return "email_changes"
+}
+
+// PreferredTag is a tag a user picked as an interest, used to recommend events
+type PreferredTag struct {
+	UserID    uuid.UUID `gorm:"type:uuid;primaryKey" json:"user_id"`
+	TagID     uuid.UUID `gorm:"type:uuid;primaryKey;index" json:"tag_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (PreferredTag) TableName() string {
+	return "user_preferred_tags"
+}
@@ -0,0 +1,228 @@
+package profiles
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"evently/internal/outbox"
+	"evently/internal/tags"
+	"evently/internal/users"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	GetUser(ctx context.Context, userID uuid.UUID) (*users.User, error)
+	UpdateUser(ctx context.Context, userID uuid.UUID, updates map[string]interface{}) error
+	EmailExists(ctx context.Context, email string) (bool, error)
+
+	// Preferred tags
+	GetPreferredTags(ctx context.Context, userID uuid.UUID) ([]tags.Tag, error)
+	GetActiveTagsBySlugs(ctx context.Context, slugs []string) ([]tags.Tag, error)
+	ReplacePreferredTags(ctx context.Context, userID uuid.UUID, tagIDs []uuid.UUID) error
+
+	// Email changes
+	CreateEmailChange(ctx context.Context, change *EmailChange, messages ...*outbox.Message) error
+	GetPendingEmailChange(ctx context.Context, userID uuid.UUID) (*EmailChange, error)
+	GetEmailChangeByTokenHash(ctx context.Context, tokenHash string) (*EmailChange, error)
+	GetEmailChanges(ctx context.Context, userID uuid.UUID) ([]EmailChange, error)
+	CancelEmailChanges(ctx context.Context, userID uuid.UUID) (int64, error)
+	ExpireEmailChange(ctx context.Context, id uuid.UUID) error
+	CompleteEmailChange(ctx context.Context, change *EmailChange, messages ...*outbox.Message) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) GetUser(ctx context.Context, userID uuid.UUID) (*users.User, error) {
+	var user users.User
+	err := r.db.WithContext(ctx).First(&user, "id = ?", userID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *repository) UpdateUser(ctx context.Context, userID uuid.UUID, updates map[string]interface{}) error {
+	updates["updated_at"] = time.Now()
+	result := r.db.WithContext(ctx).Model(&users.User{}).
+		Where("id = ?", userID).
+		Updates(updates)
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return ErrUserNotFound
+	}
+
+	return nil
+}
+
+func (r *repository) EmailExists(ctx context.Context, email string) (bool, error) {
+	var count int64
+	// Soft-deleted accounts keep their email reserved until purged, as at registration
+	err := r.db.WithContext(ctx).Unscoped().Model(&users.User{}).Where("email = ?", email).Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+//  PREFERRED TAGS
+
+func (r *repository) GetPreferredTags(ctx context.Context, userID uuid.UUID) ([]tags.Tag, error) {
+	var preferred []tags.Tag
+	err := r.db.WithContext(ctx).
+		Joins("JOIN user_preferred_tags upt ON upt.tag_id = tags.id").
+		Where("upt.user_id = ?", userID).
+		Order("tags.name ASC").
+		Find(&preferred).Error
+	return preferred, err
+}
+
+func (r *repository) GetActiveTagsBySlugs(ctx context.Context, slugs []string) ([]tags.Tag, error) {
+	var found []tags.Tag
+	if len(slugs) == 0 {
+		return found, nil
+	}
+	err := r.db.WithContext(ctx).
+		Where("slug IN ? AND is_active = ?", slugs, true).
+		Find(&found).Error
+	return found, err
+}
+
+// ReplacePreferredTags swaps the user's interests for tagIDs, an empty list clears them
+func (r *repository) ReplacePreferredTags(ctx context.Context, userID uuid.UUID, tagIDs []uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&PreferredTag{}).Error; err != nil {
+			return err
+		}
+		if len(tagIDs) == 0 {
+			return nil
+		}
+
+		now := time.Now()
+		rows := make([]PreferredTag, len(tagIDs))
+		for i, tagID := range tagIDs {
+			rows[i] = PreferredTag{UserID: userID, TagID: tagID, CreatedAt: now}
+		}
+		return tx.Create(&rows).Error
+	})
+}
+
+//  EMAIL CHANGES
+
+// CreateEmailChange stores a new request, cancelling any the user still had
+// pending so only the latest link works, and queues the confirmation email
+func (r *repository) CreateEmailChange(ctx context.Context, change *EmailChange, messages ...*outbox.Message) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&EmailChange{}).
+			Where("user_id = ? AND status = ?", change.UserID, EmailChangeStatusPending).
+			Updates(map[string]interface{}{"status": EmailChangeStatusCancelled, "updated_at": time.Now()}).Error
+		if err != nil {
+			return err
+		}
+		if err := tx.Create(change).Error; err != nil {
+			return err
+		}
+		return outbox.Enqueue(tx, messages...)
+	})
+}
+
+func (r *repository) GetPendingEmailChange(ctx context.Context, userID uuid.UUID) (*EmailChange, error) {
+	var change EmailChange
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND status = ? AND expires_at > ?", userID, EmailChangeStatusPending, time.Now()).
+		Order("created_at DESC").
+		First(&change).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNoPendingEmailChange
+		}
+		return nil, err
+	}
+	return &change, nil
+}
+
+func (r *repository) GetEmailChangeByTokenHash(ctx context.Context, tokenHash string) (*EmailChange, error) {
+	var change EmailChange
+	err := r.db.WithContext(ctx).First(&change, "token_hash = ?", tokenHash).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidEmailToken
+		}
+		return nil, err
+	}
+	return &change, nil
+}
+
+// GetEmailChanges lists every email change the user asked for, newest first
+func (r *repository) GetEmailChanges(ctx context.Context, userID uuid.UUID) ([]EmailChange, error) {
+	var changes []EmailChange
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&changes).Error
+	return changes, err
+}
+
+func (r *repository) CancelEmailChanges(ctx context.Context, userID uuid.UUID) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&EmailChange{}).
+		Where("user_id = ? AND status = ?", userID, EmailChangeStatusPending).
+		Updates(map[string]interface{}{"status": EmailChangeStatusCancelled, "updated_at": time.Now()})
+	return result.RowsAffected, result.Error
+}
+
+func (r *repository) ExpireEmailChange(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Model(&EmailChange{}).
+		Where("id = ? AND status = ?", id, EmailChangeStatusPending).
+		Updates(map[string]interface{}{"status": EmailChangeStatusExpired, "updated_at": time.Now()}).Error
+}
+
+// CompleteEmailChange moves the account to the new address and marks the
+// request verified in one transaction. The account must still be on the
+// address the request was made from.
+func (r *repository) CompleteEmailChange(ctx context.Context, change *EmailChange, messages ...*outbox.Message) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&EmailChange{}).
+			Where("id = ? AND status = ?", change.ID, EmailChangeStatusPending).
+			Updates(map[string]interface{}{"status": EmailChangeStatusVerified, "verified_at": now, "updated_at": now})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrInvalidEmailToken
+		}
+
+		result = tx.Model(&users.User{}).
+			Where("id = ? AND email = ?", change.UserID, change.OldEmail).
+			Updates(map[string]interface{}{"email": change.NewEmail, "updated_at": now})
+		if result.Error != nil {
+			if strings.Contains(result.Error.Error(), "duplicate key") {
+				return ErrEmailTaken
+			}
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrInvalidEmailToken
+		}
+
+		change.Status = EmailChangeStatusVerified
+		change.VerifiedAt = &now
+		return outbox.Enqueue(tx, messages...)
+	})
+}
@@ -0,0 +1,26 @@
+package profiles
+
+import "evently/internal/notificationprefs"
+
+// UpdateProfileRequest changes the current user's profile. Omitted fields keep
+// their current value, an empty string clears an optional one.
+type UpdateProfileRequest struct {
+	FirstName               *string                                     `json:"first_name"`
+	LastName                *string                                     `json:"last_name"`
+	Phone                   *string                                     `json:"phone"`          // E.164, e.g. +919876543210
+	AvatarURL               *string                                     `json:"avatar_url"`     // http or https URL
+	City                    *string                                     `json:"city"`           // Events in this city are recommended
+	PreferredTags           *[]string                                   `json:"preferred_tags"` // Tag slugs, replaces the current list
+	NotificationPreferences *notificationprefs.UpdatePreferencesRequest `json:"notification_preferences"`
+}
+
+// ChangeEmailRequest starts moving the account to another email address
+type ChangeEmailRequest struct {
+	NewEmail string `json:"new_email" binding:"required,email,max=255"`
+	Password string `json:"password" binding:"required"` // Current password, required to change the sign-in address
+}
+
+// VerifyEmailRequest confirms a new email address with the token from the emailed link
+type VerifyEmailRequest struct {
+	Token string `json:"token" binding:"required"`
+}
@@ -0,0 +1,32 @@
+package profiles
+
+import (
+	"time"
+
+	"evently/internal/notificationprefs"
+	"evently/internal/tags"
+)
+
+// ProfileResponse is the current user's profile with their preferences
+type ProfileResponse struct {
+	ID                      string                                 `json:"id"`
+	FirstName               string                                 `json:"first_name"`
+	LastName                string                                 `json:"last_name"`
+	Email                   string                                 `json:"email"`
+	Role                    string                                 `json:"role"`
+	Phone                   string                                 `json:"phone,omitempty"`
+	AvatarURL               string                                 `json:"avatar_url,omitempty"`
+	City                    string                                 `json:"city,omitempty"`
+	DisplayCurrency         string                                 `json:"display_currency,omitempty"`
+	PreferredTags           []tags.TagResponse                     `json:"preferred_tags"`
+	PendingEmailChange      *PendingEmailChange                    `json:"pending_email_change,omitempty"` // Set until the new address is confirmed
+	NotificationPreferences *notificationprefs.PreferencesResponse `json:"notification_preferences"`
+	CreatedAt               time.Time                              `json:"created_at"`
+	UpdatedAt               time.Time                              `json:"updated_at"`
+}
+
+// PendingEmailChange is an email change waiting for the new address to be confirmed
+type PendingEmailChange struct {
+	NewEmail  string    `json:"new_email"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
@@ -0,0 +1,22 @@
+package profiles
+
+import (
+	"evently/internal/shared/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupProfileRoutes(rg *gin.RouterGroup, controller *Controller) {
+	// Public route, opened from the link emailed to the new address
+	rg.POST("/users/email/verify", controller.VerifyEmailChange) // POST /api/v1/users/email/verify
+
+	users := rg.Group("/users/me")
+	users.Use(middleware.JWTAuth(), middleware.RequireRoles("USER", "ADMIN"))
+	{
+		users.GET("", controller.GetProfile)                                                 // GET /api/v1/users/me
+		users.PUT("", controller.UpdateProfile)                                              // PUT /api/v1/users/me
+		users.POST("/email", middleware.DenyImpersonation(), controller.RequestEmailChange)  // POST /api/v1/users/me/email
+		users.DELETE("/email", middleware.DenyImpersonation(), controller.CancelEmailChange) // DELETE /api/v1/users/me/email
+		users.GET("/email-changes", controller.GetEmailChanges)                              // GET /api/v1/users/me/email-changes
+	}
+}
@@ -0,0 +1,260 @@
+package profiles
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"evently/internal/notificationprefs"
+	"evently/internal/tags"
+
+	"github.com/google/uuid"
+)
+
+// MaxPreferredTags caps how many interests a user can pick
+const MaxPreferredTags = 20
+
+var (
+	ErrUserNotFound     = errors.New("user not found")
+	ErrInvalidName      = errors.New("first_name and last_name must be 2 to 100 characters")
+	ErrInvalidPhone     = errors.New("phone must be in E.164 format, e.g. +919876543210")
+	ErrInvalidAvatarURL = errors.New("avatar_url must be an http or https URL of at most 500 characters")
+	ErrInvalidCity      = errors.New("city must be at most 100 characters")
+	ErrTooManyTags      = fmt.Errorf("at most %d preferred tags can be chosen", MaxPreferredTags)
+	ErrUnknownTag       = errors.New("unknown or inactive tag")
+)
+
+var e164Pattern = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+
+const (
+	maxAvatarURLLength = 500
+	maxCityLength      = 100
+)
+
+type Service interface {
+	GetProfile(ctx context.Context, userID uuid.UUID) (*ProfileResponse, error)
+	UpdateProfile(ctx context.Context, userID uuid.UUID, req UpdateProfileRequest) (*ProfileResponse, error)
+
+	// Email changes are confirmed from a link sent to the new address
+	RequestEmailChange(ctx context.Context, userID uuid.UUID, req ChangeEmailRequest, requestIP string) (*PendingEmailChange, error)
+	CancelEmailChange(ctx context.Context, userID uuid.UUID) error
+	VerifyEmailChange(ctx context.Context, token string) (*EmailChange, error)
+	GetEmailChanges(ctx context.Context, userID uuid.UUID) ([]EmailChange, error)
+
+	// GetPhone returns the phone on the user's profile, empty when they haven't added one
+	GetPhone(ctx context.Context, userID uuid.UUID) (string, error)
+
+	SetConfig(config *Config)
+}
+
+type service struct {
+	repo        Repository
+	preferences notificationprefs.Service
+	config      *Config
+}
+
+func NewService(repo Repository, preferences notificationprefs.Service) Service {
+	return &service{
+		repo:        repo,
+		preferences: preferences,
+		config:      DefaultConfig(),
+	}
+}
+
+func (s *service) SetConfig(config *Config) {
+	s.config = config
+}
+
+func (s *service) GetProfile(ctx context.Context, userID uuid.UUID) (*ProfileResponse, error) {
+	user, err := s.repo.GetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	preferred, err := s.repo.GetPreferredTags(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get preferred tags: %w", err)
+	}
+
+	preferences, err := s.preferences.GetPreferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	profile := &ProfileResponse{
+		ID:                      user.ID.String(),
+		FirstName:               user.FirstName,
+		LastName:                user.LastName,
+		Email:                   user.Email,
+		Role:                    string(user.Role),
+		Phone:                   user.Phone,
+		AvatarURL:               user.AvatarURL,
+		City:                    user.City,
+		DisplayCurrency:         user.DisplayCurrency,
+		PreferredTags:           make([]tags.TagResponse, len(preferred)),
+		NotificationPreferences: preferences,
+		CreatedAt:               user.CreatedAt,
+		UpdatedAt:               user.UpdatedAt,
+	}
+	for i := range preferred {
+		profile.PreferredTags[i] = preferred[i].ToResponse()
+	}
+
+	pending, err := s.repo.GetPendingEmailChange(ctx, userID)
+	if err == nil {
+		profile.PendingEmailChange = &PendingEmailChange{NewEmail: pending.NewEmail, ExpiresAt: pending.ExpiresAt}
+	} else if !errors.Is(err, ErrNoPendingEmailChange) {
+		return nil, fmt.Errorf("failed to get pending email change: %w", err)
+	}
+
+	return profile, nil
+}
+
+// UpdateProfile validates every field before anything is saved, so a rejected
+// request changes nothing. Notification preferences are forwarded to the
+// notification preference service.
+func (s *service) UpdateProfile(ctx context.Context, userID uuid.UUID, req UpdateProfileRequest) (*ProfileResponse, error) {
+	updates, err := profileUpdates(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var tagIDs []uuid.UUID
+	if req.PreferredTags != nil {
+		if tagIDs, err = s.resolvePreferredTags(ctx, *req.PreferredTags); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := s.repo.GetUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	if req.NotificationPreferences != nil {
+		if _, err := s.preferences.UpdatePreferences(ctx, userID, *req.NotificationPreferences); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(updates) > 0 {
+		if err := s.repo.UpdateUser(ctx, userID, updates); err != nil {
+			return nil, fmt.Errorf("failed to update profile: %w", err)
+		}
+	}
+
+	if req.PreferredTags != nil {
+		if err := s.repo.ReplacePreferredTags(ctx, userID, tagIDs); err != nil {
+			return nil, fmt.Errorf("failed to save preferred tags: %w", err)
+		}
+	}
+
+	return s.GetProfile(ctx, userID)
+}
+
+func (s *service) GetPhone(ctx context.Context, userID uuid.UUID) (string, error) {
+	user, err := s.repo.GetUser(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	return user.Phone, nil
+}
+
+// profileUpdates validates the user columns a profile update changes
+func profileUpdates(req UpdateProfileRequest) (map[string]interface{}, error) {
+	updates := map[string]interface{}{}
+
+	for column, value := range map[string]*string{"first_name": req.FirstName, "last_name": req.LastName} {
+		if value == nil {
+			continue
+		}
+		name := strings.TrimSpace(*value)
+		if length := utf8.RuneCountInString(name); length < 2 || length > 100 {
+			return nil, ErrInvalidName
+		}
+		updates[column] = name
+	}
+
+	if req.Phone != nil {
+		phone := strings.TrimSpace(*req.Phone)
+		if phone != "" && !e164Pattern.MatchString(phone) {
+			return nil, ErrInvalidPhone
+		}
+		updates["phone"] = phone
+	}
+
+	if req.AvatarURL != nil {
+		avatarURL := strings.TrimSpace(*req.AvatarURL)
+		if avatarURL != "" && !isWebURL(avatarURL) {
+			return nil, ErrInvalidAvatarURL
+		}
+		updates["avatar_url"] = avatarURL
+	}
+
+	if req.City != nil {
+		city := strings.Join(strings.Fields(*req.City), " ")
+		if utf8.RuneCountInString(city) > maxCityLength {
+			return nil, ErrInvalidCity
+		}
+		updates["city"] = city
+	}
+
+	return updates, nil
+}
+
+// resolvePreferredTags looks up the tags for a list of slugs, which must all
+// be active tags
+func (s *service) resolvePreferredTags(ctx context.Context, slugs []string) ([]uuid.UUID, error) {
+	seen := make(map[string]bool, len(slugs))
+	normalized := make([]string, 0, len(slugs))
+	for _, slug := range slugs {
+		slug = strings.ToLower(strings.TrimSpace(slug))
+		if slug == "" || seen[slug] {
+			continue
+		}
+		seen[slug] = true
+		normalized = append(normalized, slug)
+	}
+	if len(normalized) > MaxPreferredTags {
+		return nil, ErrTooManyTags
+	}
+
+	found, err := s.repo.GetActiveTagsBySlugs(ctx, normalized)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tags: %w", err)
+	}
+
+	bySlug := make(map[string]uuid.UUID, len(found))
+	for _, tag := range found {
+		bySlug[tag.Slug] = tag.ID
+	}
+
+	var unknown []string
+	tagIDs := make([]uuid.UUID, 0, len(normalized))
+	for _, slug := range normalized {
+		tagID, ok := bySlug[slug]
+		if !ok {
+			unknown = append(unknown, slug)
+			continue
+		}
+		tagIDs = append(tagIDs, tagID)
+	}
+	if len(unknown) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownTag, strings.Join(unknown, ", "))
+	}
+	return tagIDs, nil
+}
+
+func isWebURL(raw string) bool {
+	if len(raw) > maxAvatarURLLength {
+		return false
+	}
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return false
+	}
+	return (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
@@ -0,0 +1,298 @@
+package promotions
+
+import (
+	"net/http"
+	"time"
+
+	"evently/internal/shared/utils/response"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type Controller struct {
+	service Service
+}
+
+func NewController(service Service) *Controller {
+	return &Controller{service: service}
+}
+
+//  ADMIN SLOT MANAGEMENT
+
+// CreateSlot godoc
+//
+// @Summary      Pin a promotion (Admin)
+// @Description  Pin an upcoming published event into one of the event's promotion slots (max 4)
+// @Description
+// @Description  Requires the `events:write` permission.
+// @Tags         Admin Events
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        eventId path string true "Event ID" Format(uuid)
+// @Param        request body CreatePromotionSlotRequest true "Request body"
+// @Success      201 {object} response.StandardApiResponse{data=PromotionSlotResponse} "Promotion slot created successfully"
+// @Failure      400 {object} response.StandardApiResponse "Invalid slot (self-promotion, duplicate, slots full, or event not promotable)"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Event not found"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/events/{eventId}/promotions [post]
+func (ctrl *Controller) CreateSlot(c *gin.Context) {
+	eventID, err := uuid.Parse(c.Param("eventId"))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid event ID", nil, err.Error())
+		return
+	}
+
+	var req CreatePromotionSlotRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid request body", nil, err.Error())
+		return
+	}
+
+	adminID, exists := c.Get("user_id")
+	if !exists {
+		response.RespondJSON(c, "error", http.StatusUnauthorized, "Admin not authenticated", nil, nil)
+		return
+	}
+
+	adminUUID, err := uuid.Parse(adminID.(string))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusInternalServerError, "Invalid admin ID format", nil, nil)
+		return
+	}
+
+	slot, err := ctrl.service.CreateSlot(c.Request.Context(), eventID, adminUUID, req)
+	if err != nil {
+		statusCode := http.StatusBadRequest
+		if err.Error() == "event not found" || err.Error() == "promoted event not found" {
+			statusCode = http.StatusNotFound
+		}
+		response.RespondJSON(c, "error", statusCode, err.Error(), nil, nil)
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusCreated, "Promotion slot created successfully", slot, nil)
+}
+
+// GetSlots godoc
+//
+// @Summary      List pinned promotions (Admin)
+// @Description  List the manually pinned promotion slots for an event
+// @Description
+// @Description  Requires the `events:write` permission.
+// @Tags         Admin Events
+// @Produce      json
+// @Security     Bearer
+// @Param        eventId path string true "Event ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=[]PromotionSlotResponse} "Promotion slots retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/events/{eventId}/promotions [get]
+func (ctrl *Controller) GetSlots(c *gin.Context) {
+	eventID, err := uuid.Parse(c.Param("eventId"))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid event ID", nil, err.Error())
+		return
+	}
+
+	slots, err := ctrl.service.GetSlots(c.Request.Context(), eventID)
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusInternalServerError, err.Error(), nil, nil)
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Promotion slots retrieved successfully", slots, nil)
+}
+
+// UpdateSlot godoc
+//
+// @Summary      Update a pinned promotion (Admin)
+// @Description  Change the position or schedule window of a pinned promotion
+// @Description
+// @Description  Requires the `events:write` permission.
+// @Tags         Admin Events
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        eventId path string true "Event ID" Format(uuid)
+// @Param        slotId path string true "Slot ID" Format(uuid)
+// @Param        request body UpdatePromotionSlotRequest true "Request body"
+// @Success      200 {object} response.StandardApiResponse{data=PromotionSlotResponse} "Promotion slot updated successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Promotion slot not found"
+// @Router       /api/v1/admin/events/{eventId}/promotions/{slotId} [put]
+func (ctrl *Controller) UpdateSlot(c *gin.Context) {
+	eventID, err := uuid.Parse(c.Param("eventId"))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid event ID", nil, err.Error())
+		return
+	}
+
+	slotID, err := uuid.Parse(c.Param("slotId"))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid slot ID", nil, err.Error())
+		return
+	}
+
+	var req UpdatePromotionSlotRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid request body", nil, err.Error())
+		return
+	}
+
+	slot, err := ctrl.service.UpdateSlot(c.Request.Context(), eventID, slotID, req)
+	if err != nil {
+		statusCode := http.StatusBadRequest
+		if err.Error() == "promotion slot not found" {
+			statusCode = http.StatusNotFound
+		}
+		response.RespondJSON(c, "error", statusCode, err.Error(), nil, nil)
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Promotion slot updated successfully", slot, nil)
+}
+
+// DeleteSlot godoc
+//
+// @Summary      Remove a pinned promotion (Admin)
+// @Description  Requires the `events:write` permission.
+// @Tags         Admin Events
+// @Produce      json
+// @Security     Bearer
+// @Param        eventId path string true "Event ID" Format(uuid)
+// @Param        slotId path string true "Slot ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse "Promotion slot deleted successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Promotion slot not found"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/events/{eventId}/promotions/{slotId} [delete]
+func (ctrl *Controller) DeleteSlot(c *gin.Context) {
+	eventID, err := uuid.Parse(c.Param("eventId"))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid event ID", nil, err.Error())
+		return
+	}
+
+	slotID, err := uuid.Parse(c.Param("slotId"))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid slot ID", nil, err.Error())
+		return
+	}
+
+	if err := ctrl.service.DeleteSlot(c.Request.Context(), eventID, slotID); err != nil {
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "promotion slot not found" {
+			statusCode = http.StatusNotFound
+		}
+		response.RespondJSON(c, "error", statusCode, err.Error(), nil, nil)
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Promotion slot deleted successfully", nil, nil)
+}
+
+// GetPromotionAnalytics godoc
+//
+// @Summary      Get promotion performance (Admin)
+// @Description  Impressions, clicks and click-through rate for each event promoted on this event's page
+// @Description
+// @Description  Requires the `events:write` permission.
+// @Tags         Admin Events
+// @Produce      json
+// @Security     Bearer
+// @Param        eventId path string true "Event ID" Format(uuid)
+// @Param        from query string false "Start date (YYYY-MM-DD), defaults to 30 days before `to`" Format(date)
+// @Param        to query string false "End date (YYYY-MM-DD), defaults to today" Format(date)
+// @Success      200 {object} response.StandardApiResponse{data=PromotionAnalyticsResponse} "Promotion analytics retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/events/{eventId}/promotions/analytics [get]
+func (ctrl *Controller) GetPromotionAnalytics(c *gin.Context) {
+	eventID, err := uuid.Parse(c.Param("eventId"))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid event ID", nil, err.Error())
+		return
+	}
+
+	var from, to time.Time
+	if fromStr := c.Query("from"); fromStr != "" {
+		if from, err = time.Parse("2006-01-02", fromStr); err != nil {
+			response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid from date, expected YYYY-MM-DD", nil, err.Error())
+			return
+		}
+	}
+	if toStr := c.Query("to"); toStr != "" {
+		if to, err = time.Parse("2006-01-02", toStr); err != nil {
+			response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid to date, expected YYYY-MM-DD", nil, err.Error())
+			return
+		}
+	}
+
+	result, err := ctrl.service.GetPromotionAnalytics(c.Request.Context(), eventID, from, to)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		switch err.Error() {
+		case "event not found":
+			statusCode = http.StatusNotFound
+		case "from must be before to":
+			statusCode = http.StatusBadRequest
+		}
+		response.RespondJSON(c, "error", statusCode, err.Error(), nil, nil)
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Promotion analytics retrieved successfully", result, nil)
+}
+
+//  PUBLIC
+
+// RecordClick godoc
+//
+// @Summary      Record promotion click
+// @Description  Track a click on a 'you may also like' promotion shown on an event detail page
+// @Tags         Events
+// @Produce      json
+// @Param        eventId path string true "Event ID" Format(uuid)
+// @Param        promotedEventId path string true "Promoted event ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse "Promotion click recorded"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Event is not currently promoted on this page"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/events/{eventId}/promotions/{promotedEventId}/click [post]
+func (ctrl *Controller) RecordClick(c *gin.Context) {
+	eventID, err := uuid.Parse(c.Param("eventId"))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid event ID", nil, err.Error())
+		return
+	}
+
+	promotedEventID, err := uuid.Parse(c.Param("promotedEventId"))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid promoted event ID", nil, err.Error())
+		return
+	}
+
+	if err := ctrl.service.RecordClick(c.Request.Context(), eventID, promotedEventID); err != nil {
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "promotion not found" {
+			statusCode = http.StatusNotFound
+		}
+		response.RespondJSON(c, "error", statusCode, err.Error(), nil, nil)
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Promotion click recorded", nil, nil)
+}
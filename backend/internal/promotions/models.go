@@ -0,0 +1,76 @@
+package promotions
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Slot sources
+const (
+	SlotSourcePinned = "PINNED"
+	SlotSourceAuto   = "AUTO"
+)
+
+// MaxPromotionSlots is the number of "you may also like" slots returned per event
+const MaxPromotionSlots = 4
+
+// PromotionSlot is an admin-pinned cross-promotion attached to an event
+type PromotionSlot struct {
+	ID              uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	EventID         uuid.UUID  `gorm:"type:uuid;not null;index;uniqueIndex:idx_promotion_slot_unique" json:"event_id"`
+	PromotedEventID uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_promotion_slot_unique" json:"promoted_event_id"`
+	Position        int        `gorm:"not null;default:1" json:"position"`
+	CreatedBy       uuid.UUID  `gorm:"type:uuid;not null" json:"created_by"`
+	StartsAt        *time.Time `json:"starts_at,omitempty"`
+	EndsAt          *time.Time `json:"ends_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// PromotionMetric holds daily impression and click counters per source/promoted event pair
+type PromotionMetric struct {
+	ID              uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	EventID         uuid.UUID `gorm:"type:uuid;not null;index;uniqueIndex:idx_promotion_metric_unique" json:"event_id"`
+	PromotedEventID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_promotion_metric_unique" json:"promoted_event_id"`
+	Date            time.Time `gorm:"type:date;not null;uniqueIndex:idx_promotion_metric_unique" json:"date"`
+	Source          string    `gorm:"type:varchar(10);not null;default:'AUTO'" json:"source"`
+	Impressions     int64     `gorm:"not null;default:0" json:"impressions"`
+	Clicks          int64     `gorm:"not null;default:0" json:"clicks"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+func (PromotionSlot) TableName() string {
+	return "promotion_slots"
+}
+
+func (PromotionMetric) TableName() string {
+	return "promotion_metrics"
+}
+
+// IsActive reports whether the pinned slot is inside its optional schedule window
+func (ps *PromotionSlot) IsActive(now time.Time) bool {
+	if ps.StartsAt != nil && now.Before(*ps.StartsAt) {
+		return false
+	}
+	if ps.EndsAt != nil && now.After(*ps.EndsAt) {
+		return false
+	}
+	return true
+}
+
+// ToResponse converts a slot to its API representation
+func (ps *PromotionSlot) ToResponse(promotedName string) PromotionSlotResponse {
+	return PromotionSlotResponse{
+		ID:              ps.ID.String(),
+		EventID:         ps.EventID.String(),
+		PromotedEventID: ps.PromotedEventID.String(),
+		PromotedName:    promotedName,
+		Position:        ps.Position,
+		StartsAt:        ps.StartsAt,
+		EndsAt:          ps.EndsAt,
+		IsActive:        ps.IsActive(time.Now()),
+		CreatedAt:       ps.CreatedAt,
+	}
+}
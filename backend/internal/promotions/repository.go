@@ -0,0 +1,224 @@
+package promotions
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	// Pinned slots
+	CreateSlot(ctx context.Context, slot *PromotionSlot) error
+	GetSlotByID(ctx context.Context, id uuid.UUID) (*PromotionSlot, error)
+	GetSlotsByEventID(ctx context.Context, eventID uuid.UUID) ([]PromotionSlot, error)
+	UpdateSlot(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error
+	DeleteSlot(ctx context.Context, id uuid.UUID) error
+
+	// Event lookups
+	GetEventSummary(ctx context.Context, eventID uuid.UUID) (*EventSummary, error)
+	GetPromotableEvents(ctx context.Context, eventIDs []uuid.UUID) ([]EventSummary, error)
+	GetRecommendedEvents(ctx context.Context, eventID uuid.UUID, exclude []uuid.UUID, limit int) ([]EventSummary, error)
+
+	// Tracking
+	IncrementImpressions(ctx context.Context, eventID uuid.UUID, promoted []PromotedEvent) error
+	IncrementClick(ctx context.Context, eventID, promotedEventID uuid.UUID, source string) error
+	GetPerformance(ctx context.Context, eventID uuid.UUID, from, to time.Time) ([]PromotionPerformance, error)
+}
+
+// EventSummary is the slice of event data needed to render a promotion
+type EventSummary struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Venue     string    `json:"venue"`
+	DateTime  time.Time `json:"date_time"`
+	BasePrice float64   `json:"base_price"`
+	ImageURL  string    `json:"image_url"`
+	Status    string    `json:"status"`
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+//  PINNED SLOTS
+
+func (r *repository) CreateSlot(ctx context.Context, slot *PromotionSlot) error {
+	return r.db.WithContext(ctx).Create(slot).Error
+}
+
+func (r *repository) GetSlotByID(ctx context.Context, id uuid.UUID) (*PromotionSlot, error) {
+	var slot PromotionSlot
+	err := r.db.WithContext(ctx).First(&slot, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &slot, nil
+}
+
+func (r *repository) GetSlotsByEventID(ctx context.Context, eventID uuid.UUID) ([]PromotionSlot, error) {
+	var slots []PromotionSlot
+	err := r.db.WithContext(ctx).
+		Where("event_id = ?", eventID).
+		Order("position ASC, created_at ASC").
+		Find(&slots).Error
+	return slots, err
+}
+
+func (r *repository) UpdateSlot(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
+	return r.db.WithContext(ctx).Model(&PromotionSlot{}).Where("id = ?", id).Updates(updates).Error
+}
+
+func (r *repository) DeleteSlot(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&PromotionSlot{}, "id = ?", id).Error
+}
+
+//  EVENT LOOKUPS
+
+func (r *repository) GetEventSummary(ctx context.Context, eventID uuid.UUID) (*EventSummary, error) {
+	var event EventSummary
+	err := r.db.WithContext(ctx).
+		Table("events").
+		Select("id, name, venue, date_time, base_price, image_url, status").
+		Where("id = ? AND deleted_at IS NULL", eventID).
+		First(&event).Error
+	if err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// GetPromotableEvents returns the subset of the given events that are still published and upcoming
+func (r *repository) GetPromotableEvents(ctx context.Context, eventIDs []uuid.UUID) ([]EventSummary, error) {
+	var events []EventSummary
+	if len(eventIDs) == 0 {
+		return events, nil
+	}
+
+	err := r.db.WithContext(ctx).
+		Table("events").
+		Select("id, name, venue, date_time, base_price, image_url, status").
+		Where("id IN ? AND status = ? AND date_time > ? AND deleted_at IS NULL", eventIDs, "published", time.Now()).
+		Find(&events).Error
+	return events, err
+}
+
+// GetRecommendedEvents ranks upcoming published, listed events by the number of tags they
+// share with the source event, falling back to the soonest upcoming events
+func (r *repository) GetRecommendedEvents(ctx context.Context, eventID uuid.UUID, exclude []uuid.UUID, limit int) ([]EventSummary, error) {
+	var events []EventSummary
+	if limit <= 0 {
+		return events, nil
+	}
+
+	excluded := append([]uuid.UUID{eventID}, exclude...)
+
+	err := r.db.WithContext(ctx).
+		Table("events e").
+		Select("e.id, e.name, e.venue, e.date_time, e.base_price, e.image_url, e.status").
+		Joins("LEFT JOIN event_tags et ON et.event_id = e.id AND et.tag_id IN (SELECT tag_id FROM event_tags WHERE event_id = ?)", eventID).
+		Where("e.id NOT IN ? AND e.status = ? AND e.date_time > ? AND e.unlisted = false AND e.deleted_at IS NULL", excluded, "published", time.Now()).
+		Group("e.id, e.name, e.venue, e.date_time, e.base_price, e.image_url, e.status").
+		Order("COUNT(et.tag_id) DESC, e.date_time ASC").
+		Limit(limit).
+		Scan(&events).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recommended events: %w", err)
+	}
+
+	return events, nil
+}
+
+//  TRACKING
+
+func (r *repository) IncrementImpressions(ctx context.Context, eventID uuid.UUID, promoted []PromotedEvent) error {
+	if len(promoted) == 0 {
+		return nil
+	}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	metrics := make([]PromotionMetric, 0, len(promoted))
+	for _, p := range promoted {
+		promotedID, err := uuid.Parse(p.EventID)
+		if err != nil {
+			continue
+		}
+		metrics = append(metrics, PromotionMetric{
+			EventID:         eventID,
+			PromotedEventID: promotedID,
+			Date:            today,
+			Source:          p.Source,
+			Impressions:     1,
+		})
+	}
+
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "event_id"}, {Name: "promoted_event_id"}, {Name: "date"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"impressions": gorm.Expr("promotion_metrics.impressions + 1"),
+			"source":      gorm.Expr("EXCLUDED.source"),
+			"updated_at":  time.Now(),
+		}),
+	}).Create(&metrics).Error
+}
+
+func (r *repository) IncrementClick(ctx context.Context, eventID, promotedEventID uuid.UUID, source string) error {
+	metric := PromotionMetric{
+		EventID:         eventID,
+		PromotedEventID: promotedEventID,
+		Date:            time.Now().UTC().Truncate(24 * time.Hour),
+		Source:          source,
+		Clicks:          1,
+	}
+
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "event_id"}, {Name: "promoted_event_id"}, {Name: "date"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"clicks":     gorm.Expr("promotion_metrics.clicks + 1"),
+			"updated_at": time.Now(),
+		}),
+	}).Create(&metric).Error
+}
+
+func (r *repository) GetPerformance(ctx context.Context, eventID uuid.UUID, from, to time.Time) ([]PromotionPerformance, error) {
+	var rows []struct {
+		PromotedEventID uuid.UUID
+		PromotedName    string
+		Source          string
+		Impressions     int64
+		Clicks          int64
+	}
+
+	err := r.db.WithContext(ctx).
+		Table("promotion_metrics pm").
+		Select("pm.promoted_event_id, e.name AS promoted_name, MAX(pm.source) AS source, SUM(pm.impressions) AS impressions, SUM(pm.clicks) AS clicks").
+		Joins("JOIN events e ON e.id = pm.promoted_event_id").
+		Where("pm.event_id = ? AND pm.date BETWEEN ? AND ?", eventID, from, to).
+		Group("pm.promoted_event_id, e.name").
+		Order("impressions DESC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get promotion performance: %w", err)
+	}
+
+	performance := make([]PromotionPerformance, len(rows))
+	for i, row := range rows {
+		performance[i] = PromotionPerformance{
+			PromotedEventID:  row.PromotedEventID.String(),
+			PromotedName:     row.PromotedName,
+			Source:           row.Source,
+			Impressions:      row.Impressions,
+			Clicks:           row.Clicks,
+			ClickThroughRate: clickThroughRate(row.Impressions, row.Clicks),
+		}
+	}
+
+	return performance, nil
+}
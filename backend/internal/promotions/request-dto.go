@@ -0,0 +1,16 @@
+package promotions
+
+import "time"
+
+type CreatePromotionSlotRequest struct {
+	PromotedEventID string     `json:"promoted_event_id" binding:"required,uuid"`
+	Position        int        `json:"position" binding:"omitempty,min=1,max=4"`
+	StartsAt        *time.Time `json:"starts_at"`
+	EndsAt          *time.Time `json:"ends_at"`
+}
+
+type UpdatePromotionSlotRequest struct {
+	Position *int       `json:"position" binding:"omitempty,min=1,max=4"`
+	StartsAt *time.Time `json:"starts_at"`
+	EndsAt   *time.Time `json:"ends_at"`
+}
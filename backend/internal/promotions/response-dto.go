@@ -0,0 +1,46 @@
+package promotions
+
+import "time"
+
+// PromotedEvent is a single "you may also like" entry shown on an event detail page
+type PromotedEvent struct {
+	EventID   string    `json:"event_id"`
+	Name      string    `json:"name"`
+	Venue     string    `json:"venue"`
+	DateTime  time.Time `json:"date_time"`
+	BasePrice float64   `json:"base_price"`
+	ImageURL  string    `json:"image_url"`
+	Position  int       `json:"position"`
+	Source    string    `json:"source"` // PINNED or AUTO
+}
+
+type PromotionSlotResponse struct {
+	ID              string     `json:"id"`
+	EventID         string     `json:"event_id"`
+	PromotedEventID string     `json:"promoted_event_id"`
+	PromotedName    string     `json:"promoted_name,omitempty"`
+	Position        int        `json:"position"`
+	StartsAt        *time.Time `json:"starts_at,omitempty"`
+	EndsAt          *time.Time `json:"ends_at,omitempty"`
+	IsActive        bool       `json:"is_active"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+type PromotionPerformance struct {
+	PromotedEventID  string  `json:"promoted_event_id"`
+	PromotedName     string  `json:"promoted_name"`
+	Source           string  `json:"source"`
+	Impressions      int64   `json:"impressions"`
+	Clicks           int64   `json:"clicks"`
+	ClickThroughRate float64 `json:"click_through_rate"`
+}
+
+type PromotionAnalyticsResponse struct {
+	EventID          string                 `json:"event_id"`
+	From             time.Time              `json:"from"`
+	To               time.Time              `json:"to"`
+	TotalImpressions int64                  `json:"total_impressions"`
+	TotalClicks      int64                  `json:"total_clicks"`
+	ClickThroughRate float64                `json:"click_through_rate"`
+	Promotions       []PromotionPerformance `json:"promotions"`
+}
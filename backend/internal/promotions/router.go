@@ -0,0 +1,26 @@
+package promotions
+
+import (
+	"evently/internal/shared/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupPromotionRoutes(rg *gin.RouterGroup, controller *Controller) {
+	// Public click tracking - promotions are rendered on the public event detail page
+	publicEvents := rg.Group("/events")
+	{
+		publicEvents.POST("/:eventId/promotions/:promotedEventId/click", controller.RecordClick) // POST /api/v1/events/:eventId/promotions/:promotedEventId/click
+	}
+
+	// Admin slot management
+	adminEvents := rg.Group("/admin/events")
+	adminEvents.Use(middleware.JWTAuth(), middleware.RequirePermission(middleware.PermissionEventsWrite))
+	{
+		adminEvents.GET("/:eventId/promotions", controller.GetSlots)                        // GET /api/v1/admin/events/:eventId/promotions
+		adminEvents.POST("/:eventId/promotions", controller.CreateSlot)                     // POST /api/v1/admin/events/:eventId/promotions
+		adminEvents.PUT("/:eventId/promotions/:slotId", controller.UpdateSlot)              // PUT /api/v1/admin/events/:eventId/promotions/:slotId
+		adminEvents.DELETE("/:eventId/promotions/:slotId", controller.DeleteSlot)           // DELETE /api/v1/admin/events/:eventId/promotions/:slotId
+		adminEvents.GET("/:eventId/promotions/analytics", controller.GetPromotionAnalytics) // GET /api/v1/admin/events/:eventId/promotions/analytics
+	}
+}
@@ -0,0 +1,393 @@
+package promotions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"evently/internal/shared/utils/constants"
+	"evently/pkg/cache"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Service interface {
+	SetCacheService(cacheService cache.Service)
+
+	// Admin slot management
+	CreateSlot(ctx context.Context, eventID, adminID uuid.UUID, req CreatePromotionSlotRequest) (*PromotionSlotResponse, error)
+	GetSlots(ctx context.Context, eventID uuid.UUID) ([]PromotionSlotResponse, error)
+	UpdateSlot(ctx context.Context, eventID, slotID uuid.UUID, req UpdatePromotionSlotRequest) (*PromotionSlotResponse, error)
+	DeleteSlot(ctx context.Context, eventID, slotID uuid.UUID) error
+
+	// Public promotion surface
+	GetPromotionsForEvent(ctx context.Context, eventID uuid.UUID) ([]PromotedEvent, error)
+	RecordClick(ctx context.Context, eventID, promotedEventID uuid.UUID) error
+
+	// Analytics
+	GetPromotionAnalytics(ctx context.Context, eventID uuid.UUID, from, to time.Time) (*PromotionAnalyticsResponse, error)
+}
+
+type service struct {
+	repo         Repository
+	cacheService cache.Service
+}
+
+func NewService(repo Repository) Service {
+	return &service{repo: repo}
+}
+
+// SetCacheService injects the cache service dependency
+func (s *service) SetCacheService(cacheService cache.Service) {
+	s.cacheService = cacheService
+}
+
+func (s *service) invalidatePromotionCache(ctx context.Context, eventID uuid.UUID) {
+	if s.cacheService == nil {
+		return
+	}
+	if err := s.cacheService.Delete(ctx, constants.BuildEventPromotionsKey(eventID.String())); err != nil {
+		log.Printf("Warning: failed to invalidate promotion cache for event %s: %v", eventID, err)
+	}
+}
+
+//  ADMIN SLOT MANAGEMENT
+
+func (s *service) CreateSlot(ctx context.Context, eventID, adminID uuid.UUID, req CreatePromotionSlotRequest) (*PromotionSlotResponse, error) {
+	promotedEventID, err := uuid.Parse(req.PromotedEventID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid promoted event ID: %w", err)
+	}
+
+	if promotedEventID == eventID {
+		return nil, errors.New("an event cannot promote itself")
+	}
+
+	if req.StartsAt != nil && req.EndsAt != nil && !req.EndsAt.After(*req.StartsAt) {
+		return nil, errors.New("ends_at must be after starts_at")
+	}
+
+	if _, err := s.repo.GetEventSummary(ctx, eventID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("event not found")
+		}
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	promoted, err := s.repo.GetEventSummary(ctx, promotedEventID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("promoted event not found")
+		}
+		return nil, fmt.Errorf("failed to get promoted event: %w", err)
+	}
+	if promoted.Status != "published" || !promoted.DateTime.After(time.Now()) {
+		return nil, errors.New("only upcoming published events can be promoted")
+	}
+
+	existing, err := s.repo.GetSlotsByEventID(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get promotion slots: %w", err)
+	}
+	if len(existing) >= MaxPromotionSlots {
+		return nil, fmt.Errorf("event already has the maximum of %d pinned promotions", MaxPromotionSlots)
+	}
+	for _, slot := range existing {
+		if slot.PromotedEventID == promotedEventID {
+			return nil, errors.New("event is already pinned as a promotion")
+		}
+	}
+
+	position := req.Position
+	if position == 0 {
+		position = len(existing) + 1
+	}
+
+	slot := &PromotionSlot{
+		ID:              uuid.New(),
+		EventID:         eventID,
+		PromotedEventID: promotedEventID,
+		Position:        position,
+		CreatedBy:       adminID,
+		StartsAt:        req.StartsAt,
+		EndsAt:          req.EndsAt,
+	}
+
+	if err := s.repo.CreateSlot(ctx, slot); err != nil {
+		return nil, fmt.Errorf("failed to create promotion slot: %w", err)
+	}
+
+	s.invalidatePromotionCache(ctx, eventID)
+
+	response := slot.ToResponse(promoted.Name)
+	return &response, nil
+}
+
+func (s *service) GetSlots(ctx context.Context, eventID uuid.UUID) ([]PromotionSlotResponse, error) {
+	slots, err := s.repo.GetSlotsByEventID(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get promotion slots: %w", err)
+	}
+
+	responses := make([]PromotionSlotResponse, len(slots))
+	for i, slot := range slots {
+		name := ""
+		if promoted, err := s.repo.GetEventSummary(ctx, slot.PromotedEventID); err == nil {
+			name = promoted.Name
+		}
+		responses[i] = slot.ToResponse(name)
+	}
+
+	return responses, nil
+}
+
+func (s *service) UpdateSlot(ctx context.Context, eventID, slotID uuid.UUID, req UpdatePromotionSlotRequest) (*PromotionSlotResponse, error) {
+	slot, err := s.getEventSlot(ctx, eventID, slotID)
+	if err != nil {
+		return nil, err
+	}
+
+	startsAt, endsAt := slot.StartsAt, slot.EndsAt
+	if req.StartsAt != nil {
+		startsAt = req.StartsAt
+	}
+	if req.EndsAt != nil {
+		endsAt = req.EndsAt
+	}
+	if startsAt != nil && endsAt != nil && !endsAt.After(*startsAt) {
+		return nil, errors.New("ends_at must be after starts_at")
+	}
+
+	updates := make(map[string]interface{})
+	if req.Position != nil {
+		updates["position"] = *req.Position
+	}
+	if req.StartsAt != nil {
+		updates["starts_at"] = *req.StartsAt
+	}
+	if req.EndsAt != nil {
+		updates["ends_at"] = *req.EndsAt
+	}
+
+	if len(updates) > 0 {
+		if err := s.repo.UpdateSlot(ctx, slotID, updates); err != nil {
+			return nil, fmt.Errorf("failed to update promotion slot: %w", err)
+		}
+		s.invalidatePromotionCache(ctx, eventID)
+	}
+
+	updated, err := s.repo.GetSlotByID(ctx, slotID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get promotion slot: %w", err)
+	}
+
+	name := ""
+	if promoted, err := s.repo.GetEventSummary(ctx, updated.PromotedEventID); err == nil {
+		name = promoted.Name
+	}
+
+	response := updated.ToResponse(name)
+	return &response, nil
+}
+
+func (s *service) DeleteSlot(ctx context.Context, eventID, slotID uuid.UUID) error {
+	if _, err := s.getEventSlot(ctx, eventID, slotID); err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteSlot(ctx, slotID); err != nil {
+		return fmt.Errorf("failed to delete promotion slot: %w", err)
+	}
+
+	s.invalidatePromotionCache(ctx, eventID)
+	return nil
+}
+
+func (s *service) getEventSlot(ctx context.Context, eventID, slotID uuid.UUID) (*PromotionSlot, error) {
+	slot, err := s.repo.GetSlotByID(ctx, slotID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("promotion slot not found")
+		}
+		return nil, fmt.Errorf("failed to get promotion slot: %w", err)
+	}
+	if slot.EventID != eventID {
+		return nil, errors.New("promotion slot not found")
+	}
+	return slot, nil
+}
+
+//  PUBLIC PROMOTION SURFACE
+
+// GetPromotionsForEvent returns pinned promotions first, then fills the remaining
+// slots from tag-based recommendations. Every call counts as an impression.
+func (s *service) GetPromotionsForEvent(ctx context.Context, eventID uuid.UUID) ([]PromotedEvent, error) {
+	promoted, err := s.resolvePromotions(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Impression tracking must never slow down or fail the event detail page
+	go func(items []PromotedEvent) {
+		if err := s.repo.IncrementImpressions(context.Background(), eventID, items); err != nil {
+			log.Printf("Warning: failed to record promotion impressions for event %s: %v", eventID, err)
+		}
+	}(promoted)
+
+	return promoted, nil
+}
+
+func (s *service) resolvePromotions(ctx context.Context, eventID uuid.UUID) ([]PromotedEvent, error) {
+	cacheKey := constants.BuildEventPromotionsKey(eventID.String())
+
+	if s.cacheService != nil {
+		var cached []PromotedEvent
+		if err := s.cacheService.Get(ctx, cacheKey, &cached); err == nil {
+			return cached, nil
+		}
+	}
+
+	slots, err := s.repo.GetSlotsByEventID(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get promotion slots: %w", err)
+	}
+
+	now := time.Now()
+	var activeSlots []PromotionSlot
+	var pinnedIDs []uuid.UUID
+	for _, slot := range slots {
+		if slot.IsActive(now) {
+			activeSlots = append(activeSlots, slot)
+			pinnedIDs = append(pinnedIDs, slot.PromotedEventID)
+		}
+	}
+
+	pinnedEvents, err := s.repo.GetPromotableEvents(ctx, pinnedIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pinned events: %w", err)
+	}
+	pinnedByID := make(map[uuid.UUID]EventSummary, len(pinnedEvents))
+	for _, event := range pinnedEvents {
+		pinnedByID[event.ID] = event
+	}
+
+	sort.SliceStable(activeSlots, func(i, j int) bool {
+		return activeSlots[i].Position < activeSlots[j].Position
+	})
+
+	promoted := make([]PromotedEvent, 0, MaxPromotionSlots)
+	for _, slot := range activeSlots {
+		if len(promoted) >= MaxPromotionSlots {
+			break
+		}
+		event, ok := pinnedByID[slot.PromotedEventID]
+		if !ok {
+			// Pinned event was cancelled or has already taken place
+			continue
+		}
+		promoted = append(promoted, toPromotedEvent(event, len(promoted)+1, SlotSourcePinned))
+	}
+
+	if remaining := MaxPromotionSlots - len(promoted); remaining > 0 {
+		recommended, err := s.repo.GetRecommendedEvents(ctx, eventID, pinnedIDs, remaining)
+		if err != nil {
+			log.Printf("Warning: failed to auto-fill promotions for event %s: %v", eventID, err)
+		}
+		for _, event := range recommended {
+			promoted = append(promoted, toPromotedEvent(event, len(promoted)+1, SlotSourceAuto))
+		}
+	}
+
+	if s.cacheService != nil {
+		if err := s.cacheService.Set(ctx, cacheKey, promoted, constants.TTL_EVENT_PROMOTIONS); err != nil {
+			log.Printf("Warning: failed to cache promotions for event %s: %v", eventID, err)
+		}
+	}
+
+	return promoted, nil
+}
+
+func (s *service) RecordClick(ctx context.Context, eventID, promotedEventID uuid.UUID) error {
+	promoted, err := s.resolvePromotions(ctx, eventID)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range promoted {
+		if p.EventID == promotedEventID.String() {
+			if err := s.repo.IncrementClick(ctx, eventID, promotedEventID, p.Source); err != nil {
+				return fmt.Errorf("failed to record promotion click: %w", err)
+			}
+			return nil
+		}
+	}
+
+	return errors.New("promotion not found")
+}
+
+//  ANALYTICS
+
+func (s *service) GetPromotionAnalytics(ctx context.Context, eventID uuid.UUID, from, to time.Time) (*PromotionAnalyticsResponse, error) {
+	if _, err := s.repo.GetEventSummary(ctx, eventID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("event not found")
+		}
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	if to.IsZero() {
+		to = time.Now().UTC()
+	}
+	if from.IsZero() {
+		from = to.AddDate(0, 0, -30)
+	}
+	if from.After(to) {
+		return nil, errors.New("from must be before to")
+	}
+
+	performance, err := s.repo.GetPerformance(ctx, eventID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &PromotionAnalyticsResponse{
+		EventID:    eventID.String(),
+		From:       from,
+		To:         to,
+		Promotions: performance,
+	}
+	for _, p := range performance {
+		result.TotalImpressions += p.Impressions
+		result.TotalClicks += p.Clicks
+	}
+	result.ClickThroughRate = clickThroughRate(result.TotalImpressions, result.TotalClicks)
+
+	return result, nil
+}
+
+//  HELPERS
+
+func toPromotedEvent(event EventSummary, position int, source string) PromotedEvent {
+	return PromotedEvent{
+		EventID:   event.ID.String(),
+		Name:      event.Name,
+		Venue:     event.Venue,
+		DateTime:  event.DateTime,
+		BasePrice: event.BasePrice,
+		ImageURL:  event.ImageURL,
+		Position:  position,
+		Source:    source,
+	}
+}
+
+// clickThroughRate returns clicks per impression as a percentage
+func clickThroughRate(impressions, clicks int64) float64 {
+	if impressions == 0 {
+		return 0
+	}
+	return float64(clicks) / float64(impressions) * 100
+}
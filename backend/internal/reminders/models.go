@@ -0,0 +1,35 @@
+package reminders
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Reminder records that an attendee was reminded about an event at one offset.
+// Reminders are keyed by attendee rather than event date, so moving an event
+// does not send a reminder that already went out again.
+type Reminder struct {
+	ID            uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	EventID       uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_event_reminder_attendee" json:"event_id"`
+	UserID        uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_event_reminder_attendee" json:"user_id"`
+	OffsetMinutes int       `gorm:"not null;uniqueIndex:idx_event_reminder_attendee" json:"offset_minutes"` // How long before the event it was due
+	BookingID     uuid.UUID `gorm:"type:uuid;not null" json:"booking_id"`
+	EventDateTime time.Time `gorm:"not null" json:"event_date_time"` // The event time the reminder was sent for
+	SentAt        time.Time `gorm:"not null" json:"sent_at"`
+}
+
+func (Reminder) TableName() string {
+	return "event_reminders"
+}
+
+// Attendee is a confirmed attendee due a reminder. Attendees with several
+// bookings for the event are reminded once, about their earliest booking.
+type Attendee struct {
+	BookingID  uuid.UUID
+	BookingRef string
+	UserID     uuid.UUID
+	EventID    uuid.UUID
+	Venue      string
+	DateTime   time.Time
+}
@@ -0,0 +1,76 @@
+package reminders
+
+import (
+	"context"
+	"time"
+
+	"evently/internal/outbox"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	GetDueAttendees(ctx context.Context, offset time.Duration, now time.Time, limit int) ([]Attendee, error)
+	MarkSent(ctx context.Context, reminders []Reminder, messages []*outbox.Message) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+// GetDueAttendees returns confirmed attendees of published events starting within
+// offset who have not had this reminder or a later one. Attendees who booked
+// after the reminder was due are left out, their confirmation is recent enough.
+func (r *repository) GetDueAttendees(ctx context.Context, offset time.Duration, now time.Time, limit int) ([]Attendee, error) {
+	minutes := int(offset / time.Minute)
+
+	var attendees []Attendee
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT * FROM (
+			SELECT DISTINCT ON (b.event_id, b.user_id)
+				b.id AS booking_id, b.booking_ref, b.user_id, b.event_id, e.venue, e.date_time
+			FROM bookings b
+			JOIN events e ON e.id = b.event_id
+			WHERE b.status = 'CONFIRMED'
+				AND e.status IN ('published', 'sold_out')
+				AND e.deleted_at IS NULL
+				AND e.date_time > ?
+				AND e.date_time <= ?
+				AND b.created_at <= e.date_time - make_interval(mins => ?)
+				AND NOT EXISTS (
+					SELECT 1 FROM event_reminders r
+					WHERE r.event_id = b.event_id AND r.user_id = b.user_id AND r.offset_minutes <= ?
+				)
+			ORDER BY b.event_id, b.user_id, b.created_at
+		) due
+		ORDER BY date_time ASC
+		LIMIT ?`,
+		now, now.Add(offset), minutes, minutes, limit,
+	).Scan(&attendees).Error
+	return attendees, err
+}
+
+// MarkSent records the reminders and queues their notifications in one
+// transaction. Reminders already recorded by another replica are skipped, and
+// their notifications are dropped by the outbox dedup key.
+func (r *repository) MarkSent(ctx context.Context, reminders []Reminder, messages []*outbox.Message) error {
+	if len(reminders) == 0 {
+		return nil
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "event_id"}, {Name: "user_id"}, {Name: "offset_minutes"}},
+			DoNothing: true,
+		}).Create(&reminders).Error
+		if err != nil {
+			return err
+		}
+		return outbox.Enqueue(tx, messages...)
+	})
+}
@@ -0,0 +1,66 @@
+package reminders
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// SendJob queues event reminders as they fall due
+type SendJob struct {
+	service Service
+	config  *Config
+	done    chan struct{}
+}
+
+// NewSendJob creates a new event reminder job
+func NewSendJob(service Service, config *Config) *SendJob {
+	if config == nil {
+		config = DefaultConfig()
+	}
+
+	return &SendJob{
+		service: service,
+		config:  config,
+		done:    make(chan struct{}),
+	}
+}
+
+// Start starts the event reminder job
+func (j *SendJob) Start(ctx context.Context) {
+	log.Printf("Started event reminder job with %v interval, reminding %v before events", j.config.CheckInterval, j.config.Offsets)
+	go j.run(ctx)
+}
+
+// Stop stops the event reminder job
+func (j *SendJob) Stop() {
+	close(j.done)
+}
+
+func (j *SendJob) run(ctx context.Context) {
+	ticker := time.NewTicker(j.config.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			j.send(ctx)
+		case <-j.done:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (j *SendJob) send(ctx context.Context) {
+	queued, err := j.service.SendDueReminders(ctx)
+	if err != nil {
+		log.Printf("Failed to send event reminders: %v", err)
+		return
+	}
+
+	if queued > 0 {
+		log.Printf("Queued %d event reminders", queued)
+	}
+}
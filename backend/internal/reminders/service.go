@@ -0,0 +1,151 @@
+package reminders
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"evently/internal/outbox"
+
+	"github.com/google/uuid"
+)
+
+const NotificationTypeEventReminder = "EVENT_REMINDER"
+
+// Config contains configuration for event reminders
+type Config struct {
+	Offsets       []time.Duration // How long before an event reminders go out
+	CheckInterval time.Duration
+	BatchSize     int    // Attendees reminded per offset per run
+	BookingURL    string // Link to the booking, {booking_id} is replaced
+}
+
+// DefaultConfig returns default event reminder configuration
+func DefaultConfig() *Config {
+	return &Config{
+		Offsets:       []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, 2 * time.Hour},
+		CheckInterval: time.Minute, // Look for due reminders every minute
+		BatchSize:     500,
+		BookingURL:    "http://localhost:3000/bookings/{booking_id}",
+	}
+}
+
+func (c *Config) bookingURL(bookingID uuid.UUID) string {
+	return strings.ReplaceAll(c.BookingURL, "{booking_id}", bookingID.String())
+}
+
+type Service interface {
+	// SendDueReminders queues reminders for attendees whose reminder time has passed
+	SendDueReminders(ctx context.Context) (int, error)
+}
+
+type service struct {
+	repo    Repository
+	config  *Config
+	offsets []time.Duration // Config offsets, shortest first
+}
+
+func NewService(repo Repository, config *Config) Service {
+	if config == nil {
+		config = DefaultConfig()
+	}
+	return &service{repo: repo, config: config, offsets: sortedOffsets(config.Offsets)}
+}
+
+// SendDueReminders works from the shortest offset up. An attendee who is due
+// several reminders at once, because they booked late or the event was moved
+// earlier, only gets the closest one, and never one further out than a
+// reminder they already had.
+func (s *service) SendDueReminders(ctx context.Context) (int, error) {
+	now := time.Now()
+
+	sent := 0
+	for _, offset := range s.offsets {
+		attendees, err := s.repo.GetDueAttendees(ctx, offset, now, s.config.BatchSize)
+		if err != nil {
+			return sent, fmt.Errorf("failed to get attendees due a %v reminder: %w", offset, err)
+		}
+		if len(attendees) == 0 {
+			continue
+		}
+
+		reminders := make([]Reminder, 0, len(attendees))
+		messages := make([]*outbox.Message, 0, len(attendees))
+		for _, attendee := range attendees {
+			message, err := s.buildMessage(attendee, offset)
+			if err != nil {
+				return sent, err
+			}
+			messages = append(messages, message)
+			reminders = append(reminders, Reminder{
+				EventID:       attendee.EventID,
+				UserID:        attendee.UserID,
+				OffsetMinutes: int(offset / time.Minute),
+				BookingID:     attendee.BookingID,
+				EventDateTime: attendee.DateTime,
+				SentAt:        now,
+			})
+		}
+
+		if err := s.repo.MarkSent(ctx, reminders, messages); err != nil {
+			return sent, fmt.Errorf("failed to queue %v reminders: %w", offset, err)
+		}
+
+		log.Printf("⏰ Queued %d event reminders %v before the event", len(messages), offset)
+		sent += len(messages)
+	}
+	return sent, nil
+}
+
+func (s *service) buildMessage(attendee Attendee, offset time.Duration) (*outbox.Message, error) {
+	eventID, bookingID := attendee.EventID, attendee.BookingID
+	payload := &outbox.NotificationPayload{
+		Type:        NotificationTypeEventReminder,
+		RecipientID: attendee.UserID,
+		EventID:     &eventID,
+		BookingID:   &bookingID,
+		TemplateData: map[string]interface{}{
+			"event_date_time": attendee.DateTime.UTC().Format("Mon, Jan 2, 2006 3:04 PM MST"),
+			"venue":           attendee.Venue,
+			"booking_ref":     attendee.BookingRef,
+			"starts_in":       startsIn(offset),
+			"action_url":      s.config.bookingURL(attendee.BookingID),
+		},
+	}
+
+	dedupKey := fmt.Sprintf("event-reminder:%s:%s:%dm", attendee.EventID, attendee.UserID, int(offset/time.Minute))
+	return outbox.NewNotificationMessage(outbox.AggregateEventReminder, attendee.BookingID, dedupKey, payload)
+}
+
+// sortedOffsets drops offsets under a minute and duplicates, shortest first
+func sortedOffsets(offsets []time.Duration) []time.Duration {
+	seen := make(map[time.Duration]bool, len(offsets))
+	sorted := make([]time.Duration, 0, len(offsets))
+	for _, offset := range offsets {
+		offset = offset.Truncate(time.Minute)
+		if offset <= 0 || seen[offset] {
+			continue
+		}
+		seen[offset] = true
+		sorted = append(sorted, offset)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted
+}
+
+// startsIn describes an offset for the reminder text, e.g. "in 7 days"
+func startsIn(offset time.Duration) string {
+	switch {
+	case offset >= 48*time.Hour && offset%(24*time.Hour) == 0:
+		return fmt.Sprintf("in %d days", offset/(24*time.Hour))
+	case offset == time.Hour:
+		return "in 1 hour"
+	case offset > time.Hour && offset%time.Hour == 0:
+		return fmt.Sprintf("in %d hours", offset/time.Hour)
+	default:
+		return fmt.Sprintf("in %d minutes", offset/time.Minute)
+	}
+}
@@ -0,0 +1,382 @@
+package resale
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"evently/internal/bookings"
+	"evently/internal/shared/utils/response"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type Controller struct {
+	service Service
+}
+
+func NewController(service Service) *Controller {
+	return &Controller{service: service}
+}
+
+//  BUYERS
+
+// GetListings godoc
+//
+// @Summary      Browse resale listings
+// @Description  Listings open for purchase, cheapest first
+// @Tags         Resale
+// @Produce      json
+// @Param        event_id query string false "Event ID" Format(uuid)
+// @Param        page query integer false "Page" default(1) minimum(1)
+// @Param        limit query integer false "Limit" default(20) minimum(1) maximum(100)
+// @Success      200 {object} response.StandardApiResponse{data=PaginatedListings} "Listings retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/resale/listings [get]
+func (ctrl *Controller) GetListings(c *gin.Context) {
+	var query ListingQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid query parameters", nil, err.Error())
+		return
+	}
+
+	listings, err := ctrl.service.GetListings(c.Request.Context(), query)
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusInternalServerError, "Failed to get listings", nil, err.Error())
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Listings retrieved successfully", listings, nil)
+}
+
+// GetListing godoc
+//
+// @Summary      Get resale listing
+// @Tags         Resale
+// @Produce      json
+// @Param        listingId path string true "Listing ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=ResaleListing} "Listing retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Listing not found"
+// @Router       /api/v1/resale/listings/{listingId} [get]
+func (ctrl *Controller) GetListing(c *gin.Context) {
+	listingID, err := uuid.Parse(c.Param("listingId"))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid listing ID", nil, err.Error())
+		return
+	}
+
+	listing, err := ctrl.service.GetListing(c.Request.Context(), listingID)
+	if err != nil {
+		respondError(c, err, "Failed to get listing")
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Listing retrieved successfully", listing, nil)
+}
+
+// PurchaseListing godoc
+//
+// @Summary      Buy resale listing
+// @Description  Pay for the listed seats and take ownership of them. The seats are reissued under a new
+// @Description  booking reference, and the seller is paid the price minus the resale fee.
+// @Tags         Resale
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        listingId path string true "Listing ID" Format(uuid)
+// @Param        request body PurchaseListingRequest true "Request body"
+// @Success      200 {object} response.StandardApiResponse{data=PurchaseResponse} "Purchase complete"
+// @Failure      400 {object} response.StandardApiResponse "Buying your own listing"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      402 {object} response.StandardApiResponse "Payment was declined"
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Listing not found"
+// @Failure      409 {object} response.StandardApiResponse "Listing is no longer for sale or the seats can no longer be resold"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/resale/listings/{listingId}/purchase [post]
+func (ctrl *Controller) PurchaseListing(c *gin.Context) {
+	listingID, err := uuid.Parse(c.Param("listingId"))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid listing ID", nil, err.Error())
+		return
+	}
+
+	userID, ok := ctrl.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req PurchaseListingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid request body", nil, err.Error())
+		return
+	}
+
+	purchase, err := ctrl.service.PurchaseListing(c.Request.Context(), listingID, userID, req)
+	if err != nil {
+		respondError(c, err, "Failed to purchase listing")
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Purchase complete, the tickets are now yours", purchase, nil)
+}
+
+// GetUserResale godoc
+//
+// @Summary      Get user resale activity
+// @Description  The user's listings and purchases, newest first
+// @Tags         Resale
+// @Produce      json
+// @Security     Bearer
+// @Success      200 {object} response.StandardApiResponse{data=UserResale} "Resale activity retrieved successfully"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/users/resale [get]
+func (ctrl *Controller) GetUserResale(c *gin.Context) {
+	userID, ok := ctrl.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	resale, err := ctrl.service.GetUserResale(c.Request.Context(), userID)
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusInternalServerError, "Failed to get resale activity", nil, err.Error())
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Resale activity retrieved successfully", resale, nil)
+}
+
+//  SELLERS
+
+// CreateListing godoc
+//
+// @Summary      List seats for resale
+// @Description  Put a confirmed booking, or some of its seats, up for resale. The price can't exceed what
+// @Description  was paid for the seats. Listings close a configurable time before the event starts, or at
+// @Description  the event's cancellation deadline when that comes first.
+// @Tags         Resale
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        request body CreateListingRequest true "Request body"
+// @Success      201 {object} response.StandardApiResponse{data=ResaleListing} "Seats listed for resale"
+// @Failure      400 {object} response.StandardApiResponse "Invalid request or price above face value"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse "Booking belongs to another user"
+// @Failure      404 {object} response.StandardApiResponse "Booking not found"
+// @Failure      409 {object} response.StandardApiResponse "Booking can't be resold, is already listed, or resale has closed for the event"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/resale/listings [post]
+func (ctrl *Controller) CreateListing(c *gin.Context) {
+	userID, ok := ctrl.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req CreateListingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid request body", nil, err.Error())
+		return
+	}
+
+	listing, err := ctrl.service.CreateListing(c.Request.Context(), userID, req)
+	if err != nil {
+		respondError(c, err, "Failed to create listing")
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusCreated, "Seats listed for resale", listing, nil)
+}
+
+// CancelListing godoc
+//
+// @Summary      Cancel resale listing
+// @Description  Withdraw a listing that hasn't sold
+// @Tags         Resale
+// @Produce      json
+// @Security     Bearer
+// @Param        listingId path string true "Listing ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=ResaleListing} "Listing cancelled"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse "Listing belongs to another user"
+// @Failure      404 {object} response.StandardApiResponse "Listing not found"
+// @Failure      409 {object} response.StandardApiResponse "Listing is no longer for sale"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/resale/listings/{listingId} [delete]
+func (ctrl *Controller) CancelListing(c *gin.Context) {
+	listingID, err := uuid.Parse(c.Param("listingId"))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid listing ID", nil, err.Error())
+		return
+	}
+
+	userID, ok := ctrl.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	listing, err := ctrl.service.CancelListing(c.Request.Context(), listingID, userID)
+	if err != nil {
+		respondError(c, err, "Failed to cancel listing")
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Listing cancelled", listing, nil)
+}
+
+//  ADMIN
+
+// ListSales godoc
+//
+// @Summary      List resale sales (Admin)
+// @Description  Completed resales with the payouts owed to sellers, newest first
+// @Description
+// @Description  Requires the `bookings:manage` permission.
+// @Tags         Admin Resale
+// @Produce      json
+// @Security     Bearer
+// @Param        payout_status query string false "Payout status" Enums(PENDING, PAID)
+// @Param        event_id query string false "Event ID" Format(uuid)
+// @Param        page query integer false "Page" default(1) minimum(1)
+// @Param        limit query integer false "Limit" default(20) minimum(1) maximum(100)
+// @Success      200 {object} response.StandardApiResponse{data=PaginatedSales} "Sales retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/resale/sales [get]
+func (ctrl *Controller) ListSales(c *gin.Context) {
+	var query AdminSaleQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid query parameters", nil, err.Error())
+		return
+	}
+
+	sales, err := ctrl.service.ListSales(c.Request.Context(), query)
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusInternalServerError, "Failed to get sales", nil, err.Error())
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Sales retrieved successfully", sales, nil)
+}
+
+// MarkPayoutPaid godoc
+//
+// @Summary      Record seller payout (Admin)
+// @Description  Mark the seller's share of a sale as paid out
+// @Description
+// @Description  Requires the `bookings:manage` permission.
+// @Tags         Admin Resale
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        saleId path string true "Sale ID" Format(uuid)
+// @Param        request body MarkPayoutRequest true "Request body"
+// @Success      200 {object} response.StandardApiResponse{data=ResaleSale} "Payout recorded"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Sale not found"
+// @Failure      409 {object} response.StandardApiResponse "Payout has already been paid"
+// @Router       /api/v1/admin/resale/sales/{saleId}/payout [post]
+func (ctrl *Controller) MarkPayoutPaid(c *gin.Context) {
+	saleID, err := uuid.Parse(c.Param("saleId"))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid sale ID", nil, err.Error())
+		return
+	}
+
+	var req MarkPayoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid request body", nil, err.Error())
+		return
+	}
+
+	sale, err := ctrl.service.MarkPayoutPaid(c.Request.Context(), saleID, req)
+	if err != nil {
+		respondError(c, err, "Failed to record payout")
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Payout recorded", sale, nil)
+}
+
+func (ctrl *Controller) currentUserID(c *gin.Context) (uuid.UUID, bool) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.RespondJSON(c, "error", http.StatusUnauthorized, "User not authenticated", nil, nil)
+		return uuid.Nil, false
+	}
+
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusInternalServerError, "Invalid user ID format", nil, nil)
+		return uuid.Nil, false
+	}
+	return userUUID, true
+}
+
+// respondError answers a failed resale operation with its status and error code
+func respondError(c *gin.Context, err error, message string) {
+	response.RespondError(c, errorStatus(err), errorCode(err), message, err.Error())
+}
+
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrListingNotFound), errors.Is(err, ErrSaleNotFound),
+		strings.Contains(err.Error(), "booking not found"):
+		return http.StatusNotFound
+	case errors.Is(err, ErrListingForbidden), strings.HasPrefix(err.Error(), "unauthorized"):
+		return http.StatusForbidden
+	case errors.Is(err, ErrPaymentDeclined):
+		return http.StatusPaymentRequired
+	case errors.Is(err, ErrListingClosed), errors.Is(err, ErrListingUnavailable), errors.Is(err, ErrAlreadyListed),
+		errors.Is(err, ErrResaleClosed), errors.Is(err, ErrPayoutAlreadyPaid), errors.Is(err, bookings.ErrBookingNotTransferable),
+		strings.Contains(err.Error(), "modified by another process"), strings.Contains(err.Error(), "no longer part of this booking"):
+		return http.StatusConflict
+	case errors.Is(err, ErrPriceAboveFace), errors.Is(err, ErrOwnListing), strings.HasPrefix(err.Error(), "invalid"):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func errorCode(err error) string {
+	switch {
+	case errors.Is(err, ErrListingNotFound):
+		return response.CodeListingNotFound
+	case errors.Is(err, ErrSaleNotFound):
+		return response.CodeSaleNotFound
+	case errors.Is(err, ErrPaymentDeclined):
+		return response.CodePaymentDeclined
+	case errors.Is(err, ErrListingClosed):
+		return response.CodeListingClosed
+	case errors.Is(err, ErrListingUnavailable):
+		return response.CodeListingUnavailable
+	case errors.Is(err, ErrAlreadyListed):
+		return response.CodeAlreadyListed
+	case errors.Is(err, ErrResaleClosed):
+		return response.CodeResaleClosed
+	case errors.Is(err, ErrPayoutAlreadyPaid):
+		return response.CodePayoutAlreadyPaid
+	case errors.Is(err, ErrPriceAboveFace):
+		return response.CodePriceAboveFace
+	case errors.Is(err, ErrOwnListing):
+		return response.CodeOwnListing
+	case errors.Is(err, bookings.ErrBookingNotTransferable):
+		return response.CodeBookingNotTransferable
+	case strings.Contains(err.Error(), "booking not found"):
+		return response.CodeBookingNotFound
+	case strings.Contains(err.Error(), "modified by another process"):
+		return response.CodeBookingModified
+	default:
+		return response.StatusErrorCode(errorStatus(err))
+	}
+}
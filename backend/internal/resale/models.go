@@ -0,0 +1,78 @@
+package resale
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Listing statuses
+const (
+	ListingStatusActive    = "ACTIVE"    // Open for purchase
+	ListingStatusSold      = "SOLD"      // Bought, the seats belong to the buyer
+	ListingStatusCancelled = "CANCELLED" // Withdrawn by the seller, or the booking can no longer be resold
+	ListingStatusExpired   = "EXPIRED"   // Not sold before the resale cutoff
+)
+
+// Payout statuses
+const (
+	PayoutStatusPending = "PENDING" // Owed to the seller
+	PayoutStatusPaid    = "PAID"    // Sent to the seller
+)
+
+// ResaleListing offers seats of a confirmed booking for resale, at no more than
+// their face value
+type ResaleListing struct {
+	ID              uuid.UUID   `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	BookingID       uuid.UUID   `gorm:"type:uuid;not null;index;uniqueIndex:idx_resale_listings_one_active,where:status = 'ACTIVE'" json:"booking_id"`
+	EventID         uuid.UUID   `gorm:"type:uuid;not null;index:idx_resale_listings_event_status" json:"event_id"`
+	SellerID        uuid.UUID   `gorm:"type:uuid;not null;index" json:"seller_id"`
+	SeatIDs         []uuid.UUID `gorm:"type:jsonb;serializer:json;not null" json:"seat_ids"` // Empty lists the whole booking
+	SeatCount       int         `gorm:"not null" json:"seat_count"`
+	FaceValue       float64     `gorm:"not null" json:"face_value"` // What the seller paid for the seats, the price cap
+	Price           float64     `gorm:"not null" json:"price"`
+	Currency        string      `gorm:"type:varchar(3);not null" json:"currency"`
+	Status          string      `gorm:"type:varchar(20);check:status IN ('ACTIVE', 'SOLD', 'CANCELLED', 'EXPIRED');not null;default:'ACTIVE';index:idx_resale_listings_event_status" json:"status"`
+	ExpiresAt       time.Time   `gorm:"not null" json:"expires_at"` // Resale cutoff for the event
+	BuyerID         *uuid.UUID  `gorm:"type:uuid" json:"buyer_id,omitempty"`
+	SoldAt          *time.Time  `json:"sold_at,omitempty"`
+	ResultBookingID *uuid.UUID  `gorm:"type:uuid" json:"result_booking_id,omitempty"` // Booking the buyer holds
+	CreatedAt       time.Time   `json:"created_at"`
+	UpdatedAt       time.Time   `json:"updated_at"`
+}
+
+// ResaleSale records a purchase: what the buyer paid, the fee kept and the
+// payout owed to the seller
+type ResaleSale struct {
+	ID                uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	ListingID         uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex" json:"listing_id"`
+	EventID           uuid.UUID  `gorm:"type:uuid;not null;index" json:"event_id"`
+	SellerID          uuid.UUID  `gorm:"type:uuid;not null;index" json:"seller_id"`
+	BuyerID           uuid.UUID  `gorm:"type:uuid;not null;index" json:"buyer_id"`
+	BookingID         uuid.UUID  `gorm:"type:uuid;not null" json:"booking_id"`          // Booking the buyer holds
+	OwnershipChangeID uuid.UUID  `gorm:"type:uuid;not null" json:"ownership_change_id"` // Entry in the booking ownership audit trail
+	Price             float64    `gorm:"not null" json:"price"`
+	Fee               float64    `gorm:"not null" json:"fee"`
+	SellerPayout      float64    `gorm:"not null" json:"seller_payout"`
+	Currency          string     `gorm:"type:varchar(3);not null" json:"currency"`
+	PaymentMethod     string     `gorm:"type:varchar(50)" json:"payment_method"`
+	TransactionID     string     `gorm:"type:varchar(100);not null;unique" json:"transaction_id"`
+	PayoutStatus      string     `gorm:"type:varchar(20);check:payout_status IN ('PENDING', 'PAID');not null;default:'PENDING';index" json:"payout_status"`
+	PayoutReference   string     `gorm:"type:varchar(100)" json:"payout_reference,omitempty"`
+	PaidOutAt         *time.Time `json:"paid_out_at,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+func (ResaleListing) TableName() string {
+	return "resale_listings"
+}
+
+func (ResaleSale) TableName() string {
+	return "resale_sales"
+}
+
+// IsActive reports whether the listing can still be bought
+func (l *ResaleListing) IsActive() bool {
+	return l.Status == ListingStatusActive && l.ExpiresAt.After(time.Now())
+}
@@ -0,0 +1,289 @@
+package resale
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"evently/internal/bookings"
+	"evently/internal/outbox"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	// Listings
+	CreateListing(ctx context.Context, listing *ResaleListing) error
+	GetListing(ctx context.Context, id uuid.UUID) (*ResaleListing, error)
+	ListActiveListings(ctx context.Context, filter ListingFilter) ([]ResaleListing, int64, error)
+	GetListingsBySellerID(ctx context.Context, sellerID uuid.UUID) ([]ResaleListing, error)
+	CloseListing(ctx context.Context, id uuid.UUID, status string, at time.Time) (bool, error)
+	ExpireListings(ctx context.Context, now time.Time) (int64, error)
+
+	// Sales
+	CompleteSale(ctx context.Context, completion *SaleCompletion, messages ...*outbox.Message) error
+	GetSale(ctx context.Context, id uuid.UUID) (*ResaleSale, error)
+	GetSalesByBuyerID(ctx context.Context, buyerID uuid.UUID) ([]ResaleSale, error)
+	ListSales(ctx context.Context, filter SaleFilter) ([]ResaleSale, int64, error)
+	MarkPayoutPaid(ctx context.Context, id uuid.UUID, reference string, at time.Time) (bool, error)
+
+	// Cutoff lookups
+	GetCancellationPolicy(ctx context.Context, eventID uuid.UUID) (*CancellationPolicy, error)
+}
+
+// ListingFilter narrows listing searches; zero values are ignored
+type ListingFilter struct {
+	EventID *uuid.UUID
+	Limit   int
+	Offset  int
+}
+
+// SaleFilter narrows sale listings; zero values are ignored
+type SaleFilter struct {
+	EventID      *uuid.UUID
+	PayoutStatus string
+	Limit        int
+	Offset       int
+}
+
+// SaleCompletion is everything a purchase writes: the sold listing, the sale
+// and the ownership move of the seats to the buyer
+type SaleCompletion struct {
+	Listing *ResaleListing
+	Sale    *ResaleSale
+	Move    *bookings.OwnershipMove
+}
+
+// CancellationPolicy is the slice of an event's cancellation policy that
+// decides when resale closes
+type CancellationPolicy struct {
+	AllowCancellation    bool
+	CancellationDeadline time.Time
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+//  LISTINGS
+
+func (r *repository) CreateListing(ctx context.Context, listing *ResaleListing) error {
+	return r.db.WithContext(ctx).Create(listing).Error
+}
+
+func (r *repository) GetListing(ctx context.Context, id uuid.UUID) (*ResaleListing, error) {
+	var listing ResaleListing
+	err := r.db.WithContext(ctx).First(&listing, "id = ?", id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrListingNotFound
+		}
+		return nil, fmt.Errorf("failed to get listing: %w", err)
+	}
+	return &listing, nil
+}
+
+// ListActiveListings returns open listings whose booking still belongs to the
+// seller and is confirmed, cheapest first
+func (r *repository) ListActiveListings(ctx context.Context, filter ListingFilter) ([]ResaleListing, int64, error) {
+	activeListings := func() *gorm.DB {
+		query := r.db.WithContext(ctx).
+			Table("resale_listings rl").
+			Joins("JOIN bookings b ON b.id = rl.booking_id AND b.user_id = rl.seller_id AND b.status = 'CONFIRMED'").
+			Where("rl.status = ? AND rl.expires_at > ?", ListingStatusActive, time.Now())
+		if filter.EventID != nil {
+			query = query.Where("rl.event_id = ?", *filter.EventID)
+		}
+		return query
+	}
+
+	var total int64
+	if err := activeListings().Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count listings: %w", err)
+	}
+
+	var listings []ResaleListing
+	err := activeListings().
+		Select("rl.*").
+		Order("rl.price ASC, rl.created_at ASC").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Find(&listings).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list listings: %w", err)
+	}
+	return listings, total, nil
+}
+
+func (r *repository) GetListingsBySellerID(ctx context.Context, sellerID uuid.UUID) ([]ResaleListing, error) {
+	var listings []ResaleListing
+	err := r.db.WithContext(ctx).
+		Where("seller_id = ?", sellerID).
+		Order("created_at DESC").
+		Find(&listings).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get listings: %w", err)
+	}
+	return listings, nil
+}
+
+// CloseListing withdraws an active listing. It reports false when the listing
+// was no longer active.
+func (r *repository) CloseListing(ctx context.Context, id uuid.UUID, status string, at time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&ResaleListing{}).
+		Where("id = ? AND status = ?", id, ListingStatusActive).
+		Updates(map[string]interface{}{
+			"status":     status,
+			"updated_at": at,
+		})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to update listing: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// ExpireListings closes active listings past the resale cutoff
+func (r *repository) ExpireListings(ctx context.Context, now time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Model(&ResaleListing{}).
+		Where("status = ? AND expires_at <= ?", ListingStatusActive, now).
+		Updates(map[string]interface{}{
+			"status":     ListingStatusExpired,
+			"updated_at": now,
+		})
+	return result.RowsAffected, result.Error
+}
+
+//  SALES
+
+// CompleteSale marks the listing sold, moves the seats to the buyer and
+// records the sale in one transaction. The listing must still be active and
+// before its cutoff.
+func (r *repository) CompleteSale(ctx context.Context, completion *SaleCompletion, messages ...*outbox.Message) error {
+	listing, sale := completion.Listing, completion.Sale
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&ResaleListing{}).
+			Where("id = ? AND status = ? AND expires_at > ?", listing.ID, ListingStatusActive, now).
+			Updates(map[string]interface{}{
+				"status":            listing.Status,
+				"buyer_id":          listing.BuyerID,
+				"sold_at":           listing.SoldAt,
+				"result_booking_id": listing.ResultBookingID,
+				"updated_at":        now,
+			})
+		if result.Error != nil {
+			return fmt.Errorf("failed to update listing: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return ErrListingClosed
+		}
+
+		if err := bookings.ApplyOwnershipMove(tx, completion.Move); err != nil {
+			return err
+		}
+
+		if err := tx.Create(sale).Error; err != nil {
+			return fmt.Errorf("failed to record sale: %w", err)
+		}
+		return outbox.Enqueue(tx, messages...)
+	})
+}
+
+func (r *repository) GetSale(ctx context.Context, id uuid.UUID) (*ResaleSale, error) {
+	var sale ResaleSale
+	err := r.db.WithContext(ctx).First(&sale, "id = ?", id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrSaleNotFound
+		}
+		return nil, fmt.Errorf("failed to get sale: %w", err)
+	}
+	return &sale, nil
+}
+
+func (r *repository) GetSalesByBuyerID(ctx context.Context, buyerID uuid.UUID) ([]ResaleSale, error) {
+	var sales []ResaleSale
+	err := r.db.WithContext(ctx).
+		Where("buyer_id = ?", buyerID).
+		Order("created_at DESC").
+		Find(&sales).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get purchases: %w", err)
+	}
+	return sales, nil
+}
+
+func (r *repository) ListSales(ctx context.Context, filter SaleFilter) ([]ResaleSale, int64, error) {
+	applyFilter := func(query *gorm.DB) *gorm.DB {
+		if filter.EventID != nil {
+			query = query.Where("event_id = ?", *filter.EventID)
+		}
+		if filter.PayoutStatus != "" {
+			query = query.Where("payout_status = ?", filter.PayoutStatus)
+		}
+		return query
+	}
+
+	var total int64
+	if err := applyFilter(r.db.WithContext(ctx).Model(&ResaleSale{})).Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count sales: %w", err)
+	}
+
+	var sales []ResaleSale
+	err := applyFilter(r.db.WithContext(ctx).Model(&ResaleSale{})).
+		Order("created_at DESC").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Find(&sales).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list sales: %w", err)
+	}
+	return sales, total, nil
+}
+
+// MarkPayoutPaid records a pending payout as sent. It reports false when the
+// payout was already paid.
+func (r *repository) MarkPayoutPaid(ctx context.Context, id uuid.UUID, reference string, at time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&ResaleSale{}).
+		Where("id = ? AND payout_status = ?", id, PayoutStatusPending).
+		Updates(map[string]interface{}{
+			"payout_status":    PayoutStatusPaid,
+			"payout_reference": reference,
+			"paid_out_at":      at,
+			"updated_at":       at,
+		})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to update payout: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+//  CUTOFF LOOKUPS
+
+// GetCancellationPolicy returns the event's cancellation policy, or nil when
+// it has none
+func (r *repository) GetCancellationPolicy(ctx context.Context, eventID uuid.UUID) (*CancellationPolicy, error) {
+	var policies []CancellationPolicy
+	err := r.db.WithContext(ctx).
+		Table("cancellation_policies").
+		Select("allow_cancellation, cancellation_deadline").
+		Where("event_id = ?", eventID).
+		Limit(1).
+		Find(&policies).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cancellation policy: %w", err)
+	}
+	if len(policies) == 0 {
+		return nil, nil
+	}
+	return &policies[0], nil
+}
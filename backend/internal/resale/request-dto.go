@@ -0,0 +1,31 @@
+package resale
+
+// List seats of a confirmed booking for resale. Without seat IDs the whole
+// booking is listed. The price can't exceed what the seller paid.
+type CreateListingRequest struct {
+	BookingID string   `json:"booking_id" binding:"required,uuid"`
+	SeatIDs   []string `json:"seat_ids"`
+	Price     float64  `json:"price" binding:"required,gt=0"`
+}
+
+type PurchaseListingRequest struct {
+	PaymentMethod string `json:"payment_method" binding:"required"`
+}
+
+// MarkPayoutRequest records that a seller's payout was sent
+type MarkPayoutRequest struct {
+	Reference string `json:"reference" binding:"required,max=100"`
+}
+
+type ListingQuery struct {
+	EventID string `form:"event_id" binding:"omitempty,uuid"`
+	Page    int    `form:"page,default=1" binding:"min=1"`
+	Limit   int    `form:"limit,default=20" binding:"min=1,max=100"`
+}
+
+type AdminSaleQuery struct {
+	PayoutStatus string `form:"payout_status" binding:"omitempty,oneof=PENDING PAID"`
+	EventID      string `form:"event_id" binding:"omitempty,uuid"`
+	Page         int    `form:"page,default=1" binding:"min=1"`
+	Limit        int    `form:"limit,default=20" binding:"min=1,max=100"`
+}
@@ -0,0 +1,40 @@
+package resale
+
+import (
+	"evently/internal/bookings"
+	"evently/internal/shared/utils/response"
+)
+
+type PaginatedListings struct {
+	Listings   []ResaleListing `json:"listings"`
+	TotalCount int64           `json:"total_count"`
+	Page       int             `json:"page"`
+	Limit      int             `json:"limit"`
+}
+
+func (p PaginatedListings) PaginationMeta() response.Pagination {
+	return response.NewPagination(p.Page, p.Limit, p.TotalCount)
+}
+
+type PaginatedSales struct {
+	Sales      []ResaleSale `json:"sales"`
+	TotalCount int64        `json:"total_count"`
+	Page       int          `json:"page"`
+	Limit      int          `json:"limit"`
+}
+
+func (p PaginatedSales) PaginationMeta() response.Pagination {
+	return response.NewPagination(p.Page, p.Limit, p.TotalCount)
+}
+
+// PurchaseResponse is a completed purchase and the booking the buyer now holds
+type PurchaseResponse struct {
+	Sale    *ResaleSale       `json:"sale"`
+	Booking *bookings.Booking `json:"booking"`
+}
+
+// UserResale lists what a user has put up for resale and what they bought
+type UserResale struct {
+	Listings  []ResaleListing `json:"listings"`
+	Purchases []ResaleSale    `json:"purchases"`
+}
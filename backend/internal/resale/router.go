@@ -0,0 +1,39 @@
+package resale
+
+import (
+	"evently/internal/shared/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupResaleRoutes(rg *gin.RouterGroup, controller *Controller) {
+	// Public marketplace
+	publicResale := rg.Group("/resale")
+	{
+		publicResale.GET("/listings", controller.GetListings)           // GET /api/v1/resale/listings
+		publicResale.GET("/listings/:listingId", controller.GetListing) // GET /api/v1/resale/listings/:listingId
+	}
+
+	// Sellers and buyers - ownership, the price cap and the cutoff are enforced by the service
+	userResale := rg.Group("/resale")
+	userResale.Use(middleware.JWTAuth(), middleware.RequireRoles("USER", "ADMIN"))
+	{
+		userResale.POST("/listings", controller.CreateListing)                       // POST /api/v1/resale/listings
+		userResale.DELETE("/listings/:listingId", controller.CancelListing)          // DELETE /api/v1/resale/listings/:listingId
+		userResale.POST("/listings/:listingId/purchase", controller.PurchaseListing) // POST /api/v1/resale/listings/:listingId/purchase
+	}
+
+	users := rg.Group("/users")
+	users.Use(middleware.JWTAuth(), middleware.RequireRoles("USER", "ADMIN"))
+	{
+		users.GET("/resale", controller.GetUserResale) // GET /api/v1/users/resale
+	}
+
+	// Admin payouts
+	adminResale := rg.Group("/admin/resale")
+	adminResale.Use(middleware.JWTAuth(), middleware.RequirePermission(middleware.PermissionBookingsManage))
+	{
+		adminResale.GET("/sales", controller.ListSales)                      // GET /api/v1/admin/resale/sales
+		adminResale.POST("/sales/:saleId/payout", controller.MarkPayoutPaid) // POST /api/v1/admin/resale/sales/:saleId/payout
+	}
+}
@@ -0,0 +1,473 @@
+package resale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"time"
+
+	"evently/internal/bookings"
+	"evently/internal/outbox"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrListingNotFound    = errors.New("listing not found")
+	ErrListingForbidden   = errors.New("listing does not belong to user")
+	ErrListingClosed      = errors.New("listing is no longer for sale")
+	ErrListingUnavailable = errors.New("listed seats can no longer be resold")
+	ErrPriceAboveFace     = errors.New("price is above face value")
+	ErrResaleClosed       = errors.New("resale has closed for this event")
+	ErrAlreadyListed      = errors.New("booking is already listed for resale")
+	ErrOwnListing         = errors.New("you can't buy your own listing")
+	ErrPaymentDeclined    = errors.New("payment was declined")
+	ErrSaleNotFound       = errors.New("sale not found")
+	ErrPayoutAlreadyPaid  = errors.New("payout has already been paid")
+)
+
+// Config contains configuration for the resale marketplace
+type Config struct {
+	FeePercent float64       // Share of the sale price kept from the seller's payout
+	Cutoff     time.Duration // Listings close this long before the event starts
+}
+
+// DefaultConfig returns default resale configuration
+func DefaultConfig() *Config {
+	return &Config{
+		FeePercent: 10,            // Keep 10% of every sale
+		Cutoff:     2 * time.Hour, // Stop resale 2 hours before the doors open
+	}
+}
+
+// BookingService is the slice of the booking service resale needs to check
+// listed seats and move them to the buyer
+type BookingService interface {
+	GetBooking(ctx context.Context, bookingID uuid.UUID) (*bookings.Booking, error)
+	CheckTransferableSeats(ctx context.Context, bookingID, ownerID uuid.UUID, seatIDs []string) (*bookings.TransferableSeats, error)
+	PrepareOwnershipMove(ctx context.Context, bookingID, fromUserID, toUserID uuid.UUID, seatIDs []uuid.UUID, reason string) (*bookings.OwnershipMove, error)
+}
+
+type Service interface {
+	SetConfig(config *Config)
+	SetPaymentGateway(gateway bookings.PaymentGateway)
+
+	// Sellers
+	CreateListing(ctx context.Context, sellerID uuid.UUID, req CreateListingRequest) (*ResaleListing, error)
+	CancelListing(ctx context.Context, listingID, sellerID uuid.UUID) (*ResaleListing, error)
+
+	// Buyers
+	GetListings(ctx context.Context, query ListingQuery) (*PaginatedListings, error)
+	GetListing(ctx context.Context, listingID uuid.UUID) (*ResaleListing, error)
+	PurchaseListing(ctx context.Context, listingID, buyerID uuid.UUID, req PurchaseListingRequest) (*PurchaseResponse, error)
+	GetUserResale(ctx context.Context, userID uuid.UUID) (*UserResale, error)
+
+	// Admin payouts
+	ListSales(ctx context.Context, query AdminSaleQuery) (*PaginatedSales, error)
+	MarkPayoutPaid(ctx context.Context, saleID uuid.UUID, req MarkPayoutRequest) (*ResaleSale, error)
+}
+
+type service struct {
+	repo           Repository
+	bookingService BookingService
+	paymentGateway bookings.PaymentGateway
+	config         *Config
+}
+
+func NewService(repo Repository, bookingService BookingService) Service {
+	return &service{
+		repo:           repo,
+		bookingService: bookingService,
+		paymentGateway: bookings.MockPaymentGateway{},
+		config:         DefaultConfig(),
+	}
+}
+
+func (s *service) SetConfig(config *Config) {
+	s.config = config
+}
+
+func (s *service) SetPaymentGateway(gateway bookings.PaymentGateway) {
+	s.paymentGateway = gateway
+}
+
+//  SELLERS
+
+// CreateListing puts seats of the seller's confirmed booking up for resale.
+// The price is capped at what the seller paid, and the listing closes at the
+// event's resale cutoff.
+func (s *service) CreateListing(ctx context.Context, sellerID uuid.UUID, req CreateListingRequest) (*ResaleListing, error) {
+	s.expireListings(ctx)
+
+	bookingID, err := uuid.Parse(req.BookingID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid booking ID: %w", err)
+	}
+
+	seats, err := s.bookingService.CheckTransferableSeats(ctx, bookingID, sellerID, req.SeatIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	price := roundAmount(req.Price)
+	if price > seats.FaceValue {
+		return nil, fmt.Errorf("%w: the most you can ask is %.2f %s", ErrPriceAboveFace, seats.FaceValue, seats.Booking.Currency)
+	}
+
+	closesAt, err := s.resaleCutoff(ctx, seats.Booking.EventID, seats.EventStart)
+	if err != nil {
+		return nil, err
+	}
+
+	listing := &ResaleListing{
+		ID:        uuid.New(),
+		BookingID: bookingID,
+		EventID:   seats.Booking.EventID,
+		SellerID:  sellerID,
+		SeatIDs:   seats.SeatIDs,
+		SeatCount: seats.SeatCount,
+		FaceValue: seats.FaceValue,
+		Price:     price,
+		Currency:  seats.Booking.Currency,
+		Status:    ListingStatusActive,
+		ExpiresAt: closesAt,
+	}
+
+	if err := s.repo.CreateListing(ctx, listing); err != nil {
+		if strings.Contains(err.Error(), "duplicate key") {
+			return nil, ErrAlreadyListed
+		}
+		return nil, fmt.Errorf("failed to create listing: %w", err)
+	}
+
+	log.Printf("🏷️ RESALE: Booking %s listed by user %s (%d seats at %.2f %s), closes %s",
+		bookingID, sellerID, listing.SeatCount, listing.Price, listing.Currency, closesAt.Format(time.RFC3339))
+	return listing, nil
+}
+
+// CancelListing lets the seller withdraw a listing that hasn't sold
+func (s *service) CancelListing(ctx context.Context, listingID, sellerID uuid.UUID) (*ResaleListing, error) {
+	listing, err := s.getActiveListing(ctx, listingID)
+	if err != nil {
+		return nil, err
+	}
+	if listing.SellerID != sellerID {
+		return nil, ErrListingForbidden
+	}
+	return s.closeListing(ctx, listing, ListingStatusCancelled)
+}
+
+//  BUYERS
+
+// GetListings returns the listings open for purchase, cheapest first
+func (s *service) GetListings(ctx context.Context, query ListingQuery) (*PaginatedListings, error) {
+	s.expireListings(ctx)
+
+	filter := ListingFilter{
+		Limit:  query.Limit,
+		Offset: (query.Page - 1) * query.Limit,
+	}
+	if query.EventID != "" {
+		eventID, err := uuid.Parse(query.EventID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid event ID: %w", err)
+		}
+		filter.EventID = &eventID
+	}
+
+	listings, total, err := s.repo.ListActiveListings(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PaginatedListings{
+		Listings:   listings,
+		TotalCount: total,
+		Page:       query.Page,
+		Limit:      query.Limit,
+	}, nil
+}
+
+func (s *service) GetListing(ctx context.Context, listingID uuid.UUID) (*ResaleListing, error) {
+	s.expireListings(ctx)
+	return s.repo.GetListing(ctx, listingID)
+}
+
+// PurchaseListing charges the buyer and moves the listed seats to them. The
+// seats are reissued under a new booking reference, the seller's payout is the
+// price minus the resale fee, and the payment is voided if the seats can't be
+// moved after all.
+func (s *service) PurchaseListing(ctx context.Context, listingID, buyerID uuid.UUID, req PurchaseListingRequest) (*PurchaseResponse, error) {
+	listing, err := s.getActiveListing(ctx, listingID)
+	if err != nil {
+		return nil, err
+	}
+	if listing.SellerID == buyerID {
+		return nil, ErrOwnListing
+	}
+
+	move, err := s.bookingService.PrepareOwnershipMove(ctx, listing.BookingID, listing.SellerID, buyerID,
+		listing.SeatIDs, bookings.OwnershipChangeResale)
+	if err != nil {
+		// The seller cancelled, transferred or checked in, so the listing is stale
+		if errors.Is(err, bookings.ErrBookingNotTransferable) || strings.HasPrefix(err.Error(), "unauthorized") {
+			if _, closeErr := s.closeListing(ctx, listing, ListingStatusCancelled); closeErr != nil {
+				log.Printf("⚠️ RESALE: Failed to withdraw stale listing %s: %v", listing.ID, closeErr)
+			}
+			return nil, fmt.Errorf("%w: %v", ErrListingUnavailable, err)
+		}
+		return nil, err
+	}
+
+	resultID := move.ResultBookingID()
+	fee := roundAmount(listing.Price * s.config.FeePercent / 100)
+	sale := &ResaleSale{
+		ID:                uuid.New(),
+		ListingID:         listing.ID,
+		EventID:           listing.EventID,
+		SellerID:          listing.SellerID,
+		BuyerID:           buyerID,
+		BookingID:         resultID,
+		OwnershipChangeID: move.Change.ID,
+		Price:             listing.Price,
+		Fee:               fee,
+		SellerPayout:      roundAmount(listing.Price - fee),
+		Currency:          listing.Currency,
+		PaymentMethod:     req.PaymentMethod,
+		PayoutStatus:      PayoutStatusPending,
+	}
+
+	payment := &bookings.Payment{
+		ID:            sale.ID,
+		BookingID:     resultID,
+		Amount:        sale.Price,
+		Currency:      sale.Currency,
+		PaymentMethod: sale.PaymentMethod,
+		TransactionID: generateTransactionID(),
+	}
+	transactionID, err := s.paymentGateway.Charge(ctx, payment)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrPaymentDeclined, err)
+	}
+	payment.MarkCompleted(transactionID)
+	sale.TransactionID = transactionID
+
+	now := time.Now()
+	listing.Status = ListingStatusSold
+	listing.BuyerID = &buyerID
+	listing.SoldAt = &now
+	listing.ResultBookingID = &resultID
+
+	messages, err := buildSaleMessages(listing, sale, move.Change)
+	if err != nil {
+		s.voidPayment(ctx, payment)
+		return nil, err
+	}
+
+	completion := &SaleCompletion{Listing: listing, Sale: sale, Move: move}
+	if err := s.repo.CompleteSale(ctx, completion, messages...); err != nil {
+		s.voidPayment(ctx, payment)
+		return nil, err
+	}
+
+	log.Printf("🏷️ RESALE: Listing %s sold to user %s for %.2f %s, %.2f owed to seller %s",
+		listing.ID, buyerID, sale.Price, sale.Currency, sale.SellerPayout, listing.SellerID)
+
+	booking, err := s.bookingService.GetBooking(ctx, resultID)
+	if err != nil {
+		return nil, err
+	}
+	return &PurchaseResponse{Sale: sale, Booking: booking}, nil
+}
+
+// GetUserResale returns the user's listings and purchases, newest first
+func (s *service) GetUserResale(ctx context.Context, userID uuid.UUID) (*UserResale, error) {
+	s.expireListings(ctx)
+
+	listings, err := s.repo.GetListingsBySellerID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	purchases, err := s.repo.GetSalesByBuyerID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &UserResale{Listings: listings, Purchases: purchases}, nil
+}
+
+//  ADMIN PAYOUTS
+
+func (s *service) ListSales(ctx context.Context, query AdminSaleQuery) (*PaginatedSales, error) {
+	filter := SaleFilter{
+		PayoutStatus: query.PayoutStatus,
+		Limit:        query.Limit,
+		Offset:       (query.Page - 1) * query.Limit,
+	}
+	if query.EventID != "" {
+		eventID, err := uuid.Parse(query.EventID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid event ID: %w", err)
+		}
+		filter.EventID = &eventID
+	}
+
+	sales, total, err := s.repo.ListSales(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PaginatedSales{
+		Sales:      sales,
+		TotalCount: total,
+		Page:       query.Page,
+		Limit:      query.Limit,
+	}, nil
+}
+
+// MarkPayoutPaid records that the seller's share of a sale was sent
+func (s *service) MarkPayoutPaid(ctx context.Context, saleID uuid.UUID, req MarkPayoutRequest) (*ResaleSale, error) {
+	if _, err := s.repo.GetSale(ctx, saleID); err != nil {
+		return nil, err
+	}
+
+	paid, err := s.repo.MarkPayoutPaid(ctx, saleID, strings.TrimSpace(req.Reference), time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if !paid {
+		return nil, ErrPayoutAlreadyPaid
+	}
+	return s.repo.GetSale(ctx, saleID)
+}
+
+//  HELPERS
+
+// resaleCutoff is when listings for an event close: Cutoff before it starts,
+// or earlier when the event's cancellation policy stops taking cancellations
+// first, so seats can't be resold once the organizer considers the
+// attendance final
+func (s *service) resaleCutoff(ctx context.Context, eventID uuid.UUID, eventStart time.Time) (time.Time, error) {
+	closesAt := eventStart.Add(-s.config.Cutoff)
+
+	policy, err := s.repo.GetCancellationPolicy(ctx, eventID)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if policy != nil && policy.AllowCancellation && policy.CancellationDeadline.Before(closesAt) {
+		closesAt = policy.CancellationDeadline
+	}
+
+	if !closesAt.After(time.Now()) {
+		return time.Time{}, fmt.Errorf("%w: listings closed at %s", ErrResaleClosed, closesAt.UTC().Format(time.RFC3339))
+	}
+	return closesAt, nil
+}
+
+// expireListings closes listings past the cutoff so the booking can be listed again
+func (s *service) expireListings(ctx context.Context) {
+	expired, err := s.repo.ExpireListings(ctx, time.Now())
+	if err != nil {
+		log.Printf("⚠️ Failed to expire resale listings: %v", err)
+		return
+	}
+	if expired > 0 {
+		log.Printf("🏷️ RESALE: Expired %d unsold listings", expired)
+	}
+}
+
+func (s *service) getActiveListing(ctx context.Context, listingID uuid.UUID) (*ResaleListing, error) {
+	s.expireListings(ctx)
+
+	listing, err := s.repo.GetListing(ctx, listingID)
+	if err != nil {
+		return nil, err
+	}
+	if listing.Status != ListingStatusActive {
+		return nil, fmt.Errorf("%w: it was %s", ErrListingClosed, strings.ToLower(listing.Status))
+	}
+	return listing, nil
+}
+
+func (s *service) closeListing(ctx context.Context, listing *ResaleListing, status string) (*ResaleListing, error) {
+	now := time.Now()
+	closed, err := s.repo.CloseListing(ctx, listing.ID, status, now)
+	if err != nil {
+		return nil, err
+	}
+	if !closed {
+		return nil, ErrListingClosed
+	}
+
+	listing.Status = status
+	listing.UpdatedAt = now
+	return listing, nil
+}
+
+// voidPayment reverses the buyer's charge when the purchase couldn't be completed
+func (s *service) voidPayment(ctx context.Context, payment *bookings.Payment) {
+	if err := s.paymentGateway.Void(ctx, payment); err != nil {
+		log.Printf("❌ RESALE: Failed to void payment %s: %v", payment.TransactionID, err)
+		return
+	}
+	log.Printf("↩️ RESALE: Voided payment %s", payment.TransactionID)
+}
+
+// buildSaleMessages creates the notifications for the seller and the buyer
+func buildSaleMessages(listing *ResaleListing, sale *ResaleSale, change *bookings.BookingOwnershipChange) ([]*outbox.Message, error) {
+	eventID := listing.EventID
+	sourceID := change.SourceBookingID
+	resultID := sale.BookingID
+
+	sold, err := outbox.NewNotificationMessage(outbox.AggregateResaleListing, listing.ID,
+		fmt.Sprintf("resale:%s:sold", listing.ID), &outbox.NotificationPayload{
+			Type:        "RESALE_SOLD",
+			RecipientID: sale.SellerID,
+			EventID:     &eventID,
+			BookingID:   &sourceID,
+			TemplateData: map[string]interface{}{
+				"seat_count":     change.SeatCount,
+				"price":          fmt.Sprintf("%.2f", sale.Price),
+				"fee":            fmt.Sprintf("%.2f", sale.Fee),
+				"payout":         fmt.Sprintf("%.2f", sale.SellerPayout),
+				"currency":       sale.Currency,
+				"booking_number": change.PreviousRef,
+				"remaining_ref":  change.RemainingRef, // Empty when the whole booking was sold
+			},
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build resale notification: %w", err)
+	}
+
+	purchased, err := outbox.NewNotificationMessage(outbox.AggregateResaleListing, listing.ID,
+		fmt.Sprintf("resale:%s:purchased", listing.ID), &outbox.NotificationPayload{
+			Type:        "RESALE_PURCHASED",
+			RecipientID: sale.BuyerID,
+			EventID:     &eventID,
+			BookingID:   &resultID,
+			TemplateData: map[string]interface{}{
+				"seat_count":     change.SeatCount,
+				"price":          fmt.Sprintf("%.2f", sale.Price),
+				"currency":       sale.Currency,
+				"booking_number": change.NewRef,
+			},
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build resale notification: %w", err)
+	}
+
+	return []*outbox.Message{sold, purchased}, nil
+}
+
+// mock tr id
+func generateTransactionID() string {
+	shortUUID := strings.ToUpper(strings.ReplaceAll(uuid.New().String(), "-", "")[:8])
+	return fmt.Sprintf("TXN_%d_%s", time.Now().Unix(), shortUUID)
+}
+
+// roundAmount rounds to the smallest currency unit
+func roundAmount(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
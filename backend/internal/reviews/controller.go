@@ -0,0 +1,308 @@
+package reviews
+
+import (
+	"net/http"
+	"strings"
+
+	"evently/internal/shared/utils/response"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type Controller struct {
+	service Service
+}
+
+func NewController(service Service) *Controller {
+	return &Controller{service: service}
+}
+
+//  PUBLIC
+
+// GetEventReviews godoc
+//
+// @Summary      List event reviews
+// @Description  Published reviews for an event with its rating summary, newest first
+// @Tags         Reviews
+// @Produce      json
+// @Param        eventId path string true "Event ID" Format(uuid)
+// @Param        page query integer false "Page" default(1) minimum(1)
+// @Param        limit query integer false "Limit" default(10) minimum(1) maximum(100)
+// @Success      200 {object} response.StandardApiResponse{data=EventReviewsResponse} "Reviews retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Event not found"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/events/{eventId}/reviews [get]
+func (ctrl *Controller) GetEventReviews(c *gin.Context) {
+	eventID, err := uuid.Parse(c.Param("eventId"))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid event ID", nil, err.Error())
+		return
+	}
+
+	var query ReviewListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid query parameters", nil, err.Error())
+		return
+	}
+
+	reviews, err := ctrl.service.GetEventReviews(c.Request.Context(), eventID, query)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "event not found" {
+			statusCode = http.StatusNotFound
+		}
+		response.RespondJSON(c, "error", statusCode, err.Error(), nil, nil)
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Reviews retrieved successfully", reviews, nil)
+}
+
+//  ATTENDEE
+
+// CreateReview godoc
+//
+// @Summary      Review an event
+// @Description  Rate and comment on a past event. Requires a confirmed booking that was checked in; one review per user per event.
+// @Tags         Reviews
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        eventId path string true "Event ID" Format(uuid)
+// @Param        request body CreateReviewRequest true "Request body"
+// @Success      201 {object} response.StandardApiResponse{data=ReviewResponse} "Review posted successfully"
+// @Failure      400 {object} response.StandardApiResponse "Event has not taken place yet or was cancelled"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse "User did not check in to the event"
+// @Failure      404 {object} response.StandardApiResponse "Event not found"
+// @Failure      409 {object} response.StandardApiResponse "User has already reviewed this event"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/events/{eventId}/reviews [post]
+func (ctrl *Controller) CreateReview(c *gin.Context) {
+	eventID, err := uuid.Parse(c.Param("eventId"))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid event ID", nil, err.Error())
+		return
+	}
+
+	userID, ok := ctrl.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req CreateReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid request body", nil, err.Error())
+		return
+	}
+
+	review, err := ctrl.service.CreateReview(c.Request.Context(), eventID, userID, req)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		switch {
+		case err.Error() == "event not found":
+			statusCode = http.StatusNotFound
+		case strings.HasPrefix(err.Error(), "only attendees"):
+			statusCode = http.StatusForbidden
+		case err.Error() == "you have already reviewed this event":
+			statusCode = http.StatusConflict
+		case strings.HasPrefix(err.Error(), "reviews open"), strings.HasPrefix(err.Error(), "cancelled events"):
+			statusCode = http.StatusBadRequest
+		}
+		response.RespondJSON(c, "error", statusCode, err.Error(), nil, nil)
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusCreated, "Review posted successfully", review, nil)
+}
+
+// UpdateReview godoc
+//
+// @Summary      Edit own review
+// @Tags         Reviews
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        reviewId path string true "Review ID" Format(uuid)
+// @Param        request body UpdateReviewRequest true "Request body"
+// @Success      200 {object} response.StandardApiResponse{data=ReviewResponse} "Review updated successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse "Review belongs to another user"
+// @Failure      404 {object} response.StandardApiResponse "Review not found"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/reviews/{reviewId} [put]
+func (ctrl *Controller) UpdateReview(c *gin.Context) {
+	reviewID, err := uuid.Parse(c.Param("reviewId"))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid review ID", nil, err.Error())
+		return
+	}
+
+	userID, ok := ctrl.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid request body", nil, err.Error())
+		return
+	}
+
+	review, err := ctrl.service.UpdateReview(c.Request.Context(), reviewID, userID, req)
+	if err != nil {
+		response.RespondJSON(c, "error", ownershipStatus(err), err.Error(), nil, nil)
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Review updated successfully", review, nil)
+}
+
+// DeleteReview godoc
+//
+// @Summary      Delete own review
+// @Tags         Reviews
+// @Produce      json
+// @Security     Bearer
+// @Param        reviewId path string true "Review ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse "Review deleted successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse "Review belongs to another user"
+// @Failure      404 {object} response.StandardApiResponse "Review not found"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/reviews/{reviewId} [delete]
+func (ctrl *Controller) DeleteReview(c *gin.Context) {
+	reviewID, err := uuid.Parse(c.Param("reviewId"))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid review ID", nil, err.Error())
+		return
+	}
+
+	userID, ok := ctrl.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := ctrl.service.DeleteReview(c.Request.Context(), reviewID, userID); err != nil {
+		response.RespondJSON(c, "error", ownershipStatus(err), err.Error(), nil, nil)
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Review deleted successfully", nil, nil)
+}
+
+//  ADMIN MODERATION
+
+// ListReviews godoc
+//
+// @Summary      List reviews (Admin)
+// @Description  Browse reviews across events for moderation, newest first
+// @Description
+// @Description  Requires the `reviews:moderate` permission.
+// @Tags         Admin Reviews
+// @Produce      json
+// @Security     Bearer
+// @Param        status query string false "Status" Enums(PUBLISHED, HIDDEN)
+// @Param        event_id query string false "Event ID" Format(uuid)
+// @Param        page query integer false "Page" default(1) minimum(1)
+// @Param        limit query integer false "Limit" default(20) minimum(1) maximum(100)
+// @Success      200 {object} response.StandardApiResponse{data=PaginatedReviews} "Reviews retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/reviews [get]
+func (ctrl *Controller) ListReviews(c *gin.Context) {
+	var query AdminReviewListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid query parameters", nil, err.Error())
+		return
+	}
+
+	reviews, err := ctrl.service.ListReviews(c.Request.Context(), query)
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusInternalServerError, "Failed to list reviews", nil, err.Error())
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Reviews retrieved successfully", reviews, nil)
+}
+
+// ModerateReview godoc
+//
+// @Summary      Moderate a review (Admin)
+// @Description  Hide a review from the public listing and rating average, or restore it
+// @Description
+// @Description  Requires the `reviews:moderate` permission.
+// @Tags         Admin Reviews
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        reviewId path string true "Review ID" Format(uuid)
+// @Param        request body ModerateReviewRequest true "Request body"
+// @Success      200 {object} response.StandardApiResponse{data=ReviewResponse} "Review moderated successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Review not found"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/reviews/{reviewId}/moderation [put]
+func (ctrl *Controller) ModerateReview(c *gin.Context) {
+	reviewID, err := uuid.Parse(c.Param("reviewId"))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid review ID", nil, err.Error())
+		return
+	}
+
+	adminID, ok := ctrl.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req ModerateReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid request body", nil, err.Error())
+		return
+	}
+
+	review, err := ctrl.service.ModerateReview(c.Request.Context(), reviewID, adminID, req)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "review not found" {
+			statusCode = http.StatusNotFound
+		}
+		response.RespondJSON(c, "error", statusCode, err.Error(), nil, nil)
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Review moderated successfully", review, nil)
+}
+
+func (ctrl *Controller) currentUserID(c *gin.Context) (uuid.UUID, bool) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.RespondJSON(c, "error", http.StatusUnauthorized, "User not authenticated", nil, nil)
+		return uuid.Nil, false
+	}
+
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusInternalServerError, "Invalid user ID format", nil, nil)
+		return uuid.Nil, false
+	}
+	return userUUID, true
+}
+
+func ownershipStatus(err error) int {
+	switch {
+	case err.Error() == "review not found":
+		return http.StatusNotFound
+	case strings.HasPrefix(err.Error(), "unauthorized"):
+		return http.StatusForbidden
+	}
+	return http.StatusInternalServerError
+}
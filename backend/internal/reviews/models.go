@@ -0,0 +1,61 @@
+package reviews
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Review statuses
+const (
+	ReviewStatusPublished = "PUBLISHED"
+	ReviewStatusHidden    = "HIDDEN"
+)
+
+// Rating bounds
+const (
+	MinRating = 1
+	MaxRating = 5
+)
+
+// EventReview is an attendee's rating and comment on an event they checked in to
+type EventReview struct {
+	ID             uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	EventID        uuid.UUID  `gorm:"type:uuid;not null;index;uniqueIndex:idx_event_review_user" json:"event_id"`
+	UserID         uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_event_review_user" json:"user_id"`
+	BookingID      uuid.UUID  `gorm:"type:uuid;not null" json:"booking_id"`
+	Rating         int        `gorm:"not null;check:chk_event_reviews_rating,rating BETWEEN 1 AND 5" json:"rating"`
+	Comment        string     `gorm:"type:text" json:"comment"`
+	Status         string     `gorm:"type:varchar(20);not null;default:'PUBLISHED';index" json:"status"`
+	ModeratedBy    *uuid.UUID `gorm:"type:uuid" json:"moderated_by,omitempty"`
+	ModeratedAt    *time.Time `json:"moderated_at,omitempty"`
+	ModerationNote string     `gorm:"type:varchar(500)" json:"moderation_note,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+func (EventReview) TableName() string {
+	return "event_reviews"
+}
+
+// IsPublished reports whether the review is visible to the public
+func (r *EventReview) IsPublished() bool {
+	return r.Status == ReviewStatusPublished
+}
+
+// ToResponse converts a review to its API representation
+func (r *EventReview) ToResponse(reviewerName string) ReviewResponse {
+	return ReviewResponse{
+		ID:             r.ID.String(),
+		EventID:        r.EventID.String(),
+		UserID:         r.UserID.String(),
+		ReviewerName:   reviewerName,
+		Rating:         r.Rating,
+		Comment:        r.Comment,
+		Status:         r.Status,
+		ModerationNote: r.ModerationNote,
+		ModeratedAt:    r.ModeratedAt,
+		CreatedAt:      r.CreatedAt,
+		UpdatedAt:      r.UpdatedAt,
+	}
+}
@@ -0,0 +1,217 @@
+package reviews
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	Create(ctx context.Context, review *EventReview) error
+	GetByID(ctx context.Context, id uuid.UUID) (*ReviewRow, error)
+	ExistsForUser(ctx context.Context, eventID, userID uuid.UUID) (bool, error)
+	Update(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	List(ctx context.Context, filter ReviewFilter) ([]ReviewRow, int64, error)
+
+	// Aggregates over published reviews
+	GetRatingCounts(ctx context.Context, eventID uuid.UUID) (map[int]int64, error)
+	GetRatingAggregates(ctx context.Context, eventIDs []uuid.UUID) ([]RatingAggregate, error)
+
+	// Eligibility lookups
+	GetEventSummary(ctx context.Context, eventID uuid.UUID) (*EventSummary, error)
+	GetCheckedInBookingID(ctx context.Context, eventID, userID uuid.UUID) (uuid.UUID, error)
+}
+
+// ReviewRow is a review joined with the reviewer's name
+type ReviewRow struct {
+	EventReview `gorm:"embedded"`
+	FirstName   string
+	LastName    string
+}
+
+// ReviewerName shortens the last name to an initial so reviews don't expose full names
+func (r *ReviewRow) ReviewerName() string {
+	if r.LastName == "" {
+		return r.FirstName
+	}
+	return fmt.Sprintf("%s %s.", r.FirstName, string([]rune(r.LastName)[:1]))
+}
+
+// ReviewFilter narrows review listings; zero values are ignored
+type ReviewFilter struct {
+	EventID *uuid.UUID
+	Status  string
+	Limit   int
+	Offset  int
+}
+
+// RatingAggregate is the average rating and review count of one event
+type RatingAggregate struct {
+	EventID       uuid.UUID
+	AverageRating float64
+	ReviewCount   int64
+}
+
+// EventSummary is the slice of event data needed to check review eligibility
+type EventSummary struct {
+	ID       uuid.UUID
+	Name     string
+	DateTime time.Time
+	Status   string
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+//  REVIEWS
+
+func (r *repository) Create(ctx context.Context, review *EventReview) error {
+	return r.db.WithContext(ctx).Create(review).Error
+}
+
+func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*ReviewRow, error) {
+	var row ReviewRow
+	err := r.withReviewer(ctx).
+		Where("er.id = ?", id).
+		Take(&row).Error
+	if err != nil {
+		return nil, err
+	}
+	return &row, nil
+}
+
+func (r *repository) ExistsForUser(ctx context.Context, eventID, userID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&EventReview{}).
+		Where("event_id = ? AND user_id = ?", eventID, userID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *repository) Update(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
+	return r.db.WithContext(ctx).Model(&EventReview{}).Where("id = ?", id).Updates(updates).Error
+}
+
+func (r *repository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&EventReview{}, "id = ?", id).Error
+}
+
+func (r *repository) List(ctx context.Context, filter ReviewFilter) ([]ReviewRow, int64, error) {
+	applyFilter := func(query *gorm.DB) *gorm.DB {
+		if filter.EventID != nil {
+			query = query.Where("er.event_id = ?", *filter.EventID)
+		}
+		if filter.Status != "" {
+			query = query.Where("er.status = ?", filter.Status)
+		}
+		return query
+	}
+
+	var total int64
+	if err := applyFilter(r.db.WithContext(ctx).Table("event_reviews er")).Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count reviews: %w", err)
+	}
+
+	var rows []ReviewRow
+	err := applyFilter(r.withReviewer(ctx)).
+		Order("er.created_at DESC").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Find(&rows).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list reviews: %w", err)
+	}
+
+	return rows, total, nil
+}
+
+func (r *repository) withReviewer(ctx context.Context) *gorm.DB {
+	return r.db.WithContext(ctx).
+		Table("event_reviews er").
+		Select("er.*, u.first_name, u.last_name").
+		Joins("LEFT JOIN users u ON u.id = er.user_id")
+}
+
+//  AGGREGATES
+
+func (r *repository) GetRatingCounts(ctx context.Context, eventID uuid.UUID) (map[int]int64, error) {
+	var rows []struct {
+		Rating int
+		Count  int64
+	}
+	err := r.db.WithContext(ctx).
+		Model(&EventReview{}).
+		Select("rating, COUNT(*) AS count").
+		Where("event_id = ? AND status = ?", eventID, ReviewStatusPublished).
+		Group("rating").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rating counts: %w", err)
+	}
+
+	counts := make(map[int]int64, MaxRating)
+	for _, row := range rows {
+		counts[row.Rating] = row.Count
+	}
+	return counts, nil
+}
+
+func (r *repository) GetRatingAggregates(ctx context.Context, eventIDs []uuid.UUID) ([]RatingAggregate, error) {
+	var aggregates []RatingAggregate
+	if len(eventIDs) == 0 {
+		return aggregates, nil
+	}
+
+	err := r.db.WithContext(ctx).
+		Model(&EventReview{}).
+		Select("event_id, AVG(rating) AS average_rating, COUNT(*) AS review_count").
+		Where("event_id IN ? AND status = ?", eventIDs, ReviewStatusPublished).
+		Group("event_id").
+		Scan(&aggregates).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rating aggregates: %w", err)
+	}
+	return aggregates, nil
+}
+
+//  ELIGIBILITY
+
+func (r *repository) GetEventSummary(ctx context.Context, eventID uuid.UUID) (*EventSummary, error) {
+	var event EventSummary
+	err := r.db.WithContext(ctx).
+		Table("events").
+		Select("id, name, date_time, status").
+		Where("id = ? AND deleted_at IS NULL", eventID).
+		Take(&event).Error
+	if err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// GetCheckedInBookingID returns the user's earliest confirmed, checked-in booking for the event
+func (r *repository) GetCheckedInBookingID(ctx context.Context, eventID, userID uuid.UUID) (uuid.UUID, error) {
+	var booking struct {
+		ID uuid.UUID
+	}
+	err := r.db.WithContext(ctx).
+		Table("bookings").
+		Select("id").
+		Where("event_id = ? AND user_id = ? AND status = ? AND checked_in_at IS NOT NULL", eventID, userID, "CONFIRMED").
+		Order("checked_in_at ASC").
+		Take(&booking).Error
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return booking.ID, nil
+}
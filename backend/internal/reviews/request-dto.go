@@ -0,0 +1,30 @@
+package reviews
+
+type CreateReviewRequest struct {
+	Rating  int    `json:"rating" binding:"required,min=1,max=5"`
+	Comment string `json:"comment" binding:"max=2000"`
+}
+
+// UpdateReviewRequest edits a user's own review, omitted fields are left unchanged
+type UpdateReviewRequest struct {
+	Rating  *int    `json:"rating" binding:"omitempty,min=1,max=5"`
+	Comment *string `json:"comment" binding:"omitempty,max=2000"`
+}
+
+// ModerateReviewRequest hides or restores a review
+type ModerateReviewRequest struct {
+	Status string `json:"status" binding:"required,oneof=PUBLISHED HIDDEN"`
+	Note   string `json:"note" binding:"max=500"`
+}
+
+type ReviewListQuery struct {
+	Page  int `form:"page,default=1" binding:"min=1"`
+	Limit int `form:"limit,default=10" binding:"min=1,max=100"`
+}
+
+type AdminReviewListQuery struct {
+	Status  string `form:"status" binding:"omitempty,oneof=PUBLISHED HIDDEN"`
+	EventID string `form:"event_id" binding:"omitempty,uuid"`
+	Page    int    `form:"page,default=1" binding:"min=1"`
+	Limit   int    `form:"limit,default=20" binding:"min=1,max=100"`
+}
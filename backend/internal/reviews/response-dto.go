@@ -0,0 +1,52 @@
+package reviews
+
+import (
+	"time"
+
+	"evently/internal/shared/utils/response"
+)
+
+type ReviewResponse struct {
+	ID             string     `json:"id"`
+	EventID        string     `json:"event_id"`
+	UserID         string     `json:"user_id"`
+	ReviewerName   string     `json:"reviewer_name"`
+	Rating         int        `json:"rating"`
+	Comment        string     `json:"comment"`
+	Status         string     `json:"status"`
+	ModerationNote string     `json:"moderation_note,omitempty"`
+	ModeratedAt    *time.Time `json:"moderated_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// RatingSummary aggregates the published reviews of an event
+type RatingSummary struct {
+	EventID       string        `json:"event_id"`
+	AverageRating float64       `json:"average_rating"`
+	ReviewCount   int64         `json:"review_count"`
+	Distribution  map[int]int64 `json:"distribution"` // Review count per star rating
+}
+
+type EventReviewsResponse struct {
+	Summary    RatingSummary    `json:"summary"`
+	Reviews    []ReviewResponse `json:"reviews"`
+	TotalCount int64            `json:"total_count"`
+	Page       int              `json:"page"`
+	Limit      int              `json:"limit"`
+}
+
+func (p EventReviewsResponse) PaginationMeta() response.Pagination {
+	return response.NewPagination(p.Page, p.Limit, p.TotalCount)
+}
+
+type PaginatedReviews struct {
+	Reviews    []ReviewResponse `json:"reviews"`
+	TotalCount int64            `json:"total_count"`
+	Page       int              `json:"page"`
+	Limit      int              `json:"limit"`
+}
+
+func (p PaginatedReviews) PaginationMeta() response.Pagination {
+	return response.NewPagination(p.Page, p.Limit, p.TotalCount)
+}
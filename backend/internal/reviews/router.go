@@ -0,0 +1,37 @@
+package reviews
+
+import (
+	"evently/internal/shared/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupReviewRoutes(rg *gin.RouterGroup, controller *Controller) {
+	// Public reviews shown on the event detail page
+	publicEvents := rg.Group("/events")
+	{
+		publicEvents.GET("/:eventId/reviews", controller.GetEventReviews) // GET /api/v1/events/:eventId/reviews
+	}
+
+	// Attendee reviews - eligibility (checked-in booking, event in the past) is enforced by the service
+	userEvents := rg.Group("/events")
+	userEvents.Use(middleware.JWTAuth(), middleware.RequireRoles("USER", "ADMIN"))
+	{
+		userEvents.POST("/:eventId/reviews", controller.CreateReview) // POST /api/v1/events/:eventId/reviews
+	}
+
+	userReviews := rg.Group("/reviews")
+	userReviews.Use(middleware.JWTAuth(), middleware.RequireRoles("USER", "ADMIN"))
+	{
+		userReviews.PUT("/:reviewId", controller.UpdateReview)    // PUT /api/v1/reviews/:reviewId
+		userReviews.DELETE("/:reviewId", controller.DeleteReview) // DELETE /api/v1/reviews/:reviewId
+	}
+
+	// Admin moderation
+	adminReviews := rg.Group("/admin/reviews")
+	adminReviews.Use(middleware.JWTAuth(), middleware.RequirePermission(middleware.PermissionReviewsModerate))
+	{
+		adminReviews.GET("", controller.ListReviews)                         // GET /api/v1/admin/reviews
+		adminReviews.PUT("/:reviewId/moderation", controller.ModerateReview) // PUT /api/v1/admin/reviews/:reviewId/moderation
+	}
+}
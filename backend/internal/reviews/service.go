@@ -0,0 +1,346 @@
+package reviews
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"time"
+
+	"evently/internal/shared/utils/constants"
+	"evently/pkg/cache"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Service interface {
+	SetCacheService(cacheService cache.Service)
+
+	// Attendee reviews
+	CreateReview(ctx context.Context, eventID, userID uuid.UUID, req CreateReviewRequest) (*ReviewResponse, error)
+	UpdateReview(ctx context.Context, reviewID, userID uuid.UUID, req UpdateReviewRequest) (*ReviewResponse, error)
+	DeleteReview(ctx context.Context, reviewID, userID uuid.UUID) error
+
+	// Public review surface
+	GetEventReviews(ctx context.Context, eventID uuid.UUID, query ReviewListQuery) (*EventReviewsResponse, error)
+	GetRatingSummary(ctx context.Context, eventID uuid.UUID) (*RatingSummary, error)
+	GetRatingSummaries(ctx context.Context, eventIDs []uuid.UUID) (map[uuid.UUID]RatingSummary, error)
+
+	// Admin moderation
+	ListReviews(ctx context.Context, query AdminReviewListQuery) (*PaginatedReviews, error)
+	ModerateReview(ctx context.Context, reviewID, adminID uuid.UUID, req ModerateReviewRequest) (*ReviewResponse, error)
+}
+
+type service struct {
+	repo         Repository
+	cacheService cache.Service
+}
+
+func NewService(repo Repository) Service {
+	return &service{repo: repo}
+}
+
+// SetCacheService injects the cache service dependency
+func (s *service) SetCacheService(cacheService cache.Service) {
+	s.cacheService = cacheService
+}
+
+func (s *service) invalidateSummaryCache(ctx context.Context, eventID uuid.UUID) {
+	if s.cacheService == nil {
+		return
+	}
+	if err := s.cacheService.Delete(ctx, constants.BuildReviewSummaryKey(eventID.String())); err != nil {
+		log.Printf("Warning: failed to invalidate rating summary cache for event %s: %v", eventID, err)
+	}
+}
+
+//  ATTENDEE REVIEWS
+
+func (s *service) CreateReview(ctx context.Context, eventID, userID uuid.UUID, req CreateReviewRequest) (*ReviewResponse, error) {
+	event, err := s.repo.GetEventSummary(ctx, eventID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("event not found")
+		}
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	if event.Status == "cancelled" {
+		return nil, errors.New("cancelled events cannot be reviewed")
+	}
+	if event.DateTime.After(time.Now()) {
+		return nil, errors.New("reviews open once the event has taken place")
+	}
+
+	bookingID, err := s.repo.GetCheckedInBookingID(ctx, eventID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("only attendees who checked in can review this event")
+		}
+		return nil, fmt.Errorf("failed to verify attendance: %w", err)
+	}
+
+	exists, err := s.repo.ExistsForUser(ctx, eventID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check existing review: %w", err)
+	}
+	if exists {
+		return nil, errors.New("you have already reviewed this event")
+	}
+
+	review := &EventReview{
+		ID:        uuid.New(),
+		EventID:   eventID,
+		UserID:    userID,
+		BookingID: bookingID,
+		Rating:    req.Rating,
+		Comment:   strings.TrimSpace(req.Comment),
+		Status:    ReviewStatusPublished,
+	}
+
+	if err := s.repo.Create(ctx, review); err != nil {
+		// The unique index catches a concurrent submission that slipped past the check above
+		if strings.Contains(err.Error(), "duplicate key") {
+			return nil, errors.New("you have already reviewed this event")
+		}
+		return nil, fmt.Errorf("failed to create review: %w", err)
+	}
+
+	s.invalidateSummaryCache(ctx, eventID)
+	log.Printf("⭐ Review %s posted for event %s with rating %d", review.ID, eventID, review.Rating)
+
+	return s.getResponse(ctx, review.ID)
+}
+
+func (s *service) UpdateReview(ctx context.Context, reviewID, userID uuid.UUID, req UpdateReviewRequest) (*ReviewResponse, error) {
+	review, err := s.getOwnedReview(ctx, reviewID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := map[string]interface{}{}
+	if req.Rating != nil {
+		updates["rating"] = *req.Rating
+	}
+	if req.Comment != nil {
+		updates["comment"] = strings.TrimSpace(*req.Comment)
+	}
+	if len(updates) == 0 {
+		response := review.ToResponse(review.ReviewerName())
+		return &response, nil
+	}
+	updates["updated_at"] = time.Now()
+
+	if err := s.repo.Update(ctx, reviewID, updates); err != nil {
+		return nil, fmt.Errorf("failed to update review: %w", err)
+	}
+
+	s.invalidateSummaryCache(ctx, review.EventID)
+	return s.getResponse(ctx, reviewID)
+}
+
+func (s *service) DeleteReview(ctx context.Context, reviewID, userID uuid.UUID) error {
+	review, err := s.getOwnedReview(ctx, reviewID, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, reviewID); err != nil {
+		return fmt.Errorf("failed to delete review: %w", err)
+	}
+
+	s.invalidateSummaryCache(ctx, review.EventID)
+	return nil
+}
+
+//  PUBLIC REVIEW SURFACE
+
+func (s *service) GetEventReviews(ctx context.Context, eventID uuid.UUID, query ReviewListQuery) (*EventReviewsResponse, error) {
+	if _, err := s.repo.GetEventSummary(ctx, eventID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("event not found")
+		}
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	summary, err := s.GetRatingSummary(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, total, err := s.repo.List(ctx, ReviewFilter{
+		EventID: &eventID,
+		Status:  ReviewStatusPublished,
+		Limit:   query.Limit,
+		Offset:  (query.Page - 1) * query.Limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &EventReviewsResponse{
+		Summary:    *summary,
+		Reviews:    toResponses(rows),
+		TotalCount: total,
+		Page:       query.Page,
+		Limit:      query.Limit,
+	}, nil
+}
+
+func (s *service) GetRatingSummary(ctx context.Context, eventID uuid.UUID) (*RatingSummary, error) {
+	cacheKey := constants.BuildReviewSummaryKey(eventID.String())
+	if s.cacheService != nil {
+		var cached RatingSummary
+		if err := s.cacheService.Get(ctx, cacheKey, &cached); err == nil {
+			return &cached, nil
+		}
+	}
+
+	counts, err := s.repo.GetRatingCounts(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &RatingSummary{
+		EventID:      eventID.String(),
+		Distribution: make(map[int]int64, MaxRating),
+	}
+	var total int64
+	for rating := MinRating; rating <= MaxRating; rating++ {
+		count := counts[rating]
+		summary.Distribution[rating] = count
+		summary.ReviewCount += count
+		total += int64(rating) * count
+	}
+	if summary.ReviewCount > 0 {
+		summary.AverageRating = roundRating(float64(total) / float64(summary.ReviewCount))
+	}
+
+	if s.cacheService != nil {
+		if err := s.cacheService.Set(ctx, cacheKey, summary, constants.TTL_REVIEW_SUMMARY); err != nil {
+			log.Printf("Warning: failed to cache rating summary for event %s: %v", eventID, err)
+		}
+	}
+
+	return summary, nil
+}
+
+// GetRatingSummaries returns averages and counts for many events in one query.
+// Events without published reviews are omitted and the distribution is left empty.
+func (s *service) GetRatingSummaries(ctx context.Context, eventIDs []uuid.UUID) (map[uuid.UUID]RatingSummary, error) {
+	aggregates, err := s.repo.GetRatingAggregates(ctx, eventIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make(map[uuid.UUID]RatingSummary, len(aggregates))
+	for _, aggregate := range aggregates {
+		summaries[aggregate.EventID] = RatingSummary{
+			EventID:       aggregate.EventID.String(),
+			AverageRating: roundRating(aggregate.AverageRating),
+			ReviewCount:   aggregate.ReviewCount,
+		}
+	}
+	return summaries, nil
+}
+
+//  ADMIN MODERATION
+
+func (s *service) ListReviews(ctx context.Context, query AdminReviewListQuery) (*PaginatedReviews, error) {
+	filter := ReviewFilter{
+		Status: query.Status,
+		Limit:  query.Limit,
+		Offset: (query.Page - 1) * query.Limit,
+	}
+	if query.EventID != "" {
+		eventID, err := uuid.Parse(query.EventID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid event ID: %w", err)
+		}
+		filter.EventID = &eventID
+	}
+
+	rows, total, err := s.repo.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PaginatedReviews{
+		Reviews:    toResponses(rows),
+		TotalCount: total,
+		Page:       query.Page,
+		Limit:      query.Limit,
+	}, nil
+}
+
+func (s *service) ModerateReview(ctx context.Context, reviewID, adminID uuid.UUID, req ModerateReviewRequest) (*ReviewResponse, error) {
+	review, err := s.repo.GetByID(ctx, reviewID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("review not found")
+		}
+		return nil, fmt.Errorf("failed to get review: %w", err)
+	}
+
+	now := time.Now()
+	updates := map[string]interface{}{
+		"status":          req.Status,
+		"moderated_by":    adminID,
+		"moderated_at":    now,
+		"moderation_note": strings.TrimSpace(req.Note),
+		"updated_at":      now,
+	}
+	if err := s.repo.Update(ctx, reviewID, updates); err != nil {
+		return nil, fmt.Errorf("failed to moderate review: %w", err)
+	}
+
+	// Hiding or restoring a review changes the published aggregate
+	if review.Status != req.Status {
+		s.invalidateSummaryCache(ctx, review.EventID)
+	}
+	log.Printf("🛡️ Review %s moderated to %s by admin %s", reviewID, req.Status, adminID)
+
+	return s.getResponse(ctx, reviewID)
+}
+
+//  HELPERS
+
+func (s *service) getOwnedReview(ctx context.Context, reviewID, userID uuid.UUID) (*ReviewRow, error) {
+	review, err := s.repo.GetByID(ctx, reviewID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("review not found")
+		}
+		return nil, fmt.Errorf("failed to get review: %w", err)
+	}
+
+	if review.UserID != userID {
+		return nil, errors.New("unauthorized: review does not belong to user")
+	}
+	return review, nil
+}
+
+func (s *service) getResponse(ctx context.Context, reviewID uuid.UUID) (*ReviewResponse, error) {
+	review, err := s.repo.GetByID(ctx, reviewID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get review: %w", err)
+	}
+	response := review.ToResponse(review.ReviewerName())
+	return &response, nil
+}
+
+func toResponses(rows []ReviewRow) []ReviewResponse {
+	responses := make([]ReviewResponse, len(rows))
+	for i := range rows {
+		responses[i] = rows[i].ToResponse(rows[i].ReviewerName())
+	}
+	return responses
+}
+
+// roundRating rounds an average to one decimal place for display
+func roundRating(average float64) float64 {
+	return math.Round(average*10) / 10
+}
@@ -0,0 +1,163 @@
+package seats
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Seat attribute names used in responses and availability filters
+const (
+	AttributeWheelchairAccessible = "WHEELCHAIR_ACCESSIBLE"
+	AttributeCompanion            = "COMPANION"
+	AttributeRestrictedView       = "RESTRICTED_VIEW"
+	AttributeAisle                = "AISLE"
+)
+
+var validAttributes = map[string]bool{
+	AttributeWheelchairAccessible: true,
+	AttributeCompanion:            true,
+	AttributeRestrictedView:       true,
+	AttributeAisle:                true,
+}
+
+// SeatAttributes lists the attribute names for the given flags in a stable order
+func SeatAttributes(wheelchairAccessible, companion, restrictedView, aisle bool) []string {
+	var attrs []string
+	if wheelchairAccessible {
+		attrs = append(attrs, AttributeWheelchairAccessible)
+	}
+	if companion {
+		attrs = append(attrs, AttributeCompanion)
+	}
+	if restrictedView {
+		attrs = append(attrs, AttributeRestrictedView)
+	}
+	if aisle {
+		attrs = append(attrs, AttributeAisle)
+	}
+	return attrs
+}
+
+// SeatAttributeFilter narrows an availability listing by seat attributes
+type SeatAttributeFilter struct {
+	Require []string
+	Exclude []string
+}
+
+// ParseSeatAttributeFilter parses comma separated attribute lists from query parameters
+func ParseSeatAttributeFilter(require, exclude string) (SeatAttributeFilter, error) {
+	var filter SeatAttributeFilter
+	var err error
+
+	if filter.Require, err = parseAttributeList(require); err != nil {
+		return filter, err
+	}
+	if filter.Exclude, err = parseAttributeList(exclude); err != nil {
+		return filter, err
+	}
+	return filter, nil
+}
+
+func parseAttributeList(raw string) ([]string, error) {
+	var attrs []string
+	for _, part := range strings.Split(raw, ",") {
+		attr := strings.ToUpper(strings.TrimSpace(part))
+		if attr == "" {
+			continue
+		}
+		if !validAttributes[attr] {
+			return nil, fmt.Errorf("invalid seat attribute: %s", attr)
+		}
+		attrs = append(attrs, attr)
+	}
+	return attrs, nil
+}
+
+func (f SeatAttributeFilter) IsEmpty() bool {
+	return len(f.Require) == 0 && len(f.Exclude) == 0
+}
+
+// Matches reports whether a seat has every required attribute and none of the excluded ones
+func (f SeatAttributeFilter) Matches(attrs []string) bool {
+	has := make(map[string]bool, len(attrs))
+	for _, attr := range attrs {
+		has[attr] = true
+	}
+	for _, attr := range f.Require {
+		if !has[attr] {
+			return false
+		}
+	}
+	for _, attr := range f.Exclude {
+		if has[attr] {
+			return false
+		}
+	}
+	return true
+}
+
+// Apply returns the seats matching the filter
+func (f SeatAttributeFilter) Apply(seats []SeatResponse) []SeatResponse {
+	if f.IsEmpty() {
+		return seats
+	}
+
+	filtered := make([]SeatResponse, 0, len(seats))
+	for _, seat := range seats {
+		if f.Matches(seat.Attributes) {
+			filtered = append(filtered, seat)
+		}
+	}
+	return filtered
+}
+
+// SeatBookingRules holds the admin-configurable accessibility rules enforced when seats are held.
+// A single row (ID 1) is stored; defaults apply until an admin saves a configuration.
+type SeatBookingRules struct {
+	ID                          int        `gorm:"primaryKey" json:"-"`
+	CompanionRequiresAccessible bool       `gorm:"not null;default:true" json:"companion_requires_accessible"`
+	MaxCompanionsPerAccessible  int        `gorm:"not null;default:1" json:"max_companions_per_accessible"`
+	UpdatedBy                   *uuid.UUID `gorm:"type:uuid" json:"updated_by,omitempty"`
+	UpdatedAt                   time.Time  `json:"updated_at"`
+}
+
+func (SeatBookingRules) TableName() string {
+	return "seat_booking_rules"
+}
+
+const seatBookingRulesID = 1
+
+func DefaultSeatBookingRules() *SeatBookingRules {
+	return &SeatBookingRules{
+		ID:                          seatBookingRulesID,
+		CompanionRequiresAccessible: true,
+		MaxCompanionsPerAccessible:  1,
+	}
+}
+
+// Validate checks a set of seats about to be held against the rules
+func (r *SeatBookingRules) Validate(seats []Seat) error {
+	var accessible, companions int
+	for _, seat := range seats {
+		if seat.WheelchairAccessible {
+			accessible++
+		}
+		if seat.CompanionSeat {
+			companions++
+		}
+	}
+
+	if companions == 0 || !r.CompanionRequiresAccessible {
+		return nil
+	}
+	if accessible == 0 {
+		return fmt.Errorf("companion seats must be booked together with a wheelchair accessible seat")
+	}
+	if r.MaxCompanionsPerAccessible > 0 && companions > accessible*r.MaxCompanionsPerAccessible {
+		return fmt.Errorf("at most %d companion seat(s) may be booked per wheelchair accessible seat", r.MaxCompanionsPerAccessible)
+	}
+	return nil
+}
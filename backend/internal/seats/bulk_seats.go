@@ -0,0 +1,171 @@
+package seats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"evently/internal/shared/utils/constants"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// BulkUpdateSeats sets the status or attributes of whole rows and seat ranges
+// of a section at once. The seats are changed in one transaction and every
+// cached availability and layout that shows them is dropped.
+func (s *service) BulkUpdateSeats(ctx context.Context, req BulkUpdateSeatsRequest) (*BulkUpdateSeatsResponse, error) {
+	sectionID, err := uuid.Parse(req.SectionID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid section ID: %w", err)
+	}
+	if len(req.Rows) == 0 && len(req.Ranges) == 0 {
+		return nil, fmt.Errorf("invalid bulk update: select seats with rows or ranges")
+	}
+
+	updates := make(map[string]interface{})
+	if req.Status != nil {
+		updates["status"] = *req.Status
+	}
+	if req.WheelchairAccessible != nil {
+		updates["wheelchair_accessible"] = *req.WheelchairAccessible
+	}
+	if req.CompanionSeat != nil {
+		updates["companion_seat"] = *req.CompanionSeat
+	}
+	if req.RestrictedView != nil {
+		updates["restricted_view"] = *req.RestrictedView
+	}
+	if req.Aisle != nil {
+		updates["aisle"] = *req.Aisle
+	}
+	if len(updates) == 0 {
+		return nil, fmt.Errorf("invalid bulk update: no status or attributes to set")
+	}
+
+	templateID, err := s.repo.GetSectionTemplateID(ctx, sectionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("section not found")
+		}
+		return nil, fmt.Errorf("failed to get section: %w", err)
+	}
+
+	sectionSeats, err := s.repo.GetSeatsBySectionID(ctx, sectionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get section seats: %w", err)
+	}
+	selected, err := selectSeats(sectionSeats, req.Rows, req.Ranges)
+	if err != nil {
+		return nil, err
+	}
+
+	seatIDs := make([]uuid.UUID, len(selected))
+	response := &BulkUpdateSeatsResponse{
+		SectionID: sectionID.String(),
+		Matched:   len(selected),
+		Rows:      []string{},
+		Changes:   updates,
+		SeatIDs:   make([]string, len(selected)),
+	}
+	seenRows := make(map[string]bool)
+	for i, seat := range selected {
+		seatIDs[i] = seat.ID
+		response.SeatIDs[i] = seat.ID.String()
+		if !seenRows[seat.Row] {
+			seenRows[seat.Row] = true
+			response.Rows = append(response.Rows, seat.Row)
+		}
+	}
+
+	updated, err := s.repo.BulkUpdateSeats(ctx, sectionID, seatIDs, updates)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update seats: %w", err)
+	}
+	response.Updated = updated
+	response.Unchanged = int64(len(selected)) - updated
+
+	if updated > 0 {
+		s.invalidateSectionSeats(ctx, sectionID, templateID)
+	}
+	log.InfoContext(ctx, "Seats bulk updated", "section_id", sectionID, "matched", len(selected), "updated", updated, "changes", updates)
+
+	return response, nil
+}
+
+// selectSeats returns the seats in the rows and ranges, in seat map order.
+// Every row and range end has to be in the section.
+func selectSeats(sectionSeats []Seat, rows []string, ranges []SeatRange) ([]Seat, error) {
+	byNumber := make(map[string]Seat, len(sectionSeats))
+	inRow := make(map[string]bool)
+	for _, seat := range sectionSeats {
+		byNumber[seat.SeatNumber] = seat
+		inRow[seat.Row] = true
+	}
+
+	wanted := make(map[uuid.UUID]bool)
+	wantedRows := make(map[string]bool, len(rows))
+	for _, row := range rows {
+		if !inRow[row] {
+			return nil, fmt.Errorf("invalid bulk update: row %s is not in the section", row)
+		}
+		wantedRows[row] = true
+	}
+	type positions struct{ from, to int }
+	spans := make([]positions, 0, len(ranges))
+	for _, r := range ranges {
+		from, ok := byNumber[r.From]
+		if !ok {
+			return nil, fmt.Errorf("invalid seat range: seat %s is not in the section", r.From)
+		}
+		to, ok := byNumber[r.To]
+		if !ok {
+			return nil, fmt.Errorf("invalid seat range: seat %s is not in the section", r.To)
+		}
+		if from.Position > to.Position {
+			return nil, fmt.Errorf("invalid seat range: %s comes after %s", r.From, r.To)
+		}
+		spans = append(spans, positions{from.Position, to.Position})
+	}
+
+	var selected []Seat
+	for _, seat := range sectionSeats {
+		match := wantedRows[seat.Row]
+		for _, span := range spans {
+			if seat.Position >= span.from && seat.Position <= span.to {
+				match = true
+				break
+			}
+		}
+		if match && !wanted[seat.ID] {
+			wanted[seat.ID] = true
+			selected = append(selected, seat)
+		}
+	}
+	sort.SliceStable(selected, func(i, j int) bool { return selected[i].Position < selected[j].Position })
+	return selected, nil
+}
+
+// invalidateSectionSeats drops the cached availability of a section and the
+// layouts of the events held on its template
+func (s *service) invalidateSectionSeats(ctx context.Context, sectionID, templateID uuid.UUID) {
+	if s.cacheService == nil {
+		return
+	}
+
+	patterns := constants.BuildSectionSeatCachePatterns(sectionID.String())
+	eventIDs, err := s.repo.GetTemplateEventIDs(ctx, templateID)
+	if err != nil {
+		log.WarnContext(ctx, "Failed to list template events for cache invalidation", "template_id", templateID, "error", err)
+	}
+	for _, eventID := range eventIDs {
+		patterns = append(patterns, constants.BuildVenueLayoutKey(eventID.String()))
+	}
+
+	for _, pattern := range patterns {
+		if err := s.cacheService.DeletePattern(ctx, pattern); err != nil {
+			log.WarnContext(ctx, "Failed to invalidate cache pattern", "pattern", pattern, "error", err)
+		}
+	}
+}
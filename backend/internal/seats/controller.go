@@ -1,23 +1,49 @@
 package seats
 
 import (
+	"errors"
+	"evently/internal/shared/utils/privacy"
 	"evently/internal/shared/utils/response"
-	"log"
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
 
 type Controller struct {
-	service Service
+	service   Service
+	redactPII bool
 }
 
 func NewController(service Service) *Controller {
 	return &Controller{service: service}
 }
 
+// SetRedactPII masks user IDs in the hold debugging endpoints
+func (c *Controller) SetRedactPII(redact bool) {
+	c.redactPII = redact
+}
+
+func (c *Controller) redactHold(details *SeatHoldDetails) {
+	if c.redactPII && details != nil {
+		details.UserID = privacy.RedactID(details.UserID)
+	}
+}
+
 // SEAT MANAGEMENT
 
+// GetSeatsBySectionID godoc
+//
+// @Summary      Get seats in section
+// @Description  Get all seats in a specific section
+// @Tags         Seats
+// @Produce      json
+// @Param        sectionId path string true "Section ID" Format(uuid)
+// @Param        event_id query string false "Event ID to get event-specific seat data" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=[]Seat} "Seats retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/sections/{sectionId}/seats [get]
 func (c *Controller) GetSeatsBySectionID(ctx *gin.Context) {
 	sectionID := ctx.Param("sectionId")
 	if sectionID == "" {
@@ -34,6 +60,21 @@ func (c *Controller) GetSeatsBySectionID(ctx *gin.Context) {
 	response.RespondJSON(ctx, "success", http.StatusOK, "Seats retrieved successfully", seats, nil)
 }
 
+// GetSeat godoc
+//
+// @Summary      Get individual seat details
+// @Description  Get details of a specific seat
+// @Tags         Seats
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=Seat} "Seat details retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/seats/{id} [get]
 func (c *Controller) GetSeat(ctx *gin.Context) {
 	id := ctx.Param("id")
 	if id == "" {
@@ -54,6 +95,25 @@ func (c *Controller) GetSeat(ctx *gin.Context) {
 	response.RespondJSON(ctx, "success", http.StatusOK, "Seat retrieved successfully", seat, nil)
 }
 
+// UpdateSeat godoc
+//
+// @Summary      Update seat (Admin)
+// @Description  Update seat details (Admin only)
+// @Description
+// @Description  Requires the `venues:manage` permission.
+// @Tags         Admin Seats
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "ID" Format(uuid)
+// @Param        request body UpdateSeatRequest true "Request body"
+// @Success      200 {object} response.StandardApiResponse{data=Seat} "Seat updated successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/seats/{id} [put]
 func (c *Controller) UpdateSeat(ctx *gin.Context) {
 	id := ctx.Param("id")
 	if id == "" {
@@ -80,6 +140,67 @@ func (c *Controller) UpdateSeat(ctx *gin.Context) {
 	response.RespondJSON(ctx, "success", http.StatusOK, "Seat updated successfully", seat, nil)
 }
 
+// BulkUpdateSeats godoc
+//
+// @Summary      Bulk update seats (Admin)
+// @Description  Set the status or attributes of whole rows and seat ranges of a section, e.g. block row C or
+// @Description  mark A1-A10 as aisle seats. Ranges run from one seat number to another in seat map order.
+// @Description  All seats change in one transaction, and cached availability of the section and layouts of
+// @Description  events held on its template are dropped.
+// @Description
+// @Description  Requires the `venues:manage` permission.
+// @Tags         Admin Seats
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        request body BulkUpdateSeatsRequest true "Request body"
+// @Success      200 {object} response.StandardApiResponse{data=BulkUpdateSeatsResponse} "Seats updated successfully"
+// @Failure      400 {object} response.StandardApiResponse "No seats selected, no changes, or a row or seat not in the section"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Section not found"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/seats/bulk [post]
+func (c *Controller) BulkUpdateSeats(ctx *gin.Context) {
+	var req BulkUpdateSeatsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid request data", nil, err.Error())
+		return
+	}
+
+	summary, err := c.service.BulkUpdateSeats(ctx.Request.Context(), req)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		switch {
+		case err.Error() == "section not found":
+			statusCode = http.StatusNotFound
+		case strings.HasPrefix(err.Error(), "invalid"):
+			statusCode = http.StatusBadRequest
+		}
+		response.RespondJSON(ctx, "error", statusCode, "Failed to update seats", nil, err.Error())
+		return
+	}
+
+	response.RespondJSON(ctx, "success", http.StatusOK, "Seats updated successfully", summary, nil)
+}
+
+// DeleteSeat godoc
+//
+// @Summary      Delete seat (Admin)
+// @Description  Delete a seat (Admin only)
+// @Description
+// @Description  Requires the `venues:manage` permission.
+// @Tags         Admin Seats
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse "Seat deleted successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/seats/{id} [delete]
 func (c *Controller) DeleteSeat(ctx *gin.Context) {
 	id := ctx.Param("id")
 	if id == "" {
@@ -100,8 +221,90 @@ func (c *Controller) DeleteSeat(ctx *gin.Context) {
 	response.RespondJSON(ctx, "success", http.StatusOK, "Seat deleted successfully", nil, nil)
 }
 
+//  ACCESSIBILITY RULES
+
+// GetSeatBookingRules godoc
+//
+// @Summary      Get seat booking rules (Admin)
+// @Description  Accessibility rules enforced when seats are held
+// @Description
+// @Description  Requires the `venues:manage` permission.
+// @Tags         Admin Seats
+// @Produce      json
+// @Security     Bearer
+// @Success      200 {object} response.StandardApiResponse{data=SeatBookingRules} "Seat booking rules retrieved successfully"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/seats/rules [get]
+func (c *Controller) GetSeatBookingRules(ctx *gin.Context) {
+	rules, err := c.service.GetSeatBookingRules(ctx.Request.Context())
+	if err != nil {
+		response.RespondJSON(ctx, "error", http.StatusInternalServerError, "Failed to get seat booking rules", nil, err.Error())
+		return
+	}
+
+	response.RespondJSON(ctx, "success", http.StatusOK, "Seat booking rules retrieved successfully", rules, nil)
+}
+
+// UpdateSeatBookingRules godoc
+//
+// @Summary      Update seat booking rules (Admin)
+// @Description  Requires the `venues:manage` permission.
+// @Tags         Admin Seats
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        request body UpdateSeatBookingRulesRequest true "Request body"
+// @Success      200 {object} response.StandardApiResponse{data=SeatBookingRules} "Seat booking rules updated successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/seats/rules [put]
+func (c *Controller) UpdateSeatBookingRules(ctx *gin.Context) {
+	userID, exists := ctx.Get("user_id")
+	if !exists {
+		response.RespondJSON(ctx, "error", http.StatusUnauthorized, "User not authenticated", nil, nil)
+		return
+	}
+
+	var req UpdateSeatBookingRulesRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid request data", nil, err.Error())
+		return
+	}
+
+	rules, err := c.service.UpdateSeatBookingRules(ctx.Request.Context(), userID.(string), req)
+	if err != nil {
+		response.RespondJSON(ctx, "error", http.StatusInternalServerError, "Failed to update seat booking rules", nil, err.Error())
+		return
+	}
+
+	response.RespondJSON(ctx, "success", http.StatusOK, "Seat booking rules updated successfully", rules, nil)
+}
+
 //  SEAT HOLDING
 
+// HoldSeats godoc
+//
+// @Summary      Hold seats
+// @Description  Temporarily hold seats for booking
+// @Description
+// @Description  Partner API keys need the `bookings:write` scope.
+// @Tags         Seats
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Security     ApiKey
+// @Param        request body SeatHoldRequest true "Request body"
+// @Success      200 {object} response.StandardApiResponse{data=SeatHoldResponse} "Seats held successfully"
+// @Failure      400 {object} response.StandardApiResponse "Invalid request, seats unavailable, or seat booking rules violated (e.g. companion seat without an accessible seat)"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      409 {object} response.StandardApiResponse "Some seats are already held or booked"
+// @Failure      503 {object} response.StandardApiResponse "Redis is down and new holds are refused until it recovers - HOLDS_UNAVAILABLE"
+// @Router       /api/v1/seats/hold [post]
 func (c *Controller) HoldSeats(ctx *gin.Context) {
 	var req SeatHoldRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
@@ -111,13 +314,33 @@ func (c *Controller) HoldSeats(ctx *gin.Context) {
 
 	holdResponse, err := c.service.HoldSeats(ctx.Request.Context(), req)
 	if err != nil {
-		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Failed to hold seats", nil, err.Error())
+		if respondHoldsUnavailable(ctx, err) {
+			return
+		}
+		response.RespondError(ctx, http.StatusBadRequest, holdErrorCode(err, http.StatusBadRequest), "Failed to hold seats", err.Error())
 		return
 	}
 
 	response.RespondJSON(ctx, "success", http.StatusOK, "Seats held successfully", holdResponse, nil)
 }
 
+// ReleaseHold godoc
+//
+// @Summary      Release seat hold
+// @Description  Release a previously held set of seats
+// @Description
+// @Description  Partner API keys need the `bookings:write` scope.
+// @Tags         Seats
+// @Produce      json
+// @Security     Bearer
+// @Security     ApiKey
+// @Param        holdId path string true "Hold ID"
+// @Success      200 {object} response.StandardApiResponse "Hold released successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Hold not found or expired"
+// @Router       /api/v1/seats/hold/{holdId} [delete]
 func (c *Controller) ReleaseHold(ctx *gin.Context) {
 	holdID := ctx.Param("holdId")
 	if holdID == "" {
@@ -127,13 +350,92 @@ func (c *Controller) ReleaseHold(ctx *gin.Context) {
 
 	err := c.service.ReleaseHold(ctx.Request.Context(), holdID)
 	if err != nil {
-		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Failed to release hold", nil, err.Error())
+		response.RespondError(ctx, http.StatusBadRequest, holdErrorCode(err, http.StatusBadRequest), "Failed to release hold", err.Error())
 		return
 	}
 
 	response.RespondJSON(ctx, "success", http.StatusOK, "Hold released successfully", nil, nil)
 }
 
+// ExtendHold godoc
+//
+// @Summary      Extend seat hold
+// @Description  Atomically extend the TTL of the caller's hold. The total hold lifetime is capped by
+// @Description  REDIS_SEAT_HOLD_MAX_TTL. A HOLD_EXTENDED event is published on the Redis channel
+// @Description  `hold_events:{owner_token}` so checkout clients can refresh their timers. The owner
+// @Description  token is an opaque HMAC of the user ID; raw user IDs are never written to hold keys.
+// @Tags         Seats
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        holdId path string true "Hold ID"
+// @Param        request body ExtendHoldRequest false "Request body"
+// @Success      200 {object} response.StandardApiResponse{data=HoldExtensionResponse} "Hold extended successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse "Hold belongs to a different user"
+// @Failure      404 {object} response.StandardApiResponse "Hold not found or expired"
+// @Failure      409 {object} response.StandardApiResponse "Maximum hold time reached"
+// @Router       /api/v1/seats/holds/{holdId}/extend [post]
+func (c *Controller) ExtendHold(ctx *gin.Context) {
+	holdID := ctx.Param("holdId")
+	if holdID == "" {
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Hold ID is required", nil, "missing hold ID")
+		return
+	}
+
+	userID, exists := ctx.Get("user_id")
+	if !exists {
+		response.RespondJSON(ctx, "error", http.StatusUnauthorized, "User not authenticated", nil, nil)
+		return
+	}
+
+	// Body is optional
+	var req ExtendHoldRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid request data", nil, err.Error())
+			return
+		}
+	}
+
+	result, err := c.service.ExtendHold(ctx.Request.Context(), holdID, userID.(string), req)
+	if err != nil {
+		statusCode := http.StatusBadRequest
+		switch err.Error() {
+		case "hold not found or expired":
+			statusCode = http.StatusNotFound
+		case "hold belongs to different user":
+			statusCode = http.StatusForbidden
+		case "maximum hold time reached":
+			statusCode = http.StatusConflict
+		}
+		response.RespondError(ctx, statusCode, holdErrorCode(err, statusCode), "Failed to extend hold", err.Error())
+		return
+	}
+
+	response.RespondJSON(ctx, "success", http.StatusOK, "Hold extended successfully", result, nil)
+}
+
+// ValidateHold godoc
+//
+// @Summary      Validate seat hold
+// @Description  Validate if a seat hold is still active and valid. The hold owner's user ID in the
+// @Description  details is masked when PRIVACY_REDACT_DEBUG_PII is enabled.
+// @Description
+// @Description  Partner API keys need the `bookings:write` scope.
+// @Tags         Seats
+// @Produce      json
+// @Security     Bearer
+// @Security     ApiKey
+// @Param        holdId path string true "Hold ID"
+// @Param        user_id query string false "User ID"
+// @Success      200 {object} response.StandardApiResponse{data=HoldValidationResult} "Hold validation result"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/seats/hold/{holdId}/validate [get]
 func (c *Controller) ValidateHold(ctx *gin.Context) {
 	holdID := ctx.Param("holdId")
 	if holdID == "" {
@@ -158,9 +460,25 @@ func (c *Controller) ValidateHold(ctx *gin.Context) {
 		return
 	}
 
+	c.redactHold(result.Details)
 	response.RespondJSON(ctx, "success", http.StatusOK, "Hold is valid", result, nil)
 }
 
+// GetUserHolds godoc
+//
+// @Summary      Get user holds
+// @Description  Get all current holds for a specific user. User IDs in the response are masked when
+// @Description  PRIVACY_REDACT_DEBUG_PII is enabled.
+// @Tags         Seats
+// @Produce      json
+// @Security     Bearer
+// @Param        userId path string true "User ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=[]SeatHoldDetails} "User holds retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/users/{userId}/holds [get]
 func (c *Controller) GetUserHolds(ctx *gin.Context) {
 	userID := ctx.Param("userId")
 	if userID == "" {
@@ -174,11 +492,33 @@ func (c *Controller) GetUserHolds(ctx *gin.Context) {
 		return
 	}
 
+	for i := range holds {
+		c.redactHold(&holds[i])
+	}
+
 	response.RespondJSON(ctx, "success", http.StatusOK, "User holds retrieved successfully", holds, nil)
 }
 
 //  AVAILABILITY CHECKS
 
+// CheckSeatAvailability godoc
+//
+// @Summary      Check seat availability
+// @Description  Check availability status for multiple seats
+// @Description
+// @Description  Partner API keys need the `bookings:write` scope.
+// @Tags         Seats
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Security     ApiKey
+// @Param        request body object true "Request body"
+// @Success      200 {object} response.StandardApiResponse{data=SeatAvailabilityResponse} "Seat availability check completed"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/seats/availability [post]
 func (c *Controller) CheckSeatAvailability(ctx *gin.Context) {
 	var req struct {
 		SeatIDs []string `json:"seat_ids" binding:"required,min=1"`
@@ -194,10 +534,25 @@ func (c *Controller) CheckSeatAvailability(ctx *gin.Context) {
 		response.RespondJSON(ctx, "error", http.StatusInternalServerError, "Failed to check availability", nil, err.Error())
 		return
 	}
+	c.flagApproximate(ctx, availability.Approximate)
 
 	response.RespondJSON(ctx, "success", http.StatusOK, "Seat availability checked successfully", availability, nil)
 }
 
+// GetAvailableSeatsInSection godoc
+//
+// @Summary      Get available seats in section
+// @Description  Get all available seats in a specific section for an event
+// @Tags         Seats
+// @Produce      json
+// @Param        sectionId path string true "Section ID" Format(uuid)
+// @Param        event_id query string true "Event ID to check availability for" Format(uuid)
+// @Param        attributes query string false "Comma separated seat attributes that every returned seat must have"
+// @Param        exclude_attributes query string false "Comma separated seat attributes to exclude"
+// @Success      200 {object} response.StandardApiResponse{data=[]SeatResponse} "Available seats retrieved successfully. While Redis is down seats come from Postgres alone with hold_unknown set, so some of them may be held."
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/sections/{sectionId}/seats/available [get]
 func (c *Controller) GetAvailableSeatsInSection(ctx *gin.Context) {
 	sectionID := ctx.Param("sectionId")
 	if sectionID == "" {
@@ -211,13 +566,417 @@ func (c *Controller) GetAvailableSeatsInSection(ctx *gin.Context) {
 		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Event ID is required", nil, "missing event_id query parameter")
 		return
 	}
-	log.Default().Println("Event ID for availability check:", eventID)
-	log.Default().Println("Section ID for availability check:", sectionID)
-	seats, err := c.service.GetAvailableSeatsInSectionForEvent(ctx.Request.Context(), sectionID, eventID)
+	filter, err := ParseSeatAttributeFilter(ctx.Query("attributes"), ctx.Query("exclude_attributes"))
+	if err != nil {
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid attribute filter", nil, err.Error())
+		return
+	}
+
+	seats, err := c.service.GetAvailableSeatsInSectionForEvent(ctx.Request.Context(), sectionID, eventID, filter)
 	if err != nil {
 		response.RespondJSON(ctx, "error", http.StatusInternalServerError, "Failed to get available seats", nil, err.Error())
 		return
 	}
+	c.flagApproximate(ctx, c.service.AvailabilityDegraded() || (len(seats) > 0 && seats[0].HoldUnknown))
 
 	response.RespondJSON(ctx, "success", http.StatusOK, "Available seats retrieved successfully", seats, nil)
 }
+
+//  GENERAL ADMISSION
+
+// CreateTicketType godoc
+//
+// @Summary      Create a general admission ticket type (Admin only)
+// @Description  Sells an event, or one venue section of it, by quantity. Seats in that section can no longer be held individually for the event.
+// @Description
+// @Description  Requires the `venues:manage` permission.
+// @Tags         Seats
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        request body CreateTicketTypeRequest true "Request body"
+// @Success      201 {object} response.StandardApiResponse{data=TicketTypeResponse} "Ticket type created successfully"
+// @Failure      400 {object} response.StandardApiResponse "Invalid request, or the section is not part of the event's venue or already has seats booked"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Event or section not found"
+// @Failure      409 {object} response.StandardApiResponse "A ticket type with this name already exists for the event"
+// @Router       /api/v1/admin/ticket-types [post]
+func (c *Controller) CreateTicketType(ctx *gin.Context) {
+	var req CreateTicketTypeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid request data", nil, err.Error())
+		return
+	}
+
+	ticketType, err := c.service.CreateTicketType(ctx.Request.Context(), req)
+	if err != nil {
+		response.RespondJSON(ctx, "error", ticketTypeErrorStatus(err), "Failed to create ticket type", nil, err.Error())
+		return
+	}
+
+	response.RespondJSON(ctx, "success", http.StatusCreated, "Ticket type created successfully", ticketType, nil)
+}
+
+// UpdateTicketType godoc
+//
+// @Summary      Update a general admission ticket type (Admin only)
+// @Description  Capacity cannot go below the tickets already sold
+// @Description
+// @Description  Requires the `venues:manage` permission.
+// @Tags         Seats
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "ID" Format(uuid)
+// @Param        request body UpdateTicketTypeRequest true "Request body"
+// @Success      200 {object} response.StandardApiResponse{data=TicketTypeResponse} "Ticket type updated successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Ticket type not found"
+// @Failure      409 {object} response.StandardApiResponse "Name taken or capacity below tickets sold"
+// @Router       /api/v1/admin/ticket-types/{id} [put]
+func (c *Controller) UpdateTicketType(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	var req UpdateTicketTypeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid request data", nil, err.Error())
+		return
+	}
+
+	ticketType, err := c.service.UpdateTicketType(ctx.Request.Context(), id, req)
+	if err != nil {
+		response.RespondJSON(ctx, "error", ticketTypeErrorStatus(err), "Failed to update ticket type", nil, err.Error())
+		return
+	}
+
+	response.RespondJSON(ctx, "success", http.StatusOK, "Ticket type updated successfully", ticketType, nil)
+}
+
+// DeleteTicketType godoc
+//
+// @Summary      Delete a general admission ticket type (Admin only)
+// @Description  Only ticket types without sold or held tickets can be deleted
+// @Description
+// @Description  Requires the `venues:manage` permission.
+// @Tags         Seats
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse "Ticket type deleted successfully"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Ticket type not found"
+// @Failure      409 {object} response.StandardApiResponse "Tickets are sold or held"
+// @Router       /api/v1/admin/ticket-types/{id} [delete]
+func (c *Controller) DeleteTicketType(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	if err := c.service.DeleteTicketType(ctx.Request.Context(), id); err != nil {
+		response.RespondJSON(ctx, "error", ticketTypeErrorStatus(err), "Failed to delete ticket type", nil, err.Error())
+		return
+	}
+
+	response.RespondJSON(ctx, "success", http.StatusOK, "Ticket type deleted successfully", nil, nil)
+}
+
+// GetTicketType godoc
+//
+// @Summary      Get a general admission ticket type
+// @Tags         Seats
+// @Produce      json
+// @Param        id path string true "ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=TicketTypeResponse} "Ticket type retrieved successfully"
+// @Failure      404 {object} response.StandardApiResponse "Ticket type not found"
+// @Router       /api/v1/ticket-types/{id} [get]
+func (c *Controller) GetTicketType(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	ticketType, err := c.service.GetTicketType(ctx.Request.Context(), id)
+	if err != nil {
+		response.RespondJSON(ctx, "error", ticketTypeErrorStatus(err), "Failed to get ticket type", nil, err.Error())
+		return
+	}
+
+	response.RespondJSON(ctx, "success", http.StatusOK, "Ticket type retrieved successfully", ticketType, nil)
+}
+
+// GetEventTicketTypes godoc
+//
+// @Summary      List an event's general admission ticket types
+// @Description  Ticket types with sold, held and remaining counts
+// @Tags         Seats
+// @Produce      json
+// @Param        event_id query string true "Event ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=[]TicketTypeResponse} "Ticket types retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Event not found"
+// @Router       /api/v1/ticket-types [get]
+func (c *Controller) GetEventTicketTypes(ctx *gin.Context) {
+	eventID := ctx.Query("event_id")
+	if eventID == "" {
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Event ID is required", nil, "missing event_id query parameter")
+		return
+	}
+
+	ticketTypes, err := c.service.GetEventTicketTypes(ctx.Request.Context(), eventID)
+	if err != nil {
+		response.RespondJSON(ctx, "error", ticketTypeErrorStatus(err), "Failed to get ticket types", nil, err.Error())
+		return
+	}
+	c.flagApproximate(ctx, c.service.AvailabilityDegraded() || (len(ticketTypes) > 0 && ticketTypes[0].HeldUnknown))
+
+	response.RespondJSON(ctx, "success", http.StatusOK, "Ticket types retrieved successfully", ticketTypes, nil)
+}
+
+// HoldTickets godoc
+//
+// @Summary      Hold general admission tickets
+// @Description  Takes a quantity of tickets off the ticket type's remaining count. The hold is confirmed, extended and released through the same endpoints as seat holds.
+// @Tags         Seats
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "ID" Format(uuid)
+// @Param        request body TicketHoldRequest true "Request body"
+// @Success      200 {object} response.StandardApiResponse{data=TicketHoldResponse} "Tickets held successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Ticket type not found"
+// @Failure      409 {object} response.StandardApiResponse "Not enough tickets left"
+// @Failure      503 {object} response.StandardApiResponse "Redis is down and new holds are refused until it recovers - HOLDS_UNAVAILABLE"
+// @Router       /api/v1/ticket-types/{id}/hold [post]
+func (c *Controller) HoldTickets(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	var req TicketHoldRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid request data", nil, err.Error())
+		return
+	}
+
+	holdResponse, err := c.service.HoldTickets(ctx.Request.Context(), id, req)
+	if err != nil {
+		if respondHoldsUnavailable(ctx, err) {
+			return
+		}
+		statusCode := http.StatusBadRequest
+		if errors.Is(err, ErrNotEnoughTickets) {
+			statusCode = http.StatusConflict
+		} else if err.Error() == "ticket type not found" {
+			statusCode = http.StatusNotFound
+		}
+		response.RespondError(ctx, statusCode, holdErrorCode(err, statusCode), "Failed to hold tickets", err.Error())
+		return
+	}
+
+	response.RespondJSON(ctx, "success", http.StatusOK, "Tickets held successfully", holdResponse, nil)
+}
+
+//  EVENT SEAT BLOCKS
+
+// BlockSeats godoc
+//
+// @Summary      Block seats for an event (Admin)
+// @Description  Takes seats off sale for this event only, for guests, press or equipment. Blocked seats
+// @Description  can't be held and show as `BLOCKED` with their reason in the event layout. Seats that are
+// @Description  booked, held or already blocked for the event are refused.
+// @Description
+// @Description  Requires the `venues:manage` permission.
+// @Tags         Admin Seats
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        eventId path string true "Event ID" Format(uuid)
+// @Param        request body BlockSeatsRequest true "Request body"
+// @Success      201 {object} response.StandardApiResponse{data=[]SeatBlockResponse} "Seats blocked successfully"
+// @Failure      400 {object} response.StandardApiResponse "Seats are not part of the event's venue or are sold as general admission"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Event or seat not found"
+// @Failure      409 {object} response.StandardApiResponse "Seats are booked, held or already blocked for the event"
+// @Failure      503 {object} response.StandardApiResponse "Holds can't be checked while Redis is down - HOLDS_UNAVAILABLE"
+// @Router       /api/v1/admin/events/{eventId}/seat-blocks [post]
+func (c *Controller) BlockSeats(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		response.RespondJSON(ctx, "error", http.StatusUnauthorized, "User not authenticated", nil, nil)
+		return
+	}
+
+	var req BlockSeatsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid request data", nil, err.Error())
+		return
+	}
+
+	blocks, err := c.service.BlockSeats(ctx.Request.Context(), ctx.Param("eventId"), adminID.(string), req)
+	if err != nil {
+		if respondHoldsUnavailable(ctx, err) {
+			return
+		}
+		respondSeatBlockError(ctx, err, "Failed to block seats")
+		return
+	}
+
+	response.RespondJSON(ctx, "success", http.StatusCreated, "Seats blocked successfully", blocks, nil)
+}
+
+// ListSeatBlocks godoc
+//
+// @Summary      List seat blocks for an event (Admin)
+// @Description  Seats held back from sale for the event. Requires the venues:manage permission.
+// @Description
+// @Description  Requires the `venues:manage` permission.
+// @Tags         Admin Seats
+// @Produce      json
+// @Security     Bearer
+// @Param        eventId path string true "Event ID" Format(uuid)
+// @Param        include_released query boolean false "Also list blocks that were released" default(false)
+// @Success      200 {object} response.StandardApiResponse{data=[]SeatBlockResponse} "Seat blocks retrieved successfully"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Event not found"
+// @Router       /api/v1/admin/events/{eventId}/seat-blocks [get]
+func (c *Controller) ListSeatBlocks(ctx *gin.Context) {
+	includeReleased := ctx.Query("include_released") == "true"
+
+	blocks, err := c.service.ListSeatBlocks(ctx.Request.Context(), ctx.Param("eventId"), includeReleased)
+	if err != nil {
+		respondSeatBlockError(ctx, err, "Failed to get seat blocks")
+		return
+	}
+
+	response.RespondJSON(ctx, "success", http.StatusOK, "Seat blocks retrieved successfully", blocks, nil)
+}
+
+// ReleaseSeatBlocks godoc
+//
+// @Summary      Release seat blocks (Admin)
+// @Description  Returns blocked seats to sale and offers them to the event's waitlist, as seats freed by a
+// @Description  cancellation are. Seats that aren't blocked are ignored.
+// @Description
+// @Description  Requires the `venues:manage` permission.
+// @Tags         Admin Seats
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        eventId path string true "Event ID" Format(uuid)
+// @Param        request body ReleaseSeatBlocksRequest true "Request body"
+// @Success      200 {object} response.StandardApiResponse{data=SeatBlockReleaseResponse} "Seat blocks released successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "None of the seats are blocked for the event"
+// @Router       /api/v1/admin/events/{eventId}/seat-blocks/release [post]
+func (c *Controller) ReleaseSeatBlocks(ctx *gin.Context) {
+	adminID, exists := ctx.Get("user_id")
+	if !exists {
+		response.RespondJSON(ctx, "error", http.StatusUnauthorized, "User not authenticated", nil, nil)
+		return
+	}
+
+	var req ReleaseSeatBlocksRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid request data", nil, err.Error())
+		return
+	}
+
+	result, err := c.service.ReleaseSeatBlocks(ctx.Request.Context(), ctx.Param("eventId"), adminID.(string), req)
+	if err != nil {
+		respondSeatBlockError(ctx, err, "Failed to release seat blocks")
+		return
+	}
+
+	response.RespondJSON(ctx, "success", http.StatusOK, "Seat blocks released successfully", result, nil)
+}
+
+// flagApproximate marks availability served without Redis holds, so clients can
+// tell it apart even when the body has no seats to carry the flag
+func (c *Controller) flagApproximate(ctx *gin.Context, approximate bool) {
+	if approximate {
+		ctx.Header("X-Availability-Approximate", "true")
+	}
+}
+
+// respondHoldsUnavailable answers 503 while Redis is down and holds are refused
+func respondHoldsUnavailable(ctx *gin.Context, err error) bool {
+	if !errors.Is(err, ErrHoldsUnavailable) {
+		return false
+	}
+	ctx.Header("Retry-After", "30")
+	response.RespondError(ctx, http.StatusServiceUnavailable, response.CodeHoldsUnavailable, "Seat holds temporarily unavailable", err.Error())
+	return true
+}
+
+func ticketTypeErrorStatus(err error) int {
+	msg := err.Error()
+	switch {
+	case msg == "ticket type not found" || msg == "event not found" || msg == "section not found":
+		return http.StatusNotFound
+	case strings.HasPrefix(msg, "invalid"):
+		return http.StatusBadRequest
+	case strings.Contains(msg, "already exists") || strings.Contains(msg, "cannot be"):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// holdErrorCode picks the error code of a failed seat or ticket hold, falling
+// back to the generic code of its status
+func holdErrorCode(err error, status int) string {
+	msg := err.Error()
+	switch {
+	case errors.Is(err, ErrSeatHeld):
+		return response.CodeSeatAlreadyHeld
+	case errors.Is(err, ErrSeatsBooked):
+		return response.CodeSeatAlreadyBooked
+	case errors.Is(err, ErrSeatsUnavailable):
+		return response.CodeSeatUnavailable
+	case errors.Is(err, ErrSeatBlocked):
+		return response.CodeSeatBlocked
+	case errors.Is(err, ErrNotEnoughTickets):
+		return response.CodeNotEnoughTickets
+	case msg == "ticket type not found":
+		return response.CodeTicketTypeNotFound
+	case strings.Contains(msg, "hold not found"):
+		return response.CodeHoldNotFound
+	case msg == "hold belongs to different user":
+		return response.CodeHoldForbidden
+	case msg == "maximum hold time reached":
+		return response.CodeHoldLimitReached
+	default:
+		return response.StatusErrorCode(status)
+	}
+}
+
+// respondSeatBlockError answers a failed seat block operation with its status and error code
+func respondSeatBlockError(ctx *gin.Context, err error, message string) {
+	status := seatBlockErrorStatus(err)
+	code := response.StatusErrorCode(status)
+	switch {
+	case errors.Is(err, ErrSeatBlockNotFound):
+		code = response.CodeSeatBlockNotFound
+	case errors.Is(err, ErrSeatBlockConflict):
+		code = response.CodeSeatBlockConflict
+	}
+	response.RespondError(ctx, status, code, message, err.Error())
+}
+
+func seatBlockErrorStatus(err error) int {
+	msg := err.Error()
+	switch {
+	case errors.Is(err, ErrSeatBlockNotFound) || msg == "event not found" || msg == "seat not found":
+		return http.StatusNotFound
+	case errors.Is(err, ErrSeatBlockConflict):
+		return http.StatusConflict
+	case strings.HasPrefix(msg, "invalid"):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,438 @@
+package seats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"evently/pkg/metrics"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// General admission sells a ticket type by quantity. Postgres holds the
+// capacity and the tickets sold, Redis the live remaining count that holds take
+// from atomically. The count is seeded from Postgres whenever Redis has none.
+
+//  TICKET TYPES
+
+func (s *service) CreateTicketType(ctx context.Context, req CreateTicketTypeRequest) (*TicketTypeResponse, error) {
+	eventID, err := uuid.Parse(req.EventID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid event ID: %w", err)
+	}
+
+	event, err := s.getTicketingEvent(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	ticketType := &TicketType{
+		EventID:         eventID,
+		Name:            strings.TrimSpace(req.Name),
+		Capacity:        req.Capacity,
+		PriceMultiplier: req.PriceMultiplier,
+	}
+	if ticketType.PriceMultiplier == 0 {
+		ticketType.PriceMultiplier = 1.0
+	}
+
+	if req.SectionID != nil {
+		sectionID, err := uuid.Parse(*req.SectionID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid section ID: %w", err)
+		}
+		if err := s.checkGeneralAdmissionSection(ctx, event, sectionID); err != nil {
+			return nil, err
+		}
+		ticketType.SectionID = &sectionID
+	}
+
+	if err := s.checkTicketTypeName(ctx, eventID, uuid.Nil, ticketType.Name); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CreateTicketType(ctx, ticketType); err != nil {
+		return nil, fmt.Errorf("failed to create ticket type: %w", err)
+	}
+
+	responses, err := s.ticketTypeResponses(ctx, event, []TicketType{*ticketType})
+	if err != nil {
+		return nil, err
+	}
+	return &responses[0], nil
+}
+
+func (s *service) UpdateTicketType(ctx context.Context, id string, req UpdateTicketTypeRequest) (*TicketTypeResponse, error) {
+	ticketType, err := s.getTicketType(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := make(map[string]interface{})
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if err := s.checkTicketTypeName(ctx, ticketType.EventID, ticketType.ID, name); err != nil {
+			return nil, err
+		}
+		updates["name"] = name
+		ticketType.Name = name
+	}
+	if req.PriceMultiplier != nil {
+		updates["price_multiplier"] = *req.PriceMultiplier
+		ticketType.PriceMultiplier = *req.PriceMultiplier
+	}
+
+	delta := 0
+	if req.Capacity != nil {
+		sold, err := s.repo.CountTicketsSold(ctx, []uuid.UUID{ticketType.ID})
+		if err != nil {
+			return nil, err
+		}
+		if *req.Capacity < sold[ticketType.ID] {
+			return nil, fmt.Errorf("capacity cannot be below the %d tickets already sold", sold[ticketType.ID])
+		}
+		delta = *req.Capacity - ticketType.Capacity
+		updates["capacity"] = *req.Capacity
+		ticketType.Capacity = *req.Capacity
+	}
+
+	if len(updates) > 0 {
+		if err := s.repo.UpdateTicketType(ctx, ticketType.ID, updates); err != nil {
+			return nil, fmt.Errorf("failed to update ticket type: %w", err)
+		}
+	}
+
+	// Holds in flight stay counted, so the live count moves by the difference
+	if delta != 0 {
+		if err := s.repo.AdjustTicketsRemaining(ctx, ticketType.ID, delta); err != nil {
+			log.WarnContext(ctx, "Failed to adjust remaining tickets", "ticket_type_id", ticketType.ID, "error", err)
+		}
+	}
+
+	event, err := s.getTicketingEvent(ctx, ticketType.EventID)
+	if err != nil {
+		return nil, err
+	}
+	responses, err := s.ticketTypeResponses(ctx, event, []TicketType{*ticketType})
+	if err != nil {
+		return nil, err
+	}
+	return &responses[0], nil
+}
+
+func (s *service) DeleteTicketType(ctx context.Context, id string) error {
+	ticketType, err := s.getTicketType(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	event, err := s.getTicketingEvent(ctx, ticketType.EventID)
+	if err != nil {
+		return err
+	}
+	responses, err := s.ticketTypeResponses(ctx, event, []TicketType{*ticketType})
+	if err != nil {
+		return err
+	}
+	if responses[0].Sold > 0 {
+		return fmt.Errorf("ticket type has %d tickets sold and cannot be deleted", responses[0].Sold)
+	}
+	if responses[0].Held > 0 {
+		return fmt.Errorf("ticket type has %d tickets on hold and cannot be deleted", responses[0].Held)
+	}
+
+	if err := s.repo.DeleteTicketType(ctx, ticketType.ID); err != nil {
+		return fmt.Errorf("failed to delete ticket type: %w", err)
+	}
+
+	if err := s.repo.ClearTicketsRemaining(ctx, ticketType.ID); err != nil {
+		log.WarnContext(ctx, "Failed to clear remaining tickets", "ticket_type_id", ticketType.ID, "error", err)
+	}
+	return nil
+}
+
+func (s *service) GetTicketType(ctx context.Context, id string) (*TicketTypeResponse, error) {
+	ticketType, err := s.getTicketType(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := s.getTicketingEvent(ctx, ticketType.EventID)
+	if err != nil {
+		return nil, err
+	}
+	responses, err := s.ticketTypeResponses(ctx, event, []TicketType{*ticketType})
+	if err != nil {
+		return nil, err
+	}
+	return &responses[0], nil
+}
+
+// GetEventTicketTypes lists an event's general admission ticket types with remaining counts
+func (s *service) GetEventTicketTypes(ctx context.Context, eventID string) ([]TicketTypeResponse, error) {
+	eventUUID, err := uuid.Parse(eventID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid event ID: %w", err)
+	}
+
+	event, err := s.getTicketingEvent(ctx, eventUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	ticketTypes, err := s.repo.GetTicketTypesByEvent(ctx, eventUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ticket types: %w", err)
+	}
+
+	return s.ticketTypeResponses(ctx, event, ticketTypes)
+}
+
+//  TICKET HOLDING
+
+// HoldTickets holds a quantity of a general admission ticket type. The hold
+// shares the seat hold lifecycle: validate, extend and release work the same.
+func (s *service) HoldTickets(ctx context.Context, ticketTypeID string, req TicketHoldRequest) (*TicketHoldResponse, error) {
+	if s.redisWatch.Degraded() {
+		return nil, ErrHoldsUnavailable
+	}
+
+	ticketType, err := s.getTicketType(ctx, ticketTypeID)
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := s.getTicketingEvent(ctx, ticketType.EventID)
+	if err != nil {
+		return nil, err
+	}
+
+	sold, err := s.repo.CountTicketsSold(ctx, []uuid.UUID{ticketType.ID})
+	if err != nil {
+		metrics.RecordSeatHold(ticketType.EventID.String(), metrics.ResultError)
+		return nil, err
+	}
+
+	// Redis only sees an opaque token and the encrypted user ID
+	if s.privacy == nil {
+		metrics.RecordSeatHold(ticketType.EventID.String(), metrics.ResultError)
+		return nil, fmt.Errorf("seat holding disabled - hold privacy key not configured")
+	}
+	owner, err := s.privacy.Owner(req.UserID)
+	if err != nil {
+		metrics.RecordSeatHold(ticketType.EventID.String(), metrics.ResultError)
+		return nil, fmt.Errorf("failed to seal hold owner: %w", err)
+	}
+
+	holdID := uuid.New().String()
+	ttl := s.config.Redis.SeatHoldTTL
+	seed := ticketType.Capacity - sold[ticketType.ID]
+	log.InfoContext(ctx, "Holding tickets", "hold_id", holdID, "owner", owner.Token, "ticket_type_id", ticketType.ID, "quantity", req.Quantity, "ttl", ttl)
+
+	remaining, err := s.repo.AtomicHoldTickets(ctx, ticketType.ID, req.Quantity, seed, owner, holdID, event.ID.String(), ttl)
+	if err != nil {
+		if errors.Is(err, ErrNotEnoughTickets) {
+			metrics.RecordSeatHold(ticketType.EventID.String(), metrics.ResultContention)
+		} else {
+			metrics.RecordSeatHold(ticketType.EventID.String(), metrics.ResultError)
+		}
+		return nil, fmt.Errorf("failed to hold tickets: %w", err)
+	}
+	metrics.RecordSeatHold(ticketType.EventID.String(), metrics.ResultSuccess)
+
+	s.trackHoldExpiry(ctx, TrackedHold{
+		HoldID:       holdID,
+		EventID:      event.ID.String(),
+		SealedOwner:  owner.Sealed,
+		TicketTypeID: ticketType.ID.String(),
+		Quantity:     req.Quantity,
+		ExpiresAt:    time.Now().Add(ttl),
+	})
+
+	// Feed the hold monitor's conversion tracking
+	if err := s.repo.RecordHoldCreated(ctx); err != nil {
+		log.WarnContext(ctx, "Failed to record hold metric", "hold_id", holdID, "error", err)
+	}
+
+	unitPrice := event.BasePrice * ticketType.PriceMultiplier
+	return &TicketHoldResponse{
+		HoldID:       holdID,
+		EventID:      event.ID.String(),
+		UserID:       req.UserID,
+		TicketTypeID: ticketType.ID.String(),
+		Name:         ticketType.Name,
+		Quantity:     req.Quantity,
+		UnitPrice:    unitPrice,
+		TotalPrice:   unitPrice * float64(req.Quantity),
+		Currency:     event.Currency,
+		Remaining:    remaining,
+		ExpiresAt:    time.Now().Add(ttl),
+		TTL:          int(ttl.Seconds()),
+	}, nil
+}
+
+// GetTicketsByHoldID returns the ticket type, quantity and price of a general admission hold
+func (s *service) GetTicketsByHoldID(ctx context.Context, holdID string) (*TicketHoldInfo, error) {
+	details, err := s.repo.GetHoldDetails(ctx, holdID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hold details: %w", err)
+	}
+	if details.TicketTypeID == "" {
+		return nil, fmt.Errorf("hold is not a general admission hold")
+	}
+
+	ticketType, err := s.getTicketType(ctx, details.TicketTypeID)
+	if err != nil {
+		return nil, err
+	}
+	event, err := s.getTicketingEvent(ctx, ticketType.EventID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TicketHoldInfo{
+		TicketTypeID: ticketType.ID,
+		SectionID:    ticketType.SectionID,
+		Name:         ticketType.Name,
+		Quantity:     details.Quantity,
+		UnitPrice:    event.BasePrice * ticketType.PriceMultiplier,
+		BasePrice:    event.BasePrice,
+	}, nil
+}
+
+// ConsumeHold releases a hold whose booking went through. Seats are freed as
+// usual, general admission tickets stay taken because they are now sold.
+func (s *service) ConsumeHold(ctx context.Context, holdID string) error {
+	if err := s.repo.ConsumeTicketHold(ctx, holdID); err != nil {
+		return err
+	}
+	return s.ReleaseHold(ctx, holdID)
+}
+
+// ReturnTickets puts the tickets of a cancelled booking back on sale
+func (s *service) ReturnTickets(ctx context.Context, ticketTypeID uuid.UUID, quantity int) error {
+	return s.repo.AdjustTicketsRemaining(ctx, ticketTypeID, quantity)
+}
+
+//  HELPERS
+
+func (s *service) getTicketType(ctx context.Context, id string) (*TicketType, error) {
+	ticketTypeID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ticket type ID: %w", err)
+	}
+
+	ticketType, err := s.repo.GetTicketTypeByID(ctx, ticketTypeID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("ticket type not found")
+		}
+		return nil, fmt.Errorf("failed to get ticket type: %w", err)
+	}
+	return ticketType, nil
+}
+
+func (s *service) getTicketingEvent(ctx context.Context, eventID uuid.UUID) (*TicketingEvent, error) {
+	event, err := s.repo.GetTicketingEvent(ctx, eventID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("event not found")
+		}
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+	return event, nil
+}
+
+// checkGeneralAdmissionSection makes sure a section can be sold by quantity:
+// it belongs to the event's venue and none of its seats are booked already
+func (s *service) checkGeneralAdmissionSection(ctx context.Context, event *TicketingEvent, sectionID uuid.UUID) error {
+	templateID, err := s.repo.GetSectionTemplateID(ctx, sectionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("section not found")
+		}
+		return fmt.Errorf("failed to get section: %w", err)
+	}
+	if templateID != event.VenueTemplateID {
+		return fmt.Errorf("invalid ticket type: section %s is not part of the event's venue", sectionID)
+	}
+
+	seatBookings, err := s.getSeatBookingsForEvent(ctx, event.ID, sectionID)
+	if err != nil {
+		return err
+	}
+	if len(seatBookings) > 0 {
+		return fmt.Errorf("invalid ticket type: section %s already has %d seats booked for this event", sectionID, len(seatBookings))
+	}
+	return nil
+}
+
+func (s *service) checkTicketTypeName(ctx context.Context, eventID, excludeID uuid.UUID, name string) error {
+	ticketTypes, err := s.repo.GetTicketTypesByEvent(ctx, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to get ticket types: %w", err)
+	}
+	for _, ticketType := range ticketTypes {
+		if ticketType.ID != excludeID && strings.EqualFold(ticketType.Name, name) {
+			return fmt.Errorf("ticket type %q already exists for this event", name)
+		}
+	}
+	return nil
+}
+
+// ticketTypeResponses adds sold and remaining counts. Without Redis the
+// remaining count cannot see holds and falls back to capacity minus sold,
+// flagged as held_unknown.
+func (s *service) ticketTypeResponses(ctx context.Context, event *TicketingEvent, ticketTypes []TicketType) ([]TicketTypeResponse, error) {
+	ids := make([]uuid.UUID, 0, len(ticketTypes))
+	for _, ticketType := range ticketTypes {
+		ids = append(ids, ticketType.ID)
+	}
+
+	sold, err := s.repo.CountTicketsSold(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	degraded := s.redisWatch.Degraded()
+	responses := make([]TicketTypeResponse, 0, len(ticketTypes))
+	for _, ticketType := range ticketTypes {
+		unsold := ticketType.Capacity - sold[ticketType.ID]
+
+		remaining, heldUnknown := unsold, degraded
+		if !degraded {
+			remaining, err = s.repo.TicketsRemaining(ctx, ticketType.ID, unsold)
+			if err != nil {
+				log.WarnContext(ctx, "Failed to read remaining tickets", "ticket_type_id", ticketType.ID, "error", err)
+				s.redisWatch.ReportFailure(err)
+				remaining, heldUnknown = unsold, true
+			}
+		}
+		remaining = max(0, min(remaining, unsold))
+
+		response := TicketTypeResponse{
+			ID:              ticketType.ID.String(),
+			EventID:         ticketType.EventID.String(),
+			Name:            ticketType.Name,
+			Capacity:        ticketType.Capacity,
+			Sold:            sold[ticketType.ID],
+			Held:            max(0, unsold-remaining),
+			Remaining:       remaining,
+			SoldOut:         remaining == 0,
+			HeldUnknown:     heldUnknown,
+			PriceMultiplier: ticketType.PriceMultiplier,
+			Price:           event.BasePrice * ticketType.PriceMultiplier,
+		}
+		if ticketType.SectionID != nil {
+			sectionID := ticketType.SectionID.String()
+			response.SectionID = &sectionID
+		}
+		responses = append(responses, response)
+	}
+
+	return responses, nil
+}
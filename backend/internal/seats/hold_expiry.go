@@ -0,0 +1,160 @@
+package seats
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"evently/internal/domainevents"
+	"evently/internal/shared/config"
+
+	"github.com/google/uuid"
+)
+
+// Holds expire through Redis key TTLs, which leaves no trace of the expiry.
+// While domain events are enabled every hold is also added to a sorted set
+// scored by its expiry; releasing or consuming a hold removes it again, so a
+// hold still in the set after its expiry, whose hold key is gone, expired.
+
+// TrackedHold is what is kept about a hold until it is released or expires.
+// The owner stays sealed so Redis does not reveal who held the seats.
+type TrackedHold struct {
+	HoldID       string    `json:"hold_id"`
+	EventID      string    `json:"event_id"`
+	SealedOwner  string    `json:"sealed_owner"`
+	SeatIDs      []string  `json:"seat_ids,omitempty"`
+	TicketTypeID string    `json:"ticket_type_id,omitempty"`
+	Quantity     int       `json:"quantity"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+// SeatHoldExpiredData is the data of SeatHoldExpired domain events
+type SeatHoldExpiredData struct {
+	HoldID       uuid.UUID `json:"hold_id"`
+	EventID      string    `json:"event_id"`
+	UserID       string    `json:"user_id,omitempty"`
+	SeatIDs      []string  `json:"seat_ids,omitempty"`
+	TicketTypeID string    `json:"ticket_type_id,omitempty"` // Set for general admission holds
+	Quantity     int       `json:"quantity"`
+	ExpiredAt    time.Time `json:"expired_at"`
+}
+
+// DomainEventPublisher records domain events for downstream consumers
+type DomainEventPublisher interface {
+	Publish(ctx context.Context, eventType string, aggregateID uuid.UUID, dedupKey string, data interface{}) error
+}
+
+// trackHoldExpiry starts tracking a new hold. A failure only costs the hold's
+// SeatHoldExpired event, so it is logged rather than failing the hold.
+func (s *service) trackHoldExpiry(ctx context.Context, hold TrackedHold) {
+	if !s.config.DomainEvents.Enabled {
+		return
+	}
+	if err := s.repo.TrackHoldExpiry(ctx, hold); err != nil {
+		log.WarnContext(ctx, "Failed to track hold expiry", "hold_id", hold.HoldID, "error", err)
+	}
+}
+
+func (s *service) untrackHoldExpiry(ctx context.Context, holdID string) {
+	if !s.config.DomainEvents.Enabled {
+		return
+	}
+	if err := s.repo.UntrackHoldExpiry(ctx, holdID); err != nil {
+		log.WarnContext(ctx, "Failed to untrack hold expiry", "hold_id", holdID, "error", err)
+	}
+}
+
+// HoldExpirySweeper records SeatHoldExpired for tracked holds that expired.
+// Several instances can run it at once; each expired hold is claimed by one.
+type HoldExpirySweeper struct {
+	repo      Repository
+	publisher DomainEventPublisher
+	privacy   *HoldPrivacy
+	interval  time.Duration
+	batchSize int
+	done      chan struct{}
+}
+
+// NewHoldExpirySweeper creates a new hold expiry sweeper
+func NewHoldExpirySweeper(repo Repository, publisher DomainEventPublisher, cfg *config.Config) *HoldExpirySweeper {
+	privacy, err := NewHoldPrivacy(cfg.Privacy.HoldKey)
+	if err != nil {
+		log.Warn("Hold owners can't be read, SeatHoldExpired is sent without user IDs", "error", err)
+	}
+
+	return &HoldExpirySweeper{
+		repo:      repo,
+		publisher: publisher,
+		privacy:   privacy,
+		interval:  cfg.DomainEvents.HoldSweepInterval,
+		batchSize: 100,
+		done:      make(chan struct{}),
+	}
+}
+
+// Start starts the sweep loop
+func (w *HoldExpirySweeper) Start(ctx context.Context) {
+	log.Info("Starting hold expiry sweeper", "interval", w.interval)
+	go w.run(ctx)
+}
+
+// Stop stops the sweep loop
+func (w *HoldExpirySweeper) Stop() {
+	log.Info("Stopping hold expiry sweeper")
+	close(w.done)
+}
+
+func (w *HoldExpirySweeper) run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := w.Sweep(ctx); err != nil {
+				log.Error("Hold expiry sweep failed", "error", err)
+			}
+		case <-w.done:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Sweep records an event for every tracked hold that has expired and returns
+// how many were found
+func (w *HoldExpirySweeper) Sweep(ctx context.Context) (int, error) {
+	holds, err := w.repo.ClaimExpiredHolds(ctx, time.Now(), w.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, hold := range holds {
+		holdID, err := uuid.Parse(hold.HoldID)
+		if err != nil {
+			continue
+		}
+
+		data := SeatHoldExpiredData{
+			HoldID:       holdID,
+			EventID:      hold.EventID,
+			SeatIDs:      hold.SeatIDs,
+			TicketTypeID: hold.TicketTypeID,
+			Quantity:     hold.Quantity,
+			ExpiredAt:    hold.ExpiresAt,
+		}
+		if w.privacy != nil && hold.SealedOwner != "" {
+			if userID, err := w.privacy.OpenOwner(hold.SealedOwner); err == nil {
+				data.UserID = userID
+			}
+		}
+
+		dedupKey := fmt.Sprintf("seat_hold:%s:expired", holdID)
+		if err := w.publisher.Publish(ctx, domainevents.SeatHoldExpired, holdID, dedupKey, data); err != nil {
+			log.ErrorContext(ctx, "Failed to record hold expiry", "hold_id", holdID, "error", err)
+		}
+	}
+
+	return len(holds), nil
+}
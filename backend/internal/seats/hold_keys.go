@@ -0,0 +1,111 @@
+package seats
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Every hold key carries the {holds} hash tag. A hold script touches the keys
+// of the hold, of each of its seats, of its owner and of general admission at
+// once, and Redis Cluster only runs a script when all of them hash to the same
+// slot. The tag keeps them together, on a single node of the cluster.
+
+const holdKeyPrefix = "{holds}:"
+
+const (
+	holdExpiryKey     = holdKeyPrefix + "hold_expiry"      // Tracked hold IDs scored by expiry
+	holdExpiryDataKey = holdKeyPrefix + "hold_expiry_data" // Tracked hold ID -> TrackedHold JSON
+	gaHoldsKey        = holdKeyPrefix + "ga_holds"         // General admission hold ID -> "ticket_type_id:quantity"
+	gaHoldExpiryKey   = holdKeyPrefix + "ga_hold_expiry"   // General admission hold IDs scored by expiry
+)
+
+func holdKey(holdID string) string {
+	return holdKeyPrefix + "hold:" + holdID
+}
+
+func holdSeatsKey(holdID string) string {
+	return holdKeyPrefix + "hold_seats:" + holdID
+}
+
+func holdOwnerKey(holdID string) string {
+	return holdKeyPrefix + "hold_owner:" + holdID
+}
+
+func seatHoldKey(seatID string) string {
+	return holdKeyPrefix + "seat_hold:" + seatID
+}
+
+func userHoldsKey(ownerToken string) string {
+	return holdKeyPrefix + "user_holds:" + ownerToken
+}
+
+func ticketsRemainingKey(ticketTypeID string) string {
+	return holdKeyPrefix + "ga_remaining:" + ticketTypeID
+}
+
+// luaHoldKeys is prepended to the hold scripts, which build the keys of seats
+// and owners they only learn while running
+const luaHoldKeys = `
+local TAG = "` + holdKeyPrefix + `"
+`
+
+// holdRetry retries hold scripts while Redis fails over. A Sentinel promotion
+// or a cluster resharding leaves a few seconds where the old master refuses
+// writes or can't be reached, which the client's own quick retries don't cover.
+type holdRetry struct {
+	attempts int
+	backoff  time.Duration
+}
+
+var defaultHoldRetry = holdRetry{attempts: 3, backoff: 200 * time.Millisecond}
+
+// do runs op until it succeeds, fails for a reason other than a failover, or
+// runs out of attempts. Scripts that aren't safe to run twice are only retried
+// when Redis refused to run them, not when the connection dropped mid-call.
+func (h holdRetry) do(ctx context.Context, name string, idempotent bool, op func() error) error {
+	err := op()
+	for attempt := 1; attempt <= h.attempts && err != nil && isFailoverError(err, idempotent); attempt++ {
+		log.WarnContext(ctx, "Redis failing over, retrying hold script", "script", name, "attempt", attempt, "error", err)
+		select {
+		case <-time.After(h.backoff * time.Duration(attempt)):
+		case <-ctx.Done():
+			return err
+		}
+		err = op()
+	}
+	return err
+}
+
+// isFailoverError tells whether err comes from a node that is failing over. A
+// dropped connection only counts for idempotent scripts, the call may have run.
+func isFailoverError(err error, idempotent bool) bool {
+	var redisErr redis.Error
+	if errors.As(err, &redisErr) {
+		if errors.Is(err, redis.Nil) {
+			return false
+		}
+		msg := redisErr.Error()
+		for _, prefix := range []string{"READONLY ", "LOADING ", "MASTERDOWN ", "TRYAGAIN ", "CLUSTERDOWN ", "NOREPLICAS "} {
+			if strings.HasPrefix(msg, prefix) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true // Never reached Redis
+	}
+	if !idempotent {
+		return false
+	}
+	var netErr net.Error
+	return errors.Is(err, io.EOF) || errors.As(err, &netErr)
+}
@@ -14,14 +14,52 @@ type Seat struct {
 	Row        string    `gorm:"not null" json:"row"`
 	Position   int       `gorm:"not null" json:"position"`
 	Status     string    `gorm:"type:varchar(20);check:status IN ('AVAILABLE', 'BLOCKED');default:'AVAILABLE'" json:"status"`
-	CreatedAt  time.Time `json:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at"`
+
+	// Accessibility and comfort attributes
+	WheelchairAccessible bool `gorm:"not null;default:false" json:"wheelchair_accessible"`
+	CompanionSeat        bool `gorm:"not null;default:false" json:"companion_seat"`
+	RestrictedView       bool `gorm:"not null;default:false" json:"restricted_view"`
+	Aisle                bool `gorm:"not null;default:false" json:"aisle"`
+
+	// Seat map position, in the venue template's map units
+	MapX *float64 `json:"map_x,omitempty"`
+	MapY *float64 `json:"map_y,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 
 	// Relationships
 	Section      *VenueSection `json:"section,omitempty" gorm:"foreignKey:SectionID;constraint:OnDelete:CASCADE;"`
 	SeatBookings []SeatBooking `json:"seat_bookings,omitempty" gorm:"foreignKey:SeatID;constraint:OnDelete:RESTRICT;"`
 }
 
+// TicketType is general admission inventory: a number of tickets for an event,
+// optionally admitting to one venue section, sold by quantity instead of by seat
+type TicketType struct {
+	ID              uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	EventID         uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_ticket_type_event_name" json:"event_id"`
+	SectionID       *uuid.UUID `gorm:"type:uuid;index" json:"section_id,omitempty"`
+	Name            string     `gorm:"type:varchar(100);not null;uniqueIndex:idx_ticket_type_event_name" json:"name"`
+	Capacity        int        `gorm:"not null" json:"capacity"`
+	PriceMultiplier float64    `gorm:"not null" json:"price_multiplier"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// SeatBlock takes a seat off sale for one event, for guests, press or
+// equipment. Released blocks are kept as a record of who held the seat back.
+type SeatBlock struct {
+	ID         uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	EventID    uuid.UUID  `gorm:"type:uuid;not null;index;uniqueIndex:idx_event_seat_blocks_active,where:released_at IS NULL" json:"event_id"`
+	SeatID     uuid.UUID  `gorm:"type:uuid;not null;index;uniqueIndex:idx_event_seat_blocks_active,where:released_at IS NULL" json:"seat_id"`
+	Reason     string     `gorm:"type:varchar(20);not null;check:reason IN ('VIP', 'PRESS', 'EQUIPMENT', 'OTHER')" json:"reason"`
+	Note       string     `gorm:"type:varchar(255)" json:"note,omitempty"`
+	BlockedBy  uuid.UUID  `gorm:"type:uuid;not null" json:"blocked_by"`
+	CreatedAt  time.Time  `json:"blocked_at"`
+	ReleasedAt *time.Time `json:"released_at,omitempty"`
+	ReleasedBy *uuid.UUID `gorm:"type:uuid" json:"released_by,omitempty"`
+}
+
 // Forward declarations
 type VenueSection struct {
 	ID              uuid.UUID `json:"id"`
@@ -46,15 +84,34 @@ type SeatInfo struct {
 	SeatNumber  string    `json:"seat_number"`
 	Row         string    `json:"row"`
 	Price       float64   `json:"price"`
+	BasePrice   float64   `json:"base_price"` // Event base price, before the section multiplier
 	SectionName string    `json:"section_name"`
 }
 
+// TicketHoldInfo represents the tickets of a general admission hold for external services
+type TicketHoldInfo struct {
+	TicketTypeID uuid.UUID  `json:"ticket_type_id"`
+	SectionID    *uuid.UUID `json:"section_id,omitempty"`
+	Name         string     `json:"name"`
+	Quantity     int        `json:"quantity"`
+	UnitPrice    float64    `json:"unit_price"`
+	BasePrice    float64    `json:"base_price"` // Event base price per ticket, before the multiplier
+}
+
 // Helpers
 
 func (Seat) TableName() string {
 	return "seats"
 }
 
+func (TicketType) TableName() string {
+	return "ticket_types"
+}
+
+func (SeatBlock) TableName() string {
+	return "event_seat_blocks"
+}
+
 func (s *Seat) IsAvailable() bool {
 	return s.Status == "AVAILABLE"
 }
@@ -63,6 +120,10 @@ func (s *Seat) IsBlocked() bool {
 	return s.Status == "BLOCKED"
 }
 
+func (s *Seat) Attributes() []string {
+	return SeatAttributes(s.WheelchairAccessible, s.CompanionSeat, s.RestrictedView, s.Aisle)
+}
+
 func (s *Seat) IsBookedForEvent(eventID uuid.UUID, seatBookings []SeatBooking) bool {
 	if s.IsBlocked() {
 		return false
@@ -103,5 +164,6 @@ func (s *Seat) ToResponse(eventID uuid.UUID, price float64, isHeld bool, seatBoo
 		Status:     effectiveStatus, // Event-specific status (AVAILABLE/BOOKED/BLOCKED/HELD)
 		Price:      price,
 		IsHeld:     isHeld,
+		Attributes: s.Attributes(),
 	}
 }
@@ -0,0 +1,192 @@
+package seats
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"evently/internal/shared/config"
+	"evently/pkg/alerting"
+)
+
+// HoldMonitor periodically inspects Redis holds and raises alerts for anomalies:
+// long-lived holds (TTL misconfiguration), a spike of holds that never convert
+// into bookings, and drift between held seat keys and hold metadata.
+type HoldMonitor struct {
+	repo       Repository
+	alerter    alerting.Alerter
+	config     config.HoldMonitorConfig
+	maxHoldTTL time.Duration
+	done       chan struct{}
+}
+
+// NewHoldMonitor creates a new hold monitor
+func NewHoldMonitor(repo Repository, alerter alerting.Alerter, cfg *config.Config) *HoldMonitor {
+	return &HoldMonitor{
+		repo:       repo,
+		alerter:    alerter,
+		config:     cfg.HoldMonitor,
+		maxHoldTTL: cfg.Redis.SeatHoldMaxTTL,
+		done:       make(chan struct{}),
+	}
+}
+
+// Start starts the monitor loop
+func (m *HoldMonitor) Start(ctx context.Context) {
+	log.Info("Starting hold monitor", "interval", m.config.Interval)
+	go m.run(ctx)
+}
+
+// Stop stops the monitor loop
+func (m *HoldMonitor) Stop() {
+	log.Info("Stopping hold monitor")
+	close(m.done)
+}
+
+func (m *HoldMonitor) run(ctx context.Context) {
+	ticker := time.NewTicker(m.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			m.Check(ctx)
+		case <-m.done:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Check runs every anomaly check once
+func (m *HoldMonitor) Check(ctx context.Context) {
+	holds, err := m.repo.ScanHolds(ctx)
+	if err != nil {
+		log.ErrorContext(ctx, "Failed to scan holds", "error", err)
+		return
+	}
+
+	m.checkLongLivedHolds(ctx, holds)
+	m.checkSeatKeyDrift(ctx, holds)
+	m.checkConversion(ctx)
+}
+
+func (m *HoldMonitor) checkLongLivedHolds(ctx context.Context, holds []HoldSnapshot) {
+	now := time.Now()
+
+	var offenders []string
+	for _, hold := range holds {
+		tooOld := !hold.CreatedAt.IsZero() && now.Sub(hold.CreatedAt) > m.config.MaxHoldAge
+		// A TTL beyond the configured max can only come from a misconfigured writer
+		ttlTooLong := m.maxHoldTTL > 0 && hold.TTL > m.maxHoldTTL
+		// TTL of -1 means the key has no expiry at all
+		noExpiry := hold.TTL < 0
+
+		if tooOld || ttlTooLong || noExpiry {
+			offenders = append(offenders, hold.HoldID)
+		}
+	}
+
+	if len(offenders) == 0 {
+		return
+	}
+
+	sample := offenders
+	if len(sample) > 5 {
+		sample = sample[:5]
+	}
+
+	m.send(ctx, alerting.Alert{
+		Key:      "holds.long_lived",
+		Severity: alerting.SeverityWarning,
+		Title:    "Long-lived seat holds detected",
+		Message:  fmt.Sprintf("%d holds exceed the expected lifetime, check seat hold TTL configuration", len(offenders)),
+		Fields: map[string]string{
+			"count":        fmt.Sprintf("%d", len(offenders)),
+			"max_hold_age": m.config.MaxHoldAge.String(),
+			"max_hold_ttl": m.maxHoldTTL.String(),
+			"sample":       strings.Join(sample, ", "),
+		},
+	})
+}
+
+func (m *HoldMonitor) checkSeatKeyDrift(ctx context.Context, holds []HoldSnapshot) {
+	expected := 0
+	for _, hold := range holds {
+		expected += hold.SeatCount
+	}
+
+	actual, err := m.repo.CountSeatHoldKeys(ctx)
+	if err != nil {
+		log.ErrorContext(ctx, "Failed to count seat hold keys", "error", err)
+		return
+	}
+
+	drift := int(actual) - expected
+	if drift < 0 {
+		drift = -drift
+	}
+	if drift <= m.config.MaxSeatKeyDrift {
+		return
+	}
+
+	m.send(ctx, alerting.Alert{
+		Key:      "holds.seat_key_drift",
+		Severity: alerting.SeverityCritical,
+		Title:    "Redis seat holds diverge from hold metadata",
+		Message:  "The number of held seat keys does not match the seats recorded on active holds",
+		Fields: map[string]string{
+			"seat_hold_keys": fmt.Sprintf("%d", actual),
+			"expected_seats": fmt.Sprintf("%d", expected),
+			"active_holds":   fmt.Sprintf("%d", len(holds)),
+			"allowed_drift":  fmt.Sprintf("%d", m.config.MaxSeatKeyDrift),
+			"observed_drift": fmt.Sprintf("%d", drift),
+		},
+	})
+}
+
+func (m *HoldMonitor) checkConversion(ctx context.Context) {
+	since := time.Now().Add(-m.config.ConversionWindow)
+
+	created, err := m.repo.CountHoldsCreatedSince(ctx, since)
+	if err != nil {
+		log.ErrorContext(ctx, "Failed to count holds", "error", err)
+		return
+	}
+	if created < int64(m.config.MinHoldsForConversion) {
+		return
+	}
+
+	bookings, err := m.repo.CountBookingsSince(ctx, since)
+	if err != nil {
+		log.ErrorContext(ctx, "Failed to count bookings", "error", err)
+		return
+	}
+
+	rate := float64(bookings) / float64(created)
+	if rate >= m.config.MinConversionRate {
+		return
+	}
+
+	m.send(ctx, alerting.Alert{
+		Key:      "holds.low_conversion",
+		Severity: alerting.SeverityWarning,
+		Title:    "Spike in holds released without bookings",
+		Message:  fmt.Sprintf("Only %.1f%% of holds in the last %v converted into bookings", rate*100, m.config.ConversionWindow),
+		Fields: map[string]string{
+			"holds_created":   fmt.Sprintf("%d", created),
+			"bookings":        fmt.Sprintf("%d", bookings),
+			"conversion_rate": fmt.Sprintf("%.3f", rate),
+			"threshold":       fmt.Sprintf("%.3f", m.config.MinConversionRate),
+		},
+	})
+}
+
+func (m *HoldMonitor) send(ctx context.Context, alert alerting.Alert) {
+	alert.Timestamp = time.Now()
+	if err := m.alerter.Send(ctx, alert); err != nil {
+		log.ErrorContext(ctx, "Failed to deliver hold alert", "alert", alert.Key, "error", err)
+	}
+}
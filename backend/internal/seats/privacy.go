@@ -0,0 +1,95 @@
+package seats
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+)
+
+// HoldPrivacy keeps raw user IDs out of Redis. Hold keys are indexed by an
+// opaque owner token (a keyed HMAC of the user ID), and the user ID itself is
+// only stored AES-GCM encrypted under hold_owner:<hold_id>, so reading Redis
+// does not reveal who is holding which seats.
+type HoldPrivacy struct {
+	tokenKey []byte
+	aead     cipher.AEAD
+}
+
+// HoldOwner identifies who placed a hold without exposing the user ID
+type HoldOwner struct {
+	Token  string // Opaque token the owner's holds are indexed by
+	Sealed string // Encrypted user ID, stored under hold_owner:<hold_id>
+}
+
+// NewHoldPrivacy derives the token and encryption keys from a secret
+func NewHoldPrivacy(secret string) (*HoldPrivacy, error) {
+	if secret == "" {
+		return nil, fmt.Errorf("hold privacy secret is empty")
+	}
+
+	tokenKey := sha256.Sum256([]byte("hold-owner-token:" + secret))
+	encryptionKey := sha256.Sum256([]byte("hold-owner-encryption:" + secret))
+
+	block, err := aes.NewCipher(encryptionKey[:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCM: %w", err)
+	}
+
+	return &HoldPrivacy{tokenKey: tokenKey[:], aead: aead}, nil
+}
+
+// OwnerToken returns the stable opaque token a user's holds are indexed by
+func (p *HoldPrivacy) OwnerToken(userID string) string {
+	mac := hmac.New(sha256.New, p.tokenKey)
+	mac.Write([]byte(userID))
+	return hex.EncodeToString(mac.Sum(nil)[:16])
+}
+
+// Owner returns the token and sealed user ID stored with a new hold
+func (p *HoldPrivacy) Owner(userID string) (HoldOwner, error) {
+	sealed, err := p.SealOwner(userID)
+	if err != nil {
+		return HoldOwner{}, err
+	}
+	return HoldOwner{Token: p.OwnerToken(userID), Sealed: sealed}, nil
+}
+
+// SealOwner encrypts a user ID for the hold owner mapping
+func (p *HoldPrivacy) SealOwner(userID string) (string, error) {
+	nonce := make([]byte, p.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	sealed := p.aead.Seal(nonce, nonce, []byte(userID), nil)
+	return base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// OpenOwner decrypts a user ID sealed by SealOwner
+func (p *HoldPrivacy) OpenOwner(sealed string) (string, error) {
+	data, err := base64.RawStdEncoding.DecodeString(sealed)
+	if err != nil {
+		return "", fmt.Errorf("invalid hold owner encoding: %w", err)
+	}
+
+	nonceSize := p.aead.NonceSize()
+	if len(data) < nonceSize {
+		return "", fmt.Errorf("invalid hold owner ciphertext")
+	}
+
+	userID, err := p.aead.Open(nil, data[:nonceSize], data[nonceSize:], nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt hold owner: %w", err)
+	}
+	return string(userID), nil
+}
@@ -2,6 +2,7 @@ package seats
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"time"
@@ -10,35 +11,78 @@ import (
 	"github.com/redis/go-redis/v9"
 )
 
+// ErrSeatHeld is returned when another hold claimed a seat first
+var ErrSeatHeld = errors.New("seat already held")
+
+// ErrSeatsUnavailable is returned when seats are missing or not available in the venue
+var ErrSeatsUnavailable = errors.New("seats not available")
+
+// ErrSeatsBooked is returned when seats are already booked for the event
+var ErrSeatsBooked = errors.New("seats already booked for this event")
+
+// ErrNotEnoughTickets is returned when a ticket type has fewer tickets left than requested
+var ErrNotEnoughTickets = errors.New("not enough tickets left")
+
 // AtomicRedisOperations handles atomic Redis operations for seat holding
 type AtomicRedisOperations struct {
-	redis *redis.Client
+	redis redis.UniversalClient
+	retry holdRetry
 }
 
 // NewAtomicRedisOperations creates a new atomic Redis operations handler
-func NewAtomicRedisOperations(redisClient *redis.Client) *AtomicRedisOperations {
+func NewAtomicRedisOperations(redisClient redis.UniversalClient) *AtomicRedisOperations {
 	return &AtomicRedisOperations{
 		redis: redisClient,
+		retry: defaultHoldRetry,
 	}
 }
 
-// Lua script for atomic seat holding - prevents race conditions
-const luaAtomicSeatHold = `
--- KEYS[1] = hold_id
--- ARGV[1] = user_id
--- ARGV[2] = event_id  
--- ARGV[3] = ttl_seconds
--- ARGV[4..N] = seat_ids
+// SetRetry sets how often and how patiently hold scripts are retried while
+// Redis fails over. Zero attempts turns retrying off.
+func (a *AtomicRedisOperations) SetRetry(attempts int, backoff time.Duration) {
+	a.retry = holdRetry{attempts: attempts, backoff: backoff}
+}
+
+// run runs a script, loading it first if Redis doesn't have it cached
+func (a *AtomicRedisOperations) run(ctx context.Context, name string, idempotent bool, script *redis.Script, keys []string, args ...interface{}) (interface{}, error) {
+	var result interface{}
+	err := a.retry.do(ctx, name, idempotent, func() error {
+		var err error
+		result, err = script.Run(ctx, a.redis, keys, args...).Result()
+		return err
+	})
+	return result, err
+}
 
-local hold_id = KEYS[1]
-local user_id = ARGV[1]
-local event_id = ARGV[2]
-local ttl = tonumber(ARGV[3])
+// Lua script for atomic seat holding - prevents race conditions.
+// Holds are indexed by an opaque owner token; the user ID is only stored
+// encrypted under hold_owner:<hold_id> (see HoldPrivacy).
+// A hold that already exists was created by an earlier attempt of this call,
+// so retrying after a dropped connection is safe.
+const luaAtomicSeatHold = luaHoldKeys + `
+-- KEYS[1] = hold key
+-- ARGV[1] = hold_id
+-- ARGV[2] = owner_token
+-- ARGV[3] = sealed_owner
+-- ARGV[4] = event_id
+-- ARGV[5] = ttl_seconds
+-- ARGV[6..N] = seat_ids
+
+local hold_key = KEYS[1]
+local hold_id = ARGV[1]
+local owner_token = ARGV[2]
+local sealed_owner = ARGV[3]
+local event_id = ARGV[4]
+local ttl = tonumber(ARGV[5])
+
+if redis.call("EXISTS", hold_key) == 1 then
+    return {1, "success"}
+end
 
 -- Check if all seats are available (not held)
-for i = 4, #ARGV do
+for i = 6, #ARGV do
     local seat_id = ARGV[i]
-    local seat_hold_key = "seat_hold:" .. seat_id
+    local seat_hold_key = TAG .. "seat_hold:" .. seat_id
     
     if redis.call("EXISTS", seat_hold_key) == 1 then
         -- Seat is already held, return failure with the seat that's held
@@ -47,25 +91,25 @@ for i = 4, #ARGV do
 end
 
 -- All seats are available, hold them atomically
-local hold_key = "hold:" .. hold_id
-local hold_seats_key = "hold_seats:" .. hold_id
-local user_holds_key = "user_holds:" .. user_id
+local hold_seats_key = TAG .. "hold_seats:" .. hold_id
+local user_holds_key = TAG .. "user_holds:" .. owner_token
 local created_at = redis.call("TIME")[1]
 
 -- Create hold metadata
 redis.call("HMSET", hold_key,
-    "user_id", user_id,
+    "owner", owner_token,
     "event_id", event_id,
-    "seat_count", #ARGV - 3,
+    "seat_count", #ARGV - 5,
     "created_at", created_at
 )
 redis.call("EXPIRE", hold_key, ttl)
+redis.call("SETEX", TAG .. "hold_owner:" .. hold_id, ttl, sealed_owner)
 
 -- Hold individual seats and add to hold set
-for i = 4, #ARGV do
+for i = 6, #ARGV do
     local seat_id = ARGV[i]
-    local seat_hold_key = "seat_hold:" .. seat_id
-    local hold_value = user_id .. ":" .. hold_id
+    local seat_hold_key = TAG .. "seat_hold:" .. seat_id
+    local hold_value = owner_token .. ":" .. hold_id
     
     redis.call("SETEX", seat_hold_key, ttl, hold_value)
     redis.call("SADD", hold_seats_key, seat_id)
@@ -74,7 +118,7 @@ end
 -- Set expiry for hold seats set
 redis.call("EXPIRE", hold_seats_key, ttl)
 
--- Add to user's holds
+-- Add to owner's holds
 redis.call("SADD", user_holds_key, hold_id)
 redis.call("EXPIRE", user_holds_key, ttl)
 
@@ -83,12 +127,13 @@ return {1, "success"}
 `
 
 // Lua script for atomic seat release
-const luaAtomicSeatRelease = `
--- KEYS[1] = hold_id
-local hold_id = KEYS[1]
+const luaAtomicSeatRelease = luaHoldKeys + `
+-- KEYS[1] = hold key
+-- ARGV[1] = hold_id
+local hold_key = KEYS[1]
+local hold_id = ARGV[1]
 
-local hold_key = "hold:" .. hold_id
-local hold_seats_key = "hold_seats:" .. hold_id
+local hold_seats_key = TAG .. "hold_seats:" .. hold_id
 
 -- Get hold metadata
 local hold_data = redis.call("HGETALL", hold_key)
@@ -96,15 +141,16 @@ if #hold_data == 0 then
     return {0, "hold_not_found"}
 end
 
-local user_id = nil
+-- Holds created before owner tokens were introduced carry user_id instead
+local owner = nil
 for i = 1, #hold_data, 2 do
-    if hold_data[i] == "user_id" then
-        user_id = hold_data[i + 1]
+    if hold_data[i] == "owner" or hold_data[i] == "user_id" then
+        owner = hold_data[i + 1]
         break
     end
 end
 
-if not user_id then
+if not owner then
     return {0, "invalid_hold_data"}
 end
 
@@ -113,31 +159,240 @@ local seat_ids = redis.call("SMEMBERS", hold_seats_key)
 
 -- Release individual seat holds
 for i = 1, #seat_ids do
-    local seat_hold_key = "seat_hold:" .. seat_ids[i]
+    local seat_hold_key = TAG .. "seat_hold:" .. seat_ids[i]
     redis.call("DEL", seat_hold_key)
 end
 
--- Remove from user's holds
-local user_holds_key = "user_holds:" .. user_id
+-- Return general admission tickets to their ticket type
+local released = #seat_ids
+local ga_entry = redis.call("HGET", TAG .. "ga_holds", hold_id)
+if ga_entry then
+    local sep = string.find(ga_entry, ":", 1, true)
+    local remaining_key = TAG .. "ga_remaining:" .. string.sub(ga_entry, 1, sep - 1)
+    released = tonumber(string.sub(ga_entry, sep + 1))
+    if redis.call("EXISTS", remaining_key) == 1 then
+        redis.call("INCRBY", remaining_key, released)
+    end
+    redis.call("HDEL", TAG .. "ga_holds", hold_id)
+    redis.call("ZREM", TAG .. "ga_hold_expiry", hold_id)
+end
+
+-- Remove from owner's holds
+local user_holds_key = TAG .. "user_holds:" .. owner
 redis.call("SREM", user_holds_key, hold_id)
 
 -- Clean up hold metadata
 redis.call("DEL", hold_key)
 redis.call("DEL", hold_seats_key)
+redis.call("DEL", TAG .. "hold_owner:" .. hold_id)
+
+return {1, released}
+`
+
+// Lua script for atomic hold extension - all keys of a hold share one TTL
+const luaAtomicSeatExtend = luaHoldKeys + `
+-- KEYS[1] = hold key
+-- ARGV[1] = hold_id
+-- ARGV[2] = owner_token
+-- ARGV[3] = extension_seconds
+-- ARGV[4] = max_total_seconds
+local hold_key = KEYS[1]
+local hold_id = ARGV[1]
+local owner_token = ARGV[2]
+local extension = tonumber(ARGV[3])
+local max_total = tonumber(ARGV[4])
+
+local hold_seats_key = TAG .. "hold_seats:" .. hold_id
+
+local current_ttl = redis.call("TTL", hold_key)
+if current_ttl <= 0 then
+    return {0, "hold_not_found"}
+end
+
+local owner = redis.call("HGET", hold_key, "owner")
+if owner ~= owner_token then
+    return {0, "hold_belongs_to_different_user"}
+end
+
+-- Cap the new TTL so the hold never outlives created_at + max_total
+local now = tonumber(redis.call("TIME")[1])
+local created_at = tonumber(redis.call("HGET", hold_key, "created_at")) or now
+local remaining_allowed = created_at + max_total - now
+
+local new_ttl = current_ttl + extension
+if new_ttl > remaining_allowed then
+    new_ttl = remaining_allowed
+end
+
+if new_ttl <= current_ttl then
+    return {0, "max_hold_time_reached"}
+end
+
+redis.call("EXPIRE", hold_key, new_ttl)
+redis.call("EXPIRE", hold_seats_key, new_ttl)
+redis.call("EXPIRE", TAG .. "hold_owner:" .. hold_id, new_ttl)
+redis.call("HINCRBY", hold_key, "extensions", 1)
+
+local seat_ids = redis.call("SMEMBERS", hold_seats_key)
+for i = 1, #seat_ids do
+    redis.call("EXPIRE", TAG .. "seat_hold:" .. seat_ids[i], new_ttl)
+end
+
+-- General admission tickets go back to the pool later too
+if redis.call("ZSCORE", TAG .. "ga_hold_expiry", hold_id) then
+    redis.call("ZADD", TAG .. "ga_hold_expiry", "XX", now + new_ttl, hold_id)
+end
+
+-- The owner's holds set is shared across holds, only ever lengthen it
+local user_holds_key = TAG .. "user_holds:" .. owner_token
+if redis.call("TTL", user_holds_key) < new_ttl then
+    redis.call("EXPIRE", user_holds_key, new_ttl)
+end
+
+return {1, new_ttl}
+`
+
+// General admission holds take a quantity off ga_remaining:<ticket_type_id>.
+// Each hold is registered in ga_holds (hold_id -> "ticket_type_id:quantity")
+// and ga_hold_expiry (scored by expiry), so the tickets of a hold that expires
+// are returned by the next script that touches general admission. The grace
+// period lets a booking that validated the hold just before expiry finish.
+const luaReclaimExpiredTickets = luaHoldKeys + `
+local function reclaim_expired_tickets()
+    local cutoff = tonumber(redis.call("TIME")[1]) - 60
+    local expired = redis.call("ZRANGEBYSCORE", TAG .. "ga_hold_expiry", "-inf", cutoff, "LIMIT", 0, 100)
+    for i = 1, #expired do
+        local entry = redis.call("HGET", TAG .. "ga_holds", expired[i])
+        if entry then
+            local sep = string.find(entry, ":", 1, true)
+            local remaining_key = TAG .. "ga_remaining:" .. string.sub(entry, 1, sep - 1)
+            if redis.call("EXISTS", remaining_key) == 1 then
+                redis.call("INCRBY", remaining_key, tonumber(string.sub(entry, sep + 1)))
+            end
+            redis.call("HDEL", TAG .. "ga_holds", expired[i])
+        end
+        redis.call("ZREM", TAG .. "ga_hold_expiry", expired[i])
+    end
+end
+`
+
+// Lua script for atomic general admission holding. Like seat holds, a hold
+// that already exists was created by an earlier attempt of this call.
+const luaAtomicTicketHold = luaReclaimExpiredTickets + `
+-- KEYS[1] = hold key
+-- ARGV[1] = hold_id
+-- ARGV[2] = owner_token
+-- ARGV[3] = sealed_owner
+-- ARGV[4] = event_id
+-- ARGV[5] = ttl_seconds
+-- ARGV[6] = ticket_type_id
+-- ARGV[7] = quantity
+-- ARGV[8] = remaining, used when the counter does not exist yet
+
+local hold_key = KEYS[1]
+local hold_id = ARGV[1]
+local owner_token = ARGV[2]
+local sealed_owner = ARGV[3]
+local event_id = ARGV[4]
+local ttl = tonumber(ARGV[5])
+local ticket_type_id = ARGV[6]
+local quantity = tonumber(ARGV[7])
+
+local remaining_key = TAG .. "ga_remaining:" .. ticket_type_id
+if redis.call("EXISTS", hold_key) == 1 then
+    return {1, tonumber(redis.call("GET", remaining_key)) or 0}
+end
 
-return {1, #seat_ids}
+reclaim_expired_tickets()
+
+redis.call("SET", remaining_key, ARGV[8], "NX")
+
+local remaining = tonumber(redis.call("GET", remaining_key))
+if remaining < quantity then
+    return {0, remaining}
+end
+remaining = redis.call("DECRBY", remaining_key, quantity)
+
+local user_holds_key = TAG .. "user_holds:" .. owner_token
+local now = tonumber(redis.call("TIME")[1])
+
+-- Same hold metadata as seat holds, so validation and extension work unchanged.
+-- No seat keys are held, which is what seat_count tells the hold monitor.
+redis.call("HMSET", hold_key,
+    "owner", owner_token,
+    "event_id", event_id,
+    "seat_count", 0,
+    "ticket_type_id", ticket_type_id,
+    "quantity", quantity,
+    "created_at", now
+)
+redis.call("EXPIRE", hold_key, ttl)
+redis.call("SETEX", TAG .. "hold_owner:" .. hold_id, ttl, sealed_owner)
+
+redis.call("HSET", TAG .. "ga_holds", hold_id, ticket_type_id .. ":" .. quantity)
+redis.call("ZADD", TAG .. "ga_hold_expiry", now + ttl, hold_id)
+
+redis.call("SADD", user_holds_key, hold_id)
+redis.call("EXPIRE", user_holds_key, ttl)
+
+return {1, remaining}
+`
+
+// Lua script for reading a ticket type's remaining count
+const luaTicketsRemaining = luaReclaimExpiredTickets + `
+-- KEYS[1] = remaining key
+-- ARGV[1] = remaining, used when the counter does not exist yet
+reclaim_expired_tickets()
+
+redis.call("SET", KEYS[1], ARGV[1], "NX")
+return tonumber(redis.call("GET", KEYS[1]))
+`
+
+// Lua script for handing tickets back, or taking them away after a capacity change.
+// A missing counter is left alone, it is seeded from the database on next use.
+const luaAdjustTicketsRemaining = `
+-- KEYS[1] = remaining key
+-- ARGV[1] = delta
+if redis.call("EXISTS", KEYS[1]) == 0 then
+    return 0
+end
+redis.call("INCRBY", KEYS[1], tonumber(ARGV[1]))
+return 1
 `
 
+// Lua script for unregistering a hold whose tickets were booked, so that
+// releasing the hold afterwards does not return them
+const luaConsumeTicketHold = `
+-- KEYS[1] = ga_holds key
+-- KEYS[2] = ga_hold_expiry key
+-- ARGV[1] = hold_id
+redis.call("HDEL", KEYS[1], ARGV[1])
+redis.call("ZREM", KEYS[2], ARGV[1])
+return 1
+`
+
+var (
+	seatHoldScript         = redis.NewScript(luaAtomicSeatHold)
+	seatReleaseScript      = redis.NewScript(luaAtomicSeatRelease)
+	seatExtendScript       = redis.NewScript(luaAtomicSeatExtend)
+	ticketHoldScript       = redis.NewScript(luaAtomicTicketHold)
+	ticketsRemainingScript = redis.NewScript(luaTicketsRemaining)
+	adjustTicketsScript    = redis.NewScript(luaAdjustTicketsRemaining)
+	consumeTicketsScript   = redis.NewScript(luaConsumeTicketHold)
+)
+
 // AtomicHoldSeats atomically holds multiple seats using Lua script
-func (a *AtomicRedisOperations) AtomicHoldSeats(ctx context.Context, seatIDs []uuid.UUID, userID, holdID, eventID string, ttl time.Duration) error {
+func (a *AtomicRedisOperations) AtomicHoldSeats(ctx context.Context, seatIDs []uuid.UUID, owner HoldOwner, holdID, eventID string, ttl time.Duration) error {
 	if a.redis == nil {
 		return fmt.Errorf("redis client not available")
 	}
 
 	// Prepare arguments for Lua script
-	keys := []string{holdID}
+	keys := []string{holdKey(holdID)}
 	args := []interface{}{
-		userID,
+		holdID,
+		owner.Token,
+		owner.Sealed,
 		eventID,
 		strconv.Itoa(int(ttl.Seconds())),
 	}
@@ -148,13 +403,9 @@ func (a *AtomicRedisOperations) AtomicHoldSeats(ctx context.Context, seatIDs []u
 	}
 
 	// Execute Lua script
-	result, err := a.redis.EvalSha(ctx, luaAtomicSeatHold, keys, args...).Result()
+	result, err := a.run(ctx, "seat_hold", true, seatHoldScript, keys, args...)
 	if err != nil {
-		// If script is not loaded, try to load and execute
-		result, err = a.redis.Eval(ctx, luaAtomicSeatHold, keys, args...).Result()
-		if err != nil {
-			return fmt.Errorf("failed to execute atomic seat hold: %w", err)
-		}
+		return fmt.Errorf("failed to execute atomic seat hold: %w", err)
 	}
 
 	// Parse result
@@ -171,9 +422,9 @@ func (a *AtomicRedisOperations) AtomicHoldSeats(ctx context.Context, seatIDs []u
 	if success == 0 {
 		conflictSeat, ok := resultArray[1].(string)
 		if ok {
-			return fmt.Errorf("seat already held: %s", conflictSeat)
+			return fmt.Errorf("%w: %s", ErrSeatHeld, conflictSeat)
 		}
-		return fmt.Errorf("failed to hold seats")
+		return ErrSeatHeld
 	}
 
 	return nil
@@ -186,13 +437,9 @@ func (a *AtomicRedisOperations) AtomicReleaseHold(ctx context.Context, holdID st
 	}
 
 	// Execute Lua script
-	result, err := a.redis.EvalSha(ctx, luaAtomicSeatRelease, []string{holdID}).Result()
+	result, err := a.run(ctx, "seat_release", false, seatReleaseScript, []string{holdKey(holdID)}, holdID)
 	if err != nil {
-		// If script is not loaded, try to load and execute
-		result, err = a.redis.Eval(ctx, luaAtomicSeatRelease, []string{holdID}).Result()
-		if err != nil {
-			return 0, fmt.Errorf("failed to execute atomic seat release: %w", err)
-		}
+		return 0, fmt.Errorf("failed to execute atomic seat release: %w", err)
 	}
 
 	// Parse result
@@ -222,22 +469,169 @@ func (a *AtomicRedisOperations) AtomicReleaseHold(ctx context.Context, holdID st
 	return int(releasedCount), nil
 }
 
-// PreloadScripts loads Lua scripts into Redis for better performance
-func (a *AtomicRedisOperations) PreloadScripts(ctx context.Context) error {
+// AtomicExtendHold atomically extends every key of a hold, bounded by maxTotal
+// measured from hold creation. Returns the new TTL.
+func (a *AtomicRedisOperations) AtomicExtendHold(ctx context.Context, holdID, ownerToken string, extension, maxTotal time.Duration) (time.Duration, error) {
 	if a.redis == nil {
-		return fmt.Errorf("redis client not available")
+		return 0, fmt.Errorf("redis client not available")
+	}
+
+	keys := []string{holdKey(holdID)}
+	args := []interface{}{
+		holdID,
+		ownerToken,
+		strconv.Itoa(int(extension.Seconds())),
+		strconv.Itoa(int(maxTotal.Seconds())),
 	}
 
-	// Load seat hold script
-	_, err := a.redis.ScriptLoad(ctx, luaAtomicSeatHold).Result()
+	// Execute Lua script
+	result, err := a.run(ctx, "hold_extend", false, seatExtendScript, keys, args...)
 	if err != nil {
-		return fmt.Errorf("failed to load seat hold script: %w", err)
+		return 0, fmt.Errorf("failed to execute atomic hold extension: %w", err)
+	}
+
+	// Parse result
+	resultArray, ok := result.([]interface{})
+	if !ok || len(resultArray) != 2 {
+		return 0, fmt.Errorf("unexpected result format from Lua script")
 	}
 
-	// Load seat release script
-	_, err = a.redis.ScriptLoad(ctx, luaAtomicSeatRelease).Result()
+	success, ok := resultArray[0].(int64)
+	if !ok {
+		return 0, fmt.Errorf("invalid success flag in Lua script result")
+	}
+
+	if success == 0 {
+		reason, _ := resultArray[1].(string)
+		switch reason {
+		case "hold_not_found":
+			return 0, fmt.Errorf("hold not found or expired")
+		case "hold_belongs_to_different_user":
+			return 0, fmt.Errorf("hold belongs to different user")
+		case "max_hold_time_reached":
+			return 0, fmt.Errorf("maximum hold time reached")
+		}
+		return 0, fmt.Errorf("failed to extend hold")
+	}
+
+	newTTL, ok := resultArray[1].(int64)
+	if !ok {
+		return 0, fmt.Errorf("invalid TTL in Lua script result")
+	}
+
+	return time.Duration(newTTL) * time.Second, nil
+}
+
+// AtomicHoldTickets atomically takes a quantity of general admission tickets.
+// seed is the remaining count to start from when Redis has none. Returns the
+// count left after the hold.
+func (a *AtomicRedisOperations) AtomicHoldTickets(ctx context.Context, ticketTypeID uuid.UUID, quantity, seed int, owner HoldOwner, holdID, eventID string, ttl time.Duration) (int, error) {
+	if a.redis == nil {
+		return 0, fmt.Errorf("redis client not available")
+	}
+
+	keys := []string{holdKey(holdID)}
+	args := []interface{}{
+		holdID,
+		owner.Token,
+		owner.Sealed,
+		eventID,
+		strconv.Itoa(int(ttl.Seconds())),
+		ticketTypeID.String(),
+		strconv.Itoa(quantity),
+		strconv.Itoa(seed),
+	}
+
+	// Execute Lua script
+	result, err := a.run(ctx, "ticket_hold", true, ticketHoldScript, keys, args...)
 	if err != nil {
-		return fmt.Errorf("failed to load seat release script: %w", err)
+		return 0, fmt.Errorf("failed to execute atomic ticket hold: %w", err)
+	}
+
+	// Parse result
+	resultArray, ok := result.([]interface{})
+	if !ok || len(resultArray) != 2 {
+		return 0, fmt.Errorf("unexpected result format from Lua script")
+	}
+
+	success, ok := resultArray[0].(int64)
+	if !ok {
+		return 0, fmt.Errorf("invalid success flag in Lua script result")
+	}
+
+	remaining, ok := resultArray[1].(int64)
+	if !ok {
+		return 0, fmt.Errorf("invalid remaining count in Lua script result")
+	}
+
+	if success == 0 {
+		return int(remaining), fmt.Errorf("%w: %d left", ErrNotEnoughTickets, remaining)
+	}
+
+	return int(remaining), nil
+}
+
+// TicketsRemaining returns a ticket type's remaining count, seeding it when missing
+func (a *AtomicRedisOperations) TicketsRemaining(ctx context.Context, ticketTypeID uuid.UUID, seed int) (int, error) {
+	if a.redis == nil {
+		return 0, fmt.Errorf("redis client not available")
+	}
+
+	keys := []string{ticketsRemainingKey(ticketTypeID.String())}
+	result, err := a.run(ctx, "tickets_remaining", true, ticketsRemainingScript, keys, strconv.Itoa(seed))
+	if err != nil {
+		return 0, fmt.Errorf("failed to read remaining tickets: %w", err)
+	}
+
+	remaining, ok := result.(int64)
+	if !ok {
+		return 0, fmt.Errorf("invalid remaining count in Lua script result")
+	}
+	return int(remaining), nil
+}
+
+// AdjustTicketsRemaining adds delta to a ticket type's remaining count, if it has one
+func (a *AtomicRedisOperations) AdjustTicketsRemaining(ctx context.Context, ticketTypeID uuid.UUID, delta int) error {
+	if a.redis == nil {
+		return fmt.Errorf("redis client not available")
+	}
+
+	keys := []string{ticketsRemainingKey(ticketTypeID.String())}
+	if _, err := a.run(ctx, "tickets_adjust", false, adjustTicketsScript, keys, strconv.Itoa(delta)); err != nil {
+		return fmt.Errorf("failed to adjust remaining tickets: %w", err)
+	}
+
+	return nil
+}
+
+// ConsumeTicketHold keeps a hold's tickets taken once they are booked
+func (a *AtomicRedisOperations) ConsumeTicketHold(ctx context.Context, holdID string) error {
+	if a.redis == nil {
+		return fmt.Errorf("redis client not available")
+	}
+
+	keys := []string{gaHoldsKey, gaHoldExpiryKey}
+	if _, err := a.run(ctx, "tickets_consume", true, consumeTicketsScript, keys, holdID); err != nil {
+		return fmt.Errorf("failed to consume ticket hold: %w", err)
+	}
+
+	return nil
+}
+
+// PreloadScripts loads Lua scripts into Redis for better performance. A
+// cluster client loads them on every master.
+func (a *AtomicRedisOperations) PreloadScripts(ctx context.Context) error {
+	if a.redis == nil {
+		return fmt.Errorf("redis client not available")
+	}
+
+	for _, script := range []*redis.Script{
+		seatHoldScript, seatReleaseScript, seatExtendScript,
+		ticketHoldScript, ticketsRemainingScript, adjustTicketsScript, consumeTicketsScript,
+	} {
+		if err := script.Load(ctx, a.redis).Err(); err != nil {
+			return fmt.Errorf("failed to load hold script: %w", err)
+		}
 	}
 
 	return nil
@@ -0,0 +1,167 @@
+package seats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"evently/internal/shared/config"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrHoldsUnavailable is returned for new holds while Redis is down
+var ErrHoldsUnavailable = errors.New("seat holds are temporarily unavailable, please try again shortly")
+
+// RedisWatch decides whether seat availability runs in degraded mode. It pings
+// Redis on an interval and also counts failed Redis reads reported by the
+// service. While degraded, availability comes from Postgres alone and new holds
+// are refused; enough successful pings in a row switch it back.
+type RedisWatch struct {
+	client redis.UniversalClient
+	config config.RedisWatchConfig
+	done   chan struct{}
+
+	mu        sync.RWMutex
+	degraded  bool
+	since     time.Time // When the current mode started
+	failures  int       // Consecutive failures while healthy
+	successes int       // Consecutive successful pings while degraded
+	lastError string
+}
+
+// RedisWatchStatus is the degraded mode state reported by the health check
+type RedisWatchStatus struct {
+	Status    string    `json:"status"` // "ok" or "degraded"
+	Degraded  bool      `json:"degraded"`
+	Since     time.Time `json:"since"`
+	LastError string    `json:"last_error,omitempty"`
+}
+
+// NewRedisWatch creates a new Redis watch
+func NewRedisWatch(client redis.UniversalClient, cfg config.RedisWatchConfig) *RedisWatch {
+	return &RedisWatch{
+		client: client,
+		config: cfg,
+		done:   make(chan struct{}),
+		since:  time.Now(),
+	}
+}
+
+// Start starts the ping loop
+func (w *RedisWatch) Start(ctx context.Context) {
+	log.Info("Starting Redis watch", "interval", w.config.Interval)
+	go w.run(ctx)
+}
+
+// Stop stops the ping loop
+func (w *RedisWatch) Stop() {
+	log.Info("Stopping Redis watch")
+	close(w.done)
+}
+
+func (w *RedisWatch) run(ctx context.Context) {
+	ticker := time.NewTicker(w.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.Check(ctx)
+		case <-w.done:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Check pings Redis once and updates the mode
+func (w *RedisWatch) Check(ctx context.Context) {
+	if w.client == nil {
+		w.ReportFailure(fmt.Errorf("redis client not available"))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, w.config.ProbeTimeout)
+	defer cancel()
+
+	if err := w.client.Ping(ctx).Err(); err != nil {
+		w.ReportFailure(err)
+		return
+	}
+	w.reportSuccess()
+}
+
+// Degraded reports whether availability should skip Redis. A nil watch is never degraded.
+func (w *RedisWatch) Degraded() bool {
+	if w == nil {
+		return false
+	}
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.degraded
+}
+
+// ReportFailure counts a failed Redis call. Only the ping loop can end degraded mode,
+// so reads failing during an outage just keep it going.
+func (w *RedisWatch) ReportFailure(err error) {
+	if w == nil || err == nil || errors.Is(err, redis.Nil) {
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.lastError = err.Error()
+	w.successes = 0
+	if w.degraded {
+		return
+	}
+
+	w.failures++
+	if w.failures >= w.config.FailureThreshold {
+		w.degraded = true
+		w.since = time.Now()
+		w.failures = 0
+		log.Warn("Redis unavailable, serving Postgres-only availability and refusing new holds", "error", err)
+	}
+}
+
+func (w *RedisWatch) reportSuccess() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.failures = 0
+	if !w.degraded {
+		return
+	}
+
+	w.successes++
+	if w.successes >= w.config.RecoverAfter {
+		w.degraded = false
+		w.since = time.Now()
+		w.successes = 0
+		w.lastError = ""
+		log.Info("Redis is back, seat holds re-enabled")
+	}
+}
+
+// Status returns the current mode for health checks
+func (w *RedisWatch) Status() RedisWatchStatus {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	status := RedisWatchStatus{
+		Status:    "ok",
+		Degraded:  w.degraded,
+		Since:     w.since,
+		LastError: w.lastError,
+	}
+	if w.degraded {
+		status.Status = "degraded"
+	}
+	return status
+}
@@ -2,12 +2,22 @@ package seats
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"time"
 
+	"evently/internal/shared/dbresolver"
+	"evently/pkg/cache"
+
 	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type Repository interface {
@@ -21,29 +31,72 @@ type Repository interface {
 	UpdateSeatsStatus(ctx context.Context, seatIDs []uuid.UUID, status string) error
 	DeleteSeat(ctx context.Context, id uuid.UUID) error
 	DeleteSeatsBySectionID(ctx context.Context, sectionID uuid.UUID) error
+	BulkUpdateSeats(ctx context.Context, sectionID uuid.UUID, seatIDs []uuid.UUID, updates map[string]interface{}) (int64, error)
+	GetTemplateEventIDs(ctx context.Context, templateID uuid.UUID) ([]uuid.UUID, error)
 
 	// Availability checks
 	CheckSeatsAvailability(ctx context.Context, seatIDs []uuid.UUID) (map[string]bool, error)
 	GetAvailableSeatsInSection(ctx context.Context, sectionID uuid.UUID) ([]Seat, error)
 
 	// Redis seat holding operations
-	HoldSeats(ctx context.Context, seatIDs []uuid.UUID, userID, holdID, eventID string, ttl time.Duration) error
-	AtomicHoldSeats(ctx context.Context, seatIDs []uuid.UUID, userID, holdID, eventID string, ttl time.Duration) error
+	HoldSeats(ctx context.Context, seatIDs []uuid.UUID, owner HoldOwner, holdID, eventID string, ttl time.Duration) error
+	AtomicHoldSeats(ctx context.Context, seatIDs []uuid.UUID, owner HoldOwner, holdID, eventID string, ttl time.Duration) error
 	ReleaseHold(ctx context.Context, holdID string) error
 	AtomicReleaseHold(ctx context.Context, holdID string) (int, error)
 	CheckSeatHolds(ctx context.Context, seatIDs []uuid.UUID) (map[string]string, error) // seatID -> holdID
-	GetUserHolds(ctx context.Context, userID string) ([]string, error)                  // returns holdIDs
+	GetUserHolds(ctx context.Context, ownerToken string) ([]string, error)              // returns holdIDs
 	IsHoldValid(ctx context.Context, holdID string) (bool, error)
 	GetHoldDetails(ctx context.Context, holdID string) (*SeatHoldDetails, error)
+	AtomicExtendHold(ctx context.Context, holdID, ownerToken string, extension, maxTotal time.Duration) (time.Duration, error)
+	PublishHoldEvent(ctx context.Context, event *HoldEvent) error
+
+	// Hold monitoring
+	RecordHoldCreated(ctx context.Context) error
+	CountHoldsCreatedSince(ctx context.Context, since time.Time) (int64, error)
+	ScanHolds(ctx context.Context) ([]HoldSnapshot, error)
+	CountSeatHoldKeys(ctx context.Context) (int64, error)
+	CountBookingsSince(ctx context.Context, since time.Time) (int64, error)
+
+	// Hold expiry tracking for SeatHoldExpired events
+	TrackHoldExpiry(ctx context.Context, hold TrackedHold) error
+	UntrackHoldExpiry(ctx context.Context, holdID string) error
+	ClaimExpiredHolds(ctx context.Context, before time.Time, limit int) ([]TrackedHold, error)
+
+	// Booking rules
+	GetSeatBookingRules(ctx context.Context) (*SeatBookingRules, error)
+	SaveSeatBookingRules(ctx context.Context, rules *SeatBookingRules) error
+
+	// General admission
+	CreateTicketType(ctx context.Context, ticketType *TicketType) error
+	GetTicketTypeByID(ctx context.Context, id uuid.UUID) (*TicketType, error)
+	GetTicketTypesByEvent(ctx context.Context, eventID uuid.UUID) ([]TicketType, error)
+	UpdateTicketType(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error
+	DeleteTicketType(ctx context.Context, id uuid.UUID) error
+	CountTicketsSold(ctx context.Context, ticketTypeIDs []uuid.UUID) (map[uuid.UUID]int, error)
+	GetTicketingEvent(ctx context.Context, eventID uuid.UUID) (*TicketingEvent, error)
+	GetSectionTemplateID(ctx context.Context, sectionID uuid.UUID) (uuid.UUID, error)
+	FindGeneralAdmissionSections(ctx context.Context, eventID uuid.UUID, sectionIDs []uuid.UUID) ([]uuid.UUID, error)
+	AtomicHoldTickets(ctx context.Context, ticketTypeID uuid.UUID, quantity, seed int, owner HoldOwner, holdID, eventID string, ttl time.Duration) (int, error)
+	TicketsRemaining(ctx context.Context, ticketTypeID uuid.UUID, seed int) (int, error)
+	AdjustTicketsRemaining(ctx context.Context, ticketTypeID uuid.UUID, delta int) error
+	ClearTicketsRemaining(ctx context.Context, ticketTypeID uuid.UUID) error
+	ConsumeTicketHold(ctx context.Context, holdID string) error
+
+	// Event seat blocks
+	CreateSeatBlocks(ctx context.Context, blocks []SeatBlock) error
+	DeleteSeatBlocks(ctx context.Context, blockIDs []uuid.UUID) error
+	ReleaseSeatBlocks(ctx context.Context, eventID uuid.UUID, seatIDs []uuid.UUID, releasedBy uuid.UUID) ([]SeatBlock, error)
+	ListSeatBlocks(ctx context.Context, eventID uuid.UUID, includeReleased bool) ([]SeatBlockResponse, error)
+	GetBlockedSeats(ctx context.Context, eventID uuid.UUID, seatIDs []uuid.UUID) (map[uuid.UUID]string, error) // seatID -> reason
 }
 
 type repository struct {
 	db          *gorm.DB
-	redis       *redis.Client
+	redis       redis.UniversalClient
 	atomicRedis *AtomicRedisOperations
 }
 
-func NewRepository(db *gorm.DB, redisClient *redis.Client) Repository {
+func NewRepository(db *gorm.DB, redisClient redis.UniversalClient) Repository {
 	atomicRedis := NewAtomicRedisOperations(redisClient)
 	return &repository{
 		db:          db,
@@ -52,6 +105,11 @@ func NewRepository(db *gorm.DB, redisClient *redis.Client) Repository {
 	}
 }
 
+// SetHoldRetry sets how hold scripts are retried while Redis fails over
+func (r *repository) SetHoldRetry(attempts int, backoff time.Duration) {
+	r.atomicRedis.SetRetry(attempts, backoff)
+}
+
 // SEAT CRUD
 
 func (r *repository) CreateSeats(ctx context.Context, seats []Seat) error {
@@ -70,6 +128,7 @@ func (r *repository) GetSeatByID(ctx context.Context, id uuid.UUID) (*Seat, erro
 func (r *repository) GetSeatsBySectionID(ctx context.Context, sectionID uuid.UUID) ([]Seat, error) {
 	var seats []Seat
 	err := r.db.WithContext(ctx).
+		Scopes(dbresolver.Read).
 		Where("section_id = ?", sectionID).
 		Order("row ASC, position ASC").
 		Find(&seats).Error
@@ -85,6 +144,23 @@ func (r *repository) GetSeatsByIDs(ctx context.Context, seatIDs []uuid.UUID) ([]
 	return seats, err
 }
 
+func (r *repository) GetSeatBookingRules(ctx context.Context) (*SeatBookingRules, error) {
+	var rules SeatBookingRules
+	err := r.db.WithContext(ctx).Where("id = ?", seatBookingRulesID).First(&rules).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return DefaultSeatBookingRules(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &rules, nil
+}
+
+func (r *repository) SaveSeatBookingRules(ctx context.Context, rules *SeatBookingRules) error {
+	rules.ID = seatBookingRulesID
+	return r.db.WithContext(ctx).Save(rules).Error
+}
+
 func (r *repository) UpdateSeat(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
 	return r.db.WithContext(ctx).Model(&Seat{}).Where("id = ?", id).Updates(updates).Error
 }
@@ -105,6 +181,45 @@ func (r *repository) DeleteSeatsBySectionID(ctx context.Context, sectionID uuid.
 	return r.db.WithContext(ctx).Delete(&Seat{}, "section_id = ?", sectionID).Error
 }
 
+// BulkUpdateSeats applies updates to seats of a section in one transaction and
+// returns how many seats changed. Seats that already have the values are left alone.
+func (r *repository) BulkUpdateSeats(ctx context.Context, sectionID uuid.UUID, seatIDs []uuid.UUID, updates map[string]interface{}) (int64, error) {
+	columns := make([]string, 0, len(updates))
+	for column := range updates {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+	unchanged := make([]string, len(columns))
+	values := make([]interface{}, len(columns))
+	for i, column := range columns {
+		unchanged[i] = column + " = ?"
+		values[i] = updates[column]
+	}
+
+	var changed int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var locked []uuid.UUID
+		err := tx.Model(&Seat{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id IN ? AND section_id = ?", seatIDs, sectionID).
+			Pluck("id", &locked).Error
+		if err != nil {
+			return err
+		}
+		if len(locked) != len(seatIDs) {
+			return fmt.Errorf("seats changed during the update, %d of %d are still in the section", len(locked), len(seatIDs))
+		}
+
+		result := tx.Model(&Seat{}).
+			Where("id IN ?", seatIDs).
+			Where("NOT ("+strings.Join(unchanged, " AND ")+")", values...).
+			Updates(updates)
+		changed = result.RowsAffected
+		return result.Error
+	})
+	return changed, err
+}
+
 // AVAILABILITY CHECKS
 
 func (r *repository) CheckSeatsAvailability(ctx context.Context, seatIDs []uuid.UUID) (map[string]bool, error) {
@@ -129,6 +244,7 @@ func (r *repository) CheckSeatsAvailability(ctx context.Context, seatIDs []uuid.
 func (r *repository) GetAvailableSeatsInSection(ctx context.Context, sectionID uuid.UUID) ([]Seat, error) {
 	var seats []Seat
 	err := r.db.WithContext(ctx).
+		Scopes(dbresolver.Read).
 		Where("section_id = ? AND status = ?", sectionID, "AVAILABLE").
 		Order("row ASC, position ASC").
 		Find(&seats).Error
@@ -137,18 +253,18 @@ func (r *repository) GetAvailableSeatsInSection(ctx context.Context, sectionID u
 
 // REDIS SEAT HOLDING
 
-func (r *repository) HoldSeats(ctx context.Context, seatIDs []uuid.UUID, userID, holdID, eventID string, ttl time.Duration) error {
+func (r *repository) HoldSeats(ctx context.Context, seatIDs []uuid.UUID, owner HoldOwner, holdID, eventID string, ttl time.Duration) error {
 	// Use atomic version for better concurrency control
-	return r.AtomicHoldSeats(ctx, seatIDs, userID, holdID, eventID, ttl)
+	return r.AtomicHoldSeats(ctx, seatIDs, owner, holdID, eventID, ttl)
 }
 
 // AtomicHoldSeats provides atomic seat holding using Lua scripts
-func (r *repository) AtomicHoldSeats(ctx context.Context, seatIDs []uuid.UUID, userID, holdID, eventID string, ttl time.Duration) error {
+func (r *repository) AtomicHoldSeats(ctx context.Context, seatIDs []uuid.UUID, owner HoldOwner, holdID, eventID string, ttl time.Duration) error {
 	if r.atomicRedis == nil {
 		return fmt.Errorf("atomic redis operations not available - seat holding disabled")
 	}
 
-	return r.atomicRedis.AtomicHoldSeats(ctx, seatIDs, userID, holdID, eventID, ttl)
+	return r.atomicRedis.AtomicHoldSeats(ctx, seatIDs, owner, holdID, eventID, ttl)
 }
 
 func (r *repository) ReleaseHold(ctx context.Context, holdID string) error {
@@ -166,6 +282,229 @@ func (r *repository) AtomicReleaseHold(ctx context.Context, holdID string) (int,
 	return r.atomicRedis.AtomicReleaseHold(ctx, holdID)
 }
 
+// AtomicExtendHold extends a hold's TTL using Lua scripts
+func (r *repository) AtomicExtendHold(ctx context.Context, holdID, ownerToken string, extension, maxTotal time.Duration) (time.Duration, error) {
+	if r.atomicRedis == nil {
+		return 0, fmt.Errorf("atomic redis operations not available - seat holding disabled")
+	}
+
+	return r.atomicRedis.AtomicExtendHold(ctx, holdID, ownerToken, extension, maxTotal)
+}
+
+// PublishHoldEvent publishes a hold lifecycle event on the owner's hold channel
+// so connected checkout clients can refresh their timers
+func (r *repository) PublishHoldEvent(ctx context.Context, event *HoldEvent) error {
+	if r.redis == nil {
+		return fmt.Errorf("redis client not available")
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal hold event: %w", err)
+	}
+
+	channel := fmt.Sprintf("hold_events:%s", event.OwnerToken)
+	return r.redis.Publish(ctx, channel, payload).Err()
+}
+
+// HOLD EXPIRY TRACKING
+
+func (r *repository) TrackHoldExpiry(ctx context.Context, hold TrackedHold) error {
+	if r.redis == nil {
+		return fmt.Errorf("redis client not available")
+	}
+
+	data, err := json.Marshal(hold)
+	if err != nil {
+		return fmt.Errorf("failed to marshal tracked hold: %w", err)
+	}
+
+	pipe := r.redis.TxPipeline()
+	pipe.HSet(ctx, holdExpiryDataKey, hold.HoldID, data)
+	pipe.ZAdd(ctx, holdExpiryKey, redis.Z{Score: float64(hold.ExpiresAt.Unix()), Member: hold.HoldID})
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+func (r *repository) UntrackHoldExpiry(ctx context.Context, holdID string) error {
+	if r.redis == nil {
+		return fmt.Errorf("redis client not available")
+	}
+
+	pipe := r.redis.TxPipeline()
+	pipe.ZRem(ctx, holdExpiryKey, holdID)
+	pipe.HDel(ctx, holdExpiryDataKey, holdID)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// ClaimExpiredHolds returns tracked holds due before the given time whose hold
+// key is gone. Holds that were extended are rescored instead. Removing a hold
+// from the set is the claim, so concurrent sweepers never return the same hold.
+func (r *repository) ClaimExpiredHolds(ctx context.Context, before time.Time, limit int) ([]TrackedHold, error) {
+	if r.redis == nil {
+		return nil, fmt.Errorf("redis client not available")
+	}
+
+	holdIDs, err := r.redis.ZRangeByScore(ctx, holdExpiryKey, &redis.ZRangeBy{
+		Min:   "-inf",
+		Max:   strconv.FormatInt(before.Unix(), 10),
+		Count: int64(limit),
+	}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tracked holds: %w", err)
+	}
+
+	var expired []TrackedHold
+	for _, holdID := range holdIDs {
+		ttl, err := r.redis.TTL(ctx, holdKey(holdID)).Result()
+		if err != nil {
+			continue
+		}
+		// -2 means the key is gone, anything else means the hold is still alive
+		if ttl != -2 {
+			if ttl > 0 {
+				r.redis.ZAdd(ctx, holdExpiryKey, redis.Z{Score: float64(time.Now().Add(ttl).Unix()), Member: holdID})
+			}
+			continue
+		}
+
+		removed, err := r.redis.ZRem(ctx, holdExpiryKey, holdID).Result()
+		if err != nil || removed == 0 {
+			continue // claimed by another sweeper
+		}
+
+		data, err := r.redis.HGet(ctx, holdExpiryDataKey, holdID).Result()
+		r.redis.HDel(ctx, holdExpiryDataKey, holdID)
+		if err != nil {
+			continue
+		}
+
+		var hold TrackedHold
+		if err := json.Unmarshal([]byte(data), &hold); err != nil {
+			continue
+		}
+		expired = append(expired, hold)
+	}
+
+	return expired, nil
+}
+
+// HOLD MONITORING
+
+// holds created are counted in per-minute buckets
+func holdsCreatedKey(minute int64) string {
+	return fmt.Sprintf("%shold_metrics:created:%d", holdKeyPrefix, minute)
+}
+
+func (r *repository) RecordHoldCreated(ctx context.Context) error {
+	if r.redis == nil {
+		return fmt.Errorf("redis client not available")
+	}
+
+	key := holdsCreatedKey(time.Now().Unix() / 60)
+	pipe := r.redis.TxPipeline()
+	pipe.Incr(ctx, key)
+	pipe.Expire(ctx, key, 24*time.Hour)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (r *repository) CountHoldsCreatedSince(ctx context.Context, since time.Time) (int64, error) {
+	if r.redis == nil {
+		return 0, fmt.Errorf("redis client not available")
+	}
+
+	var keys []string
+	for minute := since.Unix() / 60; minute <= time.Now().Unix()/60; minute++ {
+		keys = append(keys, holdsCreatedKey(minute))
+	}
+
+	values, err := r.redis.MGet(ctx, keys...).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	var total int64
+	for _, value := range values {
+		if str, ok := value.(string); ok {
+			if count, err := strconv.ParseInt(str, 10, 64); err == nil {
+				total += count
+			}
+		}
+	}
+	return total, nil
+}
+
+// ScanHolds returns a snapshot of every active hold in Redis
+func (r *repository) ScanHolds(ctx context.Context) ([]HoldSnapshot, error) {
+	if r.redis == nil {
+		return nil, fmt.Errorf("redis client not available")
+	}
+
+	// A cluster keeps every hold on the node owning the {holds} slot
+	keys, err := cache.MatchKeys(ctx, r.redis, holdKey("*"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan holds: %w", err)
+	}
+
+	var snapshots []HoldSnapshot
+	for _, key := range keys {
+		data, err := r.redis.HGetAll(ctx, key).Result()
+		if err != nil || len(data) == 0 {
+			continue // expired between scan and read
+		}
+
+		ttl, err := r.redis.TTL(ctx, key).Result()
+		if err != nil {
+			continue
+		}
+
+		snapshot := HoldSnapshot{
+			HoldID:     strings.TrimPrefix(key, holdKey("")),
+			OwnerToken: data["owner"],
+			EventID:    data["event_id"],
+			TTL:        ttl,
+		}
+		snapshot.SeatCount, _ = strconv.Atoi(data["seat_count"])
+		if createdAt, err := strconv.ParseInt(data["created_at"], 10, 64); err == nil {
+			snapshot.CreatedAt = time.Unix(createdAt, 0)
+		}
+
+		snapshots = append(snapshots, snapshot)
+	}
+
+	return snapshots, nil
+}
+
+func (r *repository) CountSeatHoldKeys(ctx context.Context) (int64, error) {
+	if r.redis == nil {
+		return 0, fmt.Errorf("redis client not available")
+	}
+
+	var count atomic.Int64
+	err := cache.ScanKeys(ctx, r.redis, seatHoldKey("*"), 500, func(keys []string) error {
+		count.Add(int64(len(keys)))
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to scan seat holds: %w", err)
+	}
+	return count.Load(), nil
+}
+
+func (r *repository) CountBookingsSince(ctx context.Context, since time.Time) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Table("bookings").
+		Where("created_at >= ?", since).
+		Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to count bookings: %w", err)
+	}
+	return count, nil
+}
+
 func (r *repository) CheckSeatHolds(ctx context.Context, seatIDs []uuid.UUID) (map[string]string, error) {
 	holds := make(map[string]string)
 
@@ -174,8 +513,7 @@ func (r *repository) CheckSeatHolds(ctx context.Context, seatIDs []uuid.UUID) (m
 	}
 
 	for _, seatID := range seatIDs {
-		seatHoldKey := fmt.Sprintf("seat_hold:%s", seatID.String())
-		holdValue, err := r.redis.Get(ctx, seatHoldKey).Result()
+		holdValue, err := r.redis.Get(ctx, seatHoldKey(seatID.String())).Result()
 
 		if err == redis.Nil {
 			// No hold on this seat
@@ -190,13 +528,12 @@ func (r *repository) CheckSeatHolds(ctx context.Context, seatIDs []uuid.UUID) (m
 	return holds, nil
 }
 
-func (r *repository) GetUserHolds(ctx context.Context, userID string) ([]string, error) {
+func (r *repository) GetUserHolds(ctx context.Context, ownerToken string) ([]string, error) {
 	if r.redis == nil {
 		return []string{}, nil // Return empty slice if Redis not available
 	}
 
-	userHoldsKey := fmt.Sprintf("user_holds:%s", userID)
-	holdIDs, err := r.redis.SMembers(ctx, userHoldsKey).Result()
+	holdIDs, err := r.redis.SMembers(ctx, userHoldsKey(ownerToken)).Result()
 	if err == redis.Nil {
 		return []string{}, nil
 	}
@@ -208,8 +545,7 @@ func (r *repository) IsHoldValid(ctx context.Context, holdID string) (bool, erro
 		return false, fmt.Errorf("Redis client not available - seat holding disabled")
 	}
 
-	holdKey := fmt.Sprintf("hold:%s", holdID)
-	exists, err := r.redis.Exists(ctx, holdKey).Result()
+	exists, err := r.redis.Exists(ctx, holdKey(holdID)).Result()
 	return exists > 0, err
 }
 
@@ -218,8 +554,8 @@ func (r *repository) GetHoldDetails(ctx context.Context, holdID string) (*SeatHo
 		return nil, fmt.Errorf("redis client not available - seat holding disabled")
 	}
 
-	holdKey := fmt.Sprintf("hold:%s", holdID)
-	holdData, err := r.redis.HGetAll(ctx, holdKey).Result()
+	key := holdKey(holdID)
+	holdData, err := r.redis.HGetAll(ctx, key).Result()
 	if err != nil {
 		if err == redis.Nil {
 			return nil, fmt.Errorf("hold not found")
@@ -232,34 +568,311 @@ func (r *repository) GetHoldDetails(ctx context.Context, holdID string) (*SeatHo
 	}
 
 	// Get seat IDs
-	holdSeatsKey := fmt.Sprintf("hold_seats:%s", holdID)
-	seatIDs, err := r.redis.SMembers(ctx, holdSeatsKey).Result()
+	seatIDs, err := r.redis.SMembers(ctx, holdSeatsKey(holdID)).Result()
 	if err != nil && err != redis.Nil {
 		return nil, err
 	}
 
-	ttl, err := r.redis.TTL(ctx, holdKey).Result()
+	ttl, err := r.redis.TTL(ctx, key).Result()
 	if err != nil {
 		ttl = 0
 	}
 
 	details := &SeatHoldDetails{
-		HoldID:  holdID,
-		UserID:  holdData["user_id"],
-		EventID: holdData["event_id"],
-		SeatIDs: seatIDs,
-		TTL:     int(ttl.Seconds()),
+		HoldID:       holdID,
+		UserID:       holdData["user_id"], // only set on holds created before owner tokens
+		OwnerToken:   holdData["owner"],
+		EventID:      holdData["event_id"],
+		SeatIDs:      seatIDs,
+		TicketTypeID: holdData["ticket_type_id"],
+		TTL:          int(ttl.Seconds()),
+	}
+	details.Quantity, _ = strconv.Atoi(holdData["quantity"])
+
+	// The owner's user ID is stored encrypted next to the hold, the service decrypts it
+	if details.OwnerToken != "" {
+		sealed, err := r.redis.Get(ctx, holdOwnerKey(holdID)).Result()
+		if err != nil && err != redis.Nil {
+			return nil, err
+		}
+		details.SealedOwner = sealed
 	}
 
 	return details, nil
 }
 
+// GENERAL ADMISSION
+
+func (r *repository) CreateTicketType(ctx context.Context, ticketType *TicketType) error {
+	return r.db.WithContext(ctx).Create(ticketType).Error
+}
+
+func (r *repository) GetTicketTypeByID(ctx context.Context, id uuid.UUID) (*TicketType, error) {
+	var ticketType TicketType
+	err := r.db.WithContext(ctx).First(&ticketType, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &ticketType, nil
+}
+
+func (r *repository) GetTicketTypesByEvent(ctx context.Context, eventID uuid.UUID) ([]TicketType, error) {
+	var ticketTypes []TicketType
+	err := r.db.WithContext(ctx).
+		Where("event_id = ?", eventID).
+		Order("created_at ASC").
+		Find(&ticketTypes).Error
+	return ticketTypes, err
+}
+
+func (r *repository) UpdateTicketType(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
+	return r.db.WithContext(ctx).Model(&TicketType{}).Where("id = ?", id).Updates(updates).Error
+}
+
+func (r *repository) DeleteTicketType(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&TicketType{}, "id = ?", id).Error
+}
+
+// CountTicketsSold sums the tickets of non-cancelled bookings per ticket type
+func (r *repository) CountTicketsSold(ctx context.Context, ticketTypeIDs []uuid.UUID) (map[uuid.UUID]int, error) {
+	sold := make(map[uuid.UUID]int, len(ticketTypeIDs))
+	if len(ticketTypeIDs) == 0 {
+		return sold, nil
+	}
+
+	var rows []struct {
+		TicketTypeID uuid.UUID
+		Sold         int
+	}
+	err := r.db.WithContext(ctx).
+		Table("ticket_bookings tb").
+		Select("tb.ticket_type_id, COALESCE(SUM(tb.quantity), 0) AS sold").
+		Joins("JOIN bookings b ON b.id = tb.booking_id").
+		Where("tb.ticket_type_id IN ? AND b.status != 'CANCELLED'", ticketTypeIDs).
+		Group("tb.ticket_type_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to count tickets sold: %w", err)
+	}
+
+	for _, row := range rows {
+		sold[row.TicketTypeID] = row.Sold
+	}
+	return sold, nil
+}
+
+func (r *repository) GetTicketingEvent(ctx context.Context, eventID uuid.UUID) (*TicketingEvent, error) {
+	var event TicketingEvent
+	err := r.db.WithContext(ctx).
+		Table("events").
+		Select("id, venue_template_id, base_price, currency").
+		Where("id = ? AND deleted_at IS NULL", eventID).
+		Take(&event).Error
+	if err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+func (r *repository) GetSectionTemplateID(ctx context.Context, sectionID uuid.UUID) (uuid.UUID, error) {
+	var section struct {
+		TemplateID uuid.UUID
+	}
+	err := r.db.WithContext(ctx).
+		Table("venue_sections").
+		Select("template_id").
+		Where("id = ?", sectionID).
+		Take(&section).Error
+	return section.TemplateID, err
+}
+
+// GetTemplateEventIDs lists the events held on a venue template
+func (r *repository) GetTemplateEventIDs(ctx context.Context, templateID uuid.UUID) ([]uuid.UUID, error) {
+	var eventIDs []uuid.UUID
+	err := r.db.WithContext(ctx).
+		Table("events").
+		Where("venue_template_id = ? AND deleted_at IS NULL", templateID).
+		Pluck("id", &eventIDs).Error
+	return eventIDs, err
+}
+
+// FindGeneralAdmissionSections returns which of the sections are sold as general admission for the event
+func (r *repository) FindGeneralAdmissionSections(ctx context.Context, eventID uuid.UUID, sectionIDs []uuid.UUID) ([]uuid.UUID, error) {
+	var gaSections []uuid.UUID
+	err := r.db.WithContext(ctx).
+		Model(&TicketType{}).
+		Where("event_id = ? AND section_id IN ?", eventID, sectionIDs).
+		Distinct().
+		Pluck("section_id", &gaSections).Error
+	return gaSections, err
+}
+
+// AtomicHoldTickets takes general admission tickets using Lua scripts
+func (r *repository) AtomicHoldTickets(ctx context.Context, ticketTypeID uuid.UUID, quantity, seed int, owner HoldOwner, holdID, eventID string, ttl time.Duration) (int, error) {
+	if r.atomicRedis == nil {
+		return 0, fmt.Errorf("atomic redis operations not available - seat holding disabled")
+	}
+
+	return r.atomicRedis.AtomicHoldTickets(ctx, ticketTypeID, quantity, seed, owner, holdID, eventID, ttl)
+}
+
+func (r *repository) TicketsRemaining(ctx context.Context, ticketTypeID uuid.UUID, seed int) (int, error) {
+	if r.atomicRedis == nil {
+		return 0, fmt.Errorf("atomic redis operations not available - seat holding disabled")
+	}
+
+	return r.atomicRedis.TicketsRemaining(ctx, ticketTypeID, seed)
+}
+
+func (r *repository) AdjustTicketsRemaining(ctx context.Context, ticketTypeID uuid.UUID, delta int) error {
+	if r.atomicRedis == nil {
+		return fmt.Errorf("atomic redis operations not available - seat holding disabled")
+	}
+
+	return r.atomicRedis.AdjustTicketsRemaining(ctx, ticketTypeID, delta)
+}
+
+func (r *repository) ClearTicketsRemaining(ctx context.Context, ticketTypeID uuid.UUID) error {
+	if r.redis == nil {
+		return fmt.Errorf("redis client not available - seat holding disabled")
+	}
+
+	return r.redis.Del(ctx, ticketsRemainingKey(ticketTypeID.String())).Err()
+}
+
+func (r *repository) ConsumeTicketHold(ctx context.Context, holdID string) error {
+	if r.atomicRedis == nil {
+		return fmt.Errorf("atomic redis operations not available - seat holding disabled")
+	}
+
+	return r.atomicRedis.ConsumeTicketHold(ctx, holdID)
+}
+
+//  EVENT SEAT BLOCKS
+
+func (r *repository) CreateSeatBlocks(ctx context.Context, blocks []SeatBlock) error {
+	return r.db.WithContext(ctx).Create(&blocks).Error
+}
+
+// DeleteSeatBlocks drops blocks that were never in effect
+func (r *repository) DeleteSeatBlocks(ctx context.Context, blockIDs []uuid.UUID) error {
+	return r.db.WithContext(ctx).Where("id IN ?", blockIDs).Delete(&SeatBlock{}).Error
+}
+
+// ReleaseSeatBlocks ends the active blocks on the seats and returns them
+func (r *repository) ReleaseSeatBlocks(ctx context.Context, eventID uuid.UUID, seatIDs []uuid.UUID, releasedBy uuid.UUID) ([]SeatBlock, error) {
+	var released []SeatBlock
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("event_id = ? AND seat_id IN ? AND released_at IS NULL", eventID, seatIDs).
+			Find(&released).Error; err != nil {
+			return err
+		}
+		if len(released) == 0 {
+			return nil
+		}
+
+		now := time.Now()
+		ids := make([]uuid.UUID, len(released))
+		for i := range released {
+			ids[i] = released[i].ID
+			released[i].ReleasedAt = &now
+			released[i].ReleasedBy = &releasedBy
+		}
+		return tx.Model(&SeatBlock{}).
+			Where("id IN ?", ids).
+			Updates(map[string]interface{}{"released_at": now, "released_by": releasedBy}).Error
+	})
+	return released, err
+}
+
+func (r *repository) ListSeatBlocks(ctx context.Context, eventID uuid.UUID, includeReleased bool) ([]SeatBlockResponse, error) {
+	var rows []struct {
+		SeatBlock
+		SeatNumber  string
+		Row         string
+		SectionID   uuid.UUID
+		SectionName string
+	}
+	query := r.db.WithContext(ctx).
+		Table("event_seat_blocks esb").
+		Select("esb.*, s.seat_number, s.row, s.section_id, vs.name AS section_name").
+		Joins("JOIN seats s ON s.id = esb.seat_id").
+		Joins("JOIN venue_sections vs ON vs.id = s.section_id").
+		Where("esb.event_id = ?", eventID)
+	if !includeReleased {
+		query = query.Where("esb.released_at IS NULL")
+	}
+	if err := query.Order("vs.name ASC, s.row ASC, s.position ASC, esb.created_at DESC").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	blocks := make([]SeatBlockResponse, len(rows))
+	for i, row := range rows {
+		blocks[i] = SeatBlockResponse{
+			SeatBlock:   row.SeatBlock,
+			SeatNumber:  row.SeatNumber,
+			Row:         row.Row,
+			SectionID:   row.SectionID,
+			SectionName: row.SectionName,
+		}
+	}
+	return blocks, nil
+}
+
+// GetBlockedSeats returns which of the seats are blocked for the event, with
+// the reason. Seats are only filtered when seatIDs is not empty.
+func (r *repository) GetBlockedSeats(ctx context.Context, eventID uuid.UUID, seatIDs []uuid.UUID) (map[uuid.UUID]string, error) {
+	var rows []struct {
+		SeatID uuid.UUID
+		Reason string
+	}
+	query := r.db.WithContext(ctx).
+		Model(&SeatBlock{}).
+		Select("seat_id, reason").
+		Where("event_id = ? AND released_at IS NULL", eventID)
+	if len(seatIDs) > 0 {
+		query = query.Where("seat_id IN ?", seatIDs)
+	}
+	if err := query.Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to query seat blocks: %w", err)
+	}
+
+	blocked := make(map[uuid.UUID]string, len(rows))
+	for _, row := range rows {
+		blocked[row.SeatID] = row.Reason
+	}
+	return blocked, nil
+}
+
 // Helper struct
 
+// HoldSnapshot is a point-in-time view of a hold used by the hold monitor
+type HoldSnapshot struct {
+	HoldID     string
+	OwnerToken string
+	EventID    string
+	SeatCount  int
+	CreatedAt  time.Time
+	TTL        time.Duration
+}
+
 type SeatHoldDetails struct {
-	HoldID  string   `json:"hold_id"`
-	UserID  string   `json:"user_id"`
-	EventID string   `json:"event_id"`
-	SeatIDs []string `json:"seat_ids"`
-	TTL     int      `json:"ttl_seconds"`
+	HoldID       string   `json:"hold_id"`
+	UserID       string   `json:"user_id"`
+	OwnerToken   string   `json:"-"`
+	SealedOwner  string   `json:"-"`
+	EventID      string   `json:"event_id"`
+	SeatIDs      []string `json:"seat_ids"`
+	TicketTypeID string   `json:"ticket_type_id,omitempty"` // Set on general admission holds
+	Quantity     int      `json:"quantity,omitempty"`
+	TTL          int      `json:"ttl_seconds"`
+}
+
+// TicketingEvent is what general admission needs to know about an event
+type TicketingEvent struct {
+	ID              uuid.UUID
+	VenueTemplateID uuid.UUID
+	BasePrice       float64
+	Currency        string
 }
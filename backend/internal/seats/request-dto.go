@@ -5,6 +5,37 @@ type UpdateSeatRequest struct {
 	Row        *string `json:"row" binding:"omitempty"`
 	Position   *int    `json:"position" binding:"omitempty,min=1"`
 	Status     *string `json:"status" binding:"omitempty,oneof=AVAILABLE BLOCKED"`
+
+	WheelchairAccessible *bool `json:"wheelchair_accessible"`
+	CompanionSeat        *bool `json:"companion_seat"`
+	RestrictedView       *bool `json:"restricted_view"`
+	Aisle                *bool `json:"aisle"`
+}
+
+// BulkUpdateSeatsRequest changes the status or attributes of whole rows or
+// seat ranges of a section, e.g. block row C or mark A1-A10 as aisle seats
+type BulkUpdateSeatsRequest struct {
+	SectionID string      `json:"section_id" binding:"required,uuid"`
+	Rows      []string    `json:"rows" binding:"omitempty,max=100,dive,min=1,max=10"`
+	Ranges    []SeatRange `json:"ranges" binding:"omitempty,max=100,dive"`
+
+	Status               *string `json:"status" binding:"omitempty,oneof=AVAILABLE BLOCKED"`
+	WheelchairAccessible *bool   `json:"wheelchair_accessible"`
+	CompanionSeat        *bool   `json:"companion_seat"`
+	RestrictedView       *bool   `json:"restricted_view"`
+	Aisle                *bool   `json:"aisle"`
+}
+
+// SeatRange selects the seats from one seat number to another, inclusive, in
+// seat map order
+type SeatRange struct {
+	From string `json:"from" binding:"required,max=20"`
+	To   string `json:"to" binding:"required,max=20"`
+}
+
+type UpdateSeatBookingRulesRequest struct {
+	CompanionRequiresAccessible *bool `json:"companion_requires_accessible"`
+	MaxCompanionsPerAccessible  *int  `json:"max_companions_per_accessible" binding:"omitempty,min=0,max=10"`
 }
 
 // Seat holding models (Your core booking flow)
@@ -13,3 +44,39 @@ type SeatHoldRequest struct {
 	SeatIDs []string `json:"seat_ids" binding:"required,min=1"`
 	UserID  string   `json:"user_id" binding:"required,uuid"`
 }
+
+// Optional body for hold extension, defaults to the configured extension step
+type ExtendHoldRequest struct {
+	ExtendBySeconds int `json:"extend_by_seconds" binding:"omitempty,min=1"`
+}
+
+// General admission ticket types
+type CreateTicketTypeRequest struct {
+	EventID         string  `json:"event_id" binding:"required,uuid"`
+	SectionID       *string `json:"section_id" binding:"omitempty,uuid"` // Venue section the tickets admit to, if any
+	Name            string  `json:"name" binding:"required,min=1,max=100"`
+	Capacity        int     `json:"capacity" binding:"required,min=1,max=1000000"`
+	PriceMultiplier float64 `json:"price_multiplier" binding:"omitempty,min=0.1,max=10"` // Applied to the event base price, defaults to 1
+}
+
+type UpdateTicketTypeRequest struct {
+	Name            *string  `json:"name" binding:"omitempty,min=1,max=100"`
+	Capacity        *int     `json:"capacity" binding:"omitempty,min=1,max=1000000"`
+	PriceMultiplier *float64 `json:"price_multiplier" binding:"omitempty,min=0.1,max=10"`
+}
+
+type TicketHoldRequest struct {
+	Quantity int    `json:"quantity" binding:"required,min=1,max=20"`
+	UserID   string `json:"user_id" binding:"required,uuid"`
+}
+
+// Event seat blocks
+type BlockSeatsRequest struct {
+	SeatIDs []string `json:"seat_ids" binding:"required,min=1,max=500,dive,uuid"`
+	Reason  string   `json:"reason" binding:"required,oneof=VIP PRESS EQUIPMENT OTHER"`
+	Note    string   `json:"note" binding:"omitempty,max=255"`
+}
+
+type ReleaseSeatBlocksRequest struct {
+	SeatIDs []string `json:"seat_ids" binding:"required,min=1,max=500,dive,uuid"`
+}
@@ -1,15 +1,32 @@
 package seats
 
-import "time"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type SeatResponse struct {
-	ID         string  `json:"id"`
-	SeatNumber string  `json:"seat_number"`
-	Row        string  `json:"row"`
-	Position   int     `json:"position"`
-	Status     string  `json:"status"`
-	Price      float64 `json:"price"`
-	IsHeld     bool    `json:"is_held"`
+	ID          string   `json:"id"`
+	SeatNumber  string   `json:"seat_number"`
+	Row         string   `json:"row"`
+	Position    int      `json:"position"`
+	Status      string   `json:"status"`
+	Price       float64  `json:"price"`
+	IsHeld      bool     `json:"is_held"`
+	HoldUnknown bool     `json:"hold_unknown,omitempty"` // Redis was down, the seat may be held
+	Attributes  []string `json:"attributes,omitempty"`
+}
+
+// BulkUpdateSeatsResponse summarizes a bulk seat update
+type BulkUpdateSeatsResponse struct {
+	SectionID string                 `json:"section_id"`
+	Matched   int                    `json:"matched"`   // Seats the rows and ranges select
+	Updated   int64                  `json:"updated"`   // Seats that changed
+	Unchanged int64                  `json:"unchanged"` // Seats that already had the values
+	Rows      []string               `json:"rows"`      // Rows the selected seats are in
+	Changes   map[string]interface{} `json:"changes"`
+	SeatIDs   []string               `json:"seat_ids"`
 }
 
 type SeatHoldResponse struct {
@@ -18,10 +35,27 @@ type SeatHoldResponse struct {
 	UserID     string         `json:"user_id"`
 	Seats      []HeldSeatInfo `json:"seats"`
 	TotalPrice float64        `json:"total_price"`
+	Currency   string         `json:"currency"`
 	ExpiresAt  time.Time      `json:"expires_at"`
 	TTL        int            `json:"ttl_seconds"`
 }
 
+// TicketHoldResponse is a general admission hold: a quantity instead of seats
+type TicketHoldResponse struct {
+	HoldID       string    `json:"hold_id"`
+	EventID      string    `json:"event_id"`
+	UserID       string    `json:"user_id"`
+	TicketTypeID string    `json:"ticket_type_id"`
+	Name         string    `json:"name"`
+	Quantity     int       `json:"quantity"`
+	UnitPrice    float64   `json:"unit_price"`
+	TotalPrice   float64   `json:"total_price"`
+	Currency     string    `json:"currency"`
+	Remaining    int       `json:"remaining"` // Tickets of this type left after the hold
+	ExpiresAt    time.Time `json:"expires_at"`
+	TTL          int       `json:"ttl_seconds"`
+}
+
 type HeldSeatInfo struct {
 	SeatID      string  `json:"seat_id"`
 	SectionID   string  `json:"section_id"`
@@ -39,9 +73,28 @@ type HoldValidationResult struct {
 	TTL     int              `json:"ttl_seconds,omitempty"`
 }
 
+// Hold extension models
+type HoldExtensionResponse struct {
+	HoldID     string    `json:"hold_id"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	TTL        int       `json:"ttl_seconds"`
+	MaxHoldTTL int       `json:"max_hold_seconds"`
+}
+
+// HoldEvent is published to hold_events:<owner_token> when a hold changes
+type HoldEvent struct {
+	Type       string    `json:"type"` // HOLD_EXTENDED
+	HoldID     string    `json:"hold_id"`
+	OwnerToken string    `json:"owner"`
+	EventID    string    `json:"event_id"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	TTL        int       `json:"ttl_seconds"`
+}
+
 // Availability models
 type SeatAvailabilityResponse struct {
-	Seats []SeatAvailabilityInfo `json:"seats"`
+	Seats       []SeatAvailabilityInfo `json:"seats"`
+	Approximate bool                   `json:"approximate,omitempty"` // Postgres only, holds unknown
 }
 
 type SeatAvailabilityInfo struct {
@@ -50,3 +103,35 @@ type SeatAvailabilityInfo struct {
 	Status    string `json:"status"` // AVAILABLE, BOOKED, BLOCKED, HELD
 	HoldInfo  string `json:"hold_info,omitempty"`
 }
+
+// TicketTypeResponse is a general admission ticket type with its live availability
+type TicketTypeResponse struct {
+	ID              string  `json:"id"`
+	EventID         string  `json:"event_id"`
+	SectionID       *string `json:"section_id,omitempty"`
+	Name            string  `json:"name"`
+	Capacity        int     `json:"capacity"`
+	Sold            int     `json:"sold"`
+	Held            int     `json:"held"`
+	Remaining       int     `json:"remaining"`
+	SoldOut         bool    `json:"sold_out"`
+	HeldUnknown     bool    `json:"held_unknown,omitempty"` // Redis was down, held tickets are counted as remaining
+	PriceMultiplier float64 `json:"price_multiplier"`
+	Price           float64 `json:"price"`
+}
+
+// SeatBlockResponse is a seat block with the seat it covers
+type SeatBlockResponse struct {
+	SeatBlock
+	SeatNumber  string    `json:"seat_number"`
+	Row         string    `json:"row"`
+	SectionID   uuid.UUID `json:"section_id"`
+	SectionName string    `json:"section_name"`
+}
+
+// SeatBlockReleaseResponse lists the seats returned to sale
+type SeatBlockReleaseResponse struct {
+	EventID  string   `json:"event_id"`
+	Released int      `json:"released"`
+	SeatIDs  []string `json:"seat_ids"`
+}
@@ -16,21 +16,61 @@ func SetupSeatRoutes(rg *gin.RouterGroup, controller *Controller) {
 		// Individual seat
 		seats.GET("/:id", controller.GetSeat) // GET /api/v1/seats/:id
 
+		// Hold extensions are for shoppers in checkout
+		seats.POST("/holds/:holdId/extend", controller.ExtendHold) // POST /api/v1/seats/holds/:holdId/extend
+	}
+
+	// Booking flow - partner keys with bookings:write can hold seats before confirming
+	bookingFlow := rg.Group("/seats")
+	bookingFlow.Use(middleware.JWTOrAPIKey(middleware.ScopeBookingsWrite), middleware.RequireRoles("USER", "ADMIN"))
+	{
 		// Core seat holding endpoints (booking flow)
-		seats.POST("/hold", controller.HoldSeats)                    // POST /api/v1/seats/hold
-		seats.DELETE("/hold/:holdId", controller.ReleaseHold)        // DELETE /api/v1/seats/hold/:holdId
-		seats.GET("/hold/:holdId/validate", controller.ValidateHold) // GET /api/v1/seats/hold/:holdId/validate
+		bookingFlow.POST("/hold", controller.HoldSeats)                    // POST /api/v1/seats/hold
+		bookingFlow.DELETE("/hold/:holdId", controller.ReleaseHold)        // DELETE /api/v1/seats/hold/:holdId
+		bookingFlow.GET("/hold/:holdId/validate", controller.ValidateHold) // GET /api/v1/seats/hold/:holdId/validate
 
 		// Availability checks
-		seats.POST("/availability", controller.CheckSeatAvailability) // POST /api/v1/seats/availability
+		bookingFlow.POST("/availability", controller.CheckSeatAvailability) // POST /api/v1/seats/availability
 	}
 
 	// ADMIN SEAT OPERATIONS
 	adminSeats := rg.Group("/admin/seats")
-	adminSeats.Use(middleware.JWTAuth(), middleware.RequireAdmin())
+	adminSeats.Use(middleware.JWTAuth(), middleware.RequirePermission(middleware.PermissionVenuesManage))
+	{
+		adminSeats.GET("/rules", controller.GetSeatBookingRules)    // GET /api/v1/admin/seats/rules
+		adminSeats.PUT("/rules", controller.UpdateSeatBookingRules) // PUT /api/v1/admin/seats/rules
+		adminSeats.POST("/bulk", controller.BulkUpdateSeats)        // POST /api/v1/admin/seats/bulk - Status or attributes of rows and seat ranges
+		adminSeats.PUT("/:id", controller.UpdateSeat)               // PUT /api/v1/admin/seats/:id
+		adminSeats.DELETE("/:id", controller.DeleteSeat)            // DELETE /api/v1/admin/seats/:id
+	}
+
+	// Per-event seat blocks for guests, press and equipment
+	adminSeatBlocks := rg.Group("/admin/events/:eventId/seat-blocks")
+	adminSeatBlocks.Use(middleware.JWTAuth(), middleware.RequirePermission(middleware.PermissionVenuesManage))
+	{
+		adminSeatBlocks.GET("", controller.ListSeatBlocks)             // GET /api/v1/admin/events/:eventId/seat-blocks?include_released=true
+		adminSeatBlocks.POST("", controller.BlockSeats)                // POST /api/v1/admin/events/:eventId/seat-blocks
+		adminSeatBlocks.POST("/release", controller.ReleaseSeatBlocks) // POST /api/v1/admin/events/:eventId/seat-blocks/release - Back on sale, waitlist notified
+	}
+
+	// GENERAL ADMISSION
+
+	adminTicketTypes := rg.Group("/admin/ticket-types")
+	adminTicketTypes.Use(middleware.JWTAuth(), middleware.RequirePermission(middleware.PermissionVenuesManage))
 	{
-		adminSeats.PUT("/:id", controller.UpdateSeat)    // PUT /api/v1/admin/seats/:id
-		adminSeats.DELETE("/:id", controller.DeleteSeat) // DELETE /api/v1/admin/seats/:id
+		adminTicketTypes.POST("", controller.CreateTicketType)       // POST /api/v1/admin/ticket-types
+		adminTicketTypes.PUT("/:id", controller.UpdateTicketType)    // PUT /api/v1/admin/ticket-types/:id
+		adminTicketTypes.DELETE("/:id", controller.DeleteTicketType) // DELETE /api/v1/admin/ticket-types/:id
+	}
+
+	ticketTypes := rg.Group("/ticket-types")
+	{
+		// Availability, with remaining counts
+		ticketTypes.GET("", controller.GetEventTicketTypes) // GET /api/v1/ticket-types?event_id=xxx
+		ticketTypes.GET("/:id", controller.GetTicketType)   // GET /api/v1/ticket-types/:id
+
+		// Quantity holds, released and extended through the seat hold endpoints
+		ticketTypes.POST("/:id/hold", middleware.JWTAuth(), middleware.RequireRoles("USER", "ADMIN"), controller.HoldTickets) // POST /api/v1/ticket-types/:id/hold
 	}
 
 	// SECTION-BASED OPERATIONS
@@ -39,7 +79,7 @@ func SetupSeatRoutes(rg *gin.RouterGroup, controller *Controller) {
 	{
 		// Seat retrieval
 		sections.GET("/:sectionId/seats", controller.GetSeatsBySectionID)                  // GET /api/v1/sections/:sectionId/seats
-		sections.GET("/:sectionId/seats/available", controller.GetAvailableSeatsInSection) // GET /api/v1/sections/:sectionId/seats/available?event_id=xxx
+		sections.GET("/:sectionId/seats/available", controller.GetAvailableSeatsInSection) // GET /api/v1/sections/:sectionId/seats/available?event_id=xxx&attributes=AISLE
 	}
 
 	// USER-SPECIFIC HOLDS
@@ -0,0 +1,299 @@
+package seats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"evently/internal/shared/utils/constants"
+
+	"github.com/google/uuid"
+)
+
+// Admins block seats for one event to keep them off sale for guests, press or
+// equipment. A block only applies to its event, other events at the venue can
+// still sell the seat. Releasing a block returns the seats to sale and offers
+// them to the event's waitlist.
+
+var (
+	ErrSeatBlockConflict = errors.New("seats cannot be blocked")
+	ErrSeatBlockNotFound = errors.New("no active seat blocks for these seats")
+	ErrSeatBlocked       = errors.New("seats are blocked for this event")
+)
+
+// FreedSeat is a blocked seat returned to sale
+type FreedSeat struct {
+	SeatID    *uuid.UUID
+	SectionID *uuid.UUID
+	Price     float64
+}
+
+// WaitlistNotifier offers seats returned to sale to the event's waitlist
+type WaitlistNotifier interface {
+	ProcessCancellation(ctx context.Context, eventID uuid.UUID, freedTickets int, freed []FreedSeat) error
+}
+
+// SetWaitlistService notifies the waitlist when blocks are released
+func (s *service) SetWaitlistService(waitlist WaitlistNotifier) {
+	s.waitlist = waitlist
+}
+
+// BlockSeats takes seats off sale for an event. Seats that are booked, held or
+// already blocked for the event can't be blocked.
+func (s *service) BlockSeats(ctx context.Context, eventID, adminID string, req BlockSeatsRequest) ([]SeatBlockResponse, error) {
+	eventUUID, err := uuid.Parse(eventID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid event ID: %w", err)
+	}
+	adminUUID, err := uuid.Parse(adminID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid admin ID: %w", err)
+	}
+	seatUUIDs, err := parseSeatIDs(req.SeatIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := s.getTicketingEvent(ctx, eventUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Seats must belong to the event's venue and be sold seat by seat
+	seats, err := s.repo.GetSeatsByIDs(ctx, seatUUIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get seat details: %w", err)
+	}
+	if len(seats) != len(seatUUIDs) {
+		return nil, fmt.Errorf("seat not found")
+	}
+	sectionIDs := make([]uuid.UUID, 0, len(seats))
+	for _, seat := range seats {
+		if seat.Section == nil || seat.Section.TemplateID != event.VenueTemplateID {
+			return nil, fmt.Errorf("invalid seat block: seat %s is not part of the event's venue", seat.ID)
+		}
+		sectionIDs = append(sectionIDs, seat.SectionID)
+	}
+	gaSections, err := s.repo.FindGeneralAdmissionSections(ctx, eventUUID, sectionIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check general admission sections: %w", err)
+	}
+	if len(gaSections) > 0 {
+		return nil, fmt.Errorf("invalid seat block: sections are general admission for this event: %v", gaSections)
+	}
+
+	bookedSeats, err := s.checkSeatsBookedForEvent(ctx, seatUUIDs, eventUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check event-specific bookings: %w", err)
+	}
+	if len(bookedSeats) > 0 {
+		return nil, fmt.Errorf("%w: already booked for this event: %v", ErrSeatBlockConflict, bookedSeats)
+	}
+
+	blocked, err := s.repo.GetBlockedSeats(ctx, eventUUID, seatUUIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(blocked) > 0 {
+		return nil, fmt.Errorf("%w: already blocked for this event: %v", ErrSeatBlockConflict, blockedSeatIDs(blocked))
+	}
+
+	// Holds can't be seen while Redis is down
+	if s.redisWatch.Degraded() {
+		return nil, ErrHoldsUnavailable
+	}
+
+	blocks := make([]SeatBlock, len(seatUUIDs))
+	blockIDs := make([]uuid.UUID, len(seatUUIDs))
+	for i, seatID := range seatUUIDs {
+		blockIDs[i] = uuid.New()
+		blocks[i] = SeatBlock{
+			ID:        blockIDs[i],
+			EventID:   eventUUID,
+			SeatID:    seatID,
+			Reason:    req.Reason,
+			Note:      strings.TrimSpace(req.Note),
+			BlockedBy: adminUUID,
+		}
+	}
+	if err := s.repo.CreateSeatBlocks(ctx, blocks); err != nil {
+		if strings.Contains(err.Error(), "duplicate key") {
+			return nil, fmt.Errorf("%w: already blocked for this event", ErrSeatBlockConflict)
+		}
+		return nil, fmt.Errorf("failed to block seats: %w", err)
+	}
+
+	// Holds are checked after the blocks are written, and HoldSeats checks
+	// blocks after taking its hold, so a racing hold and block can't both win
+	holds, approximate := s.checkSeatHolds(ctx, seatUUIDs)
+	var heldSeats []string
+	for seatID, holdValue := range holds {
+		if holdValue != "" {
+			heldSeats = append(heldSeats, seatID)
+		}
+	}
+	if approximate || len(heldSeats) > 0 {
+		if err := s.repo.DeleteSeatBlocks(ctx, blockIDs); err != nil {
+			log.ErrorContext(ctx, "Failed to undo seat blocks", "event_id", eventID, "error", err)
+		}
+		if approximate {
+			return nil, ErrHoldsUnavailable
+		}
+		return nil, fmt.Errorf("%w: currently held: %v", ErrSeatBlockConflict, heldSeats)
+	}
+
+	s.invalidateSeatInventory(ctx, eventID)
+	log.InfoContext(ctx, "Seats blocked", "event_id", eventID, "seats", len(blocks), "reason", req.Reason, "admin_id", adminID)
+
+	return s.seatBlockResponses(ctx, eventUUID, blockIDs)
+}
+
+func (s *service) ListSeatBlocks(ctx context.Context, eventID string, includeReleased bool) ([]SeatBlockResponse, error) {
+	eventUUID, err := uuid.Parse(eventID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid event ID: %w", err)
+	}
+	if _, err := s.getTicketingEvent(ctx, eventUUID); err != nil {
+		return nil, err
+	}
+
+	blocks, err := s.repo.ListSeatBlocks(ctx, eventUUID, includeReleased)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list seat blocks: %w", err)
+	}
+	return blocks, nil
+}
+
+// ReleaseSeatBlocks returns blocked seats to sale and offers them to the
+// event's waitlist. Seats that aren't blocked are ignored.
+func (s *service) ReleaseSeatBlocks(ctx context.Context, eventID, adminID string, req ReleaseSeatBlocksRequest) (*SeatBlockReleaseResponse, error) {
+	eventUUID, err := uuid.Parse(eventID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid event ID: %w", err)
+	}
+	adminUUID, err := uuid.Parse(adminID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid admin ID: %w", err)
+	}
+	seatUUIDs, err := parseSeatIDs(req.SeatIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	released, err := s.repo.ReleaseSeatBlocks(ctx, eventUUID, seatUUIDs, adminUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to release seat blocks: %w", err)
+	}
+	if len(released) == 0 {
+		return nil, ErrSeatBlockNotFound
+	}
+
+	s.invalidateSeatInventory(ctx, eventID)
+	log.InfoContext(ctx, "Seat blocks released", "event_id", eventID, "seats", len(released), "admin_id", adminID)
+
+	releasedIDs := make([]uuid.UUID, len(released))
+	response := &SeatBlockReleaseResponse{EventID: eventID, Released: len(released)}
+	for i, block := range released {
+		releasedIDs[i] = block.SeatID
+		response.SeatIDs = append(response.SeatIDs, block.SeatID.String())
+	}
+	s.notifyWaitlist(eventID, eventUUID, releasedIDs)
+
+	return response, nil
+}
+
+// notifyWaitlist offers released seats to the waitlist in the background, the
+// way a cancelled booking's seats are
+func (s *service) notifyWaitlist(eventID string, eventUUID uuid.UUID, seatIDs []uuid.UUID) {
+	if s.waitlist == nil {
+		return
+	}
+
+	go func() {
+		ctx := context.Background()
+		seats, err := s.repo.GetSeatsByIDs(ctx, seatIDs)
+		if err != nil {
+			log.Error("Failed to load released seats for waitlist", "event_id", eventID, "error", err)
+			return
+		}
+		prices, _, err := s.calculateSeatPrices(eventID, seats)
+		if err != nil {
+			log.Warn("Failed to price released seats for waitlist", "event_id", eventID, "error", err)
+		}
+
+		freed := make([]FreedSeat, len(seats))
+		for i := range seats {
+			freed[i] = FreedSeat{SeatID: &seats[i].ID, SectionID: &seats[i].SectionID, Price: prices[seats[i].ID.String()]}
+		}
+		if err := s.waitlist.ProcessCancellation(ctx, eventUUID, len(freed), freed); err != nil {
+			log.Error("Failed to notify waitlist of released seats", "event_id", eventID, "error", err)
+		}
+	}()
+}
+
+// checkSeatsBlockedForEvent returns the seats blocked for the event
+func (s *service) checkSeatsBlockedForEvent(ctx context.Context, seatIDs []uuid.UUID, eventID uuid.UUID) ([]string, error) {
+	blocked, err := s.repo.GetBlockedSeats(ctx, eventID, seatIDs)
+	if err != nil {
+		return nil, err
+	}
+	return blockedSeatIDs(blocked), nil
+}
+
+// invalidateSeatInventory drops the cached layout and seat availability of an
+// event whose blocks changed
+func (s *service) invalidateSeatInventory(ctx context.Context, eventID string) {
+	if s.cacheService == nil {
+		return
+	}
+	for _, pattern := range constants.BuildSeatInventoryCachePatterns(eventID) {
+		if err := s.cacheService.DeletePattern(ctx, pattern); err != nil {
+			log.WarnContext(ctx, "Failed to invalidate cache pattern", "pattern", pattern, "error", err)
+		}
+	}
+}
+
+func (s *service) seatBlockResponses(ctx context.Context, eventID uuid.UUID, blockIDs []uuid.UUID) ([]SeatBlockResponse, error) {
+	blocks, err := s.repo.ListSeatBlocks(ctx, eventID, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list seat blocks: %w", err)
+	}
+
+	wanted := make(map[uuid.UUID]bool, len(blockIDs))
+	for _, id := range blockIDs {
+		wanted[id] = true
+	}
+	created := make([]SeatBlockResponse, 0, len(blockIDs))
+	for _, block := range blocks {
+		if wanted[block.ID] {
+			created = append(created, block)
+		}
+	}
+	return created, nil
+}
+
+// parseSeatIDs parses seat IDs, dropping duplicates
+func parseSeatIDs(ids []string) ([]uuid.UUID, error) {
+	seen := make(map[uuid.UUID]bool, len(ids))
+	seatIDs := make([]uuid.UUID, 0, len(ids))
+	for _, idStr := range ids {
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid seat ID: %s", idStr)
+		}
+		if !seen[id] {
+			seen[id] = true
+			seatIDs = append(seatIDs, id)
+		}
+	}
+	return seatIDs, nil
+}
+
+func blockedSeatIDs(blocked map[uuid.UUID]string) []string {
+	ids := make([]string, 0, len(blocked))
+	for id := range blocked {
+		ids = append(ids, id.String())
+	}
+	return ids
+}
@@ -7,47 +7,89 @@ import (
 	"time"
 
 	"evently/internal/shared/config"
+	"evently/internal/shared/dbresolver"
 	"evently/internal/shared/utils/constants"
 	"evently/pkg/cache"
 	"evently/pkg/logger"
+	"evently/pkg/metrics"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
+// log is the logger of the seats module, see LOG_MODULE_LEVELS
+var log = logger.Module("seats")
+
 type Service interface {
 	// Seat Management
 	GetSeatsBySectionID(ctx context.Context, sectionID string) ([]Seat, error)
 	GetSeatByID(ctx context.Context, id string) (*Seat, error)
 	UpdateSeat(ctx context.Context, id string, req UpdateSeatRequest) (*Seat, error)
 	DeleteSeat(ctx context.Context, id string) error
+	BulkUpdateSeats(ctx context.Context, req BulkUpdateSeatsRequest) (*BulkUpdateSeatsResponse, error)
+
+	// Accessibility Rules
+	GetSeatBookingRules(ctx context.Context) (*SeatBookingRules, error)
+	UpdateSeatBookingRules(ctx context.Context, adminID string, req UpdateSeatBookingRulesRequest) (*SeatBookingRules, error)
 
 	// Seat Holding (Core Flow)
 	HoldSeats(ctx context.Context, req SeatHoldRequest) (*SeatHoldResponse, error)
 	ReleaseHold(ctx context.Context, holdID string) error
 	ValidateHold(ctx context.Context, holdID string, userID string) (*HoldValidationResult, error)
 	GetUserHolds(ctx context.Context, userID string) ([]SeatHoldDetails, error)
+	ExtendHold(ctx context.Context, holdID, userID string, req ExtendHoldRequest) (*HoldExtensionResponse, error)
 
 	// Availability Checks
 	CheckSeatAvailability(ctx context.Context, seatIDs []string) (*SeatAvailabilityResponse, error)
 	GetAvailableSeatsInSection(ctx context.Context, sectionID string) ([]SeatResponse, error)
-	GetAvailableSeatsInSectionForEvent(ctx context.Context, sectionID string, eventID string) ([]SeatResponse, error)
+	GetAvailableSeatsInSectionForEvent(ctx context.Context, sectionID string, eventID string, filter SeatAttributeFilter) ([]SeatResponse, error)
+	AvailabilityDegraded() bool
+
+	// General Admission
+	CreateTicketType(ctx context.Context, req CreateTicketTypeRequest) (*TicketTypeResponse, error)
+	UpdateTicketType(ctx context.Context, id string, req UpdateTicketTypeRequest) (*TicketTypeResponse, error)
+	DeleteTicketType(ctx context.Context, id string) error
+	GetTicketType(ctx context.Context, id string) (*TicketTypeResponse, error)
+	GetEventTicketTypes(ctx context.Context, eventID string) ([]TicketTypeResponse, error)
+	HoldTickets(ctx context.Context, ticketTypeID string, req TicketHoldRequest) (*TicketHoldResponse, error)
 
 	// Additional helper methods
 	GetSeatsByHoldID(ctx context.Context, holdID string) ([]SeatInfo, error)
+	GetTicketsByHoldID(ctx context.Context, holdID string) (*TicketHoldInfo, error)
 	GetHoldDetails(ctx context.Context, holdID string) (*SeatHoldDetails, error)
+	ConsumeHold(ctx context.Context, holdID string) error
+	ReturnTickets(ctx context.Context, ticketTypeID uuid.UUID, quantity int) error
+
+	// Event Seat Blocks
+	BlockSeats(ctx context.Context, eventID, adminID string, req BlockSeatsRequest) ([]SeatBlockResponse, error)
+	ListSeatBlocks(ctx context.Context, eventID string, includeReleased bool) ([]SeatBlockResponse, error)
+	ReleaseSeatBlocks(ctx context.Context, eventID, adminID string, req ReleaseSeatBlocksRequest) (*SeatBlockReleaseResponse, error)
 }
 
 type service struct {
 	repo         Repository
 	config       *config.Config
 	cacheService cache.Service
+	privacy      *HoldPrivacy
+	redisWatch   *RedisWatch
+	waitlist     WaitlistNotifier
 }
 
 func NewService(repo Repository, cfg *config.Config) Service {
+	privacy, err := NewHoldPrivacy(cfg.Privacy.HoldKey)
+	if err != nil {
+		log.Error("Hold privacy unavailable, seat holding disabled", "error", err)
+	}
+
+	// Hold scripts outlast a Sentinel or cluster failover
+	if retrier, ok := repo.(interface{ SetHoldRetry(int, time.Duration) }); ok {
+		retrier.SetHoldRetry(cfg.Redis.HoldRetries, cfg.Redis.HoldRetryBackoff)
+	}
+
 	return &service{
-		repo:   repo,
-		config: cfg,
+		repo:    repo,
+		config:  cfg,
+		privacy: privacy,
 	}
 }
 
@@ -55,6 +97,11 @@ func (s *service) SetCacheService(cacheService cache.Service) {
 	s.cacheService = cacheService
 }
 
+// SetRedisWatch lets availability fall back to Postgres and refuse new holds while Redis is down
+func (s *service) SetRedisWatch(redisWatch *RedisWatch) {
+	s.redisWatch = redisWatch
+}
+
 //  SEAT MANAGEMENT
 
 func (s *service) GetSeatsBySectionID(ctx context.Context, sectionID string) ([]Seat, error) {
@@ -106,6 +153,18 @@ func (s *service) UpdateSeat(ctx context.Context, id string, req UpdateSeatReque
 		}
 		updates["status"] = *req.Status
 	}
+	if req.WheelchairAccessible != nil {
+		updates["wheelchair_accessible"] = *req.WheelchairAccessible
+	}
+	if req.CompanionSeat != nil {
+		updates["companion_seat"] = *req.CompanionSeat
+	}
+	if req.RestrictedView != nil {
+		updates["restricted_view"] = *req.RestrictedView
+	}
+	if req.Aisle != nil {
+		updates["aisle"] = *req.Aisle
+	}
 
 	if len(updates) > 0 {
 		if err := s.repo.UpdateSeat(ctx, seatID, updates); err != nil {
@@ -134,6 +193,41 @@ func (s *service) DeleteSeat(ctx context.Context, id string) error {
 	return s.repo.DeleteSeat(ctx, seatID)
 }
 
+//  ACCESSIBILITY RULES
+
+func (s *service) GetSeatBookingRules(ctx context.Context) (*SeatBookingRules, error) {
+	rules, err := s.repo.GetSeatBookingRules(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get seat booking rules: %w", err)
+	}
+	return rules, nil
+}
+
+func (s *service) UpdateSeatBookingRules(ctx context.Context, adminID string, req UpdateSeatBookingRulesRequest) (*SeatBookingRules, error) {
+	adminUUID, err := uuid.Parse(adminID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid admin ID: %w", err)
+	}
+
+	rules, err := s.GetSeatBookingRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.CompanionRequiresAccessible != nil {
+		rules.CompanionRequiresAccessible = *req.CompanionRequiresAccessible
+	}
+	if req.MaxCompanionsPerAccessible != nil {
+		rules.MaxCompanionsPerAccessible = *req.MaxCompanionsPerAccessible
+	}
+	rules.UpdatedBy = &adminUUID
+
+	if err := s.repo.SaveSeatBookingRules(ctx, rules); err != nil {
+		return nil, fmt.Errorf("failed to save seat booking rules: %w", err)
+	}
+	return rules, nil
+}
+
 //  SEAT HOLDING (CORE FLOW)
 
 func (s *service) HoldSeats(ctx context.Context, req SeatHoldRequest) (*SeatHoldResponse, error) {
@@ -141,6 +235,9 @@ func (s *service) HoldSeats(ctx context.Context, req SeatHoldRequest) (*SeatHold
 	if len(req.SeatIDs) == 0 {
 		return nil, fmt.Errorf("no seats specified")
 	}
+	if s.redisWatch.Degraded() {
+		return nil, ErrHoldsUnavailable
+	}
 	// Parse seat IDs
 	var seatUUIDs []uuid.UUID
 	for _, idStr := range req.SeatIDs {
@@ -154,6 +251,7 @@ func (s *service) HoldSeats(ctx context.Context, req SeatHoldRequest) (*SeatHold
 	// Check if seats exist and are available in Postgres (base availability) - checkmate
 	availability, err := s.repo.CheckSeatsAvailability(ctx, seatUUIDs)
 	if err != nil {
+		metrics.RecordSeatHold(req.EventID, metrics.ResultError)
 		return nil, fmt.Errorf("failed to check seat availability: %w", err)
 	}
 
@@ -165,7 +263,8 @@ func (s *service) HoldSeats(ctx context.Context, req SeatHoldRequest) (*SeatHold
 	}
 
 	if len(unavailableSeats) > 0 {
-		return nil, fmt.Errorf("seats not available: %v", unavailableSeats)
+		metrics.RecordSeatHold(req.EventID, metrics.ResultContention)
+		return nil, fmt.Errorf("%w: %v", ErrSeatsUnavailable, unavailableSeats)
 	}
 
 	// Parse event ID for booking checks
@@ -177,16 +276,19 @@ func (s *service) HoldSeats(ctx context.Context, req SeatHoldRequest) (*SeatHold
 	// Check if any of the seats are already booked for this specific event
 	bookedSeats, err := s.checkSeatsBookedForEvent(ctx, seatUUIDs, eventUUID)
 	if err != nil {
+		metrics.RecordSeatHold(req.EventID, metrics.ResultError)
 		return nil, fmt.Errorf("failed to check event-specific bookings: %w", err)
 	}
 
 	if len(bookedSeats) > 0 {
-		return nil, fmt.Errorf("seats already booked for this event: %v", bookedSeats)
+		metrics.RecordSeatHold(req.EventID, metrics.ResultContention)
+		return nil, fmt.Errorf("%w: %v", ErrSeatsBooked, bookedSeats)
 	}
 
 	// Check if seats are already held in Redis
 	holds, err := s.repo.CheckSeatHolds(ctx, seatUUIDs)
 	if err != nil {
+		metrics.RecordSeatHold(req.EventID, metrics.ResultError)
 		return nil, fmt.Errorf("failed to check seat holds: %w", err)
 	}
 
@@ -198,7 +300,8 @@ func (s *service) HoldSeats(ctx context.Context, req SeatHoldRequest) (*SeatHold
 	}
 
 	if len(heldSeats) > 0 {
-		return nil, fmt.Errorf("seats already held: %v", heldSeats)
+		metrics.RecordSeatHold(req.EventID, metrics.ResultContention)
+		return nil, fmt.Errorf("%w: %v", ErrSeatHeld, heldSeats)
 	}
 
 	// Get seat details for response
@@ -207,20 +310,98 @@ func (s *service) HoldSeats(ctx context.Context, req SeatHoldRequest) (*SeatHold
 		return nil, fmt.Errorf("failed to get seat details: %w", err)
 	}
 
+	// Sections sold as general admission for this event are held by quantity
+	sectionIDs := make([]uuid.UUID, 0, len(seats))
+	for _, seat := range seats {
+		sectionIDs = append(sectionIDs, seat.SectionID)
+	}
+	gaSections, err := s.repo.FindGeneralAdmissionSections(ctx, eventUUID, sectionIDs)
+	if err != nil {
+		metrics.RecordSeatHold(req.EventID, metrics.ResultError)
+		return nil, fmt.Errorf("failed to check general admission sections: %w", err)
+	}
+	if len(gaSections) > 0 {
+		return nil, fmt.Errorf("sections are general admission for this event, hold tickets instead: %v", gaSections)
+	}
+
+	// Prices are quoted in the event's currency
+	event, err := s.getTicketingEvent(ctx, eventUUID)
+	if err != nil {
+		metrics.RecordSeatHold(req.EventID, metrics.ResultError)
+		return nil, err
+	}
+
+	// Enforce accessibility rules before anything is reserved
+	rules, err := s.repo.GetSeatBookingRules(ctx)
+	if err != nil {
+		metrics.RecordSeatHold(req.EventID, metrics.ResultError)
+		return nil, fmt.Errorf("failed to get seat booking rules: %w", err)
+	}
+	if err := rules.Validate(seats); err != nil {
+		return nil, err
+	}
+
+	// Redis only sees an opaque token and the encrypted user ID
+	if s.privacy == nil {
+		metrics.RecordSeatHold(req.EventID, metrics.ResultError)
+		return nil, fmt.Errorf("seat holding disabled - hold privacy key not configured")
+	}
+	owner, err := s.privacy.Owner(req.UserID)
+	if err != nil {
+		metrics.RecordSeatHold(req.EventID, metrics.ResultError)
+		return nil, fmt.Errorf("failed to seal hold owner: %w", err)
+	}
+
 	// Generate hold ID and hold seats in Redis atomically
 	holdID := uuid.New().String()
 	ttl := s.config.Redis.SeatHoldTTL // Use configurable TTL
-	logger.GetDefault().Info("Holding seats with hold ID:", holdID, "for user:", req.UserID, "with TTL:", ttl)
-	if err := s.repo.AtomicHoldSeats(ctx, seatUUIDs, req.UserID, holdID, req.EventID, ttl); err != nil {
+	log.InfoContext(ctx, "Holding seats", "hold_id", holdID, "owner", owner.Token, "ttl", ttl)
+	if err := s.repo.AtomicHoldSeats(ctx, seatUUIDs, owner, holdID, req.EventID, ttl); err != nil {
+		// Losing the race to a concurrent hold is contention, anything else is an infrastructure error
+		if errors.Is(err, ErrSeatHeld) {
+			metrics.RecordSeatHold(req.EventID, metrics.ResultContention)
+		} else {
+			metrics.RecordSeatHold(req.EventID, metrics.ResultError)
+		}
 		return nil, fmt.Errorf("failed to hold seats atomically: %w", err)
 	}
 
+	// Blocks are checked once the hold is taken, and BlockSeats checks holds
+	// once its blocks are written, so a racing hold and block can't both win
+	blockedSeats, err := s.checkSeatsBlockedForEvent(ctx, seatUUIDs, eventUUID)
+	if err != nil || len(blockedSeats) > 0 {
+		if releaseErr := s.repo.ReleaseHold(ctx, holdID); releaseErr != nil {
+			log.WarnContext(ctx, "Failed to release hold on blocked seats", "hold_id", holdID, "error", releaseErr)
+		}
+		if err != nil {
+			metrics.RecordSeatHold(req.EventID, metrics.ResultError)
+			return nil, fmt.Errorf("failed to check seat blocks: %w", err)
+		}
+		metrics.RecordSeatHold(req.EventID, metrics.ResultContention)
+		return nil, fmt.Errorf("%w: %v", ErrSeatBlocked, blockedSeats)
+	}
+	metrics.RecordSeatHold(req.EventID, metrics.ResultSuccess)
+
+	s.trackHoldExpiry(ctx, TrackedHold{
+		HoldID:      holdID,
+		EventID:     req.EventID,
+		SealedOwner: owner.Sealed,
+		SeatIDs:     req.SeatIDs,
+		Quantity:    len(seatUUIDs),
+		ExpiresAt:   time.Now().Add(ttl),
+	})
+
+	// Feed the hold monitor's conversion tracking
+	if err := s.repo.RecordHoldCreated(ctx); err != nil {
+		log.WarnContext(ctx, "Failed to record hold metric", "hold_id", holdID, "error", err)
+	}
+
 	// Build response
 	var heldSeatInfo []HeldSeatInfo
 	var totalPrice float64
 
 	// Calculate actual seat prices based on event and section
-	seatPrices, err := s.calculateSeatPrices(req.EventID, seats)
+	seatPrices, _, err := s.calculateSeatPrices(req.EventID, seats)
 	if err != nil {
 		return nil, fmt.Errorf("failed to calculate seat prices: %w", err)
 	}
@@ -245,6 +426,7 @@ func (s *service) HoldSeats(ctx context.Context, req SeatHoldRequest) (*SeatHold
 		UserID:     req.UserID,
 		Seats:      heldSeatInfo,
 		TotalPrice: totalPrice,
+		Currency:   event.Currency,
 		ExpiresAt:  time.Now().Add(ttl),
 		TTL:        int(ttl.Seconds()),
 	}, nil
@@ -260,11 +442,15 @@ func (s *service) ReleaseHold(ctx context.Context, holdID string) error {
 		return fmt.Errorf("hold not found or expired")
 	}
 
-	return s.repo.ReleaseHold(ctx, holdID)
+	if err := s.repo.ReleaseHold(ctx, holdID); err != nil {
+		return err
+	}
+	s.untrackHoldExpiry(ctx, holdID)
+	return nil
 }
 
 func (s *service) ValidateHold(ctx context.Context, holdID string, userID string) (*HoldValidationResult, error) {
-	details, err := s.repo.GetHoldDetails(ctx, holdID)
+	details, err := s.GetHoldDetails(ctx, holdID)
 	if err != nil {
 		return &HoldValidationResult{
 			Valid:  false,
@@ -293,15 +479,67 @@ func (s *service) ValidateHold(ctx context.Context, holdID string, userID string
 	}, nil
 }
 
+func (s *service) ExtendHold(ctx context.Context, holdID, userID string, req ExtendHoldRequest) (*HoldExtensionResponse, error) {
+	extension := s.config.Redis.SeatHoldExtension
+	if req.ExtendBySeconds > 0 {
+		requested := time.Duration(req.ExtendBySeconds) * time.Second
+		if requested < extension {
+			extension = requested
+		}
+	}
+
+	details, err := s.repo.GetHoldDetails(ctx, holdID)
+	if err != nil {
+		return nil, fmt.Errorf("hold not found or expired")
+	}
+	if s.privacy == nil {
+		return nil, fmt.Errorf("seat holding disabled - hold privacy key not configured")
+	}
+	ownerToken := s.privacy.OwnerToken(userID)
+
+	maxTotal := s.config.Redis.SeatHoldMaxTTL
+	newTTL, err := s.repo.AtomicExtendHold(ctx, holdID, ownerToken, extension, maxTotal)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(newTTL)
+	log.InfoContext(ctx, "Extended seat hold", "hold_id", holdID, "owner", ownerToken, "ttl", newTTL)
+
+	// Notify connected checkout clients; the extension itself already succeeded
+	event := &HoldEvent{
+		Type:       "HOLD_EXTENDED",
+		HoldID:     holdID,
+		OwnerToken: ownerToken,
+		EventID:    details.EventID,
+		ExpiresAt:  expiresAt,
+		TTL:        int(newTTL.Seconds()),
+	}
+	if err := s.repo.PublishHoldEvent(ctx, event); err != nil {
+		log.WarnContext(ctx, "Failed to publish hold event", "hold_id", holdID, "error", err)
+	}
+
+	return &HoldExtensionResponse{
+		HoldID:     holdID,
+		ExpiresAt:  expiresAt,
+		TTL:        int(newTTL.Seconds()),
+		MaxHoldTTL: int(maxTotal.Seconds()),
+	}, nil
+}
+
 func (s *service) GetUserHolds(ctx context.Context, userID string) ([]SeatHoldDetails, error) {
-	holdIDs, err := s.repo.GetUserHolds(ctx, userID)
+	if s.privacy == nil {
+		return []SeatHoldDetails{}, nil
+	}
+
+	holdIDs, err := s.repo.GetUserHolds(ctx, s.privacy.OwnerToken(userID))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user holds: %w", err)
 	}
 
 	var holdDetails []SeatHoldDetails
 	for _, holdID := range holdIDs {
-		details, err := s.repo.GetHoldDetails(ctx, holdID)
+		details, err := s.GetHoldDetails(ctx, holdID)
 		if err != nil {
 			continue // skip invalid holds
 		}
@@ -329,11 +567,8 @@ func (s *service) CheckSeatAvailability(ctx context.Context, seatIDs []string) (
 		return nil, fmt.Errorf("failed to check postgres availability: %w", err)
 	}
 
-	// Check Redis holds also
-	redisHolds, err := s.repo.CheckSeatHolds(ctx, seatUUIDs)
-	if err != nil {
-		return nil, fmt.Errorf("failed to check redis holds: %w", err)
-	}
+	// Check Redis holds also. Without Redis, Postgres availability is returned as approximate.
+	redisHolds, approximate := s.checkSeatHolds(ctx, seatUUIDs)
 
 	var availability []SeatAvailabilityInfo
 	for _, id := range seatIDs {
@@ -356,7 +591,8 @@ func (s *service) CheckSeatAvailability(ctx context.Context, seatIDs []string) (
 	}
 
 	return &SeatAvailabilityResponse{
-		Seats: availability,
+		Seats:       availability,
+		Approximate: approximate,
 	}, nil
 }
 
@@ -364,26 +600,28 @@ func (s *service) GetAvailableSeatsInSection(ctx context.Context, sectionID stri
 	return nil, fmt.Errorf("GetAvailableSeatsInSection is deprecated - use GetAvailableSeatsInSectionForEvent instead")
 }
 
-func (s *service) GetAvailableSeatsInSectionForEvent(ctx context.Context, sectionID string, eventID string) ([]SeatResponse, error) {
-	logger.GetDefault().Info("Fetching available seats for section:", sectionID, "and event:", eventID)
+func (s *service) GetAvailableSeatsInSectionForEvent(ctx context.Context, sectionID string, eventID string, filter SeatAttributeFilter) ([]SeatResponse, error) {
+	log.InfoContext(ctx, "Fetching available seats", "section_id", sectionID, "event_id", eventID)
 	sectionUUID, err := uuid.Parse(sectionID)
 	if err != nil {
 		return nil, fmt.Errorf("invalid section ID: %w", err)
 	}
-	logger.GetDefault().Debug("getting available seats for section:", sectionID, "and event:", eventID)
+	log.DebugContext(ctx, "Getting available seats", "section_id", sectionID, "event_id", eventID)
 	eventUUID, err := uuid.Parse(eventID)
 	if err != nil {
 		return nil, fmt.Errorf("invalid event ID: %w", err)
 	}
 
+	// The cache lives in Redis too, so it is skipped while Redis is down
+	degraded := s.redisWatch.Degraded()
 	cacheKey := constants.BuildSeatAvailabilityKey(sectionID, eventID)
-	if s.cacheService != nil {
+	if s.cacheService != nil && !degraded {
 		var cachedSeats []SeatResponse
 		if err := s.cacheService.Get(ctx, cacheKey, &cachedSeats); err == nil {
-			logger.GetDefault().Debug("cache hit for seat availability:", cacheKey)
-			return cachedSeats, nil
+			log.DebugContext(ctx, "Cache hit for seat availability", "key", cacheKey)
+			return filter.Apply(cachedSeats), nil
 		} else {
-			logger.GetDefault().Debug("cache miss for seat availability:", cacheKey)
+			log.DebugContext(ctx, "Cache miss for seat availability", "key", cacheKey)
 		}
 	}
 
@@ -394,7 +632,7 @@ func (s *service) GetAvailableSeatsInSectionForEvent(ctx context.Context, sectio
 	}
 
 	// Get seat bookings for this event
-	seatBookings, err := s.getSeatBookingsForEvent(ctx, eventUUID, sectionUUID)
+	seatBookings, err := s.getSeatBookingsForEvent(ctx, eventUUID, sectionUUID, dbresolver.Read)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get seat bookings: %w", err)
 	}
@@ -405,13 +643,19 @@ func (s *service) GetAvailableSeatsInSectionForEvent(ctx context.Context, sectio
 		seatUUIDs = append(seatUUIDs, seat.ID)
 	}
 
-	holds, err := s.repo.CheckSeatHolds(ctx, seatUUIDs)
+	holds, approximate := s.checkSeatHolds(ctx, seatUUIDs)
+
+	// Seats blocked for this event are off sale
+	blocked, err := s.repo.GetBlockedSeats(ctx, eventUUID, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to check holds: %w", err)
+		return nil, err
 	}
 
 	var response []SeatResponse
 	for _, seat := range seats {
+		if _, ok := blocked[seat.ID]; ok {
+			continue
+		}
 		isHeld := holds[seat.ID.String()] != ""
 
 		// Use the new event-specific status logic
@@ -420,37 +664,60 @@ func (s *service) GetAvailableSeatsInSectionForEvent(ctx context.Context, sectio
 		// Only include seats that are effectively available
 		if effectiveStatus == "AVAILABLE" {
 			response = append(response, SeatResponse{
-				ID:         seat.ID.String(),
-				SeatNumber: seat.SeatNumber,
-				Row:        seat.Row,
-				Position:   seat.Position,
-				Status:     effectiveStatus,
-				Price:      0, // Will be calculated with section multiplier
-				IsHeld:     isHeld,
+				ID:          seat.ID.String(),
+				SeatNumber:  seat.SeatNumber,
+				Row:         seat.Row,
+				Position:    seat.Position,
+				Status:      effectiveStatus,
+				Price:       0, // Will be calculated with section multiplier
+				IsHeld:      isHeld,
+				HoldUnknown: approximate,
+				Attributes:  seat.Attributes(),
 			})
 		}
 	}
 
-	// Cache the result
-	if s.cacheService != nil {
+	// Cache the result, unless holds could not be seen
+	if s.cacheService != nil && !approximate {
 		if err := s.cacheService.Set(ctx, cacheKey, response, constants.TTL_SEATS_AVAILABLE); err != nil {
-			logger.GetDefault().Debug("Warning: failed to cache seat availability:", err)
-		} else {
-			logger.GetDefault().Debug("Cached seat availability:", cacheKey)
+			log.DebugContext(ctx, "Failed to cache seat availability", "error", err)
 		}
 	}
 
-	return response, nil
+	// The cached list is unfiltered so every attribute combination shares one entry
+	return filter.Apply(response), nil
+}
+
+// AvailabilityDegraded reports whether availability is currently served without Redis holds
+func (s *service) AvailabilityDegraded() bool {
+	return s.redisWatch.Degraded()
 }
 
-// calculates the actual price for each seat based on event pricing
-func (s *service) calculateSeatPrices(eventID string, seats []Seat) (map[string]float64, error) {
+// checkSeatHolds returns the holds on the given seats. When Redis is down, or
+// the read fails, no holds are returned and approximate is true.
+func (s *service) checkSeatHolds(ctx context.Context, seatIDs []uuid.UUID) (holds map[string]string, approximate bool) {
+	if s.redisWatch.Degraded() {
+		return map[string]string{}, true
+	}
+
+	holds, err := s.repo.CheckSeatHolds(ctx, seatIDs)
+	if err != nil {
+		log.WarnContext(ctx, "Failed to check seat holds, serving approximate availability", "error", err)
+		s.redisWatch.ReportFailure(err)
+		return map[string]string{}, true
+	}
+	return holds, false
+}
+
+// calculates the actual price for each seat based on event pricing, along with
+// the event base price the section multipliers apply to
+func (s *service) calculateSeatPrices(eventID string, seats []Seat) (map[string]float64, float64, error) {
 	prices := make(map[string]float64)
 
 	// Parse event ID
 	eventUUID, err := uuid.Parse(eventID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid event ID: %w", err)
+		return nil, 0, fmt.Errorf("invalid event ID: %w", err)
 	}
 
 	// Get event details to get base price
@@ -462,7 +729,7 @@ func (s *service) calculateSeatPrices(eventID string, seats []Seat) (map[string]
 	// Get base price from events table
 	if err := s.repo.(*repository).db.Table("events").
 		Select("base_price").
-		Where("id = ?", eventUUID).
+		Where("id = ? AND deleted_at IS NULL", eventUUID).
 		First(&event).Error; err != nil {
 		event.BasePrice = 50.0 // fallback
 	}
@@ -492,7 +759,7 @@ func (s *service) calculateSeatPrices(eventID string, seats []Seat) (map[string]
 		for _, seat := range seats {
 			prices[seat.ID.String()] = event.BasePrice
 		}
-		return prices, nil
+		return prices, event.BasePrice, nil
 	}
 
 	// Create a map of section ID to price multiplier
@@ -512,7 +779,7 @@ func (s *service) calculateSeatPrices(eventID string, seats []Seat) (map[string]
 		prices[seat.ID.String()] = finalPrice
 	}
 
-	return prices, nil
+	return prices, event.BasePrice, nil
 }
 
 // retrieves seats associated with a hold ID
@@ -551,7 +818,7 @@ func (s *service) GetSeatsByHoldID(ctx context.Context, holdID string) ([]SeatIn
 	}
 
 	// Calculate actual seat prices using the existing calculateSeatPrices method
-	seatPrices, err := s.calculateSeatPrices(holdData.EventID, seats)
+	seatPrices, basePrice, err := s.calculateSeatPrices(holdData.EventID, seats)
 	if err != nil {
 		return nil, fmt.Errorf("failed to calculate seat prices: %w", err)
 	}
@@ -579,6 +846,7 @@ func (s *service) GetSeatsByHoldID(ctx context.Context, holdID string) ([]SeatIn
 				SeatNumber:  seat.SeatNumber,
 				Row:         seat.Row,
 				Price:       seatPrice, // Now using calculated price based on event and section
+				BasePrice:   basePrice,
 				SectionName: section.Name,
 			}
 			seatInfos = append(seatInfos, seatInfo)
@@ -588,8 +856,25 @@ func (s *service) GetSeatsByHoldID(ctx context.Context, holdID string) ([]SeatIn
 	return seatInfos, nil
 }
 
+// GetHoldDetails returns a hold with its owner's user ID decrypted
 func (s *service) GetHoldDetails(ctx context.Context, holdID string) (*SeatHoldDetails, error) {
-	return s.repo.GetHoldDetails(ctx, holdID)
+	details, err := s.repo.GetHoldDetails(ctx, holdID)
+	if err != nil {
+		return nil, err
+	}
+
+	if details.SealedOwner != "" {
+		if s.privacy == nil {
+			return nil, fmt.Errorf("hold privacy key not configured")
+		}
+		userID, err := s.privacy.OpenOwner(details.SealedOwner)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve hold owner: %w", err)
+		}
+		details.UserID = userID
+	}
+
+	return details, nil
 }
 
 func (s *service) checkSeatsBookedForEvent(ctx context.Context, seatIDs []uuid.UUID, eventID uuid.UUID) ([]string, error) {
@@ -608,11 +893,14 @@ func (s *service) checkSeatsBookedForEvent(ctx context.Context, seatIDs []uuid.U
 	return bookedSeatIDs, nil
 }
 
-func (s *service) getSeatBookingsForEvent(ctx context.Context, eventID uuid.UUID, sectionID uuid.UUID) ([]SeatBooking, error) {
+// getSeatBookingsForEvent returns the section's booked seats. Availability
+// listings pass dbresolver.Read, checks that guard a write read the primary.
+func (s *service) getSeatBookingsForEvent(ctx context.Context, eventID uuid.UUID, sectionID uuid.UUID, scopes ...func(*gorm.DB) *gorm.DB) ([]SeatBooking, error) {
 	var seatBookings []SeatBooking
 
 	// Query seat_bookings table for this event and section
 	if err := s.repo.(*repository).db.WithContext(ctx).
+		Scopes(scopes...).
 		Table("seat_bookings sb").
 		Joins("JOIN bookings b ON b.id = sb.booking_id").
 		Where("b.event_id = ? AND sb.section_id = ? AND b.status != 'CANCELLED'", eventID, sectionID).
@@ -0,0 +1,326 @@
+package series
+
+import (
+	"net/http"
+	"strings"
+
+	"evently/internal/shared/utils/response"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type Controller struct {
+	service Service
+}
+
+func NewController(service Service) *Controller {
+	return &Controller{service: service}
+}
+
+// CreateSeries godoc
+//
+// @Summary      Create a recurring series
+// @Description  Turns an upcoming event into the first occurrence of a series and clones it for every date the recurrence rule produces. Clones share the source event's section pricing, tags and cancellation policy.
+// @Description
+// @Description  Requires the `events:write` permission.
+// @Tags         Admin Series
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        request body CreateSeriesRequest true "Request body"
+// @Success      201 {object} response.StandardApiResponse{data=SeriesResponse} "Series created successfully"
+// @Failure      400 {object} response.StandardApiResponse "Invalid recurrence rule, or the source event is past or cancelled"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Source event not found"
+// @Failure      409 {object} response.StandardApiResponse "Source event already belongs to a series"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/series [post]
+func (ctrl *Controller) CreateSeries(c *gin.Context) {
+	adminID, ok := ctrl.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req CreateSeriesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid request body", nil, err.Error())
+		return
+	}
+
+	series, err := ctrl.service.CreateSeries(c.Request.Context(), adminID, req)
+	if err != nil {
+		statusCode := http.StatusBadRequest
+		switch {
+		case err.Error() == "event not found":
+			statusCode = http.StatusNotFound
+		case err.Error() == "event already belongs to a series":
+			statusCode = http.StatusConflict
+		case strings.HasPrefix(err.Error(), "failed to"):
+			statusCode = http.StatusInternalServerError
+		}
+		response.RespondJSON(c, "error", statusCode, err.Error(), nil, nil)
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusCreated, "Series created successfully", series, nil)
+}
+
+// ListSeries godoc
+//
+// @Summary      List series
+// @Description  Requires the `events:write` permission.
+// @Tags         Admin Series
+// @Produce      json
+// @Security     Bearer
+// @Param        page query integer false "Page" default(1) minimum(1)
+// @Param        limit query integer false "Limit" default(20) minimum(1) maximum(100)
+// @Success      200 {object} response.StandardApiResponse{data=PaginatedSeries} "Series retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/series [get]
+func (ctrl *Controller) ListSeries(c *gin.Context) {
+	var query SeriesListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid query parameters", nil, err.Error())
+		return
+	}
+
+	series, err := ctrl.service.ListSeries(c.Request.Context(), query)
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusInternalServerError, "Failed to retrieve series", nil, err.Error())
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Series retrieved successfully", series, nil)
+}
+
+// GetSeries godoc
+//
+// @Summary      Get a series with its occurrences
+// @Description  Requires the `events:write` permission.
+// @Tags         Admin Series
+// @Produce      json
+// @Security     Bearer
+// @Param        seriesId path string true "Series ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=SeriesResponse} "Series retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Series not found"
+// @Router       /api/v1/admin/series/{seriesId} [get]
+func (ctrl *Controller) GetSeries(c *gin.Context) {
+	seriesID, ok := ctrl.seriesID(c)
+	if !ok {
+		return
+	}
+
+	series, err := ctrl.service.GetSeries(c.Request.Context(), seriesID)
+	if err != nil {
+		response.RespondJSON(c, "error", notFoundStatus(err), err.Error(), nil, nil)
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Series retrieved successfully", series, nil)
+}
+
+// UpdateSeries godoc
+//
+// @Summary      Edit the whole series
+// @Description  Applies the changes to every upcoming, non-cancelled occurrence. Occurrences edited on their own are skipped unless include_detached is set.
+// @Description
+// @Description  Requires the `events:write` permission.
+// @Tags         Admin Series
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        seriesId path string true "Series ID" Format(uuid)
+// @Param        request body UpdateSeriesRequest true "Request body"
+// @Success      200 {object} response.StandardApiResponse{data=SeriesUpdateResult} "Series updated successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Series not found"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/series/{seriesId} [put]
+func (ctrl *Controller) UpdateSeries(c *gin.Context) {
+	seriesID, ok := ctrl.seriesID(c)
+	if !ok {
+		return
+	}
+
+	adminID, ok := ctrl.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateSeriesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid request body", nil, err.Error())
+		return
+	}
+
+	result, err := ctrl.service.UpdateSeries(c.Request.Context(), seriesID, adminID, req)
+	if err != nil {
+		response.RespondJSON(c, "error", notFoundStatus(err), err.Error(), nil, nil)
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Series updated successfully", result, nil)
+}
+
+// CancelSeries godoc
+//
+// @Summary      Cancel all upcoming occurrences
+// @Description  Requires the `events:write` permission.
+// @Tags         Admin Series
+// @Produce      json
+// @Security     Bearer
+// @Param        seriesId path string true "Series ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=SeriesUpdateResult} "Series cancelled successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Series not found"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/series/{seriesId}/cancel [post]
+func (ctrl *Controller) CancelSeries(c *gin.Context) {
+	seriesID, ok := ctrl.seriesID(c)
+	if !ok {
+		return
+	}
+
+	adminID, ok := ctrl.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	result, err := ctrl.service.CancelSeries(c.Request.Context(), seriesID, adminID)
+	if err != nil {
+		response.RespondJSON(c, "error", notFoundStatus(err), err.Error(), nil, nil)
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Series cancelled successfully", result, nil)
+}
+
+// UpdateOccurrence godoc
+//
+// @Summary      Edit a single occurrence
+// @Description  Updates one occurrence and detaches it so later series-wide edits leave it alone
+// @Description
+// @Description  Requires the `events:write` permission.
+// @Tags         Admin Series
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        seriesId path string true "Series ID" Format(uuid)
+// @Param        eventId path string true "Event ID" Format(uuid)
+// @Param        request body UpdateOccurrenceRequest true "Request body"
+// @Success      200 {object} response.StandardApiResponse{data=Occurrence} "Occurrence updated successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Series not found or event is not one of its occurrences"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/series/{seriesId}/occurrences/{eventId} [put]
+func (ctrl *Controller) UpdateOccurrence(c *gin.Context) {
+	seriesID, ok := ctrl.seriesID(c)
+	if !ok {
+		return
+	}
+
+	eventID, err := uuid.Parse(c.Param("eventId"))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid event ID", nil, err.Error())
+		return
+	}
+
+	adminID, ok := ctrl.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateOccurrenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid request body", nil, err.Error())
+		return
+	}
+
+	occurrence, err := ctrl.service.UpdateOccurrence(c.Request.Context(), seriesID, eventID, adminID, req)
+	if err != nil {
+		statusCode := http.StatusBadRequest
+		if strings.HasSuffix(err.Error(), "not found") || err.Error() == "occurrence not found in series" {
+			statusCode = http.StatusNotFound
+		}
+		response.RespondJSON(c, "error", statusCode, err.Error(), nil, nil)
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Occurrence updated successfully", occurrence, nil)
+}
+
+// GetSeriesAnalytics godoc
+//
+// @Summary      Series analytics
+// @Description  Booking, revenue and utilization totals across all occurrences, with a per-occurrence breakdown
+// @Description
+// @Description  Requires the `events:write` permission.
+// @Tags         Admin Series
+// @Produce      json
+// @Security     Bearer
+// @Param        seriesId path string true "Series ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=SeriesAnalytics} "Series analytics retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Series not found"
+// @Router       /api/v1/admin/series/{seriesId}/analytics [get]
+func (ctrl *Controller) GetSeriesAnalytics(c *gin.Context) {
+	seriesID, ok := ctrl.seriesID(c)
+	if !ok {
+		return
+	}
+
+	analytics, err := ctrl.service.GetSeriesAnalytics(c.Request.Context(), seriesID)
+	if err != nil {
+		response.RespondJSON(c, "error", notFoundStatus(err), err.Error(), nil, nil)
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Series analytics retrieved successfully", analytics, nil)
+}
+
+func (ctrl *Controller) seriesID(c *gin.Context) (uuid.UUID, bool) {
+	seriesID, err := uuid.Parse(c.Param("seriesId"))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid series ID", nil, err.Error())
+		return uuid.Nil, false
+	}
+	return seriesID, true
+}
+
+func (ctrl *Controller) currentUserID(c *gin.Context) (uuid.UUID, bool) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.RespondJSON(c, "error", http.StatusUnauthorized, "Admin not authenticated", nil, nil)
+		return uuid.Nil, false
+	}
+
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusInternalServerError, "Invalid admin ID format", nil, nil)
+		return uuid.Nil, false
+	}
+	return userUUID, true
+}
+
+func notFoundStatus(err error) int {
+	if err.Error() == "series not found" {
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,36 @@
+package series
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Recurrence frequencies
+const (
+	FrequencyWeekly  = "WEEKLY"
+	FrequencyMonthly = "MONTHLY"
+	FrequencyCustom  = "CUSTOM"
+)
+
+// MaxOccurrences caps how many events a single series can materialize
+const MaxOccurrences = 104
+
+// EventSeries groups events materialized from one recurrence rule. The source
+// event is the first occurrence; the rest are clones sharing its pricing, tags
+// and cancellation policy.
+type EventSeries struct {
+	ID            uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	Name          string     `gorm:"not null;size:255" json:"name"`
+	SourceEventID uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex" json:"source_event_id"`
+	Frequency     string     `gorm:"type:varchar(20);not null;check:frequency IN ('WEEKLY', 'MONTHLY', 'CUSTOM')" json:"frequency"`
+	Interval      int        `gorm:"not null;default:1" json:"interval"`
+	Until         *time.Time `json:"until,omitempty"`
+	CreatedBy     uuid.UUID  `gorm:"type:uuid;not null" json:"created_by"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+func (EventSeries) TableName() string {
+	return "event_series"
+}
@@ -0,0 +1,87 @@
+package series
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// OccurrenceDates expands the rule into the dates of every occurrence after the
+// source event starting at start. Times of day follow the source event.
+func (r RecurrenceRule) OccurrenceDates(start time.Time) ([]time.Time, error) {
+	interval := r.Interval
+	if interval <= 0 {
+		interval = 1
+	}
+
+	switch r.Frequency {
+	case FrequencyCustom:
+		return r.customDates(start)
+	case FrequencyWeekly, FrequencyMonthly:
+		if r.Count == 0 && r.Until == nil {
+			return nil, errors.New("recurrence needs either a count or an until date")
+		}
+		if r.Until != nil && !r.Until.After(start) {
+			return nil, errors.New("recurrence until date must be after the source event")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported recurrence frequency: %s", r.Frequency)
+	}
+
+	limit := MaxOccurrences - 1
+	if r.Count > 0 && r.Count-1 < limit {
+		limit = r.Count - 1
+	}
+
+	var dates []time.Time
+	for step := 1; len(dates) < limit; step++ {
+		var next time.Time
+		if r.Frequency == FrequencyWeekly {
+			next = start.AddDate(0, 0, 7*interval*step)
+		} else {
+			next = time.Date(start.Year(), start.Month()+time.Month(interval*step), start.Day(),
+				start.Hour(), start.Minute(), start.Second(), start.Nanosecond(), start.Location())
+			// Months without the source day (e.g. the 31st) are skipped rather than rolled over
+			if next.Day() != start.Day() {
+				if step > MaxOccurrences*12 {
+					break
+				}
+				continue
+			}
+		}
+
+		if r.Until != nil && next.After(*r.Until) {
+			break
+		}
+		dates = append(dates, next)
+	}
+
+	if len(dates) == 0 {
+		return nil, errors.New("recurrence produces no occurrences after the source event")
+	}
+	return dates, nil
+}
+
+func (r RecurrenceRule) customDates(start time.Time) ([]time.Time, error) {
+	if len(r.Dates) == 0 {
+		return nil, errors.New("custom recurrence requires at least one date")
+	}
+	if len(r.Dates) > MaxOccurrences-1 {
+		return nil, fmt.Errorf("a series can have at most %d occurrences", MaxOccurrences)
+	}
+
+	dates := make([]time.Time, len(r.Dates))
+	copy(dates, r.Dates)
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+
+	for i, date := range dates {
+		if !date.After(start) {
+			return nil, fmt.Errorf("occurrence date %s must be after the source event", date.Format(time.RFC3339))
+		}
+		if i > 0 && date.Equal(dates[i-1]) {
+			return nil, fmt.Errorf("occurrence date %s is listed twice", date.Format(time.RFC3339))
+		}
+	}
+	return dates, nil
+}
@@ -0,0 +1,234 @@
+package series
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	Create(ctx context.Context, series *EventSeries) error
+	GetByID(ctx context.Context, id uuid.UUID) (*EventSeries, error)
+	List(ctx context.Context, limit, offset int) ([]EventSeries, int64, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// Occurrences
+	GetSourceEvent(ctx context.Context, eventID uuid.UUID) (*SourceEvent, error)
+	AttachOccurrences(ctx context.Context, seriesID uuid.UUID, eventIDs []uuid.UUID) error
+	GetOccurrences(ctx context.Context, seriesID uuid.UUID) ([]OccurrenceRow, error)
+	CountOccurrences(ctx context.Context, seriesIDs []uuid.UUID) (map[uuid.UUID]int, error)
+	MarkDetached(ctx context.Context, seriesID, eventID uuid.UUID) error
+
+	// Analytics
+	GetOccurrencePerformance(ctx context.Context, seriesID uuid.UUID) ([]PerformanceRow, error)
+}
+
+// SourceEvent is the slice of event data needed to start a series
+type SourceEvent struct {
+	ID       uuid.UUID
+	DateTime time.Time
+	Status   string
+	SeriesID *uuid.UUID
+}
+
+// OccurrenceRow is an event that belongs to a series
+type OccurrenceRow struct {
+	ID             uuid.UUID
+	Name           string
+	DateTime       time.Time
+	BasePrice      float64
+	Status         string
+	SeriesDetached bool
+}
+
+func (o *OccurrenceRow) ToOccurrence() Occurrence {
+	return Occurrence{
+		EventID:   o.ID.String(),
+		Name:      o.Name,
+		DateTime:  o.DateTime,
+		BasePrice: o.BasePrice,
+		Status:    o.Status,
+		Detached:  o.SeriesDetached,
+	}
+}
+
+// PerformanceRow holds booking totals for one occurrence
+type PerformanceRow struct {
+	EventID           uuid.UUID
+	DateTime          time.Time
+	Status            string
+	TotalCapacity     int
+	TicketsSold       int
+	Bookings          int
+	CancelledBookings int
+	Revenue           float64
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+//  SERIES
+
+func (r *repository) Create(ctx context.Context, series *EventSeries) error {
+	return r.db.WithContext(ctx).Create(series).Error
+}
+
+func (r *repository) GetByID(ctx context.Context, id uuid.UUID) (*EventSeries, error) {
+	var series EventSeries
+	if err := r.db.WithContext(ctx).First(&series, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &series, nil
+}
+
+func (r *repository) List(ctx context.Context, limit, offset int) ([]EventSeries, int64, error) {
+	var total int64
+	if err := r.db.WithContext(ctx).Model(&EventSeries{}).Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count series: %w", err)
+	}
+
+	var series []EventSeries
+	err := r.db.WithContext(ctx).
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&series).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list series: %w", err)
+	}
+
+	return series, total, nil
+}
+
+// Delete removes the series and releases its occurrences back to standalone events
+func (r *repository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Table("events").
+			Where("series_id = ?", id).
+			Updates(map[string]interface{}{"series_id": nil, "series_detached": false}).Error
+		if err != nil {
+			return fmt.Errorf("failed to release occurrences: %w", err)
+		}
+
+		return tx.Delete(&EventSeries{}, "id = ?", id).Error
+	})
+}
+
+//  OCCURRENCES
+
+func (r *repository) GetSourceEvent(ctx context.Context, eventID uuid.UUID) (*SourceEvent, error) {
+	var event SourceEvent
+	err := r.db.WithContext(ctx).
+		Table("events").
+		Select("id, date_time, status, series_id").
+		Where("id = ? AND deleted_at IS NULL", eventID).
+		Take(&event).Error
+	if err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+func (r *repository) AttachOccurrences(ctx context.Context, seriesID uuid.UUID, eventIDs []uuid.UUID) error {
+	return r.db.WithContext(ctx).
+		Table("events").
+		Where("id IN ?", eventIDs).
+		Updates(map[string]interface{}{"series_id": seriesID, "series_detached": false}).Error
+}
+
+func (r *repository) GetOccurrences(ctx context.Context, seriesID uuid.UUID) ([]OccurrenceRow, error) {
+	var occurrences []OccurrenceRow
+	err := r.db.WithContext(ctx).
+		Table("events").
+		Select("id, name, date_time, base_price, status, series_detached").
+		Where("series_id = ? AND deleted_at IS NULL", seriesID).
+		Order("date_time ASC").
+		Scan(&occurrences).Error
+	return occurrences, err
+}
+
+func (r *repository) CountOccurrences(ctx context.Context, seriesIDs []uuid.UUID) (map[uuid.UUID]int, error) {
+	counts := make(map[uuid.UUID]int, len(seriesIDs))
+	if len(seriesIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		SeriesID uuid.UUID
+		Count    int
+	}
+	err := r.db.WithContext(ctx).
+		Table("events").
+		Select("series_id, COUNT(*) AS count").
+		Where("series_id IN ? AND deleted_at IS NULL", seriesIDs).
+		Group("series_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		counts[row.SeriesID] = row.Count
+	}
+	return counts, nil
+}
+
+func (r *repository) MarkDetached(ctx context.Context, seriesID, eventID uuid.UUID) error {
+	return r.db.WithContext(ctx).
+		Table("events").
+		Where("id = ? AND series_id = ?", eventID, seriesID).
+		Update("series_detached", true).Error
+}
+
+//  ANALYTICS
+
+func (r *repository) GetOccurrencePerformance(ctx context.Context, seriesID uuid.UUID) ([]PerformanceRow, error) {
+	var rows []PerformanceRow
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT
+			e.id AS event_id,
+			e.date_time,
+			e.status,
+			COALESCE(cap.total_capacity, 0) AS total_capacity,
+			COALESCE(sold.tickets_sold, 0) AS tickets_sold,
+			COALESCE(sold.revenue, 0) AS revenue,
+			COALESCE(bk.bookings, 0) AS bookings,
+			COALESCE(bk.cancelled_bookings, 0) AS cancelled_bookings
+		FROM events e
+		LEFT JOIN (
+			SELECT template_id, SUM(total_seats) AS total_capacity
+			FROM venue_sections
+			GROUP BY template_id
+		) cap ON cap.template_id = e.venue_template_id
+		LEFT JOIN (
+			SELECT sb.event_id, COUNT(*) AS tickets_sold, SUM(sb.seat_price) AS revenue
+			FROM seat_bookings sb
+			JOIN bookings b ON b.id = sb.booking_id AND b.status = 'CONFIRMED'
+			WHERE sb.event_id IN (SELECT id FROM events WHERE series_id = ?)
+			GROUP BY sb.event_id
+		) sold ON sold.event_id = e.id
+		LEFT JOIN (
+			SELECT
+				event_id,
+				COUNT(*) FILTER (WHERE status = 'CONFIRMED') AS bookings,
+				COUNT(*) FILTER (WHERE status = 'CANCELLED') AS cancelled_bookings
+			FROM bookings
+			WHERE event_id IN (SELECT id FROM events WHERE series_id = ?)
+			GROUP BY event_id
+		) bk ON bk.event_id = e.id
+		WHERE e.series_id = ? AND e.deleted_at IS NULL
+		ORDER BY e.date_time ASC
+	`, seriesID, seriesID, seriesID).Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get occurrence performance: %w", err)
+	}
+	return rows, nil
+}
@@ -0,0 +1,50 @@
+package series
+
+import "time"
+
+// RecurrenceRule describes when occurrences after the source event take place.
+// WEEKLY and MONTHLY repeat every Interval weeks/months until Count occurrences
+// (including the source event) or the Until date is reached; CUSTOM uses Dates.
+type RecurrenceRule struct {
+	Frequency string      `json:"frequency" binding:"required,oneof=WEEKLY MONTHLY CUSTOM"`
+	Interval  int         `json:"interval" binding:"omitempty,min=1,max=12"`
+	Count     int         `json:"count" binding:"omitempty,min=2,max=104"`
+	Until     *time.Time  `json:"until"`
+	Dates     []time.Time `json:"dates" binding:"omitempty,max=103"`
+}
+
+type CreateSeriesRequest struct {
+	Name          string         `json:"name" binding:"required,min=3,max=255"`
+	SourceEventID string         `json:"source_event_id" binding:"required,uuid"`
+	Recurrence    RecurrenceRule `json:"recurrence" binding:"required"`
+}
+
+// UpdateSeriesRequest changes every upcoming occurrence. Occurrences edited on
+// their own are skipped unless IncludeDetached is set.
+type UpdateSeriesRequest struct {
+	Name            *string  `json:"name" binding:"omitempty,min=3,max=255"`
+	Description     *string  `json:"description" binding:"omitempty,max=2000"`
+	Venue           *string  `json:"venue" binding:"omitempty,min=3,max=255"`
+	BasePrice       *float64 `json:"base_price" binding:"omitempty,min=0"`
+	ImageURL        *string  `json:"image_url" binding:"omitempty,url"`
+	Tags            []string `json:"tags"`
+	IncludeDetached bool     `json:"include_detached"`
+}
+
+// UpdateOccurrenceRequest changes a single occurrence and detaches it from
+// series-wide edits
+type UpdateOccurrenceRequest struct {
+	Name        *string    `json:"name" binding:"omitempty,min=3,max=255"`
+	Description *string    `json:"description" binding:"omitempty,max=2000"`
+	Venue       *string    `json:"venue" binding:"omitempty,min=3,max=255"`
+	DateTime    *time.Time `json:"date_time"`
+	BasePrice   *float64   `json:"base_price" binding:"omitempty,min=0"`
+	Status      *string    `json:"status" binding:"omitempty,oneof=published cancelled"`
+	ImageURL    *string    `json:"image_url" binding:"omitempty,url"`
+	Tags        []string   `json:"tags"`
+}
+
+type SeriesListQuery struct {
+	Page  int `form:"page,default=1" binding:"min=1"`
+	Limit int `form:"limit,default=20" binding:"min=1,max=100"`
+}
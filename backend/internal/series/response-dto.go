@@ -0,0 +1,69 @@
+package series
+
+import (
+	"time"
+
+	"evently/internal/shared/utils/response"
+)
+
+type Occurrence struct {
+	EventID   string    `json:"event_id"`
+	Name      string    `json:"name"`
+	DateTime  time.Time `json:"date_time"`
+	BasePrice float64   `json:"base_price"`
+	Status    string    `json:"status"`
+	Detached  bool      `json:"detached"` // Edited on its own, skipped by series-wide edits
+}
+
+type SeriesResponse struct {
+	EventSeries
+	OccurrenceCount int          `json:"occurrence_count"`
+	Occurrences     []Occurrence `json:"occurrences,omitempty"`
+}
+
+type PaginatedSeries struct {
+	Series     []SeriesResponse `json:"series"`
+	TotalCount int64            `json:"total_count"`
+	Page       int              `json:"page"`
+	Limit      int              `json:"limit"`
+}
+
+func (p PaginatedSeries) PaginationMeta() response.Pagination {
+	return response.NewPagination(p.Page, p.Limit, p.TotalCount)
+}
+
+// SeriesUpdateResult reports which occurrences a series-wide edit touched
+type SeriesUpdateResult struct {
+	SeriesID string            `json:"series_id"`
+	Updated  []string          `json:"updated"`
+	Skipped  []string          `json:"skipped"`          // Detached, past or cancelled occurrences
+	Failed   map[string]string `json:"failed,omitempty"` // Event ID -> error
+}
+
+type OccurrencePerformance struct {
+	EventID             string    `json:"event_id"`
+	DateTime            time.Time `json:"date_time"`
+	Status              string    `json:"status"`
+	TotalCapacity       int       `json:"total_capacity"`
+	TicketsSold         int       `json:"tickets_sold"`
+	Bookings            int       `json:"bookings"`
+	CancelledBookings   int       `json:"cancelled_bookings"`
+	Revenue             float64   `json:"revenue"`
+	CapacityUtilization float64   `json:"capacity_utilization"`
+}
+
+// SeriesAnalytics aggregates booking performance across all occurrences
+type SeriesAnalytics struct {
+	SeriesID              string                  `json:"series_id"`
+	Name                  string                  `json:"name"`
+	TotalOccurrences      int                     `json:"total_occurrences"`
+	UpcomingOccurrences   int                     `json:"upcoming_occurrences"`
+	CancelledOccurrences  int                     `json:"cancelled_occurrences"`
+	TotalBookings         int                     `json:"total_bookings"`
+	TotalTicketsSold      int                     `json:"total_tickets_sold"`
+	TotalRevenue          float64                 `json:"total_revenue"`
+	AverageUtilization    float64                 `json:"average_utilization"`
+	CancellationRate      float64                 `json:"cancellation_rate"`
+	BestOccurrenceEventID string                  `json:"best_occurrence_event_id,omitempty"`
+	Occurrences           []OccurrencePerformance `json:"occurrences"`
+}
@@ -0,0 +1,24 @@
+package series
+
+import (
+	"evently/internal/shared/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupSeriesRoutes(rg *gin.RouterGroup, controller *Controller) {
+	// Recurring event series - events:write
+	admin := rg.Group("/admin/series")
+	admin.Use(middleware.JWTAuth(), middleware.RequirePermission(middleware.PermissionEventsWrite))
+	{
+		admin.POST("", controller.CreateSeries)                          // POST /api/v1/admin/series
+		admin.GET("", controller.ListSeries)                             // GET /api/v1/admin/series
+		admin.GET("/:seriesId", controller.GetSeries)                    // GET /api/v1/admin/series/:seriesId
+		admin.PUT("/:seriesId", controller.UpdateSeries)                 // PUT /api/v1/admin/series/:seriesId - Edit all upcoming occurrences
+		admin.POST("/:seriesId/cancel", controller.CancelSeries)         // POST /api/v1/admin/series/:seriesId/cancel
+		admin.GET("/:seriesId/analytics", controller.GetSeriesAnalytics) // GET /api/v1/admin/series/:seriesId/analytics
+
+		// Single occurrence edits detach the event from later series-wide edits
+		admin.PUT("/:seriesId/occurrences/:eventId", controller.UpdateOccurrence) // PUT /api/v1/admin/series/:seriesId/occurrences/:eventId
+	}
+}
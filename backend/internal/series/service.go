@@ -0,0 +1,408 @@
+package series
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"evently/internal/shared/utils/constants"
+	"evently/pkg/cache"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Service interface {
+	SetEventService(eventService EventService)
+	SetCacheService(cacheService cache.Service)
+
+	CreateSeries(ctx context.Context, adminID uuid.UUID, req CreateSeriesRequest) (*SeriesResponse, error)
+	GetSeries(ctx context.Context, seriesID uuid.UUID) (*SeriesResponse, error)
+	ListSeries(ctx context.Context, query SeriesListQuery) (*PaginatedSeries, error)
+
+	// Editing
+	UpdateSeries(ctx context.Context, seriesID, adminID uuid.UUID, req UpdateSeriesRequest) (*SeriesUpdateResult, error)
+	UpdateOccurrence(ctx context.Context, seriesID, eventID, adminID uuid.UUID, req UpdateOccurrenceRequest) (*Occurrence, error)
+	CancelSeries(ctx context.Context, seriesID, adminID uuid.UUID) (*SeriesUpdateResult, error)
+
+	// Analytics
+	GetSeriesAnalytics(ctx context.Context, seriesID uuid.UUID) (*SeriesAnalytics, error)
+}
+
+// EventService interface to materialize and edit occurrences without importing the events package
+type EventService interface {
+	CloneOccurrence(ctx context.Context, sourceID, adminID uuid.UUID, dateTime time.Time) (uuid.UUID, error)
+	UpdateOccurrence(ctx context.Context, eventID, adminID uuid.UUID, req UpdateOccurrenceRequest) error
+	DeleteOccurrence(ctx context.Context, eventID, adminID uuid.UUID) error
+}
+
+type service struct {
+	repo         Repository
+	eventService EventService
+	cacheService cache.Service
+}
+
+func NewService(repo Repository) Service {
+	return &service{repo: repo}
+}
+
+func (s *service) SetEventService(eventService EventService) {
+	s.eventService = eventService
+}
+
+// SetCacheService injects the cache service dependency
+func (s *service) SetCacheService(cacheService cache.Service) {
+	s.cacheService = cacheService
+}
+
+// invalidateEventCache drops cached event responses, which carry the series ID
+func (s *service) invalidateEventCache(ctx context.Context) {
+	if s.cacheService == nil {
+		return
+	}
+	if err := s.cacheService.DeletePattern(ctx, constants.PATTERN_INVALIDATE_EVENT_ALL); err != nil {
+		log.Printf("Warning: failed to invalidate event cache after series change: %v", err)
+	}
+}
+
+//  SERIES
+
+// CreateSeries turns the source event into the first occurrence of a series and
+// clones it for every date the recurrence rule produces. Clones share the
+// source's section pricing, tags and cancellation policy.
+func (s *service) CreateSeries(ctx context.Context, adminID uuid.UUID, req CreateSeriesRequest) (*SeriesResponse, error) {
+	if s.eventService == nil {
+		return nil, errors.New("event service not configured")
+	}
+
+	sourceID, err := uuid.Parse(req.SourceEventID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid source event ID: %w", err)
+	}
+
+	source, err := s.repo.GetSourceEvent(ctx, sourceID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("event not found")
+		}
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	if source.SeriesID != nil {
+		return nil, errors.New("event already belongs to a series")
+	}
+	if source.Status == "cancelled" {
+		return nil, errors.New("cancelled events cannot start a series")
+	}
+	if source.DateTime.Before(time.Now()) {
+		return nil, errors.New("source event must be in the future")
+	}
+
+	dates, err := req.Recurrence.OccurrenceDates(source.DateTime)
+	if err != nil {
+		return nil, err
+	}
+
+	interval := req.Recurrence.Interval
+	if interval <= 0 {
+		interval = 1
+	}
+
+	series := &EventSeries{
+		Name:          req.Name,
+		SourceEventID: sourceID,
+		Frequency:     req.Recurrence.Frequency,
+		Interval:      interval,
+		Until:         req.Recurrence.Until,
+		CreatedBy:     adminID,
+	}
+	if err := s.repo.Create(ctx, series); err != nil {
+		return nil, fmt.Errorf("failed to create series: %w", err)
+	}
+
+	eventIDs := []uuid.UUID{sourceID}
+	for _, date := range dates {
+		eventID, err := s.eventService.CloneOccurrence(ctx, sourceID, adminID, date)
+		if err != nil {
+			s.rollbackSeries(ctx, series.ID, adminID, eventIDs[1:])
+			return nil, fmt.Errorf("failed to create occurrence on %s: %w", date.Format(time.RFC3339), err)
+		}
+		eventIDs = append(eventIDs, eventID)
+	}
+
+	if err := s.repo.AttachOccurrences(ctx, series.ID, eventIDs); err != nil {
+		s.rollbackSeries(ctx, series.ID, adminID, eventIDs[1:])
+		return nil, fmt.Errorf("failed to attach occurrences: %w", err)
+	}
+	s.invalidateEventCache(ctx)
+
+	log.Printf("🔁 Created series %s with %d occurrences", series.ID, len(eventIDs))
+
+	return s.GetSeries(ctx, series.ID)
+}
+
+// rollbackSeries removes the clones and series row after a failed materialization (best effort)
+func (s *service) rollbackSeries(ctx context.Context, seriesID, adminID uuid.UUID, cloneIDs []uuid.UUID) {
+	for _, eventID := range cloneIDs {
+		if err := s.eventService.DeleteOccurrence(ctx, eventID, adminID); err != nil {
+			log.Printf("Warning: failed to remove occurrence %s of failed series %s: %v", eventID, seriesID, err)
+		}
+	}
+	if err := s.repo.Delete(ctx, seriesID); err != nil {
+		log.Printf("Warning: failed to remove failed series %s: %v", seriesID, err)
+	}
+}
+
+func (s *service) GetSeries(ctx context.Context, seriesID uuid.UUID) (*SeriesResponse, error) {
+	series, err := s.getSeries(ctx, seriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.repo.GetOccurrences(ctx, seriesID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get occurrences: %w", err)
+	}
+
+	occurrences := make([]Occurrence, len(rows))
+	for i := range rows {
+		occurrences[i] = rows[i].ToOccurrence()
+	}
+
+	return &SeriesResponse{
+		EventSeries:     *series,
+		OccurrenceCount: len(occurrences),
+		Occurrences:     occurrences,
+	}, nil
+}
+
+func (s *service) ListSeries(ctx context.Context, query SeriesListQuery) (*PaginatedSeries, error) {
+	offset := (query.Page - 1) * query.Limit
+
+	list, total, err := s.repo.List(ctx, query.Limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	seriesIDs := make([]uuid.UUID, len(list))
+	for i, series := range list {
+		seriesIDs[i] = series.ID
+	}
+
+	counts, err := s.repo.CountOccurrences(ctx, seriesIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count occurrences: %w", err)
+	}
+
+	responses := make([]SeriesResponse, len(list))
+	for i, series := range list {
+		responses[i] = SeriesResponse{
+			EventSeries:     series,
+			OccurrenceCount: counts[series.ID],
+		}
+	}
+
+	return &PaginatedSeries{
+		Series:     responses,
+		TotalCount: total,
+		Page:       query.Page,
+		Limit:      query.Limit,
+	}, nil
+}
+
+//  EDITING
+
+// UpdateSeries applies the changes to every upcoming, non-cancelled occurrence.
+// Detached occurrences keep their own edits unless IncludeDetached is set.
+func (s *service) UpdateSeries(ctx context.Context, seriesID, adminID uuid.UUID, req UpdateSeriesRequest) (*SeriesUpdateResult, error) {
+	if s.eventService == nil {
+		return nil, errors.New("event service not configured")
+	}
+
+	change := UpdateOccurrenceRequest{
+		Name:        req.Name,
+		Description: req.Description,
+		Venue:       req.Venue,
+		BasePrice:   req.BasePrice,
+		ImageURL:    req.ImageURL,
+		Tags:        req.Tags,
+	}
+
+	return s.applyToUpcoming(ctx, seriesID, adminID, change, req.IncludeDetached)
+}
+
+// CancelSeries cancels every upcoming occurrence, including detached ones
+func (s *service) CancelSeries(ctx context.Context, seriesID, adminID uuid.UUID) (*SeriesUpdateResult, error) {
+	if s.eventService == nil {
+		return nil, errors.New("event service not configured")
+	}
+
+	cancelled := "cancelled"
+	return s.applyToUpcoming(ctx, seriesID, adminID, UpdateOccurrenceRequest{Status: &cancelled}, true)
+}
+
+func (s *service) applyToUpcoming(ctx context.Context, seriesID, adminID uuid.UUID, change UpdateOccurrenceRequest, includeDetached bool) (*SeriesUpdateResult, error) {
+	if _, err := s.getSeries(ctx, seriesID); err != nil {
+		return nil, err
+	}
+
+	occurrences, err := s.repo.GetOccurrences(ctx, seriesID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get occurrences: %w", err)
+	}
+
+	result := &SeriesUpdateResult{
+		SeriesID: seriesID.String(),
+		Updated:  []string{},
+		Skipped:  []string{},
+	}
+
+	now := time.Now()
+	for _, occurrence := range occurrences {
+		eventID := occurrence.ID.String()
+		if occurrence.Status == "cancelled" || occurrence.DateTime.Before(now) || (occurrence.SeriesDetached && !includeDetached) {
+			result.Skipped = append(result.Skipped, eventID)
+			continue
+		}
+
+		if err := s.eventService.UpdateOccurrence(ctx, occurrence.ID, adminID, change); err != nil {
+			if result.Failed == nil {
+				result.Failed = make(map[string]string)
+			}
+			result.Failed[eventID] = err.Error()
+			continue
+		}
+		result.Updated = append(result.Updated, eventID)
+	}
+
+	return result, nil
+}
+
+// UpdateOccurrence edits one occurrence and detaches it so later series-wide
+// edits leave it alone
+func (s *service) UpdateOccurrence(ctx context.Context, seriesID, eventID, adminID uuid.UUID, req UpdateOccurrenceRequest) (*Occurrence, error) {
+	if s.eventService == nil {
+		return nil, errors.New("event service not configured")
+	}
+
+	if _, err := s.getSeries(ctx, seriesID); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.findOccurrence(ctx, seriesID, eventID); err != nil {
+		return nil, err
+	}
+
+	if err := s.eventService.UpdateOccurrence(ctx, eventID, adminID, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.MarkDetached(ctx, seriesID, eventID); err != nil {
+		return nil, fmt.Errorf("failed to detach occurrence: %w", err)
+	}
+
+	updated, err := s.findOccurrence(ctx, seriesID, eventID)
+	if err != nil {
+		return nil, err
+	}
+	result := updated.ToOccurrence()
+	return &result, nil
+}
+
+//  ANALYTICS
+
+func (s *service) GetSeriesAnalytics(ctx context.Context, seriesID uuid.UUID) (*SeriesAnalytics, error) {
+	series, err := s.getSeries(ctx, seriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.repo.GetOccurrencePerformance(ctx, seriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	analytics := &SeriesAnalytics{
+		SeriesID:         series.ID.String(),
+		Name:             series.Name,
+		TotalOccurrences: len(rows),
+		Occurrences:      make([]OccurrencePerformance, 0, len(rows)),
+	}
+
+	now := time.Now()
+	var utilizationSum float64
+	var utilizationCount, cancelledBookings int
+	var bestRevenue float64
+	for _, row := range rows {
+		performance := OccurrencePerformance{
+			EventID:           row.EventID.String(),
+			DateTime:          row.DateTime,
+			Status:            row.Status,
+			TotalCapacity:     row.TotalCapacity,
+			TicketsSold:       row.TicketsSold,
+			Bookings:          row.Bookings,
+			CancelledBookings: row.CancelledBookings,
+			Revenue:           row.Revenue,
+		}
+		if row.TotalCapacity > 0 {
+			performance.CapacityUtilization = float64(row.TicketsSold) / float64(row.TotalCapacity) * 100
+		}
+
+		if row.Status == "cancelled" {
+			analytics.CancelledOccurrences++
+		} else {
+			// Cancelled occurrences would drag the average down without saying anything about demand
+			utilizationSum += performance.CapacityUtilization
+			utilizationCount++
+			if row.DateTime.After(now) {
+				analytics.UpcomingOccurrences++
+			}
+		}
+
+		if row.Revenue > bestRevenue {
+			bestRevenue = row.Revenue
+			analytics.BestOccurrenceEventID = performance.EventID
+		}
+
+		analytics.TotalBookings += row.Bookings
+		analytics.TotalTicketsSold += row.TicketsSold
+		analytics.TotalRevenue += row.Revenue
+		cancelledBookings += row.CancelledBookings
+		analytics.Occurrences = append(analytics.Occurrences, performance)
+	}
+
+	if utilizationCount > 0 {
+		analytics.AverageUtilization = utilizationSum / float64(utilizationCount)
+	}
+	if total := analytics.TotalBookings + cancelledBookings; total > 0 {
+		analytics.CancellationRate = float64(cancelledBookings) / float64(total) * 100
+	}
+
+	return analytics, nil
+}
+
+func (s *service) getSeries(ctx context.Context, seriesID uuid.UUID) (*EventSeries, error) {
+	series, err := s.repo.GetByID(ctx, seriesID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("series not found")
+		}
+		return nil, fmt.Errorf("failed to get series: %w", err)
+	}
+	return series, nil
+}
+
+func (s *service) findOccurrence(ctx context.Context, seriesID, eventID uuid.UUID) (*OccurrenceRow, error) {
+	occurrences, err := s.repo.GetOccurrences(ctx, seriesID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get occurrences: %w", err)
+	}
+	for i := range occurrences {
+		if occurrences[i].ID == eventID {
+			return &occurrences[i], nil
+		}
+	}
+	return nil, errors.New("occurrence not found in series")
+}
@@ -0,0 +1,77 @@
+package config
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const cacheTTLEnvPrefix = "CACHE_TTL_"
+
+// loadCacheTTLConfig reads cache TTL overrides from the YAML file, if any, then
+// applies CACHE_TTL_<NAME> environment variables on top. Invalid entries are
+// logged and skipped so a typo never stops the server from starting.
+func loadCacheTTLConfig(file string) CacheTTLConfig {
+	cfg := CacheTTLConfig{
+		File:      file,
+		Overrides: map[string]time.Duration{},
+	}
+
+	if file != "" {
+		fileOverrides, err := readCacheTTLFile(file)
+		if err != nil {
+			log.Printf("⚠️ Ignoring cache TTL file %s: %v", file, err)
+		}
+		for name, ttl := range fileOverrides {
+			cfg.Overrides[name] = ttl
+		}
+	}
+
+	for _, entry := range os.Environ() {
+		key, value, _ := strings.Cut(entry, "=")
+		if !strings.HasPrefix(key, cacheTTLEnvPrefix) || key == "CACHE_TTL_FILE" {
+			continue
+		}
+
+		ttl, err := time.ParseDuration(value)
+		if err != nil {
+			log.Printf("⚠️ Ignoring %s: %v", key, err)
+			continue
+		}
+		cfg.Overrides[strings.TrimPrefix(key, cacheTTLEnvPrefix)] = ttl
+	}
+
+	return cfg
+}
+
+// readCacheTTLFile parses a flat YAML map of cache name to duration:
+//
+//	event_detail: 30m
+//	venue_layout: 2h
+func readCacheTTLFile(file string) (map[string]time.Duration, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw map[string]string
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("invalid YAML: %w", err)
+	}
+
+	overrides := make(map[string]time.Duration, len(raw))
+	for name, value := range raw {
+		ttl, err := time.ParseDuration(value)
+		if err != nil {
+			log.Printf("⚠️ Ignoring cache TTL %q in %s: %v", name, file, err)
+			continue
+		}
+		overrides[strings.ToUpper(name)] = ttl
+	}
+
+	return overrides, nil
+}
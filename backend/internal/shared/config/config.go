@@ -18,6 +18,7 @@ type Config struct {
 	WriteTimeout   time.Duration
 	IdleTimeout    time.Duration
 	MaxHeaderBytes int
+	PublicURL      string // Externally reachable base URL, used for links embedded in emails
 
 	// Database configuration
 	Database DatabaseConfig
@@ -25,17 +26,138 @@ type Config struct {
 	// Redis configuration
 	Redis RedisConfig
 
+	// Per-cache TTL overrides
+	CacheTTLs CacheTTLConfig
+
+	// In-process cache in front of Redis
+	LocalCache LocalCacheConfig
+
 	// JWT configuration
 	JWT JWTConfig
 
+	// TOTP two-factor authentication
+	TwoFactor TwoFactorConfig
+
 	// Rate limiting
 	RateLimit RateLimitConfig
 
 	// File upload
 	Upload UploadConfig
 
+	// Platform branding used when an organizer has not set their own
+	Branding BrandingConfig
+
 	// Logging
-	LogLevel string
+	LogLevel         string
+	LogModuleLevels  map[string]string // Level overrides by module, e.g. seats:debug,waitlist:warn
+	CacheRequestLogs bool              // Per-request cache hit/miss log lines; metrics are recorded regardless
+
+	// Notification outbox relay
+	Outbox OutboxConfig
+
+	// Domain events published to Kafka for downstream consumers
+	DomainEvents DomainEventsConfig
+
+	// Failed payment retries
+	Dunning DunningConfig
+
+	// Booking confirmation saga recovery
+	Saga SagaConfig
+
+	// Overlapping booking detection
+	BookingConflict BookingConflictConfig
+
+	// Booking transfers between users
+	Transfer TransferConfig
+
+	// User profiles and email changes
+	Profile ProfileConfig
+
+	// Support admins acting as users
+	Impersonation ImpersonationConfig
+
+	// Resale marketplace
+	Resale ResaleConfig
+
+	// Service fees and taxes added to ticket prices
+	Pricing PricingConfig
+
+	// Event currencies and exchange rates
+	Currency CurrencyConfig
+
+	// Precomputed upcoming-events window
+	UpcomingEvents UpcomingEventsConfig
+
+	// Scheduled event status transitions
+	EventLifecycle EventLifecycleConfig
+
+	// Public event sitemap for crawlers
+	Sitemap SitemapConfig
+
+	// Favorited event notifications
+	Favorites FavoritesConfig
+
+	// Organizer alerts when events near capacity
+	CapacityAlerts CapacityAlertsConfig
+
+	// Archival of completed events
+	Archive ArchiveConfig
+
+	// Notifications about venue and schedule changes
+	EventChanges EventChangesConfig
+
+	// Reminders sent to attendees ahead of events
+	EventReminders EventRemindersConfig
+
+	// Data protection for seat holds and debug endpoints
+	Privacy PrivacyConfig
+
+	// Waitlist notification links
+	Waitlist WaitlistConfig
+
+	// SMS/push follow-up of unopened waitlist emails
+	WaitlistEscalation WaitlistEscalationConfig
+
+	// Repair of drift between Redis waitlist queues and Postgres
+	WaitlistReconcile WaitlistReconcileConfig
+
+	// Yearly recap email
+	Recap RecapConfig
+
+	// Scheduled analytics report emails for admins
+	AnalyticsReports AnalyticsReportsConfig
+
+	// Read-only event analytics shared through signed links
+	AnalyticsShareLinks AnalyticsShareLinksConfig
+
+	// Analytics rollup tables
+	AnalyticsRollup AnalyticsRollupConfig
+
+	// User archives of tickets and invoices
+	Documents DocumentsConfig
+
+	// Admin previews and test sends of notification emails
+	EmailTemplates EmailTemplatesConfig
+
+	// Partner API keys
+	APIKeys APIKeysConfig
+
+	// Custom roles granting admin permissions
+	Permissions PermissionsConfig
+
+	// Signed webhook deliveries to partner endpoints
+	Webhooks WebhooksConfig
+
+	// Background jobs such as exports
+	Jobs JobsConfig
+
+	// Monitoring and alerting
+	Metrics         MetricsConfig
+	Alerting        AlertingConfig
+	HoldMonitor     HoldMonitorConfig
+	SeatConsistency SeatConsistencyConfig
+	RedisWatch      RedisWatchConfig
+	PoolBreaker     PoolBreakerConfig
 
 	// External services
 	AWS   AWSConfig
@@ -51,6 +173,41 @@ type DatabaseConfig struct {
 	Password string
 	SSLMode  string
 	DSN      string
+
+	// Apply pending migrations at startup rather than only checking the schema
+	// version. Off by default so production schema changes go through cmd/migrate.
+	AutoMigrate bool
+
+	// Read replicas for analytics, event listing and seat availability reads.
+	// Reads fall back to the primary while no replica is healthy.
+	ReplicaDSNs          []string
+	ReplicaCheckInterval time.Duration
+
+	// Connection pool, applied to the primary and each replica
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration // Zero keeps idle connections until ConnMaxLifetime
+	// PgBouncer disables prepared statements, which transaction pooling can't
+	// keep on one server connection, and uses the simple query protocol
+	PgBouncer bool
+}
+
+// Cache TTL overrides keyed by cache name, e.g. EVENT_DETAIL. Values come from the
+// optional YAML file and CACHE_TTL_<NAME> environment variables, env winning.
+type CacheTTLConfig struct {
+	File      string
+	Overrides map[string]time.Duration
+}
+
+// In-process LRU in front of Redis for small, hot objects (event details, tag
+// lists, venue templates). Each replica keeps its own copy; invalidations are
+// broadcast over Redis pub/sub so every replica drops its entries.
+type LocalCacheConfig struct {
+	Enabled       bool
+	MaxEntries    int
+	MaxEntryBytes int           // Larger values are only cached in Redis
+	TTL           time.Duration // Upper bound on local staleness if a broadcast is missed
 }
 
 // Redis configuration
@@ -61,10 +218,23 @@ type RedisConfig struct {
 	DB       int
 	Addr     string
 
-	SeatHoldTTL time.Duration
-	SessionTTL  time.Duration
-	CacheTTL    time.Duration
-	TempDataTTL time.Duration
+	// Mode is standalone, sentinel or cluster. Sentinel and cluster connect
+	// through Addrs, the sentinels or the cluster seed nodes.
+	Mode             string
+	Addrs            []string
+	MasterName       string // Sentinel master set name
+	SentinelPassword string
+
+	// Seat hold scripts are retried while the node they run on fails over
+	HoldRetries      int
+	HoldRetryBackoff time.Duration
+
+	SeatHoldTTL       time.Duration
+	SeatHoldExtension time.Duration // Added per extend request
+	SeatHoldMaxTTL    time.Duration // Max total lifetime of a hold, including extensions
+	SessionTTL        time.Duration
+	CacheTTL          time.Duration
+	TempDataTTL       time.Duration
 }
 
 // JWT configuration
@@ -74,6 +244,12 @@ type JWTConfig struct {
 	RefreshExpiresIn time.Duration
 }
 
+type TwoFactorConfig struct {
+	Issuer           string        // Account issuer shown in authenticator apps
+	ChallengeTTL     time.Duration // Time to enter a code after the password was accepted
+	RequireForAdmins bool          // Admin-only routes need a session that passed two-factor verification
+}
+
 // rate limiting configuration
 type RateLimitConfig struct {
 	Enabled                 bool          `json:"enabled"`
@@ -88,13 +264,318 @@ type RateLimitConfig struct {
 	UserRequests            int           `json:"user_requests"`   // NEW
 	HealthRequests          int           `json:"health_requests"` // NEW
 	WhitelistedIPs          []string      `json:"whitelisted_ips"`
+
+	// Per-user quotas, algorithm selection and burst allowances
+	PerUserLimits       bool           `json:"per_user_limits"`
+	UserLimitMultiplier float64        `json:"user_limit_multiplier"`
+	SlidingLogTypes     []string       `json:"sliding_log_types"`
+	Burst               map[string]int `json:"burst"`
 }
 
-type UploadConfig struct {
-	MaxSize int64
+// Notification outbox relay configuration
+type OutboxConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+	MaxAttempts  int
+	BaseBackoff  time.Duration
+	MaxBackoff   time.Duration
+}
+
+type DomainEventsConfig struct {
+	Enabled           bool // Publishes recorded events and tracks seat hold expiry
+	Brokers           []string
+	TopicPrefix       string
+	PollInterval      time.Duration
+	BatchSize         int
+	MaxAttempts       int
+	BaseBackoff       time.Duration
+	MaxBackoff        time.Duration
+	Retention         time.Duration // Published events are kept this long
+	HoldSweepInterval time.Duration // How often expired seat holds are looked for
+}
+
+// Failed payment retry schedule and cancellation
+type DunningConfig struct {
+	CheckInterval    time.Duration
+	RetrySchedule    []time.Duration
+	MaxAttempts      int
+	PaymentWindow    time.Duration // Pending bookings still unpaid after this are cancelled
+	ResumePaymentURL string
+}
+
+// Recovery of booking confirmations interrupted part way through
+type SagaConfig struct {
+	RecoveryInterval    time.Duration
+	StaleAfter          time.Duration
+	MaxRecoveryAttempts int
+}
+
+// Detection of bookings for events at the same time
+type BookingConflictConfig struct {
+	Mode   string // off, warn or block
+	Window time.Duration
+}
+
+// Offers of bookings to other users
+type TransferConfig struct {
+	Expiry    time.Duration
+	AcceptURL string // {transfer_id} is replaced
+}
+
+// Profile updates made by users
+type ProfileConfig struct {
+	EmailChangeExpiry time.Duration // How long a new email address has to be confirmed
+	EmailVerifyURL    string        // {token} is replaced
+}
+
+// Tokens support admins use to act as a user
+type ImpersonationConfig struct {
+	TTL    time.Duration // Token lifetime when the admin doesn't ask for one
+	MaxTTL time.Duration // Longest lifetime an admin can ask for
+}
+
+// Resale of booked seats between users
+type ResaleConfig struct {
+	FeePercent float64       // Kept from the seller's payout
+	Cutoff     time.Duration // Listings close this long before the event
+}
+
+// Fees and taxes charged on top of ticket prices
+type PricingConfig struct {
+	ServiceFeePercent   float64
+	ServiceFeePerTicket float64
+	TaxRules            []TaxRuleConfig
+	RefundServiceFee    bool
+}
+
+// A tax rule written as NAME:RATE, or NAME:RATE:fees to also tax the service fee
+type TaxRuleConfig struct {
+	Name        string
+	Rate        float64
+	IncludeFees bool
+}
+
+// Currencies events can be priced in. Rates are units of the currency per one
+// unit of the base currency, e.g. USD:0.012 with an INR base.
+type CurrencyConfig struct {
+	Base            string
+	Rates           map[string]float64
+	RatesURL        string        // Optional JSON rates feed, the static rates are the fallback
+	RefreshInterval time.Duration // How long fetched rates are used before refetching
+}
+
+// Window of upcoming events every /events/upcoming limit is served from
+type UpcomingEventsConfig struct {
+	WindowSize      int
+	RefreshInterval time.Duration
+}
+
+// Scheduler that completes, starts and sells out events
+type EventLifecycleConfig struct {
+	Enabled   bool
+	Interval  time.Duration
+	BatchSize int
+}
+
+type SitemapConfig struct {
+	EventURL string // Public event page, {event_id} is replaced
+}
+
+// Selling-out alerts for favorited events
+type FavoritesConfig struct {
+	SellOutCheckInterval  time.Duration
+	SellOutRemainingRatio float64 // Share of capacity left when users are notified
+}
+
+// Capacity threshold alerts for organizers, thresholds are set per event
+type CapacityAlertsConfig struct {
+	CheckInterval time.Duration
+}
+
+// Moves completed events and their bookings to archive tables
+type ArchiveConfig struct {
+	Enabled         bool
+	CheckInterval   time.Duration
+	RetentionMonths int // Months after the event date before it is archived
+}
+
+type EventChangesConfig struct {
+	BatchWindow   time.Duration // Edits within this window are sent as one notification
+	CheckInterval time.Duration
+	ReconfirmURL  string
+	WaitlistURL   string
+}
+
+type EventRemindersConfig struct {
+	Enabled       bool
+	Offsets       []time.Duration // How long before an event reminders go out
+	CheckInterval time.Duration
+	BookingURL    string
+}
+
+type PrivacyConfig struct {
+	HoldKey        string // Secret for hold owner tokens and encryption, defaults to the JWT secret
+	RedactDebugPII bool   // Mask user IDs and IPs in debug and admin inspection endpoints
+}
+
+type WaitlistConfig struct {
+	BookingURL string // Booking page linked from notifications, {event_id} and {entry_id} are replaced
+}
+
+type WaitlistEscalationConfig struct {
+	Enabled     bool
+	After       time.Duration
+	Interval    time.Duration
+	MaxAttempts int
+}
+
+type WaitlistReconcileConfig struct {
+	Enabled  bool
+	Interval time.Duration
+}
+
+// Yearly recap email schedule and send rate
+type AnalyticsRollupConfig struct {
+	Enabled      bool
+	Interval     time.Duration
+	NightlyHour  int // Hour the daily booking rows are rebuilt in full
+	BackfillDays int
+}
+
+type RecapConfig struct {
+	Enabled       bool
+	SendMonth     int
+	SendDay       int
+	BatchSize     int
+	BatchInterval time.Duration
+}
+
+type AnalyticsReportsConfig struct {
+	Enabled   bool
+	SendHour  int // UTC hour weekly (Monday) and monthly (1st) reports go out
+	BatchSize int
+}
+
+type AnalyticsShareLinksConfig struct {
+	DefaultTTL time.Duration // Link lifetime when the admin does not choose one
+	MaxTTL     time.Duration
+	Secret     string // Signs share tokens, defaults to the JWT secret
+}
+
+// ZIP archives of a user's tickets and invoices, kept outside the public uploads
+type DocumentsConfig struct {
+	Path            string
+	LinkTTL         time.Duration // How long a download link works, the file is deleted after
+	MinInterval     time.Duration // Minimum gap between a user's archive requests
+	CleanupInterval time.Duration
+	LinkSecret      string // Signs download links, defaults to the JWT secret
+}
+
+type EmailTemplatesConfig struct {
+	TestSendLimit  int // Test emails an admin can send per window
+	TestSendWindow time.Duration
+	CacheTTL       time.Duration // Template edits reach other instances within this time
+	MJMLAPIURL     string
+	MJMLAppID      string // MJML API credentials, MJML templates are rejected without them
+	MJMLSecretKey  string
+}
+
+// Scoped API keys for partner integrations, managed under /admin/api-keys
+type APIKeysConfig struct {
+	CacheTTL      time.Duration // Revocations reach other instances within this time
+	RotationGrace time.Duration // Previous secret keeps working this long after a rotation
+	FlushInterval time.Duration // How often metered usage is written
+}
+
+// Custom roles and their permissions, managed under /admin/roles
+type PermissionsConfig struct {
+	CacheTTL time.Duration // Role changes reach other instances within this time
+}
+
+// Partner webhook delivery worker, endpoints are managed under /admin/webhooks
+type WebhooksConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+	Concurrency  int           // Deliveries sent at once per instance
+	Timeout      time.Duration // Per-request timeout, slower endpoints count as failed
+	MaxAttempts  int
+	BaseBackoff  time.Duration
+	MaxBackoff   time.Duration
+	AllowHTTP    bool // Allows plain http:// endpoint URLs, for local development
+}
+
+type JobsConfig struct {
+	Path            string // Where job results are stored
+	Workers         int
+	PollInterval    time.Duration
+	Retention       time.Duration // Finished jobs and their results are deleted after this
+	CleanupInterval time.Duration
+}
+
+// Alert delivery channels, every configured channel receives each alert
+type MetricsConfig struct {
+	Enabled bool
 	Path    string
 }
 
+type AlertingConfig struct {
+	SlackWebhookURL string
+	WebhookURL      string
+	EmailRecipients []string
+	Cooldown        time.Duration // Minimum gap between repeats of the same alert
+}
+
+// Seat hold anomaly detection thresholds
+type HoldMonitorConfig struct {
+	Enabled               bool
+	Interval              time.Duration
+	MaxHoldAge            time.Duration // Holds older than this are reported as long-lived
+	ConversionWindow      time.Duration // Window used to compare holds created vs bookings
+	MinHoldsForConversion int           // Minimum holds in the window before conversion is checked
+	MinConversionRate     float64       // Alert when bookings/holds falls below this ratio
+	MaxSeatKeyDrift       int           // Allowed difference between held seat keys and hold seat counts
+}
+
+// Double booking detection. StrictLocks also serializes booking finalization
+// per seat with Postgres advisory locks.
+type SeatConsistencyConfig struct {
+	Enabled     bool
+	Interval    time.Duration
+	StrictLocks bool
+}
+
+// Redis outage detection for seat availability. While Redis is considered down,
+// availability is served from Postgres alone and new holds are refused.
+type RedisWatchConfig struct {
+	Interval         time.Duration // Time between Redis pings
+	ProbeTimeout     time.Duration
+	FailureThreshold int // Consecutive failures before switching to degraded mode
+	RecoverAfter     int // Consecutive successful pings before leaving degraded mode
+}
+
+// Load shedding for booking endpoints while the Postgres pool is saturated, so
+// they answer 503 with Retry-After instead of waiting for a connection
+type PoolBreakerConfig struct {
+	Enabled         bool
+	SampleInterval  time.Duration // Time between pool stat samples
+	SaturationRatio float64       // Share of MaxOpenConns in use, with waiters, that counts as saturated
+	TripAfter       int           // Consecutive saturated samples before shedding
+	OpenFor         time.Duration // Minimum time to shed once tripped, also sent as Retry-After
+}
+
+type UploadConfig struct {
+	MaxSize   int64
+	Path      string
+	PublicURL string // URL prefix uploaded files are served from, absolute when emails must load them
+}
+
+type BrandingConfig struct {
+	Name           string
+	LogoURL        string
+	PrimaryColor   string
+	SecondaryColor string
+}
+
 type AWSConfig struct {
 	Region          string
 	AccessKeyID     string
@@ -121,6 +602,16 @@ func Load() *Config {
 		WriteTimeout:   getDurationEnv("WRITE_TIMEOUT", 15*time.Second),
 		IdleTimeout:    getDurationEnv("IDLE_TIMEOUT", 60*time.Second),
 		MaxHeaderBytes: getIntEnv("MAX_HEADER_BYTES", 1<<20), // 1 MB
+		PublicURL:      strings.TrimSuffix(getEnv("PUBLIC_URL", "http://localhost:8080"), "/"),
+
+		CacheTTLs: loadCacheTTLConfig(getEnv("CACHE_TTL_FILE", "")),
+
+		LocalCache: LocalCacheConfig{
+			Enabled:       getBoolEnv("CACHE_LOCAL_ENABLED", true),
+			MaxEntries:    getIntEnv("CACHE_LOCAL_MAX_ENTRIES", 10000),
+			MaxEntryBytes: getIntEnv("CACHE_LOCAL_MAX_ENTRY_BYTES", 64*1024),
+			TTL:           getDurationEnv("CACHE_LOCAL_TTL", 30*time.Second),
+		},
 
 		// Database configuration
 		Database: DatabaseConfig{
@@ -130,6 +621,17 @@ func Load() *Config {
 			User:     getEnv("DB_USER", "evently_user"),
 			Password: getEnv("DB_PASSWORD", "evently_password"),
 			SSLMode:  getEnv("DB_SSLMODE", "disable"),
+
+			AutoMigrate: getBoolEnv("DB_AUTO_MIGRATE", false),
+
+			ReplicaDSNs:          getStringSliceEnv("DB_REPLICA_DSNS", nil),
+			ReplicaCheckInterval: getDurationEnv("DB_REPLICA_CHECK_INTERVAL", 10*time.Second),
+
+			MaxOpenConns:    getIntEnv("DB_MAX_OPEN_CONNS", 100),
+			MaxIdleConns:    getIntEnv("DB_MAX_IDLE_CONNS", 10),
+			ConnMaxLifetime: getDurationEnv("DB_CONN_MAX_LIFETIME", time.Hour),
+			ConnMaxIdleTime: getDurationEnv("DB_CONN_MAX_IDLE_TIME", 0),
+			PgBouncer:       getBoolEnv("DB_PGBOUNCER", false),
 		},
 
 		// Redis configuration
@@ -139,11 +641,20 @@ func Load() *Config {
 			Password: getEnv("REDIS_PASSWORD", ""),
 			DB:       getIntEnv("REDIS_DB", 0),
 
+			Mode:             getEnv("REDIS_MODE", "standalone"),
+			Addrs:            getStringSliceEnv("REDIS_ADDRS", nil),
+			MasterName:       getEnv("REDIS_MASTER_NAME", "mymaster"),
+			SentinelPassword: getEnv("REDIS_SENTINEL_PASSWORD", ""),
+			HoldRetries:      getIntEnv("REDIS_HOLD_RETRIES", 3),
+			HoldRetryBackoff: getDurationEnv("REDIS_HOLD_RETRY_BACKOFF", 200*time.Millisecond),
+
 			// TTL configurations with defaults
-			SeatHoldTTL: getDurationEnv("REDIS_SEAT_HOLD_TTL", 10*time.Minute),
-			SessionTTL:  getDurationEnv("REDIS_SESSION_TTL", 24*time.Hour),
-			CacheTTL:    getDurationEnv("REDIS_CACHE_TTL", 1*time.Hour),
-			TempDataTTL: getDurationEnv("REDIS_TEMP_DATA_TTL", 5*time.Minute),
+			SeatHoldTTL:       getDurationEnv("REDIS_SEAT_HOLD_TTL", 10*time.Minute),
+			SeatHoldExtension: getDurationEnv("REDIS_SEAT_HOLD_EXTENSION", 5*time.Minute),
+			SeatHoldMaxTTL:    getDurationEnv("REDIS_SEAT_HOLD_MAX_TTL", 20*time.Minute),
+			SessionTTL:        getDurationEnv("REDIS_SESSION_TTL", 24*time.Hour),
+			CacheTTL:          getDurationEnv("REDIS_CACHE_TTL", 1*time.Hour),
+			TempDataTTL:       getDurationEnv("REDIS_TEMP_DATA_TTL", 5*time.Minute),
 		},
 
 		// JWT configuration
@@ -153,6 +664,12 @@ func Load() *Config {
 			RefreshExpiresIn: getDurationEnvSeconds("JWT_REFRESH_EXPIRES_IN", 24*time.Hour),
 		},
 
+		TwoFactor: TwoFactorConfig{
+			Issuer:           getEnv("TWO_FACTOR_ISSUER", "Evently"),
+			ChallengeTTL:     getDurationEnv("TWO_FACTOR_CHALLENGE_TTL", 5*time.Minute),
+			RequireForAdmins: getBoolEnv("TWO_FACTOR_REQUIRE_FOR_ADMINS", true),
+		},
+
 		// Rate limiting
 		RateLimit: RateLimitConfig{
 			Enabled:                 getBoolEnv("RATE_LIMIT_ENABLED", true),
@@ -167,16 +684,287 @@ func Load() *Config {
 			UserRequests:            getIntEnv("RATE_LIMIT_USER_REQUESTS", 150),
 			HealthRequests:          getIntEnv("RATE_LIMIT_HEALTH_REQUESTS", 1000),
 			WhitelistedIPs:          getStringSliceEnv("RATE_LIMIT_WHITELISTED_IPS", []string{}),
+
+			PerUserLimits:       getBoolEnv("RATE_LIMIT_PER_USER", true),
+			UserLimitMultiplier: getFloatEnv("RATE_LIMIT_USER_MULTIPLIER", 1.0),
+			SlidingLogTypes:     getStringSliceEnv("RATE_LIMIT_SLIDING_LOG_TYPES", []string{"booking_critical"}),
+			Burst:               getIntMapEnv("RATE_LIMIT_BURST"),
 		},
 
 		// File upload
 		Upload: UploadConfig{
-			MaxSize: getInt64Env("MAX_UPLOAD_SIZE", 10*1024*1024), // 10 MB
-			Path:    getEnv("UPLOAD_PATH", "./uploads"),
+			MaxSize:   getInt64Env("MAX_UPLOAD_SIZE", 10*1024*1024), // 10 MB
+			Path:      getEnv("UPLOAD_PATH", "./uploads"),
+			PublicURL: getEnv("UPLOAD_PUBLIC_URL", "/uploads"),
+		},
+
+		Branding: BrandingConfig{
+			Name:           getEnv("BRAND_NAME", "Evently"),
+			LogoURL:        getEnv("BRAND_LOGO_URL", ""),
+			PrimaryColor:   getEnv("BRAND_PRIMARY_COLOR", "#4F46E5"),
+			SecondaryColor: getEnv("BRAND_SECONDARY_COLOR", "#111827"),
 		},
 
 		// Logging
-		LogLevel: getEnv("LOG_LEVEL", "debug"),
+		LogLevel:         getEnv("LOG_LEVEL", "debug"),
+		LogModuleLevels:  getStringMapEnv("LOG_MODULE_LEVELS"),
+		CacheRequestLogs: getBoolEnv("CACHE_REQUEST_LOGS", getEnv("GIN_MODE", "debug") != "release"),
+
+		// Notification outbox relay
+		Outbox: OutboxConfig{
+			PollInterval: getDurationEnv("OUTBOX_POLL_INTERVAL", 2*time.Second),
+			BatchSize:    getIntEnv("OUTBOX_BATCH_SIZE", 50),
+			MaxAttempts:  getIntEnv("OUTBOX_MAX_ATTEMPTS", 10),
+			BaseBackoff:  getDurationEnv("OUTBOX_BASE_BACKOFF", 5*time.Second),
+			MaxBackoff:   getDurationEnv("OUTBOX_MAX_BACKOFF", 10*time.Minute),
+		},
+
+		DomainEvents: DomainEventsConfig{
+			Enabled:           getBoolEnv("DOMAIN_EVENTS_ENABLED", false),
+			Brokers:           getStringSliceEnv("KAFKA_BROKERS", []string{"localhost:9092"}),
+			TopicPrefix:       getEnv("DOMAIN_EVENTS_TOPIC_PREFIX", "evently."),
+			PollInterval:      getDurationEnv("DOMAIN_EVENTS_POLL_INTERVAL", time.Second),
+			BatchSize:         getIntEnv("DOMAIN_EVENTS_BATCH_SIZE", 100),
+			MaxAttempts:       getIntEnv("DOMAIN_EVENTS_MAX_ATTEMPTS", 20),
+			BaseBackoff:       getDurationEnv("DOMAIN_EVENTS_BASE_BACKOFF", 5*time.Second),
+			MaxBackoff:        getDurationEnv("DOMAIN_EVENTS_MAX_BACKOFF", 10*time.Minute),
+			Retention:         getDurationEnv("DOMAIN_EVENTS_RETENTION", 7*24*time.Hour),
+			HoldSweepInterval: getDurationEnv("DOMAIN_EVENTS_HOLD_SWEEP_INTERVAL", 15*time.Second),
+		},
+
+		Dunning: DunningConfig{
+			CheckInterval:    getDurationEnv("PAYMENT_RETRY_CHECK_INTERVAL", time.Minute),
+			RetrySchedule:    getDurationSliceEnv("PAYMENT_RETRY_SCHEDULE", []time.Duration{10 * time.Minute, time.Hour}),
+			MaxAttempts:      getIntEnv("PAYMENT_MAX_ATTEMPTS", 3),
+			PaymentWindow:    getDurationEnv("PAYMENT_WINDOW", 2*time.Hour),
+			ResumePaymentURL: getEnv("PAYMENT_RESUME_URL", "http://localhost:3000/bookings/{booking_id}/pay"),
+		},
+
+		Saga: SagaConfig{
+			RecoveryInterval:    getDurationEnv("BOOKING_SAGA_RECOVERY_INTERVAL", time.Minute),
+			StaleAfter:          getDurationEnv("BOOKING_SAGA_STALE_AFTER", 5*time.Minute),
+			MaxRecoveryAttempts: getIntEnv("BOOKING_SAGA_MAX_RECOVERY_ATTEMPTS", 5),
+		},
+
+		BookingConflict: BookingConflictConfig{
+			Mode:   strings.ToLower(getEnv("BOOKING_CONFLICT_MODE", "warn")),
+			Window: getDurationEnv("BOOKING_CONFLICT_WINDOW", 3*time.Hour),
+		},
+
+		Transfer: TransferConfig{
+			Expiry:    getDurationEnv("TRANSFER_EXPIRY", 48*time.Hour),
+			AcceptURL: getEnv("TRANSFER_ACCEPT_URL", "http://localhost:3000/transfers/{transfer_id}"),
+		},
+
+		Profile: ProfileConfig{
+			EmailChangeExpiry: getDurationEnv("PROFILE_EMAIL_CHANGE_EXPIRY", 24*time.Hour),
+			EmailVerifyURL:    getEnv("PROFILE_EMAIL_VERIFY_URL", "http://localhost:3000/account/verify-email?token={token}"),
+		},
+
+		Impersonation: ImpersonationConfig{
+			TTL:    getDurationEnv("IMPERSONATION_TTL", 15*time.Minute),
+			MaxTTL: getDurationEnv("IMPERSONATION_MAX_TTL", time.Hour),
+		},
+
+		Resale: ResaleConfig{
+			FeePercent: getFloatEnv("RESALE_FEE_PERCENT", 10),
+			Cutoff:     getDurationEnv("RESALE_CUTOFF", 2*time.Hour),
+		},
+
+		Pricing: PricingConfig{
+			ServiceFeePercent:   getFloatEnv("PRICING_SERVICE_FEE_PERCENT", 0),
+			ServiceFeePerTicket: getFloatEnv("PRICING_SERVICE_FEE_PER_TICKET", 0),
+			TaxRules:            getTaxRulesEnv("PRICING_TAX_RULES"),
+			RefundServiceFee:    getBoolEnv("PRICING_REFUND_SERVICE_FEE", false),
+		},
+
+		Currency: CurrencyConfig{
+			Base:            strings.ToUpper(getEnv("CURRENCY_BASE", "INR")),
+			Rates:           getRatesEnv("CURRENCY_RATES"),
+			RatesURL:        getEnv("CURRENCY_RATES_URL", ""),
+			RefreshInterval: getDurationEnv("CURRENCY_RATES_REFRESH_INTERVAL", time.Hour),
+		},
+
+		UpcomingEvents: UpcomingEventsConfig{
+			WindowSize:      getIntEnv("UPCOMING_EVENTS_WINDOW_SIZE", 200),
+			RefreshInterval: getDurationEnv("UPCOMING_EVENTS_REFRESH_INTERVAL", 5*time.Minute),
+		},
+
+		EventLifecycle: EventLifecycleConfig{
+			Enabled:   getBoolEnv("EVENT_LIFECYCLE_ENABLED", true),
+			Interval:  getDurationEnv("EVENT_LIFECYCLE_INTERVAL", time.Minute),
+			BatchSize: getIntEnv("EVENT_LIFECYCLE_BATCH_SIZE", 200),
+		},
+
+		Sitemap: SitemapConfig{
+			EventURL: getEnv("SITEMAP_EVENT_URL", "http://localhost:3000/events/{event_id}"),
+		},
+
+		Favorites: FavoritesConfig{
+			SellOutCheckInterval:  getDurationEnv("FAVORITES_SELLOUT_CHECK_INTERVAL", 5*time.Minute),
+			SellOutRemainingRatio: getFloatEnv("FAVORITES_SELLOUT_REMAINING_RATIO", 0.1),
+		},
+
+		CapacityAlerts: CapacityAlertsConfig{
+			CheckInterval: getDurationEnv("CAPACITY_ALERTS_CHECK_INTERVAL", time.Minute),
+		},
+
+		Archive: ArchiveConfig{
+			Enabled:         getBoolEnv("ARCHIVE_ENABLED", true),
+			CheckInterval:   getDurationEnv("ARCHIVE_CHECK_INTERVAL", 24*time.Hour),
+			RetentionMonths: getIntEnv("ARCHIVE_RETENTION_MONTHS", 12),
+		},
+
+		EventChanges: EventChangesConfig{
+			BatchWindow:   getDurationEnv("EVENT_CHANGE_BATCH_WINDOW", 5*time.Minute),
+			CheckInterval: getDurationEnv("EVENT_CHANGE_CHECK_INTERVAL", 30*time.Second),
+			ReconfirmURL:  getEnv("EVENT_CHANGE_RECONFIRM_URL", "http://localhost:3000/bookings/{booking_id}/reconfirm"),
+			WaitlistURL:   getEnv("EVENT_CHANGE_WAITLIST_URL", "http://localhost:3000/waitlist/{entry_id}"),
+		},
+
+		EventReminders: EventRemindersConfig{
+			Enabled:       getBoolEnv("EVENT_REMINDERS_ENABLED", true),
+			Offsets:       getDurationSliceEnv("EVENT_REMINDER_OFFSETS", []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, 2 * time.Hour}),
+			CheckInterval: getDurationEnv("EVENT_REMINDER_CHECK_INTERVAL", time.Minute),
+			BookingURL:    getEnv("EVENT_REMINDER_BOOKING_URL", "http://localhost:3000/bookings/{booking_id}"),
+		},
+
+		Privacy: PrivacyConfig{
+			HoldKey:        getEnv("HOLD_PRIVACY_KEY", getEnv("JWT_SECRET", "your-super-secret-jwt-key")),
+			RedactDebugPII: getBoolEnv("PRIVACY_REDACT_DEBUG_PII", true),
+		},
+
+		Waitlist: WaitlistConfig{
+			BookingURL: getEnv("WAITLIST_BOOKING_URL", "http://localhost:3000/events/{event_id}/book?waitlist={entry_id}"),
+		},
+
+		WaitlistEscalation: WaitlistEscalationConfig{
+			Enabled:     getBoolEnv("WAITLIST_ESCALATION_ENABLED", true),
+			After:       getDurationEnv("WAITLIST_ESCALATION_AFTER", 5*time.Minute),
+			Interval:    getDurationEnv("WAITLIST_ESCALATION_INTERVAL", 30*time.Second),
+			MaxAttempts: getIntEnv("WAITLIST_ESCALATION_MAX_ATTEMPTS", 3),
+		},
+
+		WaitlistReconcile: WaitlistReconcileConfig{
+			Enabled:  getBoolEnv("WAITLIST_RECONCILE_ENABLED", true),
+			Interval: getDurationEnv("WAITLIST_RECONCILE_INTERVAL", 5*time.Minute),
+		},
+
+		AnalyticsRollup: AnalyticsRollupConfig{
+			Enabled:      getBoolEnv("ANALYTICS_ROLLUP_ENABLED", true),
+			Interval:     getDurationEnv("ANALYTICS_ROLLUP_INTERVAL", time.Hour),
+			NightlyHour:  getIntEnv("ANALYTICS_ROLLUP_NIGHTLY_HOUR", 3),
+			BackfillDays: getIntEnv("ANALYTICS_ROLLUP_BACKFILL_DAYS", 400),
+		},
+
+		Recap: RecapConfig{
+			Enabled:       getBoolEnv("RECAP_ENABLED", true),
+			SendMonth:     getIntEnv("RECAP_SEND_MONTH", 1),
+			SendDay:       getIntEnv("RECAP_SEND_DAY", 2),
+			BatchSize:     getIntEnv("RECAP_BATCH_SIZE", 100),
+			BatchInterval: getDurationEnv("RECAP_BATCH_INTERVAL", 30*time.Second),
+		},
+
+		AnalyticsReports: AnalyticsReportsConfig{
+			Enabled:   getBoolEnv("ANALYTICS_REPORTS_ENABLED", true),
+			SendHour:  getIntEnv("ANALYTICS_REPORTS_SEND_HOUR", 7),
+			BatchSize: getIntEnv("ANALYTICS_REPORTS_BATCH_SIZE", 100),
+		},
+
+		AnalyticsShareLinks: AnalyticsShareLinksConfig{
+			DefaultTTL: getDurationEnv("ANALYTICS_SHARE_LINK_TTL", 7*24*time.Hour),
+			MaxTTL:     getDurationEnv("ANALYTICS_SHARE_LINK_MAX_TTL", 90*24*time.Hour),
+			Secret:     getEnv("ANALYTICS_SHARE_LINK_SECRET", getEnv("JWT_SECRET", "your-super-secret-jwt-key")),
+		},
+
+		Documents: DocumentsConfig{
+			Path:            getEnv("DOCUMENTS_PATH", "./storage/documents"),
+			LinkTTL:         getDurationEnv("DOCUMENTS_LINK_TTL", 24*time.Hour),
+			MinInterval:     getDurationEnv("DOCUMENTS_MIN_INTERVAL", time.Hour),
+			CleanupInterval: getDurationEnv("DOCUMENTS_CLEANUP_INTERVAL", time.Hour),
+			LinkSecret:      getEnv("DOCUMENTS_LINK_SECRET", getEnv("JWT_SECRET", "your-super-secret-jwt-key")),
+		},
+
+		EmailTemplates: EmailTemplatesConfig{
+			TestSendLimit:  getIntEnv("EMAIL_TEST_SEND_LIMIT", 10),
+			TestSendWindow: getDurationEnv("EMAIL_TEST_SEND_WINDOW", time.Hour),
+			CacheTTL:       getDurationEnv("EMAIL_TEMPLATE_CACHE_TTL", time.Minute),
+			MJMLAPIURL:     getEnv("MJML_API_URL", "https://api.mjml.io/v1/render"),
+			MJMLAppID:      getEnv("MJML_APP_ID", ""),
+			MJMLSecretKey:  getEnv("MJML_SECRET_KEY", ""),
+		},
+
+		APIKeys: APIKeysConfig{
+			CacheTTL:      getDurationEnv("API_KEY_CACHE_TTL", 30*time.Second),
+			RotationGrace: getDurationEnv("API_KEY_ROTATION_GRACE", 24*time.Hour),
+			FlushInterval: getDurationEnv("API_KEY_USAGE_FLUSH_INTERVAL", time.Minute),
+		},
+
+		Permissions: PermissionsConfig{
+			CacheTTL: getDurationEnv("PERMISSION_CACHE_TTL", 30*time.Second),
+		},
+
+		Webhooks: WebhooksConfig{
+			PollInterval: getDurationEnv("WEBHOOK_POLL_INTERVAL", 2*time.Second),
+			BatchSize:    getIntEnv("WEBHOOK_BATCH_SIZE", 50),
+			Concurrency:  getIntEnv("WEBHOOK_CONCURRENCY", 4),
+			Timeout:      getDurationEnv("WEBHOOK_TIMEOUT", 10*time.Second),
+			MaxAttempts:  getIntEnv("WEBHOOK_MAX_ATTEMPTS", 8),
+			BaseBackoff:  getDurationEnv("WEBHOOK_BASE_BACKOFF", 30*time.Second),
+			MaxBackoff:   getDurationEnv("WEBHOOK_MAX_BACKOFF", 6*time.Hour),
+			AllowHTTP:    getBoolEnv("WEBHOOK_ALLOW_HTTP", false),
+		},
+
+		Jobs: JobsConfig{
+			Path:            getEnv("JOBS_PATH", "./storage/jobs"),
+			Workers:         getIntEnv("JOBS_WORKERS", 2),
+			PollInterval:    getDurationEnv("JOBS_POLL_INTERVAL", 2*time.Second),
+			Retention:       getDurationEnv("JOBS_RETENTION", 7*24*time.Hour),
+			CleanupInterval: getDurationEnv("JOBS_CLEANUP_INTERVAL", time.Hour),
+		},
+
+		Metrics: MetricsConfig{
+			Enabled: getBoolEnv("METRICS_ENABLED", true),
+			Path:    getEnv("METRICS_PATH", "/metrics"),
+		},
+
+		Alerting: AlertingConfig{
+			SlackWebhookURL: getEnv("ALERT_SLACK_WEBHOOK_URL", ""),
+			WebhookURL:      getEnv("ALERT_WEBHOOK_URL", ""),
+			EmailRecipients: getStringSliceEnv("ALERT_EMAIL_RECIPIENTS", []string{}),
+			Cooldown:        getDurationEnv("ALERT_COOLDOWN", 15*time.Minute),
+		},
+
+		HoldMonitor: HoldMonitorConfig{
+			Enabled:               getBoolEnv("HOLD_MONITOR_ENABLED", true),
+			Interval:              getDurationEnv("HOLD_MONITOR_INTERVAL", time.Minute),
+			MaxHoldAge:            getDurationEnv("HOLD_MONITOR_MAX_HOLD_AGE", 30*time.Minute),
+			ConversionWindow:      getDurationEnv("HOLD_MONITOR_CONVERSION_WINDOW", 15*time.Minute),
+			MinHoldsForConversion: getIntEnv("HOLD_MONITOR_MIN_HOLDS", 50),
+			MinConversionRate:     getFloatEnv("HOLD_MONITOR_MIN_CONVERSION_RATE", 0.05),
+			MaxSeatKeyDrift:       getIntEnv("HOLD_MONITOR_MAX_SEAT_KEY_DRIFT", 25),
+		},
+
+		SeatConsistency: SeatConsistencyConfig{
+			Enabled:     getBoolEnv("SEAT_CONSISTENCY_CHECK_ENABLED", true),
+			Interval:    getDurationEnv("SEAT_CONSISTENCY_CHECK_INTERVAL", 5*time.Minute),
+			StrictLocks: getBoolEnv("BOOKING_STRICT_SEAT_LOCKS", false),
+		},
+
+		RedisWatch: RedisWatchConfig{
+			Interval:         getDurationEnv("REDIS_WATCH_INTERVAL", 5*time.Second),
+			ProbeTimeout:     getDurationEnv("REDIS_WATCH_PROBE_TIMEOUT", time.Second),
+			FailureThreshold: getIntEnv("REDIS_WATCH_FAILURE_THRESHOLD", 3),
+			RecoverAfter:     getIntEnv("REDIS_WATCH_RECOVER_AFTER", 2),
+		},
+
+		PoolBreaker: PoolBreakerConfig{
+			Enabled:         getBoolEnv("DB_POOL_BREAKER_ENABLED", true),
+			SampleInterval:  getDurationEnv("DB_POOL_BREAKER_SAMPLE_INTERVAL", 500*time.Millisecond),
+			SaturationRatio: getFloatEnv("DB_POOL_BREAKER_SATURATION_RATIO", 0.95),
+			TripAfter:       getIntEnv("DB_POOL_BREAKER_TRIP_AFTER", 3),
+			OpenFor:         getDurationEnv("DB_POOL_BREAKER_OPEN_FOR", 5*time.Second),
+		},
 
 		AWS: AWSConfig{
 			Region:          getEnv("AWS_REGION", ""),
@@ -258,6 +1046,16 @@ func getDurationEnvSeconds(key string, fallback time.Duration) time.Duration {
 	return fallback
 }
 
+// gets a float environment variable with a fallback value
+func getFloatEnv(key string, fallback float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+	}
+	return fallback
+}
+
 // gets a boolean environment variable with a fallback value
 func getBoolEnv(key string, fallback bool) bool {
 	if value := os.Getenv(key); value != "" {
@@ -285,6 +1083,98 @@ func getStringSliceEnv(key string, fallback []string) []string {
 	return fallback
 }
 
+// gets tax rules such as "GST:18:fees,CITY:2". Malformed rules are skipped.
+func getTaxRulesEnv(key string) []TaxRuleConfig {
+	var rules []TaxRuleConfig
+	for _, value := range getStringSliceEnv(key, nil) {
+		parts := strings.Split(value, ":")
+		if len(parts) < 2 || len(parts) > 3 {
+			continue
+		}
+		name := strings.TrimSpace(parts[0])
+		rate, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+		if name == "" || err != nil || rate < 0 {
+			continue
+		}
+		rules = append(rules, TaxRuleConfig{
+			Name:        name,
+			Rate:        rate,
+			IncludeFees: len(parts) == 3 && strings.EqualFold(strings.TrimSpace(parts[2]), "fees"),
+		})
+	}
+	return rules
+}
+
+// gets exchange rates such as "USD:0.012,EUR:0.011". Malformed rates are skipped.
+func getRatesEnv(key string) map[string]float64 {
+	rates := make(map[string]float64)
+	for _, value := range getStringSliceEnv(key, nil) {
+		parts := strings.Split(value, ":")
+		if len(parts) != 2 {
+			continue
+		}
+		code := strings.ToUpper(strings.TrimSpace(parts[0]))
+		rate, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+		if code == "" || err != nil || rate <= 0 {
+			continue
+		}
+		rates[code] = rate
+	}
+	return rates
+}
+
+// getIntMapEnv parses "name:value" pairs, skipping malformed or negative entries
+func getIntMapEnv(key string) map[string]int {
+	values := make(map[string]int)
+	for _, value := range getStringSliceEnv(key, nil) {
+		parts := strings.Split(value, ":")
+		if len(parts) != 2 {
+			continue
+		}
+		name := strings.TrimSpace(parts[0])
+		n, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+		if name == "" || err != nil || n < 0 {
+			continue
+		}
+		values[name] = n
+	}
+	return values
+}
+
+// getStringMapEnv parses "name:value" pairs, skipping malformed entries
+func getStringMapEnv(key string) map[string]string {
+	values := make(map[string]string)
+	for _, value := range getStringSliceEnv(key, nil) {
+		parts := strings.Split(value, ":")
+		if len(parts) != 2 {
+			continue
+		}
+		name, v := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+		if name == "" || v == "" {
+			continue
+		}
+		values[name] = v
+	}
+	return values
+}
+
+func getDurationSliceEnv(key string, fallback []time.Duration) []time.Duration {
+	values := getStringSliceEnv(key, nil)
+	if len(values) == 0 {
+		return fallback
+	}
+
+	result := make([]time.Duration, 0, len(values))
+	for _, value := range values {
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return fallback
+		}
+		result = append(result, duration)
+	}
+	return result
+}
+
 func (c *Config) IsProduction() bool {
 	return c.GinMode == "release"
 }
@@ -2,13 +2,20 @@ package database
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 	"log"
 	"time"
 
 	"evently/internal/shared/config"
+	"evently/internal/shared/dbresolver"
+	"evently/internal/shared/migrate"
+	"evently/migrations"
+	"evently/pkg/cache"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
 	"github.com/redis/go-redis/v9"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -17,7 +24,8 @@ import (
 
 type DB struct {
 	PostgreSQL *gorm.DB
-	Redis      *redis.Client
+	Redis      redis.UniversalClient
+	Replicas   *dbresolver.Resolver // Nil without read replicas
 }
 
 // initializes the database connections
@@ -27,10 +35,14 @@ func InitDB(cfg *config.Config) (*DB, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize PostgreSQL: %w", err)
 	}
-	err = Migrate(pg)
+	if err := checkSchema(cfg, pg); err != nil {
+		return nil, err
+	}
+
+	// Route marked reads to the read replicas, if any
+	replicas, err := initReplicas(cfg, pg)
 	if err != nil {
-		log.Printf("Migration failed: %v", err)
-		return nil, fmt.Errorf("failed to run migrations: %w", err)
+		return nil, fmt.Errorf("failed to initialize read replicas: %w", err)
 	}
 
 	// Initialize Redis
@@ -42,6 +54,7 @@ func InitDB(cfg *config.Config) (*DB, error) {
 	return &DB{
 		PostgreSQL: pg,
 		Redis:      redisClient,
+		Replicas:   replicas,
 	}, nil
 }
 
@@ -61,12 +74,17 @@ func initPostgreSQL(cfg *config.Config) (*gorm.DB, error) {
 		NowFunc: func() time.Time {
 			return time.Now().UTC()
 		},
-		PrepareStmt:                              true,
+		PrepareStmt:                              !cfg.Database.PgBouncer,
 		DisableForeignKeyConstraintWhenMigrating: true,
 	}
 
-	// Connect to database
-	db, err := gorm.Open(postgres.Open(cfg.Database.DSN), gormConfig)
+	// Connect to database. PgBouncer in transaction mode hands each transaction
+	// any server connection, so statements can't be prepared on one.
+	dialector := postgres.New(postgres.Config{
+		DSN:                  cfg.Database.DSN,
+		PreferSimpleProtocol: cfg.Database.PgBouncer,
+	})
+	db, err := gorm.Open(dialector, gormConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
@@ -78,9 +96,7 @@ func initPostgreSQL(cfg *config.Config) (*gorm.DB, error) {
 	}
 
 	// Configure connection pool
-	sqlDB.SetMaxIdleConns(10)
-	sqlDB.SetMaxOpenConns(100)
-	sqlDB.SetConnMaxLifetime(time.Hour)
+	configurePool(sqlDB, cfg.Database)
 
 	// Test the connection
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -90,18 +106,114 @@ func initPostgreSQL(cfg *config.Config) (*gorm.DB, error) {
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
+	registerPoolMetrics(sqlDB)
+
 	log.Println("✅ PostgreSQL connected successfully")
 	return db, nil
 }
 
+func configurePool(sqlDB *sql.DB, cfg config.DatabaseConfig) {
+	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+}
+
+// checkSchema refuses to start against a schema at another version than this
+// binary's migrations, applying pending ones first when DB_AUTO_MIGRATE is set
+func checkSchema(cfg *config.Config, pg *gorm.DB) error {
+	sqlDB, err := pg.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get underlying sql.DB: %w", err)
+	}
+	migrator, err := migrate.New(sqlDB, migrations.FS)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	if cfg.Database.AutoMigrate {
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			log.Printf("Migration failed: %v", err)
+			return fmt.Errorf("failed to run migrations: %w", err)
+		}
+		if applied > 0 {
+			log.Printf("✅ Applied %d migrations", applied)
+		}
+	}
+
+	if err := migrator.Validate(ctx); err != nil {
+		return fmt.Errorf("schema check failed: %w", err)
+	}
+	log.Printf("✅ Schema at version %d", migrator.Expected())
+	return nil
+}
+
+// initReplicas opens the read replica pools and registers the resolver that
+// routes reads to them. A replica that can't be reached at startup is left out
+// of rotation until a health check reaches it, rather than failing startup.
+func initReplicas(cfg *config.Config, pg *gorm.DB) (*dbresolver.Resolver, error) {
+	if len(cfg.Database.ReplicaDSNs) == 0 {
+		return nil, nil
+	}
+
+	replicas := make([]*dbresolver.Replica, 0, len(cfg.Database.ReplicaDSNs))
+	for i, dsn := range cfg.Database.ReplicaDSNs {
+		sqlDB, err := openReplica(dsn, cfg.Database.PgBouncer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open replica %d: %w", i+1, err)
+		}
+		configurePool(sqlDB, cfg.Database)
+
+		// Named by position so DSN credentials stay out of logs
+		replicas = append(replicas, &dbresolver.Replica{Name: fmt.Sprintf("replica-%d", i+1), DB: sqlDB})
+	}
+
+	resolver := dbresolver.New(replicas, cfg.Database.ReplicaCheckInterval)
+	if err := pg.Use(resolver); err != nil {
+		resolver.Close()
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	resolver.CheckReplicas(ctx)
+
+	log.Printf("✅ PostgreSQL read replicas configured: %d", len(replicas))
+	return resolver, nil
+}
+
+// openReplica opens a replica pool with pgx, on the simple query protocol
+// when it is reached through PgBouncer like the primary
+func openReplica(dsn string, pgBouncer bool) (*sql.DB, error) {
+	connConfig, err := pgx.ParseConfig(dsn)
+	if err != nil {
+		return nil, err
+	}
+	if pgBouncer {
+		connConfig.DefaultQueryExecMode = pgx.QueryExecModeSimpleProtocol
+	}
+	return stdlib.OpenDB(*connConfig), nil
+}
+
 // initRedis initializes Redis connection
-func initRedis(cfg *config.Config) (*redis.Client, error) {
-	// Create Redis client
-	client := redis.NewClient(&redis.Options{
-		Addr:     cfg.Redis.Addr,
-		Password: cfg.Redis.Password,
-		DB:       cfg.Redis.DB,
-	})
+func initRedis(cfg *config.Config) (redis.UniversalClient, error) {
+	// Create Redis client for the configured mode
+	client, err := cache.NewClient(cache.NewConfigFromRedisConfig(cache.RedisConfig{
+		Addr:             cfg.Redis.Addr,
+		Password:         cfg.Redis.Password,
+		DB:               cfg.Redis.DB,
+		Mode:             cfg.Redis.Mode,
+		Addrs:            cfg.Redis.Addrs,
+		MasterName:       cfg.Redis.MasterName,
+		SentinelPassword: cfg.Redis.SentinelPassword,
+	}))
+	if err != nil {
+		return nil, err
+	}
 
 	// Test the connection
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -112,7 +224,7 @@ func initRedis(cfg *config.Config) (*redis.Client, error) {
 		return nil, fmt.Errorf("failed to ping Redis: %w", err)
 	}
 
-	log.Println("✅ Redis connected successfully")
+	log.Printf("✅ Redis connected successfully (%s)", cfg.Redis.Mode)
 	return client, nil
 }
 
@@ -127,6 +239,12 @@ func (db *DB) Close() error {
 		}
 	}
 
+	if db.Replicas != nil {
+		if err := db.Replicas.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("read replicas: %w", err))
+		}
+	}
+
 	if db.Redis != nil {
 		if err := db.Redis.Close(); err != nil {
 			errs = append(errs, fmt.Errorf("redis: %w", err))
@@ -143,14 +261,8 @@ func (db *DB) Close() error {
 
 func (db *DB) HealthCheckDB(ctx context.Context) error {
 	// Check PostgreSQL
-	if db.PostgreSQL != nil {
-		sqlDB, err := db.PostgreSQL.DB()
-		if err != nil {
-			return fmt.Errorf("PostgreSQL health check failed: %w", err)
-		}
-		if err := sqlDB.PingContext(ctx); err != nil {
-			return fmt.Errorf("PostgreSQL ping failed: %w", err)
-		}
+	if err := db.HealthCheckPostgres(ctx); err != nil {
+		return err
 	}
 
 	// Check Redis
@@ -163,6 +275,21 @@ func (db *DB) HealthCheckDB(ctx context.Context) error {
 	return nil
 }
 
+// HealthCheckPostgres pings PostgreSQL only, for callers that can run without Redis
+func (db *DB) HealthCheckPostgres(ctx context.Context) error {
+	if db.PostgreSQL == nil {
+		return nil
+	}
+	sqlDB, err := db.PostgreSQL.DB()
+	if err != nil {
+		return fmt.Errorf("PostgreSQL health check failed: %w", err)
+	}
+	if err := sqlDB.PingContext(ctx); err != nil {
+		return fmt.Errorf("PostgreSQL ping failed: %w", err)
+	}
+	return nil
+}
+
 // BeginTx starts a new database transaction
 func (db *DB) BeginTx(ctx context.Context) *gorm.DB {
 	return db.PostgreSQL.WithContext(ctx).Begin()
@@ -174,6 +301,6 @@ func (db *DB) GetPostgreSQL() *gorm.DB {
 }
 
 // GetRedis returns the Redis client instance
-func (db *DB) GetRedis() *redis.Client {
+func (db *DB) GetRedis() redis.UniversalClient {
 	return db.Redis
 }
@@ -0,0 +1,195 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"sync"
+	"time"
+
+	"evently/internal/shared/config"
+	"evently/pkg/metrics"
+)
+
+// PoolStatus is the connection pool state reported by the health check
+type PoolStatus struct {
+	MaxOpen        int    `json:"max_open"`
+	Open           int    `json:"open"`
+	InUse          int    `json:"in_use"`
+	Idle           int    `json:"idle"`
+	WaitCount      int64  `json:"wait_count"`       // Queries that had to wait for a connection, since startup
+	WaitDurationMs int64  `json:"wait_duration_ms"` // Total time spent waiting
+	Breaker        string `json:"breaker"`          // "closed", or "open" while booking requests are shed
+}
+
+// PoolBreaker sheds booking requests while the Postgres pool is saturated, so
+// they fail fast with a retryable 503 instead of queueing for a connection
+// until they time out. The pool is sampled on an interval: it trips after
+// TripAfter saturated samples in a row, stays open for at least OpenFor, and
+// closes on the first sample after that which finds the pool has drained.
+type PoolBreaker struct {
+	db     *sql.DB
+	config config.PoolBreakerConfig
+	done   chan struct{}
+
+	mu            sync.RWMutex
+	open          bool
+	openedAt      time.Time
+	saturated     int // Consecutive saturated samples while closed
+	lastWaitCount int64
+}
+
+// NewPoolBreaker creates a new pool breaker
+func NewPoolBreaker(db *sql.DB, cfg config.PoolBreakerConfig) *PoolBreaker {
+	return &PoolBreaker{
+		db:            db,
+		config:        cfg,
+		done:          make(chan struct{}),
+		lastWaitCount: db.Stats().WaitCount,
+	}
+}
+
+// Start starts the sampling loop
+func (b *PoolBreaker) Start(ctx context.Context) {
+	log.Printf("🔌 POOL BREAKER: Sampling the connection pool every %v", b.config.SampleInterval)
+	go b.run(ctx)
+}
+
+// Stop stops the sampling loop
+func (b *PoolBreaker) Stop() {
+	log.Println("🔌 POOL BREAKER: Stopping...")
+	close(b.done)
+}
+
+func (b *PoolBreaker) run(ctx context.Context) {
+	ticker := time.NewTicker(b.config.SampleInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			b.Sample()
+		case <-b.done:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Sample reads the pool stats once and updates the breaker. The pool counts
+// as saturated when nearly every connection is in use and queries had to
+// wait for one since the last sample.
+func (b *PoolBreaker) Sample() {
+	stats := b.db.Stats()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	waited := stats.WaitCount > b.lastWaitCount
+	b.lastWaitCount = stats.WaitCount
+
+	saturated := waited && stats.MaxOpenConnections > 0 &&
+		float64(stats.InUse) >= float64(stats.MaxOpenConnections)*b.config.SaturationRatio
+
+	if b.open {
+		if !saturated && time.Since(b.openedAt) >= b.config.OpenFor {
+			b.open = false
+			metrics.DBPoolBreakerOpen.Set(0)
+			log.Printf("✅ POOL BREAKER: Connection pool drained (%d/%d in use), accepting bookings again",
+				stats.InUse, stats.MaxOpenConnections)
+		}
+		return
+	}
+
+	if !saturated {
+		b.saturated = 0
+		return
+	}
+
+	b.saturated++
+	if b.saturated >= b.config.TripAfter {
+		b.open = true
+		b.openedAt = time.Now()
+		b.saturated = 0
+		metrics.DBPoolBreakerOpen.Set(1)
+		log.Printf("⚠️ POOL BREAKER: Connection pool saturated (%d/%d in use, %d waits), shedding booking requests",
+			stats.InUse, stats.MaxOpenConnections, stats.WaitCount)
+	}
+}
+
+// Open reports whether requests should be shed. A nil breaker never is.
+func (b *PoolBreaker) Open() bool {
+	if b == nil {
+		return false
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.open
+}
+
+// RetryAfter is how long shed clients should wait before retrying
+func (b *PoolBreaker) RetryAfter() time.Duration {
+	return b.config.OpenFor
+}
+
+// PoolStatus returns the pool stats and breaker state for the health check
+func (db *DB) PoolStatus(breaker *PoolBreaker) *PoolStatus {
+	if db.PostgreSQL == nil {
+		return nil
+	}
+	sqlDB, err := db.PostgreSQL.DB()
+	if err != nil {
+		return nil
+	}
+
+	stats := sqlDB.Stats()
+	status := &PoolStatus{
+		MaxOpen:        stats.MaxOpenConnections,
+		Open:           stats.OpenConnections,
+		InUse:          stats.InUse,
+		Idle:           stats.Idle,
+		WaitCount:      stats.WaitCount,
+		WaitDurationMs: stats.WaitDuration.Milliseconds(),
+		Breaker:        "closed",
+	}
+	if breaker.Open() {
+		status.Breaker = "open"
+	}
+	return status
+}
+
+var poolMetricsOnce sync.Once
+
+// registerPoolMetrics exposes the pool stats on /metrics, read at scrape time
+func registerPoolMetrics(sqlDB *sql.DB) {
+	poolMetricsOnce.Do(func() { registerPoolGauges(sqlDB) })
+}
+
+func registerPoolGauges(sqlDB *sql.DB) {
+	gauges := []struct {
+		name  string
+		help  string
+		value func(sql.DBStats) float64
+	}{
+		{"evently_db_pool_max_open_connections", "Maximum number of open Postgres connections.",
+			func(s sql.DBStats) float64 { return float64(s.MaxOpenConnections) }},
+		{"evently_db_pool_open_connections", "Open Postgres connections, in use and idle.",
+			func(s sql.DBStats) float64 { return float64(s.OpenConnections) }},
+		{"evently_db_pool_in_use_connections", "Postgres connections currently in use.",
+			func(s sql.DBStats) float64 { return float64(s.InUse) }},
+		{"evently_db_pool_idle_connections", "Idle Postgres connections.",
+			func(s sql.DBStats) float64 { return float64(s.Idle) }},
+		{"evently_db_pool_wait_count", "Queries that waited for a Postgres connection since startup.",
+			func(s sql.DBStats) float64 { return float64(s.WaitCount) }},
+		{"evently_db_pool_wait_seconds", "Total time spent waiting for a Postgres connection since startup.",
+			func(s sql.DBStats) float64 { return s.WaitDuration.Seconds() }},
+	}
+
+	for _, gauge := range gauges {
+		value := gauge.value
+		metrics.Default.NewGaugeFunc(gauge.name, gauge.help, func() float64 {
+			return value(sqlDB.Stats())
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package dbresolver
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"log"
+	"net"
+	"sync/atomic"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	readKey    = "evently:read_replica" // Marks a statement as safe to serve from a replica
+	primaryKey = "evently:primary_pool" // The pool a routed statement is given back afterwards
+)
+
+// Read is a scope that sends a read-only query to a replica:
+//
+//	r.db.WithContext(ctx).Scopes(dbresolver.Read).Find(&events)
+//
+// Queries run on the primary when no replica is configured or healthy, inside
+// a transaction, and when they lock rows.
+func Read(db *gorm.DB) *gorm.DB {
+	return db.Set(readKey, true)
+}
+
+// ReadDB returns a handle whose queries all go through Read, for repositories
+// that only read
+func ReadDB(db *gorm.DB) *gorm.DB {
+	return db.Scopes(Read).Session(&gorm.Session{})
+}
+
+// Replica is one read replica connection pool
+type Replica struct {
+	Name string
+	DB   *sql.DB
+
+	healthy atomic.Bool
+}
+
+// ReplicaStatus reports whether a replica is taking reads
+type ReplicaStatus struct {
+	Name    string `json:"name"`
+	Healthy bool   `json:"healthy"`
+}
+
+// Resolver is a GORM plugin that routes queries marked with Read to healthy
+// replicas in turn. A replica whose connection fails is taken out of rotation
+// until a health check reaches it again, so reads fall back to the primary
+// rather than failing.
+type Resolver struct {
+	replicas      []*Replica
+	next          atomic.Uint64
+	checkInterval time.Duration
+	done          chan struct{}
+}
+
+// New creates a resolver for the given replicas, all assumed healthy until a
+// query or health check says otherwise
+func New(replicas []*Replica, checkInterval time.Duration) *Resolver {
+	for _, replica := range replicas {
+		replica.healthy.Store(true)
+	}
+
+	return &Resolver{
+		replicas:      replicas,
+		checkInterval: checkInterval,
+		done:          make(chan struct{}),
+	}
+}
+
+func (r *Resolver) Name() string {
+	return "evently:dbresolver"
+}
+
+// Initialize registers the routing callbacks. Find, First, Count and Pluck go
+// through the query callbacks, Raw(...).Scan and Rows through the row ones.
+func (r *Resolver) Initialize(db *gorm.DB) error {
+	if err := db.Callback().Query().Before("gorm:query").Register("evently:route_query", r.route); err != nil {
+		return err
+	}
+	if err := db.Callback().Query().After("gorm:query").Register("evently:check_query", r.checkResult); err != nil {
+		return err
+	}
+	if err := db.Callback().Row().Before("gorm:row").Register("evently:route_row", r.route); err != nil {
+		return err
+	}
+	return db.Callback().Row().After("gorm:row").Register("evently:check_row", r.checkResult)
+}
+
+func (r *Resolver) route(db *gorm.DB) {
+	if db.Error != nil {
+		return
+	}
+	if read, ok := db.Get(readKey); !ok || read != true {
+		return
+	}
+
+	// Reads in a transaction must see its writes
+	if _, inTx := db.Statement.ConnPool.(gorm.TxCommitter); inTx {
+		return
+	}
+	// Locking reads only make sense on the primary
+	if _, locking := db.Statement.Clauses["FOR"]; locking {
+		return
+	}
+
+	if replica := r.pick(); replica != nil {
+		db.InstanceSet(primaryKey, db.Statement.ConnPool)
+		db.Statement.ConnPool = replica.DB
+	}
+}
+
+// checkResult gives the statement its primary pool back, so a chain reused
+// for a later write does not end up on the replica, and takes the replica out
+// of rotation when its connection failed
+func (r *Resolver) checkResult(db *gorm.DB) {
+	primary, routed := db.InstanceGet(primaryKey)
+	if !routed {
+		return
+	}
+	replicaPool := db.Statement.ConnPool
+	db.Statement.ConnPool = primary.(gorm.ConnPool)
+
+	if db.Error == nil || !isConnectionError(db.Error) {
+		return
+	}
+	for _, replica := range r.replicas {
+		if replicaPool == replica.DB {
+			r.markUnhealthy(replica, db.Error)
+			return
+		}
+	}
+}
+
+// pick returns the next healthy replica, or nil to use the primary
+func (r *Resolver) pick() *Replica {
+	count := len(r.replicas)
+	if count == 0 {
+		return nil
+	}
+
+	start := r.next.Add(1)
+	for i := 0; i < count; i++ {
+		replica := r.replicas[(start+uint64(i))%uint64(count)]
+		if replica.healthy.Load() {
+			return replica
+		}
+	}
+	return nil
+}
+
+func (r *Resolver) markUnhealthy(replica *Replica, err error) {
+	if replica.healthy.CompareAndSwap(true, false) {
+		log.Printf("⚠️ DB REPLICA: %s taken out of rotation, reads fall back to the primary: %v", replica.Name, err)
+	}
+}
+
+// Status reports the health of every replica
+func (r *Resolver) Status() []ReplicaStatus {
+	statuses := make([]ReplicaStatus, len(r.replicas))
+	for i, replica := range r.replicas {
+		statuses[i] = ReplicaStatus{Name: replica.Name, Healthy: replica.healthy.Load()}
+	}
+	return statuses
+}
+
+// Start starts the replica health checks
+func (r *Resolver) Start(ctx context.Context) {
+	log.Printf("🩺 DB REPLICA: Checking %d replicas every %v", len(r.replicas), r.checkInterval)
+	go r.run(ctx)
+}
+
+// Stop stops the replica health checks
+func (r *Resolver) Stop() {
+	log.Println("🩺 DB REPLICA: Stopping health checks...")
+	close(r.done)
+}
+
+func (r *Resolver) run(ctx context.Context) {
+	ticker := time.NewTicker(r.checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			r.CheckReplicas(ctx)
+		case <-r.done:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// CheckReplicas pings every replica and puts reachable ones back in rotation
+func (r *Resolver) CheckReplicas(ctx context.Context) {
+	for _, replica := range r.replicas {
+		pingCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+		err := replica.DB.PingContext(pingCtx)
+		cancel()
+
+		if err != nil {
+			r.markUnhealthy(replica, err)
+			continue
+		}
+		if replica.healthy.CompareAndSwap(false, true) {
+			log.Printf("✅ DB REPLICA: %s is back in rotation", replica.Name)
+		}
+	}
+}
+
+// Close closes the replica connection pools
+func (r *Resolver) Close() error {
+	var errs []error
+	for _, replica := range r.replicas {
+		if err := replica.DB.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func isConnectionError(err error) bool {
+	var netErr net.Error
+	return errors.Is(err, driver.ErrBadConn) || errors.As(err, &netErr)
+}
@@ -0,0 +1,130 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"evently/internal/shared/utils/response"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// ImpersonatedByHeader is set on every response to a request made with an
+// impersonation token, for clients that don't read the response envelope
+const ImpersonatedByHeader = "X-Impersonated-By"
+
+// ImpersonatedAction is one request an admin made while acting as a user
+type ImpersonatedAction struct {
+	SessionID      string
+	ImpersonatorID string
+	UserID         string
+	Method         string
+	Path           string
+	Route          string // Route pattern, e.g. /api/v1/bookings/:id
+	StatusCode     int
+	RequestIP      string
+	Duration       time.Duration
+}
+
+// ImpersonationAuditor checks impersonation sessions are still open and keeps
+// an audit trail of the requests made in them
+type ImpersonationAuditor interface {
+	SessionActive(ctx context.Context, sessionID string) (bool, error)
+	RecordAction(ctx context.Context, action ImpersonatedAction)
+}
+
+var (
+	impersonationMu      sync.RWMutex
+	impersonationAuditor ImpersonationAuditor
+)
+
+// SetImpersonationAuditor enables impersonation. Until it is called, requests
+// carrying an impersonation token are rejected.
+func SetImpersonationAuditor(auditor ImpersonationAuditor) {
+	impersonationMu.Lock()
+	defer impersonationMu.Unlock()
+	impersonationAuditor = auditor
+}
+
+// Impersonating reports whether the request was made by an admin acting as the user
+func Impersonating(c *gin.Context) bool {
+	return c.GetString("impersonation_id") != ""
+}
+
+// DenyImpersonation keeps impersonating admins away from account security
+// routes such as password, email and two-factor changes
+func DenyImpersonation() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if Impersonating(c) {
+			response.RespondJSON(c, "error", http.StatusForbidden, "Not allowed while impersonating a user", nil, nil)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// beginImpersonation checks the session of an impersonation token and flags
+// the request and its response. It returns the auditor the finished request
+// is recorded with, nil for ordinary tokens, and false when the session is
+// closed or can't be checked.
+func beginImpersonation(c *gin.Context, claims jwt.MapClaims) (ImpersonationAuditor, bool) {
+	sessionID, _ := claims["impersonation_id"].(string)
+	if sessionID == "" {
+		return nil, true
+	}
+	impersonatorID, _ := claims["impersonator_id"].(string)
+
+	impersonationMu.RLock()
+	auditor := impersonationAuditor
+	impersonationMu.RUnlock()
+	if auditor == nil {
+		return nil, false
+	}
+
+	active, err := auditor.SessionActive(c.Request.Context(), sessionID)
+	if err != nil || !active {
+		return nil, false
+	}
+
+	var expiresAt time.Time
+	if exp, ok := claims["exp"].(float64); ok {
+		expiresAt = time.Unix(int64(exp), 0).UTC()
+	}
+
+	c.Set("impersonation_id", sessionID)
+	c.Set("impersonator_id", impersonatorID)
+	c.Set(response.ImpersonationKey, &response.ImpersonationBanner{
+		Active:         true,
+		ImpersonatorID: impersonatorID,
+		SessionID:      sessionID,
+		ExpiresAt:      expiresAt,
+		Message:        "You are viewing this account as a support admin. Every action is recorded.",
+	})
+	c.Header(ImpersonatedByHeader, impersonatorID)
+
+	// A route can pass through authentication twice, the request is recorded once
+	if c.GetBool("impersonation_audited") {
+		return nil, true
+	}
+	c.Set("impersonation_audited", true)
+	return auditor, true
+}
+
+// recordImpersonation adds a finished request to the audit trail of its session
+func recordImpersonation(c *gin.Context, auditor ImpersonationAuditor, started time.Time) {
+	auditor.RecordAction(context.WithoutCancel(c.Request.Context()), ImpersonatedAction{
+		SessionID:      c.GetString("impersonation_id"),
+		ImpersonatorID: c.GetString("impersonator_id"),
+		UserID:         c.GetString("user_id"),
+		Method:         c.Request.Method,
+		Path:           c.Request.URL.Path,
+		Route:          c.FullPath(),
+		StatusCode:     c.Writer.Status(),
+		RequestIP:      c.ClientIP(),
+		Duration:       time.Since(started),
+	})
+}
@@ -1,10 +1,11 @@
 package middleware
 
 import (
+	"errors"
 	"fmt"
-	"log"
 	"net/http"
 	"strings"
+	"time"
 
 	"evently/internal/shared/config"
 	"evently/internal/shared/utils/response"
@@ -24,14 +25,14 @@ func JWTAuthWithConfig(cfg *config.Config) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
-			response.RespondJSON(c, "error", http.StatusUnauthorized, "Authorization header is required", nil, nil)
+			response.RespondError(c, http.StatusUnauthorized, response.CodeMissingToken, "Authorization header is required", nil)
 			c.Abort()
 			return
 		}
 
 		parts := strings.SplitN(authHeader, " ", 2)
 		if len(parts) != 2 || parts[0] != "Bearer" {
-			response.RespondJSON(c, "error", http.StatusUnauthorized, "authorization header format must be Bearer {token}", nil, nil)
+			response.RespondError(c, http.StatusUnauthorized, response.CodeInvalidToken, "authorization header format must be Bearer {token}", nil)
 			c.Abort()
 			return
 		}
@@ -45,31 +46,118 @@ func JWTAuthWithConfig(cfg *config.Config) gin.HandlerFunc {
 			return []byte(cfg.JWT.Secret), nil
 		})
 
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			response.RespondError(c, http.StatusUnauthorized, response.CodeTokenExpired, "token has expired", nil)
+			c.Abort()
+			return
+		}
 		if err != nil || !token.Valid {
-			response.RespondJSON(c, "error", http.StatusUnauthorized, "invalid or expired token", nil, nil)
+			response.RespondError(c, http.StatusUnauthorized, response.CodeInvalidToken, "invalid token", nil)
 			c.Abort()
 			return
 		}
 
 		if claims, ok := token.Claims.(jwt.MapClaims); ok {
 			if tokenType, ok := claims["type"]; !ok || tokenType != "access" {
-				response.RespondJSON(c, "error", http.StatusUnauthorized, "invalid token type", nil, nil)
+				response.RespondError(c, http.StatusUnauthorized, response.CodeInvalidToken, "invalid token type", nil)
 				c.Abort()
 				return
 			}
-			log.Println("JWT claims:", claims)
 			c.Set("user_id", claims["user_id"])
 			c.Set("user_email", claims["email"])
 			c.Set("user_role", claims["role"])
+			c.Set("mfa_verified", claims["mfa"] == true)
+
+			auditor, ok := beginImpersonation(c, claims)
+			if !ok {
+				response.RespondError(c, http.StatusUnauthorized, response.CodeTokenRevoked, "impersonation session has ended", nil)
+				c.Abort()
+				return
+			}
+			if auditor != nil {
+				started := time.Now()
+				c.Next()
+				recordImpersonation(c, auditor, started)
+				return
+			}
 		}
 
 		c.Next()
 	}
 }
 
+// OptionalJWTAuth identifies the caller when a valid access token is sent but
+// lets anonymous requests (and bad tokens) through without user context
+func OptionalJWTAuth() gin.HandlerFunc {
+	cfg := config.Load()
+	return func(c *gin.Context) {
+		if claims, ok := accessClaims(c, cfg); ok {
+			// A closed impersonation session browses anonymously
+			if auditor, ok := beginImpersonation(c, claims); ok {
+				c.Set("user_id", claims["user_id"])
+				c.Set("user_email", claims["email"])
+				c.Set("user_role", claims["role"])
+				c.Set("mfa_verified", claims["mfa"] == true)
+
+				if auditor != nil {
+					started := time.Now()
+					c.Next()
+					recordImpersonation(c, auditor, started)
+					return
+				}
+			}
+		}
+
+		c.Next()
+	}
+}
+
+// BearerUserID returns a function that reads the user ID from a valid access
+// token without touching the gin context, for middleware that runs before auth
+func BearerUserID(cfg *config.Config) func(c *gin.Context) string {
+	return func(c *gin.Context) string {
+		claims, ok := accessClaims(c, cfg)
+		if !ok {
+			return ""
+		}
+		userID, _ := claims["user_id"].(string)
+		return userID
+	}
+}
+
+// accessClaims parses the bearer token of a request, if it is a valid access token
+func accessClaims(c *gin.Context, cfg *config.Config) (jwt.MapClaims, bool) {
+	parts := strings.SplitN(c.GetHeader("Authorization"), " ", 2)
+	if len(parts) != 2 || parts[0] != "Bearer" {
+		return nil, false
+	}
+
+	token, err := jwt.Parse(parts[1], func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, jwt.ErrSignatureInvalid
+		}
+		return []byte(cfg.JWT.Secret), nil
+	})
+	if err != nil || !token.Valid {
+		return nil, false
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || claims["type"] != "access" {
+		return nil, false
+	}
+	return claims, true
+}
+
 // checks if user has required role
 func RequireRole(requiredRole string) gin.HandlerFunc {
+	requireTwoFactor := adminOnly(requiredRole) && config.Load().TwoFactor.RequireForAdmins
 	return func(c *gin.Context) {
+		if apiKeyAuthenticated(c) && adminOnly(requiredRole) {
+			refuseAPIKey(c)
+			return
+		}
+
 		userRole, exists := c.Get("user_role")
 		if !exists {
 			response.RespondJSON(c, "error", http.StatusUnauthorized, "user role not found in context", nil, nil)
@@ -82,6 +170,9 @@ func RequireRole(requiredRole string) gin.HandlerFunc {
 			c.Abort()
 			return
 		}
+		if requireTwoFactor && !twoFactorVerified(c) {
+			return
+		}
 		fmt.Print("userRole:", userRole)
 		c.Next()
 	}
@@ -93,7 +184,13 @@ func RequireAdmin() gin.HandlerFunc {
 
 // checks if user has any of the required roles
 func RequireRoles(requiredRoles ...string) gin.HandlerFunc {
+	requireTwoFactor := adminOnly(requiredRoles...) && config.Load().TwoFactor.RequireForAdmins
 	return func(c *gin.Context) {
+		if apiKeyAuthenticated(c) && adminOnly(requiredRoles...) {
+			refuseAPIKey(c)
+			return
+		}
+
 		userRole, exists := c.Get("user_role")
 		if !exists {
 			response.RespondJSON(c, "error", http.StatusUnauthorized, "user role not found in context", nil, nil)
@@ -114,7 +211,31 @@ func RequireRoles(requiredRoles ...string) gin.HandlerFunc {
 			c.Abort()
 			return
 		}
+		if requireTwoFactor && !twoFactorVerified(c) {
+			return
+		}
 
 		c.Next()
 	}
 }
+
+// adminOnly reports whether a route is restricted to admins
+func adminOnly(roles ...string) bool {
+	for _, role := range roles {
+		if role != string(users.RoleAdmin) {
+			return false
+		}
+	}
+	return len(roles) > 0
+}
+
+// twoFactorVerified rejects sessions that did not pass two-factor verification.
+// Admins without 2FA can still reach /auth/2fa/* to enroll.
+func twoFactorVerified(c *gin.Context) bool {
+	if c.GetBool("mfa_verified") {
+		return true
+	}
+	response.RespondError(c, http.StatusForbidden, response.CodeTwoFactorRequired, "Two-factor authentication required", "enroll at /auth/2fa/enroll and sign in again")
+	c.Abort()
+	return false
+}
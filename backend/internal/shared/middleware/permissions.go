@@ -0,0 +1,154 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"evently/internal/shared/config"
+	"evently/internal/shared/utils/response"
+	"evently/internal/users"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Permissions guard the admin API. Admins hold every permission; other users
+// get the permissions of the custom roles assigned to them.
+const (
+	PermissionEventsWrite         = "events:write"         // Events, series, tags, promotions and cancellation policies
+	PermissionVenuesManage        = "venues:manage"        // Venue templates, physical venues, sections, seats and ticket types
+	PermissionBookingsManage      = "bookings:manage"      // Admin booking console, waitlists, resale payouts and late cancellations
+	PermissionAnalyticsRead       = "analytics:read"       // Admin analytics reports and share links
+	PermissionReviewsModerate     = "reviews:moderate"     // Hide and restore reviews
+	PermissionSupportManage       = "support:manage"       // Support tickets
+	PermissionNotificationsManage = "notifications:manage" // Notification templates, branding and yearly recap runs
+	PermissionArchiveManage       = "archive:manage"       // Archived events and soft-deleted records
+	PermissionUsersManage         = "users:manage"         // Delete user accounts
+	PermissionUsersImpersonate    = "users:impersonate"    // Act as a user to see what they see
+	PermissionSystemManage        = "system:manage"        // API keys, webhooks, rate limits and caches
+	PermissionPermissionsManage   = "permissions:manage"   // Custom roles and who holds them
+)
+
+// PermissionInfo describes a permission for the role management API
+type PermissionInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// Permissions lists every permission a custom role can grant
+var Permissions = []PermissionInfo{
+	{PermissionEventsWrite, "Create and manage events, series, tags, promotions and cancellation policies"},
+	{PermissionVenuesManage, "Manage venue templates, physical venues, sections, seats and ticket types"},
+	{PermissionBookingsManage, "Use the admin booking console, manage waitlists, record resale payouts and review late cancellations"},
+	{PermissionAnalyticsRead, "Read admin analytics reports"},
+	{PermissionReviewsModerate, "Moderate reviews"},
+	{PermissionSupportManage, "Answer and resolve support tickets"},
+	{PermissionNotificationsManage, "Edit notification templates and branding"},
+	{PermissionArchiveManage, "Archive, restore and purge events and soft-deleted records"},
+	{PermissionUsersManage, "Delete user accounts"},
+	{PermissionUsersImpersonate, "Sign in as a user for support, with every action recorded"},
+	{PermissionSystemManage, "Manage API keys, webhooks, rate limits and caches"},
+	{PermissionPermissionsManage, "Create custom roles and assign them to users"},
+}
+
+// PermissionNames returns the name of every permission
+func PermissionNames() []string {
+	names := make([]string, len(Permissions))
+	for i, p := range Permissions {
+		names[i] = p.Name
+	}
+	return names
+}
+
+// PermissionResolver returns the permissions granted to a user by their custom roles
+type PermissionResolver interface {
+	UserPermissions(ctx context.Context, userID string) ([]string, error)
+}
+
+var (
+	permissionMu       sync.RWMutex
+	permissionResolver PermissionResolver
+)
+
+// SetPermissionResolver enables custom roles. Until it is called, only admins
+// pass permission checks.
+func SetPermissionResolver(resolver PermissionResolver) {
+	permissionMu.Lock()
+	defer permissionMu.Unlock()
+	permissionResolver = resolver
+}
+
+// scopePermissions are the permissions an API key scope grants. Other scopes
+// grant none, so a key only reaches the admin routes its scope was made for.
+var scopePermissions = map[string]string{
+	ScopeAnalyticsRead: PermissionAnalyticsRead,
+}
+
+// RequirePermission lets admins and users holding permission through. Like
+// admins, users reaching a route through a custom role must have passed
+// two-factor verification when it is required for admins. API keys pass when
+// the scope they were authenticated with grants permission.
+func RequirePermission(permission string) gin.HandlerFunc {
+	requireTwoFactor := config.Load().TwoFactor.RequireForAdmins
+	return func(c *gin.Context) {
+		if apiKeyAuthenticated(c) {
+			if scopePermissions[c.GetString("api_key_scope")] != permission {
+				response.RespondJSON(c, "error", http.StatusForbidden, ErrAPIKeyScope.Error(), nil, map[string]interface{}{"required_permission": permission})
+				c.Abort()
+				return
+			}
+			c.Next()
+			return
+		}
+
+		userRole, exists := c.Get("user_role")
+		if !exists {
+			response.RespondJSON(c, "error", http.StatusUnauthorized, "user role not found in context", nil, nil)
+			c.Abort()
+			return
+		}
+
+		if role, _ := userRole.(string); role != string(users.RoleAdmin) {
+			allowed, err := hasPermission(c, permission)
+			if err != nil {
+				response.RespondJSON(c, "error", http.StatusInternalServerError, "Failed to check permissions", nil, err.Error())
+				c.Abort()
+				return
+			}
+			if !allowed {
+				response.RespondJSON(c, "error", http.StatusForbidden, "Insufficient permissions", nil, map[string]interface{}{"required_permission": permission})
+				c.Abort()
+				return
+			}
+		}
+		if requireTwoFactor && !twoFactorVerified(c) {
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// hasPermission asks the resolver whether the caller's custom roles grant permission
+func hasPermission(c *gin.Context, permission string) (bool, error) {
+	permissionMu.RLock()
+	resolver := permissionResolver
+	permissionMu.RUnlock()
+
+	// An impersonating admin only gets what the user could do themselves
+	userID := c.GetString("user_id")
+	if resolver == nil || userID == "" || Impersonating(c) {
+		return false, nil
+	}
+
+	granted, err := resolver.UserPermissions(c.Request.Context(), userID)
+	if err != nil {
+		return false, err
+	}
+	for _, p := range granted {
+		if p == permission {
+			return true, nil
+		}
+	}
+	return false, nil
+}
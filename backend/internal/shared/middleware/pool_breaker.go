@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"evently/internal/shared/utils/response"
+	"evently/pkg/metrics"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LoadShedder reports whether requests should be turned away while a backing
+// resource is saturated, and for how long clients should back off
+type LoadShedder interface {
+	Open() bool
+	RetryAfter() time.Duration
+}
+
+// ShedWhenSaturated answers 503 with Retry-After while the shedder is open, so
+// clients back off instead of waiting on a saturated connection pool until
+// they time out
+func ShedWhenSaturated(shedder LoadShedder) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !shedder.Open() {
+			c.Next()
+			return
+		}
+
+		metrics.DBPoolRejectionsTotal.Inc(c.FullPath())
+		c.Header("Retry-After", fmt.Sprintf("%d", retryAfterSeconds(shedder.RetryAfter())))
+		response.RespondJSON(c, "error", http.StatusServiceUnavailable,
+			"Booking service is busy, please retry shortly", nil, nil)
+		c.Abort()
+	}
+}
+
+func retryAfterSeconds(d time.Duration) int {
+	secs := int((d + time.Second - 1) / time.Second)
+	if secs < 1 {
+		return 1
+	}
+	return secs
+}
@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"evently/internal/shared/utils/response"
+	"evently/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// RequestID gives every request an ID: the caller's X-Request-ID when it is
+// well formed, a new UUID otherwise. The ID is echoed in the X-Request-ID
+// header and the response envelope, and carried by the request context so
+// that logs and notifications written for the request can be traced back to it.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := response.RequestID(c)
+		if requestID == "" {
+			requestID = uuid.New().String()
+		}
+
+		c.Set(response.RequestIDKey, requestID)
+		c.Header(response.RequestIDHeader, requestID)
+		c.Request = c.Request.WithContext(logger.ContextWithRequestID(c.Request.Context(), requestID))
+		c.Next()
+	}
+}
@@ -0,0 +1,325 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// The version table matches golang-migrate's, a single row holding the current
+// version and whether a migration failed part way
+const versionTable = "schema_migrations"
+
+// Serializes migrators across instances starting at the same time
+const lockID = 7436529150182467
+
+const noTransactionDirective = "-- migrate:no-transaction"
+
+var fileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+var (
+	ErrDirty        = errors.New("schema is dirty: a migration failed part way, fix it by hand and run `migrate force <version>`")
+	ErrSchemaBehind = errors.New("schema is behind this binary: run `migrate up`")
+	ErrSchemaAhead  = errors.New("schema is ahead of this binary: deploy a newer release or run `migrate down`")
+)
+
+// Migration is one versioned schema change
+type Migration struct {
+	Version       uint
+	Name          string
+	Up            string
+	Down          string
+	NoTransaction bool
+}
+
+// MigrationStatus reports whether a migration has been applied
+type MigrationStatus struct {
+	Version uint   `json:"version"`
+	Name    string `json:"name"`
+	Applied bool   `json:"applied"`
+}
+
+// Load reads the migrations in a directory of .up.sql and .down.sql files,
+// ordered by version
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	byVersion := make(map[uint]*Migration)
+	for _, entry := range entries {
+		match := fileName.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+
+		version, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil || version == 0 {
+			return nil, fmt.Errorf("invalid migration version in %s", entry.Name())
+		}
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", entry.Name(), err)
+		}
+
+		migration, ok := byVersion[uint(version)]
+		if !ok {
+			migration = &Migration{Version: uint(version), Name: match[2]}
+			byVersion[uint(version)] = migration
+		} else if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has two names: %s and %s", version, migration.Name, match[2])
+		}
+
+		sqlText := string(content)
+		if match[3] == "up" {
+			migration.Up = sqlText
+			migration.NoTransaction = strings.HasPrefix(strings.TrimSpace(sqlText), noTransactionDirective)
+		} else {
+			migration.Down = sqlText
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up file", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Migrator applies migrations to a Postgres database
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+}
+
+// New creates a migrator for the migrations in fsys
+func New(db *sql.DB, fsys fs.FS) (*Migrator, error) {
+	migrations, err := Load(fsys)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// Expected returns the version this binary's migrations bring the schema to
+func (m *Migrator) Expected() uint {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Version returns the schema's current version, 0 before any migration
+func (m *Migrator) Version(ctx context.Context) (uint, bool, error) {
+	if err := m.ensureVersionTable(ctx, m.db); err != nil {
+		return 0, false, err
+	}
+	return readVersion(ctx, m.db)
+}
+
+// Validate checks that the schema is at exactly the version this binary expects
+func (m *Migrator) Validate(ctx context.Context) error {
+	version, dirty, err := m.Version(ctx)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case dirty:
+		return fmt.Errorf("%w (version %d)", ErrDirty, version)
+	case version < m.Expected():
+		return fmt.Errorf("%w (schema %d, expected %d)", ErrSchemaBehind, version, m.Expected())
+	case version > m.Expected():
+		return fmt.Errorf("%w (schema %d, expected %d)", ErrSchemaAhead, version, m.Expected())
+	}
+	return nil
+}
+
+// Status lists every migration and whether it has been applied
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	version, _, err := m.Version(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, len(m.migrations))
+	for i, migration := range m.migrations {
+		statuses[i] = MigrationStatus{
+			Version: migration.Version,
+			Name:    migration.Name,
+			Applied: migration.Version <= version,
+		}
+	}
+	return statuses, nil
+}
+
+// Up applies every pending migration and returns how many ran
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	applied := 0
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		version, dirty, err := readVersion(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if dirty {
+			return fmt.Errorf("%w (version %d)", ErrDirty, version)
+		}
+
+		for _, migration := range m.migrations {
+			if migration.Version <= version {
+				continue
+			}
+			log.Printf("🗄️ MIGRATE: Applying %d_%s", migration.Version, migration.Name)
+			if err := m.run(ctx, conn, migration.Up, migration.NoTransaction, migration.Version); err != nil {
+				return fmt.Errorf("migration %d_%s failed: %w", migration.Version, migration.Name, err)
+			}
+			applied++
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down reverts the given number of applied migrations, newest first, and
+// returns how many were reverted
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	reverted := 0
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		version, dirty, err := readVersion(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if dirty {
+			return fmt.Errorf("%w (version %d)", ErrDirty, version)
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && reverted < steps; i-- {
+			migration := m.migrations[i]
+			if migration.Version > version {
+				continue
+			}
+			if migration.Down == "" {
+				return fmt.Errorf("migration %d_%s can't be reverted: it has no down file", migration.Version, migration.Name)
+			}
+
+			previous := uint(0)
+			if i > 0 {
+				previous = m.migrations[i-1].Version
+			}
+			log.Printf("🗄️ MIGRATE: Reverting %d_%s", migration.Version, migration.Name)
+			if err := m.run(ctx, conn, migration.Down, migration.NoTransaction, previous); err != nil {
+				return fmt.Errorf("reverting %d_%s failed: %w", migration.Version, migration.Name, err)
+			}
+			reverted++
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// Force sets the schema version without running anything and clears the
+// dirty flag, to adopt an existing database or recover from a failed migration
+func (m *Migrator) Force(ctx context.Context, version uint) error {
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		return writeVersion(ctx, conn, version, false)
+	})
+}
+
+// run executes a migration and records the version it leaves the schema at.
+// Transactional migrations commit both together. Others mark the schema dirty
+// first, so a failure part way is caught at the next start.
+func (m *Migrator) run(ctx context.Context, conn *sql.Conn, statements string, noTransaction bool, version uint) error {
+	if noTransaction {
+		if err := writeVersion(ctx, conn, version, true); err != nil {
+			return err
+		}
+		if _, err := conn.ExecContext(ctx, statements); err != nil {
+			return err
+		}
+		return writeVersion(ctx, conn, version, false)
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, statements); err != nil {
+		return err
+	}
+	if err := writeVersion(ctx, tx, version, false); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// withLock runs fn on one connection holding the migration advisory lock
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", lockID); err != nil {
+		return fmt.Errorf("failed to take migration lock: %w", err)
+	}
+	defer conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", lockID)
+
+	if err := m.ensureVersionTable(ctx, conn); err != nil {
+		return err
+	}
+	return fn(conn)
+}
+
+type execQuerier interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+func (m *Migrator) ensureVersionTable(ctx context.Context, db execQuerier) error {
+	_, err := db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS `+versionTable+` (version bigint NOT NULL PRIMARY KEY, dirty boolean NOT NULL)`)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", versionTable, err)
+	}
+	return nil
+}
+
+func readVersion(ctx context.Context, db execQuerier) (uint, bool, error) {
+	var version int64
+	var dirty bool
+	err := db.QueryRowContext(ctx, `SELECT version, dirty FROM `+versionTable+` LIMIT 1`).Scan(&version, &dirty)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	return uint(version), dirty, nil
+}
+
+func writeVersion(ctx context.Context, db execQuerier, version uint, dirty bool) error {
+	if _, err := db.ExecContext(ctx, `DELETE FROM `+versionTable); err != nil {
+		return fmt.Errorf("failed to write schema version: %w", err)
+	}
+	if version == 0 && !dirty {
+		return nil
+	}
+	if _, err := db.ExecContext(ctx, `INSERT INTO `+versionTable+` (version, dirty) VALUES ($1, $2)`, int64(version), dirty); err != nil {
+		return fmt.Errorf("failed to write schema version: %w", err)
+	}
+	return nil
+}
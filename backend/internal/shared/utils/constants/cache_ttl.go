@@ -0,0 +1,100 @@
+package constants
+
+import (
+	"sort"
+	"time"
+)
+
+// cacheTTLs maps config names (the TTL_ variable name without its prefix) to the
+// overridable cache TTLs, e.g. CACHE_TTL_EVENT_DETAIL=30m sets TTL_EVENT_DETAIL
+var cacheTTLs = map[string]*time.Duration{
+	"EVENT_LIST":          &TTL_EVENT_LIST,
+	"EVENT_UPCOMING":      &TTL_EVENT_UPCOMING,
+	"EVENT_DETAIL":        &TTL_EVENT_DETAIL,
+	"EVENT_SEARCH":        &TTL_EVENT_SEARCH,
+	"EVENT_PROMOTIONS":    &TTL_EVENT_PROMOTIONS,
+	"TAGS_ACTIVE":         &TTL_TAGS_ACTIVE,
+	"TAGS_LIST":           &TTL_TAGS_LIST,
+	"TAG_DETAIL":          &TTL_TAG_DETAIL,
+	"VENUE_TEMPLATES":     &TTL_VENUE_TEMPLATES,
+	"VENUE_TEMPLATE":      &TTL_VENUE_TEMPLATE,
+	"VENUE_SECTIONS":      &TTL_VENUE_SECTIONS,
+	"VENUE_LAYOUT":        &TTL_VENUE_LAYOUT,
+	"SEATS_BY_SECTION":    &TTL_SEATS_BY_SECTION,
+	"SEATS_AVAILABLE":     &TTL_SEATS_AVAILABLE,
+	"SEAT_DETAIL":         &TTL_SEAT_DETAIL,
+	"SEAT_AVAILABILITY":   &TTL_SEAT_AVAILABILITY,
+	"ANALYTICS_DASHBOARD": &TTL_ANALYTICS_DASHBOARD,
+	"ANALYTICS_EVENT":     &TTL_ANALYTICS_EVENT,
+	"ANALYTICS_TAGS":      &TTL_ANALYTICS_TAGS,
+	"ANALYTICS_BOOKINGS":  &TTL_ANALYTICS_BOOKINGS,
+	"ANALYTICS_USERS":     &TTL_ANALYTICS_USERS,
+	"ANALYTICS_PERSONAL":  &TTL_ANALYTICS_PERSONAL,
+	"USER_PROFILE":        &TTL_USER_PROFILE,
+	"USER_ROLES":          &TTL_USER_ROLES,
+	"USER_BOOKINGS":       &TTL_USER_BOOKINGS,
+	"BOOKING_DETAIL":      &TTL_BOOKING_DETAIL,
+	"WAITLIST_STATUS":     &TTL_WAITLIST_STATUS,
+	"WAITLIST_STATS":      &TTL_WAITLIST_STATS,
+	"WAITLIST_POSITION":   &TTL_WAITLIST_POSITION,
+	"CANCELLATION_POLICY": &TTL_CANCELLATION_POLICY,
+	"USER_CANCELLATIONS":  &TTL_USER_CANCELLATIONS,
+	"CANCELLATION_DETAIL": &TTL_CANCELLATION_DETAIL,
+	"BRANDING_EVENT":      &TTL_BRANDING_EVENT,
+	"REVIEW_SUMMARY":      &TTL_REVIEW_SUMMARY,
+}
+
+// cacheTTLDefaults remembers the compiled-in values so the startup table can flag overrides
+var cacheTTLDefaults = snapshotCacheTTLs()
+
+// CacheTTLEntry is one row of the effective cache TTL table
+type CacheTTLEntry struct {
+	Name       string
+	TTL        time.Duration
+	Default    time.Duration
+	Overridden bool
+}
+
+func snapshotCacheTTLs() map[string]time.Duration {
+	snapshot := make(map[string]time.Duration, len(cacheTTLs))
+	for name, ttl := range cacheTTLs {
+		snapshot[name] = *ttl
+	}
+	return snapshot
+}
+
+// ApplyCacheTTLOverrides replaces TTL defaults with configured values. It must run
+// at startup before any service caches data. Unknown or non-positive overrides are
+// skipped and returned so the caller can warn about them.
+func ApplyCacheTTLOverrides(overrides map[string]time.Duration) []string {
+	var rejected []string
+	for name, value := range overrides {
+		ttl, ok := cacheTTLs[name]
+		if !ok || value <= 0 {
+			rejected = append(rejected, name)
+			continue
+		}
+		*ttl = value
+	}
+
+	sort.Strings(rejected)
+	return rejected
+}
+
+// CacheTTLTable returns the effective cache TTLs sorted by name
+func CacheTTLTable() []CacheTTLEntry {
+	entries := make([]CacheTTLEntry, 0, len(cacheTTLs))
+	for name, ttl := range cacheTTLs {
+		entries = append(entries, CacheTTLEntry{
+			Name:       name,
+			TTL:        *ttl,
+			Default:    cacheTTLDefaults[name],
+			Overridden: *ttl != cacheTTLDefaults[name],
+		})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+	return entries
+}
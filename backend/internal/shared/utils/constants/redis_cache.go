@@ -44,25 +44,42 @@ const (
 // Event Cache Keys
 const (
 	// Event listings and searches
-	CACHE_KEY_EVENTS_LIST     = CACHE_PREFIX + ":events:list"     // + :page:X:limit:Y:status:Z
-	CACHE_KEY_EVENTS_UPCOMING = CACHE_PREFIX + ":events:upcoming" // + :page:X:limit:Y
+	CACHE_KEY_EVENTS_LIST     = CACHE_PREFIX + ":events:list"     // + :page:X:limit:Y:status:Z, filtered lists add :f:<filter hash> first
+	CACHE_KEY_EVENTS_UPCOMING = CACHE_PREFIX + ":events:upcoming" // + :window
 	CACHE_KEY_EVENTS_BY_TAG   = CACHE_PREFIX + ":events:by_tag"   // + :slug:X:page:Y
 	CACHE_KEY_EVENTS_SEARCH   = CACHE_PREFIX + ":events:search"   // + :query:X:page:Y
 
+	// Single ordered window every upcoming-events limit is sliced from
+	CACHE_KEY_EVENTS_UPCOMING_WINDOW = CACHE_KEY_EVENTS_UPCOMING + ":window"
+
 	// Individual event details
 	CACHE_KEY_EVENT_DETAIL      = CACHE_PREFIX + ":events:detail:uuid:"      // + event-id
 	CACHE_KEY_EVENT_WITH_TAGS   = CACHE_PREFIX + ":events:with_tags:uuid:"   // + event-id
 	CACHE_KEY_EVENT_FULL_DETAIL = CACHE_PREFIX + ":events:full_detail:uuid:" // + event-id (with venue info)
+
+	// Cross-promotion slots shown on event details
+	CACHE_KEY_EVENT_PROMOTIONS = CACHE_PREFIX + ":promotions:event:uuid:" // + event-id
 )
 
 // Event Cache TTLs
-const (
+var (
 	TTL_EVENT_LIST     = TTL_SEMI_STATIC_SHORT  // 1 hour
 	TTL_EVENT_UPCOMING = TTL_SEMI_STATIC_QUICK  // 15 minutes
 	TTL_EVENT_DETAIL   = TTL_SEMI_STATIC_MEDIUM // 2 hours
 	TTL_EVENT_SEARCH   = TTL_SEMI_STATIC_QUICK  // 15 minutes
+
+	TTL_EVENT_PROMOTIONS = TTL_SEMI_STATIC_QUICK // 15 minutes
 )
 
+// Keys also kept in each replica's in-process cache: small, read on most
+// requests, and only ever changed through the invalidation helpers
+var LOCAL_CACHE_KEY_PREFIXES = []string{
+	CACHE_KEY_EVENT_DETAIL,
+	CACHE_KEY_TAGS_ACTIVE,
+	CACHE_KEY_TAG_BY_SLUG,
+	CACHE_KEY_VENUE_TEMPLATE,
+}
+
 //  TAGS MODULE
 
 // Tag Cache Keys
@@ -72,10 +89,12 @@ const (
 	CACHE_KEY_TAG_BY_SLUG   = CACHE_PREFIX + ":tags:detail:slug:"   // + tag-slug
 	CACHE_KEY_TAG_BY_ID     = CACHE_PREFIX + ":tags:detail:uuid:"   // + tag-id
 	CACHE_KEY_TAGS_BY_EVENT = CACHE_PREFIX + ":tags:by_event:uuid:" // + event-id
+	CACHE_KEY_TAGS_TREE     = CACHE_PREFIX + ":tags:tree:active"    // Active tags nested by parent
+	CACHE_KEY_TAG_CHILDREN  = CACHE_PREFIX + ":tags:children:slug:" // + tag-slug
 )
 
 // Tag Cache TTLs
-const (
+var (
 	TTL_TAGS_ACTIVE = TTL_STATIC_LONG  // 24 hours
 	TTL_TAGS_LIST   = TTL_STATIC_SHORT // 6 hours
 	TTL_TAG_DETAIL  = TTL_STATIC_LONG  // 24 hours
@@ -99,7 +118,7 @@ const (
 )
 
 // Venue Cache TTLs
-const (
+var (
 	TTL_VENUE_TEMPLATES = TTL_STATIC_MEDIUM    // 12 hours
 	TTL_VENUE_TEMPLATE  = TTL_STATIC_MEDIUM    // 12 hours
 	TTL_VENUE_SECTIONS  = TTL_STATIC_MEDIUM    // 12 hours
@@ -123,7 +142,7 @@ const (
 )
 
 // Seat Cache TTLs
-const (
+var (
 	TTL_SEATS_BY_SECTION  = TTL_DYNAMIC_SHORT  // 5 minutes
 	TTL_SEATS_AVAILABLE   = TTL_DYNAMIC_QUICK  // 2 minutes
 	TTL_SEAT_DETAIL       = TTL_DYNAMIC_MEDIUM // 10 minutes
@@ -160,7 +179,7 @@ const (
 )
 
 // Analytics Cache TTLs
-const (
+var (
 	TTL_ANALYTICS_DASHBOARD = TTL_SEMI_STATIC_SHORT // 1 hour
 	TTL_ANALYTICS_EVENT     = TTL_SEMI_STATIC_SHORT // 1 hour
 	TTL_ANALYTICS_TAGS      = TTL_DYNAMIC_MEDIUM    // 10 minutes
@@ -178,7 +197,7 @@ const (
 )
 
 // Auth Cache TTLs
-const (
+var (
 	TTL_USER_PROFILE = TTL_STATIC_SHORT // 6 hours
 	TTL_USER_ROLES   = TTL_STATIC_SHORT // 6 hours
 )
@@ -193,7 +212,7 @@ const (
 )
 
 // Booking Cache TTLs
-const (
+var (
 	TTL_USER_BOOKINGS  = TTL_DYNAMIC_MEDIUM // 10 minutes
 	TTL_BOOKING_DETAIL = TTL_DYNAMIC_MEDIUM // 10 minutes
 )
@@ -209,7 +228,7 @@ const (
 )
 
 // Waitlist Cache TTLs
-const (
+var (
 	TTL_WAITLIST_STATUS   = TTL_REALTIME_MEDIUM // 1 minute
 	TTL_WAITLIST_STATS    = TTL_DYNAMIC_SHORT   // 5 minutes
 	TTL_WAITLIST_POSITION = TTL_REALTIME_MEDIUM // 1 minute
@@ -225,12 +244,36 @@ const (
 )
 
 // Cancellation Cache TTLs
-const (
+var (
 	TTL_CANCELLATION_POLICY = TTL_SEMI_STATIC_MEDIUM // 2 hours
 	TTL_USER_CANCELLATIONS  = TTL_DYNAMIC_MEDIUM     // 10 minutes
 	TTL_CANCELLATION_DETAIL = TTL_DYNAMIC_MEDIUM     // 10 minutes
 )
 
+//  BRANDING MODULE
+
+// Branding Cache Keys
+const (
+	CACHE_KEY_BRANDING_EVENT = CACHE_PREFIX + ":branding:event:uuid:" // + event-id
+)
+
+// Branding Cache TTLs
+var (
+	TTL_BRANDING_EVENT = TTL_SEMI_STATIC_LONG // 4 hours
+)
+
+//  REVIEWS MODULE
+
+// Review Cache Keys
+const (
+	CACHE_KEY_REVIEW_SUMMARY = CACHE_PREFIX + ":reviews:summary:event:uuid:" // + event-id
+)
+
+// Review Cache TTLs
+var (
+	TTL_REVIEW_SUMMARY = TTL_SEMI_STATIC_QUICK // 15 minutes
+)
+
 // Patterns for cache invalidation
 const (
 	// Event-related invalidation patterns
@@ -248,6 +291,9 @@ const (
 
 	// Analytics invalidation patterns
 	PATTERN_INVALIDATE_ANALYTICS = CACHE_PREFIX + ":analytics:*"
+
+	// Branding invalidation patterns
+	PATTERN_INVALIDATE_BRANDING_EVENT = CACHE_PREFIX + ":branding:event:*"
 )
 
 // helpers
@@ -258,18 +304,77 @@ func BuildEventListKey(page, limit int, status string) string {
 	return CACHE_KEY_EVENTS_LIST + ":page:" + fmt.Sprintf("%d", page) + ":limit:" + fmt.Sprintf("%d", limit)
 }
 
+// BuildFilteredEventListKey keys a filtered list page by the hash of its normalized filters
+func BuildFilteredEventListKey(filterHash string, page, limit int, status string) string {
+	key := BuildFilteredEventListPrefix(filterHash) + fmt.Sprintf(":page:%d:limit:%d", page, limit)
+	if status != "" {
+		key += ":status:" + status
+	}
+	return key
+}
+
+// BuildFilteredEventListPrefix covers every page of one filter set
+func BuildFilteredEventListPrefix(filterHash string) string {
+	return CACHE_KEY_EVENTS_LIST + ":f:" + filterHash
+}
+
 func BuildEventDetailKey(eventID string) string {
 	return CACHE_KEY_EVENT_DETAIL + eventID
 }
 
+func BuildEventPromotionsKey(eventID string) string {
+	return CACHE_KEY_EVENT_PROMOTIONS + eventID
+}
+
+func BuildEventBrandingKey(eventID string) string {
+	return CACHE_KEY_BRANDING_EVENT + eventID
+}
+
+func BuildReviewSummaryKey(eventID string) string {
+	return CACHE_KEY_REVIEW_SUMMARY + eventID
+}
+
 func BuildTagBySlugKey(slug string) string {
 	return CACHE_KEY_TAG_BY_SLUG + slug
 }
 
+func BuildTagChildrenKey(slug string) string {
+	return CACHE_KEY_TAG_CHILDREN + slug
+}
+
 func BuildVenueLayoutKey(eventID string) string {
 	return CACHE_KEY_VENUE_LAYOUT + eventID
 }
 
+// BuildEventPriceCachePatterns covers every cached response that shows an event's
+// section prices or cancellation terms: its details, venue layout, per-section
+// seat availability and cancellation policy
+func BuildEventPriceCachePatterns(eventID string) []string {
+	return []string{
+		PATTERN_INVALIDATE_EVENT_DETAIL + eventID + "*",
+		CACHE_KEY_VENUE_LAYOUT + eventID,
+		CACHE_KEY_SEATS_AVAILABLE + "*:event:" + eventID,
+		CACHE_KEY_CANCELLATION_POLICY + eventID,
+	}
+}
+
+// BuildSeatInventoryCachePatterns covers every cached response that shows which
+// of an event's seats are on sale: its venue layout and per-section seat availability
+func BuildSeatInventoryCachePatterns(eventID string) []string {
+	return []string{
+		CACHE_KEY_VENUE_LAYOUT + eventID,
+		CACHE_KEY_SEATS_AVAILABLE + "*:event:" + eventID,
+	}
+}
+
+// BuildSectionSeatCachePatterns covers the cached seat availability of a
+// section for every event
+func BuildSectionSeatCachePatterns(sectionID string) []string {
+	return []string{
+		CACHE_KEY_SEATS_AVAILABLE + sectionID + ":event:*",
+	}
+}
+
 func BuildUserBookingsKey(userID string, page int) string {
 	return CACHE_KEY_USER_BOOKINGS + userID + ":page:" + fmt.Sprintf("%d", page)
 }
@@ -0,0 +1,33 @@
+package privacy
+
+import (
+	"net"
+	"strings"
+)
+
+// RedactID masks an identifier such as a user ID, keeping the last four
+// characters so support staff can still tell records apart
+func RedactID(id string) string {
+	if id == "" {
+		return ""
+	}
+	if len(id) <= 4 {
+		return "****"
+	}
+	return "****" + id[len(id)-4:]
+}
+
+// RedactIP masks the host part of an IP address: the last octet of IPv4 and
+// everything after the /48 prefix of IPv6
+func RedactIP(value string) string {
+	ip := net.ParseIP(value)
+	if ip == nil {
+		return RedactID(value)
+	}
+
+	if v4 := ip.To4(); v4 != nil {
+		return net.IPv4(v4[0], v4[1], v4[2], 0).String()
+	}
+	masked := ip.Mask(net.CIDRMask(48, 128)).String()
+	return strings.TrimSuffix(masked, "::") + "::"
+}
@@ -0,0 +1,128 @@
+package response
+
+import "net/http"
+
+// Error codes clients can branch on instead of parsing messages. Errors
+// without a business code get the generic code of their HTTP status.
+const (
+	// Generic
+	CodeBadRequest         = "BAD_REQUEST"
+	CodeUnauthenticated    = "UNAUTHENTICATED"
+	CodePaymentRequired    = "PAYMENT_REQUIRED"
+	CodeForbidden          = "FORBIDDEN"
+	CodeNotFound           = "NOT_FOUND"
+	CodeConflict           = "CONFLICT"
+	CodeGone               = "GONE"
+	CodePreconditionFailed = "PRECONDITION_FAILED"
+	CodePayloadTooLarge    = "PAYLOAD_TOO_LARGE"
+	CodeUnprocessable      = "UNPROCESSABLE"
+	CodeRateLimited        = "RATE_LIMITED"
+	CodeInternalError      = "INTERNAL_ERROR"
+	CodeServiceUnavailable = "SERVICE_UNAVAILABLE"
+	CodeError              = "ERROR"
+
+	// Auth
+	CodeInvalidCredentials    = "INVALID_CREDENTIALS"
+	CodeUserAlreadyExists     = "USER_ALREADY_EXISTS"
+	CodeInvalidToken          = "INVALID_TOKEN"
+	CodeMissingToken          = "MISSING_TOKEN"
+	CodeTokenExpired          = "TOKEN_EXPIRED"
+	CodeTokenRevoked          = "TOKEN_REVOKED" // Impersonation session ended before the token expired
+	CodeTwoFactorRequired     = "TWO_FACTOR_REQUIRED"
+	CodeInvalidTwoFactorCode  = "INVALID_TWO_FACTOR_CODE"
+	CodeTwoFactorStateInvalid = "TWO_FACTOR_STATE_INVALID" // Enabled twice, disabled when not enrolled, ...
+
+	// Events
+	CodeEventNotFound           = "EVENT_NOT_FOUND"
+	CodeInvalidStatusTransition = "INVALID_STATUS_TRANSITION"
+	CodeEventNotCancellable     = "EVENT_NOT_CANCELLABLE"
+	CodeEventHasBookings        = "EVENT_HAS_BOOKINGS"
+	CodeVenueConflict           = "VENUE_CONFLICT"
+
+	// Seat and ticket holds
+	CodeSeatAlreadyHeld    = "SEAT_ALREADY_HELD"
+	CodeSeatAlreadyBooked  = "SEAT_ALREADY_BOOKED"
+	CodeSeatUnavailable    = "SEAT_UNAVAILABLE"
+	CodeSeatBlocked        = "SEAT_BLOCKED"
+	CodeNotEnoughTickets   = "NOT_ENOUGH_TICKETS"
+	CodeHoldNotFound       = "HOLD_NOT_FOUND" // Also returned once a hold has expired
+	CodeHoldForbidden      = "HOLD_FORBIDDEN"
+	CodeHoldLimitReached   = "HOLD_LIMIT_REACHED"
+	CodeHoldsUnavailable   = "HOLDS_UNAVAILABLE"
+	CodeSeatBlockNotFound  = "SEAT_BLOCK_NOT_FOUND"
+	CodeSeatBlockConflict  = "SEAT_BLOCK_CONFLICT"
+	CodeTicketTypeNotFound = "TICKET_TYPE_NOT_FOUND"
+
+	// Bookings and transfers
+	CodeBookingNotFound        = "BOOKING_NOT_FOUND"
+	CodeBookingConflict        = "BOOKING_CONFLICT"
+	CodeBookingModified        = "BOOKING_MODIFIED" // Optimistic lock lost, refresh and retry
+	CodeAlreadyCheckedIn       = "ALREADY_CHECKED_IN"
+	CodeNotAwaitingPayment     = "NOT_AWAITING_PAYMENT"
+	CodePaymentDeclined        = "PAYMENT_DECLINED"
+	CodeTransferNotFound       = "TRANSFER_NOT_FOUND"
+	CodeTransferClosed         = "TRANSFER_CLOSED"
+	CodeRecipientNotFound      = "RECIPIENT_NOT_FOUND"
+	CodeBookingNotTransferable = "BOOKING_NOT_TRANSFERABLE"
+
+	// Waitlist
+	CodeWaitlistFull         = "WAITLIST_FULL"
+	CodeAlreadyOnWaitlist    = "ALREADY_ON_WAITLIST"
+	CodeWaitlistEntryMissing = "WAITLIST_ENTRY_NOT_FOUND"
+	CodeWaitlistEntryClosed  = "WAITLIST_ENTRY_CLOSED"
+
+	// Cancellations
+	CodeCancellationNotFound     = "CANCELLATION_NOT_FOUND"
+	CodeCancellationNotPending   = "CANCELLATION_NOT_PENDING"
+	CodeCancellationExists       = "CANCELLATION_EXISTS"
+	CodeCancellationNotAllowed   = "CANCELLATION_NOT_ALLOWED"
+	CodeCancellationDeadline     = "CANCELLATION_DEADLINE_PASSED"
+	CodeBookingAlreadyCancelled  = "BOOKING_ALREADY_CANCELLED"
+	CodeCancellationPolicyExists = "CANCELLATION_POLICY_EXISTS"
+	CodeNoCancellationPolicy     = "NO_CANCELLATION_POLICY"
+
+	// Resale
+	CodeListingNotFound    = "LISTING_NOT_FOUND"
+	CodeListingClosed      = "LISTING_CLOSED"
+	CodeListingUnavailable = "LISTING_UNAVAILABLE"
+	CodePriceAboveFace     = "PRICE_ABOVE_FACE_VALUE"
+	CodeResaleClosed       = "RESALE_CLOSED"
+	CodeAlreadyListed      = "ALREADY_LISTED"
+	CodeOwnListing         = "OWN_LISTING"
+	CodeSaleNotFound       = "SALE_NOT_FOUND"
+	CodePayoutAlreadyPaid  = "PAYOUT_ALREADY_PAID"
+)
+
+// StatusErrorCode is the generic code of an HTTP error status
+func StatusErrorCode(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return CodeBadRequest
+	case http.StatusUnauthorized:
+		return CodeUnauthenticated
+	case http.StatusPaymentRequired:
+		return CodePaymentRequired
+	case http.StatusForbidden:
+		return CodeForbidden
+	case http.StatusNotFound:
+		return CodeNotFound
+	case http.StatusConflict:
+		return CodeConflict
+	case http.StatusGone:
+		return CodeGone
+	case http.StatusPreconditionFailed:
+		return CodePreconditionFailed
+	case http.StatusRequestEntityTooLarge:
+		return CodePayloadTooLarge
+	case http.StatusUnprocessableEntity:
+		return CodeUnprocessable
+	case http.StatusTooManyRequests:
+		return CodeRateLimited
+	case http.StatusInternalServerError:
+		return CodeInternalError
+	case http.StatusServiceUnavailable:
+		return CodeServiceUnavailable
+	default:
+		return CodeError
+	}
+}
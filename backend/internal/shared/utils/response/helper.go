@@ -1,13 +1,80 @@
 package response
 
-import "github.com/gin-gonic/gin"
+import (
+	"net/http"
+	"regexp"
 
+	"github.com/gin-gonic/gin"
+)
+
+// RequestIDHeader carries the ID that ties a request to its response and logs
+const RequestIDHeader = "X-Request-ID"
+
+// RequestIDKey is the gin context key holding the ID of the request
+const RequestIDKey = "request_id"
+
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// RequestID returns the ID of the request, taken from the context or, until
+// one is set there, from a well-formed X-Request-ID header
+func RequestID(c *gin.Context) string {
+	if id := c.GetString(RequestIDKey); id != "" {
+		return id
+	}
+	if id := c.GetHeader(RequestIDHeader); requestIDPattern.MatchString(id) {
+		return id
+	}
+	return ""
+}
+
+// RespondJSON writes the standard envelope. Error responses get the generic
+// code of their status; use RespondError for business errors.
 func RespondJSON(c *gin.Context, status string, code int, message string, data interface{}, errors interface{}) {
-	c.JSON(code, StandardApiResponse{
+	errorCode := ""
+	if code >= http.StatusBadRequest {
+		errorCode = StatusErrorCode(code)
+	}
+	respond(c, status, code, errorCode, message, data, errors)
+}
+
+// RespondError writes an error envelope with a machine-readable error code
+func RespondError(c *gin.Context, code int, errorCode string, message string, errors interface{}) {
+	respond(c, "error", code, errorCode, message, nil, errors)
+}
+
+// RespondErrorWithData is RespondError for errors that carry data, such as the
+// conflicting bookings of a refused booking
+func RespondErrorWithData(c *gin.Context, code int, errorCode string, message string, data interface{}, errors interface{}) {
+	respond(c, "error", code, errorCode, message, data, errors)
+}
+
+// RespondPaginated writes a page of a list whose data has no pagination of its own
+func RespondPaginated(c *gin.Context, code int, message string, data interface{}, pagination Pagination) {
+	respondWith(c, "success", code, "", message, data, nil, &pagination)
+}
+
+func respond(c *gin.Context, status string, code int, errorCode string, message string, data interface{}, errors interface{}) {
+	var pagination *Pagination
+	if paged, ok := data.(Paginated); ok {
+		meta := paged.PaginationMeta()
+		pagination = &meta
+	}
+	respondWith(c, status, code, errorCode, message, data, errors, pagination)
+}
+
+func respondWith(c *gin.Context, status string, code int, errorCode string, message string, data interface{}, errors interface{}, pagination *Pagination) {
+	resp := StandardApiResponse{
 		Status:     status,
 		StatusCode: code,
+		ErrorCode:  errorCode,
 		Message:    message,
+		RequestID:  RequestID(c),
 		Data:       data,
+		Pagination: pagination,
 		Errors:     errors,
-	})
+	}
+	if banner, ok := c.Get(ImpersonationKey); ok {
+		resp.Impersonation, _ = banner.(*ImpersonationBanner)
+	}
+	c.JSON(code, resp)
 }
@@ -1,9 +1,53 @@
 package response
 
+import "time"
+
 type StandardApiResponse struct {
-	Status     string      `json:"status"`           // "success" or "error"
-	StatusCode int         `json:"status_code"`      // HTTP status code
-	Message    string      `json:"message"`          // Human-readable message
-	Data       interface{} `json:"data,omitempty"`   // Payload for success
-	Errors     interface{} `json:"errors,omitempty"` // Validation or error details
+	Status        string               `json:"status"`                  // "success" or "error"
+	StatusCode    int                  `json:"status_code"`             // HTTP status code
+	ErrorCode     string               `json:"error_code,omitempty"`    // Machine-readable code, set on every error
+	Message       string               `json:"message"`                 // Human-readable message
+	RequestID     string               `json:"request_id,omitempty"`    // Also sent in the X-Request-ID header
+	Data          interface{}          `json:"data,omitempty"`          // Payload for success
+	Pagination    *Pagination          `json:"pagination,omitempty"`    // Set when data is a paged list
+	Errors        interface{}          `json:"errors,omitempty"`        // Validation or error details
+	Impersonation *ImpersonationBanner `json:"impersonation,omitempty"` // Set when an admin is acting as the user
+}
+
+// Pagination describes the page of a paged list, in the same terms for every
+// list whatever fields its data carries
+type Pagination struct {
+	Page       int   `json:"page"`
+	Limit      int   `json:"limit"`
+	TotalCount int64 `json:"total_count"`
+	TotalPages int   `json:"total_pages"`
+}
+
+// NewPagination works out the page count of a list
+func NewPagination(page, limit int, totalCount int64) Pagination {
+	totalPages := 0
+	if limit > 0 {
+		totalPages = int((totalCount + int64(limit) - 1) / int64(limit))
+	}
+	return Pagination{Page: page, Limit: limit, TotalCount: totalCount, TotalPages: totalPages}
+}
+
+// Paginated is implemented by paged list DTOs, so the envelope can carry their
+// pagination without each controller building it
+type Paginated interface {
+	PaginationMeta() Pagination
+}
+
+// ImpersonationKey is the gin context key holding the banner of a request made
+// with an impersonation token
+const ImpersonationKey = "impersonation_banner"
+
+// ImpersonationBanner lets clients show that a support admin is viewing the
+// app as the user
+type ImpersonationBanner struct {
+	Active         bool      `json:"active"`
+	ImpersonatorID string    `json:"impersonator_id"`
+	SessionID      string    `json:"session_id"`
+	ExpiresAt      time.Time `json:"expires_at"`
+	Message        string    `json:"message"`
 }
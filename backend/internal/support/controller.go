@@ -0,0 +1,397 @@
+package support
+
+import (
+	"net/http"
+	"strings"
+
+	"evently/internal/shared/utils/response"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type Controller struct {
+	service Service
+}
+
+func NewController(service Service) *Controller {
+	return &Controller{service: service}
+}
+
+//  USER
+
+// CreateTicket godoc
+//
+// @Summary      Open a support ticket
+// @Description  A ticket can reference one of the user's bookings (which also links its event) or an event
+// @Tags         Support
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        request body CreateTicketRequest true "Request body"
+// @Success      201 {object} response.StandardApiResponse{data=SupportTicket} "Support ticket created successfully"
+// @Failure      400 {object} response.StandardApiResponse "Booking is for a different event"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse "Booking belongs to another user"
+// @Failure      404 {object} response.StandardApiResponse "Booking or event not found"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/support/tickets [post]
+func (ctrl *Controller) CreateTicket(c *gin.Context) {
+	userID, ok := ctrl.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req CreateTicketRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid request body", nil, err.Error())
+		return
+	}
+
+	ticket, err := ctrl.service.CreateTicket(c.Request.Context(), userID, req)
+	if err != nil {
+		response.RespondJSON(c, "error", errorStatus(err), err.Error(), nil, nil)
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusCreated, "Support ticket created successfully", ticket, nil)
+}
+
+// ListUserTickets godoc
+//
+// @Summary      List own tickets
+// @Tags         Support
+// @Produce      json
+// @Security     Bearer
+// @Param        status query string false "Status" Enums(OPEN, PENDING, RESOLVED)
+// @Param        page query integer false "Page" default(1) minimum(1)
+// @Param        limit query integer false "Limit" default(20) minimum(1) maximum(100)
+// @Success      200 {object} response.StandardApiResponse{data=PaginatedTickets} "Tickets retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/support/tickets [get]
+func (ctrl *Controller) ListUserTickets(c *gin.Context) {
+	userID, ok := ctrl.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var query TicketListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid query parameters", nil, err.Error())
+		return
+	}
+
+	tickets, err := ctrl.service.ListUserTickets(c.Request.Context(), userID, query)
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusInternalServerError, "Failed to retrieve tickets", nil, err.Error())
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Tickets retrieved successfully", tickets, nil)
+}
+
+// GetUserTicket godoc
+//
+// @Summary      Get own ticket with its conversation
+// @Tags         Support
+// @Produce      json
+// @Security     Bearer
+// @Param        ticketId path string true "Ticket ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=SupportTicket} "Ticket retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse "Ticket belongs to another user"
+// @Failure      404 {object} response.StandardApiResponse "Ticket not found"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/support/tickets/{ticketId} [get]
+func (ctrl *Controller) GetUserTicket(c *gin.Context) {
+	ticketID, ok := ctrl.ticketID(c)
+	if !ok {
+		return
+	}
+
+	userID, ok := ctrl.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	ticket, err := ctrl.service.GetUserTicket(c.Request.Context(), ticketID, userID)
+	if err != nil {
+		response.RespondJSON(c, "error", errorStatus(err), err.Error(), nil, nil)
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Ticket retrieved successfully", ticket, nil)
+}
+
+// ReplyAsUser godoc
+//
+// @Summary      Reply to own ticket
+// @Description  Sends the ticket back to support (OPEN), reopening it if it was resolved
+// @Tags         Support
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        ticketId path string true "Ticket ID" Format(uuid)
+// @Param        request body ReplyRequest true "Request body"
+// @Success      200 {object} response.StandardApiResponse{data=SupportTicket} "Reply sent successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse "Ticket belongs to another user"
+// @Failure      404 {object} response.StandardApiResponse "Ticket not found"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/support/tickets/{ticketId}/messages [post]
+func (ctrl *Controller) ReplyAsUser(c *gin.Context) {
+	ticketID, ok := ctrl.ticketID(c)
+	if !ok {
+		return
+	}
+
+	userID, ok := ctrl.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req ReplyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid request body", nil, err.Error())
+		return
+	}
+
+	ticket, err := ctrl.service.ReplyAsUser(c.Request.Context(), ticketID, userID, req)
+	if err != nil {
+		response.RespondJSON(c, "error", errorStatus(err), err.Error(), nil, nil)
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Reply sent successfully", ticket, nil)
+}
+
+//  ADMIN
+
+// ListTickets godoc
+//
+// @Summary      List support tickets
+// @Description  Most recently active first
+// @Description
+// @Description  Requires the `support:manage` permission.
+// @Tags         Admin Support
+// @Produce      json
+// @Security     Bearer
+// @Param        status query string false "Status" Enums(OPEN, PENDING, RESOLVED)
+// @Param        category query string false "Category" Enums(REFUND, ACCESS, PAYMENT, OTHER)
+// @Param        booking_id query string false "Booking ID" Format(uuid)
+// @Param        event_id query string false "Event ID" Format(uuid)
+// @Param        user_id query string false "User ID" Format(uuid)
+// @Param        page query integer false "Page" default(1) minimum(1)
+// @Param        limit query integer false "Limit" default(20) minimum(1) maximum(100)
+// @Success      200 {object} response.StandardApiResponse{data=PaginatedTickets} "Tickets retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/support/tickets [get]
+func (ctrl *Controller) ListTickets(c *gin.Context) {
+	var query AdminTicketListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid query parameters", nil, err.Error())
+		return
+	}
+
+	tickets, err := ctrl.service.ListTickets(c.Request.Context(), query)
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusInternalServerError, "Failed to retrieve tickets", nil, err.Error())
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Tickets retrieved successfully", tickets, nil)
+}
+
+// GetTicket godoc
+//
+// @Summary      Get a ticket with its conversation
+// @Description  Requires the `support:manage` permission.
+// @Tags         Admin Support
+// @Produce      json
+// @Security     Bearer
+// @Param        ticketId path string true "Ticket ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=SupportTicket} "Ticket retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Ticket not found"
+// @Router       /api/v1/admin/support/tickets/{ticketId} [get]
+func (ctrl *Controller) GetTicket(c *gin.Context) {
+	ticketID, ok := ctrl.ticketID(c)
+	if !ok {
+		return
+	}
+
+	ticket, err := ctrl.service.GetTicket(c.Request.Context(), ticketID)
+	if err != nil {
+		response.RespondJSON(c, "error", errorStatus(err), err.Error(), nil, nil)
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Ticket retrieved successfully", ticket, nil)
+}
+
+// GetBookingTickets godoc
+//
+// @Summary      Support tickets for a booking
+// @Description  Tickets raised about the booking, shown in the admin booking console
+// @Description
+// @Description  Requires the `support:manage` permission.
+// @Tags         Admin Bookings
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=[]SupportTicket} "Tickets retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/bookings/{id}/support-tickets [get]
+func (ctrl *Controller) GetBookingTickets(c *gin.Context) {
+	bookingID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid booking ID", nil, err.Error())
+		return
+	}
+
+	tickets, err := ctrl.service.GetBookingTickets(c.Request.Context(), bookingID)
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusInternalServerError, "Failed to retrieve tickets", nil, err.Error())
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Tickets retrieved successfully", tickets, nil)
+}
+
+// ReplyAsAdmin godoc
+//
+// @Summary      Reply to a ticket
+// @Description  Emails the reply to the user and moves the ticket to PENDING, or RESOLVED when resolve is set
+// @Description
+// @Description  Requires the `support:manage` permission.
+// @Tags         Admin Support
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        ticketId path string true "Ticket ID" Format(uuid)
+// @Param        request body AdminReplyRequest true "Request body"
+// @Success      200 {object} response.StandardApiResponse{data=SupportTicket} "Reply sent successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Ticket not found"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/support/tickets/{ticketId}/messages [post]
+func (ctrl *Controller) ReplyAsAdmin(c *gin.Context) {
+	ticketID, ok := ctrl.ticketID(c)
+	if !ok {
+		return
+	}
+
+	adminID, ok := ctrl.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req AdminReplyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid request body", nil, err.Error())
+		return
+	}
+
+	ticket, err := ctrl.service.ReplyAsAdmin(c.Request.Context(), ticketID, adminID, req)
+	if err != nil {
+		response.RespondJSON(c, "error", errorStatus(err), err.Error(), nil, nil)
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Reply sent successfully", ticket, nil)
+}
+
+// UpdateStatus godoc
+//
+// @Summary      Change ticket status
+// @Description  Requires the `support:manage` permission.
+// @Tags         Admin Support
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        ticketId path string true "Ticket ID" Format(uuid)
+// @Param        request body UpdateStatusRequest true "Request body"
+// @Success      200 {object} response.StandardApiResponse{data=SupportTicket} "Ticket status updated successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Ticket not found"
+// @Failure      409 {object} response.StandardApiResponse "Transition not allowed or ticket changed concurrently"
+// @Router       /api/v1/admin/support/tickets/{ticketId}/status [put]
+func (ctrl *Controller) UpdateStatus(c *gin.Context) {
+	ticketID, ok := ctrl.ticketID(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid request body", nil, err.Error())
+		return
+	}
+
+	ticket, err := ctrl.service.UpdateStatus(c.Request.Context(), ticketID, req)
+	if err != nil {
+		response.RespondJSON(c, "error", errorStatus(err), err.Error(), nil, nil)
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Ticket status updated successfully", ticket, nil)
+}
+
+//  HELPERS
+
+func (ctrl *Controller) ticketID(c *gin.Context) (uuid.UUID, bool) {
+	ticketID, err := uuid.Parse(c.Param("ticketId"))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid ticket ID", nil, err.Error())
+		return uuid.Nil, false
+	}
+	return ticketID, true
+}
+
+func (ctrl *Controller) currentUserID(c *gin.Context) (uuid.UUID, bool) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.RespondJSON(c, "error", http.StatusUnauthorized, "User not authenticated", nil, nil)
+		return uuid.Nil, false
+	}
+
+	userUUID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusInternalServerError, "Invalid user ID format", nil, nil)
+		return uuid.Nil, false
+	}
+	return userUUID, true
+}
+
+func errorStatus(err error) int {
+	msg := err.Error()
+	switch {
+	case strings.HasSuffix(msg, "not found"):
+		return http.StatusNotFound
+	case strings.HasPrefix(msg, "unauthorized"):
+		return http.StatusForbidden
+	case strings.HasPrefix(msg, "cannot move ticket"), msg == "ticket was modified by another request":
+		return http.StatusConflict
+	case strings.HasPrefix(msg, "failed to"):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
@@ -0,0 +1,82 @@
+package support
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TicketStatus string
+
+const (
+	TicketStatusOpen     TicketStatus = "OPEN"     // Waiting on support
+	TicketStatusPending  TicketStatus = "PENDING"  // Waiting on the user
+	TicketStatusResolved TicketStatus = "RESOLVED" // Closed; a user reply reopens it
+)
+
+// Ticket categories
+const (
+	CategoryRefund  = "REFUND"
+	CategoryAccess  = "ACCESS"
+	CategoryPayment = "PAYMENT"
+	CategoryOther   = "OTHER"
+)
+
+// Message author roles
+const (
+	AuthorUser  = "USER"
+	AuthorAdmin = "ADMIN"
+)
+
+// CanTransitionTo reports whether an admin may move a ticket to the given status
+func (s TicketStatus) CanTransitionTo(next TicketStatus) bool {
+	switch s {
+	case TicketStatusOpen:
+		return next == TicketStatusPending || next == TicketStatusResolved
+	case TicketStatusPending:
+		return next == TicketStatusOpen || next == TicketStatusResolved
+	case TicketStatusResolved:
+		return next == TicketStatusOpen
+	default:
+		return false
+	}
+}
+
+type SupportTicket struct {
+	ID          uuid.UUID    `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	Number      string       `gorm:"type:varchar(20);uniqueIndex;not null" json:"number"`
+	UserID      uuid.UUID    `gorm:"type:uuid;not null;index" json:"user_id"`
+	BookingID   *uuid.UUID   `gorm:"type:uuid;index" json:"booking_id,omitempty"`
+	EventID     *uuid.UUID   `gorm:"type:uuid;index" json:"event_id,omitempty"`
+	Category    string       `gorm:"type:varchar(20);not null;check:category IN ('REFUND', 'ACCESS', 'PAYMENT', 'OTHER')" json:"category"`
+	Subject     string       `gorm:"size:200;not null" json:"subject"`
+	Status      TicketStatus `gorm:"type:varchar(20);not null;default:'OPEN';check:status IN ('OPEN', 'PENDING', 'RESOLVED');index" json:"status"`
+	LastReplyAt time.Time    `gorm:"not null" json:"last_reply_at"`
+	ResolvedAt  *time.Time   `json:"resolved_at,omitempty"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+
+	Messages []SupportMessage `gorm:"foreignKey:TicketID;constraint:OnDelete:CASCADE;" json:"messages,omitempty"`
+}
+
+func (SupportTicket) TableName() string {
+	return "support_tickets"
+}
+
+type SupportMessage struct {
+	ID         uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	TicketID   uuid.UUID `gorm:"type:uuid;not null;index" json:"ticket_id"`
+	AuthorID   uuid.UUID `gorm:"type:uuid;not null" json:"author_id"`
+	AuthorRole string    `gorm:"type:varchar(10);not null;check:author_role IN ('USER', 'ADMIN')" json:"author_role"`
+	Body       string    `gorm:"type:text;not null" json:"body"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func (SupportMessage) TableName() string {
+	return "support_messages"
+}
+
+// GenerateTicketNumber returns a short human-friendly reference for emails and phone support
+func GenerateTicketNumber() string {
+	return "SUP" + uuid.New().String()[:8]
+}
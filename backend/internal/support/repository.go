@@ -0,0 +1,186 @@
+package support
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"evently/internal/outbox"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	Create(ctx context.Context, ticket *SupportTicket, message *SupportMessage) error
+	GetByID(ctx context.Context, id uuid.UUID, withMessages bool) (*SupportTicket, error)
+	List(ctx context.Context, filter TicketFilter, limit, offset int) ([]SupportTicket, int64, error)
+	AddMessage(ctx context.Context, ticket *SupportTicket, message *SupportMessage, status TicketStatus, notifications []*outbox.Message) error
+	UpdateStatus(ctx context.Context, id uuid.UUID, from, to TicketStatus) (bool, error)
+
+	// Lookups for linking tickets
+	GetBookingLink(ctx context.Context, bookingID uuid.UUID) (*BookingLink, error)
+	EventExists(ctx context.Context, eventID uuid.UUID) (bool, error)
+}
+
+// TicketFilter narrows ticket listings; nil fields are ignored
+type TicketFilter struct {
+	UserID    *uuid.UUID
+	BookingID *uuid.UUID
+	EventID   *uuid.UUID
+	Status    string
+	Category  string
+}
+
+// BookingLink is the slice of booking data needed to attach a ticket
+type BookingLink struct {
+	ID      uuid.UUID
+	UserID  uuid.UUID
+	EventID uuid.UUID
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+//  TICKETS
+
+// Create saves the ticket with its opening message
+func (r *repository) Create(ctx context.Context, ticket *SupportTicket, message *SupportMessage) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Messages").Create(ticket).Error; err != nil {
+			return fmt.Errorf("failed to create ticket: %w", err)
+		}
+
+		message.TicketID = ticket.ID
+		if err := tx.Create(message).Error; err != nil {
+			return fmt.Errorf("failed to create message: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *repository) GetByID(ctx context.Context, id uuid.UUID, withMessages bool) (*SupportTicket, error) {
+	query := r.db.WithContext(ctx)
+	if withMessages {
+		query = query.Preload("Messages", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC")
+		})
+	}
+
+	var ticket SupportTicket
+	if err := query.First(&ticket, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &ticket, nil
+}
+
+func (r *repository) List(ctx context.Context, filter TicketFilter, limit, offset int) ([]SupportTicket, int64, error) {
+	query := r.db.WithContext(ctx).Model(&SupportTicket{})
+
+	if filter.UserID != nil {
+		query = query.Where("user_id = ?", *filter.UserID)
+	}
+	if filter.BookingID != nil {
+		query = query.Where("booking_id = ?", *filter.BookingID)
+	}
+	if filter.EventID != nil {
+		query = query.Where("event_id = ?", *filter.EventID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.Category != "" {
+		query = query.Where("category = ?", filter.Category)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count tickets: %w", err)
+	}
+
+	var tickets []SupportTicket
+	err := query.
+		Order("last_reply_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&tickets).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list tickets: %w", err)
+	}
+
+	return tickets, total, nil
+}
+
+// AddMessage appends a reply, moves the ticket to the given status and queues
+// any reply emails in the same transaction
+func (r *repository) AddMessage(ctx context.Context, ticket *SupportTicket, message *SupportMessage, status TicketStatus, notifications []*outbox.Message) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		message.TicketID = ticket.ID
+		if err := tx.Create(message).Error; err != nil {
+			return fmt.Errorf("failed to create message: %w", err)
+		}
+
+		updates := map[string]interface{}{
+			"status":        status,
+			"last_reply_at": message.CreatedAt,
+			"resolved_at":   nil,
+		}
+		if status == TicketStatusResolved {
+			updates["resolved_at"] = message.CreatedAt
+		}
+		if err := tx.Model(&SupportTicket{}).Where("id = ?", ticket.ID).Updates(updates).Error; err != nil {
+			return fmt.Errorf("failed to update ticket: %w", err)
+		}
+
+		return outbox.Enqueue(tx, notifications...)
+	})
+}
+
+// UpdateStatus moves a ticket between statuses, returning false if it was changed concurrently
+func (r *repository) UpdateStatus(ctx context.Context, id uuid.UUID, from, to TicketStatus) (bool, error) {
+	updates := map[string]interface{}{
+		"status":      to,
+		"resolved_at": nil,
+	}
+	if to == TicketStatusResolved {
+		updates["resolved_at"] = time.Now()
+	}
+
+	result := r.db.WithContext(ctx).
+		Model(&SupportTicket{}).
+		Where("id = ? AND status = ?", id, from).
+		Updates(updates)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+//  LOOKUPS
+
+func (r *repository) GetBookingLink(ctx context.Context, bookingID uuid.UUID) (*BookingLink, error) {
+	var link BookingLink
+	err := r.db.WithContext(ctx).
+		Table("bookings").
+		Select("id, user_id, event_id").
+		Where("id = ?", bookingID).
+		Take(&link).Error
+	if err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
+
+func (r *repository) EventExists(ctx context.Context, eventID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Table("events").
+		Where("id = ? AND deleted_at IS NULL", eventID).
+		Count(&count).Error
+	return count > 0, err
+}
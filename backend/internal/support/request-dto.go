@@ -0,0 +1,39 @@
+package support
+
+type CreateTicketRequest struct {
+	BookingID *string `json:"booking_id" binding:"omitempty,uuid"`
+	EventID   *string `json:"event_id" binding:"omitempty,uuid"`
+	Category  string  `json:"category" binding:"required,oneof=REFUND ACCESS PAYMENT OTHER"`
+	Subject   string  `json:"subject" binding:"required,min=3,max=200"`
+	Message   string  `json:"message" binding:"required,min=1,max=5000"`
+}
+
+type ReplyRequest struct {
+	Message string `json:"message" binding:"required,min=1,max=5000"`
+}
+
+// AdminReplyRequest lets support answer and optionally close the ticket in one step
+type AdminReplyRequest struct {
+	Message string `json:"message" binding:"required,min=1,max=5000"`
+	Resolve bool   `json:"resolve"`
+}
+
+type UpdateStatusRequest struct {
+	Status string `json:"status" binding:"required,oneof=OPEN PENDING RESOLVED"`
+}
+
+type TicketListQuery struct {
+	Status string `form:"status" binding:"omitempty,oneof=OPEN PENDING RESOLVED"`
+	Page   int    `form:"page,default=1" binding:"min=1"`
+	Limit  int    `form:"limit,default=20" binding:"min=1,max=100"`
+}
+
+type AdminTicketListQuery struct {
+	Status    string `form:"status" binding:"omitempty,oneof=OPEN PENDING RESOLVED"`
+	Category  string `form:"category" binding:"omitempty,oneof=REFUND ACCESS PAYMENT OTHER"`
+	BookingID string `form:"booking_id" binding:"omitempty,uuid"`
+	EventID   string `form:"event_id" binding:"omitempty,uuid"`
+	UserID    string `form:"user_id" binding:"omitempty,uuid"`
+	Page      int    `form:"page,default=1" binding:"min=1"`
+	Limit     int    `form:"limit,default=20" binding:"min=1,max=100"`
+}
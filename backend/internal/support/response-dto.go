@@ -0,0 +1,14 @@
+package support
+
+import "evently/internal/shared/utils/response"
+
+type PaginatedTickets struct {
+	Tickets    []SupportTicket `json:"tickets"`
+	TotalCount int64           `json:"total_count"`
+	Page       int             `json:"page"`
+	Limit      int             `json:"limit"`
+}
+
+func (p PaginatedTickets) PaginationMeta() response.Pagination {
+	return response.NewPagination(p.Page, p.Limit, p.TotalCount)
+}
@@ -0,0 +1,36 @@
+package support
+
+import (
+	"evently/internal/shared/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupSupportRoutes(rg *gin.RouterGroup, controller *Controller) {
+	// User tickets - a ticket can reference one of the user's bookings or an event
+	tickets := rg.Group("/support/tickets")
+	tickets.Use(middleware.JWTAuth(), middleware.RequireRoles("USER", "ADMIN"))
+	{
+		tickets.POST("", controller.CreateTicket)                   // POST /api/v1/support/tickets
+		tickets.GET("", controller.ListUserTickets)                 // GET /api/v1/support/tickets
+		tickets.GET("/:ticketId", controller.GetUserTicket)         // GET /api/v1/support/tickets/:ticketId
+		tickets.POST("/:ticketId/messages", controller.ReplyAsUser) // POST /api/v1/support/tickets/:ticketId/messages
+	}
+
+	// Admin support console
+	admin := rg.Group("/admin/support/tickets")
+	admin.Use(middleware.JWTAuth(), middleware.RequirePermission(middleware.PermissionSupportManage))
+	{
+		admin.GET("", controller.ListTickets)                      // GET /api/v1/admin/support/tickets
+		admin.GET("/:ticketId", controller.GetTicket)              // GET /api/v1/admin/support/tickets/:ticketId
+		admin.POST("/:ticketId/messages", controller.ReplyAsAdmin) // POST /api/v1/admin/support/tickets/:ticketId/messages
+		admin.PUT("/:ticketId/status", controller.UpdateStatus)    // PUT /api/v1/admin/support/tickets/:ticketId/status
+	}
+
+	// Tickets shown alongside a booking in the admin booking console
+	adminBookings := rg.Group("/admin/bookings")
+	adminBookings.Use(middleware.JWTAuth(), middleware.RequirePermission(middleware.PermissionSupportManage))
+	{
+		adminBookings.GET("/:id/support-tickets", controller.GetBookingTickets) // GET /api/v1/admin/bookings/:id/support-tickets
+	}
+}
@@ -0,0 +1,303 @@
+package support
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"evently/internal/outbox"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const NotificationTypeTicketReply = "SUPPORT_TICKET_REPLY"
+
+type Service interface {
+	// User tickets
+	CreateTicket(ctx context.Context, userID uuid.UUID, req CreateTicketRequest) (*SupportTicket, error)
+	ListUserTickets(ctx context.Context, userID uuid.UUID, query TicketListQuery) (*PaginatedTickets, error)
+	GetUserTicket(ctx context.Context, ticketID, userID uuid.UUID) (*SupportTicket, error)
+	ReplyAsUser(ctx context.Context, ticketID, userID uuid.UUID, req ReplyRequest) (*SupportTicket, error)
+
+	// Admin console
+	ListTickets(ctx context.Context, query AdminTicketListQuery) (*PaginatedTickets, error)
+	GetTicket(ctx context.Context, ticketID uuid.UUID) (*SupportTicket, error)
+	GetBookingTickets(ctx context.Context, bookingID uuid.UUID) ([]SupportTicket, error)
+	ReplyAsAdmin(ctx context.Context, ticketID, adminID uuid.UUID, req AdminReplyRequest) (*SupportTicket, error)
+	UpdateStatus(ctx context.Context, ticketID uuid.UUID, req UpdateStatusRequest) (*SupportTicket, error)
+}
+
+type service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) Service {
+	return &service{repo: repo}
+}
+
+//  USER TICKETS
+
+func (s *service) CreateTicket(ctx context.Context, userID uuid.UUID, req CreateTicketRequest) (*SupportTicket, error) {
+	ticket := &SupportTicket{
+		Number:   GenerateTicketNumber(),
+		UserID:   userID,
+		Category: req.Category,
+		Subject:  req.Subject,
+		Status:   TicketStatusOpen,
+	}
+
+	if req.EventID != nil {
+		eventID, err := uuid.Parse(*req.EventID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid event ID: %w", err)
+		}
+		exists, err := s.repo.EventExists(ctx, eventID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get event: %w", err)
+		}
+		if !exists {
+			return nil, errors.New("event not found")
+		}
+		ticket.EventID = &eventID
+	}
+
+	// A booking also pins the ticket to the booking's event
+	if req.BookingID != nil {
+		bookingID, err := uuid.Parse(*req.BookingID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid booking ID: %w", err)
+		}
+		booking, err := s.repo.GetBookingLink(ctx, bookingID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errors.New("booking not found")
+			}
+			return nil, fmt.Errorf("failed to get booking: %w", err)
+		}
+		if booking.UserID != userID {
+			return nil, errors.New("unauthorized: booking does not belong to user")
+		}
+		if ticket.EventID != nil && *ticket.EventID != booking.EventID {
+			return nil, errors.New("booking is for a different event")
+		}
+		ticket.BookingID = &booking.ID
+		ticket.EventID = &booking.EventID
+	}
+
+	now := time.Now()
+	ticket.LastReplyAt = now
+	message := &SupportMessage{
+		AuthorID:   userID,
+		AuthorRole: AuthorUser,
+		Body:       req.Message,
+		CreatedAt:  now,
+	}
+
+	if err := s.repo.Create(ctx, ticket, message); err != nil {
+		return nil, err
+	}
+
+	log.Printf("🎫 Support ticket %s opened by user %s", ticket.Number, userID)
+
+	return s.repo.GetByID(ctx, ticket.ID, true)
+}
+
+func (s *service) ListUserTickets(ctx context.Context, userID uuid.UUID, query TicketListQuery) (*PaginatedTickets, error) {
+	filter := TicketFilter{
+		UserID: &userID,
+		Status: query.Status,
+	}
+	return s.list(ctx, filter, query.Page, query.Limit)
+}
+
+func (s *service) GetUserTicket(ctx context.Context, ticketID, userID uuid.UUID) (*SupportTicket, error) {
+	ticket, err := s.getTicket(ctx, ticketID, true)
+	if err != nil {
+		return nil, err
+	}
+	if ticket.UserID != userID {
+		return nil, errors.New("unauthorized: ticket does not belong to user")
+	}
+	return ticket, nil
+}
+
+// ReplyAsUser adds a user message; the ticket goes back to support, reopening it if resolved
+func (s *service) ReplyAsUser(ctx context.Context, ticketID, userID uuid.UUID, req ReplyRequest) (*SupportTicket, error) {
+	ticket, err := s.GetUserTicket(ctx, ticketID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	message := &SupportMessage{
+		AuthorID:   userID,
+		AuthorRole: AuthorUser,
+		Body:       req.Message,
+		CreatedAt:  time.Now(),
+	}
+
+	if err := s.repo.AddMessage(ctx, ticket, message, TicketStatusOpen, nil); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetByID(ctx, ticketID, true)
+}
+
+//  ADMIN CONSOLE
+
+func (s *service) ListTickets(ctx context.Context, query AdminTicketListQuery) (*PaginatedTickets, error) {
+	filter := TicketFilter{
+		Status:   query.Status,
+		Category: query.Category,
+	}
+
+	if query.UserID != "" {
+		userID, err := uuid.Parse(query.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid user ID: %w", err)
+		}
+		filter.UserID = &userID
+	}
+	if query.BookingID != "" {
+		bookingID, err := uuid.Parse(query.BookingID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid booking ID: %w", err)
+		}
+		filter.BookingID = &bookingID
+	}
+	if query.EventID != "" {
+		eventID, err := uuid.Parse(query.EventID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid event ID: %w", err)
+		}
+		filter.EventID = &eventID
+	}
+
+	return s.list(ctx, filter, query.Page, query.Limit)
+}
+
+func (s *service) GetTicket(ctx context.Context, ticketID uuid.UUID) (*SupportTicket, error) {
+	return s.getTicket(ctx, ticketID, true)
+}
+
+// GetBookingTickets returns every ticket raised about a booking for the admin booking console
+func (s *service) GetBookingTickets(ctx context.Context, bookingID uuid.UUID) ([]SupportTicket, error) {
+	tickets, _, err := s.repo.List(ctx, TicketFilter{BookingID: &bookingID}, 100, 0)
+	if err != nil {
+		return nil, err
+	}
+	if tickets == nil {
+		tickets = []SupportTicket{}
+	}
+	return tickets, nil
+}
+
+// ReplyAsAdmin answers a ticket and emails the user. The ticket then waits on
+// the user unless the reply also resolves it.
+func (s *service) ReplyAsAdmin(ctx context.Context, ticketID, adminID uuid.UUID, req AdminReplyRequest) (*SupportTicket, error) {
+	ticket, err := s.getTicket(ctx, ticketID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	status := TicketStatusPending
+	if req.Resolve {
+		status = TicketStatusResolved
+	}
+
+	message := &SupportMessage{
+		ID:         uuid.New(),
+		AuthorID:   adminID,
+		AuthorRole: AuthorAdmin,
+		Body:       req.Message,
+		CreatedAt:  time.Now(),
+	}
+
+	notification, err := s.buildReplyNotification(ticket, message, status)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.AddMessage(ctx, ticket, message, status, []*outbox.Message{notification}); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetByID(ctx, ticketID, true)
+}
+
+func (s *service) UpdateStatus(ctx context.Context, ticketID uuid.UUID, req UpdateStatusRequest) (*SupportTicket, error) {
+	ticket, err := s.getTicket(ctx, ticketID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	next := TicketStatus(req.Status)
+	if ticket.Status == next {
+		return s.repo.GetByID(ctx, ticketID, true)
+	}
+	if !ticket.Status.CanTransitionTo(next) {
+		return nil, fmt.Errorf("cannot move ticket from %s to %s", ticket.Status, next)
+	}
+
+	updated, err := s.repo.UpdateStatus(ctx, ticketID, ticket.Status, next)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update ticket status: %w", err)
+	}
+	if !updated {
+		return nil, errors.New("ticket was modified by another request")
+	}
+
+	return s.repo.GetByID(ctx, ticketID, true)
+}
+
+//  HELPERS
+
+func (s *service) buildReplyNotification(ticket *SupportTicket, message *SupportMessage, status TicketStatus) (*outbox.Message, error) {
+	payload := &outbox.NotificationPayload{
+		Type:        NotificationTypeTicketReply,
+		RecipientID: ticket.UserID,
+		EventID:     ticket.EventID,
+		BookingID:   ticket.BookingID,
+		TemplateData: map[string]interface{}{
+			"ticket_number": ticket.Number,
+			"subject":       ticket.Subject,
+			"reply":         message.Body,
+			"resolved":      status == TicketStatusResolved,
+		},
+	}
+
+	return outbox.NewNotificationMessage(outbox.AggregateSupportTicket, ticket.ID,
+		fmt.Sprintf("support:reply:%s", message.ID), payload)
+}
+
+func (s *service) getTicket(ctx context.Context, ticketID uuid.UUID, withMessages bool) (*SupportTicket, error) {
+	ticket, err := s.repo.GetByID(ctx, ticketID, withMessages)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("ticket not found")
+		}
+		return nil, fmt.Errorf("failed to get ticket: %w", err)
+	}
+	return ticket, nil
+}
+
+func (s *service) list(ctx context.Context, filter TicketFilter, page, limit int) (*PaginatedTickets, error) {
+	offset := (page - 1) * limit
+
+	tickets, total, err := s.repo.List(ctx, filter, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	if tickets == nil {
+		tickets = []SupportTicket{}
+	}
+
+	return &PaginatedTickets{
+		Tickets:    tickets,
+		TotalCount: total,
+		Page:       page,
+		Limit:      limit,
+	}, nil
+}
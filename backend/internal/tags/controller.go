@@ -18,6 +18,8 @@ type Controller interface {
 	DeleteTag(c *gin.Context)
 	GetAllTags(c *gin.Context)
 	GetActiveTags(c *gin.Context)
+	GetTagTree(c *gin.Context)
+	GetTagChildren(c *gin.Context)
 }
 
 type controller struct {
@@ -28,6 +30,24 @@ func NewController(service Service) Controller {
 	return &controller{service: service}
 }
 
+// CreateTag godoc
+//
+// @Summary      Create tag (Admin)
+// @Description  Create a new tag (Admin only)
+// @Description
+// @Description  Requires the `events:write` permission.
+// @Tags         Admin Tags
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        request body CreateTagRequest true "Request body"
+// @Success      201 {object} response.StandardApiResponse{data=TagResponse} "Tag created successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      409 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/tags [post]
 func (ctrl *controller) CreateTag(c *gin.Context) {
 	var req CreateTagRequest
 
@@ -62,6 +82,23 @@ func (ctrl *controller) CreateTag(c *gin.Context) {
 	response.RespondJSON(c, "success", http.StatusCreated, "Tag created successfully", tag, nil)
 }
 
+// GetTag godoc
+//
+// @Summary      Get tag by ID (Admin)
+// @Description  Get a specific tag by ID
+// @Description
+// @Description  Requires the `events:write` permission.
+// @Tags         Admin Tags
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=TagResponse} "Tag retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/tags/{id} [get]
 func (ctrl *controller) GetTag(c *gin.Context) {
 	tagIDStr := c.Param("id")
 	tagID, err := uuid.Parse(tagIDStr)
@@ -83,6 +120,18 @@ func (ctrl *controller) GetTag(c *gin.Context) {
 	response.RespondJSON(c, "success", http.StatusOK, "Tag retrieved successfully", tag, nil)
 }
 
+// GetTagBySlug godoc
+//
+// @Summary      Get tag by slug
+// @Description  Retrieve a tag by its slug identifier
+// @Tags         Tags
+// @Produce      json
+// @Param        slug path string true "Tag slug"
+// @Success      200 {object} response.StandardApiResponse{data=TagResponse} "Tag retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/tags/slug/{slug} [get]
 func (ctrl *controller) GetTagBySlug(c *gin.Context) {
 	slug := c.Param("slug")
 	if slug == "" {
@@ -90,7 +139,7 @@ func (ctrl *controller) GetTagBySlug(c *gin.Context) {
 		return
 	}
 
-	tag, err := ctrl.service.GetTagBySlug(slug)
+	tag, err := ctrl.service.GetTagBySlug(c.Request.Context(), slug)
 	if err != nil {
 		statusCode := http.StatusInternalServerError
 		if err.Error() == "tag not found" {
@@ -103,6 +152,26 @@ func (ctrl *controller) GetTagBySlug(c *gin.Context) {
 	response.RespondJSON(c, "success", http.StatusOK, "Tag retrieved successfully", tag, nil)
 }
 
+// UpdateTag godoc
+//
+// @Summary      Update tag (Admin)
+// @Description  Update an existing tag (Admin only)
+// @Description
+// @Description  Requires the `events:write` permission.
+// @Tags         Admin Tags
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "ID" Format(uuid)
+// @Param        request body UpdateTagRequest true "Request body"
+// @Success      200 {object} response.StandardApiResponse{data=TagResponse} "Tag updated successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse
+// @Failure      409 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/tags/{id} [put]
 func (ctrl *controller) UpdateTag(c *gin.Context) {
 	tagIDStr := c.Param("id")
 	tagID, err := uuid.Parse(tagIDStr)
@@ -146,6 +215,23 @@ func (ctrl *controller) UpdateTag(c *gin.Context) {
 	response.RespondJSON(c, "success", http.StatusOK, "Tag updated successfully", tag, nil)
 }
 
+// DeleteTag godoc
+//
+// @Summary      Delete tag (Admin)
+// @Description  Delete a tag (Admin only). Tags used by events or with child tags can't be deleted.
+// @Description
+// @Description  Requires the `events:write` permission.
+// @Tags         Admin Tags
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse "Tag deleted successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/tags/{id} [delete]
 func (ctrl *controller) DeleteTag(c *gin.Context) {
 	tagIDStr := c.Param("id")
 	tagID, err := uuid.Parse(tagIDStr)
@@ -180,6 +266,22 @@ func (ctrl *controller) DeleteTag(c *gin.Context) {
 	response.RespondJSON(c, "success", http.StatusOK, "Tag deleted successfully", nil, nil)
 }
 
+// GetAllTags godoc
+//
+// @Summary      Get all tags (Admin)
+// @Description  Get all tags with admin filters and options
+// @Description
+// @Description  Requires the `events:write` permission.
+// @Tags         Admin Tags
+// @Produce      json
+// @Security     Bearer
+// @Param        parent_id query string false "Only the tags directly below this tag" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=PaginatedTags} "Tags retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/tags [get]
 func (ctrl *controller) GetAllTags(c *gin.Context) {
 	var query TagListQuery
 
@@ -197,8 +299,18 @@ func (ctrl *controller) GetAllTags(c *gin.Context) {
 	response.RespondJSON(c, "success", http.StatusOK, "Tags retrieved successfully", tags, nil)
 }
 
+// GetActiveTags godoc
+//
+// @Summary      Get active tags
+// @Description  Retrieve all active tags for filtering
+// @Tags         Tags
+// @Produce      json
+// @Success      200 {object} response.StandardApiResponse{data=[]TagResponse} "Active tags retrieved successfully"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/tags/active [get]
+// @Router       /api/v1/admin/tags/active [get]
 func (ctrl *controller) GetActiveTags(c *gin.Context) {
-	tags, err := ctrl.service.GetActiveTags()
+	tags, err := ctrl.service.GetActiveTags(c.Request.Context())
 	if err != nil {
 		response.RespondJSON(c, "error", http.StatusInternalServerError, err.Error(), nil, nil)
 		return
@@ -206,3 +318,54 @@ func (ctrl *controller) GetActiveTags(c *gin.Context) {
 
 	response.RespondJSON(c, "success", http.StatusOK, "Active tags retrieved successfully", tags, nil)
 }
+
+// GetTagTree godoc
+//
+// @Summary      Get tag taxonomy
+// @Description  Active tags nested under their parents, sorted by name at every level. Tags whose parent is inactive are listed at the top level.
+// @Tags         Tags
+// @Produce      json
+// @Success      200 {object} response.StandardApiResponse{data=[]TagNode} "Tag tree retrieved successfully"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/tags/tree [get]
+func (ctrl *controller) GetTagTree(c *gin.Context) {
+	tree, err := ctrl.service.GetTagTree(c.Request.Context())
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusInternalServerError, err.Error(), nil, nil)
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Tag tree retrieved successfully", tree, nil)
+}
+
+// GetTagChildren godoc
+//
+// @Summary      Get child tags
+// @Description  Active tags directly below the tag
+// @Tags         Tags
+// @Produce      json
+// @Param        slug path string true "Tag slug"
+// @Success      200 {object} response.StandardApiResponse{data=[]TagResponse} "Child tags retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Tag not found"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/tags/slug/{slug}/children [get]
+func (ctrl *controller) GetTagChildren(c *gin.Context) {
+	slug := c.Param("slug")
+	if slug == "" {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Tag slug is required", nil, nil)
+		return
+	}
+
+	children, err := ctrl.service.GetTagChildren(c.Request.Context(), slug)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "tag not found" {
+			statusCode = http.StatusNotFound
+		}
+		response.RespondJSON(c, "error", statusCode, err.Error(), nil, nil)
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Child tags retrieved successfully", children, nil)
+}
@@ -2,67 +2,63 @@ package tags
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"regexp"
 	"strings"
 	"time"
 
 	"evently/internal/shared/utils/constants"
+	"evently/pkg/cache"
 
 	"github.com/redis/go-redis/v9"
 )
 
 // Cache Helper Methods
 
-func SetCache(ctx context.Context, client *redis.Client, key string, value interface{}, ttl time.Duration) error {
+func SetCache(ctx context.Context, client redis.UniversalClient, key string, value interface{}, ttl time.Duration) error {
 	if client == nil {
 		return nil // skip caching if Redis is not available
 	}
 
-	data, err := json.Marshal(value)
-	if err != nil {
-		return fmt.Errorf("failed to marshal cache data: %w", err)
-	}
-
-	return client.Set(ctx, key, data, ttl).Err()
+	return cache.SetJSON(ctx, client, key, value, ttl)
 }
 
-func GetCache(ctx context.Context, client *redis.Client, key string, dest interface{}) error {
+func GetCache(ctx context.Context, client redis.UniversalClient, key string, dest interface{}) error {
 	if client == nil {
 		return fmt.Errorf("redis client not available")
 	}
 
-	data, err := client.Get(ctx, key).Result()
-	if err != nil {
-		return err
-	}
-
-	return json.Unmarshal([]byte(data), dest)
+	return cache.GetJSON(ctx, client, key, dest)
 }
 
-func DeleteCache(ctx context.Context, client *redis.Client, keys ...string) error {
+func DeleteCache(ctx context.Context, client redis.UniversalClient, keys ...string) error {
 	if client == nil || len(keys) == 0 {
 		return nil
 	}
 
-	return client.Del(ctx, keys...).Err()
+	if _, err := cache.DeleteKeys(ctx, client, keys...); err != nil {
+		return err
+	}
+
+	cache.Local().Invalidate(ctx, keys...)
+	return nil
 }
 
-func InvalidateTagCache(ctx context.Context, client *redis.Client) error {
+func InvalidateTagCache(ctx context.Context, client redis.UniversalClient) error {
 	if client == nil {
 		return nil
 	}
 
-	keys, err := client.Keys(ctx, constants.PATTERN_INVALIDATE_TAGS_ALL).Result()
+	keys, err := cache.MatchKeys(ctx, client, constants.PATTERN_INVALIDATE_TAGS_ALL)
 	if err != nil {
 		return err
 	}
 
-	if len(keys) > 0 {
-		return client.Del(ctx, keys...).Err()
+	if _, err := cache.DeleteKeys(ctx, client, keys...); err != nil {
+		return err
 	}
 
+	cache.Local().InvalidatePattern(ctx, constants.PATTERN_INVALIDATE_TAGS_ALL)
 	return nil
 }
 
@@ -0,0 +1,191 @@
+package tags
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"evently/internal/shared/utils/constants"
+	"evently/pkg/cache"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MaxTagDepth caps how deep the taxonomy goes, counting top-level tags as
+// the first level (e.g. Music → Jazz → Bebop is three levels)
+const MaxTagDepth = 4
+
+// validateParent checks a tag can be nested under parentID: the parent
+// exists, the tag isn't moved under itself or one of its own child tags, and
+// the tag with everything below it still fits within MaxTagDepth. tagID is nil
+// for a tag being created.
+func (s *service) validateParent(tagID *uuid.UUID, parentID uuid.UUID) error {
+	if tagID != nil && *tagID == parentID {
+		return errors.New("a tag cannot be its own parent")
+	}
+
+	if _, err := s.repo.GetByID(parentID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("parent tag not found")
+		}
+		return fmt.Errorf("failed to get parent tag: %w", err)
+	}
+
+	ancestors, err := s.repo.GetAncestorIDs(parentID)
+	if err != nil {
+		return fmt.Errorf("failed to check parent tag: %w", err)
+	}
+
+	height := 0
+	if tagID != nil {
+		for _, ancestorID := range ancestors {
+			if ancestorID == *tagID {
+				return errors.New("a tag cannot be nested under one of its own child tags")
+			}
+		}
+
+		if height, err = s.repo.GetSubtreeHeight(*tagID); err != nil {
+			return fmt.Errorf("failed to check child tags: %w", err)
+		}
+	}
+
+	// The parent sits one level below its last ancestor and the tag one below the parent
+	if len(ancestors)+2+height > MaxTagDepth {
+		return fmt.Errorf("tags can be nested at most %d levels deep", MaxTagDepth)
+	}
+	return nil
+}
+
+// parseParentID reads the parent_id of an update, where an empty string moves
+// the tag to the top level
+func parseParentID(raw string) (*uuid.UUID, error) {
+	if raw == "" {
+		return nil, nil
+	}
+
+	parentID, err := uuid.Parse(raw)
+	if err != nil {
+		return nil, errors.New("invalid parent tag ID")
+	}
+	return &parentID, nil
+}
+
+func sameParent(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// GetTagTree returns the active tags nested under their parents, sorted by
+// name at every level. A tag whose parent is inactive is listed at the top
+// level so it stays reachable.
+func (s *service) GetTagTree(ctx context.Context) ([]TagNode, error) {
+	cacheKey := constants.CACHE_KEY_TAGS_TREE
+
+	var cachedTree []TagNode
+	if err := GetCache(ctx, s.redisClient, cacheKey, &cachedTree); err == nil {
+		return cachedTree, nil
+	}
+
+	// Cache miss
+	tags, err := s.repo.GetActive()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active tags: %w", err)
+	}
+
+	tree := buildTagTree(tags)
+
+	if err := SetCache(ctx, s.redisClient, cacheKey, tree, constants.TTL_TAGS_ACTIVE); err != nil {
+		fmt.Printf("Warning: failed to cache tag tree: %v\n", err)
+	}
+
+	return tree, nil
+}
+
+// GetTagChildren returns the active tags directly below the tag with the slug
+func (s *service) GetTagChildren(ctx context.Context, slug string) ([]TagResponse, error) {
+	cacheKey := constants.BuildTagChildrenKey(slug)
+
+	var cachedChildren []TagResponse
+	if err := GetCache(ctx, s.redisClient, cacheKey, &cachedChildren); err == nil {
+		return cachedChildren, nil
+	}
+
+	// Cache miss
+	tag, err := s.repo.GetBySlug(slug)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("tag not found")
+		}
+		return nil, fmt.Errorf("failed to get tag: %w", err)
+	}
+
+	children, err := s.repo.GetChildren(tag.ID, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get child tags: %w", err)
+	}
+
+	responses := make([]TagResponse, len(children))
+	for i, child := range children {
+		responses[i] = child.ToResponse()
+	}
+
+	if err := SetCache(ctx, s.redisClient, cacheKey, responses, constants.TTL_TAG_DETAIL); err != nil {
+		fmt.Printf("Warning: failed to cache child tags: %v\n", err)
+	}
+
+	return responses, nil
+}
+
+// buildTagTree nests tags under their parents; tags whose parent is not in
+// the list become top-level nodes
+func buildTagTree(tags []Tag) []TagNode {
+	present := make(map[uuid.UUID]bool, len(tags))
+	for _, tag := range tags {
+		present[tag.ID] = true
+	}
+
+	children := make(map[uuid.UUID][]Tag)
+	var roots []Tag
+	for _, tag := range tags {
+		if tag.ParentID != nil && present[*tag.ParentID] {
+			children[*tag.ParentID] = append(children[*tag.ParentID], tag)
+		} else {
+			roots = append(roots, tag)
+		}
+	}
+
+	var build func(level []Tag, depth int) []TagNode
+	build = func(level []Tag, depth int) []TagNode {
+		sort.Slice(level, func(i, j int) bool { return level[i].Name < level[j].Name })
+
+		nodes := make([]TagNode, len(level))
+		for i := range level {
+			nodes[i] = TagNode{TagResponse: level[i].ToResponse(), Children: []TagNode{}}
+			if depth < tagWalkLimit {
+				nodes[i].Children = build(children[level[i].ID], depth+1)
+			}
+		}
+		return nodes
+	}
+
+	return build(roots, 1)
+}
+
+// invalidateFilteredEventLists drops cached event lists filtered by tag,
+// which include events of child tags and go stale when a tag is moved
+func (s *service) invalidateFilteredEventLists(ctx context.Context) error {
+	if s.redisClient == nil {
+		return nil
+	}
+
+	keys, err := cache.MatchKeys(ctx, s.redisClient, constants.CACHE_KEY_EVENTS_LIST+":f:*")
+	if err != nil {
+		return err
+	}
+	_, err = cache.DeleteKeys(ctx, s.redisClient, keys...)
+	return err
+}
@@ -4,20 +4,23 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 // Tag represents the normalized tag entity
 type Tag struct {
-	ID          uuid.UUID  `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
-	Name        string     `json:"name" gorm:"uniqueIndex;not null;size:100"`
-	Slug        string     `json:"slug" gorm:"uniqueIndex;not null;size:100"`
-	Description string     `json:"description" gorm:"size:500"`
-	Color       string     `json:"color" gorm:"size:7;default:'#6B7280'"` // Hex color code
-	IsActive    bool       `json:"is_active" gorm:"default:true"`
-	CreatedBy   uuid.UUID  `json:"created_by" gorm:"type:uuid;not null"`
-	UpdatedBy   *uuid.UUID `json:"updated_by" gorm:"type:uuid"`
-	CreatedAt   time.Time  `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt   time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+	ID          uuid.UUID      `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	Name        string         `json:"name" gorm:"uniqueIndex;not null;size:100"`
+	Slug        string         `json:"slug" gorm:"uniqueIndex;not null;size:100"`
+	Description string         `json:"description" gorm:"size:500"`
+	Color       string         `json:"color" gorm:"size:7;default:'#6B7280'"` // Hex color code
+	IsActive    bool           `json:"is_active" gorm:"default:true"`
+	ParentID    *uuid.UUID     `json:"parent_id" gorm:"type:uuid;index"` // Broader tag this one narrows, nil for top-level tags
+	CreatedBy   uuid.UUID      `json:"created_by" gorm:"type:uuid;not null"`
+	UpdatedBy   *uuid.UUID     `json:"updated_by" gorm:"type:uuid"`
+	CreatedAt   time.Time      `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
+	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
 }
 
 // EventTag represents the many-to-many relationship between events and tags
@@ -31,6 +34,12 @@ type EventTag struct {
 
 // Helper methods
 func (t *Tag) ToResponse() TagResponse {
+	var parentID *string
+	if t.ParentID != nil {
+		id := t.ParentID.String()
+		parentID = &id
+	}
+
 	return TagResponse{
 		ID:          t.ID.String(),
 		Name:        t.Name,
@@ -38,6 +47,7 @@ func (t *Tag) ToResponse() TagResponse {
 		Description: t.Description,
 		Color:       t.Color,
 		IsActive:    t.IsActive,
+		ParentID:    parentID,
 		CreatedAt:   t.CreatedAt,
 		UpdatedAt:   t.UpdatedAt,
 	}
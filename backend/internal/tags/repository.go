@@ -12,6 +12,7 @@ type Repository interface {
 	Create(tag *Tag) error
 	GetByID(id uuid.UUID) (*Tag, error)
 	GetBySlug(slug string) (*Tag, error)
+	GetBySlugIncludingDeleted(slug string) (*Tag, error)
 	Update(id uuid.UUID, updates map[string]interface{}) (*Tag, error)
 	Delete(id uuid.UUID) error
 	GetAll(query TagListQuery) ([]Tag, int64, error)
@@ -24,6 +25,10 @@ type Repository interface {
 	ReplaceEventTags(eventID uuid.UUID, tagIDs []uuid.UUID) error
 
 	GetTagsByNames(names []string) ([]Tag, error)
+
+	GetChildren(parentID uuid.UUID, activeOnly bool) ([]Tag, error)
+	GetAncestorIDs(id uuid.UUID) ([]uuid.UUID, error)
+	GetSubtreeHeight(id uuid.UUID) (int, error)
 }
 
 type repository struct {
@@ -56,6 +61,16 @@ func (r *repository) GetBySlug(slug string) (*Tag, error) {
 	return &tag, nil
 }
 
+// GetBySlugIncludingDeleted also finds soft-deleted tags, which still hold their unique name and slug
+func (r *repository) GetBySlugIncludingDeleted(slug string) (*Tag, error) {
+	var tag Tag
+	err := r.db.Unscoped().Where("slug = ?", slug).First(&tag).Error
+	if err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
 func (r *repository) Update(id uuid.UUID, updates map[string]interface{}) (*Tag, error) {
 	var tag Tag
 
@@ -76,12 +91,8 @@ func (r *repository) Update(id uuid.UUID, updates map[string]interface{}) (*Tag,
 
 func (r *repository) Delete(id uuid.UUID) error {
 	return r.db.Transaction(func(tx *gorm.DB) error {
-		// remove all event-tag relationships
-		if err := tx.Where("tag_id = ?", id).Delete(&EventTag{}).Error; err != nil {
-			return err
-		}
-
-		// Then delete the tag
+		// Soft delete keeps event-tag relationships so a restore brings them back;
+		// deleted tags are filtered out wherever tags are joined
 		return tx.Where("id = ?", id).Delete(&Tag{}).Error
 	})
 }
@@ -103,6 +114,10 @@ func (r *repository) GetAll(query TagListQuery) ([]Tag, int64, error) {
 		db = db.Where("is_active = ?", *query.IsActive)
 	}
 
+	if query.ParentID != "" {
+		db = db.Where("parent_id = ?", query.ParentID)
+	}
+
 	// Count total records
 	if err := db.Count(&totalCount).Error; err != nil {
 		return nil, 0, err
@@ -188,7 +203,7 @@ func (r *repository) GetTagsByEventID(eventID uuid.UUID) ([]Tag, error) {
 
 	err := r.db.Table("tags").
 		Joins("JOIN event_tags ON tags.id = event_tags.tag_id").
-		Where("event_tags.event_id = ? AND tags.is_active = ?", eventID, true).
+		Where("event_tags.event_id = ? AND tags.is_active = ? AND tags.deleted_at IS NULL", eventID, true).
 		Find(&tags).Error
 
 	return tags, err
@@ -238,3 +253,52 @@ func (r *repository) GetTagsByNames(names []string) ([]Tag, error) {
 	err := r.db.Where("slug IN ? AND is_active = ?", names, true).Find(&tags).Error
 	return tags, err
 }
+
+// Tag hierarchy
+
+// tagWalkLimit bounds the hierarchy walks so a corrupted parent chain can't loop forever
+const tagWalkLimit = 32
+
+func (r *repository) GetChildren(parentID uuid.UUID, activeOnly bool) ([]Tag, error) {
+	var tags []Tag
+	db := r.db.Where("parent_id = ?", parentID)
+	if activeOnly {
+		db = db.Where("is_active = ?", true)
+	}
+	err := db.Order("name ASC").Find(&tags).Error
+	return tags, err
+}
+
+// GetAncestorIDs returns the tag's parent, grandparent and so on up to its top-level tag
+func (r *repository) GetAncestorIDs(id uuid.UUID) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := r.db.Raw(`
+		WITH RECURSIVE ancestors AS (
+			SELECT parent_id AS id, 1 AS depth FROM tags WHERE id = ?
+			UNION
+			SELECT t.parent_id, a.depth + 1
+			FROM tags t
+			JOIN ancestors a ON t.id = a.id
+			WHERE a.depth < ?
+		)
+		SELECT id FROM ancestors WHERE id IS NOT NULL ORDER BY depth
+	`, id, tagWalkLimit).Scan(&ids).Error
+	return ids, err
+}
+
+// GetSubtreeHeight returns how many levels of child tags sit below the tag, 0 for a leaf
+func (r *repository) GetSubtreeHeight(id uuid.UUID) (int, error) {
+	var height int
+	err := r.db.Raw(`
+		WITH RECURSIVE subtree AS (
+			SELECT id, 0 AS depth FROM tags WHERE id = ?
+			UNION
+			SELECT t.id, s.depth + 1
+			FROM tags t
+			JOIN subtree s ON t.parent_id = s.id
+			WHERE t.deleted_at IS NULL AND s.depth < ?
+		)
+		SELECT COALESCE(MAX(depth), 0) FROM subtree
+	`, id, tagWalkLimit).Scan(&height).Error
+	return height, err
+}
@@ -1,9 +1,10 @@
 package tags
 
 type CreateTagRequest struct {
-	Name        string `json:"name" binding:"required,min=2,max=100"`
-	Description string `json:"description" binding:"max=500"`
-	Color       string `json:"color" binding:"omitempty,len=7"` // Hex color validation
+	Name        string  `json:"name" binding:"required,min=2,max=100"`
+	Description string  `json:"description" binding:"max=500"`
+	Color       string  `json:"color" binding:"omitempty,len=7"`    // Hex color validation
+	ParentID    *string `json:"parent_id" binding:"omitempty,uuid"` // Nest the tag under another, e.g. Jazz under Music
 }
 
 type UpdateTagRequest struct {
@@ -11,6 +12,7 @@ type UpdateTagRequest struct {
 	Description *string `json:"description" binding:"omitempty,max=500"`
 	Color       *string `json:"color" binding:"omitempty,len=7"`
 	IsActive    *bool   `json:"is_active"`
+	ParentID    *string `json:"parent_id"` // Empty string moves the tag to the top level
 }
 
 type TagListQuery struct {
@@ -18,6 +20,7 @@ type TagListQuery struct {
 	Limit     int    `form:"limit" binding:"omitempty,min=1,max=100"`
 	Search    string `form:"search"`
 	IsActive  *bool  `form:"is_active"`
+	ParentID  string `form:"parent_id" binding:"omitempty,uuid"` // Only the direct children of this tag
 	SortBy    string `form:"sort_by" binding:"omitempty,oneof=name created_at updated_at"`
 	SortOrder string `form:"sort_order" binding:"omitempty,oneof=asc desc"`
 }
@@ -1,6 +1,10 @@
 package tags
 
-import "time"
+import (
+	"time"
+
+	"evently/internal/shared/utils/response"
+)
 
 type TagResponse struct {
 	ID          string    `json:"id"`
@@ -9,10 +13,17 @@ type TagResponse struct {
 	Description string    `json:"description"`
 	Color       string    `json:"color"`
 	IsActive    bool      `json:"is_active"`
+	ParentID    *string   `json:"parent_id,omitempty"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 }
 
+// TagNode is a tag with its child tags, for browsing the taxonomy
+type TagNode struct {
+	TagResponse
+	Children []TagNode `json:"children"`
+}
+
 type PaginatedTags struct {
 	Tags       []TagResponse `json:"tags"`
 	TotalCount int64         `json:"total_count"`
@@ -21,6 +32,10 @@ type PaginatedTags struct {
 	TotalPages int           `json:"total_pages"`
 }
 
+func (p PaginatedTags) PaginationMeta() response.Pagination {
+	return response.NewPagination(p.Page, p.Limit, p.TotalCount)
+}
+
 // Tag Analytics
 type TagAnalytics struct {
 	TagID           string  `json:"tag_id"`
@@ -10,15 +10,17 @@ func SetupTagRoutes(router *gin.RouterGroup, controller Controller) {
 	// Public routes
 	publicTags := router.Group("/tags")
 	{
-		publicTags.GET("/active", controller.GetActiveTags)    // GET /api/v1/tags/active - Get active tags for filtering
-		publicTags.GET("/slug/:slug", controller.GetTagBySlug) // GET /api/v1/tags/slug/:slug - Get tag by slug
+		publicTags.GET("/active", controller.GetActiveTags)               // GET /api/v1/tags/active - Get active tags for filtering
+		publicTags.GET("/tree", controller.GetTagTree)                    // GET /api/v1/tags/tree - Browse the tag taxonomy
+		publicTags.GET("/slug/:slug", controller.GetTagBySlug)            // GET /api/v1/tags/slug/:slug - Get tag by slug
+		publicTags.GET("/slug/:slug/children", controller.GetTagChildren) // GET /api/v1/tags/slug/:slug/children - Get a tag's child tags
 	}
 
 	// Admin routes
 	adminTags := router.Group("/admin/tags")
-	adminTags.Use(middleware.JWTAuth())
+	adminTags.Use(middleware.JWTAuth(), middleware.RequirePermission(middleware.PermissionEventsWrite))
 	{
-		// Tag management - Admin only
+		// Tag management - events:write
 		adminTags.POST("", controller.CreateTag)           // POST /api/v1/admin/tags - Create tag
 		adminTags.GET("", controller.GetAllTags)           // GET /api/v1/admin/tags - Get all tags (with filters)
 		adminTags.GET("/:id", controller.GetTag)           // GET /api/v1/admin/tags/:id - Get tag by ID
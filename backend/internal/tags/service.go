@@ -19,11 +19,13 @@ import (
 type Service interface {
 	CreateTag(adminID uuid.UUID, req CreateTagRequest) (*TagResponse, error)
 	GetTagByID(id uuid.UUID) (*TagResponse, error)
-	GetTagBySlug(slug string) (*TagResponse, error)
+	GetTagBySlug(ctx context.Context, slug string) (*TagResponse, error)
 	UpdateTag(id uuid.UUID, adminID uuid.UUID, req UpdateTagRequest) (*TagResponse, error)
 	DeleteTag(id uuid.UUID, adminID uuid.UUID) error
 	GetAllTags(query TagListQuery) (*PaginatedTags, error)
-	GetActiveTags() ([]TagResponse, error)
+	GetActiveTags(ctx context.Context) ([]TagResponse, error)
+	GetTagTree(ctx context.Context) ([]TagNode, error)
+	GetTagChildren(ctx context.Context, slug string) ([]TagResponse, error)
 
 	AssignTagsToEvent(eventID uuid.UUID, tagNames []string) error
 	RemoveTagsFromEvent(eventID uuid.UUID, tagNames []string) error
@@ -34,7 +36,7 @@ type Service interface {
 
 type service struct {
 	repo        Repository
-	redisClient *redis.Client
+	redisClient redis.UniversalClient
 }
 
 func NewService(repo Repository) Service {
@@ -56,11 +58,14 @@ func (s *service) CreateTag(adminID uuid.UUID, req CreateTagRequest) (*TagRespon
 		return nil, errors.New("tag name must contain at least one alphanumeric character")
 	}
 
-	existingTag, err := s.repo.GetBySlug(slug)
+	existingTag, err := s.repo.GetBySlugIncludingDeleted(slug)
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, fmt.Errorf("failed to check existing tag: %w", err)
 	}
 	if existingTag != nil {
+		if existingTag.DeletedAt.Valid {
+			return nil, errors.New("a deleted tag with similar name exists, restore or purge it first")
+		}
 		return nil, errors.New("a tag with similar name already exists")
 	}
 
@@ -69,12 +74,25 @@ func (s *service) CreateTag(adminID uuid.UUID, req CreateTagRequest) (*TagRespon
 		color = "#6B7280" // Default gray color
 	}
 
+	var parentID *uuid.UUID
+	if req.ParentID != nil {
+		if parentID, err = parseParentID(*req.ParentID); err != nil {
+			return nil, err
+		}
+		if parentID != nil {
+			if err := s.validateParent(nil, *parentID); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	tag := &Tag{
 		Name:        name,
 		Slug:        slug,
 		Description: strings.TrimSpace(req.Description),
 		Color:       color,
 		IsActive:    true,
+		ParentID:    parentID,
 		CreatedBy:   adminID,
 	}
 
@@ -105,8 +123,7 @@ func (s *service) GetTagByID(id uuid.UUID) (*TagResponse, error) {
 	return &response, nil
 }
 
-func (s *service) GetTagBySlug(slug string) (*TagResponse, error) {
-	ctx := context.Background()
+func (s *service) GetTagBySlug(ctx context.Context, slug string) (*TagResponse, error) {
 	cacheKey := constants.BuildTagBySlugKey(slug)
 
 	// Trying cache
@@ -158,7 +175,7 @@ func (s *service) UpdateTag(id uuid.UUID, adminID uuid.UUID, req UpdateTagReques
 
 		// Check if another tag with same slug exists (excluding current tag)
 		if slug != currentTag.Slug {
-			existingTag, err := s.repo.GetBySlug(slug)
+			existingTag, err := s.repo.GetBySlugIncludingDeleted(slug)
 			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 				return nil, fmt.Errorf("failed to check existing tag: %w", err)
 			}
@@ -190,6 +207,21 @@ func (s *service) UpdateTag(id uuid.UUID, adminID uuid.UUID, req UpdateTagReques
 		updates["is_active"] = *req.IsActive
 	}
 
+	parentChanged := false
+	if req.ParentID != nil {
+		parentID, err := parseParentID(*req.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		if parentID != nil {
+			if err := s.validateParent(&id, *parentID); err != nil {
+				return nil, err
+			}
+		}
+		parentChanged = !sameParent(currentTag.ParentID, parentID)
+		updates["parent_id"] = parentID
+	}
+
 	updates["updated_at"] = time.Now()
 	updates["updated_by"] = adminID
 
@@ -204,6 +236,11 @@ func (s *service) UpdateTag(id uuid.UUID, adminID uuid.UUID, req UpdateTagReques
 
 		fmt.Printf("Warning: failed to invalidate tag cache after update: %v\n", err)
 	}
+	if parentChanged {
+		if err := s.invalidateFilteredEventLists(ctx); err != nil {
+			fmt.Printf("Warning: failed to invalidate tag-filtered event lists after moving tag: %v\n", err)
+		}
+	}
 
 	response := updatedTag.ToResponse()
 	return &response, nil
@@ -229,6 +266,15 @@ func (s *service) DeleteTag(id uuid.UUID, adminID uuid.UUID) error {
 		return fmt.Errorf("cannot delete tag as it is being used by %d event(s). Consider deactivating it instead", len(eventIDs))
 	}
 
+	children, err := s.repo.GetChildren(id, false)
+	if err != nil {
+		return fmt.Errorf("failed to check child tags: %w", err)
+	}
+
+	if len(children) > 0 {
+		return fmt.Errorf("cannot delete tag as it has %d child tag(s). Move them to another parent first", len(children))
+	}
+
 	if err := s.repo.Delete(id); err != nil {
 		return fmt.Errorf("failed to delete tag: %w", err)
 	}
@@ -275,8 +321,7 @@ func (s *service) GetAllTags(query TagListQuery) (*PaginatedTags, error) {
 	}, nil
 }
 
-func (s *service) GetActiveTags() ([]TagResponse, error) {
-	ctx := context.Background()
+func (s *service) GetActiveTags(ctx context.Context) ([]TagResponse, error) {
 	cacheKey := constants.CACHE_KEY_TAGS_ACTIVE
 
 	var cachedTags []TagResponse
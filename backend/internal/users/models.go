@@ -4,17 +4,27 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type User struct {
-	ID        uuid.UUID `json:"id" gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
-	FirstName string    `json:"first_name" gorm:"not null"`
-	LastName  string    `json:"last_name" gorm:"not null"`
-	Password  string    `json:"-" gorm:"not null"`
-	Role      Role      `json:"role" gorm:"index;not null;default:'USER'"`
-	Email     string    `json:"email" gorm:"uniqueIndex;not null"`
-	CreatedAt time.Time `json:"created_at" gorm:"index"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID        uuid.UUID      `json:"id" gorm:"primaryKey;type:uuid;default:uuid_generate_v4()"`
+	FirstName string         `json:"first_name" gorm:"not null"`
+	LastName  string         `json:"last_name" gorm:"not null"`
+	Password  string         `json:"-" gorm:"not null"`
+	Role      Role           `json:"role" gorm:"index;not null;default:'USER'"`
+	Email     string         `json:"email" gorm:"uniqueIndex;not null"`
+	CreatedAt time.Time      `json:"created_at" gorm:"index"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+
+	// Booking totals are also shown in this currency, empty shows event currencies only
+	DisplayCurrency string `json:"display_currency,omitempty" gorm:"type:varchar(3)"`
+
+	// Profile details users fill in themselves, all optional
+	Phone     string `json:"phone,omitempty" gorm:"type:varchar(20)"` // E.164
+	AvatarURL string `json:"avatar_url,omitempty" gorm:"type:varchar(500)"`
+	City      string `json:"city,omitempty" gorm:"type:varchar(100);index"` // Used to recommend nearby events
 }
 
 func IsValidRole(role string) bool {
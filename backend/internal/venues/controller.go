@@ -1,8 +1,15 @@
 package venues
 
 import (
+	"errors"
 	"evently/internal/shared/utils/response"
+	"fmt"
+	"io"
+	"mime"
 	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
@@ -17,6 +24,22 @@ func NewController(service Service) *Controller {
 
 //  VENUE TEMPLATES
 
+// CreateTemplate godoc
+//
+// @Summary      Create venue template (Admin)
+// @Description  Create a new venue template (Admin only)
+// @Description
+// @Description  Requires the `venues:manage` permission.
+// @Tags         Admin Venues
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        request body CreateTemplateRequest true "Request body"
+// @Success      201 {object} response.StandardApiResponse{data=VenueTemplate} "Venue template created successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/venue-templates [post]
 func (c *Controller) CreateTemplate(ctx *gin.Context) {
 	var req CreateTemplateRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
@@ -33,6 +56,21 @@ func (c *Controller) CreateTemplate(ctx *gin.Context) {
 	response.RespondJSON(ctx, "success", http.StatusCreated, "Template created successfully", template, nil)
 }
 
+// GetTemplate godoc
+//
+// @Summary      Get venue template by ID (Admin)
+// @Description  Requires the `venues:manage` permission.
+// @Tags         Admin Venues
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=VenueTemplate} "Venue template retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/venue-templates/{id} [get]
 func (c *Controller) GetTemplate(ctx *gin.Context) {
 	id := ctx.Param("id")
 	if id == "" {
@@ -53,6 +91,22 @@ func (c *Controller) GetTemplate(ctx *gin.Context) {
 	response.RespondJSON(ctx, "success", http.StatusOK, "Template retrieved successfully", template, nil)
 }
 
+// GetTemplates godoc
+//
+// @Summary      Get venue templates (Admin)
+// @Description  Get all venue templates (Admin only). Only the latest version of each template is listed unless all_versions is set.
+// @Description
+// @Description  Requires the `venues:manage` permission.
+// @Tags         Admin Venues
+// @Produce      json
+// @Security     Bearer
+// @Param        all_versions query boolean false "All versions" default(false)
+// @Success      200 {object} response.StandardApiResponse{data=PaginatedTemplates} "Venue templates retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/venue-templates [get]
 func (c *Controller) GetTemplates(ctx *gin.Context) {
 	var filters TemplateFilters
 	if err := ctx.ShouldBindQuery(&filters); err != nil {
@@ -69,6 +123,23 @@ func (c *Controller) GetTemplates(ctx *gin.Context) {
 	response.RespondJSON(ctx, "success", http.StatusOK, "Templates retrieved successfully", result, nil)
 }
 
+// UpdateTemplate godoc
+//
+// @Summary      Update venue template (Admin)
+// @Description  Requires the `venues:manage` permission.
+// @Tags         Admin Venues
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "ID" Format(uuid)
+// @Param        request body UpdateTemplateRequest true "Request body"
+// @Success      200 {object} response.StandardApiResponse{data=VenueTemplate} "Venue template updated successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/venue-templates/{id} [put]
 func (c *Controller) UpdateTemplate(ctx *gin.Context) {
 	id := ctx.Param("id")
 	if id == "" {
@@ -85,8 +156,11 @@ func (c *Controller) UpdateTemplate(ctx *gin.Context) {
 	template, err := c.service.UpdateTemplate(ctx.Request.Context(), id, req)
 	if err != nil {
 		statusCode := http.StatusInternalServerError
-		if err.Error() == "template not found" {
+		switch {
+		case err.Error() == "template not found":
 			statusCode = http.StatusNotFound
+		case err.Error() == "venue not found", strings.HasPrefix(err.Error(), "invalid venue ID"):
+			statusCode = http.StatusBadRequest
 		}
 		response.RespondJSON(ctx, "error", statusCode, "Failed to update template", nil, err.Error())
 		return
@@ -95,6 +169,21 @@ func (c *Controller) UpdateTemplate(ctx *gin.Context) {
 	response.RespondJSON(ctx, "success", http.StatusOK, "Template updated successfully", template, nil)
 }
 
+// DeleteTemplate godoc
+//
+// @Summary      Delete venue template (Admin)
+// @Description  Requires the `venues:manage` permission.
+// @Tags         Admin Venues
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse "Venue template deleted successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/venue-templates/{id} [delete]
 func (c *Controller) DeleteTemplate(ctx *gin.Context) {
 	id := ctx.Param("id")
 	if id == "" {
@@ -115,8 +204,585 @@ func (c *Controller) DeleteTemplate(ctx *gin.Context) {
 	response.RespondJSON(ctx, "success", http.StatusOK, "Template deleted successfully", nil, nil)
 }
 
+// DiffTemplates godoc
+//
+// @Summary      Diff venue template versions (Admin)
+// @Description  Compare two versions of a venue template. Sections are matched by name, or by identical
+// @Description  layout when renamed; seats are matched by row and position. Also reports booked seats of
+// @Description  upcoming events pinned to the older version that have no counterpart in the newer one.
+// @Description
+// @Description  Requires the `venues:manage` permission.
+// @Tags         Admin Venues
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "Older template version" Format(uuid)
+// @Param        targetId path string true "Newer template version" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=TemplateDiffResponse} "Template diff retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse "Invalid template IDs"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Template not found"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/venue-templates/{id}/diff/{targetId} [get]
+func (c *Controller) DiffTemplates(ctx *gin.Context) {
+	id := ctx.Param("id")
+	targetID := ctx.Param("targetId")
+	if id == "" || targetID == "" {
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Template IDs are required", nil, "missing template ID")
+		return
+	}
+
+	diff, err := c.service.DiffTemplates(ctx.Request.Context(), id, targetID)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		switch {
+		case err.Error() == "template not found":
+			statusCode = http.StatusNotFound
+		case err.Error() == "cannot diff a template against itself", strings.HasPrefix(err.Error(), "invalid template ID"):
+			statusCode = http.StatusBadRequest
+		}
+		response.RespondJSON(ctx, "error", statusCode, "Failed to diff templates", nil, err.Error())
+		return
+	}
+
+	response.RespondJSON(ctx, "success", http.StatusOK, "Template diff retrieved successfully", diff, nil)
+}
+
+//  TEMPLATE VERSIONS
+
+// DuplicateTemplate godoc
+//
+// @Summary      Duplicate venue template (Admin)
+// @Description  Copy a template version with its sections and seats into a new template, starting at version 1.
+// @Description
+// @Description  Requires the `venues:manage` permission.
+// @Tags         Admin Venues
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "ID" Format(uuid)
+// @Param        request body DuplicateTemplateRequest true "Request body"
+// @Success      201 {object} response.StandardApiResponse{data=VenueTemplate} "Template duplicated successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Template not found"
+// @Failure      409 {object} response.StandardApiResponse "A template with this name already exists"
+// @Router       /api/v1/admin/venue-templates/{id}/duplicate [post]
+func (c *Controller) DuplicateTemplate(ctx *gin.Context) {
+	id := ctx.Param("id")
+	if id == "" {
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Template ID is required", nil, "missing template ID")
+		return
+	}
+
+	var req DuplicateTemplateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid request data", nil, err.Error())
+		return
+	}
+
+	template, err := c.service.DuplicateTemplate(ctx.Request.Context(), id, req)
+	if err != nil {
+		response.RespondJSON(ctx, "error", templateVersionErrorStatus(err), "Failed to duplicate template", nil, err.Error())
+		return
+	}
+
+	response.RespondJSON(ctx, "success", http.StatusCreated, "Template duplicated successfully", template, nil)
+}
+
+// GetTemplateVersions godoc
+//
+// @Summary      List venue template versions (Admin)
+// @Description  Every version of the template, newest first. Events pin the version they were created on;
+// @Description  adding, editing or deleting sections of a version events use, or importing a layout into it,
+// @Description  creates the next version and leaves the events on theirs. Older versions can't be edited (409).
+// @Description
+// @Description  Requires the `venues:manage` permission.
+// @Tags         Admin Venues
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "Any version of the template" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=[]TemplateVersionResponse} "Template versions retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Template not found"
+// @Router       /api/v1/admin/venue-templates/{id}/versions [get]
+func (c *Controller) GetTemplateVersions(ctx *gin.Context) {
+	id := ctx.Param("id")
+	if id == "" {
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Template ID is required", nil, "missing template ID")
+		return
+	}
+
+	versions, err := c.service.GetTemplateVersions(ctx.Request.Context(), id)
+	if err != nil {
+		response.RespondJSON(ctx, "error", templateVersionErrorStatus(err), "Failed to get template versions", nil, err.Error())
+		return
+	}
+
+	response.RespondJSON(ctx, "success", http.StatusOK, "Template versions retrieved successfully", versions, nil)
+}
+
+// DiffTemplateVersions godoc
+//
+// @Summary      Diff venue template versions by number (Admin)
+// @Description  Compare two versions of a template, by default the latest version against the one before it.
+// @Description
+// @Description  Requires the `venues:manage` permission.
+// @Tags         Admin Venues
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "Any version of the template" Format(uuid)
+// @Param        from query integer false "Defaults to the version before to" minimum(1)
+// @Param        to query integer false "Defaults to the latest version" minimum(1)
+// @Success      200 {object} response.StandardApiResponse{data=TemplateDiffResponse} "Template diff retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse "Invalid version numbers"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Template or version not found"
+// @Router       /api/v1/admin/venue-templates/{id}/versions/diff [get]
+func (c *Controller) DiffTemplateVersions(ctx *gin.Context) {
+	id := ctx.Param("id")
+	if id == "" {
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Template ID is required", nil, "missing template ID")
+		return
+	}
+
+	versions := make([]int, 2)
+	for i, name := range []string{"from", "to"} {
+		value := ctx.Query(name)
+		if value == "" {
+			continue
+		}
+		version, err := strconv.Atoi(value)
+		if err != nil || version < 1 {
+			response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid version", nil, fmt.Sprintf("%s must be a version number", name))
+			return
+		}
+		versions[i] = version
+	}
+
+	diff, err := c.service.DiffTemplateVersions(ctx.Request.Context(), id, versions[0], versions[1])
+	if err != nil {
+		response.RespondJSON(ctx, "error", templateVersionErrorStatus(err), "Failed to diff template versions", nil, err.Error())
+		return
+	}
+
+	response.RespondJSON(ctx, "success", http.StatusOK, "Template diff retrieved successfully", diff, nil)
+}
+
+func templateVersionErrorStatus(err error) int {
+	switch {
+	case err.Error() == "template not found", err.Error() == "template version not found":
+		return http.StatusNotFound
+	case strings.HasPrefix(err.Error(), "template with name"), errors.Is(err, ErrTemplateSuperseded):
+		return http.StatusConflict
+	case strings.HasPrefix(err.Error(), "invalid"), err.Error() == "cannot diff a template against itself":
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// GetTemplateLayout godoc
+//
+// @Summary      Get venue template seat map (Admin)
+// @Description  Canvas, stage, section placement and seat coordinates of a template, for the layout editor
+// @Description
+// @Description  Requires the `venues:manage` permission.
+// @Tags         Admin Venues
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=TemplateLayoutResponse} "Template layout retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Template not found"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/venue-templates/{id}/layout [get]
+func (c *Controller) GetTemplateLayout(ctx *gin.Context) {
+	id := ctx.Param("id")
+	if id == "" {
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Template ID is required", nil, "missing template ID")
+		return
+	}
+
+	layout, err := c.service.GetTemplateLayout(ctx.Request.Context(), id)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		switch {
+		case err.Error() == "template not found":
+			statusCode = http.StatusNotFound
+		case strings.HasPrefix(err.Error(), "invalid template ID"):
+			statusCode = http.StatusBadRequest
+		}
+		response.RespondJSON(ctx, "error", statusCode, "Failed to get template layout", nil, err.Error())
+		return
+	}
+
+	response.RespondJSON(ctx, "success", http.StatusOK, "Template layout retrieved successfully", layout, nil)
+}
+
+// UpdateTemplateLayout godoc
+//
+// @Summary      Update venue template seat map (Admin)
+// @Description  Sets the canvas size, stage and section or seat coordinates in one transaction. Sections
+// @Description  and seats must belong to the template, and coordinates must fall inside the canvas when it
+// @Description  has a size. Cached event layouts for the template are invalidated.
+// @Description
+// @Description  Requires the `venues:manage` permission.
+// @Tags         Admin Venues
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "ID" Format(uuid)
+// @Param        request body UpdateTemplateLayoutRequest true "Request body"
+// @Success      200 {object} response.StandardApiResponse{data=TemplateLayoutResponse} "Template layout updated successfully"
+// @Failure      400 {object} response.StandardApiResponse "Invalid layout"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Template not found"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/venue-templates/{id}/layout [put]
+func (c *Controller) UpdateTemplateLayout(ctx *gin.Context) {
+	id := ctx.Param("id")
+	if id == "" {
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Template ID is required", nil, "missing template ID")
+		return
+	}
+
+	var req UpdateTemplateLayoutRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid request data", nil, err.Error())
+		return
+	}
+
+	layout, err := c.service.UpdateTemplateLayout(ctx.Request.Context(), id, req)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		switch {
+		case err.Error() == "template not found":
+			statusCode = http.StatusNotFound
+		case strings.HasPrefix(err.Error(), "invalid"):
+			statusCode = http.StatusBadRequest
+		}
+		response.RespondJSON(ctx, "error", statusCode, "Failed to update template layout", nil, err.Error())
+		return
+	}
+
+	response.RespondJSON(ctx, "success", http.StatusOK, "Template layout updated successfully", layout, nil)
+}
+
+// ImportTemplateLayout loads sections and seats from a CSV or JSON manifest, sent
+// as the request body or as a "file" form upload. With dry_run=true the
+// manifest is only validated.
+//
+// @Summary      Import venue template seats from a manifest (Admin)
+// @Description  Creates sections and seats from a CSV or JSON seat manifest in one transaction. Send the
+// @Description  manifest as the request body (text/csv or application/json) or as a 'file' form upload.
+// @Description  CSV manifests need a header row with section, row and seat_number columns, and may add
+// @Description  attributes (separated by semicolons), x and y. Sections that do not exist in the template
+// @Description  are created; seats listed for existing sections are added to them. Every row is
+// @Description  validated first and nothing is imported if any row has an error. Manifests are limited
+// @Description  to 10 MB and 50,000 seats.
+// @Description
+// @Description  Requires the `venues:manage` permission.
+// @Tags         Admin Venues
+// @Accept       json,multipart/form-data,text/csv
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "ID" Format(uuid)
+// @Param        dry_run query boolean false "Validate the manifest and report what would be imported without writing anything" default(false)
+// @Param        file formData file true "File"
+// @Success      200 {object} response.StandardApiResponse{data=LayoutImportResponse} "Layout manifest is valid (dry run)"
+// @Success      201 {object} response.StandardApiResponse{data=LayoutImportResponse} "Template layout imported successfully"
+// @Failure      400 {object} response.StandardApiResponse "Manifest could not be read"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Template not found"
+// @Failure      409 {object} response.StandardApiResponse
+// @Failure      413 {object} response.StandardApiResponse "Manifest is too large"
+// @Failure      415 {object} response.StandardApiResponse "Unsupported manifest format"
+// @Failure      422 {object} response.StandardApiResponse "Manifest has row errors; nothing was imported"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/venue-templates/{id}/layout/import [post]
+func (c *Controller) ImportTemplateLayout(ctx *gin.Context) {
+	id := ctx.Param("id")
+	if id == "" {
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Template ID is required", nil, "missing template ID")
+		return
+	}
+
+	dryRun, err := strconv.ParseBool(ctx.DefaultQuery("dry_run", "false"))
+	if err != nil {
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid dry_run value", nil, err.Error())
+		return
+	}
+
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, MaxLayoutManifestBytes)
+
+	format, manifest, err := layoutManifest(ctx)
+	if err != nil {
+		statusCode := http.StatusBadRequest
+		var maxBytesErr *http.MaxBytesError
+		switch {
+		case errors.As(err, &maxBytesErr):
+			statusCode = http.StatusRequestEntityTooLarge
+		case strings.HasPrefix(err.Error(), "unsupported manifest format"):
+			statusCode = http.StatusUnsupportedMediaType
+		}
+		response.RespondJSON(ctx, "error", statusCode, "Failed to read layout manifest", nil, err.Error())
+		return
+	}
+	defer manifest.Close()
+
+	report, err := c.service.ImportTemplateLayout(ctx.Request.Context(), id, format, manifest, dryRun)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		var maxBytesErr *http.MaxBytesError
+		switch {
+		case err.Error() == "template not found":
+			statusCode = http.StatusNotFound
+		case errors.As(err, &maxBytesErr), errors.Is(err, ErrManifestTooLarge):
+			statusCode = http.StatusRequestEntityTooLarge
+		case errors.Is(err, ErrTemplateSuperseded):
+			statusCode = http.StatusConflict
+		case strings.HasPrefix(err.Error(), "invalid"):
+			statusCode = http.StatusBadRequest
+		}
+		response.RespondJSON(ctx, "error", statusCode, "Failed to import template layout", nil, err.Error())
+		return
+	}
+
+	switch {
+	case report.ErrorCount > 0:
+		response.RespondJSON(ctx, "error", http.StatusUnprocessableEntity, "Layout manifest has errors; nothing was imported", report, nil)
+	case dryRun:
+		response.RespondJSON(ctx, "success", http.StatusOK, "Layout manifest is valid", report, nil)
+	default:
+		response.RespondJSON(ctx, "success", http.StatusCreated, "Template layout imported successfully", report, nil)
+	}
+}
+
+// layoutManifest picks the manifest and its format from the upload's file
+// extension or the request content type
+func layoutManifest(ctx *gin.Context) (ManifestFormat, io.ReadCloser, error) {
+	mediaType, _, _ := mime.ParseMediaType(ctx.GetHeader("Content-Type"))
+	if mediaType != "multipart/form-data" {
+		format, err := manifestFormat(mediaType)
+		if err != nil {
+			return "", nil, err
+		}
+		return format, ctx.Request.Body, nil
+	}
+
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		return "", nil, err
+	}
+
+	var format ManifestFormat
+	switch strings.ToLower(filepath.Ext(fileHeader.Filename)) {
+	case ".csv":
+		format = ManifestFormatCSV
+	case ".json":
+		format = ManifestFormatJSON
+	default:
+		partType, _, _ := mime.ParseMediaType(fileHeader.Header.Get("Content-Type"))
+		if format, err = manifestFormat(partType); err != nil {
+			return "", nil, err
+		}
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return "", nil, err
+	}
+	return format, file, nil
+}
+
+func manifestFormat(mediaType string) (ManifestFormat, error) {
+	switch mediaType {
+	case "text/csv", "application/csv":
+		return ManifestFormatCSV, nil
+	case "application/json":
+		return ManifestFormatJSON, nil
+	default:
+		return "", fmt.Errorf("unsupported manifest format %q: send text/csv or application/json", mediaType)
+	}
+}
+
+//  PHYSICAL VENUES
+
+// CreatePhysicalVenue godoc
+//
+// @Summary      Create physical venue (Admin)
+// @Description  Create a building or hall that venue templates are used in. Events on templates of the
+// @Description  same venue cannot overlap, counting each event's duration plus the changeover time.
+// @Description
+// @Description  Requires the `venues:manage` permission.
+// @Tags         Admin Venues
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        request body CreatePhysicalVenueRequest true "Request body"
+// @Success      201 {object} response.StandardApiResponse{data=PhysicalVenue} "Venue created successfully"
+// @Failure      400 {object} response.StandardApiResponse "Invalid request data or duplicate name"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/physical-venues [post]
+func (c *Controller) CreatePhysicalVenue(ctx *gin.Context) {
+	var req CreatePhysicalVenueRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid request data", nil, err.Error())
+		return
+	}
+
+	venue, err := c.service.CreatePhysicalVenue(ctx.Request.Context(), req)
+	if err != nil {
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Failed to create venue", nil, err.Error())
+		return
+	}
+
+	response.RespondJSON(ctx, "success", http.StatusCreated, "Venue created successfully", venue, nil)
+}
+
+// GetPhysicalVenues godoc
+//
+// @Summary      List physical venues (Admin)
+// @Description  Requires the `venues:manage` permission.
+// @Tags         Admin Venues
+// @Produce      json
+// @Security     Bearer
+// @Success      200 {object} response.StandardApiResponse{data=[]PhysicalVenue} "Venues retrieved successfully"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/physical-venues [get]
+func (c *Controller) GetPhysicalVenues(ctx *gin.Context) {
+	venues, err := c.service.GetPhysicalVenues(ctx.Request.Context())
+	if err != nil {
+		response.RespondJSON(ctx, "error", http.StatusInternalServerError, "Failed to get venues", nil, err.Error())
+		return
+	}
+
+	response.RespondJSON(ctx, "success", http.StatusOK, "Venues retrieved successfully", venues, nil)
+}
+
+// GetPhysicalVenue godoc
+//
+// @Summary      Get physical venue (Admin)
+// @Description  Requires the `venues:manage` permission.
+// @Tags         Admin Venues
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=PhysicalVenue} "Venue retrieved successfully"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Venue not found"
+// @Router       /api/v1/admin/physical-venues/{id} [get]
+func (c *Controller) GetPhysicalVenue(ctx *gin.Context) {
+	venue, err := c.service.GetPhysicalVenueByID(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		response.RespondJSON(ctx, "error", physicalVenueErrorStatus(err), "Failed to get venue", nil, err.Error())
+		return
+	}
+
+	response.RespondJSON(ctx, "success", http.StatusOK, "Venue retrieved successfully", venue, nil)
+}
+
+// UpdatePhysicalVenue godoc
+//
+// @Summary      Update physical venue (Admin)
+// @Description  Requires the `venues:manage` permission.
+// @Tags         Admin Venues
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "ID" Format(uuid)
+// @Param        request body UpdatePhysicalVenueRequest true "Request body"
+// @Success      200 {object} response.StandardApiResponse{data=PhysicalVenue} "Venue updated successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Venue not found"
+// @Router       /api/v1/admin/physical-venues/{id} [put]
+func (c *Controller) UpdatePhysicalVenue(ctx *gin.Context) {
+	var req UpdatePhysicalVenueRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid request data", nil, err.Error())
+		return
+	}
+
+	venue, err := c.service.UpdatePhysicalVenue(ctx.Request.Context(), ctx.Param("id"), req)
+	if err != nil {
+		response.RespondJSON(ctx, "error", physicalVenueErrorStatus(err), "Failed to update venue", nil, err.Error())
+		return
+	}
+
+	response.RespondJSON(ctx, "success", http.StatusOK, "Venue updated successfully", venue, nil)
+}
+
+// DeletePhysicalVenue godoc
+//
+// @Summary      Delete physical venue (Admin)
+// @Description  Only venues no template is linked to can be deleted
+// @Description
+// @Description  Requires the `venues:manage` permission.
+// @Tags         Admin Venues
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse "Venue deleted successfully"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Venue not found"
+// @Failure      409 {object} response.StandardApiResponse "Venue is still used by templates"
+// @Router       /api/v1/admin/physical-venues/{id} [delete]
+func (c *Controller) DeletePhysicalVenue(ctx *gin.Context) {
+	if err := c.service.DeletePhysicalVenue(ctx.Request.Context(), ctx.Param("id")); err != nil {
+		response.RespondJSON(ctx, "error", physicalVenueErrorStatus(err), "Failed to delete venue", nil, err.Error())
+		return
+	}
+
+	response.RespondJSON(ctx, "success", http.StatusOK, "Venue deleted successfully", nil, nil)
+}
+
+func physicalVenueErrorStatus(err error) int {
+	switch {
+	case err.Error() == "venue not found":
+		return http.StatusNotFound
+	case strings.HasPrefix(err.Error(), "invalid venue ID"), strings.HasPrefix(err.Error(), "venue with name"):
+		return http.StatusBadRequest
+	case strings.HasPrefix(err.Error(), "venue is used by"):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
+
 // VENUE SECTIONS
 
+// CreateSection godoc
+//
+// @Summary      Create venue section (Admin)
+// @Description  Seats are generated from the rows; total_seats must equal the rows times seats_per_row.
+// @Description
+// @Description  Requires the `venues:manage` permission.
+// @Tags         Admin Venues
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "ID" Format(uuid)
+// @Param        request body CreateSectionRequest true "Request body"
+// @Success      201 {object} response.StandardApiResponse{data=VenueSection} "Section created successfully"
+// @Failure      400 {object} response.StandardApiResponse "Invalid section, e.g. total_seats doesn't match the rows"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      409 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/venue-templates/{id}/sections [post]
 func (c *Controller) CreateSection(ctx *gin.Context) {
 	templateID := ctx.Param("id")
 	if templateID == "" {
@@ -132,13 +798,33 @@ func (c *Controller) CreateSection(ctx *gin.Context) {
 
 	section, err := c.service.CreateSection(ctx.Request.Context(), templateID, req)
 	if err != nil {
-		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Failed to create section", nil, err.Error())
+		statusCode := http.StatusBadRequest
+		if errors.Is(err, ErrTemplateSuperseded) {
+			statusCode = http.StatusConflict
+		}
+		response.RespondJSON(ctx, "error", statusCode, "Failed to create section", nil, err.Error())
 		return
 	}
 
 	response.RespondJSON(ctx, "success", http.StatusCreated, "Section created successfully", section, nil)
 }
 
+// GetSectionsByEventID godoc
+//
+// @Summary      Get event sections
+// @Description  Get all sections for a specific event
+// @Description
+// @Description  Roles: USER.
+// @Tags         Events
+// @Produce      json
+// @Security     Bearer
+// @Param        eventId path string true "Event ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=[]VenueSection} "Event sections retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/events/{eventId}/sections [get]
 func (c *Controller) GetSectionsByEventID(ctx *gin.Context) {
 	eventID := ctx.Param("eventId")
 	if eventID == "" {
@@ -155,6 +841,20 @@ func (c *Controller) GetSectionsByEventID(ctx *gin.Context) {
 	response.RespondJSON(ctx, "success", http.StatusOK, "Sections retrieved successfully", sections, nil)
 }
 
+// GetSectionsByTemplateID godoc
+//
+// @Summary      Get venue sections (Admin)
+// @Description  Requires the `venues:manage` permission.
+// @Tags         Admin Venues
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=[]VenueSection} "Sections retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/venue-templates/{id}/sections [get]
 func (c *Controller) GetSectionsByTemplateID(ctx *gin.Context) {
 	templateID := ctx.Param("id")
 	if templateID == "" {
@@ -171,6 +871,68 @@ func (c *Controller) GetSectionsByTemplateID(ctx *gin.Context) {
 	response.RespondJSON(ctx, "success", http.StatusOK, "Sections retrieved successfully", sections, nil)
 }
 
+// ReconcileTemplateSections godoc
+//
+// @Summary      Reconcile venue sections (Admin)
+// @Description  Checks every section of the template: total_seats against rows × seats_per_row and against
+// @Description  the seats the section has. Sections with no booked seats can be repaired with
+// @Description  `PUT /admin/sections/{id}` and `regenerate_seats: true`.
+// @Description
+// @Description  Requires the `venues:manage` permission.
+// @Tags         Admin Venues
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=SectionReconciliationResponse} "Section reconciliation retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Template not found"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/venue-templates/{id}/sections/reconciliation [get]
+func (c *Controller) ReconcileTemplateSections(ctx *gin.Context) {
+	templateID := ctx.Param("id")
+	if templateID == "" {
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Template ID is required", nil, "missing template ID")
+		return
+	}
+
+	report, err := c.service.ReconcileTemplateSections(ctx.Request.Context(), templateID)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		switch {
+		case err.Error() == "template not found":
+			statusCode = http.StatusNotFound
+		case strings.HasPrefix(err.Error(), "invalid"):
+			statusCode = http.StatusBadRequest
+		}
+		response.RespondJSON(ctx, "error", statusCode, "Failed to reconcile sections", nil, err.Error())
+		return
+	}
+
+	response.RespondJSON(ctx, "success", http.StatusOK, "Section reconciliation retrieved successfully", report, nil)
+}
+
+// GetVenueLayout godoc
+//
+// @Summary      Get event venue layout
+// @Description  Get the venue layout for a specific event. When the template has a seat map,
+// @Description  `venue_info.map` holds the canvas and stage, each section a `geometry` and each seat `x`/`y`.
+// @Description  Seats an admin blocked for the event have status `BLOCKED` and a `block_reason`.
+// @Description  Draft events are not found; admins use GET /admin/events/{eventId}/preview/venue/layout.
+// @Description
+// @Description  Roles: USER.
+// @Tags         Events
+// @Produce      json
+// @Security     Bearer
+// @Param        eventId path string true "Event ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=VenueLayoutResponse} "Venue layout retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/events/{eventId}/venue/layout [get]
 func (c *Controller) GetVenueLayout(ctx *gin.Context) {
 	eventID := ctx.Param("eventId")
 	if eventID == "" {
@@ -180,17 +942,69 @@ func (c *Controller) GetVenueLayout(ctx *gin.Context) {
 
 	layout, err := c.service.GetVenueLayout(ctx.Request.Context(), eventID)
 	if err != nil {
-		statusCode := http.StatusInternalServerError
-		if err.Error() == "no venue sections found for event" {
-			statusCode = http.StatusNotFound
-		}
-		response.RespondJSON(ctx, "error", statusCode, "Failed to get venue layout", nil, err.Error())
+		respondLayoutError(ctx, err)
 		return
 	}
 
 	response.RespondJSON(ctx, "success", http.StatusOK, "Venue layout retrieved successfully", layout, nil)
 }
 
+// PreviewVenueLayout returns the seat map of any event, drafts included
+//
+// @Summary      Preview event venue layout (Admin)
+// @Description  Seat map of any event, drafts included, in the same shape as GET /events/{eventId}/venue/layout. Never cached.
+// @Description
+// @Description  Requires the `events:write` permission.
+// @Tags         Admin Events
+// @Produce      json
+// @Security     Bearer
+// @Param        eventId path string true "Event ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=VenueLayoutResponse} "Venue layout preview retrieved successfully"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Event not found"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/events/{eventId}/preview/venue/layout [get]
+func (c *Controller) PreviewVenueLayout(ctx *gin.Context) {
+	layout, err := c.service.PreviewVenueLayout(ctx.Request.Context(), ctx.Param("eventId"))
+	if err != nil {
+		respondLayoutError(ctx, err)
+		return
+	}
+
+	response.RespondJSON(ctx, "success", http.StatusOK, "Venue layout preview retrieved successfully", layout, nil)
+}
+
+func respondLayoutError(ctx *gin.Context, err error) {
+	statusCode := http.StatusInternalServerError
+	if errors.Is(err, ErrEventNotFound) || err.Error() == "no venue sections found for event" {
+		statusCode = http.StatusNotFound
+	}
+	response.RespondJSON(ctx, "error", statusCode, "Failed to get venue layout", nil, err.Error())
+}
+
+// UpdateSection godoc
+//
+// @Summary      Update venue section (Admin)
+// @Description  Changing row_start, row_end or seats_per_row deletes the section's seats and generates them
+// @Description  again, and has to be confirmed with regenerate_seats. Seat attributes and map positions are
+// @Description  not kept. Regeneration is refused when any seat of the section is booked.
+// @Description
+// @Description  Requires the `venues:manage` permission.
+// @Tags         Admin Venues
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "ID" Format(uuid)
+// @Param        request body UpdateSectionRequest true "Request body"
+// @Success      200 {object} response.StandardApiResponse{data=VenueSection} "Section updated successfully"
+// @Failure      400 {object} response.StandardApiResponse "Invalid update, or rows changed without regenerate_seats"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Section not found"
+// @Failure      409 {object} response.StandardApiResponse "Seats are booked, or the template version has been superseded"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/sections/{id} [put]
 func (c *Controller) UpdateSection(ctx *gin.Context) {
 	id := ctx.Param("id")
 	if id == "" {
@@ -207,8 +1021,13 @@ func (c *Controller) UpdateSection(ctx *gin.Context) {
 	section, err := c.service.UpdateSection(ctx.Request.Context(), id, req)
 	if err != nil {
 		statusCode := http.StatusInternalServerError
-		if err.Error() == "section not found" {
+		switch {
+		case err.Error() == "section not found":
 			statusCode = http.StatusNotFound
+		case errors.Is(err, ErrTemplateSuperseded), errors.Is(err, ErrSectionHasBookings):
+			statusCode = http.StatusConflict
+		case strings.HasPrefix(err.Error(), "invalid"):
+			statusCode = http.StatusBadRequest
 		}
 		response.RespondJSON(ctx, "error", statusCode, "Failed to update section", nil, err.Error())
 		return
@@ -217,6 +1036,22 @@ func (c *Controller) UpdateSection(ctx *gin.Context) {
 	response.RespondJSON(ctx, "success", http.StatusOK, "Section updated successfully", section, nil)
 }
 
+// DeleteSection godoc
+//
+// @Summary      Delete venue section (Admin)
+// @Description  Requires the `venues:manage` permission.
+// @Tags         Admin Venues
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse "Section deleted successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Section not found"
+// @Failure      409 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/sections/{id} [delete]
 func (c *Controller) DeleteSection(ctx *gin.Context) {
 	id := ctx.Param("id")
 	if id == "" {
@@ -227,8 +1062,11 @@ func (c *Controller) DeleteSection(ctx *gin.Context) {
 	err := c.service.DeleteSection(ctx.Request.Context(), id)
 	if err != nil {
 		statusCode := http.StatusInternalServerError
-		if err.Error() == "section not found" {
+		switch {
+		case err.Error() == "section not found":
 			statusCode = http.StatusNotFound
+		case errors.Is(err, ErrTemplateSuperseded):
+			statusCode = http.StatusConflict
 		}
 		response.RespondJSON(ctx, "error", statusCode, "Failed to delete section", nil, err.Error())
 		return
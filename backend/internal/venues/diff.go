@@ -0,0 +1,241 @@
+package venues
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// Sections are matched between versions by name. Unmatched sections with the
+// same row range and seat count are reported as renames rather than as a
+// removal plus an addition. Seats within a matched section are matched by
+// row and position, so a changed seat number is reported as a rename.
+
+type seatKey struct {
+	Row      string
+	Position int
+}
+
+func sectionLayoutKey(section VenueSection) string {
+	return fmt.Sprintf("%s|%s|%d|%d", section.RowStart, section.RowEnd, section.SeatsPerRow, section.TotalSeats)
+}
+
+// matchSections pairs sections of the old version with sections of the new one.
+// The returned map goes from old section ID to new section.
+func matchSections(from, to []VenueSection) (map[uuid.UUID]VenueSection, []VenueSection, []VenueSection, []SectionRename) {
+	matched := make(map[uuid.UUID]VenueSection)
+	toByName := make(map[string]VenueSection, len(to))
+	for _, section := range to {
+		toByName[section.Name] = section
+	}
+
+	usedTo := make(map[uuid.UUID]bool)
+	var unmatchedFrom []VenueSection
+	for _, section := range from {
+		if target, ok := toByName[section.Name]; ok {
+			matched[section.ID] = target
+			usedTo[target.ID] = true
+			continue
+		}
+		unmatchedFrom = append(unmatchedFrom, section)
+	}
+
+	var unmatchedTo []VenueSection
+	for _, section := range to {
+		if !usedTo[section.ID] {
+			unmatchedTo = append(unmatchedTo, section)
+		}
+	}
+
+	// Pair leftovers with an identical layout as renames
+	var removed []VenueSection
+	var renames []SectionRename
+	for _, section := range unmatchedFrom {
+		renamed := false
+		for i, candidate := range unmatchedTo {
+			if sectionLayoutKey(candidate) != sectionLayoutKey(section) {
+				continue
+			}
+			matched[section.ID] = candidate
+			renames = append(renames, SectionRename{
+				FromSectionID: section.ID.String(),
+				ToSectionID:   candidate.ID.String(),
+				FromName:      section.Name,
+				ToName:        candidate.Name,
+			})
+			unmatchedTo = append(unmatchedTo[:i], unmatchedTo[i+1:]...)
+			renamed = true
+			break
+		}
+		if !renamed {
+			removed = append(removed, section)
+		}
+	}
+
+	return matched, removed, unmatchedTo, renames
+}
+
+// diffSeats compares the seats of two matched sections
+func diffSeats(from, to VenueSection) SectionSeatDiff {
+	diff := SectionSeatDiff{
+		FromSectionID: from.ID.String(),
+		ToSectionID:   to.ID.String(),
+		Name:          to.Name,
+		AddedSeats:    []SeatDiff{},
+		RemovedSeats:  []SeatDiff{},
+		RenamedSeats:  []SeatRename{},
+	}
+
+	toSeats := seatsByPosition(to.Seats)
+	fromSeats := seatsByPosition(from.Seats)
+
+	for _, seat := range from.Seats {
+		target, ok := toSeats[seatKey{Row: seat.Row, Position: seat.Position}]
+		if !ok {
+			diff.RemovedSeats = append(diff.RemovedSeats, toSeatDiff(seat))
+			continue
+		}
+		if target.SeatNumber != seat.SeatNumber {
+			diff.RenamedSeats = append(diff.RenamedSeats, SeatRename{
+				FromSeatID:     seat.ID.String(),
+				ToSeatID:       target.ID.String(),
+				FromSeatNumber: seat.SeatNumber,
+				ToSeatNumber:   target.SeatNumber,
+				Row:            seat.Row,
+				Position:       seat.Position,
+			})
+		}
+	}
+
+	for _, seat := range to.Seats {
+		if _, ok := fromSeats[seatKey{Row: seat.Row, Position: seat.Position}]; !ok {
+			diff.AddedSeats = append(diff.AddedSeats, toSeatDiff(seat))
+		}
+	}
+
+	return diff
+}
+
+func (d SectionSeatDiff) hasChanges() bool {
+	return len(d.AddedSeats) > 0 || len(d.RemovedSeats) > 0 || len(d.RenamedSeats) > 0
+}
+
+func seatsByPosition(seats []Seat) map[seatKey]Seat {
+	result := make(map[seatKey]Seat, len(seats))
+	for _, seat := range seats {
+		result[seatKey{Row: seat.Row, Position: seat.Position}] = seat
+	}
+	return result
+}
+
+func toSeatDiff(seat Seat) SeatDiff {
+	return SeatDiff{
+		SeatID:     seat.ID.String(),
+		SeatNumber: seat.SeatNumber,
+		Row:        seat.Row,
+		Position:   seat.Position,
+	}
+}
+
+func toSectionDiff(section VenueSection) SectionDiff {
+	return SectionDiff{
+		SectionID:  section.ID.String(),
+		Name:       section.Name,
+		TotalSeats: section.TotalSeats,
+	}
+}
+
+// buildTemplateDiff diffs two versions of a template and reports which booked
+// seats of events pinned to the old version would be orphaned by the switch
+func buildTemplateDiff(fromID, toID uuid.UUID, from, to []VenueSection, booked []TemplateBookedSeat) *TemplateDiffResponse {
+	matched, removed, added, renames := matchSections(from, to)
+
+	diff := &TemplateDiffResponse{
+		FromTemplateID:  fromID.String(),
+		ToTemplateID:    toID.String(),
+		AddedSections:   []SectionDiff{},
+		RemovedSections: []SectionDiff{},
+		RenamedSections: []SectionRename{},
+		ChangedSections: []SectionSeatDiff{},
+		AffectedEvents:  []EventSeatImpact{},
+	}
+	if renames != nil {
+		diff.RenamedSections = renames
+	}
+
+	for _, section := range added {
+		diff.AddedSections = append(diff.AddedSections, toSectionDiff(section))
+		diff.Summary.SeatsAdded += len(section.Seats)
+	}
+	for _, section := range removed {
+		diff.RemovedSections = append(diff.RemovedSections, toSectionDiff(section))
+		diff.Summary.SeatsRemoved += len(section.Seats)
+	}
+
+	// Seat positions available in the new version, per old section
+	targetSeats := make(map[uuid.UUID]map[seatKey]Seat, len(matched))
+	for _, section := range from {
+		target, ok := matched[section.ID]
+		if !ok {
+			continue
+		}
+		targetSeats[section.ID] = seatsByPosition(target.Seats)
+
+		seatDiff := diffSeats(section, target)
+		if seatDiff.hasChanges() {
+			diff.ChangedSections = append(diff.ChangedSections, seatDiff)
+			diff.Summary.SeatsAdded += len(seatDiff.AddedSeats)
+			diff.Summary.SeatsRemoved += len(seatDiff.RemovedSeats)
+			diff.Summary.SeatsRenamed += len(seatDiff.RenamedSeats)
+		}
+	}
+
+	diff.Summary.SectionsAdded = len(diff.AddedSections)
+	diff.Summary.SectionsRemoved = len(diff.RemovedSections)
+	diff.Summary.SectionsRenamed = len(diff.RenamedSections)
+
+	impacts := make(map[uuid.UUID]*EventSeatImpact)
+	var eventOrder []uuid.UUID
+	for _, seat := range booked {
+		impact, ok := impacts[seat.EventID]
+		if !ok {
+			impact = &EventSeatImpact{
+				EventID:       seat.EventID.String(),
+				EventName:     seat.EventName,
+				EventDate:     seat.EventDate,
+				OrphanedSeats: []OrphanedSeat{},
+			}
+			impacts[seat.EventID] = impact
+			eventOrder = append(eventOrder, seat.EventID)
+		}
+		impact.BookedSeats++
+
+		if seatsInTarget, ok := targetSeats[seat.SectionID]; ok {
+			if _, exists := seatsInTarget[seatKey{Row: seat.Row, Position: seat.Position}]; exists {
+				continue
+			}
+		}
+
+		impact.OrphanedSeats = append(impact.OrphanedSeats, OrphanedSeat{
+			BookingID:   seat.BookingID.String(),
+			SeatID:      seat.SeatID.String(),
+			SectionName: seat.SectionName,
+			SeatNumber:  seat.SeatNumber,
+			Row:         seat.Row,
+			Position:    seat.Position,
+		})
+		diff.Summary.OrphanedSeats++
+	}
+
+	for _, eventID := range eventOrder {
+		diff.AffectedEvents = append(diff.AffectedEvents, *impacts[eventID])
+	}
+
+	// Events with the most orphaned seats first
+	sort.SliceStable(diff.AffectedEvents, func(i, j int) bool {
+		return len(diff.AffectedEvents[i].OrphanedSeats) > len(diff.AffectedEvents[j].OrphanedSeats)
+	})
+
+	return diff
+}
@@ -2,51 +2,46 @@ package venues
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"time"
 
 	"evently/internal/shared/utils/constants"
+	"evently/pkg/cache"
 
 	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
 )
 
-func SetCache(ctx context.Context, redisClient *redis.Client, key string, value interface{}, ttl time.Duration) error {
+func SetCache(ctx context.Context, redisClient redis.UniversalClient, key string, value interface{}, ttl time.Duration) error {
 	if redisClient == nil {
 		return nil // Skip caching if Redis not available
 	}
 
-	data, err := json.Marshal(value)
-	if err != nil {
-		return fmt.Errorf("failed to marshal cache data: %w", err)
-	}
-
-	return redisClient.Set(ctx, key, data, ttl).Err()
+	return cache.SetJSON(ctx, redisClient, key, value, ttl)
 }
 
-func GetCache(ctx context.Context, redisClient *redis.Client, key string, dest interface{}) error {
+func GetCache(ctx context.Context, redisClient redis.UniversalClient, key string, dest interface{}) error {
 	if redisClient == nil {
 		return fmt.Errorf("redis client not available")
 	}
 
-	data, err := redisClient.Get(ctx, key).Result()
-	if err != nil {
-		return err
-	}
-
-	return json.Unmarshal([]byte(data), dest)
+	return cache.GetJSON(ctx, redisClient, key, dest)
 }
 
-func DeleteCache(ctx context.Context, redisClient *redis.Client, keys ...string) error {
+func DeleteCache(ctx context.Context, redisClient redis.UniversalClient, keys ...string) error {
 	if redisClient == nil || len(keys) == 0 {
 		return nil
 	}
 
-	return redisClient.Del(ctx, keys...).Err()
+	if _, err := cache.DeleteKeys(ctx, redisClient, keys...); err != nil {
+		return err
+	}
+
+	cache.Local().Invalidate(ctx, keys...)
+	return nil
 }
 
-func InvalidateVenueCache(ctx context.Context, redisClient *redis.Client, templateID *uuid.UUID) error {
+func InvalidateVenueCache(ctx context.Context, redisClient redis.UniversalClient, templateID *uuid.UUID) error {
 	if redisClient == nil {
 		return nil
 	}
@@ -60,17 +55,30 @@ func InvalidateVenueCache(ctx context.Context, redisClient *redis.Client, templa
 		patterns = append(patterns, constants.CACHE_KEY_VENUE_SECTIONS+templateID.String()+"*")
 	}
 
+	return deletePatterns(ctx, redisClient, patterns)
+}
+
+// InvalidateEventPriceCache drops the cached details, layout and seat availability
+// of an event whose section pricing changed
+func InvalidateEventPriceCache(ctx context.Context, redisClient redis.UniversalClient, eventID uuid.UUID) error {
+	if redisClient == nil {
+		return nil
+	}
+
+	return deletePatterns(ctx, redisClient, constants.BuildEventPriceCachePatterns(eventID.String()))
+}
+
+func deletePatterns(ctx context.Context, redisClient redis.UniversalClient, patterns []string) error {
 	for _, pattern := range patterns {
-		keys, err := redisClient.Keys(ctx, pattern).Result()
+		keys, err := cache.MatchKeys(ctx, redisClient, pattern)
 		if err != nil {
 			return err
 		}
-		if len(keys) > 0 {
-			if err := redisClient.Del(ctx, keys...).Err(); err != nil {
-				return err
-			}
+		if _, err := cache.DeleteKeys(ctx, redisClient, keys...); err != nil {
+			return err
 		}
 	}
 
+	cache.Local().InvalidatePattern(ctx, patterns...)
 	return nil
 }
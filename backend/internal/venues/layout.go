@@ -0,0 +1,203 @@
+package venues
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Seat map geometry is optional. Templates without a map keep rendering from
+// row counts, and a map can be filled in a few sections at a time.
+
+func (s *service) GetTemplateLayout(ctx context.Context, id string) (*TemplateLayoutResponse, error) {
+	templateID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template ID: %w", err)
+	}
+
+	template, err := s.repo.GetTemplateByID(ctx, templateID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("template not found")
+		}
+		return nil, fmt.Errorf("failed to get template: %w", err)
+	}
+
+	sections, err := s.repo.GetSectionsWithSeats(ctx, templateID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sections: %w", err)
+	}
+
+	layout := &TemplateLayoutResponse{
+		TemplateID:   template.ID.String(),
+		TemplateName: template.Name,
+		LayoutType:   template.LayoutType,
+		Map:          template.MapGeometry(),
+		Sections:     make([]SectionLayoutResponse, 0, len(sections)),
+	}
+	for _, section := range sections {
+		sectionLayout := SectionLayoutResponse{
+			SectionID: section.ID.String(),
+			Name:      section.Name,
+			Geometry:  section.Geometry(),
+			Seats:     make([]SeatLayoutResponse, 0, len(section.Seats)),
+		}
+		for _, seat := range section.Seats {
+			sectionLayout.Seats = append(sectionLayout.Seats, SeatLayoutResponse{
+				SeatID:     seat.ID.String(),
+				SeatNumber: seat.SeatNumber,
+				Row:        seat.Row,
+				Position:   seat.Position,
+				X:          seat.MapX,
+				Y:          seat.MapY,
+			})
+		}
+		layout.Sections = append(layout.Sections, sectionLayout)
+	}
+
+	return layout, nil
+}
+
+func (s *service) UpdateTemplateLayout(ctx context.Context, id string, req UpdateTemplateLayoutRequest) (*TemplateLayoutResponse, error) {
+	templateID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template ID: %w", err)
+	}
+
+	template, err := s.repo.GetTemplateByID(ctx, templateID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("template not found")
+		}
+		return nil, fmt.Errorf("failed to get template: %w", err)
+	}
+
+	if req.Stage != nil && req.RemoveStage {
+		return nil, fmt.Errorf("invalid layout: stage and remove_stage cannot both be set")
+	}
+
+	templateUpdates := make(map[string]interface{})
+	if req.MapWidth != nil {
+		templateUpdates["map_width"] = *req.MapWidth
+		template.MapWidth = req.MapWidth
+	}
+	if req.MapHeight != nil {
+		templateUpdates["map_height"] = *req.MapHeight
+		template.MapHeight = req.MapHeight
+	}
+
+	// Coordinates are checked against the canvas after this update
+	canvas := mapBounds{width: template.MapWidth, height: template.MapHeight}
+
+	if req.Stage != nil {
+		if err := canvas.check("stage", *req.Stage.X, *req.Stage.Y); err != nil {
+			return nil, err
+		}
+		templateUpdates["stage_type"] = req.Stage.Type
+		templateUpdates["stage_x"] = *req.Stage.X
+		templateUpdates["stage_y"] = *req.Stage.Y
+		templateUpdates["stage_width"] = req.Stage.Width
+		templateUpdates["stage_height"] = req.Stage.Height
+	}
+	if req.RemoveStage {
+		templateUpdates["stage_type"] = ""
+		templateUpdates["stage_x"] = nil
+		templateUpdates["stage_y"] = nil
+		templateUpdates["stage_width"] = nil
+		templateUpdates["stage_height"] = nil
+	}
+
+	sections, err := s.repo.GetSectionsWithSeats(ctx, templateID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sections: %w", err)
+	}
+
+	sectionSeats := make(map[uuid.UUID]map[uuid.UUID]bool, len(sections))
+	for _, section := range sections {
+		seatIDs := make(map[uuid.UUID]bool, len(section.Seats))
+		for _, seat := range section.Seats {
+			seatIDs[seat.ID] = true
+		}
+		sectionSeats[section.ID] = seatIDs
+	}
+
+	sectionUpdates := make(map[uuid.UUID]map[string]interface{}, len(req.Sections))
+	var seatPositions []SeatPosition
+	for _, sectionReq := range req.Sections {
+		sectionID, err := uuid.Parse(sectionReq.SectionID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid section ID: %w", err)
+		}
+		seatIDs, ok := sectionSeats[sectionID]
+		if !ok {
+			return nil, fmt.Errorf("invalid layout: section %s is not part of this template", sectionID)
+		}
+		if _, duplicate := sectionUpdates[sectionID]; duplicate {
+			return nil, fmt.Errorf("invalid layout: section %s is listed more than once", sectionID)
+		}
+
+		if (sectionReq.X == nil) != (sectionReq.Y == nil) {
+			return nil, fmt.Errorf("invalid layout: section %s needs both x and y", sectionID)
+		}
+
+		updates := make(map[string]interface{})
+		if sectionReq.X != nil {
+			if err := canvas.check("section "+sectionID.String(), *sectionReq.X, *sectionReq.Y); err != nil {
+				return nil, err
+			}
+			updates["map_x"] = *sectionReq.X
+			updates["map_y"] = *sectionReq.Y
+		}
+		if sectionReq.Rotation != nil {
+			updates["rotation"] = *sectionReq.Rotation
+		}
+		if sectionReq.Curvature != nil {
+			updates["curvature"] = *sectionReq.Curvature
+		}
+		sectionUpdates[sectionID] = updates
+
+		for _, seatReq := range sectionReq.Seats {
+			seatID, err := uuid.Parse(seatReq.SeatID)
+			if err != nil {
+				return nil, fmt.Errorf("invalid seat ID: %w", err)
+			}
+			if !seatIDs[seatID] {
+				return nil, fmt.Errorf("invalid layout: seat %s is not in section %s", seatID, sectionID)
+			}
+			if err := canvas.check("seat "+seatID.String(), *seatReq.X, *seatReq.Y); err != nil {
+				return nil, err
+			}
+			seatPositions = append(seatPositions, SeatPosition{SeatID: seatID, X: *seatReq.X, Y: *seatReq.Y})
+		}
+	}
+
+	if err := s.repo.SaveTemplateLayout(ctx, templateID, templateUpdates, sectionUpdates, seatPositions); err != nil {
+		return nil, fmt.Errorf("failed to save layout: %w", err)
+	}
+
+	// Event layouts embed the geometry, so they go too
+	if err := InvalidateVenueCache(ctx, s.redisClient, &templateID); err != nil {
+		log.WarnContext(ctx, "Failed to invalidate venue cache after layout update", "error", err)
+	}
+
+	return s.GetTemplateLayout(ctx, id)
+}
+
+// mapBounds rejects coordinates outside the canvas, when the template has one
+type mapBounds struct {
+	width  *float64
+	height *float64
+}
+
+func (b mapBounds) check(what string, x, y float64) error {
+	if x < 0 || y < 0 {
+		return fmt.Errorf("invalid layout: %s has negative coordinates", what)
+	}
+	if (b.width != nil && x > *b.width) || (b.height != nil && y > *b.height) {
+		return fmt.Errorf("invalid layout: %s is outside the map", what)
+	}
+	return nil
+}
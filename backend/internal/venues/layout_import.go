@@ -0,0 +1,483 @@
+package venues
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Layout manifests let admins load a large venue in one request instead of
+// creating sections and seats by hand. A manifest lists one seat per entry;
+// sections that do not exist in the template yet are created from it.
+
+// ManifestFormat is the encoding of a seat manifest
+type ManifestFormat string
+
+const (
+	ManifestFormatCSV  ManifestFormat = "csv"
+	ManifestFormatJSON ManifestFormat = "json"
+)
+
+const (
+	// MaxLayoutManifestBytes caps the size of an uploaded manifest
+	MaxLayoutManifestBytes = 10 << 20
+	maxLayoutManifestSeats = 50000
+	maxLayoutImportErrors  = 500
+	layoutImportBatchSize  = 1000
+)
+
+// CSV manifests separate seat attributes with this character
+const manifestAttributeSeparator = ";"
+
+var ErrManifestTooLarge = fmt.Errorf("manifest has more than %d seats", maxLayoutManifestSeats)
+
+// Seat attributes a manifest can set
+const (
+	SeatAttributeWheelchairAccessible = "wheelchair_accessible"
+	SeatAttributeCompanionSeat        = "companion_seat"
+	SeatAttributeRestrictedView       = "restricted_view"
+	SeatAttributeAisle                = "aisle"
+)
+
+var manifestColumns = map[string]bool{
+	"section":     true,
+	"row":         true,
+	"seat_number": true,
+	"attributes":  true,
+	"x":           true,
+	"y":           true,
+}
+
+func (s *service) ImportTemplateLayout(ctx context.Context, id string, format ManifestFormat, manifest io.Reader, dryRun bool) (*LayoutImportResponse, error) {
+	templateID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template ID: %w", err)
+	}
+
+	template, err := s.repo.GetTemplateByID(ctx, templateID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("template not found")
+		}
+		return nil, fmt.Errorf("failed to get template: %w", err)
+	}
+
+	report := &LayoutImportResponse{
+		TemplateID: templateID.String(),
+		DryRun:     dryRun,
+		Sections:   []LayoutImportSectionSummary{},
+	}
+
+	var entries []LayoutImportSeat
+	switch format {
+	case ManifestFormatCSV:
+		entries, err = parseCSVManifest(manifest, report)
+	case ManifestFormatJSON:
+		entries, err = parseJSONManifest(manifest)
+	default:
+		err = fmt.Errorf("invalid manifest: unsupported format %q", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 && report.ErrorCount == 0 {
+		return nil, fmt.Errorf("invalid manifest: no seats listed")
+	}
+
+	existing, err := s.repo.GetSectionsWithSeats(ctx, templateID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sections: %w", err)
+	}
+
+	plan := newLayoutImportPlan(templateID, existing)
+	canvas := mapBounds{width: template.MapWidth, height: template.MapHeight}
+	for _, entry := range entries {
+		if err := plan.add(entry, canvas); err != nil {
+			report.addError(entry.Line, err.Error())
+		}
+	}
+
+	plan.finish()
+	report.Sections = plan.summary(dryRun)
+	sort.SliceStable(report.Errors, func(i, j int) bool { return report.Errors[i].Line < report.Errors[j].Line })
+
+	// Nothing is written unless the whole manifest is valid
+	if report.ErrorCount > 0 {
+		return report, nil
+	}
+	report.SectionsCreated = len(plan.newSections)
+	report.SectionsUpdated = len(plan.seatTotals)
+	report.SeatsCreated = len(plan.seats)
+	if dryRun {
+		return report, nil
+	}
+
+	// Seats are added to a new version when events use this one
+	target, sectionIDs, err := s.editableVersion(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+	if target.ID != templateID {
+		plan.retarget(target.ID, sectionIDs)
+		templateID = target.ID
+		report.TemplateID = templateID.String()
+		report.Sections = plan.summary(dryRun)
+	}
+
+	if err := s.repo.ImportTemplateLayout(ctx, plan.newSections, plan.seatTotals, plan.seats); err != nil {
+		return nil, fmt.Errorf("failed to import layout: %w", err)
+	}
+
+	if err := InvalidateVenueCache(ctx, s.redisClient, &templateID); err != nil {
+		log.WarnContext(ctx, "Failed to invalidate venue cache after layout import", "error", err)
+	}
+
+	return report, nil
+}
+
+// parseCSVManifest reads a manifest with a header row. Rows that cannot be
+// read are reported against their line and skipped.
+func parseCSVManifest(manifest io.Reader, report *LayoutImportResponse) ([]LayoutImportSeat, error) {
+	reader := csv.NewReader(manifest)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("invalid manifest: file is empty")
+		}
+		return nil, fmt.Errorf("invalid manifest: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if !manifestColumns[name] {
+			return nil, fmt.Errorf("invalid manifest: unknown column %q", name)
+		}
+		if _, duplicate := columns[name]; duplicate {
+			return nil, fmt.Errorf("invalid manifest: column %q is listed more than once", name)
+		}
+		columns[name] = i
+	}
+	for _, required := range []string{"section", "row", "seat_number"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("invalid manifest: missing column %q", required)
+		}
+	}
+
+	var entries []LayoutImportSeat
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return nil, fmt.Errorf("failed to read manifest: %w", err)
+			}
+			report.addError(parseErr.Line, parseErr.Err.Error())
+			continue
+		}
+		line, _ := reader.FieldPos(0)
+		if len(entries)+report.ErrorCount >= maxLayoutManifestSeats {
+			return nil, ErrManifestTooLarge
+		}
+		if len(record) != len(header) {
+			report.addError(line, fmt.Sprintf("expected %d fields, got %d", len(header), len(record)))
+			continue
+		}
+
+		field := func(name string) string {
+			if i, ok := columns[name]; ok {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		entry := LayoutImportSeat{
+			Line:       line,
+			Section:    field("section"),
+			Row:        field("row"),
+			SeatNumber: field("seat_number"),
+		}
+		if attributes := field("attributes"); attributes != "" {
+			for _, attribute := range strings.Split(attributes, manifestAttributeSeparator) {
+				entry.Attributes = append(entry.Attributes, strings.TrimSpace(attribute))
+			}
+		}
+
+		var coordinateErr error
+		entry.X, coordinateErr = parseCoordinate("x", field("x"))
+		if coordinateErr == nil {
+			entry.Y, coordinateErr = parseCoordinate("y", field("y"))
+		}
+		if coordinateErr != nil {
+			report.addError(line, coordinateErr.Error())
+			continue
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+func parseCoordinate(name, value string) (*float64, error) {
+	if value == "" {
+		return nil, nil
+	}
+	coordinate, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not a number", name)
+	}
+	return &coordinate, nil
+}
+
+// parseJSONManifest reads a LayoutImportRequest. Entries are numbered from 1
+// in error reports.
+func parseJSONManifest(manifest io.Reader) ([]LayoutImportSeat, error) {
+	var req LayoutImportRequest
+	if err := json.NewDecoder(manifest).Decode(&req); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("invalid manifest: %w", err)
+	}
+	if len(req.Seats) > maxLayoutManifestSeats {
+		return nil, ErrManifestTooLarge
+	}
+
+	for i := range req.Seats {
+		req.Seats[i].Line = i + 1
+		req.Seats[i].Section = strings.TrimSpace(req.Seats[i].Section)
+		req.Seats[i].Row = strings.TrimSpace(req.Seats[i].Row)
+		req.Seats[i].SeatNumber = strings.TrimSpace(req.Seats[i].SeatNumber)
+	}
+	return req.Seats, nil
+}
+
+func (r *LayoutImportResponse) addError(line int, message string) {
+	r.ErrorCount++
+	if len(r.Errors) < maxLayoutImportErrors {
+		r.Errors = append(r.Errors, LayoutImportError{Line: line, Message: message})
+	}
+}
+
+// layoutImportPlan collects the sections and seats a manifest adds to a template
+type layoutImportPlan struct {
+	templateID  uuid.UUID
+	sections    map[string]*importSection
+	order       []*importSection
+	newSections []VenueSection
+	seatTotals  map[uuid.UUID]int // Seats added to existing sections
+	seats       []Seat
+}
+
+type importSection struct {
+	id           uuid.UUID
+	name         string
+	isNew        bool
+	ambiguous    bool
+	seatNumbers  map[string]bool
+	lastPosition int
+	added        int
+	rowSeats     map[string]int
+	rows         []string
+}
+
+func newLayoutImportPlan(templateID uuid.UUID, existing []VenueSection) *layoutImportPlan {
+	plan := &layoutImportPlan{
+		templateID: templateID,
+		sections:   make(map[string]*importSection, len(existing)),
+		seatTotals: make(map[uuid.UUID]int),
+	}
+	for _, section := range existing {
+		if known, ok := plan.sections[section.Name]; ok {
+			known.ambiguous = true
+			continue
+		}
+		target := &importSection{
+			id:          section.ID,
+			name:        section.Name,
+			seatNumbers: make(map[string]bool, len(section.Seats)),
+			rowSeats:    make(map[string]int),
+		}
+		for _, seat := range section.Seats {
+			target.seatNumbers[seat.SeatNumber] = true
+			if seat.Position > target.lastPosition {
+				target.lastPosition = seat.Position
+			}
+		}
+		plan.sections[section.Name] = target
+	}
+	return plan
+}
+
+// add validates one manifest entry and plans its seat
+func (p *layoutImportPlan) add(entry LayoutImportSeat, canvas mapBounds) error {
+	switch {
+	case entry.Section == "":
+		return fmt.Errorf("section is required")
+	case len(entry.Section) > 255:
+		return fmt.Errorf("section name is longer than 255 characters")
+	case entry.Row == "":
+		return fmt.Errorf("row is required")
+	case len(entry.Row) > 10:
+		return fmt.Errorf("row is longer than 10 characters")
+	case entry.SeatNumber == "":
+		return fmt.Errorf("seat_number is required")
+	}
+
+	seat := Seat{
+		ID:         uuid.New(),
+		SeatNumber: entry.SeatNumber,
+		Row:        entry.Row,
+		Status:     "AVAILABLE",
+	}
+	for _, attribute := range entry.Attributes {
+		switch strings.ToLower(attribute) {
+		case SeatAttributeWheelchairAccessible:
+			seat.WheelchairAccessible = true
+		case SeatAttributeCompanionSeat:
+			seat.CompanionSeat = true
+		case SeatAttributeRestrictedView:
+			seat.RestrictedView = true
+		case SeatAttributeAisle:
+			seat.Aisle = true
+		case "":
+		default:
+			return fmt.Errorf("unknown seat attribute %q", attribute)
+		}
+	}
+
+	if (entry.X == nil) != (entry.Y == nil) {
+		return fmt.Errorf("seat needs both x and y")
+	}
+	if entry.X != nil {
+		if err := canvas.check("seat", *entry.X, *entry.Y); err != nil {
+			return errors.New(strings.TrimPrefix(err.Error(), "invalid layout: "))
+		}
+		seat.MapX, seat.MapY = entry.X, entry.Y
+	}
+
+	section, ok := p.sections[entry.Section]
+	if !ok {
+		section = &importSection{
+			id:          uuid.New(),
+			name:        entry.Section,
+			isNew:       true,
+			seatNumbers: make(map[string]bool),
+			rowSeats:    make(map[string]int),
+		}
+		p.sections[entry.Section] = section
+	}
+	if section.ambiguous {
+		return fmt.Errorf("section %q matches more than one section in this template", entry.Section)
+	}
+	if section.seatNumbers[entry.SeatNumber] {
+		return fmt.Errorf("seat %s is already in section %q", entry.SeatNumber, entry.Section)
+	}
+
+	if section.added == 0 {
+		p.order = append(p.order, section)
+	}
+	if _, seen := section.rowSeats[entry.Row]; !seen {
+		section.rows = append(section.rows, entry.Row)
+	}
+	section.seatNumbers[entry.SeatNumber] = true
+	section.rowSeats[entry.Row]++
+	section.lastPosition++
+	section.added++
+
+	seat.SectionID = section.id
+	seat.Position = section.lastPosition
+	p.seats = append(p.seats, seat)
+	return nil
+}
+
+// finish builds the sections the manifest creates and the seat counts of the
+// ones it adds to. New sections take their rows in manifest order.
+func (p *layoutImportPlan) finish() {
+	for _, section := range p.order {
+		if !section.isNew {
+			p.seatTotals[section.id] = section.added
+			continue
+		}
+
+		seatsPerRow := 0
+		for _, count := range section.rowSeats {
+			if count > seatsPerRow {
+				seatsPerRow = count
+			}
+		}
+		p.newSections = append(p.newSections, VenueSection{
+			ID:          section.id,
+			TemplateID:  p.templateID,
+			Name:        section.name,
+			RowStart:    section.rows[0],
+			RowEnd:      section.rows[len(section.rows)-1],
+			SeatsPerRow: seatsPerRow,
+			TotalSeats:  section.added,
+			Amenities:   StringList{},
+		})
+	}
+}
+
+// retarget moves the plan onto a copy of its template, whose sections have
+// the IDs in sectionIDs
+func (p *layoutImportPlan) retarget(templateID uuid.UUID, sectionIDs map[uuid.UUID]uuid.UUID) {
+	p.templateID = templateID
+	for i := range p.newSections {
+		p.newSections[i].TemplateID = templateID
+	}
+
+	seatTotals := make(map[uuid.UUID]int, len(p.seatTotals))
+	for id, added := range p.seatTotals {
+		seatTotals[sectionIDs[id]] = added
+	}
+	p.seatTotals = seatTotals
+
+	for _, section := range p.order {
+		if !section.isNew {
+			section.id = sectionIDs[section.id]
+		}
+	}
+	for i := range p.seats {
+		if copied, ok := sectionIDs[p.seats[i].SectionID]; ok {
+			p.seats[i].SectionID = copied
+		}
+	}
+}
+
+// summary lists the sections the manifest touches. A dry run leaves out the IDs
+// of new sections since they are not created.
+func (p *layoutImportPlan) summary(dryRun bool) []LayoutImportSectionSummary {
+	summary := make([]LayoutImportSectionSummary, 0, len(p.order))
+	for _, section := range p.order {
+		sectionSummary := LayoutImportSectionSummary{
+			Name:  section.name,
+			New:   section.isNew,
+			Rows:  len(section.rows),
+			Seats: section.added,
+		}
+		if !section.isNew || !dryRun {
+			sectionSummary.SectionID = section.id.String()
+		}
+		summary = append(summary, sectionSummary)
+	}
+	return summary
+}
@@ -1,9 +1,13 @@
 package venues
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 // Forward declaration for seat
@@ -14,34 +18,117 @@ type Seat struct {
 	Row        string    `json:"row"`
 	Position   int       `json:"position"`
 	Status     string    `json:"status"`
-	CreatedAt  time.Time `json:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at"`
+
+	WheelchairAccessible bool `json:"wheelchair_accessible"`
+	CompanionSeat        bool `json:"companion_seat"`
+	RestrictedView       bool `json:"restricted_view"`
+	Aisle                bool `json:"aisle"`
+
+	// Seat map position, in the template's map units
+	MapX *float64 `json:"map_x,omitempty"`
+	MapY *float64 `json:"map_y,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// StringList is a list of strings stored as a jsonb array
+type StringList []string
+
+// Value implements the driver.Valuer interface for database storage
+func (l StringList) Value() (driver.Value, error) {
+	if l == nil {
+		return nil, nil
+	}
+	return json.Marshal(l)
+}
+
+// Scan implements the sql.Scanner interface for database retrieval
+func (l *StringList) Scan(value interface{}) error {
+	if value == nil {
+		*l = nil
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(bytes, l)
+}
+
+// GormDataType tells GORM how to handle this type
+func (StringList) GormDataType() string {
+	return "jsonb"
+}
+
+// PhysicalVenue is the real building or hall. Several templates (seating
+// configurations) can belong to one venue, and events on any of them compete
+// for the same space.
+type PhysicalVenue struct {
+	ID                     uuid.UUID      `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	Name                   string         `gorm:"unique;not null" json:"name"`
+	Address                string         `json:"address"`
+	City                   string         `gorm:"index" json:"city"`
+	DefaultDurationMinutes int            `gorm:"not null;default:180" json:"default_duration_minutes"` // Used for events without their own duration
+	ChangeoverMinutes      int            `gorm:"not null;default:0" json:"changeover_minutes"`         // Gap needed between two events
+	CreatedAt              time.Time      `json:"created_at"`
+	UpdatedAt              time.Time      `json:"updated_at"`
+	DeletedAt              gorm.DeletedAt `json:"-" gorm:"index"`
 }
 
-// VenueTemplate defines the structure for venue templates
+// VenueTemplate defines the structure for venue templates. Each version of a
+// template is its own row; versions share a lineage and a name, and events pin
+// the version they were created on.
 type VenueTemplate struct {
-	ID                 uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
-	Name               string    `gorm:"unique;not null" json:"name"`
-	Description        string    `json:"description"`
-	DefaultRows        int       `json:"default_rows"`
-	DefaultSeatsPerRow int       `json:"default_seats_per_row"`
-	LayoutType         string    `gorm:"type:varchar(20);index;check:layout_type IN ('THEATER', 'STADIUM', 'CONFERENCE', 'GENERAL')" json:"layout_type"`
-	CreatedAt          time.Time `json:"created_at"`
-	UpdatedAt          time.Time `json:"updated_at"`
+	ID                 uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	Name               string     `gorm:"not null;index" json:"name"`
+	Description        string     `json:"description"`
+	DefaultRows        int        `json:"default_rows"`
+	DefaultSeatsPerRow int        `json:"default_seats_per_row"`
+	LayoutType         string     `gorm:"type:varchar(20);index;check:layout_type IN ('THEATER', 'STADIUM', 'CONFERENCE', 'GENERAL')" json:"layout_type"`
+	PhysicalVenueID    *uuid.UUID `gorm:"type:uuid;index" json:"physical_venue_id,omitempty"` // Building this configuration is used in
+
+	// Seat map canvas and stage; section and seat coordinates use the same units
+	MapWidth    *float64 `json:"map_width,omitempty"`
+	MapHeight   *float64 `json:"map_height,omitempty"`
+	StageType   string   `gorm:"type:varchar(10)" json:"stage_type,omitempty"` // STAGE, SCREEN or FIELD
+	StageX      *float64 `json:"stage_x,omitempty"`
+	StageY      *float64 `json:"stage_y,omitempty"`
+	StageWidth  *float64 `json:"stage_width,omitempty"`
+	StageHeight *float64 `json:"stage_height,omitempty"`
+
+	// Versioning; the first version's ID is the lineage ID
+	LineageID         uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_venue_templates_lineage_version" json:"lineage_id"`
+	Version           int        `gorm:"not null;default:1;uniqueIndex:idx_venue_templates_lineage_version" json:"version"`
+	PreviousVersionID *uuid.UUID `gorm:"type:uuid" json:"previous_version_id,omitempty"`
+
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
 }
 
 // VenueSection defines the structure for venue sections (fixed per venue template)
 type VenueSection struct {
-	ID          uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
-	TemplateID  uuid.UUID `gorm:"type:uuid;not null;index" json:"template_id"`
-	Name        string    `gorm:"not null" json:"name"`
-	Description string    `json:"description"`
-	RowStart    string    `json:"row_start"`
-	RowEnd      string    `json:"row_end"`
-	SeatsPerRow int       `json:"seats_per_row"`
-	TotalSeats  int       `json:"total_seats"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID          uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	TemplateID  uuid.UUID  `gorm:"type:uuid;not null;index" json:"template_id"`
+	Name        string     `gorm:"not null" json:"name"`
+	Description string     `json:"description"`
+	RowStart    string     `json:"row_start"`
+	RowEnd      string     `json:"row_end"`
+	SeatsPerRow int        `json:"seats_per_row"`
+	TotalSeats  int        `json:"total_seats"`
+	Amenities   StringList `json:"amenities"` // e.g. step-free access, accessible restrooms, hearing loop
+
+	// Seat map placement; sections without coordinates are drawn from row counts
+	MapX      *float64 `json:"map_x,omitempty"`
+	MapY      *float64 `json:"map_y,omitempty"`
+	Rotation  *float64 `json:"rotation,omitempty"`  // Degrees clockwise
+	Curvature *float64 `json:"curvature,omitempty"` // Degrees of arc the rows bend through, 0 is straight
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 
 	// Relationships
 	Template *VenueTemplate `json:"template,omitempty" gorm:"foreignKey:TemplateID;constraint:OnDelete:RESTRICT;"`
@@ -65,6 +152,11 @@ type EventPricing struct {
 	// This is defined in the migration, not here
 }
 
+// TableName sets the table name for PhysicalVenue
+func (PhysicalVenue) TableName() string {
+	return "physical_venues"
+}
+
 // TableName sets the table name for VenueTemplate
 func (VenueTemplate) TableName() string {
 	return "venue_templates"
@@ -105,10 +197,39 @@ func (vs *VenueSection) ToResponseWithPricing(basePrice float64, priceMultiplier
 		SeatsPerRow:     vs.SeatsPerRow,
 		TotalSeats:      vs.TotalSeats,
 		AvailableSeats:  availableSeats,
+		Amenities:       vs.Amenities,
+		Geometry:        vs.Geometry(),
 		Seats:           seats,
 	}
 }
 
+// MapGeometry returns the seat map canvas and stage, nil when the template has no map
+func (t *VenueTemplate) MapGeometry() *MapGeometry {
+	if t.MapWidth == nil && t.MapHeight == nil && t.StageType == "" {
+		return nil
+	}
+
+	geometry := &MapGeometry{Width: t.MapWidth, Height: t.MapHeight}
+	if t.StageType != "" && t.StageX != nil && t.StageY != nil {
+		geometry.Stage = &StageGeometry{
+			Type:   t.StageType,
+			X:      *t.StageX,
+			Y:      *t.StageY,
+			Width:  t.StageWidth,
+			Height: t.StageHeight,
+		}
+	}
+	return geometry
+}
+
+// Geometry returns the section's placement on the seat map, nil when it has none
+func (vs *VenueSection) Geometry() *SectionGeometry {
+	if vs.MapX == nil && vs.MapY == nil && vs.Rotation == nil && vs.Curvature == nil {
+		return nil
+	}
+	return &SectionGeometry{X: vs.MapX, Y: vs.MapY, Rotation: vs.Rotation, Curvature: vs.Curvature}
+}
+
 // Helper to convert EventPricing to response format
 func (ep *EventPricing) ToResponse(sectionName string, basePrice float64) EventPricingResponse {
 	return EventPricingResponse{
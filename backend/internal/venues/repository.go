@@ -2,7 +2,12 @@ package venues
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
+
+	"evently/internal/seats"
+	"evently/internal/shared/utils/response"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -17,6 +22,24 @@ type Repository interface {
 	DeleteTemplate(ctx context.Context, id uuid.UUID) error
 	GetTemplateByName(ctx context.Context, name string) (*VenueTemplate, error)
 
+	// Template versions
+	GetLatestTemplateVersion(ctx context.Context, lineageID uuid.UUID) (*VenueTemplate, error)
+	GetTemplateVersion(ctx context.Context, lineageID uuid.UUID, version int) (*VenueTemplate, error)
+	GetTemplateVersions(ctx context.Context, lineageID uuid.UUID) ([]TemplateVersionResponse, error)
+	NextTemplateVersion(ctx context.Context, lineageID uuid.UUID) (int, error)
+	CountEventsForTemplate(ctx context.Context, templateID uuid.UUID) (int64, error)
+	CopyTemplate(ctx context.Context, template *VenueTemplate, sections []VenueSection, seats []Seat) error
+	RenameLineage(ctx context.Context, lineageID uuid.UUID, name string) error
+
+	// Physical Venues (Buildings that templates are used in)
+	CreatePhysicalVenue(ctx context.Context, venue *PhysicalVenue) error
+	GetPhysicalVenueByID(ctx context.Context, id uuid.UUID) (*PhysicalVenue, error)
+	GetPhysicalVenueByName(ctx context.Context, name string) (*PhysicalVenue, error)
+	GetPhysicalVenues(ctx context.Context) ([]PhysicalVenue, error)
+	UpdatePhysicalVenue(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error
+	DeletePhysicalVenue(ctx context.Context, id uuid.UUID) error
+	CountTemplatesForPhysicalVenue(ctx context.Context, id uuid.UUID) (int64, error)
+
 	// Venue Sections (Fixed per template)
 	CreateSection(ctx context.Context, section *VenueSection) error
 	GetSectionByID(ctx context.Context, id uuid.UUID) (*VenueSection, error)
@@ -29,13 +52,27 @@ type Repository interface {
 	// Event Pricing (Per event-section combination)
 	CreateEventPricing(ctx context.Context, pricing *EventPricing) error
 	GetEventPricing(ctx context.Context, eventID uuid.UUID, sectionID uuid.UUID) (*EventPricing, error)
+	GetEventPricingByID(ctx context.Context, id uuid.UUID) (*EventPricing, error)
 	GetEventPricingByEventID(ctx context.Context, eventID uuid.UUID) ([]EventPricing, error)
 	UpdateEventPricing(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error
 	DeleteEventPricing(ctx context.Context, id uuid.UUID) error
 	DeleteEventPricingByEventID(ctx context.Context, eventID uuid.UUID) error
 
 	// Get venue layout for an event (sections + pricing + seats)
-	GetVenueLayoutForEvent(ctx context.Context, eventID uuid.UUID) (*VenueLayoutResponse, error)
+	GetVenueLayoutForEvent(ctx context.Context, eventID uuid.UUID, includeDrafts bool) (*VenueLayoutResponse, error)
+
+	// Booked seats of upcoming events pinned to a template
+	GetBookedSeatsForTemplate(ctx context.Context, templateID uuid.UUID) ([]TemplateBookedSeat, error)
+
+	// Seat map geometry
+	SaveTemplateLayout(ctx context.Context, templateID uuid.UUID, templateUpdates map[string]interface{}, sectionUpdates map[uuid.UUID]map[string]interface{}, seatPositions []SeatPosition) error
+	ImportTemplateLayout(ctx context.Context, sections []VenueSection, seatTotals map[uuid.UUID]int, seats []Seat) error
+
+	// Section integrity
+	CreateSectionWithSeats(ctx context.Context, section *VenueSection, seats []Seat) error
+	RegenerateSectionSeats(ctx context.Context, sectionID uuid.UUID, updates map[string]interface{}, seats []Seat) error
+	CountSectionBookings(ctx context.Context, sectionID uuid.UUID) (int64, error)
+	GetSectionSeatCounts(ctx context.Context, templateID uuid.UUID) ([]SectionSeatCount, error)
 }
 
 type repository struct {
@@ -63,13 +100,109 @@ func (r *repository) GetTemplateByID(ctx context.Context, id uuid.UUID) (*VenueT
 
 func (r *repository) GetTemplateByName(ctx context.Context, name string) (*VenueTemplate, error) {
 	var template VenueTemplate
-	err := r.db.WithContext(ctx).First(&template, "name = ?", name).Error
+	// Soft-deleted templates still hold their unique name
+	err := r.db.WithContext(ctx).Unscoped().First(&template, "name = ?", name).Error
+	if err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+//  TEMPLATE VERSIONS
+
+func (r *repository) GetLatestTemplateVersion(ctx context.Context, lineageID uuid.UUID) (*VenueTemplate, error) {
+	var template VenueTemplate
+	err := r.db.WithContext(ctx).
+		Where("lineage_id = ?", lineageID).
+		Order("version DESC").
+		First(&template).Error
+	if err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+func (r *repository) GetTemplateVersion(ctx context.Context, lineageID uuid.UUID, version int) (*VenueTemplate, error) {
+	var template VenueTemplate
+	err := r.db.WithContext(ctx).
+		Where("lineage_id = ? AND version = ?", lineageID, version).
+		First(&template).Error
 	if err != nil {
 		return nil, err
 	}
 	return &template, nil
 }
 
+// GetTemplateVersions lists the versions of a template, newest first, with
+// their size and the events pinned to each
+func (r *repository) GetTemplateVersions(ctx context.Context, lineageID uuid.UUID) ([]TemplateVersionResponse, error) {
+	var versions []TemplateVersionResponse
+	err := r.db.WithContext(ctx).
+		Table("venue_templates vt").
+		Select(`vt.id, vt.name, vt.version, vt.previous_version_id, vt.created_at,
+			(SELECT COUNT(*) FROM venue_sections vs WHERE vs.template_id = vt.id) AS sections,
+			(SELECT COUNT(*) FROM seats s JOIN venue_sections vs ON vs.id = s.section_id WHERE vs.template_id = vt.id) AS seats,
+			(SELECT COUNT(*) FROM events e WHERE e.venue_template_id = vt.id AND e.deleted_at IS NULL) AS events`).
+		Where("vt.lineage_id = ? AND vt.deleted_at IS NULL", lineageID).
+		Order("vt.version DESC").
+		Scan(&versions).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to query template versions: %w", err)
+	}
+	if len(versions) > 0 {
+		versions[0].Latest = true
+	}
+	return versions, nil
+}
+
+// NextTemplateVersion numbers a new version of a template. Deleted versions
+// keep their numbers.
+func (r *repository) NextTemplateVersion(ctx context.Context, lineageID uuid.UUID) (int, error) {
+	var latest int
+	err := r.db.WithContext(ctx).
+		Unscoped().
+		Model(&VenueTemplate{}).
+		Select("COALESCE(MAX(version), 0)").
+		Where("lineage_id = ?", lineageID).
+		Scan(&latest).Error
+	return latest + 1, err
+}
+
+// CountEventsForTemplate counts the events pinned to a template version, past ones included
+func (r *repository) CountEventsForTemplate(ctx context.Context, templateID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Table("events").
+		Where("venue_template_id = ? AND deleted_at IS NULL", templateID).
+		Count(&count).Error
+	return count, err
+}
+
+// CopyTemplate creates a template with its sections and their seats in one transaction
+func (r *repository) CopyTemplate(ctx context.Context, template *VenueTemplate, sections []VenueSection, seats []Seat) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(template).Error; err != nil {
+			return fmt.Errorf("failed to create template: %w", err)
+		}
+		if len(sections) > 0 {
+			if err := tx.Omit("Template", "Seats").Create(&sections).Error; err != nil {
+				return fmt.Errorf("failed to create sections: %w", err)
+			}
+		}
+		if len(seats) > 0 {
+			if err := tx.CreateInBatches(&seats, layoutImportBatchSize).Error; err != nil {
+				return fmt.Errorf("failed to create seats: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// RenameLineage renames every version of a template, which share one name
+func (r *repository) RenameLineage(ctx context.Context, lineageID uuid.UUID, name string) error {
+	return r.db.WithContext(ctx).Model(&VenueTemplate{}).Where("lineage_id = ?", lineageID).Update("name", name).Error
+}
+
 func (r *repository) GetTemplates(ctx context.Context, filters TemplateFilters) (*PaginatedTemplates, error) {
 	var templates []VenueTemplate
 	var total int64
@@ -86,6 +219,11 @@ func (r *repository) GetTemplates(ctx context.Context, filters TemplateFilters)
 		query = query.Where("layout_type = ?", filters.LayoutType)
 	}
 
+	if !filters.AllVersions {
+		query = query.Where(`version = (SELECT MAX(v.version) FROM venue_templates v
+			WHERE v.lineage_id = venue_templates.lineage_id AND v.deleted_at IS NULL)`)
+	}
+
 	// Count total records
 	if err := query.Count(&total).Error; err != nil {
 		return nil, err
@@ -127,6 +265,51 @@ func (r *repository) DeleteTemplate(ctx context.Context, id uuid.UUID) error {
 	return r.db.WithContext(ctx).Delete(&VenueTemplate{}, "id = ?", id).Error
 }
 
+//  PHYSICAL VENUES
+
+func (r *repository) CreatePhysicalVenue(ctx context.Context, venue *PhysicalVenue) error {
+	return r.db.WithContext(ctx).Create(venue).Error
+}
+
+func (r *repository) GetPhysicalVenueByID(ctx context.Context, id uuid.UUID) (*PhysicalVenue, error) {
+	var venue PhysicalVenue
+	err := r.db.WithContext(ctx).First(&venue, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &venue, nil
+}
+
+func (r *repository) GetPhysicalVenueByName(ctx context.Context, name string) (*PhysicalVenue, error) {
+	var venue PhysicalVenue
+	// Soft-deleted venues still hold their unique name
+	err := r.db.WithContext(ctx).Unscoped().First(&venue, "name = ?", name).Error
+	if err != nil {
+		return nil, err
+	}
+	return &venue, nil
+}
+
+func (r *repository) GetPhysicalVenues(ctx context.Context) ([]PhysicalVenue, error) {
+	var venues []PhysicalVenue
+	err := r.db.WithContext(ctx).Order("name ASC").Find(&venues).Error
+	return venues, err
+}
+
+func (r *repository) UpdatePhysicalVenue(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
+	return r.db.WithContext(ctx).Model(&PhysicalVenue{}).Where("id = ?", id).Updates(updates).Error
+}
+
+func (r *repository) DeletePhysicalVenue(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&PhysicalVenue{}, "id = ?", id).Error
+}
+
+func (r *repository) CountTemplatesForPhysicalVenue(ctx context.Context, id uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&VenueTemplate{}).Where("physical_venue_id = ?", id).Count(&count).Error
+	return count, err
+}
+
 //  VENUE SECTIONS
 
 func (r *repository) CreateSection(ctx context.Context, section *VenueSection) error {
@@ -195,6 +378,18 @@ func (r *repository) GetEventPricing(ctx context.Context, eventID uuid.UUID, sec
 	return &pricing, nil
 }
 
+func (r *repository) GetEventPricingByID(ctx context.Context, id uuid.UUID) (*EventPricing, error) {
+	var pricing EventPricing
+	err := r.db.WithContext(ctx).
+		Preload("Section").
+		Where("id = ?", id).
+		First(&pricing).Error
+	if err != nil {
+		return nil, err
+	}
+	return &pricing, nil
+}
+
 func (r *repository) GetEventPricingByEventID(ctx context.Context, eventID uuid.UUID) ([]EventPricing, error) {
 	var pricing []EventPricing
 	err := r.db.WithContext(ctx).
@@ -217,7 +412,13 @@ func (r *repository) DeleteEventPricingByEventID(ctx context.Context, eventID uu
 }
 
 // returns the complete venue layout for an event
-func (r *repository) GetVenueLayoutForEvent(ctx context.Context, eventID uuid.UUID) (*VenueLayoutResponse, error) {
+// ErrEventNotFound is returned for events that don't exist, and for drafts
+// outside an admin preview
+var ErrEventNotFound = errors.New("event not found")
+
+// GetVenueLayoutForEvent builds an event's seat map. Draft events are only
+// found with includeDrafts, for admin previews.
+func (r *repository) GetVenueLayoutForEvent(ctx context.Context, eventID uuid.UUID, includeDrafts bool) (*VenueLayoutResponse, error) {
 	// First get the event details
 	var event struct {
 		ID              uuid.UUID `json:"id"`
@@ -226,12 +427,17 @@ func (r *repository) GetVenueLayoutForEvent(ctx context.Context, eventID uuid.UU
 		BasePrice       float64   `json:"base_price"`
 	}
 
-	err := r.db.WithContext(ctx).
+	query := r.db.WithContext(ctx).
 		Table("events").
 		Select("id, name, venue_template_id, base_price").
-		Where("id = ?", eventID).
-		First(&event).Error
-	if err != nil {
+		Where("id = ? AND deleted_at IS NULL", eventID)
+	if !includeDrafts {
+		query = query.Where("status <> ?", "draft")
+	}
+	if err := query.First(&event).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrEventNotFound
+		}
 		return nil, fmt.Errorf("failed to get event: %w", err)
 	}
 
@@ -268,6 +474,7 @@ func (r *repository) GetVenueLayoutForEvent(ctx context.Context, eventID uuid.UU
 			TemplateName: template.Name,
 			LayoutType:   template.LayoutType,
 			Description:  template.Description,
+			Map:          template.MapGeometry(),
 		},
 		BasePrice:      event.BasePrice,
 		Sections:       []VenueSectionResponse{},
@@ -275,6 +482,12 @@ func (r *repository) GetVenueLayoutForEvent(ctx context.Context, eventID uuid.UU
 		AvailableSeats: 0,
 	}
 
+	// Seats admins blocked for this event, with the reason
+	blockedSeats, err := r.getBlockedSeats(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blocked seats: %w", err)
+	}
+
 	// Process each section
 	for _, section := range sections {
 		priceMultiplier := pricingMap[section.ID]
@@ -296,16 +509,21 @@ func (r *repository) GetVenueLayoutForEvent(ctx context.Context, eventID uuid.UU
 			isHeld := false
 
 			// Calculate event-specific effective status
-			effectiveStatus := r.calculateEffectiveStatus(seat, bookedSeatIDs, isHeld)
+			blockReason, isBlocked := blockedSeats[seat.ID]
+			effectiveStatus := r.calculateEffectiveStatus(seat, bookedSeatIDs, isHeld, isBlocked)
 
 			seatResponses[i] = SeatResponse{
-				ID:         seat.ID.String(),
-				SeatNumber: seat.SeatNumber,
-				Row:        seat.Row,
-				Position:   seat.Position,
-				Status:     effectiveStatus, // Use event-specific status
-				Price:      event.BasePrice * priceMultiplier,
-				IsHeld:     isHeld,
+				ID:          seat.ID.String(),
+				SeatNumber:  seat.SeatNumber,
+				Row:         seat.Row,
+				Position:    seat.Position,
+				Status:      effectiveStatus, // Use event-specific status
+				Price:       event.BasePrice * priceMultiplier,
+				IsHeld:      isHeld,
+				BlockReason: blockReason,
+				Attributes:  seats.SeatAttributes(seat.WheelchairAccessible, seat.CompanionSeat, seat.RestrictedView, seat.Aisle),
+				X:           seat.MapX,
+				Y:           seat.MapY,
 			}
 
 			if effectiveStatus == "AVAILABLE" {
@@ -344,10 +562,128 @@ func (r *repository) getBookedSeatIDs(ctx context.Context, eventID uuid.UUID, se
 	return bookedMap, nil
 }
 
+// getBlockedSeats returns the seats blocked for an event, with the reason
+func (r *repository) getBlockedSeats(ctx context.Context, eventID uuid.UUID) (map[uuid.UUID]string, error) {
+	var blocks []struct {
+		SeatID uuid.UUID
+		Reason string
+	}
+	if err := r.db.WithContext(ctx).
+		Table("event_seat_blocks").
+		Select("seat_id, reason").
+		Where("event_id = ? AND released_at IS NULL", eventID).
+		Scan(&blocks).Error; err != nil {
+		return nil, fmt.Errorf("failed to query seat blocks: %w", err)
+	}
+
+	blocked := make(map[uuid.UUID]string, len(blocks))
+	for _, block := range blocks {
+		blocked[block.SeatID] = block.Reason
+	}
+
+	return blocked, nil
+}
+
+// TemplateBookedSeat is a booked seat of an event that uses the template
+type TemplateBookedSeat struct {
+	EventID     uuid.UUID
+	EventName   string
+	EventDate   time.Time
+	BookingID   uuid.UUID
+	SeatID      uuid.UUID
+	SectionID   uuid.UUID
+	SectionName string
+	SeatNumber  string
+	Row         string
+	Position    int
+}
+
+func (r *repository) GetBookedSeatsForTemplate(ctx context.Context, templateID uuid.UUID) ([]TemplateBookedSeat, error) {
+	var seats []TemplateBookedSeat
+	err := r.db.WithContext(ctx).
+		Table("seat_bookings sb").
+		Select(`e.id AS event_id, e.name AS event_name, e.date_time AS event_date,
+			b.id AS booking_id, s.id AS seat_id, vs.id AS section_id, vs.name AS section_name,
+			s.seat_number, s.row, s.position`).
+		Joins("JOIN bookings b ON b.id = sb.booking_id").
+		Joins("JOIN events e ON e.id = b.event_id").
+		Joins("JOIN seats s ON s.id = sb.seat_id").
+		Joins("JOIN venue_sections vs ON vs.id = s.section_id").
+		Where("e.venue_template_id = ? AND b.status != 'CANCELLED' AND e.date_time >= ?", templateID, time.Now()).
+		Order("e.date_time ASC, vs.name ASC, s.row ASC, s.position ASC").
+		Scan(&seats).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to query booked seats for template: %w", err)
+	}
+	return seats, nil
+}
+
+// SeatPosition places a seat on the seat map
+type SeatPosition struct {
+	SeatID uuid.UUID
+	X      float64
+	Y      float64
+}
+
+// SaveTemplateLayout writes the canvas, section and seat geometry of a template in one transaction
+func (r *repository) SaveTemplateLayout(ctx context.Context, templateID uuid.UUID, templateUpdates map[string]interface{}, sectionUpdates map[uuid.UUID]map[string]interface{}, seatPositions []SeatPosition) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if len(templateUpdates) > 0 {
+			if err := tx.Model(&VenueTemplate{}).Where("id = ?", templateID).Updates(templateUpdates).Error; err != nil {
+				return fmt.Errorf("failed to update template map: %w", err)
+			}
+		}
+
+		for sectionID, updates := range sectionUpdates {
+			if len(updates) == 0 {
+				continue
+			}
+			if err := tx.Model(&VenueSection{}).Where("id = ? AND template_id = ?", sectionID, templateID).Updates(updates).Error; err != nil {
+				return fmt.Errorf("failed to update section %s geometry: %w", sectionID, err)
+			}
+		}
+
+		for _, position := range seatPositions {
+			if err := tx.Table("seats").
+				Where("id = ?", position.SeatID).
+				Updates(map[string]interface{}{"map_x": position.X, "map_y": position.Y, "updated_at": time.Now()}).Error; err != nil {
+				return fmt.Errorf("failed to update seat %s position: %w", position.SeatID, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// ImportTemplateLayout creates the sections and seats of a layout manifest in one
+// transaction. seatTotals are seats added to existing sections.
+func (r *repository) ImportTemplateLayout(ctx context.Context, sections []VenueSection, seatTotals map[uuid.UUID]int, seats []Seat) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if len(sections) > 0 {
+			if err := tx.Create(&sections).Error; err != nil {
+				return fmt.Errorf("failed to create sections: %w", err)
+			}
+		}
+
+		for sectionID, added := range seatTotals {
+			if err := tx.Model(&VenueSection{}).
+				Where("id = ?", sectionID).
+				Updates(map[string]interface{}{"total_seats": gorm.Expr("total_seats + ?", added), "updated_at": time.Now()}).Error; err != nil {
+				return fmt.Errorf("failed to update section %s: %w", sectionID, err)
+			}
+		}
+
+		if err := tx.CreateInBatches(&seats, layoutImportBatchSize).Error; err != nil {
+			return fmt.Errorf("failed to create seats: %w", err)
+		}
+		return nil
+	})
+}
+
 // determines the effective status of a seat for an event
-func (r *repository) calculateEffectiveStatus(seat Seat, bookedSeatIDs map[uuid.UUID]bool, isHeld bool) string {
+func (r *repository) calculateEffectiveStatus(seat Seat, bookedSeatIDs map[uuid.UUID]bool, isHeld bool, isBlocked bool) string {
 
-	if seat.Status == "BLOCKED" {
+	if seat.Status == "BLOCKED" || isBlocked {
 		return "BLOCKED"
 	}
 
@@ -372,6 +708,8 @@ type TemplateFilters struct {
 	LayoutType string `form:"layout_type" binding:"omitempty,oneof=THEATER STADIUM CONFERENCE GENERAL"`
 	SortBy     string `form:"sort_by" binding:"omitempty,oneof=name created_at updated_at"`
 	SortOrder  string `form:"sort_order" binding:"omitempty,oneof=asc desc"`
+
+	AllVersions bool `form:"all_versions"` // Older versions are left out unless set
 }
 
 type PaginatedTemplates struct {
@@ -381,3 +719,84 @@ type PaginatedTemplates struct {
 	Limit      int             `json:"limit"`
 	TotalPages int             `json:"total_pages"`
 }
+
+func (p PaginatedTemplates) PaginationMeta() response.Pagination {
+	return response.NewPagination(p.Page, p.Limit, p.TotalCount)
+}
+
+//  SECTION INTEGRITY
+
+// SectionSeatCount is a section with the seats it has and the booked ones
+type SectionSeatCount struct {
+	ID          uuid.UUID
+	Name        string
+	RowStart    string
+	RowEnd      string
+	SeatsPerRow int
+	TotalSeats  int
+	ActualSeats int
+	ActualRows  int
+	BookedSeats int
+}
+
+// CreateSectionWithSeats creates a section and its seats in one transaction
+func (r *repository) CreateSectionWithSeats(ctx context.Context, section *VenueSection, seats []Seat) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Template", "Seats").Create(section).Error; err != nil {
+			return err
+		}
+		if len(seats) > 0 {
+			if err := tx.CreateInBatches(&seats, layoutImportBatchSize).Error; err != nil {
+				return fmt.Errorf("failed to create seats: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// RegenerateSectionSeats updates a section and replaces its seats in one transaction
+func (r *repository) RegenerateSectionSeats(ctx context.Context, sectionID uuid.UUID, updates map[string]interface{}, seats []Seat) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&VenueSection{}).Where("id = ?", sectionID).Updates(updates).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("section_id = ?", sectionID).Delete(&Seat{}).Error; err != nil {
+			return fmt.Errorf("failed to delete seats: %w", err)
+		}
+		if len(seats) > 0 {
+			if err := tx.CreateInBatches(&seats, layoutImportBatchSize).Error; err != nil {
+				return fmt.Errorf("failed to create seats: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// CountSectionBookings counts the seats of a section in bookings that aren't cancelled
+func (r *repository) CountSectionBookings(ctx context.Context, sectionID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Table("seat_bookings sb").
+		Joins("JOIN bookings b ON b.id = sb.booking_id").
+		Where("sb.section_id = ? AND b.status != 'CANCELLED'", sectionID).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *repository) GetSectionSeatCounts(ctx context.Context, templateID uuid.UUID) ([]SectionSeatCount, error) {
+	var counts []SectionSeatCount
+	err := r.db.WithContext(ctx).
+		Table("venue_sections vs").
+		Select(`vs.id, vs.name, vs.row_start, vs.row_end, vs.seats_per_row, vs.total_seats,
+			(SELECT COUNT(*) FROM seats s WHERE s.section_id = vs.id) AS actual_seats,
+			(SELECT COUNT(DISTINCT s.row) FROM seats s WHERE s.section_id = vs.id) AS actual_rows,
+			(SELECT COUNT(*) FROM seat_bookings sb JOIN bookings b ON b.id = sb.booking_id
+				WHERE sb.section_id = vs.id AND b.status != 'CANCELLED') AS booked_seats`).
+		Where("vs.template_id = ?", templateID).
+		Order("vs.name ASC").
+		Scan(&counts).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to count section seats: %w", err)
+	}
+	return counts, nil
+}
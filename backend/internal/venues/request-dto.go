@@ -12,6 +12,7 @@ type CreateTemplateRequest struct {
 	DefaultRows        int    `json:"default_rows" binding:"required,min=1,max=50"`
 	DefaultSeatsPerRow int    `json:"default_seats_per_row" binding:"required,min=1,max=100"`
 	LayoutType         string `json:"layout_type" binding:"required,oneof=THEATER STADIUM CONFERENCE GENERAL"`
+	PhysicalVenueID    string `json:"physical_venue_id" binding:"omitempty,uuid"`
 }
 
 type UpdateTemplateRequest struct {
@@ -20,25 +21,54 @@ type UpdateTemplateRequest struct {
 	DefaultRows        *int    `json:"default_rows" binding:"omitempty,min=1,max=50"`
 	DefaultSeatsPerRow *int    `json:"default_seats_per_row" binding:"omitempty,min=1,max=100"`
 	LayoutType         *string `json:"layout_type" binding:"omitempty,oneof=THEATER STADIUM CONFERENCE GENERAL"`
+	PhysicalVenueID    *string `json:"physical_venue_id" binding:"omitempty,uuid"`
+}
+
+// DuplicateTemplateRequest copies a template into a new template of its own
+type DuplicateTemplateRequest struct {
+	Name        string  `json:"name" binding:"required,min=3,max=255"`
+	Description *string `json:"description" binding:"omitempty,max=1000"` // Defaults to the source template's
+}
+
+type CreatePhysicalVenueRequest struct {
+	Name                   string `json:"name" binding:"required,min=3,max=255"`
+	Address                string `json:"address" binding:"max=500"`
+	City                   string `json:"city" binding:"max=100"`
+	DefaultDurationMinutes int    `json:"default_duration_minutes" binding:"omitempty,min=15,max=1440"`
+	ChangeoverMinutes      int    `json:"changeover_minutes" binding:"omitempty,min=0,max=1440"`
+}
+
+type UpdatePhysicalVenueRequest struct {
+	Name                   *string `json:"name" binding:"omitempty,min=3,max=255"`
+	Address                *string `json:"address" binding:"omitempty,max=500"`
+	City                   *string `json:"city" binding:"omitempty,max=100"`
+	DefaultDurationMinutes *int    `json:"default_duration_minutes" binding:"omitempty,min=15,max=1440"`
+	ChangeoverMinutes      *int    `json:"changeover_minutes" binding:"omitempty,min=0,max=1440"`
 }
 
 type CreateSectionRequest struct {
-	TemplateID  string `json:"template_id" binding:"required,uuid"`
-	Name        string `json:"name" binding:"required,min=1,max=255"`
-	Description string `json:"description" binding:"omitempty,max=500"`
-	RowStart    string `json:"row_start" binding:"max=10"`
-	RowEnd      string `json:"row_end" binding:"max=10"`
-	SeatsPerRow int    `json:"seats_per_row" binding:"required,min=1,max=100"`
-	TotalSeats  int    `json:"total_seats" binding:"required,min=1"`
+	TemplateID  string   `json:"template_id" binding:"required,uuid"`
+	Name        string   `json:"name" binding:"required,min=1,max=255"`
+	Description string   `json:"description" binding:"omitempty,max=500"`
+	RowStart    string   `json:"row_start" binding:"max=10"`
+	RowEnd      string   `json:"row_end" binding:"max=10"`
+	SeatsPerRow int      `json:"seats_per_row" binding:"required,min=1,max=100"`
+	TotalSeats  int      `json:"total_seats" binding:"required,min=1"`
+	Amenities   []string `json:"amenities" binding:"omitempty,max=20,dive,min=1,max=50"`
 }
 
 type UpdateSectionRequest struct {
-	Name        *string `json:"name" binding:"omitempty,min=1,max=255"`
-	Description *string `json:"description" binding:"omitempty,max=500"`
-	RowStart    *string `json:"row_start" binding:"omitempty,max=10"`
-	RowEnd      *string `json:"row_end" binding:"omitempty,max=10"`
-	SeatsPerRow *int    `json:"seats_per_row" binding:"omitempty,min=1,max=100"`
-	TotalSeats  *int    `json:"total_seats" binding:"omitempty,min=1"`
+	Name        *string   `json:"name" binding:"omitempty,min=1,max=255"`
+	Description *string   `json:"description" binding:"omitempty,max=500"`
+	RowStart    *string   `json:"row_start" binding:"omitempty,max=10"`
+	RowEnd      *string   `json:"row_end" binding:"omitempty,max=10"`
+	SeatsPerRow *int      `json:"seats_per_row" binding:"omitempty,min=1,max=100"`
+	TotalSeats  *int      `json:"total_seats" binding:"omitempty,min=1"`
+	Amenities   *[]string `json:"amenities" binding:"omitempty,max=20,dive,min=1,max=50"`
+
+	// Confirms that the section's seats are deleted and laid out again from
+	// its rows; required when rows or seats per row change
+	RegenerateSeats bool `json:"regenerate_seats"`
 }
 
 type CreateEventPricingRequest struct {
@@ -51,3 +81,53 @@ type UpdateEventPricingRequest struct {
 	PriceMultiplier *float64 `json:"price_multiplier" binding:"omitempty,min=0.1,max=10"`
 	IsActive        *bool    `json:"is_active"`
 }
+
+// UpdateTemplateLayoutRequest edits a template's seat map. Omitted fields and
+// sections keep their current geometry.
+type UpdateTemplateLayoutRequest struct {
+	MapWidth    *float64               `json:"map_width" binding:"omitempty,gt=0"`
+	MapHeight   *float64               `json:"map_height" binding:"omitempty,gt=0"`
+	Stage       *StageLayoutRequest    `json:"stage"`
+	RemoveStage bool                   `json:"remove_stage"`
+	Sections    []SectionLayoutRequest `json:"sections" binding:"omitempty,dive"`
+}
+
+type StageLayoutRequest struct {
+	Type   string   `json:"type" binding:"required,oneof=STAGE SCREEN FIELD"`
+	X      *float64 `json:"x" binding:"required"`
+	Y      *float64 `json:"y" binding:"required"`
+	Width  *float64 `json:"width" binding:"omitempty,gt=0"`
+	Height *float64 `json:"height" binding:"omitempty,gt=0"`
+}
+
+type SectionLayoutRequest struct {
+	SectionID string              `json:"section_id" binding:"required,uuid"`
+	X         *float64            `json:"x"`
+	Y         *float64            `json:"y"`
+	Rotation  *float64            `json:"rotation" binding:"omitempty,min=-360,max=360"`
+	Curvature *float64            `json:"curvature" binding:"omitempty,min=-180,max=180"`
+	Seats     []SeatLayoutRequest `json:"seats" binding:"omitempty,dive"`
+}
+
+type SeatLayoutRequest struct {
+	SeatID string   `json:"seat_id" binding:"required,uuid"`
+	X      *float64 `json:"x" binding:"required"`
+	Y      *float64 `json:"y" binding:"required"`
+}
+
+// LayoutImportRequest is a JSON seat manifest. CSV manifests have the same
+// fields as columns, with attributes separated by semicolons.
+type LayoutImportRequest struct {
+	Seats []LayoutImportSeat `json:"seats"`
+}
+
+type LayoutImportSeat struct {
+	Section    string   `json:"section"`
+	Row        string   `json:"row"`
+	SeatNumber string   `json:"seat_number"`
+	Attributes []string `json:"attributes"` // wheelchair_accessible, companion_seat, restricted_view, aisle
+	X          *float64 `json:"x"`
+	Y          *float64 `json:"y"`
+
+	Line int `json:"-"` // CSV line, or position in a JSON manifest, for error reports
+}
@@ -1,6 +1,10 @@
 package venues
 
-import "time"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type VenueLayoutResponse struct {
 	EventID        string                 `json:"event_id"`
@@ -13,33 +17,90 @@ type VenueLayoutResponse struct {
 }
 
 type VenueInfo struct {
-	TemplateID   string `json:"template_id"`
-	TemplateName string `json:"template_name"`
-	LayoutType   string `json:"layout_type"`
-	Description  string `json:"description"`
+	TemplateID   string       `json:"template_id"`
+	TemplateName string       `json:"template_name"`
+	LayoutType   string       `json:"layout_type"`
+	Description  string       `json:"description"`
+	Map          *MapGeometry `json:"map,omitempty"` // Canvas size and stage, when the template has a seat map
 }
 
 type VenueSectionResponse struct {
-	ID              string         `json:"id"`
-	Name            string         `json:"name"`
-	PriceMultiplier float64        `json:"price_multiplier"`
-	Price           float64        `json:"price"`
-	RowStart        string         `json:"row_start"`
-	RowEnd          string         `json:"row_end"`
-	SeatsPerRow     int            `json:"seats_per_row"`
-	TotalSeats      int            `json:"total_seats"`
-	AvailableSeats  int            `json:"available_seats"`
-	Seats           []SeatResponse `json:"seats"`
+	ID              string           `json:"id"`
+	Name            string           `json:"name"`
+	PriceMultiplier float64          `json:"price_multiplier"`
+	Price           float64          `json:"price"`
+	RowStart        string           `json:"row_start"`
+	RowEnd          string           `json:"row_end"`
+	SeatsPerRow     int              `json:"seats_per_row"`
+	TotalSeats      int              `json:"total_seats"`
+	AvailableSeats  int              `json:"available_seats"`
+	Amenities       []string         `json:"amenities,omitempty"`
+	Geometry        *SectionGeometry `json:"geometry,omitempty"`
+	Seats           []SeatResponse   `json:"seats"`
 }
 
 type SeatResponse struct {
-	ID         string  `json:"id"`
-	SeatNumber string  `json:"seat_number"`
-	Row        string  `json:"row"`
-	Position   int     `json:"position"`
-	Status     string  `json:"status"`
-	Price      float64 `json:"price"`
-	IsHeld     bool    `json:"is_held"`
+	ID          string   `json:"id"`
+	SeatNumber  string   `json:"seat_number"`
+	Row         string   `json:"row"`
+	Position    int      `json:"position"`
+	Status      string   `json:"status"`
+	Price       float64  `json:"price"`
+	IsHeld      bool     `json:"is_held"`
+	BlockReason string   `json:"block_reason,omitempty"` // VIP, PRESS, EQUIPMENT or OTHER when blocked for this event
+	Attributes  []string `json:"attributes,omitempty"`
+	X           *float64 `json:"x,omitempty"` // Seat map position
+	Y           *float64 `json:"y,omitempty"`
+}
+
+// MapGeometry is the seat map canvas; all coordinates are in its units with
+// the origin at the top left
+type MapGeometry struct {
+	Width  *float64       `json:"width,omitempty"`
+	Height *float64       `json:"height,omitempty"`
+	Stage  *StageGeometry `json:"stage,omitempty"`
+}
+
+// StageGeometry is the stage, screen or field the seats face
+type StageGeometry struct {
+	Type   string   `json:"type"`
+	X      float64  `json:"x"`
+	Y      float64  `json:"y"`
+	Width  *float64 `json:"width,omitempty"`
+	Height *float64 `json:"height,omitempty"`
+}
+
+// SectionGeometry places a section on the seat map
+type SectionGeometry struct {
+	X         *float64 `json:"x,omitempty"`
+	Y         *float64 `json:"y,omitempty"`
+	Rotation  *float64 `json:"rotation,omitempty"`  // Degrees clockwise
+	Curvature *float64 `json:"curvature,omitempty"` // Degrees of arc the rows bend through
+}
+
+// TemplateLayoutResponse is the seat map of a template, as edited by admins
+type TemplateLayoutResponse struct {
+	TemplateID   string                  `json:"template_id"`
+	TemplateName string                  `json:"template_name"`
+	LayoutType   string                  `json:"layout_type"`
+	Map          *MapGeometry            `json:"map,omitempty"`
+	Sections     []SectionLayoutResponse `json:"sections"`
+}
+
+type SectionLayoutResponse struct {
+	SectionID string               `json:"section_id"`
+	Name      string               `json:"name"`
+	Geometry  *SectionGeometry     `json:"geometry,omitempty"`
+	Seats     []SeatLayoutResponse `json:"seats"`
+}
+
+type SeatLayoutResponse struct {
+	SeatID     string   `json:"seat_id"`
+	SeatNumber string   `json:"seat_number"`
+	Row        string   `json:"row"`
+	Position   int      `json:"position"`
+	X          *float64 `json:"x,omitempty"`
+	Y          *float64 `json:"y,omitempty"`
 }
 
 type SeatHoldResponse struct {
@@ -70,3 +131,151 @@ type EventPricingResponse struct {
 	Price           float64 `json:"price"`
 	IsActive        bool    `json:"is_active"`
 }
+
+// TemplateVersionResponse is one version of a template with what uses it
+type TemplateVersionResponse struct {
+	ID                uuid.UUID  `json:"id"`
+	Name              string     `json:"name"`
+	Version           int        `json:"version"`
+	PreviousVersionID *uuid.UUID `json:"previous_version_id,omitempty"`
+	Latest            bool       `json:"latest"`
+	Sections          int        `json:"sections"`
+	Seats             int        `json:"seats"`
+	Events            int        `json:"events"` // Events pinned to this version
+	CreatedAt         time.Time  `json:"created_at"`
+}
+
+// TemplateDiffResponse describes the changes between two template versions and
+// the booked seats that switching events to the newer version would orphan
+type TemplateDiffResponse struct {
+	FromTemplateID  string            `json:"from_template_id"`
+	ToTemplateID    string            `json:"to_template_id"`
+	FromVersion     int               `json:"from_version"`
+	ToVersion       int               `json:"to_version"`
+	AddedSections   []SectionDiff     `json:"added_sections"`
+	RemovedSections []SectionDiff     `json:"removed_sections"`
+	RenamedSections []SectionRename   `json:"renamed_sections"`
+	ChangedSections []SectionSeatDiff `json:"changed_sections"`
+	Summary         TemplateDiffStats `json:"summary"`
+	AffectedEvents  []EventSeatImpact `json:"affected_events"`
+}
+
+type SectionDiff struct {
+	SectionID  string `json:"section_id"`
+	Name       string `json:"name"`
+	TotalSeats int    `json:"total_seats"`
+}
+
+type SectionRename struct {
+	FromSectionID string `json:"from_section_id"`
+	ToSectionID   string `json:"to_section_id"`
+	FromName      string `json:"from_name"`
+	ToName        string `json:"to_name"`
+}
+
+// SectionSeatDiff lists seat-level changes in a section present in both versions
+type SectionSeatDiff struct {
+	FromSectionID string       `json:"from_section_id"`
+	ToSectionID   string       `json:"to_section_id"`
+	Name          string       `json:"name"`
+	AddedSeats    []SeatDiff   `json:"added_seats"`
+	RemovedSeats  []SeatDiff   `json:"removed_seats"`
+	RenamedSeats  []SeatRename `json:"renamed_seats"`
+}
+
+type SeatDiff struct {
+	SeatID     string `json:"seat_id"`
+	SeatNumber string `json:"seat_number"`
+	Row        string `json:"row"`
+	Position   int    `json:"position"`
+}
+
+type SeatRename struct {
+	FromSeatID     string `json:"from_seat_id"`
+	ToSeatID       string `json:"to_seat_id"`
+	FromSeatNumber string `json:"from_seat_number"`
+	ToSeatNumber   string `json:"to_seat_number"`
+	Row            string `json:"row"`
+	Position       int    `json:"position"`
+}
+
+type TemplateDiffStats struct {
+	SectionsAdded   int `json:"sections_added"`
+	SectionsRemoved int `json:"sections_removed"`
+	SectionsRenamed int `json:"sections_renamed"`
+	SeatsAdded      int `json:"seats_added"`
+	SeatsRemoved    int `json:"seats_removed"`
+	SeatsRenamed    int `json:"seats_renamed"`
+	OrphanedSeats   int `json:"orphaned_seats"`
+}
+
+// EventSeatImpact lists the booked seats of an event pinned to the older version
+// that have no counterpart in the newer one
+type EventSeatImpact struct {
+	EventID       string         `json:"event_id"`
+	EventName     string         `json:"event_name"`
+	EventDate     time.Time      `json:"event_date"`
+	BookedSeats   int            `json:"booked_seats"`
+	OrphanedSeats []OrphanedSeat `json:"orphaned_seats"`
+}
+
+type OrphanedSeat struct {
+	BookingID   string `json:"booking_id"`
+	SeatID      string `json:"seat_id"`
+	SectionName string `json:"section_name"`
+	SeatNumber  string `json:"seat_number"`
+	Row         string `json:"row"`
+	Position    int    `json:"position"`
+}
+
+// SectionReconciliationResponse checks every section of a template against its
+// rows and its seats
+type SectionReconciliationResponse struct {
+	TemplateID   string                  `json:"template_id"`
+	Version      int                     `json:"version"`
+	Consistent   int                     `json:"consistent"`
+	Inconsistent int                     `json:"inconsistent"`
+	Sections     []SectionReconciliation `json:"sections"`
+}
+
+type SectionReconciliation struct {
+	SectionID     string   `json:"section_id"`
+	Name          string   `json:"name"`
+	RowStart      string   `json:"row_start"`
+	RowEnd        string   `json:"row_end"`
+	SeatsPerRow   int      `json:"seats_per_row"`
+	TotalSeats    int      `json:"total_seats"`          // Declared on the section
+	GridSeats     *int     `json:"grid_seats,omitempty"` // Rows × seats per row, when the rows can be read
+	ActualSeats   int      `json:"actual_seats"`
+	ActualRows    int      `json:"actual_rows"`
+	BookedSeats   int      `json:"booked_seats"`
+	Consistent    bool     `json:"consistent"`
+	CanRegenerate bool     `json:"can_regenerate"` // Seats can be regenerated from the rows, none are booked
+	Issues        []string `json:"issues"`
+}
+
+// LayoutImportResponse reports what a seat manifest adds to a template. When
+// the manifest has errors nothing is imported and the counts are zero.
+type LayoutImportResponse struct {
+	TemplateID      string                       `json:"template_id"`
+	DryRun          bool                         `json:"dry_run"`
+	SectionsCreated int                          `json:"sections_created"`
+	SectionsUpdated int                          `json:"sections_updated"`
+	SeatsCreated    int                          `json:"seats_created"`
+	Sections        []LayoutImportSectionSummary `json:"sections"`
+	ErrorCount      int                          `json:"error_count"`
+	Errors          []LayoutImportError          `json:"errors,omitempty"` // The first 500 errors
+}
+
+type LayoutImportSectionSummary struct {
+	SectionID string `json:"section_id,omitempty"`
+	Name      string `json:"name"`
+	New       bool   `json:"new"`
+	Rows      int    `json:"rows"`
+	Seats     int    `json:"seats"`
+}
+
+type LayoutImportError struct {
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
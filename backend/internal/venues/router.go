@@ -9,7 +9,7 @@ import (
 func SetupVenueRoutes(rg *gin.RouterGroup, controller *Controller) {
 	// Venue Templates routes
 	templates := rg.Group("/admin/venue-templates")
-	templates.Use(middleware.JWTAuth(), middleware.RequireAdmin())
+	templates.Use(middleware.JWTAuth(), middleware.RequirePermission(middleware.PermissionVenuesManage))
 	{
 		templates.POST("", controller.CreateTemplate)       // POST /api/v1/venue-templates
 		templates.GET("", controller.GetTemplates)          // GET /api/v1/venue-templates
@@ -18,8 +18,31 @@ func SetupVenueRoutes(rg *gin.RouterGroup, controller *Controller) {
 		templates.DELETE("/:id", controller.DeleteTemplate) // DELETE /api/v1/venue-templates/:id
 
 		// Template sections routes
-		templates.POST("/:id/sections", controller.CreateSection)          // POST /api/v1/venue-templates/:id/sections
-		templates.GET("/:id/sections", controller.GetSectionsByTemplateID) // GET /api/v1/venue-templates/:id/sections
+		templates.POST("/:id/sections", controller.CreateSection)                           // POST /api/v1/venue-templates/:id/sections
+		templates.GET("/:id/sections", controller.GetSectionsByTemplateID)                  // GET /api/v1/venue-templates/:id/sections
+		templates.GET("/:id/sections/reconciliation", controller.ReconcileTemplateSections) // GET /api/v1/venue-templates/:id/sections/reconciliation - Sections whose size doesn't match their rows or seats
+
+		// Template versions; section edits of a template events use create a new version
+		templates.POST("/:id/duplicate", controller.DuplicateTemplate)       // POST /api/v1/venue-templates/:id/duplicate - Copy into a new template
+		templates.GET("/:id/versions", controller.GetTemplateVersions)       // GET /api/v1/venue-templates/:id/versions
+		templates.GET("/:id/versions/diff", controller.DiffTemplateVersions) // GET /api/v1/venue-templates/:id/versions/diff?from=&to=
+		templates.GET("/:id/diff/:targetId", controller.DiffTemplates)       // GET /api/v1/venue-templates/:id/diff/:targetId
+
+		// Seat map layout editor
+		templates.GET("/:id/layout", controller.GetTemplateLayout)            // GET /api/v1/venue-templates/:id/layout
+		templates.PUT("/:id/layout", controller.UpdateTemplateLayout)         // PUT /api/v1/venue-templates/:id/layout
+		templates.POST("/:id/layout/import", controller.ImportTemplateLayout) // POST /api/v1/venue-templates/:id/layout/import
+	}
+
+	// Physical venues; templates used in the same venue cannot host overlapping events
+	physicalVenues := rg.Group("/admin/physical-venues")
+	physicalVenues.Use(middleware.JWTAuth(), middleware.RequirePermission(middleware.PermissionVenuesManage))
+	{
+		physicalVenues.POST("", controller.CreatePhysicalVenue)       // POST /api/v1/admin/physical-venues
+		physicalVenues.GET("", controller.GetPhysicalVenues)          // GET /api/v1/admin/physical-venues
+		physicalVenues.GET("/:id", controller.GetPhysicalVenue)       // GET /api/v1/admin/physical-venues/:id
+		physicalVenues.PUT("/:id", controller.UpdatePhysicalVenue)    // PUT /api/v1/admin/physical-venues/:id
+		physicalVenues.DELETE("/:id", controller.DeletePhysicalVenue) // DELETE /api/v1/admin/physical-venues/:id
 	}
 
 	// Event-specific venue reading routes
@@ -30,9 +53,16 @@ func SetupVenueRoutes(rg *gin.RouterGroup, controller *Controller) {
 		events.GET("/:eventId/venue/layout", controller.GetVenueLayout)   // GET /api/v1/events/:eventId/venue/layout
 	}
 
+	// Admins preview the seat map of draft events before they are published
+	preview := rg.Group("/admin/events/:eventId/preview")
+	preview.Use(middleware.JWTAuth(), middleware.RequirePermission(middleware.PermissionEventsWrite))
+	{
+		preview.GET("/venue/layout", controller.PreviewVenueLayout) // GET /api/v1/admin/events/:eventId/preview/venue/layout
+	}
+
 	// Individual section routes
 	sections := rg.Group("/admin/sections")
-	sections.Use(middleware.JWTAuth(), middleware.RequireAdmin())
+	sections.Use(middleware.JWTAuth(), middleware.RequirePermission(middleware.PermissionVenuesManage))
 	{
 		sections.PUT("/:id", controller.UpdateSection)    // PUT /api/v1/sections/:id
 		sections.DELETE("/:id", controller.DeleteSection) // DELETE /api/v1/sections/:id
@@ -0,0 +1,158 @@
+package venues
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// A section's total_seats has to equal its rows times its seats per row, and
+// its seats are laid out from them. Changing the rows of a section deletes its
+// seats and lays them out again, which is refused once any of them is booked.
+
+var ErrSectionHasBookings = errors.New("section seats have bookings and can't be regenerated")
+
+// sectionRows lists the row labels of a section
+func (s *service) sectionRows(section *VenueSection) ([]string, error) {
+	if section.RowStart == "" || section.RowEnd == "" {
+		return nil, fmt.Errorf("invalid section: row start and end must be specified for seat generation")
+	}
+	rows, err := s.generateRowLabels(section.RowStart, section.RowEnd)
+	if err != nil {
+		return nil, fmt.Errorf("invalid section rows: %w", err)
+	}
+	return rows, nil
+}
+
+// checkSectionSeats validates the seat counts of a section update. When the
+// update changes the rows or seats per row, or asks for it, the section's
+// seats are regenerated and the new ones are returned.
+func (s *service) checkSectionSeats(ctx context.Context, sectionID uuid.UUID, req UpdateSectionRequest, updates map[string]interface{}) ([]Seat, error) {
+	section, err := s.repo.GetSectionByID(ctx, sectionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("section not found")
+		}
+		return nil, fmt.Errorf("failed to get section: %w", err)
+	}
+
+	updated := *section
+	if req.RowStart != nil {
+		updated.RowStart = *req.RowStart
+	}
+	if req.RowEnd != nil {
+		updated.RowEnd = *req.RowEnd
+	}
+	if req.SeatsPerRow != nil {
+		updated.SeatsPerRow = *req.SeatsPerRow
+	}
+	resized := updated.RowStart != section.RowStart || updated.RowEnd != section.RowEnd || updated.SeatsPerRow != section.SeatsPerRow
+
+	if !resized && !req.RegenerateSeats {
+		if req.TotalSeats != nil && *req.TotalSeats != section.TotalSeats {
+			return nil, fmt.Errorf("invalid section update: total_seats follows the section's rows, change rows or seats per row instead")
+		}
+		return nil, nil
+	}
+	if !req.RegenerateSeats {
+		return nil, fmt.Errorf("invalid section update: changing rows or seats per row regenerates the section's seats, set regenerate_seats to confirm")
+	}
+
+	rows, err := s.sectionRows(&updated)
+	if err != nil {
+		return nil, err
+	}
+	updated.TotalSeats = len(rows) * updated.SeatsPerRow
+	if req.TotalSeats != nil {
+		updated.TotalSeats = *req.TotalSeats
+	}
+
+	booked, err := s.repo.CountSectionBookings(ctx, sectionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count section bookings: %w", err)
+	}
+	if booked > 0 {
+		return nil, fmt.Errorf("%w: %d booked seats", ErrSectionHasBookings, booked)
+	}
+
+	regenerated, err := s.buildSectionSeats(&updated)
+	if err != nil {
+		return nil, err
+	}
+	updates["row_start"] = updated.RowStart
+	updates["row_end"] = updated.RowEnd
+	updates["seats_per_row"] = updated.SeatsPerRow
+	updates["total_seats"] = updated.TotalSeats
+	return regenerated, nil
+}
+
+// ReconcileTemplateSections reports the sections of a template whose declared
+// size doesn't match their rows or their seats
+func (s *service) ReconcileTemplateSections(ctx context.Context, id string) (*SectionReconciliationResponse, error) {
+	templateID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template ID: %w", err)
+	}
+
+	template, err := s.repo.GetTemplateByID(ctx, templateID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("template not found")
+		}
+		return nil, fmt.Errorf("failed to get template: %w", err)
+	}
+
+	counts, err := s.repo.GetSectionSeatCounts(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &SectionReconciliationResponse{
+		TemplateID: templateID.String(),
+		Version:    template.Version,
+		Sections:   make([]SectionReconciliation, 0, len(counts)),
+	}
+	for _, count := range counts {
+		section := SectionReconciliation{
+			SectionID:   count.ID.String(),
+			Name:        count.Name,
+			RowStart:    count.RowStart,
+			RowEnd:      count.RowEnd,
+			SeatsPerRow: count.SeatsPerRow,
+			TotalSeats:  count.TotalSeats,
+			ActualSeats: count.ActualSeats,
+			ActualRows:  count.ActualRows,
+			BookedSeats: count.BookedSeats,
+			Issues:      []string{},
+		}
+
+		rows, err := s.sectionRows(&VenueSection{RowStart: count.RowStart, RowEnd: count.RowEnd})
+		if err != nil {
+			section.Issues = append(section.Issues, err.Error())
+		} else {
+			gridSeats := len(rows) * count.SeatsPerRow
+			section.GridSeats = &gridSeats
+			if gridSeats != count.TotalSeats {
+				section.Issues = append(section.Issues, fmt.Sprintf("rows %s-%s with %d seats per row make %d seats, not %d",
+					count.RowStart, count.RowEnd, count.SeatsPerRow, gridSeats, count.TotalSeats))
+			}
+			section.CanRegenerate = count.BookedSeats == 0
+		}
+		if count.ActualSeats != count.TotalSeats {
+			section.Issues = append(section.Issues, fmt.Sprintf("total_seats is %d but the section has %d seats", count.TotalSeats, count.ActualSeats))
+		}
+
+		section.Consistent = len(section.Issues) == 0
+		if section.Consistent {
+			report.Consistent++
+		} else {
+			report.Inconsistent++
+		}
+		report.Sections = append(report.Sections, section)
+	}
+
+	return report, nil
+}
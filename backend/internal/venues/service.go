@@ -4,18 +4,22 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
+	"io"
 	"strconv"
 
 	"evently/internal/seats"
 	"evently/internal/shared/utils/constants"
 	"evently/pkg/cache"
+	"evently/pkg/logger"
 
 	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
 	"gorm.io/gorm"
 )
 
+// log is the logger of the venues module, see LOG_MODULE_LEVELS
+var log = logger.Module("venues")
+
 type Service interface {
 	// Venue Templates
 	CreateTemplate(ctx context.Context, req CreateTemplateRequest) (*VenueTemplate, error)
@@ -23,6 +27,24 @@ type Service interface {
 	GetTemplates(ctx context.Context, filters TemplateFilters) (*PaginatedTemplates, error)
 	UpdateTemplate(ctx context.Context, id string, req UpdateTemplateRequest) (*VenueTemplate, error)
 	DeleteTemplate(ctx context.Context, id string) error
+	DiffTemplates(ctx context.Context, fromID string, toID string) (*TemplateDiffResponse, error)
+
+	// Template versions (Section edits of a template events use create a new version)
+	DuplicateTemplate(ctx context.Context, id string, req DuplicateTemplateRequest) (*VenueTemplate, error)
+	GetTemplateVersions(ctx context.Context, id string) ([]TemplateVersionResponse, error)
+	DiffTemplateVersions(ctx context.Context, id string, from, to int) (*TemplateDiffResponse, error)
+
+	// Physical Venues (Buildings that templates are used in)
+	CreatePhysicalVenue(ctx context.Context, req CreatePhysicalVenueRequest) (*PhysicalVenue, error)
+	GetPhysicalVenueByID(ctx context.Context, id string) (*PhysicalVenue, error)
+	GetPhysicalVenues(ctx context.Context) ([]PhysicalVenue, error)
+	UpdatePhysicalVenue(ctx context.Context, id string, req UpdatePhysicalVenueRequest) (*PhysicalVenue, error)
+	DeletePhysicalVenue(ctx context.Context, id string) error
+
+	// Seat map layout editor
+	GetTemplateLayout(ctx context.Context, id string) (*TemplateLayoutResponse, error)
+	UpdateTemplateLayout(ctx context.Context, id string, req UpdateTemplateLayoutRequest) (*TemplateLayoutResponse, error)
+	ImportTemplateLayout(ctx context.Context, id string, format ManifestFormat, manifest io.Reader, dryRun bool) (*LayoutImportResponse, error)
 
 	// Venue Sections (Fixed per template)
 	CreateSection(ctx context.Context, templateID string, req CreateSectionRequest) (*VenueSection, error)
@@ -30,6 +52,7 @@ type Service interface {
 	GetSectionsByEventID(ctx context.Context, eventID string) ([]VenueSection, error)
 	UpdateSection(ctx context.Context, id string, req UpdateSectionRequest) (*VenueSection, error)
 	DeleteSection(ctx context.Context, id string) error
+	ReconcileTemplateSections(ctx context.Context, templateID string) (*SectionReconciliationResponse, error)
 
 	// Event Pricing (Per event-section combination)
 	CreateEventPricing(ctx context.Context, req CreateEventPricingRequest) (*EventPricingResponse, error)
@@ -40,12 +63,13 @@ type Service interface {
 
 	// Venue Layout for Events
 	GetVenueLayout(ctx context.Context, eventID string) (*VenueLayoutResponse, error)
+	PreviewVenueLayout(ctx context.Context, eventID string) (*VenueLayoutResponse, error)
 }
 
 type service struct {
 	repo        Repository
 	seatRepo    seats.Repository
-	redisClient *redis.Client
+	redisClient redis.UniversalClient
 }
 
 func NewService(repo Repository, seatRepo seats.Repository) Service {
@@ -85,6 +109,16 @@ func (s *service) CreateTemplate(ctx context.Context, req CreateTemplateRequest)
 		DefaultRows:        req.DefaultRows,
 		DefaultSeatsPerRow: req.DefaultSeatsPerRow,
 		LayoutType:         req.LayoutType,
+		Version:            1,
+	}
+	template.LineageID = template.ID
+
+	if req.PhysicalVenueID != "" {
+		venueID, err := s.resolvePhysicalVenue(ctx, req.PhysicalVenueID)
+		if err != nil {
+			return nil, err
+		}
+		template.PhysicalVenueID = &venueID
 	}
 
 	if err := s.repo.CreateTemplate(ctx, template); err != nil {
@@ -93,7 +127,7 @@ func (s *service) CreateTemplate(ctx context.Context, req CreateTemplateRequest)
 
 	// Invalidate venue template caches after creation
 	if err := InvalidateVenueCache(ctx, s.redisClient, nil); err != nil {
-		log.Printf("Warning: failed to invalidate venue cache after template creation: %v", err)
+		log.WarnContext(ctx, "Failed to invalidate venue cache after template creation", "error", err)
 	}
 
 	return template, nil
@@ -110,10 +144,7 @@ func (s *service) GetTemplateByID(ctx context.Context, id string) (*VenueTemplat
 	// get from cache first
 	var cachedTemplate VenueTemplate
 	if err := GetCache(ctx, s.redisClient, cacheKey, &cachedTemplate); err == nil {
-		log.Printf("Cache HIT for venue template: %s", cacheKey)
 		return &cachedTemplate, nil
-	} else {
-		log.Printf("Cache MISS for venue template: %s (error: %v)", cacheKey, err)
 	}
 
 	// Cache miss
@@ -127,9 +158,7 @@ func (s *service) GetTemplateByID(ctx context.Context, id string) (*VenueTemplat
 
 	// Cache it
 	if err := SetCache(ctx, s.redisClient, cacheKey, template, constants.TTL_VENUE_TEMPLATE); err != nil {
-		log.Printf("Warning: failed to cache venue template: %v", err)
-	} else {
-		log.Printf("Cached venue template: %s", cacheKey)
+		log.WarnContext(ctx, "Failed to cache venue template", "error", err)
 	}
 
 	return template, nil
@@ -148,21 +177,19 @@ func (s *service) GetTemplates(ctx context.Context, filters TemplateFilters) (*P
 	}
 
 	// Build cache key based on filters
-	cacheKey := fmt.Sprintf("%s:page:%d:limit:%d:type:%s:search:%s",
+	cacheKey := fmt.Sprintf("%s:page:%d:limit:%d:type:%s:search:%s:all:%t",
 		constants.CACHE_KEY_VENUE_TEMPLATES,
 		filters.Page,
 		filters.Limit,
 		filters.LayoutType,
 		filters.Search,
+		filters.AllVersions,
 	)
 
 	// Try to get from cache first
 	var cachedResult PaginatedTemplates
 	if err := GetCache(ctx, s.redisClient, cacheKey, &cachedResult); err == nil {
-		log.Printf("Cache HIT for venue templates: %s", cacheKey)
 		return &cachedResult, nil
-	} else {
-		log.Printf("Cache MISS for venue templates: %s (error: %v)", cacheKey, err)
 	}
 
 	// Cache miss
@@ -173,10 +200,7 @@ func (s *service) GetTemplates(ctx context.Context, filters TemplateFilters) (*P
 
 	// Cache it
 	if err := SetCache(ctx, s.redisClient, cacheKey, result, constants.TTL_VENUE_TEMPLATES); err != nil {
-
-		log.Printf("Warning: failed to cache venue templates: %v", err)
-	} else {
-		log.Printf("Cached venue templates: %s", cacheKey)
+		log.WarnContext(ctx, "Failed to cache venue templates", "error", err)
 	}
 
 	return result, nil
@@ -199,18 +223,20 @@ func (s *service) UpdateTemplate(ctx context.Context, id string, req UpdateTempl
 
 	updates := make(map[string]interface{})
 
-	if req.Name != nil {
-		// Check name uniqueness if changing name
-		if *req.Name != existing.Name {
-			nameExists, err := s.repo.GetTemplateByName(ctx, *req.Name)
-			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
-				return nil, fmt.Errorf("failed to check template name: %w", err)
-			}
-			if nameExists != nil {
-				return nil, fmt.Errorf("template with name '%s' already exists", *req.Name)
-			}
+	// Versions of a template share its name, so a rename applies to all of them
+	renamed := false
+	if req.Name != nil && *req.Name != existing.Name {
+		nameExists, err := s.repo.GetTemplateByName(ctx, *req.Name)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("failed to check template name: %w", err)
 		}
-		updates["name"] = *req.Name
+		if nameExists != nil && nameExists.LineageID != existing.LineageID {
+			return nil, fmt.Errorf("template with name '%s' already exists", *req.Name)
+		}
+		if err := s.repo.RenameLineage(ctx, existing.LineageID, *req.Name); err != nil {
+			return nil, fmt.Errorf("failed to rename template: %w", err)
+		}
+		renamed = true
 	}
 
 	if req.Description != nil {
@@ -238,15 +264,24 @@ func (s *service) UpdateTemplate(ctx context.Context, id string, req UpdateTempl
 		updates["layout_type"] = *req.LayoutType
 	}
 
+	if req.PhysicalVenueID != nil {
+		venueID, err := s.resolvePhysicalVenue(ctx, *req.PhysicalVenueID)
+		if err != nil {
+			return nil, err
+		}
+		updates["physical_venue_id"] = venueID
+	}
+
 	if len(updates) > 0 {
 		if err := s.repo.UpdateTemplate(ctx, templateID, updates); err != nil {
 			return nil, fmt.Errorf("failed to update template: %w", err)
 		}
+	}
 
+	if len(updates) > 0 || renamed {
 		// Invalidate specific template caches after update
 		if err := InvalidateVenueCache(ctx, s.redisClient, &templateID); err != nil {
-
-			log.Printf("Warning: failed to invalidate venue cache after template update: %v", err)
+			log.WarnContext(ctx, "Failed to invalidate venue cache after template update", "error", err)
 		}
 	}
 
@@ -276,6 +311,178 @@ func (s *service) DeleteTemplate(ctx context.Context, id string) error {
 	return nil
 }
 
+// DiffTemplates compares two versions of a venue template. Booked seats of
+// upcoming events pinned to the older version are checked against the newer one.
+func (s *service) DiffTemplates(ctx context.Context, fromID string, toID string) (*TemplateDiffResponse, error) {
+	fromUUID, err := uuid.Parse(fromID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template ID: %w", err)
+	}
+	toUUID, err := uuid.Parse(toID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template ID: %w", err)
+	}
+	if fromUUID == toUUID {
+		return nil, fmt.Errorf("cannot diff a template against itself")
+	}
+
+	templates := make([]*VenueTemplate, 2)
+	for i, id := range []uuid.UUID{fromUUID, toUUID} {
+		template, err := s.repo.GetTemplateByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, fmt.Errorf("template not found")
+			}
+			return nil, fmt.Errorf("failed to get template: %w", err)
+		}
+		templates[i] = template
+	}
+
+	fromSections, err := s.repo.GetSectionsWithSeats(ctx, fromUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sections: %w", err)
+	}
+	toSections, err := s.repo.GetSectionsWithSeats(ctx, toUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sections: %w", err)
+	}
+
+	booked, err := s.repo.GetBookedSeatsForTemplate(ctx, fromUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := buildTemplateDiff(fromUUID, toUUID, fromSections, toSections, booked)
+	diff.FromVersion, diff.ToVersion = templates[0].Version, templates[1].Version
+	return diff, nil
+}
+
+//  PHYSICAL VENUES
+
+func (s *service) CreatePhysicalVenue(ctx context.Context, req CreatePhysicalVenueRequest) (*PhysicalVenue, error) {
+	existing, err := s.repo.GetPhysicalVenueByName(ctx, req.Name)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to check venue name: %w", err)
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("venue with name '%s' already exists", req.Name)
+	}
+
+	venue := &PhysicalVenue{
+		ID:                     uuid.New(),
+		Name:                   req.Name,
+		Address:                req.Address,
+		City:                   req.City,
+		DefaultDurationMinutes: req.DefaultDurationMinutes,
+		ChangeoverMinutes:      req.ChangeoverMinutes,
+	}
+	if venue.DefaultDurationMinutes == 0 {
+		venue.DefaultDurationMinutes = 180
+	}
+
+	if err := s.repo.CreatePhysicalVenue(ctx, venue); err != nil {
+		return nil, fmt.Errorf("failed to create venue: %w", err)
+	}
+
+	return venue, nil
+}
+
+func (s *service) GetPhysicalVenueByID(ctx context.Context, id string) (*PhysicalVenue, error) {
+	venueID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid venue ID: %w", err)
+	}
+
+	venue, err := s.repo.GetPhysicalVenueByID(ctx, venueID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("venue not found")
+		}
+		return nil, fmt.Errorf("failed to get venue: %w", err)
+	}
+	return venue, nil
+}
+
+func (s *service) GetPhysicalVenues(ctx context.Context) ([]PhysicalVenue, error) {
+	venues, err := s.repo.GetPhysicalVenues(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get venues: %w", err)
+	}
+	return venues, nil
+}
+
+func (s *service) UpdatePhysicalVenue(ctx context.Context, id string, req UpdatePhysicalVenueRequest) (*PhysicalVenue, error) {
+	existing, err := s.GetPhysicalVenueByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := make(map[string]interface{})
+
+	if req.Name != nil {
+		if *req.Name != existing.Name {
+			nameExists, err := s.repo.GetPhysicalVenueByName(ctx, *req.Name)
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, fmt.Errorf("failed to check venue name: %w", err)
+			}
+			if nameExists != nil {
+				return nil, fmt.Errorf("venue with name '%s' already exists", *req.Name)
+			}
+		}
+		updates["name"] = *req.Name
+	}
+	if req.Address != nil {
+		updates["address"] = *req.Address
+	}
+	if req.City != nil {
+		updates["city"] = *req.City
+	}
+	if req.DefaultDurationMinutes != nil {
+		updates["default_duration_minutes"] = *req.DefaultDurationMinutes
+	}
+	if req.ChangeoverMinutes != nil {
+		updates["changeover_minutes"] = *req.ChangeoverMinutes
+	}
+
+	if len(updates) > 0 {
+		if err := s.repo.UpdatePhysicalVenue(ctx, existing.ID, updates); err != nil {
+			return nil, fmt.Errorf("failed to update venue: %w", err)
+		}
+	}
+
+	return s.repo.GetPhysicalVenueByID(ctx, existing.ID)
+}
+
+func (s *service) DeletePhysicalVenue(ctx context.Context, id string) error {
+	venue, err := s.GetPhysicalVenueByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// Templates pointing at a deleted venue would silently lose their overlap checks
+	templates, err := s.repo.CountTemplatesForPhysicalVenue(ctx, venue.ID)
+	if err != nil {
+		return fmt.Errorf("failed to check venue templates: %w", err)
+	}
+	if templates > 0 {
+		return fmt.Errorf("venue is used by %d template(s), move them to another venue first", templates)
+	}
+
+	if err := s.repo.DeletePhysicalVenue(ctx, venue.ID); err != nil {
+		return fmt.Errorf("failed to delete venue: %w", err)
+	}
+	return nil
+}
+
+// resolvePhysicalVenue checks that a template's venue exists
+func (s *service) resolvePhysicalVenue(ctx context.Context, id string) (uuid.UUID, error) {
+	venue, err := s.GetPhysicalVenueByID(ctx, id)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return venue.ID, nil
+}
+
 //  VENUE SECTIONS
 
 func (s *service) CreateSection(ctx context.Context, templateID string, req CreateSectionRequest) (*VenueSection, error) {
@@ -284,34 +491,37 @@ func (s *service) CreateSection(ctx context.Context, templateID string, req Crea
 		return nil, fmt.Errorf("invalid template ID: %w", err)
 	}
 
-	// Validate template exists
-	_, err = s.repo.GetTemplateByID(ctx, templateUUID)
+	// Sections are added to a new version when events use this one
+	template, _, err := s.editableVersion(ctx, templateUUID)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("template not found")
-		}
-		return nil, fmt.Errorf("failed to validate template: %w", err)
+		return nil, err
 	}
 
 	section := &VenueSection{
-		TemplateID:  templateUUID,
+		ID:          uuid.New(),
+		TemplateID:  template.ID,
 		Name:        req.Name,
 		Description: req.Description,
 		RowStart:    req.RowStart,
 		RowEnd:      req.RowEnd,
 		SeatsPerRow: req.SeatsPerRow,
 		TotalSeats:  req.TotalSeats,
+		Amenities:   StringList(req.Amenities),
+	}
+
+	// total_seats has to match the rows, so the section and its seats are
+	// checked before either is written
+	sectionSeats, err := s.buildSectionSeats(section)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := s.repo.CreateSection(ctx, section); err != nil {
+	if err := s.repo.CreateSectionWithSeats(ctx, section, sectionSeats); err != nil {
 		return nil, fmt.Errorf("failed to create section: %w", err)
 	}
 
-	// Auto-generate seats for the section
-	if err := s.generateSeatsForSection(ctx, section); err != nil {
-		// If seat generation fails, we might want to rollback the section creation
-		// For now, just log the error and continue
-		return nil, fmt.Errorf("failed to generate seats for section: %w", err)
+	if err := InvalidateVenueCache(ctx, s.redisClient, &template.ID); err != nil {
+		log.WarnContext(ctx, "Failed to invalidate venue cache after section creation", "error", err)
 	}
 
 	return section, nil
@@ -328,10 +538,7 @@ func (s *service) GetSectionsByTemplateID(ctx context.Context, templateID string
 	// Try to get from cache first
 	var cachedSections []VenueSection
 	if err := GetCache(ctx, s.redisClient, cacheKey, &cachedSections); err == nil {
-		log.Printf("Cache HIT for venue sections: %s", cacheKey)
 		return cachedSections, nil
-	} else {
-		log.Printf("Cache MISS for venue sections: %s (error: %v)", cacheKey, err)
 	}
 
 	// Cache miss - get from database
@@ -342,10 +549,7 @@ func (s *service) GetSectionsByTemplateID(ctx context.Context, templateID string
 
 	// Cache the result
 	if err := SetCache(ctx, s.redisClient, cacheKey, sections, constants.TTL_VENUE_SECTIONS); err != nil {
-
-		log.Printf("Warning: failed to cache venue sections: %v", err)
-	} else {
-		log.Printf("Cached venue sections: %s", cacheKey)
+		log.WarnContext(ctx, "Failed to cache venue sections", "error", err)
 	}
 
 	return sections, nil
@@ -358,7 +562,7 @@ func (s *service) GetSectionsByEventID(ctx context.Context, eventID string) ([]V
 	}
 
 	// Get the venue layout which contains the sections (this internally gets the template ID)
-	layout, err := s.repo.GetVenueLayoutForEvent(ctx, eventUUID)
+	layout, err := s.repo.GetVenueLayoutForEvent(ctx, eventUUID, false)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get venue layout: %w", err)
 	}
@@ -394,42 +598,43 @@ func (s *service) GetVenueLayout(ctx context.Context, eventID string) (*VenueLay
 	// Try to get from cache first
 	var cachedLayout VenueLayoutResponse
 	if err := GetCache(ctx, s.redisClient, cacheKey, &cachedLayout); err == nil {
-		log.Printf("Cache HIT for venue layout: %s", cacheKey)
 		return &cachedLayout, nil
-	} else {
-		log.Printf("Cache MISS for venue layout: %s (error: %v)", cacheKey, err)
 	}
 
 	// Cache miss - get from database
-	layout, err := s.repo.GetVenueLayoutForEvent(ctx, eventUUID)
+	layout, err := s.repo.GetVenueLayoutForEvent(ctx, eventUUID, false)
 	if err != nil {
 		return nil, err
 	}
 
 	// Cache the result
 	if err := SetCache(ctx, s.redisClient, cacheKey, layout, constants.TTL_VENUE_LAYOUT); err != nil {
-
-		log.Printf("Warning: failed to cache venue layout: %v", err)
-	} else {
-		log.Printf("Cached venue layout: %s", cacheKey)
+		log.WarnContext(ctx, "Failed to cache venue layout", "error", err)
 	}
 
 	return layout, nil
 }
 
+// PreviewVenueLayout returns an event's seat map for admins, drafts included.
+// It is read from the database every time so a draft's layout never lands in
+// the public cache.
+func (s *service) PreviewVenueLayout(ctx context.Context, eventID string) (*VenueLayoutResponse, error) {
+	eventUUID, err := uuid.Parse(eventID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid event ID: %w", err)
+	}
+	return s.repo.GetVenueLayoutForEvent(ctx, eventUUID, true)
+}
+
 func (s *service) UpdateSection(ctx context.Context, id string, req UpdateSectionRequest) (*VenueSection, error) {
 	sectionID, err := uuid.Parse(id)
 	if err != nil {
 		return nil, fmt.Errorf("invalid section ID: %w", err)
 	}
 
-	// Check if section exists
-	_, err = s.repo.GetSectionByID(ctx, sectionID)
+	sectionID, err = s.editableSection(ctx, sectionID)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("section not found")
-		}
-		return nil, fmt.Errorf("failed to get section: %w", err)
+		return nil, err
 	}
 
 	updates := make(map[string]interface{})
@@ -464,7 +669,22 @@ func (s *service) UpdateSection(ctx context.Context, id string, req UpdateSectio
 		updates["total_seats"] = *req.TotalSeats
 	}
 
-	if len(updates) > 0 {
+	if req.Amenities != nil {
+		updates["amenities"] = StringList(*req.Amenities)
+	}
+
+	// Changing rows or seats per row rebuilds the section's seats
+	regenerated, err := s.checkSectionSeats(ctx, sectionID, req, updates)
+	if err != nil {
+		return nil, err
+	}
+
+	if regenerated != nil {
+		if err := s.repo.RegenerateSectionSeats(ctx, sectionID, updates, regenerated); err != nil {
+			return nil, fmt.Errorf("failed to regenerate section seats: %w", err)
+		}
+		log.InfoContext(ctx, "Regenerated section seats", "section_id", sectionID, "seats", len(regenerated))
+	} else if len(updates) > 0 {
 		if err := s.repo.UpdateSection(ctx, sectionID, updates); err != nil {
 			return nil, fmt.Errorf("failed to update section: %w", err)
 		}
@@ -480,13 +700,9 @@ func (s *service) DeleteSection(ctx context.Context, id string) error {
 		return fmt.Errorf("invalid section ID: %w", err)
 	}
 
-	// Check if section exists
-	_, err = s.repo.GetSectionByID(ctx, sectionID)
+	sectionID, err = s.editableSection(ctx, sectionID)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return fmt.Errorf("section not found")
-		}
-		return fmt.Errorf("failed to get section: %w", err)
+		return err
 	}
 
 	if err := s.repo.DeleteSection(ctx, sectionID); err != nil {
@@ -496,6 +712,27 @@ func (s *service) DeleteSection(ctx context.Context, id string) error {
 	return nil
 }
 
+// editableSection returns the ID of the section edits should be written to,
+// which is its copy in a new version when events use the section's template
+func (s *service) editableSection(ctx context.Context, sectionID uuid.UUID) (uuid.UUID, error) {
+	section, err := s.repo.GetSectionByID(ctx, sectionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return uuid.Nil, fmt.Errorf("section not found")
+		}
+		return uuid.Nil, fmt.Errorf("failed to get section: %w", err)
+	}
+
+	_, sectionIDs, err := s.editableVersion(ctx, section.TemplateID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if copied, ok := sectionIDs[sectionID]; ok {
+		return copied, nil
+	}
+	return sectionID, nil
+}
+
 //  EVENT PRICING
 
 func (s *service) CreateEventPricing(ctx context.Context, req CreateEventPricingRequest) (*EventPricingResponse, error) {
@@ -530,6 +767,8 @@ func (s *service) CreateEventPricing(ctx context.Context, req CreateEventPricing
 		return nil, fmt.Errorf("failed to create event pricing: %w", err)
 	}
 
+	s.invalidateEventPriceCache(ctx, eventID)
+
 	// Get section name for response
 	section, err := s.repo.GetSectionByID(ctx, sectionID)
 	if err != nil {
@@ -569,42 +808,37 @@ func (s *service) UpdateEventPricing(ctx context.Context, id string, req UpdateE
 		return nil, fmt.Errorf("invalid pricing ID: %w", err)
 	}
 
+	pricing, err := s.repo.GetEventPricingByID(ctx, pricingID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pricing: %w", err)
+	}
+
 	updates := make(map[string]interface{})
 
 	if req.PriceMultiplier != nil {
 		updates["price_multiplier"] = *req.PriceMultiplier
+		pricing.PriceMultiplier = *req.PriceMultiplier
 	}
 
 	if req.IsActive != nil {
 		updates["is_active"] = *req.IsActive
+		pricing.IsActive = *req.IsActive
 	}
 
 	if len(updates) > 0 {
 		if err := s.repo.UpdateEventPricing(ctx, pricingID, updates); err != nil {
 			return nil, fmt.Errorf("failed to update pricing: %w", err)
 		}
-	}
 
-	// Get updated pricing for response
-	eventUUID := uuid.Nil // We need to get this from the pricing record
-	pricings, err := s.repo.GetEventPricingByEventID(ctx, eventUUID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get updated pricing: %w", err)
+		s.invalidateEventPriceCache(ctx, pricing.EventID)
 	}
 
-	// Find the updated pricing (simplified for now)
-	for _, pricing := range pricings {
-		if pricing.ID.String() == id {
-			sectionName := ""
-			if pricing.Section != nil {
-				sectionName = pricing.Section.Name
-			}
-			response := pricing.ToResponse(sectionName, 0)
-			return &response, nil
-		}
+	sectionName := ""
+	if pricing.Section != nil {
+		sectionName = pricing.Section.Name
 	}
-
-	return nil, fmt.Errorf("updated pricing not found")
+	response := pricing.ToResponse(sectionName, 0) // Base price will be set by caller
+	return &response, nil
 }
 
 func (s *service) DeleteEventPricing(ctx context.Context, id string) error {
@@ -613,10 +847,17 @@ func (s *service) DeleteEventPricing(ctx context.Context, id string) error {
 		return fmt.Errorf("invalid pricing ID: %w", err)
 	}
 
+	pricing, err := s.repo.GetEventPricingByID(ctx, pricingID)
+	if err != nil {
+		return fmt.Errorf("failed to get pricing: %w", err)
+	}
+
 	if err := s.repo.DeleteEventPricing(ctx, pricingID); err != nil {
 		return fmt.Errorf("failed to delete pricing: %w", err)
 	}
 
+	s.invalidateEventPriceCache(ctx, pricing.EventID)
+
 	return nil
 }
 
@@ -630,50 +871,54 @@ func (s *service) DeleteEventPricingByEventID(ctx context.Context, eventID strin
 		return fmt.Errorf("failed to delete event pricing: %w", err)
 	}
 
+	s.invalidateEventPriceCache(ctx, eventUUID)
+
 	return nil
 }
 
-//  HELPER FUNCTIONS
-
-// generateSeatsForSection automatically creates seats for a venue section
-func (s *service) generateSeatsForSection(ctx context.Context, section *VenueSection) error {
-	if section.RowStart == "" || section.RowEnd == "" {
-		return fmt.Errorf("row start and end must be specified for seat generation")
+// invalidateEventPriceCache drops cached responses showing the event's section
+// prices. A failure is only logged; the entries expire with their TTL.
+func (s *service) invalidateEventPriceCache(ctx context.Context, eventID uuid.UUID) {
+	if err := InvalidateEventPriceCache(ctx, s.redisClient, eventID); err != nil {
+		log.WarnContext(ctx, "Failed to invalidate price caches", "event_id", eventID, "error", err)
 	}
+}
+
+//  HELPER FUNCTIONS
 
-	// Generate row labels (A-Z or numeric)
-	rows, err := s.generateRowLabels(section.RowStart, section.RowEnd)
+// buildSectionSeats lays out seats row by row, seats_per_row to a row
+func (s *service) buildSectionSeats(section *VenueSection) ([]Seat, error) {
+	rows, err := s.sectionRows(section)
 	if err != nil {
-		return fmt.Errorf("failed to generate row labels: %w", err)
+		return nil, err
 	}
 
-	var seatsToCreate []seats.Seat
+	seatsToCreate := make([]Seat, 0, len(rows)*section.SeatsPerRow)
 	position := 1
 
 	// Generate seats for each row
 	for _, row := range rows {
 		for seatNum := 1; seatNum <= section.SeatsPerRow; seatNum++ {
-			seat := seats.Seat{
+			seatsToCreate = append(seatsToCreate, Seat{
 				ID:         uuid.New(),
 				SectionID:  section.ID,
 				SeatNumber: fmt.Sprintf("%s%d", row, seatNum),
 				Row:        row,
 				Position:   position,
 				Status:     "AVAILABLE",
-			}
-			seatsToCreate = append(seatsToCreate, seat)
+				Aisle:      seatNum == 1 || seatNum == section.SeatsPerRow,
+			})
 			position++
 		}
 	}
 
 	// Validate total seats match
 	if len(seatsToCreate) != section.TotalSeats {
-		return fmt.Errorf("generated seat count (%d) doesn't match section total (%d)",
-			len(seatsToCreate), section.TotalSeats)
+		return nil, fmt.Errorf("invalid section: total_seats is %d but rows %s-%s with %d seats per row make %d",
+			section.TotalSeats, section.RowStart, section.RowEnd, section.SeatsPerRow, len(seatsToCreate))
 	}
 
-	// Create all seats in batch
-	return s.seatRepo.CreateSeats(ctx, seatsToCreate)
+	return seatsToCreate, nil
 }
 
 // generateRowLabels creates row labels between start and end
@@ -0,0 +1,214 @@
+package venues
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Events pin the template version they were created on. Editing the sections
+// of a version that events use copies it into a new version first, so seat
+// maps of existing events never change under them. Versions nobody uses yet
+// are edited in place.
+
+var ErrTemplateSuperseded = errors.New("template version has been superseded, edit the latest version")
+
+// editableVersion returns the template version section edits should be written
+// to. When the template is copied into a new version, the IDs of its sections
+// are mapped to their copies.
+func (s *service) editableVersion(ctx context.Context, templateID uuid.UUID) (*VenueTemplate, map[uuid.UUID]uuid.UUID, error) {
+	template, err := s.repo.GetTemplateByID(ctx, templateID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, fmt.Errorf("template not found")
+		}
+		return nil, nil, fmt.Errorf("failed to get template: %w", err)
+	}
+
+	latest, err := s.repo.GetLatestTemplateVersion(ctx, template.LineageID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get latest template version: %w", err)
+	}
+	if latest.ID != template.ID {
+		return nil, nil, fmt.Errorf("%w: version %d is the latest", ErrTemplateSuperseded, latest.Version)
+	}
+
+	events, err := s.repo.CountEventsForTemplate(ctx, template.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to count template events: %w", err)
+	}
+	if events == 0 {
+		return template, nil, nil
+	}
+
+	version, err := s.repo.NextTemplateVersion(ctx, template.LineageID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to number template version: %w", err)
+	}
+	next := *template
+	next.ID = uuid.New()
+	next.Version = version
+	next.PreviousVersionID = &template.ID
+
+	sectionIDs, err := s.copyTemplate(ctx, template.ID, &next)
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key") {
+			return nil, nil, fmt.Errorf("%w: another edit created a new version", ErrTemplateSuperseded)
+		}
+		return nil, nil, err
+	}
+
+	log.InfoContext(ctx, "Venue template copied into a new version", "lineage_id", template.LineageID, "version", next.Version, "template_id", next.ID, "events_kept", events, "kept_version", template.Version)
+	return &next, sectionIDs, nil
+}
+
+// copyTemplate creates template with copies of the sections and seats of
+// source, and returns the IDs of the copied sections by their source IDs
+func (s *service) copyTemplate(ctx context.Context, sourceID uuid.UUID, template *VenueTemplate) (map[uuid.UUID]uuid.UUID, error) {
+	sections, err := s.repo.GetSectionsWithSeats(ctx, sourceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sections: %w", err)
+	}
+
+	sectionIDs := make(map[uuid.UUID]uuid.UUID, len(sections))
+	copies := make([]VenueSection, len(sections))
+	var seats []Seat
+	for i, section := range sections {
+		sectionIDs[section.ID] = uuid.New()
+		copies[i] = section
+		copies[i].ID = sectionIDs[section.ID]
+		copies[i].TemplateID = template.ID
+		copies[i].Template = nil
+		copies[i].Seats = nil
+		copies[i].CreatedAt, copies[i].UpdatedAt = time.Time{}, time.Time{}
+
+		for _, seat := range section.Seats {
+			seat.ID = uuid.New()
+			seat.SectionID = copies[i].ID
+			seat.CreatedAt, seat.UpdatedAt = time.Time{}, time.Time{}
+			seats = append(seats, seat)
+		}
+	}
+
+	template.CreatedAt, template.UpdatedAt = time.Time{}, time.Time{}
+	template.DeletedAt = gorm.DeletedAt{}
+	if err := s.repo.CopyTemplate(ctx, template, copies, seats); err != nil {
+		return nil, fmt.Errorf("failed to copy template: %w", err)
+	}
+
+	if err := InvalidateVenueCache(ctx, s.redisClient, &sourceID); err != nil {
+		log.WarnContext(ctx, "Failed to invalidate venue cache after template copy", "error", err)
+	}
+	return sectionIDs, nil
+}
+
+// DuplicateTemplate copies a template version into a new template of its own,
+// starting at version 1
+func (s *service) DuplicateTemplate(ctx context.Context, id string, req DuplicateTemplateRequest) (*VenueTemplate, error) {
+	templateID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template ID: %w", err)
+	}
+
+	source, err := s.repo.GetTemplateByID(ctx, templateID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("template not found")
+		}
+		return nil, fmt.Errorf("failed to get template: %w", err)
+	}
+
+	existing, err := s.repo.GetTemplateByName(ctx, req.Name)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to check template name: %w", err)
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("template with name '%s' already exists", req.Name)
+	}
+
+	duplicate := *source
+	duplicate.ID = uuid.New()
+	duplicate.Name = req.Name
+	duplicate.LineageID = duplicate.ID
+	duplicate.Version = 1
+	duplicate.PreviousVersionID = nil
+	if req.Description != nil {
+		duplicate.Description = *req.Description
+	}
+
+	if _, err := s.copyTemplate(ctx, source.ID, &duplicate); err != nil {
+		return nil, err
+	}
+	return &duplicate, nil
+}
+
+// GetTemplateVersions lists every version of the template id belongs to
+func (s *service) GetTemplateVersions(ctx context.Context, id string) ([]TemplateVersionResponse, error) {
+	templateID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template ID: %w", err)
+	}
+
+	template, err := s.repo.GetTemplateByID(ctx, templateID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("template not found")
+		}
+		return nil, fmt.Errorf("failed to get template: %w", err)
+	}
+
+	return s.repo.GetTemplateVersions(ctx, template.LineageID)
+}
+
+// DiffTemplateVersions compares two versions of the template id belongs to.
+// to defaults to the latest version and from to the one before it.
+func (s *service) DiffTemplateVersions(ctx context.Context, id string, from, to int) (*TemplateDiffResponse, error) {
+	templateID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template ID: %w", err)
+	}
+
+	template, err := s.repo.GetTemplateByID(ctx, templateID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("template not found")
+		}
+		return nil, fmt.Errorf("failed to get template: %w", err)
+	}
+
+	if to == 0 {
+		latest, err := s.repo.GetLatestTemplateVersion(ctx, template.LineageID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get latest template version: %w", err)
+		}
+		to = latest.Version
+	}
+	if from == 0 {
+		if to <= 1 {
+			return nil, fmt.Errorf("invalid version: template has a single version")
+		}
+		from = to - 1
+	}
+	if from < 1 || to < 1 {
+		return nil, fmt.Errorf("invalid version: versions start at 1")
+	}
+
+	versionIDs := make([]string, 2)
+	for i, version := range []int{from, to} {
+		v, err := s.repo.GetTemplateVersion(ctx, template.LineageID, version)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, fmt.Errorf("template version not found")
+			}
+			return nil, fmt.Errorf("failed to get template version: %w", err)
+		}
+		versionIDs[i] = v.ID.String()
+	}
+
+	return s.DiffTemplates(ctx, versionIDs[0], versionIDs[1])
+}
@@ -1,9 +1,12 @@
 package waitlist
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
+	"evently/internal/shared/utils/response"
+
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
@@ -18,137 +21,190 @@ func NewController(service Service) *Controller {
 	}
 }
 
+// JoinWaitlist godoc
+//
+// @Summary      Join waitlist
+// @Description  Join the waitlist for an event
+// @Tags         Waitlist
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        request body JoinWaitlistRequest true "Request body"
+// @Success      201 {object} response.StandardApiResponse{data=WaitlistResponse} "Successfully joined waitlist"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Router       /api/v1/waitlist [post]
 func (c *Controller) JoinWaitlist(ctx *gin.Context) {
 	var request JoinWaitlistRequest
 	if err := ctx.ShouldBindJSON(&request); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request body",
-			"details": err.Error(),
-		})
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid request body", nil, err.Error())
 		return
 	}
 
 	// user ID from jwt
 	userIDStr, exists := ctx.Get("user_id")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{
-			"error": "User not authenticated",
-		})
+		response.RespondJSON(ctx, "error", http.StatusUnauthorized, "User not authenticated", nil, nil)
 		return
 	}
 
 	userID, err := uuid.Parse(userIDStr.(string))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid user ID",
-		})
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid user ID", nil, nil)
 		return
 	}
 
 	// Call service to join waitlist
-	response, err := c.service.JoinWaitlist(ctx.Request.Context(), userID, &request)
+	entry, err := c.service.JoinWaitlist(ctx.Request.Context(), userID, &request)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{
-			"error": err.Error(),
-		})
+		response.RespondError(ctx, http.StatusBadRequest, waitlistErrorCode(err, http.StatusBadRequest), err.Error(), nil)
 		return
 	}
 
-	ctx.JSON(http.StatusCreated, gin.H{
-		"message": "Successfully joined waitlist",
-		"data":    response,
-	})
+	response.RespondJSON(ctx, "success", http.StatusCreated, "Successfully joined waitlist", entry, nil)
 }
 
+// LeaveWaitlist godoc
+//
+// @Summary      Leave waitlist
+// @Description  Leave the waitlist for a specific event
+// @Tags         Waitlist
+// @Produce      json
+// @Security     Bearer
+// @Param        event_id path string true "Event ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse "Successfully left waitlist"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Router       /api/v1/waitlist/{event_id} [delete]
 func (c *Controller) LeaveWaitlist(ctx *gin.Context) {
 	eventIDStr := ctx.Param("event_id")
 	eventID, err := uuid.Parse(eventIDStr)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid event ID",
-		})
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid event ID", nil, nil)
 		return
 	}
 
 	// Get user ID from context
 	userIDStr, exists := ctx.Get("user_id")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{
-			"error": "User not authenticated",
-		})
+		response.RespondJSON(ctx, "error", http.StatusUnauthorized, "User not authenticated", nil, nil)
 		return
 	}
 
 	userID, err := uuid.Parse(userIDStr.(string))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid user ID",
-		})
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid user ID", nil, nil)
 		return
 	}
 
 	// Call service to leave waitlist
 	err = c.service.LeaveWaitlist(ctx.Request.Context(), userID, eventID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{
-			"error": err.Error(),
-		})
+		response.RespondError(ctx, http.StatusBadRequest, waitlistErrorCode(err, http.StatusBadRequest), err.Error(), nil)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{
-		"message": "Successfully left waitlist",
-	})
+	response.RespondJSON(ctx, "success", http.StatusOK, "Successfully left waitlist", nil, nil)
 }
 
+// GetWaitlistStatus godoc
+//
+// @Summary      Get waitlist status
+// @Description  Get waitlist status for a specific event and current user
+// @Tags         Waitlist
+// @Produce      json
+// @Security     Bearer
+// @Param        event_id path string true "Event ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=WaitlistResponse} "Waitlist status retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse
+// @Router       /api/v1/waitlist/status/{event_id} [get]
 func (c *Controller) GetWaitlistStatus(ctx *gin.Context) {
 	eventIDStr := ctx.Param("event_id")
 	eventID, err := uuid.Parse(eventIDStr)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid event ID",
-		})
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid event ID", nil, nil)
 		return
 	}
 
 	// Get user ID from context
 	userIDStr, exists := ctx.Get("user_id")
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{
-			"error": "User not authenticated",
-		})
+		response.RespondJSON(ctx, "error", http.StatusUnauthorized, "User not authenticated", nil, nil)
 		return
 	}
 
 	userID, err := uuid.Parse(userIDStr.(string))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid user ID",
-		})
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid user ID", nil, nil)
 		return
 	}
 
 	// Get waitlist status
 	status, err := c.service.GetWaitlistStatus(ctx.Request.Context(), userID, eventID)
 	if err != nil {
-		ctx.JSON(http.StatusNotFound, gin.H{
-			"error": err.Error(),
-		})
+		response.RespondError(ctx, http.StatusNotFound, waitlistErrorCode(err, http.StatusNotFound), err.Error(), nil)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{
-		"data": status,
-	})
+	response.RespondJSON(ctx, "success", http.StatusOK, "Waitlist status retrieved successfully", status, nil)
+}
+
+// transparentGIF is a 1x1 transparent GIF returned by the open-tracking pixel
+var transparentGIF = []byte{
+	0x47, 0x49, 0x46, 0x38, 0x39, 0x61, 0x01, 0x00, 0x01, 0x00, 0x80, 0x00, 0x00, 0xff, 0xff, 0xff,
+	0x00, 0x00, 0x00, 0x21, 0xf9, 0x04, 0x01, 0x00, 0x00, 0x00, 0x00, 0x2c, 0x00, 0x00, 0x00, 0x00,
+	0x01, 0x00, 0x01, 0x00, 0x00, 0x02, 0x02, 0x44, 0x01, 0x00, 0x3b,
 }
 
+// TrackNotificationOpen is the open-tracking pixel embedded in spot-available emails.
+// It always returns the image so a tracking failure never shows up as a broken email.
+//
+// @Summary      Email open-tracking pixel
+// @Description  Embedded in spot-available emails. Records the open so the notification is not
+// @Description  escalated over SMS/push. Always returns a 1x1 transparent GIF.
+// @Tags         Waitlist
+// @Produce      json,image/gif
+// @Param        notification_id path string true "Notification ID" Format(uuid)
+// @Success      200 {file} file "Transparent tracking pixel"
+// @Router       /api/v1/waitlist/notifications/{notification_id}/open [get]
+func (c *Controller) TrackNotificationOpen(ctx *gin.Context) {
+	if notificationID, err := uuid.Parse(ctx.Param("notification_id")); err == nil {
+		if err := c.service.RecordNotificationOpened(ctx.Request.Context(), notificationID); err != nil {
+			log.WarnContext(ctx.Request.Context(), "Failed to record notification open", "notification_id", notificationID, "error", err)
+		}
+	}
+
+	ctx.Header("Cache-Control", "no-store, no-cache, must-revalidate, private")
+	ctx.Header("Pragma", "no-cache")
+	ctx.Data(http.StatusOK, "image/gif", transparentGIF)
+}
+
+// GetWaitlistStats godoc
+//
+// @Summary      Get waitlist statistics (Admin)
+// @Description  Get waitlist statistics for a specific event
+// @Description
+// @Description  Requires the `bookings:manage` permission.
+// @Tags         Admin Waitlist
+// @Produce      json
+// @Security     Bearer
+// @Param        event_id path string true "Event ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=WaitlistStatsResponse} "Waitlist statistics retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/waitlist/stats/{event_id} [get]
 func (c *Controller) GetWaitlistStats(ctx *gin.Context) {
 	eventIDStr := ctx.Param("event_id")
 	eventID, err := uuid.Parse(eventIDStr)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid event ID",
-		})
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid event ID", nil, nil)
 		return
 	}
 
@@ -156,24 +212,120 @@ func (c *Controller) GetWaitlistStats(ctx *gin.Context) {
 
 	stats, err := c.service.GetWaitlistStats(ctx.Request.Context(), eventID)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{
-			"error": err.Error(),
-		})
+		response.RespondJSON(ctx, "error", http.StatusInternalServerError, "Failed to get waitlist stats", nil, err.Error())
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{
-		"data": stats,
-	})
+	response.RespondJSON(ctx, "success", http.StatusOK, "Waitlist stats retrieved successfully", stats, nil)
 }
 
+// GetWaitlistHealth summarizes waitlist health across upcoming events
+//
+// @Summary      Get waitlist health (Admin)
+// @Description  Queue depth, notification-to-conversion rate, booking window used, requeues and windows
+// @Description  expired without action for every upcoming event with a waitlist, with totals across them.
+// @Description  A window expires without action when the user never opened the spot-available notification.
+// @Description
+// @Description  Requires the `bookings:manage` permission.
+// @Tags         Admin Waitlist
+// @Produce      json
+// @Security     Bearer
+// @Success      200 {object} response.StandardApiResponse{data=WaitlistHealthResponse} "Waitlist health retrieved successfully"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/waitlist/health [get]
+func (c *Controller) GetWaitlistHealth(ctx *gin.Context) {
+	health, err := c.service.GetWaitlistHealth(ctx.Request.Context())
+	if err != nil {
+		response.RespondJSON(ctx, "error", http.StatusInternalServerError, "Failed to get waitlist health", nil, err.Error())
+		return
+	}
+
+	response.RespondJSON(ctx, "success", http.StatusOK, "Waitlist health retrieved successfully", health, nil)
+}
+
+// ExportWaitlistEntries starts a CSV export of an event's waitlist; poll the
+// returned job for progress and the download link
+//
+// @Summary      Export waitlist entries as CSV (Admin)
+// @Description  Starts a background export of an event's waitlist with each user's contact details. Poll /jobs/{id} for progress and download the CSV from its result_url.
+// @Description
+// @Description  Requires the `bookings:manage` permission.
+// @Tags         Admin Waitlist
+// @Produce      json
+// @Security     Bearer
+// @Param        event_id path string true "Event ID" Format(uuid)
+// @Param        status query string false "Only export entries with this status" Enums(ACTIVE, NOTIFIED, EXPIRED, CONVERTED, CANCELLED)
+// @Success      202 {object} response.StandardApiResponse{data=jobs.JobResponse} "Export queued"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Failure      503 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/waitlist/export/{event_id} [post]
+func (c *Controller) ExportWaitlistEntries(ctx *gin.Context) {
+	userIDStr, exists := ctx.Get("user_id")
+	if !exists {
+		response.RespondJSON(ctx, "error", http.StatusUnauthorized, "User not authenticated", nil, nil)
+		return
+	}
+
+	adminID, err := uuid.Parse(userIDStr.(string))
+	if err != nil {
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid user ID", nil, nil)
+		return
+	}
+
+	eventID, err := uuid.Parse(ctx.Param("event_id"))
+	if err != nil {
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid event ID", nil, nil)
+		return
+	}
+
+	status := WaitlistStatus(ctx.Query("status"))
+	if status != "" && !status.IsValid() {
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid status filter", nil, nil)
+		return
+	}
+
+	job, err := c.service.StartEntriesExport(ctx.Request.Context(), eventID, status, adminID)
+	if err != nil {
+		if errors.Is(err, ErrExportsUnavailable) {
+			response.RespondJSON(ctx, "error", http.StatusServiceUnavailable, err.Error(), nil, nil)
+			return
+		}
+		response.RespondJSON(ctx, "error", http.StatusInternalServerError, "Failed to start waitlist export", nil, err.Error())
+		return
+	}
+
+	response.RespondJSON(ctx, "success", http.StatusAccepted, "Waitlist export started", job, nil)
+}
+
+// GetWaitlistEntries godoc
+//
+// @Summary      Get waitlist entries (Admin)
+// @Description  Get all waitlist entries for a specific event
+// @Description
+// @Description  Requires the `bookings:manage` permission.
+// @Tags         Admin Waitlist
+// @Produce      json
+// @Security     Bearer
+// @Param        event_id path string true "Event ID" Format(uuid)
+// @Param        status query string false "Status"
+// @Param        page query string false "Page"
+// @Param        limit query string false "Limit"
+// @Success      200 {object} response.StandardApiResponse{data=[]WaitlistEntry} "Waitlist entries retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/waitlist/entries/{event_id} [get]
 func (c *Controller) GetWaitlistEntries(ctx *gin.Context) {
 	eventIDStr := ctx.Param("event_id")
 	eventID, err := uuid.Parse(eventIDStr)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid event ID",
-		})
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid event ID", nil, nil)
 		return
 	}
 
@@ -183,9 +335,7 @@ func (c *Controller) GetWaitlistEntries(ctx *gin.Context) {
 	if statusStr != "" {
 		status = WaitlistStatus(statusStr)
 		if !status.IsValid() {
-			ctx.JSON(http.StatusBadRequest, gin.H{
-				"error": "Invalid status filter",
-			})
+			response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid status filter", nil, nil)
 			return
 		}
 	}
@@ -206,9 +356,7 @@ func (c *Controller) GetWaitlistEntries(ctx *gin.Context) {
 
 	entries, err := c.service.GetWaitlistEntries(ctx.Request.Context(), eventID, status)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{
-			"error": err.Error(),
-		})
+		response.RespondJSON(ctx, "error", http.StatusInternalServerError, "Failed to get waitlist entries", nil, err.Error())
 		return
 	}
 
@@ -224,24 +372,33 @@ func (c *Controller) GetWaitlistEntries(ctx *gin.Context) {
 
 	paginatedEntries := entries[start:end]
 
-	ctx.JSON(http.StatusOK, gin.H{
-		"data": paginatedEntries,
-		"pagination": gin.H{
-			"page":        page,
-			"limit":       limit,
-			"total":       len(entries),
-			"total_pages": (len(entries) + limit - 1) / limit,
-		},
-	})
+	response.RespondPaginated(ctx, http.StatusOK, "Waitlist entries retrieved successfully", paginatedEntries,
+		response.NewPagination(page, limit, int64(len(entries))))
 }
 
+// NotifyNextInLine godoc
+//
+// @Summary      Notify next in line (Admin)
+// @Description  Manually notify the next person in waitlist for an event
+// @Description
+// @Description  Requires the `bookings:manage` permission.
+// @Tags         Admin Waitlist
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        event_id path string true "Event ID" Format(uuid)
+// @Param        request body object true "Request body"
+// @Success      200 {object} response.StandardApiResponse "Notification sent successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/waitlist/notify/{event_id} [post]
 func (c *Controller) NotifyNextInLine(ctx *gin.Context) {
 	eventIDStr := ctx.Param("event_id")
 	eventID, err := uuid.Parse(eventIDStr)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid event ID",
-		})
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid event ID", nil, nil)
 		return
 	}
 
@@ -250,10 +407,7 @@ func (c *Controller) NotifyNextInLine(ctx *gin.Context) {
 	}
 
 	if err := ctx.ShouldBindJSON(&request); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request body",
-			"details": err.Error(),
-		})
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid request body", nil, err.Error())
 		return
 	}
 
@@ -261,24 +415,36 @@ func (c *Controller) NotifyNextInLine(ctx *gin.Context) {
 
 	err = c.service.NotifyNextInLine(ctx.Request.Context(), eventID, request.AvailableTickets)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{
-			"error": err.Error(),
-		})
+		response.RespondJSON(ctx, "error", http.StatusInternalServerError, "Failed to notify next in line", nil, err.Error())
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{
-		"message": "Notifications sent to next users in line",
-	})
+	response.RespondJSON(ctx, "success", http.StatusOK, "Notifications sent to next users in line", nil, nil)
 }
 
+// ProcessCancellation godoc
+//
+// @Summary      Process cancellation (Admin)
+// @Description  Process waitlist notifications after booking cancellations
+// @Description
+// @Description  Requires the `bookings:manage` permission.
+// @Tags         Admin Waitlist
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        event_id path string true "Event ID" Format(uuid)
+// @Param        request body object true "Request body"
+// @Success      200 {object} response.StandardApiResponse "Cancellation processed successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/waitlist/cancellation/{event_id} [post]
 func (c *Controller) ProcessCancellation(ctx *gin.Context) {
 	eventIDStr := ctx.Param("event_id")
 	eventID, err := uuid.Parse(eventIDStr)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid event ID",
-		})
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid event ID", nil, nil)
 		return
 	}
 
@@ -287,22 +453,32 @@ func (c *Controller) ProcessCancellation(ctx *gin.Context) {
 	}
 
 	if err := ctx.ShouldBindJSON(&request); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request body",
-			"details": err.Error(),
-		})
+		response.RespondJSON(ctx, "error", http.StatusBadRequest, "Invalid request body", nil, err.Error())
 		return
 	}
 
-	err = c.service.ProcessCancellation(ctx.Request.Context(), eventID, request.FreedTickets)
+	err = c.service.ProcessCancellation(ctx.Request.Context(), eventID, request.FreedTickets, nil)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{
-			"error": err.Error(),
-		})
+		response.RespondJSON(ctx, "error", http.StatusInternalServerError, "Failed to process cancellation", nil, err.Error())
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{
-		"message": "Cancellation processed successfully",
-	})
+	response.RespondJSON(ctx, "success", http.StatusOK, "Cancellation processed successfully", nil, nil)
+}
+
+// waitlistErrorCode picks the error code of a failed waitlist operation,
+// falling back to the generic code of its status
+func waitlistErrorCode(err error, status int) string {
+	switch {
+	case errors.Is(err, ErrWaitlistFull):
+		return response.CodeWaitlistFull
+	case errors.Is(err, ErrAlreadyOnWaitlist):
+		return response.CodeAlreadyOnWaitlist
+	case errors.Is(err, ErrEntryNotFound):
+		return response.CodeWaitlistEntryMissing
+	case errors.Is(err, ErrEntryNotActive):
+		return response.CodeWaitlistEntryClosed
+	default:
+		return response.StatusErrorCode(status)
+	}
 }
@@ -0,0 +1,41 @@
+package waitlist
+
+import (
+	"fmt"
+	"time"
+
+	"evently/internal/domainevents"
+	"evently/internal/outbox"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// WaitlistJoinedData is the data of WaitlistJoined domain events
+type WaitlistJoinedData struct {
+	WaitlistEntryID uuid.UUID `json:"waitlist_entry_id"`
+	EventID         uuid.UUID `json:"event_id"`
+	UserID          uuid.UUID `json:"user_id"`
+	Position        int       `json:"position"`
+	Quantity        int       `json:"quantity"`
+	JoinedAt        time.Time `json:"joined_at"`
+}
+
+// enqueueJoinedDomainEvent records WaitlistJoined in the caller's transaction
+func enqueueJoinedDomainEvent(tx *gorm.DB, entry *WaitlistEntry) error {
+	data := WaitlistJoinedData{
+		WaitlistEntryID: entry.ID,
+		EventID:         entry.EventID,
+		UserID:          entry.UserID,
+		Position:        entry.Position,
+		Quantity:        entry.Quantity,
+		JoinedAt:        entry.JoinedAt,
+	}
+
+	event, err := domainevents.NewEvent(domainevents.WaitlistJoined, entry.ID,
+		fmt.Sprintf("waitlist:%s:joined", entry.ID), data)
+	if err != nil {
+		return err
+	}
+	return outbox.Enqueue(tx, event)
+}
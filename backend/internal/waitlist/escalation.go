@@ -0,0 +1,243 @@
+package waitlist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var e164Pattern = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+
+// EscalationSender delivers spot-available follow-ups over channels other than email
+type EscalationSender interface {
+	SendSMS(ctx context.Context, phone, body string) error
+	SendPush(ctx context.Context, token, title, body string, data map[string]string) error
+}
+
+// EscalationConfig contains configuration for escalating unopened spot-available emails
+type EscalationConfig struct {
+	Enabled         bool
+	After           time.Duration // How long an email may go unopened before escalating
+	Interval        time.Duration
+	MaxAttempts     int
+	BatchSize       int
+	OpenTrackingURL string // Base URL of the open-tracking pixel; empty disables the pixel
+}
+
+// DefaultEscalationConfig returns default escalation configuration
+func DefaultEscalationConfig() *EscalationConfig {
+	return &EscalationConfig{
+		Enabled:     true,
+		After:       5 * time.Minute,  // A third of the default booking window
+		Interval:    30 * time.Second, // Frequent enough to matter within a short window
+		MaxAttempts: 3,
+		BatchSize:   100,
+	}
+}
+
+// EscalationCandidate is an unopened spot-available notification claimed for escalation
+type EscalationCandidate struct {
+	Notification WaitlistNotification
+	Entry        WaitlistEntry
+}
+
+func (s *service) SetEscalationSender(sender EscalationSender) {
+	s.escalationSender = sender
+}
+
+func (s *service) SetEscalationConfig(config *EscalationConfig) {
+	s.escalationConfig = config
+}
+
+// RecordNotificationOpened marks a notification as opened so it is never escalated
+func (s *service) RecordNotificationOpened(ctx context.Context, notificationID uuid.UUID) error {
+	return s.repo.MarkNotificationOpened(ctx, notificationID)
+}
+
+// RecordNotificationDelivery tracks whether a waitlist notification was delivered
+// on a channel, once the notification consumer has finished retrying it
+func (s *service) RecordNotificationDelivery(ctx context.Context, entryID uuid.UUID, messageID string,
+	notificationType NotificationType, channel NotificationChannel, deliveryErr error) error {
+
+	record := &WaitlistNotification{
+		ID:               uuid.New(),
+		WaitlistEntryID:  entryID,
+		NotificationType: notificationType,
+		Channel:          channel,
+		Status:           NotificationStatusSent,
+		MessageID:        &messageID,
+	}
+	if deliveryErr != nil {
+		errMsg := deliveryErr.Error()
+		record.Status = NotificationStatusFailed
+		record.ErrorMessage = &errMsg
+	} else {
+		now := time.Now()
+		record.SentAt = &now
+	}
+	return s.repo.RecordNotificationDelivery(ctx, record)
+}
+
+// EscalateUnopenedNotifications follows up unopened spot-available emails over SMS/push
+// for users who opted in, while their booking window is still open
+func (s *service) EscalateUnopenedNotifications(ctx context.Context) (int, error) {
+	cfg := s.escalationConfig
+	if cfg == nil || !cfg.Enabled || s.escalationSender == nil {
+		return 0, nil
+	}
+
+	candidates, err := s.repo.ClaimEscalations(ctx, time.Now().Add(-cfg.After), cfg.MaxAttempts, cfg.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	escalated := 0
+	for i := range candidates {
+		if s.escalate(ctx, &candidates[i]) {
+			escalated++
+		}
+	}
+
+	if len(candidates) > 0 {
+		log.InfoContext(ctx, "Escalated unopened spot-available notifications", "escalated", escalated, "candidates", len(candidates))
+	}
+	return escalated, nil
+}
+
+// escalate sends the follow-up on every opted-in channel. The notification counts as
+// escalated once any channel succeeds; otherwise it is retried until attempts run out.
+func (s *service) escalate(ctx context.Context, candidate *EscalationCandidate) bool {
+	entry := &candidate.Entry
+	notification := &candidate.Notification
+
+	event := "an event you're waitlisted for"
+	if details := s.lookupEvent(ctx, entry.EventID); details != nil {
+		event = details.Name
+	}
+
+	title := "A spot is waiting for you"
+	body := fmt.Sprintf("A spot just opened up for %s. Book your %d ticket(s) before %s or it goes to the next person: %s",
+		event, entry.Quantity, entry.ExpiresAt.UTC().Format("15:04 MST"), s.bookingURL(entry))
+
+	var sent []string
+	var errs []error
+
+	if entry.SMSPhone != nil && *entry.SMSPhone != "" {
+		if err := s.escalationSender.SendSMS(ctx, *entry.SMSPhone, "Evently: "+body); err != nil {
+			errs = append(errs, fmt.Errorf("sms: %w", err))
+		} else {
+			sent = append(sent, string(NotificationChannelSMS))
+		}
+	}
+
+	if entry.PushToken != nil && *entry.PushToken != "" {
+		data := map[string]string{
+			"type":              string(NotificationTypeSpotAvailable),
+			"event_id":          entry.EventID.String(),
+			"waitlist_entry_id": entry.ID.String(),
+			"booking_url":       s.bookingURL(entry),
+		}
+		if err := s.escalationSender.SendPush(ctx, *entry.PushToken, title, body, data); err != nil {
+			errs = append(errs, fmt.Errorf("push: %w", err))
+		} else {
+			sent = append(sent, string(NotificationChannelPush))
+		}
+	}
+
+	var errorMessage *string
+	if len(errs) > 0 {
+		msg := errors.Join(errs...).Error()
+		errorMessage = &msg
+	}
+
+	if err := s.repo.RecordEscalation(ctx, notification.ID, strings.Join(sent, ","), errorMessage); err != nil {
+		log.ErrorContext(ctx, "Failed to record escalation", "notification_id", notification.ID, "error", err)
+	}
+
+	if len(sent) == 0 {
+		log.WarnContext(ctx, "Escalation attempt failed", "attempt", notification.EscalationAttempts,
+			"user_id", entry.UserID, "event_id", entry.EventID, "error", errors.Join(errs...))
+		return false
+	}
+
+	log.InfoContext(ctx, "Sent escalation follow-up", "channels", strings.Join(sent, "+"), "user_id", entry.UserID, "event_id", entry.EventID)
+	return true
+}
+
+// applyEscalationRequest validates and stores the contact details a user opted in with
+func applyEscalationRequest(entry *WaitlistEntry, req *EscalationRequest) error {
+	if req == nil {
+		return nil
+	}
+
+	if phone := strings.TrimSpace(req.Phone); phone != "" {
+		if !e164Pattern.MatchString(phone) {
+			return fmt.Errorf("phone must be in E.164 format, e.g. +14155550123")
+		}
+		entry.SMSPhone = &phone
+	}
+
+	if token := strings.TrimSpace(req.PushToken); token != "" {
+		if len(token) > 512 {
+			return fmt.Errorf("push token is too long")
+		}
+		entry.PushToken = &token
+	}
+
+	return nil
+}
+
+// EscalationJob periodically escalates unopened spot-available notifications
+type EscalationJob struct {
+	service Service
+	config  *EscalationConfig
+	done    chan struct{}
+}
+
+// NewEscalationJob creates a new escalation job
+func NewEscalationJob(service Service, config *EscalationConfig) *EscalationJob {
+	if config == nil {
+		config = DefaultEscalationConfig()
+	}
+
+	return &EscalationJob{
+		service: service,
+		config:  config,
+		done:    make(chan struct{}),
+	}
+}
+
+// Start starts the escalation job
+func (j *EscalationJob) Start(ctx context.Context) {
+	log.Info("Starting waitlist escalation job", "interval", j.config.Interval, "after", j.config.After)
+	go j.run(ctx)
+}
+
+// Stop stops the escalation job
+func (j *EscalationJob) Stop() {
+	log.Info("Stopping waitlist escalation job")
+	close(j.done)
+}
+
+func (j *EscalationJob) run(ctx context.Context) {
+	ticker := time.NewTicker(j.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := j.service.EscalateUnopenedNotifications(ctx); err != nil {
+				log.Error("Waitlist escalation run failed", "error", err)
+			}
+		case <-j.done:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package waitlist
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventLookup provides the event details shown in waitlist notifications. It is
+// implemented outside the package so the waitlist doesn't depend on events.
+type EventLookup interface {
+	GetEventDetails(ctx context.Context, eventID uuid.UUID) (*EventDetails, error)
+}
+
+// EventDetails describes the event a waitlist notification is about
+type EventDetails struct {
+	Name     string
+	DateTime time.Time
+	Venue    string
+	Sections []SectionAvailability
+}
+
+// SectionAvailability is the number of seats left in a section of the event's venue
+type SectionAvailability struct {
+	ID        uuid.UUID
+	Name      string
+	Available int
+}
+
+// DefaultBookingURL is the booking page linked from waitlist notifications
+const DefaultBookingURL = "http://localhost:3000/events/{event_id}/book?waitlist={entry_id}"
+
+func (s *service) SetEventLookup(lookup EventLookup) {
+	s.eventLookup = lookup
+}
+
+// bookingURL builds the deep link to the booking page for an entry
+func (s *service) bookingURL(entry *WaitlistEntry) string {
+	url := s.config.BookingURL
+	if url == "" {
+		url = DefaultBookingURL
+	}
+	url = strings.ReplaceAll(url, "{event_id}", entry.EventID.String())
+	return strings.ReplaceAll(url, "{entry_id}", entry.ID.String())
+}
+
+// lookupEvent returns the event's details, or nil when they can't be loaded.
+// Notifications still go out without them; the event title is then filled in
+// when the notification is published.
+func (s *service) lookupEvent(ctx context.Context, eventID uuid.UUID) *EventDetails {
+	if s.eventLookup == nil {
+		return nil
+	}
+	details, err := s.eventLookup.GetEventDetails(ctx, eventID)
+	if err != nil {
+		log.WarnContext(ctx, "Failed to look up event for notifications", "event_id", eventID, "error", err)
+		return nil
+	}
+	return details
+}
+
+// eventTemplateData adds the event's details and the entry's booking link to template data
+func (s *service) eventTemplateData(templateData map[string]interface{}, entry *WaitlistEntry, details *EventDetails) {
+	templateData["booking_url"] = s.bookingURL(entry)
+	if details == nil {
+		return
+	}
+
+	templateData["event_title"] = details.Name
+	templateData["event_date_time"] = details.DateTime.UTC().Format("Mon, Jan 2, 2006 at 15:04 MST")
+	if details.Venue != "" {
+		templateData["venue"] = details.Venue
+	}
+
+	var sections []map[string]interface{}
+	for _, section := range details.Sections {
+		if section.Available > 0 {
+			sections = append(sections, map[string]interface{}{
+				"name":      section.Name,
+				"available": section.Available,
+			})
+		}
+	}
+	if len(sections) > 0 {
+		templateData["sections"] = sections
+	}
+}
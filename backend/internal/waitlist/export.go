@@ -0,0 +1,117 @@
+package waitlist
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"evently/internal/jobs"
+
+	"github.com/google/uuid"
+)
+
+// ExportJobType is the job type of waitlist CSV exports
+const ExportJobType = "WAITLIST_EXPORT"
+
+const exportPageSize = 500
+
+var ErrExportsUnavailable = errors.New("waitlist exports are not available")
+
+// ExportRow is a waitlist entry with the contact details of its user
+type ExportRow struct {
+	WaitlistEntry
+	Email     string
+	FirstName string
+	LastName  string
+}
+
+// ExportParams are the parameters a waitlist export job is enqueued with
+type ExportParams struct {
+	EventID uuid.UUID      `json:"event_id"`
+	Status  WaitlistStatus `json:"status,omitempty"`
+}
+
+func (s *service) SetJobService(jobService jobs.Service) {
+	s.jobService = jobService
+}
+
+// StartEntriesExport queues a CSV export of an event's waitlist for an admin
+func (s *service) StartEntriesExport(ctx context.Context, eventID uuid.UUID, status WaitlistStatus, adminID uuid.UUID) (*jobs.JobResponse, error) {
+	if s.jobService == nil {
+		return nil, ErrExportsUnavailable
+	}
+	return s.jobService.Enqueue(ctx, ExportJobType, adminID, ExportParams{EventID: eventID, Status: status})
+}
+
+// ExportEntries is the job handler that writes an event's waitlist as CSV
+func (s *service) ExportEntries(ctx context.Context, job *jobs.Job, progress jobs.Progress) (*jobs.Result, error) {
+	var params ExportParams
+	if err := job.DecodeParams(&params); err != nil {
+		return nil, fmt.Errorf("invalid export parameters: %w", err)
+	}
+
+	total, err := s.repo.CountEntries(ctx, params.EventID, params.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	writer.Write([]string{"position", "status", "email", "first_name", "last_name", "quantity", "joined_at", "notified_at", "expires_at"})
+
+	written := 0
+	for offset := 0; ; offset += exportPageSize {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		rows, err := s.repo.ListExportRows(ctx, params.EventID, params.Status, offset, exportPageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, row := range rows {
+			writer.Write([]string{
+				strconv.Itoa(row.Position),
+				string(row.Status),
+				row.Email,
+				row.FirstName,
+				row.LastName,
+				strconv.Itoa(row.Quantity),
+				row.JoinedAt.UTC().Format(time.RFC3339),
+				formatExportTime(row.NotifiedAt),
+				formatExportTime(row.ExpiresAt),
+			})
+		}
+		written += len(rows)
+
+		if total > 0 {
+			progress.Set(written*100/int(total), fmt.Sprintf("Exported %d of %d entries", written, total))
+		}
+		if len(rows) < exportPageSize {
+			break
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, fmt.Errorf("failed to write export: %w", err)
+	}
+
+	return &jobs.Result{
+		Name:        fmt.Sprintf("waitlist-%s.csv", params.EventID),
+		ContentType: "text/csv",
+		Body:        &buf,
+	}, nil
+}
+
+func formatExportTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
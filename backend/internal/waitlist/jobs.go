@@ -2,7 +2,6 @@ package waitlist
 
 import (
 	"context"
-	"log"
 	"time"
 )
 
@@ -44,7 +43,7 @@ func NewJobProcessor(service Service, config *JobConfig) *JobProcessor {
 
 // Start starts all background jobs
 func (jp *JobProcessor) Start(ctx context.Context) {
-	log.Println("Starting waitlist background jobs...")
+	log.Info("Starting waitlist background jobs")
 
 	// Start expired booking processor
 	go jp.startExpiryProcessor(ctx)
@@ -52,14 +51,14 @@ func (jp *JobProcessor) Start(ctx context.Context) {
 	// Start analytics updater
 	go jp.startAnalyticsUpdater(ctx)
 
-	log.Println("Waitlist background jobs started")
+	log.Info("Waitlist background jobs started")
 }
 
 // Stop stops all background jobs
 func (jp *JobProcessor) Stop() {
-	log.Println("Stopping waitlist background jobs...")
+	log.Info("Stopping waitlist background jobs")
 	close(jp.done)
-	log.Println("Waitlist background jobs stopped")
+	log.Info("Waitlist background jobs stopped")
 }
 
 // startExpiryProcessor starts the expired booking window processor
@@ -67,7 +66,7 @@ func (jp *JobProcessor) startExpiryProcessor(ctx context.Context) {
 	ticker := time.NewTicker(jp.config.ExpiryCheckInterval)
 	defer ticker.Stop()
 
-	log.Printf("Started expired booking processor with %v interval", jp.config.ExpiryCheckInterval)
+	log.Info("Started expired booking processor", "interval", jp.config.ExpiryCheckInterval)
 
 	for {
 		select {
@@ -85,12 +84,12 @@ func (jp *JobProcessor) startExpiryProcessor(ctx context.Context) {
 func (jp *JobProcessor) processExpiredBookings(ctx context.Context) {
 	processed, err := jp.service.ProcessExpiredBookingWindows(ctx)
 	if err != nil {
-		log.Printf("Error processing expired bookings: %v", err)
+		log.Error("Failed to process expired booking windows", "error", err)
 		return
 	}
 
 	if processed > 0 {
-		log.Printf("Processed %d expired booking windows", processed)
+		log.Info("Processed expired booking windows", "processed", processed)
 	}
 }
 
@@ -99,7 +98,7 @@ func (jp *JobProcessor) startAnalyticsUpdater(ctx context.Context) {
 	ticker := time.NewTicker(jp.config.AnalyticsInterval)
 	defer ticker.Stop()
 
-	log.Printf("Started analytics updater with %v interval", jp.config.AnalyticsInterval)
+	log.Info("Started waitlist analytics updater", "interval", jp.config.AnalyticsInterval)
 
 	// Run immediately on startup
 	jp.updateAnalytics(ctx)
@@ -120,11 +119,11 @@ func (jp *JobProcessor) startAnalyticsUpdater(ctx context.Context) {
 func (jp *JobProcessor) updateAnalytics(ctx context.Context) {
 	err := jp.service.UpdateDailyAnalytics(ctx)
 	if err != nil {
-		log.Printf("Error updating analytics: %v", err)
+		log.Error("Failed to update waitlist analytics", "error", err)
 		return
 	}
 
-	log.Println("Updated daily waitlist analytics")
+	log.Debug("Updated daily waitlist analytics")
 }
 
 // GetJobStatus returns the status of background jobs
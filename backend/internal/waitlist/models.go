@@ -1,45 +1,11 @@
 package waitlist
 
 import (
-	"database/sql/driver"
-	"encoding/json"
-	"errors"
 	"time"
 
 	"github.com/google/uuid"
 )
 
-// JSONMap represents a JSON map type that can be stored in the database
-type JSONMap map[string]interface{}
-
-// Value implements the driver.Valuer interface for database storage
-func (j JSONMap) Value() (driver.Value, error) {
-	if j == nil {
-		return nil, nil
-	}
-	return json.Marshal(j)
-}
-
-// Scan implements the sql.Scanner interface for database retrieval
-func (j *JSONMap) Scan(value interface{}) error {
-	if value == nil {
-		*j = nil
-		return nil
-	}
-
-	bytes, ok := value.([]byte)
-	if !ok {
-		return errors.New("type assertion to []byte failed")
-	}
-
-	return json.Unmarshal(bytes, j)
-}
-
-// GormDataType tells GORM how to handle this type
-func (JSONMap) GormDataType() string {
-	return "jsonb"
-}
-
 // WaitlistStatus represents the status of a waitlist entry
 type WaitlistStatus string
 
@@ -82,18 +48,28 @@ const (
 
 // WaitlistEntry represents a user's position in an event waitlist
 type WaitlistEntry struct {
-	ID          uuid.UUID      `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()" db:"id"`
-	UserID      uuid.UUID      `json:"user_id" gorm:"type:uuid;not null;index" db:"user_id"`
-	EventID     uuid.UUID      `json:"event_id" gorm:"type:uuid;not null;index" db:"event_id"`
-	Position    int            `json:"position" gorm:"not null;index" db:"position"`
-	Quantity    int            `json:"quantity" gorm:"not null" db:"quantity"`
-	Status      WaitlistStatus `json:"status" gorm:"type:varchar(20);not null;index" db:"status"`
-	Preferences JSONMap        `json:"preferences" gorm:"type:jsonb" db:"preferences"`
-	JoinedAt    time.Time      `json:"joined_at" gorm:"not null" db:"joined_at"`
-	NotifiedAt  *time.Time     `json:"notified_at,omitempty" db:"notified_at"`
-	ExpiresAt   *time.Time     `json:"expires_at,omitempty" db:"expires_at"`
-	CreatedAt   time.Time      `json:"created_at" gorm:"autoCreateTime" db:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at" gorm:"autoUpdateTime" db:"updated_at"`
+	ID          uuid.UUID        `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()" db:"id"`
+	UserID      uuid.UUID        `json:"user_id" gorm:"type:uuid;not null;index" db:"user_id"`
+	EventID     uuid.UUID        `json:"event_id" gorm:"type:uuid;not null;index" db:"event_id"`
+	Position    int              `json:"position" gorm:"not null;index" db:"position"`
+	Quantity    int              `json:"quantity" gorm:"not null" db:"quantity"`
+	Status      WaitlistStatus   `json:"status" gorm:"type:varchar(20);not null;index" db:"status"`
+	Preferences *SeatPreferences `json:"preferences" gorm:"type:jsonb" db:"preferences"`
+	JoinedAt    time.Time        `json:"joined_at" gorm:"not null" db:"joined_at"`
+	NotifiedAt  *time.Time       `json:"notified_at,omitempty" db:"notified_at"`
+	ExpiresAt   *time.Time       `json:"expires_at,omitempty" db:"expires_at"`
+	CreatedAt   time.Time        `json:"created_at" gorm:"autoCreateTime" db:"created_at"`
+	UpdatedAt   time.Time        `json:"updated_at" gorm:"autoUpdateTime" db:"updated_at"`
+
+	// Channels the user opted into for escalation when a spot-available email goes unopened
+	SMSPhone  *string `json:"-" gorm:"type:varchar(20)" db:"sms_phone"`
+	PushToken *string `json:"-" gorm:"type:varchar(512)" db:"push_token"`
+
+	// Waitlist health: missed booking windows and how much of the window a conversion took
+	RequeueCount         int        `json:"requeue_count" gorm:"not null;default:0" db:"requeue_count"`
+	ExpiredWithoutAction int        `json:"expired_without_action" gorm:"not null;default:0" db:"expired_without_action"` // Windows missed without opening the notification
+	ConvertedAt          *time.Time `json:"converted_at,omitempty" db:"converted_at"`
+	BookingWindowUsed    *float64   `json:"booking_window_used,omitempty" db:"booking_window_used"` // Fraction of the window elapsed at conversion
 }
 
 // WaitlistNotification represents a notification sent to a waitlist user
@@ -108,6 +84,13 @@ type WaitlistNotification struct {
 	SentAt           *time.Time          `json:"sent_at,omitempty" db:"sent_at"`
 	CreatedAt        time.Time           `json:"created_at" gorm:"autoCreateTime" db:"created_at"`
 	UpdatedAt        time.Time           `json:"updated_at" gorm:"autoUpdateTime" db:"updated_at"`
+
+	// Open tracking and SMS/push escalation of unopened spot-available emails
+	OpenedAt           *time.Time `json:"opened_at,omitempty" db:"opened_at"`
+	EscalationAttempts int        `json:"escalation_attempts" gorm:"not null;default:0" db:"escalation_attempts"`
+	EscalatedAt        *time.Time `json:"escalated_at,omitempty" db:"escalated_at"`
+	EscalatedChannels  string     `json:"escalated_channels,omitempty" gorm:"type:varchar(50)" db:"escalated_channels"`
+	EscalationError    *string    `json:"escalation_error,omitempty" db:"escalation_error"`
 }
 
 // WaitlistAnalytics represents daily analytics for waitlist operations
@@ -127,24 +110,34 @@ type WaitlistAnalytics struct {
 
 // Redis Key Helpers
 
+// An event's waitlist keys hash-tag the event ID, so the queue scripts that
+// update the queue and positions together run on one Redis Cluster slot
+
+const queueKeyPrefix = "waitlist:queue:"
+
+// eventTag is the hash tag shared by an event's waitlist keys
+func eventTag(eventID uuid.UUID) string {
+	return "{" + eventID.String() + "}"
+}
+
 // GetQueueKey returns the Redis key for an event's waitlist queue
 func GetQueueKey(eventID uuid.UUID) string {
-	return "waitlist:queue:" + eventID.String()
+	return queueKeyPrefix + eventTag(eventID)
 }
 
 // GetPositionKey returns the Redis key for tracking positions
 func GetPositionKey(eventID uuid.UUID) string {
-	return "waitlist:positions:" + eventID.String()
+	return "waitlist:positions:" + eventTag(eventID)
 }
 
 // GetStatsKey returns the Redis key for event waitlist statistics
 func GetStatsKey(eventID uuid.UUID) string {
-	return "waitlist:stats:" + eventID.String()
+	return "waitlist:stats:" + eventTag(eventID)
 }
 
 // GetLockKey returns the Redis key for distributed locking
 func GetLockKey(eventID uuid.UUID) string {
-	return "waitlist:lock:" + eventID.String()
+	return "waitlist:lock:" + eventTag(eventID)
 }
 
 // Kafka message helpers have been removed as part of notification system simplification.
@@ -180,6 +173,18 @@ func (ws WaitlistStatus) CanTransitionTo(target WaitlistStatus) bool {
 	return false
 }
 
+// EscalationChannels lists the channels the user opted into for escalation
+func (we *WaitlistEntry) EscalationChannels() []NotificationChannel {
+	var channels []NotificationChannel
+	if we.SMSPhone != nil && *we.SMSPhone != "" {
+		channels = append(channels, NotificationChannelSMS)
+	}
+	if we.PushToken != nil && *we.PushToken != "" {
+		channels = append(channels, NotificationChannelPush)
+	}
+	return channels
+}
+
 // IsActive returns true if the waitlist entry is in active status
 func (we *WaitlistEntry) IsActive() bool {
 	return we.Status == WaitlistStatusActive
@@ -228,4 +233,8 @@ const (
 
 	// RedisKeyTTL is the TTL for Redis keys (24 hours)
 	RedisKeyTTL = 24 * time.Hour
+
+	// PreferenceScanDepth is how many users past the freed ticket count are read
+	// from the queue, so users passed over for their preferences can be replaced
+	PreferenceScanDepth = 50
 )
@@ -0,0 +1,189 @@
+package waitlist
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// MaxPreferredSections is the maximum number of sections a waitlist entry can prefer
+const MaxPreferredSections = 20
+
+// SeatPreferences narrows which freed seats a waitlist entry is offered.
+// An entry without preferences is offered any freed seats.
+type SeatPreferences struct {
+	PreferredSections []uuid.UUID `json:"preferred_sections,omitempty"` // Empty means any section
+	ContiguousSeats   bool        `json:"contiguous_seats,omitempty"`   // All seats side by side in one row
+	MaxPrice          *float64    `json:"max_price,omitempty"`          // Per ticket, before fees
+}
+
+// Value implements the driver.Valuer interface for database storage
+func (p SeatPreferences) Value() (driver.Value, error) {
+	return json.Marshal(p)
+}
+
+// Scan implements the sql.Scanner interface for database retrieval
+func (p *SeatPreferences) Scan(value interface{}) error {
+	if value == nil {
+		*p = SeatPreferences{}
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+
+	// Entries joined before preferences were structured may hold free-form
+	// JSON, which is read as no preferences rather than failing the load
+	if err := json.Unmarshal(bytes, p); err != nil {
+		*p = SeatPreferences{}
+	}
+	return nil
+}
+
+// GormDataType tells GORM how to handle this type
+func (SeatPreferences) GormDataType() string {
+	return "jsonb"
+}
+
+// IsEmpty reports whether the preferences accept any seat
+func (p *SeatPreferences) IsEmpty() bool {
+	return p == nil || (len(p.PreferredSections) == 0 && !p.ContiguousSeats && p.MaxPrice == nil)
+}
+
+// FreedSeat is a seat or general admission ticket returned to sale
+type FreedSeat struct {
+	SeatID    *uuid.UUID // Nil for general admission tickets
+	SectionID *uuid.UUID
+	Price     float64 // Per ticket, before fees
+
+	// Filled in from the seat map so contiguity can be checked
+	Row      string
+	Position int
+}
+
+func (p *SeatPreferences) validate() error {
+	if len(p.PreferredSections) > MaxPreferredSections {
+		return fmt.Errorf("at most %d preferred sections are allowed", MaxPreferredSections)
+	}
+	seen := make(map[uuid.UUID]bool, len(p.PreferredSections))
+	for _, sectionID := range p.PreferredSections {
+		if sectionID == uuid.Nil {
+			return fmt.Errorf("preferred section ID is required")
+		}
+		if seen[sectionID] {
+			return fmt.Errorf("preferred section %s is listed twice", sectionID)
+		}
+		seen[sectionID] = true
+	}
+	if p.MaxPrice != nil && *p.MaxPrice <= 0 {
+		return fmt.Errorf("max price must be positive")
+	}
+	return nil
+}
+
+func (p *SeatPreferences) accepts(seat FreedSeat) bool {
+	if p.MaxPrice != nil && seat.Price > *p.MaxPrice {
+		return false
+	}
+	if len(p.PreferredSections) == 0 {
+		return true
+	}
+	if seat.SectionID == nil {
+		return false
+	}
+	for _, sectionID := range p.PreferredSections {
+		if sectionID == *seat.SectionID {
+			return true
+		}
+	}
+	return false
+}
+
+// match picks quantity seats from freed that satisfy the preferences and
+// returns their indexes, or nil when the freed seats can't satisfy them
+func (p *SeatPreferences) match(freed []FreedSeat, quantity int) []int {
+	var candidates []int
+	for i, seat := range freed {
+		if p.accepts(seat) {
+			candidates = append(candidates, i)
+		}
+	}
+	if len(candidates) < quantity {
+		return nil
+	}
+	if !p.ContiguousSeats || quantity == 1 {
+		return candidates[:quantity]
+	}
+
+	// Contiguous means one row of reserved seats with consecutive positions, or
+	// general admission tickets in the same section, which are never split up
+	groups := make(map[string][]int)
+	for _, i := range candidates {
+		seat := freed[i]
+		section := ""
+		if seat.SectionID != nil {
+			section = seat.SectionID.String()
+		}
+		if seat.SeatID == nil {
+			groups["ga:"+section] = append(groups["ga:"+section], i)
+			continue
+		}
+		if seat.Row == "" {
+			continue // Not on the seat map, so contiguity can't be shown
+		}
+		key := "row:" + section + ":" + seat.Row
+		groups[key] = append(groups[key], i)
+	}
+
+	keys := make([]string, 0, len(groups))
+	for key := range groups {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		group := groups[key]
+		if len(group) < quantity {
+			continue
+		}
+		if freed[group[0]].SeatID == nil {
+			return group[:quantity]
+		}
+
+		sort.Slice(group, func(a, b int) bool {
+			return freed[group[a]].Position < freed[group[b]].Position
+		})
+		start := 0
+		for i := 1; i <= len(group); i++ {
+			if i < len(group) && freed[group[i]].Position == freed[group[i-1]].Position+1 {
+				if i-start+1 == quantity {
+					return group[start : i+1]
+				}
+				continue
+			}
+			start = i
+		}
+	}
+	return nil
+}
+
+// withoutSeats returns freed minus the seats at the given indexes
+func withoutSeats(freed []FreedSeat, taken []int) []FreedSeat {
+	skip := make(map[int]bool, len(taken))
+	for _, i := range taken {
+		skip[i] = true
+	}
+	remaining := make([]FreedSeat, 0, len(freed)-len(taken))
+	for i, seat := range freed {
+		if !skip[i] {
+			remaining = append(remaining, seat)
+		}
+	}
+	return remaining
+}
@@ -0,0 +1,145 @@
+package waitlist
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// The queue scripts keep an event's waitlist ZSET dense: member scores are the
+// positions 1..n, and the positions hash mirrors them. Each script runs
+// atomically, so concurrent joins, leaves and requeues can't leave gaps or
+// duplicate positions.
+
+// luaQueueRenumber is prepended to the scripts that move members around
+const luaQueueRenumber = `
+-- Renumbers members from a 0-based rank to the end of the queue
+local function renumber(queue_key, positions_key, from)
+    local members = redis.call("ZRANGE", queue_key, from, -1)
+    for i, member in ipairs(members) do
+        local position = from + i
+        redis.call("ZADD", queue_key, position, member)
+        redis.call("HSET", positions_key, member, position)
+    end
+end
+`
+
+// Lua script for joining the end of the queue
+const luaQueueAdd = `
+-- KEYS[1] = queue key
+-- KEYS[2] = positions key
+-- ARGV[1] = member (user ID)
+-- ARGV[2] = ttl_seconds
+
+if redis.call("ZSCORE", KEYS[1], ARGV[1]) then
+    return -1
+end
+
+local position = redis.call("ZCARD", KEYS[1]) + 1
+redis.call("ZADD", KEYS[1], position, ARGV[1])
+redis.call("HSET", KEYS[2], ARGV[1], position)
+redis.call("EXPIRE", KEYS[1], ARGV[2])
+redis.call("EXPIRE", KEYS[2], ARGV[2])
+
+return position
+`
+
+// Lua script for leaving the queue; everyone behind moves up one place
+const luaQueueRemove = luaQueueRenumber + `
+-- KEYS[1] = queue key
+-- KEYS[2] = positions key
+-- ARGV[1] = member (user ID)
+
+redis.call("HDEL", KEYS[2], ARGV[1])
+
+local rank = redis.call("ZRANK", KEYS[1], ARGV[1])
+if not rank then
+    return 0
+end
+
+redis.call("ZREM", KEYS[1], ARGV[1])
+renumber(KEYS[1], KEYS[2], rank)
+
+return 1
+`
+
+// Lua script for putting a member at a position, clamped to the queue. The
+// member is taken out first if already queued; members from the position on
+// move back one place.
+const luaQueueInsertAt = luaQueueRenumber + `
+-- KEYS[1] = queue key
+-- KEYS[2] = positions key
+-- ARGV[1] = member (user ID)
+-- ARGV[2] = position, 0 for the end of the queue
+-- ARGV[3] = ttl_seconds
+
+local rank = redis.call("ZRANK", KEYS[1], ARGV[1])
+if rank then
+    redis.call("ZREM", KEYS[1], ARGV[1])
+    renumber(KEYS[1], KEYS[2], rank)
+end
+
+local size = redis.call("ZCARD", KEYS[1])
+local position = tonumber(ARGV[2])
+if position < 1 or position > size + 1 then
+    position = size + 1
+end
+
+local behind = redis.call("ZRANGE", KEYS[1], position - 1, -1)
+for i = #behind, 1, -1 do
+    redis.call("ZADD", KEYS[1], position + i, behind[i])
+    redis.call("HSET", KEYS[2], behind[i], position + i)
+end
+
+redis.call("ZADD", KEYS[1], position, ARGV[1])
+redis.call("HSET", KEYS[2], ARGV[1], position)
+redis.call("EXPIRE", KEYS[1], ARGV[3])
+redis.call("EXPIRE", KEYS[2], ARGV[3])
+
+return position
+`
+
+// Lua script for renumbering the whole queue
+const luaQueueRenumberAll = luaQueueRenumber + `
+-- KEYS[1] = queue key
+-- KEYS[2] = positions key
+-- ARGV[1] = ttl_seconds
+
+renumber(KEYS[1], KEYS[2], 0)
+
+local size = redis.call("ZCARD", KEYS[1])
+if size > 0 then
+    redis.call("EXPIRE", KEYS[1], ARGV[1])
+    redis.call("EXPIRE", KEYS[2], ARGV[1])
+end
+
+return size
+`
+
+var (
+	queueAddScript         = redis.NewScript(luaQueueAdd)
+	queueRemoveScript      = redis.NewScript(luaQueueRemove)
+	queueInsertAtScript    = redis.NewScript(luaQueueInsertAt)
+	queueRenumberAllScript = redis.NewScript(luaQueueRenumberAll)
+)
+
+// runQueueScript runs a queue script against an event's queue and positions keys
+func (r *repository) runQueueScript(ctx context.Context, script *redis.Script, eventID uuid.UUID, args ...interface{}) (int, error) {
+	if r.redis == nil {
+		return 0, fmt.Errorf("redis client not available")
+	}
+
+	keys := []string{GetQueueKey(eventID), GetPositionKey(eventID)}
+	result, err := script.Run(ctx, r.redis, keys, args...).Int()
+	if err != nil {
+		return 0, err
+	}
+	return result, nil
+}
+
+func queueTTLSeconds() string {
+	return strconv.Itoa(int(RedisKeyTTL.Seconds()))
+}
@@ -0,0 +1,217 @@
+package waitlist
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"evently/pkg/metrics"
+
+	"github.com/google/uuid"
+)
+
+// ReconcileConfig contains configuration for the queue reconciliation job
+type ReconcileConfig struct {
+	Enabled  bool
+	Interval time.Duration
+}
+
+// DefaultReconcileConfig returns default reconciliation configuration
+func DefaultReconcileConfig() *ReconcileConfig {
+	return &ReconcileConfig{
+		Enabled:  true,
+		Interval: 5 * time.Minute,
+	}
+}
+
+// ReconcileResult counts the repairs made by a reconciliation run
+type ReconcileResult struct {
+	Events       int `json:"events"`
+	Restored     int `json:"restored"`     // Queued entries missing from Redis, put back at their position
+	Removed      int `json:"removed"`      // Redis members without a queued entry
+	Repositioned int `json:"repositioned"` // Entries whose stored position drifted from Redis
+}
+
+// ReconcileQueues compares every Redis waitlist queue with its Postgres entries.
+// Postgres decides who is queued and Redis decides the order: missing entries
+// are put back at their stored position, members whose entry left the queue are
+// removed, and stored positions are brought in line with Redis.
+func (s *service) ReconcileQueues(ctx context.Context) (*ReconcileResult, error) {
+	eventIDs, err := s.repo.ListQueueEventIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ReconcileResult{}
+	unknown := make(map[string]bool)
+	for _, eventID := range eventIDs {
+		if err := s.reconcileQueue(ctx, eventID, result, unknown); err != nil {
+			log.ErrorContext(ctx, "Failed to reconcile waitlist", "event_id", eventID, "error", err)
+			continue
+		}
+		result.Events++
+	}
+
+	s.orphanMu.Lock()
+	s.unknownMembers = unknown
+	s.orphanMu.Unlock()
+
+	return result, nil
+}
+
+func (s *service) reconcileQueue(ctx context.Context, eventID uuid.UUID, result *ReconcileResult, unknown map[string]bool) error {
+	entries, err := s.repo.ListQueuedEntries(ctx, eventID)
+	if err != nil {
+		return err
+	}
+	members, err := s.repo.GetQueueMembers(ctx, eventID)
+	if err != nil {
+		return err
+	}
+
+	queued := make(map[uuid.UUID]bool, len(entries))
+	stored := make(map[uuid.UUID]int, len(entries))
+	for _, entry := range entries {
+		queued[entry.UserID] = true
+		stored[entry.ID] = entry.Position
+	}
+
+	// Members without a queued entry
+	inRedis := make(map[uuid.UUID]bool, len(members))
+	var strays []uuid.UUID
+	for _, member := range members {
+		userID, err := uuid.Parse(member)
+		if err != nil {
+			continue // Not a user ID, so not written by the waitlist
+		}
+		inRedis[userID] = true
+		if !queued[userID] {
+			strays = append(strays, userID)
+		}
+	}
+
+	if len(strays) > 0 {
+		statuses, err := s.repo.GetEntryStatuses(ctx, eventID, strays)
+		if err != nil {
+			return err
+		}
+		for _, userID := range strays {
+			// A member with no entry at all may be joining right now, so it is
+			// only removed when the previous run saw it too
+			key := eventID.String() + ":" + userID.String()
+			if _, ok := statuses[userID]; !ok && !s.wasUnknown(key) {
+				unknown[key] = true
+				continue
+			}
+
+			if err := s.repo.RemoveFromQueue(ctx, userID, eventID); err != nil {
+				return fmt.Errorf("failed to remove user %s: %w", userID, err)
+			}
+			log.InfoContext(ctx, "Reconcile removed user from the queue", "user_id", userID, "event_id", eventID)
+			metrics.WaitlistQueueRepairsTotal.Inc("removed")
+			result.Removed++
+		}
+	}
+
+	// Queued entries missing from Redis, in stored position order
+	for i := range entries {
+		entry := &entries[i]
+		if inRedis[entry.UserID] {
+			continue
+		}
+		if err := s.repo.RestoreQueuePosition(ctx, entry); err != nil {
+			return fmt.Errorf("failed to restore user %s: %w", entry.UserID, err)
+		}
+		log.InfoContext(ctx, "Reconcile restored user to the queue", "user_id", entry.UserID, "position", entry.Position, "event_id", eventID)
+		metrics.WaitlistQueueRepairsTotal.Inc("restored")
+		result.Restored++
+	}
+
+	// Stored positions follow the Redis order
+	members, err = s.repo.GetQueueMembers(ctx, eventID)
+	if err != nil {
+		return err
+	}
+	ranks := make(map[string]int, len(members))
+	for i, member := range members {
+		ranks[member] = i + 1
+	}
+	positions := make(map[uuid.UUID]int)
+	for _, entry := range entries {
+		if position, ok := ranks[entry.UserID.String()]; ok && position != stored[entry.ID] {
+			positions[entry.ID] = position
+		}
+	}
+	if len(positions) > 0 {
+		if err := s.repo.UpdateEntryPositions(ctx, positions); err != nil {
+			return err
+		}
+		metrics.WaitlistQueueRepairsTotal.Add(float64(len(positions)), "repositioned")
+		result.Repositioned += len(positions)
+	}
+
+	s.recordQueueLength(ctx, eventID)
+	return nil
+}
+
+func (s *service) wasUnknown(key string) bool {
+	s.orphanMu.Lock()
+	defer s.orphanMu.Unlock()
+	return s.unknownMembers[key]
+}
+
+// ReconcileJob periodically reconciles Redis waitlist queues with Postgres
+type ReconcileJob struct {
+	service Service
+	config  *ReconcileConfig
+	done    chan struct{}
+}
+
+// NewReconcileJob creates a new reconciliation job
+func NewReconcileJob(service Service, config *ReconcileConfig) *ReconcileJob {
+	if config == nil {
+		config = DefaultReconcileConfig()
+	}
+
+	return &ReconcileJob{
+		service: service,
+		config:  config,
+		done:    make(chan struct{}),
+	}
+}
+
+// Start starts the reconciliation job
+func (j *ReconcileJob) Start(ctx context.Context) {
+	log.Info("Starting waitlist reconcile job", "interval", j.config.Interval)
+	go j.run(ctx)
+}
+
+// Stop stops the reconciliation job
+func (j *ReconcileJob) Stop() {
+	log.Info("Stopping waitlist reconcile job")
+	close(j.done)
+}
+
+func (j *ReconcileJob) run(ctx context.Context) {
+	ticker := time.NewTicker(j.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			result, err := j.service.ReconcileQueues(ctx)
+			if err != nil {
+				log.Error("Waitlist reconcile run failed", "error", err)
+				continue
+			}
+			if result.Restored+result.Removed+result.Repositioned > 0 {
+				log.Info("Waitlist reconcile run", "events", result.Events, "restored", result.Restored,
+					"removed", result.Removed, "repositioned", result.Repositioned)
+			}
+		case <-j.done:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -4,12 +4,16 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
-	"log"
+	"strings"
 	"time"
 
+	"evently/internal/outbox"
+	"evently/pkg/cache"
+
 	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // Repository interface defines the contract for waitlist data operations
@@ -22,12 +26,18 @@ type Repository interface {
 	GetNextInQueue(ctx context.Context, eventID uuid.UUID, count int) ([]WaitlistEntry, error)
 	UpdatePositions(ctx context.Context, eventID uuid.UUID) error
 
+	// Seat preferences
+	CountEventSections(ctx context.Context, eventID uuid.UUID, sectionIDs []uuid.UUID) (int, error)
+	ResolveSeatPositions(ctx context.Context, freed []FreedSeat) ([]FreedSeat, error)
+
 	// Database Operations
 	CreateEntry(ctx context.Context, entry *WaitlistEntry) error
 	UpdateEntry(ctx context.Context, entry *WaitlistEntry) error
 	GetEntry(ctx context.Context, userID, eventID uuid.UUID) (*WaitlistEntry, error)
 	GetEntryByID(ctx context.Context, id uuid.UUID) (*WaitlistEntry, error)
 	ListEntries(ctx context.Context, eventID uuid.UUID, status WaitlistStatus) ([]WaitlistEntry, error)
+	CountEntries(ctx context.Context, eventID uuid.UUID, status WaitlistStatus) (int64, error)
+	ListExportRows(ctx context.Context, eventID uuid.UUID, status WaitlistStatus, offset, limit int) ([]ExportRow, error)
 	DeleteEntry(ctx context.Context, id uuid.UUID) error
 
 	// Batch Operations
@@ -37,149 +47,79 @@ type Repository interface {
 	// Analytics
 	GetWaitlistStats(ctx context.Context, eventID uuid.UUID) (*WaitlistStatsResponse, error)
 	CreateAnalytics(ctx context.Context, analytics *WaitlistAnalytics) error
+	GetWaitlistHealth(ctx context.Context) ([]EventWaitlistHealth, error)
 
 	// Notifications
 	CreateNotification(ctx context.Context, notification *WaitlistNotification) error
 	UpdateNotification(ctx context.Context, notification *WaitlistNotification) error
 	GetPendingNotifications(ctx context.Context, limit int) ([]WaitlistNotification, error)
+	NotifyEntry(ctx context.Context, entry *WaitlistEntry, notification *WaitlistNotification, message *outbox.Message) error
+	EnqueueNotifications(ctx context.Context, messages []*outbox.Message) error
+	CloseEntries(ctx context.Context, eventID uuid.UUID, ids []uuid.UUID, messages []*outbox.Message) error
+
+	// Open tracking and escalation
+	RecordNotificationDelivery(ctx context.Context, notification *WaitlistNotification) error
+	MarkNotificationOpened(ctx context.Context, id uuid.UUID) error
+	MarkEntryNotificationsOpened(ctx context.Context, entryID uuid.UUID) error
+	NotificationOpenedSince(ctx context.Context, entryID uuid.UUID, since time.Time) (bool, error)
+	ClaimEscalations(ctx context.Context, sentBefore time.Time, maxAttempts, limit int) ([]EscalationCandidate, error)
+	RecordEscalation(ctx context.Context, id uuid.UUID, channels string, escalationError *string) error
 
 	// Re-queuing Operations
-	RequeueExpiredUser(ctx context.Context, userID, eventID uuid.UUID) error
+	RequeueExpiredUser(ctx context.Context, userID, eventID uuid.UUID, withoutAction bool) error
+	RestoreQueuePosition(ctx context.Context, entry *WaitlistEntry) error
+	RevertConvertedEntry(ctx context.Context, entry *WaitlistEntry) error
+
+	// Queue reconciliation
+	ListQueueEventIDs(ctx context.Context) ([]uuid.UUID, error)
+	ListQueuedEntries(ctx context.Context, eventID uuid.UUID) ([]WaitlistEntry, error)
+	GetQueueMembers(ctx context.Context, eventID uuid.UUID) ([]string, error)
+	GetEntryStatuses(ctx context.Context, eventID uuid.UUID, userIDs []uuid.UUID) (map[uuid.UUID]WaitlistStatus, error)
+	UpdateEntryPositions(ctx context.Context, positions map[uuid.UUID]int) error
 }
 
 // repository implements the Repository interface
 type repository struct {
 	db    *gorm.DB
-	redis *redis.Client
+	redis redis.UniversalClient
 }
 
 // NewRepository creates a new waitlist repository
-func NewRepository(db *gorm.DB, redisClient *redis.Client) Repository {
+func NewRepository(db *gorm.DB, redisClient redis.UniversalClient) Repository {
 	return &repository{
 		db:    db,
 		redis: redisClient,
 	}
 }
 
-// AddToQueue adds a user to the event waitlist queue using Redis ZSet
+// AddToQueue adds a user to the end of the event waitlist queue
 func (r *repository) AddToQueue(ctx context.Context, entry *WaitlistEntry) error {
-	queueKey := GetQueueKey(entry.EventID)
-	lockKey := GetLockKey(entry.EventID)
-
-	// Acquire distributed lock for queue operations
-	lock := r.redis.SetNX(ctx, lockKey, "locked", 5*time.Second)
-	if !lock.Val() {
-		return fmt.Errorf("could not acquire lock for event %s", entry.EventID)
+	position, err := r.runQueueScript(ctx, queueAddScript, entry.EventID, entry.UserID.String(), queueTTLSeconds())
+	if err != nil {
+		return fmt.Errorf("failed to add user to queue: %w", err)
 	}
-	defer r.redis.Del(ctx, lockKey)
-
-	// Get current queue length to determine position
-	queueLength := r.redis.ZCard(ctx, queueKey).Val()
-
-	// Check if user is already in queue
-	userKey := entry.UserID.String()
-	score := r.redis.ZScore(ctx, queueKey, userKey).Val()
-	if score != 0 { // User already exists in queue
+	if position < 0 {
 		return fmt.Errorf("user already in waitlist for event %s", entry.EventID)
 	}
 
-	// Add user to queue with position as score
-	position := int(queueLength) + 1
 	entry.Position = position
-
-	err := r.redis.ZAdd(ctx, queueKey, redis.Z{
-		Score:  float64(position),
-		Member: userKey,
-	}).Err()
-	if err != nil {
-		return fmt.Errorf("failed to add user to queue: %w", err)
-	}
-
-	// Set TTL for the queue key
-	r.redis.Expire(ctx, queueKey, RedisKeyTTL)
-
-	// Update position tracking
-	positionKey := GetPositionKey(entry.EventID)
-	r.redis.HSet(ctx, positionKey, userKey, position)
-	r.redis.Expire(ctx, positionKey, RedisKeyTTL)
-
 	return nil
 }
 
-// RemoveFromQueue removes a user from the waitlist queue
+// RemoveFromQueue removes a user from the waitlist queue and moves everyone behind them up
 func (r *repository) RemoveFromQueue(ctx context.Context, userID, eventID uuid.UUID) error {
-	queueKey := GetQueueKey(eventID)
-	lockKey := GetLockKey(eventID)
-
-	// Acquire distributed lock
-	lock := r.redis.SetNX(ctx, lockKey, "locked", 5*time.Second)
-	if !lock.Val() {
-		return fmt.Errorf("could not acquire lock for event %s", eventID)
-	}
-	defer r.redis.Del(ctx, lockKey)
-
-	userKey := userID.String()
-
-	// Check if user exists in queue
-	position := r.redis.ZScore(ctx, queueKey, userKey).Val()
-	if position == 0 {
-		// Check if user actually exists (ZScore returns 0 for both non-existent and position 0)
-		exists := r.redis.ZRank(ctx, queueKey, userKey).Val()
-		if exists == 0 {
-			// Double-check with EXISTS to distinguish between position 0 and non-existent
-			memberExists := false
-			allMembers := r.redis.ZRange(ctx, queueKey, 0, -1).Val()
-			for _, member := range allMembers {
-				if member == userKey {
-					memberExists = true
-					break
-				}
-			}
-			if !memberExists {
-				log.Printf("⚠️ RemoveFromQueue: User %s not found in Redis queue for event %s (already removed or never existed)", userID, eventID)
-				// Don't return error - user might have been removed already or never in queue
-				return nil
-			}
-		}
+	removed, err := r.runQueueScript(ctx, queueRemoveScript, eventID, userID.String())
+	if err != nil {
+		return fmt.Errorf("failed to remove user from queue: %w", err)
 	}
 
-	// Remove user from queue
-	removed := r.redis.ZRem(ctx, queueKey, userKey).Val()
 	if removed == 0 {
-		log.Printf("⚠️ RemoveFromQueue: User %s was not in Redis queue for event %s", userID, eventID)
-		// Don't return error - user might have been removed already
+		// Don't return error - user might have been removed already or never in queue
+		log.DebugContext(ctx, "User not in waitlist queue, already removed or never queued", "user_id", userID, "event_id", eventID)
 	} else {
-		log.Printf("✅ RemoveFromQueue: Successfully removed user %s from Redis queue for event %s", userID, eventID)
+		log.DebugContext(ctx, "Removed user from waitlist queue", "user_id", userID, "event_id", eventID)
 	}
 
-	// Get all remaining members and rebuild positions (more reliable than incremental updates)
-	allMembers := r.redis.ZRangeWithScores(ctx, queueKey, 0, -1).Val()
-
-	if len(allMembers) > 0 {
-		// Use pipeline for atomic position updates
-		pipe := r.redis.Pipeline()
-		pipe.Del(ctx, queueKey)
-
-		// Re-add all members with correct positions
-		for i, member := range allMembers {
-			newPosition := i + 1
-			pipe.ZAdd(ctx, queueKey, redis.Z{
-				Score:  float64(newPosition),
-				Member: member.Member,
-			})
-		}
-
-		pipe.Expire(ctx, queueKey, RedisKeyTTL)
-		_, err := pipe.Exec(ctx)
-		if err != nil {
-			log.Printf("❌ RemoveFromQueue: Failed to rebuild positions after removing user %s: %v", userID, err)
-		}
-	}
-
-	// Remove from position tracking
-	positionKey := GetPositionKey(eventID)
-	r.redis.HDel(ctx, positionKey, userKey)
-
 	return nil
 }
 
@@ -243,55 +183,85 @@ func (r *repository) GetNextInQueue(ctx context.Context, eventID uuid.UUID, coun
 	return entries, nil
 }
 
-// UpdatePositions recalculates and updates positions for all users in a queue
-func (r *repository) UpdatePositions(ctx context.Context, eventID uuid.UUID) error {
-	queueKey := GetQueueKey(eventID)
-	lockKey := GetLockKey(eventID)
-
-	// Acquire distributed lock
-	lock := r.redis.SetNX(ctx, lockKey, "locked", 10*time.Second)
-	if !lock.Val() {
-		return fmt.Errorf("could not acquire lock for event %s", eventID)
+// CountEventSections counts how many of the sections belong to the event's venue template
+func (r *repository) CountEventSections(ctx context.Context, eventID uuid.UUID, sectionIDs []uuid.UUID) (int, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Table("venue_sections").
+		Joins("JOIN events ON events.venue_template_id = venue_sections.template_id").
+		Where("events.id = ? AND venue_sections.id IN ?", eventID, sectionIDs).
+		Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to count event sections: %w", err)
 	}
-	defer r.redis.Del(ctx, lockKey)
+	return int(count), nil
+}
 
-	// Get all members in order
-	members := r.redis.ZRange(ctx, queueKey, 0, -1).Val()
+// ResolveSeatPositions fills in the row and position of freed reserved seats from the seat map
+func (r *repository) ResolveSeatPositions(ctx context.Context, freed []FreedSeat) ([]FreedSeat, error) {
+	var seatIDs []uuid.UUID
+	for _, seat := range freed {
+		if seat.SeatID != nil {
+			seatIDs = append(seatIDs, *seat.SeatID)
+		}
+	}
+	if len(seatIDs) == 0 {
+		return freed, nil
+	}
 
-	// Rebuild the queue with correct positions
-	pipe := r.redis.Pipeline()
-	pipe.Del(ctx, queueKey)
+	var rows []struct {
+		ID       uuid.UUID
+		Row      string
+		Position int
+	}
+	err := r.db.WithContext(ctx).
+		Table("seats").
+		Select("id, row, position").
+		Where("id IN ?", seatIDs).
+		Scan(&rows).Error
+	if err != nil {
+		return freed, fmt.Errorf("failed to load seat positions: %w", err)
+	}
 
-	for i, member := range members {
-		position := i + 1
-		pipe.ZAdd(ctx, queueKey, redis.Z{
-			Score:  float64(position),
-			Member: member,
-		})
+	byID := make(map[uuid.UUID]int, len(rows))
+	for i, row := range rows {
+		byID[row.ID] = i
 	}
 
-	pipe.Expire(ctx, queueKey, RedisKeyTTL)
+	resolved := make([]FreedSeat, len(freed))
+	for i, seat := range freed {
+		if seat.SeatID != nil {
+			if j, ok := byID[*seat.SeatID]; ok {
+				seat.Row = rows[j].Row
+				seat.Position = rows[j].Position
+			}
+		}
+		resolved[i] = seat
+	}
+	return resolved, nil
+}
 
-	_, err := pipe.Exec(ctx)
-	if err != nil {
+// UpdatePositions recalculates and updates positions for all users in a queue
+func (r *repository) UpdatePositions(ctx context.Context, eventID uuid.UUID) error {
+	if _, err := r.runQueueScript(ctx, queueRenumberAllScript, eventID, queueTTLSeconds()); err != nil {
 		return fmt.Errorf("failed to update positions: %w", err)
 	}
-
 	return nil
 }
 
-// CreateEntry creates a new waitlist entry in the database
+// CreateEntry creates a new waitlist entry in the database and records its
+// WaitlistJoined event in the same transaction
 func (r *repository) CreateEntry(ctx context.Context, entry *WaitlistEntry) error {
 	entry.ID = uuid.New()
 	entry.CreatedAt = time.Now()
 	entry.UpdatedAt = time.Now()
 
-	err := r.db.WithContext(ctx).Create(entry).Error
-	if err != nil {
-		return fmt.Errorf("failed to create waitlist entry: %w", err)
-	}
-
-	return nil
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(entry).Error; err != nil {
+			return fmt.Errorf("failed to create waitlist entry: %w", err)
+		}
+		return enqueueJoinedDomainEvent(tx, entry)
+	})
 }
 
 // UpdateEntry updates an existing waitlist entry
@@ -361,6 +331,46 @@ func (r *repository) ListEntries(ctx context.Context, eventID uuid.UUID, status
 	return entries, nil
 }
 
+// CountEntries counts an event's entries, optionally with one status
+func (r *repository) CountEntries(ctx context.Context, eventID uuid.UUID, status WaitlistStatus) (int64, error) {
+	var count int64
+	query := r.db.WithContext(ctx).Model(&WaitlistEntry{}).Where("event_id = ?", eventID)
+
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	if err := query.Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count waitlist entries: %w", err)
+	}
+
+	return count, nil
+}
+
+// ListExportRows pages through an event's entries with the contact details of each user
+func (r *repository) ListExportRows(ctx context.Context, eventID uuid.UUID, status WaitlistStatus, offset, limit int) ([]ExportRow, error) {
+	var rows []ExportRow
+	query := r.db.WithContext(ctx).
+		Table("waitlist_entries we").
+		Select("we.*, u.email, u.first_name, u.last_name").
+		Joins("LEFT JOIN users u ON u.id = we.user_id").
+		Where("we.event_id = ?", eventID)
+
+	if status != "" {
+		query = query.Where("we.status = ?", status)
+	}
+
+	err := query.Order("we.position ASC, we.id ASC").
+		Offset(offset).
+		Limit(limit).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list waitlist export rows: %w", err)
+	}
+
+	return rows, nil
+}
+
 // DeleteEntry deletes a waitlist entry
 func (r *repository) DeleteEntry(ctx context.Context, id uuid.UUID) error {
 	err := r.db.WithContext(ctx).
@@ -487,6 +497,70 @@ func (r *repository) CreateNotification(ctx context.Context, notification *Waitl
 	return nil
 }
 
+// NotifyEntry updates the entry, records the notification and writes the outbox
+// message and waitlist.notified webhook in a single transaction so the
+// notification cannot be lost
+func (r *repository) NotifyEntry(ctx context.Context, entry *WaitlistEntry, notification *WaitlistNotification, message *outbox.Message) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		entry.UpdatedAt = now
+
+		if err := tx.Model(entry).Where("id = ?", entry.ID).Updates(entry).Error; err != nil {
+			return fmt.Errorf("failed to update waitlist entry: %w", err)
+		}
+
+		if notification.ID == uuid.Nil {
+			notification.ID = uuid.New()
+		}
+		notification.CreatedAt = now
+		notification.UpdatedAt = now
+		if err := tx.Create(notification).Error; err != nil {
+			return fmt.Errorf("failed to create notification: %w", err)
+		}
+
+		if err := enqueueNotifiedWebhook(tx, entry); err != nil {
+			return err
+		}
+
+		return outbox.Enqueue(tx, message)
+	})
+}
+
+// EnqueueNotifications writes outbox messages that aren't tied to an entry update
+func (r *repository) EnqueueNotifications(ctx context.Context, messages []*outbox.Message) error {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return outbox.Enqueue(tx, messages...)
+	})
+}
+
+// CloseEntries cancels entries and queues their notifications in one
+// transaction, then drops the event's Redis queue
+func (r *repository) CloseEntries(ctx context.Context, eventID uuid.UUID, ids []uuid.UUID, messages []*outbox.Message) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&WaitlistEntry{}).
+			Where("id IN ?", ids).
+			Updates(map[string]interface{}{
+				"status":     WaitlistStatusCancelled,
+				"updated_at": time.Now(),
+			}).Error; err != nil {
+			return err
+		}
+		return outbox.Enqueue(tx, messages...)
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := r.redis.Del(ctx, GetQueueKey(eventID), GetPositionKey(eventID)).Err(); err != nil {
+		return fmt.Errorf("failed to clear waitlist queue: %w", err)
+	}
+	return nil
+}
+
 // UpdateNotification updates a notification record
 func (r *repository) UpdateNotification(ctx context.Context, notification *WaitlistNotification) error {
 	notification.UpdatedAt = time.Now()
@@ -519,12 +593,190 @@ func (r *repository) GetPendingNotifications(ctx context.Context, limit int) ([]
 	return notifications, nil
 }
 
-// RequeueExpiredUser moves an expired user back to the end of the active queue
-func (r *repository) RequeueExpiredUser(ctx context.Context, userID, eventID uuid.UUID) error {
-	// Get current queue length to determine new position
-	queueLength, err := r.GetQueueLength(ctx, eventID)
+// RecordNotificationDelivery stores the delivery outcome on the notification row
+// for the same entry, message and channel, creating the row when the
+// notification was not recorded when it was queued (SMS, push, position updates)
+func (r *repository) RecordNotificationDelivery(ctx context.Context, notification *WaitlistNotification) error {
+	now := time.Now()
+	result := r.db.WithContext(ctx).
+		Model(&WaitlistNotification{}).
+		Where("waitlist_entry_id = ? AND message_id = ? AND channel = ?",
+			notification.WaitlistEntryID, notification.MessageID, notification.Channel).
+		Updates(map[string]interface{}{
+			"status":        notification.Status,
+			"error_message": notification.ErrorMessage,
+			"sent_at":       notification.SentAt,
+			"updated_at":    now,
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to record notification delivery: %w", result.Error)
+	}
+	if result.RowsAffected > 0 {
+		return nil
+	}
+
+	notification.CreatedAt = now
+	notification.UpdatedAt = now
+	if err := r.db.WithContext(ctx).Create(notification).Error; err != nil {
+		return fmt.Errorf("failed to record notification delivery: %w", err)
+	}
+	return nil
+}
+
+// MarkNotificationOpened records the first open of a notification
+func (r *repository) MarkNotificationOpened(ctx context.Context, id uuid.UUID) error {
+	err := r.db.WithContext(ctx).
+		Model(&WaitlistNotification{}).
+		Where("id = ? AND opened_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"opened_at":  time.Now(),
+			"updated_at": time.Now(),
+		}).Error
 	if err != nil {
-		return fmt.Errorf("failed to get queue length: %w", err)
+		return fmt.Errorf("failed to mark notification opened: %w", err)
+	}
+	return nil
+}
+
+// MarkEntryNotificationsOpened records an open on every unopened notification of an entry
+func (r *repository) MarkEntryNotificationsOpened(ctx context.Context, entryID uuid.UUID) error {
+	err := r.db.WithContext(ctx).
+		Model(&WaitlistNotification{}).
+		Where("waitlist_entry_id = ? AND opened_at IS NULL", entryID).
+		Updates(map[string]interface{}{
+			"opened_at":  time.Now(),
+			"updated_at": time.Now(),
+		}).Error
+	if err != nil {
+		return fmt.Errorf("failed to mark entry notifications opened: %w", err)
+	}
+	return nil
+}
+
+// NotificationOpenedSince reports whether a spot-available notification sent to the
+// entry since the given time was opened
+func (r *repository) NotificationOpenedSince(ctx context.Context, entryID uuid.UUID, since time.Time) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&WaitlistNotification{}).
+		Where("waitlist_entry_id = ? AND notification_type = ? AND created_at >= ? AND opened_at IS NOT NULL",
+			entryID, NotificationTypeSpotAvailable, since).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to check notification opens: %w", err)
+	}
+	return count > 0, nil
+}
+
+// ClaimEscalations locks unopened spot-available emails sent before sentBefore whose
+// booking window is still open and whose user opted into SMS or push, and counts an
+// escalation attempt against each so concurrent instances never double-send
+func (r *repository) ClaimEscalations(ctx context.Context, sentBefore time.Time, maxAttempts, limit int) ([]EscalationCandidate, error) {
+	var candidates []EscalationCandidate
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var notifications []WaitlistNotification
+		err := tx.Clauses(clause.Locking{
+			Strength: "UPDATE",
+			Table:    clause.Table{Name: "waitlist_notifications"},
+			Options:  "SKIP LOCKED",
+		}).
+			Joins("JOIN waitlist_entries ON waitlist_entries.id = waitlist_notifications.waitlist_entry_id").
+			Where("waitlist_notifications.notification_type = ? AND waitlist_notifications.channel = ?",
+				NotificationTypeSpotAvailable, NotificationChannelEmail).
+			Where("waitlist_notifications.opened_at IS NULL AND waitlist_notifications.escalated_at IS NULL").
+			Where("waitlist_notifications.escalation_attempts < ?", maxAttempts).
+			Where("waitlist_notifications.created_at <= ?", sentBefore).
+			// Only the notification for the entry's current booking window
+			Where("waitlist_notifications.created_at >= waitlist_entries.notified_at").
+			Where("waitlist_entries.status = ? AND waitlist_entries.expires_at > ?", WaitlistStatusNotified, time.Now()).
+			Where("(waitlist_entries.sms_phone IS NOT NULL OR waitlist_entries.push_token IS NOT NULL)").
+			Order("waitlist_notifications.created_at ASC").
+			Limit(limit).
+			Find(&notifications).Error
+		if err != nil {
+			return err
+		}
+
+		if len(notifications) == 0 {
+			return nil
+		}
+
+		ids := make([]uuid.UUID, len(notifications))
+		entryIDs := make([]uuid.UUID, len(notifications))
+		for i := range notifications {
+			ids[i] = notifications[i].ID
+			entryIDs[i] = notifications[i].WaitlistEntryID
+		}
+
+		var entries []WaitlistEntry
+		if err := tx.Where("id IN ?", entryIDs).Find(&entries).Error; err != nil {
+			return err
+		}
+		entriesByID := make(map[uuid.UUID]WaitlistEntry, len(entries))
+		for _, entry := range entries {
+			entriesByID[entry.ID] = entry
+		}
+
+		now := time.Now()
+		for _, notification := range notifications {
+			notification.EscalationAttempts++
+			notification.UpdatedAt = now
+			candidates = append(candidates, EscalationCandidate{
+				Notification: notification,
+				Entry:        entriesByID[notification.WaitlistEntryID],
+			})
+		}
+
+		return tx.Model(&WaitlistNotification{}).
+			Where("id IN ?", ids).
+			Updates(map[string]interface{}{
+				"updated_at":          now,
+				"escalation_attempts": gorm.Expr("escalation_attempts + 1"),
+			}).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim escalations: %w", err)
+	}
+
+	return candidates, nil
+}
+
+// RecordEscalation stores the outcome of an escalation attempt on the notification.
+// Channels is empty when every channel failed, leaving the notification eligible for a retry.
+func (r *repository) RecordEscalation(ctx context.Context, id uuid.UUID, channels string, escalationError *string) error {
+	now := time.Now()
+	updates := map[string]interface{}{
+		"escalation_error": escalationError,
+		"updated_at":       now,
+	}
+	if channels != "" {
+		updates["escalated_at"] = now
+		updates["escalated_channels"] = channels
+	}
+
+	err := r.db.WithContext(ctx).
+		Model(&WaitlistNotification{}).
+		Where("id = ?", id).
+		Updates(updates).Error
+	if err != nil {
+		return fmt.Errorf("failed to record escalation: %w", err)
+	}
+	return nil
+}
+
+// RequeueExpiredUser moves an expired user back to the end of the active queue.
+// withoutAction counts the missed window as one the user never opened.
+func (r *repository) RequeueExpiredUser(ctx context.Context, userID, eventID uuid.UUID, withoutAction bool) error {
+	missedUnopened := 0
+	if withoutAction {
+		missedUnopened = 1
+	}
+
+	// Move to the end of the Redis queue first, so the stored position matches it
+	position, err := r.runQueueScript(ctx, queueInsertAtScript, eventID, userID.String(), 0, queueTTLSeconds())
+	if err != nil {
+		return fmt.Errorf("failed to move user to the end of the Redis queue: %w", err)
 	}
 
 	// Update the entry to move back to active status at end of queue
@@ -534,25 +786,178 @@ func (r *repository) RequeueExpiredUser(ctx context.Context, userID, eventID uui
 		Where("user_id = ? AND event_id = ? AND status = ?", userID, eventID, WaitlistStatusNotified).
 		Updates(map[string]interface{}{
 			"status":      WaitlistStatusActive,
-			"position":    queueLength + 1, // Place at end of queue
+			"position":    position,
 			"notified_at": nil,
 			"expires_at":  nil,
 			"updated_at":  now,
+
+			"requeue_count":          gorm.Expr("requeue_count + 1"),
+			"expired_without_action": gorm.Expr("expired_without_action + ?", missedUnopened),
 		}).Error
 
 	if err != nil {
 		return fmt.Errorf("failed to requeue expired user: %w", err)
 	}
 
-	// Add back to Redis queue at the end
-	err = r.redis.ZAdd(ctx, GetQueueKey(eventID), redis.Z{
-		Score:  float64(queueLength + 1),
-		Member: userID.String(),
-	}).Err()
+	return nil
+}
 
+// RestoreQueuePosition puts a user back into the Redis queue at their recorded position
+func (r *repository) RestoreQueuePosition(ctx context.Context, entry *WaitlistEntry) error {
+	position, err := r.runQueueScript(ctx, queueInsertAtScript, entry.EventID, entry.UserID.String(), entry.Position, queueTTLSeconds())
 	if err != nil {
-		return fmt.Errorf("failed to add user back to Redis queue: %w", err)
+		return fmt.Errorf("failed to restore user to Redis queue: %w", err)
+	}
+	entry.Position = position
+	return nil
+}
+
+// RevertConvertedEntry hands a converted entry its notification back and forgets the conversion
+func (r *repository) RevertConvertedEntry(ctx context.Context, entry *WaitlistEntry) error {
+	now := time.Now()
+	err := r.db.WithContext(ctx).
+		Model(&WaitlistEntry{}).
+		Where("id = ? AND status = ?", entry.ID, WaitlistStatusConverted).
+		Updates(map[string]interface{}{
+			"status":              WaitlistStatusNotified,
+			"converted_at":        nil,
+			"booking_window_used": nil,
+			"updated_at":          now,
+		}).Error
+	if err != nil {
+		return fmt.Errorf("failed to revert waitlist conversion: %w", err)
 	}
 
+	entry.Status = WaitlistStatusNotified
+	entry.ConvertedAt = nil
+	entry.BookingWindowUsed = nil
+	entry.UpdatedAt = now
 	return nil
 }
+
+// GetWaitlistHealth summarizes the waitlist of every upcoming event that has one
+func (r *repository) GetWaitlistHealth(ctx context.Context) ([]EventWaitlistHealth, error) {
+	events := []EventWaitlistHealth{}
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT
+			w.event_id,
+			e.name AS event_name,
+			e.date_time AS event_date_time,
+			COUNT(*) FILTER (WHERE w.status = ?) AS queue_depth,
+			COUNT(*) FILTER (WHERE w.status = ?) AS awaiting_booking,
+			COUNT(*) FILTER (WHERE w.status = ?) AS conversions,
+			COALESCE(MAX(n.sent), 0) AS notifications_sent,
+			AVG(w.booking_window_used) AS avg_booking_window_used,
+			COALESCE(SUM(w.requeue_count), 0) AS requeues,
+			COALESCE(SUM(w.expired_without_action), 0) AS expired_without_action
+		FROM waitlist_entries w
+		JOIN events e ON e.id = w.event_id AND e.deleted_at IS NULL
+		LEFT JOIN (
+			SELECT we.event_id, COUNT(*) AS sent
+			FROM waitlist_notifications wn
+			JOIN waitlist_entries we ON we.id = wn.waitlist_entry_id
+			WHERE wn.notification_type = ? AND wn.channel = ?
+			GROUP BY we.event_id
+		) n ON n.event_id = w.event_id
+		WHERE e.date_time > NOW()
+		GROUP BY w.event_id, e.name, e.date_time
+		ORDER BY queue_depth DESC, e.date_time ASC
+	`, WaitlistStatusActive, WaitlistStatusNotified, WaitlistStatusConverted, NotificationTypeSpotAvailable, NotificationChannelEmail).
+		Scan(&events).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get waitlist health: %w", err)
+	}
+	return events, nil
+}
+
+// ListQueueEventIDs returns the events with queued entries in Postgres or a queue in Redis
+func (r *repository) ListQueueEventIDs(ctx context.Context) ([]uuid.UUID, error) {
+	var eventIDs []uuid.UUID
+	err := r.db.WithContext(ctx).
+		Model(&WaitlistEntry{}).
+		Distinct("event_id").
+		Where("status IN ?", []WaitlistStatus{WaitlistStatusActive, WaitlistStatusNotified}).
+		Pluck("event_id", &eventIDs).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list waitlisted events: %w", err)
+	}
+
+	seen := make(map[uuid.UUID]bool, len(eventIDs))
+	for _, id := range eventIDs {
+		seen[id] = true
+	}
+
+	queueKeys, err := cache.MatchKeys(ctx, r.redis, queueKeyPrefix+"*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan waitlist queues: %w", err)
+	}
+	for _, key := range queueKeys {
+		id, err := uuid.Parse(strings.Trim(strings.TrimPrefix(key, queueKeyPrefix), "{}"))
+		if err != nil || seen[id] {
+			continue
+		}
+		seen[id] = true
+		eventIDs = append(eventIDs, id)
+	}
+
+	return eventIDs, nil
+}
+
+// ListQueuedEntries returns the entries that belong in an event's Redis queue, in queue order
+func (r *repository) ListQueuedEntries(ctx context.Context, eventID uuid.UUID) ([]WaitlistEntry, error) {
+	var entries []WaitlistEntry
+	err := r.db.WithContext(ctx).
+		Where("event_id = ? AND status IN ?", eventID, []WaitlistStatus{WaitlistStatusActive, WaitlistStatusNotified}).
+		Order("position ASC, joined_at ASC").
+		Find(&entries).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list queued entries: %w", err)
+	}
+	return entries, nil
+}
+
+// GetQueueMembers returns the members of an event's Redis queue in order
+func (r *repository) GetQueueMembers(ctx context.Context, eventID uuid.UUID) ([]string, error) {
+	members, err := r.redis.ZRange(ctx, GetQueueKey(eventID), 0, -1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read waitlist queue: %w", err)
+	}
+	return members, nil
+}
+
+// GetEntryStatuses returns the status of each user's entry; users without an entry are left out
+func (r *repository) GetEntryStatuses(ctx context.Context, eventID uuid.UUID, userIDs []uuid.UUID) (map[uuid.UUID]WaitlistStatus, error) {
+	var rows []struct {
+		UserID uuid.UUID
+		Status WaitlistStatus
+	}
+	err := r.db.WithContext(ctx).
+		Model(&WaitlistEntry{}).
+		Select("user_id, status").
+		Where("event_id = ? AND user_id IN ?", eventID, userIDs).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get entry statuses: %w", err)
+	}
+
+	statuses := make(map[uuid.UUID]WaitlistStatus, len(rows))
+	for _, row := range rows {
+		statuses[row.UserID] = row.Status
+	}
+	return statuses, nil
+}
+
+// UpdateEntryPositions stores the queue positions of entries by ID
+func (r *repository) UpdateEntryPositions(ctx context.Context, positions map[uuid.UUID]int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for id, position := range positions {
+			err := tx.Model(&WaitlistEntry{}).
+				Where("id = ?", id).
+				Update("position", position).Error
+			if err != nil {
+				return fmt.Errorf("failed to update entry position: %w", err)
+			}
+		}
+		return nil
+	})
+}
@@ -3,7 +3,14 @@ package waitlist
 import "github.com/google/uuid"
 
 type JoinWaitlistRequest struct {
-	EventID     uuid.UUID `json:"event_id" validate:"required"`
-	Quantity    int       `json:"quantity" validate:"required,min=1,max=10"`
-	Preferences JSONMap   `json:"preferences,omitempty"`
+	EventID     uuid.UUID          `json:"event_id" validate:"required"`
+	Quantity    int                `json:"quantity" validate:"required,min=1,max=10"`
+	Preferences *SeatPreferences   `json:"preferences,omitempty"`
+	Escalation  *EscalationRequest `json:"escalation,omitempty"`
+}
+
+// EscalationRequest opts into SMS and/or push follow-ups when a spot-available email goes unopened
+type EscalationRequest struct {
+	Phone     string `json:"phone,omitempty"`      // E.164, e.g. +14155550123
+	PushToken string `json:"push_token,omitempty"` // Device token from the mobile app
 }
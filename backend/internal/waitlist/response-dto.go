@@ -7,16 +7,48 @@ import (
 )
 
 type WaitlistResponse struct {
-	ID            uuid.UUID      `json:"id"`
-	EventID       uuid.UUID      `json:"event_id"`
-	Position      int            `json:"position"`
-	Quantity      int            `json:"quantity"`
-	Status        WaitlistStatus `json:"status"`
-	EstimatedWait *time.Duration `json:"estimated_wait,omitempty"`
-	Preferences   JSONMap        `json:"preferences,omitempty"`
-	JoinedAt      time.Time      `json:"joined_at"`
-	NotifiedAt    *time.Time     `json:"notified_at,omitempty"`
-	ExpiresAt     *time.Time     `json:"expires_at,omitempty"`
+	ID            uuid.UUID        `json:"id"`
+	EventID       uuid.UUID        `json:"event_id"`
+	Position      int              `json:"position"`
+	Quantity      int              `json:"quantity"`
+	Status        WaitlistStatus   `json:"status"`
+	EstimatedWait *time.Duration   `json:"estimated_wait,omitempty"`
+	Preferences   *SeatPreferences `json:"preferences,omitempty"`
+	JoinedAt      time.Time        `json:"joined_at"`
+	NotifiedAt    *time.Time       `json:"notified_at,omitempty"`
+	ExpiresAt     *time.Time       `json:"expires_at,omitempty"`
+
+	EscalationChannels []NotificationChannel `json:"escalation_channels,omitempty"`
+}
+
+// WaitlistHealthResponse summarizes waitlist health across upcoming events
+type WaitlistHealthResponse struct {
+	Events               int                   `json:"events"`
+	QueueDepth           int                   `json:"queue_depth"`
+	AwaitingBooking      int                   `json:"awaiting_booking"`
+	NotificationsSent    int                   `json:"notifications_sent"`
+	Conversions          int                   `json:"conversions"`
+	ConversionRate       float64               `json:"conversion_rate"` // Conversions per spot-available notification
+	AvgBookingWindowUsed *float64              `json:"avg_booking_window_used,omitempty"`
+	Requeues             int                   `json:"requeues"`
+	ExpiredWithoutAction int                   `json:"expired_without_action"`
+	ByEvent              []EventWaitlistHealth `json:"by_event"`
+	GeneratedAt          time.Time             `json:"generated_at"`
+}
+
+// EventWaitlistHealth is the waitlist health of one event
+type EventWaitlistHealth struct {
+	EventID              uuid.UUID `json:"event_id"`
+	EventName            string    `json:"event_name"`
+	EventDateTime        time.Time `json:"event_date_time"`
+	QueueDepth           int       `json:"queue_depth"`      // Users waiting to be notified
+	AwaitingBooking      int       `json:"awaiting_booking"` // Notified users whose window is open
+	NotificationsSent    int       `json:"notifications_sent"`
+	Conversions          int       `json:"conversions"`
+	ConversionRate       float64   `json:"conversion_rate"`
+	AvgBookingWindowUsed *float64  `json:"avg_booking_window_used,omitempty"` // Fraction of the window elapsed at conversion
+	Requeues             int       `json:"requeues"`
+	ExpiredWithoutAction int       `json:"expired_without_action"`
 }
 
 type WaitlistStatsResponse struct {
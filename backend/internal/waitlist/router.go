@@ -9,6 +9,9 @@ import (
 func SetupWaitlistRoutes(rg *gin.RouterGroup, controller *Controller) {
 	waitlist := rg.Group("/waitlist")
 	{
+		// Public open-tracking pixel loaded by email clients
+		waitlist.GET("/notifications/:notification_id/open", controller.TrackNotificationOpen)
+
 		// Authenticated user operations
 		authenticated := waitlist.Group("")
 		authenticated.Use(middleware.JWTAuth(), middleware.RequireRoles("USER", "ADMIN"))
@@ -21,10 +24,12 @@ func SetupWaitlistRoutes(rg *gin.RouterGroup, controller *Controller) {
 
 	// Admin waitlist routes
 	adminWaitlist := rg.Group("/admin/waitlist")
-	adminWaitlist.Use(middleware.JWTAuth(), middleware.RequireAdmin())
+	adminWaitlist.Use(middleware.JWTAuth(), middleware.RequirePermission(middleware.PermissionBookingsManage))
 	{
-		adminWaitlist.GET("/stats/:event_id", controller.GetWaitlistStats)     // Get stats
-		adminWaitlist.GET("/entries/:event_id", controller.GetWaitlistEntries) // List entries
+		adminWaitlist.GET("/health", controller.GetWaitlistHealth)                // Health across upcoming events
+		adminWaitlist.GET("/stats/:event_id", controller.GetWaitlistStats)        // Get stats
+		adminWaitlist.GET("/entries/:event_id", controller.GetWaitlistEntries)    // List entries
+		adminWaitlist.POST("/export/:event_id", controller.ExportWaitlistEntries) // Export entries as CSV
 
 		adminWaitlist.POST("/notify/:event_id", controller.NotifyNextInLine)          // Manual notify
 		adminWaitlist.POST("/cancellation/:event_id", controller.ProcessCancellation) // Process cancellation
@@ -2,22 +2,30 @@ package waitlist
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"log"
+	"math"
+	"strings"
+	"sync"
 	"time"
 
+	"evently/internal/jobs"
+	"evently/internal/outbox"
+	"evently/pkg/logger"
+	"evently/pkg/metrics"
+
 	"github.com/google/uuid"
 )
 
-type NotificationService interface {
-	SendWaitlistNotification(ctx context.Context, userID uuid.UUID, email, name string,
-		eventID, waitlistEntryID uuid.UUID, notificationType string,
-		templateData map[string]interface{}) error
-}
+var (
+	ErrAlreadyOnWaitlist = errors.New("user already in waitlist")
+	ErrWaitlistFull      = errors.New("waitlist is full")
+	ErrEntryNotFound     = errors.New("waitlist entry not found")
+	ErrEntryNotActive    = errors.New("cannot leave waitlist")
+)
 
-type UserService interface {
-	GetUserByID(ctx context.Context, userID uuid.UUID) (email, firstName, lastName string, err error)
-}
+// log is the logger of the waitlist module, see LOG_MODULE_LEVELS
+var log = logger.Module("waitlist")
 
 type Service interface {
 	// Core waitlist operations
@@ -26,8 +34,9 @@ type Service interface {
 	GetWaitlistStatus(ctx context.Context, userID, eventID uuid.UUID) (*WaitlistResponse, error)
 
 	// Event-triggered operations
-	ProcessCancellation(ctx context.Context, eventID uuid.UUID, freedTickets int) error
+	ProcessCancellation(ctx context.Context, eventID uuid.UUID, freedTickets int, freed []FreedSeat) error
 	ProcessBookingExpiry(ctx context.Context, userID, eventID uuid.UUID) error
+	CloseWaitlist(ctx context.Context, eventID uuid.UUID, reason string) (int, error)
 
 	// Notification operations
 	NotifyNextInLine(ctx context.Context, eventID uuid.UUID, availableTickets int) error
@@ -36,6 +45,7 @@ type Service interface {
 	// Admin operations
 	GetWaitlistStats(ctx context.Context, eventID uuid.UUID) (*WaitlistStatsResponse, error)
 	GetWaitlistEntries(ctx context.Context, eventID uuid.UUID, status WaitlistStatus) ([]WaitlistEntry, error)
+	GetWaitlistHealth(ctx context.Context) (*WaitlistHealthResponse, error)
 
 	// Background job operations
 	ProcessExpiredBookingWindows(ctx context.Context) (int, error)
@@ -43,14 +53,42 @@ type Service interface {
 
 	// Booking operations
 	MarkAsConverted(ctx context.Context, userID, eventID, bookingID uuid.UUID) error
+	RevertConversion(ctx context.Context, userID, eventID, bookingID uuid.UUID) error
 	GetWaitlistStatusForBooking(ctx context.Context, userID, eventID uuid.UUID) (*WaitlistStatusForBooking, error)
+
+	// Event details and booking links in notifications
+	SetEventLookup(lookup EventLookup)
+
+	// Escalation of unopened spot-available emails
+	SetEscalationSender(sender EscalationSender)
+	SetEscalationConfig(config *EscalationConfig)
+	RecordNotificationOpened(ctx context.Context, notificationID uuid.UUID) error
+	EscalateUnopenedNotifications(ctx context.Context) (int, error)
+
+	// Delivery outcomes reported by the notification consumer
+	RecordNotificationDelivery(ctx context.Context, entryID uuid.UUID, messageID string,
+		notificationType NotificationType, channel NotificationChannel, deliveryErr error) error
+
+	// CSV exports run on the job worker pool
+	SetJobService(jobService jobs.Service)
+	StartEntriesExport(ctx context.Context, eventID uuid.UUID, status WaitlistStatus, adminID uuid.UUID) (*jobs.JobResponse, error)
+	ExportEntries(ctx context.Context, job *jobs.Job, progress jobs.Progress) (*jobs.Result, error)
+
+	// Repairs drift between the Redis queues and Postgres entries
+	ReconcileQueues(ctx context.Context) (*ReconcileResult, error)
 }
 
 type service struct {
-	repo                Repository
-	notificationService NotificationService
-	userService         UserService
-	config              *ServiceConfig
+	repo             Repository
+	config           *ServiceConfig
+	escalationSender EscalationSender
+	escalationConfig *EscalationConfig
+	jobService       jobs.Service
+	eventLookup      EventLookup
+
+	// Queue members without an entry seen by the last reconciliation run
+	orphanMu       sync.Mutex
+	unknownMembers map[string]bool
 }
 
 type ServiceConfig struct {
@@ -58,6 +96,7 @@ type ServiceConfig struct {
 	MaxWaitlistSize       int
 	MaxQuantityPerUser    int
 	NotificationTimeout   time.Duration
+	BookingURL            string // Booking page linked from notifications, {event_id} and {entry_id} are replaced
 }
 
 func DefaultServiceConfig() *ServiceConfig {
@@ -66,19 +105,21 @@ func DefaultServiceConfig() *ServiceConfig {
 		MaxWaitlistSize:       MaxWaitlistSize,
 		MaxQuantityPerUser:    MaxQuantityPerUser,
 		NotificationTimeout:   5 * time.Second,
+		BookingURL:            DefaultBookingURL,
 	}
 }
 
-func NewService(repo Repository, notificationService NotificationService, userService UserService, config *ServiceConfig) Service {
+// Notifications are written to the transactional outbox and delivered by the
+// outbox relay, so the service has no direct dependency on the notification service.
+func NewService(repo Repository, config *ServiceConfig) Service {
 	if config == nil {
 		config = DefaultServiceConfig()
 	}
 
 	return &service{
-		repo:                repo,
-		notificationService: notificationService,
-		userService:         userService,
-		config:              config,
+		repo:             repo,
+		config:           config,
+		escalationConfig: DefaultEscalationConfig(),
 	}
 }
 
@@ -89,10 +130,17 @@ func (s *service) JoinWaitlist(ctx context.Context, userID uuid.UUID, request *J
 		return nil, fmt.Errorf("invalid join request: %w", err)
 	}
 
+	if err := s.validatePreferences(ctx, request.EventID, request.Preferences); err != nil {
+		return nil, fmt.Errorf("invalid join request: %w", err)
+	}
+	if request.Preferences.IsEmpty() {
+		request.Preferences = nil
+	}
+
 	// Check if user is already in waitlist
 	existingEntry, err := s.repo.GetEntry(ctx, userID, request.EventID)
 	if err == nil && existingEntry != nil {
-		return nil, fmt.Errorf("user already in waitlist for event %s", request.EventID)
+		return nil, fmt.Errorf("%w for event %s", ErrAlreadyOnWaitlist, request.EventID)
 	}
 
 	// Check waitlist capacity
@@ -102,7 +150,7 @@ func (s *service) JoinWaitlist(ctx context.Context, userID uuid.UUID, request *J
 	}
 
 	if queueLength >= s.config.MaxWaitlistSize {
-		return nil, fmt.Errorf("waitlist is full (max %d users)", s.config.MaxWaitlistSize)
+		return nil, fmt.Errorf("%w (max %d users)", ErrWaitlistFull, s.config.MaxWaitlistSize)
 	}
 
 	// Create waitlist entry
@@ -114,6 +162,9 @@ func (s *service) JoinWaitlist(ctx context.Context, userID uuid.UUID, request *J
 		Preferences: request.Preferences,
 		JoinedAt:    time.Now(),
 	}
+	if err := applyEscalationRequest(entry, request.Escalation); err != nil {
+		return nil, fmt.Errorf("invalid join request: %w", err)
+	}
 
 	// Add to Redis queue first to get position
 	err = s.repo.AddToQueue(ctx, entry)
@@ -129,7 +180,8 @@ func (s *service) JoinWaitlist(ctx context.Context, userID uuid.UUID, request *J
 		return nil, fmt.Errorf("failed to create waitlist entry: %w", err)
 	}
 
-	log.Printf("User %s joined waitlist for event %s at position %d", userID, request.EventID, entry.Position)
+	log.InfoContext(ctx, "User joined waitlist", "user_id", userID, "event_id", request.EventID, "position", entry.Position)
+	s.recordQueueLength(ctx, request.EventID)
 
 	// Return response
 	response := &WaitlistResponse{
@@ -140,6 +192,8 @@ func (s *service) JoinWaitlist(ctx context.Context, userID uuid.UUID, request *J
 		Status:      entry.Status,
 		Preferences: entry.Preferences,
 		JoinedAt:    entry.JoinedAt,
+
+		EscalationChannels: entry.EscalationChannels(),
 	}
 
 	// Estimate wait time (this is a simple heuristic)
@@ -155,11 +209,11 @@ func (s *service) LeaveWaitlist(ctx context.Context, userID, eventID uuid.UUID)
 	// Get existing entry
 	entry, err := s.repo.GetEntry(ctx, userID, eventID)
 	if err != nil {
-		return fmt.Errorf("waitlist entry not found: %w", err)
+		return fmt.Errorf("%w: %w", ErrEntryNotFound, err)
 	}
 
 	if entry.Status != WaitlistStatusActive {
-		return fmt.Errorf("cannot leave waitlist in status %s", entry.Status)
+		return fmt.Errorf("%w in status %s", ErrEntryNotActive, entry.Status)
 	}
 
 	// Remove from Redis queue
@@ -175,12 +229,13 @@ func (s *service) LeaveWaitlist(ctx context.Context, userID, eventID uuid.UUID)
 		return fmt.Errorf("failed to update entry status: %w", err)
 	}
 
-	log.Printf("User %s left waitlist for event %s", userID, eventID)
+	log.InfoContext(ctx, "User left waitlist", "user_id", userID, "event_id", eventID)
+	s.recordQueueLength(ctx, eventID)
 
 	// Update positions for remaining users
 	go func() {
 		if err := s.repo.UpdatePositions(context.Background(), eventID); err != nil {
-			log.Printf("Failed to update positions after user left: %v", err)
+			log.WarnContext(ctx, "Failed to update positions after user left", "event_id", eventID, "error", err)
 		}
 	}()
 
@@ -191,7 +246,7 @@ func (s *service) GetWaitlistStatus(ctx context.Context, userID, eventID uuid.UU
 	// Get entry from database
 	entry, err := s.repo.GetEntry(ctx, userID, eventID)
 	if err != nil {
-		return nil, fmt.Errorf("waitlist entry not found: %w", err)
+		return nil, fmt.Errorf("%w: %w", ErrEntryNotFound, err)
 	}
 
 	// Get current position from Redis if active
@@ -217,6 +272,15 @@ func (s *service) GetWaitlistStatus(ctx context.Context, userID, eventID uuid.UU
 		JoinedAt:    entry.JoinedAt,
 		NotifiedAt:  entry.NotifiedAt,
 		ExpiresAt:   entry.ExpiresAt,
+
+		EscalationChannels: entry.EscalationChannels(),
+	}
+
+	// Checking status after being notified means the user saw it, so there is nothing to escalate
+	if entry.Status == WaitlistStatusNotified {
+		if err := s.repo.MarkEntryNotificationsOpened(ctx, entry.ID); err != nil {
+			log.WarnContext(ctx, "Failed to mark notifications opened", "entry_id", entry.ID, "error", err)
+		}
 	}
 
 	// Calculate time remaining if notified
@@ -230,32 +294,60 @@ func (s *service) GetWaitlistStatus(ctx context.Context, userID, eventID uuid.UU
 	return response, nil
 }
 
-// ProcessCancellation handles event cancellations that free up tickets
-func (s *service) ProcessCancellation(ctx context.Context, eventID uuid.UUID, freedTickets int) error {
-	log.Printf("🎫 WAITLIST: Processing cancellation for event %s, freed tickets: %d", eventID, freedTickets)
+// ProcessCancellation offers freed tickets to the next users in line. When the
+// freed seats are known, users whose seat preferences they can't satisfy are
+// passed over and keep their place; with no seats, preferences aren't checked.
+func (s *service) ProcessCancellation(ctx context.Context, eventID uuid.UUID, freedTickets int, freed []FreedSeat) error {
+	log.InfoContext(ctx, "Processing freed tickets for waitlist", "event_id", eventID, "freed_tickets", freedTickets)
+
+	// Look further down the queue when users may be passed over for their preferences
+	scanCount := freedTickets
+	if len(freed) > 0 {
+		scanCount += PreferenceScanDepth
+
+		var err error
+		freed, err = s.repo.ResolveSeatPositions(ctx, freed)
+		if err != nil {
+			log.WarnContext(ctx, "Failed to resolve freed seat positions", "event_id", eventID, "error", err)
+		}
+	}
 
 	// Get next users in queue
-	nextInQueue, err := s.repo.GetNextInQueue(ctx, eventID, freedTickets)
+	nextInQueue, err := s.repo.GetNextInQueue(ctx, eventID, scanCount)
 	if err != nil {
-		log.Printf("❌ WAITLIST ERROR: Failed to get next in queue for event %s: %v", eventID, err)
+		log.ErrorContext(ctx, "Failed to get next in queue", "event_id", eventID, "error", err)
 		return fmt.Errorf("failed to get next in queue: %w", err)
 	}
 
 	if len(nextInQueue) == 0 {
-		log.Printf("📭 WAITLIST: No users in waitlist for event %s - no notifications sent", eventID)
+		log.InfoContext(ctx, "No users in waitlist, no notifications sent", "event_id", eventID)
 		return nil
 	}
 
-	log.Printf("👥 WAITLIST: Found %d users in queue for event %s, will notify up to %d users",
-		len(nextInQueue), eventID, freedTickets)
+	log.InfoContext(ctx, "Found users in waitlist queue", "event_id", eventID, "users", len(nextInQueue), "notify_up_to", freedTickets)
 
 	// Notify users and update their status
+	details := s.lookupEvent(ctx, eventID)
 	var notifiedUsers []uuid.UUID
-	for i, entry := range nextInQueue {
-		if i >= freedTickets {
+	skipped := 0
+	for _, entry := range nextInQueue {
+		if len(notifiedUsers) >= freedTickets {
 			break // Don't notify more users than available tickets
 		}
 
+		// Seats matched to one user's preferences aren't offered to the next user with preferences
+		if len(freed) > 0 && !entry.Preferences.IsEmpty() {
+			matched := entry.Preferences.match(freed, entry.Quantity)
+			if matched == nil {
+				log.DebugContext(ctx, "Skipping user, freed seats don't match preferences",
+					"user_id", entry.UserID, "position", entry.Position, "event_id", eventID)
+				metrics.WaitlistPreferenceSkipsTotal.Inc(eventID.String())
+				skipped++
+				continue
+			}
+			freed = withoutSeats(freed, matched)
+		}
+
 		// Update entry status to notified
 		entry.Status = WaitlistStatusNotified
 		entry.NotifiedAt = &time.Time{}
@@ -263,33 +355,27 @@ func (s *service) ProcessCancellation(ctx context.Context, eventID uuid.UUID, fr
 		expiresAt := time.Now().Add(s.config.BookingWindowDuration)
 		entry.ExpiresAt = &expiresAt
 
-		err = s.repo.UpdateEntry(ctx, &entry)
-		if err != nil {
-			log.Printf("Failed to update entry %s: %v", entry.ID, err)
-			continue
-		}
-
-		// Send notification
-		log.Printf("📧 SENDING: Notification to user %s (position %d) for event %s - expires at %s",
-			entry.UserID, entry.Position, eventID, expiresAt.Format("15:04:05"))
+		// Status update and notification are committed together via the outbox
+		log.DebugContext(ctx, "Queueing spot-available notification", "user_id", entry.UserID,
+			"position", entry.Position, "event_id", eventID, "expires_at", expiresAt)
 
-		err = s.sendSpotAvailableNotification(ctx, &entry)
+		err = s.queueSpotAvailableNotification(ctx, &entry, details)
 		if err != nil {
-			log.Printf("❌ NOTIFICATION FAILED: User %s for event %s - Error: %v", entry.UserID, eventID, err)
-		} else {
-			log.Printf("✅ NOTIFICATION SENT: User %s for event %s via Kafka", entry.UserID, eventID)
+			log.ErrorContext(ctx, "Failed to queue spot-available notification", "user_id", entry.UserID, "event_id", eventID, "error", err)
+			continue
 		}
+		log.DebugContext(ctx, "Spot-available notification queued", "user_id", entry.UserID, "event_id", eventID)
 
 		notifiedUsers = append(notifiedUsers, entry.UserID)
 	}
 
-	log.Printf("🎉 WAITLIST COMPLETE: Notified %d users from waitlist for event %s", len(notifiedUsers), eventID)
+	log.InfoContext(ctx, "Notified users from waitlist", "event_id", eventID, "notified", len(notifiedUsers), "skipped", skipped)
 
 	return nil
 }
 
 func (s *service) NotifyNextInLine(ctx context.Context, eventID uuid.UUID, availableTickets int) error {
-	return s.ProcessCancellation(ctx, eventID, availableTickets)
+	return s.ProcessCancellation(ctx, eventID, availableTickets, nil)
 }
 
 func (s *service) ProcessBookingExpiry(ctx context.Context, userID, eventID uuid.UUID) error {
@@ -303,7 +389,11 @@ func (s *service) ProcessBookingExpiry(ctx context.Context, userID, eventID uuid
 	}
 
 	// Update status to expired
+	withoutAction := s.missedWithoutAction(ctx, entry)
 	entry.Status = WaitlistStatusExpired
+	if withoutAction {
+		entry.ExpiredWithoutAction++
+	}
 	err = s.repo.UpdateEntry(ctx, entry)
 	if err != nil {
 		return fmt.Errorf("failed to update entry: %w", err)
@@ -312,79 +402,67 @@ func (s *service) ProcessBookingExpiry(ctx context.Context, userID, eventID uuid
 	// Remove from Redis queue
 	err = s.repo.RemoveFromQueue(ctx, userID, eventID)
 	if err != nil {
-		log.Printf("Failed to remove expired user from queue: %v", err)
+		log.WarnContext(ctx, "Failed to remove expired user from queue", "user_id", userID, "event_id", eventID, "error", err)
 	}
 
-	log.Printf("Booking window expired for user %s, event %s", userID, eventID)
+	log.InfoContext(ctx, "Booking window expired", "user_id", userID, "event_id", eventID)
+	if withoutAction {
+		metrics.WaitlistExpiredWithoutActionTotal.Inc(eventID.String())
+	}
+	s.recordQueueLength(ctx, eventID)
 
 	// Notify next user in line
 	go func() {
 		if err := s.NotifyNextInLine(context.Background(), eventID, entry.Quantity); err != nil {
-			log.Printf("Failed to notify next in line: %v", err)
+			log.WarnContext(ctx, "Failed to notify next in line", "event_id", eventID, "error", err)
 		}
 	}()
 
 	return nil
 }
 
-func (s *service) sendSpotAvailableNotification(ctx context.Context, entry *WaitlistEntry) error {
-	// Get real user details from user service
-	userEmail, firstName, lastName, err := s.userService.GetUserByID(ctx, entry.UserID)
-	if err != nil {
-		log.Printf("❌ USER FETCH ERROR: Failed to get user details for %s: %v", entry.UserID, err)
-		return fmt.Errorf("failed to get user details: %w", err)
-	}
+// queueSpotAvailableNotification marks the entry notified and writes the
+// notification to the outbox in the same transaction
+func (s *service) queueSpotAvailableNotification(ctx context.Context, entry *WaitlistEntry, details *EventDetails) error {
+	// The record ID is assigned up front so the email can carry its open-tracking pixel
+	notificationID := uuid.New()
 
-	userName := firstName
-	if lastName != "" {
-		userName = firstName + " " + lastName
-	}
-	if userName == "" {
-		userName = "User" // Fallback if no name is available
-	}
-
-	// Prepare template data
 	templateData := map[string]interface{}{
 		"event_id":       entry.EventID.String(),
 		"position":       entry.Position,
 		"quantity":       entry.Quantity,
 		"expires_at":     entry.ExpiresAt,
-		"event_title":    "Event Title", // TODO: Fetch from event service
-		"venue_name":     "Venue Name",  // TODO: Fetch from venue service
 		"booking_window": s.config.BookingWindowDuration.Minutes(),
 	}
+	s.eventTemplateData(templateData, entry, details)
+	if s.escalationConfig != nil && s.escalationConfig.OpenTrackingURL != "" {
+		templateData["open_tracking_url"] = fmt.Sprintf("%s/%s/open",
+			strings.TrimSuffix(s.escalationConfig.OpenTrackingURL, "/"), notificationID)
+	}
 
-	// Send via unified notification service
-	log.Printf("� UNIFIED: Sending spot available notification to user %s for event %s", entry.UserID, entry.EventID)
-	notificationErr := s.notificationService.SendWaitlistNotification(ctx,
-		entry.UserID,
-		userEmail,
-		userName,
-		entry.EventID,
-		entry.ID,
-		"WAITLIST_SPOT_AVAILABLE", // Notification type string
-		templateData,
-	)
-	if notificationErr != nil {
-		log.Printf("❌ NOTIFICATION FAILED: Could not send notification for user %s: %v", entry.UserID, notificationErr)
-		return fmt.Errorf("failed to send notification: %w", notificationErr)
-	}
-	log.Printf("✅ NOTIFICATION SUCCESS: Spot available notification sent for user %s", entry.UserID)
-
-	// Create notification record
+	// An entry can be notified again after being re-queued, so the dedup key
+	// includes the notification time
+	dedupKey := fmt.Sprintf("waitlist:%s:spot_available:%d", entry.ID, entry.NotifiedAt.Unix())
+	message, err := s.buildOutboxMessage(entry, "WAITLIST_SPOT_AVAILABLE", dedupKey, templateData)
+	if err != nil {
+		return err
+	}
+
+	messageID := message.ID.String()
 	notificationRecord := &WaitlistNotification{
+		ID:               notificationID,
 		WaitlistEntryID:  entry.ID,
 		NotificationType: NotificationTypeSpotAvailable,
 		Channel:          NotificationChannelEmail,
 		Status:           NotificationStatusPending,
+		MessageID:        &messageID,
 	}
 
-	err = s.repo.CreateNotification(ctx, notificationRecord)
-	if err != nil {
-		log.Printf("⚠️ DB WARNING: Failed to create notification record for user %s: %v", entry.UserID, err)
-	} else {
-		log.Printf("💾 DB SUCCESS: Notification record created for user %s", entry.UserID)
+	if err := s.repo.NotifyEntry(ctx, entry, notificationRecord, message); err != nil {
+		return fmt.Errorf("failed to queue notification: %w", err)
 	}
+	metrics.OnSaleActivity.Inc(entry.EventID.String(), metrics.OnSaleQueueAdmission)
+	metrics.WaitlistNotificationsTotal.Inc(entry.EventID.String())
 
 	return nil
 }
@@ -399,50 +477,98 @@ func (s *service) NotifyPositionUpdate(ctx context.Context, eventID uuid.UUID) e
 		return nil
 	}
 
-	log.Printf("📊 POSITION UPDATE: Sending position updates to %d users for event %s", len(entries), eventID)
+	log.DebugContext(ctx, "Queueing position updates", "event_id", eventID, "users", len(entries))
 
-	// Send individual notifications via unified service
-	for _, entry := range entries {
-		// Get real user details from user service
-		userEmail, firstName, lastName, err := s.userService.GetUserByID(ctx, entry.UserID)
-		if err != nil {
-			log.Printf("❌ USER FETCH ERROR: Failed to get user details for %s: %v", entry.UserID, err)
-			continue // Skip this notification but continue with others
+	details := s.lookupEvent(ctx, eventID)
+	messages := make([]*outbox.Message, 0, len(entries))
+	for i := range entries {
+		entry := &entries[i]
+
+		templateData := map[string]interface{}{
+			"event_id": entry.EventID.String(),
+			"position": entry.Position,
+			"quantity": entry.Quantity,
 		}
+		s.eventTemplateData(templateData, entry, details)
 
-		userName := firstName
-		if lastName != "" {
-			userName = firstName + " " + lastName
+		// One update per position, repeated calls don't re-send the same position
+		dedupKey := fmt.Sprintf("waitlist:%s:position:%d", entry.ID, entry.Position)
+		message, err := s.buildOutboxMessage(entry, "WAITLIST_POSITION_UPDATE", dedupKey, templateData)
+		if err != nil {
+			log.ErrorContext(ctx, "Failed to queue position update", "user_id", entry.UserID, "error", err)
+			continue
 		}
-		if userName == "" {
-			userName = "User" // Fallback if no name is available
+		messages = append(messages, message)
+	}
+
+	if err := s.repo.EnqueueNotifications(ctx, messages); err != nil {
+		return fmt.Errorf("failed to queue position updates: %w", err)
+	}
+
+	log.DebugContext(ctx, "Queued position updates", "event_id", eventID)
+	return nil
+}
+
+// CloseWaitlist cancels the waiting and notified entries of a cancelled event and
+// tells those users the event is off. Returns the number of entries closed.
+func (s *service) CloseWaitlist(ctx context.Context, eventID uuid.UUID, reason string) (int, error) {
+	var entries []WaitlistEntry
+	for _, status := range []WaitlistStatus{WaitlistStatusActive, WaitlistStatusNotified} {
+		statusEntries, err := s.repo.ListEntries(ctx, eventID, status)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get waitlist entries: %w", err)
 		}
+		entries = append(entries, statusEntries...)
+	}
+
+	if len(entries) == 0 {
+		return 0, nil
+	}
 
+	ids := make([]uuid.UUID, 0, len(entries))
+	messages := make([]*outbox.Message, 0, len(entries))
+	for i := range entries {
+		entry := &entries[i]
 		templateData := map[string]interface{}{
-			"event_id":    entry.EventID.String(),
-			"position":    entry.Position,
-			"quantity":    entry.Quantity,
-			"event_title": "Event Title", // TODO: Fetch from event service
-			"venue_name":  "Venue Name",  // TODO: Fetch from venue service
+			"event_id":       entry.EventID.String(),
+			"recipient_kind": "waitlist",
+			"reason":         reason,
 		}
 
-		notificationErr := s.notificationService.SendWaitlistNotification(ctx,
-			entry.UserID,
-			userEmail,
-			userName,
-			entry.EventID,
-			entry.ID,
-			"WAITLIST_POSITION_UPDATE", // Notification type string
-			templateData,
-		)
-		if notificationErr != nil {
-			log.Printf("❌ Position update failed for user %s: %v", entry.UserID, notificationErr)
-			continue // Continue with other notifications even if one fails
+		dedupKey := fmt.Sprintf("waitlist:%s:event_cancelled", entry.ID)
+		message, err := s.buildOutboxMessage(entry, "EVENT_CANCELLED", dedupKey, templateData)
+		if err != nil {
+			return 0, err
 		}
+		ids = append(ids, entry.ID)
+		messages = append(messages, message)
 	}
 
-	log.Printf("✅ POSITION UPDATE: Completed sending position updates for event %s", eventID)
-	return nil
+	if err := s.repo.CloseEntries(ctx, eventID, ids, messages); err != nil {
+		return 0, fmt.Errorf("failed to close waitlist: %w", err)
+	}
+
+	log.InfoContext(ctx, "Closed waitlist for cancelled event", "event_id", eventID, "entries", len(entries))
+	s.recordQueueLength(ctx, eventID)
+	return len(entries), nil
+}
+
+// buildOutboxMessage creates an outbox message for a waitlist notification.
+// Recipient details are resolved by the outbox relay at delivery time.
+func (s *service) buildOutboxMessage(entry *WaitlistEntry, notificationType, dedupKey string,
+	templateData map[string]interface{}) (*outbox.Message, error) {
+
+	eventID := entry.EventID
+	entryID := entry.ID
+	payload := &outbox.NotificationPayload{
+		Type:            notificationType,
+		RecipientID:     entry.UserID,
+		EventID:         &eventID,
+		WaitlistEntryID: &entryID,
+		TemplateData:    templateData,
+	}
+
+	return outbox.NewNotificationMessage(outbox.AggregateWaitlistEntry, entry.ID, dedupKey, payload)
 }
 
 // GetWaitlistStats gets statistics for a waitlist
@@ -472,14 +598,20 @@ func (s *service) ProcessExpiredBookingWindows(ctx context.Context) (int, error)
 
 	for _, entry := range expiredEntries {
 		// Requeue the user at the end of the waitlist
-		err := s.repo.RequeueExpiredUser(ctx, entry.UserID, entry.EventID)
+		withoutAction := s.missedWithoutAction(ctx, &entry)
+		err := s.repo.RequeueExpiredUser(ctx, entry.UserID, entry.EventID, withoutAction)
 		if err != nil {
-			log.Printf("Failed to requeue expired user %s for event %s: %v", entry.UserID, entry.EventID, err)
+			log.ErrorContext(ctx, "Failed to requeue expired user", "user_id", entry.UserID, "event_id", entry.EventID, "error", err)
 			continue
 		}
 
-		log.Printf("🔄 RE-QUEUED: User %s moved back to end of waitlist for event %s (missed booking window)",
-			entry.UserID, entry.EventID)
+		metrics.WaitlistRequeuesTotal.Inc(entry.EventID.String())
+		if withoutAction {
+			metrics.WaitlistExpiredWithoutActionTotal.Inc(entry.EventID.String())
+		}
+
+		log.InfoContext(ctx, "User missed booking window, moved back to end of waitlist",
+			"user_id", entry.UserID, "event_id", entry.EventID)
 
 		requeuedUsers = append(requeuedUsers, entry.UserID)
 		eventTickets[entry.EventID] += entry.Quantity
@@ -487,14 +619,15 @@ func (s *service) ProcessExpiredBookingWindows(ctx context.Context) (int, error)
 
 	// Notify next users for each event (the tickets are still available)
 	for eventID, freedTickets := range eventTickets {
+		s.recordQueueLength(ctx, eventID)
 		go func(eID uuid.UUID, tickets int) {
 			if err := s.NotifyNextInLine(context.Background(), eID, tickets); err != nil {
-				log.Printf("Failed to notify next in line for event %s: %v", eID, err)
+				log.WarnContext(ctx, "Failed to notify next in line", "event_id", eID, "error", err)
 			}
 		}(eventID, freedTickets)
 	}
 
-	log.Printf("✅ REQUEUE COMPLETE: Re-queued %d expired users instead of removing them", len(requeuedUsers))
+	log.InfoContext(ctx, "Re-queued expired users", "users", len(requeuedUsers))
 	return len(expiredEntries), nil
 }
 
@@ -502,7 +635,7 @@ func (s *service) ProcessExpiredBookingWindows(ctx context.Context) (int, error)
 func (s *service) UpdateDailyAnalytics(ctx context.Context) error {
 	// This would typically query aggregated data and update analytics tables
 	// For now, we'll just log that the function was called
-	log.Println("Updating daily waitlist analytics...")
+	log.DebugContext(ctx, "Updating daily waitlist analytics")
 
 	// TODO: Implement actual analytics aggregation
 	// This would involve:
@@ -513,6 +646,80 @@ func (s *service) UpdateDailyAnalytics(ctx context.Context) error {
 	return nil
 }
 
+// missedWithoutAction reports whether a notified user let their window expire
+// without opening the notification. Unknown counts as opened.
+func (s *service) missedWithoutAction(ctx context.Context, entry *WaitlistEntry) bool {
+	if entry.NotifiedAt == nil {
+		return false
+	}
+	opened, err := s.repo.NotificationOpenedSince(ctx, entry.ID, *entry.NotifiedAt)
+	if err != nil {
+		log.WarnContext(ctx, "Failed to check notification opens", "entry_id", entry.ID, "error", err)
+		return false
+	}
+	return !opened
+}
+
+// GetWaitlistHealth summarizes queue depth, conversions and missed windows across upcoming events
+func (s *service) GetWaitlistHealth(ctx context.Context) (*WaitlistHealthResponse, error) {
+	events, err := s.repo.GetWaitlistHealth(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	health := &WaitlistHealthResponse{ByEvent: events, Events: len(events), GeneratedAt: time.Now()}
+	var windowUsed float64
+	var windowConversions int
+
+	for i := range events {
+		event := &events[i]
+		event.ConversionRate = conversionRate(event.Conversions, event.NotificationsSent)
+
+		health.QueueDepth += event.QueueDepth
+		health.AwaitingBooking += event.AwaitingBooking
+		health.NotificationsSent += event.NotificationsSent
+		health.Conversions += event.Conversions
+		health.Requeues += event.Requeues
+		health.ExpiredWithoutAction += event.ExpiredWithoutAction
+
+		// Weighted by conversions so busy events count for more
+		if event.AvgBookingWindowUsed != nil {
+			windowUsed += *event.AvgBookingWindowUsed * float64(event.Conversions)
+			windowConversions += event.Conversions
+		}
+	}
+
+	health.ConversionRate = conversionRate(health.Conversions, health.NotificationsSent)
+	if windowConversions > 0 {
+		average := windowUsed / float64(windowConversions)
+		health.AvgBookingWindowUsed = &average
+	}
+	return health, nil
+}
+
+// conversionRate returns conversions per notification, 0 before any were sent
+func conversionRate(conversions, notifications int) float64 {
+	if notifications == 0 {
+		return 0
+	}
+	return float64(conversions) / float64(notifications)
+}
+
+// recordQueueLength publishes the event's current queue length, dropping the series once it drains
+func (s *service) recordQueueLength(ctx context.Context, eventID uuid.UUID) {
+	length, err := s.repo.GetQueueLength(ctx, eventID)
+	if err != nil {
+		log.WarnContext(ctx, "Failed to read queue length", "event_id", eventID, "error", err)
+		return
+	}
+
+	if length == 0 {
+		metrics.WaitlistQueueLength.Delete(eventID.String())
+		return
+	}
+	metrics.WaitlistQueueLength.Set(float64(length), eventID.String())
+}
+
 // validateJoinRequest validates a join waitlist request
 func (s *service) validateJoinRequest(request *JoinWaitlistRequest) error {
 	if request.EventID == uuid.Nil {
@@ -530,49 +737,104 @@ func (s *service) validateJoinRequest(request *JoinWaitlistRequest) error {
 	return nil
 }
 
+// validatePreferences checks the preferences are well formed and name sections of the event
+func (s *service) validatePreferences(ctx context.Context, eventID uuid.UUID, preferences *SeatPreferences) error {
+	if preferences == nil {
+		return nil
+	}
+	if err := preferences.validate(); err != nil {
+		return err
+	}
+	if len(preferences.PreferredSections) == 0 {
+		return nil
+	}
+
+	found, err := s.repo.CountEventSections(ctx, eventID, preferences.PreferredSections)
+	if err != nil {
+		return fmt.Errorf("failed to check preferred sections: %w", err)
+	}
+	if found != len(preferences.PreferredSections) {
+		return fmt.Errorf("preferred sections must belong to the event's venue")
+	}
+	return nil
+}
+
 // MarkAsConverted marks a waitlist entry as converted after successful booking
 func (s *service) MarkAsConverted(ctx context.Context, userID, eventID, bookingID uuid.UUID) error {
-	log.Printf("🔄 MARK AS CONVERTED: Starting conversion for user %s, event %s, booking %s", userID, eventID, bookingID)
+	log.DebugContext(ctx, "Converting waitlist entry", "user_id", userID, "event_id", eventID, "booking_id", bookingID)
 
 	// Get the waitlist entry
 	entry, err := s.repo.GetEntry(ctx, userID, eventID)
 	if err != nil {
 		// No waitlist entry found - user wasn't on waitlist, which is fine
-		log.Printf("ℹ️  MARK AS CONVERTED: No waitlist entry found for user %s, event %s (user wasn't on waitlist)", userID, eventID)
+		log.DebugContext(ctx, "User wasn't on waitlist, nothing to convert", "user_id", userID, "event_id", eventID)
 		return nil
 	}
 
-	log.Printf("📊 MARK AS CONVERTED: Found waitlist entry with status %s for user %s, event %s", entry.Status, userID, eventID)
+	log.DebugContext(ctx, "Found waitlist entry to convert", "status", entry.Status, "user_id", userID, "event_id", eventID)
 
 	// Only update if user was notified (allowing conversion)
 	if entry.Status != WaitlistStatusNotified {
 		// User wasn't in notified status, no need to update
-		log.Printf("⚠️  MARK AS CONVERTED: User %s is in status %s, not NOTIFIED - skipping conversion", userID, entry.Status)
+		log.WarnContext(ctx, "Waitlist entry is not NOTIFIED, skipping conversion", "user_id", userID, "status", entry.Status)
 		return nil
 	}
 
-	log.Printf("📝 MARK AS CONVERTED: Updating database status to CONVERTED for user %s", userID)
-	// Update status to converted
+	// Update status to converted, noting how much of the booking window it took
+	now := time.Now()
 	entry.Status = WaitlistStatusConverted
+	entry.ConvertedAt = &now
+	if entry.NotifiedAt != nil && entry.ExpiresAt != nil && entry.ExpiresAt.After(*entry.NotifiedAt) {
+		used := now.Sub(*entry.NotifiedAt).Seconds() / entry.ExpiresAt.Sub(*entry.NotifiedAt).Seconds()
+		used = math.Max(0, math.Min(1, used))
+		entry.BookingWindowUsed = &used
+	}
 	err = s.repo.UpdateEntry(ctx, entry)
 	if err != nil {
-		log.Printf("❌ MARK AS CONVERTED: Database update failed for user %s: %v", userID, err)
+		log.ErrorContext(ctx, "Failed to mark waitlist entry converted", "user_id", userID, "error", err)
 		return fmt.Errorf("failed to mark waitlist entry as converted: %w", err)
 	}
-	log.Printf("✅ MARK AS CONVERTED: Database status updated to CONVERTED for user %s", userID)
+	metrics.WaitlistConversionsTotal.Inc(eventID.String())
+	if entry.BookingWindowUsed != nil {
+		metrics.WaitlistBookingWindowUsed.Observe(*entry.BookingWindowUsed)
+	}
 
-	log.Printf("🗑️  MARK AS CONVERTED: Removing user %s from Redis queue for event %s", userID, eventID)
 	// Remove from Redis queue since they've successfully booked
 	err = s.repo.RemoveFromQueue(ctx, userID, eventID)
 	if err != nil {
-		log.Printf("❌ MARK AS CONVERTED: Failed to remove user %s from Redis queue: %v", userID, err)
+		log.WarnContext(ctx, "Failed to remove converted user from queue", "user_id", userID, "event_id", eventID, "error", err)
 	} else {
-		log.Printf("✅ MARK AS CONVERTED: Successfully removed user %s from Redis queue", userID)
+		s.recordQueueLength(ctx, eventID)
+	}
+
+	log.InfoContext(ctx, "User booked from waitlist", "user_id", userID, "event_id", eventID, "booking_id", bookingID)
+
+	return nil
+}
+
+// RevertConversion undoes MarkAsConverted when the booking that converted the entry
+// is rolled back, handing the user their notification and booking window back
+func (s *service) RevertConversion(ctx context.Context, userID, eventID, bookingID uuid.UUID) error {
+	entry, err := s.repo.GetEntry(ctx, userID, eventID)
+	if err != nil {
+		// No waitlist entry - nothing was converted
+		return nil
+	}
+
+	if entry.Status != WaitlistStatusConverted {
+		return nil
+	}
+
+	if err := s.repo.RevertConvertedEntry(ctx, entry); err != nil {
+		return err
 	}
 
-	log.Printf("✅ WAITLIST CONVERTED: User %s successfully booked from waitlist for event %s (booking %s)",
-		userID, eventID, bookingID)
+	if err := s.repo.RestoreQueuePosition(ctx, entry); err != nil {
+		return err
+	}
+	s.recordQueueLength(ctx, eventID)
 
+	log.InfoContext(ctx, "Reverted waitlist conversion", "user_id", userID, "event_id", eventID, "booking_id", bookingID)
 	return nil
 }
 
@@ -0,0 +1,50 @@
+package waitlist
+
+import (
+	"fmt"
+	"time"
+
+	"evently/internal/webhooks"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// WaitlistWebhookData is the data of waitlist.notified webhook events, sent
+// when an entry is offered a spot and its booking window opens
+type WaitlistWebhookData struct {
+	WaitlistEntryID uuid.UUID  `json:"waitlist_entry_id"`
+	EventID         uuid.UUID  `json:"event_id"`
+	UserID          uuid.UUID  `json:"user_id"`
+	Position        int        `json:"position"`
+	Quantity        int        `json:"quantity"`
+	NotifiedAt      *time.Time `json:"notified_at,omitempty"`
+	ExpiresAt       *time.Time `json:"expires_at,omitempty"` // End of the booking window
+}
+
+// enqueueNotifiedWebhook records a waitlist.notified event in the caller's
+// transaction. Entries can be notified again after being re-queued, so the
+// dedup key includes the notification time.
+func enqueueNotifiedWebhook(tx *gorm.DB, entry *WaitlistEntry) error {
+	notifiedAt := time.Now()
+	if entry.NotifiedAt != nil {
+		notifiedAt = *entry.NotifiedAt
+	}
+
+	data := WaitlistWebhookData{
+		WaitlistEntryID: entry.ID,
+		EventID:         entry.EventID,
+		UserID:          entry.UserID,
+		Position:        entry.Position,
+		Quantity:        entry.Quantity,
+		NotifiedAt:      entry.NotifiedAt,
+		ExpiresAt:       entry.ExpiresAt,
+	}
+
+	event, err := webhooks.NewEvent(webhooks.EventWaitlistNotified,
+		fmt.Sprintf("waitlist:%s:notified:%d", entry.ID, notifiedAt.Unix()), data)
+	if err != nil {
+		return err
+	}
+	return webhooks.Enqueue(tx, event)
+}
@@ -0,0 +1,367 @@
+package webhooks
+
+import (
+	"errors"
+	"net/http"
+
+	"evently/internal/shared/utils/response"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type Controller struct {
+	service Service
+}
+
+func NewController(service Service) *Controller {
+	return &Controller{service: service}
+}
+
+// CreateEndpoint registers an endpoint. The signing secret is only returned in this response.
+//
+// @Summary      Register webhook endpoint (Admin)
+// @Description  Registers a partner HTTPS URL for the given event types. The signing secret is only returned in this response. Every delivery is a JSON POST carrying X-Evently-Event, X-Evently-Event-ID, X-Evently-Delivery and X-Evently-Signature headers. The signature is 't=<unix seconds>,v1=<hex HMAC-SHA256 of '<t>.<body>' keyed with the secret>'. Any 2xx answer acknowledges a delivery; anything else is retried with exponential backoff (8 attempts by default).
+// @Description
+// @Description  Requires the `system:manage` permission.
+// @Tags         Admin Webhooks
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        request body CreateEndpointRequest true "Request body"
+// @Success      201 {object} response.StandardApiResponse{data=EndpointSecretResponse} "Webhook endpoint created successfully"
+// @Failure      400 {object} response.StandardApiResponse "Invalid URL or event types"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse
+// @Failure      409 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/webhooks/endpoints [post]
+func (ctrl *Controller) CreateEndpoint(c *gin.Context) {
+	adminID, ok := ctrl.adminID(c)
+	if !ok {
+		return
+	}
+
+	var req CreateEndpointRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid request body", nil, err.Error())
+		return
+	}
+
+	created, err := ctrl.service.CreateEndpoint(c.Request.Context(), adminID, req)
+	if err != nil {
+		ctrl.respondError(c, err, "Failed to create webhook endpoint")
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusCreated, "Webhook endpoint created successfully. Store the secret now; it will not be shown again", created, nil)
+}
+
+// ListEndpoints godoc
+//
+// @Summary      List webhook endpoints (Admin)
+// @Description  Requires the `system:manage` permission.
+// @Tags         Admin Webhooks
+// @Produce      json
+// @Security     Bearer
+// @Success      200 {object} response.StandardApiResponse{data=[]Endpoint} "Webhook endpoints retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse
+// @Failure      409 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/webhooks/endpoints [get]
+func (ctrl *Controller) ListEndpoints(c *gin.Context) {
+	endpoints, err := ctrl.service.ListEndpoints(c.Request.Context())
+	if err != nil {
+		ctrl.respondError(c, err, "Failed to retrieve webhook endpoints")
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Webhook endpoints retrieved successfully", endpoints, nil)
+}
+
+// GetEndpoint godoc
+//
+// @Summary      Get webhook endpoint (Admin)
+// @Description  Requires the `system:manage` permission.
+// @Tags         Admin Webhooks
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=Endpoint} "Webhook endpoint retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Webhook endpoint not found"
+// @Failure      409 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/webhooks/endpoints/{id} [get]
+func (ctrl *Controller) GetEndpoint(c *gin.Context) {
+	id, ok := ctrl.pathID(c, "Invalid webhook endpoint ID")
+	if !ok {
+		return
+	}
+
+	endpoint, err := ctrl.service.GetEndpoint(c.Request.Context(), id)
+	if err != nil {
+		ctrl.respondError(c, err, "Failed to retrieve webhook endpoint")
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Webhook endpoint retrieved successfully", endpoint, nil)
+}
+
+// UpdateEndpoint godoc
+//
+// @Summary      Update webhook endpoint (Admin)
+// @Description  Deactivated endpoints keep their pending deliveries, which are sent once the endpoint is reactivated.
+// @Description
+// @Description  Requires the `system:manage` permission.
+// @Tags         Admin Webhooks
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "ID" Format(uuid)
+// @Param        request body UpdateEndpointRequest true "Request body"
+// @Success      200 {object} response.StandardApiResponse{data=Endpoint} "Webhook endpoint updated successfully"
+// @Failure      400 {object} response.StandardApiResponse "Invalid URL or event types"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Webhook endpoint not found"
+// @Failure      409 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/webhooks/endpoints/{id} [put]
+func (ctrl *Controller) UpdateEndpoint(c *gin.Context) {
+	id, ok := ctrl.pathID(c, "Invalid webhook endpoint ID")
+	if !ok {
+		return
+	}
+
+	var req UpdateEndpointRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid request body", nil, err.Error())
+		return
+	}
+
+	endpoint, err := ctrl.service.UpdateEndpoint(c.Request.Context(), id, req)
+	if err != nil {
+		ctrl.respondError(c, err, "Failed to update webhook endpoint")
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Webhook endpoint updated successfully", endpoint, nil)
+}
+
+// DeleteEndpoint removes an endpoint along with its delivery log
+//
+// @Summary      Delete webhook endpoint (Admin)
+// @Description  Deletes the endpoint with its deliveries and their attempt log.
+// @Description
+// @Description  Requires the `system:manage` permission.
+// @Tags         Admin Webhooks
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse "Webhook endpoint deleted successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Webhook endpoint not found"
+// @Failure      409 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/webhooks/endpoints/{id} [delete]
+func (ctrl *Controller) DeleteEndpoint(c *gin.Context) {
+	id, ok := ctrl.pathID(c, "Invalid webhook endpoint ID")
+	if !ok {
+		return
+	}
+
+	if err := ctrl.service.DeleteEndpoint(c.Request.Context(), id); err != nil {
+		ctrl.respondError(c, err, "Failed to delete webhook endpoint")
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Webhook endpoint deleted successfully", nil, nil)
+}
+
+// RotateSecret godoc
+//
+// @Summary      Rotate webhook signing secret (Admin)
+// @Description  Issues a new secret. Deliveries sent from now on are signed with it.
+// @Description
+// @Description  Requires the `system:manage` permission.
+// @Tags         Admin Webhooks
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=EndpointSecretResponse} "Webhook secret rotated successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Webhook endpoint not found"
+// @Failure      409 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/webhooks/endpoints/{id}/rotate-secret [post]
+func (ctrl *Controller) RotateSecret(c *gin.Context) {
+	id, ok := ctrl.pathID(c, "Invalid webhook endpoint ID")
+	if !ok {
+		return
+	}
+
+	rotated, err := ctrl.service.RotateSecret(c.Request.Context(), id)
+	if err != nil {
+		ctrl.respondError(c, err, "Failed to rotate webhook secret")
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Webhook secret rotated successfully. Store the secret now; it will not be shown again", rotated, nil)
+}
+
+// ListDeliveries returns an endpoint's deliveries, newest first
+//
+// @Summary      List endpoint deliveries (Admin)
+// @Description  The endpoint's delivery log, newest first.
+// @Description
+// @Description  Requires the `system:manage` permission.
+// @Tags         Admin Webhooks
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "ID" Format(uuid)
+// @Param        status query string false "Status" Enums(PENDING, SUCCEEDED, FAILED)
+// @Param        event_type query string false "Event type" Enums(booking.confirmed, booking.cancelled, event.updated, event.status_changed, waitlist.notified)
+// @Param        page query integer false "Page" default(1) minimum(1)
+// @Param        limit query integer false "Limit" default(20) minimum(1) maximum(100)
+// @Success      200 {object} response.StandardApiResponse{data=DeliveryListResponse} "Webhook deliveries retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Webhook endpoint not found"
+// @Failure      409 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/webhooks/endpoints/{id}/deliveries [get]
+func (ctrl *Controller) ListDeliveries(c *gin.Context) {
+	id, ok := ctrl.pathID(c, "Invalid webhook endpoint ID")
+	if !ok {
+		return
+	}
+
+	var query DeliveryListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid query parameters", nil, err.Error())
+		return
+	}
+
+	deliveries, err := ctrl.service.ListDeliveries(c.Request.Context(), id, query)
+	if err != nil {
+		ctrl.respondError(c, err, "Failed to retrieve webhook deliveries")
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Webhook deliveries retrieved successfully", deliveries, nil)
+}
+
+// GetDelivery returns a delivery with its event and every attempt
+//
+// @Summary      Get webhook delivery (Admin)
+// @Description  Returns the delivery with its event payload and one log entry per attempt.
+// @Description
+// @Description  Requires the `system:manage` permission.
+// @Tags         Admin Webhooks
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "ID" Format(uuid)
+// @Success      200 {object} response.StandardApiResponse{data=Delivery} "Webhook delivery retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Webhook delivery not found"
+// @Failure      409 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/webhooks/deliveries/{id} [get]
+func (ctrl *Controller) GetDelivery(c *gin.Context) {
+	id, ok := ctrl.pathID(c, "Invalid webhook delivery ID")
+	if !ok {
+		return
+	}
+
+	delivery, err := ctrl.service.GetDelivery(c.Request.Context(), id)
+	if err != nil {
+		ctrl.respondError(c, err, "Failed to retrieve webhook delivery")
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Webhook delivery retrieved successfully", delivery, nil)
+}
+
+// RedeliverDelivery sends a delivery's event to its endpoint again
+//
+// @Summary      Redeliver webhook (Admin)
+// @Description  Queues a new delivery of the same event to the same endpoint, sent on the worker's next poll.
+// @Description
+// @Description  Requires the `system:manage` permission.
+// @Tags         Admin Webhooks
+// @Produce      json
+// @Security     Bearer
+// @Param        id path string true "ID" Format(uuid)
+// @Success      202 {object} response.StandardApiResponse{data=Delivery} "Webhook redelivery queued successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "Webhook delivery not found"
+// @Failure      409 {object} response.StandardApiResponse "Webhook endpoint is inactive"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/webhooks/deliveries/{id}/redeliver [post]
+func (ctrl *Controller) RedeliverDelivery(c *gin.Context) {
+	id, ok := ctrl.pathID(c, "Invalid webhook delivery ID")
+	if !ok {
+		return
+	}
+
+	delivery, err := ctrl.service.Redeliver(c.Request.Context(), id)
+	if err != nil {
+		ctrl.respondError(c, err, "Failed to redeliver webhook")
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusAccepted, "Webhook redelivery queued successfully", delivery, nil)
+}
+
+func (ctrl *Controller) adminID(c *gin.Context) (uuid.UUID, bool) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.RespondJSON(c, "error", http.StatusUnauthorized, "User not authenticated", nil, nil)
+		return uuid.Nil, false
+	}
+
+	adminID, err := uuid.Parse(userID.(string))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusInternalServerError, "Invalid user ID format", nil, nil)
+		return uuid.Nil, false
+	}
+	return adminID, true
+}
+
+func (ctrl *Controller) pathID(c *gin.Context, message string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, message, nil, err.Error())
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func (ctrl *Controller) respondError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, ErrEndpointNotFound), errors.Is(err, ErrDeliveryNotFound):
+		response.RespondJSON(c, "error", http.StatusNotFound, err.Error(), nil, nil)
+	case errors.Is(err, ErrEndpointInactive):
+		response.RespondJSON(c, "error", http.StatusConflict, err.Error(), nil, nil)
+	case errors.Is(err, ErrInvalidEventType), errors.Is(err, ErrInvalidURL), errors.Is(err, ErrInsecureURL):
+		response.RespondJSON(c, "error", http.StatusBadRequest, err.Error(), nil, nil)
+	default:
+		response.RespondJSON(c, "error", http.StatusInternalServerError, message, nil, err.Error())
+	}
+}
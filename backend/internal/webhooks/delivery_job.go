@@ -0,0 +1,63 @@
+package webhooks
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// DeliveryJob sends due webhook deliveries. Several instances can run it at
+// once; claimed deliveries are leased so each is sent by one worker at a time.
+type DeliveryJob struct {
+	service Service
+	config  *Config
+	done    chan struct{}
+	stopped chan struct{}
+}
+
+// NewDeliveryJob creates a new webhook delivery job
+func NewDeliveryJob(service Service, config *Config) *DeliveryJob {
+	if config == nil {
+		config = DefaultConfig()
+	}
+
+	return &DeliveryJob{
+		service: service,
+		config:  config,
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+}
+
+// Start starts the webhook delivery job
+func (j *DeliveryJob) Start(ctx context.Context) {
+	log.Printf("📡 WEBHOOK: Starting delivery job with %v poll interval", j.config.PollInterval)
+	go j.run(ctx)
+}
+
+// Stop stops the job once the batch being sent is done
+func (j *DeliveryJob) Stop() {
+	log.Println("📡 WEBHOOK: Stopping delivery job...")
+	close(j.done)
+	<-j.stopped
+}
+
+func (j *DeliveryJob) run(ctx context.Context) {
+	defer close(j.stopped)
+
+	ticker := time.NewTicker(j.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := j.service.DeliverDue(ctx); err != nil {
+				log.Printf("❌ WEBHOOK: %v", err)
+			}
+		case <-j.done:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Event types endpoints can subscribe to
+const (
+	EventBookingConfirmed   = "booking.confirmed"
+	EventBookingCancelled   = "booking.cancelled"
+	EventEventUpdated       = "event.updated"
+	EventEventStatusChanged = "event.status_changed"
+	EventWaitlistNotified   = "waitlist.notified"
+)
+
+// EventTypes lists every event type an endpoint can subscribe to
+var EventTypes = []string{EventBookingConfirmed, EventBookingCancelled, EventEventUpdated, EventEventStatusChanged, EventWaitlistNotified}
+
+// Endpoint is a partner URL that receives signed POSTs for the event types it
+// subscribes to. The secret signs every delivery, so it is kept as is and only
+// shown when the endpoint is created or its secret rotated.
+type Endpoint struct {
+	ID          uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	Name        string    `gorm:"not null" json:"name"`
+	Description string    `json:"description,omitempty"`
+	URL         string    `gorm:"type:text;not null" json:"url"`
+	Secret      string    `gorm:"not null" json:"-"`
+	EventTypes  []string  `gorm:"type:jsonb;serializer:json;not null" json:"event_types"`
+	Active      bool      `gorm:"not null;default:true;index" json:"active"` // Inactive endpoints keep their pending deliveries until reactivated
+	CreatedBy   uuid.UUID `gorm:"type:uuid;not null" json:"created_by"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func (Endpoint) TableName() string {
+	return "webhook_endpoints"
+}
+
+// Event is something that happened, recorded in the same transaction as the
+// change it describes. Payload is the exact body POSTed to endpoints.
+type Event struct {
+	ID         uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	Type       string    `gorm:"type:varchar(50);not null;index" json:"type"`
+	DedupKey   string    `gorm:"type:varchar(255);not null;uniqueIndex" json:"-"`
+	Payload    string    `gorm:"type:jsonb;not null" json:"payload"`
+	OccurredAt time.Time `gorm:"not null" json:"occurred_at"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func (Event) TableName() string {
+	return "webhook_events"
+}
+
+// MarshalJSON shows the payload as JSON rather than as a string
+func (e Event) MarshalJSON() ([]byte, error) {
+	type event Event
+	return json.Marshal(struct {
+		event
+		Payload json.RawMessage `json:"payload"`
+	}{event(e), json.RawMessage(e.Payload)})
+}
+
+// EventBody is the JSON body of a delivery
+type EventBody struct {
+	ID         uuid.UUID   `json:"id"`
+	Type       string      `json:"type"`
+	OccurredAt time.Time   `json:"occurred_at"`
+	Data       interface{} `json:"data"`
+}
+
+// NewEvent builds an event for Enqueue. The dedup key must be stable for the
+// logical event so retried writes collapse into one event.
+func NewEvent(eventType, dedupKey string, data interface{}) (*Event, error) {
+	event := &Event{
+		ID:         uuid.New(),
+		Type:       eventType,
+		DedupKey:   dedupKey,
+		OccurredAt: time.Now().UTC(),
+	}
+
+	payload, err := json.Marshal(EventBody{ID: event.ID, Type: eventType, OccurredAt: event.OccurredAt, Data: data})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+	event.Payload = string(payload)
+
+	return event, nil
+}
+
+type DeliveryStatus string
+
+const (
+	DeliveryStatusPending   DeliveryStatus = "PENDING"
+	DeliveryStatusSucceeded DeliveryStatus = "SUCCEEDED"
+	DeliveryStatusFailed    DeliveryStatus = "FAILED"
+)
+
+// Delivery is one event sent to one endpoint, retried with backoff until the
+// endpoint answers with a 2xx status or the attempts run out
+type Delivery struct {
+	ID             uuid.UUID      `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	EndpointID     uuid.UUID      `gorm:"type:uuid;not null;index" json:"endpoint_id"`
+	EventID        uuid.UUID      `gorm:"type:uuid;not null;index" json:"event_id"`
+	EventType      string         `gorm:"type:varchar(50);not null" json:"event_type"`
+	Status         DeliveryStatus `gorm:"type:varchar(20);check:status IN ('PENDING', 'SUCCEEDED', 'FAILED');default:'PENDING';index:idx_webhook_delivery_due" json:"status"`
+	Attempts       int            `gorm:"not null;default:0" json:"attempts"`
+	NextAttemptAt  time.Time      `gorm:"not null;index:idx_webhook_delivery_due" json:"next_attempt_at"`
+	LastStatusCode *int           `json:"last_status_code,omitempty"`
+	LastError      string         `gorm:"type:text" json:"last_error,omitempty"`
+	DeliveredAt    *time.Time     `json:"delivered_at,omitempty"`
+	RedeliveryOf   *uuid.UUID     `gorm:"type:uuid" json:"redelivery_of,omitempty"` // Delivery an admin sent again
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+
+	// Relationships
+	Endpoint *Endpoint         `json:"-" gorm:"foreignKey:EndpointID;constraint:OnDelete:CASCADE;"`
+	Event    *Event            `json:"event,omitempty" gorm:"foreignKey:EventID;constraint:OnDelete:CASCADE;"`
+	Log      []DeliveryAttempt `json:"attempt_log,omitempty" gorm:"foreignKey:DeliveryID;constraint:OnDelete:CASCADE;"`
+}
+
+func (Delivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+// DeliveryAttempt is the delivery log: one row per POST to the endpoint
+type DeliveryAttempt struct {
+	ID           uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	DeliveryID   uuid.UUID `gorm:"type:uuid;not null;index" json:"delivery_id"`
+	Attempt      int       `gorm:"not null" json:"attempt"`
+	StatusCode   *int      `json:"status_code,omitempty"`
+	Error        string    `gorm:"type:text" json:"error,omitempty"`
+	ResponseBody string    `gorm:"type:text" json:"response_body,omitempty"` // First KB of the endpoint's answer
+	DurationMs   int64     `gorm:"not null" json:"duration_ms"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+func (DeliveryAttempt) TableName() string {
+	return "webhook_delivery_attempts"
+}
@@ -0,0 +1,249 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	// Endpoints
+	CreateEndpoint(ctx context.Context, endpoint *Endpoint) error
+	GetEndpoint(ctx context.Context, id uuid.UUID) (*Endpoint, error)
+	ListEndpoints(ctx context.Context) ([]Endpoint, error)
+	UpdateEndpoint(ctx context.Context, endpoint *Endpoint) error
+	DeleteEndpoint(ctx context.Context, id uuid.UUID) error
+
+	// Publishes an event outside of a caller's transaction
+	Enqueue(ctx context.Context, events ...*Event) error
+
+	// Deliveries. Claimed rows are leased so that other workers skip them until
+	// the lease expires.
+	ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]Delivery, error)
+	RecordAttempt(ctx context.Context, attempt *DeliveryAttempt, updates map[string]interface{}) error
+	CreateDelivery(ctx context.Context, delivery *Delivery) error
+	GetDelivery(ctx context.Context, id uuid.UUID) (*Delivery, error)
+	ListDeliveries(ctx context.Context, filters DeliveryFilters) ([]Delivery, int64, error)
+}
+
+// DeliveryFilters narrows an endpoint's delivery log
+type DeliveryFilters struct {
+	EndpointID uuid.UUID
+	Status     DeliveryStatus
+	EventType  string
+	Page       int
+	Limit      int
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+// Enqueue records events using the caller's transaction and queues a delivery
+// for every active endpoint subscribed to each event's type. Events whose dedup
+// key already exists are ignored, which makes enqueueing idempotent.
+func Enqueue(tx *gorm.DB, events ...*Event) error {
+	for _, event := range events {
+		if event == nil {
+			continue
+		}
+
+		result := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "dedup_key"}},
+			DoNothing: true,
+		}).Create(event)
+		if result.Error != nil {
+			return fmt.Errorf("failed to record webhook event: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			continue
+		}
+
+		subscribed, err := json.Marshal([]string{event.Type})
+		if err != nil {
+			return fmt.Errorf("failed to queue webhook deliveries: %w", err)
+		}
+
+		now := time.Now()
+		err = tx.Exec(`
+			INSERT INTO webhook_deliveries (id, endpoint_id, event_id, event_type, status, attempts, next_attempt_at, created_at, updated_at)
+			SELECT uuid_generate_v4(), id, ?, ?, ?, 0, ?, ?, ?
+			FROM webhook_endpoints
+			WHERE active AND event_types @> ?::jsonb`,
+			event.ID, event.Type, DeliveryStatusPending, now, now, now, string(subscribed)).Error
+		if err != nil {
+			return fmt.Errorf("failed to queue webhook deliveries: %w", err)
+		}
+	}
+	return nil
+}
+
+//  ENDPOINTS
+
+// CreateEndpoint writes every column so an inactive endpoint is not given the default
+func (r *repository) CreateEndpoint(ctx context.Context, endpoint *Endpoint) error {
+	return r.db.WithContext(ctx).Select("*").Create(endpoint).Error
+}
+
+func (r *repository) GetEndpoint(ctx context.Context, id uuid.UUID) (*Endpoint, error) {
+	var endpoint Endpoint
+	if err := r.db.WithContext(ctx).Where("id = ?", id).Take(&endpoint).Error; err != nil {
+		return nil, err
+	}
+	return &endpoint, nil
+}
+
+func (r *repository) ListEndpoints(ctx context.Context) ([]Endpoint, error) {
+	var endpoints []Endpoint
+	err := r.db.WithContext(ctx).Order("created_at DESC").Find(&endpoints).Error
+	return endpoints, err
+}
+
+func (r *repository) UpdateEndpoint(ctx context.Context, endpoint *Endpoint) error {
+	return r.db.WithContext(ctx).Save(endpoint).Error
+}
+
+// DeleteEndpoint removes an endpoint with its deliveries and their log
+func (r *repository) DeleteEndpoint(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Delete(&Endpoint{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *repository) Enqueue(ctx context.Context, events ...*Event) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return Enqueue(tx, events...)
+	})
+}
+
+//  DELIVERIES
+
+// ClaimDeliveries claims due deliveries of active endpoints, with their endpoint and event
+func (r *repository) ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]Delivery, error) {
+	var deliveries []Delivery
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", DeliveryStatusPending, now).
+			Where("endpoint_id IN (SELECT id FROM webhook_endpoints WHERE active)").
+			Order("next_attempt_at ASC").
+			Limit(limit).
+			Find(&deliveries).Error
+		if err != nil {
+			return err
+		}
+
+		if len(deliveries) == 0 {
+			return nil
+		}
+
+		ids := make([]uuid.UUID, len(deliveries))
+		for i, delivery := range deliveries {
+			ids[i] = delivery.ID
+		}
+
+		return tx.Model(&Delivery{}).
+			Where("id IN ?", ids).
+			Updates(map[string]interface{}{
+				"next_attempt_at": now.Add(lease),
+				"attempts":        gorm.Expr("attempts + 1"),
+				"updated_at":      now,
+			}).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+	if len(deliveries) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]uuid.UUID, len(deliveries))
+	for i := range deliveries {
+		ids[i] = deliveries[i].ID
+	}
+	var claimed []Delivery
+	err = r.db.WithContext(ctx).
+		Preload("Endpoint").
+		Preload("Event").
+		Where("id IN ?", ids).
+		Order("next_attempt_at ASC").
+		Find(&claimed).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to load webhook deliveries: %w", err)
+	}
+
+	return claimed, nil
+}
+
+// RecordAttempt logs an attempt and applies its outcome to the delivery
+func (r *repository) RecordAttempt(ctx context.Context, attempt *DeliveryAttempt, updates map[string]interface{}) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(attempt).Error; err != nil {
+			return fmt.Errorf("failed to log webhook attempt: %w", err)
+		}
+
+		updates["updated_at"] = time.Now()
+		if err := tx.Model(&Delivery{}).Where("id = ?", attempt.DeliveryID).Updates(updates).Error; err != nil {
+			return fmt.Errorf("failed to update webhook delivery: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *repository) CreateDelivery(ctx context.Context, delivery *Delivery) error {
+	return r.db.WithContext(ctx).Omit(clause.Associations).Create(delivery).Error
+}
+
+// GetDelivery returns a delivery with its event and attempt log
+func (r *repository) GetDelivery(ctx context.Context, id uuid.UUID) (*Delivery, error) {
+	var delivery Delivery
+	err := r.db.WithContext(ctx).
+		Preload("Event").
+		Preload("Log", func(db *gorm.DB) *gorm.DB {
+			return db.Order("attempt ASC")
+		}).
+		Where("id = ?", id).
+		Take(&delivery).Error
+	if err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+func (r *repository) ListDeliveries(ctx context.Context, filters DeliveryFilters) ([]Delivery, int64, error) {
+	query := r.db.WithContext(ctx).Model(&Delivery{}).Where("endpoint_id = ?", filters.EndpointID)
+	if filters.Status != "" {
+		query = query.Where("status = ?", filters.Status)
+	}
+	if filters.EventType != "" {
+		query = query.Where("event_type = ?", filters.EventType)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var deliveries []Delivery
+	err := query.
+		Order("created_at DESC").
+		Offset((filters.Page - 1) * filters.Limit).
+		Limit(filters.Limit).
+		Find(&deliveries).Error
+	return deliveries, total, err
+}
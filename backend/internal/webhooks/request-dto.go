@@ -0,0 +1,24 @@
+package webhooks
+
+type CreateEndpointRequest struct {
+	Name        string   `json:"name" binding:"required,max=100"`
+	Description string   `json:"description" binding:"omitempty,max=500"`
+	URL         string   `json:"url" binding:"required,max=2048"`
+	EventTypes  []string `json:"event_types" binding:"required,min=1"` // booking.confirmed, booking.cancelled, event.updated, event.status_changed, waitlist.notified
+	Active      *bool    `json:"active"`                               // Defaults to true
+}
+
+type UpdateEndpointRequest struct {
+	Name        *string   `json:"name" binding:"omitempty,min=1,max=100"`
+	Description *string   `json:"description" binding:"omitempty,max=500"`
+	URL         *string   `json:"url" binding:"omitempty,max=2048"`
+	EventTypes  *[]string `json:"event_types" binding:"omitempty,min=1"`
+	Active      *bool     `json:"active"`
+}
+
+type DeliveryListQuery struct {
+	Status    string `form:"status" binding:"omitempty,oneof=PENDING SUCCEEDED FAILED"`
+	EventType string `form:"event_type"`
+	Page      int    `form:"page" binding:"omitempty,min=1"`
+	Limit     int    `form:"limit" binding:"omitempty,min=1,max=100"`
+}
@@ -0,0 +1,22 @@
+package webhooks
+
+import "evently/internal/shared/utils/response"
+
+// EndpointSecretResponse carries a new signing secret. It is shown once, when
+// the endpoint is created or the secret rotated.
+type EndpointSecretResponse struct {
+	Endpoint *Endpoint `json:"endpoint"`
+	Secret   string    `json:"secret"`
+}
+
+type DeliveryListResponse struct {
+	Deliveries []Delivery `json:"deliveries"`
+	Total      int64      `json:"total"`
+	Page       int        `json:"page"`
+	Limit      int        `json:"limit"`
+	TotalPages int        `json:"total_pages"`
+}
+
+func (p DeliveryListResponse) PaginationMeta() response.Pagination {
+	return response.NewPagination(p.Page, p.Limit, p.Total)
+}
@@ -0,0 +1,23 @@
+package webhooks
+
+import (
+	"evently/internal/shared/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupWebhookRoutes(rg *gin.RouterGroup, controller *Controller) {
+	admin := rg.Group("/admin/webhooks")
+	admin.Use(middleware.JWTAuth(), middleware.RequirePermission(middleware.PermissionSystemManage))
+	{
+		admin.POST("/endpoints", controller.CreateEndpoint)                   // POST /api/v1/admin/webhooks/endpoints
+		admin.GET("/endpoints", controller.ListEndpoints)                     // GET /api/v1/admin/webhooks/endpoints
+		admin.GET("/endpoints/:id", controller.GetEndpoint)                   // GET /api/v1/admin/webhooks/endpoints/:id
+		admin.PUT("/endpoints/:id", controller.UpdateEndpoint)                // PUT /api/v1/admin/webhooks/endpoints/:id
+		admin.DELETE("/endpoints/:id", controller.DeleteEndpoint)             // DELETE /api/v1/admin/webhooks/endpoints/:id
+		admin.POST("/endpoints/:id/rotate-secret", controller.RotateSecret)   // POST /api/v1/admin/webhooks/endpoints/:id/rotate-secret
+		admin.GET("/endpoints/:id/deliveries", controller.ListDeliveries)     // GET /api/v1/admin/webhooks/endpoints/:id/deliveries - Delivery log
+		admin.GET("/deliveries/:id", controller.GetDelivery)                  // GET /api/v1/admin/webhooks/deliveries/:id - With every attempt
+		admin.POST("/deliveries/:id/redeliver", controller.RedeliverDelivery) // POST /api/v1/admin/webhooks/deliveries/:id/redeliver
+	}
+}
@@ -0,0 +1,484 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"evently/pkg/backoff"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Headers sent with every delivery. Endpoints verify a delivery by computing
+// the HMAC-SHA256 of "<t>.<body>" with their secret and comparing it to v1 in
+// "X-Evently-Signature: t=<unix seconds>,v1=<hex>", and should reject old
+// timestamps to stop replays.
+const (
+	SignatureHeader = "X-Evently-Signature"
+	EventTypeHeader = "X-Evently-Event"
+	EventIDHeader   = "X-Evently-Event-ID"
+	DeliveryHeader  = "X-Evently-Delivery"
+)
+
+// secretPrefix starts every signing secret so leaked secrets are easy to recognise
+const secretPrefix = "whsec_"
+
+// maxResponseLog is how much of an endpoint's answer is kept in the delivery log
+const maxResponseLog = 1024
+
+var (
+	ErrEndpointNotFound = errors.New("webhook endpoint not found")
+	ErrDeliveryNotFound = errors.New("webhook delivery not found")
+	ErrEndpointInactive = errors.New("webhook endpoint is inactive")
+	ErrInvalidEventType = fmt.Errorf("event_types must be one or more of: %s", strings.Join(EventTypes, ", "))
+	ErrInvalidURL       = errors.New("url must be an absolute http or https URL")
+	ErrInsecureURL      = errors.New("url must use https")
+)
+
+// Config contains webhook delivery settings
+type Config struct {
+	PollInterval time.Duration // How often due deliveries are claimed
+	BatchSize    int           // Deliveries claimed per poll
+	Concurrency  int           // Deliveries sent at the same time
+	Timeout      time.Duration // How long an endpoint has to answer
+	MaxAttempts  int           // Attempts before a delivery is marked failed
+	BaseBackoff  time.Duration // Delay before the first retry, doubled for each further one
+	MaxBackoff   time.Duration
+	Lease        time.Duration // Claimed deliveries are hidden from other workers this long
+	AllowHTTP    bool          // Accept plain http endpoint URLs, for local development
+}
+
+// DefaultConfig returns default webhook configuration
+func DefaultConfig() *Config {
+	return &Config{
+		PollInterval: 2 * time.Second,
+		BatchSize:    50,
+		Concurrency:  4,
+		Timeout:      10 * time.Second,
+		MaxAttempts:  8,
+		BaseBackoff:  30 * time.Second,
+		MaxBackoff:   6 * time.Hour,
+		Lease:        5 * time.Minute,
+		AllowHTTP:    false,
+	}
+}
+
+type Service interface {
+	// Endpoints
+	CreateEndpoint(ctx context.Context, adminID uuid.UUID, req CreateEndpointRequest) (*EndpointSecretResponse, error)
+	ListEndpoints(ctx context.Context) ([]Endpoint, error)
+	GetEndpoint(ctx context.Context, id uuid.UUID) (*Endpoint, error)
+	UpdateEndpoint(ctx context.Context, id uuid.UUID, req UpdateEndpointRequest) (*Endpoint, error)
+	DeleteEndpoint(ctx context.Context, id uuid.UUID) error
+	RotateSecret(ctx context.Context, id uuid.UUID) (*EndpointSecretResponse, error)
+
+	// Delivery log and redelivery
+	ListDeliveries(ctx context.Context, endpointID uuid.UUID, query DeliveryListQuery) (*DeliveryListResponse, error)
+	GetDelivery(ctx context.Context, id uuid.UUID) (*Delivery, error)
+	Redeliver(ctx context.Context, id uuid.UUID) (*Delivery, error)
+
+	// Publish records an event for domains that change state outside of a
+	// transaction. Domains with a transaction use Enqueue instead.
+	Publish(ctx context.Context, eventType, dedupKey string, data interface{}) error
+
+	// DeliverDue sends due deliveries and returns how many were attempted
+	DeliverDue(ctx context.Context) (int, error)
+}
+
+type service struct {
+	repo   Repository
+	config *Config
+	client *http.Client
+}
+
+func NewService(repo Repository, config *Config) Service {
+	if config == nil {
+		config = DefaultConfig()
+	}
+	return &service{
+		repo:   repo,
+		config: config,
+		client: &http.Client{
+			Timeout: config.Timeout,
+			// A redirect is not an acknowledgement; the endpoint must answer itself
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+//  ENDPOINTS
+
+func (s *service) CreateEndpoint(ctx context.Context, adminID uuid.UUID, req CreateEndpointRequest) (*EndpointSecretResponse, error) {
+	endpointURL, err := s.validateURL(req.URL)
+	if err != nil {
+		return nil, err
+	}
+	eventTypes, err := normalizeEventTypes(req.EventTypes)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := generateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := &Endpoint{
+		ID:          uuid.New(),
+		Name:        strings.TrimSpace(req.Name),
+		Description: strings.TrimSpace(req.Description),
+		URL:         endpointURL,
+		Secret:      secret,
+		EventTypes:  eventTypes,
+		Active:      req.Active == nil || *req.Active,
+		CreatedBy:   adminID,
+	}
+	if err := s.repo.CreateEndpoint(ctx, endpoint); err != nil {
+		return nil, fmt.Errorf("failed to create webhook endpoint: %w", err)
+	}
+
+	return &EndpointSecretResponse{Endpoint: endpoint, Secret: secret}, nil
+}
+
+func (s *service) ListEndpoints(ctx context.Context) ([]Endpoint, error) {
+	endpoints, err := s.repo.ListEndpoints(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook endpoints: %w", err)
+	}
+	return endpoints, nil
+}
+
+func (s *service) GetEndpoint(ctx context.Context, id uuid.UUID) (*Endpoint, error) {
+	endpoint, err := s.repo.GetEndpoint(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrEndpointNotFound
+		}
+		return nil, fmt.Errorf("failed to get webhook endpoint: %w", err)
+	}
+	return endpoint, nil
+}
+
+func (s *service) UpdateEndpoint(ctx context.Context, id uuid.UUID, req UpdateEndpointRequest) (*Endpoint, error) {
+	endpoint, err := s.GetEndpoint(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		endpoint.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Description != nil {
+		endpoint.Description = strings.TrimSpace(*req.Description)
+	}
+	if req.URL != nil {
+		if endpoint.URL, err = s.validateURL(*req.URL); err != nil {
+			return nil, err
+		}
+	}
+	if req.EventTypes != nil {
+		if endpoint.EventTypes, err = normalizeEventTypes(*req.EventTypes); err != nil {
+			return nil, err
+		}
+	}
+	if req.Active != nil {
+		endpoint.Active = *req.Active
+	}
+
+	if err := s.repo.UpdateEndpoint(ctx, endpoint); err != nil {
+		return nil, fmt.Errorf("failed to update webhook endpoint: %w", err)
+	}
+	return endpoint, nil
+}
+
+func (s *service) DeleteEndpoint(ctx context.Context, id uuid.UUID) error {
+	if err := s.repo.DeleteEndpoint(ctx, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrEndpointNotFound
+		}
+		return fmt.Errorf("failed to delete webhook endpoint: %w", err)
+	}
+	return nil
+}
+
+// RotateSecret replaces an endpoint's signing secret. Deliveries sent from now
+// on, including retries, are signed with the new secret.
+func (s *service) RotateSecret(ctx context.Context, id uuid.UUID) (*EndpointSecretResponse, error) {
+	endpoint, err := s.GetEndpoint(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := generateSecret()
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Secret = secret
+	if err := s.repo.UpdateEndpoint(ctx, endpoint); err != nil {
+		return nil, fmt.Errorf("failed to rotate webhook secret: %w", err)
+	}
+
+	return &EndpointSecretResponse{Endpoint: endpoint, Secret: secret}, nil
+}
+
+func (s *service) validateURL(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.Host == "" || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return "", ErrInvalidURL
+	}
+	if parsed.Scheme == "http" && !s.config.AllowHTTP {
+		return "", ErrInsecureURL
+	}
+	return raw, nil
+}
+
+func normalizeEventTypes(eventTypes []string) ([]string, error) {
+	known := make(map[string]bool, len(EventTypes))
+	for _, eventType := range EventTypes {
+		known[eventType] = true
+	}
+
+	seen := make(map[string]bool, len(eventTypes))
+	normalized := make([]string, 0, len(eventTypes))
+	for _, eventType := range eventTypes {
+		eventType = strings.ToLower(strings.TrimSpace(eventType))
+		if !known[eventType] {
+			return nil, ErrInvalidEventType
+		}
+		if !seen[eventType] {
+			seen[eventType] = true
+			normalized = append(normalized, eventType)
+		}
+	}
+	if len(normalized) == 0 {
+		return nil, ErrInvalidEventType
+	}
+	return normalized, nil
+}
+
+func generateSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return secretPrefix + base64.RawURLEncoding.EncodeToString(secret), nil
+}
+
+//  DELIVERIES
+
+func (s *service) ListDeliveries(ctx context.Context, endpointID uuid.UUID, query DeliveryListQuery) (*DeliveryListResponse, error) {
+	if _, err := s.GetEndpoint(ctx, endpointID); err != nil {
+		return nil, err
+	}
+
+	if query.Page <= 0 {
+		query.Page = 1
+	}
+	if query.Limit <= 0 {
+		query.Limit = 20
+	}
+
+	deliveries, total, err := s.repo.ListDeliveries(ctx, DeliveryFilters{
+		EndpointID: endpointID,
+		Status:     DeliveryStatus(query.Status),
+		EventType:  query.EventType,
+		Page:       query.Page,
+		Limit:      query.Limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+
+	return &DeliveryListResponse{
+		Deliveries: deliveries,
+		Total:      total,
+		Page:       query.Page,
+		Limit:      query.Limit,
+		TotalPages: int((total + int64(query.Limit) - 1) / int64(query.Limit)),
+	}, nil
+}
+
+func (s *service) GetDelivery(ctx context.Context, id uuid.UUID) (*Delivery, error) {
+	delivery, err := s.repo.GetDelivery(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrDeliveryNotFound
+		}
+		return nil, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+	return delivery, nil
+}
+
+// Redeliver queues the delivery's event to its endpoint again, as a new
+// delivery so the original attempt log is kept
+func (s *service) Redeliver(ctx context.Context, id uuid.UUID) (*Delivery, error) {
+	original, err := s.GetDelivery(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint, err := s.GetEndpoint(ctx, original.EndpointID)
+	if err != nil {
+		return nil, err
+	}
+	if !endpoint.Active {
+		return nil, ErrEndpointInactive
+	}
+
+	now := time.Now()
+	delivery := &Delivery{
+		ID:            uuid.New(),
+		EndpointID:    original.EndpointID,
+		EventID:       original.EventID,
+		EventType:     original.EventType,
+		Status:        DeliveryStatusPending,
+		NextAttemptAt: now,
+		RedeliveryOf:  &original.ID,
+	}
+	if err := s.repo.CreateDelivery(ctx, delivery); err != nil {
+		return nil, fmt.Errorf("failed to queue redelivery: %w", err)
+	}
+	return delivery, nil
+}
+
+func (s *service) Publish(ctx context.Context, eventType, dedupKey string, data interface{}) error {
+	event, err := NewEvent(eventType, dedupKey, data)
+	if err != nil {
+		return err
+	}
+	return s.repo.Enqueue(ctx, event)
+}
+
+func (s *service) DeliverDue(ctx context.Context) (int, error) {
+	deliveries, err := s.repo.ClaimDeliveries(ctx, s.config.BatchSize, s.config.Lease)
+	if err != nil {
+		return 0, err
+	}
+
+	concurrency := s.config.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	slots := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i := range deliveries {
+		slots <- struct{}{}
+		wg.Add(1)
+		go func(delivery *Delivery) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			s.deliver(ctx, delivery)
+		}(&deliveries[i])
+	}
+	wg.Wait()
+
+	return len(deliveries), nil
+}
+
+// deliver POSTs one delivery and records the outcome. Any 2xx answer
+// acknowledges it; everything else is retried with backoff.
+func (s *service) deliver(ctx context.Context, delivery *Delivery) {
+	if delivery.Endpoint == nil || delivery.Event == nil {
+		// The endpoint was deleted after the claim
+		return
+	}
+
+	body := []byte(delivery.Event.Payload)
+	attempt := &DeliveryAttempt{
+		ID:         uuid.New(),
+		DeliveryID: delivery.ID,
+		Attempt:    delivery.Attempts,
+	}
+
+	started := time.Now()
+	statusCode, responseBody, sendErr := s.send(ctx, delivery, body, started)
+	attempt.DurationMs = time.Since(started).Milliseconds()
+	attempt.ResponseBody = responseBody
+	if statusCode != 0 {
+		attempt.StatusCode = &statusCode
+	}
+	if sendErr == nil && (statusCode < 200 || statusCode > 299) {
+		sendErr = fmt.Errorf("endpoint answered %d", statusCode)
+	}
+
+	updates := map[string]interface{}{"last_status_code": attempt.StatusCode}
+	switch {
+	case sendErr == nil:
+		updates["status"] = DeliveryStatusSucceeded
+		updates["delivered_at"] = time.Now()
+		updates["last_error"] = ""
+		log.Printf("✅ WEBHOOK: Delivered %s %s to %s (attempt %d)", delivery.EventType, delivery.EventID, delivery.Endpoint.URL, delivery.Attempts)
+	case delivery.Attempts >= s.config.MaxAttempts:
+		attempt.Error = sendErr.Error()
+		updates["status"] = DeliveryStatusFailed
+		updates["last_error"] = attempt.Error
+		log.Printf("❌ WEBHOOK: Giving up on delivery %s to %s after %d attempts: %v", delivery.ID, delivery.Endpoint.URL, delivery.Attempts, sendErr)
+	default:
+		attempt.Error = sendErr.Error()
+		delay := backoff.Exponential(s.config.BaseBackoff, s.config.MaxBackoff, delivery.Attempts)
+		updates["next_attempt_at"] = time.Now().Add(delay)
+		updates["last_error"] = attempt.Error
+		log.Printf("⚠️ WEBHOOK: Delivery %s to %s failed (attempt %d), retrying in %v: %v",
+			delivery.ID, delivery.Endpoint.URL, delivery.Attempts, delay, sendErr)
+	}
+
+	if err := s.repo.RecordAttempt(ctx, attempt, updates); err != nil {
+		// The lease expires and the delivery is sent again
+		log.Printf("❌ WEBHOOK: %v", err)
+	}
+}
+
+func (s *service) send(ctx context.Context, delivery *Delivery, body []byte, now time.Time) (int, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.Endpoint.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Evently-Webhooks/1.0")
+	req.Header.Set(EventTypeHeader, delivery.EventType)
+	req.Header.Set(EventIDHeader, delivery.EventID.String())
+	req.Header.Set(DeliveryHeader, delivery.ID.String())
+	req.Header.Set(SignatureHeader, Sign(delivery.Endpoint.Secret, now, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+
+	answer, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseLog))
+	// Drain the rest so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	return resp.StatusCode, strings.ReplaceAll(strings.ToValidUTF8(string(answer), ""), "\x00", ""), nil
+}
+
+// Sign returns the signature header value for a body sent at timestamp
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	t := strconv.FormatInt(timestamp.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(t))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "t=" + t + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
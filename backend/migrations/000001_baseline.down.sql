@@ -0,0 +1,56 @@
+-- Drops every baseline table, newest first
+
+DROP TABLE IF EXISTS "domain_events";
+DROP TABLE IF EXISTS "outbox_messages";
+DROP TABLE IF EXISTS "tag_popularity_agg";
+DROP TABLE IF EXISTS "event_revenue_agg";
+DROP TABLE IF EXISTS "daily_bookings_agg";
+DROP TABLE IF EXISTS "analytics_share_links";
+DROP TABLE IF EXISTS "analytics_report_subscriptions";
+DROP TABLE IF EXISTS "yearly_recap_subscriptions";
+DROP TABLE IF EXISTS "waitlist_analytics";
+DROP TABLE IF EXISTS "waitlist_notifications";
+DROP TABLE IF EXISTS "waitlist_entries";
+DROP TABLE IF EXISTS "cancellations";
+DROP TABLE IF EXISTS "cancellation_policies";
+DROP TABLE IF EXISTS "event_change_batches";
+DROP TABLE IF EXISTS "archived_bookings";
+DROP TABLE IF EXISTS "archived_events";
+DROP TABLE IF EXISTS "event_capacity_alerts";
+DROP TABLE IF EXISTS "event_capacity_alert_settings";
+DROP TABLE IF EXISTS "webhook_delivery_attempts";
+DROP TABLE IF EXISTS "webhook_deliveries";
+DROP TABLE IF EXISTS "webhook_events";
+DROP TABLE IF EXISTS "webhook_endpoints";
+DROP TABLE IF EXISTS "api_key_usage";
+DROP TABLE IF EXISTS "api_keys";
+DROP TABLE IF EXISTS "event_favorites";
+DROP TABLE IF EXISTS "jobs";
+DROP TABLE IF EXISTS "document_archives";
+DROP TABLE IF EXISTS "support_messages";
+DROP TABLE IF EXISTS "support_tickets";
+DROP TABLE IF EXISTS "event_reviews";
+DROP TABLE IF EXISTS "booking_sagas";
+DROP TABLE IF EXISTS "booking_charges";
+DROP TABLE IF EXISTS "payments";
+DROP TABLE IF EXISTS "ticket_bookings";
+DROP TABLE IF EXISTS "seat_bookings";
+DROP TABLE IF EXISTS "bookings";
+DROP TABLE IF EXISTS "promotion_metrics";
+DROP TABLE IF EXISTS "promotion_slots";
+DROP TABLE IF EXISTS "event_series";
+DROP TABLE IF EXISTS "ticket_types";
+DROP TABLE IF EXISTS "venue_conflict_overrides";
+DROP TABLE IF EXISTS "event_pricing";
+DROP TABLE IF EXISTS "event_tags";
+DROP TABLE IF EXISTS "events";
+DROP TABLE IF EXISTS "seat_booking_rules";
+DROP TABLE IF EXISTS "seats";
+DROP TABLE IF EXISTS "venue_sections";
+DROP TABLE IF EXISTS "venue_templates";
+DROP TABLE IF EXISTS "physical_venues";
+DROP TABLE IF EXISTS "tags";
+DROP TABLE IF EXISTS "organizer_brandings";
+DROP TABLE IF EXISTS "user_recovery_codes";
+DROP TABLE IF EXISTS "user_two_factors";
+DROP TABLE IF EXISTS "users";
@@ -0,0 +1,921 @@
+-- Baseline schema: every table as created by the last GORM AutoMigrate release.
+-- Databases created by that release are marked with `migrate force 1` instead of running this.
+
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+CREATE EXTENSION IF NOT EXISTS "pg_trgm";
+
+CREATE TABLE "users" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "first_name" text NOT NULL,
+    "last_name" text NOT NULL,
+    "password" text NOT NULL,
+    "role" text NOT NULL DEFAULT 'USER',
+    "email" text NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "display_currency" varchar(3),
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_users_role" ON "users" ("role");
+CREATE INDEX IF NOT EXISTS "idx_users_deleted_at" ON "users" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_users_created_at" ON "users" ("created_at");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_users_email" ON "users" ("email");
+
+CREATE TABLE "user_two_factors" (
+    "user_id" uuid,
+    "secret" text NOT NULL,
+    "enabled" boolean NOT NULL DEFAULT false,
+    "enabled_at" timestamptz,
+    "last_used_step" bigint NOT NULL DEFAULT 0,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("user_id")
+);
+
+CREATE TABLE "user_recovery_codes" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "user_id" uuid NOT NULL,
+    "code_hash" text NOT NULL,
+    "used_at" timestamptz,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_user_recovery_codes_user_id" ON "user_recovery_codes" ("user_id");
+
+CREATE TABLE "organizer_brandings" (
+    "user_id" uuid,
+    "display_name" varchar(100),
+    "logo_key" varchar(255),
+    "logo_url" varchar(500),
+    "primary_color" varchar(7),
+    "secondary_color" varchar(7),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("user_id")
+);
+
+CREATE TABLE "tags" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "name" varchar(100) NOT NULL,
+    "slug" varchar(100) NOT NULL,
+    "description" varchar(500),
+    "color" varchar(7) DEFAULT '#6B7280',
+    "is_active" boolean DEFAULT true,
+    "created_by" uuid NOT NULL,
+    "updated_by" uuid,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_tags_name" ON "tags" ("name");
+CREATE INDEX IF NOT EXISTS "idx_tags_deleted_at" ON "tags" ("deleted_at");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_tags_slug" ON "tags" ("slug");
+
+CREATE TABLE "physical_venues" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "name" text NOT NULL,
+    "address" text,
+    "city" text,
+    "default_duration_minutes" bigint NOT NULL DEFAULT 180,
+    "changeover_minutes" bigint NOT NULL DEFAULT 0,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "uni_physical_venues_name" UNIQUE ("name")
+);
+CREATE INDEX IF NOT EXISTS "idx_physical_venues_deleted_at" ON "physical_venues" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_physical_venues_city" ON "physical_venues" ("city");
+
+CREATE TABLE "venue_templates" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "name" text NOT NULL,
+    "description" text,
+    "default_rows" bigint,
+    "default_seats_per_row" bigint,
+    "layout_type" varchar(20),
+    "physical_venue_id" uuid,
+    "map_width" decimal,
+    "map_height" decimal,
+    "stage_type" varchar(10),
+    "stage_x" decimal,
+    "stage_y" decimal,
+    "stage_width" decimal,
+    "stage_height" decimal,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "uni_venue_templates_name" UNIQUE ("name"),
+    CONSTRAINT "chk_venue_templates_layout_type" CHECK (layout_type IN ('THEATER', 'STADIUM', 'CONFERENCE', 'GENERAL'))
+);
+CREATE INDEX IF NOT EXISTS "idx_venue_templates_deleted_at" ON "venue_templates" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_venue_templates_physical_venue_id" ON "venue_templates" ("physical_venue_id");
+CREATE INDEX IF NOT EXISTS "idx_venue_templates_layout_type" ON "venue_templates" ("layout_type");
+
+CREATE TABLE "venue_sections" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "template_id" uuid NOT NULL,
+    "name" text NOT NULL,
+    "description" text,
+    "row_start" text,
+    "row_end" text,
+    "seats_per_row" bigint,
+    "total_seats" bigint,
+    "amenities" jsonb,
+    "map_x" decimal,
+    "map_y" decimal,
+    "rotation" decimal,
+    "curvature" decimal,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_venue_sections_template_id" ON "venue_sections" ("template_id");
+
+CREATE TABLE "seats" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "section_id" text NOT NULL,
+    "seat_number" text NOT NULL,
+    "row" text NOT NULL,
+    "position" bigint NOT NULL,
+    "status" varchar(20) DEFAULT 'AVAILABLE',
+    "wheelchair_accessible" boolean NOT NULL DEFAULT false,
+    "companion_seat" boolean NOT NULL DEFAULT false,
+    "restricted_view" boolean NOT NULL DEFAULT false,
+    "aisle" boolean NOT NULL DEFAULT false,
+    "map_x" decimal,
+    "map_y" decimal,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "chk_seats_status" CHECK (status IN ('AVAILABLE', 'BLOCKED'))
+);
+CREATE INDEX IF NOT EXISTS "idx_seats_section_id" ON "seats" ("section_id");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_section_seat" ON "seats" ("section_id","seat_number");
+
+CREATE TABLE "seat_booking_rules" (
+    "id" bigserial,
+    "companion_requires_accessible" boolean NOT NULL DEFAULT true,
+    "max_companions_per_accessible" bigint NOT NULL DEFAULT 1,
+    "updated_by" uuid,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+
+CREATE TABLE "events" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "name" varchar(255) NOT NULL,
+    "description" text,
+    "venue" varchar(255) NOT NULL,
+    "venue_template_id" uuid NOT NULL,
+    "date_time" timestamptz NOT NULL,
+    "duration_minutes" bigint NOT NULL DEFAULT 0,
+    "base_price" decimal NOT NULL,
+    "currency" varchar(3) NOT NULL DEFAULT 'INR',
+    "status" varchar(20) DEFAULT 'published',
+    "image_url" varchar(500),
+    "unlisted" boolean NOT NULL DEFAULT false,
+    "no_index" boolean NOT NULL DEFAULT false,
+    "series_id" uuid,
+    "series_detached" boolean DEFAULT false,
+    "created_by" uuid NOT NULL,
+    "updated_by" uuid,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "chk_events_base_price" CHECK (base_price >= 0)
+);
+CREATE INDEX IF NOT EXISTS "idx_events_deleted_at" ON "events" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_events_series_id" ON "events" ("series_id");
+
+CREATE TABLE "event_tags" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "event_id" uuid NOT NULL,
+    "tag_id" uuid NOT NULL,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_event_tag_unique" ON "event_tags" ("event_id","tag_id");
+CREATE INDEX IF NOT EXISTS "idx_event_tags_event_id" ON "event_tags" ("event_id");
+CREATE INDEX IF NOT EXISTS "idx_event_tags_tag_id" ON "event_tags" ("tag_id");
+
+CREATE TABLE "event_pricing" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "event_id" uuid NOT NULL,
+    "section_id" uuid NOT NULL,
+    "price_multiplier" decimal NOT NULL DEFAULT 1,
+    "is_active" boolean DEFAULT true,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_event_pricing_event_id" ON "event_pricing" ("event_id");
+CREATE INDEX IF NOT EXISTS "idx_event_pricing_section_id" ON "event_pricing" ("section_id");
+
+CREATE TABLE "venue_conflict_overrides" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "event_id" uuid NOT NULL,
+    "conflicting_event_id" uuid NOT NULL,
+    "physical_venue_id" uuid,
+    "admin_id" uuid NOT NULL,
+    "reason" text NOT NULL,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_venue_conflict_overrides_event_id" ON "venue_conflict_overrides" ("event_id");
+
+CREATE TABLE "ticket_types" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "event_id" uuid NOT NULL,
+    "section_id" uuid,
+    "name" varchar(100) NOT NULL,
+    "capacity" bigint NOT NULL,
+    "price_multiplier" decimal NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_ticket_types_section_id" ON "ticket_types" ("section_id");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_ticket_type_event_name" ON "ticket_types" ("event_id","name");
+
+CREATE TABLE "event_series" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "name" varchar(255) NOT NULL,
+    "source_event_id" uuid NOT NULL,
+    "frequency" varchar(20) NOT NULL,
+    "interval" bigint NOT NULL DEFAULT 1,
+    "until" timestamptz,
+    "created_by" uuid NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "chk_event_series_frequency" CHECK (frequency IN ('WEEKLY', 'MONTHLY', 'CUSTOM'))
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_event_series_source_event_id" ON "event_series" ("source_event_id");
+
+CREATE TABLE "promotion_slots" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "event_id" uuid NOT NULL,
+    "promoted_event_id" uuid NOT NULL,
+    "position" bigint NOT NULL DEFAULT 1,
+    "created_by" uuid NOT NULL,
+    "starts_at" timestamptz,
+    "ends_at" timestamptz,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_promotion_slot_unique" ON "promotion_slots" ("event_id","promoted_event_id");
+CREATE INDEX IF NOT EXISTS "idx_promotion_slots_event_id" ON "promotion_slots" ("event_id");
+
+CREATE TABLE "promotion_metrics" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "event_id" uuid NOT NULL,
+    "promoted_event_id" uuid NOT NULL,
+    "date" date NOT NULL,
+    "source" varchar(10) NOT NULL DEFAULT 'AUTO',
+    "impressions" bigint NOT NULL DEFAULT 0,
+    "clicks" bigint NOT NULL DEFAULT 0,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_promotion_metric_unique" ON "promotion_metrics" ("event_id","promoted_event_id","date");
+CREATE INDEX IF NOT EXISTS "idx_promotion_metrics_event_id" ON "promotion_metrics" ("event_id");
+
+CREATE TABLE "bookings" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "user_id" uuid NOT NULL,
+    "event_id" uuid NOT NULL,
+    "total_seats" bigint NOT NULL,
+    "total_price" decimal NOT NULL,
+    "status" varchar(20) DEFAULT 'CONFIRMED',
+    "booking_ref" text NOT NULL,
+    "version" bigint NOT NULL DEFAULT 1,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "cancelled_at" timestamptz,
+    "checked_in_at" timestamptz,
+    "currency" varchar(3) NOT NULL DEFAULT 'INR',
+    "exchange_rate" decimal NOT NULL DEFAULT 1,
+    "base_total_price" decimal NOT NULL DEFAULT 0,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "uni_bookings_booking_ref" UNIQUE ("booking_ref"),
+    CONSTRAINT "chk_bookings_status" CHECK (status IN ('CONFIRMED', 'CONFIRMING', 'PENDING', 'CANCELLED'))
+);
+CREATE INDEX IF NOT EXISTS "idx_bookings_status" ON "bookings" ("status");
+CREATE INDEX IF NOT EXISTS "idx_bookings_event_id" ON "bookings" ("event_id");
+CREATE INDEX IF NOT EXISTS "idx_bookings_user_id" ON "bookings" ("user_id");
+
+CREATE TABLE "seat_bookings" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "booking_id" uuid NOT NULL,
+    "event_id" uuid NOT NULL,
+    "seat_id" text NOT NULL,
+    "section_id" uuid NOT NULL,
+    "seat_price" decimal NOT NULL,
+    "created_at" timestamptz,
+    "section_name" varchar(100) NOT NULL DEFAULT '',
+    "base_price" decimal NOT NULL DEFAULT 0,
+    "price_multiplier" decimal NOT NULL DEFAULT 1,
+    "service_fee" decimal NOT NULL DEFAULT 0,
+    "tax" decimal NOT NULL DEFAULT 0,
+    "total_price" decimal NOT NULL DEFAULT 0,
+    "non_refundable" decimal NOT NULL DEFAULT 0,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_seat_bookings_seat_id" ON "seat_bookings" ("seat_id");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_unique_seat_event" ON "seat_bookings" ("event_id","seat_id");
+CREATE INDEX IF NOT EXISTS "idx_seat_bookings_event_id" ON "seat_bookings" ("event_id");
+CREATE INDEX IF NOT EXISTS "idx_seat_bookings_booking_id" ON "seat_bookings" ("booking_id");
+
+CREATE TABLE "ticket_bookings" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "booking_id" uuid NOT NULL,
+    "event_id" uuid NOT NULL,
+    "ticket_type_id" uuid NOT NULL,
+    "section_id" uuid,
+    "quantity" bigint NOT NULL,
+    "unit_price" decimal NOT NULL,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_ticket_bookings_event_id" ON "ticket_bookings" ("event_id");
+CREATE INDEX IF NOT EXISTS "idx_ticket_bookings_booking_id" ON "ticket_bookings" ("booking_id");
+CREATE INDEX IF NOT EXISTS "idx_ticket_bookings_ticket_type_id" ON "ticket_bookings" ("ticket_type_id");
+
+CREATE TABLE "payments" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "booking_id" uuid NOT NULL,
+    "amount" decimal NOT NULL,
+    "currency" varchar(3) DEFAULT 'INR',
+    "status" varchar(20) DEFAULT 'PENDING',
+    "payment_method" varchar(50),
+    "transaction_id" text,
+    "processed_at" timestamptz,
+    "failure_reason" text,
+    "attempts" bigint NOT NULL DEFAULT 0,
+    "next_retry_at" timestamptz,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "uni_payments_transaction_id" UNIQUE ("transaction_id"),
+    CONSTRAINT "chk_payments_status" CHECK (status IN ('PENDING', 'AUTHORIZED', 'COMPLETED', 'FAILED', 'REFUNDED', 'VOIDED'))
+);
+CREATE INDEX IF NOT EXISTS "idx_payments_next_retry_at" ON "payments" ("next_retry_at");
+CREATE INDEX IF NOT EXISTS "idx_payments_booking_id" ON "payments" ("booking_id");
+
+CREATE TABLE "booking_charges" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "booking_id" uuid NOT NULL,
+    "type" varchar(20) NOT NULL,
+    "name" varchar(100) NOT NULL,
+    "rate" decimal NOT NULL DEFAULT 0,
+    "amount" decimal NOT NULL,
+    "refundable" boolean NOT NULL DEFAULT true,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "chk_booking_charges_type" CHECK (type IN ('BASE', 'SECTION', 'SERVICE_FEE', 'TAX'))
+);
+CREATE INDEX IF NOT EXISTS "idx_booking_charges_booking_id" ON "booking_charges" ("booking_id");
+
+CREATE TABLE "booking_sagas" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "booking_id" uuid NOT NULL,
+    "user_id" uuid NOT NULL,
+    "event_id" uuid NOT NULL,
+    "hold_id" varchar(100) NOT NULL,
+    "transaction_id" varchar(100),
+    "converts_waitlist" boolean NOT NULL DEFAULT false,
+    "status" varchar(20) NOT NULL,
+    "current_step" varchar(30),
+    "completed_steps" bigint NOT NULL DEFAULT 0,
+    "last_error" text,
+    "recovery_attempts" bigint NOT NULL DEFAULT 0,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "finished_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_booking_sagas_booking_id" ON "booking_sagas" ("booking_id");
+CREATE INDEX IF NOT EXISTS "idx_booking_sagas_status_updated" ON "booking_sagas" ("status","updated_at");
+
+CREATE TABLE "event_reviews" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "event_id" uuid NOT NULL,
+    "user_id" uuid NOT NULL,
+    "booking_id" uuid NOT NULL,
+    "rating" bigint NOT NULL,
+    "comment" text,
+    "status" varchar(20) NOT NULL DEFAULT 'PUBLISHED',
+    "moderated_by" uuid,
+    "moderated_at" timestamptz,
+    "moderation_note" varchar(500),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "chk_event_reviews_rating" CHECK (rating BETWEEN 1 AND 5)
+);
+CREATE INDEX IF NOT EXISTS "idx_event_reviews_event_id" ON "event_reviews" ("event_id");
+CREATE INDEX IF NOT EXISTS "idx_event_reviews_status" ON "event_reviews" ("status");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_event_review_user" ON "event_reviews" ("event_id","user_id");
+
+CREATE TABLE "support_tickets" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "number" varchar(20) NOT NULL,
+    "user_id" uuid NOT NULL,
+    "booking_id" uuid,
+    "event_id" uuid,
+    "category" varchar(20) NOT NULL,
+    "subject" varchar(200) NOT NULL,
+    "status" varchar(20) NOT NULL DEFAULT 'OPEN',
+    "last_reply_at" timestamptz NOT NULL,
+    "resolved_at" timestamptz,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "chk_support_tickets_category" CHECK (category IN ('REFUND', 'ACCESS', 'PAYMENT', 'OTHER')),
+    CONSTRAINT "chk_support_tickets_status" CHECK (status IN ('OPEN', 'PENDING', 'RESOLVED'))
+);
+CREATE INDEX IF NOT EXISTS "idx_support_tickets_status" ON "support_tickets" ("status");
+CREATE INDEX IF NOT EXISTS "idx_support_tickets_event_id" ON "support_tickets" ("event_id");
+CREATE INDEX IF NOT EXISTS "idx_support_tickets_booking_id" ON "support_tickets" ("booking_id");
+CREATE INDEX IF NOT EXISTS "idx_support_tickets_user_id" ON "support_tickets" ("user_id");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_support_tickets_number" ON "support_tickets" ("number");
+
+CREATE TABLE "support_messages" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "ticket_id" uuid NOT NULL,
+    "author_id" uuid NOT NULL,
+    "author_role" varchar(10) NOT NULL,
+    "body" text NOT NULL,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "chk_support_messages_author_role" CHECK (author_role IN ('USER', 'ADMIN'))
+);
+CREATE INDEX IF NOT EXISTS "idx_support_messages_ticket_id" ON "support_messages" ("ticket_id");
+
+CREATE TABLE "document_archives" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "user_id" uuid NOT NULL,
+    "status" varchar(20) NOT NULL,
+    "storage_key" varchar(255),
+    "size_bytes" bigint NOT NULL DEFAULT 0,
+    "booking_count" bigint NOT NULL DEFAULT 0,
+    "ticket_count" bigint NOT NULL DEFAULT 0,
+    "invoice_count" bigint NOT NULL DEFAULT 0,
+    "error" text,
+    "expires_at" timestamptz,
+    "created_at" timestamptz,
+    "completed_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "chk_document_archives_status" CHECK (status IN ('PROCESSING', 'READY', 'FAILED', 'EXPIRED'))
+);
+CREATE INDEX IF NOT EXISTS "idx_document_archives_status" ON "document_archives" ("status");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_document_archives_one_processing" ON "document_archives" ("user_id") WHERE status = 'PROCESSING';
+CREATE INDEX IF NOT EXISTS "idx_document_archives_user_id" ON "document_archives" ("user_id");
+CREATE INDEX IF NOT EXISTS "idx_document_archives_expires_at" ON "document_archives" ("expires_at");
+
+CREATE TABLE "jobs" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "type" varchar(50) NOT NULL,
+    "owner_id" uuid NOT NULL,
+    "status" varchar(20) NOT NULL,
+    "progress" bigint NOT NULL DEFAULT 0,
+    "progress_message" varchar(255),
+    "params" jsonb NOT NULL DEFAULT '{}',
+    "cancel_requested" boolean NOT NULL DEFAULT false,
+    "error" text,
+    "result_key" varchar(255),
+    "result_name" varchar(255),
+    "result_type" varchar(100),
+    "result_size" bigint NOT NULL DEFAULT 0,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "started_at" timestamptz,
+    "heartbeat_at" timestamptz,
+    "finished_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "chk_jobs_progress" CHECK (progress BETWEEN 0 AND 100),
+    CONSTRAINT "chk_jobs_status" CHECK (status IN ('QUEUED', 'RUNNING', 'SUCCEEDED', 'FAILED', 'CANCELLED'))
+);
+CREATE INDEX IF NOT EXISTS "idx_jobs_finished_at" ON "jobs" ("finished_at");
+CREATE INDEX IF NOT EXISTS "idx_jobs_status_created" ON "jobs" ("status","created_at");
+CREATE INDEX IF NOT EXISTS "idx_jobs_owner_id" ON "jobs" ("owner_id");
+CREATE INDEX IF NOT EXISTS "idx_jobs_type" ON "jobs" ("type");
+
+CREATE TABLE "event_favorites" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "user_id" uuid NOT NULL,
+    "event_id" uuid NOT NULL,
+    "sell_out_notified_at" timestamptz,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_event_favorites_user_id" ON "event_favorites" ("user_id");
+CREATE INDEX IF NOT EXISTS "idx_event_favorites_event_id" ON "event_favorites" ("event_id");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_event_favorite_user" ON "event_favorites" ("user_id","event_id");
+
+CREATE TABLE "api_keys" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "name" text NOT NULL,
+    "prefix" text NOT NULL,
+    "key_hash" text NOT NULL,
+    "scopes" jsonb NOT NULL,
+    "owner_id" uuid NOT NULL,
+    "created_by" uuid NOT NULL,
+    "expires_at" timestamptz,
+    "last_used_at" timestamptz,
+    "revoked_at" timestamptz,
+    "revoked_by" uuid,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "previous_key_hash" text,
+    "previous_expires_at" timestamptz,
+    "rotated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_api_keys_owner_id" ON "api_keys" ("owner_id");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_api_keys_key_hash" ON "api_keys" ("key_hash");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_api_keys_prefix" ON "api_keys" ("prefix");
+CREATE INDEX IF NOT EXISTS "idx_api_keys_previous_key_hash" ON "api_keys" ("previous_key_hash");
+CREATE INDEX IF NOT EXISTS "idx_api_keys_revoked_at" ON "api_keys" ("revoked_at");
+
+CREATE TABLE "api_key_usage" (
+    "key_id" uuid,
+    "date" date,
+    "requests" bigint NOT NULL DEFAULT 0,
+    "last_used_at" timestamptz NOT NULL,
+    PRIMARY KEY ("key_id","date")
+);
+
+CREATE TABLE "webhook_endpoints" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "name" text NOT NULL,
+    "description" text,
+    "url" text NOT NULL,
+    "secret" text NOT NULL,
+    "event_types" jsonb NOT NULL,
+    "active" boolean NOT NULL DEFAULT true,
+    "created_by" uuid NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_webhook_endpoints_active" ON "webhook_endpoints" ("active");
+
+CREATE TABLE "webhook_events" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "type" varchar(50) NOT NULL,
+    "dedup_key" varchar(255) NOT NULL,
+    "payload" jsonb NOT NULL,
+    "occurred_at" timestamptz NOT NULL,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_webhook_events_dedup_key" ON "webhook_events" ("dedup_key");
+CREATE INDEX IF NOT EXISTS "idx_webhook_events_type" ON "webhook_events" ("type");
+
+CREATE TABLE "webhook_deliveries" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "endpoint_id" uuid NOT NULL,
+    "event_id" uuid NOT NULL,
+    "event_type" varchar(50) NOT NULL,
+    "status" varchar(20) DEFAULT 'PENDING',
+    "attempts" bigint NOT NULL DEFAULT 0,
+    "next_attempt_at" timestamptz NOT NULL,
+    "last_status_code" bigint,
+    "last_error" text,
+    "delivered_at" timestamptz,
+    "redelivery_of" uuid,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "chk_webhook_deliveries_status" CHECK (status IN ('PENDING', 'SUCCEEDED', 'FAILED'))
+);
+CREATE INDEX IF NOT EXISTS "idx_webhook_deliveries_event_id" ON "webhook_deliveries" ("event_id");
+CREATE INDEX IF NOT EXISTS "idx_webhook_deliveries_endpoint_id" ON "webhook_deliveries" ("endpoint_id");
+CREATE INDEX IF NOT EXISTS "idx_webhook_delivery_due" ON "webhook_deliveries" ("status","next_attempt_at");
+
+CREATE TABLE "webhook_delivery_attempts" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "delivery_id" uuid NOT NULL,
+    "attempt" bigint NOT NULL,
+    "status_code" bigint,
+    "error" text,
+    "response_body" text,
+    "duration_ms" bigint NOT NULL,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_webhook_delivery_attempts_delivery_id" ON "webhook_delivery_attempts" ("delivery_id");
+
+CREATE TABLE "event_capacity_alert_settings" (
+    "event_id" uuid,
+    "enabled" boolean NOT NULL DEFAULT true,
+    "thresholds" jsonb NOT NULL,
+    "updated_by" uuid,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("event_id")
+);
+
+CREATE TABLE "event_capacity_alerts" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "event_id" uuid NOT NULL,
+    "threshold" bigint NOT NULL,
+    "booked_count" bigint NOT NULL,
+    "total_capacity" bigint NOT NULL,
+    "notified" boolean NOT NULL DEFAULT false,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_capacity_alert_threshold" ON "event_capacity_alerts" ("event_id","threshold");
+
+CREATE TABLE "archived_events" (
+    "id" uuid,
+    "name" text NOT NULL,
+    "venue" text,
+    "date_time" timestamptz,
+    "status" varchar(20),
+    "booking_count" bigint NOT NULL DEFAULT 0,
+    "revenue" decimal NOT NULL DEFAULT 0,
+    "event" jsonb NOT NULL,
+    "event_tags" jsonb,
+    "event_pricing" jsonb,
+    "cancellation_policy" jsonb,
+    "archived_at" timestamptz NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_archived_events_archived_at" ON "archived_events" ("archived_at");
+CREATE INDEX IF NOT EXISTS "idx_archived_events_date_time" ON "archived_events" ("date_time");
+
+CREATE TABLE "archived_bookings" (
+    "id" uuid,
+    "event_id" uuid NOT NULL,
+    "user_id" uuid NOT NULL,
+    "booking_ref" text NOT NULL,
+    "status" varchar(20),
+    "total_price" decimal,
+    "booking" jsonb NOT NULL,
+    "seat_bookings" jsonb,
+    "payments" jsonb,
+    "sagas" jsonb,
+    "cancellation" jsonb,
+    "archived_at" timestamptz NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_archived_bookings_user_id" ON "archived_bookings" ("user_id");
+CREATE INDEX IF NOT EXISTS "idx_archived_bookings_event_id" ON "archived_bookings" ("event_id");
+
+CREATE TABLE "event_change_batches" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "event_id" uuid NOT NULL,
+    "venue_before" text,
+    "venue_after" text,
+    "date_time_before" timestamptz,
+    "date_time_after" timestamptz,
+    "changed_by" uuid NOT NULL,
+    "edit_count" bigint NOT NULL DEFAULT 1,
+    "status" varchar(20) NOT NULL DEFAULT 'PENDING',
+    "send_after" timestamptz NOT NULL,
+    "recipient_count" bigint NOT NULL DEFAULT 0,
+    "sent_at" timestamptz,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_event_change_due" ON "event_change_batches" ("status","send_after");
+CREATE INDEX IF NOT EXISTS "idx_event_change_batches_event_id" ON "event_change_batches" ("event_id");
+
+CREATE TABLE "cancellation_policies" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "event_id" uuid NOT NULL,
+    "allow_cancellation" boolean DEFAULT true,
+    "cancellation_deadline" timestamptz,
+    "fee_type" varchar(20) DEFAULT 'NONE',
+    "fee_amount" decimal DEFAULT 0,
+    "refund_processing_days" bigint DEFAULT 5,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "uni_cancellation_policies_event_id" UNIQUE ("event_id"),
+    CONSTRAINT "chk_cancellation_policies_fee_type" CHECK (fee_type IN ('NONE', 'FIXED', 'PERCENTAGE'))
+);
+
+CREATE TABLE "cancellations" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "booking_id" uuid NOT NULL,
+    "requested_at" timestamptz,
+    "processed_at" timestamptz,
+    "cancellation_fee" decimal DEFAULT 0,
+    "refund_amount" decimal DEFAULT 0,
+    "retained_fees" decimal DEFAULT 0,
+    "currency" varchar(3) NOT NULL DEFAULT 'INR',
+    "base_refund" decimal DEFAULT 0,
+    "reason" text,
+    "status" varchar(20) DEFAULT 'PROCESSED',
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "uni_cancellations_booking_id" UNIQUE ("booking_id"),
+    CONSTRAINT "chk_cancellations_status" CHECK (status IN ('PROCESSED', 'FAILED'))
+);
+
+CREATE TABLE "waitlist_entries" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "user_id" uuid NOT NULL,
+    "event_id" uuid NOT NULL,
+    "position" bigint NOT NULL,
+    "quantity" bigint NOT NULL,
+    "status" varchar(20) NOT NULL,
+    "preferences" jsonb,
+    "joined_at" timestamptz NOT NULL,
+    "notified_at" timestamptz,
+    "expires_at" timestamptz,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "sms_phone" varchar(20),
+    "push_token" varchar(512),
+    "requeue_count" bigint NOT NULL DEFAULT 0,
+    "expired_without_action" bigint NOT NULL DEFAULT 0,
+    "converted_at" timestamptz,
+    "booking_window_used" decimal,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_waitlist_entries_status" ON "waitlist_entries" ("status");
+CREATE INDEX IF NOT EXISTS "idx_waitlist_entries_position" ON "waitlist_entries" ("position");
+CREATE INDEX IF NOT EXISTS "idx_waitlist_entries_event_id" ON "waitlist_entries" ("event_id");
+CREATE INDEX IF NOT EXISTS "idx_waitlist_entries_user_id" ON "waitlist_entries" ("user_id");
+
+CREATE TABLE "waitlist_notifications" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "waitlist_entry_id" uuid NOT NULL,
+    "notification_type" varchar(50) NOT NULL,
+    "channel" varchar(20) NOT NULL,
+    "status" varchar(20) NOT NULL,
+    "message_id" text,
+    "error_message" text,
+    "sent_at" timestamptz,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "opened_at" timestamptz,
+    "escalation_attempts" bigint NOT NULL DEFAULT 0,
+    "escalated_at" timestamptz,
+    "escalated_channels" varchar(50),
+    "escalation_error" text,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_waitlist_notifications_status" ON "waitlist_notifications" ("status");
+CREATE INDEX IF NOT EXISTS "idx_waitlist_notifications_waitlist_entry_id" ON "waitlist_notifications" ("waitlist_entry_id");
+
+CREATE TABLE "waitlist_analytics" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "event_id" uuid NOT NULL,
+    "date" date NOT NULL,
+    "total_joined" bigint DEFAULT 0,
+    "total_left" bigint DEFAULT 0,
+    "total_notified" bigint DEFAULT 0,
+    "total_converted" bigint DEFAULT 0,
+    "avg_wait_time_minutes" bigint,
+    "peak_queue_length" bigint DEFAULT 0,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_event_date" ON "waitlist_analytics" ("date");
+CREATE INDEX IF NOT EXISTS "idx_waitlist_analytics_event_id" ON "waitlist_analytics" ("event_id");
+
+CREATE TABLE "yearly_recap_subscriptions" (
+    "user_id" uuid,
+    "opted_in" boolean NOT NULL DEFAULT false,
+    "last_sent_year" bigint NOT NULL DEFAULT 0,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("user_id")
+);
+CREATE INDEX IF NOT EXISTS "idx_yearly_recap_subscriptions_opted_in" ON "yearly_recap_subscriptions" ("opted_in");
+
+CREATE TABLE "analytics_report_subscriptions" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "admin_id" uuid NOT NULL,
+    "frequency" varchar(10) NOT NULL,
+    "sections" varchar(255) NOT NULL,
+    "enabled" boolean NOT NULL DEFAULT false,
+    "last_period_start" timestamptz,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "chk_analytics_report_subscriptions_frequency" CHECK (frequency IN ('WEEKLY', 'MONTHLY'))
+);
+CREATE INDEX IF NOT EXISTS "idx_analytics_report_subscriptions_enabled" ON "analytics_report_subscriptions" ("enabled");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_report_subscriptions_admin_frequency" ON "analytics_report_subscriptions" ("admin_id","frequency");
+
+CREATE TABLE "analytics_share_links" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "event_id" uuid NOT NULL,
+    "label" varchar(100) NOT NULL,
+    "sections" varchar(255) NOT NULL,
+    "expires_at" timestamptz NOT NULL,
+    "revoked_at" timestamptz,
+    "created_by" uuid NOT NULL,
+    "view_count" bigint NOT NULL DEFAULT 0,
+    "last_viewed_at" timestamptz,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_analytics_share_links_event_id" ON "analytics_share_links" ("event_id");
+
+CREATE TABLE "daily_bookings_agg" (
+    "date" date,
+    "total_bookings" bigint NOT NULL DEFAULT 0,
+    "confirmed_bookings" bigint NOT NULL DEFAULT 0,
+    "cancelled_bookings" bigint NOT NULL DEFAULT 0,
+    "revenue" decimal NOT NULL DEFAULT 0,
+    "average_value" decimal NOT NULL DEFAULT 0,
+    "refreshed_at" timestamptz NOT NULL,
+    PRIMARY KEY ("date")
+);
+
+CREATE TABLE "event_revenue_agg" (
+    "event_id" uuid,
+    "event_name" text NOT NULL,
+    "venue" text,
+    "date_time" timestamptz,
+    "booking_count" bigint NOT NULL DEFAULT 0,
+    "revenue" decimal NOT NULL DEFAULT 0,
+    "tickets_sold" bigint NOT NULL DEFAULT 0,
+    "capacity" bigint NOT NULL DEFAULT 0,
+    "average_rating" decimal NOT NULL DEFAULT 0,
+    "review_count" bigint NOT NULL DEFAULT 0,
+    "refreshed_at" timestamptz NOT NULL,
+    PRIMARY KEY ("event_id")
+);
+CREATE INDEX IF NOT EXISTS "idx_event_revenue_agg_booking_count" ON "event_revenue_agg" ("booking_count");
+
+CREATE TABLE "tag_popularity_agg" (
+    "tag_id" uuid,
+    "tag_name" text NOT NULL,
+    "event_count" bigint NOT NULL DEFAULT 0,
+    "total_bookings" bigint NOT NULL DEFAULT 0,
+    "total_revenue" decimal NOT NULL DEFAULT 0,
+    "avg_utilization" decimal NOT NULL DEFAULT 0,
+    "refreshed_at" timestamptz NOT NULL,
+    PRIMARY KEY ("tag_id")
+);
+CREATE INDEX IF NOT EXISTS "idx_tag_popularity_agg_total_bookings" ON "tag_popularity_agg" ("total_bookings");
+
+CREATE TABLE "outbox_messages" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "aggregate_type" varchar(50) NOT NULL,
+    "aggregate_id" uuid NOT NULL,
+    "dedup_key" varchar(255) NOT NULL,
+    "payload" jsonb NOT NULL,
+    "status" varchar(20) DEFAULT 'PENDING',
+    "attempts" bigint NOT NULL DEFAULT 0,
+    "next_attempt_at" timestamptz NOT NULL,
+    "last_error" text,
+    "published_at" timestamptz,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "chk_outbox_messages_status" CHECK (status IN ('PENDING', 'PUBLISHED', 'FAILED'))
+);
+CREATE INDEX IF NOT EXISTS "idx_outbox_pending" ON "outbox_messages" ("status","next_attempt_at");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_outbox_messages_dedup_key" ON "outbox_messages" ("dedup_key");
+CREATE INDEX IF NOT EXISTS "idx_outbox_aggregate" ON "outbox_messages" ("aggregate_type","aggregate_id");
+
+CREATE TABLE "domain_events" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "type" varchar(50) NOT NULL,
+    "topic" varchar(50) NOT NULL,
+    "aggregate_id" uuid NOT NULL,
+    "dedup_key" varchar(255) NOT NULL,
+    "payload" jsonb NOT NULL,
+    "status" varchar(20) DEFAULT 'PENDING',
+    "attempts" bigint NOT NULL DEFAULT 0,
+    "next_attempt_at" timestamptz NOT NULL,
+    "last_error" text,
+    "published_at" timestamptz,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "chk_domain_events_status" CHECK (status IN ('PENDING', 'PUBLISHED', 'FAILED'))
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_domain_events_dedup_key" ON "domain_events" ("dedup_key");
+CREATE INDEX IF NOT EXISTS "idx_domain_events_aggregate_id" ON "domain_events" ("aggregate_id");
+CREATE INDEX IF NOT EXISTS "idx_domain_events_type" ON "domain_events" ("type");
+CREATE INDEX IF NOT EXISTS "idx_domain_events_pending" ON "domain_events" ("status","next_attempt_at");
+
+-- Optimistic locking lookups on bookings
+CREATE INDEX IF NOT EXISTS idx_bookings_id_version
+    ON bookings (id, version);
+
+-- Unopened spot-available emails the waitlist escalation job polls for
+CREATE INDEX IF NOT EXISTS idx_waitlist_notifications_escalation
+    ON waitlist_notifications (created_at)
+    WHERE notification_type = 'SPOT_AVAILABLE' AND opened_at IS NULL AND escalated_at IS NULL;
@@ -0,0 +1,13 @@
+-- Per-user notification channel toggles and quiet hours
+
+CREATE TABLE "notification_preferences" (
+    "user_id" uuid,
+    "channels" jsonb NOT NULL DEFAULT '{}',
+    "quiet_hours_enabled" boolean NOT NULL DEFAULT false,
+    "quiet_hours_start" varchar(5) NOT NULL DEFAULT '22:00',
+    "quiet_hours_end" varchar(5) NOT NULL DEFAULT '08:00',
+    "timezone" varchar(64) NOT NULL DEFAULT 'UTC',
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("user_id")
+);
@@ -0,0 +1,4 @@
+-- Phone number and device token for SMS and push notifications
+
+ALTER TABLE "notification_preferences" ADD COLUMN IF NOT EXISTS "sms_phone" varchar(20);
+ALTER TABLE "notification_preferences" ADD COLUMN IF NOT EXISTS "push_token" varchar(512);
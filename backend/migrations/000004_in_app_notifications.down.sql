@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "in_app_notifications";
@@ -0,0 +1,19 @@
+-- Notifications shown in the in-app notification center
+
+CREATE TABLE "in_app_notifications" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "user_id" uuid NOT NULL,
+    "source_id" uuid NOT NULL,
+    "type" varchar(50) NOT NULL,
+    "title" varchar(200) NOT NULL,
+    "body" text NOT NULL,
+    "event_id" uuid,
+    "booking_id" uuid,
+    "waitlist_entry_id" uuid,
+    "read_at" timestamptz,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_in_app_notifications_user_created" ON "in_app_notifications" ("user_id","created_at");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_in_app_notifications_source_id" ON "in_app_notifications" ("source_id");
+CREATE INDEX IF NOT EXISTS "idx_in_app_notifications_unread" ON "in_app_notifications" ("user_id") WHERE "read_at" IS NULL;
@@ -0,0 +1,23 @@
+-- Admin-managed email templates, versioned per notification type and locale
+
+CREATE TABLE "notification_template_versions" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "type" varchar(50) NOT NULL,
+    "locale" varchar(10) NOT NULL,
+    "version" bigint NOT NULL,
+    "format" varchar(20) NOT NULL,
+    "subject" text NOT NULL,
+    "source" text NOT NULL,
+    "html_body" text NOT NULL,
+    "text_body" text NOT NULL,
+    "comment" varchar(500),
+    "is_active" boolean NOT NULL DEFAULT false,
+    "created_by" uuid NOT NULL,
+    "activated_at" timestamptz,
+    "activated_by" uuid,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "chk_notification_template_versions_format" CHECK (format IN ('GO_TEMPLATE', 'MJML'))
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_notification_template_version" ON "notification_template_versions" ("type","locale","version");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_notification_template_active" ON "notification_template_versions" ("type","locale") WHERE "is_active";
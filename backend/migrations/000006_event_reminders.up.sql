@@ -0,0 +1,13 @@
+-- Event reminders sent to attendees, one per attendee and offset
+
+CREATE TABLE "event_reminders" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "event_id" uuid NOT NULL,
+    "user_id" uuid NOT NULL,
+    "offset_minutes" bigint NOT NULL,
+    "booking_id" uuid NOT NULL,
+    "event_date_time" timestamptz NOT NULL,
+    "sent_at" timestamptz NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_event_reminder_attendee" ON "event_reminders" ("event_id","user_id","offset_minutes");
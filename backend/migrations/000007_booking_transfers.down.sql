@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "booking_ownership_changes";
+DROP TABLE IF EXISTS "booking_transfers";
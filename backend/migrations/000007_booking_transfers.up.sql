@@ -0,0 +1,43 @@
+-- Offers of bookings to other users, and the audit trail of bookings changing hands
+
+CREATE TABLE "booking_transfers" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "booking_id" uuid NOT NULL,
+    "event_id" uuid NOT NULL,
+    "from_user_id" uuid NOT NULL,
+    "to_user_id" uuid NOT NULL,
+    "recipient_email" varchar(255) NOT NULL,
+    "seat_ids" jsonb NOT NULL,
+    "message" varchar(500),
+    "status" varchar(20) NOT NULL DEFAULT 'PENDING',
+    "expires_at" timestamptz NOT NULL,
+    "responded_at" timestamptz,
+    "result_booking_id" uuid,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "chk_booking_transfers_status" CHECK (status IN ('PENDING', 'ACCEPTED', 'DECLINED', 'CANCELLED', 'EXPIRED'))
+);
+CREATE INDEX IF NOT EXISTS "idx_booking_transfers_booking_id" ON "booking_transfers" ("booking_id");
+CREATE INDEX IF NOT EXISTS "idx_booking_transfers_status_expires" ON "booking_transfers" ("status","expires_at");
+CREATE INDEX IF NOT EXISTS "idx_booking_transfers_to_user_id" ON "booking_transfers" ("to_user_id");
+CREATE INDEX IF NOT EXISTS "idx_booking_transfers_from_user_id" ON "booking_transfers" ("from_user_id");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_booking_transfers_one_pending" ON "booking_transfers" ("booking_id") WHERE status = 'PENDING';
+
+CREATE TABLE "booking_ownership_changes" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "booking_id" uuid NOT NULL,
+    "source_booking_id" uuid NOT NULL,
+    "transfer_id" uuid,
+    "from_user_id" uuid NOT NULL,
+    "to_user_id" uuid NOT NULL,
+    "reason" varchar(20) NOT NULL,
+    "seat_count" bigint NOT NULL,
+    "previous_ref" varchar(50) NOT NULL,
+    "new_ref" varchar(50) NOT NULL,
+    "remaining_ref" varchar(50),
+    "changed_at" timestamptz NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_booking_ownership_changes_booking_id" ON "booking_ownership_changes" ("booking_id");
+CREATE INDEX IF NOT EXISTS "idx_booking_ownership_changes_source_booking_id" ON "booking_ownership_changes" ("source_booking_id");
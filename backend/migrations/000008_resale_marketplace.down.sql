@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "resale_sales";
+DROP TABLE IF EXISTS "resale_listings";
@@ -0,0 +1,55 @@
+-- Resale listings of booked seats, and the sales that moved them to buyers
+
+CREATE TABLE "resale_listings" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "booking_id" uuid NOT NULL,
+    "event_id" uuid NOT NULL,
+    "seller_id" uuid NOT NULL,
+    "seat_ids" jsonb NOT NULL,
+    "seat_count" bigint NOT NULL,
+    "face_value" decimal NOT NULL,
+    "price" decimal NOT NULL,
+    "currency" varchar(3) NOT NULL,
+    "status" varchar(20) NOT NULL DEFAULT 'ACTIVE',
+    "expires_at" timestamptz NOT NULL,
+    "buyer_id" uuid,
+    "sold_at" timestamptz,
+    "result_booking_id" uuid,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "chk_resale_listings_status" CHECK (status IN ('ACTIVE', 'SOLD', 'CANCELLED', 'EXPIRED'))
+);
+CREATE INDEX IF NOT EXISTS "idx_resale_listings_seller_id" ON "resale_listings" ("seller_id");
+CREATE INDEX IF NOT EXISTS "idx_resale_listings_event_status" ON "resale_listings" ("event_id","status");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_resale_listings_one_active" ON "resale_listings" ("booking_id") WHERE status = 'ACTIVE';
+CREATE INDEX IF NOT EXISTS "idx_resale_listings_booking_id" ON "resale_listings" ("booking_id");
+
+CREATE TABLE "resale_sales" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "listing_id" uuid NOT NULL,
+    "event_id" uuid NOT NULL,
+    "seller_id" uuid NOT NULL,
+    "buyer_id" uuid NOT NULL,
+    "booking_id" uuid NOT NULL,
+    "ownership_change_id" uuid NOT NULL,
+    "price" decimal NOT NULL,
+    "fee" decimal NOT NULL,
+    "seller_payout" decimal NOT NULL,
+    "currency" varchar(3) NOT NULL,
+    "payment_method" varchar(50),
+    "transaction_id" varchar(100) NOT NULL,
+    "payout_status" varchar(20) NOT NULL DEFAULT 'PENDING',
+    "payout_reference" varchar(100),
+    "paid_out_at" timestamptz,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "uni_resale_sales_transaction_id" UNIQUE ("transaction_id"),
+    CONSTRAINT "chk_resale_sales_payout_status" CHECK (payout_status IN ('PENDING', 'PAID'))
+);
+CREATE INDEX IF NOT EXISTS "idx_resale_sales_payout_status" ON "resale_sales" ("payout_status");
+CREATE INDEX IF NOT EXISTS "idx_resale_sales_buyer_id" ON "resale_sales" ("buyer_id");
+CREATE INDEX IF NOT EXISTS "idx_resale_sales_seller_id" ON "resale_sales" ("seller_id");
+CREATE INDEX IF NOT EXISTS "idx_resale_sales_event_id" ON "resale_sales" ("event_id");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_resale_sales_listing_id" ON "resale_sales" ("listing_id");
@@ -0,0 +1,22 @@
+-- Custom roles granting admin permissions, and the users holding them
+
+CREATE TABLE "roles" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "name" varchar(50) NOT NULL,
+    "description" text,
+    "permissions" jsonb NOT NULL,
+    "created_by" uuid NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_roles_name" ON "roles" ("name");
+
+CREATE TABLE "user_roles" (
+    "user_id" uuid,
+    "role_id" uuid,
+    "assigned_by" uuid NOT NULL,
+    "created_at" timestamptz,
+    PRIMARY KEY ("user_id","role_id")
+);
+CREATE INDEX IF NOT EXISTS "idx_user_roles_role_id" ON "user_roles" ("role_id");
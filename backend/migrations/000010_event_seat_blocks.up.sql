@@ -0,0 +1,18 @@
+-- Seats admins hold back from sale for one event, kept after release as history
+
+CREATE TABLE "event_seat_blocks" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "event_id" uuid NOT NULL,
+    "seat_id" uuid NOT NULL,
+    "reason" varchar(20) NOT NULL,
+    "note" varchar(255),
+    "blocked_by" uuid NOT NULL,
+    "created_at" timestamptz,
+    "released_at" timestamptz,
+    "released_by" uuid,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "chk_event_seat_blocks_reason" CHECK (reason IN ('VIP', 'PRESS', 'EQUIPMENT', 'OTHER'))
+);
+CREATE INDEX IF NOT EXISTS "idx_event_seat_blocks_event_id" ON "event_seat_blocks" ("event_id");
+CREATE INDEX IF NOT EXISTS "idx_event_seat_blocks_seat_id" ON "event_seat_blocks" ("seat_id");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_event_seat_blocks_active" ON "event_seat_blocks" ("event_id","seat_id") WHERE released_at IS NULL;
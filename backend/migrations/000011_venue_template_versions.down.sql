@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS "idx_venue_templates_lineage_version";
+DROP INDEX IF EXISTS "idx_venue_templates_name";
+ALTER TABLE "venue_templates" ADD CONSTRAINT "uni_venue_templates_name" UNIQUE ("name");
+
+ALTER TABLE "venue_templates" DROP COLUMN IF EXISTS "previous_version_id";
+ALTER TABLE "venue_templates" DROP COLUMN IF EXISTS "version";
+ALTER TABLE "venue_templates" DROP COLUMN IF EXISTS "lineage_id";
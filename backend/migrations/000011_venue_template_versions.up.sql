@@ -0,0 +1,13 @@
+-- Venue template versions: section edits of a template events use create a new
+-- version, and every version of a template shares its lineage and name
+
+ALTER TABLE "venue_templates" ADD COLUMN IF NOT EXISTS "lineage_id" uuid;
+ALTER TABLE "venue_templates" ADD COLUMN IF NOT EXISTS "version" bigint NOT NULL DEFAULT 1;
+ALTER TABLE "venue_templates" ADD COLUMN IF NOT EXISTS "previous_version_id" uuid;
+
+UPDATE "venue_templates" SET "lineage_id" = "id" WHERE "lineage_id" IS NULL;
+ALTER TABLE "venue_templates" ALTER COLUMN "lineage_id" SET NOT NULL;
+
+ALTER TABLE "venue_templates" DROP CONSTRAINT IF EXISTS "uni_venue_templates_name";
+CREATE INDEX IF NOT EXISTS "idx_venue_templates_name" ON "venue_templates" ("name");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_venue_templates_lineage_version" ON "venue_templates" ("lineage_id","version");
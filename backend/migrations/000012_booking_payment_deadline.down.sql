@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS "idx_bookings_payment_due_at";
+ALTER TABLE "bookings" DROP COLUMN IF EXISTS "payment_due_at";
@@ -0,0 +1,6 @@
+-- Payment deadline of pending bookings, after which they are cancelled and
+-- their seats freed. Bookings already pending keep no deadline and are left
+-- to the payment retries.
+
+ALTER TABLE "bookings" ADD COLUMN IF NOT EXISTS "payment_due_at" timestamptz;
+CREATE INDEX IF NOT EXISTS "idx_bookings_payment_due_at" ON "bookings" ("payment_due_at") WHERE status = 'PENDING';
@@ -0,0 +1,6 @@
+DELETE FROM "cancellations" WHERE "partial";
+DROP INDEX IF EXISTS "idx_cancellations_booking_whole";
+DROP INDEX IF EXISTS "idx_cancellations_booking_id";
+ALTER TABLE "cancellations" ADD CONSTRAINT "uni_cancellations_booking_id" UNIQUE ("booking_id");
+ALTER TABLE "cancellations" DROP COLUMN IF EXISTS "seat_ids";
+ALTER TABLE "cancellations" DROP COLUMN IF EXISTS "partial";
@@ -0,0 +1,8 @@
+-- Cancellations of some seats of a booking. A booking can now have several
+-- cancellations, but still only one for the whole booking.
+
+ALTER TABLE "cancellations" ADD COLUMN IF NOT EXISTS "partial" boolean NOT NULL DEFAULT false;
+ALTER TABLE "cancellations" ADD COLUMN IF NOT EXISTS "seat_ids" jsonb;
+ALTER TABLE "cancellations" DROP CONSTRAINT IF EXISTS "uni_cancellations_booking_id";
+CREATE INDEX IF NOT EXISTS "idx_cancellations_booking_id" ON "cancellations" ("booking_id");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_cancellations_booking_whole" ON "cancellations" ("booking_id") WHERE partial = false;
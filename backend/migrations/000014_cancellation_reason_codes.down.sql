@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS "idx_cancellations_reason_code";
+ALTER TABLE "cancellations" DROP COLUMN IF EXISTS "reason_code";
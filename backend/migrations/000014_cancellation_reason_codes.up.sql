@@ -0,0 +1,6 @@
+-- Structured reason of a cancellation, next to the free text reason. Refunds
+-- of cancelled events are recognised by the reason they were recorded with.
+
+ALTER TABLE "cancellations" ADD COLUMN IF NOT EXISTS "reason_code" varchar(30) NOT NULL DEFAULT 'OTHER';
+UPDATE "cancellations" SET "reason_code" = 'EVENT_CANCELLED' WHERE "reason" LIKE 'Event cancelled: %';
+CREATE INDEX IF NOT EXISTS "idx_cancellations_reason_code" ON "cancellations" ("reason_code");
@@ -0,0 +1,9 @@
+DELETE FROM "cancellations" WHERE "status" IN ('PENDING_APPROVAL', 'REJECTED');
+DROP INDEX IF EXISTS "idx_cancellations_booking_whole";
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_cancellations_booking_whole" ON "cancellations" ("booking_id") WHERE partial = false;
+ALTER TABLE "cancellations" DROP CONSTRAINT IF EXISTS "chk_cancellations_status";
+ALTER TABLE "cancellations" ADD CONSTRAINT "chk_cancellations_status" CHECK (status IN ('PROCESSED', 'FAILED'));
+ALTER TABLE "cancellations" DROP COLUMN IF EXISTS "review_note";
+ALTER TABLE "cancellations" DROP COLUMN IF EXISTS "reviewed_at";
+ALTER TABLE "cancellations" DROP COLUMN IF EXISTS "reviewed_by";
+ALTER TABLE "cancellation_policies" DROP COLUMN IF EXISTS "require_approval";
@@ -0,0 +1,12 @@
+-- Cancellations after the deadline can wait for an admin's approval instead of
+-- being refused. A rejected cancellation doesn't stop the booking from being
+-- cancelled again.
+
+ALTER TABLE "cancellation_policies" ADD COLUMN IF NOT EXISTS "require_approval" boolean NOT NULL DEFAULT false;
+ALTER TABLE "cancellations" ADD COLUMN IF NOT EXISTS "reviewed_by" uuid;
+ALTER TABLE "cancellations" ADD COLUMN IF NOT EXISTS "reviewed_at" timestamptz;
+ALTER TABLE "cancellations" ADD COLUMN IF NOT EXISTS "review_note" text;
+ALTER TABLE "cancellations" DROP CONSTRAINT IF EXISTS "chk_cancellations_status";
+ALTER TABLE "cancellations" ADD CONSTRAINT "chk_cancellations_status" CHECK (status IN ('PROCESSED', 'FAILED', 'PENDING_APPROVAL', 'REJECTED'));
+DROP INDEX IF EXISTS "idx_cancellations_booking_whole";
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_cancellations_booking_whole" ON "cancellations" ("booking_id") WHERE partial = false AND status <> 'REJECTED';
@@ -0,0 +1,5 @@
+-- Events can be created as drafts, hidden from public endpoints, and
+-- published by the lifecycle scheduler at a set time.
+
+ALTER TABLE "events" ADD COLUMN IF NOT EXISTS "publish_at" timestamptz;
+CREATE INDEX IF NOT EXISTS "idx_events_publish_at" ON "events" ("publish_at");
@@ -0,0 +1,5 @@
+-- Tags can be nested under a broader tag (e.g. Music → Jazz). Event filters
+-- and tag analytics include the tags below the one asked for.
+
+ALTER TABLE "tags" ADD COLUMN IF NOT EXISTS "parent_id" uuid;
+CREATE INDEX IF NOT EXISTS "idx_tags_parent_id" ON "tags" ("parent_id");
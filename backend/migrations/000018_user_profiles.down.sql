@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS "user_preferred_tags";
+DROP TABLE IF EXISTS "email_changes";
+DROP INDEX IF EXISTS "idx_users_city";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "city";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "avatar_url";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "phone";
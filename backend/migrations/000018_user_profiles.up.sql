@@ -0,0 +1,33 @@
+-- Profile details users edit from /users/me, the interests they pick, and an
+-- audit trail of email changes confirmed from a link sent to the new address
+
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "phone" varchar(20);
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "avatar_url" varchar(500);
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "city" varchar(100);
+CREATE INDEX IF NOT EXISTS "idx_users_city" ON "users" ("city");
+
+CREATE TABLE "email_changes" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "user_id" uuid NOT NULL,
+    "old_email" varchar(255) NOT NULL,
+    "new_email" varchar(255) NOT NULL,
+    "token_hash" varchar(64) NOT NULL,
+    "status" varchar(20) NOT NULL DEFAULT 'PENDING',
+    "expires_at" timestamptz NOT NULL,
+    "verified_at" timestamptz,
+    "request_ip" varchar(45),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "chk_email_changes_status" CHECK (status IN ('PENDING', 'VERIFIED', 'CANCELLED', 'EXPIRED'))
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_email_changes_token_hash" ON "email_changes" ("token_hash");
+CREATE INDEX IF NOT EXISTS "idx_email_changes_user_id" ON "email_changes" ("user_id");
+
+CREATE TABLE "user_preferred_tags" (
+    "user_id" uuid,
+    "tag_id" uuid,
+    "created_at" timestamptz,
+    PRIMARY KEY ("user_id","tag_id")
+);
+CREATE INDEX IF NOT EXISTS "idx_user_preferred_tags_tag_id" ON "user_preferred_tags" ("tag_id");
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "impersonation_actions";
+DROP TABLE IF EXISTS "impersonation_sessions";
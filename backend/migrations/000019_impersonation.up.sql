@@ -0,0 +1,33 @@
+-- Support admins acting as users, and every request made while they do
+
+CREATE TABLE "impersonation_sessions" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "admin_id" uuid NOT NULL,
+    "user_id" uuid NOT NULL,
+    "reason" varchar(500) NOT NULL,
+    "expires_at" timestamptz NOT NULL,
+    "ended_at" timestamptz,
+    "ended_by" uuid,
+    "request_ip" varchar(45),
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_impersonation_sessions_created_at" ON "impersonation_sessions" ("created_at");
+CREATE INDEX IF NOT EXISTS "idx_impersonation_sessions_user_id" ON "impersonation_sessions" ("user_id");
+CREATE INDEX IF NOT EXISTS "idx_impersonation_sessions_admin_id" ON "impersonation_sessions" ("admin_id");
+
+CREATE TABLE "impersonation_actions" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "session_id" uuid NOT NULL,
+    "admin_id" uuid NOT NULL,
+    "user_id" uuid NOT NULL,
+    "method" varchar(10) NOT NULL,
+    "path" varchar(500) NOT NULL,
+    "route" varchar(255),
+    "status_code" bigint NOT NULL,
+    "duration_ms" bigint NOT NULL DEFAULT 0,
+    "request_ip" varchar(45),
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_impersonation_actions_session_id" ON "impersonation_actions" ("session_id");
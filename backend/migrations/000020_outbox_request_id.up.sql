@@ -0,0 +1,5 @@
+-- X-Request-ID of the API request an outbox message was written for, passed
+-- on to the notification so it can be traced end to end. Messages written
+-- outside a request, by jobs and schedulers, have none.
+
+ALTER TABLE "outbox_messages" ADD COLUMN IF NOT EXISTS "request_id" varchar(128);
@@ -0,0 +1,39 @@
+CREATE TABLE IF NOT EXISTS "domain_events" (
+    "id" uuid DEFAULT uuid_generate_v4(),
+    "type" varchar(50) NOT NULL,
+    "topic" varchar(50) NOT NULL,
+    "aggregate_id" uuid NOT NULL,
+    "dedup_key" varchar(255) NOT NULL,
+    "payload" jsonb NOT NULL,
+    "status" varchar(20) DEFAULT 'PENDING',
+    "attempts" bigint NOT NULL DEFAULT 0,
+    "next_attempt_at" timestamptz NOT NULL,
+    "last_error" text,
+    "published_at" timestamptz,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "chk_domain_events_status" CHECK (status IN ('PENDING', 'PUBLISHED', 'FAILED'))
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_domain_events_dedup_key" ON "domain_events" ("dedup_key");
+CREATE INDEX IF NOT EXISTS "idx_domain_events_aggregate_id" ON "domain_events" ("aggregate_id");
+CREATE INDEX IF NOT EXISTS "idx_domain_events_type" ON "domain_events" ("type");
+CREATE INDEX IF NOT EXISTS "idx_domain_events_pending" ON "domain_events" ("status","next_attempt_at");
+
+INSERT INTO "domain_events" (
+    "id", "type", "topic", "aggregate_id", "dedup_key", "payload", "status",
+    "attempts", "next_attempt_at", "last_error", "published_at", "created_at", "updated_at"
+)
+SELECT
+    "id", "payload"->>'type', "topic", "aggregate_id", substr("dedup_key", length('domain:') + 1), "payload", "status",
+    "attempts", "next_attempt_at", "last_error", "published_at", "created_at", "updated_at"
+FROM "outbox_messages"
+WHERE "topic" <> 'notifications'
+ON CONFLICT DO NOTHING;
+
+DELETE FROM "outbox_messages" WHERE "topic" <> 'notifications';
+
+DROP INDEX IF EXISTS "idx_outbox_pending";
+CREATE INDEX IF NOT EXISTS "idx_outbox_pending" ON "outbox_messages" ("status","next_attempt_at");
+
+ALTER TABLE "outbox_messages" DROP COLUMN IF EXISTS "topic";
@@ -0,0 +1,30 @@
+-- Domain events are recorded as outbox messages under their own topics, so one
+-- table and one relay implementation serve notifications and Kafka alike.
+-- Existing events move over with their state; their dedup keys are prefixed
+-- the way domainevents.NewEvent prefixes them.
+
+ALTER TABLE "outbox_messages" ADD COLUMN IF NOT EXISTS "topic" varchar(50) NOT NULL DEFAULT 'notifications';
+
+DROP INDEX IF EXISTS "idx_outbox_pending";
+CREATE INDEX IF NOT EXISTS "idx_outbox_pending" ON "outbox_messages" ("topic","status","next_attempt_at");
+
+INSERT INTO "outbox_messages" (
+    "id", "topic", "aggregate_type", "aggregate_id", "dedup_key", "payload", "status",
+    "attempts", "next_attempt_at", "last_error", "published_at", "created_at", "updated_at"
+)
+SELECT
+    "id",
+    "topic",
+    CASE "topic"
+        WHEN 'bookings' THEN 'BOOKING'
+        WHEN 'seats' THEN 'SEAT_HOLD'
+        WHEN 'events' THEN 'EVENT'
+        WHEN 'waitlist' THEN 'WAITLIST_ENTRY'
+        ELSE upper("topic")
+    END,
+    "aggregate_id", 'domain:' || "dedup_key", "payload", "status",
+    "attempts", "next_attempt_at", "last_error", "published_at", "created_at", "updated_at"
+FROM "domain_events"
+ON CONFLICT DO NOTHING;
+
+DROP TABLE IF EXISTS "domain_events";
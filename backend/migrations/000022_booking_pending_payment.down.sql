@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS "idx_bookings_payment_due_at";
+CREATE INDEX IF NOT EXISTS "idx_bookings_payment_due_at" ON "bookings" ("payment_due_at") WHERE status = 'PENDING';
+
+UPDATE "bookings" SET "status" = 'PENDING' WHERE "status" = 'PENDING_PAYMENT';
+
+ALTER TABLE "bookings" DROP CONSTRAINT IF EXISTS "chk_bookings_status";
+ALTER TABLE "bookings" ADD CONSTRAINT "chk_bookings_status" CHECK (status IN ('CONFIRMED', 'CONFIRMING', 'PENDING', 'CANCELLED'));
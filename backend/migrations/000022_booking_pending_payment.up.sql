@@ -0,0 +1,13 @@
+-- Bookings whose payment was declined move from PENDING to their own
+-- PENDING_PAYMENT status, so PENDING only means a payment is being taken.
+-- Bookings already waiting on a failed payment move over with their deadline.
+
+ALTER TABLE "bookings" DROP CONSTRAINT IF EXISTS "chk_bookings_status";
+ALTER TABLE "bookings" ADD CONSTRAINT "chk_bookings_status" CHECK (status IN ('CONFIRMED', 'CONFIRMING', 'PENDING', 'PENDING_PAYMENT', 'CANCELLED'));
+
+UPDATE "bookings" SET "status" = 'PENDING_PAYMENT'
+WHERE "status" = 'PENDING'
+    AND EXISTS (SELECT 1 FROM "payments" WHERE "payments"."booking_id" = "bookings"."id" AND "payments"."status" = 'FAILED');
+
+DROP INDEX IF EXISTS "idx_bookings_payment_due_at";
+CREATE INDEX IF NOT EXISTS "idx_bookings_payment_due_at" ON "bookings" ("payment_due_at") WHERE status = 'PENDING_PAYMENT';
@@ -0,0 +1,11 @@
+// Package migrations holds the versioned SQL migrations that define the
+// database schema. Files follow the golang-migrate naming scheme,
+// <version>_<name>.up.sql and <version>_<name>.down.sql, and a file whose
+// first line is "-- migrate:no-transaction" runs outside a transaction, as
+// CREATE INDEX CONCURRENTLY requires.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
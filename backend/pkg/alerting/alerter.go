@@ -0,0 +1,88 @@
+package alerting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+type Severity string
+
+const (
+	SeverityInfo     Severity = "INFO"
+	SeverityWarning  Severity = "WARNING"
+	SeverityCritical Severity = "CRITICAL"
+)
+
+// Alert is a single monitoring finding
+type Alert struct {
+	Key       string            `json:"key"` // Stable identifier used for cooldowns, e.g. "holds.long_lived"
+	Severity  Severity          `json:"severity"`
+	Title     string            `json:"title"`
+	Message   string            `json:"message"`
+	Fields    map[string]string `json:"fields,omitempty"`
+	Timestamp time.Time         `json:"timestamp"`
+}
+
+// Alerter delivers alerts to a destination (Slack, webhook, email, ...)
+type Alerter interface {
+	Send(ctx context.Context, alert Alert) error
+	Name() string
+}
+
+// MultiAlerter fans an alert out to every configured alerter
+type MultiAlerter struct {
+	alerters []Alerter
+}
+
+func NewMultiAlerter(alerters ...Alerter) *MultiAlerter {
+	return &MultiAlerter{alerters: alerters}
+}
+
+func (m *MultiAlerter) Name() string {
+	return "multi"
+}
+
+func (m *MultiAlerter) Send(ctx context.Context, alert Alert) error {
+	var errs []error
+	for _, alerter := range m.alerters {
+		if err := alerter.Send(ctx, alert); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", alerter.Name(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ThrottledAlerter suppresses repeats of the same alert key within the cooldown
+type ThrottledAlerter struct {
+	next     Alerter
+	cooldown time.Duration
+	mu       sync.Mutex
+	lastSent map[string]time.Time
+}
+
+func NewThrottledAlerter(next Alerter, cooldown time.Duration) *ThrottledAlerter {
+	return &ThrottledAlerter{
+		next:     next,
+		cooldown: cooldown,
+		lastSent: make(map[string]time.Time),
+	}
+}
+
+func (t *ThrottledAlerter) Name() string {
+	return t.next.Name()
+}
+
+func (t *ThrottledAlerter) Send(ctx context.Context, alert Alert) error {
+	t.mu.Lock()
+	if last, ok := t.lastSent[alert.Key]; ok && time.Since(last) < t.cooldown {
+		t.mu.Unlock()
+		return nil
+	}
+	t.lastSent[alert.Key] = time.Now()
+	t.mu.Unlock()
+
+	return t.next.Send(ctx, alert)
+}
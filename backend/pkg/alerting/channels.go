@@ -0,0 +1,145 @@
+package alerting
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/smtp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// LogAlerter writes alerts to the application log, used when no channel is configured
+type LogAlerter struct{}
+
+func (LogAlerter) Name() string {
+	return "log"
+}
+
+func (LogAlerter) Send(ctx context.Context, alert Alert) error {
+	log.Printf("🚨 ALERT [%s] %s: %s %v", alert.Severity, alert.Title, alert.Message, alert.Fields)
+	return nil
+}
+
+// WebhookAlerter POSTs the alert as JSON to a generic webhook
+type WebhookAlerter struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookAlerter(url string) *WebhookAlerter {
+	return &WebhookAlerter{url: url, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (w *WebhookAlerter) Name() string {
+	return "webhook"
+}
+
+func (w *WebhookAlerter) Send(ctx context.Context, alert Alert) error {
+	return postJSON(ctx, w.client, w.url, alert)
+}
+
+// SlackAlerter posts to a Slack incoming webhook
+type SlackAlerter struct {
+	webhookURL string
+	client     *http.Client
+}
+
+func NewSlackAlerter(webhookURL string) *SlackAlerter {
+	return &SlackAlerter{webhookURL: webhookURL, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (s *SlackAlerter) Name() string {
+	return "slack"
+}
+
+func (s *SlackAlerter) Send(ctx context.Context, alert Alert) error {
+	text := fmt.Sprintf("*[%s] %s*\n%s", alert.Severity, alert.Title, alert.Message)
+	if fields := formatFields(alert.Fields); fields != "" {
+		text += "\n" + fields
+	}
+	return postJSON(ctx, s.client, s.webhookURL, map[string]string{"text": text})
+}
+
+// EmailConfig contains SMTP settings for the email alerter
+type EmailConfig struct {
+	Host       string
+	Port       int
+	Username   string
+	Password   string
+	From       string
+	Recipients []string
+}
+
+// EmailAlerter emails alerts to an on-call distribution list
+type EmailAlerter struct {
+	config EmailConfig
+}
+
+func NewEmailAlerter(config EmailConfig) *EmailAlerter {
+	return &EmailAlerter{config: config}
+}
+
+func (e *EmailAlerter) Name() string {
+	return "email"
+}
+
+func (e *EmailAlerter) Send(ctx context.Context, alert Alert) error {
+	subject := fmt.Sprintf("[Evently %s] %s", alert.Severity, alert.Title)
+	body := alert.Message
+	if fields := formatFields(alert.Fields); fields != "" {
+		body += "\n\n" + fields
+	}
+
+	message := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s\r\n",
+		e.config.From, strings.Join(e.config.Recipients, ", "), subject, body)
+
+	addr := fmt.Sprintf("%s:%d", e.config.Host, e.config.Port)
+	auth := smtp.PlainAuth("", e.config.Username, e.config.Password, e.config.Host)
+	if err := smtp.SendMail(addr, auth, e.config.From, e.config.Recipients, []byte(message)); err != nil {
+		return fmt.Errorf("failed to send alert email: %w", err)
+	}
+	return nil
+}
+
+func postJSON(ctx context.Context, client *http.Client, url string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal alert: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create alert request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send alert: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("alert endpoint returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func formatFields(fields map[string]string) string {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	lines := make([]string, 0, len(keys))
+	for _, k := range keys {
+		lines = append(lines, fmt.Sprintf("%s: %s", k, fields[k]))
+	}
+	return strings.Join(lines, "\n")
+}
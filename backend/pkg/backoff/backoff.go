@@ -0,0 +1,17 @@
+package backoff
+
+import "time"
+
+// Exponential returns the delay before retrying after the given attempt: base
+// after the first, doubled for every attempt after it, and capped at max. The
+// outbox relay and webhook deliveries retry with it.
+func Exponential(base, max time.Duration, attempt int) time.Duration {
+	delay := base
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= max {
+			return max
+		}
+	}
+	return delay
+}
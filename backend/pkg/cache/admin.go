@@ -0,0 +1,319 @@
+package cache
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"evently/internal/shared/middleware"
+	"evently/internal/shared/utils/constants"
+	"evently/internal/shared/utils/response"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+)
+
+// Warmer rebuilds a group of cache entries from the database and returns how
+// many entries it wrote
+type Warmer func(ctx context.Context) (int, error)
+
+const (
+	defaultKeyListLimit = 100
+	maxKeyListLimit     = 1000
+	scanBatchSize       = 500
+	warmTimeout         = 2 * time.Minute
+)
+
+type CacheKey struct {
+	Key        string `json:"key"`
+	TTLSeconds int64  `json:"ttl_seconds"` // -1 when the key never expires
+	Local      bool   `json:"local"`       // Also held in this replica's in-process cache
+}
+
+type CacheKeysResponse struct {
+	Pattern    string     `json:"pattern"`
+	Keys       []CacheKey `json:"keys"`
+	NextCursor uint64     `json:"next_cursor"` // 0 once the scan is complete
+	Local      LocalStats `json:"local"`
+}
+
+type InvalidateResponse struct {
+	Pattern string `json:"pattern"`
+	Deleted int    `json:"deleted"`
+}
+
+type WarmRequest struct {
+	Warmers []string `json:"warmers"` // Empty runs every warmer
+}
+
+type WarmResult struct {
+	Name       string `json:"name"`
+	Status     string `json:"status"` // success or error
+	Entries    int    `json:"entries"`
+	DurationMs int64  `json:"duration_ms"`
+	Error      string `json:"error,omitempty"`
+}
+
+// AdminController lets operators inspect cached keys, invalidate them by
+// pattern and rebuild hot entries, without flushing Redis. It only ever touches
+// keys under the application cache prefix.
+type AdminController struct {
+	client  redis.UniversalClient
+	warmers map[string]Warmer
+	order   []string
+}
+
+func NewAdminController(client redis.UniversalClient) *AdminController {
+	return &AdminController{client: client, warmers: make(map[string]Warmer)}
+}
+
+// RegisterWarmer makes a warmer available to POST /admin/cache/warm
+func (ctrl *AdminController) RegisterWarmer(name string, warmer Warmer) {
+	if _, exists := ctrl.warmers[name]; !exists {
+		ctrl.order = append(ctrl.order, name)
+	}
+	ctrl.warmers[name] = warmer
+}
+
+func SetupAdminRoutes(rg *gin.RouterGroup, controller *AdminController) {
+	// Cache administration - system:manage
+	admin := rg.Group("/admin/cache")
+	admin.Use(middleware.JWTAuth(), middleware.RequirePermission(middleware.PermissionSystemManage))
+	{
+		admin.GET("/keys", controller.ListKeys)          // GET /api/v1/admin/cache/keys?prefix=events:detail&limit=&cursor=
+		admin.DELETE("/keys", controller.InvalidateKeys) // DELETE /api/v1/admin/cache/keys?pattern=events:list:*
+		admin.GET("/warmers", controller.ListWarmers)    // GET /api/v1/admin/cache/warmers
+		admin.POST("/warm", controller.Warm)             // POST /api/v1/admin/cache/warm - all warmers, or those named in the body
+	}
+}
+
+// ListKeys godoc
+//
+// @Summary      List cache keys (Admin)
+// @Description  Scans cache keys under a prefix with their remaining TTL. Only application cache keys are visible; seat holds, sessions and rate limit counters are not.
+// @Description
+// @Description  Requires the `system:manage` permission.
+// @Tags         Admin Cache
+// @Produce      json
+// @Security     Bearer
+// @Param        prefix query string false "Key prefix, with or without the application prefix. Empty lists every cache key."
+// @Param        limit query integer false "Limit" default(100) minimum(1) maximum(1000)
+// @Param        cursor query integer false "next_cursor from the previous page"
+// @Success      200 {object} response.StandardApiResponse{data=CacheKeysResponse} "Cache keys retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse "Invalid limit or cursor"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/cache/keys [get]
+func (ctrl *AdminController) ListKeys(c *gin.Context) {
+	limit := defaultKeyListLimit
+	if raw := c.Query("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 || parsed > maxKeyListLimit {
+			response.RespondJSON(c, "error", http.StatusBadRequest, "Limit must be between 1 and 1000", nil, nil)
+			return
+		}
+		limit = parsed
+	}
+	var cursor uint64
+	if raw := c.Query("cursor"); raw != "" {
+		parsed, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid cursor", nil, nil)
+			return
+		}
+		cursor = parsed
+	}
+
+	pattern := cachePattern(strings.TrimSuffix(c.Query("prefix"), "*") + "*")
+	ctx := c.Request.Context()
+
+	// SCAN may return fewer keys than asked for per call, so keep going until
+	// the page is full or the keyspace is exhausted. A cluster has a cursor per
+	// node, so it is listed in a single page.
+	var keys []string
+	if _, ok := ctrl.client.(*redis.ClusterClient); ok {
+		matched, err := MatchKeys(ctx, ctrl.client, pattern)
+		if err != nil {
+			response.RespondJSON(c, "error", http.StatusInternalServerError, "Failed to scan cache keys", nil, err.Error())
+			return
+		}
+		if len(matched) > limit {
+			matched = matched[:limit]
+		}
+		keys, cursor = matched, 0
+	} else {
+		for len(keys) < limit {
+			batch, next, err := ctrl.client.Scan(ctx, cursor, pattern, int64(scanBatchSize)).Result()
+			if err != nil {
+				response.RespondJSON(c, "error", http.StatusInternalServerError, "Failed to scan cache keys", nil, err.Error())
+				return
+			}
+			keys = append(keys, batch...)
+			cursor = next
+			if cursor == 0 {
+				break
+			}
+		}
+	}
+
+	result := CacheKeysResponse{Pattern: pattern, Keys: []CacheKey{}, NextCursor: cursor, Local: Local().Stats()}
+	if len(keys) > 0 {
+		pipe := ctrl.client.Pipeline()
+		ttls := make([]*redis.DurationCmd, len(keys))
+		for i, key := range keys {
+			ttls[i] = pipe.TTL(ctx, key)
+		}
+		if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+			response.RespondJSON(c, "error", http.StatusInternalServerError, "Failed to read cache key TTLs", nil, err.Error())
+			return
+		}
+
+		for i, key := range keys {
+			ttl := ttls[i].Val()
+			if ttl == -2 {
+				continue // Expired since the scan
+			}
+			entry := CacheKey{Key: key, TTLSeconds: -1, Local: Local().Has(key)}
+			if ttl >= 0 {
+				entry.TTLSeconds = int64(ttl.Seconds())
+			}
+			result.Keys = append(result.Keys, entry)
+		}
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Cache keys retrieved successfully", result, nil)
+}
+
+// InvalidateKeys deletes every cache key matching a pattern, on Redis and in
+// the in-process cache of every replica
+//
+// @Summary      Invalidate cache keys by pattern (Admin)
+// @Description  Deletes every cache key matching a Redis glob pattern and drops local copies on every replica.
+// @Description
+// @Description  Requires the `system:manage` permission.
+// @Tags         Admin Cache
+// @Produce      json
+// @Security     Bearer
+// @Param        pattern query string true "Pattern"
+// @Success      200 {object} response.StandardApiResponse{data=InvalidateResponse} "Cache keys invalidated successfully"
+// @Failure      400 {object} response.StandardApiResponse "Pattern is required"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/cache/keys [delete]
+func (ctrl *AdminController) InvalidateKeys(c *gin.Context) {
+	raw := strings.TrimSpace(c.Query("pattern"))
+	if raw == "" {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Pattern is required, e.g. events:list:*", nil, nil)
+		return
+	}
+	pattern := cachePattern(raw)
+	ctx := c.Request.Context()
+
+	// Masters of a cluster are scanned concurrently
+	var deleted atomic.Int64
+	err := ScanKeys(ctx, ctrl.client, pattern, scanBatchSize, func(keys []string) error {
+		n, err := DeleteKeys(ctx, ctrl.client, keys...)
+		deleted.Add(n)
+		return err
+	})
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusInternalServerError, "Failed to delete cache keys", nil, err.Error())
+		return
+	}
+
+	Local().InvalidatePattern(ctx, pattern)
+
+	response.RespondJSON(c, "success", http.StatusOK, "Cache keys invalidated successfully",
+		InvalidateResponse{Pattern: pattern, Deleted: int(deleted.Load())}, nil)
+}
+
+// ListWarmers godoc
+//
+// @Summary      List cache warmers (Admin)
+// @Description  Requires the `system:manage` permission.
+// @Tags         Admin Cache
+// @Produce      json
+// @Security     Bearer
+// @Success      200 {object} response.StandardApiResponse{data=[]string} "Cache warmers retrieved successfully"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/cache/warmers [get]
+func (ctrl *AdminController) ListWarmers(c *gin.Context) {
+	names := append([]string{}, ctrl.order...)
+	response.RespondJSON(c, "success", http.StatusOK, "Cache warmers retrieved successfully", names, nil)
+}
+
+// Warm runs warmers one after another and reports each one's outcome. A failed
+// warmer does not stop the others.
+//
+// @Summary      Warm caches (Admin)
+// @Description  Rebuilds cache entries from the database, replacing what is cached. Runs every warmer unless some are named. A failed warmer does not stop the others.
+// @Description
+// @Description  Requires the `system:manage` permission.
+// @Tags         Admin Cache
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        request body WarmRequest false "Request body"
+// @Success      200 {object} response.StandardApiResponse{data=[]WarmResult} "Cache warmed successfully"
+// @Failure      400 {object} response.StandardApiResponse "Unknown cache warmer"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse "Some cache warmers failed; data holds every warmer's result"
+// @Router       /api/v1/admin/cache/warm [post]
+func (ctrl *AdminController) Warm(c *gin.Context) {
+	var req WarmRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid request body", nil, err.Error())
+			return
+		}
+	}
+
+	names := req.Warmers
+	if len(names) == 0 {
+		names = ctrl.order
+	}
+	for _, name := range names {
+		if _, ok := ctrl.warmers[name]; !ok {
+			response.RespondJSON(c, "error", http.StatusBadRequest, "Unknown cache warmer: "+name, nil, ctrl.order)
+			return
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), warmTimeout)
+	defer cancel()
+
+	results := make([]WarmResult, 0, len(names))
+	failed := false
+	for _, name := range names {
+		started := time.Now()
+		entries, err := ctrl.warmers[name](ctx)
+		result := WarmResult{Name: name, Status: "success", Entries: entries, DurationMs: time.Since(started).Milliseconds()}
+		if err != nil {
+			result.Status = "error"
+			result.Error = err.Error()
+			failed = true
+		}
+		results = append(results, result)
+	}
+
+	if failed {
+		response.RespondJSON(c, "error", http.StatusInternalServerError, "Some cache warmers failed", results, nil)
+		return
+	}
+	response.RespondJSON(c, "success", http.StatusOK, "Cache warmed successfully", results, nil)
+}
+
+// cachePattern scopes a prefix or pattern to the application cache keys, so
+// the admin API can never reach seat holds, sessions or rate limit counters.
+// The application prefix may be given or left out.
+func cachePattern(pattern string) string {
+	pattern = strings.TrimPrefix(pattern, constants.CACHE_PREFIX+":")
+	return constants.CACHE_PREFIX + ":" + pattern
+}
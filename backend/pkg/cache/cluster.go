@@ -0,0 +1,111 @@
+package cache
+
+import (
+	"context"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// In cluster mode KEYS and SCAN only see the node they run on, and commands
+// with several keys fail unless every key hashes to the same slot. These
+// helpers work the same against a single node and a cluster.
+
+// ScanKeys calls fn with every batch of keys matching pattern, on every master
+// of a cluster. fn may run concurrently for different masters.
+func ScanKeys(ctx context.Context, client redis.UniversalClient, pattern string, batch int64, fn func(keys []string) error) error {
+	scan := func(ctx context.Context, node redis.Cmdable) error {
+		var cursor uint64
+		for {
+			keys, next, err := node.Scan(ctx, cursor, pattern, batch).Result()
+			if err != nil {
+				return err
+			}
+			if len(keys) > 0 {
+				if err := fn(keys); err != nil {
+					return err
+				}
+			}
+			cursor = next
+			if cursor == 0 {
+				return nil
+			}
+		}
+	}
+
+	if cluster, ok := client.(*redis.ClusterClient); ok {
+		return cluster.ForEachMaster(ctx, func(ctx context.Context, node *redis.Client) error {
+			return scan(ctx, node)
+		})
+	}
+	return scan(ctx, client)
+}
+
+// MatchKeys returns every key matching pattern
+func MatchKeys(ctx context.Context, client redis.UniversalClient, pattern string) ([]string, error) {
+	var (
+		mu      sync.Mutex
+		matched []string
+	)
+	err := ScanKeys(ctx, client, pattern, 500, func(keys []string) error {
+		mu.Lock()
+		matched = append(matched, keys...)
+		mu.Unlock()
+		return nil
+	})
+	return matched, err
+}
+
+// DeleteKeys deletes keys and returns how many existed. On a cluster every key
+// is deleted on its own, pipelined per node.
+func DeleteKeys(ctx context.Context, client redis.UniversalClient, keys ...string) (int64, error) {
+	if len(keys) == 0 {
+		return 0, nil
+	}
+	if _, ok := client.(*redis.ClusterClient); !ok {
+		return client.Del(ctx, keys...).Result()
+	}
+
+	pipe := client.Pipeline()
+	cmds := make([]*redis.IntCmd, len(keys))
+	for i, key := range keys {
+		cmds[i] = pipe.Del(ctx, key)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+
+	var deleted int64
+	for _, cmd := range cmds {
+		deleted += cmd.Val()
+	}
+	return deleted, nil
+}
+
+// GetKeys reads several string keys, nil where a key doesn't exist. On a
+// cluster the keys are read one by one, pipelined per node.
+func GetKeys(ctx context.Context, client redis.UniversalClient, keys ...string) ([]interface{}, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	if _, ok := client.(*redis.ClusterClient); !ok {
+		return client.MGet(ctx, keys...).Result()
+	}
+
+	pipe := client.Pipeline()
+	cmds := make([]*redis.StringCmd, len(keys))
+	for i, key := range keys {
+		cmds[i] = pipe.Get(ctx, key)
+	}
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, err
+	}
+
+	values := make([]interface{}, len(keys))
+	for i, cmd := range cmds {
+		if cmd.Err() == nil {
+			values[i] = cmd.Val()
+		}
+	}
+	return values, nil
+}
@@ -0,0 +1,91 @@
+package cache
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"evently/pkg/logger"
+	"evently/pkg/metrics"
+)
+
+// Cache operations and the tier that served them, as recorded by the
+// instrumentation
+const (
+	OpGet = "get"
+	OpSet = "set"
+
+	TierLocal = "local"
+	TierRedis = "redis"
+)
+
+// requestLogs turns the per-request cache log lines on or off; metrics are
+// always recorded
+var requestLogs atomic.Bool
+
+func init() {
+	requestLogs.Store(true)
+}
+
+// SetRequestLogging turns the per-request cache log lines on or off
+func SetRequestLogging(enabled bool) {
+	requestLogs.Store(enabled)
+}
+
+// ObserveGet records a cache read: the X-Cache status of the request, hit and
+// miss counts, latency and payload size per key prefix, and a log line. Every
+// cache read goes through here, whichever client made it.
+func ObserveGet(ctx context.Context, key, result, tier string, size int, started time.Time) {
+	elapsed := time.Since(started)
+	prefix := metrics.CacheKeyPrefix(key)
+
+	RecordLookup(ctx, result == metrics.ResultHit)
+	metrics.CacheRequestsTotal.Inc(prefix, result)
+	metrics.CacheOperationDuration.Observe(elapsed.Seconds(), OpGet, prefix, tier)
+	if result == metrics.ResultHit {
+		metrics.CachePayloadBytes.Observe(float64(size), OpGet, prefix)
+	}
+
+	if !requestLogs.Load() {
+		return
+	}
+	attrs := []any{
+		slog.String("key", key),
+		slog.String("prefix", prefix),
+		slog.String("result", result),
+		slog.String("tier", tier),
+		slog.Duration("duration", elapsed),
+	}
+	if result == metrics.ResultHit {
+		attrs = append(attrs, slog.Int("bytes", size))
+	}
+	logger.GetDefault().DebugContext(ctx, "Cache get", attrs...)
+}
+
+// ObserveSet records a cache write: latency and payload size per key prefix,
+// and a log line
+func ObserveSet(ctx context.Context, key string, size int, started time.Time, err error) {
+	elapsed := time.Since(started)
+	prefix := metrics.CacheKeyPrefix(key)
+
+	metrics.CacheOperationDuration.Observe(elapsed.Seconds(), OpSet, prefix, TierRedis)
+	if err == nil {
+		metrics.CachePayloadBytes.Observe(float64(size), OpSet, prefix)
+	}
+
+	if err != nil {
+		logger.GetDefault().WarnContext(ctx, "Cache set failed",
+			slog.String("key", key), slog.String("prefix", prefix), slog.Any("error", err))
+		return
+	}
+	if !requestLogs.Load() {
+		return
+	}
+	logger.GetDefault().DebugContext(ctx, "Cache set",
+		slog.String("key", key),
+		slog.String("prefix", prefix),
+		slog.Int("bytes", size),
+		slog.Duration("duration", elapsed),
+	)
+}
@@ -0,0 +1,336 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"log"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"evently/internal/shared/utils/constants"
+	"evently/pkg/metrics"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// InvalidationChannel is the Redis pub/sub channel local cache invalidations
+// are broadcast on
+const InvalidationChannel = constants.CACHE_PREFIX + ":cache:invalidate"
+
+// LocalConfig configures the in-process cache tier
+type LocalConfig struct {
+	MaxEntries    int
+	MaxEntryBytes int           // Larger values are only cached in Redis
+	TTL           time.Duration // Local entries never outlive this, even if Redis keeps them longer
+	Prefixes      []string      // Only keys with one of these prefixes are cached locally
+}
+
+// LocalStats is a snapshot of the local tier
+type LocalStats struct {
+	Entries    int   `json:"entries"`
+	Bytes      int64 `json:"bytes"`
+	MaxEntries int   `json:"max_entries"`
+}
+
+// LocalCache is an LRU of small, hot values kept in front of Redis. Values are
+// stored as the JSON Redis holds, so a local hit decodes exactly like a Redis
+// hit. Every replica has its own LocalCache: invalidations are applied locally
+// and published on InvalidationChannel so the other replicas drop their copies.
+type LocalCache struct {
+	config     LocalConfig
+	client     redis.UniversalClient // nil disables broadcasting, e.g. in single-instance tools
+	instanceID string
+	done       chan struct{}
+
+	mu      sync.Mutex
+	ll      *list.List // Front is most recently used
+	entries map[string]*list.Element
+	bytes   int64
+}
+
+type localEntry struct {
+	key       string
+	data      []byte
+	expiresAt time.Time
+}
+
+// invalidation is the message broadcast to the other replicas
+type invalidation struct {
+	Origin   string   `json:"origin"`
+	Keys     []string `json:"keys,omitempty"`
+	Patterns []string `json:"patterns,omitempty"`
+}
+
+var (
+	localCache       *LocalCache
+	localMetricsOnce sync.Once
+)
+
+// NewLocalCache creates a local cache that broadcasts invalidations through client
+func NewLocalCache(client redis.UniversalClient, cfg LocalConfig) *LocalCache {
+	return &LocalCache{
+		config:     cfg,
+		client:     client,
+		instanceID: uuid.NewString(),
+		done:       make(chan struct{}),
+		ll:         list.New(),
+		entries:    make(map[string]*list.Element),
+	}
+}
+
+// InitLocal creates the process-wide local cache returned by Local
+func InitLocal(client redis.UniversalClient, cfg LocalConfig) *LocalCache {
+	localCache = NewLocalCache(client, cfg)
+	localMetricsOnce.Do(func() {
+		metrics.Default.NewGaugeFunc("evently_cache_local_entries", "Entries in the in-process cache.", func() float64 {
+			return float64(Local().Stats().Entries)
+		})
+		metrics.Default.NewGaugeFunc("evently_cache_local_bytes", "Bytes of values held in the in-process cache.", func() float64 {
+			return float64(Local().Stats().Bytes)
+		})
+	})
+	return localCache
+}
+
+// Local returns the process-wide local cache, or nil when it is disabled.
+// Every method is safe to call on a nil LocalCache.
+func Local() *LocalCache {
+	return localCache
+}
+
+// Cacheable reports whether key belongs in the local tier
+func (l *LocalCache) Cacheable(key string) bool {
+	if l == nil {
+		return false
+	}
+	for _, prefix := range l.config.Prefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Get returns the JSON stored for key
+func (l *LocalCache) Get(key string) ([]byte, bool) {
+	if !l.Cacheable(key) {
+		return nil, false
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	el, ok := l.entries[key]
+	if !ok {
+		metrics.LocalCacheRequestsTotal.Inc(metrics.CacheKeyPrefix(key), metrics.ResultMiss)
+		return nil, false
+	}
+	entry := el.Value.(*localEntry)
+	if time.Now().After(entry.expiresAt) {
+		l.remove(el)
+		metrics.LocalCacheRequestsTotal.Inc(metrics.CacheKeyPrefix(key), metrics.ResultMiss)
+		return nil, false
+	}
+
+	l.ll.MoveToFront(el)
+	metrics.LocalCacheRequestsTotal.Inc(metrics.CacheKeyPrefix(key), metrics.ResultHit)
+	return entry.data, true
+}
+
+// Has reports whether key is held locally, without counting as a use
+func (l *LocalCache) Has(key string) bool {
+	if l == nil {
+		return false
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, ok := l.entries[key]
+	return ok
+}
+
+// Set stores the JSON for key for at most ttl, capped at the configured local TTL
+func (l *LocalCache) Set(key string, data []byte, ttl time.Duration) {
+	if !l.Cacheable(key) || len(data) > l.config.MaxEntryBytes {
+		return
+	}
+	if ttl <= 0 || ttl > l.config.TTL {
+		ttl = l.config.TTL
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if el, ok := l.entries[key]; ok {
+		l.remove(el)
+	}
+	l.entries[key] = l.ll.PushFront(&localEntry{key: key, data: data, expiresAt: time.Now().Add(ttl)})
+	l.bytes += int64(len(data))
+
+	for l.ll.Len() > l.config.MaxEntries {
+		l.remove(l.ll.Back())
+	}
+}
+
+// Invalidate drops keys here and on every other replica
+func (l *LocalCache) Invalidate(ctx context.Context, keys ...string) {
+	var local []string
+	for _, key := range keys {
+		if l.Cacheable(key) {
+			local = append(local, key)
+		}
+	}
+	if len(local) == 0 {
+		return
+	}
+
+	l.drop(local, nil)
+	l.publish(ctx, invalidation{Keys: local})
+}
+
+// InvalidatePattern drops keys matching a Redis glob pattern here and on every
+// other replica
+func (l *LocalCache) InvalidatePattern(ctx context.Context, patterns ...string) {
+	if l == nil || len(patterns) == 0 {
+		return
+	}
+
+	l.drop(nil, patterns)
+	l.publish(ctx, invalidation{Patterns: patterns})
+}
+
+// Clear drops every local entry on this replica only
+func (l *LocalCache) Clear() {
+	if l == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.ll.Init()
+	l.entries = make(map[string]*list.Element)
+	l.bytes = 0
+}
+
+// Stats returns the current size of the local tier
+func (l *LocalCache) Stats() LocalStats {
+	if l == nil {
+		return LocalStats{}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return LocalStats{Entries: l.ll.Len(), Bytes: l.bytes, MaxEntries: l.config.MaxEntries}
+}
+
+// Start subscribes to invalidations broadcast by the other replicas
+func (l *LocalCache) Start(ctx context.Context) {
+	if l == nil || l.client == nil {
+		return
+	}
+	log.Printf("🧊 LOCAL CACHE: Caching up to %d entries for %v, listening for invalidations on %s",
+		l.config.MaxEntries, l.config.TTL, InvalidationChannel)
+	go l.run(ctx)
+}
+
+// Stop stops listening for invalidations
+func (l *LocalCache) Stop() {
+	if l == nil || l.client == nil {
+		return
+	}
+	log.Println("🧊 LOCAL CACHE: Stopping...")
+	close(l.done)
+}
+
+func (l *LocalCache) run(ctx context.Context) {
+	sub := l.client.Subscribe(ctx, InvalidationChannel)
+	defer sub.Close()
+
+	// Entries cached before the subscription was up may have missed an
+	// invalidation, so start from an empty cache
+	l.Clear()
+
+	messages := sub.Channel()
+	for {
+		select {
+		case msg, ok := <-messages:
+			if !ok {
+				return
+			}
+			var inv invalidation
+			if err := json.Unmarshal([]byte(msg.Payload), &inv); err != nil {
+				log.Printf("⚠️ LOCAL CACHE: Ignoring malformed invalidation: %v", err)
+				continue
+			}
+			if inv.Origin == l.instanceID {
+				continue
+			}
+			l.drop(inv.Keys, inv.Patterns)
+		case <-l.done:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (l *LocalCache) publish(ctx context.Context, inv invalidation) {
+	if l.client == nil {
+		return
+	}
+
+	inv.Origin = l.instanceID
+	payload, err := json.Marshal(inv)
+	if err != nil {
+		return
+	}
+	// Replicas that miss the broadcast serve the old value until the local TTL runs out
+	if err := l.client.Publish(ctx, InvalidationChannel, payload).Err(); err != nil {
+		log.Printf("⚠️ LOCAL CACHE: Failed to broadcast invalidation: %v", err)
+	}
+}
+
+func (l *LocalCache) drop(keys, patterns []string) {
+	if l == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, key := range keys {
+		if el, ok := l.entries[key]; ok {
+			l.remove(el)
+		}
+	}
+	if len(patterns) == 0 {
+		return
+	}
+	for key, el := range l.entries {
+		for _, pattern := range patterns {
+			if matchPattern(pattern, key) {
+				l.remove(el)
+				break
+			}
+		}
+	}
+}
+
+// remove unlinks an entry; l.mu must be held
+func (l *LocalCache) remove(el *list.Element) {
+	entry := l.ll.Remove(el).(*localEntry)
+	delete(l.entries, entry.key)
+	l.bytes -= int64(len(entry.data))
+}
+
+// matchPattern matches a key against a Redis glob pattern. Cache keys have no
+// slashes, so path.Match agrees with Redis; a malformed pattern matches
+// everything, since dropping too much is only a cache miss.
+func matchPattern(pattern, key string) bool {
+	matched, err := path.Match(pattern, key)
+	return matched || err != nil
+}
@@ -8,11 +8,23 @@ import (
 	"github.com/redis/go-redis/v9"
 )
 
+// Redis deployment modes
+const (
+	ModeStandalone = "standalone"
+	ModeSentinel   = "sentinel"
+	ModeCluster    = "cluster"
+)
+
 // Config holds Redis connection configuration
 type Config struct {
 	Address  string // Redis server address (host:port)
 	Password string // Redis password (empty if no password)
-	DB       int    // Redis database number (0-15)
+	DB       int    // Redis database number (0-15), cluster mode only has 0
+
+	Mode             string   // standalone (default), sentinel or cluster
+	Addrs            []string // Sentinel addresses, or cluster seed nodes. Address is used when empty.
+	MasterName       string   // Sentinel master set name
+	SentinelPassword string
 }
 
 // RedisConfig is an alias for compatibility with the main config package
@@ -23,11 +35,16 @@ type RedisConfig struct {
 	Password string
 	DB       int
 	Addr     string
+
+	Mode             string
+	Addrs            []string
+	MasterName       string
+	SentinelPassword string
 }
 
 // RedisClient wraps the Redis client with additional functionality
 type RedisClient struct {
-	client *redis.Client
+	client redis.UniversalClient
 	ctx    context.Context
 }
 
@@ -42,27 +59,67 @@ func NewConfigFromRedisConfig(rc RedisConfig) Config {
 	}
 
 	return Config{
-		Address:  address,
-		Password: rc.Password,
-		DB:       rc.DB,
+		Address:          address,
+		Password:         rc.Password,
+		DB:               rc.DB,
+		Mode:             rc.Mode,
+		Addrs:            rc.Addrs,
+		MasterName:       rc.MasterName,
+		SentinelPassword: rc.SentinelPassword,
 	}
 }
 
-// Init initializes the Redis client with the provided configuration
-func Init(cfg Config) error {
-	if cfg.Address == "" {
-		return fmt.Errorf("redis address cannot be empty")
+// NewClient creates a client for the configured mode. Sentinel clients follow
+// the master through failovers, cluster clients route every command to the
+// node owning its hash slot.
+func NewClient(cfg Config) (redis.UniversalClient, error) {
+	addrs := cfg.Addrs
+	if len(addrs) == 0 && cfg.Address != "" {
+		addrs = []string{cfg.Address}
 	}
 
-	// Create Redis client options
-	opts := &redis.Options{
-		Addr:     cfg.Address,
-		Password: cfg.Password,
-		DB:       cfg.DB,
+	switch cfg.Mode {
+	case "", ModeStandalone:
+		if cfg.Address == "" {
+			return nil, fmt.Errorf("redis address cannot be empty")
+		}
+		return redis.NewClient(&redis.Options{
+			Addr:     cfg.Address,
+			Password: cfg.Password,
+			DB:       cfg.DB,
+		}), nil
+	case ModeSentinel:
+		if len(addrs) == 0 || cfg.MasterName == "" {
+			return nil, fmt.Errorf("redis sentinel mode needs sentinel addresses and a master name")
+		}
+		return redis.NewFailoverClient(&redis.FailoverOptions{
+			MasterName:       cfg.MasterName,
+			SentinelAddrs:    addrs,
+			SentinelPassword: cfg.SentinelPassword,
+			Password:         cfg.Password,
+			DB:               cfg.DB,
+		}), nil
+	case ModeCluster:
+		if len(addrs) == 0 {
+			return nil, fmt.Errorf("redis cluster mode needs seed node addresses")
+		}
+		if cfg.DB != 0 {
+			return nil, fmt.Errorf("redis cluster mode only supports database 0")
+		}
+		return redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:    addrs,
+			Password: cfg.Password,
+		}), nil
 	}
+	return nil, fmt.Errorf("unknown redis mode %q, use standalone, sentinel or cluster", cfg.Mode)
+}
 
-	// Create new Redis client
-	client := redis.NewClient(opts)
+// Init initializes the Redis client with the provided configuration
+func Init(cfg Config) error {
+	client, err := NewClient(cfg)
+	if err != nil {
+		return err
+	}
 
 	// Create context with timeout for connection test
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -70,6 +127,7 @@ func Init(cfg Config) error {
 
 	// Test the connection
 	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
 		return fmt.Errorf("failed to connect to Redis at %s: %w", cfg.Address, err)
 	}
 
@@ -89,9 +147,21 @@ func InitWithRedisConfig(rc RedisConfig) error {
 	return Init(cfg)
 }
 
+// Use makes an already connected client the one returned by Client, for
+// packages that read the cache through the global client
+func Use(client redis.UniversalClient) {
+	if client == nil {
+		return
+	}
+	redisClient = &RedisClient{
+		client: client,
+		ctx:    context.Background(),
+	}
+}
+
 // Client returns the Redis client instance
 // Returns nil if Init() hasn't been called successfully
-func Client() *redis.Client {
+func Client() redis.UniversalClient {
 	if redisClient == nil {
 		return nil
 	}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"time"
 
+	"evently/pkg/metrics"
+
 	"github.com/redis/go-redis/v9"
 )
 
@@ -29,61 +31,43 @@ type Service interface {
 }
 
 type service struct {
-	client *redis.Client
+	client redis.UniversalClient
 }
 
-func NewService(client *redis.Client) Service {
+func NewService(client redis.UniversalClient) Service {
 	return &service{client: client}
 }
 
 func (s *service) Get(ctx context.Context, key string, dest interface{}) error {
-	val, err := s.client.Get(ctx, key).Result()
-	if err != nil {
-		if err == redis.Nil {
-			return ErrCacheMiss
-		}
-		return fmt.Errorf("cache get error: %w", err)
-	}
-
-	if err := json.Unmarshal([]byte(val), dest); err != nil {
-		return fmt.Errorf("cache unmarshal error: %w", err)
-	}
-
-	return nil
+	return GetJSON(ctx, s.client, key, dest)
 }
 
 func (s *service) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
-	data, err := json.Marshal(value)
-	if err != nil {
-		return fmt.Errorf("cache marshal error: %w", err)
-	}
-
-	if err := s.client.Set(ctx, key, data, ttl).Err(); err != nil {
-		return fmt.Errorf("cache set error: %w", err)
-	}
-
-	return nil
+	return SetJSON(ctx, s.client, key, value, ttl)
 }
 
 func (s *service) Delete(ctx context.Context, key string) error {
 	if err := s.client.Del(ctx, key).Err(); err != nil {
 		return fmt.Errorf("cache delete error: %w", err)
 	}
+	Local().Invalidate(ctx, key)
 	return nil
 }
 
 func (s *service) DeletePattern(ctx context.Context, pattern string) error {
-	keys, err := s.client.Keys(ctx, pattern).Result()
+	keys, err := MatchKeys(ctx, s.client, pattern)
 	if err != nil {
 		return fmt.Errorf("cache keys error: %w", err)
 	}
 
-	if len(keys) > 0 {
-		if err := s.client.Del(ctx, keys...).Err(); err != nil {
-			return fmt.Errorf("cache delete pattern error: %w", err)
-		}
+	if _, err := DeleteKeys(ctx, s.client, keys...); err != nil {
+		return fmt.Errorf("cache delete pattern error: %w", err)
 	}
 
+	// Drop local copies only once Redis no longer has them, or a replica
+	// could refill its local tier from the old value in between
+	Local().InvalidatePattern(ctx, pattern)
+
 	return nil
 }
 
@@ -93,7 +77,7 @@ func (s *service) Exists(ctx context.Context, key string) bool {
 }
 
 func (s *service) MGet(ctx context.Context, keys []string, dest interface{}) error {
-	values, err := s.client.MGet(ctx, keys...).Result()
+	values, err := GetKeys(ctx, s.client, keys...)
 	if err != nil {
 		return fmt.Errorf("cache mget error: %w", err)
 	}
@@ -133,6 +117,10 @@ func (s *service) MSet(ctx context.Context, items map[string]interface{}, ttl ti
 		return fmt.Errorf("cache mset error: %w", err)
 	}
 
+	for key := range items {
+		Local().Invalidate(ctx, key)
+	}
+
 	return nil
 }
 
@@ -171,6 +159,59 @@ func (s *service) GetOrSet(ctx context.Context, key string, ttl time.Duration, f
 	return json.Unmarshal(jsonData, dest)
 }
 
+// GetJSON reads a JSON value from the local tier or Redis into dest, and
+// records the lookup. A key that is not cached returns ErrCacheMiss.
+func GetJSON(ctx context.Context, client redis.UniversalClient, key string, dest interface{}) error {
+	started := time.Now()
+	if data, ok := Local().Get(key); ok {
+		ObserveGet(ctx, key, metrics.ResultHit, TierLocal, len(data), started)
+		if err := json.Unmarshal(data, dest); err != nil {
+			return fmt.Errorf("cache unmarshal error: %w", err)
+		}
+		return nil
+	}
+
+	val, err := client.Get(ctx, key).Result()
+	if err != nil {
+		if err == redis.Nil {
+			ObserveGet(ctx, key, metrics.ResultMiss, TierRedis, 0, started)
+			return ErrCacheMiss
+		}
+		ObserveGet(ctx, key, metrics.ResultError, TierRedis, 0, started)
+		return fmt.Errorf("cache get error: %w", err)
+	}
+	ObserveGet(ctx, key, metrics.ResultHit, TierRedis, len(val), started)
+
+	if err := json.Unmarshal([]byte(val), dest); err != nil {
+		return fmt.Errorf("cache unmarshal error: %w", err)
+	}
+	Local().Set(key, []byte(val), 0)
+
+	return nil
+}
+
+// SetJSON writes value to Redis as JSON and records the write. The local tier
+// keeps a copy and every other replica drops its own.
+func SetJSON(ctx context.Context, client redis.UniversalClient, key string, value interface{}, ttl time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("cache marshal error: %w", err)
+	}
+
+	started := time.Now()
+	err = client.Set(ctx, key, data, ttl).Err()
+	ObserveSet(ctx, key, len(data), started, err)
+	if err != nil {
+		return fmt.Errorf("cache set error: %w", err)
+	}
+
+	// Other replicas may hold the previous value
+	Local().Invalidate(ctx, key)
+	Local().Set(key, data, ttl)
+
+	return nil
+}
+
 func (s *service) Ping(ctx context.Context) error {
 	return s.client.Ping(ctx).Err()
 }
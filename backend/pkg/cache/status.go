@@ -0,0 +1,114 @@
+package cache
+
+import (
+	"context"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HeaderCacheStatus reports whether a response was served from the cache
+const HeaderCacheStatus = "X-Cache"
+
+// Cache status header values
+const (
+	StatusHit  = "HIT"
+	StatusMiss = "MISS"
+)
+
+type lookupKey struct{}
+
+// lookup remembers the first cache lookup made while serving a request. The
+// first lookup is the one for the resource itself; later ones fill in
+// decorations (ratings, promotions, branding) that have their own TTLs.
+type lookup struct {
+	mu     sync.Mutex
+	status string
+}
+
+// WithLookupTracking returns a context that records the outcome of the first
+// cache lookup made with it
+func WithLookupTracking(ctx context.Context) context.Context {
+	return context.WithValue(ctx, lookupKey{}, &lookup{})
+}
+
+// RecordLookup records a cache lookup against the request in ctx, if it is
+// tracked. Errors other than a miss count as a miss: the data came from the
+// database either way.
+func RecordLookup(ctx context.Context, hit bool) {
+	l, ok := ctx.Value(lookupKey{}).(*lookup)
+	if !ok {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.status != "" {
+		return
+	}
+	l.status = StatusMiss
+	if hit {
+		l.status = StatusHit
+	}
+}
+
+// LookupStatus returns HIT or MISS for the first lookup recorded in ctx, or an
+// empty string when the request made none
+func LookupStatus(ctx context.Context) string {
+	l, ok := ctx.Value(lookupKey{}).(*lookup)
+	if !ok {
+		return ""
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.status
+}
+
+// StatusMiddleware sets the X-Cache header on responses to requests that
+// looked something up in the cache. The header has to go out before the body,
+// so the writer adds it when the response is first flushed.
+func StatusMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := WithLookupTracking(c.Request.Context())
+		c.Request = c.Request.WithContext(ctx)
+		c.Writer = &statusWriter{ResponseWriter: c.Writer, ctx: ctx}
+		c.Next()
+	}
+}
+
+type statusWriter struct {
+	gin.ResponseWriter
+	ctx     context.Context
+	written bool
+}
+
+func (w *statusWriter) WriteHeaderNow() {
+	w.setHeader()
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *statusWriter) Write(data []byte) (int, error) {
+	w.setHeader()
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *statusWriter) WriteString(s string) (int, error) {
+	w.setHeader()
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *statusWriter) Flush() {
+	w.setHeader()
+	w.ResponseWriter.Flush()
+}
+
+func (w *statusWriter) setHeader() {
+	if w.written {
+		return
+	}
+	w.written = true
+	if status := LookupStatus(w.ctx); status != "" {
+		w.Header().Set(HeaderCacheStatus, status)
+	}
+}
@@ -0,0 +1,208 @@
+package currency
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"evently/pkg/logger"
+)
+
+var ErrUnsupportedCurrency = errors.New("unsupported currency")
+
+// Provider converts amounts between the currencies events are priced in
+type Provider interface {
+	// Base is the currency analytics revenue is reported in
+	Base() string
+	Supported(code string) bool
+	// Rate returns the units of "to" one unit of "from" buys
+	Rate(ctx context.Context, from, to string) (float64, error)
+}
+
+// Normalize upper-cases a currency code, e.g. "usd" becomes "USD"
+func Normalize(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// Convert applies an exchange rate and rounds to the smallest currency unit
+func Convert(amount, rate float64) float64 {
+	return math.Round(amount*rate*100) / 100
+}
+
+// StaticProvider serves fixed rates, expressed as units of each currency per
+// one unit of the base currency
+type StaticProvider struct {
+	base  string
+	rates map[string]float64
+}
+
+func NewStaticProvider(base string, rates map[string]float64) *StaticProvider {
+	base = Normalize(base)
+	table := map[string]float64{base: 1}
+	for code, rate := range rates {
+		if rate > 0 {
+			table[Normalize(code)] = rate
+		}
+	}
+	// The base is always worth exactly one unit of itself
+	table[base] = 1
+	return &StaticProvider{base: base, rates: table}
+}
+
+func (p *StaticProvider) Base() string {
+	return p.base
+}
+
+func (p *StaticProvider) Supported(code string) bool {
+	_, ok := p.rates[Normalize(code)]
+	return ok
+}
+
+func (p *StaticProvider) Rate(_ context.Context, from, to string) (float64, error) {
+	return crossRate(p.rates, from, to)
+}
+
+// HTTPProvider fetches rates from a JSON feed such as
+// {"base": "INR", "rates": {"USD": 0.012}} and reuses them for the refresh
+// interval. The static rates are served while the feed is unreachable.
+type HTTPProvider struct {
+	url      string
+	interval time.Duration
+	client   *http.Client
+	fallback *StaticProvider
+
+	mu          sync.Mutex
+	rates       map[string]float64
+	lastAttempt time.Time
+}
+
+func NewHTTPProvider(url string, interval time.Duration, fallback *StaticProvider) *HTTPProvider {
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	return &HTTPProvider{
+		url:      url,
+		interval: interval,
+		client:   &http.Client{Timeout: 10 * time.Second},
+		fallback: fallback,
+	}
+}
+
+func (p *HTTPProvider) Base() string {
+	return p.fallback.Base()
+}
+
+func (p *HTTPProvider) Supported(code string) bool {
+	p.mu.Lock()
+	_, ok := p.rates[Normalize(code)]
+	p.mu.Unlock()
+	return ok || p.fallback.Supported(code)
+}
+
+func (p *HTTPProvider) Rate(ctx context.Context, from, to string) (float64, error) {
+	rates := p.current(ctx)
+	if rate, err := crossRate(rates, from, to); err == nil {
+		return rate, nil
+	}
+	return p.fallback.Rate(ctx, from, to)
+}
+
+// current returns the fetched rates, refetching them once the interval has passed.
+// Failed fetches are not retried before the next interval either.
+func (p *HTTPProvider) current(ctx context.Context) map[string]float64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if time.Since(p.lastAttempt) < p.interval {
+		return p.rates
+	}
+	p.lastAttempt = time.Now()
+
+	rates, err := p.fetch(ctx)
+	if err != nil {
+		logger.GetDefault().WarnContext(ctx, "Failed to fetch exchange rates, using the last known rates", "url", p.url, "error", err)
+		return p.rates
+	}
+	p.rates = rates
+	return p.rates
+}
+
+func (p *HTTPProvider) fetch(ctx context.Context) (map[string]float64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("rates feed returned status %d", resp.StatusCode)
+	}
+
+	var feed struct {
+		Base  string             `json:"base"`
+		Rates map[string]float64 `json:"rates"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&feed); err != nil {
+		return nil, fmt.Errorf("failed to decode rates feed: %w", err)
+	}
+
+	feedBase := Normalize(feed.Base)
+	if feedBase == "" {
+		feedBase = p.Base()
+	}
+	table := map[string]float64{feedBase: 1}
+	for code, rate := range feed.Rates {
+		if rate > 0 {
+			table[Normalize(code)] = rate
+		}
+	}
+
+	// Re-express the feed against the configured base currency
+	baseRate, ok := table[p.Base()]
+	if !ok {
+		return nil, fmt.Errorf("rates feed has no rate for base currency %s", p.Base())
+	}
+	rates := make(map[string]float64, len(table))
+	for code, rate := range table {
+		rates[code] = rate / baseRate
+	}
+	return rates, nil
+}
+
+// crossRate converts through the base currency both rates are expressed against
+func crossRate(rates map[string]float64, from, to string) (float64, error) {
+	from, to = Normalize(from), Normalize(to)
+	if from == to {
+		return 1, nil
+	}
+
+	fromRate, ok := rates[from]
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrUnsupportedCurrency, from)
+	}
+	toRate, ok := rates[to]
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrUnsupportedCurrency, to)
+	}
+	return toRate / fromRate, nil
+}
+
+// NewProvider returns the HTTP provider when a feed URL is configured and the static rates otherwise
+func NewProvider(base string, rates map[string]float64, ratesURL string, refreshInterval time.Duration) Provider {
+	static := NewStaticProvider(base, rates)
+	if ratesURL == "" {
+		return static
+	}
+	return NewHTTPProvider(ratesURL, refreshInterval, static)
+}
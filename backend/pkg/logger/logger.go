@@ -13,16 +13,19 @@ import (
 // Logger wraps slog.Logger with additional functionality
 type Logger struct {
 	*slog.Logger
+	output slog.Handler // Unfiltered output, which module loggers filter by their own level
 }
 
 // New creates a new logger instance
 func New() *Logger {
 	// Get log level from environment
 	level := getLogLevel(os.Getenv("LOG_LEVEL"))
+	levels.setDefault(level)
 
-	// Create handler options
+	// Create handler options. Levels are applied per module, so the output
+	// itself lets every record through.
 	opts := &slog.HandlerOptions{
-		Level:     level,
+		Level:     slog.LevelDebug,
 		AddSource: level == slog.LevelDebug,
 	}
 
@@ -30,40 +33,95 @@ func New() *Logger {
 	var handler slog.Handler
 	if gin.Mode() == gin.DebugMode {
 		// Use text handler for development (more readable)
-		handler = slog.NewTextHandler(os.Stdout, opts)
+		handler = slog.NewTextHandler(stdout{}, opts)
 	} else {
 		// Use JSON handler for production (structured)
-		handler = slog.NewJSONHandler(os.Stdout, opts)
+		handler = slog.NewJSONHandler(stdout{}, opts)
 	}
 
 	// Create logger
-	logger := slog.New(handler)
+	logger := slog.New(requestIDHandler{moduleHandler{Handler: handler}})
 
 	return &Logger{
 		Logger: logger,
+		output: handler,
 	}
 }
 
+// stdout writes to os.Stdout as it is at the time of the write, so tools that
+// set stdout aside for their own output, such as cmd/contracttest, keep logs out of it
+type stdout struct{}
+
+func (stdout) Write(p []byte) (int, error) {
+	return os.Stdout.Write(p)
+}
+
 // getLogLevel converts string to slog.Level
 func getLogLevel(levelStr string) slog.Level {
-	switch strings.ToLower(levelStr) {
+	level, _ := parseLevel(levelStr)
+	return level
+}
+
+// parseLevel converts string to slog.Level, reporting whether it is a known level
+func parseLevel(levelStr string) (slog.Level, bool) {
+	switch strings.ToLower(strings.TrimSpace(levelStr)) {
 	case "debug":
-		return slog.LevelDebug
+		return slog.LevelDebug, true
 	case "info":
-		return slog.LevelInfo
+		return slog.LevelInfo, true
 	case "warn", "warning":
-		return slog.LevelWarn
+		return slog.LevelWarn, true
 	case "error":
-		return slog.LevelError
+		return slog.LevelError, true
 	default:
-		return slog.LevelInfo
+		return slog.LevelInfo, false
+	}
+}
+
+// requestIDContextKey is the context key of the request ID
+type requestIDContextKey struct{}
+
+// ContextWithRequestID returns a copy of ctx carrying the request ID, which is
+// added to every line logged with that context
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey{}, requestID)
+}
+
+// RequestIDFromContext returns the request ID carried by ctx, or an empty string
+func RequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	requestID, _ := ctx.Value(requestIDContextKey{}).(string)
+	return requestID
+}
+
+// requestIDHandler adds the request ID of the context to each record, so that
+// service logs written with a request's context can be correlated with it
+type requestIDHandler struct {
+	slog.Handler
+}
+
+func (h requestIDHandler) Handle(ctx context.Context, record slog.Record) error {
+	if requestID := RequestIDFromContext(ctx); requestID != "" {
+		record.AddAttrs(slog.String("request_id", requestID))
 	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h requestIDHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return requestIDHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h requestIDHandler) WithGroup(name string) slog.Handler {
+	return requestIDHandler{h.Handler.WithGroup(name)}
 }
 
 // WithRequestID adds request ID to logger context
 func (l *Logger) WithRequestID(requestID string) *Logger {
 	return &Logger{
 		Logger: l.Logger.With(slog.String("request_id", requestID)),
+		output: l.output,
 	}
 }
 
@@ -71,6 +129,7 @@ func (l *Logger) WithRequestID(requestID string) *Logger {
 func (l *Logger) WithUserID(userID string) *Logger {
 	return &Logger{
 		Logger: l.Logger.With(slog.String("user_id", userID)),
+		output: l.output,
 	}
 }
 
@@ -78,6 +137,7 @@ func (l *Logger) WithUserID(userID string) *Logger {
 func (l *Logger) WithError(err error) *Logger {
 	return &Logger{
 		Logger: l.Logger.With(slog.String("error", err.Error())),
+		output: l.output,
 	}
 }
 
@@ -89,6 +149,7 @@ func (l *Logger) WithFields(fields map[string]interface{}) *Logger {
 	}
 	return &Logger{
 		Logger: l.Logger.With(args...),
+		output: l.output,
 	}
 }
 
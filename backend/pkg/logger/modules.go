@@ -0,0 +1,98 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// moduleLevels holds the level of each module, LOG_LEVEL for the others
+type moduleLevels struct {
+	mu       sync.RWMutex
+	fallback slog.Level
+	modules  map[string]slog.Level
+}
+
+var levels = &moduleLevels{modules: map[string]slog.Level{}}
+
+func (m *moduleLevels) setDefault(level slog.Level) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.fallback = level
+}
+
+func (m *moduleLevels) level(module string) slog.Level {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if level, ok := m.modules[module]; ok {
+		return level
+	}
+	return m.fallback
+}
+
+// SetModuleLevels overrides LOG_LEVEL for some modules, e.g. {"seats": "debug"}
+// to trace seat holds without the debug lines of every other module. Entries
+// with an unknown level are ignored and returned.
+func SetModuleLevels(overrides map[string]string) []string {
+	modules := make(map[string]slog.Level, len(overrides))
+	var rejected []string
+	for module, value := range overrides {
+		level, ok := parseLevel(value)
+		if !ok {
+			rejected = append(rejected, module+":"+value)
+			continue
+		}
+		modules[strings.ToLower(module)] = level
+	}
+	sort.Strings(rejected)
+
+	levels.mu.Lock()
+	defer levels.mu.Unlock()
+	levels.modules = modules
+	return rejected
+}
+
+// moduleHandler drops records below the level of its module. Levels are read
+// on every record, so loggers created before SetModuleLevels follow it too.
+type moduleHandler struct {
+	slog.Handler
+	module string // Empty for lines not logged by a module
+}
+
+func (h moduleHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= levels.level(h.module) && h.Handler.Enabled(ctx, level)
+}
+
+func (h moduleHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return moduleHandler{Handler: h.Handler.WithAttrs(attrs), module: h.module}
+}
+
+func (h moduleHandler) WithGroup(name string) slog.Handler {
+	return moduleHandler{Handler: h.Handler.WithGroup(name), module: h.module}
+}
+
+// Module returns the logger of a module, such as a service or background job.
+// Its lines carry a component field and are filtered by the module's level.
+func (l *Logger) Module(name string) *Logger {
+	output := l.output
+	if output == nil {
+		output = l.Logger.Handler()
+	}
+
+	name = strings.ToLower(name)
+	handler := moduleHandler{
+		Handler: output.WithAttrs([]slog.Attr{slog.String("component", name)}),
+		module:  name,
+	}
+	return &Logger{
+		Logger: slog.New(requestIDHandler{handler}),
+		output: output,
+	}
+}
+
+// Module returns the logger of a module, see (*Logger).Module
+func Module(name string) *Logger {
+	return GetDefault().Module(name)
+}
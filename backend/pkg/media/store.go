@@ -0,0 +1,116 @@
+package media
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ErrTooLarge is returned when an upload exceeds the store's size limit
+var ErrTooLarge = errors.New("file exceeds maximum upload size")
+
+// Store persists uploaded media and returns a URL clients can load it from
+type Store interface {
+	Save(ctx context.Context, key string, r io.Reader) (string, error)
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+	URL(key string) string
+}
+
+// LocalStore keeps media on the local filesystem under Root and serves it from URLPrefix
+type LocalStore struct {
+	Root      string
+	URLPrefix string
+	MaxSize   int64
+}
+
+func NewLocalStore(root, urlPrefix string, maxSize int64) *LocalStore {
+	return &LocalStore{
+		Root:      root,
+		URLPrefix: strings.TrimSuffix(urlPrefix, "/"),
+		MaxSize:   maxSize,
+	}
+}
+
+func (s *LocalStore) Save(ctx context.Context, key string, r io.Reader) (string, error) {
+	fullPath, err := s.resolve(key)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0o755); err != nil {
+		return "", fmt.Errorf("failed to create media directory: %w", err)
+	}
+
+	// Write to a temp file first so a failed upload never replaces an existing file
+	tmp, err := os.CreateTemp(filepath.Dir(fullPath), ".upload-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create media file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	reader := r
+	if s.MaxSize > 0 {
+		reader = io.LimitReader(r, s.MaxSize+1)
+	}
+
+	written, err := io.Copy(tmp, reader)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to write media file: %w", err)
+	}
+	if s.MaxSize > 0 && written > s.MaxSize {
+		return "", ErrTooLarge
+	}
+
+	if err := os.Rename(tmp.Name(), fullPath); err != nil {
+		return "", fmt.Errorf("failed to store media file: %w", err)
+	}
+
+	return s.URL(key), nil
+}
+
+func (s *LocalStore) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	fullPath, err := s.resolve(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(fullPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open media file: %w", err)
+	}
+	return file, nil
+}
+
+func (s *LocalStore) Delete(ctx context.Context, key string) error {
+	fullPath, err := s.resolve(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(fullPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete media file: %w", err)
+	}
+	return nil
+}
+
+func (s *LocalStore) URL(key string) string {
+	return s.URLPrefix + "/" + strings.TrimPrefix(path.Clean("/"+key), "/")
+}
+
+// resolve maps a key onto the filesystem, rejecting keys that escape the root
+func (s *LocalStore) resolve(key string) (string, error) {
+	cleaned := strings.TrimPrefix(path.Clean("/"+key), "/")
+	if cleaned == "" {
+		return "", fmt.Errorf("invalid media key %q", key)
+	}
+	return filepath.Join(s.Root, filepath.FromSlash(cleaned)), nil
+}
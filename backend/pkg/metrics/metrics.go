@@ -0,0 +1,132 @@
+package metrics
+
+import (
+	"runtime"
+	"strings"
+	"time"
+
+	"evently/internal/shared/utils/constants"
+)
+
+// Default is the registry served on /metrics
+var Default = NewRegistry()
+
+// Result label values shared across metrics
+const (
+	ResultHit        = "hit"
+	ResultMiss       = "miss"
+	ResultError      = "error"
+	ResultSuccess    = "success"
+	ResultContention = "contention"
+	ResultBypass     = "bypass"
+)
+
+// Activity label values of OnSaleActivity
+const (
+	OnSaleHoldCreated      = "hold_created"
+	OnSaleHoldConflict     = "hold_conflict"
+	OnSaleBookingConfirmed = "booking_confirmed"
+	OnSalePaymentFailed    = "payment_failed"
+	OnSaleQueueAdmission   = "queue_admission"
+)
+
+var (
+	HTTPRequestsTotal = Default.NewCounterVec("evently_http_requests_total",
+		"Total HTTP requests by method, route and status code.", "method", "route", "status")
+
+	HTTPRequestDuration = Default.NewHistogramVec("evently_http_request_duration_seconds",
+		"HTTP request latency in seconds by method and route.", DefaultBuckets, "method", "route")
+
+	CacheRequestsTotal = Default.NewCounterVec("evently_cache_requests_total",
+		"Cache lookups by key prefix and result (hit, miss, error).", "prefix", "result")
+
+	CacheOperationDuration = Default.NewHistogramVec("evently_cache_operation_duration_seconds",
+		"Cache get and set latency in seconds by operation, key prefix and tier (local, redis).", CacheBuckets, "op", "prefix", "tier")
+
+	CachePayloadBytes = Default.NewHistogramVec("evently_cache_payload_bytes",
+		"Size of cached values read and written by operation and key prefix.", SizeBuckets, "op", "prefix")
+
+	LocalCacheRequestsTotal = Default.NewCounterVec("evently_cache_local_requests_total",
+		"In-process cache lookups by key prefix and result (hit, miss).", "prefix", "result")
+
+	EventListCacheTotal = Default.NewCounterVec("evently_event_list_cache_total",
+		"Event list cache lookups by filter used and result (hit, miss, bypass for filter sets not cached).", "filter", "result")
+
+	SeatHoldsTotal = Default.NewCounterVec("evently_seat_holds_total",
+		"Seat hold attempts by result (success, contention, error).", "result")
+
+	BookingsTotal = Default.NewCounterVec("evently_bookings_total",
+		"Booking confirmations by outcome status.", "status")
+
+	WaitlistQueueLength = Default.NewGaugeVec("evently_waitlist_queue_length",
+		"Users currently waiting per event.", "event_id")
+
+	WaitlistNotificationsTotal = Default.NewCounterVec("evently_waitlist_notifications_total",
+		"Spot-available notifications queued per event.", "event_id")
+
+	WaitlistConversionsTotal = Default.NewCounterVec("evently_waitlist_conversions_total",
+		"Waitlist entries that booked within their window per event.", "event_id")
+
+	WaitlistRequeuesTotal = Default.NewCounterVec("evently_waitlist_requeues_total",
+		"Users moved back to the end of the queue after missing their booking window per event.", "event_id")
+
+	WaitlistExpiredWithoutActionTotal = Default.NewCounterVec("evently_waitlist_expired_without_action_total",
+		"Booking windows that expired without the user opening the notification per event.", "event_id")
+
+	WaitlistPreferenceSkipsTotal = Default.NewCounterVec("evently_waitlist_preference_skips_total",
+		"Users passed over because the freed seats did not match their seat preferences per event.", "event_id")
+
+	WaitlistQueueRepairsTotal = Default.NewCounterVec("evently_waitlist_queue_repairs_total",
+		"Redis waitlist queue drift repaired by reconciliation, by kind (restored, removed, repositioned).", "kind")
+
+	WaitlistBookingWindowUsed = Default.NewHistogramVec("evently_waitlist_booking_window_used_ratio",
+		"Fraction of the booking window elapsed when a notified user booked.", RatioBuckets)
+
+	KafkaPublishFailuresTotal = Default.NewCounterVec("evently_kafka_publish_failures_total",
+		"Messages that failed to publish to Kafka by topic.", "topic")
+
+	DBPoolBreakerOpen = Default.NewGaugeVec("evently_db_pool_breaker_open",
+		"1 while booking requests are shed because the Postgres pool is saturated.")
+
+	DBPoolRejectionsTotal = Default.NewCounterVec("evently_db_pool_rejections_total",
+		"Requests answered 503 by the pool breaker by route.", "route")
+
+	// Per-event activity over the last minute for the on-sale live view, kept in memory only
+	OnSaleActivity = NewWindowCounter("evently_onsale_activity", time.Minute, "event_id", "activity")
+)
+
+func init() {
+	Default.NewGaugeFunc("go_goroutines", "Number of goroutines that currently exist.", func() float64 {
+		return float64(runtime.NumGoroutine())
+	})
+	Default.NewGaugeFunc("go_memstats_heap_alloc_bytes", "Number of heap bytes allocated and still in use.", func() float64 {
+		var stats runtime.MemStats
+		runtime.ReadMemStats(&stats)
+		return float64(stats.HeapAlloc)
+	})
+}
+
+// CacheKeyPrefix reduces a cache key to its first two segments after the app prefix
+// (e.g. "events:detail") so ids never become label values
+func CacheKeyPrefix(key string) string {
+	key = strings.TrimPrefix(key, constants.CACHE_PREFIX+":")
+
+	parts := strings.SplitN(key, ":", 3)
+	if len(parts) > 2 {
+		parts = parts[:2]
+	}
+	return strings.Join(parts, ":")
+}
+
+// RecordSeatHold counts a hold attempt, and against its event, holds created and
+// holds lost to contention
+func RecordSeatHold(eventID, result string) {
+	SeatHoldsTotal.Inc(result)
+
+	switch result {
+	case ResultSuccess:
+		OnSaleActivity.Inc(eventID, OnSaleHoldCreated)
+	case ResultContention:
+		OnSaleActivity.Inc(eventID, OnSaleHoldConflict)
+	}
+}
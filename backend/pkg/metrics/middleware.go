@@ -0,0 +1,46 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// unmatchedRoute labels requests that hit no registered route, keeping raw paths out of labels
+const unmatchedRoute = "unmatched"
+
+// Middleware records request counts and latency per route template
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+		method := c.Request.Method
+
+		HTTPRequestsTotal.Inc(method, route, strconv.Itoa(c.Writer.Status()))
+		HTTPRequestDuration.Observe(time.Since(start).Seconds(), method, route)
+	}
+}
+
+// Handler serves the default registry as a gin handler
+//
+// @Summary      Prometheus metrics
+// @Description  Exposes request latency, cache hit/miss, seat hold, booking, waitlist health and Kafka publish
+// @Description  metrics in the Prometheus text format. Waitlist health covers queue length, notifications,
+// @Description  conversions, requeues, windows expired without action and booking window used. The path is set by METRICS_PATH and the endpoint is
+// @Description  disabled when METRICS_ENABLED=false.
+// @Tags         Health
+// @Produce      text/plain
+// @Success      200 {file} file "Metrics in Prometheus text exposition format"
+// @Router       /metrics [get]
+func Handler() gin.HandlerFunc {
+	h := Default.Handler()
+	return func(c *gin.Context) {
+		h.ServeHTTP(c.Writer, c.Request)
+	}
+}
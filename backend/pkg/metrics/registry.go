@@ -0,0 +1,354 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ContentType is the Prometheus text exposition format served by Handler
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// DefaultBuckets are latency buckets in seconds suited to HTTP handlers
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// CacheBuckets are latency buckets in seconds suited to cache round trips,
+// from in-process hits to a slow Redis
+var CacheBuckets = []float64{0.0001, 0.00025, 0.0005, 0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1}
+
+// SizeBuckets are payload sizes in bytes, 256 B to 1 MB
+var SizeBuckets = []float64{256, 1024, 4096, 16384, 65536, 262144, 1048576}
+
+// RatioBuckets split a 0 to 1 ratio into tenths
+var RatioBuckets = []float64{0.1, 0.2, 0.3, 0.4, 0.5, 0.6, 0.7, 0.8, 0.9, 1}
+
+type collector interface {
+	write(w *bufio.Writer)
+}
+
+// Registry holds metrics and renders them in the Prometheus text format
+type Registry struct {
+	mu         sync.RWMutex
+	names      map[string]struct{}
+	collectors []collector
+}
+
+func NewRegistry() *Registry {
+	return &Registry{names: make(map[string]struct{})}
+}
+
+func (r *Registry) register(name string, c collector) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.names[name]; exists {
+		panic(fmt.Sprintf("metrics: duplicate metric %q", name))
+	}
+	r.names[name] = struct{}{}
+	r.collectors = append(r.collectors, c)
+}
+
+// Write renders every registered metric in registration order
+func (r *Registry) Write(w io.Writer) error {
+	r.mu.RLock()
+	collectors := append([]collector(nil), r.collectors...)
+	r.mu.RUnlock()
+
+	bw := bufio.NewWriter(w)
+	for _, c := range collectors {
+		c.write(bw)
+	}
+	return bw.Flush()
+}
+
+// Handler serves the registry for Prometheus scrapes
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", ContentType)
+		_ = r.Write(w)
+	})
+}
+
+//  COUNTER
+
+// CounterVec is a monotonically increasing counter partitioned by labels
+type CounterVec struct {
+	desc   desc
+	mu     sync.Mutex
+	series map[string]*counterSeries
+}
+
+type counterSeries struct {
+	labels []string
+	value  float64
+}
+
+func (r *Registry) NewCounterVec(name, help string, labelNames ...string) *CounterVec {
+	c := &CounterVec{
+		desc:   desc{name: name, help: help, kind: "counter", labelNames: labelNames},
+		series: make(map[string]*counterSeries),
+	}
+	r.register(name, c)
+	return c
+}
+
+func (c *CounterVec) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Add increments the counter; negative values are ignored since counters never decrease
+func (c *CounterVec) Add(v float64, labelValues ...string) {
+	if v < 0 {
+		return
+	}
+	key := c.desc.seriesKey(labelValues)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s, ok := c.series[key]
+	if !ok {
+		s = &counterSeries{labels: append([]string(nil), labelValues...)}
+		c.series[key] = s
+	}
+	s.value += v
+}
+
+func (c *CounterVec) write(w *bufio.Writer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.desc.writeHeader(w)
+	for _, key := range sortedKeys(c.series) {
+		s := c.series[key]
+		c.desc.writeSample(w, c.desc.name, s.labels, "", "", s.value)
+	}
+}
+
+//  GAUGE
+
+// GaugeVec is a value that can go up and down, partitioned by labels
+type GaugeVec struct {
+	desc   desc
+	mu     sync.Mutex
+	series map[string]*counterSeries
+}
+
+func (r *Registry) NewGaugeVec(name, help string, labelNames ...string) *GaugeVec {
+	g := &GaugeVec{
+		desc:   desc{name: name, help: help, kind: "gauge", labelNames: labelNames},
+		series: make(map[string]*counterSeries),
+	}
+	r.register(name, g)
+	return g
+}
+
+func (g *GaugeVec) Set(v float64, labelValues ...string) {
+	key := g.desc.seriesKey(labelValues)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	s, ok := g.series[key]
+	if !ok {
+		s = &counterSeries{labels: append([]string(nil), labelValues...)}
+		g.series[key] = s
+	}
+	s.value = v
+}
+
+// Delete drops a series so finished entities stop being exported
+func (g *GaugeVec) Delete(labelValues ...string) {
+	key := g.desc.seriesKey(labelValues)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	delete(g.series, key)
+}
+
+func (g *GaugeVec) write(w *bufio.Writer) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.desc.writeHeader(w)
+	for _, key := range sortedKeys(g.series) {
+		s := g.series[key]
+		g.desc.writeSample(w, g.desc.name, s.labels, "", "", s.value)
+	}
+}
+
+// GaugeFunc reports a value computed at scrape time
+type GaugeFunc struct {
+	desc desc
+	fn   func() float64
+}
+
+func (r *Registry) NewGaugeFunc(name, help string, fn func() float64) *GaugeFunc {
+	g := &GaugeFunc{desc: desc{name: name, help: help, kind: "gauge"}, fn: fn}
+	r.register(name, g)
+	return g
+}
+
+func (g *GaugeFunc) write(w *bufio.Writer) {
+	g.desc.writeHeader(w)
+	g.desc.writeSample(w, g.desc.name, nil, "", "", g.fn())
+}
+
+//  HISTOGRAM
+
+// HistogramVec samples observations into cumulative buckets, partitioned by labels
+type HistogramVec struct {
+	desc    desc
+	buckets []float64
+	mu      sync.Mutex
+	series  map[string]*histogramSeries
+}
+
+type histogramSeries struct {
+	labels []string
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+func (r *Registry) NewHistogramVec(name, help string, buckets []float64, labelNames ...string) *HistogramVec {
+	sorted := append([]float64(nil), buckets...)
+	sort.Float64s(sorted)
+
+	h := &HistogramVec{
+		desc:    desc{name: name, help: help, kind: "histogram", labelNames: labelNames},
+		buckets: sorted,
+		series:  make(map[string]*histogramSeries),
+	}
+	r.register(name, h)
+	return h
+}
+
+func (h *HistogramVec) Observe(v float64, labelValues ...string) {
+	key := h.desc.seriesKey(labelValues)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	s, ok := h.series[key]
+	if !ok {
+		s = &histogramSeries{
+			labels: append([]string(nil), labelValues...),
+			counts: make([]uint64, len(h.buckets)),
+		}
+		h.series[key] = s
+	}
+
+	// Only the first matching bucket is bumped; counts are accumulated when rendering
+	if i := sort.SearchFloat64s(h.buckets, v); i < len(h.buckets) {
+		s.counts[i]++
+	}
+	s.count++
+	s.sum += v
+}
+
+func (h *HistogramVec) write(w *bufio.Writer) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.desc.writeHeader(w)
+	for _, key := range sortedKeys(h.series) {
+		s := h.series[key]
+
+		var cumulative uint64
+		for i, upper := range h.buckets {
+			cumulative += s.counts[i]
+			h.desc.writeSample(w, h.desc.name+"_bucket", s.labels, "le", formatFloat(upper), float64(cumulative))
+		}
+		h.desc.writeSample(w, h.desc.name+"_bucket", s.labels, "le", "+Inf", float64(s.count))
+		h.desc.writeSample(w, h.desc.name+"_sum", s.labels, "", "", s.sum)
+		h.desc.writeSample(w, h.desc.name+"_count", s.labels, "", "", float64(s.count))
+	}
+}
+
+//  SHARED
+
+type desc struct {
+	name       string
+	help       string
+	kind       string
+	labelNames []string
+}
+
+// seriesKey identifies a label combination; a mismatched label count is a programming error
+func (d desc) seriesKey(labelValues []string) string {
+	if len(labelValues) != len(d.labelNames) {
+		panic(fmt.Sprintf("metrics: %s expects %d label values, got %d", d.name, len(d.labelNames), len(labelValues)))
+	}
+	return strings.Join(labelValues, "\xff")
+}
+
+func (d desc) writeHeader(w *bufio.Writer) {
+	fmt.Fprintf(w, "# HELP %s %s\n", d.name, escapeHelp(d.help))
+	fmt.Fprintf(w, "# TYPE %s %s\n", d.name, d.kind)
+}
+
+func (d desc) writeSample(w *bufio.Writer, name string, labelValues []string, extraName, extraValue string, value float64) {
+	w.WriteString(name)
+
+	if len(labelValues) > 0 || extraName != "" {
+		w.WriteByte('{')
+		for i, labelName := range d.labelNames {
+			if i > 0 {
+				w.WriteByte(',')
+			}
+			fmt.Fprintf(w, "%s=\"%s\"", labelName, escapeLabelValue(labelValues[i]))
+		}
+		if extraName != "" {
+			if len(labelValues) > 0 {
+				w.WriteByte(',')
+			}
+			fmt.Fprintf(w, "%s=\"%s\"", extraName, extraValue)
+		}
+		w.WriteByte('}')
+	}
+
+	w.WriteByte(' ')
+	w.WriteString(formatFloat(value))
+	w.WriteByte('\n')
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+var (
+	helpEscaper  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+func escapeHelp(s string) string {
+	return helpEscaper.Replace(s)
+}
+
+func escapeLabelValue(s string) string {
+	return labelEscaper.Replace(s)
+}
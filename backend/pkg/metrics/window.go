@@ -0,0 +1,115 @@
+package metrics
+
+import (
+	"sync"
+	"time"
+)
+
+// windowBuckets is how many slices a window is split into; counts age out one slice at a time
+const windowBuckets = 60
+
+// WindowCounter counts occurrences over a sliding window, partitioned by labels.
+// It backs live views that need recent rates rather than lifetime totals, so it
+// is kept in memory and not exported to Prometheus. Series idle for a whole
+// window are dropped, which keeps per-entity labels such as event IDs bounded.
+type WindowCounter struct {
+	desc       desc
+	window     time.Duration
+	resolution time.Duration
+
+	mu        sync.Mutex
+	series    map[string]*windowSeries
+	lastSweep int64
+}
+
+type windowSeries struct {
+	counts [windowBuckets]uint64
+	slots  [windowBuckets]int64 // Slice each bucket was last written in
+	last   int64
+}
+
+func NewWindowCounter(name string, window time.Duration, labelNames ...string) *WindowCounter {
+	resolution := window / windowBuckets
+	if resolution <= 0 {
+		resolution = time.Nanosecond
+	}
+	return &WindowCounter{
+		desc:       desc{name: name, labelNames: labelNames},
+		window:     window,
+		resolution: resolution,
+		series:     make(map[string]*windowSeries),
+	}
+}
+
+// Window is the span Count covers
+func (c *WindowCounter) Window() time.Duration {
+	return c.window
+}
+
+func (c *WindowCounter) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+func (c *WindowCounter) Add(n uint64, labelValues ...string) {
+	key := c.desc.seriesKey(labelValues)
+	slot := c.slot(time.Now())
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.sweep(slot)
+
+	s, ok := c.series[key]
+	if !ok {
+		s = &windowSeries{}
+		c.series[key] = s
+	}
+
+	i := slot % windowBuckets
+	if s.slots[i] != slot {
+		s.slots[i] = slot
+		s.counts[i] = 0
+	}
+	s.counts[i] += n
+	s.last = slot
+}
+
+// Count returns the occurrences within the last window
+func (c *WindowCounter) Count(labelValues ...string) uint64 {
+	key := c.desc.seriesKey(labelValues)
+	slot := c.slot(time.Now())
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s, ok := c.series[key]
+	if !ok {
+		return 0
+	}
+
+	var total uint64
+	for i := range s.counts {
+		if slot-s.slots[i] < windowBuckets {
+			total += s.counts[i]
+		}
+	}
+	return total
+}
+
+func (c *WindowCounter) slot(t time.Time) int64 {
+	return t.UnixNano() / int64(c.resolution)
+}
+
+// sweep drops idle series at most once per window; callers hold the lock
+func (c *WindowCounter) sweep(slot int64) {
+	if slot-c.lastSweep < windowBuckets {
+		return
+	}
+	c.lastSweep = slot
+
+	for key, s := range c.series {
+		if slot-s.last >= windowBuckets {
+			delete(c.series, key)
+		}
+	}
+}
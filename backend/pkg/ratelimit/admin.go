@@ -0,0 +1,242 @@
+package ratelimit
+
+import (
+	"fmt"
+	"net/http"
+
+	"evently/internal/shared/middleware"
+	"evently/internal/shared/utils/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AddListEntryRequest struct {
+	Value string `json:"value" binding:"required"` // IP address, CIDR range or user ID
+	Note  string `json:"note" binding:"omitempty,max=255"`
+}
+
+type ListsResponse struct {
+	Allow []ListEntry `json:"allow"`
+	Deny  []ListEntry `json:"deny"`
+}
+
+type ResetCountersResponse struct {
+	Key     string `json:"key"`
+	Cleared int64  `json:"cleared"` // Number of counters deleted
+}
+
+// AdminController manages the runtime allowlist/denylist and inspects rate limit state
+type AdminController struct {
+	rateLimiter *RateLimiter
+}
+
+func NewAdminController(rateLimiter *RateLimiter) *AdminController {
+	return &AdminController{rateLimiter: rateLimiter}
+}
+
+func SetupAdminRoutes(rg *gin.RouterGroup, controller *AdminController) {
+	// Rate limit administration - system:manage
+	admin := rg.Group("/admin/rate-limits")
+	admin.Use(middleware.JWTAuth(), middleware.RequirePermission(middleware.PermissionSystemManage))
+	{
+		admin.GET("/lists", controller.GetLists)                 // GET /api/v1/admin/rate-limits/lists
+		admin.POST("/lists/:list", controller.AddListEntry)      // POST /api/v1/admin/rate-limits/lists/:list - allow or deny
+		admin.DELETE("/lists/:list", controller.RemoveListEntry) // DELETE /api/v1/admin/rate-limits/lists/:list?value=
+		admin.GET("/keys/:key", controller.InspectKey)           // GET /api/v1/admin/rate-limits/keys/:key - IP or user ID
+		admin.DELETE("/keys/:key/counters", controller.ResetKey) // DELETE /api/v1/admin/rate-limits/keys/:key/counters
+	}
+}
+
+// GetLists godoc
+//
+// @Summary      Get rate limit allowlist and denylist (Admin)
+// @Description  Runtime lists stored in Redis. Changes take effect on every replica immediately.
+// @Description
+// @Description  Requires the `system:manage` permission.
+// @Tags         Admin Rate Limits
+// @Produce      json
+// @Security     Bearer
+// @Success      200 {object} response.StandardApiResponse{data=ListsResponse} "Rate limit lists retrieved successfully"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/rate-limits/lists [get]
+func (ctrl *AdminController) GetLists(c *gin.Context) {
+	lists := ctrl.rateLimiter.Lists()
+
+	allow, err := lists.Entries(c.Request.Context(), ListAllow)
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusInternalServerError, "Failed to retrieve rate limit lists", nil, err.Error())
+		return
+	}
+	deny, err := lists.Entries(c.Request.Context(), ListDeny)
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusInternalServerError, "Failed to retrieve rate limit lists", nil, err.Error())
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Rate limit lists retrieved successfully", ListsResponse{Allow: allow, Deny: deny}, nil)
+}
+
+// AddListEntry godoc
+//
+// @Summary      Add allowlist or denylist entry (Admin)
+// @Description  Allowlisted callers bypass rate limits. Denylisted callers are rejected with 403.
+// @Description
+// @Description  Requires the `system:manage` permission.
+// @Tags         Admin Rate Limits
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        list path string true "List" Enums(allow, deny)
+// @Param        request body AddListEntryRequest true "Request body"
+// @Success      201 {object} response.StandardApiResponse{data=ListEntry} "List entry added successfully"
+// @Failure      400 {object} response.StandardApiResponse "Invalid list or entry"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/rate-limits/lists/{list} [post]
+func (ctrl *AdminController) AddListEntry(c *gin.Context) {
+	name, ok := ctrl.listParam(c)
+	if !ok {
+		return
+	}
+
+	var req AddListEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Invalid request body", nil, err.Error())
+		return
+	}
+
+	entry, err := ParseListEntry(req.Value)
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, err.Error(), nil, nil)
+		return
+	}
+	entry.Note = req.Note
+	if adminID, exists := c.Get("user_id"); exists {
+		entry.AddedBy = fmt.Sprint(adminID)
+	}
+
+	if err := ctrl.rateLimiter.Lists().Add(c.Request.Context(), name, entry); err != nil {
+		response.RespondJSON(c, "error", http.StatusInternalServerError, "Failed to add list entry", nil, err.Error())
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusCreated, "List entry added successfully", entry, nil)
+}
+
+// RemoveListEntry godoc
+//
+// @Summary      Remove allowlist or denylist entry (Admin)
+// @Description  Requires the `system:manage` permission.
+// @Tags         Admin Rate Limits
+// @Produce      json
+// @Security     Bearer
+// @Param        list path string true "List" Enums(allow, deny)
+// @Param        value query string true "IP address, CIDR range or user ID"
+// @Success      200 {object} response.StandardApiResponse "List entry removed successfully"
+// @Failure      400 {object} response.StandardApiResponse
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      404 {object} response.StandardApiResponse "List entry not found"
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/rate-limits/lists/{list} [delete]
+func (ctrl *AdminController) RemoveListEntry(c *gin.Context) {
+	name, ok := ctrl.listParam(c)
+	if !ok {
+		return
+	}
+
+	entry, err := ParseListEntry(c.Query("value"))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusBadRequest, err.Error(), nil, nil)
+		return
+	}
+
+	removed, err := ctrl.rateLimiter.Lists().Remove(c.Request.Context(), name, entry.Value)
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusInternalServerError, "Failed to remove list entry", nil, err.Error())
+		return
+	}
+	if !removed {
+		response.RespondJSON(c, "error", http.StatusNotFound, "List entry not found", nil, nil)
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "List entry removed successfully", nil, nil)
+}
+
+// InspectKey godoc
+//
+// @Summary      Inspect rate limit state (Admin)
+// @Description  List membership, usage in the current window and recent rejections for an IP address or user ID. Authenticated requests are counted per user when per-user limits are enabled, otherwise per client IP.
+// @Description
+// @Description  Requires the `system:manage` permission.
+// @Tags         Admin Rate Limits
+// @Produce      json
+// @Security     Bearer
+// @Param        key path string true "IP address or user ID"
+// @Success      200 {object} response.StandardApiResponse{data=Inspection} "Rate limit state retrieved successfully"
+// @Failure      400 {object} response.StandardApiResponse "Key must be an IP address or user ID"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/rate-limits/keys/{key} [get]
+func (ctrl *AdminController) InspectKey(c *gin.Context) {
+	if entry, err := ParseListEntry(c.Param("key")); err != nil || entry.Type == EntryTypeCIDR {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Key must be an IP address or user ID", nil, nil)
+		return
+	}
+
+	inspection, err := ctrl.rateLimiter.Inspect(c.Request.Context(), c.Param("key"))
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusInternalServerError, "Failed to inspect rate limit state", nil, err.Error())
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Rate limit state retrieved successfully", inspection, nil)
+}
+
+// ResetKey clears the current counters of an IP or user ID, e.g. after a client bug
+// has been fixed, without touching the allowlist or denylist
+//
+// @Summary      Reset rate limit counters (Admin)
+// @Description  Clears the current counters of an IP address or user ID for every limit type. Allowlist, denylist and rejection history are unchanged.
+// @Description
+// @Description  Requires the `system:manage` permission.
+// @Tags         Admin Rate Limits
+// @Produce      json
+// @Security     Bearer
+// @Param        key path string true "IP address or user ID"
+// @Success      200 {object} response.StandardApiResponse{data=ResetCountersResponse} "Rate limit counters reset successfully"
+// @Failure      400 {object} response.StandardApiResponse "Key must be an IP address or user ID"
+// @Failure      401 {object} response.StandardApiResponse
+// @Failure      403 {object} response.StandardApiResponse
+// @Failure      500 {object} response.StandardApiResponse
+// @Router       /api/v1/admin/rate-limits/keys/{key}/counters [delete]
+func (ctrl *AdminController) ResetKey(c *gin.Context) {
+	entry, err := ParseListEntry(c.Param("key"))
+	if err != nil || entry.Type == EntryTypeCIDR {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "Key must be an IP address or user ID", nil, nil)
+		return
+	}
+
+	cleared, err := ctrl.rateLimiter.ResetCounters(c.Request.Context(), entry.Value)
+	if err != nil {
+		response.RespondJSON(c, "error", http.StatusInternalServerError, "Failed to reset rate limit counters", nil, err.Error())
+		return
+	}
+
+	response.RespondJSON(c, "success", http.StatusOK, "Rate limit counters reset successfully",
+		ResetCountersResponse{Key: entry.Value, Cleared: cleared}, nil)
+}
+
+func (ctrl *AdminController) listParam(c *gin.Context) (ListName, bool) {
+	name := ListName(c.Param("list"))
+	if !name.IsValid() {
+		response.RespondJSON(c, "error", http.StatusBadRequest, "List must be allow or deny", nil, nil)
+		return "", false
+	}
+	return name, true
+}
@@ -0,0 +1,236 @@
+package ratelimit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"evently/internal/shared/utils/privacy"
+	"evently/pkg/cache"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	maxRecentRejections = 50
+	rejectionRetention  = 24 * time.Hour
+)
+
+// every limit type, in the order shown by the inspection endpoint
+var allLimitTypes = []RateLimitType{
+	RateLimitTypeDefault,
+	RateLimitTypePublic,
+	RateLimitTypeAuth,
+	RateLimitTypeBooking,
+	RateLimitTypeBookingCritical,
+	RateLimitTypeAdmin,
+	RateLimitTypeAnalytics,
+	RateLimitTypeUser,
+	RateLimitTypeHealth,
+}
+
+// Rejection is a request refused by the rate limiter
+type Rejection struct {
+	At        time.Time     `json:"at"`
+	ClientIP  string        `json:"client_ip"`
+	UserID    string        `json:"user_id,omitempty"`
+	LimitType RateLimitType `json:"limit_type"`
+	Path      string        `json:"path"`
+	Reason    string        `json:"reason"` // "limit_exceeded" or "denylisted"
+}
+
+// LimitStatus is the current usage of one limit type for a key
+type LimitStatus struct {
+	Type      RateLimitType `json:"type"`
+	Algorithm Algorithm     `json:"algorithm"`
+	Limit     int           `json:"limit"`
+	Burst     int           `json:"burst"`
+	Used      int64         `json:"used"`
+	Remaining int64         `json:"remaining"`
+}
+
+// Inspection describes the current rate limit state of an IP or user ID
+type Inspection struct {
+	Key              string        `json:"key"`
+	KeyType          EntryType     `json:"key_type"`
+	Allowlisted      bool          `json:"allowlisted"`
+	Denylisted       bool          `json:"denylisted"`
+	Window           string        `json:"window"`
+	Limits           []LimitStatus `json:"limits,omitempty"` // Omitted for user IDs when per-user limits are off
+	RecentRejections []Rejection   `json:"recent_rejections"`
+}
+
+func rejectionsKey(key string) string {
+	return fmt.Sprintf("evently:ratelimit:rejections:%s", key)
+}
+
+// RecordRejection keeps the last rejections per client IP and per user for inspection
+func (r *RateLimiter) RecordRejection(ctx context.Context, rejection Rejection) error {
+	payload, err := json.Marshal(rejection)
+	if err != nil {
+		return fmt.Errorf("failed to marshal rejection: %w", err)
+	}
+
+	keys := []string{rejectionsKey(rejection.ClientIP)}
+	if rejection.UserID != "" {
+		keys = append(keys, rejectionsKey(rejection.UserID))
+	}
+
+	_, err = r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, key := range keys {
+			pipe.LPush(ctx, key, payload)
+			pipe.LTrim(ctx, key, 0, maxRecentRejections-1)
+			pipe.Expire(ctx, key, rejectionRetention)
+		}
+		return nil
+	})
+	return err
+}
+
+// Inspect returns list membership, current usage and recent rejections for an IP or user ID
+func (r *RateLimiter) Inspect(ctx context.Context, key string) (*Inspection, error) {
+	entry, err := ParseListEntry(key)
+	if err != nil || entry.Type == EntryTypeCIDR {
+		return nil, fmt.Errorf("invalid key %q: must be an IP address or user ID", key)
+	}
+
+	inspection := &Inspection{
+		Key:     entry.Value,
+		KeyType: entry.Type,
+		Window:  r.config.WindowDuration.String(),
+	}
+
+	var clientIP, userID string
+	if entry.Type == EntryTypeIP {
+		clientIP = entry.Value
+	} else {
+		userID = entry.Value
+	}
+	inspection.Allowlisted = r.lists.Allowed(clientIP, userID)
+	inspection.Denylisted = r.lists.Denied(clientIP, userID)
+
+	if subject, ok := r.counterSubject(entry); ok {
+		limits, err := r.usage(ctx, subject, entry.Type == EntryTypeUser)
+		if err != nil {
+			return nil, err
+		}
+		inspection.Limits = limits
+	}
+
+	rejections, err := r.recentRejections(ctx, entry.Value)
+	if err != nil {
+		return nil, err
+	}
+	inspection.RecentRejections = rejections
+
+	if r.config.RedactPII {
+		for i := range inspection.RecentRejections {
+			inspection.RecentRejections[i].ClientIP = privacy.RedactIP(inspection.RecentRejections[i].ClientIP)
+			inspection.RecentRejections[i].UserID = privacy.RedactID(inspection.RecentRejections[i].UserID)
+		}
+	}
+
+	return inspection, nil
+}
+
+// ResetCounters clears every counter of an IP or user ID so its next request starts
+// a fresh window. Allowlist, denylist and rejection history are left untouched.
+// Returns the number of counters that were cleared.
+func (r *RateLimiter) ResetCounters(ctx context.Context, key string) (int64, error) {
+	entry, err := ParseListEntry(key)
+	if err != nil || entry.Type == EntryTypeCIDR {
+		return 0, fmt.Errorf("invalid key %q: must be an IP address or user ID", key)
+	}
+
+	subject, ok := r.counterSubject(entry)
+	if !ok {
+		return 0, nil
+	}
+
+	keys := make([]string, 0, 2*len(allLimitTypes))
+	for _, limitType := range allLimitTypes {
+		keys = append(keys,
+			counterKey(AlgorithmFixedWindow, subject, limitType),
+			counterKey(AlgorithmSlidingLog, subject, limitType))
+	}
+
+	cleared, err := cache.DeleteKeys(ctx, r.client, keys...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to reset rate limit counters: %w", err)
+	}
+	return cleared, nil
+}
+
+// counterSubject returns the counter subject of an inspected key. User IDs only
+// have counters of their own when per-user limits are on.
+func (r *RateLimiter) counterSubject(entry *ListEntry) (string, bool) {
+	if entry.Type == EntryTypeUser {
+		return userSubject(entry.Value), r.config.PerUserLimits
+	}
+	return entry.Value, true
+}
+
+// usage counts requests in the current window for every limit type of a subject
+func (r *RateLimiter) usage(ctx context.Context, subject string, authenticated bool) ([]LimitStatus, error) {
+	windowStart := strconv.FormatInt(time.Now().Add(-r.config.WindowDuration).UnixMilli(), 10)
+
+	logCounts := make([]*redis.IntCmd, len(allLimitTypes))
+	windowCounts := make([]*redis.StringCmd, len(allLimitTypes))
+	_, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, limitType := range allLimitTypes {
+			if r.AlgorithmFor(limitType) == AlgorithmSlidingLog {
+				logCounts[i] = pipe.ZCount(ctx, counterKey(AlgorithmSlidingLog, subject, limitType), "("+windowStart, "+inf")
+			} else {
+				windowCounts[i] = pipe.Get(ctx, counterKey(AlgorithmFixedWindow, subject, limitType))
+			}
+		}
+		return nil
+	})
+	if err != nil && err != redis.Nil {
+		return nil, fmt.Errorf("failed to read rate limit counters: %w", err)
+	}
+
+	limits := make([]LimitStatus, len(allLimitTypes))
+	for i, limitType := range allLimitTypes {
+		limit := r.Limit(limitType)
+		if authenticated {
+			limit = r.userLimit(limitType)
+		}
+
+		var used int64
+		if logCounts[i] != nil {
+			used = logCounts[i].Val()
+		} else {
+			used, _ = windowCounts[i].Int64() // redis.Nil when no requests this window
+		}
+
+		limits[i] = LimitStatus{
+			Type:      limitType,
+			Algorithm: r.AlgorithmFor(limitType),
+			Limit:     limit,
+			Burst:     r.config.Burst[limitType],
+			Used:      used,
+			Remaining: int64(remaining(limit, used)),
+		}
+	}
+	return limits, nil
+}
+
+func (r *RateLimiter) recentRejections(ctx context.Context, key string) ([]Rejection, error) {
+	values, err := r.client.LRange(ctx, rejectionsKey(key), 0, maxRecentRejections-1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read recent rejections: %w", err)
+	}
+
+	rejections := make([]Rejection, 0, len(values))
+	for _, value := range values {
+		var rejection Rejection
+		if err := json.Unmarshal([]byte(value), &rejection); err != nil {
+			continue
+		}
+		rejections = append(rejections, rejection)
+	}
+	return rejections, nil
+}
@@ -0,0 +1,261 @@
+package ratelimit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// ListName identifies the allowlist or the denylist
+type ListName string
+
+const (
+	ListAllow ListName = "allow"
+	ListDeny  ListName = "deny"
+)
+
+func (n ListName) IsValid() bool {
+	return n == ListAllow || n == ListDeny
+}
+
+// EntryType is the kind of value stored in a list
+type EntryType string
+
+const (
+	EntryTypeIP   EntryType = "ip"
+	EntryTypeCIDR EntryType = "cidr"
+	EntryTypeUser EntryType = "user"
+)
+
+const (
+	// Lists are Redis hashes keyed by entry value so every replica reads the same data
+	listKeyPrefix = "evently:ratelimit:list:"
+	// Replicas reload their in-memory copy when a change is announced here
+	listChangedChannel = "evently:ratelimit:lists:changed"
+	// Safety net for missed pub/sub messages (e.g. during a Redis reconnect)
+	listResyncInterval = 30 * time.Second
+)
+
+// ListEntry is an IP, CIDR range or user ID on the allowlist or denylist
+type ListEntry struct {
+	Value   string    `json:"value"`
+	Type    EntryType `json:"type"`
+	Note    string    `json:"note,omitempty"`
+	AddedBy string    `json:"added_by,omitempty"`
+	AddedAt time.Time `json:"added_at"`
+}
+
+// ParseListEntry normalizes a raw IP, CIDR or user ID into a list entry
+func ParseListEntry(value string) (*ListEntry, error) {
+	value = strings.TrimSpace(value)
+
+	if ip := net.ParseIP(value); ip != nil {
+		return &ListEntry{Value: ip.String(), Type: EntryTypeIP}, nil
+	}
+	if _, network, err := net.ParseCIDR(value); err == nil {
+		return &ListEntry{Value: network.String(), Type: EntryTypeCIDR}, nil
+	}
+	if id, err := uuid.Parse(value); err == nil {
+		return &ListEntry{Value: id.String(), Type: EntryTypeUser}, nil
+	}
+
+	return nil, fmt.Errorf("invalid entry %q: must be an IP address, CIDR range or user ID", value)
+}
+
+// listSnapshot is the parsed in-memory form of one list
+type listSnapshot struct {
+	ips      map[string]bool
+	networks []*net.IPNet
+	users    map[string]bool
+}
+
+func newListSnapshot(entries []ListEntry) *listSnapshot {
+	snapshot := &listSnapshot{
+		ips:   make(map[string]bool),
+		users: make(map[string]bool),
+	}
+
+	for _, entry := range entries {
+		switch entry.Type {
+		case EntryTypeIP:
+			snapshot.ips[entry.Value] = true
+		case EntryTypeCIDR:
+			if _, network, err := net.ParseCIDR(entry.Value); err == nil {
+				snapshot.networks = append(snapshot.networks, network)
+			}
+		case EntryTypeUser:
+			snapshot.users[entry.Value] = true
+		}
+	}
+	return snapshot
+}
+
+func (s *listSnapshot) matches(clientIP, userID string) bool {
+	if userID != "" && s.users[userID] {
+		return true
+	}
+
+	ip := net.ParseIP(clientIP)
+	if ip == nil {
+		return false
+	}
+	if s.ips[ip.String()] {
+		return true
+	}
+	for _, network := range s.networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// AccessLists keeps the Redis-backed allowlist and denylist in memory so the
+// rate limit middleware can check them without a Redis round trip
+type AccessLists struct {
+	client redis.UniversalClient
+
+	mu    sync.RWMutex
+	allow *listSnapshot
+	deny  *listSnapshot
+}
+
+func NewAccessLists(client redis.UniversalClient) *AccessLists {
+	return &AccessLists{
+		client: client,
+		allow:  newListSnapshot(nil),
+		deny:   newListSnapshot(nil),
+	}
+}
+
+func listKey(name ListName) string {
+	return listKeyPrefix + string(name)
+}
+
+// Allowed reports whether the caller is on the allowlist
+func (l *AccessLists) Allowed(clientIP, userID string) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.allow.matches(clientIP, userID)
+}
+
+// Denied reports whether the caller is on the denylist
+func (l *AccessLists) Denied(clientIP, userID string) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.deny.matches(clientIP, userID)
+}
+
+// Entries returns the stored entries of a list, newest first
+func (l *AccessLists) Entries(ctx context.Context, name ListName) ([]ListEntry, error) {
+	values, err := l.client.HGetAll(ctx, listKey(name)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s list: %w", name, err)
+	}
+
+	entries := make([]ListEntry, 0, len(values))
+	for value, raw := range values {
+		var entry ListEntry
+		if err := json.Unmarshal([]byte(raw), &entry); err != nil {
+			log.Printf("Skipping malformed %s list entry %s: %v", name, value, err)
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].AddedAt.After(entries[j].AddedAt)
+	})
+	return entries, nil
+}
+
+// Add stores an entry and tells every replica to reload
+func (l *AccessLists) Add(ctx context.Context, name ListName, entry *ListEntry) error {
+	entry.AddedAt = time.Now()
+	payload, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal list entry: %w", err)
+	}
+
+	if err := l.client.HSet(ctx, listKey(name), entry.Value, payload).Err(); err != nil {
+		return fmt.Errorf("failed to add %s list entry: %w", name, err)
+	}
+	return l.announce(ctx)
+}
+
+// Remove deletes an entry and tells every replica to reload
+func (l *AccessLists) Remove(ctx context.Context, name ListName, value string) (bool, error) {
+	removed, err := l.client.HDel(ctx, listKey(name), value).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to remove %s list entry: %w", name, err)
+	}
+	if removed == 0 {
+		return false, nil
+	}
+	return true, l.announce(ctx)
+}
+
+// announce reloads the local copy right away and notifies the other replicas
+func (l *AccessLists) announce(ctx context.Context) error {
+	if err := l.Reload(ctx); err != nil {
+		return err
+	}
+	return l.client.Publish(ctx, listChangedChannel, time.Now().Unix()).Err()
+}
+
+// Reload replaces the in-memory lists with the current Redis contents
+func (l *AccessLists) Reload(ctx context.Context) error {
+	allow, err := l.Entries(ctx, ListAllow)
+	if err != nil {
+		return err
+	}
+	deny, err := l.Entries(ctx, ListDeny)
+	if err != nil {
+		return err
+	}
+
+	allowSnapshot, denySnapshot := newListSnapshot(allow), newListSnapshot(deny)
+
+	l.mu.Lock()
+	l.allow, l.deny = allowSnapshot, denySnapshot
+	l.mu.Unlock()
+	return nil
+}
+
+// Watch loads the lists and keeps them in sync until ctx is cancelled
+func (l *AccessLists) Watch(ctx context.Context) {
+	if err := l.Reload(ctx); err != nil {
+		log.Printf("Failed to load rate limit lists: %v", err)
+	}
+
+	pubsub := l.client.Subscribe(ctx, listChangedChannel)
+	go func() {
+		defer pubsub.Close()
+
+		ticker := time.NewTicker(listResyncInterval)
+		defer ticker.Stop()
+
+		messages := pubsub.Channel()
+		for {
+			select {
+			case <-messages:
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+
+			if err := l.Reload(ctx); err != nil {
+				log.Printf("Failed to reload rate limit lists: %v", err)
+			}
+		}
+	}()
+}
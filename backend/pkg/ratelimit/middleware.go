@@ -2,26 +2,37 @@ package ratelimit
 
 import (
 	"fmt"
+	"log"
 	"net"
 	"net/http"
 	"strings"
+	"time"
 
 	"evently/internal/shared/utils/response"
 
 	"github.com/gin-gonic/gin"
 )
 
+// UserIdentifier returns the authenticated user ID of a request, or "" when anonymous.
+// The rate limiter runs before route auth, so it cannot rely on the gin context.
+type UserIdentifier func(c *gin.Context) string
+
 // rate limiting middleware
-func Middleware(rateLimiter *RateLimiter) gin.HandlerFunc {
+func Middleware(rateLimiter *RateLimiter, identifyUser UserIdentifier) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Get client IP
 		clientIP := getClientIP(c)
 
+		var userID string
+		if identifyUser != nil {
+			userID = identifyUser(c)
+		}
+
 		// Determine rate limit type from route
-		limitType := getRateLimitType(c.FullPath())
+		limitType := LimitTypeForPath(c.FullPath())
 
 		// Check rate limit
-		result, err := rateLimiter.IsAllowed(c.Request.Context(), clientIP, limitType)
+		result, err := rateLimiter.IsAllowed(c.Request.Context(), clientIP, userID, limitType)
 		if err != nil {
 			response.RespondJSON(c, "error", http.StatusInternalServerError,
 				"Rate limit check failed", nil, nil)
@@ -34,8 +45,16 @@ func Middleware(rateLimiter *RateLimiter) gin.HandlerFunc {
 		c.Header("X-RateLimit-Remaining", fmt.Sprintf("%d", result.Remaining))
 		c.Header("X-RateLimit-Reset", fmt.Sprintf("%d", result.ResetTime))
 
+		if result.Denied {
+			recordRejection(c, rateLimiter, clientIP, userID, limitType, "denylisted")
+			response.RespondJSON(c, "error", http.StatusForbidden, "Access denied", nil, nil)
+			c.Abort()
+			return
+		}
+
 		// Check if rate limited
 		if !result.Allowed {
+			recordRejection(c, rateLimiter, clientIP, userID, limitType, "limit_exceeded")
 			response.RespondJSON(c, "error", http.StatusTooManyRequests,
 				"Rate limit exceeded", nil, map[string]interface{}{
 					"limit":      result.Limit,
@@ -49,13 +68,29 @@ func Middleware(rateLimiter *RateLimiter) gin.HandlerFunc {
 	}
 }
 
-// Alternative approach: More specific route matching
-func getRateLimitType(path string) RateLimitType {
+func recordRejection(c *gin.Context, rateLimiter *RateLimiter, clientIP, userID string, limitType RateLimitType, reason string) {
+	rejection := Rejection{
+		At:        time.Now(),
+		ClientIP:  clientIP,
+		UserID:    userID,
+		LimitType: limitType,
+		Path:      c.Request.URL.Path,
+		Reason:    reason,
+	}
+	if err := rateLimiter.RecordRejection(c.Request.Context(), rejection); err != nil {
+		log.Printf("Failed to record rate limit rejection for %s: %v", clientIP, err)
+	}
+}
+
+// LimitTypeForPath returns the limit type of a route from its full path, such as
+// /api/v1/seats/hold/:holdId. The API docs document each route's limit with it.
+func LimitTypeForPath(path string) RateLimitType {
 	switch {
 	// Health/monitoring endpoints
 	case strings.HasPrefix(path, "/health"),
 		strings.HasPrefix(path, "/ping"),
-		strings.HasPrefix(path, "/status"):
+		strings.HasPrefix(path, "/status"),
+		strings.HasPrefix(path, "/metrics"):
 		return RateLimitTypeHealth
 
 	// Admin endpoints (catch-all for admin)
@@ -3,9 +3,10 @@ package ratelimit
 import (
 	"context"
 	"fmt"
-	"strconv"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
 )
 
@@ -23,6 +24,18 @@ const (
 	RateLimitTypeHealth          RateLimitType = "health"
 )
 
+// Algorithm is how requests are counted within the window
+type Algorithm string
+
+const (
+	// AlgorithmFixedWindow keeps a single counter that resets when the window expires.
+	// Cheap, but a caller can spend two windows' worth of requests across a boundary.
+	AlgorithmFixedWindow Algorithm = "fixed_window"
+	// AlgorithmSlidingLog stores one timestamp per request and counts those inside the
+	// trailing window, so the limit holds exactly at any point in time.
+	AlgorithmSlidingLog Algorithm = "sliding_log"
+)
+
 // Enhanced Config with new rate limit types
 type Config struct {
 	Enabled                 bool          `json:"enabled"`
@@ -37,6 +50,14 @@ type Config struct {
 	UserRequests            int           `json:"user_requests"`
 	HealthRequests          int           `json:"health_requests"`
 	WhitelistedIPs          []string      `json:"whitelisted_ips"`
+	RedactPII               bool          `json:"redact_pii"` // Mask IPs and user IDs in key inspection
+
+	// Authenticated requests count against the user instead of the client IP,
+	// so users behind a shared NAT do not exhaust each other's quota
+	PerUserLimits       bool                  `json:"per_user_limits"`
+	UserLimitMultiplier float64               `json:"user_limit_multiplier"` // Scales every limit for authenticated users
+	SlidingLogTypes     []RateLimitType       `json:"sliding_log_types"`     // Types counted with AlgorithmSlidingLog
+	Burst               map[RateLimitType]int `json:"burst"`                 // Extra requests tolerated above each limit
 }
 
 // Result represents rate limit check result
@@ -45,25 +66,34 @@ type Result struct {
 	Limit     int   `json:"limit"`
 	Remaining int   `json:"remaining"`
 	ResetTime int64 `json:"reset_time"`
+	Denied    bool  `json:"denied"` // Caller is on the denylist
 }
 
 // RateLimiter handles rate limiting using Redis
 type RateLimiter struct {
-	client *redis.Client
+	client redis.UniversalClient
 	config *Config
+	lists  *AccessLists
 }
 
-func NewRateLimiter(client *redis.Client, config *Config) *RateLimiter {
+func NewRateLimiter(client redis.UniversalClient, config *Config) *RateLimiter {
 	return &RateLimiter{
 		client: client,
 		config: config,
+		lists:  NewAccessLists(client),
 	}
 }
 
-// checks if request is allowed
-func (r *RateLimiter) IsAllowed(ctx context.Context, clientIP string, limitType RateLimitType) (*Result, error) {
+// Lists returns the runtime allowlist and denylist
+func (r *RateLimiter) Lists() *AccessLists {
+	return r.lists
+}
+
+// checks if request is allowed; userID is empty for anonymous requests
+func (r *RateLimiter) IsAllowed(ctx context.Context, clientIP, userID string, limitType RateLimitType) (*Result, error) {
+	subject, limit := r.subject(clientIP, userID, limitType)
+
 	if !r.config.Enabled {
-		limit := r.getLimit(limitType)
 		return &Result{
 			Allowed:   true,
 			Limit:     limit,
@@ -72,9 +102,18 @@ func (r *RateLimiter) IsAllowed(ctx context.Context, clientIP string, limitType
 		}, nil
 	}
 
+	// Denylisted callers are rejected before any counting
+	if r.lists.Denied(clientIP, userID) {
+		return &Result{
+			Allowed:   false,
+			Limit:     limit,
+			ResetTime: time.Now().Add(r.config.WindowDuration).Unix(),
+			Denied:    true,
+		}, nil
+	}
+
 	// Check if IP is whitelisted
-	if r.isWhitelisted(clientIP) {
-		limit := r.getLimit(limitType)
+	if r.isWhitelisted(clientIP, userID) {
 		return &Result{
 			Allowed:   true,
 			Limit:     limit,
@@ -83,72 +122,153 @@ func (r *RateLimiter) IsAllowed(ctx context.Context, clientIP string, limitType
 		}, nil
 	}
 
-	// Create Redis key
-	key := fmt.Sprintf("evently:ratelimit:%s:%s", clientIP, limitType)
-	limit := r.getLimit(limitType)
+	burst := r.config.Burst[limitType]
+	if r.AlgorithmFor(limitType) == AlgorithmSlidingLog {
+		return r.checkSlidingLog(ctx, counterKey(AlgorithmSlidingLog, subject, limitType), limit, burst)
+	}
+	return r.checkFixedWindow(ctx, counterKey(AlgorithmFixedWindow, subject, limitType), limit, burst)
+}
 
-	return r.checkLimit(ctx, key, limit)
+// subject returns who a request is counted against and the limit that applies to them
+func (r *RateLimiter) subject(clientIP, userID string, limitType RateLimitType) (string, int) {
+	if userID == "" || !r.config.PerUserLimits {
+		return clientIP, r.Limit(limitType)
+	}
+	return userSubject(userID), r.userLimit(limitType)
 }
 
-// performs the actual rate limit check using sliding window
-func (r *RateLimiter) checkLimit(ctx context.Context, key string, limit int) (*Result, error) {
+// checkFixedWindow counts requests in a counter that expires with the window
+func (r *RateLimiter) checkFixedWindow(ctx context.Context, key string, limit, burst int) (*Result, error) {
+	luaScript := `
+		local key = KEYS[1]
+		local window_ms = tonumber(ARGV[1])
+
+		local current_count = redis.call('INCR', key)
+		local ttl = redis.call('PTTL', key)
+		if ttl < 0 then
+			redis.call('PEXPIRE', key, window_ms)
+			ttl = window_ms
+		end
+
+		return {current_count, ttl}
+	`
+
 	now := time.Now()
-	windowStart := now.Add(-r.config.WindowDuration)
+	result, err := r.client.Eval(ctx, luaScript, []string{key},
+		r.config.WindowDuration.Milliseconds()).Result()
+	if err != nil {
+		return nil, fmt.Errorf("redis eval failed: %w", err)
+	}
+
+	values, ok := result.([]interface{})
+	if !ok || len(values) != 2 {
+		return nil, fmt.Errorf("unexpected redis response")
+	}
+
+	currentCount, _ := values[0].(int64)
+	ttl, _ := values[1].(int64)
 
-	// Lua script for atomic sliding window rate limiting
+	return &Result{
+		Allowed:   currentCount <= int64(limit+burst),
+		Limit:     limit,
+		Remaining: remaining(limit, currentCount),
+		ResetTime: now.Add(time.Duration(ttl) * time.Millisecond).Unix(),
+	}, nil
+}
+
+// checkSlidingLog records each accepted request and counts those inside the trailing window
+func (r *RateLimiter) checkSlidingLog(ctx context.Context, key string, limit, burst int) (*Result, error) {
 	luaScript := `
 		local key = KEYS[1]
-		local window_start = tonumber(ARGV[1])
-		local now = tonumber(ARGV[2])
-		local limit = tonumber(ARGV[3])
-		local window_seconds = tonumber(ARGV[4])
+		local now = tonumber(ARGV[1])
+		local window_ms = tonumber(ARGV[2])
+		local capacity = tonumber(ARGV[3])
+		local member = ARGV[4]
 
-		-- Remove old entries
-		redis.call('ZREMRANGEBYSCORE', key, '-inf', window_start)
+		-- Remove entries that have left the window
+		redis.call('ZREMRANGEBYSCORE', key, '-inf', now - window_ms)
 
-		-- Count current requests
 		local current_count = redis.call('ZCARD', key)
+		local allowed = 0
+		if current_count < capacity then
+			redis.call('ZADD', key, now, member)
+			current_count = current_count + 1
+			allowed = 1
+		end
+		redis.call('PEXPIRE', key, window_ms)
 
-		-- Check if limit exceeded
-		if current_count >= limit then
-			redis.call('EXPIRE', key, window_seconds)
-			return {current_count, limit - current_count}
+		-- The window frees a slot when its oldest entry expires
+		local reset = now + window_ms
+		local oldest = redis.call('ZRANGE', key, 0, 0, 'WITHSCORES')
+		if oldest[2] then
+			reset = tonumber(oldest[2]) + window_ms
 		end
 
-		-- Add current request
-		redis.call('ZADD', key, now, now)
-		redis.call('EXPIRE', key, window_seconds)
-		
-		return {current_count + 1, limit - current_count - 1}
+		return {allowed, current_count, reset}
 	`
 
+	// Members must be unique so concurrent requests in the same millisecond are all counted
+	now := time.Now()
 	result, err := r.client.Eval(ctx, luaScript, []string{key},
-		windowStart.Unix(),
-		now.Unix(),
-		limit,
-		int(r.config.WindowDuration.Seconds())).Result()
-
+		now.UnixMilli(),
+		r.config.WindowDuration.Milliseconds(),
+		limit+burst,
+		uuid.NewString()).Result()
 	if err != nil {
 		return nil, fmt.Errorf("redis eval failed: %w", err)
 	}
 
 	values, ok := result.([]interface{})
-	if !ok || len(values) != 2 {
+	if !ok || len(values) != 3 {
 		return nil, fmt.Errorf("unexpected redis response")
 	}
 
-	currentCount, _ := strconv.Atoi(fmt.Sprintf("%.0f", values[0]))
-	remaining, _ := strconv.Atoi(fmt.Sprintf("%.0f", values[1]))
+	allowed, _ := values[0].(int64)
+	currentCount, _ := values[1].(int64)
+	resetMillis, _ := values[2].(int64)
 
 	return &Result{
-		Allowed:   currentCount <= limit,
+		Allowed:   allowed == 1,
 		Limit:     limit,
-		Remaining: remaining,
-		ResetTime: now.Add(r.config.WindowDuration).Unix(),
+		Remaining: remaining(limit, currentCount),
+		ResetTime: time.UnixMilli(resetMillis).Unix(),
 	}, nil
 }
 
-func (r *RateLimiter) getLimit(limitType RateLimitType) int {
+// remaining is reported against the advertised limit; burst headroom is not shown to clients
+func remaining(limit int, used int64) int {
+	if left := int64(limit) - used; left > 0 {
+		return int(left)
+	}
+	return 0
+}
+
+// AlgorithmFor returns how requests of the limit type are counted
+func (r *RateLimiter) AlgorithmFor(limitType RateLimitType) Algorithm {
+	for _, t := range r.config.SlidingLogTypes {
+		if t == limitType {
+			return AlgorithmSlidingLog
+		}
+	}
+	return AlgorithmFixedWindow
+}
+
+// userLimit scales a limit for authenticated users, never below one request
+func (r *RateLimiter) userLimit(limitType RateLimitType) int {
+	limit := r.Limit(limitType)
+	if r.config.UserLimitMultiplier <= 0 {
+		return limit
+	}
+	scaled := int(float64(limit) * r.config.UserLimitMultiplier)
+	if scaled < 1 {
+		return 1
+	}
+	return scaled
+}
+
+// Limit returns the requests per window allowed for the limit type, before
+// the multiplier of authenticated users and any burst allowance
+func (r *RateLimiter) Limit(limitType RateLimitType) int {
 	switch limitType {
 	case RateLimitTypePublic:
 		return r.config.PublicRequests
@@ -171,11 +291,56 @@ func (r *RateLimiter) getLimit(limitType RateLimitType) int {
 	}
 }
 
-func (r *RateLimiter) isWhitelisted(ip string) bool {
+// counterKey is per algorithm so switching a type between them never reads the other's data
+func counterKey(algorithm Algorithm, subject string, limitType RateLimitType) string {
+	return fmt.Sprintf("evently:ratelimit:%s:%s:%s", algorithm, subject, limitType)
+}
+
+func userSubject(userID string) string {
+	return "user:" + userID
+}
+
+// ParseLimitTypes converts configured type names, skipping unknown ones
+func ParseLimitTypes(names []string) []RateLimitType {
+	types := make([]RateLimitType, 0, len(names))
+	for _, name := range names {
+		if limitType, ok := parseLimitType(name); ok {
+			types = append(types, limitType)
+		}
+	}
+	return types
+}
+
+// ParseBurst converts configured per-type burst allowances, skipping unknown types
+func ParseBurst(values map[string]int) map[RateLimitType]int {
+	burst := make(map[RateLimitType]int, len(values))
+	for name, value := range values {
+		if limitType, ok := parseLimitType(name); ok && value > 0 {
+			burst[limitType] = value
+		}
+	}
+	return burst
+}
+
+func parseLimitType(name string) (RateLimitType, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, limitType := range allLimitTypes {
+		if string(limitType) == name {
+			return limitType, true
+		}
+	}
+	return "", false
+}
+
+func (r *RateLimiter) isWhitelisted(ip, userID string) bool {
+	if r.lists.Allowed(ip, userID) {
+		return true
+	}
+
 	// for _, whitelistedIP := range r.config.WhitelistedIPs {
 	// 	if ip == whitelistedIP {
 	// 		return true
 	// 	}
 	// }
-	return false // whitelisting disabled for task
+	return false // env whitelisting disabled for task, use the runtime allowlist
 }
@@ -7,7 +7,12 @@ import (
 	"evently/internal/seats"
 	"evently/internal/shared/config"
 	"evently/internal/shared/database"
+	"evently/internal/shared/middleware"
+	"evently/internal/shared/utils/constants"
+	"evently/internal/shared/utils/response"
+	"evently/pkg/cache"
 	"evently/pkg/logger"
+	"evently/pkg/metrics"
 	"evently/pkg/ratelimit"
 	"fmt"
 	"log/slog"
@@ -28,6 +33,124 @@ var (
 	GitCommit = "unknown"
 )
 
+// @title                     Event System Backend API
+// @version                   1.0.0
+// @description               This is the backend API for Event Booking - a comprehensive event management and booking platform.
+// @description               It provides endpoints for managing events, venues, bookings, waitlists, seats, and analytics.
+// @description
+// @description               ## Features
+// @description               - Event management and discovery
+// @description               - Venue and seating management
+// @description               - Real-time seat booking with hold mechanism
+// @description               - Waitlist management for sold-out events
+// @description               - Booking and cancellation handling
+// @description               - Comprehensive analytics
+// @description               - Role-based access control (USER/ADMIN)
+// @description               - TOTP two-factor authentication; admin-only endpoints require a 2FA-verified session
+// @description                 unless TWO_FACTOR_REQUIRE_FOR_ADMINS is disabled
+// @description
+// @description               ## Responses
+// @description               Every JSON response uses the same envelope: status, status_code, message, request_id and data,
+// @description               plus error_code on errors and pagination on paged lists. Send X-Request-ID to pick the request ID.
+// @description
+// @description               ## Rate limits
+// @description               Each route counts against the limit of its tier, documented in its x-rate-limit field with the
+// @description               default number of requests per window. RATE_LIMIT_* settings change them per deployment and
+// @description               authenticated requests count against the user instead of the client IP. Limited responses carry
+// @description               X-RateLimit-Limit, X-RateLimit-Remaining and X-RateLimit-Reset and answer 429 once spent.
+// @contact.name              Developer(Mit Shah)
+// @contact.email             mitshah2406.work@gmail.com
+//
+// @host                      localhost:8080
+// @BasePath                  /
+// @schemes                   http https
+//
+// @securityDefinitions.apikey Bearer
+// @in                        header
+// @name                      Authorization
+// @description               Enter: Bearer {your-token}
+//
+// @securityDefinitions.apikey ApiKey
+// @in                        header
+// @name                      X-API-Key
+// @description               Partner API key issued under /admin/api-keys. events:read is accepted on /events, bookings:write on seat holds, availability and /bookings/confirm, analytics:read on GET /analytics/admin/* except share links. Requests act as the key's owner.
+//
+// @tag.name                  Health
+// @tag.description           Health check and status endpoints
+// @tag.name                  Authentication
+// @tag.description           User authentication and authorization
+// @tag.name                  Events
+// @tag.description           Public event browsing and discovery
+// @tag.name                  Admin Events
+// @tag.description           Event management (Admin only)
+// @tag.name                  Seats
+// @tag.description           Seat management and booking
+// @tag.name                  Admin Seats
+// @tag.description           Seat administration (Admin only)
+// @tag.name                  Bookings
+// @tag.description           Booking management and operations
+// @tag.name                  Admin Bookings
+// @tag.description           Door check-in (Admin only)
+// @tag.name                  Resale
+// @tag.description           Reselling booked seats at up to face value
+// @tag.name                  Admin Resale
+// @tag.description           Resale sales and seller payouts (Admin only)
+// @tag.name                  Reviews
+// @tag.description           Attendee ratings and comments on past events
+// @tag.name                  Admin Reviews
+// @tag.description           Review moderation (Admin only)
+// @tag.name                  Admin Series
+// @tag.description           Recurring event series (Admin only)
+// @tag.name                  Support
+// @tag.description           Support tickets about bookings and events
+// @tag.name                  Admin Support
+// @tag.description           Support ticket console (Admin only)
+// @tag.name                  Admin Notification Templates
+// @tag.description           Email template previews and test sends (Admin only)
+// @tag.name                  Favorites
+// @tag.description           Saved events with sell-out and price drop alerts
+// @tag.name                  Documents
+// @tag.description           Archives of a user's tickets and invoices
+// @tag.name                  Jobs
+// @tag.description           Long-running background jobs such as exports, with progress and downloadable results
+// @tag.name                  Waitlist
+// @tag.description           Waitlist management for sold-out events
+// @tag.name                  Admin Waitlist
+// @tag.description           Waitlist administration (Admin only)
+// @tag.name                  Tags
+// @tag.description           Tag management for event categorization
+// @tag.name                  Admin Tags
+// @tag.description           Tag administration (Admin only)
+// @tag.name                  Admin Venues
+// @tag.description           Venue template management (Admin only)
+// @tag.name                  Admin Branding
+// @tag.description           Organizer logo and colors for tickets, invoices and emails (Admin only)
+// @tag.name                  Analytics
+// @tag.description           Analytics and reporting endpoints
+// @tag.name                  Cancellation
+// @tag.description           Booking cancellation management
+// @tag.name                  Admin Cancellation
+// @tag.description           Cancellation policy management (Admin only)
+// @tag.name                  Admin Archive
+// @tag.description           Event archival and soft-deleted records (Admin only)
+// @tag.name                  Admin API Keys
+// @tag.description           Scoped partner API keys, rotation, revocation and usage (Admin only)
+// @tag.name                  Admin Roles
+// @tag.description           Custom roles granting admin permissions, and who holds them
+// @tag.name                  Admin Impersonation
+// @tag.description           Support admins acting as a user, with every request recorded (users:impersonate)
+// @tag.name                  Admin Webhooks
+// @tag.description           Partner webhook endpoints, delivery logs and redelivery (Admin only)
+// @tag.name                  Admin Rate Limits
+// @tag.description           Rate limit allowlist, denylist and inspection (Admin only)
+// @tag.name                  Admin Cache
+// @tag.description           Cache inspection, invalidation and warming (Admin only)
+// @tag.name                  Profile
+// @tag.description           Current user's profile, interests and email changes
+// @tag.name                  Notification Preferences
+// @tag.description           Per-user notification channels and quiet hours
+// @tag.name                  Notification Center
+// @tag.description           Per-user in-app notifications
 func main() {
 	// Load environment variables
 	appLogger := logger.GetDefault()
@@ -47,6 +170,18 @@ func main() {
 	// Load config
 	cfg := config.Load()
 
+	// Apply cache TTL overrides before any service caches data
+	if rejected := constants.ApplyCacheTTLOverrides(cfg.CacheTTLs.Overrides); len(rejected) > 0 {
+		appLogger.Warn("Ignoring unknown or non-positive cache TTL overrides", slog.Any("names", rejected))
+	}
+	logCacheTTLs(appLogger, cfg.CacheTTLs.File)
+
+	// Per-module log levels, e.g. LOG_MODULE_LEVELS=seats:debug,waitlist:warn
+	if rejected := logger.SetModuleLevels(cfg.LogModuleLevels); len(rejected) > 0 {
+		appLogger.Warn("Ignoring module log levels with an unknown level", slog.Any("levels", rejected))
+	}
+	cache.SetRequestLogging(cfg.CacheRequestLogs)
+
 	// Set Gin mode (debug/release)
 	gin.SetMode(cfg.GinMode)
 
@@ -83,12 +218,24 @@ func main() {
 			AdminRequests:           cfg.RateLimit.AdminRequests,
 			AnalyticsRequests:       cfg.RateLimit.AnalyticsRequests,
 			WhitelistedIPs:          cfg.RateLimit.WhitelistedIPs,
+			RedactPII:               cfg.Privacy.RedactDebugPII,
 			BookingCriticalRequests: cfg.RateLimit.BookingCriticalRequests,
 			UserRequests:            cfg.RateLimit.UserRequests,
 			HealthRequests:          cfg.RateLimit.HealthRequests,
+
+			PerUserLimits:       cfg.RateLimit.PerUserLimits,
+			UserLimitMultiplier: cfg.RateLimit.UserLimitMultiplier,
+			SlidingLogTypes:     ratelimit.ParseLimitTypes(cfg.RateLimit.SlidingLogTypes),
+			Burst:               ratelimit.ParseBurst(cfg.RateLimit.Burst),
 		}
 
 		rateLimiter = ratelimit.NewRateLimiter(db.GetRedis(), rateLimiterConfig)
+
+		// Runtime allowlist/denylist changes reach every replica over Redis pub/sub
+		listsCtx, listsCancel := context.WithCancel(context.Background())
+		defer listsCancel()
+		rateLimiter.Lists().Watch(listsCtx)
+
 		appLogger.Info("Rate limiter initialized",
 			slog.Bool("enabled", cfg.RateLimit.Enabled),
 			slog.Duration("window", cfg.RateLimit.WindowDuration),
@@ -125,7 +272,11 @@ func main() {
 		}()
 	}
 	// Setup router with rate limiter
-	router := setupRouter(cfg, db, rateLimiter, notificationService)
+	router, appRouter := setupRouter(cfg, db, rateLimiter, notificationService)
+
+	// Start background workers (notification outbox relay, seat hold monitor)
+	appRouter.StartBackgroundJobs(notificationCtx)
+	defer appRouter.StopBackgroundJobs()
 
 	// HTTP server
 	srv := &http.Server{
@@ -167,35 +318,49 @@ func main() {
 	appLogger.Info("Server exited gracefully")
 }
 
-func setupRouter(cfg *config.Config, db *database.DB, rateLimiter *ratelimit.RateLimiter, notificationService notifications.NotificationService) *gin.Engine {
+func setupRouter(cfg *config.Config, db *database.DB, rateLimiter *ratelimit.RateLimiter, notificationService notifications.NotificationService) (*gin.Engine, *routes.Router) {
 	engine := gin.New()
 	appLogger := logger.GetDefault()
 
+	// X-Request-ID on every request, before logging so request lines carry it
+	engine.Use(middleware.RequestID())
+
 	// Built-in middleware: logs requests + recovers from panics
 	engine.Use(RequestLoggerMiddleware(appLogger), gin.Recovery())
 
+	// Per-route request counts and latency for Prometheus
+	if cfg.Metrics.Enabled {
+		engine.Use(metrics.Middleware())
+	}
+
+	// X-Cache: HIT|MISS on responses that were looked up in the cache
+	engine.Use(cache.StatusMiddleware())
+
 	// CORS configuration
 	engine.Use(cors.New(cors.Config{
 		AllowOriginFunc: func(origin string) bool {
 			return true // allow every origin dynamically
 		},
 		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Length", "Content-Type", "Authorization", "X-RateLimit-*"},
-		ExposeHeaders:    []string{"Content-Length", "X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset"},
+		AllowHeaders:     []string{"Origin", "Content-Length", "Content-Type", "Authorization", "X-RateLimit-*", response.RequestIDHeader},
+		ExposeHeaders:    []string{"Content-Length", "X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset", cache.HeaderCacheStatus, response.RequestIDHeader},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
 	}))
 
 	// Global rate limiting middleware (applied to all routes)
 	if rateLimiter != nil {
-		engine.Use(ratelimit.Middleware(rateLimiter))
+		engine.Use(ratelimit.Middleware(rateLimiter, middleware.BearerUserID(cfg)))
 		appLogger.Info("Rate limiting middleware applied to all routes")
 	}
 
 	appRouter := routes.NewRouter(cfg, db, notificationService)
+	if rateLimiter != nil {
+		appRouter.SetRateLimiter(rateLimiter)
+	}
 	appRouter.SetupRoutes(engine)
 
-	return engine
+	return engine, appRouter
 }
 
 func RequestLoggerMiddleware(l *logger.Logger) gin.HandlerFunc {
@@ -206,3 +371,28 @@ func RequestLoggerMiddleware(l *logger.Logger) gin.HandlerFunc {
 		l.LogHTTPRequest(c, duration)
 	}
 }
+
+// logCacheTTLs prints the effective cache TTL table so per-environment tuning is visible at startup
+func logCacheTTLs(appLogger *logger.Logger, file string) {
+	entries := constants.CacheTTLTable()
+
+	overridden := 0
+	for _, entry := range entries {
+		if entry.Overridden {
+			overridden++
+		}
+	}
+	appLogger.Info("Effective cache TTLs",
+		slog.Int("caches", len(entries)),
+		slog.Int("overridden", overridden),
+		slog.String("file", file),
+	)
+
+	for _, entry := range entries {
+		attrs := []any{slog.String("cache", entry.Name), slog.Duration("ttl", entry.TTL)}
+		if entry.Overridden {
+			attrs = append(attrs, slog.Duration("default", entry.Default))
+		}
+		appLogger.Info("Cache TTL", attrs...)
+	}
+}